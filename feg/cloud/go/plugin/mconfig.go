@@ -101,6 +101,7 @@ func (*Builder) Build(
 	if aaa != nil {
 		mc := &mconfig.AAAConfig{LogLevel: protos.LogLevel_INFO}
 		protos.FillIn(aaa, mc)
+		mc.BandwidthSchedule = getBandwidthSchedule(aaa.BandwidthSchedule)
 		mconfigOut["aaa_server"] = mc
 	}
 
@@ -134,3 +135,16 @@ func getGyInitMethod(initMethod *uint32) mconfig.GyInitMethod {
 	}
 	return mconfig.GyInitMethod(*initMethod)
 }
+
+func getBandwidthSchedule(windows []*models.BandwidthWindow) []*mconfig.AAAConfig_BandwidthWindow {
+	var res []*mconfig.AAAConfig_BandwidthWindow
+	for _, w := range windows {
+		if w == nil {
+			continue
+		}
+		mw := &mconfig.AAAConfig_BandwidthWindow{}
+		protos.FillIn(w, mw)
+		res = append(res, mw)
+	}
+	return res
+}
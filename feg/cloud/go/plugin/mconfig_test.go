@@ -145,6 +145,38 @@ func TestBuilder_Build(t *testing.T) {
 			IdleSessionTimeoutMs: 21600000,
 			AccountingEnabled:    false,
 			CreateSessionOnAuth:  false,
+			ApnMaxSessions:       map[string]uint32{"venue.ssid": 50},
+			BandwidthSchedule: []*mconfig.AAAConfig_BandwidthWindow{{
+				Name:             "happy_hour",
+				Apns:             []string{"venue.ssid"},
+				Days:             []string{"fri", "sat"},
+				Start:            "17:00",
+				End:              "19:00",
+				MaxBandwidthUp:   10000000,
+				MaxBandwidthDown: 50000000,
+			}},
+			BandwidthScheduleTimezone:  "America/Los_Angeles",
+			SessionTable:               "redis",
+			ReportInterimUsage:         true,
+			EapTlsServerCert:           "/var/opt/magma/certs/eap_tls.crt",
+			EapTlsServerKey:            "/var/opt/magma/certs/eap_tls.key",
+			EapTlsCaBundle:             "/var/opt/magma/certs/eap_tls_ca.pem",
+			MacAuthBypass:              true,
+			MaxSessionDurationMs:       86400000,
+			ApnMaxSessionDurationMs:    map[string]uint32{"venue.ssid": 14400000},
+			DirectoryRecords:           true,
+			CreateSessionFailurePolicy: "disconnect",
+			ResyncSessionsOnStartup:    true,
+			ApnOverrides: map[string]*mconfig.AAAConfig_ApnOverride{
+				"venue.ssid": {IdleSessionTimeoutMs: 3600000, Accounting: "enabled"},
+			},
+			AuditLogEnabled:       true,
+			AuditLogEvents:        []string{"auth_failure", "stop"},
+			AuditLogBackends:      []string{"file", "syslog"},
+			UsageExportEnabled:    true,
+			UsageExportCollectors: []string{"10.0.2.1:4739"},
+			UsageExportFormat:     "ipfix",
+			UsageExportTransport:  "udp",
 		},
 		"health": &mconfig.GatewayHealthConfig{
 			RequiredServices:          []string{"S6A_PROXY", "SESSION_PROXY"},
@@ -246,6 +278,38 @@ var defaultConfig = &models.NetworkFederationConfigs{
 		IDLESessionTimeoutMs: 21600000,
 		AccountingEnabled:    false,
 		CreateSessionOnAuth:  false,
+		ApnMaxSessions:       map[string]uint32{"venue.ssid": 50},
+		BandwidthSchedule: []*models.BandwidthWindow{{
+			Name:             "happy_hour",
+			Apns:             []string{"venue.ssid"},
+			Days:             []string{"fri", "sat"},
+			Start:            "17:00",
+			End:              "19:00",
+			MaxBandwidthUp:   10000000,
+			MaxBandwidthDown: 50000000,
+		}},
+		BandwidthScheduleTimezone:  "America/Los_Angeles",
+		SessionTable:               "redis",
+		ReportInterimUsage:         true,
+		EapTLSServerCert:           "/var/opt/magma/certs/eap_tls.crt",
+		EapTLSServerKey:            "/var/opt/magma/certs/eap_tls.key",
+		EapTLSCaBundle:             "/var/opt/magma/certs/eap_tls_ca.pem",
+		MacAuthBypass:              true,
+		MaxSessionDurationMs:       86400000,
+		ApnMaxSessionDurationMs:    map[string]uint32{"venue.ssid": 14400000},
+		DirectoryRecords:           true,
+		CreateSessionFailurePolicy: "disconnect",
+		ResyncSessionsOnStartup:    true,
+		ApnOverrides: map[string]models.ApnOverride{
+			"venue.ssid": {IDLESessionTimeoutMs: 3600000, Accounting: "enabled"},
+		},
+		AuditLogEnabled:       true,
+		AuditLogEvents:        []string{"auth_failure", "stop"},
+		AuditLogBackends:      []string{"file", "syslog"},
+		UsageExportEnabled:    true,
+		UsageExportCollectors: []string{"10.0.2.1:4739"},
+		UsageExportFormat:     "ipfix",
+		UsageExportTransport:  "udp",
 	},
 	ServedNetworkIds: []string{},
 	Health: &models.Health{
@@ -6,9 +6,14 @@ package models
 // Editing this file might prove futile when you re-run the swagger generate command
 
 import (
+	"encoding/json"
+	"strconv"
+
 	strfmt "github.com/go-openapi/strfmt"
 
+	"github.com/go-openapi/errors"
 	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
 )
 
 // AaaServer aaa server configuration
@@ -18,15 +23,424 @@ type AaaServer struct {
 	// accounting enabled
 	AccountingEnabled bool `json:"accounting_enabled,omitempty"`
 
+	// maximum duration of sessions by APN, overrides max_session_duration_ms, 0 - unlimited
+	ApnMaxSessionDurationMs map[string]uint32 `json:"apn_max_session_duration_ms,omitempty"`
+
+	// maximum concurrent sessions by APN, APNs not in the map are not limited
+	ApnMaxSessions map[string]uint32 `json:"apn_max_sessions,omitempty"`
+
+	// accounting settings overrides by APN, resolved from the session's APN at its Start
+	ApnOverrides map[string]ApnOverride `json:"apn_overrides,omitempty"`
+
+	// audit log backends, file - the rotating audit log file, syslog - the local syslog's auth facility, zap - structured records of the service's log, empty - file
+	AuditLogBackends []string `json:"audit_log_backends"`
+
+	// audit log of authentications & session lifecycle events
+	AuditLogEnabled bool `json:"audit_log_enabled,omitempty"`
+
+	// audit logged event types, empty - all events
+	AuditLogEvents []string `json:"audit_log_events"`
+
+	// scheduled bandwidth profiles (e.g. happy hours), applied to new sessions at Accept & to established sessions via CoA at the windows' boundaries
+	BandwidthSchedule []*BandwidthWindow `json:"bandwidth_schedule"`
+
+	// IANA time zone of the bandwidth schedule, empty - the gateway's local time zone
+	BandwidthScheduleTimezone string `json:"bandwidth_schedule_timezone,omitempty"`
+
+	// bandwidth restored when a window ends if the session's base bandwidth is unknown
+	BaseBandwidthDown uint32 `json:"base_bandwidth_down,omitempty"`
+
+	// bandwidth restored when a window ends if the session's base bandwidth is unknown
+	BaseBandwidthUp uint32 `json:"base_bandwidth_up,omitempty"`
+
+	// handling of Accounting Starts whose session manager CreateSession fails, reject - the Start is rejected & the session kept, disconnect - the subscriber is also disconnected & its session removed, empty - reject
+	// Enum: [reject disconnect]
+	CreateSessionFailurePolicy string `json:"create_session_failure_policy,omitempty"`
+
 	// create session on auth
 	CreateSessionOnAuth bool `json:"create_session_on_auth,omitempty"`
 
+	// register the sessions' UE IP & MAC addresses of their IMSIs in the directory service (directoryd)
+	DirectoryRecords bool `json:"directory_records,omitempty"`
+
+	// PEM file path of the CAs of the EAP-TLS clients' certificates
+	EapTLSCaBundle string `json:"eap_tls_ca_bundle,omitempty" magma_alt_name:"EapTlsCaBundle"`
+
+	// JSON file path of the EAP-TLS clients' certificate identity to IMSI map, empty - the certificates' identities are mapped by their subjects
+	EapTLSImsiMap string `json:"eap_tls_imsi_map,omitempty" magma_alt_name:"EapTlsImsiMap"`
+
+	// EAP-TLS server certificate PEM file path, enables EAP-TLS authentication
+	EapTLSServerCert string `json:"eap_tls_server_cert,omitempty" magma_alt_name:"EapTlsServerCert"`
+
+	// EAP-TLS server key PEM file path
+	EapTLSServerKey string `json:"eap_tls_server_key,omitempty" magma_alt_name:"EapTlsServerKey"`
+
 	// idle session timeout ms
 	IDLESessionTimeoutMs uint32 `json:"idle_session_timeout_ms,omitempty" magma_alt_name:"IdleSessionTimeoutMs"`
+
+	// enable MAC Authentication Bypass (MAB) of non-EAP devices in subscriberdb's MAC allow-list
+	MacAuthBypass bool `json:"mac_auth_bypass,omitempty"`
+
+	// maximum duration of sessions, terminated once it expires regardless of their activity, 0 - unlimited
+	MaxSessionDurationMs uint32 `json:"max_session_duration_ms,omitempty"`
+
+	// report sessions' cumulative Interim-Update usage to the session manager, for NASes metering sessions not metered by pipelined
+	ReportInterimUsage bool `json:"report_interim_usage,omitempty"`
+
+	// resynchronize the session table with the session manager's CWF sessions on startup, sessions are rebuilt from the session manager's where possible & the session manager's sessions which can't be rebuilt are ended
+	ResyncSessionsOnStartup bool `json:"resync_sessions_on_startup,omitempty"`
+
+	// session table of authenticated sessions, redis sessions survive AAA server restarts, empty - memory
+	// Enum: [memory redis]
+	SessionTable string `json:"session_table,omitempty"`
+
+	// host:port addresses of the usage records' flow collectors
+	UsageExportCollectors []string `json:"usage_export_collectors"`
+
+	// export of the sessions' Interim-Update & Stop usage records to flow collectors
+	UsageExportEnabled bool `json:"usage_export_enabled,omitempty"`
+
+	// usage records format, ipfix - IPFIX (RFC 7011), json - JSON lines, empty - ipfix
+	// Enum: [ipfix json]
+	UsageExportFormat string `json:"usage_export_format,omitempty"`
+
+	// usage records transport, empty - udp
+	// Enum: [udp tcp]
+	UsageExportTransport string `json:"usage_export_transport,omitempty"`
 }
 
 // Validate validates this aaa server
 func (m *AaaServer) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateApnOverrides(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateAuditLogBackends(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateAuditLogEvents(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateBandwidthSchedule(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateCreateSessionFailurePolicy(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateSessionTable(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateUsageExportFormat(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateUsageExportTransport(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *AaaServer) validateApnOverrides(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.ApnOverrides) { // not required
+		return nil
+	}
+
+	for k := range m.ApnOverrides {
+
+		if err := validate.Required("apn_overrides"+"."+k, "body", m.ApnOverrides[k]); err != nil {
+			return err
+		}
+		if val, ok := m.ApnOverrides[k]; ok {
+			if err := val.Validate(formats); err != nil {
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+var aaaServerAuditLogBackendsItemsEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["file","syslog","zap"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		aaaServerAuditLogBackendsItemsEnum = append(aaaServerAuditLogBackendsItemsEnum, v)
+	}
+}
+
+func (m *AaaServer) validateAuditLogBackendsItemsEnum(path, location string, value string) error {
+	if err := validate.Enum(path, location, value, aaaServerAuditLogBackendsItemsEnum); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *AaaServer) validateAuditLogBackends(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.AuditLogBackends) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.AuditLogBackends); i++ {
+
+		// value enum
+		if err := m.validateAuditLogBackendsItemsEnum("audit_log_backends"+"."+strconv.Itoa(i), "body", m.AuditLogBackends[i]); err != nil {
+			return err
+		}
+
+	}
+
+	return nil
+}
+
+var aaaServerAuditLogEventsItemsEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["auth_success","auth_failure","start","interim","stop","timeout","terminate","clone","patch","export","import","quarantine","flush","rebind","coa"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		aaaServerAuditLogEventsItemsEnum = append(aaaServerAuditLogEventsItemsEnum, v)
+	}
+}
+
+func (m *AaaServer) validateAuditLogEventsItemsEnum(path, location string, value string) error {
+	if err := validate.Enum(path, location, value, aaaServerAuditLogEventsItemsEnum); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *AaaServer) validateAuditLogEvents(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.AuditLogEvents) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.AuditLogEvents); i++ {
+
+		// value enum
+		if err := m.validateAuditLogEventsItemsEnum("audit_log_events"+"."+strconv.Itoa(i), "body", m.AuditLogEvents[i]); err != nil {
+			return err
+		}
+
+	}
+
+	return nil
+}
+
+func (m *AaaServer) validateBandwidthSchedule(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.BandwidthSchedule) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.BandwidthSchedule); i++ {
+		if swag.IsZero(m.BandwidthSchedule[i]) { // not required
+			continue
+		}
+
+		if m.BandwidthSchedule[i] != nil {
+			if err := m.BandwidthSchedule[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("bandwidth_schedule" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+var aaaServerTypeCreateSessionFailurePolicyPropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["reject","disconnect"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		aaaServerTypeCreateSessionFailurePolicyPropEnum = append(aaaServerTypeCreateSessionFailurePolicyPropEnum, v)
+	}
+}
+
+const (
+
+	// AaaServerCreateSessionFailurePolicyReject captures enum value "reject"
+	AaaServerCreateSessionFailurePolicyReject string = "reject"
+
+	// AaaServerCreateSessionFailurePolicyDisconnect captures enum value "disconnect"
+	AaaServerCreateSessionFailurePolicyDisconnect string = "disconnect"
+)
+
+// prop value enum
+func (m *AaaServer) validateCreateSessionFailurePolicyEnum(path, location string, value string) error {
+	if err := validate.Enum(path, location, value, aaaServerTypeCreateSessionFailurePolicyPropEnum); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *AaaServer) validateCreateSessionFailurePolicy(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.CreateSessionFailurePolicy) { // not required
+		return nil
+	}
+
+	// value enum
+	if err := m.validateCreateSessionFailurePolicyEnum("create_session_failure_policy", "body", m.CreateSessionFailurePolicy); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var aaaServerTypeSessionTablePropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["memory","redis"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		aaaServerTypeSessionTablePropEnum = append(aaaServerTypeSessionTablePropEnum, v)
+	}
+}
+
+const (
+
+	// AaaServerSessionTableMemory captures enum value "memory"
+	AaaServerSessionTableMemory string = "memory"
+
+	// AaaServerSessionTableRedis captures enum value "redis"
+	AaaServerSessionTableRedis string = "redis"
+)
+
+// prop value enum
+func (m *AaaServer) validateSessionTableEnum(path, location string, value string) error {
+	if err := validate.Enum(path, location, value, aaaServerTypeSessionTablePropEnum); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *AaaServer) validateSessionTable(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.SessionTable) { // not required
+		return nil
+	}
+
+	// value enum
+	if err := m.validateSessionTableEnum("session_table", "body", m.SessionTable); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var aaaServerTypeUsageExportFormatPropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["ipfix","json"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		aaaServerTypeUsageExportFormatPropEnum = append(aaaServerTypeUsageExportFormatPropEnum, v)
+	}
+}
+
+const (
+
+	// AaaServerUsageExportFormatIpfix captures enum value "ipfix"
+	AaaServerUsageExportFormatIpfix string = "ipfix"
+
+	// AaaServerUsageExportFormatJSON captures enum value "json"
+	AaaServerUsageExportFormatJSON string = "json"
+)
+
+// prop value enum
+func (m *AaaServer) validateUsageExportFormatEnum(path, location string, value string) error {
+	if err := validate.Enum(path, location, value, aaaServerTypeUsageExportFormatPropEnum); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *AaaServer) validateUsageExportFormat(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.UsageExportFormat) { // not required
+		return nil
+	}
+
+	// value enum
+	if err := m.validateUsageExportFormatEnum("usage_export_format", "body", m.UsageExportFormat); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var aaaServerTypeUsageExportTransportPropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["udp","tcp"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		aaaServerTypeUsageExportTransportPropEnum = append(aaaServerTypeUsageExportTransportPropEnum, v)
+	}
+}
+
+const (
+
+	// AaaServerUsageExportTransportUDP captures enum value "udp"
+	AaaServerUsageExportTransportUDP string = "udp"
+
+	// AaaServerUsageExportTransportTCP captures enum value "tcp"
+	AaaServerUsageExportTransportTCP string = "tcp"
+)
+
+// prop value enum
+func (m *AaaServer) validateUsageExportTransportEnum(path, location string, value string) error {
+	if err := validate.Enum(path, location, value, aaaServerTypeUsageExportTransportPropEnum); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *AaaServer) validateUsageExportTransport(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.UsageExportTransport) { // not required
+		return nil
+	}
+
+	// value enum
+	if err := m.validateUsageExportTransportEnum("usage_export_transport", "body", m.UsageExportTransport); err != nil {
+		return err
+	}
+
 	return nil
 }
 
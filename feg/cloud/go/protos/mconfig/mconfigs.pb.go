@@ -686,7 +686,60 @@ type AAAConfig struct {
 	// enable accounting & maintain long term user sessions
 	AccountingEnabled bool `protobuf:"varint,3,opt,name=AccountingEnabled,proto3" json:"AccountingEnabled,omitempty"`
 	// Postpone Auth success until successful accounting CreateSession completion
-	CreateSessionOnAuth  bool     `protobuf:"varint,4,opt,name=CreateSessionOnAuth,proto3" json:"CreateSessionOnAuth,omitempty"`
+	CreateSessionOnAuth bool `protobuf:"varint,4,opt,name=CreateSessionOnAuth,proto3" json:"CreateSessionOnAuth,omitempty"`
+	// Maximum concurrent sessions by APN, APNs not in the map are not limited
+	ApnMaxSessions map[string]uint32 `protobuf:"bytes,5,rep,name=ApnMaxSessions,proto3" json:"ApnMaxSessions,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// Scheduled bandwidth profiles, applied to new sessions at Accept & to established sessions via CoA at the
+	// windows' boundaries
+	BandwidthSchedule []*AAAConfig_BandwidthWindow `protobuf:"bytes,6,rep,name=BandwidthSchedule,proto3" json:"BandwidthSchedule,omitempty"`
+	// IANA time zone of the bandwidth schedule, empty - the gateway's local time zone
+	BandwidthScheduleTimezone string `protobuf:"bytes,7,opt,name=BandwidthScheduleTimezone,proto3" json:"BandwidthScheduleTimezone,omitempty"`
+	// Bandwidth restored when a window ends if the session's base bandwidth is unknown, 0 - the window's is kept
+	BaseBandwidthUp   uint32 `protobuf:"varint,8,opt,name=BaseBandwidthUp,proto3" json:"BaseBandwidthUp,omitempty"`
+	BaseBandwidthDown uint32 `protobuf:"varint,9,opt,name=BaseBandwidthDown,proto3" json:"BaseBandwidthDown,omitempty"`
+	// Session table of authenticated sessions: memory (default) or redis, redis sessions survive AAA server restarts
+	SessionTable string `protobuf:"bytes,10,opt,name=SessionTable,proto3" json:"SessionTable,omitempty"`
+	// Report sessions' cumulative Interim-Update usage to the session manager, for NASes metering sessions not
+	// metered by pipelined
+	ReportInterimUsage bool `protobuf:"varint,11,opt,name=ReportInterimUsage,proto3" json:"ReportInterimUsage,omitempty"`
+	// EAP-TLS server certificate & key PEM file paths, enable EAP-TLS authentication
+	EapTlsServerCert string `protobuf:"bytes,12,opt,name=EapTlsServerCert,proto3" json:"EapTlsServerCert,omitempty"`
+	EapTlsServerKey  string `protobuf:"bytes,13,opt,name=EapTlsServerKey,proto3" json:"EapTlsServerKey,omitempty"`
+	// PEM file path of the CAs of the EAP-TLS clients' certificates
+	EapTlsCaBundle string `protobuf:"bytes,14,opt,name=EapTlsCaBundle,proto3" json:"EapTlsCaBundle,omitempty"`
+	// JSON file path of the EAP-TLS clients' certificate identity to IMSI map, empty - the certificates' identities
+	// are mapped by their subjects
+	EapTlsImsiMap string `protobuf:"bytes,15,opt,name=EapTlsImsiMap,proto3" json:"EapTlsImsiMap,omitempty"`
+	// Enable MAC Authentication Bypass (MAB) of non-EAP devices in subscriberdb's MAC allow-list
+	MacAuthBypass bool `protobuf:"varint,16,opt,name=MacAuthBypass,proto3" json:"MacAuthBypass,omitempty"`
+	// Maximum duration of sessions, terminated once it expires regardless of their activity, 0 - unlimited
+	MaxSessionDurationMs uint32 `protobuf:"varint,17,opt,name=MaxSessionDurationMs,proto3" json:"MaxSessionDurationMs,omitempty"`
+	// Maximum duration of sessions by APN, overrides MaxSessionDurationMs, 0 - unlimited
+	ApnMaxSessionDurationMs map[string]uint32 `protobuf:"bytes,18,rep,name=ApnMaxSessionDurationMs,proto3" json:"ApnMaxSessionDurationMs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// Register the sessions' UE IP & MAC addresses of their IMSIs in the directory service (directoryd)
+	DirectoryRecords bool `protobuf:"varint,19,opt,name=DirectoryRecords,proto3" json:"DirectoryRecords,omitempty"`
+	// Handling of Accounting Starts whose session manager CreateSession fails: reject (default) - the Start is
+	// rejected & the session kept, disconnect - the subscriber is also disconnected & its session removed
+	CreateSessionFailurePolicy string `protobuf:"bytes,20,opt,name=CreateSessionFailurePolicy,proto3" json:"CreateSessionFailurePolicy,omitempty"`
+	// Resynchronize the session table with the session manager's CWF sessions on startup: sessions are rebuilt from
+	// the session manager's where possible & the session manager's sessions which can't be rebuilt are ended
+	ResyncSessionsOnStartup bool `protobuf:"varint,21,opt,name=ResyncSessionsOnStartup,proto3" json:"ResyncSessionsOnStartup,omitempty"`
+	// Accounting settings overrides by APN, resolved from the session's APN at its Start
+	ApnOverrides map[string]*AAAConfig_ApnOverride `protobuf:"bytes,22,rep,name=ApnOverrides,proto3" json:"ApnOverrides,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Audit log of authentications & session lifecycle events
+	AuditLogEnabled bool `protobuf:"varint,23,opt,name=AuditLogEnabled,proto3" json:"AuditLogEnabled,omitempty"`
+	// Audit logged event types (auth_success, auth_failure, start, interim, stop, timeout, terminate...), empty - all
+	AuditLogEvents []string `protobuf:"bytes,24,rep,name=AuditLogEvents,proto3" json:"AuditLogEvents,omitempty"`
+	// Audit log backends: file, syslog & zap, empty - file
+	AuditLogBackends []string `protobuf:"bytes,25,rep,name=AuditLogBackends,proto3" json:"AuditLogBackends,omitempty"`
+	// Usage records export of the sessions' Interim-Updates & Stops to flow collectors
+	UsageExportEnabled bool `protobuf:"varint,26,opt,name=UsageExportEnabled,proto3" json:"UsageExportEnabled,omitempty"`
+	// Usage records flow collectors' host:port addresses
+	UsageExportCollectors []string `protobuf:"bytes,27,rep,name=UsageExportCollectors,proto3" json:"UsageExportCollectors,omitempty"`
+	// Usage records format: ipfix or json, empty - ipfix
+	UsageExportFormat string `protobuf:"bytes,28,opt,name=UsageExportFormat,proto3" json:"UsageExportFormat,omitempty"`
+	// Usage records transport: udp or tcp, empty - udp
+	UsageExportTransport string   `protobuf:"bytes,29,opt,name=UsageExportTransport,proto3" json:"UsageExportTransport,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -744,6 +797,339 @@ func (m *AAAConfig) GetCreateSessionOnAuth() bool {
 	return false
 }
 
+func (m *AAAConfig) GetApnMaxSessions() map[string]uint32 {
+	if m != nil {
+		return m.ApnMaxSessions
+	}
+	return nil
+}
+
+func (m *AAAConfig) GetBandwidthSchedule() []*AAAConfig_BandwidthWindow {
+	if m != nil {
+		return m.BandwidthSchedule
+	}
+	return nil
+}
+
+func (m *AAAConfig) GetBandwidthScheduleTimezone() string {
+	if m != nil {
+		return m.BandwidthScheduleTimezone
+	}
+	return ""
+}
+
+func (m *AAAConfig) GetBaseBandwidthUp() uint32 {
+	if m != nil {
+		return m.BaseBandwidthUp
+	}
+	return 0
+}
+
+func (m *AAAConfig) GetBaseBandwidthDown() uint32 {
+	if m != nil {
+		return m.BaseBandwidthDown
+	}
+	return 0
+}
+
+func (m *AAAConfig) GetSessionTable() string {
+	if m != nil {
+		return m.SessionTable
+	}
+	return ""
+}
+
+func (m *AAAConfig) GetReportInterimUsage() bool {
+	if m != nil {
+		return m.ReportInterimUsage
+	}
+	return false
+}
+
+func (m *AAAConfig) GetEapTlsServerCert() string {
+	if m != nil {
+		return m.EapTlsServerCert
+	}
+	return ""
+}
+
+func (m *AAAConfig) GetEapTlsServerKey() string {
+	if m != nil {
+		return m.EapTlsServerKey
+	}
+	return ""
+}
+
+func (m *AAAConfig) GetEapTlsCaBundle() string {
+	if m != nil {
+		return m.EapTlsCaBundle
+	}
+	return ""
+}
+
+func (m *AAAConfig) GetEapTlsImsiMap() string {
+	if m != nil {
+		return m.EapTlsImsiMap
+	}
+	return ""
+}
+
+func (m *AAAConfig) GetMacAuthBypass() bool {
+	if m != nil {
+		return m.MacAuthBypass
+	}
+	return false
+}
+
+func (m *AAAConfig) GetMaxSessionDurationMs() uint32 {
+	if m != nil {
+		return m.MaxSessionDurationMs
+	}
+	return 0
+}
+
+func (m *AAAConfig) GetApnMaxSessionDurationMs() map[string]uint32 {
+	if m != nil {
+		return m.ApnMaxSessionDurationMs
+	}
+	return nil
+}
+
+func (m *AAAConfig) GetDirectoryRecords() bool {
+	if m != nil {
+		return m.DirectoryRecords
+	}
+	return false
+}
+
+func (m *AAAConfig) GetCreateSessionFailurePolicy() string {
+	if m != nil {
+		return m.CreateSessionFailurePolicy
+	}
+	return ""
+}
+
+func (m *AAAConfig) GetResyncSessionsOnStartup() bool {
+	if m != nil {
+		return m.ResyncSessionsOnStartup
+	}
+	return false
+}
+
+func (m *AAAConfig) GetApnOverrides() map[string]*AAAConfig_ApnOverride {
+	if m != nil {
+		return m.ApnOverrides
+	}
+	return nil
+}
+
+func (m *AAAConfig) GetAuditLogEnabled() bool {
+	if m != nil {
+		return m.AuditLogEnabled
+	}
+	return false
+}
+
+func (m *AAAConfig) GetAuditLogEvents() []string {
+	if m != nil {
+		return m.AuditLogEvents
+	}
+	return nil
+}
+
+func (m *AAAConfig) GetAuditLogBackends() []string {
+	if m != nil {
+		return m.AuditLogBackends
+	}
+	return nil
+}
+
+func (m *AAAConfig) GetUsageExportEnabled() bool {
+	if m != nil {
+		return m.UsageExportEnabled
+	}
+	return false
+}
+
+func (m *AAAConfig) GetUsageExportCollectors() []string {
+	if m != nil {
+		return m.UsageExportCollectors
+	}
+	return nil
+}
+
+func (m *AAAConfig) GetUsageExportFormat() string {
+	if m != nil {
+		return m.UsageExportFormat
+	}
+	return ""
+}
+
+func (m *AAAConfig) GetUsageExportTransport() string {
+	if m != nil {
+		return m.UsageExportTransport
+	}
+	return ""
+}
+
+// Recurring daily window of a scheduled bandwidth profile (e.g. happy hours)
+type AAAConfig_BandwidthWindow struct {
+	Name string `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	// APNs the window applies to, empty - all APNs
+	Apns []string `protobuf:"bytes,2,rep,name=Apns,proto3" json:"Apns,omitempty"`
+	// Days of week the window starts on (sun, mon, ... sat), empty - every day
+	Days []string `protobuf:"bytes,3,rep,name=Days,proto3" json:"Days,omitempty"`
+	// Local "HH:MM" times, an End at or before Start ends the window on the next day
+	Start string `protobuf:"bytes,4,opt,name=Start,proto3" json:"Start,omitempty"`
+	End   string `protobuf:"bytes,5,opt,name=End,proto3" json:"End,omitempty"`
+	// Sessions' bandwidth within the window in bits per second
+	MaxBandwidthUp       uint32   `protobuf:"varint,6,opt,name=MaxBandwidthUp,proto3" json:"MaxBandwidthUp,omitempty"`
+	MaxBandwidthDown     uint32   `protobuf:"varint,7,opt,name=MaxBandwidthDown,proto3" json:"MaxBandwidthDown,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AAAConfig_BandwidthWindow) Reset()         { *m = AAAConfig_BandwidthWindow{} }
+func (m *AAAConfig_BandwidthWindow) String() string { return proto.CompactTextString(m) }
+func (*AAAConfig_BandwidthWindow) ProtoMessage()    {}
+func (*AAAConfig_BandwidthWindow) Descriptor() ([]byte, []int) {
+	return fileDescriptor_mconfigs_7e64c4c30087ead7, []int{8, 1}
+}
+func (m *AAAConfig_BandwidthWindow) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AAAConfig_BandwidthWindow.Unmarshal(m, b)
+}
+func (m *AAAConfig_BandwidthWindow) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AAAConfig_BandwidthWindow.Marshal(b, m, deterministic)
+}
+func (dst *AAAConfig_BandwidthWindow) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AAAConfig_BandwidthWindow.Merge(dst, src)
+}
+func (m *AAAConfig_BandwidthWindow) XXX_Size() int {
+	return xxx_messageInfo_AAAConfig_BandwidthWindow.Size(m)
+}
+func (m *AAAConfig_BandwidthWindow) XXX_DiscardUnknown() {
+	xxx_messageInfo_AAAConfig_BandwidthWindow.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AAAConfig_BandwidthWindow proto.InternalMessageInfo
+
+func (m *AAAConfig_BandwidthWindow) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *AAAConfig_BandwidthWindow) GetApns() []string {
+	if m != nil {
+		return m.Apns
+	}
+	return nil
+}
+
+func (m *AAAConfig_BandwidthWindow) GetDays() []string {
+	if m != nil {
+		return m.Days
+	}
+	return nil
+}
+
+func (m *AAAConfig_BandwidthWindow) GetStart() string {
+	if m != nil {
+		return m.Start
+	}
+	return ""
+}
+
+func (m *AAAConfig_BandwidthWindow) GetEnd() string {
+	if m != nil {
+		return m.End
+	}
+	return ""
+}
+
+func (m *AAAConfig_BandwidthWindow) GetMaxBandwidthUp() uint32 {
+	if m != nil {
+		return m.MaxBandwidthUp
+	}
+	return 0
+}
+
+func (m *AAAConfig_BandwidthWindow) GetMaxBandwidthDown() uint32 {
+	if m != nil {
+		return m.MaxBandwidthDown
+	}
+	return 0
+}
+
+// Accounting settings of an APN's sessions, unset fields fall back to the global settings
+type AAAConfig_ApnOverride struct {
+	// Idle session TTL, 0 - IdleSessionTimeoutMs
+	IdleSessionTimeoutMs uint32 `protobuf:"varint,1,opt,name=IdleSessionTimeoutMs,proto3" json:"IdleSessionTimeoutMs,omitempty"`
+	// Maximum duration of sessions, 0 - ApnMaxSessionDurationMs or MaxSessionDurationMs
+	MaxSessionDurationMs uint32 `protobuf:"varint,2,opt,name=MaxSessionDurationMs,proto3" json:"MaxSessionDurationMs,omitempty"`
+	// enabled, disabled or empty - AccountingEnabled
+	Accounting string `protobuf:"bytes,3,opt,name=Accounting,proto3" json:"Accounting,omitempty"`
+	// enabled, disabled or empty - CreateSessionOnAuth
+	CreateSessionOnAuth  string   `protobuf:"bytes,4,opt,name=CreateSessionOnAuth,proto3" json:"CreateSessionOnAuth,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AAAConfig_ApnOverride) Reset()         { *m = AAAConfig_ApnOverride{} }
+func (m *AAAConfig_ApnOverride) String() string { return proto.CompactTextString(m) }
+func (*AAAConfig_ApnOverride) ProtoMessage()    {}
+func (*AAAConfig_ApnOverride) Descriptor() ([]byte, []int) {
+	return fileDescriptor_mconfigs_7e64c4c30087ead7, []int{8, 3}
+}
+func (m *AAAConfig_ApnOverride) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AAAConfig_ApnOverride.Unmarshal(m, b)
+}
+func (m *AAAConfig_ApnOverride) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AAAConfig_ApnOverride.Marshal(b, m, deterministic)
+}
+func (dst *AAAConfig_ApnOverride) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AAAConfig_ApnOverride.Merge(dst, src)
+}
+func (m *AAAConfig_ApnOverride) XXX_Size() int {
+	return xxx_messageInfo_AAAConfig_ApnOverride.Size(m)
+}
+func (m *AAAConfig_ApnOverride) XXX_DiscardUnknown() {
+	xxx_messageInfo_AAAConfig_ApnOverride.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AAAConfig_ApnOverride proto.InternalMessageInfo
+
+func (m *AAAConfig_ApnOverride) GetIdleSessionTimeoutMs() uint32 {
+	if m != nil {
+		return m.IdleSessionTimeoutMs
+	}
+	return 0
+}
+
+func (m *AAAConfig_ApnOverride) GetMaxSessionDurationMs() uint32 {
+	if m != nil {
+		return m.MaxSessionDurationMs
+	}
+	return 0
+}
+
+func (m *AAAConfig_ApnOverride) GetAccounting() string {
+	if m != nil {
+		return m.Accounting
+	}
+	return ""
+}
+
+func (m *AAAConfig_ApnOverride) GetCreateSessionOnAuth() string {
+	if m != nil {
+		return m.CreateSessionOnAuth
+	}
+	return ""
+}
+
 type GatewayHealthConfig struct {
 	RequiredServices          []string `protobuf:"bytes,1,rep,name=required_services,json=requiredServices,proto3" json:"required_services,omitempty"`
 	UpdateIntervalSecs        uint32   `protobuf:"varint,2,opt,name=update_interval_secs,json=updateIntervalSecs,proto3" json:"update_interval_secs,omitempty"`
@@ -1022,6 +1408,11 @@ func init() {
 	proto.RegisterType((*EapAkaConfig)(nil), "magma.mconfig.EapAkaConfig")
 	proto.RegisterType((*EapAkaConfig_Timeouts)(nil), "magma.mconfig.EapAkaConfig.Timeouts")
 	proto.RegisterType((*AAAConfig)(nil), "magma.mconfig.AAAConfig")
+	proto.RegisterMapType((map[string]uint32)(nil), "magma.mconfig.AAAConfig.ApnMaxSessionsEntry")
+	proto.RegisterType((*AAAConfig_BandwidthWindow)(nil), "magma.mconfig.AAAConfig.BandwidthWindow")
+	proto.RegisterMapType((map[string]uint32)(nil), "magma.mconfig.AAAConfig.ApnMaxSessionDurationMsEntry")
+	proto.RegisterType((*AAAConfig_ApnOverride)(nil), "magma.mconfig.AAAConfig.ApnOverride")
+	proto.RegisterMapType((map[string]*AAAConfig_ApnOverride)(nil), "magma.mconfig.AAAConfig.ApnOverridesEntry")
 	proto.RegisterType((*GatewayHealthConfig)(nil), "magma.mconfig.GatewayHealthConfig")
 	proto.RegisterType((*HSSConfig)(nil), "magma.mconfig.HSSConfig")
 	proto.RegisterMapType((map[string]*HSSConfig_SubscriptionProfile)(nil), "magma.mconfig.HSSConfig.SubProfilesEntry")
@@ -1035,90 +1426,127 @@ func init() {
 }
 
 var fileDescriptor_mconfigs_7e64c4c30087ead7 = []byte{
-	// 1345 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x57, 0x6d, 0x4f, 0x1b, 0xc7,
-	0x13, 0xff, 0xdb, 0xe6, 0xe1, 0x3c, 0x36, 0xc1, 0x2c, 0x24, 0x18, 0x92, 0x7f, 0x43, 0x9c, 0x56,
-	0xa5, 0x49, 0x6a, 0x52, 0x2a, 0xa5, 0x51, 0x54, 0x35, 0x72, 0xc0, 0x25, 0xa8, 0x90, 0xa0, 0x3d,
-	0x52, 0xa9, 0x55, 0xa5, 0xd3, 0x72, 0xb7, 0xb6, 0x57, 0xb9, 0xbb, 0x75, 0xf7, 0xf6, 0x08, 0xee,
-	0xbb, 0x7e, 0x85, 0x7c, 0x8b, 0xbe, 0x6a, 0x5f, 0xe4, 0x8b, 0x54, 0xf9, 0x22, 0xfd, 0x08, 0xd5,
-	0x3e, 0xdc, 0xd9, 0x18, 0x83, 0x84, 0xdc, 0x57, 0xbe, 0x9d, 0xdf, 0x6f, 0x66, 0x67, 0x67, 0x66,
-	0x67, 0xc7, 0x70, 0xaf, 0x43, 0xbb, 0x5b, 0x7d, 0xc1, 0x25, 0x4f, 0xb6, 0x22, 0x9f, 0xc7, 0x1d,
-	0xd6, 0xcd, 0x7e, 0x93, 0xa6, 0x96, 0xa3, 0x85, 0x88, 0x74, 0x23, 0xd2, 0xb4, 0xd2, 0xf5, 0x35,
-	0x2e, 0xfc, 0xa7, 0x22, 0xd3, 0xf1, 0x79, 0x14, 0xf1, 0xd8, 0x30, 0x1b, 0xef, 0x4b, 0x50, 0xdb,
-	0x65, 0x24, 0xda, 0x09, 0x19, 0x8d, 0xe5, 0x8e, 0xe6, 0xa3, 0x75, 0x70, 0x34, 0xea, 0xf3, 0xb0,
-	0x5e, 0xd8, 0x28, 0x6c, 0x96, 0x71, 0xbe, 0x46, 0x75, 0x98, 0x27, 0x41, 0x20, 0x68, 0x92, 0xd4,
-	0x8b, 0x1a, 0xca, 0x96, 0x68, 0x03, 0x2a, 0x82, 0x4a, 0x41, 0xe2, 0x24, 0x62, 0x32, 0xa9, 0x97,
-	0x36, 0x0a, 0x9b, 0x0b, 0x78, 0x54, 0x84, 0x1e, 0xc2, 0xd2, 0x3b, 0x22, 0xfd, 0x5e, 0xc0, 0xbb,
-	0x1e, 0x8b, 0x25, 0x15, 0xa7, 0x24, 0xac, 0xcf, 0x68, 0x5e, 0x2d, 0x03, 0xf6, 0xad, 0x1c, 0xdd,
-	0x35, 0xe6, 0x06, 0x9e, 0xcf, 0xd3, 0x58, 0xd6, 0x67, 0x35, 0x0d, 0xb4, 0x68, 0x47, 0x49, 0xd0,
-	0x7d, 0x58, 0x08, 0xb9, 0x4f, 0x42, 0x2f, 0xf3, 0x67, 0x4e, 0xfb, 0x53, 0xd5, 0xc2, 0x96, 0x75,
-	0xea, 0x1e, 0x54, 0xfb, 0x82, 0x07, 0xa9, 0x2f, 0xbd, 0x98, 0x44, 0xb4, 0x3e, 0xaf, 0x39, 0x15,
-	0x2b, 0x7b, 0x45, 0x22, 0x8a, 0x56, 0x60, 0x56, 0x50, 0x12, 0x46, 0x75, 0x47, 0x63, 0x66, 0x81,
-	0x10, 0xcc, 0xf4, 0x78, 0x22, 0xeb, 0x65, 0x2d, 0xd4, 0xdf, 0xe8, 0xff, 0x00, 0x01, 0x4d, 0xa4,
-	0x67, 0xe8, 0xa0, 0x91, 0xb2, 0x92, 0x60, 0xad, 0x72, 0x1b, 0xf4, 0xc2, 0xd3, 0x7a, 0x15, 0x13,
-	0x37, 0x25, 0x78, 0xa9, 0x74, 0x1f, 0xc0, 0x52, 0xc0, 0x12, 0x72, 0x12, 0x52, 0x6f, 0x48, 0xaa,
-	0x6e, 0x14, 0x36, 0x1d, 0xbc, 0x68, 0x81, 0x5d, 0xcb, 0x6d, 0xfc, 0x51, 0x30, 0x49, 0x71, 0xa9,
-	0x38, 0xa5, 0x62, 0xaa, 0xa4, 0x5c, 0x08, 0x52, 0x69, 0x42, 0x90, 0xce, 0x39, 0x3e, 0x33, 0xe6,
-	0xf8, 0xf9, 0x43, 0xcf, 0x8e, 0x1d, 0xba, 0xf1, 0x4f, 0x01, 0xca, 0xee, 0x13, 0x62, 0x9d, 0xdc,
-	0x86, 0x72, 0xc8, 0xbb, 0x5e, 0x48, 0x4f, 0xa9, 0xf1, 0xf2, 0xc6, 0xf6, 0xcd, 0xa6, 0x29, 0x46,
-	0x5d, 0x83, 0xcd, 0x03, 0xde, 0x3d, 0x50, 0x20, 0x76, 0x42, 0xfb, 0x85, 0xbe, 0x81, 0xb9, 0x44,
-	0x1f, 0x54, 0x1b, 0xaf, 0x6c, 0xdf, 0x6d, 0x9e, 0xab, 0xde, 0xe6, 0x78, 0x79, 0x62, 0x4b, 0x47,
-	0xcf, 0x60, 0x4d, 0xd0, 0x5f, 0x53, 0xe5, 0x5c, 0x87, 0xb0, 0x30, 0x15, 0xd4, 0x93, 0x3d, 0x41,
-	0x93, 0x1e, 0x0f, 0x03, 0x5d, 0x0c, 0x45, 0xbc, 0x6a, 0x09, 0xdf, 0x1b, 0xfc, 0x38, 0x83, 0x95,
-	0x6e, 0xc4, 0x62, 0x16, 0xa5, 0x91, 0x97, 0xd9, 0x18, 0xea, 0xce, 0xeb, 0x5a, 0x5b, 0xb5, 0x04,
-	0x6c, 0xf0, 0x5c, 0xb7, 0xb1, 0x03, 0xce, 0xde, 0x99, 0x3d, 0xf0, 0xd0, 0xf9, 0xc2, 0xb5, 0x9c,
-	0x6f, 0xfc, 0x5e, 0x00, 0x67, 0x6f, 0x30, 0xa5, 0x15, 0xf4, 0x2d, 0x54, 0x58, 0xcc, 0xa4, 0x17,
-	0x51, 0xd9, 0xe3, 0x81, 0x4e, 0xfe, 0x8d, 0xed, 0xdb, 0x63, 0xda, 0x7b, 0x83, 0xfd, 0x98, 0xc9,
-	0x43, 0x4d, 0xc1, 0xc0, 0xf2, 0xef, 0xc6, 0xfb, 0x22, 0x20, 0x97, 0x26, 0x09, 0xe3, 0xf1, 0x91,
-	0xe0, 0x67, 0x83, 0x29, 0x92, 0xf8, 0x39, 0x14, 0xbb, 0x67, 0x36, 0x81, 0xab, 0xe3, 0xfb, 0xdb,
-	0x60, 0xe1, 0x62, 0xf7, 0x4c, 0x13, 0x07, 0x3a, 0x3b, 0x13, 0x88, 0x83, 0x9c, 0x38, 0xb8, 0x3a,
-	0xbb, 0xf3, 0x53, 0x64, 0xd7, 0xb9, 0x3a, 0xbb, 0x7f, 0x96, 0xa0, 0xec, 0xbe, 0x3b, 0xfb, 0x4f,
-	0x0a, 0xba, 0x78, 0xbd, 0x6c, 0x7e, 0x05, 0x2b, 0xa7, 0x54, 0xb0, 0xce, 0xc0, 0x23, 0xa9, 0xec,
-	0x71, 0xc1, 0x7e, 0x23, 0x92, 0xf1, 0x58, 0xdf, 0x59, 0x07, 0x2f, 0x1b, 0xac, 0x35, 0x0a, 0xa1,
-	0x4d, 0x58, 0xdc, 0x21, 0x7e, 0x8f, 0x1e, 0x1f, 0x1f, 0xb8, 0xd4, 0xe7, 0x71, 0x90, 0xd8, 0x86,
-	0x3a, 0x2e, 0xbe, 0x3a, 0x9e, 0xb3, 0x53, 0xc4, 0x73, 0xee, 0xca, 0x78, 0xa2, 0x4d, 0xa8, 0x09,
-	0xda, 0x65, 0x89, 0xa4, 0xc2, 0xe3, 0xb1, 0x3e, 0x99, 0x4e, 0x9f, 0x83, 0x6f, 0x64, 0xf2, 0xd7,
-	0xb1, 0x3a, 0x14, 0x7a, 0x02, 0xab, 0x01, 0x15, 0xec, 0x94, 0x7a, 0x69, 0x9c, 0xab, 0x0c, 0x5b,
-	0xb3, 0x83, 0x6f, 0x1a, 0xf8, 0x4d, 0x8e, 0x9a, 0x16, 0xf4, 0xb1, 0x08, 0xd5, 0x36, 0xe9, 0xb7,
-	0xde, 0x4e, 0xd3, 0x85, 0xbe, 0x83, 0x79, 0xc9, 0x22, 0xca, 0x53, 0x69, 0xb3, 0xf6, 0xe9, 0x58,
-	0xd6, 0x46, 0x77, 0x68, 0x1e, 0x1b, 0x6a, 0x82, 0x33, 0x25, 0xd5, 0x82, 0x8f, 0xc2, 0x28, 0xde,
-	0x0f, 0x54, 0x8b, 0x2d, 0xa9, 0x16, 0x6c, 0x97, 0xeb, 0x1f, 0x0a, 0xe0, 0x64, 0x7c, 0xf5, 0x48,
-	0xee, 0xf4, 0x48, 0x18, 0xd2, 0xb8, 0x4b, 0x0f, 0x13, 0xed, 0xdc, 0x02, 0x1e, 0x15, 0xa1, 0xc7,
-	0xb0, 0xdc, 0x16, 0x82, 0x8b, 0x57, 0x5c, 0xb2, 0x0e, 0xf3, 0x75, 0x9a, 0x0f, 0x4d, 0x5f, 0x5f,
-	0xc0, 0x93, 0x20, 0x74, 0x07, 0xca, 0xf6, 0x16, 0x1f, 0x66, 0xcf, 0xee, 0x50, 0x80, 0x9e, 0xc0,
-	0x2d, 0xbb, 0x50, 0x41, 0xa6, 0xb1, 0x54, 0x8a, 0x34, 0x38, 0xcc, 0x0a, 0xe5, 0x12, 0xb4, 0xf1,
-	0xb1, 0x00, 0xe5, 0x56, 0xab, 0x35, 0x45, 0x48, 0xb7, 0x61, 0x65, 0x3f, 0x08, 0xa9, 0xb5, 0x6f,
-	0x43, 0x90, 0x1f, 0x65, 0x22, 0x86, 0x1e, 0xc1, 0x52, 0xcb, 0xd7, 0x2f, 0x3e, 0x8b, 0xbb, 0xed,
-	0x58, 0x3d, 0x8b, 0x81, 0xad, 0xff, 0x8b, 0x80, 0x8a, 0xd5, 0x8e, 0xa0, 0x44, 0x66, 0x76, 0x4c,
-	0x21, 0xe9, 0x83, 0x39, 0x78, 0x12, 0xd4, 0xf8, 0xab, 0x08, 0xcb, 0x7b, 0x44, 0xd2, 0x77, 0x64,
-	0xf0, 0x92, 0x92, 0x50, 0xf6, 0xec, 0xf9, 0x1e, 0xc2, 0x92, 0xaa, 0x6c, 0x26, 0x68, 0xe0, 0xa9,
-	0xdb, 0xc8, 0x7c, 0xaa, 0xb2, 0xa3, 0x12, 0x59, 0xcb, 0x00, 0xd7, 0xca, 0xd1, 0x63, 0x58, 0x49,
-	0xfb, 0x01, 0x91, 0x34, 0x9f, 0x62, 0xbc, 0x84, 0xfa, 0xd9, 0xc1, 0x90, 0xc1, 0xb2, 0x41, 0xc6,
-	0xa5, 0x7e, 0x82, 0x9e, 0x42, 0xdd, 0x6a, 0x5c, 0xbc, 0x7b, 0x26, 0x63, 0xb7, 0x0c, 0x7e, 0xe1,
-	0xea, 0x3d, 0x87, 0x3b, 0x7e, 0xc8, 0xd3, 0xc0, 0x0b, 0x58, 0xe2, 0xf3, 0x38, 0xa6, 0xbe, 0xf4,
-	0xfa, 0x54, 0x30, 0x1e, 0x98, 0x3d, 0x4d, 0x12, 0xd7, 0x34, 0x67, 0x37, 0xa7, 0x1c, 0x69, 0x86,
-	0xde, 0xfa, 0x39, 0xdc, 0x31, 0x13, 0xc0, 0x25, 0x06, 0xcc, 0x60, 0xb5, 0xa6, 0x39, 0x93, 0x0c,
-	0x34, 0x3e, 0xcc, 0x40, 0xf9, 0xa5, 0xeb, 0x5e, 0xe3, 0xa9, 0x1a, 0x9d, 0x5b, 0xf2, 0xe6, 0xf6,
-	0x09, 0x54, 0x42, 0x49, 0xf5, 0xfd, 0xf7, 0x78, 0x5f, 0xc7, 0xaa, 0x8a, 0xcb, 0xa1, 0xa4, 0x2a,
-	0x2f, 0xaf, 0xfb, 0x68, 0x03, 0xaa, 0x39, 0x4e, 0xa2, 0x8e, 0x0e, 0x4b, 0x15, 0x83, 0x25, 0xb4,
-	0xa2, 0x0e, 0x3a, 0x80, 0x6a, 0x92, 0x9e, 0x78, 0x7d, 0xc1, 0x3b, 0x2c, 0xa4, 0xea, 0xe8, 0xa5,
-	0xcd, 0xca, 0xf6, 0x17, 0x63, 0x0e, 0xe4, 0xae, 0x36, 0xdd, 0xf4, 0xe4, 0xc8, 0x72, 0xdb, 0xb1,
-	0x14, 0x03, 0x5c, 0x49, 0x86, 0x12, 0xf4, 0x0b, 0x2c, 0x07, 0xb4, 0x43, 0xd2, 0x50, 0x7a, 0x23,
-	0x56, 0xed, 0x13, 0xf6, 0xe8, 0x2a, 0xa3, 0x89, 0x2f, 0x58, 0x5f, 0x9a, 0x47, 0x53, 0xe9, 0xe0,
-	0x25, 0x6b, 0x68, 0xb8, 0x21, 0xfa, 0x12, 0x50, 0x22, 0x05, 0x25, 0x91, 0x32, 0xae, 0x14, 0x4e,
-	0xa8, 0x30, 0x13, 0xaa, 0x83, 0x97, 0x0c, 0xe2, 0x0e, 0x81, 0x75, 0x1f, 0x96, 0x27, 0x18, 0x46,
-	0x9f, 0xc1, 0x62, 0x44, 0xce, 0xbc, 0x34, 0xf4, 0x4e, 0x98, 0xf4, 0x04, 0x91, 0x54, 0x47, 0x7d,
-	0x06, 0x57, 0x23, 0x72, 0xf6, 0x26, 0x7c, 0xc1, 0x24, 0x26, 0x32, 0xa7, 0x05, 0x23, 0xb4, 0x62,
-	0x4e, 0xdb, 0xcd, 0x68, 0xeb, 0x21, 0xd4, 0xc6, 0x43, 0x82, 0x6a, 0x50, 0x7a, 0x4b, 0x07, 0x76,
-	0xa0, 0x54, 0x9f, 0xe8, 0x05, 0xcc, 0x9e, 0x92, 0x30, 0xa5, 0xb6, 0x0d, 0x5e, 0x2f, 0x12, 0x46,
-	0xf5, 0x59, 0xf1, 0x69, 0xa1, 0xf1, 0x77, 0x01, 0x16, 0x30, 0x09, 0x58, 0x9a, 0x04, 0xb6, 0x74,
-	0x9a, 0xb0, 0x2c, 0xb4, 0x40, 0x8d, 0x2b, 0x82, 0xf9, 0x89, 0xd7, 0xe7, 0x42, 0xda, 0x1e, 0xb8,
-	0x64, 0xa0, 0x43, 0x83, 0x1c, 0x71, 0x21, 0x27, 0xf1, 0x89, 0xec, 0xd9, 0x09, 0x77, 0x8c, 0x4f,
-	0x64, 0xef, 0xd2, 0x6b, 0x59, 0xba, 0xf4, 0x5a, 0x5e, 0xdc, 0x61, 0x64, 0x04, 0x3e, 0xbf, 0x83,
-	0x9a, 0x85, 0x1f, 0x3c, 0x83, 0xea, 0xe8, 0x30, 0x85, 0xaa, 0xe0, 0xe0, 0xb6, 0xdb, 0xc6, 0x3f,
-	0xb6, 0x77, 0x6b, 0xff, 0x43, 0x8b, 0x50, 0x39, 0x6a, 0x63, 0xcf, 0x6d, 0xbb, 0xee, 0xfe, 0xeb,
-	0x57, 0xb5, 0x02, 0xaa, 0xc0, 0xbc, 0x12, 0xfc, 0xd0, 0xfe, 0xa9, 0x56, 0x7c, 0x71, 0xff, 0xe7,
-	0x7b, 0x3a, 0x92, 0x5b, 0xea, 0xef, 0x9b, 0xbe, 0xae, 0x5b, 0x5d, 0x3e, 0xf6, 0x3f, 0xee, 0x64,
-	0x4e, 0xaf, 0xbf, 0xfe, 0x37, 0x00, 0x00, 0xff, 0xff, 0xf1, 0xca, 0x6d, 0x91, 0xe4, 0x0d, 0x00,
-	0x00,
+	// 1940 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xad, 0x58, 0x5b, 0x6f, 0x1b, 0xc7,
+	0x15, 0x2e, 0x29, 0xd9, 0x22, 0x87, 0x94, 0x44, 0x8e, 0x64, 0x6b, 0x45, 0x3b, 0x17, 0x33, 0x37,
+	0xd7, 0x71, 0xe9, 0x56, 0x6d, 0x53, 0xc3, 0x08, 0x5a, 0x50, 0x24, 0x63, 0x2b, 0x31, 0x2d, 0x61,
+	0x29, 0x27, 0x48, 0x51, 0x60, 0x31, 0xda, 0x1d, 0x92, 0x0b, 0xef, 0x85, 0xdd, 0x8b, 0x25, 0xe6,
+	0xad, 0x7f, 0x21, 0x3f, 0xa1, 0x6f, 0x7d, 0x6a, 0x1f, 0xf2, 0x1f, 0x82, 0x3e, 0x16, 0xfd, 0x23,
+	0xfd, 0x09, 0x3d, 0x73, 0x66, 0x76, 0xb9, 0x5c, 0x5e, 0x10, 0x41, 0x79, 0xe2, 0xf2, 0x3b, 0xdf,
+	0x9c, 0x99, 0x39, 0xb7, 0x39, 0x33, 0xe4, 0xc1, 0x90, 0x8f, 0x9e, 0x4c, 0x02, 0x3f, 0xf2, 0xc3,
+	0x27, 0xae, 0xe9, 0x7b, 0x43, 0x7b, 0x94, 0xfc, 0x86, 0x2d, 0xc4, 0xe9, 0xb6, 0xcb, 0x46, 0x2e,
+	0x6b, 0x29, 0xb4, 0x71, 0xe8, 0x07, 0xe6, 0xd3, 0x20, 0x19, 0x63, 0xfa, 0xae, 0xeb, 0x7b, 0x92,
+	0xd9, 0xfc, 0x7e, 0x83, 0xd4, 0xba, 0x36, 0x73, 0x3b, 0x8e, 0xcd, 0xbd, 0xa8, 0x83, 0x7c, 0xda,
+	0x20, 0x25, 0x94, 0x9a, 0xbe, 0xa3, 0x15, 0xde, 0x2f, 0x3c, 0x2c, 0xeb, 0xe9, 0x7f, 0xaa, 0x91,
+	0x2d, 0x66, 0x59, 0x01, 0x0f, 0x43, 0xad, 0x88, 0xa2, 0xe4, 0x2f, 0x7d, 0x9f, 0x54, 0x02, 0x1e,
+	0x05, 0xcc, 0x0b, 0x5d, 0x3b, 0x0a, 0xb5, 0x0d, 0x90, 0x6e, 0xeb, 0x59, 0x88, 0x7e, 0x4a, 0xea,
+	0x97, 0x2c, 0x32, 0xc7, 0x96, 0x3f, 0x32, 0x6c, 0x2f, 0xe2, 0xc1, 0x5b, 0xe6, 0x68, 0x9b, 0xc8,
+	0xab, 0x25, 0x82, 0x13, 0x85, 0xd3, 0xf7, 0xa4, 0xba, 0xa9, 0x61, 0xfa, 0xb1, 0x17, 0x69, 0xb7,
+	0x90, 0x46, 0x10, 0xea, 0x08, 0x84, 0x7e, 0x40, 0xb6, 0x1d, 0xdf, 0x64, 0x8e, 0x91, 0xac, 0xe7,
+	0x36, 0xae, 0xa7, 0x8a, 0x60, 0x5b, 0x2d, 0xea, 0x01, 0xa9, 0xc2, 0xd2, 0xad, 0xd8, 0x8c, 0x0c,
+	0x8f, 0xb9, 0x5c, 0xdb, 0x42, 0x4e, 0x45, 0x61, 0xaf, 0x00, 0xa2, 0xfb, 0xe4, 0x56, 0xc0, 0x99,
+	0xe3, 0x6a, 0x25, 0x94, 0xc9, 0x3f, 0x94, 0x92, 0xcd, 0xb1, 0x1f, 0x46, 0x5a, 0x19, 0x41, 0xfc,
+	0xa6, 0xef, 0x10, 0x62, 0xf1, 0x30, 0x32, 0x24, 0x9d, 0xa0, 0xa4, 0x2c, 0x10, 0x1d, 0x87, 0xdc,
+	0x23, 0xf8, 0xc7, 0xc0, 0x71, 0x15, 0x69, 0x37, 0x01, 0xbc, 0x10, 0x63, 0x1f, 0x91, 0xba, 0x65,
+	0x87, 0xec, 0xc2, 0xe1, 0xc6, 0x8c, 0x54, 0x05, 0x52, 0x49, 0xdf, 0x55, 0x82, 0xae, 0xe2, 0x36,
+	0xff, 0x51, 0x90, 0x4e, 0x19, 0x80, 0x25, 0x78, 0x70, 0x23, 0xa7, 0x2c, 0x18, 0x69, 0x63, 0x89,
+	0x91, 0xe6, 0x16, 0xbe, 0x99, 0x5b, 0xf8, 0xfc, 0xa6, 0x6f, 0xe5, 0x36, 0xdd, 0xfc, 0x5f, 0x81,
+	0x94, 0x07, 0x9f, 0x31, 0xb5, 0xc8, 0x23, 0x52, 0x76, 0xc0, 0xb9, 0x0e, 0x7f, 0xcb, 0xe5, 0x2a,
+	0x77, 0x8e, 0xee, 0xb4, 0x64, 0x30, 0x62, 0x0c, 0xb6, 0x5e, 0xfa, 0xa3, 0x97, 0x42, 0xa8, 0x97,
+	0x1c, 0xf5, 0x45, 0xff, 0x40, 0x6e, 0x87, 0xb8, 0x51, 0x54, 0x5e, 0x39, 0x7a, 0xaf, 0x35, 0x17,
+	0xbd, 0xad, 0x7c, 0x78, 0xea, 0x8a, 0x4e, 0x9f, 0x91, 0xc3, 0x80, 0xff, 0x35, 0x16, 0x8b, 0x1b,
+	0x32, 0xdb, 0x89, 0x03, 0x6e, 0x44, 0x63, 0xd8, 0xd0, 0xd8, 0x77, 0x2c, 0x0c, 0x86, 0xa2, 0x7e,
+	0xa0, 0x08, 0x5f, 0x48, 0xf9, 0x79, 0x22, 0x16, 0x63, 0x5d, 0xdb, 0xb3, 0xdd, 0xd8, 0x35, 0x12,
+	0x1d, 0xb3, 0xb1, 0x5b, 0x18, 0x6b, 0x07, 0x8a, 0xa0, 0x4b, 0x79, 0x3a, 0xb6, 0xd9, 0x21, 0xa5,
+	0xe7, 0x57, 0x6a, 0xc3, 0xb3, 0xc5, 0x17, 0xae, 0xb5, 0xf8, 0xe6, 0xdf, 0x0a, 0xa0, 0x65, 0x7a,
+	0x43, 0x2d, 0xf4, 0x73, 0x52, 0x81, 0x45, 0x46, 0x86, 0xcb, 0xa3, 0xb1, 0x6f, 0xa1, 0xf3, 0x77,
+	0x8e, 0xee, 0xe5, 0x46, 0x3f, 0x9f, 0x9e, 0x00, 0xa7, 0x8f, 0x14, 0x9d, 0xd8, 0xe9, 0x77, 0xf3,
+	0xfb, 0x22, 0xa1, 0x03, 0x08, 0x00, 0xdb, 0xf7, 0xce, 0x02, 0xff, 0x6a, 0x7a, 0x03, 0x27, 0x7e,
+	0x42, 0x8a, 0xa3, 0x2b, 0xe5, 0xc0, 0x83, 0xfc, 0xfc, 0xca, 0x58, 0x3a, 0x50, 0x90, 0x38, 0x45,
+	0xef, 0x2c, 0x21, 0x4e, 0x53, 0xe2, 0x74, 0xbd, 0x77, 0xb7, 0x6e, 0xe0, 0xdd, 0xd2, 0x7a, 0xef,
+	0xfe, 0x73, 0x03, 0x02, 0xfa, 0xf2, 0xea, 0x67, 0x09, 0xe8, 0xe2, 0xf5, 0xbc, 0xf9, 0x1b, 0xb2,
+	0x0f, 0x3f, 0xf6, 0x70, 0x6a, 0xb0, 0x18, 0x1c, 0x14, 0xd8, 0xdf, 0xb1, 0x08, 0x7c, 0x83, 0x39,
+	0x5b, 0xd2, 0xf7, 0xa4, 0xac, 0x9d, 0x15, 0xd1, 0x87, 0x64, 0xb7, 0xc3, 0xcc, 0x31, 0x3f, 0x3f,
+	0x7f, 0x39, 0xe0, 0xa0, 0xdf, 0x0a, 0x55, 0x41, 0xcd, 0xc3, 0xeb, 0xed, 0x79, 0xeb, 0x06, 0xf6,
+	0xbc, 0xbd, 0xd6, 0x9e, 0xb0, 0xc2, 0x5a, 0xc0, 0x47, 0x76, 0x08, 0x65, 0xdd, 0xf0, 0x3d, 0xdc,
+	0x19, 0xba, 0xaf, 0xa4, 0xef, 0x24, 0xf8, 0xa9, 0x27, 0x36, 0x45, 0x3f, 0x23, 0x07, 0x16, 0x6c,
+	0xf1, 0x2d, 0x37, 0x62, 0x2f, 0x1d, 0x32, 0x2b, 0xcd, 0x25, 0xfd, 0x8e, 0x14, 0xbf, 0x4e, 0xa5,
+	0xb2, 0x04, 0xfd, 0xb7, 0x48, 0xaa, 0x3d, 0x36, 0x69, 0xbf, 0xb9, 0x49, 0x15, 0xfa, 0x23, 0xd9,
+	0x8a, 0x6c, 0x97, 0xfb, 0x71, 0xa4, 0xbc, 0xf6, 0x61, 0xce, 0x6b, 0xd9, 0x19, 0x5a, 0xe7, 0x92,
+	0x1a, 0xea, 0xc9, 0x20, 0x51, 0x82, 0xcf, 0x1c, 0xd7, 0x3b, 0xb1, 0x44, 0x89, 0xdd, 0x10, 0x25,
+	0x58, 0xfd, 0x6d, 0xfc, 0x00, 0x99, 0x9e, 0xf0, 0xc5, 0x21, 0xd9, 0x19, 0x33, 0xc7, 0xe1, 0xde,
+	0x88, 0xf7, 0x43, 0x5c, 0x1c, 0x1c, 0x92, 0x19, 0x88, 0xfe, 0x9a, 0xec, 0xf5, 0x82, 0xc0, 0x0f,
+	0x5e, 0xf9, 0x91, 0x3d, 0xb4, 0x4d, 0x74, 0x73, 0x5f, 0xd6, 0xf5, 0x6d, 0x7d, 0x99, 0x88, 0xde,
+	0x87, 0x80, 0x95, 0x59, 0xdc, 0x4f, 0x8e, 0xdd, 0x19, 0x00, 0x56, 0xbd, 0xab, 0xfe, 0x08, 0x23,
+	0x43, 0xd0, 0x89, 0x81, 0xdc, 0xea, 0x27, 0x81, 0xb2, 0x42, 0xda, 0xfc, 0x7b, 0x9d, 0x94, 0xdb,
+	0xed, 0xf6, 0x0d, 0x4c, 0x7a, 0x44, 0xf6, 0x4f, 0x2c, 0x87, 0x2b, 0xfd, 0xca, 0x04, 0xe9, 0x56,
+	0x96, 0xca, 0xe8, 0x63, 0x52, 0x6f, 0x9b, 0x78, 0xe2, 0xdb, 0xde, 0xa8, 0xe7, 0x89, 0x63, 0xd1,
+	0x52, 0xf1, 0xbf, 0x28, 0x10, 0xb6, 0xea, 0x40, 0x80, 0x44, 0x89, 0x1e, 0x19, 0x48, 0xb8, 0x31,
+	0xc8, 0x97, 0x25, 0x22, 0x7a, 0x4e, 0x76, 0xda, 0x13, 0xaf, 0xcf, 0xae, 0x14, 0x1c, 0x42, 0xe8,
+	0x6f, 0x80, 0xb7, 0x1f, 0xe7, 0xbc, 0x9d, 0xee, 0xbc, 0x35, 0x4f, 0xef, 0x79, 0xd0, 0x7f, 0xe8,
+	0x39, 0x1d, 0xf4, 0x6b, 0x52, 0x3f, 0x66, 0x9e, 0x75, 0x69, 0x5b, 0xd1, 0x78, 0x00, 0x69, 0x67,
+	0xc5, 0x0e, 0x87, 0xbc, 0x10, 0x8a, 0x1f, 0xae, 0x54, 0x9c, 0x8e, 0xf8, 0xc6, 0xf6, 0x2c, 0xff,
+	0x52, 0x5f, 0x54, 0x01, 0xe5, 0xfd, 0x70, 0x01, 0x14, 0xb6, 0xfa, 0xce, 0xf7, 0x92, 0x56, 0x66,
+	0x35, 0x41, 0xd4, 0x86, 0x63, 0x16, 0xf2, 0x94, 0xf0, 0x7a, 0xa2, 0x6a, 0x5f, 0x1e, 0x16, 0x56,
+	0x9f, 0x83, 0xba, 0xfe, 0xa5, 0x87, 0x9d, 0xcf, 0xb6, 0xbe, 0x28, 0xa0, 0x4d, 0x52, 0x4d, 0xfc,
+	0x26, 0xdc, 0xa0, 0x1a, 0xa1, 0x39, 0x8c, 0xb6, 0x08, 0xd5, 0xf9, 0xc4, 0x0f, 0x22, 0xec, 0xe7,
+	0x6c, 0xf7, 0x75, 0xc8, 0x46, 0x1c, 0x9b, 0xa2, 0x92, 0xbe, 0x44, 0x02, 0xed, 0x51, 0x0d, 0x12,
+	0xec, 0xdc, 0x09, 0x55, 0xcf, 0xc3, 0x03, 0xd9, 0x1d, 0x95, 0xf5, 0x05, 0x5c, 0xec, 0x2b, 0x8b,
+	0x7d, 0xc5, 0xa7, 0xda, 0x36, 0x52, 0xf3, 0x30, 0xfd, 0x98, 0xec, 0x48, 0xa8, 0xc3, 0x8e, 0x63,
+	0x0f, 0xe2, 0x4d, 0xdb, 0x41, 0x62, 0x0e, 0xa5, 0x1f, 0x92, 0x6d, 0x89, 0x9c, 0xb8, 0xa1, 0xdd,
+	0x67, 0x13, 0x6d, 0x17, 0x69, 0xf3, 0xa0, 0x60, 0xf5, 0x99, 0x29, 0xc2, 0xe8, 0x78, 0x3a, 0x61,
+	0xd0, 0x4b, 0xd5, 0x70, 0x3b, 0xf3, 0xa0, 0x88, 0xfa, 0x59, 0x68, 0x74, 0xe3, 0x20, 0x49, 0xe0,
+	0xba, 0x8c, 0xfa, 0x65, 0x32, 0xea, 0x93, 0x83, 0xb9, 0x88, 0xca, 0x0c, 0xa3, 0x18, 0x45, 0xbf,
+	0xff, 0x69, 0xe1, 0x39, 0x1b, 0x27, 0xe3, 0x74, 0x95, 0x56, 0x61, 0xee, 0xae, 0x1d, 0x70, 0x33,
+	0xf2, 0x81, 0x05, 0x07, 0x44, 0x00, 0x65, 0x6b, 0x0f, 0x77, 0xb3, 0x80, 0x43, 0x65, 0x6c, 0xcc,
+	0x65, 0x92, 0x3a, 0x1d, 0xce, 0x7c, 0xc7, 0x36, 0xa7, 0xda, 0x3e, 0x5a, 0x6a, 0x0d, 0x83, 0x3e,
+	0x25, 0x07, 0x3a, 0x0f, 0xa7, 0x9e, 0x99, 0xa4, 0xcb, 0xa9, 0x37, 0x88, 0x58, 0x10, 0xc5, 0x13,
+	0xed, 0x0e, 0x4e, 0xb9, 0x4a, 0x4c, 0x5f, 0x91, 0x2a, 0x6c, 0xe0, 0x14, 0x9c, 0x19, 0xd8, 0xd0,
+	0x71, 0x6a, 0x77, 0xd1, 0x16, 0x8f, 0xd6, 0xd9, 0x22, 0x25, 0x4b, 0x03, 0xcc, 0x8d, 0x17, 0x81,
+	0xd3, 0x8e, 0x2d, 0x3b, 0x82, 0x5a, 0x95, 0x94, 0x96, 0x03, 0xd9, 0x81, 0xe7, 0x60, 0x11, 0x38,
+	0x29, 0xf4, 0x16, 0xaa, 0x62, 0xa8, 0x69, 0x58, 0xd4, 0x73, 0xa8, 0xb0, 0x63, 0x82, 0x1c, 0x33,
+	0xf3, 0x0d, 0x17, 0xe7, 0xef, 0x21, 0x32, 0x17, 0x70, 0x91, 0x12, 0x18, 0xeb, 0xbd, 0x2b, 0x11,
+	0xfd, 0xc9, 0x02, 0x1a, 0x32, 0x25, 0x16, 0x25, 0xf4, 0x77, 0xe4, 0x4e, 0x06, 0xed, 0xf8, 0x70,
+	0x42, 0x08, 0xbf, 0x84, 0xda, 0x3d, 0x9c, 0x60, 0xb9, 0x50, 0xa4, 0x72, 0x46, 0xf0, 0x85, 0x1f,
+	0xb8, 0x2c, 0xd2, 0xee, 0xa3, 0x93, 0x16, 0x05, 0x22, 0x58, 0x33, 0xe0, 0xb9, 0xb8, 0xa9, 0x89,
+	0x0f, 0xed, 0x1d, 0x1c, 0xb0, 0x54, 0xd6, 0x68, 0x93, 0xbd, 0x25, 0x35, 0x91, 0xd6, 0xc8, 0xc6,
+	0x1b, 0xc8, 0x44, 0x79, 0x35, 0x11, 0x9f, 0xe2, 0x62, 0x05, 0x17, 0xb9, 0x98, 0xab, 0x82, 0x2f,
+	0xff, 0x3c, 0x2b, 0x3e, 0x2d, 0x34, 0xfe, 0x5d, 0x10, 0xa5, 0x69, 0xae, 0xfc, 0x89, 0x0b, 0x97,
+	0xb8, 0x8e, 0x29, 0x05, 0xf8, 0x2d, 0x30, 0x98, 0x4a, 0x9c, 0x18, 0x62, 0xc7, 0xf8, 0x2d, 0xb0,
+	0x2e, 0x9b, 0x26, 0xa7, 0x2c, 0x7e, 0x8b, 0x99, 0x30, 0x66, 0xd4, 0xe5, 0x45, 0xfe, 0x11, 0x2b,
+	0xea, 0x79, 0x96, 0xba, 0xb2, 0x88, 0x4f, 0xe1, 0x56, 0x58, 0x77, 0xb6, 0x20, 0xca, 0xe6, 0x25,
+	0x87, 0x0a, 0xb7, 0x66, 0x11, 0x2c, 0x87, 0xf2, 0x52, 0xb0, 0x80, 0x37, 0xbe, 0x24, 0xf7, 0xd7,
+	0xe5, 0xe0, 0xb5, 0xec, 0xf2, 0x63, 0x81, 0x54, 0x32, 0x11, 0xbb, 0xf2, 0x04, 0x2d, 0xac, 0x39,
+	0x41, 0x57, 0xd5, 0x9f, 0xe2, 0x9a, 0xfa, 0xf3, 0x2e, 0x21, 0xb3, 0xc3, 0x55, 0x5d, 0x11, 0x33,
+	0xc8, 0xba, 0x73, 0xb6, 0xbc, 0xf4, 0x9c, 0x6d, 0x70, 0x38, 0xc7, 0xf3, 0xd9, 0xb8, 0xc4, 0x14,
+	0xcf, 0xb2, 0xa6, 0x58, 0xec, 0xb9, 0x96, 0xa6, 0x76, 0xc6, 0x60, 0xcd, 0x7f, 0x15, 0xc9, 0xde,
+	0x73, 0x98, 0xfc, 0x92, 0x4d, 0x5f, 0x40, 0x2f, 0x18, 0x8d, 0x55, 0xbb, 0xf2, 0x29, 0xa9, 0x8b,
+	0x46, 0x15, 0x4a, 0x99, 0x65, 0x88, 0xe6, 0xda, 0x36, 0xb9, 0xb0, 0x1a, 0x26, 0x66, 0x22, 0x18,
+	0x28, 0x1c, 0x76, 0xb7, 0x1f, 0x4f, 0x2c, 0xd0, 0x92, 0x3e, 0x4a, 0xc0, 0x18, 0x33, 0xb1, 0x18,
+	0x95, 0xb2, 0xe4, 0x5d, 0x02, 0xda, 0xe9, 0x10, 0x4a, 0x9a, 0xa6, 0x46, 0x2c, 0xb6, 0xd2, 0xb2,
+	0x01, 0xbb, 0x2b, 0xe5, 0x0b, 0x9d, 0xf4, 0x9f, 0xc8, 0x7d, 0xd3, 0xf1, 0x63, 0xcb, 0x80, 0x3b,
+	0x3f, 0x6c, 0xd2, 0x83, 0xac, 0x35, 0x26, 0x70, 0x0c, 0xfa, 0x96, 0x9c, 0x53, 0xf6, 0x64, 0x87,
+	0xc8, 0xe9, 0xa6, 0x94, 0x33, 0x64, 0xe0, 0xd4, 0xa0, 0x40, 0x5e, 0xe8, 0x57, 0x28, 0x90, 0xef,
+	0x24, 0x87, 0xc8, 0x59, 0xa6, 0xa0, 0xf9, 0xc3, 0x26, 0x29, 0xbf, 0x18, 0x0c, 0xae, 0x71, 0xf3,
+	0xcc, 0x3e, 0x43, 0xa4, 0x77, 0x95, 0x77, 0x49, 0xc5, 0x81, 0xfd, 0x8b, 0x76, 0xde, 0xf0, 0x27,
+	0x68, 0xab, 0xaa, 0x5e, 0x06, 0x48, 0xb8, 0xff, 0x74, 0x02, 0x8d, 0x6e, 0x35, 0x95, 0x33, 0x77,
+	0x88, 0x66, 0xa9, 0xea, 0x44, 0x11, 0xda, 0xee, 0x90, 0xbe, 0x24, 0xd5, 0x30, 0xbe, 0x30, 0x26,
+	0x81, 0x3f, 0xb4, 0x1d, 0x2e, 0xb6, 0x2e, 0xaa, 0xfb, 0x2f, 0x73, 0x0b, 0x48, 0x97, 0xda, 0x1a,
+	0xc4, 0x17, 0x67, 0x8a, 0x2b, 0x8b, 0x7b, 0x25, 0x9c, 0x21, 0xf4, 0x2f, 0x64, 0xcf, 0xe2, 0x43,
+	0x16, 0x3b, 0x91, 0x91, 0xd1, 0xaa, 0x6e, 0xa4, 0x8f, 0xd7, 0x29, 0x0d, 0xcd, 0xc0, 0x9e, 0x44,
+	0xf2, 0x0e, 0x2c, 0xc6, 0xe8, 0x75, 0xa5, 0x68, 0x36, 0x21, 0xfd, 0x15, 0xa1, 0x61, 0x04, 0x61,
+	0xee, 0x0a, 0xe5, 0x62, 0xc0, 0x05, 0x0f, 0xe4, 0x83, 0x13, 0xf4, 0xa5, 0x52, 0x32, 0x98, 0x09,
+	0x1a, 0x26, 0xd9, 0x5b, 0xa2, 0x98, 0x7e, 0x44, 0x76, 0x5d, 0x76, 0x65, 0xc4, 0x8e, 0x71, 0x01,
+	0x77, 0x76, 0xc8, 0x3e, 0x59, 0xed, 0x36, 0xf5, 0x2a, 0xc0, 0xaf, 0x9d, 0x63, 0x3b, 0xd2, 0x01,
+	0x4b, 0x68, 0x56, 0x86, 0x56, 0x4c, 0x69, 0xdd, 0x84, 0xd6, 0x70, 0x48, 0x2d, 0x6f, 0x92, 0x25,
+	0x19, 0x76, 0x3c, 0x9f, 0x61, 0xd7, 0xb3, 0x44, 0x26, 0xd3, 0xfe, 0x53, 0x20, 0xdb, 0x3a, 0xb3,
+	0xec, 0x38, 0xb4, 0x54, 0xe8, 0xb4, 0xc8, 0x5e, 0x80, 0x80, 0x78, 0x7d, 0x08, 0x6c, 0x33, 0x34,
+	0xf0, 0xe8, 0x90, 0xb5, 0xa9, 0x2e, 0x45, 0x7d, 0x29, 0x39, 0x03, 0xc1, 0x32, 0x3e, 0x83, 0x22,
+	0x22, 0x1f, 0xac, 0x72, 0x7c, 0x10, 0xac, 0x4c, 0xcb, 0x8d, 0x95, 0x69, 0xb9, 0x38, 0x43, 0xe6,
+	0x45, 0x6b, 0x7e, 0x06, 0xf1, 0xb4, 0xf5, 0xe8, 0x19, 0xa9, 0x66, 0xdf, 0x46, 0x68, 0x95, 0x94,
+	0xf4, 0xde, 0xa0, 0xa7, 0x7f, 0xdd, 0xeb, 0xd6, 0x7e, 0x41, 0x77, 0x49, 0xe5, 0xac, 0xa7, 0x1b,
+	0x83, 0xde, 0x60, 0x70, 0x72, 0xfa, 0xaa, 0x56, 0xa0, 0x15, 0xb8, 0xe2, 0x01, 0xf0, 0x55, 0xef,
+	0xdb, 0x5a, 0xf1, 0xf8, 0x83, 0x3f, 0x3f, 0x40, 0x4b, 0x3e, 0x11, 0xaf, 0xb1, 0x98, 0xae, 0x4f,
+	0x46, 0x7e, 0xee, 0x59, 0xf6, 0xe2, 0x36, 0xfe, 0xff, 0xed, 0xff, 0x01, 0x01, 0x6a, 0x1f, 0x6c,
+	0xb3, 0x15, 0x00, 0x00,
 }
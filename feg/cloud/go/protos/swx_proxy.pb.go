@@ -116,7 +116,9 @@ type AuthenticationRequest struct {
 	// Concatenation of RAND and AUTS in the case of resync
 	ResyncInfo []byte `protobuf:"bytes,4,opt,name=resync_info,json=resyncInfo,proto3" json:"resync_info,omitempty"`
 	// Send an additional SAR message to the HSS to retrieve user profile params
-	RetrieveUserProfile  bool     `protobuf:"varint,5,opt,name=retrieve_user_profile,json=retrieveUserProfile,proto3" json:"retrieve_user_profile,omitempty"`
+	RetrieveUserProfile bool `protobuf:"varint,5,opt,name=retrieve_user_profile,json=retrieveUserProfile,proto3" json:"retrieve_user_profile,omitempty"`
+	// Only replenish the proxy's cached vectors of the user (if running low), no vectors are returned
+	Prefetch             bool     `protobuf:"varint,6,opt,name=prefetch,proto3" json:"prefetch,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -181,6 +183,13 @@ func (m *AuthenticationRequest) GetRetrieveUserProfile() bool {
 	return false
 }
 
+func (m *AuthenticationRequest) GetPrefetch() bool {
+	if m != nil {
+		return m.Prefetch
+	}
+	return false
+}
+
 // MultimediaAuthenticationAnswer (Section 8.2.2.1)
 type AuthenticationAnswer struct {
 	// Subscriber identifier
@@ -712,56 +721,55 @@ func init() {
 }
 
 var fileDescriptor_swx_proxy_229ecfe1a265a130 = []byte{
-	// 801 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x55, 0x51, 0x6f, 0xe2, 0x46,
-	0x10, 0x8e, 0xc3, 0xdd, 0x95, 0x0c, 0x24, 0x75, 0x36, 0xa1, 0xe2, 0x12, 0xdd, 0x05, 0x51, 0x55,
-	0xa5, 0xa9, 0x0a, 0x12, 0x57, 0xf5, 0xdd, 0x07, 0x7b, 0xd4, 0x4a, 0x59, 0xac, 0xb5, 0xc9, 0xe9,
-	0xfa, 0xb2, 0x72, 0xcd, 0x00, 0x56, 0x63, 0x9b, 0xee, 0xda, 0x01, 0x7e, 0x44, 0xa5, 0xfe, 0x83,
-	0xfe, 0x9f, 0xfe, 0x96, 0x3e, 0xf5, 0xb5, 0x2f, 0x95, 0x8d, 0x49, 0x20, 0x2a, 0x97, 0x48, 0xed,
-	0x13, 0xf6, 0x37, 0xb3, 0xdf, 0xcc, 0x7e, 0x33, 0x1f, 0x86, 0xb3, 0x31, 0x4e, 0x5a, 0x33, 0x19,
-	0xc5, 0x91, 0x6a, 0xa9, 0xf9, 0x42, 0xcc, 0x64, 0xb4, 0x58, 0x36, 0x33, 0x80, 0x1c, 0x04, 0xee,
-	0x24, 0x70, 0x9b, 0x63, 0x9c, 0xd4, 0x7f, 0xdb, 0x87, 0x8a, 0x91, 0xc4, 0x53, 0x0c, 0x63, 0xdf,
-	0x73, 0x63, 0x3f, 0x0a, 0x39, 0xfe, 0x92, 0xa0, 0x8a, 0xc9, 0x39, 0x1c, 0x24, 0x0a, 0xa5, 0x08,
-	0xdd, 0x00, 0xab, 0x5a, 0x4d, 0x6b, 0x1c, 0xf0, 0x62, 0x0a, 0x30, 0x37, 0x40, 0xd2, 0x82, 0x53,
-	0xe5, 0xcf, 0x44, 0x98, 0x04, 0xc2, 0x4d, 0xe2, 0xa9, 0xb8, 0x45, 0x2f, 0x8e, 0xa4, 0xaa, 0xee,
-	0xd7, 0xb4, 0xc6, 0x21, 0x3f, 0x56, 0xfe, 0x8c, 0x25, 0x41, 0xca, 0x7b, 0xbd, 0x0a, 0x10, 0x07,
-	0x2a, 0xee, 0x56, 0x19, 0xa1, 0xbc, 0x29, 0x06, 0x58, 0x2d, 0xd4, 0xb4, 0xc6, 0x51, 0xfb, 0xa2,
-	0x79, 0xd7, 0x52, 0x73, 0xbb, 0x1d, 0x3b, 0x4b, 0xe3, 0xa7, 0xee, 0xbf, 0xa0, 0xe4, 0x02, 0x4a,
-	0x12, 0xd5, 0x32, 0xf4, 0x84, 0x1f, 0x8e, 0xa3, 0xea, 0xb3, 0x9a, 0xd6, 0x28, 0x73, 0x58, 0x41,
-	0x66, 0x38, 0x8e, 0x48, 0x1b, 0x2a, 0x12, 0x63, 0xe9, 0xe3, 0x2d, 0x8a, 0xec, 0x36, 0x33, 0x19,
-	0x8d, 0xfd, 0x1b, 0xac, 0x3e, 0xaf, 0x69, 0x8d, 0x22, 0x3f, 0x59, 0x07, 0x87, 0x0a, 0xa5, 0xb5,
-	0x0a, 0xd5, 0xff, 0x2a, 0xc0, 0xe9, 0x76, 0x0f, 0x46, 0xa8, 0xe6, 0x28, 0x3f, 0xae, 0xc8, 0x7b,
-	0xd0, 0x53, 0x45, 0x1e, 0xa8, 0x51, 0x68, 0x94, 0xda, 0xdf, 0xec, 0xbc, 0xdb, 0x8a, 0xb7, 0x69,
-	0x9b, 0xd6, 0xbd, 0x54, 0xfc, 0x48, 0xf9, 0xb3, 0x4d, 0xe5, 0x18, 0x94, 0xb7, 0x3a, 0x4f, 0x05,
-	0x2b, 0xb5, 0xbf, 0x7e, 0x8c, 0x74, 0xe3, 0x46, 0xbc, 0x94, 0xdc, 0xbf, 0x9c, 0xfd, 0xa9, 0xc1,
-	0xe1, 0x56, 0xc5, 0xdd, 0xb3, 0xd1, 0xfe, 0xcb, 0x6c, 0xce, 0xe1, 0x40, 0xba, 0xe1, 0x28, 0x55,
-	0x24, 0xcc, 0xf6, 0xa2, 0xcc, 0x8b, 0x29, 0x60, 0x24, 0x71, 0x48, 0x08, 0x3c, 0x5b, 0x48, 0x54,
-	0xd9, 0x65, 0xca, 0x3c, 0x7b, 0x26, 0x2d, 0x38, 0xf1, 0xa2, 0x70, 0xec, 0x8f, 0x52, 0x2a, 0xf7,
-	0xc6, 0x8f, 0x97, 0xe2, 0x67, 0x5c, 0xe6, 0x43, 0x25, 0x0f, 0x42, 0x57, 0xb8, 0x24, 0x9f, 0xc3,
-	0xa1, 0x1f, 0xc6, 0x38, 0x91, 0xeb, 0xd4, 0xe7, 0x59, 0x6a, 0xf9, 0x0e, 0xbc, 0xc2, 0xe5, 0xd9,
-	0x17, 0x50, 0xda, 0x90, 0x82, 0x7c, 0x06, 0x2f, 0x02, 0xe5, 0xab, 0x51, 0x98, 0x0f, 0x30, 0x7f,
-	0xab, 0xb7, 0xe1, 0x84, 0xe3, 0xc4, 0x57, 0xb1, 0x7c, 0xb2, 0x09, 0xea, 0xa7, 0x40, 0x36, 0xcf,
-	0xac, 0x84, 0xaf, 0xff, 0xbe, 0x0f, 0xaf, 0x37, 0x61, 0x07, 0x65, 0xe0, 0x87, 0x4f, 0xb7, 0xd6,
-	0x30, 0xdd, 0x69, 0x57, 0x45, 0xa1, 0xf0, 0xa2, 0x11, 0x66, 0xca, 0x1d, 0xb5, 0xbf, 0xdd, 0x98,
-	0xc1, 0xc7, 0xc9, 0x9b, 0x3c, 0x3b, 0xdc, 0x89, 0x46, 0x98, 0x3a, 0x61, 0xfd, 0xbc, 0xb2, 0x4a,
-	0x46, 0x9b, 0x59, 0xa5, 0x90, 0x55, 0xcd, 0x13, 0x52, 0xab, 0xd4, 0xa7, 0x00, 0xf7, 0x47, 0xc9,
-	0x4b, 0xa8, 0x58, 0x94, 0xf7, 0x0d, 0x46, 0x99, 0x23, 0x1c, 0xca, 0xfb, 0x26, 0x33, 0x1c, 0x73,
-	0xc0, 0xf4, 0xbd, 0x34, 0xc4, 0xe8, 0x7b, 0x61, 0x53, 0x7e, 0x4d, 0xb9, 0x30, 0x6c, 0xdb, 0xec,
-	0xb1, 0x3e, 0x65, 0x8e, 0xae, 0x91, 0x63, 0x38, 0xcc, 0xe1, 0xce, 0xf7, 0x06, 0xeb, 0x51, 0x7d,
-	0x3f, 0x85, 0x38, 0xed, 0x0f, 0xae, 0xa9, 0xb0, 0x45, 0xc7, 0xee, 0xbc, 0xd3, 0x0b, 0x97, 0x3e,
-	0x94, 0xed, 0xf9, 0x82, 0x4a, 0x19, 0xc9, 0xac, 0xd6, 0x09, 0x7c, 0x4a, 0x39, 0x1f, 0x70, 0x31,
-	0x64, 0x5d, 0xfa, 0xce, 0x64, 0xb4, 0xab, 0xef, 0x91, 0x1a, 0x9c, 0x9b, 0x5d, 0xca, 0x1c, 0xd3,
-	0xf9, 0x20, 0x8c, 0x1f, 0x38, 0x35, 0xba, 0x1f, 0x04, 0xa7, 0x3d, 0xd3, 0x76, 0x28, 0xa7, 0x5d,
-	0xfd, 0xd7, 0x2f, 0x49, 0x1d, 0x5e, 0x0d, 0x6d, 0xca, 0x05, 0x1b, 0x08, 0x36, 0x60, 0xe2, 0x4d,
-	0xcf, 0xb2, 0x84, 0x3d, 0x7c, 0x6b, 0x77, 0xb8, 0x69, 0x65, 0xad, 0xfe, 0x71, 0x79, 0xf9, 0xdd,
-	0x43, 0x2b, 0xe7, 0xcb, 0x59, 0x82, 0x4f, 0xa8, 0x61, 0x09, 0xe3, 0xca, 0xd0, 0xf7, 0xd2, 0x16,
-	0xf3, 0x17, 0x61, 0x71, 0xb3, 0x4f, 0x75, 0xad, 0xfd, 0xb7, 0x06, 0x45, 0x7b, 0xbe, 0xb0, 0xd2,
-	0x3f, 0x4d, 0x62, 0x43, 0x79, 0x83, 0x04, 0x49, 0x6d, 0xa7, 0x21, 0xf2, 0x19, 0x9c, 0x5d, 0x3c,
-	0xe2, 0xce, 0xfa, 0x1e, 0xb9, 0x82, 0xe2, 0x6a, 0x90, 0x28, 0xc9, 0xeb, 0x1d, 0xd3, 0x5d, 0xd3,
-	0xbd, 0xda, 0x11, 0xbf, 0x23, 0xeb, 0x03, 0x74, 0x51, 0xfe, 0x5f, 0x74, 0xed, 0x05, 0x1c, 0xdb,
-	0xf3, 0x45, 0xcf, 0x8d, 0x71, 0xee, 0x2e, 0x6d, 0x94, 0xb7, 0xbe, 0x87, 0xc4, 0x83, 0xca, 0x7a,
-	0xdb, 0x70, 0xf3, 0x14, 0xf9, 0xea, 0xc9, 0xbb, 0xf9, 0x68, 0xe5, 0xb7, 0xe7, 0x3f, 0xbe, 0xcc,
-	0x32, 0x5a, 0xe9, 0xd7, 0xcb, 0xbb, 0x89, 0x92, 0x51, 0x6b, 0x12, 0xe5, 0x9f, 0xb1, 0x9f, 0x5e,
-	0x64, 0xbf, 0x6f, 0xfe, 0x09, 0x00, 0x00, 0xff, 0xff, 0x22, 0xcb, 0xe4, 0x84, 0xdb, 0x06, 0x00,
-	0x00,
+	// 792 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xad, 0x55, 0x6d, 0x4f, 0xda, 0x50,
+	0x14, 0x16, 0x51, 0x87, 0x07, 0x74, 0x78, 0x81, 0x05, 0x31, 0xbe, 0xa4, 0xcb, 0x32, 0xe7, 0x32,
+	0x48, 0x70, 0xd9, 0xf7, 0x0a, 0x95, 0x35, 0x8e, 0xd2, 0xdc, 0x82, 0xc6, 0x7d, 0xb9, 0xe9, 0xca,
+	0x05, 0x9a, 0x49, 0xeb, 0x6e, 0x8b, 0xc0, 0x8f, 0xd8, 0x6f, 0xd8, 0x1f, 0xd8, 0x2f, 0xd9, 0x6f,
+	0xd9, 0xa7, 0x7d, 0xdd, 0x97, 0x9d, 0x96, 0x17, 0xc1, 0x0c, 0x35, 0xd9, 0x92, 0x26, 0xbd, 0xf7,
+	0x9c, 0xe7, 0x3c, 0xe7, 0xbd, 0x85, 0x5c, 0x8b, 0xb7, 0x0b, 0xd7, 0xc2, 0xf5, 0x5d, 0xaf, 0xe0,
+	0xf5, 0x07, 0x0c, 0x8f, 0x83, 0x61, 0x3e, 0x14, 0x90, 0xf5, 0xae, 0xd9, 0xee, 0x9a, 0x79, 0x44,
+	0x48, 0xdf, 0x97, 0x21, 0x23, 0xf7, 0xfc, 0x0e, 0x77, 0x7c, 0xdb, 0x32, 0x7d, 0xdb, 0x75, 0x28,
+	0xff, 0xd2, 0xe3, 0x9e, 0x4f, 0x76, 0x60, 0xbd, 0xe7, 0x71, 0xc1, 0x1c, 0xb3, 0xcb, 0xb3, 0x91,
+	0x83, 0xc8, 0xe1, 0x3a, 0x8d, 0x05, 0x02, 0x0d, 0xef, 0xa4, 0x00, 0x69, 0xcf, 0xbe, 0x66, 0x4e,
+	0xaf, 0xcb, 0x4c, 0xb4, 0x66, 0x37, 0xdc, 0xf2, 0x5d, 0xe1, 0x65, 0x97, 0x11, 0xb7, 0x41, 0xb7,
+	0x50, 0xa7, 0xf5, 0xba, 0x01, 0xef, 0xf9, 0x48, 0x41, 0xea, 0x90, 0x31, 0xe7, 0xdc, 0x30, 0xcf,
+	0xea, 0x70, 0x64, 0x8e, 0xa2, 0xc5, 0x66, 0x71, 0x3f, 0x3f, 0x0d, 0x29, 0x3f, 0x1f, 0x8e, 0x11,
+	0xc2, 0x68, 0xda, 0xfc, 0x8b, 0x94, 0xec, 0x43, 0x5c, 0x70, 0x6f, 0xe8, 0x58, 0xcc, 0x76, 0x5a,
+	0x6e, 0x76, 0x05, 0xb9, 0x12, 0x14, 0x46, 0x22, 0x15, 0x25, 0xa4, 0x08, 0x19, 0xc1, 0x7d, 0x61,
+	0xf3, 0x1b, 0xce, 0xc2, 0x6c, 0xb0, 0x00, 0x2d, 0xfb, 0x8a, 0x67, 0x57, 0x11, 0x1a, 0xa3, 0xa9,
+	0x89, 0xb2, 0x81, 0x3a, 0x7d, 0xa4, 0x22, 0x39, 0x88, 0x5d, 0x0b, 0xde, 0xe2, 0xbe, 0xd5, 0xc9,
+	0xae, 0x85, 0xb0, 0xe9, 0x5d, 0xfa, 0x15, 0x85, 0xf4, 0x7c, 0x7c, 0xb2, 0xe3, 0xf5, 0xb9, 0xb8,
+	0xbf, 0x5a, 0x17, 0x90, 0x0c, 0xaa, 0x75, 0xa7, 0x52, 0xd1, 0xc3, 0x78, 0xf1, 0xcd, 0xc2, 0xbc,
+	0x47, 0xbc, 0x79, 0x43, 0xd5, 0x6f, 0xcb, 0x48, 0x37, 0x91, 0x66, 0xb6, 0xaa, 0x1a, 0x24, 0xe6,
+	0xb2, 0x0a, 0x8a, 0x19, 0x2f, 0xbe, 0x7e, 0x88, 0x74, 0x26, 0x5b, 0x1a, 0xef, 0xdd, 0x5e, 0x72,
+	0x3f, 0x23, 0xb0, 0x31, 0xe7, 0x71, 0x71, 0xdf, 0x22, 0xff, 0xd2, 0x37, 0xac, 0x96, 0x30, 0x9d,
+	0x66, 0x50, 0x11, 0x27, 0x9c, 0x99, 0x04, 0x8d, 0x05, 0x02, 0xa4, 0x70, 0x08, 0x81, 0x95, 0x01,
+	0xb6, 0x30, 0x4c, 0x26, 0x41, 0xc3, 0x33, 0xce, 0x5b, 0xca, 0x72, 0x9d, 0x96, 0xdd, 0x0c, 0xa8,
+	0xcc, 0x2b, 0xdb, 0x1f, 0xb2, 0xcf, 0x7c, 0x38, 0x6e, 0x38, 0xb9, 0xa3, 0x3a, 0xe3, 0x43, 0xf2,
+	0x1c, 0x36, 0x6c, 0xc7, 0xe7, 0x6d, 0x31, 0x81, 0xae, 0x86, 0xd0, 0xc4, 0x54, 0x88, 0xa0, 0xdc,
+	0x0b, 0x88, 0xcf, 0x36, 0xfe, 0x19, 0xac, 0x75, 0x3d, 0xdb, 0x6b, 0x3a, 0xe3, 0x06, 0x8e, 0x6f,
+	0x52, 0x11, 0x52, 0x94, 0xb7, 0x6d, 0xcf, 0x17, 0x8f, 0x5e, 0x10, 0x29, 0x0d, 0x64, 0xd6, 0x66,
+	0x54, 0x78, 0xe9, 0xdb, 0x32, 0xec, 0xcd, 0x8a, 0xeb, 0x5c, 0x74, 0x6d, 0xe7, 0xf1, 0x6b, 0xd7,
+	0x08, 0xe6, 0xdd, 0xf4, 0xb0, 0x0b, 0x96, 0xdb, 0xe4, 0x61, 0xe5, 0x36, 0x8b, 0x6f, 0x67, 0x7a,
+	0x70, 0x3f, 0x39, 0xaa, 0x03, 0xe3, 0x12, 0xda, 0x06, 0x5b, 0x32, 0x39, 0x8f, 0xd6, 0x28, 0xa4,
+	0x0d, 0xd7, 0x28, 0x1a, 0x7a, 0x1d, 0x03, 0x82, 0x35, 0x92, 0x3a, 0x00, 0xb7, 0xa6, 0x64, 0x1b,
+	0x32, 0xba, 0x42, 0xab, 0xb2, 0xa6, 0x68, 0x75, 0x56, 0xc7, 0x93, 0xaa, 0xc9, 0x75, 0xb5, 0xa6,
+	0x25, 0x97, 0x02, 0x95, 0xa6, 0x5c, 0x30, 0x43, 0xa1, 0xe7, 0x0a, 0x65, 0xb2, 0x61, 0xa8, 0x15,
+	0xad, 0x8a, 0xb0, 0x64, 0x84, 0x6c, 0xe1, 0x68, 0x8d, 0xc4, 0xa5, 0xf7, 0xb2, 0x56, 0x51, 0x92,
+	0xcb, 0x81, 0x88, 0x2a, 0xd5, 0xda, 0xb9, 0xc2, 0x0c, 0x56, 0x32, 0x4a, 0xa7, 0xc9, 0xe8, 0x91,
+	0x0d, 0x09, 0xa3, 0x3f, 0x50, 0x84, 0x70, 0x45, 0xe8, 0x2b, 0x05, 0x4f, 0x15, 0x4a, 0x6b, 0x94,
+	0x35, 0xb4, 0xb2, 0x72, 0xaa, 0x6a, 0x4a, 0x19, 0xbd, 0x1c, 0xc0, 0x8e, 0x5a, 0x46, 0x56, 0xb5,
+	0x7e, 0xc9, 0xe4, 0x0f, 0x54, 0x91, 0xcb, 0x97, 0x8c, 0x2a, 0x15, 0xd5, 0xc0, 0x60, 0x10, 0xf0,
+	0xf5, 0x25, 0x91, 0x60, 0xb7, 0x81, 0xde, 0x98, 0x56, 0xc3, 0x47, 0x63, 0xc7, 0x15, 0x5d, 0x67,
+	0x46, 0xe3, 0xc4, 0x28, 0x51, 0x55, 0x0f, 0x43, 0xfd, 0x71, 0x74, 0xf4, 0xee, 0xee, 0x2a, 0x8f,
+	0x87, 0x33, 0x0e, 0x4f, 0x14, 0x59, 0x67, 0xf2, 0x99, 0x8c, 0xae, 0x30, 0xc4, 0xf1, 0x85, 0xe9,
+	0x54, 0xad, 0x2a, 0xc9, 0x48, 0xf1, 0x77, 0x04, 0x62, 0x18, 0xa3, 0x1e, 0x7c, 0x50, 0x89, 0x01,
+	0x89, 0x19, 0x12, 0x4e, 0x0e, 0x16, 0x2e, 0xc4, 0xb8, 0x07, 0xb9, 0xfd, 0x07, 0xb6, 0x53, 0x5a,
+	0x22, 0x67, 0x10, 0x1b, 0x35, 0x12, 0x3f, 0x2c, 0x7b, 0x0b, 0xba, 0x3b, 0xa1, 0xdb, 0x5d, 0xa0,
+	0x9f, 0x92, 0x55, 0x01, 0xca, 0x5c, 0xfc, 0x2f, 0xba, 0xe2, 0x00, 0xb6, 0x30, 0xf9, 0x0a, 0xe6,
+	0xda, 0x37, 0x87, 0x06, 0x17, 0x37, 0xb6, 0xc5, 0x89, 0x05, 0x99, 0xc9, 0xb4, 0xf1, 0x59, 0x2b,
+	0xf2, 0xea, 0xd1, 0xb3, 0xf9, 0xa0, 0xe7, 0x93, 0x9d, 0x8f, 0xdb, 0x21, 0xa2, 0x10, 0xfc, 0xd9,
+	0xac, 0x2b, 0xb7, 0xd7, 0x2c, 0xb4, 0xdd, 0xf1, 0x2f, 0xee, 0xd3, 0x5a, 0xf8, 0x3e, 0xfe, 0x03,
+	0xd0, 0x9c, 0x34, 0xe3, 0xf7, 0x06, 0x00, 0x00,
 }
@@ -0,0 +1,162 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package alerting_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+
+	"magma/feg/gateway/alerting"
+)
+
+type recordingEmitter struct {
+	events []alerting.Event
+}
+
+func (r *recordingEmitter) Emit(event alerting.Event) error {
+	r.events = append(r.events, event)
+	return nil
+}
+
+func TestRatioRule(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	auth := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "eap_auth"}, []string{"code", "apn"})
+	reg.MustRegister(auth)
+
+	cfg := &alerting.Config{Rules: []alerting.Rule{{
+		Name:        "auth_failures",
+		Severity:    "major",
+		Kind:        alerting.KindRatio,
+		Metric:      "eap_auth",
+		Labels:      map[string]string{"code": "Failure"},
+		Denominator: "eap_auth",
+		Op:          ">",
+		Threshold:   50,
+		ForSec:      30,
+	}}}
+	rec := &recordingEmitter{}
+	engine, err := alerting.NewEngine(cfg, reg, rec)
+	assert.NoError(t, err)
+
+	now := time.Unix(1000, 0)
+	auth.WithLabelValues("Success", "a").Add(10)
+	auth.WithLabelValues("Failure", "a").Add(1)
+	assert.Empty(t, engine.Evaluate(now)) // first evaluation only records the baseline
+
+	// 9 failures out of 10 requests, pending for less than 30 sec
+	auth.WithLabelValues("Success", "b").Add(1)
+	auth.WithLabelValues("Failure", "a").Add(5)
+	auth.WithLabelValues("Failure", "b").Add(4)
+	now = now.Add(30 * time.Second)
+	assert.Empty(t, engine.Evaluate(now))
+
+	auth.WithLabelValues("Failure", "a").Add(3)
+	now = now.Add(30 * time.Second)
+	events := engine.Evaluate(now)
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, "auth_failures", events[0].Alert)
+		assert.Equal(t, alerting.StateFiring, events[0].State)
+		assert.Equal(t, 100.0, events[0].Value)
+	}
+
+	// No requests - the alert state doesn't change
+	now = now.Add(30 * time.Second)
+	assert.Empty(t, engine.Evaluate(now))
+
+	auth.WithLabelValues("Success", "a").Add(10)
+	now = now.Add(30 * time.Second)
+	events = engine.Evaluate(now)
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, alerting.StateResolved, events[0].State)
+		assert.Equal(t, 0.0, events[0].Value)
+	}
+	assert.Len(t, rec.events, 2)
+}
+
+func TestValueAndRateRules(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	sessions := prometheus.NewGauge(prometheus.GaugeOpts{Name: "active_sessions"})
+	timeouts := prometheus.NewCounter(prometheus.CounterOpts{Name: "session_timeouts"})
+	reg.MustRegister(sessions, timeouts)
+
+	cfg := &alerting.Config{Rules: []alerting.Rule{
+		{Name: "too_many_sessions", Metric: "active_sessions", Op: ">=", Threshold: 100},
+		{Name: "timeouts", Kind: alerting.KindRate, Metric: "session_timeouts", Op: ">", Threshold: 1},
+	}}
+	engine, err := alerting.NewEngine(cfg, reg)
+	assert.NoError(t, err)
+	assert.Equal(t, alerting.KindValue, cfg.Rules[0].Kind)
+
+	now := time.Unix(1000, 0)
+	sessions.Set(100)
+	events := engine.Evaluate(now)
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, "too_many_sessions", events[0].Alert)
+	}
+
+	timeouts.Add(20)
+	now = now.Add(10 * time.Second)
+	events = engine.Evaluate(now)
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, "timeouts", events[0].Alert)
+		assert.Equal(t, 2.0, events[0].Value)
+	}
+}
+
+func TestConfig(t *testing.T) {
+	for _, cfg := range []alerting.Config{
+		{Rules: []alerting.Rule{{Metric: "m", Op: ">"}}},
+		{Rules: []alerting.Rule{{Name: "r", Op: ">"}}},
+		{Rules: []alerting.Rule{{Name: "r", Metric: "m", Op: "=="}}},
+		{Rules: []alerting.Rule{{Name: "r", Metric: "m", Op: ">", Kind: "avg"}}},
+		{Rules: []alerting.Rule{{Name: "r", Metric: "m", Op: ">", Kind: alerting.KindRatio}}},
+		{Rules: []alerting.Rule{{Name: "r", Metric: "m", Op: ">"}, {Name: "r", Metric: "m", Op: "<"}}},
+	} {
+		assert.Error(t, cfg.Validate())
+	}
+
+	f, err := ioutil.TempFile("", "alerts")
+	assert.NoError(t, err)
+	defer os.Remove(f.Name())
+	_, err = f.WriteString(`{"evaluation_interval_sec": 15, "rules": [{"name": "r", "metric": "m", "op": "<", "threshold": 1}]}`)
+	assert.NoError(t, err)
+	f.Close()
+
+	cfg, err := alerting.ReadConfig(f.Name())
+	assert.NoError(t, err)
+	assert.Equal(t, 15*time.Second, cfg.EvaluationInterval())
+	assert.Len(t, cfg.Rules, 1)
+}
+
+func TestWebhookEmitter(t *testing.T) {
+	received := make(chan alerting.Event, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var ev alerting.Event
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&ev))
+		received <- ev
+	}))
+	defer srv.Close()
+
+	ev := alerting.Event{Alert: "a", Severity: "minor", State: alerting.StateFiring, Value: 3, Op: ">", Threshold: 2}
+	assert.NoError(t, alerting.NewWebhookEmitter(srv.URL).Emit(ev))
+	got := <-received
+	assert.Equal(t, ev.Alert, got.Alert)
+	assert.Equal(t, ev.State, got.State)
+	assert.Equal(t, ev.Value, got.Value)
+
+	assert.Error(t, alerting.NewWebhookEmitter(srv.URL+"/\x00").Emit(ev))
+}
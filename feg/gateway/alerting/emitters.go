@@ -0,0 +1,76 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package alerting
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const webhookTimeout = 10 * time.Second
+
+// WebhookEmitter POSTs JSON encoded alert events to a webhook URL
+type WebhookEmitter struct {
+	URL    string
+	client *http.Client
+}
+
+// NewWebhookEmitter returns a new emitter POSTing events to url
+func NewWebhookEmitter(url string) *WebhookEmitter {
+	return &WebhookEmitter{URL: url, client: &http.Client{Timeout: webhookTimeout}}
+}
+
+// Emit implements Emitter
+func (w *WebhookEmitter) Emit(event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	resp, err := w.client.Post(w.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("Webhook %s returned status: %s", w.URL, resp.Status)
+	}
+	return nil
+}
+
+// alertsFiring is exported with the service's metrics, so firing alerts reach orc8r along with the rest of
+// the gateway metrics
+var alertsFiring = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "gateway_alerts_firing",
+		Help: "Locally evaluated alerts, 1 while firing, partitioned by alert name & severity",
+	},
+	[]string{"alert", "severity"},
+)
+
+func init() {
+	prometheus.MustRegister(alertsFiring)
+}
+
+// MetricsEmitter reflects alert states in the gateway_alerts_firing metric
+type MetricsEmitter struct{}
+
+// Emit implements Emitter
+func (MetricsEmitter) Emit(event Event) error {
+	value := 0.0
+	if event.State == StateFiring {
+		value = 1
+	}
+	alertsFiring.WithLabelValues(event.Alert, event.Severity).Set(value)
+	return nil
+}
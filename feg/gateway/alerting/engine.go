@@ -0,0 +1,228 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package alerting
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// Alert states
+const (
+	StateFiring   = "firing"
+	StateResolved = "resolved"
+)
+
+// Event - alert state change event
+type Event struct {
+	Alert     string    `json:"alert"`
+	Severity  string    `json:"severity"`
+	State     string    `json:"state"`
+	Value     float64   `json:"value"`
+	Op        string    `json:"op"`
+	Threshold float64   `json:"threshold"`
+	Time      time.Time `json:"time"`
+}
+
+func (e Event) String() string {
+	return fmt.Sprintf("alert %s %s: %g %s %g", e.Alert, e.State, e.Value, e.Op, e.Threshold)
+}
+
+// Emitter delivers alert events
+type Emitter interface {
+	Emit(event Event) error
+}
+
+type ruleState struct {
+	lastNumerator, lastDenominator float64
+	lastEval                       time.Time
+	pendingSince                   time.Time // zero if the condition doesn't hold
+	firing                         bool
+}
+
+// Engine periodically evaluates alerting rules over locally gathered metrics
+type Engine struct {
+	cfg      *Config
+	gatherer prometheus.Gatherer
+	emitters []Emitter
+	states   map[string]*ruleState
+	mu       sync.Mutex
+}
+
+// NewEngine returns a new alerting engine evaluating cfg's rules over metrics gathered from gatherer
+// (prometheus.DefaultGatherer if nil) & emitting alert events to the given emitters
+func NewEngine(cfg *Config, gatherer prometheus.Gatherer, emitters ...Emitter) (*Engine, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("Nil alerting configuration")
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if gatherer == nil {
+		gatherer = prometheus.DefaultGatherer
+	}
+	return &Engine{cfg: cfg, gatherer: gatherer, emitters: emitters, states: map[string]*ruleState{}}, nil
+}
+
+// Run evaluates the rules every evaluation interval, it never returns
+func (e *Engine) Run() {
+	interval := e.cfg.EvaluationInterval()
+	glog.Infof("Alerting engine started with %d rules, evaluation interval: %v", len(e.cfg.Rules), interval)
+	for {
+		e.Evaluate(time.Now())
+		time.Sleep(interval)
+	}
+}
+
+// Evaluate evaluates all rules once, emits events for alerts changing state & returns them
+func (e *Engine) Evaluate(now time.Time) []Event {
+	families, err := e.gatherer.Gather()
+	if err != nil {
+		glog.Errorf("Alerting engine failed to gather metrics: %v", err)
+		return nil
+	}
+	byName := make(map[string]*dto.MetricFamily, len(families))
+	for _, f := range families {
+		byName[f.GetName()] = f
+	}
+
+	e.mu.Lock()
+	var events []Event
+	for _, rule := range e.cfg.Rules {
+		if ev := e.evaluateRule(rule, byName, now); ev != nil {
+			events = append(events, *ev)
+		}
+	}
+	e.mu.Unlock()
+
+	for _, ev := range events {
+		glog.Warningf("Alerting: %s", ev)
+		for _, emitter := range e.emitters {
+			if err := emitter.Emit(ev); err != nil {
+				glog.Errorf("Failed to emit %s: %v", ev, err)
+			}
+		}
+	}
+	return events
+}
+
+// evaluateRule updates the rule's state & returns an event if the alert changed state, it must be called with
+// the engine lock held
+func (e *Engine) evaluateRule(rule Rule, families map[string]*dto.MetricFamily, now time.Time) *Event {
+	state, ok := e.states[rule.Name]
+	if !ok {
+		state = &ruleState{}
+		e.states[rule.Name] = state
+	}
+	numerator, found := sum(families[rule.Metric], rule.Labels)
+	var denominator float64
+	if rule.Kind == KindRatio {
+		var denominatorFound bool
+		denominator, denominatorFound = sum(families[rule.Denominator], rule.DenominatorLabels)
+		found = found && denominatorFound
+	}
+	value, valid := 0.0, false
+	if found {
+		switch rule.Kind {
+		case KindValue:
+			value, valid = numerator, true
+		case KindRate:
+			if !state.lastEval.IsZero() && numerator >= state.lastNumerator {
+				if elapsed := now.Sub(state.lastEval).Seconds(); elapsed > 0 {
+					value, valid = (numerator-state.lastNumerator)/elapsed, true
+				}
+			}
+		case KindRatio:
+			delta := denominator - state.lastDenominator
+			if !state.lastEval.IsZero() && numerator >= state.lastNumerator && delta > 0 {
+				value, valid = (numerator-state.lastNumerator)*100/delta, true
+			}
+		}
+		state.lastNumerator, state.lastDenominator, state.lastEval = numerator, denominator, now
+	}
+
+	// A missing metric or a period with no data leaves the alert as is
+	if !valid {
+		return nil
+	}
+	if !comparators[rule.Op](value, rule.Threshold) {
+		state.pendingSince = time.Time{}
+		if state.firing {
+			state.firing = false
+			return newEvent(rule, StateResolved, value, now)
+		}
+		return nil
+	}
+	if state.pendingSince.IsZero() {
+		state.pendingSince = now
+	}
+	if !state.firing && now.Sub(state.pendingSince) >= time.Duration(rule.ForSec)*time.Second {
+		state.firing = true
+		return newEvent(rule, StateFiring, value, now)
+	}
+	return nil
+}
+
+func newEvent(rule Rule, state string, value float64, now time.Time) *Event {
+	return &Event{
+		Alert:     rule.Name,
+		Severity:  rule.Severity,
+		State:     state,
+		Value:     value,
+		Op:        rule.Op,
+		Threshold: rule.Threshold,
+		Time:      now,
+	}
+}
+
+// sum returns the sum of all family's samples matching the given labels & true if any sample matched
+func sum(family *dto.MetricFamily, labels map[string]string) (float64, bool) {
+	if family == nil {
+		return 0, false
+	}
+	var total float64
+	var found bool
+	for _, m := range family.GetMetric() {
+		if !matches(m, labels) {
+			continue
+		}
+		found = true
+		switch family.GetType() {
+		case dto.MetricType_COUNTER:
+			total += m.GetCounter().GetValue()
+		case dto.MetricType_GAUGE:
+			total += m.GetGauge().GetValue()
+		case dto.MetricType_SUMMARY:
+			total += float64(m.GetSummary().GetSampleCount())
+		case dto.MetricType_HISTOGRAM:
+			total += float64(m.GetHistogram().GetSampleCount())
+		default:
+			total += m.GetUntyped().GetValue()
+		}
+	}
+	return total, found
+}
+
+func matches(m *dto.Metric, labels map[string]string) bool {
+	matched := 0
+	for _, pair := range m.GetLabel() {
+		if value, ok := labels[pair.GetName()]; ok {
+			if value != pair.GetValue() {
+				return false
+			}
+			matched++
+		}
+	}
+	return matched == len(labels)
+}
@@ -0,0 +1,124 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package alerting implements a lightweight alerting engine evaluating rules over the gateway's local
+// Prometheus metrics & emitting alert events, for gateways whose metrics aren't scraped & alerted on centrally
+package alerting
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"time"
+)
+
+// Rule kinds
+const (
+	// KindValue - the rule is evaluated over the current (summed) value of the metric
+	KindValue = "value"
+	// KindRate - the rule is evaluated over the metric's per second increase since the previous evaluation
+	KindRate = "rate"
+	// KindRatio - the rule is evaluated over the metric's increase since the previous evaluation, in percents of
+	// the denominator metric's increase over the same period
+	KindRatio = "ratio"
+)
+
+const defaultEvaluationInterval = time.Minute
+
+// Rule - an alerting rule
+type Rule struct {
+	Name     string `json:"name"`
+	Severity string `json:"severity"`
+	Kind     string `json:"kind"`
+	// Metric & Labels select the metric samples to sum, all given labels must match
+	Metric string            `json:"metric"`
+	Labels map[string]string `json:"labels"`
+	// Denominator & DenominatorLabels select the denominator samples of 'ratio' rules
+	Denominator       string            `json:"denominator"`
+	DenominatorLabels map[string]string `json:"denominator_labels"`
+	// Op is one of: >, >=, <, <=
+	Op        string  `json:"op"`
+	Threshold float64 `json:"threshold"`
+	// ForSec - the condition must hold for at least ForSec seconds before the alert fires
+	ForSec int `json:"for_sec"`
+}
+
+// Config - alerting engine configuration
+type Config struct {
+	EvaluationIntervalSec int      `json:"evaluation_interval_sec"`
+	Webhooks              []string `json:"webhooks"`
+	Rules                 []Rule   `json:"rules"`
+}
+
+// ReadConfig reads & validates alerting configuration from the given JSON file
+func ReadConfig(path string) (*Config, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &Config{}
+	if err = json.Unmarshal(b, cfg); err != nil {
+		return nil, fmt.Errorf("Invalid alerting configuration %s: %v", path, err)
+	}
+	return cfg, cfg.Validate()
+}
+
+// Validate validates the configuration's rules
+func (cfg *Config) Validate() error {
+	if cfg.EvaluationIntervalSec < 0 {
+		return fmt.Errorf("Invalid evaluation interval: %d", cfg.EvaluationIntervalSec)
+	}
+	names := map[string]bool{}
+	for i := range cfg.Rules {
+		r := &cfg.Rules[i]
+		if len(r.Name) == 0 {
+			return fmt.Errorf("Rule #%d: missing name", i)
+		}
+		if names[r.Name] {
+			return fmt.Errorf("Rule %s: duplicate name", r.Name)
+		}
+		names[r.Name] = true
+		if len(r.Metric) == 0 {
+			return fmt.Errorf("Rule %s: missing metric", r.Name)
+		}
+		if len(r.Kind) == 0 {
+			r.Kind = KindValue
+		}
+		switch r.Kind {
+		case KindValue, KindRate:
+		case KindRatio:
+			if len(r.Denominator) == 0 {
+				return fmt.Errorf("Rule %s: missing denominator metric for ratio rule", r.Name)
+			}
+		default:
+			return fmt.Errorf("Rule %s: unknown kind '%s'", r.Name, r.Kind)
+		}
+		if _, ok := comparators[r.Op]; !ok {
+			return fmt.Errorf("Rule %s: unknown operator '%s'", r.Name, r.Op)
+		}
+		if r.ForSec < 0 {
+			return fmt.Errorf("Rule %s: invalid for_sec: %d", r.Name, r.ForSec)
+		}
+	}
+	return nil
+}
+
+// EvaluationInterval returns the configured evaluation interval or the default one if not set
+func (cfg *Config) EvaluationInterval() time.Duration {
+	if cfg == nil || cfg.EvaluationIntervalSec <= 0 {
+		return defaultEvaluationInterval
+	}
+	return time.Duration(cfg.EvaluationIntervalSec) * time.Second
+}
+
+var comparators = map[string]func(value, threshold float64) bool{
+	">":  func(v, t float64) bool { return v > t },
+	">=": func(v, t float64) bool { return v >= t },
+	"<":  func(v, t float64) bool { return v < t },
+	"<=": func(v, t float64) bool { return v <= t },
+}
@@ -7,6 +7,10 @@
 module magma/feg/gateway
 
 replace (
+	fbc/lib/go/debughttp => ../radius/lib/go/debughttp
+	fbc/lib/go/radius => ../radius/lib/go/radius
+	fbc/lib/go/retry => ../radius/lib/go/retry
+	fbc/lib/go/rolling => ../radius/lib/go/rolling
 	github.com/fiorix/go-diameter => ./third-party/go/src/github.com/fiorix/go-diameter
 
 	magma/feg/cloud/go => ../../feg/cloud/go
@@ -18,6 +22,10 @@ replace (
 )
 
 require (
+	fbc/lib/go/debughttp v0.0.0-00010101000000-000000000000
+	fbc/lib/go/radius v0.0.0-00010101000000-000000000000
+	fbc/lib/go/retry v0.0.0-00010101000000-000000000000
+	fbc/lib/go/rolling v0.0.0-00010101000000-000000000000
 	github.com/fiorix/go-diameter v3.0.3-0.20180924121357-70410bd9fce3+incompatible
 	github.com/go-redis/redis v6.14.1+incompatible
 	github.com/golang/glog v0.0.0-20160126235308-23def4e6c14b
@@ -30,6 +38,8 @@ require (
 	github.com/prometheus/common v0.2.0
 	github.com/shirou/gopsutil v2.18.10+incompatible
 	github.com/stretchr/testify v1.3.0
+	go.uber.org/zap v1.10.0
+	golang.org/x/crypto v0.0.0-20190103213133-ff983b9c42bc
 	golang.org/x/net v0.0.0-20190110200230-915654e7eabc
 	google.golang.org/grpc v1.17.0
 
@@ -38,6 +38,10 @@ const (
 	MOCK_HSS      = "HSS"
 
 	SESSION_MANAGER = "SESSIOND"
+	// SUBSCRIBERDB has no default location, it must be configured in service_registry.yml to be used
+	SUBSCRIBERDB = "SUBSCRIBERDB"
+	// PIPELINED has no default location, it must be configured in service_registry.yml to be used
+	PIPELINED = "PIPELINED"
 )
 
 // Add a new service.
@@ -0,0 +1,76 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package main implements AAA dispatcher, sharding sessions across several AAA server instances. The dispatcher
+// serves as the AAA_SERVER service, its instances must be configured with other addresses
+package main
+
+import (
+	"flag"
+	"log"
+
+	"fbc/lib/go/debughttp"
+
+	"magma/feg/gateway/registry"
+	"magma/feg/gateway/services/aaa/dispatcher"
+	"magma/feg/gateway/services/aaa/protos"
+	"magma/feg/gateway/services/aaa/serviceinfo"
+	"magma/orc8r/cloud/go/service"
+)
+
+var (
+	instances = flag.String("instances", "",
+		"Comma separated AAA server instances, service registry names or host:port addresses")
+	replicas  = flag.Int("ring_replicas", dispatcher.DefaultReplicas, "Number of hash ring points per AAA instance")
+	debugAddr = flag.String("debug_http_addr", "",
+		"Debug HTTP endpoint (pprof, expvar & runtime metrics) listen address, e.g. "+debughttp.DefaultAddr+
+			", empty - disabled")
+	debugTokenFile = flag.String("debug_http_token_file", "",
+		"Debug HTTP endpoint bearer token file path, empty - the endpoint doesn't require authorization")
+	debugAllowRemote = flag.Bool("debug_http_allow_remote", false,
+		"Allow the debug HTTP endpoint to listen on non loopback addresses")
+)
+
+func main() {
+	srv, err := service.NewServiceWithOptions(registry.ModuleName, registry.AAA_SERVER)
+	if err != nil {
+		log.Fatalf("Error creating AAA dispatcher service: %s", err)
+	}
+	if len(*debugAddr) > 0 {
+		debugCfg, err := debughttp.NewConfig(*debugAddr, *debugTokenFile, *debugAllowRemote)
+		if err == nil {
+			_, err = debughttp.Start(debugCfg, nil)
+		}
+		if err != nil {
+			log.Fatalf("Error starting debug HTTP endpoint: %v", err)
+		}
+		log.Printf("Debug HTTP endpoint on %s is enabled", *debugAddr)
+	}
+	names, err := dispatcher.ParseInstances(*instances)
+	if err != nil {
+		log.Fatalf("Invalid AAA instances: %v", err)
+	}
+	ring, err := dispatcher.NewRing(names, *replicas)
+	if err != nil {
+		log.Fatalf("Invalid AAA instances: %v", err)
+	}
+	d, err := dispatcher.New(ring)
+	if err != nil {
+		log.Fatalf("Error creating AAA dispatcher: %v", err)
+	}
+	protos.RegisterAccountingServer(srv.GrpcServer, d)
+	protos.RegisterAuthenticatorServer(srv.GrpcServer, d)
+	protos.RegisterSessionAdminServer(srv.GrpcServer, d)
+	log.Printf("AAA dispatcher of instances %v is enabled", names)
+	log.Printf("AAA dispatcher service info: %s", serviceinfo.Banner(serviceinfo.New(registry.AAA_SERVER)))
+
+	err = srv.Run()
+	if err != nil {
+		log.Fatalf("Error running AAA dispatcher service: %s", err)
+	}
+}
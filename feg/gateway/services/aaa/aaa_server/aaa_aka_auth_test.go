@@ -14,6 +14,8 @@ import (
 	"time"
 
 	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	cp "magma/feg/cloud/go/protos"
 	"magma/feg/cloud/go/protos/mconfig"
@@ -54,6 +56,9 @@ func (s *testAuthenticator) Handle(ctx context.Context, in *protos.Eap) (*protos
 func (s *testAuthenticator) SupportedMethods(ctx context.Context, in *protos.Void) (*protos.EapMethodList, error) {
 	return &protos.EapMethodList{Methods: s.supportedMethods}, nil
 }
+func (s *testAuthenticator) MacAuthBypass(ctx context.Context, in *protos.Context) (*protos.Context, error) {
+	return nil, status.Errorf(codes.Unimplemented, "MAC Authentication Bypass is not supported")
+}
 
 var (
 	plmnID5      = "00101"
@@ -10,44 +10,1357 @@ LICENSE file in the root directory of this source tree.
 package main
 
 import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
 	"log"
+	"strconv"
+	"strings"
+	"time"
 
+	"fbc/lib/go/debughttp"
+	"fbc/lib/go/retry"
 	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
 
+	fegprotos "magma/feg/cloud/go/protos"
 	"magma/feg/cloud/go/protos/mconfig"
+	"magma/feg/gateway/alerting"
+	"magma/feg/gateway/object_store"
 	"magma/feg/gateway/registry"
+	"magma/feg/gateway/services/aaa"
+	"magma/feg/gateway/services/aaa/acctfaults"
+	"magma/feg/gateway/services/aaa/acctproxy"
+	"magma/feg/gateway/services/aaa/acctqueue"
+	"magma/feg/gateway/services/aaa/adminauth"
+	"magma/feg/gateway/services/aaa/aggregate"
+	"magma/feg/gateway/services/aaa/anomaly"
+	"magma/feg/gateway/services/aaa/apnalias"
+	"magma/feg/gateway/services/aaa/apnauth"
+	"magma/feg/gateway/services/aaa/apreport"
+	"magma/feg/gateway/services/aaa/apvendor"
+	"magma/feg/gateway/services/aaa/audit"
+	"magma/feg/gateway/services/aaa/canary"
+	"magma/feg/gateway/services/aaa/charging"
+	"magma/feg/gateway/services/aaa/coalog"
+	"magma/feg/gateway/services/aaa/deadlines"
+	"magma/feg/gateway/services/aaa/directory"
+	"magma/feg/gateway/services/aaa/enrich"
+	"magma/feg/gateway/services/aaa/export"
+	"magma/feg/gateway/services/aaa/failuremode"
+	"magma/feg/gateway/services/aaa/fairshare"
+	"magma/feg/gateway/services/aaa/fingerprint"
+	"magma/feg/gateway/services/aaa/guest"
+	"magma/feg/gateway/services/aaa/health"
+	"magma/feg/gateway/services/aaa/hssprobe"
+	"magma/feg/gateway/services/aaa/mab"
+	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/aaa/metricsexport"
+	"magma/feg/gateway/services/aaa/panics"
+	"magma/feg/gateway/services/aaa/persisted"
+	"magma/feg/gateway/services/aaa/pipelined"
+	"magma/feg/gateway/services/aaa/policyhook"
+	"magma/feg/gateway/services/aaa/prefetch"
 	"magma/feg/gateway/services/aaa/protos"
+	"magma/feg/gateway/services/aaa/quarantine"
+	"magma/feg/gateway/services/aaa/radiusauthz"
+	"magma/feg/gateway/services/aaa/ratelimit"
+	"magma/feg/gateway/services/aaa/readiness"
+	"magma/feg/gateway/services/aaa/recorder"
+	"magma/feg/gateway/services/aaa/reqlog"
+	"magma/feg/gateway/services/aaa/retention"
+	"magma/feg/gateway/services/aaa/serviceinfo"
 	"magma/feg/gateway/services/aaa/servicers"
+	"magma/feg/gateway/services/aaa/session_manager"
+	"magma/feg/gateway/services/aaa/shedding"
+	"magma/feg/gateway/services/aaa/slo"
+	"magma/feg/gateway/services/aaa/spill"
+	"magma/feg/gateway/services/aaa/staticrules"
 	"magma/feg/gateway/services/aaa/store"
+	"magma/feg/gateway/services/aaa/timepolicy"
+	"magma/feg/gateway/services/aaa/usageexport"
+	"magma/feg/gateway/services/aaa/userdb"
+	"magma/feg/gateway/services/aaa/wholesale"
+	"magma/feg/gateway/services/eap/providers/gtc"
+	eap_registry "magma/feg/gateway/services/eap/providers/registry"
+	eap_tls "magma/feg/gateway/services/eap/providers/tls"
+	"magma/feg/gateway/services/swx_proxy"
+	"magma/feg/gateway/settings"
 	"magma/orc8r/cloud/go/service"
 	managed_configs "magma/orc8r/gateway/mconfig"
 )
 
-const AAAServiceName = "aaa_server"
+const (
+	AAAServiceName = "aaa_server"
+	// AAASettingsEnvPrefix - prefix of AAA settings environment variables, e.g. AAA_MAX_SESSIONS
+	AAASettingsEnvPrefix = "AAA"
+	// defaultAuditLogPath - audit log file path of the file backend if the audit log is enabled without a path
+	defaultAuditLogPath = "/var/opt/magma/aaa/audit.log"
+)
 
-func main() {
-	// Create a shared Session Table
-	sessions := store.NewMemorySessionTable()
+var (
+	settingsFile = flag.String("settings_file", "",
+		"JSON settings file path, sets flags not set on the command line or by AAA_<FLAG_NAME> environment variables")
+	userDbPath = flag.String("user_db", "",
+		"Local users DB file path, enables local users management & inner EAP-GTC authentication")
+	eapTLSCert = flag.String("eap_tls_cert", "",
+		"EAP-TLS server certificate PEM file path, enables EAP-TLS authentication, requires eap_tls_key & eap_tls_ca")
+	eapTLSKey     = flag.String("eap_tls_key", "", "EAP-TLS server key PEM file path")
+	eapTLSCA      = flag.String("eap_tls_ca", "", "PEM file path of the CAs of the EAP-TLS clients' certificates")
+	eapTLSIMSIMap = flag.String("eap_tls_imsi_map", "",
+		"JSON file path of the EAP-TLS clients' certificate identity to IMSI map, unknown identities are rejected, "+
+			"empty - the certificates' identities are mapped by their subjects")
+	apnMapPath = flag.String(
+		"apn_map", "", "Local IMSI to APN map file path, enables APN authorization of new sessions")
+	apnAuthSubscriberDB = flag.Bool(
+		"apn_auth_subscriberdb", false, "Enable APN authorization of new sessions using subscriberdb profiles")
+	maxSessions      = flag.Int("max_sessions", 0, "Maximum number of sessions, 0 - unlimited")
+	preemptionPolicy = flag.String("session_preemption_policy", string(store.PreemptReject),
+		"Full session table policy: reject, evict_oldest_idle or evict_lowest_priority_apn")
+	duplicateIMSIPolicy = flag.String("duplicate_imsi_policy", string(servicers.DuplicateIMSIPermit),
+		"New sessions of IMSIs with a session from a different MAC or AP policy: permit, roam or clone")
+	subscriberAggregation = flag.Bool("subscriber_aggregation", false,
+		"Aggregate the usage of subscribers' simultaneous sessions, requires the permit duplicate IMSI policy")
+	subscriberQuotaOctets = flag.Uint64("subscriber_quota_octets", 0,
+		"Quota of the combined octets of subscribers' simultaneous sessions, sessions over it are terminated, 0 - no quota")
+	apnPriorities = flag.String(
+		"apn_priorities", "", "Comma separated APN:priority list for evict_lowest_priority_apn policy, e.g. ims:10,guest:1")
+	maintenanceInterval = flag.Duration(
+		"maintenance_interval", 24*time.Hour, "Session table compaction & inactive IMSI metrics pruning interval")
+	metricsRetention = flag.Duration(
+		"inactive_imsi_metrics_retention", 24*time.Hour, "Retention of metrics of IMSIs without active sessions")
+	alertRules     = flag.String("alert_rules", "", "Local alerting rules configuration file path, enables local alerting")
+	timePolicyPath = flag.String(
+		"time_policy", "", "Time of day policy configuration file path, enables time window session policies")
+	auditLogPath   = flag.String("audit_log", "", "Audit log file path of the file backend, enables audit log")
+	auditLogRotate = flag.Int64("audit_log_rotate_bytes", 64<<20,
+		"Audit log size rotating it into <audit_log>.<UTC time>, 0 - never rotated")
+	auditLogMaxAge   = flag.Duration("audit_log_max_age", 0, "Age of rotated audit logs purging them, 0 - unlimited")
+	auditLogMaxBytes = flag.Int64("audit_log_max_bytes", 512<<20,
+		"Total size of rotated audit logs, the oldest ones over it are purged, 0 - unlimited")
+	auditLogEnabled = flag.Bool("audit_log_enabled", false,
+		"Enable the audit log of authentications & session lifecycle events, also enabled by the audit_log flag")
+	auditLogBackends = flag.String("audit_log_backends", audit.FileBackend,
+		"Comma separated audit log backends: file (rotating audit_log file), syslog (auth facility) & zap (stderr)")
+	auditLogEvents = flag.String("audit_log_events", "",
+		"Comma separated audit logged event types (auth_success, auth_failure, start, stop...), empty - all events")
+	retentionTargets = flag.String("retention", "",
+		"Retention targets (name, pattern, max_age & max_bytes) JSON file path, enables purging of their files")
+	retentionInterval = flag.Duration("retention_purge_interval", retention.DefaultPurgeInterval,
+		"Interval of purging files violating retention policies")
+	rpcLogLevel = flag.String("rpc_log_level", "info",
+		"Level of AAA calls' structured log records (debug, info, warn, error), failed calls are logged at least at "+
+			"warn level, 'off' disables the calls' logging")
+	persistedCounters = flag.String("persisted_counters", "",
+		"Restart-safe counters file path, enables the *_total_persisted metrics' persistence across restarts")
+	persistedCountersInterval = flag.Duration("persisted_counters_interval", persisted.DefaultSaveInterval,
+		"Interval of saving the restart-safe counters")
+	eventsExportPath = flag.String("events_export", "",
+		"Session lifecycle events export configuration file path, enables export to GCP Pub/Sub & AWS SNS/SQS")
+	enrichmentCallout = flag.String("enrichment_callout", "",
+		"host:port of the local enrichment_callout gRPC service appending fields to the exported events")
+	enrichmentTimeout  = flag.Duration("enrichment_timeout", enrich.DefaultTimeout, "Enrichment callout timeout")
+	enrichmentCacheTTL = flag.Duration("enrichment_cache_ttl", enrich.DefaultCacheTTL,
+		"Validity of the sessions' enrichment fields, unless the callout returns their TTL")
+	enrichmentBypass = flag.Duration("enrichment_bypass", enrich.DefaultBypass,
+		"Period without enrichment callouts after a failed callout, the events are exported without the fields")
+	usageExportEnabled = flag.Bool("usage_export_enabled", false,
+		"Enable the export of the sessions' Interim-Update & Stop usage records to the usage_export_collectors")
+	usageExportCollectors = flag.String("usage_export_collectors", "",
+		"Comma separated host:port addresses of the usage records' flow collectors")
+	usageExportFormat = flag.String("usage_export_format", usageexport.FormatIPFIX,
+		"Usage records format: ipfix (RFC 7011) or json (JSON lines)")
+	usageExportTransport = flag.String("usage_export_transport", usageexport.TransportUDP,
+		"Usage records transport: udp or tcp")
+	usageExportBatchSize = flag.Int("usage_export_batch_size", usageexport.DefaultBatchSize,
+		"Max usage records sent at once")
+	usageExportFlushInterval = flag.Duration("usage_export_flush_interval", usageexport.DefaultFlushInterval,
+		"Max delay of a queued usage record")
+	usageExportTemplateRefresh = flag.Duration("usage_export_template_refresh", usageexport.DefaultTemplateRefresh,
+		"Interval of resending the IPFIX templates to UDP collectors")
+	usageExportDomain = flag.Uint("usage_export_observation_domain", 0,
+		"IPFIX Observation Domain ID of the exported usage records")
+	apReportPath = flag.String("ap_capacity_report", "",
+		"AP capacity report configuration file path, enables per AP load reports to orc8r & an HTTP endpoint")
+	wholesaleSplitPath = flag.String("wholesale_split", "",
+		"Wholesale partners configuration file path, enables usage attribution & event files by Operator-Name partner")
+	sessionManagerRetry = flag.String("session_manager_retry", "",
+		"Session manager calls retry configuration JSON, e.g. "+
+			`{"maxAttempts":3,"initialBackoffMs":50,"backoffMultiplier":2,"jitter":0.2,"retryableCodes":["Unavailable"]}`)
+	sessionManagerBreaker = flag.String("session_manager_breaker", "",
+		"Session manager circuit breaker configuration JSON, e.g. "+
+			`{"failures":5,"cooldownMs":10000,"failureCodes":["Unavailable","DeadlineExceeded"]}`)
+	swxRetry             = flag.String("swx_retry", "", "SWx proxy calls retry configuration JSON")
+	sessionManagerRoutes = flag.String(
+		"session_manager_routes", "", "Per APN session manager routing configuration file path, default - local sessiond")
+	requiredDependencies = flag.String("required_dependencies", "",
+		"Comma separated services which must be reachable before serving, SESSIOND includes all routed session managers")
+	optionalDependencies = flag.String(
+		"optional_dependencies", "SESSIOND,RADIUS", "Comma separated services to pre-dial without waiting for them")
+	dependenciesTimeout = flag.Duration(
+		"dependencies_timeout", 30*time.Second, "Maximum wait for required dependencies, the service exits on timeout")
+	panicBreadcrumbs = flag.String(
+		"panic_breadcrumbs", "", "Recovered panics breadcrumbs file path, enables persisting of the last panics")
+	maxPanicBreadcrumbs = flag.Int("max_panic_breadcrumbs", panics.DefaultMaxBreadcrumbs, "Number of panics to keep")
+	interimIntervals    = flag.String("acct_interim_intervals", "",
+		"Comma separated APN:seconds list of Acct-Interim-Intervals returned to NASes, APN * - all other APNs")
+	defaultDeadline = flag.Duration("default_deadline", deadlines.DefaultTimeout,
+		"Deadline of inbound calls made without one & of background downstream calls")
 
+	prefetchSubscribers = flag.Int("prefetch_subscribers", 0,
+		"Number of most recently active subscribers to prefetch auth vectors for, 0 - disabled")
+	prefetchInterval = flag.Duration("prefetch_interval", time.Minute, "Auth vectors prefetch interval")
+	prefetchMaxIdle  = flag.Duration("prefetch_max_idle", 30*time.Minute,
+		"Subscribers without accounting activity for longer than max idle are not prefetched")
+	directoryRecords = flag.Bool("directory_records", false,
+		"Publish the started sessions' UE addresses & access points to the cloud directory service (directoryd), "+
+			"also enabled by the DirectoryRecords mconfig")
+	sessionResync = flag.Bool("session_resync", false,
+		"Resynchronize the session table with the session managers' CWF sessions on startup, "+
+			"also enabled by the ResyncSessionsOnStartup mconfig")
+	sessionResyncTimeout = flag.Duration("session_resync_timeout", 2*time.Minute,
+		"Maximum duration of the startup session resync, including the retries of unavailable session managers")
+	policyHookURL = flag.String("policy_hook", "",
+		"Policy endpoint URL consulted before accepting new sessions: grpc://host:port or http(s)://..., disabled if empty")
+	policyHookTimeout  = flag.Duration("policy_hook_timeout", 200*time.Millisecond, "Policy endpoint decision timeout")
+	policyHookFailOpen = flag.Bool("policy_hook_fail_open", true,
+		"Accept new sessions if the policy endpoint fails, otherwise reject them")
+	macRateLimit = flag.Float64("rate_limit_mac", 0,
+		"Accounting & authentication requests per second of a MAC address, over it requests are throttled, 0 - unlimited")
+	macRateBurst  = flag.Int("rate_limit_mac_burst", 20, "Burst of the requests of a MAC address over its rate limit")
+	imsiRateLimit = flag.Float64("rate_limit_imsi", 0,
+		"Accounting & authentication requests per second of an IMSI, over it requests are throttled, 0 - unlimited")
+	imsiRateBurst        = flag.Int("rate_limit_imsi_burst", 20, "Burst of the requests of an IMSI over its rate limit")
+	trafficCheckInterval = flag.Duration("traffic_check_interval", 0,
+		"Pipelined traffic check interval, sessions with traffic don't time out, 0 - disabled")
+	trafficIdleTimeout = flag.Duration("traffic_idle_timeout", 0,
+		"Idle timeout of sessions whose Interim-Updates report no traffic, 0 - all Interim-Updates restart the "+
+			"idle session timeout")
+	handoverWindow = flag.Duration("handover_window", servicers.DefaultHandoverWindow,
+		"Time a signaled LTE to Wi-Fi handover waits for the subscriber's Wi-Fi session")
+	terminatedLinger = flag.Duration("terminated_session_linger", servicers.DefaultTerminatedLinger,
+		"Time terminated sessions are remembered for, their late Interim-Updates & Stops are acknowledged, "+
+			"0 - disabled")
+	deviceHintTTL = flag.Duration("device_hint_ttl", fingerprint.DefaultTTL,
+		"Time device classification hints of UEs without a session wait for the UEs' sessions")
+	acctMaxConcurrent = flag.Int("acct_max_concurrent", 0,
+		"Maximum concurrent accounting calls, over the limit calls are queued or shed, 0 - load shedding is disabled")
+	acctStopQueue = flag.Int("acct_stop_queue", shedding.DefaultConfig(0).High.Size,
+		"Maximum queued accounting Stops & Terminates under overload")
+	acctStartQueue = flag.Int("acct_start_queue", shedding.DefaultConfig(0).Low.Size,
+		"Maximum queued accounting Starts & Interim-Updates under overload")
+	acctStopWeight = flag.Int("acct_stop_weight", shedding.DefaultConfig(0).High.Weight,
+		"Queued Stops & Terminates served per queued Start or Interim-Update under overload")
+	fairShareRate = flag.Float64("fair_share_rate", 0,
+		"Capacity in calls/s shared by authentication & accounting calls, 0 - fair scheduling is disabled")
+	fairShareBurst = flag.Int("fair_share_burst", 0,
+		"Calls admitted at once by the fair scheduling's full buckets, 0 - one second of fair_share_rate")
+	fairShareAuth = flag.Float64("fair_share_auth", fairshare.DefaultAuthShare,
+		"Share of the fair_share_rate capacity reserved for authentication calls, the rest is reserved for accounting")
+	fairShareMaxWait = flag.Duration("fair_share_max_wait", fairshare.DefaultMaxWait,
+		"Maximum wait of a call for its workload's capacity, calls over it are rejected")
+	apVendorOUIs = flag.String("ap_vendor_ouis", "",
+		"JSON OUI to AP vendor map file path, extends the default OUIs of AP vendor metrics")
+	sessionAdminTokenFile = flag.String("session_admin_token_file", "",
+		"Session admin API token file path, enables the session admin API, the token is granted the admin role")
+	sessionAdminRoles = flag.String("session_admin_roles", "",
+		"Session admin API roles JSON file path of tokens & client certificates, enables the session admin API")
+	attributesMaxBytes = flag.Int("session_attributes_max_bytes", 0,
+		"Maximum size of a session's attributes kept in memory, over the limit attributes are dropped, 0 - unlimited")
+	attributesSpillBytes = flag.Int("session_attributes_spill_bytes", 0,
+		"Session attribute values of at least this size are spilled to session_attributes_spill_dir, 0 - no spilling")
+	attributesSpillDir = flag.String("session_attributes_spill_dir", "/var/opt/magma/aaa/spill",
+		"Spilled session attribute values directory")
+	staticRulesPath = flag.String("static_rules", "",
+		"Per APN & subscriber static rules configuration file path, enables static rules installation at session creation")
+	chargingHintsPath = flag.String("charging_hints", "",
+		"Per APN & realm charging hints configuration file path, adds charging characteristics & rating groups to sessions")
+	apnAliasesPath = flag.String("apn_aliases", "",
+		"APN aliases configuration file path mapping SSIDs & Called-Station-Ids to canonical APNs, empty - no aliases")
+	quarantinePath = flag.String("quarantine", "",
+		"Security triggers' quarantine configuration file path, sessions of triggers without quarantine are disconnected")
+	failureModesPath = flag.String("failure_modes", "",
+		"Gx/Gy failure modes configuration file path, sessions created in failure modes without an action are allowed")
+	guestAPNs = flag.String("guest_apns", "",
+		"Comma separated list of APNs of time limited guest sessions, requires guest_max_duration")
+	guestRealms = flag.String("guest_realms", "",
+		"Comma separated list of NAI realms of time limited guest sessions, requires guest_max_duration")
+	guestMaxDuration = flag.Duration("guest_max_duration", 0,
+		"Maximum duration of guest sessions, returned as Session-Timeout & enforced by the AAA, 0 - no guest sessions")
+	hssProbeUser = flag.String("hss_probe_user", "",
+		"IMSI of the HSS reachability probes' user, preferably unknown to the HSS, empty - HSS probes are disabled")
+	hssProbeInterval = flag.Duration("hss_probe_interval", hssprobe.DefaultInterval, "HSS reachability probes interval")
+	hssProbeTimeout  = flag.Duration("hss_probe_timeout", hssprobe.DefaultTimeout, "HSS reachability probe timeout")
+	hssProbeFailures = flag.Int("hss_probe_failures", hssprobe.DefaultFailureThreshold,
+		"Consecutive failed probes marking the HSS unreachable & the AAA server unhealthy")
+	radiusEndpoints = flag.String("radius_endpoints", "",
+		"Comma separated list of Radius server endpoints of Disconnect & CoA requests in failover order, service "+
+			"registry names or host:port addresses, empty - the local Radius server only")
+	radiusCheckInterval = flag.Duration("radius_endpoints_check_interval", radiusauthz.DefaultCheckInterval,
+		"Radius server endpoints' health checks interval")
+	acctReorderWindow = flag.Duration("acct_reorder_window", 0,
+		"Maximum time a session's Accounting Start is held for the Stop of the subscriber's previous session, 0 - disabled")
+	anomalyDetection = flag.Bool("anomaly_detection", false, "Enable uplink/downlink usage anomaly detection")
+	anomalyMinUplink = flag.Uint64(
+		"anomaly_min_uplink", 10*1024*1024, "Minimal Interim-Update uplink octets delta to consider for anomalies")
+	anomalyMaxRatio = flag.Float64(
+		"anomaly_max_ratio", 20, "Maximum normal Interim-Update uplink/downlink octets ratio, 0 - disabled")
+	anomalyUplinkOnlyMaxDownlink = flag.Uint64(
+		"anomaly_uplink_only_max_downlink", 0, "Maximum downlink octets delta of an 'uplink only' interval")
+	anomalyIntervals = flag.Int(
+		"anomaly_intervals", 3, "Number of consecutive anomalous Interim-Updates required to raise an anomaly event")
+	anomalyCoATrafficClasses = flag.String(
+		"anomaly_coa_traffic_classes", "", "JSON traffic classes to apply via CoA to anomalous sessions, empty - no CoA")
+	dependencyBudgets = flag.String("dependency_latency_budgets", "",
+		"Comma separated dependency:duration list of latency budgets of downstream dependencies "+
+			"(sessiond, radius_authz, subscriberdb), calls over budget are reported as SLO breaches")
+	grpcRecording = flag.String("grpc_recording", "",
+		"Anonymized GRPC calls fixtures file path, enables recording of calls for replay based regression tests")
+	grpcRecordingMethods = flag.String("grpc_recording_methods", "",
+		"Comma separated list of recorded GRPC methods or their suffixes (e.g. accounting/Start), empty - all methods")
+	grpcRecordingMax = flag.Int("grpc_recording_max", recorder.DefaultMaxRecords,
+		"Maximum number of recorded GRPC calls")
+	acctQueuePath = flag.String("acct_queue", "",
+		"Accounting queue file path, enables queueing & replay of accounting calls failed while sessiond is unavailable")
+	acctQueueMax = flag.Int("acct_queue_max", acctqueue.DefaultMaxItems,
+		"Maximum number of queued accounting calls, calls failed while the queue is full are retransmitted by NASes")
+	acctQueueReplayInterval = flag.Duration("acct_queue_replay_interval", acctqueue.DefaultReplayInterval,
+		"Interval of queued accounting calls' replay attempts")
+	sessionTable = flag.String("session_table", store.MemoryTable,
+		"Session table: memory or redis, redis sessions survive AAA server restarts")
+	sessionTimeoutJitter = flag.Float64("session_timeout_jitter", 0,
+		"Maximum random extension of the sessions' idle timeouts as a fraction of the timeout (0-1), so sessions "+
+			"created in a burst don't all time out at once, 0 - no jitter")
+	sessionExpiryBatch = flag.Duration("session_expiry_batch_interval", 0,
+		"Timed out sessions are expired in batches at most once per interval, 0 - expired as they time out")
+	sessionExpiryConcurrency = flag.Int("session_expiry_concurrency", 0,
+		"Maximum number of timed out sessions whose ends (session manager & Disconnects) are processed concurrently, "+
+			"0 - unlimited")
+	eventTimestampMaxSkew = flag.Duration("acct_event_timestamp_max_skew", 0,
+		"Maximum Event-Timestamp skew of accounting requests from their arrival, skewed requests are flagged or "+
+			"rejected, 0 - Event-Timestamps aren't validated")
+	eventTimestampAction = flag.String("acct_event_timestamp_action", string(servicers.EventTimestampFlag),
+		"Action on accounting requests with Event-Timestamps skewed beyond the maximum: flag or reject")
+	sessionIDRules = flag.String("session_id_rules", "",
+		"JSON list of session ID rules ({\"match\":\"exact|case_insensitive|regex_extract\",\"pattern\":\"...\"}) "+
+			"normalizing the session IDs of the session table's lookups, empty - session IDs are matched as is")
+	acctResponseDeadline = flag.Duration("acct_response_deadline", 0,
+		"Accounting-Response deadline, Starts & Stops not completed within it are acknowledged while completing "+
+			"asynchronously, 0 - disabled")
+	canaryPath = flag.String("canary", "",
+		"Canary sessions JSON configuration file path, enables synthetic canary sessions of local users")
+	maxUsageRate = flag.Float64("max_usage_rate_mbps", servicers.DefaultMaxUsageRate*8/1e6,
+		"Maximum plausible session usage rate in Mbps, higher Interim-Update rates are warned of, 0 - not checked")
+	debugAddr = flag.String("debug_http_addr", "",
+		"Debug HTTP endpoint (pprof, expvar & runtime metrics) listen address, e.g. "+debughttp.DefaultAddr+
+			", empty - disabled")
+	debugTokenFile = flag.String("debug_http_token_file", "",
+		"Debug HTTP endpoint bearer token file path, empty - the endpoint doesn't require authorization")
+	debugAllowRemote = flag.Bool("debug_http_allow_remote", false,
+		"Allow the debug HTTP endpoint to listen on non loopback addresses")
+	acctProxyPath = flag.String("acct_proxy", "",
+		"Upstream RADIUS accounting servers configuration file path, enables mirroring of the accounting to them")
+	acctFaultIMSIs = flag.String("acct_fault_imsis", "",
+		"Comma separated IMSIs of synthetic test subscribers whose accounting faults may be injected by the session "+
+			"admin API, empty - disabled")
+	coaLogSize = flag.Int("coa_log_size", coalog.DefaultMaxTransactions,
+		"Number of the latest CoA & Disconnect-Requests kept in the CoA transaction log, 0 - disabled")
+	healthInterval = flag.Duration("health_check_interval", health.DefaultInterval,
+		"Interval of the health checks reported by the GRPC health service & the health HTTP endpoint")
+	healthAddr = flag.String("health_http_addr", "",
+		"Health HTTP endpoint (/healthz) listen address, e.g. 127.0.0.1:6061, empty - disabled")
+	metricsAddr = flag.String("metrics_http_addr", "",
+		"Local Prometheus /metrics endpoint listen address, e.g. 127.0.0.1:9105, empty - disabled")
+	metricsNetworkID = flag.String("metrics_push_network_id", "",
+		"Network ID of the metrics pushed to orchestrator's metricsd, empty - metrics are only polled by metricsd")
+	metricsGatewayID    = flag.String("metrics_push_gateway_id", "", "Gateway ID label of the metrics pushed to metricsd")
+	metricsPushInterval = flag.Duration("metrics_push_interval", metricsexport.DefaultPushInterval,
+		"Interval of the metrics pushes to metricsd")
+)
+
+func main() {
 	// Create the EAP AKA Provider service
-	srv, err := service.NewServiceWithOptions(registry.ModuleName, registry.AAA_SERVER)
+	var shedder *shedding.Shedder // set once the flags are parsed, before the service runs
+	shed := func(
+		ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		// queued calls are bounded by their deadlines, so the shedder follows the deadlines interceptor
+		return shedder.UnaryServerInterceptor(ctx, req, info, handler)
+	}
+	var scheduler *fairshare.Scheduler // set once the flags are parsed, before the service runs
+	schedule := func(
+		ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		// waiting calls are bounded by their deadlines, so the scheduler follows the deadlines interceptor
+		return scheduler.UnaryServerInterceptor(ctx, req, info, handler)
+	}
+	var callRecorder *recorder.Recorder // set once the flags are parsed, before the service runs
+	record := func(
+		ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return callRecorder.UnaryServerInterceptor(ctx, req, info, handler)
+	}
+	var callLogger *reqlog.Logger // set once the flags are parsed, before the service runs
+	logCall := func(
+		ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return callLogger.UnaryServerInterceptor(ctx, req, info, handler)
+	}
+	srv, err := service.NewServiceWithOptions(
+		registry.ModuleName,
+		registry.AAA_SERVER,
+		grpc.UnaryInterceptor(
+			chainUnaryInterceptors(
+				panics.UnaryServerInterceptor,
+				logCall,
+				record,
+				apvendor.UnaryServerInterceptor,
+				deadlines.UnaryServerInterceptor,
+				schedule,
+				shed)))
 	if err != nil {
 		log.Fatalf("Error creating AAA service: %s", err)
 	}
+	if len(*debugAddr) > 0 {
+		debugCfg, err := debughttp.NewConfig(*debugAddr, *debugTokenFile, *debugAllowRemote)
+		if err == nil {
+			_, err = debughttp.Start(debugCfg, nil)
+		}
+		if err != nil {
+			log.Fatalf("Error starting debug HTTP endpoint: %v", err)
+		}
+		log.Printf("Debug HTTP endpoint on %s is enabled", *debugAddr)
+	}
 	aaaConfigs := &mconfig.AAAConfig{}
+	mconfigLoaded := time.Now()
 	err = managed_configs.GetServiceConfigs(AAAServiceName, aaaConfigs)
 	if err != nil {
 		log.Printf("Error getting AAA Server service configs: %s", err)
 		aaaConfigs = nil
 	}
+	// Layer env, settings file & mconfig settings over the flags not set on the command line
+	config := settings.New(AAASettingsEnvPrefix)
+	if len(*settingsFile) > 0 {
+		if err = config.AddFile(*settingsFile); err != nil {
+			log.Fatalf("Error loading AAA settings: %v", err)
+		}
+	}
+	config.AddMconfig(mconfigSettings(aaaConfigs))
+	if err = config.Apply(flag.CommandLine); err != nil {
+		log.Fatalf("AAA settings error: %v", err)
+	}
+	deadlines.SetDefaultTimeout(*defaultDeadline)
+	if callLogger, err = reqlog.NewProduction(*rpcLogLevel); err != nil {
+		log.Fatalf("Error creating AAA calls logger: %v", err)
+	}
+	if len(*dependencyBudgets) > 0 {
+		budgets, err := slo.ParseBudgets(*dependencyBudgets)
+		if err == nil {
+			err = slo.SetBudgets(budgets)
+		}
+		if err != nil {
+			log.Fatalf("Error loading dependency latency budgets: %v", err)
+		}
+		log.Printf("Dependency latency budgets %s are enabled", *dependencyBudgets)
+	}
+	if len(*apVendorOUIs) > 0 {
+		vendors, err := apvendor.ReadOUIs(*apVendorOUIs)
+		if err != nil {
+			log.Fatalf("Error loading AP vendors OUIs: %v", err)
+		}
+		if err = apvendor.SetOUIs(vendors); err != nil {
+			log.Fatalf("Invalid AP vendors OUIs: %v", err)
+		}
+		log.Printf("AP vendors OUIs %s are enabled", *apVendorOUIs)
+	}
+	if *acctMaxConcurrent > 0 {
+		cfg := shedding.DefaultConfig(*acctMaxConcurrent)
+		cfg.High.Size, cfg.High.Weight = *acctStopQueue, *acctStopWeight
+		cfg.Low.Size = *acctStartQueue
+		if shedder, err = shedding.New(cfg); err != nil {
+			log.Fatalf("Invalid accounting load shedding configuration: %v", err)
+		}
+		log.Printf("Accounting load shedding of over %d concurrent calls is enabled", *acctMaxConcurrent)
+	}
+	if *fairShareRate > 0 {
+		scheduler, err = fairshare.New(fairshare.Config{
+			Rate:      *fairShareRate,
+			Burst:     *fairShareBurst,
+			AuthShare: *fairShareAuth,
+			MaxWait:   *fairShareMaxWait,
+		})
+		if err != nil {
+			log.Fatalf("Invalid fair scheduling configuration: %v", err)
+		}
+		log.Printf("Fair scheduling of %v calls/s, %v reserved for authentications, is enabled",
+			*fairShareRate, *fairShareAuth)
+	}
+	if len(*panicBreadcrumbs) > 0 {
+		if err = panics.SetBreadcrumbsFile(*panicBreadcrumbs, *maxPanicBreadcrumbs); err != nil {
+			log.Fatalf("Error loading panic breadcrumbs: %v", err)
+		}
+		log.Printf("Panic breadcrumbs %s are enabled", *panicBreadcrumbs)
+	}
+	if len(*grpcRecording) > 0 {
+		cfg := recorder.Config{Path: *grpcRecording, MaxRecords: *grpcRecordingMax}
+		for _, m := range strings.Split(*grpcRecordingMethods, ",") {
+			if m = strings.TrimSpace(m); len(m) > 0 {
+				cfg.Methods = append(cfg.Methods, m)
+			}
+		}
+		if callRecorder, err = recorder.New(cfg); err != nil {
+			log.Fatalf("Error opening GRPC recording %s: %v", *grpcRecording, err)
+		}
+		defer callRecorder.Close()
+		log.Printf("GRPC calls recording into %s is enabled", *grpcRecording)
+	}
+
+	// Create a shared Session Table, the limits flags are parsed by NewServiceWithOptions
+	limits := store.Limits{
+		MaxSessions:   *maxSessions,
+		Policy:        store.PreemptionPolicy(*preemptionPolicy),
+		APNPriorities: parseAPNIntegers(*apnPriorities, "priority"),
+	}
+	var sessions aaa.SessionTable
+	switch *sessionTable {
+	case store.MemoryTable, "":
+		sessions, err = store.NewMemorySessionTableWithLimits(limits)
+	case store.RedisTable:
+		var redisStore object_store.ObjectMap
+		if redisStore, err = store.NewRedisSessionStore(); err != nil {
+			log.Fatalf("Error creating Redis session store: %v", err)
+		}
+		sessions, err = store.NewPersistentSessionTable(limits, redisStore)
+	default:
+		log.Fatalf("Unknown session table: '%s'", *sessionTable)
+	}
+	if err != nil {
+		log.Fatalf("Invalid session table limits: %v", err)
+	}
+	if *sessionTimeoutJitter > 0 || *sessionExpiryBatch > 0 || *sessionExpiryConcurrency > 0 {
+		sched, err := store.NewScheduler(store.SchedulerConfig{
+			Jitter:                *sessionTimeoutJitter,
+			BatchInterval:         *sessionExpiryBatch,
+			MaxConcurrentExpiries: *sessionExpiryConcurrency,
+		})
+		if err == nil {
+			err = store.SetScheduler(sessions, sched)
+		}
+		if err != nil {
+			log.Fatalf("Invalid session timeout scheduler: %v", err)
+		}
+		log.Printf("Session timeout jitter: %v, expiry batch interval: %v, expiry concurrency: %d",
+			*sessionTimeoutJitter, *sessionExpiryBatch, *sessionExpiryConcurrency)
+	}
+	if len(*sessionIDRules) > 0 {
+		normalize, err := store.ParseSIDRules(*sessionIDRules)
+		if err != nil {
+			log.Fatalf("Error loading session ID rules: %v", err)
+		}
+		normalizing, ok := sessions.(aaa.SIDNormalizing)
+		if !ok {
+			log.Fatalf("Session table %T doesn't support session ID rules", sessions)
+		}
+		if normalize != nil {
+			normalizing.SetSIDNormalizer(normalize)
+			log.Printf("Session ID rules %s are enabled", *sessionIDRules)
+		}
+	}
+	if err = aaa.AddStandardIndexes(sessions); err != nil {
+		log.Fatalf("Error indexing session table: %v", err)
+	}
+	go store.RunMaintenance(sessions, *maintenanceInterval, *metricsRetention)
+
+	if len(*sessionManagerRoutes) > 0 {
+		routing, err := session_manager.ReadRoutingConfig(*sessionManagerRoutes)
+		if err == nil {
+			err = session_manager.SetRouting(routing)
+		}
+		if err != nil {
+			log.Fatalf("Error loading session manager routing: %v", err)
+		}
+		log.Printf("Session manager routing %s is enabled", *sessionManagerRoutes)
+	}
+	if len(*sessionManagerRetry) > 0 {
+		session_manager.SetRetry(parseRetry(*sessionManagerRetry, "session manager"))
+	}
+	if len(*sessionManagerBreaker) > 0 {
+		var cfg retry.BreakerConfig
+		err := json.Unmarshal([]byte(*sessionManagerBreaker), &cfg)
+		if err == nil {
+			err = session_manager.SetBreaker(&cfg)
+		}
+		if err != nil {
+			log.Fatalf("Invalid session manager circuit breaker configuration: %v", err)
+		}
+		log.Printf("Session manager circuit breaker %s is enabled", *sessionManagerBreaker)
+	}
+	if len(*swxRetry) > 0 {
+		swx_proxy.SetRetry(parseRetry(*swxRetry, "SWx proxy"))
+	}
+	// Negotiate session manager capabilities in the background, sessiond may not be up yet
+	go session_manager.PrefetchCapabilities()
+
 	acct, _ := servicers.NewAccountingService(sessions, proto.Clone(aaaConfigs).(*mconfig.AAAConfig))
+	if restored, err := acct.RestoreSessions(); err != nil {
+		log.Printf("Error restoring persisted sessions: %v", err)
+	} else if restored > 0 {
+		log.Printf("Restored %d persisted sessions", restored)
+	}
+	if *anomalyDetection {
+		acct.SetAnomalyDetector(anomaly.NewDetector(anomaly.Config{
+			MinUplinkOctets:       *anomalyMinUplink,
+			MaxUplinkRatio:        *anomalyMaxRatio,
+			UplinkOnlyMaxDownlink: *anomalyUplinkOnlyMaxDownlink,
+			ConsecutiveIntervals:  *anomalyIntervals,
+			CoATrafficClasses:     *anomalyCoATrafficClasses,
+		}))
+		log.Print("Usage anomaly detection is enabled")
+	}
+	if *acctResponseDeadline > 0 {
+		acct.SetResponseDeadline(*acctResponseDeadline)
+		log.Printf("Early Accounting-Responses after %v are enabled", *acctResponseDeadline)
+	}
+	if len(*apnMapPath) > 0 {
+		apnMap, err := apnauth.ReadLocalMap(*apnMapPath)
+		if err != nil {
+			log.Fatalf("Error loading IMSI to APN map: %v", err)
+		}
+		acct.SetAPNAuthorizer(apnMap)
+		log.Printf("APN authorization using %s is enabled", *apnMapPath)
+	} else if *apnAuthSubscriberDB {
+		acct.SetAPNAuthorizer(apnauth.SubscriberDB{})
+		log.Print("APN authorization using subscriberdb is enabled")
+	}
+	if aaaConfigs.GetMacAuthBypass() {
+		acct.SetMACAllowList(mab.SubscriberDB{})
+		log.Print("MAC Authentication Bypass of subscriberdb's allow-listed devices is enabled")
+	}
+	var timePolicies []timepolicy.Config
+	if len(*timePolicyPath) > 0 {
+		cfg, err := timepolicy.LoadConfig(*timePolicyPath)
+		if err != nil {
+			log.Fatalf("Error loading time of day policy: %v", err)
+		}
+		timePolicies = append(timePolicies, cfg)
+		log.Printf("Time of day policy %s is enabled", *timePolicyPath)
+	}
+	if schedule := bandwidthSchedule(aaaConfigs); len(schedule.Windows) > 0 {
+		timePolicies = append(timePolicies, schedule)
+		log.Printf("Bandwidth schedule of %d windows is enabled", len(schedule.Windows))
+	}
+	if len(timePolicies) > 0 {
+		timePolicy, err := timepolicy.NewPolicy(timePolicies...)
+		if err != nil {
+			log.Fatalf("Error loading time of day policy: %v", err)
+		}
+		acct.SetTimePolicy(timePolicy)
+	}
+	var purged []retention.Target
+	if *auditLogEnabled || len(*auditLogPath) > 0 {
+		auditLog, target, err := newAuditLog()
+		if err != nil {
+			log.Fatalf("Error opening audit log: %v", err)
+		}
+		acct.AddAuditSink(auditLog)
+		if target != nil {
+			purged = append(purged, *target)
+		}
+	}
+	if len(*retentionTargets) > 0 {
+		targets, err := retention.ReadTargets(*retentionTargets)
+		if err != nil {
+			log.Fatalf("Error loading retention configuration: %v", err)
+		}
+		purged = append(purged, targets...)
+	}
+	if len(purged) > 0 {
+		go retention.Run(purged, *retentionInterval)
+	}
+	if len(*persistedCounters) > 0 {
+		if err = metrics.Persisted.Load(*persistedCounters); err != nil {
+			log.Fatalf("Error loading restart-safe counters: %v", err)
+		}
+		go metrics.Persisted.Run(*persistedCountersInterval)
+		log.Printf("Restart-safe counters %s are enabled", *persistedCounters)
+	}
+	if len(*acctQueuePath) > 0 {
+		queue := acctqueue.New(*acctQueueMax)
+		if err = queue.Load(*acctQueuePath); err != nil {
+			log.Fatalf("Error loading accounting queue: %v", err)
+		}
+		acct.SetAcctQueue(queue)
+		go acct.RunAcctQueue(*acctQueueReplayInterval)
+		log.Printf("Accounting queue %s of %d calls is enabled, %d calls are restored",
+			*acctQueuePath, *acctQueueMax, queue.Len())
+	}
+	if len(*eventsExportPath) > 0 {
+		exportCfg, err := export.ReadConfig(*eventsExportPath)
+		if err != nil {
+			log.Fatalf("Error loading events export configuration: %v", err)
+		}
+		exporters, err := export.NewExporters(exportCfg)
+		if err != nil {
+			log.Fatalf("Error creating events exporters: %v", err)
+		}
+		var exported audit.Sinks
+		for _, e := range exporters {
+			exported = append(exported, e)
+			log.Printf("Session events export to %s is enabled", e.Name())
+		}
+		if len(*enrichmentCallout) > 0 {
+			callout, err := enrich.NewCallout(*enrichmentCallout)
+			if err != nil {
+				log.Fatalf("Error creating enrichment callout: %v", err)
+			}
+			acct.AddAuditSink(enrich.New(callout, enrich.Config{
+				Timeout:  *enrichmentTimeout,
+				CacheTTL: *enrichmentCacheTTL,
+				Bypass:   *enrichmentBypass,
+			}, exported))
+			log.Printf("Exported events enrichment by %s is enabled", *enrichmentCallout)
+		} else {
+			acct.AddAuditSink(exported)
+		}
+	}
+	if *usageExportEnabled {
+		var collectors int
+		for _, collector := range strings.Split(*usageExportCollectors, ",") {
+			if collector = strings.TrimSpace(collector); len(collector) == 0 {
+				continue
+			}
+			exporter, err := usageexport.New(usageexport.Config{
+				Collector:         collector,
+				Format:            *usageExportFormat,
+				Transport:         *usageExportTransport,
+				BatchSize:         *usageExportBatchSize,
+				FlushInterval:     *usageExportFlushInterval,
+				TemplateRefresh:   *usageExportTemplateRefresh,
+				ObservationDomain: uint32(*usageExportDomain),
+			})
+			if err != nil {
+				log.Fatalf("Error creating usage records exporter: %v", err)
+			}
+			exporter.Start()
+			acct.AddAuditSink(exporter)
+			collectors++
+			log.Printf("Usage records export to %s is enabled", exporter.Name())
+		}
+		if collectors == 0 {
+			log.Fatalf("Usage records export requires usage_export_collectors")
+		}
+	}
+	if len(*acctProxyPath) > 0 {
+		proxyCfg, err := acctproxy.ReadConfig(*acctProxyPath)
+		if err != nil {
+			log.Fatalf("Error loading accounting proxy configuration: %v", err)
+		}
+		proxy, err := acctproxy.New(proxyCfg)
+		if err != nil {
+			log.Fatalf("Error creating accounting proxy: %v", err)
+		}
+		proxy.Start()
+		acct.SetAcctProxy(proxy)
+		log.Printf("Accounting mirroring to %s is enabled", strings.Join(proxy.Servers(), ", "))
+	}
+	if len(*apReportPath) > 0 {
+		reportCfg, err := apreport.ReadConfig(*apReportPath)
+		if err != nil {
+			log.Fatalf("Error loading AP capacity report configuration: %v", err)
+		}
+		reporter := apreport.NewReporter(*reportCfg)
+		acct.AddAuditSink(reporter)
+		go reporter.Run()
+		log.Printf("AP capacity reports every %d seconds are enabled", reportCfg.IntervalSec)
+	}
+	if len(*wholesaleSplitPath) > 0 {
+		splitCfg, err := wholesale.ReadConfig(*wholesaleSplitPath)
+		if err != nil {
+			log.Fatalf("Error loading wholesale partners configuration: %v", err)
+		}
+		split, err := wholesale.NewSplit(*splitCfg)
+		if err != nil {
+			log.Fatalf("Error creating wholesale partners usage split: %v", err)
+		}
+		acct.AddAuditSink(split)
+		log.Printf("Wholesale usage split of %d configured partners is enabled", len(splitCfg.Partners))
+	}
+	if len(*interimIntervals) > 0 {
+		intervals := map[string]uint32{}
+		for apn, interval := range parseAPNIntegers(*interimIntervals, "seconds") {
+			if interval < 0 {
+				log.Fatalf("Invalid Acct-Interim-Interval of APN %s: %d", apn, interval)
+			}
+			intervals[apn] = uint32(interval)
+		}
+		acct.SetAcctInterimIntervals(intervals)
+		log.Printf("Acct-Interim-Intervals %s are enabled", *interimIntervals)
+	}
+	if *prefetchSubscribers > 0 {
+		prefetcher := prefetch.New(*prefetchSubscribers, *prefetchMaxIdle, prefetch.SwxFetcher)
+		acct.SetPrefetcher(prefetcher)
+		go prefetcher.Run(*prefetchInterval)
+		log.Printf("Auth vectors prefetch of %d subscribers is enabled", *prefetchSubscribers)
+	}
+	if *directoryRecords || aaaConfigs.GetDirectoryRecords() {
+		publisher := directory.NewPublisher(directory.NewCloudClient())
+		acct.SetDirectory(publisher)
+		go publisher.Run()
+		log.Print("Directory records of the sessions are enabled")
+	}
+	if len(*policyHookURL) > 0 {
+		endpoint, err := policyhook.NewEndpoint(*policyHookURL)
+		if err != nil {
+			log.Fatalf("Error creating policy hook: %v", err)
+		}
+		acct.SetPolicyHook(policyhook.New(endpoint, *policyHookTimeout, *policyHookFailOpen))
+		log.Printf("Policy hook %s (fail open: %t) is enabled", *policyHookURL, *policyHookFailOpen)
+	}
+	if *macRateLimit > 0 || *imsiRateLimit > 0 {
+		acct.SetRateLimits(ratelimit.New(*macRateLimit, *macRateBurst), ratelimit.New(*imsiRateLimit, *imsiRateBurst))
+		log.Printf("Request rate limits per MAC address: %g/s (burst %d) & per IMSI: %g/s (burst %d) are enabled",
+			*macRateLimit, *macRateBurst, *imsiRateLimit, *imsiRateBurst)
+	}
+	acct.SetMaxUsageRate(*maxUsageRate * 1e6 / 8)
+	acct.SetHandoverWindow(*handoverWindow)
+	acct.SetTerminatedLinger(*terminatedLinger)
+	acct.SetDeviceHintTTL(*deviceHintTTL)
+	if *trafficCheckInterval > 0 {
+		go acct.MonitorTraffic(pipelined.GetSubscriberTraffic, *trafficCheckInterval)
+		log.Printf("Pipelined traffic inactivity checks every %v are enabled", *trafficCheckInterval)
+	}
+	if *trafficIdleTimeout > 0 {
+		acct.SetTrafficIdleTimeout(*trafficIdleTimeout)
+		log.Printf("Sessions without Interim-Update traffic time out after %v", *trafficIdleTimeout)
+	}
+	if *eventTimestampMaxSkew > 0 {
+		action, err := servicers.ParseEventTimestampAction(*eventTimestampAction)
+		if err != nil {
+			log.Fatalf("Invalid Event-Timestamp action: %v", err)
+		}
+		acct.SetEventTimestampPolicy(*eventTimestampMaxSkew, action)
+		log.Printf("Event-Timestamp max skew %v is enabled, skewed requests action: %s", *eventTimestampMaxSkew, action)
+	}
+	dupPolicy, err := servicers.ParseDuplicateIMSIPolicy(*duplicateIMSIPolicy)
+	if err != nil {
+		log.Fatalf("Invalid duplicate IMSI policy: %v", err)
+	}
+	acct.SetDuplicateIMSIPolicy(dupPolicy)
+	if *subscriberAggregation {
+		if dupPolicy != servicers.DuplicateIMSIPermit {
+			log.Fatalf("Subscriber usage aggregation requires the %s duplicate IMSI policy", servicers.DuplicateIMSIPermit)
+		}
+		var quotaHook aggregate.QuotaHook
+		if *subscriberQuotaOctets > 0 {
+			quotaHook = aggregate.MaxOctets(*subscriberQuotaOctets)
+		}
+		acct.SetSubscriberAggregation(quotaHook)
+		log.Printf("Subscriber usage aggregation (quota: %d octets) is enabled", *subscriberQuotaOctets)
+	}
+	if *attributesMaxBytes > 0 || *attributesSpillBytes > 0 {
+		var spillStore spill.Store
+		if *attributesSpillBytes > 0 {
+			if spillStore, err = spill.NewDirStore(*attributesSpillDir); err != nil {
+				log.Fatalf("Error creating session attributes spill store: %v", err)
+			}
+		}
+		limits := servicers.AttributeLimits{MaxBytes: *attributesMaxBytes, SpillBytes: *attributesSpillBytes}
+		if err = acct.SetAttributeLimits(limits, spillStore); err != nil {
+			log.Fatalf("Invalid session attribute limits: %v", err)
+		}
+		log.Printf("Session attribute limits %+v are enabled", limits)
+	}
+	if len(*staticRulesPath) > 0 {
+		staticRules, err := staticrules.ReadConfig(*staticRulesPath)
+		if err != nil {
+			log.Fatalf("Error loading static rules: %v", err)
+		}
+		acct.SetStaticRules(staticRules)
+		log.Printf("Static rules %s are enabled", *staticRulesPath)
+	}
+	if len(*chargingHintsPath) > 0 {
+		chargingHints, err := charging.ReadConfig(*chargingHintsPath)
+		if err != nil {
+			log.Fatalf("Error loading charging hints: %v", err)
+		}
+		acct.SetChargingHints(chargingHints)
+		log.Printf("Charging hints %s are enabled", *chargingHintsPath)
+	}
+	if len(*apnAliasesPath) > 0 {
+		aliases, err := apnalias.ReadConfig(*apnAliasesPath)
+		if err != nil {
+			log.Fatalf("Error loading APN aliases: %v", err)
+		}
+		acct.SetAPNAliases(aliases)
+		log.Printf("%d APN aliases of %s are enabled", len(aliases.Aliases), *apnAliasesPath)
+	}
+	if len(*quarantinePath) > 0 {
+		quarantineCfg, err := quarantine.ReadConfig(*quarantinePath)
+		if err != nil {
+			log.Fatalf("Error loading quarantine configuration: %v", err)
+		}
+		acct.SetQuarantine(quarantineCfg)
+		log.Printf("Security triggers quarantine %s is enabled", *quarantinePath)
+	}
+	if len(*failureModesPath) > 0 {
+		failureModesCfg, err := failuremode.ReadConfig(*failureModesPath)
+		if err != nil {
+			log.Fatalf("Error loading failure modes configuration: %v", err)
+		}
+		acct.SetFailureModes(failureModesCfg)
+		log.Printf("Gx/Gy failure modes %s are enabled", *failureModesPath)
+	}
+	if *guestMaxDuration > 0 {
+		guestCfg := &guest.Config{
+			APNs:        guest.ParseList(*guestAPNs),
+			Realms:      guest.ParseList(*guestRealms),
+			MaxDuration: *guestMaxDuration,
+		}
+		if err = guestCfg.Validate(); err != nil {
+			log.Fatalf("Invalid guest sessions configuration: %v", err)
+		}
+		acct.SetGuestSessions(guestCfg)
+		log.Printf("Guest sessions of APNs: '%s', realms: '%s' limited to %v are enabled",
+			*guestAPNs, *guestRealms, *guestMaxDuration)
+	}
+	if len(*hssProbeUser) > 0 {
+		prober, err := hssprobe.New(hssprobe.Config{
+			UserName:         *hssProbeUser,
+			Interval:         *hssProbeInterval,
+			Timeout:          *hssProbeTimeout,
+			FailureThreshold: *hssProbeFailures,
+		})
+		if err != nil {
+			log.Fatalf("Invalid HSS probe configuration: %v", err)
+		}
+		prober.Start()
+		acct.SetHSSProber(prober)
+		fegprotos.RegisterServiceHealthServer(srv.GrpcServer, prober)
+		log.Printf("HSS reachability probes every %v are enabled", *hssProbeInterval)
+	}
+	radiusCheck := health.RegistryCheck(registry.RADIUS)
+	if len(*radiusEndpoints) > 0 {
+		names, err := radiusauthz.ParseEndpoints(*radiusEndpoints)
+		if err != nil {
+			log.Fatalf("Invalid Radius server endpoints: %v", err)
+		}
+		endpoints, err := radiusauthz.New(names, *radiusCheckInterval)
+		if err != nil {
+			log.Fatalf("Invalid Radius server endpoints: %v", err)
+		}
+		endpoints.Start()
+		acct.SetRadiusEndpoints(endpoints)
+		radiusCheck = endpoints.Check
+		log.Printf("Radius server endpoints %v with failover are enabled", names)
+	}
+	if *acctReorderWindow > 0 {
+		acct.SetReorderWindow(*acctReorderWindow)
+		log.Printf("Accounting Stop/Start reordering within %v is enabled", *acctReorderWindow)
+	}
+	if len(*acctFaultIMSIs) > 0 {
+		imsis, err := acctfaults.ParseIMSIs(*acctFaultIMSIs)
+		if err != nil {
+			log.Fatalf("Invalid accounting fault injection IMSIs: %v", err)
+		}
+		acct.SetFaultInjector(acctfaults.New(imsis))
+		log.Printf("Accounting fault injection of synthetic subscribers %s is enabled", *acctFaultIMSIs)
+	}
+	if *coaLogSize > 0 {
+		acct.SetCoALog(coalog.New(*coaLogSize))
+		log.Printf("CoA transaction log of the latest %d requests is enabled", *coaLogSize)
+	}
+	if *sessionResync || aaaConfigs.GetResyncSessionsOnStartup() {
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), *sessionResyncTimeout)
+			defer cancel()
+			res, err := acct.ResyncSessions(ctx)
+			if err != nil {
+				log.Printf("Session resync is incomplete: %v", err)
+			}
+			log.Printf("Resynced sessions with session managers: %+v", res)
+		}()
+	}
 	protos.RegisterAccountingServer(srv.GrpcServer, acct)
+	describe := func() *protos.ServiceInfo {
+		return serviceinfo.Describe(registry.AAA_SERVER, config, srv.GrpcServer, eapMethods())
+	}
+	var adminAuth *adminauth.Authorizer
+	if len(*sessionAdminTokenFile) > 0 || len(*sessionAdminRoles) > 0 {
+		adminAuth, err = sessionAdminAuthorizer(*sessionAdminTokenFile, *sessionAdminRoles)
+		if err != nil {
+			log.Fatalf("Error loading session admin authorization: %v", err)
+		}
+		admin, err := servicers.NewSessionAdminService(acct, adminAuth, config, describe)
+		if err != nil {
+			log.Fatalf("Error creating session admin service: %v", err)
+		}
+		protos.RegisterSessionAdminServer(srv.GrpcServer, admin)
+		log.Print("Session admin API is enabled")
+	}
+
+	if len(*userDbPath) > 0 {
+		db, err := userdb.NewUserDB(*userDbPath)
+		if err != nil {
+			log.Fatalf("Error loading local users DB: %v", err)
+		}
+		udb, _ := servicers.NewUserDbService(db, adminAuth)
+		protos.RegisterUserDbServer(srv.GrpcServer, udb)
+		// the local users authenticate with EAP-GTC, an inner method only, it sends clear text passwords
+		eap_registry.RegisterInner(gtc.New(db))
+		log.Printf("Local users DB %s & inner EAP-GTC authentication are enabled", *userDbPath)
+		if adminAuth == nil {
+			log.Print("Local users DB management requires session admin authorization & is disabled")
+		}
+	}
+
+	if len(*eapTLSCert) > 0 {
+		tlsCfg := eap_tls.Config{ServerCert: *eapTLSCert, ServerKey: *eapTLSKey, CABundle: *eapTLSCA}
+		if len(*eapTLSIMSIMap) > 0 {
+			imsiMap, err := eap_tls.ReadIMSIMap(*eapTLSIMSIMap)
+			if err != nil {
+				log.Fatalf("Error loading EAP-TLS IMSI map: %v", err)
+			}
+			tlsCfg.MapIdentity = imsiMap.MapIdentity
+		}
+		tlsProvider, err := eap_tls.New(tlsCfg)
+		if err != nil {
+			log.Fatalf("Error creating EAP-TLS provider: %v", err)
+		}
+		// the provider must be registered before the authenticator lists the supported methods
+		eap_registry.Register(tlsProvider)
+		log.Printf("EAP-TLS authentication of %s CAs' certificates is enabled", *eapTLSCA)
+	}
 
 	auth, _ := servicers.NewEapAuthenticator(sessions, aaaConfigs, acct)
 	protos.RegisterAuthenticatorServer(srv.GrpcServer, auth)
 
+	if len(*alertRules) > 0 {
+		alertsCfg, err := alerting.ReadConfig(*alertRules)
+		if err != nil {
+			log.Fatalf("Error loading alerting rules: %v", err)
+		}
+		emitters := []alerting.Emitter{alerting.MetricsEmitter{}}
+		for _, url := range alertsCfg.Webhooks {
+			emitters = append(emitters, alerting.NewWebhookEmitter(url))
+		}
+		engine, err := alerting.NewEngine(alertsCfg, nil, emitters...)
+		if err != nil {
+			log.Fatalf("Error creating alerting engine: %v", err)
+		}
+		go engine.Run()
+		log.Printf("Local alerting with %d rules from %s is enabled", len(alertsCfg.Rules), *alertRules)
+	}
+
+	if len(*canaryPath) > 0 {
+		canaryCfg, err := canary.ReadConfig(*canaryPath)
+		if err != nil {
+			log.Fatalf("Error loading canary sessions configuration: %v", err)
+		}
+		if len(*userDbPath) == 0 {
+			log.Print("Canary sessions authenticate local users, they'll fail without local users DB")
+		}
+		inner, err := servicers.NewInnerAuthenticator(auth)
+		if err != nil {
+			log.Fatalf("Error creating canary sessions authenticator: %v", err)
+		}
+		canary.New(canaryCfg, canary.NewClient(inner)).Start()
+		log.Printf("%d canary sessions from %s are enabled", len(canaryCfg.Sessions), *canaryPath)
+	}
+
+	// Warm up downstream connections & don't start serving until required dependencies are reachable
+	err = readiness.WarmUp(
+		expandSessionManagers(readiness.ParseDependencies(*requiredDependencies, *optionalDependencies)),
+		*dependenciesTimeout)
+	if err != nil {
+		log.Fatalf("AAA service dependencies error: %v", err)
+	}
+
+	healthChecks := map[string]health.Check{
+		"sessiond": health.SessionManagerCheck(),
+		"radius":   radiusCheck,
+	}
+	if aaaConfigs != nil {
+		healthChecks["config"] = health.MconfigCheck(AAAServiceName, proto.Clone(aaaConfigs), mconfigLoaded)
+	}
+	checker, err := health.New(health.Config{
+		Interval:    *healthInterval,
+		Sessions:    sessions.(aaa.SessionCounter).Count,
+		MaxSessions: *maxSessions,
+		Checks:      healthChecks,
+	})
+	if err != nil {
+		log.Fatalf("Invalid health checks configuration: %v", err)
+	}
+	checker.Register(srv.GrpcServer)
+	checker.Start()
+	if len(*healthAddr) > 0 {
+		if err = checker.ServeHTTPEndpoint(*healthAddr); err != nil {
+			log.Fatalf("Error starting health HTTP endpoint: %v", err)
+		}
+		log.Printf("Health HTTP endpoint on %s is enabled", *healthAddr)
+	}
+	if len(*metricsAddr) > 0 || len(*metricsNetworkID) > 0 {
+		exporter, err := metricsexport.New(metricsexport.Config{
+			Service:      AAAServiceName,
+			Addr:         *metricsAddr,
+			NetworkID:    *metricsNetworkID,
+			GatewayID:    *metricsGatewayID,
+			PushInterval: *metricsPushInterval,
+		})
+		if err == nil {
+			err = exporter.Start()
+		}
+		if err != nil {
+			log.Fatalf("Error starting metrics exporter: %v", err)
+		}
+	}
+
+	log.Printf("AAA service info: %s", serviceinfo.Banner(describe()))
 	err = srv.Run()
+	if saveErr := metrics.Persisted.Save(); saveErr != nil {
+		log.Print(saveErr)
+	}
 	if err != nil {
 		log.Fatalf("Error running AAA service: %s", err)
 	}
 }
+
+// sessionAdminAuthorizer returns the session admin API authorizer of the roles file, if any, the token of the token
+// file, if any, is granted the admin role
+func sessionAdminAuthorizer(tokenFile, rolesFile string) (*adminauth.Authorizer, error) {
+	cfg := &adminauth.Config{}
+	if len(rolesFile) > 0 {
+		var err error
+		if cfg, err = adminauth.ReadConfig(rolesFile); err != nil {
+			return nil, err
+		}
+	}
+	if len(tokenFile) > 0 {
+		token, err := ioutil.ReadFile(tokenFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Tokens = append(cfg.Tokens,
+			adminauth.TokenGrant{Name: "admin", Token: strings.TrimSpace(string(token)), Role: adminauth.Admin})
+	}
+	return adminauth.New(cfg)
+}
+
+// newAuditLog returns the audit log of the flags' backends, filtered by the flags' event types, & the retention
+// target of its rotated files, if any
+func newAuditLog() (audit.Sink, *retention.Target, error) {
+	types, err := audit.ParseEventTypes(strings.Split(*auditLogEvents, ","))
+	if err != nil {
+		return nil, nil, err
+	}
+	var (
+		sinks  audit.Sinks
+		target *retention.Target
+	)
+	for _, backend := range strings.Split(*auditLogBackends, ",") {
+		switch backend = strings.ToLower(strings.TrimSpace(backend)); backend {
+		case "":
+		case audit.FileBackend:
+			path := *auditLogPath
+			if len(path) == 0 {
+				path = defaultAuditLogPath
+			}
+			auditFile, err := retention.OpenFile(path, *auditLogRotate)
+			if err != nil {
+				return nil, nil, err
+			}
+			sinks = append(sinks, audit.NewLogger(auditFile))
+			if *auditLogMaxAge > 0 || *auditLogMaxBytes > 0 {
+				target = &retention.Target{
+					Name:    "audit_log",
+					Pattern: auditFile.Segments(),
+					Policy:  retention.Policy{MaxAge: *auditLogMaxAge, MaxBytes: *auditLogMaxBytes},
+				}
+			}
+			log.Printf("Audit log file %s is enabled", path)
+		case audit.SyslogBackend:
+			syslogSink, err := audit.NewSyslogSink(audit.SyslogTag)
+			if err != nil {
+				return nil, nil, err
+			}
+			sinks = append(sinks, syslogSink)
+			log.Printf("Audit log to syslog is enabled")
+		case audit.ZapBackend:
+			zapSink, err := audit.NewProductionZapSink()
+			if err != nil {
+				return nil, nil, err
+			}
+			sinks = append(sinks, zapSink)
+			log.Printf("Audit log to zap logger is enabled")
+		default:
+			return nil, nil, fmt.Errorf("unknown audit log backend '%s'", backend)
+		}
+	}
+	if len(types) > 0 {
+		log.Printf("Audit log events are filtered to %s", *auditLogEvents)
+	}
+	return audit.NewFilter(sinks, types), target, nil
+}
+
+// mconfigSettings returns the AAA mconfig fields as settings, by their proto names
+func mconfigSettings(cfg *mconfig.AAAConfig) map[string]string {
+	if cfg == nil {
+		return nil
+	}
+	res := map[string]string{
+		"log_level":               cfg.GetLogLevel().String(),
+		"IdleSessionTimeoutMs":    strconv.FormatUint(uint64(cfg.GetIdleSessionTimeoutMs()), 10),
+		"AccountingEnabled":       strconv.FormatBool(cfg.GetAccountingEnabled()),
+		"CreateSessionOnAuth":     strconv.FormatBool(cfg.GetCreateSessionOnAuth()),
+		"ReportInterimUsage":      strconv.FormatBool(cfg.GetReportInterimUsage()),
+		"MacAuthBypass":           strconv.FormatBool(cfg.GetMacAuthBypass()),
+		"MaxSessionDurationMs":    strconv.FormatUint(uint64(cfg.GetMaxSessionDurationMs()), 10),
+		"DirectoryRecords":        strconv.FormatBool(cfg.GetDirectoryRecords()),
+		"ResyncSessionsOnStartup": strconv.FormatBool(cfg.GetResyncSessionsOnStartup()),
+	}
+	if cfg.GetAuditLogEnabled() {
+		res["audit_log_enabled"] = "true"
+		if len(cfg.GetAuditLogBackends()) > 0 {
+			res["audit_log_backends"] = strings.Join(cfg.GetAuditLogBackends(), ",")
+		}
+		res["audit_log_events"] = strings.Join(cfg.GetAuditLogEvents(), ",")
+	}
+	if cfg.GetUsageExportEnabled() {
+		res["usage_export_enabled"] = "true"
+		res["usage_export_collectors"] = strings.Join(cfg.GetUsageExportCollectors(), ",")
+		if len(cfg.GetUsageExportFormat()) > 0 {
+			res["usage_export_format"] = cfg.GetUsageExportFormat()
+		}
+		if len(cfg.GetUsageExportTransport()) > 0 {
+			res["usage_export_transport"] = cfg.GetUsageExportTransport()
+		}
+	}
+	if len(cfg.GetSessionTable()) > 0 {
+		res["session_table"] = cfg.GetSessionTable()
+	}
+	if len(cfg.GetCreateSessionFailurePolicy()) > 0 {
+		res["CreateSessionFailurePolicy"] = cfg.GetCreateSessionFailurePolicy()
+	}
+	if len(cfg.GetEapTlsServerCert()) > 0 {
+		res["eap_tls_cert"] = cfg.GetEapTlsServerCert()
+		res["eap_tls_key"] = cfg.GetEapTlsServerKey()
+		res["eap_tls_ca"] = cfg.GetEapTlsCaBundle()
+		res["eap_tls_imsi_map"] = cfg.GetEapTlsImsiMap()
+	}
+	for apn, max := range cfg.GetApnMaxSessions() {
+		res["ApnMaxSessions."+apn] = strconv.FormatUint(uint64(max), 10)
+	}
+	for apn, ms := range cfg.GetApnMaxSessionDurationMs() {
+		res["ApnMaxSessionDurationMs."+apn] = strconv.FormatUint(uint64(ms), 10)
+	}
+	for apn, override := range cfg.GetApnOverrides() {
+		res["ApnOverrides."+apn] = override.String()
+	}
+	for _, w := range cfg.GetBandwidthSchedule() {
+		res["BandwidthSchedule."+w.GetName()] = w.String()
+	}
+	if len(cfg.GetBandwidthSchedule()) > 0 {
+		res["BandwidthScheduleTimezone"] = cfg.GetBandwidthScheduleTimezone()
+		res["BaseBandwidthUp"] = strconv.FormatUint(uint64(cfg.GetBaseBandwidthUp()), 10)
+		res["BaseBandwidthDown"] = strconv.FormatUint(uint64(cfg.GetBaseBandwidthDown()), 10)
+	}
+	return res
+}
+
+// bandwidthSchedule returns the time policy of the mconfig's bandwidth schedule, its windows rate limit sessions
+func bandwidthSchedule(cfg *mconfig.AAAConfig) timepolicy.Config {
+	res := timepolicy.Config{
+		Timezone:          cfg.GetBandwidthScheduleTimezone(),
+		BaseBandwidthUp:   cfg.GetBaseBandwidthUp(),
+		BaseBandwidthDown: cfg.GetBaseBandwidthDown(),
+	}
+	for _, w := range cfg.GetBandwidthSchedule() {
+		res.Windows = append(res.Windows, timepolicy.Window{
+			Name:             w.GetName(),
+			APNs:             w.GetApns(),
+			Days:             w.GetDays(),
+			Start:            w.GetStart(),
+			End:              w.GetEnd(),
+			Action:           timepolicy.RateLimit,
+			MaxBandwidthUp:   w.GetMaxBandwidthUp(),
+			MaxBandwidthDown: w.GetMaxBandwidthDown(),
+		})
+	}
+	return res
+}
+
+// expandSessionManagers replaces the SESSIOND dependency with all routed session managers
+func expandSessionManagers(deps []readiness.Dependency) []readiness.Dependency {
+	var res []readiness.Dependency
+	for _, d := range deps {
+		if d.Service != registry.SESSION_MANAGER {
+			res = append(res, d)
+			continue
+		}
+		for _, service := range session_manager.Services() {
+			res = append(res, readiness.Dependency{Service: service, Required: d.Required})
+		}
+	}
+	return res
+}
+
+// parseAPNIntegers parses comma separated APN:<integer> list, e.g. APN:priority
+// parseRetry parses the JSON retry configuration of the named client's calls
+func parseRetry(config, client string) *retry.Retrier {
+	var cfg retry.Config
+	if err := json.Unmarshal([]byte(config), &cfg); err != nil {
+		log.Fatalf("Invalid %s retry configuration: %v", client, err)
+	}
+	retrier, err := retry.New(cfg)
+	if err != nil {
+		log.Fatalf("Invalid %s retry configuration: %v", client, err)
+	}
+	log.Printf("Retry of %s calls %s is enabled", client, config)
+	return retrier
+}
+
+func parseAPNIntegers(list, name string) map[string]int {
+	values := map[string]int{}
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if len(entry) == 0 {
+			continue
+		}
+		idx := strings.LastIndex(entry, ":")
+		if idx < 0 {
+			log.Fatalf("Invalid APN %s '%s', expected APN:%s", name, entry, name)
+		}
+		value, err := strconv.Atoi(entry[idx+1:])
+		if err != nil {
+			log.Fatalf("Invalid APN %s '%s': %v", name, entry, err)
+		}
+		values[entry[:idx]] = value
+	}
+	return values
+}
+
+// chainUnaryInterceptors returns an interceptor calling the given interceptors in order, the first one is the
+// outermost
+func chainUnaryInterceptors(interceptors ...grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+
+		chained := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, next := interceptors[i], chained
+			chained = func(ctx context.Context, req interface{}) (interface{}, error) {
+				return interceptor(ctx, req, info, next)
+			}
+		}
+		return chained(ctx, req)
+	}
+}
+
+// eapMethods returns the names of the registered EAP method providers, ordered by their EAP types
+func eapMethods() []string {
+	var res []string
+	for _, typ := range eap_registry.SupportedTypes() {
+		if p := eap_registry.GetProvider(typ); p != nil {
+			res = append(res, p.String())
+		}
+	}
+	return res
+}
@@ -0,0 +1,167 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package acctfaults simulates accounting faults of synthetic test subscribers' sessions: missing Interim-Updates,
+// delayed Stops & clock skew of the sessions' audited events, so operators can validate their billing pipeline's
+// tolerance of the faults before enabling a new AP vendor. Faults are injected by the session admin API, only for
+// subscribers of the injector's allow list
+package acctfaults
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Maximum injected delays, so forgotten faults don't hold Stops or skew events indefinitely
+const (
+	MaxStopDelay = time.Hour
+	MaxClockSkew = 24 * time.Hour
+)
+
+// Faults - simulated accounting faults of a subscriber's sessions
+type Faults struct {
+	DropInterims uint32        // number of the next Interim-Updates acknowledged, but not accounted
+	StopDelay    time.Duration // delay of accounting the acknowledged Stops
+	ClockSkew    time.Duration // offset of the audited events' times, may be negative
+}
+
+// IsZero returns true if the faults inject nothing
+func (f Faults) IsZero() bool {
+	return f == Faults{}
+}
+
+// Validate returns an error if a delay is negative or over its maximum
+func (f Faults) Validate() error {
+	if f.StopDelay < 0 || f.StopDelay > MaxStopDelay {
+		return fmt.Errorf("stop delay %v is not within [0, %v]", f.StopDelay, MaxStopDelay)
+	}
+	if f.ClockSkew < -MaxClockSkew || f.ClockSkew > MaxClockSkew {
+		return fmt.Errorf("clock skew %v is not within [-%v, %v]", f.ClockSkew, MaxClockSkew, MaxClockSkew)
+	}
+	return nil
+}
+
+// Injector - the injected faults of the allowed synthetic subscribers, a nil Injector injects no faults
+type Injector struct {
+	allowed map[string]bool
+
+	mu     sync.Mutex
+	faults map[string]Faults // by IMSI
+}
+
+// ParseIMSIs parses comma separated list of IMSIs, with or without the IMSI prefix
+func ParseIMSIs(list string) ([]string, error) {
+	var res []string
+	for _, imsi := range strings.Split(list, ",") {
+		imsi = strings.TrimPrefix(strings.TrimSpace(imsi), "IMSI")
+		if len(imsi) == 0 {
+			continue
+		}
+		if len(imsi) < 5 || len(imsi) > 15 || strings.Trim(imsi, "0123456789") != "" {
+			return nil, fmt.Errorf("invalid IMSI '%s'", imsi)
+		}
+		res = append(res, imsi)
+	}
+	if len(res) == 0 {
+		return nil, fmt.Errorf("no IMSIs")
+	}
+	return res, nil
+}
+
+// New returns an injector of the synthetic subscribers' faults, faults of other subscribers can't be injected
+func New(imsis []string) *Injector {
+	allowed := map[string]bool{}
+	for _, imsi := range imsis {
+		allowed[imsi] = true
+	}
+	return &Injector{allowed: allowed, faults: map[string]Faults{}}
+}
+
+// Set sets the faults of the subscriber's sessions, zero faults stop the subscriber's fault injection
+func (i *Injector) Set(imsi string, f Faults) error {
+	if i == nil {
+		return fmt.Errorf("fault injection is disabled")
+	}
+	imsi = strings.TrimPrefix(imsi, "IMSI")
+	if !i.allowed[imsi] {
+		return fmt.Errorf("IMSI %s is not a synthetic test subscriber", imsi)
+	}
+	if err := f.Validate(); err != nil {
+		return err
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if f.IsZero() {
+		delete(i.faults, imsi)
+	} else {
+		i.faults[imsi] = f
+	}
+	return nil
+}
+
+// Faults returns the injected faults by IMSIs, ordered by IMSIs
+func (i *Injector) Faults() ([]string, []Faults) {
+	if i == nil {
+		return nil, nil
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	imsis := make([]string, 0, len(i.faults))
+	for imsi := range i.faults {
+		imsis = append(imsis, imsi)
+	}
+	sort.Strings(imsis)
+	res := make([]Faults, len(imsis))
+	for n, imsi := range imsis {
+		res[n] = i.faults[imsi]
+	}
+	return imsis, res
+}
+
+// DropInterim returns true if the subscriber's Interim-Update should be dropped & counts the dropped Interim-Update
+func (i *Injector) DropInterim(imsi string) bool {
+	if i == nil {
+		return false
+	}
+	imsi = strings.TrimPrefix(imsi, "IMSI")
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	f, ok := i.faults[imsi]
+	if !ok || f.DropInterims == 0 {
+		return false
+	}
+	f.DropInterims--
+	if f.IsZero() {
+		delete(i.faults, imsi)
+	} else {
+		i.faults[imsi] = f
+	}
+	return true
+}
+
+// StopDelay returns the delay of accounting the subscriber's Stops, 0 - not delayed
+func (i *Injector) StopDelay(imsi string) time.Duration {
+	return i.get(imsi).StopDelay
+}
+
+// ClockSkew returns the offset of the subscriber's audited events' times
+func (i *Injector) ClockSkew(imsi string) time.Duration {
+	return i.get(imsi).ClockSkew
+}
+
+func (i *Injector) get(imsi string) Faults {
+	if i == nil {
+		return Faults{}
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.faults[strings.TrimPrefix(imsi, "IMSI")]
+}
@@ -0,0 +1,57 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package acctfaults
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseIMSIs(t *testing.T) {
+	imsis, err := ParseIMSIs(" 001010000000001, IMSI001010000000002,")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"001010000000001", "001010000000002"}, imsis)
+	for _, list := range []string{"", " , ", "00101000000000x", "1234", "0010100000000011"} {
+		_, err = ParseIMSIs(list)
+		assert.Error(t, err, list)
+	}
+}
+
+func TestInjector(t *testing.T) {
+	inj := New([]string{"001010000000001"})
+	assert.Error(t, inj.Set("001010000000002", Faults{DropInterims: 1}), "not a synthetic subscriber")
+	assert.Error(t, inj.Set("001010000000001", Faults{StopDelay: 2 * time.Hour}))
+	assert.Error(t, inj.Set("001010000000001", Faults{ClockSkew: -25 * time.Hour}))
+
+	assert.NoError(t, inj.Set("IMSI001010000000001", Faults{DropInterims: 2, ClockSkew: -time.Minute}))
+	assert.False(t, inj.DropInterim("001010000000002"))
+	assert.True(t, inj.DropInterim("IMSI001010000000001"))
+	assert.True(t, inj.DropInterim("001010000000001"))
+	assert.False(t, inj.DropInterim("001010000000001"), "only the next 2 Interim-Updates are dropped")
+	assert.Equal(t, -time.Minute, inj.ClockSkew("001010000000001"))
+	assert.Zero(t, inj.StopDelay("001010000000001"))
+	imsis, faults := inj.Faults()
+	assert.Equal(t, []string{"001010000000001"}, imsis)
+	assert.Equal(t, []Faults{{ClockSkew: -time.Minute}}, faults)
+
+	assert.NoError(t, inj.Set("001010000000001", Faults{}))
+	assert.Zero(t, inj.ClockSkew("001010000000001"))
+	imsis, _ = inj.Faults()
+	assert.Empty(t, imsis)
+}
+
+func TestNilInjector(t *testing.T) {
+	var inj *Injector
+	assert.Error(t, inj.Set("001010000000001", Faults{DropInterims: 1}))
+	assert.False(t, inj.DropInterim("001010000000001"))
+	assert.Zero(t, inj.StopDelay("001010000000001"))
+	assert.Zero(t, inj.ClockSkew("001010000000001"))
+}
@@ -0,0 +1,379 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package acctproxy mirrors the sessions' accounting to upstream (e.g. legacy) RADIUS accounting servers, so
+// operators keep their existing accounting records while AAA manages the sessions (dual accounting). Requests are
+// queued per server without blocking accounting & sent in order, unanswered requests are retransmitted
+package acctproxy
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"fbc/lib/go/radius"
+	"fbc/lib/go/radius/rfc2865"
+	"fbc/lib/go/radius/rfc2866"
+	"fbc/lib/go/radius/rfc2869"
+	"golang.org/x/net/context"
+
+	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+// Defaults
+const (
+	DefaultPort        = "1813"
+	DefaultTimeoutMs   = 3000
+	DefaultRetransmits = 2
+	DefaultQueueSize   = 10000
+)
+
+// Proxied requests' results
+const (
+	resultAcknowledged = "acknowledged"
+	resultFailed       = "failed"
+	resultDropped      = "dropped"
+)
+
+// ServerConfig - upstream RADIUS accounting server
+type ServerConfig struct {
+	Name        string `json:"name"`        // identifies the server in logs & metrics, defaults to its address
+	Address     string `json:"address"`     // host:port, the port defaults to 1813
+	Secret      string `json:"secret"`      // RADIUS shared secret
+	TimeoutMs   int    `json:"timeout_ms"`  // Accounting-Response timeout of each transmission
+	Retransmits int    `json:"retransmits"` // retransmissions of unanswered requests, 0 - none
+	QueueSize   int    `json:"queue_size"`  // max queued requests, new requests are dropped if full
+}
+
+// UnmarshalJSON applies the defaults of the server's omitted settings
+func (c *ServerConfig) UnmarshalJSON(b []byte) error {
+	type plain ServerConfig
+	p := plain{TimeoutMs: DefaultTimeoutMs, Retransmits: DefaultRetransmits, QueueSize: DefaultQueueSize}
+	if err := json.Unmarshal(b, &p); err != nil {
+		return err
+	}
+	*c = ServerConfig(p)
+	return nil
+}
+
+// Validate returns an error if the server's configuration is invalid
+func (c ServerConfig) Validate() error {
+	if len(c.Address) == 0 {
+		return fmt.Errorf("missing server address")
+	}
+	if len(c.Secret) == 0 {
+		return fmt.Errorf("missing shared secret of %s", c.Address)
+	}
+	if c.TimeoutMs <= 0 || c.Retransmits < 0 || c.QueueSize <= 0 {
+		return fmt.Errorf("invalid timeout, retransmits or queue size of %s", c.Address)
+	}
+	return nil
+}
+
+// Config - upstream accounting servers, every request is sent to each of them
+type Config struct {
+	// NASIdentifier - NAS-Identifier of the requests of NASes which didn't identify themselves
+	NASIdentifier string         `json:"nas_identifier,omitempty"`
+	Servers       []ServerConfig `json:"servers"`
+}
+
+// ReadConfig reads & validates JSON accounting proxy configuration from the given file
+func ReadConfig(path string) (*Config, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &Config{}
+	if err = json.Unmarshal(b, cfg); err != nil {
+		return nil, fmt.Errorf("Invalid accounting proxy configuration %s: %v", path, err)
+	}
+	if err = cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("Invalid accounting proxy configuration %s: %v", path, err)
+	}
+	return cfg, nil
+}
+
+// Validate returns an error if no servers are configured or any of them is invalid
+func (cfg *Config) Validate() error {
+	if len(cfg.Servers) == 0 {
+		return fmt.Errorf("no upstream accounting servers are configured")
+	}
+	names := map[string]bool{}
+	for _, s := range cfg.Servers {
+		if err := s.Validate(); err != nil {
+			return err
+		}
+		name := serverName(s)
+		if names[name] {
+			return fmt.Errorf("duplicate upstream accounting server %s", name)
+		}
+		names[name] = true
+	}
+	return nil
+}
+
+func serverName(c ServerConfig) string {
+	if len(c.Name) > 0 {
+		return c.Name
+	}
+	return c.Address
+}
+
+// Request - accounting request mirrored to the upstream servers
+type Request struct {
+	Status           rfc2866.AcctStatusType
+	SessionID        string
+	MultiSessionID   string
+	UserName         string
+	CalledStationID  string
+	CallingStationID string
+	FramedIPAddress  net.IP
+	NASIdentifier    string
+	NASIPAddress     net.IP
+	EventTime        time.Time
+	// usage & duration (seconds) of Interim-Updates & Stops
+	OctetsIn, OctetsOut   uint64
+	PacketsIn, PacketsOut uint32
+	SessionTime           uint32
+	TerminateCause        rfc2866.AcctTerminateCause // of Stops
+
+	queued time.Time
+}
+
+// NewRequest returns the accounting request of the session's context. The User-Name is the subscriber's identity,
+// or IMSI if the identity isn't known, the NAS attributes are of the NAS which sent the session's latest request
+func NewRequest(status rfc2866.AcctStatusType, aaaCtx *protos.Context) *Request {
+	req := &Request{
+		Status:           status,
+		SessionID:        aaaCtx.GetSessionId(),
+		UserName:         aaaCtx.GetIdentity(),
+		CalledStationID:  aaaCtx.CalledStationID(),
+		CallingStationID: aaaCtx.GetMacAddr(),
+		FramedIPAddress:  net.ParseIP(aaaCtx.GetIpAddr()).To4(),
+		EventTime:        time.Now(),
+	}
+	if len(req.UserName) == 0 {
+		req.UserName = aaaCtx.GetImsi()
+	}
+	req.MultiSessionID, _ = aaaCtx.GetAttribute(protos.MultiSessionIDAttribute)
+	req.NASIdentifier, _ = aaaCtx.GetAttribute(protos.NASIdentifierAttribute)
+	if addr, ok := aaaCtx.GetAttribute(protos.NASAddressAttribute); ok {
+		if host, _, err := net.SplitHostPort(addr); err == nil {
+			req.NASIPAddress = net.ParseIP(host).To4()
+		}
+	}
+	if eventTime, ok := aaaCtx.GetUintAttribute(protos.EventTimeAttribute); ok && eventTime > 0 {
+		req.EventTime = time.Unix(int64(eventTime), 0)
+	}
+	return req
+}
+
+// packet returns the request's Accounting-Request packet, delay is its Acct-Delay-Time
+func (req *Request) packet(secret []byte, nasIdentifier string, delay time.Duration) (*radius.Packet, error) {
+	p := radius.New(radius.CodeAccountingRequest, secret)
+	rfc2866.AcctStatusType_Set(p, req.Status)
+	if err := rfc2866.AcctSessionID_SetString(p, req.SessionID); err != nil {
+		return nil, err
+	}
+	if len(req.NASIdentifier) > 0 {
+		nasIdentifier = req.NASIdentifier
+	}
+	for _, attr := range []struct {
+		value string
+		set   func(*radius.Packet, string) error
+	}{
+		{req.MultiSessionID, rfc2866.AcctMultiSessionID_SetString},
+		{req.UserName, rfc2865.UserName_SetString},
+		{req.CalledStationID, rfc2865.CalledStationID_SetString},
+		{req.CallingStationID, rfc2865.CallingStationID_SetString},
+		{nasIdentifier, rfc2865.NASIdentifier_SetString},
+	} {
+		if len(attr.value) > 0 {
+			if err := attr.set(p, attr.value); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if req.FramedIPAddress != nil {
+		if err := rfc2865.FramedIPAddress_Set(p, req.FramedIPAddress); err != nil {
+			return nil, err
+		}
+	}
+	if req.NASIPAddress != nil {
+		if err := rfc2865.NASIPAddress_Set(p, req.NASIPAddress); err != nil {
+			return nil, err
+		}
+	}
+	if err := rfc2869.EventTimestamp_Set(p, req.EventTime); err != nil {
+		return nil, err
+	}
+	rfc2866.AcctDelayTime_Set(p, rfc2866.AcctDelayTime(delay/time.Second))
+	if req.Status == rfc2866.AcctStatusType_Value_Start {
+		return p, nil
+	}
+	rfc2866.AcctInputOctets_Set(p, rfc2866.AcctInputOctets(req.OctetsIn))
+	rfc2869.AcctInputGigawords_Set(p, rfc2869.AcctInputGigawords(req.OctetsIn>>32))
+	rfc2866.AcctOutputOctets_Set(p, rfc2866.AcctOutputOctets(req.OctetsOut))
+	rfc2869.AcctOutputGigawords_Set(p, rfc2869.AcctOutputGigawords(req.OctetsOut>>32))
+	rfc2866.AcctInputPackets_Set(p, rfc2866.AcctInputPackets(req.PacketsIn))
+	rfc2866.AcctOutputPackets_Set(p, rfc2866.AcctOutputPackets(req.PacketsOut))
+	rfc2866.AcctSessionTime_Set(p, rfc2866.AcctSessionTime(req.SessionTime))
+	if req.Status == rfc2866.AcctStatusType_Value_Stop && req.TerminateCause != 0 {
+		rfc2866.AcctTerminateCause_Set(p, req.TerminateCause)
+	}
+	return p, nil
+}
+
+// Proxy forwards accounting requests to the upstream servers
+type Proxy struct {
+	nasIdentifier string
+	upstreams     []*upstream
+}
+
+// upstream sends the queued requests to its server from a single goroutine, so the server gets a session's requests
+// in order
+type upstream struct {
+	name        string
+	address     string
+	secret      []byte
+	timeout     time.Duration
+	retransmits int
+	client      *radius.Client
+	queue       chan *Request
+	done        chan struct{}
+	stopOnce    sync.Once
+	stopped     chan struct{}
+}
+
+// New returns a new, not started Proxy of the validated configuration
+func New(cfg *Config) (*Proxy, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	p := &Proxy{nasIdentifier: cfg.NASIdentifier}
+	for _, s := range cfg.Servers {
+		address := s.Address
+		if _, _, err := net.SplitHostPort(address); err != nil {
+			address = net.JoinHostPort(address, DefaultPort)
+		}
+		p.upstreams = append(p.upstreams, &upstream{
+			name:        serverName(s),
+			address:     address,
+			secret:      []byte(s.Secret),
+			timeout:     time.Duration(s.TimeoutMs) * time.Millisecond,
+			retransmits: s.Retransmits,
+			client:      &radius.Client{},
+			queue:       make(chan *Request, s.QueueSize),
+			done:        make(chan struct{}),
+			stopped:     make(chan struct{}),
+		})
+	}
+	return p, nil
+}
+
+// Servers returns the names of the upstream servers
+func (p *Proxy) Servers() []string {
+	var names []string
+	for _, u := range p.upstreams {
+		names = append(names, u.name)
+	}
+	return names
+}
+
+// Start starts forwarding of the requests to the upstream servers
+func (p *Proxy) Start() {
+	for _, u := range p.upstreams {
+		go u.run(p.nasIdentifier)
+	}
+}
+
+// Stop stops forwarding after the requests being sent, requests still queued aren't sent
+func (p *Proxy) Stop() {
+	for _, u := range p.upstreams {
+		u.stopOnce.Do(func() { close(u.done) })
+		<-u.stopped
+	}
+}
+
+// Forward queues the request for each upstream server. Requests which don't fit into a server's queue are dropped
+// & counted, the accounting is never blocked by a slow or unreachable server
+func (p *Proxy) Forward(req *Request) {
+	if p == nil || req == nil {
+		return
+	}
+	req.queued = time.Now()
+	for _, u := range p.upstreams {
+		select {
+		case u.queue <- req:
+		default:
+			metrics.ProxiedAcct.WithLabelValues(u.name, statusLabel(req.Status), resultDropped).Inc()
+		}
+	}
+}
+
+func (u *upstream) run(nasIdentifier string) {
+	defer close(u.stopped)
+	for {
+		select {
+		case req := <-u.queue:
+			u.send(req, nasIdentifier)
+		case <-u.done:
+			return
+		}
+	}
+}
+
+// send sends the request until the server acknowledges it or its retransmissions are exhausted. Each transmission
+// is a new packet with the request's current Acct-Delay-Time (RFC 2866 5.2)
+func (u *upstream) send(req *Request, nasIdentifier string) {
+	status := statusLabel(req.Status)
+	var err error
+	for try := 0; try <= u.retransmits; try++ {
+		if try > 0 {
+			metrics.ProxiedAcctRetransmits.WithLabelValues(u.name).Inc()
+		}
+		var p *radius.Packet
+		if p, err = req.packet(u.secret, nasIdentifier, time.Since(req.queued)); err != nil {
+			break // an invalid request can't be sent
+		}
+		if err = u.exchange(p); err == nil {
+			metrics.ProxiedAcct.WithLabelValues(u.name, status, resultAcknowledged).Inc()
+			return
+		}
+	}
+	metrics.ProxiedAcct.WithLabelValues(u.name, status, resultFailed).Inc()
+	log.Printf("Error proxying Accounting %s of session %s to %s: %v", status, req.SessionID, u.name, err)
+}
+
+func (u *upstream) exchange(p *radius.Packet) error {
+	ctx, cancel := context.WithTimeout(context.Background(), u.timeout)
+	defer cancel()
+	res, err := u.client.Exchange(ctx, p, u.address)
+	if err != nil {
+		return err
+	}
+	if res.Code != radius.CodeAccountingResponse {
+		return fmt.Errorf("unexpected %s response", res.Code)
+	}
+	return nil
+}
+
+// statusLabel returns the metrics label of the Acct-Status-Type, e.g. interim_update
+func statusLabel(status rfc2866.AcctStatusType) string {
+	label, ok := rfc2866.AcctStatusType_Strings[status]
+	if !ok {
+		return "unknown"
+	}
+	return strings.ToLower(strings.Replace(label, "-", "_", -1))
+}
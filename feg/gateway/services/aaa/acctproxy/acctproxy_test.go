@@ -0,0 +1,159 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package acctproxy
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"fbc/lib/go/radius"
+	"fbc/lib/go/radius/rfc2865"
+	"fbc/lib/go/radius/rfc2866"
+	"fbc/lib/go/radius/rfc2869"
+	"github.com/stretchr/testify/assert"
+
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+const secret = "s3cr3t"
+
+// upstreamServer returns the address of a test accounting server sending the received requests to the channel, the
+// server answers the requests only if answer is true
+func upstreamServer(t *testing.T, answer bool) (string, chan *radius.Packet, func()) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	received := make(chan *radius.Packet, 10)
+	server := &radius.PacketServer{
+		SecretSource: radius.StaticSecretSource([]byte(secret)),
+		Handler: radius.HandlerFunc(func(w radius.ResponseWriter, r *radius.Request) {
+			received <- r.Packet
+			if answer {
+				w.Write(r.Response(radius.CodeAccountingResponse))
+			}
+		}),
+	}
+	go server.Serve(conn)
+	return conn.LocalAddr().String(), received, func() { conn.Close() }
+}
+
+func TestReadConfig(t *testing.T) {
+	f, err := ioutil.TempFile("", "acctproxy")
+	assert.NoError(t, err)
+	defer os.Remove(f.Name())
+	_, err = f.WriteString(`{"servers": [
+		{"name": "legacy", "address": "10.0.0.1", "secret": "s1"},
+		{"address": "10.0.0.2:1646", "secret": "s2", "timeout_ms": 500, "retransmits": 0, "queue_size": 10}]}`)
+	assert.NoError(t, err)
+	f.Close()
+
+	cfg, err := ReadConfig(f.Name())
+	assert.NoError(t, err)
+	assert.Equal(t, []ServerConfig{
+		{Name: "legacy", Address: "10.0.0.1", Secret: "s1",
+			TimeoutMs: DefaultTimeoutMs, Retransmits: DefaultRetransmits, QueueSize: DefaultQueueSize},
+		{Address: "10.0.0.2:1646", Secret: "s2", TimeoutMs: 500, Retransmits: 0, QueueSize: 10},
+	}, cfg.Servers)
+	p, err := New(cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"legacy", "10.0.0.2:1646"}, p.Servers())
+	assert.Equal(t, "10.0.0.1:1813", p.upstreams[0].address)
+
+	for _, invalid := range []*Config{
+		{},
+		{Servers: []ServerConfig{{Address: "10.0.0.1", TimeoutMs: 1, QueueSize: 1}}},
+		{Servers: []ServerConfig{{Address: "10.0.0.1", Secret: "s", QueueSize: 1}}},
+		{Servers: []ServerConfig{
+			{Address: "10.0.0.1", Secret: "s", TimeoutMs: 1, QueueSize: 1},
+			{Address: "10.0.0.1", Secret: "s", TimeoutMs: 1, QueueSize: 1}}},
+	} {
+		_, err = New(invalid)
+		assert.Error(t, err)
+	}
+}
+
+func TestForward(t *testing.T) {
+	addr, received, closeServer := upstreamServer(t, true)
+	defer closeServer()
+	p, err := New(&Config{NASIdentifier: "magma-aaa", Servers: []ServerConfig{
+		{Address: addr, Secret: secret, TimeoutMs: 1000, QueueSize: 10}}})
+	assert.NoError(t, err)
+	p.Start()
+	defer p.Stop()
+
+	aaaCtx := &protos.Context{
+		SessionId:  "sid1",
+		Imsi:       "001010000000001",
+		Apn:        "98-DE-D0-84-B5-47:CWF-TP-LINK_B547_5G",
+		MacAddr:    "5c:a1:76:8b:ab:f2",
+		IpAddr:     "10.10.0.5",
+		Attributes: map[string]string{protos.NASAddressAttribute: "192.168.1.1:3799"},
+	}
+	req := NewRequest(rfc2866.AcctStatusType_Value_Stop, aaaCtx)
+	req.OctetsIn, req.OctetsOut, req.SessionTime = 5<<32+100, 200, 60
+	req.TerminateCause = rfc2866.AcctTerminateCause_Value_IdleTimeout
+	p.Forward(req)
+
+	select {
+	case pkt := <-received:
+		assert.Equal(t, rfc2866.AcctStatusType_Value_Stop, rfc2866.AcctStatusType_Get(pkt))
+		assert.Equal(t, "sid1", rfc2866.AcctSessionID_GetString(pkt))
+		assert.Equal(t, "001010000000001", rfc2865.UserName_GetString(pkt), "User-Name defaults to IMSI")
+		assert.Equal(t, aaaCtx.GetApn(), rfc2865.CalledStationID_GetString(pkt))
+		assert.Equal(t, aaaCtx.GetMacAddr(), rfc2865.CallingStationID_GetString(pkt))
+		assert.Equal(t, "10.10.0.5", rfc2865.FramedIPAddress_Get(pkt).String())
+		assert.Equal(t, "192.168.1.1", rfc2865.NASIPAddress_Get(pkt).String())
+		assert.Equal(t, "magma-aaa", rfc2865.NASIdentifier_GetString(pkt))
+		assert.Equal(t, rfc2866.AcctInputOctets(100), rfc2866.AcctInputOctets_Get(pkt))
+		assert.Equal(t, rfc2869.AcctInputGigawords(5), rfc2869.AcctInputGigawords_Get(pkt))
+		assert.Equal(t, rfc2866.AcctOutputOctets(200), rfc2866.AcctOutputOctets_Get(pkt))
+		assert.Equal(t, rfc2866.AcctSessionTime(60), rfc2866.AcctSessionTime_Get(pkt))
+		assert.Equal(t, rfc2866.AcctTerminateCause_Value_IdleTimeout, rfc2866.AcctTerminateCause_Get(pkt))
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "the request wasn't forwarded")
+	}
+}
+
+func TestRetransmit(t *testing.T) {
+	addr, received, closeServer := upstreamServer(t, false)
+	defer closeServer()
+	p, err := New(&Config{Servers: []ServerConfig{
+		{Address: addr, Secret: secret, TimeoutMs: 50, Retransmits: 2, QueueSize: 1}}})
+	assert.NoError(t, err)
+	p.Start()
+	defer p.Stop()
+
+	p.Forward(NewRequest(rfc2866.AcctStatusType_Value_Start, &protos.Context{SessionId: "sid1", Identity: "user"}))
+	var identifiers []byte
+	for i := 0; i < 3; i++ {
+		select {
+		case pkt := <-received:
+			assert.Equal(t, "user", rfc2865.UserName_GetString(pkt))
+			_, err := rfc2866.AcctInputOctets_Lookup(pkt)
+			assert.Error(t, err, "Starts have no usage")
+			identifiers = append(identifiers, pkt.Identifier)
+		case <-time.After(5 * time.Second):
+			assert.Fail(t, "the request wasn't retransmitted")
+			return
+		}
+	}
+	select {
+	case <-received:
+		assert.Fail(t, "the request is retransmitted more than twice")
+	case <-time.After(200 * time.Millisecond):
+	}
+	assert.Len(t, identifiers, 3)
+}
+
+func TestNilProxy(t *testing.T) {
+	var p *Proxy
+	p.Forward(NewRequest(rfc2866.AcctStatusType_Value_Start, &protos.Context{SessionId: "sid1"}))
+}
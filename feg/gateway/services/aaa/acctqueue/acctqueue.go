@@ -0,0 +1,208 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package acctqueue implements a bounded, persistent queue of accounting work items (session manager calls of
+// Accounting Starts & Stops) failed while the session manager is unavailable. Queued items are acknowledged to the
+// NAS & replayed in order once the session manager is back, so its restarts don't cause NAS retransmit storms
+package acctqueue
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Queue defaults
+const (
+	DefaultMaxItems       = 10000
+	DefaultReplayInterval = time.Second // interval of replays by the queue's consumer
+)
+
+// Op - queued accounting operation
+type Op string
+
+// Queued operations
+const (
+	Start         Op = "start"
+	Stop          Op = "stop"
+	StopWithUsage Op = "stop_usage" // Stop with the session's final usage
+)
+
+// ErrFull is returned by Push when the queue has its maximum number of items
+var ErrFull = errors.New("Accounting queue is full")
+
+// Item - queued accounting work item
+type Item struct {
+	// Key - idempotency key of the item, items with the key of an already queued one aren't queued
+	Key       string    `json:"key"`
+	Op        Op        `json:"op"`
+	SessionId string    `json:"session_id"`
+	Imsi      string    `json:"imsi"`
+	Apn       string    `json:"apn"`
+	Payload   []byte    `json:"payload,omitempty"` // serialized request of the operation
+	Queued    time.Time `json:"queued"`
+}
+
+// Key returns the idempotency key of the session's operation
+func Key(op Op, sid string) string {
+	return string(op) + "/" + sid
+}
+
+// Queue - bounded FIFO of accounting work items, persisted in its file once loaded
+type Queue struct {
+	mu       sync.Mutex
+	path     string
+	max      int
+	items    []Item
+	replayMu sync.Mutex // serializes replays, so a replayed item stays at the queue's head until it's removed
+}
+
+// New returns a new, not yet persisted, queue of at most max items, DefaultMaxItems if max isn't positive
+func New(max int) *Queue {
+	if max <= 0 {
+		max = DefaultMaxItems
+	}
+	return &Queue{max: max}
+}
+
+// Load restores the items queued by the previous process from the given file & persists the queue into it from then
+// on. Restored items are queued ahead of the items pushed before the queue is loaded, a missing file restores nothing
+func (q *Queue) Load(path string) error {
+	b, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	var restored []Item
+	if len(b) > 0 {
+		if err = json.Unmarshal(b, &restored); err != nil {
+			return fmt.Errorf("Invalid accounting queue file %s: %v", path, err)
+		}
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, item := range q.items {
+		if !contains(restored, item.Key) {
+			restored = append(restored, item)
+		}
+	}
+	q.items, q.path = restored, path
+	return q.save()
+}
+
+// Push queues the item, it returns false if an item of the same key is already queued & ErrFull if the queue is full.
+// Items failed to be persisted are still queued in memory
+func (q *Queue) Push(item Item) (bool, error) {
+	if item.Queued.IsZero() {
+		item.Queued = time.Now()
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if contains(q.items, item.Key) {
+		return false, nil
+	}
+	if len(q.items) >= q.max {
+		return false, ErrFull
+	}
+	q.items = append(q.items, item)
+	if err := q.save(); err != nil {
+		log.Print(err)
+	}
+	return true, nil
+}
+
+// Pending returns true if the queue has items of the subscriber, the subscriber's following operations must be
+// queued behind them
+func (q *Queue) Pending(imsi string) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, item := range q.items {
+		if item.Imsi == imsi {
+			return true
+		}
+	}
+	return false
+}
+
+// Len returns the number of queued items
+func (q *Queue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.items)
+}
+
+// Replay sends the queued items in order, sent items are removed from the queue. The replay stops at the first
+// item send fails to send & returns its error, the item is retried by the next replay. Returns the number of
+// sent items
+func (q *Queue) Replay(send func(Item) error) (int, error) {
+	q.replayMu.Lock()
+	defer q.replayMu.Unlock()
+	sent := 0
+	for {
+		q.mu.Lock()
+		if len(q.items) == 0 {
+			q.mu.Unlock()
+			return sent, nil
+		}
+		item := q.items[0]
+		q.mu.Unlock()
+
+		if err := send(item); err != nil {
+			return sent, err
+		}
+		sent++
+		q.mu.Lock()
+		q.items = q.items[1:]
+		if err := q.save(); err != nil {
+			log.Print(err)
+		}
+		q.mu.Unlock()
+	}
+}
+
+// save writes the items into the queue's file, it's a no-op if the queue isn't loaded. Must be called with q.mu held
+func (q *Queue) save() error {
+	if len(q.path) == 0 {
+		return nil
+	}
+	data, err := json.Marshal(q.items)
+	if err != nil {
+		return err
+	}
+	// write & rename, so a crash while saving doesn't lose the previously saved items
+	tmp, err := ioutil.TempFile(filepath.Dir(q.path), filepath.Base(q.path)+".tmp")
+	if err != nil {
+		return fmt.Errorf("Error creating accounting queue temp file: %v", err)
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), q.path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("Error writing accounting queue file %s: %v", q.path, err)
+	}
+	return nil
+}
+
+func contains(items []Item, key string) bool {
+	for _, item := range items {
+		if item.Key == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package acctqueue
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueueReplay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "acctqueue")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "queue.json")
+
+	q := New(3)
+	require.NoError(t, q.Load(path))
+	queued, err := q.Push(Item{Key: Key(Start, "sid1"), Op: Start, SessionId: "sid1", Imsi: "IMSI1", Payload: []byte{1}})
+	assert.NoError(t, err)
+	assert.True(t, queued)
+	// retransmitted Start isn't queued twice
+	queued, err = q.Push(Item{Key: Key(Start, "sid1"), Op: Start, SessionId: "sid1", Imsi: "IMSI1"})
+	assert.NoError(t, err)
+	assert.False(t, queued)
+	_, err = q.Push(Item{Key: Key(Stop, "sid1"), Op: Stop, SessionId: "sid1", Imsi: "IMSI1"})
+	assert.NoError(t, err)
+	_, err = q.Push(Item{Key: Key(Start, "sid2"), Op: Start, SessionId: "sid2", Imsi: "IMSI2"})
+	assert.NoError(t, err)
+	_, err = q.Push(Item{Key: Key(Start, "sid3"), Op: Start, SessionId: "sid3", Imsi: "IMSI3"})
+	assert.Equal(t, ErrFull, err)
+	assert.True(t, q.Pending("IMSI1"))
+	assert.False(t, q.Pending("IMSI3"))
+
+	// the restarted process restores the queued items
+	q = New(3)
+	require.NoError(t, q.Load(path))
+	assert.Equal(t, 3, q.Len())
+
+	// replay stops at the first failed item & resumes from it
+	var keys []string
+	unavailable := errors.New("unavailable")
+	sent, err := q.Replay(func(item Item) error {
+		if item.SessionId == "sid2" {
+			return unavailable
+		}
+		keys = append(keys, item.Key)
+		return nil
+	})
+	assert.Equal(t, unavailable, err)
+	assert.Equal(t, 2, sent)
+	assert.Equal(t, []string{"start/sid1", "stop/sid1"}, keys)
+	assert.True(t, q.Pending("IMSI2"))
+	assert.False(t, q.Pending("IMSI1"))
+
+	keys = nil
+	sent, err = q.Replay(func(item Item) error {
+		keys = append(keys, item.Key)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	assert.Equal(t, []string{"start/sid2"}, keys)
+	assert.Equal(t, 0, q.Len())
+
+	q = New(3)
+	require.NoError(t, q.Load(path))
+	assert.Equal(t, 0, q.Len())
+	files, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, files, 1) // no leftover temp files
+}
+
+func TestLoadKeepsPushedItems(t *testing.T) {
+	f, err := ioutil.TempFile("", "acctqueue")
+	require.NoError(t, err)
+	defer os.Remove(f.Name())
+	_, err = f.WriteString(`[{"key":"stop/sid1","op":"stop","session_id":"sid1","imsi":"IMSI1","payload":"AQI="}]`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	q := New(0)
+	_, err = q.Push(Item{Key: Key(Start, "sid2"), Op: Start, SessionId: "sid2", Imsi: "IMSI1"})
+	require.NoError(t, err)
+	require.NoError(t, q.Load(f.Name()))
+	var items []Item
+	q.Replay(func(item Item) error {
+		items = append(items, item)
+		return nil
+	})
+	if assert.Len(t, items, 2) {
+		assert.Equal(t, Stop, items[0].Op)
+		assert.Equal(t, []byte{1, 2}, items[0].Payload)
+		assert.Equal(t, "start/sid2", items[1].Key)
+	}
+
+	assert.Error(t, New(0).Load(os.DevNull+"/queue.json"))
+}
@@ -0,0 +1,176 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package adminauth implements role based authorization of the AAA admin & debug RPCs. Callers are identified by
+// their bearer tokens or, on TLS connections, by the common names of their client certificates & are granted the
+// roles configured for them: read-only, operator or admin, each role includes the permissions of the lower ones
+package adminauth
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Role - admin RPCs role
+type Role string
+
+// Roles, in ascending permissions order
+const (
+	ReadOnly Role = "read-only" // inspects sessions & configuration
+	Operator Role = "operator"  // also changes live sessions
+	Admin    Role = "admin"     // also releases & imports sessions
+)
+
+const (
+	authorizationMetadata = "authorization"
+	bearerPrefix          = "Bearer "
+)
+
+var roleLevels = map[Role]int{ReadOnly: 1, Operator: 2, Admin: 3}
+
+// Includes returns true if the role has the permissions of the required role
+func (r Role) Includes(required Role) bool {
+	return roleLevels[r] > 0 && roleLevels[r] >= roleLevels[required]
+}
+
+// TokenGrant - a role granted to the bearer of a token
+type TokenGrant struct {
+	Name  string `json:"name"` // the token's principal name, logged instead of the token
+	Token string `json:"token"`
+	Role  Role   `json:"role"`
+}
+
+// CertGrant - a role granted to TLS clients with certificates of a common name
+type CertGrant struct {
+	CommonName string `json:"common_name"`
+	Role       Role   `json:"role"`
+}
+
+// Config - admin RPCs authorization configuration
+type Config struct {
+	Tokens      []TokenGrant `json:"tokens"`
+	ClientCerts []CertGrant  `json:"client_certs"`
+}
+
+// Principal - an authorized caller
+type Principal struct {
+	Name string
+	Role Role
+}
+
+// Authorizer authorizes admin RPC calls by their callers' roles
+type Authorizer struct {
+	tokens []TokenGrant
+	certs  map[string]Role
+}
+
+// ReadConfig reads the JSON authorization configuration file
+func ReadConfig(path string) (*Config, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &Config{}
+	if err = json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("Invalid admin authorization configuration %s: %v", path, err)
+	}
+	return cfg, nil
+}
+
+// New returns the authorizer of the configuration
+func New(cfg *Config) (*Authorizer, error) {
+	if cfg == nil || len(cfg.Tokens)+len(cfg.ClientCerts) == 0 {
+		return nil, fmt.Errorf("No admin tokens or client certificates are configured")
+	}
+	auth := &Authorizer{certs: map[string]Role{}}
+	for i, grant := range cfg.Tokens {
+		if len(grant.Token) == 0 {
+			return nil, fmt.Errorf("Empty admin token #%d", i+1)
+		}
+		if _, ok := roleLevels[grant.Role]; !ok {
+			return nil, fmt.Errorf("Invalid role '%s' of admin token #%d", grant.Role, i+1)
+		}
+		if len(grant.Name) == 0 {
+			grant.Name = fmt.Sprintf("token#%d", i+1)
+		}
+		auth.tokens = append(auth.tokens, grant)
+	}
+	for _, grant := range cfg.ClientCerts {
+		if len(grant.CommonName) == 0 {
+			return nil, fmt.Errorf("Empty client certificate common name")
+		}
+		if _, ok := roleLevels[grant.Role]; !ok {
+			return nil, fmt.Errorf("Invalid role '%s' of client certificate %s", grant.Role, grant.CommonName)
+		}
+		auth.certs[grant.CommonName] = grant.Role
+	}
+	return auth, nil
+}
+
+// Authorize returns the principal of the call if the caller's role includes the required role, Unauthenticated
+// error if the caller is unknown & PermissionDenied error if the caller's role is insufficient
+func (a *Authorizer) Authorize(ctx context.Context, required Role) (*Principal, error) {
+	if a == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "Admin authorization is not configured")
+	}
+	principal := a.principal(ctx)
+	if principal == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "Invalid or missing admin credentials")
+	}
+	if !principal.Role.Includes(required) {
+		return nil, status.Errorf(
+			codes.PermissionDenied, "%s role of %s does not permit %s calls", principal.Role, principal.Name, required)
+	}
+	return principal, nil
+}
+
+// principal identifies the caller by the bearer token, or else by the TLS client certificate
+func (a *Authorizer) principal(ctx context.Context) *Principal {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for _, v := range md.Get(authorizationMetadata) {
+			if !strings.HasPrefix(v, bearerPrefix) {
+				continue
+			}
+			token := []byte(strings.TrimPrefix(v, bearerPrefix))
+			for _, grant := range a.tokens {
+				if subtle.ConstantTimeCompare(token, []byte(grant.Token)) == 1 {
+					return &Principal{Name: grant.Name, Role: grant.Role}
+				}
+			}
+		}
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return nil
+	}
+	// verified chains' leaves are the client certificates verified by the server's TLS configuration
+	for _, chain := range tlsInfo.State.VerifiedChains {
+		if len(chain) == 0 {
+			continue
+		}
+		cn := chain[0].Subject.CommonName
+		if role, ok := a.certs[cn]; ok {
+			return &Principal{Name: "cert:" + cn, Role: role}
+		}
+	}
+	return nil
+}
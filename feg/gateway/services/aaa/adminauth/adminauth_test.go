@@ -0,0 +1,91 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package adminauth_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"magma/feg/gateway/services/aaa/adminauth"
+)
+
+func tokenContext(token string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+}
+
+func TestAuthorizeTokens(t *testing.T) {
+	auth, err := adminauth.New(&adminauth.Config{Tokens: []adminauth.TokenGrant{
+		{Name: "noc", Token: "ro-token", Role: adminauth.ReadOnly},
+		{Token: "op-token", Role: adminauth.Operator},
+	}})
+	assert.NoError(t, err)
+
+	principal, err := auth.Authorize(tokenContext("ro-token"), adminauth.ReadOnly)
+	assert.NoError(t, err)
+	assert.Equal(t, &adminauth.Principal{Name: "noc", Role: adminauth.ReadOnly}, principal)
+
+	_, err = auth.Authorize(tokenContext("ro-token"), adminauth.Operator)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	principal, err = auth.Authorize(tokenContext("op-token"), adminauth.Operator)
+	assert.NoError(t, err)
+	assert.Equal(t, "token#2", principal.Name)
+	_, err = auth.Authorize(tokenContext("op-token"), adminauth.Admin)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = auth.Authorize(tokenContext("unknown"), adminauth.ReadOnly)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = auth.Authorize(context.Background(), adminauth.ReadOnly)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestAuthorizeClientCerts(t *testing.T) {
+	auth, err := adminauth.New(&adminauth.Config{ClientCerts: []adminauth.CertGrant{
+		{CommonName: "orc8r", Role: adminauth.Admin},
+	}})
+	assert.NoError(t, err)
+
+	certContext := func(cn string) context.Context {
+		cert := &x509.Certificate{Subject: pkix.Name{CommonName: cn}}
+		return peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{
+			State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}},
+		}})
+	}
+	principal, err := auth.Authorize(certContext("orc8r"), adminauth.Admin)
+	assert.NoError(t, err)
+	assert.Equal(t, &adminauth.Principal{Name: "cert:orc8r", Role: adminauth.Admin}, principal)
+
+	_, err = auth.Authorize(certContext("laptop"), adminauth.ReadOnly)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestNewErrors(t *testing.T) {
+	_, err := adminauth.New(&adminauth.Config{})
+	assert.Error(t, err)
+	_, err = adminauth.New(&adminauth.Config{Tokens: []adminauth.TokenGrant{{Token: "t", Role: "root"}}})
+	assert.Error(t, err)
+	_, err = adminauth.New(&adminauth.Config{Tokens: []adminauth.TokenGrant{{Role: adminauth.Admin}}})
+	assert.Error(t, err)
+	_, err = adminauth.New(&adminauth.Config{ClientCerts: []adminauth.CertGrant{{Role: adminauth.Admin}}})
+	assert.Error(t, err)
+
+	var nilAuth *adminauth.Authorizer
+	_, err = nilAuth.Authorize(tokenContext("t"), adminauth.ReadOnly)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
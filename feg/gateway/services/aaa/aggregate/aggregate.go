@@ -0,0 +1,137 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package aggregate aggregates the usage of subscribers' simultaneous sessions, e.g. of multi-device subscriptions,
+// so the subscriber's combined usage is checked against its quota instead of each device's usage independently
+package aggregate
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Usage - accumulated usage of a session or the combined usage of a subscriber's sessions
+type Usage struct {
+	Sessions              int // number of the subscriber's sessions, unset for a session's usage
+	OctetsIn, OctetsOut   uint64
+	PacketsIn, PacketsOut uint64
+}
+
+// Octets returns the total octets of both directions
+func (u Usage) Octets() uint64 {
+	return u.OctetsIn + u.OctetsOut
+}
+
+// QuotaHook checks the subscriber's combined usage, it returns a non nil error if the subscriber's quota is exceeded
+type QuotaHook interface {
+	CheckQuota(imsi string, usage Usage) error
+}
+
+// QuotaHookFunc adapts a function to the QuotaHook interface
+type QuotaHookFunc func(imsi string, usage Usage) error
+
+// CheckQuota calls f(imsi, usage)
+func (f QuotaHookFunc) CheckQuota(imsi string, usage Usage) error {
+	return f(imsi, usage)
+}
+
+// MaxOctets returns a QuotaHook limiting the combined octets of both directions of each subscriber's sessions
+func MaxOctets(limit uint64) QuotaHook {
+	return QuotaHookFunc(func(imsi string, usage Usage) error {
+		if usage.Octets() > limit {
+			return fmt.Errorf("IMSI %s used %d octets in %d sessions, quota: %d",
+				imsi, usage.Octets(), usage.Sessions, limit)
+		}
+		return nil
+	})
+}
+
+// Table - synchronized table of the subscribers' sessions' usages
+type Table struct {
+	mu          sync.Mutex
+	subscribers map[string]map[string]Usage // session usages by session ID by IMSI
+	imsis       map[string]string           // IMSIs by session ID
+}
+
+// NewTable returns an empty Table
+func NewTable() *Table {
+	return &Table{subscribers: map[string]map[string]Usage{}, imsis: map[string]string{}}
+}
+
+// Update sets the session's accumulated usage & returns the combined usage of the subscriber's sessions
+func (t *Table) Update(imsi, sid string, usage Usage) Usage {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if prev, ok := t.imsis[sid]; ok && prev != imsi {
+		t.removeLocked(sid)
+	}
+	sessions, ok := t.subscribers[imsi]
+	if !ok {
+		sessions = map[string]Usage{}
+		t.subscribers[imsi] = sessions
+	}
+	usage.Sessions = 0
+	sessions[sid] = usage
+	t.imsis[sid] = imsi
+	return combine(sessions)
+}
+
+// Remove removes the session & returns its subscriber's IMSI & the combined usage of the subscriber's remaining
+// sessions, false if the session isn't found
+func (t *Table) Remove(sid string) (string, Usage, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	imsi, ok := t.imsis[sid]
+	if !ok {
+		return "", Usage{}, false
+	}
+	t.removeLocked(sid)
+	return imsi, combine(t.subscribers[imsi]), true
+}
+
+// Get returns the combined usage of the subscriber's sessions, false if the subscriber has no sessions
+func (t *Table) Get(imsi string) (Usage, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	sessions, ok := t.subscribers[imsi]
+	if !ok {
+		return Usage{}, false
+	}
+	return combine(sessions), true
+}
+
+// Sessions returns the IDs of the subscriber's sessions
+func (t *Table) Sessions(imsi string) []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	sids := make([]string, 0, len(t.subscribers[imsi]))
+	for sid := range t.subscribers[imsi] {
+		sids = append(sids, sid)
+	}
+	return sids
+}
+
+func (t *Table) removeLocked(sid string) {
+	imsi := t.imsis[sid]
+	delete(t.imsis, sid)
+	delete(t.subscribers[imsi], sid)
+	if len(t.subscribers[imsi]) == 0 {
+		delete(t.subscribers, imsi)
+	}
+}
+
+func combine(sessions map[string]Usage) Usage {
+	res := Usage{Sessions: len(sessions)}
+	for _, u := range sessions {
+		res.OctetsIn += u.OctetsIn
+		res.OctetsOut += u.OctetsOut
+		res.PacketsIn += u.PacketsIn
+		res.PacketsOut += u.PacketsOut
+	}
+	return res
+}
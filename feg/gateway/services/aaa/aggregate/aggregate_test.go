@@ -0,0 +1,57 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package aggregate_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"magma/feg/gateway/services/aaa/aggregate"
+)
+
+func TestTable(t *testing.T) {
+	table := aggregate.NewTable()
+
+	combined := table.Update("001010000000001", "sid1", aggregate.Usage{OctetsIn: 100, OctetsOut: 1000, PacketsIn: 1})
+	assert.Equal(t, aggregate.Usage{Sessions: 1, OctetsIn: 100, OctetsOut: 1000, PacketsIn: 1}, combined)
+	table.Update("001010000000002", "sid3", aggregate.Usage{OctetsIn: 5})
+	combined = table.Update("001010000000001", "sid2", aggregate.Usage{OctetsIn: 50, OctetsOut: 500, PacketsOut: 2})
+	assert.Equal(t, aggregate.Usage{Sessions: 2, OctetsIn: 150, OctetsOut: 1500, PacketsIn: 1, PacketsOut: 2}, combined)
+
+	// the session's accumulated usage replaces its previous one
+	combined = table.Update("001010000000001", "sid1", aggregate.Usage{OctetsIn: 200, OctetsOut: 2000, PacketsIn: 3})
+	assert.Equal(t, uint64(250), combined.OctetsIn)
+	assert.Equal(t, uint64(2500), combined.OctetsOut)
+	assert.ElementsMatch(t, []string{"sid1", "sid2"}, table.Sessions("001010000000001"))
+
+	imsi, remaining, ok := table.Remove("sid1")
+	assert.True(t, ok)
+	assert.Equal(t, "001010000000001", imsi)
+	assert.Equal(t, aggregate.Usage{Sessions: 1, OctetsIn: 50, OctetsOut: 500, PacketsOut: 2}, remaining)
+
+	_, remaining, ok = table.Remove("sid2")
+	assert.True(t, ok)
+	assert.Equal(t, 0, remaining.Sessions)
+	_, ok = table.Get("001010000000001")
+	assert.False(t, ok)
+	_, _, ok = table.Remove("sid2")
+	assert.False(t, ok)
+
+	other, ok := table.Get("001010000000002")
+	assert.True(t, ok)
+	assert.Equal(t, aggregate.Usage{Sessions: 1, OctetsIn: 5}, other)
+}
+
+func TestMaxOctets(t *testing.T) {
+	hook := aggregate.MaxOctets(1000)
+
+	assert.NoError(t, hook.CheckQuota("001010000000001", aggregate.Usage{Sessions: 2, OctetsIn: 400, OctetsOut: 600}))
+	assert.Error(t, hook.CheckQuota("001010000000001", aggregate.Usage{Sessions: 2, OctetsIn: 400, OctetsOut: 601}))
+}
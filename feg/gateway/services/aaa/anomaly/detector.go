@@ -0,0 +1,167 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package anomaly implements uplink/downlink usage anomaly detection over accounting Interim-Update deltas
+package anomaly
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// Type of a detected anomaly
+type Type string
+
+const (
+	// UplinkOnly - an interval with significant uplink usage and (almost) no downlink usage
+	UplinkOnly Type = "uplink_only"
+	// UplinkRatio - an interval with uplink to downlink usage ratio above the configured maximum
+	UplinkRatio Type = "uplink_ratio"
+)
+
+// Config - anomaly detector heuristics configuration
+type Config struct {
+	// MinUplinkOctets - intervals with uplink delta below MinUplinkOctets are never considered anomalous
+	MinUplinkOctets uint64
+	// MaxUplinkRatio - maximum 'normal' uplink/downlink delta ratio, 0 disables the ratio heuristic
+	MaxUplinkRatio float64
+	// UplinkOnlyMaxDownlink - downlink deltas at or below this value make a large uplink interval 'uplink only'
+	UplinkOnlyMaxDownlink uint64
+	// ConsecutiveIntervals - number of consecutive anomalous intervals required to raise an event (1 if not set)
+	ConsecutiveIntervals int
+	// CoATrafficClasses - if not empty, JSON traffic classes to push via CoA to rate limit an anomalous session
+	CoATrafficClasses string
+}
+
+// Event - structured anomaly event
+type Event struct {
+	Type           Type    `json:"type"`
+	SessionId      string  `json:"session_id"`
+	Imsi           string  `json:"imsi"`
+	Apn            string  `json:"apn"`
+	UplinkOctets   uint64  `json:"uplink_octets"`
+	DownlinkOctets uint64  `json:"downlink_octets"`
+	Ratio          float64 `json:"ratio,omitempty"`
+	Intervals      int     `json:"intervals"`
+}
+
+// String returns JSON representation of the event
+func (e *Event) String() string {
+	if e == nil {
+		return "<nil>"
+	}
+	b, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Sprintf("%+v", *e)
+	}
+	return string(b)
+}
+
+type sessionUsage struct {
+	uplink, downlink uint64 // last reported cumulative octets
+	anomalous        int    // number of consecutive anomalous intervals
+	raised           bool   // the event of the consecutive anomalous intervals was raised
+}
+
+// Detector - tracks per session usage & applies configured heuristics to usage deltas
+type Detector struct {
+	cfg      Config
+	sessions map[string]*sessionUsage
+	mu       sync.Mutex
+}
+
+// NewDetector returns a new Detector for the given configuration
+func NewDetector(cfg Config) *Detector {
+	if cfg.ConsecutiveIntervals < 1 {
+		cfg.ConsecutiveIntervals = 1
+	}
+	return &Detector{cfg: cfg, sessions: map[string]*sessionUsage{}}
+}
+
+// Config returns the detector's configuration
+func (d *Detector) Config() Config {
+	if d == nil {
+		return Config{}
+	}
+	return d.cfg
+}
+
+// Update records session's cumulative uplink & downlink octets reported by an Interim-Update, computes deltas since
+// the previous update & returns an anomaly Event if the deltas triggered any of the configured heuristics,
+// nil otherwise. A session's consecutive anomalous intervals raise a single event, the session's next event is
+// raised by anomalous intervals following a normal interval
+func (d *Detector) Update(sid, imsi, apn string, uplink, downlink uint64) *Event {
+	if d == nil {
+		return nil
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	su, ok := d.sessions[sid]
+	if !ok {
+		su = &sessionUsage{}
+		d.sessions[sid] = su
+	}
+	upDelta, downDelta := delta(su.uplink, uplink), delta(su.downlink, downlink)
+	su.uplink, su.downlink = uplink, downlink
+
+	anomaly, ratio := d.classify(upDelta, downDelta)
+	if len(anomaly) == 0 {
+		su.anomalous, su.raised = 0, false
+		return nil
+	}
+	su.anomalous++
+	if su.anomalous < d.cfg.ConsecutiveIntervals || su.raised {
+		return nil
+	}
+	su.raised = true
+	return &Event{
+		Type:           anomaly,
+		SessionId:      sid,
+		Imsi:           imsi,
+		Apn:            apn,
+		UplinkOctets:   upDelta,
+		DownlinkOctets: downDelta,
+		Ratio:          ratio,
+		Intervals:      su.anomalous,
+	}
+}
+
+// Remove removes session's usage state, it should be called when the session ends
+func (d *Detector) Remove(sid string) {
+	if d != nil {
+		d.mu.Lock()
+		delete(d.sessions, sid)
+		d.mu.Unlock()
+	}
+}
+
+func (d *Detector) classify(upDelta, downDelta uint64) (Type, float64) {
+	if upDelta == 0 || upDelta < d.cfg.MinUplinkOctets {
+		return "", 0
+	}
+	if downDelta <= d.cfg.UplinkOnlyMaxDownlink {
+		return UplinkOnly, 0
+	}
+	if d.cfg.MaxUplinkRatio > 0 {
+		if ratio := float64(upDelta) / float64(downDelta); ratio > d.cfg.MaxUplinkRatio {
+			return UplinkRatio, ratio
+		}
+	}
+	return "", 0
+}
+
+// delta returns the difference between current & previous cumulative counters, a counter going backwards is treated
+// as a NAS side counter reset
+func delta(prev, curr uint64) uint64 {
+	if curr < prev {
+		return curr
+	}
+	return curr - prev
+}
@@ -0,0 +1,71 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package anomaly_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"magma/feg/gateway/services/aaa/anomaly"
+)
+
+func TestDetectorUplinkOnly(t *testing.T) {
+	d := anomaly.NewDetector(anomaly.Config{MinUplinkOctets: 1000, UplinkOnlyMaxDownlink: 10})
+
+	assert.Nil(t, d.Update("sid1", "123456789012345", "apn", 500, 0))
+	ev := d.Update("sid1", "123456789012345", "apn", 5500, 5)
+	if assert.NotNil(t, ev) {
+		assert.Equal(t, anomaly.UplinkOnly, ev.Type)
+		assert.Equal(t, uint64(5000), ev.UplinkOctets)
+		assert.Equal(t, uint64(5), ev.DownlinkOctets)
+		assert.Equal(t, "sid1", ev.SessionId)
+	}
+	// normal interval
+	assert.Nil(t, d.Update("sid1", "123456789012345", "apn", 7500, 100005))
+}
+
+func TestDetectorRatioConsecutive(t *testing.T) {
+	d := anomaly.NewDetector(anomaly.Config{MinUplinkOctets: 1000, MaxUplinkRatio: 4, ConsecutiveIntervals: 2})
+
+	assert.Nil(t, d.Update("sid1", "", "", 10000, 1000))
+	ev := d.Update("sid1", "", "", 20000, 2000)
+	if assert.NotNil(t, ev) {
+		assert.Equal(t, anomaly.UplinkRatio, ev.Type)
+		assert.Equal(t, 10.0, ev.Ratio)
+		assert.Equal(t, 2, ev.Intervals)
+	}
+	// normal interval resets the consecutive count
+	assert.Nil(t, d.Update("sid1", "", "", 21000, 12000))
+	assert.Nil(t, d.Update("sid1", "", "", 31000, 13000))
+
+	// counters reset by NAS
+	d.Remove("sid1")
+	assert.Nil(t, d.Update("sid1", "", "", 100, 100))
+}
+
+func TestDetectorRaisesOnce(t *testing.T) {
+	d := anomaly.NewDetector(anomaly.Config{MinUplinkOctets: 1000, UplinkOnlyMaxDownlink: 10})
+
+	// consecutive anomalous intervals raise a single event
+	events := 0
+	for i := uint64(1); i <= 3; i++ {
+		if d.Update("sid1", "", "", i*5000, 0) != nil {
+			events++
+		}
+	}
+	assert.Equal(t, 1, events)
+
+	// anomalous intervals following a normal interval raise the next event
+	assert.Nil(t, d.Update("sid1", "", "", 16000, 100000))
+	ev := d.Update("sid1", "", "", 21000, 100000)
+	if assert.NotNil(t, ev) {
+		assert.Equal(t, 1, ev.Intervals)
+	}
+}
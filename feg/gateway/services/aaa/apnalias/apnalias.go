@@ -0,0 +1,82 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package apnalias implements the APN aliases table mapping venues' SSIDs & Called-Station-Ids to the canonical APNs
+// configured in orc8r. Aliases are applied to the sessions' contexts before session creation, so metrics labels,
+// policy lookups & session manager see the canonical APN
+package apnalias
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+// Config - APN aliases configuration
+type Config struct {
+	// Aliases maps aliases to their canonical APNs, an alias is either a whole Called-Station-Id or an SSID
+	// matching the Called-Station-Ids of the SSID on all APs. Aliases are case insensitive
+	Aliases map[string]string `json:"aliases"`
+}
+
+// ReadConfig reads the APN aliases configuration from the given JSON file
+func ReadConfig(path string) (*Config, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &Config{}
+	if err = json.Unmarshal(b, cfg); err != nil {
+		return nil, fmt.Errorf("Invalid APN aliases configuration %s: %v", path, err)
+	}
+	aliases := make(map[string]string, len(cfg.Aliases))
+	for alias, apn := range cfg.Aliases {
+		alias, apn = strings.TrimSpace(alias), strings.TrimSpace(apn)
+		if len(alias) == 0 || len(apn) == 0 {
+			return nil, fmt.Errorf("Invalid APN alias '%s': empty alias or APN '%s'", alias, apn)
+		}
+		aliases[strings.ToLower(alias)] = apn
+	}
+	cfg.Aliases = aliases
+	return cfg, nil
+}
+
+// Canonical returns the canonical APN of the Called-Station-Id, the Called-Station-Id's alias takes precedence over
+// its SSID's alias. Returns false if neither is an alias
+func (c *Config) Canonical(calledStationID string) (string, bool) {
+	if c == nil || len(calledStationID) == 0 {
+		return "", false
+	}
+	if apn, ok := c.Aliases[strings.ToLower(calledStationID)]; ok {
+		return apn, true
+	}
+	if ssid := protos.SSID(calledStationID); len(ssid) > 0 {
+		apn, ok := c.Aliases[strings.ToLower(ssid)]
+		return apn, ok
+	}
+	return "", false
+}
+
+// Apply replaces the context's APN with its canonical APN & keeps the original Called-Station-Id as the context's
+// called_station_id attribute. Returns true if the APN was replaced
+func (c *Config) Apply(aaaCtx *protos.Context) bool {
+	if aaaCtx == nil {
+		return false
+	}
+	apn, ok := c.Canonical(aaaCtx.GetApn())
+	if !ok || apn == aaaCtx.GetApn() {
+		return false
+	}
+	// the APN is replaced even if the context is out of attributes for the original Called-Station-Id
+	aaaCtx.SetAttribute(protos.CalledStationIDAttribute, aaaCtx.GetApn())
+	aaaCtx.Apn = apn
+	return true
+}
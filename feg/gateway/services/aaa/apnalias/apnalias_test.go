@@ -0,0 +1,78 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package apnalias
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+func writeConfig(t *testing.T, cfg string) string {
+	f, err := ioutil.TempFile("", "apn_aliases")
+	assert.NoError(t, err)
+	_, err = f.WriteString(cfg)
+	assert.NoError(t, err)
+	f.Close()
+	return f.Name()
+}
+
+func TestAliases(t *testing.T) {
+	path := writeConfig(t, `{
+		"aliases": {
+			"Venue-Guest": "magma.wifi",
+			"00-11-22-AA-BB-CC:Venue-Guest": "lobby.wifi",
+			"Staff WiFi": "corp"
+		}
+	}`)
+	defer os.Remove(path)
+
+	cfg, err := ReadConfig(path)
+	assert.NoError(t, err)
+
+	for calledStationID, canonical := range map[string]string{
+		"00-11-22-AA-BB-DD:Venue-Guest": "magma.wifi",
+		"00:11:22:aa:bb:dd:venue-guest": "magma.wifi",
+		"00-11-22-AA-BB-CC:Venue-Guest": "lobby.wifi", // the Called-Station-Id's alias takes precedence
+		"venue-guest":                   "magma.wifi",
+		"001122AABBDD:STAFF WIFI":       "corp",
+	} {
+		apn, ok := cfg.Canonical(calledStationID)
+		assert.True(t, ok, calledStationID)
+		assert.Equal(t, canonical, apn, calledStationID)
+	}
+	for _, calledStationID := range []string{"", "00-11-22-AA-BB-DD:Visitors", "00-11-22-AA-BB-DD", "magma.wifi"} {
+		_, ok := cfg.Canonical(calledStationID)
+		assert.False(t, ok, calledStationID)
+	}
+
+	aaaCtx := &protos.Context{SessionId: "sid1", Apn: "00-11-22-AA-BB-DD:Venue-Guest"}
+	assert.True(t, cfg.Apply(aaaCtx))
+	assert.Equal(t, "magma.wifi", aaaCtx.GetApn())
+	assert.Equal(t, "00-11-22-AA-BB-DD:Venue-Guest", aaaCtx.CalledStationID())
+	assert.False(t, cfg.Apply(aaaCtx), "canonical APNs aren't aliased again")
+	assert.Equal(t, "00-11-22-AA-BB-DD:Venue-Guest", aaaCtx.CalledStationID())
+
+	var noAliases *Config
+	assert.False(t, noAliases.Apply(&protos.Context{Apn: "00-11-22-AA-BB-DD:Venue-Guest"}))
+}
+
+func TestReadInvalidConfig(t *testing.T) {
+	path := writeConfig(t, `{"aliases": {"Venue-Guest": " "}}`)
+	defer os.Remove(path)
+	_, err := ReadConfig(path)
+	assert.Error(t, err)
+
+	_, err = ReadConfig("/nonexistent/apn_aliases.json")
+	assert.Error(t, err)
+}
@@ -0,0 +1,112 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package apnauth implements subscriber to APN (SSID) authorization
+package apnauth
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"golang.org/x/net/context"
+)
+
+const (
+	imsiPrefix = "IMSI"
+	// AnyAPN matches all APNs
+	AnyAPN = "*"
+)
+
+// Rejection reasons
+const (
+	ReasonNotAllowed = "not_allowed"
+	ReasonUnknown    = "unknown_subscriber"
+	ReasonBarred     = "barred"
+	ReasonError      = "error"
+)
+
+// Authorizer authorizes subscribers' access to APNs
+type Authorizer interface {
+	// Authorize returns nil if the subscriber with the given IMSI is allowed to use the APN and a *RejectError if
+	// the subscriber is not allowed or the authorization failed. Remote lookups are bounded by the ctx deadline
+	Authorize(ctx context.Context, imsi, apn string) error
+}
+
+// RejectError describes APN authorization rejection
+type RejectError struct {
+	IMSI   string
+	APN    string
+	Reason string
+	Err    error
+}
+
+func (e *RejectError) Error() string {
+	msg := fmt.Sprintf("Subscriber %s is not authorized for APN '%s': %s", e.IMSI, e.APN, e.Reason)
+	if e.Err != nil {
+		msg += fmt.Sprintf(" (%v)", e.Err)
+	}
+	return msg
+}
+
+// LocalMap - local IMSI to allowed APNs map
+type LocalMap struct {
+	// Subscribers maps IMSIs to the APNs they are allowed to use
+	Subscribers map[string][]string `json:"subscribers"`
+	// Default lists APNs allowed to subscribers not present in Subscribers, empty - reject unknown subscribers
+	Default []string `json:"default"`
+}
+
+// ReadLocalMap reads local IMSI to APN map from the given JSON file
+func ReadLocalMap(path string) (*LocalMap, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	m := &LocalMap{}
+	if err = json.Unmarshal(b, m); err != nil {
+		return nil, fmt.Errorf("Invalid IMSI to APN map %s: %v", path, err)
+	}
+	normalized := make(map[string][]string, len(m.Subscribers))
+	for imsi, apns := range m.Subscribers {
+		normalized[NormalizeIMSI(imsi)] = apns
+	}
+	m.Subscribers = normalized
+	return m, nil
+}
+
+// Authorize implements Authorizer
+func (m *LocalMap) Authorize(_ context.Context, imsi, apn string) error {
+	imsi = NormalizeIMSI(imsi)
+	apns, ok := m.Subscribers[imsi]
+	if !ok {
+		if len(m.Default) == 0 {
+			return &RejectError{IMSI: imsi, APN: apn, Reason: ReasonUnknown}
+		}
+		apns = m.Default
+	}
+	if !matchAPN(apns, apn) {
+		return &RejectError{IMSI: imsi, APN: apn, Reason: ReasonNotAllowed}
+	}
+	return nil
+}
+
+// NormalizeIMSI returns the IMSI without the "IMSI" prefix
+func NormalizeIMSI(imsi string) string {
+	return strings.TrimPrefix(imsi, imsiPrefix)
+}
+
+func matchAPN(allowed []string, apn string) bool {
+	for _, a := range allowed {
+		if a == AnyAPN || strings.EqualFold(a, apn) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package apnauth
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+
+	lteprotos "magma/lte/cloud/go/protos"
+)
+
+func TestLocalMap(t *testing.T) {
+	f, err := ioutil.TempFile("", "apn_map")
+	assert.NoError(t, err)
+	defer os.Remove(f.Name())
+	_, err = f.WriteString(`{
+		"subscribers": {
+			"IMSI001010000000001": ["internet", "ims"],
+			"001010000000002": ["*"]
+		}
+	}`)
+	assert.NoError(t, err)
+	f.Close()
+
+	m, err := ReadLocalMap(f.Name())
+	assert.NoError(t, err)
+
+	assert.NoError(t, m.Authorize(context.Background(), "001010000000001", "Internet"))
+	assert.NoError(t, m.Authorize(context.Background(), "IMSI001010000000001", "ims"))
+	assert.NoError(t, m.Authorize(context.Background(), "IMSI001010000000002", "any.apn"))
+
+	err = m.Authorize(context.Background(), "IMSI001010000000001", "corp")
+	if assert.IsType(t, &RejectError{}, err) {
+		assert.Equal(t, ReasonNotAllowed, err.(*RejectError).Reason)
+		assert.Equal(t, "001010000000001", err.(*RejectError).IMSI)
+	}
+	err = m.Authorize(context.Background(), "001010000000003", "internet")
+	if assert.IsType(t, &RejectError{}, err) {
+		assert.Equal(t, ReasonUnknown, err.(*RejectError).Reason)
+	}
+
+	m.Default = []string{"guest"}
+	assert.NoError(t, m.Authorize(context.Background(), "001010000000003", "guest"))
+	assert.Error(t, m.Authorize(context.Background(), "001010000000003", "internet"))
+}
+
+func TestAuthorizeProfile(t *testing.T) {
+	assert.NoError(t, authorizeProfile("1", "internet", nil))
+	assert.NoError(t, authorizeProfile("1", "internet", &lteprotos.Non3GPPUserProfile{
+		ApnConfig: &lteprotos.APNConfiguration{ServiceSelection: "Internet"}}))
+	assert.NoError(t, authorizeProfile("1", "internet", &lteprotos.Non3GPPUserProfile{
+		ApnConfig: &lteprotos.APNConfiguration{ServiceSelection: AnyAPN}}))
+
+	err := authorizeProfile("1", "internet", &lteprotos.Non3GPPUserProfile{
+		ApnConfig: &lteprotos.APNConfiguration{ServiceSelection: "ims"}})
+	if assert.IsType(t, &RejectError{}, err) {
+		assert.Equal(t, ReasonNotAllowed, err.(*RejectError).Reason)
+	}
+	err = authorizeProfile("1", "internet", &lteprotos.Non3GPPUserProfile{
+		Non_3GppIpAccess: lteprotos.Non3GPPUserProfile_NON_3GPP_SUBSCRIPTION_BARRED})
+	if assert.IsType(t, &RejectError{}, err) {
+		assert.Equal(t, ReasonBarred, err.(*RejectError).Reason)
+	}
+	err = authorizeProfile("1", "internet", &lteprotos.Non3GPPUserProfile{
+		Non_3GppIpAccessApn: lteprotos.Non3GPPUserProfile_NON_3GPP_APNS_DISABLE})
+	if assert.IsType(t, &RejectError{}, err) {
+		assert.Equal(t, ReasonBarred, err.(*RejectError).Reason)
+	}
+}
@@ -0,0 +1,58 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package apnauth
+
+import (
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"magma/feg/gateway/registry"
+	"magma/feg/gateway/services/aaa/slo"
+	lteprotos "magma/lte/cloud/go/protos"
+)
+
+// SubscriberDB authorizes APNs based on subscribers' non 3GPP profiles in subscriberdb
+type SubscriberDB struct{}
+
+// Authorize implements Authorizer
+func (SubscriberDB) Authorize(ctx context.Context, imsi, apn string) error {
+	imsi = NormalizeIMSI(imsi)
+	conn, err := registry.GetConnection(registry.SUBSCRIBERDB)
+	if err != nil {
+		return &RejectError{IMSI: imsi, APN: apn, Reason: ReasonError, Err: err}
+	}
+	start := time.Now()
+	data, err := lteprotos.NewSubscriberDBClient(conn).GetSubscriberData(
+		ctx, &lteprotos.SubscriberID{Id: imsi, Type: lteprotos.SubscriberID_IMSI})
+	slo.Observe(slo.SubscriberDB, "GetSubscriberData", start, err)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return &RejectError{IMSI: imsi, APN: apn, Reason: ReasonUnknown}
+		}
+		return &RejectError{IMSI: imsi, APN: apn, Reason: ReasonError, Err: err}
+	}
+	return authorizeProfile(imsi, apn, data.GetNon_3Gpp())
+}
+
+// authorizeProfile authorizes the APN based on the subscriber's non 3GPP profile, a profile without
+// an APN configuration allows all APNs
+func authorizeProfile(imsi, apn string, profile *lteprotos.Non3GPPUserProfile) error {
+	if profile.GetNon_3GppIpAccess() == lteprotos.Non3GPPUserProfile_NON_3GPP_SUBSCRIPTION_BARRED ||
+		profile.GetNon_3GppIpAccessApn() == lteprotos.Non3GPPUserProfile_NON_3GPP_APNS_DISABLE {
+		return &RejectError{IMSI: imsi, APN: apn, Reason: ReasonBarred}
+	}
+	allowed := profile.GetApnConfig().GetServiceSelection()
+	if len(allowed) == 0 || matchAPN([]string{allowed}, apn) {
+		return nil
+	}
+	return &RejectError{IMSI: imsi, APN: apn, Reason: ReasonNotAllowed}
+}
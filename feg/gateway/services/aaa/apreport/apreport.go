@@ -0,0 +1,327 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package apreport aggregates the sessions' accounting by access point (AP): concurrent sessions, session churn &
+// throughput, & reports them every interval to orc8r, as AP metrics collected by magmad, & to an HTTP endpoint, so
+// network planning sees the Wi-Fi load without the AP vendors' controllers. APs are identified by the AP MAC of
+// their sessions' Called-Station-Id
+package apreport
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"magma/feg/gateway/services/aaa/audit"
+	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+// Defaults
+const (
+	DefaultIntervalSec = 300
+	DefaultTimeoutMs   = 10000
+)
+
+// Report results
+const (
+	reportSent   = "sent"
+	reportFailed = "failed"
+)
+
+// Config - AP capacity report configuration, reports are sent to the HTTP endpoint, orc8r or both
+type Config struct {
+	IntervalSec int    `json:"interval_sec"` // report interval
+	URL         string `json:"url"`          // HTTP endpoint accepting JSON encoded report POSTs, optional
+	Token       string `json:"token"`        // optional bearer token of the HTTP endpoint
+	TimeoutMs   int    `json:"timeout_ms"`   // HTTP endpoint call timeout
+	Orc8r       bool   `json:"orc8r"`        // report the AP metrics to orc8r
+	GatewayID   string `json:"gateway_id"`   // reporting gateway's ID, the host name if not set
+}
+
+// ReadConfig reads JSON AP capacity report configuration from the given file & applies defaults
+func ReadConfig(path string) (*Config, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &Config{}
+	if err = json.Unmarshal(b, cfg); err != nil {
+		return nil, fmt.Errorf("Invalid AP capacity report configuration %s: %v", path, err)
+	}
+	if len(cfg.URL) == 0 && !cfg.Orc8r {
+		return nil, fmt.Errorf("Invalid AP capacity report configuration %s: no url or orc8r destination", path)
+	}
+	if cfg.IntervalSec <= 0 {
+		cfg.IntervalSec = DefaultIntervalSec
+	}
+	if cfg.TimeoutMs <= 0 {
+		cfg.TimeoutMs = DefaultTimeoutMs
+	}
+	if len(cfg.GatewayID) == 0 {
+		cfg.GatewayID, _ = os.Hostname()
+	}
+	return cfg, nil
+}
+
+// AP - an AP's load over a report's interval
+type AP struct {
+	AP           string `json:"ap"`
+	Sessions     int    `json:"sessions"`      // concurrent sessions at the end of the interval
+	PeakSessions int    `json:"peak_sessions"` // maximum concurrent sessions
+	Starts       int    `json:"starts"`        // sessions started
+	Ends         int    `json:"ends"`          // sessions stopped, timed out or terminated
+	// ChurnPerMin - started & ended sessions per minute
+	ChurnPerMin float64 `json:"churn_per_min"`
+	OctetsIn    uint64  `json:"octets_in"`
+	OctetsOut   uint64  `json:"octets_out"`
+	// ThroughputInBps & ThroughputOutBps - average uplink & downlink throughput estimates, bits per second
+	ThroughputInBps  float64 `json:"throughput_in_bps"`
+	ThroughputOutBps float64 `json:"throughput_out_bps"`
+}
+
+// Report - the APs' load over the report's interval, APs without sessions or activity are omitted
+type Report struct {
+	GatewayID string    `json:"gateway_id,omitempty"`
+	Start     time.Time `json:"start"`
+	End       time.Time `json:"end"`
+	APs       []AP      `json:"aps"`
+}
+
+type session struct {
+	ap                  string
+	octetsIn, octetsOut uint64 // last reported accumulated usage
+}
+
+type load struct {
+	sessions, peak      int
+	starts, ends        int
+	octetsIn, octetsOut uint64
+}
+
+// Reporter aggregates the session events by AP, it implements audit.Sink
+type Reporter struct {
+	cfg    Config
+	client *http.Client
+
+	mu       sync.Mutex
+	start    time.Time
+	sessions map[string]*session // by session ID
+	aps      map[string]*load    // by AP
+}
+
+// NewReporter returns a new Reporter of the configuration, its reports are sent by Run
+func NewReporter(cfg Config) *Reporter {
+	return &Reporter{
+		cfg:      cfg,
+		client:   &http.Client{},
+		start:    time.Now(),
+		sessions: map[string]*session{},
+		aps:      map[string]*load{},
+	}
+}
+
+// apOf returns the AP of the Called-Station-Id: its AP MAC or, if it has none, the Called-Station-Id itself
+func apOf(calledStationID string) string {
+	if mac, ok := protos.APMAC(calledStationID); ok {
+		return mac
+	}
+	if len(calledStationID) == 0 {
+		return "unknown"
+	}
+	return calledStationID
+}
+
+// Log implements audit.Sink, Start & Import events add sessions to their AP, session end events remove them &
+// Interim-Updates add the sessions' usage since their previous event to their AP. Sessions roaming to another AP
+// are moved to it
+func (r *Reporter) Log(ev *audit.Event) error {
+	if ev == nil {
+		return nil
+	}
+	calledStationID := ev.Apn
+	if len(ev.CalledStationId) > 0 {
+		calledStationID = ev.CalledStationId // the APN is the canonical APN of the session's alias
+	}
+	ap := apOf(calledStationID)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s, ok := r.sessions[ev.SessionId]
+	switch ev.Type {
+	case audit.Start, audit.Import, audit.Interim:
+		if !ok {
+			// sessions of Interim-Updates without a Start were started before the AAA (re)started
+			s = &session{ap: ap, octetsIn: ev.OctetsIn, octetsOut: ev.OctetsOut}
+			r.sessions[ev.SessionId] = s
+			r.add(ap, ev.Type == audit.Start)
+			return nil
+		}
+		r.usage(s, ev)
+		if s.ap != ap {
+			r.remove(s.ap, false)
+			r.add(ap, false)
+			s.ap = ap
+		}
+	case audit.Stop, audit.Timeout, audit.Terminate, audit.Flush, audit.Export:
+		if !ok {
+			return nil
+		}
+		r.usage(s, ev)
+		r.remove(s.ap, ev.Type != audit.Export) // exported sessions continue on another gateway
+		delete(r.sessions, ev.SessionId)
+	}
+	return nil
+}
+
+func (r *Reporter) loadOf(ap string) *load {
+	l, ok := r.aps[ap]
+	if !ok {
+		l = &load{}
+		r.aps[ap] = l
+	}
+	return l
+}
+
+func (r *Reporter) add(ap string, start bool) {
+	l := r.loadOf(ap)
+	l.sessions++
+	if l.sessions > l.peak {
+		l.peak = l.sessions
+	}
+	if start {
+		l.starts++
+	}
+}
+
+func (r *Reporter) remove(ap string, end bool) {
+	l := r.loadOf(ap)
+	if l.sessions > 0 {
+		l.sessions--
+	}
+	if end {
+		l.ends++
+	}
+}
+
+// usage adds the session's usage since its previous event to its AP
+func (r *Reporter) usage(s *session, ev *audit.Event) {
+	l := r.loadOf(s.ap)
+	if ev.OctetsIn > s.octetsIn {
+		l.octetsIn += ev.OctetsIn - s.octetsIn
+	}
+	if ev.OctetsOut > s.octetsOut {
+		l.octetsOut += ev.OctetsOut - s.octetsOut
+	}
+	s.octetsIn, s.octetsOut = ev.OctetsIn, ev.OctetsOut
+}
+
+// Snapshot returns the report of the interval ending now & starts the next interval
+func (r *Reporter) Snapshot(now time.Time) *Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	report := &Report{GatewayID: r.cfg.GatewayID, Start: r.start, End: now, APs: []AP{}}
+	seconds := now.Sub(r.start).Seconds()
+	for ap, l := range r.aps {
+		if l.sessions == 0 && l.peak == 0 {
+			delete(r.aps, ap)
+			continue
+		}
+		entry := AP{
+			AP:           ap,
+			Sessions:     l.sessions,
+			PeakSessions: l.peak,
+			Starts:       l.starts,
+			Ends:         l.ends,
+			OctetsIn:     l.octetsIn,
+			OctetsOut:    l.octetsOut,
+		}
+		if seconds > 0 {
+			entry.ChurnPerMin = float64(l.starts+l.ends) * 60 / seconds
+			entry.ThroughputInBps = float64(l.octetsIn) * 8 / seconds
+			entry.ThroughputOutBps = float64(l.octetsOut) * 8 / seconds
+		}
+		report.APs = append(report.APs, entry)
+		*l = load{sessions: l.sessions, peak: l.sessions}
+	}
+	sort.Slice(report.APs, func(i, j int) bool { return report.APs[i].AP < report.APs[j].AP })
+	r.start = now
+	return report
+}
+
+// Run reports the APs' load every interval, it never returns
+func (r *Reporter) Run() {
+	for now := range time.Tick(time.Duration(r.cfg.IntervalSec) * time.Second) {
+		report := r.Snapshot(now)
+		if r.cfg.Orc8r {
+			setMetrics(report)
+		}
+		if len(r.cfg.URL) == 0 {
+			continue
+		}
+		if err := r.Send(report); err != nil {
+			metrics.APCapacityReports.WithLabelValues(reportFailed).Inc()
+			log.Printf("Error sending AP capacity report of %d APs: %v", len(report.APs), err)
+			continue
+		}
+		metrics.APCapacityReports.WithLabelValues(reportSent).Inc()
+	}
+}
+
+// Send POSTs the JSON encoded report to the HTTP endpoint
+func (r *Reporter) Send(report *Report) error {
+	body, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, r.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(r.cfg.Token) > 0 {
+		req.Header.Set("Authorization", "Bearer "+r.cfg.Token)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(r.cfg.TimeoutMs)*time.Millisecond)
+	defer cancel()
+	resp, err := r.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := ioutil.ReadAll(resp.Body)
+		if len(msg) > 512 {
+			msg = msg[:512]
+		}
+		return fmt.Errorf("POST %s: %s: %s", req.URL.Host, resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// setMetrics replaces the AP metrics with the report's, they're collected by magmad & sent to orc8r
+func setMetrics(report *Report) {
+	metrics.APSessions.Reset()
+	metrics.APChurn.Reset()
+	metrics.APThroughput.Reset()
+	for _, ap := range report.APs {
+		metrics.APSessions.WithLabelValues(ap.AP).Set(float64(ap.Sessions))
+		metrics.APChurn.WithLabelValues(ap.AP).Set(ap.ChurnPerMin)
+		metrics.APThroughput.WithLabelValues(ap.AP, "in").Set(ap.ThroughputInBps)
+		metrics.APThroughput.WithLabelValues(ap.AP, "out").Set(ap.ThroughputOutBps)
+	}
+}
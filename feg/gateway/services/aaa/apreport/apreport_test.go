@@ -0,0 +1,124 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package apreport
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"magma/feg/gateway/services/aaa/audit"
+)
+
+func event(typ audit.EventType, sid, apn string, octetsIn, octetsOut uint64) *audit.Event {
+	ev := audit.NewEvent(typ, sid, audit.Now(), audit.Timestamp{})
+	ev.Apn, ev.OctetsIn, ev.OctetsOut = apn, octetsIn, octetsOut
+	return ev
+}
+
+func TestReporter(t *testing.T) {
+	r := NewReporter(Config{GatewayID: "gw1"})
+	start := r.start
+	for _, ev := range []*audit.Event{
+		event(audit.Start, "sid1", "00-11-22-AA-BB-CC:magma.wifi", 0, 0),
+		event(audit.Start, "sid2", "00:11:22:aa:bb:cc", 0, 0),
+		event(audit.Start, "sid2", "00:11:22:aa:bb:cc", 0, 0), // duplicate Start of a session created on auth
+		event(audit.Interim, "sid1", "00-11-22-AA-BB-CC:magma.wifi", 1000, 2000),
+		event(audit.Interim, "sid1", "00-11-22-AA-BB-CC:magma.wifi", 1500, 4000),
+		event(audit.Stop, "sid2", "00:11:22:aa:bb:cc", 100, 200),
+		// a session started before the AAA restarted
+		event(audit.Interim, "sid3", "nas1", 5000, 5000),
+		event(audit.Interim, "sid3", "nas1", 6000, 5000),
+		event(audit.Timeout, "unknown", "nas1", 100, 100),
+		nil,
+	} {
+		assert.NoError(t, r.Log(ev))
+	}
+	report := r.Snapshot(start.Add(time.Minute))
+	assert.Equal(t, "gw1", report.GatewayID)
+	assert.Equal(t, start, report.Start)
+	assert.Equal(t, []AP{
+		{
+			AP:               "001122AABBCC",
+			Sessions:         1,
+			PeakSessions:     2,
+			Starts:           2,
+			Ends:             1,
+			ChurnPerMin:      3,
+			OctetsIn:         1600,
+			OctetsOut:        4200,
+			ThroughputInBps:  1600 * 8 / 60.,
+			ThroughputOutBps: 4200 * 8 / 60.,
+		},
+		{AP: "nas1", Sessions: 1, PeakSessions: 1, OctetsIn: 1000, ThroughputInBps: 1000 * 8 / 60.},
+	}, report.APs)
+
+	// the session roams to another AP & the other session ends
+	assert.NoError(t, r.Log(event(audit.Interim, "sid1", "00:11:22:aa:bb:dd:magma.wifi", 2500, 4000)))
+	assert.NoError(t, r.Log(event(audit.Terminate, "sid3", "nas1", 6000, 5000)))
+	report = r.Snapshot(start.Add(2 * time.Minute))
+	assert.Equal(t, []AP{
+		{AP: "001122AABBCC", PeakSessions: 1, OctetsIn: 1000, ThroughputInBps: 1000 * 8 / 60.},
+		{AP: "001122AABBDD", Sessions: 1, PeakSessions: 1},
+		{AP: "nas1", PeakSessions: 1, Ends: 1, ChurnPerMin: 1},
+	}, report.APs)
+
+	// APs without sessions & activity are omitted
+	report = r.Snapshot(start.Add(3 * time.Minute))
+	assert.Equal(t, []AP{{AP: "001122AABBDD", Sessions: 1, PeakSessions: 1}}, report.APs)
+}
+
+func TestSend(t *testing.T) {
+	var received Report
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&received))
+	}))
+	defer server.Close()
+
+	r := NewReporter(Config{URL: server.URL, Token: "secret", TimeoutMs: 1000, GatewayID: "gw1"})
+	assert.NoError(t, r.Log(event(audit.Start, "sid1", "00:11:22:aa:bb:cc", 0, 0)))
+	assert.NoError(t, r.Send(r.Snapshot(time.Now())))
+	assert.Equal(t, "gw1", received.GatewayID)
+	if assert.Len(t, received.APs, 1) {
+		assert.Equal(t, "001122AABBCC", received.APs[0].AP)
+		assert.Equal(t, 1, received.APs[0].Sessions)
+		assert.Equal(t, 1, received.APs[0].Starts)
+	}
+
+	r.cfg.Token = "wrong"
+	assert.Error(t, r.Send(r.Snapshot(time.Now())))
+}
+
+func TestReadConfig(t *testing.T) {
+	f, err := ioutil.TempFile("", "apreport")
+	assert.NoError(t, err)
+	defer os.Remove(f.Name())
+
+	_, err = f.WriteString(`{"orc8r": true, "url": "https://analytics.example.com/ap_capacity"}`)
+	assert.NoError(t, err)
+	cfg, err := ReadConfig(f.Name())
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultIntervalSec, cfg.IntervalSec)
+	assert.Equal(t, DefaultTimeoutMs, cfg.TimeoutMs)
+	assert.NotEmpty(t, cfg.GatewayID)
+
+	assert.NoError(t, ioutil.WriteFile(f.Name(), []byte(`{"interval_sec": 60}`), 0600))
+	_, err = ReadConfig(f.Name())
+	assert.Error(t, err, "no destinations")
+}
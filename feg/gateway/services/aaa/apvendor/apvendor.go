@@ -0,0 +1,197 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package apvendor fingerprints the vendors of the APs (NASes) of AAA calls & counts failed calls by vendor, so
+// vendor specific interop problems of mixed AP fleets can be pinpointed. The vendor is the NAS provided
+// ap_vendor context attribute if present, otherwise it's derived from the OUI of the Called-Station-Id MAC
+package apvendor
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/aaa/protos"
+	"magma/feg/gateway/services/eap"
+)
+
+const (
+	// Attribute - context attribute carrying the AP vendor, e.g. propagated from a NAS Vendor-Specific attribute
+	Attribute = "ap_vendor"
+	// Unknown - vendor of APs with unknown OUIs & without the vendor attribute
+	Unknown = "unknown"
+
+	maxVendorLen = 32
+)
+
+// DefaultOUIs - OUIs of common Wi-Fi AP vendors
+var DefaultOUIs = map[string]string{
+	"00:0B:85": "cisco",
+	"00:18:0A": "meraki",
+	"88:15:44": "meraki",
+	"E0:55:3D": "meraki",
+	"00:0B:86": "aruba",
+	"00:1A:1E": "aruba",
+	"24:DE:C6": "aruba",
+	"6C:F3:7F": "aruba",
+	"94:B4:0F": "aruba",
+	"D8:C7:C8": "aruba",
+	"00:24:82": "ruckus",
+	"2C:5D:93": "ruckus",
+	"58:B6:33": "ruckus",
+	"04:18:D6": "ubiquiti",
+	"24:A4:3C": "ubiquiti",
+	"44:D9:E7": "ubiquiti",
+	"78:8A:20": "ubiquiti",
+	"80:2A:A8": "ubiquiti",
+	"F0:9F:C2": "ubiquiti",
+	"FC:EC:DA": "ubiquiti",
+	"00:04:56": "cambium",
+	"58:C1:7A": "cambium",
+}
+
+var (
+	mu   sync.RWMutex
+	ouis = normalizeOUIs(DefaultOUIs)
+)
+
+// SetOUIs adds the given OUI to vendor mappings to DefaultOUIs, the given mappings take precedence
+func SetOUIs(vendors map[string]string) error {
+	table := normalizeOUIs(DefaultOUIs)
+	for oui, vendor := range vendors {
+		key, ok := parseOUI(oui)
+		if !ok {
+			return fmt.Errorf("Invalid OUI: '%s'", oui)
+		}
+		if !validVendor(vendor) {
+			return fmt.Errorf("Invalid vendor '%s' of OUI %s", vendor, oui)
+		}
+		table[key] = strings.ToLower(vendor)
+	}
+	mu.Lock()
+	ouis = table
+	mu.Unlock()
+	return nil
+}
+
+// ReadOUIs reads OUI to vendor mappings from the given JSON file, e.g. {"00:0B:86": "aruba"}
+func ReadOUIs(path string) (map[string]string, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	vendors := map[string]string{}
+	if err = json.Unmarshal(b, &vendors); err != nil {
+		return nil, fmt.Errorf("Invalid AP vendors OUIs %s: %v", path, err)
+	}
+	return vendors, nil
+}
+
+// Vendor returns the AP vendor of the AAA context
+func Vendor(aaaCtx *protos.Context) string {
+	if v, ok := aaaCtx.GetAttribute(Attribute); ok && validVendor(v) {
+		return strings.ToLower(v)
+	}
+	// Called-Station-Id: <AP MAC>[:<SSID>]
+	oui, ok := parseOUI(aaaCtx.CalledStationID())
+	if !ok {
+		return Unknown
+	}
+	mu.RLock()
+	vendor, ok := ouis[oui]
+	mu.RUnlock()
+	if !ok {
+		return Unknown
+	}
+	return vendor
+}
+
+// UnaryServerInterceptor counts failed calls by the AP vendor of their requests' contexts, EAP responses with
+// Failure code are failed calls as well
+func UnaryServerInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+
+	resp, err := handler(ctx, req)
+	var code string
+	if err != nil {
+		code = status.Code(err).String()
+	} else if e, ok := resp.(*protos.Eap); ok && eap.Packet(e.GetPayload()).Code() == eap.FailureCode {
+		code = protos.EapCode_Failure.String()
+	} else {
+		return resp, err
+	}
+	metrics.VendorErrors.WithLabelValues(Vendor(requestContext(req)), path.Base(info.FullMethod), code).Inc()
+	return resp, err
+}
+
+// requestContext returns the AAA context of the request, nil if the request has none
+func requestContext(req interface{}) *protos.Context {
+	switch r := req.(type) {
+	case *protos.Context:
+		return r
+	case interface{ GetCtx() *protos.Context }:
+		return r.GetCtx()
+	}
+	return nil
+}
+
+// parseOUI returns the upper case, colon separated OUI of the MAC address prefix of s
+func parseOUI(s string) (string, bool) {
+	var digits []byte
+	for i := 0; i < len(s) && len(digits) < 6; i++ {
+		c := s[i]
+		switch {
+		case c >= '0' && c <= '9', c >= 'A' && c <= 'F':
+			digits = append(digits, c)
+		case c >= 'a' && c <= 'f':
+			digits = append(digits, c-'a'+'A')
+		case c == ':' || c == '-' || c == '.':
+		default:
+			return "", false
+		}
+	}
+	if len(digits) < 6 {
+		return "", false
+	}
+	return fmt.Sprintf("%s:%s:%s", digits[0:2], digits[2:4], digits[4:6]), true
+}
+
+func normalizeOUIs(vendors map[string]string) map[string]string {
+	table := make(map[string]string, len(vendors))
+	for oui, vendor := range vendors {
+		if key, ok := parseOUI(oui); ok {
+			table[key] = vendor
+		}
+	}
+	return table
+}
+
+// validVendor returns true if the vendor is a short name of letters, digits, '-' & '_', so the metrics cardinality
+// stays bounded
+func validVendor(vendor string) bool {
+	if len(vendor) == 0 || len(vendor) > maxVendorLen {
+		return false
+	}
+	for _, c := range vendor {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,44 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package apvendor_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"magma/feg/gateway/services/aaa/apvendor"
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+func TestVendor(t *testing.T) {
+	defer apvendor.SetOUIs(nil)
+
+	assert.Equal(t, "aruba", apvendor.Vendor(&protos.Context{Apn: "24-DE-C6-01-02-03:magma-wifi"}))
+	assert.Equal(t, "aruba", apvendor.Vendor(&protos.Context{Apn: "24:de:c6:01:02:03"}))
+	assert.Equal(t, "ubiquiti", apvendor.Vendor(&protos.Context{Apn: "f09fc2010203:guest"}))
+	assert.Equal(t, apvendor.Unknown, apvendor.Vendor(&protos.Context{Apn: "12-34-56-01-02-03:magma-wifi"}))
+	assert.Equal(t, apvendor.Unknown, apvendor.Vendor(&protos.Context{Apn: "magma-wifi"}))
+	assert.Equal(t, apvendor.Unknown, apvendor.Vendor(nil))
+
+	// the NAS attribute takes precedence, invalid vendor names are ignored
+	ctx := &protos.Context{Apn: "24-DE-C6-01-02-03:magma-wifi", Attributes: map[string]string{apvendor.Attribute: "Acme"}}
+	assert.Equal(t, "acme", apvendor.Vendor(ctx))
+	ctx.Attributes[apvendor.Attribute] = "acme inc."
+	assert.Equal(t, "aruba", apvendor.Vendor(ctx))
+
+	// operator's OUIs extend & override the defaults
+	assert.NoError(t, apvendor.SetOUIs(map[string]string{"12-34-56": "acme", "24:DE:C6": "hpe"}))
+	assert.Equal(t, "acme", apvendor.Vendor(&protos.Context{Apn: "12-34-56-01-02-03:magma-wifi"}))
+	assert.Equal(t, "hpe", apvendor.Vendor(&protos.Context{Apn: "24-DE-C6-01-02-03:magma-wifi"}))
+	assert.Equal(t, "ubiquiti", apvendor.Vendor(&protos.Context{Apn: "F0-9F-C2-01-02-03"}))
+
+	assert.Error(t, apvendor.SetOUIs(map[string]string{"12-34": "acme"}))
+	assert.Error(t, apvendor.SetOUIs(map[string]string{"12-34-56": "acme inc."}))
+}
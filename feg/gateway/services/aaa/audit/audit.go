@@ -0,0 +1,226 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package audit implements the audit log of authentications & session lifecycle events.
+//
+// Every event carries a wall clock timestamp for reports & a monotonic clock reading for durations. Durations are
+// always computed from the monotonic readings, so they are not affected by wall clock adjustments (NTP steps,
+// manual changes). Monotonic readings are offsets from the process start & only comparable within the same
+// process run, identified by the events' process_start field
+package audit
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"magma/feg/gateway/services/aaa/coalog"
+	"magma/feg/gateway/services/aaa/fingerprint"
+)
+
+// processStart is the origin of the monotonic readings
+var processStart = time.Now()
+
+// Timestamp - wall clock time paired with the monotonic clock reading
+type Timestamp struct {
+	Wall time.Time     // wall clock time, monotonic reading stripped
+	Mono time.Duration // monotonic time since the process start
+}
+
+// Now returns the current Timestamp
+func Now() Timestamp {
+	now := time.Now()
+	return Timestamp{Wall: now.Round(0), Mono: now.Sub(processStart)}
+}
+
+// Sub returns the monotonic duration t-u
+func (t Timestamp) Sub(u Timestamp) time.Duration {
+	return t.Mono - u.Mono
+}
+
+// IsZero returns true for the zero Timestamp
+func (t Timestamp) IsZero() bool {
+	return t.Wall.IsZero() && t.Mono == 0
+}
+
+// EventType - authentication or session lifecycle event type
+type EventType string
+
+const (
+	Start      EventType = "start"      // accounting started
+	Interim    EventType = "interim"    // Interim-Update received
+	Stop       EventType = "stop"       // accounting stopped by the NAS
+	Timeout    EventType = "timeout"    // idle timeout or eviction
+	Terminate  EventType = "terminate"  // terminated by session manager or a policy
+	Clone      EventType = "clone"      // security event: the session's IMSI is used by another UE
+	Patch      EventType = "patch"      // the session's context was changed by an operator
+	Export     EventType = "export"     // the session was exported & released for a migration to another gateway
+	Import     EventType = "import"     // the session was imported from another gateway
+	Quarantine EventType = "quarantine" // security event: the session was moved to the quarantine profile
+	Flush      EventType = "flush"      // the session's NAS sent Accounting-On/Off, its sessions are gone
+	Rebind     EventType = "rebind"     // the session's accounting arrived from another NAS host
+	CoA        EventType = "coa"        // a CoA or Disconnect-Request was sent to the session's NAS
+)
+
+// Authentication events & their outcomes
+const (
+	AuthSuccess EventType = "auth_success" // the session was authenticated
+	AuthFailure EventType = "auth_failure" // the session's authentication failed
+
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
+)
+
+// Event - audit log record
+type Event struct {
+	Type      EventType `json:"event"`
+	SessionId string    `json:"session_id"`
+	Imsi      string    `json:"imsi,omitempty"`
+	MacAddr   string    `json:"mac_addr,omitempty"`
+	Apn       string    `json:"apn,omitempty"`
+	IpAddr    string    `json:"ip_addr,omitempty"`
+	Ipv6Addr  string    `json:"ipv6_addr,omitempty"`
+	// CalledStationId - the session's original Called-Station-Id, if its APN is the canonical APN of an alias
+	CalledStationId string `json:"called_station_id,omitempty"`
+	// OperatorName - RFC 5580 Operator-Name of the operator serving the session, e.g. a wholesale Wi-Fi partner
+	OperatorName string `json:"operator_name,omitempty"`
+
+	Time   time.Time `json:"time"`
+	MonoNs int64     `json:"mono_ns"`
+
+	// SessionStart & SessionStartMonoNs - the session's Start event timestamps, if known
+	SessionStart       *time.Time `json:"session_start,omitempty"`
+	SessionStartMonoNs int64      `json:"session_start_mono_ns,omitempty"`
+	// DurationNs & Duration - monotonic session duration at the event, if the session's start is known
+	DurationNs int64  `json:"duration_ns,omitempty"`
+	Duration   string `json:"duration,omitempty"`
+
+	OctetsIn  uint64 `json:"octets_in,omitempty"`
+	OctetsOut uint64 `json:"octets_out,omitempty"`
+
+	// Outcome, LatencyNs & Latency - the authentication's outcome & its request's handling latency, auth events only.
+	// Auth failures' Reason is their cause, if known
+	Outcome   string `json:"outcome,omitempty"`
+	LatencyNs int64  `json:"latency_ns,omitempty"`
+	Latency   string `json:"latency,omitempty"`
+
+	// Operator, Reason & Changes - who changed the session's context, why & what was changed, Patch events only.
+	// Quarantine events' Reason is their security trigger, Terminate events of operators' terminations have their
+	// Operator & Reason, Rebind events' Changes are their NAS address change
+	Operator string   `json:"operator,omitempty"`
+	Reason   string   `json:"reason,omitempty"`
+	Changes  []Change `json:"changes,omitempty"`
+
+	// Device - the session's device classification hint, if known
+	Device *fingerprint.Hint `json:"device,omitempty"`
+
+	// MultiSession - the logical accounting record of the session's multi-session, if the NAS groups its sessions
+	MultiSession *MultiSession `json:"multi_session,omitempty"`
+
+	// CoA - the sent request's transaction, CoA events only
+	CoA *coalog.Transaction `json:"coa,omitempty"`
+
+	// Enrichment - the fields appended by the operator's enrichment callout, exported events only
+	Enrichment map[string]string `json:"enrichment,omitempty"`
+
+	ProcessStart time.Time `json:"process_start"`
+}
+
+// Change - a changed session context field
+type Change struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// MultiSession - the cumulative usage of the sessions of a NAS multi-session (Acct-Multi-Session-Id), e.g. of a UE's
+// fast roams across the APs of one ESS. The record of the multi-session's last event is its billed usage
+type MultiSession struct {
+	Id        string `json:"id"`
+	Sessions  int    `json:"sessions"` // the multi-session's sessions so far, ended ones included
+	OctetsIn  uint64 `json:"octets_in"`
+	OctetsOut uint64 `json:"octets_out"`
+}
+
+// NewEvent returns a new event of the given type at the given time of a session started at the given start time,
+// the zero start if unknown
+func NewEvent(typ EventType, sid string, at, start Timestamp) *Event {
+	ev := &Event{
+		Type:         typ,
+		SessionId:    sid,
+		Time:         at.Wall,
+		MonoNs:       int64(at.Mono),
+		ProcessStart: processStart.Round(0),
+	}
+	if !start.IsZero() {
+		wall := start.Wall
+		ev.SessionStart = &wall
+		ev.SessionStartMonoNs = int64(start.Mono)
+		d := at.Sub(start)
+		ev.DurationNs = int64(d)
+		ev.Duration = d.String()
+	}
+	return ev
+}
+
+// SetLatency sets the event's request handling latency
+func (ev *Event) SetLatency(d time.Duration) {
+	ev.LatencyNs = int64(d)
+	ev.Latency = d.String()
+}
+
+// Sink - a destination of audit events
+type Sink interface {
+	Log(ev *Event) error
+}
+
+// Sinks fans events out to all its sinks
+type Sinks []Sink
+
+// Log implements Sink, all sinks get the event, the first error is returned
+func (sinks Sinks) Log(ev *Event) error {
+	var first error
+	for _, s := range sinks {
+		if err := s.Log(ev); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// Logger writes audit events as JSON lines
+type Logger struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewLogger returns a new Logger writing into w
+func NewLogger(w io.Writer) *Logger {
+	return &Logger{enc: json.NewEncoder(w)}
+}
+
+// Open returns a new Logger appending to the given file
+func Open(path string) (*Logger, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0640)
+	if err != nil {
+		return nil, err
+	}
+	return NewLogger(f), nil
+}
+
+// Log writes the event, a nil Logger discards all events
+func (l *Logger) Log(ev *Event) error {
+	if l == nil || ev == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.enc.Encode(ev)
+}
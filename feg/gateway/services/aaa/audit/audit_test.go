@@ -0,0 +1,107 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package audit_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"magma/feg/gateway/services/aaa/audit"
+)
+
+func TestEventDuration(t *testing.T) {
+	start := audit.Now()
+	time.Sleep(time.Millisecond)
+	now := audit.Now()
+	// Simulate a wall clock step back, the duration must still be based on the monotonic readings
+	now.Wall = now.Wall.Add(-time.Hour)
+
+	ev := audit.NewEvent(audit.Stop, "sid1", now, start)
+	assert.Equal(t, int64(now.Sub(start)), ev.DurationNs)
+	assert.True(t, ev.DurationNs >= int64(time.Millisecond))
+	assert.Equal(t, now.Sub(start).String(), ev.Duration)
+	if assert.NotNil(t, ev.SessionStart) {
+		assert.True(t, ev.SessionStart.After(ev.Time))
+	}
+
+	ev = audit.NewEvent(audit.Interim, "sid2", now, audit.Timestamp{})
+	assert.Nil(t, ev.SessionStart)
+	assert.Zero(t, ev.DurationNs)
+}
+
+func TestLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := audit.NewLogger(&buf)
+	start := audit.Now()
+	ev := audit.NewEvent(audit.Stop, "sid1", audit.Now(), start)
+	ev.Imsi, ev.OctetsIn = "001010000000001", 100
+	assert.NoError(t, l.Log(ev))
+
+	var logged map[string]interface{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &logged))
+	assert.Equal(t, "stop", logged["event"])
+	assert.Equal(t, "sid1", logged["session_id"])
+	assert.Contains(t, logged, "time")
+	assert.Contains(t, logged, "mono_ns")
+	assert.Contains(t, logged, "session_start")
+	assert.Contains(t, logged, "session_start_mono_ns")
+	assert.Contains(t, logged, "process_start")
+
+	var nilLogger *audit.Logger
+	assert.NoError(t, nilLogger.Log(ev))
+}
+
+func TestFilter(t *testing.T) {
+	types, err := audit.ParseEventTypes([]string{"auth_failure", " Stop", ""})
+	assert.NoError(t, err)
+	assert.Equal(t, map[audit.EventType]bool{audit.AuthFailure: true, audit.Stop: true}, types)
+	_, err = audit.ParseEventTypes([]string{"start", "login"})
+	assert.Error(t, err)
+
+	var buf bytes.Buffer
+	l := audit.NewLogger(&buf)
+	assert.True(t, audit.NewFilter(l, nil) == audit.Sink(l), "no types - all events pass")
+	f := audit.NewFilter(l, types)
+	assert.NoError(t, f.Log(audit.NewEvent(audit.AuthSuccess, "sid1", audit.Now(), audit.Timestamp{})))
+	assert.NoError(t, f.Log(audit.NewEvent(audit.Start, "sid1", audit.Now(), audit.Timestamp{})))
+	assert.Zero(t, buf.Len())
+	assert.NoError(t, f.Log(audit.NewEvent(audit.AuthFailure, "sid2", audit.Now(), audit.Timestamp{})))
+	var logged map[string]interface{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &logged))
+	assert.Equal(t, "auth_failure", logged["event"])
+}
+
+func TestZapSink(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	s := audit.NewZapSink(zap.New(core))
+	ev := audit.NewEvent(audit.AuthFailure, "sid1", audit.Now(), audit.Timestamp{})
+	ev.Imsi, ev.MacAddr, ev.Outcome = "001010000000001", "0a:1b:2c:3d:4e:5f", audit.OutcomeFailure
+	ev.SetLatency(5 * time.Millisecond)
+	assert.NoError(t, s.Log(ev))
+
+	entries := logs.All()
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, zapcore.WarnLevel, entries[0].Level, "auth failures are warnings")
+		fields := entries[0].ContextMap()
+		assert.Equal(t, "auth_failure", fields["event"])
+		assert.Equal(t, "sid1", fields["session_id"])
+		assert.Equal(t, "001010000000001", fields["imsi"])
+		assert.Equal(t, "0a:1b:2c:3d:4e:5f", fields["mac_addr"])
+		assert.Equal(t, "failure", fields["outcome"])
+		assert.Equal(t, 5*time.Millisecond, fields["latency"])
+		assert.NotContains(t, fields, "apn")
+	}
+}
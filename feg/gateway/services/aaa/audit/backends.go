@@ -0,0 +1,159 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/syslog"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Audit log backends
+const (
+	FileBackend   = "file"   // JSON lines of the rotating audit log file
+	SyslogBackend = "syslog" // JSON records of the local syslog's auth facility
+	ZapBackend    = "zap"    // structured records of the service's zap logger
+)
+
+// SyslogTag - syslog tag of the audit records
+const SyslogTag = "aaa_audit"
+
+// eventTypes - all known event types
+var eventTypes = map[EventType]bool{
+	AuthSuccess: true, AuthFailure: true, Start: true, Interim: true, Stop: true, Timeout: true, Terminate: true,
+	Clone: true, Patch: true, Export: true, Import: true, Quarantine: true, Flush: true, Rebind: true, CoA: true,
+}
+
+// ParseEventTypes returns the set of the named event types, names are case insensitive
+func ParseEventTypes(names []string) (map[EventType]bool, error) {
+	res := map[EventType]bool{}
+	for _, name := range names {
+		typ := EventType(strings.ToLower(strings.TrimSpace(name)))
+		if len(typ) == 0 {
+			continue
+		}
+		if !eventTypes[typ] {
+			return nil, fmt.Errorf("unknown audit event type '%s'", name)
+		}
+		res[typ] = true
+	}
+	return res, nil
+}
+
+// Filter passes the events of the given types to its sink & drops all others
+type Filter struct {
+	types map[EventType]bool
+	sink  Sink
+}
+
+// NewFilter returns a Filter of the sink's events, empty types - all events pass, the sink is returned as is
+func NewFilter(sink Sink, types map[EventType]bool) Sink {
+	if len(types) == 0 {
+		return sink
+	}
+	return &Filter{types: types, sink: sink}
+}
+
+// Log implements Sink
+func (f *Filter) Log(ev *Event) error {
+	if ev == nil || !f.types[ev.Type] {
+		return nil
+	}
+	return f.sink.Log(ev)
+}
+
+// SyslogSink writes audit events as JSON records into the local syslog
+type SyslogSink struct {
+	w *syslog.Writer
+}
+
+// NewSyslogSink returns a new SyslogSink of the auth facility with the given tag
+func NewSyslogSink(tag string) (*SyslogSink, error) {
+	w, err := syslog.New(syslog.LOG_INFO|syslog.LOG_AUTH, tag)
+	if err != nil {
+		return nil, err
+	}
+	return &SyslogSink{w: w}, nil
+}
+
+// Log implements Sink, auth failures are logged at warning level
+func (s *SyslogSink) Log(ev *Event) error {
+	if ev == nil {
+		return nil
+	}
+	rec, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	if ev.Type == AuthFailure {
+		return s.w.Warning(string(rec))
+	}
+	return s.w.Info(string(rec))
+}
+
+// ZapSink writes audit events as structured records of a zap logger
+type ZapSink struct {
+	log *zap.Logger
+}
+
+// NewZapSink returns a new ZapSink of the logger
+func NewZapSink(log *zap.Logger) *ZapSink {
+	return &ZapSink{log: log}
+}
+
+// NewProductionZapSink returns a ZapSink of JSON records to stderr
+func NewProductionZapSink() (*ZapSink, error) {
+	cfg := zap.NewProductionConfig()
+	cfg.Sampling = nil // every event is logged
+	cfg.DisableStacktrace = true
+	log, err := cfg.Build()
+	if err != nil {
+		return nil, err
+	}
+	return NewZapSink(log.Named("audit")), nil
+}
+
+// Log implements Sink, auth failures are logged at warn level
+func (s *ZapSink) Log(ev *Event) error {
+	if ev == nil {
+		return nil
+	}
+	fields := []zap.Field{
+		zap.String("event", string(ev.Type)),
+		zap.String("session_id", ev.SessionId),
+		zap.Time("time", ev.Time),
+	}
+	for _, f := range []struct{ key, value string }{
+		{"imsi", ev.Imsi}, {"mac_addr", ev.MacAddr}, {"apn", ev.Apn}, {"outcome", ev.Outcome},
+		{"operator", ev.Operator}, {"reason", ev.Reason}} {
+
+		if len(f.value) > 0 {
+			fields = append(fields, zap.String(f.key, f.value))
+		}
+	}
+	if ev.LatencyNs > 0 {
+		fields = append(fields, zap.Duration("latency", time.Duration(ev.LatencyNs)))
+	}
+	if ev.DurationNs > 0 {
+		fields = append(fields, zap.Duration("duration", time.Duration(ev.DurationNs)))
+	}
+	if ev.OctetsIn > 0 || ev.OctetsOut > 0 {
+		fields = append(fields, zap.Uint64("octets_in", ev.OctetsIn), zap.Uint64("octets_out", ev.OctetsOut))
+	}
+	if ev.Type == AuthFailure {
+		s.log.Warn("audit", fields...)
+	} else {
+		s.log.Info("audit", fields...)
+	}
+	return nil
+}
@@ -0,0 +1,213 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package bench_test
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+
+	"magma/feg/cloud/go/protos/mconfig"
+	"magma/feg/gateway/services/aaa/bench"
+	"magma/feg/gateway/services/aaa/protos"
+	"magma/feg/gateway/services/aaa/servicers"
+	"magma/feg/gateway/services/aaa/store"
+	"magma/feg/gateway/services/eap"
+	"magma/feg/gateway/services/eap/client"
+	aka_servicers "magma/feg/gateway/services/eap/providers/aka/servicers"
+	_ "magma/feg/gateway/services/eap/providers/aka/servicers/handlers"
+	eap_test "magma/feg/gateway/services/eap/test"
+)
+
+const sessionTimeout = time.Hour
+
+func TestProfilesAreReproducible(t *testing.T) {
+	for _, p := range bench.Profiles {
+		first, second := p.Sessions(), p.Sessions()
+		assert.Len(t, first, p.Subscribers*p.SessionsPerSubscriber, p.Name)
+		assert.Equal(t, first, second, p.Name)
+		last := first[len(first)-1].Interims
+		assert.Len(t, last, p.InterimsPerSession, p.Name)
+		assert.True(t, last[len(last)-1].GetOctetsOut() >= last[0].GetOctetsOut(), "cumulative usage of %s", p.Name)
+	}
+}
+
+// BenchmarkSessionTable measures an add, get, find by IMSI & remove of a session in a table loaded with the
+// profile's other sessions
+func BenchmarkSessionTable(b *testing.B) {
+	for _, p := range bench.Profiles {
+		b.Run(p.Name, func(b *testing.B) {
+			sessions := p.Sessions()
+			st := store.NewMemorySessionTable()
+			for _, s := range sessions[1:] {
+				if _, err := st.AddSession(s.Ctx, sessionTimeout, nil); err != nil {
+					b.Fatal(err)
+				}
+			}
+			aaaCtx := sessions[0].Ctx
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := st.AddSession(aaaCtx, sessionTimeout, nil); err != nil {
+					b.Fatal(err)
+				}
+				st.GetSession(aaaCtx.GetSessionId())
+				st.FindSession(aaaCtx.GetImsi())
+				st.RemoveSession(aaaCtx.GetSessionId())
+			}
+		})
+	}
+}
+
+// BenchmarkSessionTableParallel measures concurrent gets & timeout resets of the profile's sessions
+func BenchmarkSessionTableParallel(b *testing.B) {
+	for _, p := range bench.Profiles {
+		b.Run(p.Name, func(b *testing.B) {
+			sessions := p.Sessions()
+			st := store.NewMemorySessionTable()
+			for _, s := range sessions {
+				if _, err := st.AddSession(s.Ctx, sessionTimeout, nil); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for i := 0; pb.Next(); i++ {
+					sid := sessions[i%len(sessions)].Ctx.GetSessionId()
+					st.GetSession(sid)
+					st.SetTimeout(sid, sessionTimeout, nil)
+				}
+			})
+		})
+	}
+}
+
+// BenchmarkSessionTableLatency measures concurrent Interim-Update session lookups, locks & timeout resets of the
+// profile's sessions, while every 100th operation removes & re-adds a session, in single shard & sharded tables.
+// The p99 latency of the operations is reported as p99-ns
+func BenchmarkSessionTableLatency(b *testing.B) {
+	for _, p := range bench.Profiles {
+		for _, shards := range []int{1, store.DefaultShards} {
+			b.Run(fmt.Sprintf("%s/shards_%d", p.Name, shards), func(b *testing.B) {
+				sessions := p.Sessions()
+				st, err := store.NewShardedMemorySessionTable(shards, store.Limits{})
+				if err != nil {
+					b.Fatal(err)
+				}
+				for _, s := range sessions {
+					if _, err = st.AddSession(s.Ctx, sessionTimeout, nil); err != nil {
+						b.Fatal(err)
+					}
+				}
+				var (
+					mu        sync.Mutex
+					latencies []time.Duration
+				)
+				b.ReportAllocs()
+				b.ResetTimer()
+				b.RunParallel(func(pb *testing.PB) {
+					var local []time.Duration
+					for i := 0; pb.Next(); i++ {
+						aaaCtx := sessions[(i*7919)%len(sessions)].Ctx
+						start := time.Now()
+						if i%100 == 0 {
+							st.RemoveSession(aaaCtx.GetSessionId())
+							st.AddSession(aaaCtx, sessionTimeout, nil) // unless re-added by another routine
+						} else if s := st.GetSession(aaaCtx.GetSessionId()); s != nil {
+							st.SetTimeout(s.GetCtx().GetSessionId(), sessionTimeout, nil)
+						}
+						local = append(local, time.Since(start))
+					}
+					mu.Lock()
+					latencies = append(latencies, local...)
+					mu.Unlock()
+				})
+				b.StopTimer()
+				if len(latencies) > 0 {
+					sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+					b.ReportMetric(float64(latencies[len(latencies)*99/100]), "p99-ns")
+				}
+			})
+		}
+	}
+}
+
+// BenchmarkInterimUpdate measures the handling of the profile's Interim-Updates, round robin over its sessions
+func BenchmarkInterimUpdate(b *testing.B) {
+	for _, p := range bench.Profiles {
+		b.Run(p.Name, func(b *testing.B) {
+			sessions := p.Sessions()
+			st := store.NewMemorySessionTable()
+			for _, s := range sessions {
+				if _, err := st.AddSession(s.Ctx, sessionTimeout, nil); err != nil {
+					b.Fatal(err)
+				}
+			}
+			srv, err := servicers.NewAccountingService(st, &mconfig.AAAConfig{IdleSessionTimeoutMs: 3600000})
+			if err != nil {
+				b.Fatal(err)
+			}
+			ctx := context.Background()
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				s := sessions[i%len(sessions)]
+				interim := s.Interims[(i/len(sessions))%len(s.Interims)]
+				if _, err := srv.InterimUpdate(ctx, interim); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkEapIdentity measures the parsing of an EAP-Response/Identity & the EAP-AKA identity request response
+func BenchmarkEapIdentity(b *testing.B) {
+	srv, err := aka_servicers.NewEapAkaService(nil)
+	if err != nil {
+		b.Fatal(err)
+	}
+	identity := eap.NewPacket(eap.ResponseCode, 1,
+		append([]byte{client.EapMethodIdentity}, []byte("0001010000000055@wlan.mnc001.mcc001.3gppnetwork.org")...))
+	ctx := context.Background()
+	req := &protos.Eap{Payload: identity, Ctx: &protos.Context{SessionId: "bench"}}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := srv.Handle(ctx, req); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkEapAkaIdentity measures the parsing of an EAP-Response/AKA-Identity & the response of a subscriber with a
+// disallowed PLMN ID, the longest EAP-AKA path not calling the HSS
+func BenchmarkEapAkaIdentity(b *testing.B) {
+	srv, err := aka_servicers.NewEapAkaService(&mconfig.EapAkaConfig{PlmnIds: []string{"001011"}})
+	if err != nil {
+		b.Fatal(err)
+	}
+	srv.SetResponseCacheTTL(0) // every packet is handled
+	payload := eap_test.Units[eap_test.IMSI1].EapIdentityResp
+	ctx := context.Background()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		resp, _ := srv.Handle(ctx, &protos.Eap{Payload: payload, Ctx: &protos.Context{SessionId: "bench"}})
+		if len(resp.GetPayload()) == 0 {
+			b.Fatal("empty EAP-AKA response")
+		}
+	}
+}
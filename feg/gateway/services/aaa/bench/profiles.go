@@ -0,0 +1,85 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package bench implements benchmarks of the AAA critical paths & the reproducible load profiles they run.
+// See the gateway Makefile's bench & bench_report targets to compare the benchmarks of two revisions
+package bench
+
+import (
+	"fmt"
+	"math/rand"
+
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+// Profile a reproducible load: the subscribers, their (simultaneous) sessions & the Interim-Updates of each session.
+// The load is generated from the profile's seed, so benchmark runs of the same profile are comparable
+type Profile struct {
+	Name                  string
+	Subscribers           int
+	SessionsPerSubscriber int
+	InterimsPerSession    int
+	Seed                  int64
+}
+
+// Profiles the load profiles of the benchmarks, append new ones rather than changing existing ones so the reports
+// of old revisions stay comparable
+var Profiles = []Profile{
+	{Name: "small", Subscribers: 100, SessionsPerSubscriber: 1, InterimsPerSession: 10, Seed: 1},
+	{Name: "large", Subscribers: 20000, SessionsPerSubscriber: 1, InterimsPerSession: 10, Seed: 2},
+	{Name: "multi_device", Subscribers: 2000, SessionsPerSubscriber: 4, InterimsPerSession: 10, Seed: 3},
+}
+
+// Session a generated session & its Interim-Updates, with cumulative usage counters
+type Session struct {
+	Ctx      *protos.Context
+	Interims []*protos.UpdateRequest
+}
+
+// Sessions returns the profile's sessions, the same ones on every call
+func (p Profile) Sessions() []Session {
+	r := rand.New(rand.NewSource(p.Seed))
+	result := make([]Session, 0, p.Subscribers*p.SessionsPerSubscriber)
+	for sub := 0; sub < p.Subscribers; sub++ {
+		imsi := fmt.Sprintf("00101%010d", sub)
+		for dev := 0; dev < p.SessionsPerSubscriber; dev++ {
+			aaaCtx := &protos.Context{
+				SessionId: fmt.Sprintf("%X-%X", r.Int63(), r.Uint32()),
+				Imsi:      imsi,
+				Identity:  "0" + imsi + "@wlan.mnc001.mcc001.3gppnetwork.org",
+				MacAddr:   randomMAC(r),
+				Apn:       randomMAC(r) + ":magma.wifi",
+			}
+			s := Session{Ctx: aaaCtx, Interims: make([]*protos.UpdateRequest, 0, p.InterimsPerSession)}
+			var in, out, pktsIn, pktsOut uint32
+			for i := 0; i < p.InterimsPerSession; i++ {
+				in += uint32(r.Intn(1 << 20))
+				out += uint32(r.Intn(1 << 24))
+				pktsIn += uint32(r.Intn(1 << 10))
+				pktsOut += uint32(r.Intn(1 << 14))
+				s.Interims = append(s.Interims, &protos.UpdateRequest{
+					OctetsIn:   in,
+					OctetsOut:  out,
+					PacketsIn:  pktsIn,
+					PacketsOut: pktsOut,
+					Ctx:        aaaCtx,
+				})
+			}
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// randomMAC returns a random locally administered MAC in the uppercase dashed format of Calling-Station-Id
+func randomMAC(r *rand.Rand) string {
+	b := make([]byte, 6)
+	r.Read(b)
+	b[0] = b[0]&0xfc | 0x02
+	return fmt.Sprintf("%02X-%02X-%02X-%02X-%02X-%02X", b[0], b[1], b[2], b[3], b[4], b[5])
+}
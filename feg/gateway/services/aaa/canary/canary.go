@@ -0,0 +1,338 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package canary runs synthetic canary sessions through the whole AAA path (inner EAP-GTC authentication of a local
+// user, accounting Start, Interim-Updates & Stop) on an interval & reports their end to end success & latency as SLI
+// metrics, so outages are detected before subscribers report them.
+//
+// The canary sessions are accounted through the AAA server's GRPC service, their IMSIs should be test subscribers known to
+// the session manager & their users must be in the local users DB
+package canary
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"magma/feg/gateway/registry"
+	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/aaa/panics"
+	"magma/feg/gateway/services/aaa/protos"
+	"magma/feg/gateway/services/eap"
+	"magma/feg/gateway/services/eap/providers/gtc"
+)
+
+// Defaults
+const (
+	DefaultInterval = time.Minute
+	DefaultTimeout  = 5 * time.Second
+)
+
+// Steps of a canary session
+const (
+	stepAuth    = "auth"
+	stepStart   = "start"
+	stepInterim = "interim"
+	stepStop    = "stop"
+	stepSession = "session" // the whole session, only its latency is reported
+)
+
+// interimOctets - octets in & out reported by each Interim-Update of a canary session
+const interimOctets = 1000
+
+// Session - canary session's subscriber & its local user's credentials
+type Session struct {
+	Name     string `json:"name"` // canary name, the metrics' canary label
+	IMSI     string `json:"imsi"`
+	APN      string `json:"apn,omitempty"`
+	MacAddr  string `json:"mac_addr,omitempty"`
+	User     string `json:"user"`
+	Password string `json:"password"`
+}
+
+// Config - canary sessions & their schedule
+type Config struct {
+	IntervalSec int       `json:"interval_sec"` // interval of the canary sessions' runs, 0 - DefaultInterval
+	TimeoutMs   int       `json:"timeout_ms"`   // timeout of each step of a canary session, 0 - DefaultTimeout
+	Interims    int       `json:"interims"`     // number of Interim-Updates of each canary session
+	Sessions    []Session `json:"sessions"`
+}
+
+// ReadConfig reads & validates JSON canary configuration from the given file
+func ReadConfig(path string) (*Config, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &Config{}
+	if err = json.Unmarshal(b, cfg); err != nil {
+		return nil, fmt.Errorf("Invalid canary configuration %s: %v", path, err)
+	}
+	if err = cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("Invalid canary configuration %s: %v", path, err)
+	}
+	return cfg, nil
+}
+
+// Validate returns an error if the configuration has no sessions, a session's name, IMSI or user is missing or two
+// sessions have the same name
+func (cfg *Config) Validate() error {
+	if len(cfg.Sessions) == 0 {
+		return errors.New("no canary sessions")
+	}
+	if cfg.IntervalSec < 0 || cfg.TimeoutMs < 0 || cfg.Interims < 0 {
+		return errors.New("interval_sec, timeout_ms & interims must not be negative")
+	}
+	names := map[string]bool{}
+	for i, s := range cfg.Sessions {
+		if len(s.Name) == 0 || len(s.IMSI) == 0 || len(s.User) == 0 {
+			return fmt.Errorf("canary session #%d must have name, imsi & user", i+1)
+		}
+		if names[s.Name] {
+			return fmt.Errorf("duplicate canary session '%s'", s.Name)
+		}
+		names[s.Name] = true
+	}
+	return nil
+}
+
+func (cfg *Config) interval() time.Duration {
+	if cfg.IntervalSec > 0 {
+		return time.Duration(cfg.IntervalSec) * time.Second
+	}
+	return DefaultInterval
+}
+
+func (cfg *Config) timeout() time.Duration {
+	if cfg.TimeoutMs > 0 {
+		return time.Duration(cfg.TimeoutMs) * time.Millisecond
+	}
+	return DefaultTimeout
+}
+
+// Client - the AAA services the canary sessions are run through
+type Client interface {
+	HandleIdentity(ctx context.Context, in *protos.EapIdentity) (*protos.Eap, error)
+	Handle(ctx context.Context, in *protos.Eap) (*protos.Eap, error)
+	Start(ctx context.Context, aaaCtx *protos.Context) (*protos.AcctResp, error)
+	InterimUpdate(ctx context.Context, ur *protos.UpdateRequest) (*protos.AcctResp, error)
+	Stop(ctx context.Context, req *protos.StopRequest) (*protos.AcctResp, error)
+}
+
+// aaaServer is the Client of the AAA server's services, the canary sessions' EAP-GTC is an inner method which the
+// Authenticator GRPC service doesn't run, so they're authenticated by the server's in process inner authenticator
+type aaaServer struct {
+	inner protos.AuthenticatorServer
+}
+
+// NewClient returns the Client of the AAA server's services authenticating the canary sessions with the given in
+// process authenticator of inner methods (see servicers.NewInnerAuthenticator)
+func NewClient(inner protos.AuthenticatorServer) Client {
+	return aaaServer{inner: inner}
+}
+
+func (aaaServer) acct() (protos.AccountingClient, error) {
+	conn, err := registry.GetConnection(registry.AAA_SERVER)
+	if err != nil {
+		return nil, err
+	}
+	return protos.NewAccountingClient(conn), nil
+}
+
+func (c aaaServer) HandleIdentity(ctx context.Context, in *protos.EapIdentity) (*protos.Eap, error) {
+	return c.inner.HandleIdentity(ctx, in)
+}
+
+func (c aaaServer) Handle(ctx context.Context, in *protos.Eap) (*protos.Eap, error) {
+	return c.inner.Handle(ctx, in)
+}
+
+func (c aaaServer) Start(ctx context.Context, aaaCtx *protos.Context) (*protos.AcctResp, error) {
+	cli, err := c.acct()
+	if err != nil {
+		return nil, err
+	}
+	return cli.Start(ctx, aaaCtx)
+}
+
+func (c aaaServer) InterimUpdate(ctx context.Context, ur *protos.UpdateRequest) (*protos.AcctResp, error) {
+	cli, err := c.acct()
+	if err != nil {
+		return nil, err
+	}
+	return cli.InterimUpdate(ctx, ur)
+}
+
+func (c aaaServer) Stop(ctx context.Context, req *protos.StopRequest) (*protos.AcctResp, error) {
+	cli, err := c.acct()
+	if err != nil {
+		return nil, err
+	}
+	return cli.Stop(ctx, req)
+}
+
+// Runner runs the canary sessions
+type Runner struct {
+	cfg    *Config
+	client Client
+	done   chan struct{}
+
+	mu   sync.Mutex
+	runs uint64 // sequence number of the sessions' runs, part of the canary sessions' IDs
+}
+
+// New returns a Runner of the configuration's canary sessions, run through the client
+func New(cfg *Config, client Client) *Runner {
+	return &Runner{cfg: cfg, client: client, done: make(chan struct{})}
+}
+
+// Start starts running the canary sessions on the configured interval, the first sessions are run one interval after
+// Start, so the AAA server is serving by then
+func (r *Runner) Start() {
+	go func() {
+		ticker := time.NewTicker(r.cfg.interval())
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				r.Run()
+			case <-r.done:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops running the canary sessions
+func (r *Runner) Stop() {
+	close(r.done)
+}
+
+// Run runs all canary sessions concurrently & returns the number of failed sessions
+func (r *Runner) Run() int {
+	r.mu.Lock()
+	r.runs++
+	run := r.runs
+	r.mu.Unlock()
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		failed int
+	)
+	for _, s := range r.cfg.Sessions {
+		wg.Add(1)
+		go func(s Session) {
+			defer wg.Done()
+			defer panics.Recover("canary")
+			if err := r.runSession(s, fmt.Sprintf("canary-%s-%d-%d", s.Name, time.Now().Unix(), run)); err != nil {
+				log.Printf("Canary session %s failed: %v", s.Name, err)
+				mu.Lock()
+				failed++
+				mu.Unlock()
+			}
+		}(s)
+	}
+	wg.Wait()
+	return failed
+}
+
+// runSession runs the canary session's steps & reports the session's result, a started session is stopped even if
+// its Interim-Updates failed, so canary sessions don't linger
+func (r *Runner) runSession(s Session, sid string) error {
+	begin := time.Now()
+	aaaCtx := &protos.Context{SessionId: sid, Imsi: s.IMSI, Apn: s.APN, MacAddr: s.MacAddr}
+	err := r.step(s.Name, stepAuth, func(ctx context.Context) error {
+		var authErr error
+		aaaCtx, authErr = r.authenticate(ctx, s, aaaCtx)
+		return authErr
+	})
+	if err == nil {
+		err = r.step(s.Name, stepStart, func(ctx context.Context) error {
+			_, startErr := r.client.Start(ctx, aaaCtx)
+			return startErr
+		})
+		if err == nil {
+			for i := 1; i <= r.cfg.Interims && err == nil; i++ {
+				octets := uint32(i * interimOctets)
+				ur := &protos.UpdateRequest{OctetsIn: octets, OctetsOut: octets, Ctx: aaaCtx}
+				err = r.step(s.Name, stepInterim, func(ctx context.Context) error {
+					_, updateErr := r.client.InterimUpdate(ctx, ur)
+					return updateErr
+				})
+			}
+			stopErr := r.step(s.Name, stepStop, func(ctx context.Context) error {
+				_, stopErr := r.client.Stop(ctx, &protos.StopRequest{Cause: protos.StopRequest_USER_REQUEST, Ctx: aaaCtx})
+				return stopErr
+			})
+			if err == nil {
+				err = stopErr
+			}
+		}
+	}
+	if err != nil {
+		metrics.CanarySessions.WithLabelValues(s.Name, "failure").Inc()
+		metrics.CanaryUp.WithLabelValues(s.Name).Set(0)
+		return err
+	}
+	metrics.CanaryLatency.WithLabelValues(s.Name, stepSession).Observe(time.Since(begin).Seconds())
+	metrics.CanarySessions.WithLabelValues(s.Name, "success").Inc()
+	metrics.CanaryUp.WithLabelValues(s.Name).Set(1)
+	return nil
+}
+
+// step runs the session's step within the step timeout & reports its latency or failure
+func (r *Runner) step(name, step string, f func(ctx context.Context) error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.cfg.timeout())
+	defer cancel()
+	begin := time.Now()
+	if err := f(ctx); err != nil {
+		metrics.CanaryFailures.WithLabelValues(name, step).Inc()
+		return fmt.Errorf("%s: %v", step, err)
+	}
+	metrics.CanaryLatency.WithLabelValues(name, step).Observe(time.Since(begin).Seconds())
+	return nil
+}
+
+// authenticate runs EAP-GTC authentication of the session's user & returns the authenticated session's context
+func (r *Runner) authenticate(ctx context.Context, s Session, aaaCtx *protos.Context) (*protos.Context, error) {
+	identity := append([]byte{uint8(protos.EapType_Identity)}, s.User...)
+	req, err := r.client.HandleIdentity(ctx, &protos.EapIdentity{
+		Payload: eap.NewPacket(eap.ResponseCode, 1, identity),
+		Ctx:     aaaCtx,
+		Method:  uint32(gtc.TYPE),
+	})
+	if err != nil {
+		return nil, err
+	}
+	packet := eap.Packet(req.GetPayload())
+	if packet.Code() != eap.RequestCode || packet.Type() != gtc.TYPE {
+		return nil, fmt.Errorf("unexpected EAP-GTC request code %d, type %d", packet.Code(), packet.Type())
+	}
+	password := append([]byte{gtc.TYPE}, s.Password...)
+	resp, err := r.client.Handle(ctx, &protos.Eap{
+		Payload: eap.NewPacket(eap.ResponseCode, packet.Identifier(), password),
+		Ctx:     req.GetCtx(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !eap.Packet(resp.GetPayload()).IsSuccess() {
+		return nil, fmt.Errorf("EAP code %d instead of Success", eap.Packet(resp.GetPayload()).Code())
+	}
+	if resp.GetCtx() == nil {
+		return aaaCtx, nil
+	}
+	return resp.GetCtx(), nil
+}
@@ -0,0 +1,127 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package canary
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+
+	"magma/feg/gateway/services/aaa/protos"
+	"magma/feg/gateway/services/eap"
+	"magma/feg/gateway/services/eap/providers/gtc"
+)
+
+// fakeAAA authenticates the users of its passwords & records the accounting calls
+type fakeAAA struct {
+	sync.Mutex
+	passwords  map[string]string
+	calls      []string
+	interimErr error
+}
+
+func (f *fakeAAA) record(call string, aaaCtx *protos.Context) {
+	f.Lock()
+	f.calls = append(f.calls, call+" "+aaaCtx.GetImsi())
+	f.Unlock()
+}
+
+func (f *fakeAAA) HandleIdentity(ctx context.Context, in *protos.EapIdentity) (*protos.Eap, error) {
+	in.Ctx.Identity = string(eap.Packet(in.GetPayload()).TypeData())
+	data := append([]byte{gtc.TYPE}, gtc.Prompt...)
+	return &protos.Eap{Payload: eap.NewPacket(eap.RequestCode, 2, data), Ctx: in.Ctx}, nil
+}
+
+func (f *fakeAAA) Handle(ctx context.Context, in *protos.Eap) (*protos.Eap, error) {
+	packet := eap.Packet(in.GetPayload())
+	f.record("auth", in.GetCtx())
+	if f.passwords[in.GetCtx().GetIdentity()] != string(packet.TypeData()) {
+		return &protos.Eap{Payload: packet.Failure(), Ctx: in.Ctx}, nil
+	}
+	return &protos.Eap{Payload: eap.NewPacket(eap.SuccessCode, packet.Identifier(), nil), Ctx: in.Ctx}, nil
+}
+
+func (f *fakeAAA) Start(ctx context.Context, aaaCtx *protos.Context) (*protos.AcctResp, error) {
+	f.record("start", aaaCtx)
+	return &protos.AcctResp{}, nil
+}
+
+func (f *fakeAAA) InterimUpdate(ctx context.Context, ur *protos.UpdateRequest) (*protos.AcctResp, error) {
+	f.record("interim", ur.GetCtx())
+	return &protos.AcctResp{}, f.interimErr
+}
+
+func (f *fakeAAA) Stop(ctx context.Context, req *protos.StopRequest) (*protos.AcctResp, error) {
+	f.record("stop", req.GetCtx())
+	return &protos.AcctResp{}, nil
+}
+
+func TestRunner(t *testing.T) {
+	cfg := &Config{Interims: 2, Sessions: []Session{
+		{Name: "canary1", IMSI: "001010000000001", User: "canary1@lab", Password: "pass1"},
+	}}
+	assert.NoError(t, cfg.Validate())
+	aaa := &fakeAAA{passwords: map[string]string{"canary1@lab": "pass1"}}
+	r := New(cfg, aaa)
+
+	// the whole session is run
+	assert.Equal(t, 0, r.Run())
+	assert.Equal(t, []string{
+		"auth 001010000000001",
+		"start 001010000000001",
+		"interim 001010000000001",
+		"interim 001010000000001",
+		"stop 001010000000001",
+	}, aaa.calls)
+
+	// a started session is stopped after a failed Interim-Update
+	aaa.calls, aaa.interimErr = nil, errors.New("sessiond is unavailable")
+	assert.Equal(t, 1, r.Run())
+	assert.Equal(t, []string{
+		"auth 001010000000001",
+		"start 001010000000001",
+		"interim 001010000000001",
+		"stop 001010000000001",
+	}, aaa.calls)
+
+	// sessions failing authentication aren't started
+	aaa.calls, aaa.interimErr = nil, nil
+	aaa.passwords["canary1@lab"] = "pass2"
+	assert.Equal(t, 1, r.Run())
+	assert.Equal(t, []string{"auth 001010000000001"}, aaa.calls)
+}
+
+func TestReadConfig(t *testing.T) {
+	f, err := ioutil.TempFile("", "canary")
+	assert.NoError(t, err)
+	defer os.Remove(f.Name())
+	_, err = f.WriteString(`{"interval_sec": 30, "interims": 1, "sessions": [
+		{"name": "canary1", "imsi": "001010000000001", "apn": "canary", "user": "canary1@lab", "password": "pass1"}]}`)
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	cfg, err := ReadConfig(f.Name())
+	assert.NoError(t, err)
+	assert.Equal(t, 30*time.Second, cfg.interval())
+	assert.Equal(t, DefaultTimeout, cfg.timeout())
+	assert.Equal(t, "canary", cfg.Sessions[0].APN)
+
+	// sessions must be named uniquely & have IMSIs & users
+	cfg.Sessions = append(cfg.Sessions, cfg.Sessions[0])
+	assert.Error(t, cfg.Validate())
+	cfg.Sessions = []Session{{Name: "canary2", User: "canary2@lab"}}
+	assert.Error(t, cfg.Validate())
+	cfg.Sessions = nil
+	assert.Error(t, cfg.Validate())
+}
@@ -0,0 +1,130 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package charging implements per APN & realm charging hints passed to session manager at session creation, the
+// hints map the sessions of different SSIDs onto different Gy rating groups without policy server changes
+package charging
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+)
+
+const (
+	// AnyAPN - hints of all APNs without their own hints
+	AnyAPN = "*"
+	// charging characteristics are 2 octets (3GPP TS 32.298), encoded as 4 hex digits
+	chargingCharacteristicsLen = 4
+)
+
+// Hints - charging characteristics & rating groups of a session
+type Hints struct {
+	// ChargingCharacteristics 3GPP-Charging-Characteristics sent to the OCS, empty - not sent
+	ChargingCharacteristics string `json:"charging_characteristics"`
+	// RatingGroups rating groups charged in addition to the rating groups of the session's rules
+	RatingGroups []uint32 `json:"rating_groups"`
+}
+
+// Config - charging hints configuration
+type Config struct {
+	// APNs maps APNs to the hints of their sessions, APN * - all APNs without their own hints
+	APNs map[string]Hints `json:"apns"`
+	// Realms maps the realms of the subscribers' identities to hints overriding their APNs' hints
+	Realms map[string]Hints `json:"realms"`
+}
+
+// ReadConfig reads the charging hints configuration from the given JSON file
+func ReadConfig(path string) (*Config, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &Config{}
+	if err = json.Unmarshal(b, cfg); err != nil {
+		return nil, fmt.Errorf("Invalid charging hints configuration %s: %v", path, err)
+	}
+	apns := make(map[string]Hints, len(cfg.APNs))
+	for apn, hints := range cfg.APNs {
+		if err = hints.validate(); err != nil {
+			return nil, fmt.Errorf("Invalid charging hints of APN '%s': %v", apn, err)
+		}
+		apns[strings.ToLower(apn)] = hints
+	}
+	realms := make(map[string]Hints, len(cfg.Realms))
+	for realm, hints := range cfg.Realms {
+		if err = hints.validate(); err != nil {
+			return nil, fmt.Errorf("Invalid charging hints of realm '%s': %v", realm, err)
+		}
+		realms[strings.ToLower(realm)] = hints
+	}
+	cfg.APNs, cfg.Realms = apns, realms
+	return cfg, nil
+}
+
+// Hints returns the hints of the session of the APN & realm, the realm's charging characteristics override the
+// APN's & the APN's rating groups are followed by the realm's
+func (c *Config) Hints(apn, realm string) Hints {
+	if c == nil {
+		return Hints{}
+	}
+	apnHints, ok := c.APNs[strings.ToLower(apn)]
+	if !ok {
+		apnHints = c.APNs[AnyAPN]
+	}
+	realmHints := c.Realms[strings.ToLower(realm)]
+	res := Hints{
+		ChargingCharacteristics: apnHints.ChargingCharacteristics,
+		RatingGroups:            merge(apnHints.RatingGroups, realmHints.RatingGroups),
+	}
+	if len(realmHints.ChargingCharacteristics) > 0 {
+		res.ChargingCharacteristics = realmHints.ChargingCharacteristics
+	}
+	return res
+}
+
+// Empty returns true if there are no hints
+func (h Hints) Empty() bool {
+	return len(h.ChargingCharacteristics) == 0 && len(h.RatingGroups) == 0
+}
+
+func (h Hints) validate() error {
+	if len(h.ChargingCharacteristics) > 0 {
+		if _, err := hex.DecodeString(h.ChargingCharacteristics); err != nil ||
+			len(h.ChargingCharacteristics) != chargingCharacteristicsLen {
+			return fmt.Errorf("charging characteristics '%s' are not %d hex digits",
+				h.ChargingCharacteristics, chargingCharacteristicsLen)
+		}
+	}
+	for _, ratingGroup := range h.RatingGroups {
+		if ratingGroup == 0 {
+			return fmt.Errorf("zero rating group")
+		}
+	}
+	return nil
+}
+
+// merge returns the rating groups of both lists without duplicates, in their lists order
+func merge(first, second []uint32) []uint32 {
+	if len(second) == 0 {
+		return first
+	}
+	res := make([]uint32, 0, len(first)+len(second))
+	seen := make(map[uint32]bool, len(first)+len(second))
+	for _, ratingGroups := range [][]uint32{first, second} {
+		for _, ratingGroup := range ratingGroups {
+			if !seen[ratingGroup] {
+				seen[ratingGroup] = true
+				res = append(res, ratingGroup)
+			}
+		}
+	}
+	return res
+}
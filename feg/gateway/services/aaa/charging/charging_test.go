@@ -0,0 +1,71 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package charging
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeConfig(t *testing.T, cfg string) string {
+	f, err := ioutil.TempFile("", "charging_hints")
+	assert.NoError(t, err)
+	_, err = f.WriteString(cfg)
+	assert.NoError(t, err)
+	f.Close()
+	return f.Name()
+}
+
+func TestHints(t *testing.T) {
+	path := writeConfig(t, `{
+		"apns": {
+			"Internet": {"charging_characteristics": "0800", "rating_groups": [10, 11]},
+			"*": {"rating_groups": [1]}
+		},
+		"realms": {
+			"Partner.example.com": {"charging_characteristics": "0400", "rating_groups": [11, 20]},
+			"visitors.example.com": {"rating_groups": [30]}
+		}
+	}`)
+	defer os.Remove(path)
+
+	cfg, err := ReadConfig(path)
+	assert.NoError(t, err)
+
+	assert.Equal(t, Hints{ChargingCharacteristics: "0800", RatingGroups: []uint32{10, 11}}, cfg.Hints("internet", ""))
+	assert.Equal(t,
+		Hints{ChargingCharacteristics: "0400", RatingGroups: []uint32{10, 11, 20}},
+		cfg.Hints("INTERNET", "partner.example.com"))
+	assert.Equal(t,
+		Hints{ChargingCharacteristics: "0800", RatingGroups: []uint32{10, 11, 30}},
+		cfg.Hints("internet", "visitors.example.com"))
+	assert.Equal(t, Hints{RatingGroups: []uint32{1}}, cfg.Hints("guest", "unknown.example.com"))
+
+	var noHints *Config
+	assert.True(t, noHints.Hints("internet", "partner.example.com").Empty())
+}
+
+func TestReadInvalidConfig(t *testing.T) {
+	for _, cfg := range []string{
+		`{"apns": {"internet": {"rating_groups": [0]}}}`,
+		`{"apns": {"internet": {"charging_characteristics": "08"}}}`,
+		`{"realms": {"example.com": {"charging_characteristics": "08zz"}}}`,
+		`{"apns": []}`,
+	} {
+		path := writeConfig(t, cfg)
+		_, err := ReadConfig(path)
+		os.Remove(path)
+		assert.Error(t, err, cfg)
+	}
+	_, err := ReadConfig("/nonexistent/charging_hints.json")
+	assert.Error(t, err)
+}
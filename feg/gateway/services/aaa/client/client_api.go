@@ -103,3 +103,66 @@ func Stop(req *protos.StopRequest) (*protos.AcctResp, error) {
 	}
 	return cli.Stop(context.Background(), req)
 }
+
+// Handover notifies AAA of the subscriber's LTE to Wi-Fi handover, so the subscriber's next Wi-Fi session continues
+// the LTE session's charging context
+func Handover(req *protos.HandoverRequest) (*protos.AcctResp, error) {
+	if req == nil {
+		return nil, errors.New("Nil Handover Request")
+	}
+	cli, err := getAaaClient()
+	if err != nil {
+		return nil, err
+	}
+	return cli.Handover(context.Background(), req)
+}
+
+// DeviceHint attaches a device classification hint (e.g. a DHCP fingerprint or a portal's user agent) to the UE's
+// session analytics & session creation
+func DeviceHint(req *protos.DeviceHintRequest) (*protos.AcctResp, error) {
+	if req == nil {
+		return nil, errors.New("Nil Device Hint Request")
+	}
+	cli, err := getAaaClient()
+	if err != nil {
+		return nil, err
+	}
+	return cli.DeviceHint(context.Background(), req)
+}
+
+// SecurityEvent reports a security trigger (e.g. a blocklist hit) of an active session, the session is quarantined
+// or disconnected as configured for the trigger
+func SecurityEvent(req *protos.SecurityEventRequest) (*protos.AcctResp, error) {
+	if req == nil {
+		return nil, errors.New("Nil Security Event Request")
+	}
+	cli, err := getAaaClient()
+	if err != nil {
+		return nil, err
+	}
+	return cli.SecurityEvent(context.Background(), req)
+}
+
+// ChangeSession pushes a policy change (bandwidth, VLAN or filter) of an active session to its NAS via CoA
+func ChangeSession(req *protos.ChangeSessionRequest) (*protos.AcctResp, error) {
+	if req == nil {
+		return nil, errors.New("Nil Change Session Request")
+	}
+	cli, err := getAaaClient()
+	if err != nil {
+		return nil, err
+	}
+	return cli.ChangeSession(context.Background(), req)
+}
+
+// AcctOnOff flushes all sessions of the NAS which sent an Accounting-On or Accounting-Off
+func AcctOnOff(req *protos.AcctOnOffRequest) (*protos.AcctResp, error) {
+	if req == nil {
+		return nil, errors.New("Nil Accounting On/Off Request")
+	}
+	cli, err := getAaaClient()
+	if err != nil {
+		return nil, err
+	}
+	return cli.AcctOnOff(context.Background(), req)
+}
@@ -0,0 +1,251 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package coalog keeps the transaction log of the CoA & Disconnect-Requests sent to the sessions' NASes: each
+// request's changes, target NAS, result & the policy event or RPC which triggered it, so dynamic policy actions can
+// be audited. The log keeps the latest transactions in memory
+package coalog
+
+import (
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+// DefaultMaxTransactions - the default number of the latest transactions kept by the log
+const DefaultMaxTransactions = 10000
+
+// RequestIDHeader - metadata key of the triggering RPCs' caller assigned request IDs
+const RequestIDHeader = "x-request-id"
+
+// Kind - kind of the sent request
+type Kind string
+
+const (
+	KindCoA        Kind = "coa"        // CoA-Request changing the session's policy
+	KindDisconnect Kind = "disconnect" // Disconnect-Request ending the session
+)
+
+// Transactions' results
+const (
+	ResultACK   = "ack"   // the NAS acknowledged the request
+	ResultNAK   = "nak"   // the NAS rejected the request
+	ResultError = "error" // the request failed, e.g. the NAS didn't respond
+)
+
+// Trigger - the policy event or RPC which triggered a transaction
+type Trigger struct {
+	// Source - the triggering RPC's method name or the policy event, e.g. ChangeSession, quarantine_coa
+	Source string `json:"source"`
+	// Detail - the event's detail, e.g. the quarantine trigger or the time policy window
+	Detail string `json:"detail,omitempty"`
+	// RequestID - the triggering RPC's x-request-id, if set by its caller
+	RequestID string `json:"request_id,omitempty"`
+}
+
+type triggerKey struct{}
+
+// WithTrigger returns a copy of the context carrying the policy event triggering the requests sent with it
+func WithTrigger(ctx context.Context, source, detail string) context.Context {
+	return context.WithValue(ctx, triggerKey{}, Trigger{Source: source, Detail: detail})
+}
+
+// TriggerOf returns the trigger of the context's requests: the context's policy event or, within RPC handlers, the
+// RPC's method & request ID
+func TriggerOf(ctx context.Context) Trigger {
+	if t, ok := ctx.Value(triggerKey{}).(Trigger); ok {
+		return t
+	}
+	var t Trigger
+	if method, ok := grpc.Method(ctx); ok {
+		t.Source = path.Base(method)
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(RequestIDHeader); len(ids) > 0 {
+			t.RequestID = ids[0]
+		}
+	}
+	return t
+}
+
+// Transaction - a sent CoA or Disconnect-Request
+type Transaction struct {
+	ID            uint64    `json:"id"`
+	Time          time.Time `json:"time"`
+	Kind          Kind      `json:"kind"`
+	SessionID     string    `json:"session_id"`
+	Imsi          string    `json:"imsi,omitempty"`
+	NASAddress    string    `json:"nas_address,omitempty"`
+	NASIdentifier string    `json:"nas_identifier,omitempty"`
+	Trigger       Trigger   `json:"trigger"`
+	// Attributes - the requested changes, e.g. max_bandwidth_up, or the disconnect's reason
+	Attributes map[string]string `json:"attributes,omitempty"`
+	Result     string            `json:"result"`
+	Error      string            `json:"error,omitempty"`
+	// Attempt - 1 for the first request of the session's trigger, retries of failed requests count up
+	Attempt   int   `json:"attempt"`
+	LatencyMs int64 `json:"latency_ms"`
+}
+
+// NewTransaction returns the transaction of the request sent for the session, its result is set by Done
+func NewTransaction(ctx context.Context, kind Kind, aaaCtx *protos.Context, attrs map[string]string) *Transaction {
+	tx := &Transaction{
+		Time:       time.Now(),
+		Kind:       kind,
+		SessionID:  aaaCtx.GetSessionId(),
+		Imsi:       strings.TrimPrefix(aaaCtx.GetImsi(), "IMSI"),
+		Trigger:    TriggerOf(ctx),
+		Attributes: attrs,
+	}
+	tx.NASAddress, _ = aaaCtx.GetAttribute(protos.NASAddressAttribute)
+	tx.NASIdentifier, _ = aaaCtx.GetAttribute(protos.NASIdentifierAttribute)
+	return tx
+}
+
+// Done sets the transaction's result & latency of the request's response or error
+func (tx *Transaction) Done(res *protos.CoaResponse, err error) {
+	tx.LatencyMs = int64(time.Since(tx.Time) / time.Millisecond)
+	switch {
+	case err != nil:
+		tx.Result, tx.Error = ResultError, err.Error()
+	case res.GetCoaResponseType() == protos.CoaResponse_ACK:
+		tx.Result = ResultACK
+	default:
+		tx.Result = ResultNAK
+	}
+}
+
+// ChangeAttributes returns the changes requested by the CoA request
+func ChangeAttributes(req *protos.ChangeRequest) map[string]string {
+	attrs := map[string]string{}
+	set := func(name string, value uint32) {
+		if value > 0 {
+			attrs[name] = strconv.FormatUint(uint64(value), 10)
+		}
+	}
+	set("max_bandwidth_up", req.GetMaxBandwidthUp())
+	set("max_bandwidth_down", req.GetMaxBandwidthDown())
+	set("vlan_id", req.GetVlanId())
+	if len(req.GetFilterId()) > 0 {
+		attrs["filter_id"] = req.GetFilterId()
+	}
+	if len(req.GetJsonTrficClasses()) > 0 {
+		attrs["traffic_classes"] = req.GetJsonTrficClasses()
+	}
+	if q := req.GetQuarantine(); q != nil {
+		set("quarantine_vlan_id", q.GetVlanId())
+		attrs["quarantine_filter_id"] = q.GetFilterId()
+		attrs["quarantine_redirect_url"] = q.GetRedirectUrl()
+	}
+	return attrs
+}
+
+// DisconnectAttributes returns the reason & reply message of the Disconnect-Request
+func DisconnectAttributes(req *protos.DisconnectRequest) map[string]string {
+	attrs := map[string]string{"reason": req.GetReason().String()}
+	if len(req.GetReplyMessage()) > 0 {
+		attrs["reply_message"] = req.GetReplyMessage()
+	}
+	return attrs
+}
+
+// Query - filters of the listed transactions, empty filters match all transactions
+type Query struct {
+	SessionID string
+	Imsi      string
+	Source    string // the trigger's source
+	Result    string
+	Since     time.Time
+	Limit     int // max listed transactions, 0 - all
+}
+
+func (q Query) matches(tx *Transaction) bool {
+	return (len(q.SessionID) == 0 || q.SessionID == tx.SessionID) &&
+		(len(q.Imsi) == 0 || strings.TrimPrefix(q.Imsi, "IMSI") == tx.Imsi) &&
+		(len(q.Source) == 0 || q.Source == tx.Trigger.Source) &&
+		(len(q.Result) == 0 || q.Result == tx.Result) &&
+		!tx.Time.Before(q.Since)
+}
+
+// Log keeps the latest transactions in a ring, a nil Log keeps nothing
+type Log struct {
+	mu   sync.Mutex
+	ring []*Transaction
+	next int // index of the ring's next slot, the oldest transaction once the ring is full
+	seq  uint64
+}
+
+// New returns a Log of the given number of the latest transactions
+func New(max int) *Log {
+	if max <= 0 {
+		max = DefaultMaxTransactions
+	}
+	return &Log{ring: make([]*Transaction, 0, max)}
+}
+
+// Record assigns the completed transaction's ID & attempt & adds it to the log. The attempt follows the attempt of
+// the latest logged transaction of the same session & trigger if that one failed
+func (l *Log) Record(tx *Transaction) {
+	if l == nil || tx == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.seq++
+	tx.ID = l.seq
+	tx.Attempt = 1
+	l.each(func(prev *Transaction) bool {
+		if prev.SessionID != tx.SessionID || prev.Kind != tx.Kind || prev.Trigger.Source != tx.Trigger.Source {
+			return true
+		}
+		if prev.Result != ResultACK {
+			tx.Attempt = prev.Attempt + 1
+		}
+		return false
+	})
+	if len(l.ring) < cap(l.ring) {
+		l.ring = append(l.ring, tx)
+	} else {
+		l.ring[l.next] = tx
+	}
+	l.next = (l.next + 1) % cap(l.ring)
+}
+
+// List returns the logged transactions matching the query, the latest first
+func (l *Log) List(q Query) []Transaction {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var res []Transaction
+	l.each(func(tx *Transaction) bool {
+		if q.matches(tx) {
+			res = append(res, *tx)
+		}
+		return q.Limit <= 0 || len(res) < q.Limit
+	})
+	return res
+}
+
+// each calls f with the logged transactions, the latest first, until f returns false
+func (l *Log) each(f func(tx *Transaction) bool) {
+	for i := 1; i <= len(l.ring); i++ {
+		if !f(l.ring[(l.next-i+cap(l.ring))%cap(l.ring)]) {
+			return
+		}
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package coalog_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+
+	"magma/feg/gateway/services/aaa/coalog"
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+func TestTransaction(t *testing.T) {
+	aaaCtx := &protos.Context{SessionId: "sid1", Imsi: "IMSI001010000000001", Attributes: map[string]string{
+		protos.NASAddressAttribute: "10.0.0.1:3799", protos.NASIdentifierAttribute: "ap1"}}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(coalog.RequestIDHeader, "req-1"))
+
+	tx := coalog.NewTransaction(ctx, coalog.KindCoA, aaaCtx,
+		coalog.ChangeAttributes(&protos.ChangeRequest{MaxBandwidthUp: 1000, MaxBandwidthDown: 2000, FilterId: "f"}))
+	tx.Done(&protos.CoaResponse{CoaResponseType: protos.CoaResponse_ACK}, nil)
+	assert.Equal(t, "001010000000001", tx.Imsi)
+	assert.Equal(t, "10.0.0.1:3799", tx.NASAddress)
+	assert.Equal(t, "ap1", tx.NASIdentifier)
+	assert.Equal(t, coalog.Trigger{RequestID: "req-1"}, tx.Trigger)
+	assert.Equal(t, map[string]string{"max_bandwidth_up": "1000", "max_bandwidth_down": "2000", "filter_id": "f"},
+		tx.Attributes)
+	assert.Equal(t, coalog.ResultACK, tx.Result)
+
+	tx = coalog.NewTransaction(coalog.WithTrigger(ctx, "quarantine_coa", "clone"), coalog.KindDisconnect, aaaCtx,
+		coalog.DisconnectAttributes(&protos.DisconnectRequest{Reason: protos.TerminateReason_POLICY}))
+	tx.Done(nil, errors.New("timeout"))
+	assert.Equal(t, coalog.Trigger{Source: "quarantine_coa", Detail: "clone"}, tx.Trigger)
+	assert.Equal(t, map[string]string{"reason": "POLICY"}, tx.Attributes)
+	assert.Equal(t, coalog.ResultError, tx.Result)
+	assert.Equal(t, "timeout", tx.Error)
+
+	tx.Done(&protos.CoaResponse{CoaResponseType: protos.CoaResponse_NAK}, nil)
+	assert.Equal(t, coalog.ResultNAK, tx.Result)
+}
+
+func TestLog(t *testing.T) {
+	l := coalog.New(3)
+	record := func(sid, source, result string) {
+		l.Record(&coalog.Transaction{
+			SessionID: sid, Kind: coalog.KindCoA, Trigger: coalog.Trigger{Source: source}, Result: result})
+	}
+	record("sid1", "time_policy", coalog.ResultError)
+	record("sid1", "time_policy", coalog.ResultNAK)
+	record("sid2", "time_policy", coalog.ResultACK)
+
+	txs := l.List(coalog.Query{})
+	if assert.Len(t, txs, 3) {
+		assert.Equal(t, []uint64{3, 2, 1}, []uint64{txs[0].ID, txs[1].ID, txs[2].ID}, "the latest first")
+		assert.Equal(t, 2, txs[1].Attempt, "retry of the failed transaction")
+		assert.Equal(t, 1, txs[0].Attempt)
+	}
+
+	// the oldest transactions are replaced
+	record("sid1", "time_policy", coalog.ResultACK)
+	record("sid1", "anomaly_coa", coalog.ResultACK)
+	txs = l.List(coalog.Query{SessionID: "sid1"})
+	if assert.Len(t, txs, 2) {
+		assert.Equal(t, uint64(5), txs[0].ID)
+		assert.Equal(t, 1, txs[0].Attempt)
+		assert.Equal(t, uint64(4), txs[1].ID)
+		assert.Equal(t, 3, txs[1].Attempt)
+	}
+	assert.Len(t, l.List(coalog.Query{Source: "time_policy"}), 2)
+	assert.Len(t, l.List(coalog.Query{Result: coalog.ResultACK, Limit: 1}), 1)
+
+	var nilLog *coalog.Log
+	nilLog.Record(&coalog.Transaction{})
+	assert.Empty(t, nilLog.List(coalog.Query{}))
+}
@@ -0,0 +1,82 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package deadlines enforces deadlines of the AAA server's GRPC calls. Inbound calls without a deadline are flagged
+// & bounded by the default timeout, downstream calls should be made with the inbound call's context or, if they are
+// not a part of an inbound call, with a context returned by Background
+package deadlines
+
+import (
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+
+	"magma/feg/gateway/services/aaa/metrics"
+)
+
+// DefaultTimeout - default deadline of calls made without one
+const DefaultTimeout = 5 * time.Second
+
+// Call directions
+const (
+	Inbound  = "inbound"
+	Outbound = "outbound"
+)
+
+var defaultTimeout = int64(DefaultTimeout)
+
+// flagged - methods already logged as called without deadline, each method is only logged once
+var flagged sync.Map
+
+// SetDefaultTimeout sets the deadline of calls made without one, non positive timeout resets it to DefaultTimeout
+func SetDefaultTimeout(timeout time.Duration) {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	atomic.StoreInt64(&defaultTimeout, int64(timeout))
+}
+
+// GetDefaultTimeout returns the deadline of calls made without one
+func GetDefaultTimeout() time.Duration {
+	return time.Duration(atomic.LoadInt64(&defaultTimeout))
+}
+
+// Background returns a context bounded by the default timeout for downstream calls which are not a part of an
+// inbound call (timers, background goroutines), the returned cancel func must be called
+func Background() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), GetDefaultTimeout())
+}
+
+// Check flags the call if its context has no deadline & returns a context bounded by the default timeout in this
+// case, otherwise the given context is returned. The returned cancel func must be called
+func Check(ctx context.Context, method, direction string) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+	metrics.CallsWithoutDeadline.WithLabelValues(method, direction).Inc()
+	if _, logged := flagged.LoadOrStore(direction+":"+method, true); !logged {
+		log.Printf("%s call %s has no deadline, default deadline %s is used", direction, method, GetDefaultTimeout())
+	}
+	return context.WithTimeout(ctx, GetDefaultTimeout())
+}
+
+// UnaryServerInterceptor flags inbound calls without deadlines & bounds them by the default timeout
+func UnaryServerInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+
+	ctx, cancel := Check(ctx, info.FullMethod, Inbound)
+	defer cancel()
+	return handler(ctx, req)
+}
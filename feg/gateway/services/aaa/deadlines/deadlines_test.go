@@ -0,0 +1,64 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package deadlines_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+
+	"magma/feg/gateway/services/aaa/deadlines"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	defer deadlines.SetDefaultTimeout(deadlines.DefaultTimeout)
+	deadlines.SetDefaultTimeout(time.Minute)
+	info := &grpc.UnaryServerInfo{FullMethod: "/aaa.protos.accounting/stop"}
+
+	// calls without deadline get the default one
+	var deadline time.Time
+	var hasDeadline bool
+	_, err := deadlines.UnaryServerInterceptor(context.Background(), nil, info,
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			deadline, hasDeadline = ctx.Deadline()
+			return nil, nil
+		})
+	assert.NoError(t, err)
+	assert.True(t, hasDeadline)
+	assert.True(t, time.Until(deadline) > 50*time.Second)
+
+	// callers' deadlines are kept
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	expected, _ := ctx.Deadline()
+	_, err = deadlines.UnaryServerInterceptor(ctx, nil, info,
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			deadline, hasDeadline = ctx.Deadline()
+			return nil, nil
+		})
+	assert.NoError(t, err)
+	assert.True(t, hasDeadline)
+	assert.Equal(t, expected, deadline)
+}
+
+func TestBackground(t *testing.T) {
+	defer deadlines.SetDefaultTimeout(deadlines.DefaultTimeout)
+	deadlines.SetDefaultTimeout(0)
+	assert.Equal(t, deadlines.DefaultTimeout, deadlines.GetDefaultTimeout())
+
+	ctx, cancel := deadlines.Background()
+	deadline, ok := ctx.Deadline()
+	assert.True(t, ok)
+	assert.True(t, time.Until(deadline) <= deadlines.DefaultTimeout)
+	cancel()
+	assert.Error(t, ctx.Err())
+}
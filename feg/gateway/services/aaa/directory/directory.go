@@ -0,0 +1,129 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package directory publishes the UE addresses & access point of subscribers' sessions to the cloud directory
+// service (directoryd), so other services can resolve a subscriber from its traffic without keeping session state.
+// The sessions' UE IP & MAC addresses are published as lookup records of the subscribers' IMSIs as well
+package directory
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"golang.org/x/net/context"
+
+	"magma/feg/gateway/registry"
+	"magma/feg/gateway/services/aaa/deadlines"
+	orcprotos "magma/orc8r/cloud/go/protos"
+)
+
+// ServiceName the cloud directory service
+const ServiceName = "DIRECTORYD"
+
+// Record the directoryd record of a subscriber's session, keyed by IMSI
+type Record struct {
+	SessionID  string `json:"session_id"`
+	IPv4       string `json:"ipv4,omitempty"`
+	IPv6       string `json:"ipv6,omitempty"`
+	IPv6Prefix string `json:"ipv6_prefix,omitempty"`
+	MAC        string `json:"mac,omitempty"`
+	// AP the MAC address of the access point, the whole Called-Station-Id if it doesn't start with a MAC address
+	AP string `json:"ap,omitempty"`
+}
+
+// Encode returns the record's directoryd location
+func (r Record) Encode() string {
+	b, _ := json.Marshal(r) // a struct of strings can't fail to encode
+	return string(b)
+}
+
+// Decode returns the record of the directoryd location
+func Decode(location string) (Record, error) {
+	var r Record
+	if err := json.Unmarshal([]byte(location), &r); err != nil {
+		return r, fmt.Errorf("invalid directory record '%s': %v", location, err)
+	}
+	return r, nil
+}
+
+// lookupKey the key of a lookup record of a subscriber's session, mapping the session's UE IP or MAC address to the
+// subscriber's IMSI
+type lookupKey struct {
+	table orcprotos.TableID
+	id    string
+}
+
+// lookupKeys returns the keys of the record's lookup records
+func (r Record) lookupKeys() []lookupKey {
+	var keys []lookupKey
+	for _, ip := range []string{r.IPv4, r.IPv6, r.IPv6Prefix} {
+		if len(ip) > 0 {
+			keys = append(keys, lookupKey{table: orcprotos.TableID_IP_TO_IMSI, id: ip})
+		}
+	}
+	if len(r.MAC) > 0 {
+		keys = append(keys, lookupKey{table: orcprotos.TableID_MAC_TO_IMSI, id: r.MAC})
+	}
+	return keys
+}
+
+// Client updates & deletes directory records
+type Client interface {
+	Update(ctx context.Context, table orcprotos.TableID, id, location string) error
+	Delete(ctx context.Context, table orcprotos.TableID, id string) error
+}
+
+type cloudClient struct {
+	cloudRegistry registry.CloudRegistry
+}
+
+// NewCloudClient returns the client of the cloud directory service, reached through the control proxy
+func NewCloudClient() Client {
+	return cloudClient{cloudRegistry: registry.NewCloudRegistry()}
+}
+
+// Update creates or replaces the table's record
+func (c cloudClient) Update(ctx context.Context, table orcprotos.TableID, id, location string) error {
+	client, ctx, release, err := c.connect(ctx, "DirectoryService.UpdateLocation")
+	if err != nil {
+		return err
+	}
+	defer release()
+	_, err = client.UpdateLocation(ctx, &orcprotos.UpdateDirectoryLocationRequest{
+		Table:  table,
+		Id:     id,
+		Record: &orcprotos.LocationRecord{Location: location},
+	})
+	return err
+}
+
+// Delete deletes the table's record
+func (c cloudClient) Delete(ctx context.Context, table orcprotos.TableID, id string) error {
+	client, ctx, release, err := c.connect(ctx, "DirectoryService.DeleteLocation")
+	if err != nil {
+		return err
+	}
+	defer release()
+	_, err = client.DeleteLocation(ctx, &orcprotos.DeleteLocationRequest{Table: table, Id: id})
+	return err
+}
+
+// connect returns the directoryd client & the method's context, release closes the connection
+func (c cloudClient) connect(
+	ctx context.Context, method string) (orcprotos.DirectoryServiceClient, context.Context, func(), error) {
+
+	conn, err := c.cloudRegistry.GetCloudConnection(ServiceName)
+	if err != nil {
+		return nil, ctx, nil, fmt.Errorf("Directoryd client initialization error: %s", err)
+	}
+	ctx, cancel := deadlines.Check(ctx, method, deadlines.Outbound)
+	return orcprotos.NewDirectoryServiceClient(conn), ctx, func() {
+		cancel()
+		conn.Close()
+	}, nil
+}
@@ -0,0 +1,261 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package directory
+
+import (
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"magma/feg/gateway/services/aaa/deadlines"
+	"magma/feg/gateway/services/aaa/metrics"
+	orcprotos "magma/orc8r/cloud/go/protos"
+)
+
+// Directory publish operations
+const (
+	opUpdate = "update"
+	opDelete = "delete"
+)
+
+// Default backoff of the failed publishes' retries
+const (
+	DefaultRetryMin = time.Second
+	DefaultRetryMax = time.Minute
+)
+
+// Publisher keeps the records of the subscribers' sessions & publishes their changes to the directory from a single
+// goroutine, so a subscriber's updates & deletes are published in order. Changes made while a subscriber's record is
+// published are coalesced into its next publish. Failed publishes are retried with exponential backoff, or right away
+// when the subscriber's record is updated again
+type Publisher struct {
+	client Client
+
+	mu        sync.Mutex
+	records   map[string]Record    // by IMSI
+	imsis     map[string]string    // IMSIs of the records by session ID
+	lookups   map[lookupKey]string // IMSIs of the records' lookup records
+	dirty     map[string]bool      // IMSIs of the records changed since published
+	published map[string]Record    // last successfully published records by IMSI
+	changed   chan struct{}
+
+	retryMin, retryMax time.Duration
+}
+
+// NewPublisher returns a Publisher of the directory client, Run publishes the changes
+func NewPublisher(client Client) *Publisher {
+	return &Publisher{
+		client:    client,
+		records:   map[string]Record{},
+		imsis:     map[string]string{},
+		lookups:   map[lookupKey]string{},
+		dirty:     map[string]bool{},
+		published: map[string]Record{},
+		changed:   make(chan struct{}, 1),
+		retryMin:  DefaultRetryMin,
+		retryMax:  DefaultRetryMax,
+	}
+}
+
+// SetRetryBackoff sets the minimum & maximum delays of the failed publishes' retries
+func (p *Publisher) SetRetryBackoff(min, max time.Duration) {
+	p.mu.Lock()
+	p.retryMin, p.retryMax = min, max
+	p.mu.Unlock()
+}
+
+// Update sets the subscriber's record to the one of its session, unchanged records aren't published again unless
+// their last publish failed. The record of a subscriber with simultaneous sessions is the one of the last updated
+// session
+func (p *Publisher) Update(imsi string, r Record) {
+	if len(imsi) == 0 {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	current, ok := p.records[imsi]
+	if ok && current == r {
+		if p.dirty[imsi] {
+			p.markDirty(imsi) // re-publish the failed publish now
+		}
+		return
+	}
+	if ok && current.SessionID != r.SessionID {
+		delete(p.imsis, current.SessionID)
+	}
+	p.setLookups(imsi, current, r)
+	p.records[imsi] = r
+	p.imsis[r.SessionID] = imsi
+	p.markDirty(imsi)
+}
+
+// Remove deletes the subscriber's record of the ended session, records of the subscriber's other sessions are kept
+func (p *Publisher) Remove(sessionID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	imsi, ok := p.imsis[sessionID]
+	if !ok {
+		return
+	}
+	p.setLookups(imsi, p.records[imsi], Record{})
+	delete(p.imsis, sessionID)
+	delete(p.records, imsi)
+	p.markDirty(imsi)
+}
+
+// Get returns the subscriber's current record
+func (p *Publisher) Get(imsi string) (Record, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	r, ok := p.records[imsi]
+	return r, ok
+}
+
+// setLookups replaces the subscriber's previous record's lookup records by the new record's ones, addresses
+// already reassigned to other subscribers are kept. It must be called with the lock held
+func (p *Publisher) setLookups(imsi string, previous, r Record) {
+	for _, key := range previous.lookupKeys() {
+		if p.lookups[key] == imsi {
+			delete(p.lookups, key)
+		}
+	}
+	for _, key := range r.lookupKeys() {
+		p.lookups[key] = imsi
+	}
+}
+
+// markDirty must be called with the lock held
+func (p *Publisher) markDirty(imsi string) {
+	p.dirty[imsi] = true
+	select {
+	case p.changed <- struct{}{}:
+	default:
+	}
+}
+
+// Publish publishes the changed records, IMSIs in order, & returns the number of subscribers whose publish failed,
+// they are published again by the next Publish
+func (p *Publisher) Publish() int {
+	p.mu.Lock()
+	imsis := make([]string, 0, len(p.dirty))
+	for imsi := range p.dirty {
+		imsis = append(imsis, imsi)
+	}
+	p.dirty = map[string]bool{}
+	p.mu.Unlock()
+	sort.Strings(imsis)
+	failed := 0
+	for _, imsi := range imsis {
+		if !p.publish(imsi) {
+			failed++
+			p.mu.Lock()
+			p.dirty[imsi] = true
+			p.mu.Unlock()
+		}
+	}
+	return failed
+}
+
+// publish publishes the subscriber's current record & its lookup records, or their deletion. New lookup records are
+// published before the subscriber's record & stale ones are deleted after it, a failed publish is published whole
+// again by the next one
+func (p *Publisher) publish(imsi string) bool {
+	p.mu.Lock()
+	r, ok := p.records[imsi]
+	previous := p.published[imsi]
+	var updates, deletes []lookupKey
+	current := map[lookupKey]bool{}
+	for _, key := range r.lookupKeys() {
+		current[key] = true
+		if p.lookups[key] == imsi {
+			updates = append(updates, key)
+		}
+	}
+	for _, key := range previous.lookupKeys() {
+		if _, owned := p.lookups[key]; !current[key] && !owned {
+			deletes = append(deletes, key)
+		}
+	}
+	p.mu.Unlock()
+
+	ctx, cancel := deadlines.Background()
+	defer cancel()
+	for _, key := range updates {
+		if !p.apply(opUpdate, imsi, p.client.Update(ctx, key.table, key.id, imsi)) {
+			return false
+		}
+	}
+	if ok {
+		if !p.apply(opUpdate, imsi, p.client.Update(ctx, orcprotos.TableID_IMSI_TO_UE, imsi, r.Encode())) {
+			return false
+		}
+	} else if !p.apply(opDelete, imsi, p.client.Delete(ctx, orcprotos.TableID_IMSI_TO_UE, imsi)) {
+		return false
+	}
+	for _, key := range deletes {
+		if !p.apply(opDelete, imsi, p.client.Delete(ctx, key.table, key.id)) {
+			return false
+		}
+	}
+
+	p.mu.Lock()
+	if ok {
+		p.published[imsi] = r
+	} else {
+		delete(p.published, imsi)
+	}
+	p.mu.Unlock()
+	return true
+}
+
+// apply counts & logs the result of the subscriber's directory operation, it returns true if the operation succeeded
+func (p *Publisher) apply(op, imsi string, err error) bool {
+	if err != nil {
+		metrics.DirectoryUpdates.WithLabelValues(op, "failure").Inc()
+		log.Printf("Directory %s of IMSI %s failed: %v", op, imsi, err)
+		return false
+	}
+	metrics.DirectoryUpdates.WithLabelValues(op, "success").Inc()
+	return true
+}
+
+// Run publishes the records' changes as they are made & retries the failed publishes, it never returns
+func (p *Publisher) Run() {
+	var (
+		backoff time.Duration
+		retry   <-chan time.Time
+	)
+	for {
+		select {
+		case <-p.changed:
+		case <-retry:
+		}
+		if p.Publish() == 0 {
+			backoff, retry = 0, nil
+			continue
+		}
+		backoff = p.nextBackoff(backoff)
+		retry = time.After(backoff)
+	}
+}
+
+// nextBackoff returns the delay of the failed publishes' next retry
+func (p *Publisher) nextBackoff(backoff time.Duration) time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	backoff *= 2
+	if backoff < p.retryMin {
+		backoff = p.retryMin
+	}
+	if backoff > p.retryMax {
+		backoff = p.retryMax
+	}
+	return backoff
+}
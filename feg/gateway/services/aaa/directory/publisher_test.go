@@ -0,0 +1,172 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package directory
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+
+	orcprotos "magma/orc8r/cloud/go/protos"
+)
+
+type recordingClient struct {
+	sync.Mutex
+	ops []string
+	err error
+}
+
+func (c *recordingClient) Update(_ context.Context, table orcprotos.TableID, id, location string) error {
+	c.Lock()
+	defer c.Unlock()
+	c.ops = append(c.ops, fmt.Sprintf("update %s %s %s", table, id, location))
+	return c.err
+}
+
+func (c *recordingClient) Delete(_ context.Context, table orcprotos.TableID, id string) error {
+	c.Lock()
+	defer c.Unlock()
+	c.ops = append(c.ops, fmt.Sprintf("delete %s %s", table, id))
+	return c.err
+}
+
+func (c *recordingClient) reset(err error) []string {
+	c.Lock()
+	defer c.Unlock()
+	ops := c.ops
+	c.ops, c.err = nil, err
+	return ops
+}
+
+func TestPublisher(t *testing.T) {
+	client := &recordingClient{}
+	p := NewPublisher(client)
+	r1 := Record{SessionID: "sid1", IPv4: "10.0.0.1", MAC: "AA-BB-CC-DD-EE-FF", AP: "11-22-33-44-55-66"}
+	p.Update("001010000000001", Record{SessionID: "sid1", MAC: "AA-BB-CC-DD-EE-FF"})
+	p.Update("001010000000001", r1)
+	assert.Equal(t, 0, p.Publish())
+	// the changes made before the publish are coalesced, the lookup records are published first
+	assert.Equal(t, []string{
+		"update IP_TO_IMSI 10.0.0.1 001010000000001",
+		"update MAC_TO_IMSI AA-BB-CC-DD-EE-FF 001010000000001",
+		"update IMSI_TO_UE 001010000000001 " + r1.Encode(),
+	}, client.reset(nil))
+
+	// unchanged records aren't published again
+	p.Update("001010000000001", r1)
+	p.Publish()
+	assert.Empty(t, client.reset(nil))
+
+	// the record of the subscriber's last updated session is kept when its other session ends & the stale lookup
+	// records are deleted
+	r2 := Record{SessionID: "sid2", IPv4: "10.0.0.2", IPv6: "2001:db8::2", MAC: "AA-BB-CC-DD-EE-FF"}
+	p.Update("001010000000001", r2)
+	p.Remove("sid1")
+	assert.Equal(t, 0, p.Publish())
+	assert.Equal(t, []string{
+		"update IP_TO_IMSI 10.0.0.2 001010000000001",
+		"update IP_TO_IMSI 2001:db8::2 001010000000001",
+		"update MAC_TO_IMSI AA-BB-CC-DD-EE-FF 001010000000001",
+		"update IMSI_TO_UE 001010000000001 " + r2.Encode(),
+		"delete IP_TO_IMSI 10.0.0.1",
+	}, client.reset(nil))
+
+	// addresses reassigned to another subscriber aren't deleted with the previous subscriber's record
+	r3 := Record{SessionID: "sid3", IPv4: "10.0.0.2"}
+	p.Update("001010000000002", r3)
+	p.Remove("sid2")
+	p.Remove("unknown")
+	assert.Equal(t, 0, p.Publish())
+	assert.Equal(t, []string{
+		"delete IMSI_TO_UE 001010000000001",
+		"delete IP_TO_IMSI 2001:db8::2",
+		"delete MAC_TO_IMSI AA-BB-CC-DD-EE-FF",
+		"update IP_TO_IMSI 10.0.0.2 001010000000002",
+		"update IMSI_TO_UE 001010000000002 " + r3.Encode(),
+	}, client.reset(nil))
+	_, ok := p.Get("001010000000001")
+	assert.False(t, ok)
+	assert.Equal(t, map[lookupKey]string{{orcprotos.TableID_IP_TO_IMSI, "10.0.0.2"}: "001010000000002"}, p.lookups)
+}
+
+func TestPublisherRetries(t *testing.T) {
+	client := &recordingClient{err: fmt.Errorf("directoryd unavailable")}
+	p := NewPublisher(client)
+	r1 := Record{SessionID: "sid1", IPv4: "10.0.0.1"}
+	p.Update("", r1)
+	p.Update("001010000000001", r1)
+	assert.Equal(t, 1, p.Publish())
+	assert.Equal(t, []string{"update IP_TO_IMSI 10.0.0.1 001010000000001"}, client.reset(nil))
+
+	// failed publishes are published whole again by the next publish
+	assert.Equal(t, 0, p.Publish())
+	assert.Equal(t, []string{
+		"update IP_TO_IMSI 10.0.0.1 001010000000001",
+		"update IMSI_TO_UE 001010000000001 " + r1.Encode(),
+	}, client.reset(fmt.Errorf("directoryd unavailable")))
+	p.Remove("sid1")
+	assert.Equal(t, 1, p.Publish())
+	client.reset(nil)
+	assert.Equal(t, 0, p.Publish())
+	assert.Equal(t, []string{"delete IMSI_TO_UE 001010000000001", "delete IP_TO_IMSI 10.0.0.1"}, client.reset(nil))
+	assert.Equal(t, 0, p.Publish())
+	assert.Empty(t, client.reset(nil))
+
+	// failed publishes are retried with backoff & right away by the subscriber's next update
+	published := func() bool {
+		client.Lock()
+		defer client.Unlock()
+		return len(client.ops) > 0 && strings.HasPrefix(client.ops[len(client.ops)-1], "update IMSI_TO_UE")
+	}
+	await := func() {
+		for start := time.Now(); !published() && time.Since(start) < time.Second; time.Sleep(time.Millisecond) {
+		}
+	}
+	p.SetRetryBackoff(time.Millisecond, 10*time.Millisecond)
+	go p.Run()
+	client.reset(fmt.Errorf("directoryd unavailable"))
+	p.Update("001010000000001", r1)
+	time.Sleep(5 * time.Millisecond)
+	assert.False(t, published())
+	client.reset(nil)
+	await()
+	assert.True(t, published())
+
+	p.SetRetryBackoff(time.Hour, time.Hour)
+	client.reset(fmt.Errorf("directoryd unavailable"))
+	p.Update("001010000000002", r1)
+	time.Sleep(5 * time.Millisecond)
+	client.reset(nil)
+	p.Update("001010000000002", r1)
+	await()
+	assert.True(t, published())
+}
+
+func TestRecordEncoding(t *testing.T) {
+	r := Record{
+		SessionID: "sid1",
+		IPv4:      "10.0.0.1",
+		IPv6:      "2001:db8::1",
+		MAC:       "AA-BB-CC-DD-EE-FF",
+		AP:        "11-22-33-44-55-66",
+	}
+	assert.Equal(t,
+		`{"session_id":"sid1","ipv4":"10.0.0.1","ipv6":"2001:db8::1","mac":"AA-BB-CC-DD-EE-FF","ap":"11-22-33-44-55-66"}`,
+		r.Encode())
+	decoded, err := Decode(r.Encode())
+	assert.NoError(t, err)
+	assert.Equal(t, r, decoded)
+	_, err = Decode("10.0.0.1")
+	assert.Error(t, err)
+}
@@ -0,0 +1,279 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package dispatcher
+
+import (
+	"io"
+	"sort"
+	"sync"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+// Start forwards Accounting Start to the session's instance
+func (d *Dispatcher) Start(ctx context.Context, aaaCtx *protos.Context) (*protos.AcctResp, error) {
+	conn, err := d.route(aaaCtx.GetSessionId())
+	if err != nil {
+		return &protos.AcctResp{}, err
+	}
+	return protos.NewAccountingClient(conn).Start(outgoing(ctx), aaaCtx)
+}
+
+// InterimUpdate forwards Accounting Interim-Update to the session's instance
+func (d *Dispatcher) InterimUpdate(ctx context.Context, ur *protos.UpdateRequest) (*protos.AcctResp, error) {
+	conn, err := d.route(ur.GetCtx().GetSessionId())
+	if err != nil {
+		return &protos.AcctResp{}, err
+	}
+	return protos.NewAccountingClient(conn).InterimUpdate(outgoing(ctx), ur)
+}
+
+// Stop forwards Accounting Stop to the session's instance
+func (d *Dispatcher) Stop(ctx context.Context, req *protos.StopRequest) (*protos.AcctResp, error) {
+	conn, err := d.route(req.GetCtx().GetSessionId())
+	if err != nil {
+		return &protos.AcctResp{}, err
+	}
+	return protos.NewAccountingClient(conn).Stop(outgoing(ctx), req)
+}
+
+// CreateSession forwards the session creation to the session's instance
+func (d *Dispatcher) CreateSession(ctx context.Context, aaaCtx *protos.Context) (*protos.AcctResp, error) {
+	conn, err := d.route(aaaCtx.GetSessionId())
+	if err != nil {
+		return &protos.AcctResp{}, err
+	}
+	return protos.NewAccountingClient(conn).CreateSession(outgoing(ctx), aaaCtx)
+}
+
+// TerminateSession forwards session manager's termination to the session's instance
+func (d *Dispatcher) TerminateSession(
+	ctx context.Context, req *protos.TerminateSessionRequest) (*protos.AcctResp, error) {
+
+	conn, err := d.route(req.GetRadiusSessionId())
+	if err != nil {
+		return &protos.AcctResp{}, err
+	}
+	return protos.NewAccountingClient(conn).TerminateSession(outgoing(ctx), req)
+}
+
+// SetSessionBandwidth forwards the bandwidth change to the session's instance
+func (d *Dispatcher) SetSessionBandwidth(
+	ctx context.Context, req *protos.SessionBandwidthRequest) (*protos.AcctResp, error) {
+
+	conn, err := d.route(req.GetSessionId())
+	if err != nil {
+		return &protos.AcctResp{}, err
+	}
+	return protos.NewAccountingClient(conn).SetSessionBandwidth(outgoing(ctx), req)
+}
+
+// ChangeSession forwards session manager's policy change to the session's instance
+func (d *Dispatcher) ChangeSession(ctx context.Context, req *protos.ChangeSessionRequest) (*protos.AcctResp, error) {
+	conn, err := d.route(req.GetRadiusSessionId())
+	if err != nil {
+		return &protos.AcctResp{}, err
+	}
+	return protos.NewAccountingClient(conn).ChangeSession(outgoing(ctx), req)
+}
+
+// SecurityEvent forwards the security trigger to the session's instance
+func (d *Dispatcher) SecurityEvent(ctx context.Context, req *protos.SecurityEventRequest) (*protos.AcctResp, error) {
+	conn, err := d.route(req.GetSessionId())
+	if err != nil {
+		return &protos.AcctResp{}, err
+	}
+	return protos.NewAccountingClient(conn).SecurityEvent(outgoing(ctx), req)
+}
+
+// DeviceHint forwards the hint to the session's instance, hints identified by MAC address are broadcast since the
+// UE's session may not exist yet
+func (d *Dispatcher) DeviceHint(ctx context.Context, req *protos.DeviceHintRequest) (*protos.AcctResp, error) {
+	if len(req.GetSessionId()) > 0 {
+		conn, err := d.route(req.GetSessionId())
+		if err != nil {
+			return &protos.AcctResp{}, err
+		}
+		return protos.NewAccountingClient(conn).DeviceHint(outgoing(ctx), req)
+	}
+	_, err := d.fanOut(outgoing(ctx), func(ctx context.Context, conn *grpc.ClientConn) (interface{}, error) {
+		return protos.NewAccountingClient(conn).DeviceHint(ctx, req)
+	})
+	return &protos.AcctResp{}, err
+}
+
+// Handover broadcasts the handover, the subscriber's next Wi-Fi session may be dispatched to any instance
+func (d *Dispatcher) Handover(ctx context.Context, req *protos.HandoverRequest) (*protos.AcctResp, error) {
+	_, err := d.fanOut(outgoing(ctx), func(ctx context.Context, conn *grpc.ClientConn) (interface{}, error) {
+		return protos.NewAccountingClient(conn).Handover(ctx, req)
+	})
+	return &protos.AcctResp{}, err
+}
+
+// AcctOnOff broadcasts the NAS's Accounting-On/Off, the NAS's sessions may be dispatched to any instance
+func (d *Dispatcher) AcctOnOff(ctx context.Context, req *protos.AcctOnOffRequest) (*protos.AcctResp, error) {
+	_, err := d.fanOut(outgoing(ctx), func(ctx context.Context, conn *grpc.ClientConn) (interface{}, error) {
+		return protos.NewAccountingClient(conn).AcctOnOff(ctx, req)
+	})
+	return &protos.AcctResp{}, err
+}
+
+// Reconcile reconciles the reported usages on all instances & merges their reports. Reported usages of subscribers
+// without a session on any instance are reported once
+func (d *Dispatcher) Reconcile(
+	ctx context.Context, req *protos.ReconciliationRequest) (*protos.ReconciliationReport, error) {
+
+	results, err := d.fanOut(outgoing(ctx), func(ctx context.Context, conn *grpc.ClientConn) (interface{}, error) {
+		return protos.NewAccountingClient(conn).Reconcile(ctx, req)
+	})
+	if err != nil {
+		return &protos.ReconciliationReport{}, err
+	}
+	reports := make([]*protos.ReconciliationReport, 0, len(results))
+	for _, res := range results {
+		reports = append(reports, res.(*protos.ReconciliationReport))
+	}
+	return mergeReconciliationReports(reports), nil
+}
+
+// WatchSubscriberUsage merges the subscriber's usage streams of all instances until the client cancels or an
+// instance's stream fails
+func (d *Dispatcher) WatchSubscriberUsage(
+	req *protos.SubscriberUsageRequest, stream protos.Accounting_WatchSubscriberUsageServer) error {
+
+	ctx, cancel := context.WithCancel(outgoing(stream.Context()))
+	defer cancel()
+	var (
+		sendMu sync.Mutex
+		wg     sync.WaitGroup
+	)
+	errs := make(chan error, len(d.ring.Instances()))
+	for _, instance := range d.ring.Instances() {
+		conn, err := d.conn(instance)
+		if err != nil {
+			return err
+		}
+		watch, err := protos.NewAccountingClient(conn).WatchSubscriberUsage(ctx, req)
+		if err != nil {
+			return err
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				update, err := watch.Recv()
+				if err == nil {
+					sendMu.Lock()
+					err = stream.Send(update)
+					sendMu.Unlock()
+				}
+				if err != nil {
+					errs <- err
+					return
+				}
+			}
+		}()
+	}
+	err := <-errs
+	cancel()
+	wg.Wait()
+	if err == io.EOF || status.Code(err) == codes.Canceled {
+		return nil
+	}
+	return err
+}
+
+// SubscribeEvents merges the session event streams of all instances until the client cancels or an instance's
+// stream fails
+func (d *Dispatcher) SubscribeEvents(
+	req *protos.SessionEventsRequest, stream protos.Accounting_SubscribeEventsServer) error {
+
+	ctx, cancel := context.WithCancel(outgoing(stream.Context()))
+	defer cancel()
+	var (
+		sendMu sync.Mutex
+		wg     sync.WaitGroup
+	)
+	errs := make(chan error, len(d.ring.Instances()))
+	for _, instance := range d.ring.Instances() {
+		conn, err := d.conn(instance)
+		if err != nil {
+			return err
+		}
+		events, err := protos.NewAccountingClient(conn).SubscribeEvents(ctx, req)
+		if err != nil {
+			return err
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				ev, err := events.Recv()
+				if err == nil {
+					sendMu.Lock()
+					err = stream.Send(ev)
+					sendMu.Unlock()
+				}
+				if err != nil {
+					errs <- err
+					return
+				}
+			}
+		}()
+	}
+	err := <-errs
+	cancel()
+	wg.Wait()
+	if err == io.EOF || status.Code(err) == codes.Canceled {
+		return nil
+	}
+	return err
+}
+
+// mergeReconciliationReports merges the instances' reports, ordered by divergence like the instances' reports
+func mergeReconciliationReports(reports []*protos.ReconciliationReport) *protos.ReconciliationReport {
+	merged := &protos.ReconciliationReport{}
+	matched := map[string]bool{}
+	for _, r := range reports {
+		for _, e := range r.GetEntries() {
+			if len(e.GetSessionId()) > 0 {
+				merged.Entries = append(merged.Entries, e)
+				matched[e.GetImsi()] = true
+			}
+		}
+	}
+	// every instance reports the usages it has no session of, the usages are matched by the instance having the
+	// subscriber's session, if any
+	reportedOnly := map[string]bool{}
+	for _, r := range reports {
+		for _, e := range r.GetEntries() {
+			if len(e.GetSessionId()) == 0 && !matched[e.GetImsi()] && !reportedOnly[e.GetImsi()] {
+				reportedOnly[e.GetImsi()] = true
+				merged.Entries = append(merged.Entries, e)
+			}
+		}
+	}
+	for _, e := range merged.Entries {
+		if e.GetDiverged() {
+			merged.DivergedCount++
+		}
+		if e.GetFlagged() {
+			merged.FlaggedCount++
+		}
+	}
+	sort.SliceStable(merged.Entries, func(i, j int) bool {
+		return merged.Entries[i].GetDivergence() > merged.Entries[j].GetDivergence()
+	})
+	return merged
+}
@@ -0,0 +1,52 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package dispatcher
+
+import (
+	"golang.org/x/net/context"
+
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+// HandleIdentity forwards the EAP Identity to the session's instance, which keeps the session once it's authenticated
+func (d *Dispatcher) HandleIdentity(ctx context.Context, in *protos.EapIdentity) (*protos.Eap, error) {
+	conn, err := d.route(in.GetCtx().GetSessionId())
+	if err != nil {
+		return &protos.Eap{Ctx: in.GetCtx()}, err
+	}
+	return protos.NewAuthenticatorClient(conn).HandleIdentity(outgoing(ctx), in)
+}
+
+// Handle forwards the EAP message to the session's instance
+func (d *Dispatcher) Handle(ctx context.Context, in *protos.Eap) (*protos.Eap, error) {
+	conn, err := d.route(in.GetCtx().GetSessionId())
+	if err != nil {
+		return &protos.Eap{Ctx: in.GetCtx()}, err
+	}
+	return protos.NewAuthenticatorClient(conn).Handle(outgoing(ctx), in)
+}
+
+// SupportedMethods returns the EAP methods of the first instance, all instances are expected to be configured alike
+func (d *Dispatcher) SupportedMethods(ctx context.Context, in *protos.Void) (*protos.EapMethodList, error) {
+	conn, err := d.conn(d.ring.Instances()[0])
+	if err != nil {
+		return &protos.EapMethodList{}, err
+	}
+	return protos.NewAuthenticatorClient(conn).SupportedMethods(outgoing(ctx), in)
+}
+
+// MacAuthBypass forwards the device's MAC Authentication Bypass to the session's instance, which keeps the session
+// once it's authenticated
+func (d *Dispatcher) MacAuthBypass(ctx context.Context, aaaCtx *protos.Context) (*protos.Context, error) {
+	conn, err := d.route(aaaCtx.GetSessionId())
+	if err != nil {
+		return aaaCtx, err
+	}
+	return protos.NewAuthenticatorClient(conn).MacAuthBypass(outgoing(ctx), aaaCtx)
+}
@@ -0,0 +1,137 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package dispatcher shards sessions across several AAA server instances by consistent hashing of their session IDs,
+// for very large venues running several AAA processes. Session scoped calls are forwarded to the session's instance,
+// calls without a session are broadcast & admin queries are fanned out to all instances & merged into a shared view
+package dispatcher
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"magma/feg/gateway/registry"
+)
+
+// Dispatcher - AAA accounting, authenticator & session admin servers forwarding calls to the AAA instances of its ring.
+// Calls are authorized by the instances, callers' metadata (e.g. admin tokens) is forwarded with the calls
+type Dispatcher struct {
+	ring *Ring
+}
+
+// New returns the dispatcher of the ring's instances, instances are service registry names
+func New(ring *Ring) (*Dispatcher, error) {
+	if ring == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Nil AAA instances ring")
+	}
+	return &Dispatcher{ring: ring}, nil
+}
+
+// ParseInstances parses comma separated list of AAA instances, either service registry names (e.g. configured in
+// service_registry.yml) or host:port addresses. Addresses are added to the registry as AAA_SERVER@host:port services
+func ParseInstances(list string) ([]string, error) {
+	var res []string
+	for _, instance := range strings.Split(list, ",") {
+		instance = strings.TrimSpace(instance)
+		if len(instance) == 0 {
+			continue
+		}
+		if strings.Contains(instance, ":") {
+			host, portStr, err := net.SplitHostPort(instance)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid AAA instance address '%s': %v", instance, err)
+			}
+			port, err := strconv.Atoi(portStr)
+			if err != nil || port <= 0 || port > 0xFFFF {
+				return nil, fmt.Errorf("Invalid port of AAA instance address '%s'", instance)
+			}
+			service := registry.AAA_SERVER + "@" + instance
+			registry.AddService(service, host, port)
+			instance = service
+		}
+		res = append(res, instance)
+	}
+	return res, nil
+}
+
+// conn returns the connection to the instance
+func (d *Dispatcher) conn(instance string) (*grpc.ClientConn, error) {
+	conn, err := registry.GetConnection(instance)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "Error getting AAA instance %s RPC Connection: %v", instance, err)
+	}
+	return conn, nil
+}
+
+// route returns the connection to the session's instance
+func (d *Dispatcher) route(sid string) (*grpc.ClientConn, error) {
+	if len(sid) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "Missing session ID")
+	}
+	return d.conn(d.ring.Get(sid))
+}
+
+// fanOut calls all instances concurrently & returns their results in the ring's instances order, the first error
+// fails the whole fan out
+func (d *Dispatcher) fanOut(
+	ctx context.Context, call func(context.Context, *grpc.ClientConn) (interface{}, error)) ([]interface{}, error) {
+
+	instances := d.ring.Instances()
+	results := make([]interface{}, len(instances))
+	errs := make([]error, len(instances))
+	var wg sync.WaitGroup
+	for i, instance := range instances {
+		conn, err := d.conn(instance)
+		if err != nil {
+			return nil, err
+		}
+		wg.Add(1)
+		go func(i int, instance string, conn *grpc.ClientConn) {
+			defer wg.Done()
+			results[i], errs[i] = call(ctx, conn)
+			if errs[i] != nil {
+				errs[i] = status.Errorf(
+					status.Code(errs[i]), "AAA instance %s: %v", instance, status.Convert(errs[i]).Message())
+			}
+		}(i, instance, conn)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+// outgoing returns ctx with the incoming call's metadata, e.g. admin tokens, as its outgoing metadata. Transport
+// headers of the incoming call are not forwarded
+func outgoing(ctx context.Context) context.Context {
+	in, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+	out := metadata.MD{}
+	for k, v := range in {
+		if strings.HasPrefix(k, ":") || strings.HasPrefix(k, "grpc-") ||
+			k == "content-type" || k == "user-agent" || k == "te" {
+			continue
+		}
+		out[k] = append([]string(nil), v...)
+	}
+	return metadata.NewOutgoingContext(ctx, out)
+}
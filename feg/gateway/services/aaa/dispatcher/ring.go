@@ -0,0 +1,82 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package dispatcher
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// DefaultReplicas - default number of ring points per instance, more points spread sessions more evenly
+const DefaultReplicas = 128
+
+// Ring - consistent hash ring of AAA instances, adding or removing an instance moves only the sessions of the
+// instance's ring points
+type Ring struct {
+	points    []uint64
+	instances map[uint64]string
+	names     []string
+}
+
+// NewRing returns the ring of the given instances with the given number of points per instance, DefaultReplicas if
+// replicas isn't positive
+func NewRing(instances []string, replicas int) (*Ring, error) {
+	if len(instances) == 0 {
+		return nil, fmt.Errorf("No AAA instances")
+	}
+	if replicas <= 0 {
+		replicas = DefaultReplicas
+	}
+	r := &Ring{instances: map[uint64]string{}}
+	seen := map[string]bool{}
+	for _, instance := range instances {
+		if len(instance) == 0 {
+			return nil, fmt.Errorf("Empty AAA instance name")
+		}
+		if seen[instance] {
+			return nil, fmt.Errorf("Duplicate AAA instance %s", instance)
+		}
+		seen[instance] = true
+		r.names = append(r.names, instance)
+		for i := 0; i < replicas; i++ {
+			point := hash(instance + "#" + strconv.Itoa(i))
+			if _, ok := r.instances[point]; ok {
+				continue // collisions keep the first instance's point, so the ring doesn't depend on map order
+			}
+			r.instances[point] = instance
+			r.points = append(r.points, point)
+		}
+	}
+	sort.Slice(r.points, func(i, j int) bool { return r.points[i] < r.points[j] })
+	return r, nil
+}
+
+// Get returns the instance owning the key (session ID): the instance of the first ring point following the key's hash
+func (r *Ring) Get(key string) string {
+	h := hash(key)
+	i := sort.Search(len(r.points), func(i int) bool { return r.points[i] >= h })
+	if i == len(r.points) {
+		i = 0
+	}
+	return r.instances[r.points[i]]
+}
+
+// Instances returns all instances of the ring in their configured order
+func (r *Ring) Instances() []string {
+	return append([]string(nil), r.names...)
+}
+
+// hash returns the ring point of the key, unlike FNV its bits are evenly spread for keys differing in a digit
+func hash(key string) uint64 {
+	sum := sha256.Sum256([]byte(key))
+	return binary.BigEndian.Uint64(sum[:8])
+}
@@ -0,0 +1,54 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package dispatcher
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRing(t *testing.T) {
+	_, err := NewRing(nil, 0)
+	assert.Error(t, err)
+	_, err = NewRing([]string{"aaa1", "aaa1"}, 0)
+	assert.Error(t, err)
+	_, err = NewRing([]string{"aaa1", ""}, 0)
+	assert.Error(t, err)
+
+	ring, err := NewRing([]string{"aaa1", "aaa2", "aaa3"}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"aaa1", "aaa2", "aaa3"}, ring.Instances())
+
+	owners := map[string]string{}
+	counts := map[string]int{}
+	for i := 0; i < 3000; i++ {
+		sid := fmt.Sprintf("session-%d", i)
+		owners[sid] = ring.Get(sid)
+		counts[owners[sid]]++
+		assert.Equal(t, owners[sid], ring.Get(sid))
+	}
+	for instance, count := range counts {
+		assert.InDelta(t, 1000, count, 350, "sessions of %s", instance)
+	}
+
+	// an added instance takes over some sessions, other sessions stay with their instances
+	ring, err = NewRing([]string{"aaa1", "aaa2", "aaa3", "aaa4"}, 0)
+	require.NoError(t, err)
+	moved := 0
+	for sid, owner := range owners {
+		if newOwner := ring.Get(sid); newOwner != owner {
+			assert.Equal(t, "aaa4", newOwner)
+			moved++
+		}
+	}
+	assert.InDelta(t, 750, moved, 300)
+}
@@ -0,0 +1,269 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package dispatcher
+
+import (
+	"sort"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"magma/feg/gateway/registry"
+	"magma/feg/gateway/services/aaa/protos"
+	"magma/feg/gateway/services/aaa/serviceinfo"
+)
+
+// PatchSession forwards the patch to the session's instance
+func (d *Dispatcher) PatchSession(
+	ctx context.Context, req *protos.SessionPatchRequest) (*protos.SessionPatchResult, error) {
+
+	conn, err := d.route(req.GetSessionId())
+	if err != nil {
+		return nil, err
+	}
+	return protos.NewSessionAdminClient(conn).PatchSession(outgoing(ctx), req)
+}
+
+// ExportSessions returns the sessions of all instances as one export
+func (d *Dispatcher) ExportSessions(
+	ctx context.Context, req *protos.ExportSessionsRequest) (*protos.SessionExport, error) {
+
+	results, err := d.fanOut(outgoing(ctx), func(ctx context.Context, conn *grpc.ClientConn) (interface{}, error) {
+		return protos.NewSessionAdminClient(conn).ExportSessions(ctx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+	merged := &protos.SessionExport{}
+	for i, res := range results {
+		export := res.(*protos.SessionExport)
+		if i == 0 {
+			merged.Version = export.GetVersion()
+		} else if export.GetVersion() != merged.GetVersion() {
+			return nil, status.Errorf(codes.FailedPrecondition,
+				"Mismatched session export versions of AAA instances: %d != %d", export.GetVersion(), merged.GetVersion())
+		}
+		if export.GetExportedAtMs() > merged.GetExportedAtMs() {
+			merged.ExportedAtMs = export.GetExportedAtMs()
+		}
+		merged.Sessions = append(merged.Sessions, export.GetSessions()...)
+	}
+	return merged, nil
+}
+
+// ImportSessions imports each session of the export into the session's instance
+func (d *Dispatcher) ImportSessions(
+	ctx context.Context, req *protos.ImportSessionsRequest) (*protos.ImportSessionsResult, error) {
+
+	shares := map[string]*protos.ImportSessionsRequest{}
+	for _, s := range req.GetExport().GetSessions() {
+		instance := d.ring.Get(s.GetCtx().GetSessionId())
+		share, ok := shares[instance]
+		if !ok {
+			share = &protos.ImportSessionsRequest{
+				Export: &protos.SessionExport{
+					Version:      req.GetExport().GetVersion(),
+					ExportedAtMs: req.GetExport().GetExportedAtMs(),
+				},
+				Overwrite: req.GetOverwrite(),
+			}
+			shares[instance] = share
+		}
+		share.Export.Sessions = append(share.Export.Sessions, s)
+	}
+	res := &protos.ImportSessionsResult{}
+	for _, instance := range d.ring.Instances() {
+		share, ok := shares[instance]
+		if !ok {
+			continue
+		}
+		conn, err := d.conn(instance)
+		if err != nil {
+			return res, err
+		}
+		imported, err := protos.NewSessionAdminClient(conn).ImportSessions(outgoing(ctx), share)
+		if err != nil {
+			return res, status.Errorf(status.Code(err), "AAA instance %s (%d sessions imported by other instances): %v",
+				instance, res.GetImported(), status.Convert(err).Message())
+		}
+		res.Imported += imported.GetImported()
+		res.SkippedSessionIds = append(res.SkippedSessionIds, imported.GetSkippedSessionIds()...)
+	}
+	return res, nil
+}
+
+// DumpEffectiveConfig returns the effective settings of all instances, prefixed by their instances' names
+func (d *Dispatcher) DumpEffectiveConfig(
+	ctx context.Context, req *protos.DumpEffectiveConfigRequest) (*protos.EffectiveConfig, error) {
+
+	results, err := d.fanOut(outgoing(ctx), func(ctx context.Context, conn *grpc.ClientConn) (interface{}, error) {
+		return protos.NewSessionAdminClient(conn).DumpEffectiveConfig(ctx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+	merged := &protos.EffectiveConfig{}
+	for i, instance := range d.ring.Instances() {
+		cfg := results[i].(*protos.EffectiveConfig)
+		merged.Service = cfg.GetService()
+		for _, s := range cfg.GetSettings() {
+			merged.Settings = append(merged.Settings,
+				&protos.Setting{Name: instance + "/" + s.GetName(), Value: s.GetValue(), Source: s.GetSource()})
+		}
+	}
+	return merged, nil
+}
+
+// GetAuthRates returns the authentication success rates of all instances' authentications
+func (d *Dispatcher) GetAuthRates(ctx context.Context, req *protos.GetAuthRatesRequest) (*protos.AuthRates, error) {
+	results, err := d.fanOut(outgoing(ctx), func(ctx context.Context, conn *grpc.ClientConn) (interface{}, error) {
+		return protos.NewSessionAdminClient(conn).GetAuthRates(ctx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+	windows := map[uint32]*protos.WindowStats{}
+	for _, res := range results {
+		for _, w := range res.(*protos.AuthRates).GetWindows() {
+			merged, ok := windows[w.GetWindowSec()]
+			if !ok {
+				merged = &protos.WindowStats{WindowSec: w.GetWindowSec()}
+				windows[w.GetWindowSec()] = merged
+			}
+			merged.Successes += w.GetSuccesses()
+			merged.Failures += w.GetFailures()
+		}
+	}
+	rates := &protos.AuthRates{}
+	for _, w := range windows {
+		w.SuccessRate = 1
+		if total := w.GetSuccesses() + w.GetFailures(); total > 0 {
+			w.SuccessRate = float64(w.GetSuccesses()) / float64(total)
+		}
+		rates.Windows = append(rates.Windows, w)
+	}
+	sort.Slice(rates.Windows, func(i, j int) bool {
+		return rates.Windows[i].GetWindowSec() < rates.Windows[j].GetWindowSec()
+	})
+	return rates, nil
+}
+
+// ListSessions returns the matching sessions of all instances, ordered by their session IDs
+func (d *Dispatcher) ListSessions(ctx context.Context, req *protos.ListSessionsRequest) (*protos.SessionList, error) {
+	results, err := d.fanOut(outgoing(ctx), func(ctx context.Context, conn *grpc.ClientConn) (interface{}, error) {
+		return protos.NewSessionAdminClient(conn).ListSessions(ctx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+	merged := &protos.SessionList{}
+	for _, res := range results {
+		merged.Sessions = append(merged.Sessions, res.(*protos.SessionList).GetSessions()...)
+	}
+	sort.Slice(merged.Sessions, func(i, j int) bool {
+		return merged.Sessions[i].GetCtx().GetSessionId() < merged.Sessions[j].GetCtx().GetSessionId()
+	})
+	return merged, nil
+}
+
+// GetSession returns the session of its instance
+func (d *Dispatcher) GetSession(ctx context.Context, req *protos.GetSessionRequest) (*protos.ExportedSession, error) {
+	conn, err := d.route(req.GetSessionId())
+	if err != nil {
+		return nil, err
+	}
+	return protos.NewSessionAdminClient(conn).GetSession(outgoing(ctx), req)
+}
+
+// TerminateSessionByImsi terminates the IMSI's sessions of all instances
+func (d *Dispatcher) TerminateSessionByImsi(
+	ctx context.Context, req *protos.TerminateSessionsRequest) (*protos.TerminateSessionsResult, error) {
+
+	results, err := d.fanOut(outgoing(ctx), func(ctx context.Context, conn *grpc.ClientConn) (interface{}, error) {
+		res, err := protos.NewSessionAdminClient(conn).TerminateSessionByImsi(ctx, req)
+		if status.Code(err) == codes.NotFound {
+			return &protos.TerminateSessionsResult{}, nil // the IMSI's sessions are of other instances
+		}
+		return res, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	merged := &protos.TerminateSessionsResult{}
+	for _, res := range results {
+		merged.SessionIds = append(merged.SessionIds, res.(*protos.TerminateSessionsResult).GetSessionIds()...)
+	}
+	if len(merged.SessionIds) == 0 {
+		return nil, status.Errorf(codes.NotFound, "No sessions of IMSI %s are found", req.GetImsi())
+	}
+	sort.Strings(merged.SessionIds)
+	return merged, nil
+}
+
+// GetServiceInfo returns the dispatcher's build info & the service info of all instances, named by their instances
+func (d *Dispatcher) GetServiceInfo(
+	ctx context.Context, req *protos.GetServiceInfoRequest) (*protos.ServiceInfo, error) {
+
+	results, err := d.fanOut(outgoing(ctx), func(ctx context.Context, conn *grpc.ClientConn) (interface{}, error) {
+		return protos.NewSessionAdminClient(conn).GetServiceInfo(ctx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+	info := serviceinfo.New(registry.AAA_SERVER)
+	for i, instance := range d.ring.Instances() {
+		res := results[i].(*protos.ServiceInfo)
+		res.Instance = instance
+		info.Instances = append(info.Instances, res)
+	}
+	return info, nil
+}
+
+// InjectAccountingFaults injects the accounting faults into all instances, sessions of the subscriber may be served
+// by any of them, & returns the faults injected into the first instance
+func (d *Dispatcher) InjectAccountingFaults(
+	ctx context.Context, req *protos.InjectAccountingFaultsRequest) (*protos.InjectedAccountingFaults, error) {
+
+	results, err := d.fanOut(outgoing(ctx), func(ctx context.Context, conn *grpc.ClientConn) (interface{}, error) {
+		return protos.NewSessionAdminClient(conn).InjectAccountingFaults(ctx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return &protos.InjectedAccountingFaults{}, nil
+	}
+	return results[0].(*protos.InjectedAccountingFaults), nil
+}
+
+// ListCoaTransactions returns the logged CoA & Disconnect-Requests of all instances matching the request's filters,
+// the latest first
+func (d *Dispatcher) ListCoaTransactions(
+	ctx context.Context, req *protos.ListCoaTransactionsRequest) (*protos.CoaTransactionList, error) {
+
+	results, err := d.fanOut(outgoing(ctx), func(ctx context.Context, conn *grpc.ClientConn) (interface{}, error) {
+		return protos.NewSessionAdminClient(conn).ListCoaTransactions(ctx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+	res := &protos.CoaTransactionList{}
+	for _, r := range results {
+		res.Transactions = append(res.Transactions, r.(*protos.CoaTransactionList).GetTransactions()...)
+	}
+	sort.SliceStable(res.Transactions, func(i, j int) bool {
+		return res.Transactions[i].GetTimeMs() > res.Transactions[j].GetTimeMs()
+	})
+	if limit := int(req.GetLimit()); limit > 0 && len(res.Transactions) > limit {
+		res.Transactions = res.Transactions[:limit]
+	}
+	return res, nil
+}
@@ -0,0 +1,209 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package dump implements the versioned local debug dump format of AAA sessions & audit events.
+//
+// Dumps are serialized as JSON or MessagePack documents of the same schema & carry their schema version. Dumps of
+// older versions are migrated to the current version when they are read, so dumps & exports created by one gateway
+// version can be read by newer tooling. Version 1 is the session export of the session admin service's
+// ExportSessions
+package dump
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"magma/feg/gateway/services/aaa/audit"
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+// Version - current dump schema version
+const Version = 2
+
+// Format - dump serialization format
+type Format string
+
+// Dump serialization formats
+const (
+	JSON    Format = "json"
+	MsgPack Format = "msgpack"
+)
+
+// Dump - sessions & audit events of a gateway
+type Dump struct {
+	Version   int                       `json:"version"`
+	CreatedAt time.Time                 `json:"created_at"`
+	Sessions  []*protos.ExportedSession `json:"sessions,omitempty"`
+	Events    []*audit.Event            `json:"events,omitempty"`
+}
+
+// New returns a dump of the exported sessions & the audit events
+func New(export *protos.SessionExport, events []*audit.Event) *Dump {
+	return &Dump{Version: Version, CreatedAt: time.Now(), Sessions: export.GetSessions(), Events: events}
+}
+
+// Migration upgrades a dump document of its version to the next version
+type Migration func(doc map[string]interface{}) error
+
+// migrations - migrations by the versions they upgrade
+var migrations = map[int]Migration{
+	1: migrateV1,
+}
+
+// migrateV1 upgrades a session export, its export time becomes the dump's creation time
+func migrateV1(doc map[string]interface{}) error {
+	if ms, ok := doc["exported_at_ms"]; ok {
+		n, ok := ms.(json.Number)
+		if !ok {
+			return fmt.Errorf("invalid exported_at_ms: %v", ms)
+		}
+		msec, err := n.Int64()
+		if err != nil {
+			return fmt.Errorf("invalid exported_at_ms: %v", err)
+		}
+		doc["created_at"] = time.Unix(0, msec*int64(time.Millisecond)).UTC().Format(time.RFC3339Nano)
+		delete(doc, "exported_at_ms")
+	}
+	return nil
+}
+
+// Marshal serializes the dump in the given format
+func Marshal(d *Dump, format Format) ([]byte, error) {
+	b, err := json.Marshal(d)
+	if err != nil || format == JSON {
+		return b, err
+	}
+	if format != MsgPack {
+		return nil, fmt.Errorf("unknown dump format '%s'", format)
+	}
+	doc, err := decodeJSON(b)
+	if err != nil {
+		return nil, err
+	}
+	return encodeMsgPack(doc)
+}
+
+// Unmarshal deserializes the JSON or MessagePack dump & migrates it to the current version, dumps of newer versions
+// are rejected
+func Unmarshal(b []byte) (*Dump, error) {
+	var (
+		doc map[string]interface{}
+		err error
+	)
+	switch DetectFormat(b) {
+	case JSON:
+		doc, err = decodeJSON(b)
+	case MsgPack:
+		doc, err = decodeMsgPack(b)
+	default:
+		return nil, fmt.Errorf("unknown dump format")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = migrate(doc); err != nil {
+		return nil, err
+	}
+	b, err = json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	d := &Dump{}
+	if err = json.Unmarshal(b, d); err != nil {
+		return nil, fmt.Errorf("invalid dump: %v", err)
+	}
+	return d, nil
+}
+
+// DetectFormat returns the format of the serialized dump, empty format if it's neither JSON nor MessagePack map
+func DetectFormat(b []byte) Format {
+	b = bytes.TrimLeft(b, " \t\r\n")
+	if len(b) == 0 {
+		return ""
+	}
+	switch c := b[0]; {
+	case c == '{':
+		return JSON
+	case c&0xf0 == mpFixMap || c == mpMap16 || c == mpMap32:
+		return MsgPack
+	}
+	return ""
+}
+
+// FormatOf returns the dump format of the file's extension, JSON unless the extension is .msgpack or .mp
+func FormatOf(path string) Format {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".msgpack", ".mp":
+		return MsgPack
+	}
+	return JSON
+}
+
+// WriteFile writes the dump to the file in the format of the file's extension
+func WriteFile(path string, d *Dump) error {
+	b, err := Marshal(d, FormatOf(path))
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, b, 0640)
+}
+
+// ReadFile reads the dump file of any version & format
+func ReadFile(path string) (*Dump, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	d, err := Unmarshal(b)
+	if err != nil {
+		return nil, fmt.Errorf("error reading dump %s: %v", path, err)
+	}
+	return d, nil
+}
+
+// migrate upgrades the dump document of any supported version to the current version
+func migrate(doc map[string]interface{}) error {
+	n, ok := doc["version"].(json.Number)
+	if !ok {
+		return fmt.Errorf("missing dump version")
+	}
+	v, err := n.Int64()
+	if err != nil || v < 1 {
+		return fmt.Errorf("invalid dump version %s", n)
+	}
+	if v > Version {
+		return fmt.Errorf("dump version %d is newer than the supported version %d", v, Version)
+	}
+	for ; v < Version; v++ {
+		m, ok := migrations[int(v)]
+		if !ok {
+			return fmt.Errorf("no migration of dump version %d", v)
+		}
+		if err = m(doc); err != nil {
+			return fmt.Errorf("dump version %d migration error: %v", v, err)
+		}
+		doc["version"] = json.Number(fmt.Sprint(v + 1))
+	}
+	return nil
+}
+
+// decodeJSON decodes the JSON document, its numbers are kept as json.Number
+func decodeJSON(b []byte) (map[string]interface{}, error) {
+	doc := map[string]interface{}{}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid JSON dump: %v", err)
+	}
+	return doc, nil
+}
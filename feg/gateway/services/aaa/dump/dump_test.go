@@ -0,0 +1,126 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package dump
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"magma/feg/gateway/services/aaa/audit"
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+func testDump() *Dump {
+	export := &protos.SessionExport{Version: 1, Sessions: []*protos.ExportedSession{{
+		Ctx: &protos.Context{
+			SessionId: "sid1", Imsi: "001010000000001", Apn: "internet", Attributes: map[string]string{"class": "gold"}},
+		IdleTimeoutMs: 60000,
+		StartTimeMs:   1571000000000,
+		OctetsIn:      math.MaxUint64,
+		OctetsOut:     1 << 40,
+	}}}
+	events := []*audit.Event{{
+		Type: audit.Start, SessionId: "sid1", Imsi: "001010000000001", MonoNs: -1,
+		Time: time.Date(2019, 10, 13, 20, 53, 20, 0, time.UTC), Duration: "1m0s",
+	}}
+	d := New(export, events)
+	d.CreatedAt = time.Date(2019, 10, 14, 0, 0, 0, 0, time.UTC)
+	return d
+}
+
+func TestMarshal(t *testing.T) {
+	d := testDump()
+	for _, format := range []Format{JSON, MsgPack} {
+		b, err := Marshal(d, format)
+		assert.NoError(t, err)
+		assert.Equal(t, format, DetectFormat(b))
+		read, err := Unmarshal(b)
+		assert.NoError(t, err, format)
+		assert.Equal(t, d, read, format)
+	}
+	_, err := Marshal(d, "xml")
+	assert.Error(t, err)
+
+	// MessagePack encoding is deterministic
+	b1, err := Marshal(d, MsgPack)
+	assert.NoError(t, err)
+	b2, err := Marshal(testDump(), MsgPack)
+	assert.NoError(t, err)
+	assert.Equal(t, b1, b2)
+}
+
+func TestMigration(t *testing.T) {
+	// session exports are version 1 dumps
+	export := &protos.SessionExport{
+		Version: 1, ExportedAtMs: 1571011200000, Sessions: testDump().Sessions}
+	b, err := json.Marshal(export)
+	assert.NoError(t, err)
+	d, err := Unmarshal(b)
+	assert.NoError(t, err)
+	assert.Equal(t, Version, d.Version)
+	assert.True(t, testDump().CreatedAt.Equal(d.CreatedAt))
+	assert.Equal(t, export.Sessions, d.Sessions)
+	assert.Empty(t, d.Events)
+
+	// dumps of newer or unknown versions are rejected
+	_, err = Unmarshal([]byte(`{"version": 3}`))
+	assert.Error(t, err)
+	_, err = Unmarshal([]byte(`{"sessions": []}`))
+	assert.Error(t, err)
+	_, err = Unmarshal([]byte(`{"version": 1, "exported_at_ms": "yesterday"}`))
+	assert.Error(t, err)
+}
+
+func TestMsgPack(t *testing.T) {
+	doc := map[string]interface{}{
+		"ints": []interface{}{json.Number("0"), json.Number("-1"), json.Number("-33"), json.Number("200"),
+			json.Number("-40000"), json.Number("70000"), json.Number("-5000000000"), json.Number("18446744073709551615")},
+		"float":  json.Number("0.5"),
+		"str":    string(make([]byte, 300)),
+		"nested": map[string]interface{}{"nil": nil, "t": true, "f": false},
+	}
+	b, err := encodeMsgPack(doc)
+	assert.NoError(t, err)
+	decoded, err := decodeMsgPack(b)
+	assert.NoError(t, err)
+	assert.Equal(t, doc, decoded)
+
+	// binaries are decoded as base64 strings, truncated documents are invalid
+	decoded, err = decodeMsgPack([]byte{0x81, 0xa3, 'm', 's', 'k', mpBin8, 2, 0xff, 0x00})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"msk": "/wA="}, decoded)
+	_, err = decodeMsgPack(b[:len(b)-1])
+	assert.Error(t, err)
+	_, err = decodeMsgPack(append(b, 0))
+	assert.Error(t, err)
+}
+
+func TestFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dump")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	d := testDump()
+	for _, name := range []string{"dump.json", "dump.msgpack"} {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, WriteFile(path, d))
+		b, err := ioutil.ReadFile(path)
+		assert.NoError(t, err)
+		assert.Equal(t, FormatOf(path), DetectFormat(b))
+		read, err := ReadFile(path)
+		assert.NoError(t, err)
+		assert.Equal(t, d, read)
+	}
+}
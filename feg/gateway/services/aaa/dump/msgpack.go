@@ -0,0 +1,314 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package dump
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// MessagePack type markers, see https://github.com/msgpack/msgpack/blob/master/spec.md
+const (
+	mpFixMap   = 0x80
+	mpFixArray = 0x90
+	mpFixStr   = 0xa0
+	mpNil      = 0xc0
+	mpFalse    = 0xc2
+	mpTrue     = 0xc3
+	mpBin8     = 0xc4
+	mpBin16    = 0xc5
+	mpBin32    = 0xc6
+	mpFloat32  = 0xca
+	mpFloat64  = 0xcb
+	mpUint8    = 0xcc
+	mpUint16   = 0xcd
+	mpUint32   = 0xce
+	mpUint64   = 0xcf
+	mpInt8     = 0xd0
+	mpInt16    = 0xd1
+	mpInt32    = 0xd2
+	mpInt64    = 0xd3
+	mpStr8     = 0xd9
+	mpStr16    = 0xda
+	mpStr32    = 0xdb
+	mpArray16  = 0xdc
+	mpArray32  = 0xdd
+	mpMap16    = 0xde
+	mpMap32    = 0xdf
+)
+
+// encodeMsgPack encodes the decoded JSON document as MessagePack, map keys are sorted so equal documents encode
+// the same
+func encodeMsgPack(doc map[string]interface{}) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if err := mpEncode(buf, doc); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func mpEncode(buf *bytes.Buffer, v interface{}) error {
+	switch v := v.(type) {
+	case nil:
+		buf.WriteByte(mpNil)
+	case bool:
+		if v {
+			buf.WriteByte(mpTrue)
+		} else {
+			buf.WriteByte(mpFalse)
+		}
+	case json.Number:
+		return mpEncodeNumber(buf, v)
+	case string:
+		mpEncodeLen(buf, len(v), mpFixStr, 32, mpStr8, mpStr16, mpStr32)
+		buf.WriteString(v)
+	case []interface{}:
+		mpEncodeLen(buf, len(v), mpFixArray, 16, 0, mpArray16, mpArray32)
+		for _, item := range v {
+			if err := mpEncode(buf, item); err != nil {
+				return err
+			}
+		}
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		mpEncodeLen(buf, len(v), mpFixMap, 16, 0, mpMap16, mpMap32)
+		for _, k := range keys {
+			mpEncode(buf, k)
+			if err := mpEncode(buf, v[k]); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported MessagePack value type %T", v)
+	}
+	return nil
+}
+
+// mpEncodeLen writes the marker of a string, array or map of the given length, fixLimit is the maximum length of
+// its fix type + 1 & marker8 is 0 if the type has no 8 bit length
+func mpEncodeLen(buf *bytes.Buffer, n int, fix byte, fixLimit int, marker8, marker16, marker32 byte) {
+	switch {
+	case n < fixLimit:
+		buf.WriteByte(fix | byte(n))
+	case marker8 != 0 && n <= math.MaxUint8:
+		buf.Write([]byte{marker8, byte(n)})
+	case n <= math.MaxUint16:
+		buf.WriteByte(marker16)
+		binary.Write(buf, binary.BigEndian, uint16(n))
+	default:
+		buf.WriteByte(marker32)
+		binary.Write(buf, binary.BigEndian, uint32(n))
+	}
+}
+
+// mpEncodeNumber encodes integers in their smallest integer types & other numbers as float64
+func mpEncodeNumber(buf *bytes.Buffer, n json.Number) error {
+	if i, err := strconv.ParseInt(string(n), 10, 64); err == nil {
+		mpEncodeInt(buf, i)
+		return nil
+	}
+	if u, err := strconv.ParseUint(string(n), 10, 64); err == nil {
+		buf.WriteByte(mpUint64)
+		return binary.Write(buf, binary.BigEndian, u)
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return fmt.Errorf("invalid number %s", n)
+	}
+	buf.WriteByte(mpFloat64)
+	return binary.Write(buf, binary.BigEndian, f)
+}
+
+func mpEncodeInt(buf *bytes.Buffer, i int64) {
+	switch {
+	case i >= 0 && i <= math.MaxInt8:
+		buf.WriteByte(byte(i)) // positive fixint
+	case i < 0 && i >= -32:
+		buf.WriteByte(byte(int8(i))) // negative fixint
+	case i >= math.MinInt8 && i <= math.MaxInt8:
+		buf.Write([]byte{mpInt8, byte(int8(i))})
+	case i >= math.MinInt16 && i <= math.MaxInt16:
+		buf.WriteByte(mpInt16)
+		binary.Write(buf, binary.BigEndian, int16(i))
+	case i >= math.MinInt32 && i <= math.MaxInt32:
+		buf.WriteByte(mpInt32)
+		binary.Write(buf, binary.BigEndian, int32(i))
+	default:
+		buf.WriteByte(mpInt64)
+		binary.Write(buf, binary.BigEndian, i)
+	}
+}
+
+// decodeMsgPack decodes the MessagePack map document as a decoded JSON document: numbers are json.Number &
+// binaries are base64 strings
+func decodeMsgPack(b []byte) (map[string]interface{}, error) {
+	d := &mpDecoder{b: b}
+	v, err := d.decode()
+	if err != nil {
+		return nil, fmt.Errorf("invalid MessagePack dump: %v", err)
+	}
+	doc, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid MessagePack dump: %T instead of map", v)
+	}
+	if d.pos != len(b) {
+		return nil, fmt.Errorf("invalid MessagePack dump: %d trailing bytes", len(b)-d.pos)
+	}
+	return doc, nil
+}
+
+type mpDecoder struct {
+	b   []byte
+	pos int
+}
+
+// next returns the next n bytes
+func (d *mpDecoder) next(n int) ([]byte, error) {
+	if n < 0 || len(d.b)-d.pos < n {
+		return nil, fmt.Errorf("truncated at offset %d", d.pos)
+	}
+	res := d.b[d.pos : d.pos+n]
+	d.pos += n
+	return res, nil
+}
+
+// uint returns the next big endian unsigned integer of n bytes
+func (d *mpDecoder) uint(n int) (uint64, error) {
+	b, err := d.next(n)
+	if err != nil {
+		return 0, err
+	}
+	var res uint64
+	for _, c := range b {
+		res = res<<8 | uint64(c)
+	}
+	return res, nil
+}
+
+func (d *mpDecoder) decode() (interface{}, error) {
+	b, err := d.next(1)
+	if err != nil {
+		return nil, err
+	}
+	switch c := b[0]; {
+	case c <= 0x7f:
+		return json.Number(strconv.Itoa(int(c))), nil
+	case c >= 0xe0:
+		return json.Number(strconv.Itoa(int(int8(c)))), nil
+	case c&0xf0 == mpFixMap:
+		return d.decodeMap(int(c & 0x0f))
+	case c&0xf0 == mpFixArray:
+		return d.decodeArray(int(c & 0x0f))
+	case c&0xe0 == mpFixStr:
+		return d.decodeStr(int(c & 0x1f))
+	case c == mpNil:
+		return nil, nil
+	case c == mpFalse:
+		return false, nil
+	case c == mpTrue:
+		return true, nil
+	case c >= mpBin8 && c <= mpBin32:
+		n, err := d.uint(1 << (c - mpBin8))
+		if err != nil {
+			return nil, err
+		}
+		bin, err := d.next(int(n))
+		if err != nil {
+			return nil, err
+		}
+		return base64.StdEncoding.EncodeToString(bin), nil
+	case c == mpFloat32:
+		u, err := d.uint(4)
+		return json.Number(strconv.FormatFloat(float64(math.Float32frombits(uint32(u))), 'g', -1, 32)), err
+	case c == mpFloat64:
+		u, err := d.uint(8)
+		return json.Number(strconv.FormatFloat(math.Float64frombits(u), 'g', -1, 64)), err
+	case c >= mpUint8 && c <= mpUint64:
+		u, err := d.uint(1 << (c - mpUint8))
+		return json.Number(strconv.FormatUint(u, 10)), err
+	case c >= mpInt8 && c <= mpInt64:
+		size := uint(1 << (c - mpInt8))
+		u, err := d.uint(int(size))
+		shift := 64 - 8*size // sign extension
+		return json.Number(strconv.FormatInt(int64(u<<shift)>>shift, 10)), err
+	case c >= mpStr8 && c <= mpStr32:
+		n, err := d.uint(1 << (c - mpStr8))
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeStr(int(n))
+	case c == mpArray16 || c == mpArray32:
+		n, err := d.uint(2 << (c - mpArray16))
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeArray(int(n))
+	case c == mpMap16 || c == mpMap32:
+		n, err := d.uint(2 << (c - mpMap16))
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeMap(int(n))
+	}
+	return nil, fmt.Errorf("unsupported MessagePack type 0x%x at offset %d", b[0], d.pos-1)
+}
+
+func (d *mpDecoder) decodeStr(n int) (interface{}, error) {
+	b, err := d.next(n)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (d *mpDecoder) decodeArray(n int) (interface{}, error) {
+	if n > len(d.b)-d.pos { // each item takes at least one byte
+		return nil, fmt.Errorf("truncated array at offset %d", d.pos)
+	}
+	res := make([]interface{}, 0, n)
+	for i := 0; i < n; i++ {
+		v, err := d.decode()
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, v)
+	}
+	return res, nil
+}
+
+func (d *mpDecoder) decodeMap(n int) (interface{}, error) {
+	if 2*n > len(d.b)-d.pos { // each key & value takes at least one byte
+		return nil, fmt.Errorf("truncated map at offset %d", d.pos)
+	}
+	res := make(map[string]interface{}, n)
+	for i := 0; i < n; i++ {
+		k, err := d.decode()
+		if err != nil {
+			return nil, err
+		}
+		key, ok := k.(string)
+		if !ok {
+			return nil, fmt.Errorf("%T map key at offset %d", k, d.pos)
+		}
+		if res[key], err = d.decode(); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
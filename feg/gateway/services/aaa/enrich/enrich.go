@@ -0,0 +1,192 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package enrich appends operator defined fields (e.g. CRM account ids) to accounting & analytics events before
+// their export. The fields are returned by a local enrichment callout (enrichment_callout gRPC service), callouts are
+// bounded by a strict timeout & their fields are cached by session. Enrichment never blocks the export: events of
+// failed callouts are exported without the fields & the callout is bypassed for a while after a failure
+package enrich
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+
+	"magma/feg/gateway/services/aaa/audit"
+	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+// Defaults
+const (
+	DefaultTimeout  = 50 * time.Millisecond
+	DefaultCacheTTL = 10 * time.Minute
+	DefaultBypass   = 30 * time.Second
+	// maxCached - cached sessions sweeping the expired entries once reached
+	maxCached = 100000
+)
+
+// Enrichment outcomes
+const (
+	OutcomeEnriched = "enriched"
+	OutcomeCached   = "cached"
+	OutcomeFailed   = "failed"
+	OutcomeBypassed = "bypassed"
+)
+
+// Callout returns the fields appended to an event
+type Callout interface {
+	Enrich(ctx context.Context, req *protos.EnrichmentRequest) (*protos.Enrichment, error)
+}
+
+// NewCallout returns the callout of the enrichment_callout gRPC service at the given host:port address
+func NewCallout(addr string) (Callout, error) {
+	conn, err := grpc.Dial(addr, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	return grpcCallout{protos.NewEnrichmentCalloutClient(conn)}, nil
+}
+
+type grpcCallout struct {
+	client protos.EnrichmentCalloutClient
+}
+
+func (c grpcCallout) Enrich(ctx context.Context, req *protos.EnrichmentRequest) (*protos.Enrichment, error) {
+	return c.client.Enrich(ctx, req)
+}
+
+// Config - enrichment configuration, zero values are defaulted
+type Config struct {
+	Timeout  time.Duration // callout timeout
+	CacheTTL time.Duration // validity of a session's fields unless the callout returns their TTL
+	Bypass   time.Duration // period without callouts after a failed callout
+}
+
+type cached struct {
+	fields  map[string]string
+	expires time.Time
+}
+
+// Enricher is an audit.Sink appending the callout's fields to the events of its sink
+type Enricher struct {
+	callout Callout
+	cfg     Config
+	sink    audit.Sink
+	now     func() time.Time
+
+	mu       sync.Mutex
+	sessions map[string]cached // cached fields by session ID
+	bypassed time.Time         // end of the bypass period of a failed callout
+}
+
+// New returns a new Enricher of the sink's events
+func New(callout Callout, cfg Config, sink audit.Sink) *Enricher {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+	if cfg.CacheTTL <= 0 {
+		cfg.CacheTTL = DefaultCacheTTL
+	}
+	if cfg.Bypass <= 0 {
+		cfg.Bypass = DefaultBypass
+	}
+	return &Enricher{callout: callout, cfg: cfg, sink: sink, now: time.Now, sessions: map[string]cached{}}
+}
+
+// Log implements audit.Sink, the sink gets a copy of the event with the fields appended, the event itself is shared
+// with other sinks & isn't changed. Events are passed on without the fields if the callout fails
+func (e *Enricher) Log(ev *audit.Event) error {
+	if ev == nil {
+		return nil
+	}
+	if fields := e.fields(ev); len(fields) > 0 {
+		enriched := *ev
+		enriched.Enrichment = fields
+		ev = &enriched
+	}
+	return e.sink.Log(ev)
+}
+
+// fields returns the event's fields, cached fields of the event's session are reused until they expire. Cached
+// fields of ended sessions are removed
+func (e *Enricher) fields(ev *audit.Event) map[string]string {
+	now := e.now()
+	e.mu.Lock()
+	c, ok := e.sessions[ev.SessionId]
+	if ok && sessionEnded(ev.Type) {
+		delete(e.sessions, ev.SessionId)
+	}
+	bypassed := now.Before(e.bypassed)
+	e.mu.Unlock()
+	if ok && now.Before(c.expires) {
+		metrics.EnrichmentCallouts.WithLabelValues(OutcomeCached).Inc()
+		return c.fields
+	}
+	if bypassed {
+		metrics.EnrichmentCallouts.WithLabelValues(OutcomeBypassed).Inc()
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), e.cfg.Timeout)
+	defer cancel()
+	res, err := e.callout.Enrich(ctx, &protos.EnrichmentRequest{
+		Event:        string(ev.Type),
+		SessionId:    ev.SessionId,
+		Imsi:         ev.Imsi,
+		Apn:          ev.Apn,
+		MacAddr:      ev.MacAddr,
+		OperatorName: ev.OperatorName,
+	})
+	if err != nil {
+		metrics.EnrichmentCallouts.WithLabelValues(OutcomeFailed).Inc()
+		log.Printf("Enrichment of %s event of session %s failed, enrichment is bypassed for %v: %v",
+			ev.Type, ev.SessionId, e.cfg.Bypass, err)
+		e.mu.Lock()
+		e.bypassed = e.now().Add(e.cfg.Bypass)
+		e.mu.Unlock()
+		return nil
+	}
+	metrics.EnrichmentCallouts.WithLabelValues(OutcomeEnriched).Inc()
+	if !sessionEnded(ev.Type) {
+		ttl := e.cfg.CacheTTL
+		if res.GetCacheTtlMs() > 0 {
+			ttl = time.Duration(res.GetCacheTtlMs()) * time.Millisecond
+		}
+		e.cache(ev.SessionId, cached{fields: res.GetFields(), expires: now.Add(ttl)})
+	}
+	return res.GetFields()
+}
+
+// cache caches the session's fields, expired sessions are swept once the cache is full
+func (e *Enricher) cache(sid string, c cached) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if len(e.sessions) >= maxCached {
+		now := e.now()
+		for id, s := range e.sessions {
+			if !now.Before(s.expires) {
+				delete(e.sessions, id)
+			}
+		}
+	}
+	e.sessions[sid] = c
+}
+
+// sessionEnded returns true for the events ending their sessions on the gateway & for failed authentications
+func sessionEnded(typ audit.EventType) bool {
+	switch typ {
+	case audit.AuthFailure, audit.Stop, audit.Timeout, audit.Terminate, audit.Flush, audit.Export:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package enrich
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+
+	"magma/feg/gateway/services/aaa/audit"
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+type calloutFunc func(ctx context.Context, req *protos.EnrichmentRequest) (*protos.Enrichment, error)
+
+func (f calloutFunc) Enrich(ctx context.Context, req *protos.EnrichmentRequest) (*protos.Enrichment, error) {
+	return f(ctx, req)
+}
+
+type eventRecorder struct {
+	events []*audit.Event
+}
+
+func (r *eventRecorder) Log(ev *audit.Event) error {
+	r.events = append(r.events, ev)
+	return nil
+}
+
+func TestEnricher(t *testing.T) {
+	calls := 0
+	var calloutErr error
+	callout := calloutFunc(func(ctx context.Context, req *protos.EnrichmentRequest) (*protos.Enrichment, error) {
+		calls++
+		_, ok := ctx.Deadline()
+		assert.True(t, ok)
+		assert.Equal(t, "001010000000001", req.GetImsi())
+		if calloutErr != nil {
+			return nil, calloutErr
+		}
+		return &protos.Enrichment{Fields: map[string]string{"account_id": "crm-" + req.GetSessionId()}}, nil
+	})
+	recorder := &eventRecorder{}
+	now := time.Unix(1500000000, 0)
+	e := New(callout, Config{CacheTTL: time.Minute, Bypass: 10 * time.Second}, recorder)
+	e.now = func() time.Time { return now }
+	event := func(typ audit.EventType, sid string) *audit.Event {
+		ev := audit.NewEvent(typ, sid, audit.Now(), audit.Timestamp{})
+		ev.Imsi = "001010000000001"
+		return ev
+	}
+
+	start := event(audit.Start, "sid1")
+	assert.NoError(t, e.Log(start))
+	assert.Equal(t, map[string]string{"account_id": "crm-sid1"}, recorder.events[0].Enrichment)
+	assert.Nil(t, start.Enrichment, "events shared with other sinks aren't changed")
+
+	// the session's fields are cached until they expire
+	assert.NoError(t, e.Log(event(audit.Interim, "sid1")))
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, "crm-sid1", recorder.events[1].Enrichment["account_id"])
+	now = now.Add(2 * time.Minute)
+	assert.NoError(t, e.Log(event(audit.Interim, "sid1")))
+	assert.Equal(t, 2, calls)
+
+	// failed callouts pass the events on unenriched & bypass the callout for a while, cached fields are still used
+	calloutErr = errors.New("unavailable")
+	assert.NoError(t, e.Log(event(audit.Start, "sid2")))
+	assert.Nil(t, recorder.events[3].Enrichment)
+	assert.NoError(t, e.Log(event(audit.Interim, "sid2")))
+	assert.Equal(t, 3, calls, "the callout is bypassed")
+	assert.NoError(t, e.Log(event(audit.Interim, "sid1")))
+	assert.Equal(t, "crm-sid1", recorder.events[5].Enrichment["account_id"])
+	calloutErr = nil
+	now = now.Add(10 * time.Second)
+	assert.NoError(t, e.Log(event(audit.Interim, "sid2")))
+	assert.Equal(t, "crm-sid2", recorder.events[6].Enrichment["account_id"])
+
+	// ended sessions' fields are used for their last event & removed
+	assert.NoError(t, e.Log(event(audit.Stop, "sid1")))
+	assert.Equal(t, "crm-sid1", recorder.events[7].Enrichment["account_id"])
+	assert.NotContains(t, e.sessions, "sid1")
+	assert.Contains(t, e.sessions, "sid2")
+}
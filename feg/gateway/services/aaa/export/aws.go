@@ -0,0 +1,346 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package export
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"magma/feg/gateway/services/aaa/audit"
+)
+
+const (
+	awsMaxBatch   = 10 // SNS PublishBatch & SQS SendMessageBatch entries limit
+	snsAPIVersion = "2010-03-31"
+	sqsAPIVersion = "2012-11-05"
+	amzDateFormat = "20060102T150405Z"
+)
+
+// AWSConfig - AWS region & credentials, credentials which are not configured are taken from the standard
+// AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY & AWS_SESSION_TOKEN environment variables
+type AWSConfig struct {
+	Region          string `json:"region"`
+	AccessKeyID     string `json:"access_key_id"`
+	SecretAccessKey string `json:"secret_access_key"`
+	SessionToken    string `json:"session_token"`
+	// Endpoint - service endpoint override (VPC endpoints, local testing)
+	Endpoint string `json:"endpoint"`
+}
+
+// SNSConfig - AWS SNS topic destination
+type SNSConfig struct {
+	AWSConfig
+	Filter
+	TopicArn string `json:"topic_arn"`
+}
+
+// SQSConfig - AWS SQS queue destination
+type SQSConfig struct {
+	AWSConfig
+	Filter
+	QueueURL string `json:"queue_url"`
+}
+
+type awsCredentials struct {
+	accessKeyID, secretAccessKey, sessionToken string
+}
+
+// awsClient sends signed AWS Query API requests of one service
+type awsClient struct {
+	service  string
+	region   string
+	endpoint string
+	creds    awsCredentials
+	client   *http.Client
+	now      func() time.Time
+}
+
+func newAWSClient(service string, cfg AWSConfig) (*awsClient, error) {
+	if len(cfg.Region) == 0 {
+		return nil, fmt.Errorf("AWS %s region is required", strings.ToUpper(service))
+	}
+	creds := awsCredentials{cfg.AccessKeyID, cfg.SecretAccessKey, cfg.SessionToken}
+	if len(creds.accessKeyID) == 0 && len(creds.secretAccessKey) == 0 {
+		creds = awsCredentials{
+			os.Getenv("AWS_ACCESS_KEY_ID"), os.Getenv("AWS_SECRET_ACCESS_KEY"), os.Getenv("AWS_SESSION_TOKEN")}
+	}
+	if len(creds.accessKeyID) == 0 || len(creds.secretAccessKey) == 0 {
+		return nil, fmt.Errorf("AWS %s credentials are not configured", strings.ToUpper(service))
+	}
+	endpoint := cfg.Endpoint
+	if len(endpoint) == 0 {
+		endpoint = fmt.Sprintf("https://%s.%s.amazonaws.com/", service, cfg.Region)
+	}
+	return &awsClient{
+		service:  service,
+		region:   cfg.Region,
+		endpoint: endpoint,
+		creds:    creds,
+		client:   &http.Client{},
+		now:      time.Now,
+	}, nil
+}
+
+// call sends the Query API action & returns the response body
+func (c *awsClient) call(ctx context.Context, params url.Values) ([]byte, error) {
+	body := params.Encode()
+	req, err := http.NewRequest(http.MethodPost, c.endpoint, strings.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	c.sign(req, []byte(body), c.now())
+	return do(ctx, c.client, req)
+}
+
+// sign adds AWS Signature Version 4 headers to the request
+func (c *awsClient) sign(req *http.Request, body []byte, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format(amzDateFormat)
+	req.Header.Set("X-Amz-Date", amzDate)
+	if len(c.creds.sessionToken) > 0 {
+		req.Header.Set("X-Amz-Security-Token", c.creds.sessionToken)
+	}
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if len(path) == 0 {
+		path = "/"
+	}
+	bodyHash := sha256.Sum256(body)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		hex.EncodeToString(bodyHash[:]),
+	}, "\n")
+
+	date := now.Format("20060102")
+	scope := strings.Join([]string{date, c.region, c.service, "aws4_request"}, "/")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join(
+		[]string{"AWS4-HMAC-SHA256", amzDate, scope, hex.EncodeToString(requestHash[:])}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+c.creds.secretAccessKey), date)
+	key = hmacSHA256(key, c.region)
+	key = hmacSHA256(key, c.service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		c.creds.accessKeyID, scope, signedHeaders, signature))
+}
+
+func canonicalQuery(query url.Values) string {
+	var params []string
+	for name, values := range query {
+		for _, v := range values {
+			params = append(params, awsEscape(name)+"="+awsEscape(v))
+		}
+	}
+	sort.Strings(params)
+	return strings.Join(params, "&")
+}
+
+// awsEscape URI encodes s as required by AWS Signature Version 4
+func awsEscape(s string) string {
+	return strings.Replace(strings.Replace(url.QueryEscape(s), "+", "%20", -1), "%7E", "~", -1)
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// awsBatchError - a failed entry of a batch action
+type awsBatchError struct {
+	Id      string `xml:"Id"`
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+func batchErrors(action string, failed []awsBatchError) error {
+	if len(failed) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s: %d of the entries failed, first: %s: %s",
+		action, len(failed), failed[0].Code, failed[0].Message)
+}
+
+// SNS publishes events as JSON messages to an AWS SNS topic
+type SNS struct {
+	*awsClient
+	topicArn string
+}
+
+// NewSNS returns a new SNS topic publisher
+func NewSNS(cfg SNSConfig) (*SNS, error) {
+	if len(cfg.TopicArn) == 0 {
+		return nil, fmt.Errorf("SNS topic_arn is required")
+	}
+	c, err := newAWSClient("sns", cfg.AWSConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &SNS{awsClient: c, topicArn: cfg.TopicArn}, nil
+}
+
+// Name implements Publisher
+func (p *SNS) Name() string {
+	return "sns:" + p.topicArn
+}
+
+// MaxBatch implements Publisher
+func (p *SNS) MaxBatch() int {
+	return awsMaxBatch
+}
+
+// Publish implements Publisher
+func (p *SNS) Publish(ctx context.Context, events []*audit.Event) error {
+	for len(events) > 0 {
+		n := len(events)
+		if n > awsMaxBatch {
+			n = awsMaxBatch
+		}
+		params := url.Values{"Action": {"PublishBatch"}, "Version": {snsAPIVersion}, "TopicArn": {p.topicArn}}
+		for i, ev := range events[:n] {
+			prefix := "PublishBatchRequestEntries.member." + strconv.Itoa(i+1) + "."
+			if err := addAWSMessage(params, prefix, "Message", "MessageAttributes.entry.", i, ev); err != nil {
+				return err
+			}
+		}
+		body, err := p.call(ctx, params)
+		if err != nil {
+			return err
+		}
+		var resp struct {
+			Failed []awsBatchError `xml:"PublishBatchResult>Failed>member"`
+		}
+		if err = xml.Unmarshal(body, &resp); err != nil {
+			return fmt.Errorf("Invalid SNS PublishBatch response: %v", err)
+		}
+		if err = batchErrors("SNS PublishBatch", resp.Failed); err != nil {
+			return err
+		}
+		events = events[n:]
+	}
+	return nil
+}
+
+// SQS sends events as JSON messages to an AWS SQS queue
+type SQS struct {
+	*awsClient
+	queueURL string
+}
+
+// NewSQS returns a new SQS queue publisher
+func NewSQS(cfg SQSConfig) (*SQS, error) {
+	if len(cfg.QueueURL) == 0 {
+		return nil, fmt.Errorf("SQS queue_url is required")
+	}
+	c, err := newAWSClient("sqs", cfg.AWSConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &SQS{awsClient: c, queueURL: cfg.QueueURL}, nil
+}
+
+// Name implements Publisher
+func (p *SQS) Name() string {
+	return "sqs:" + p.queueURL
+}
+
+// MaxBatch implements Publisher
+func (p *SQS) MaxBatch() int {
+	return awsMaxBatch
+}
+
+// Publish implements Publisher
+func (p *SQS) Publish(ctx context.Context, events []*audit.Event) error {
+	for len(events) > 0 {
+		n := len(events)
+		if n > awsMaxBatch {
+			n = awsMaxBatch
+		}
+		params := url.Values{"Action": {"SendMessageBatch"}, "Version": {sqsAPIVersion}, "QueueUrl": {p.queueURL}}
+		for i, ev := range events[:n] {
+			prefix := "SendMessageBatchRequestEntry." + strconv.Itoa(i+1) + "."
+			if err := addAWSMessage(params, prefix, "MessageBody", "MessageAttribute.", i, ev); err != nil {
+				return err
+			}
+		}
+		body, err := p.call(ctx, params)
+		if err != nil {
+			return err
+		}
+		var resp struct {
+			Failed []awsBatchError `xml:"SendMessageBatchResult>BatchResultErrorEntry"`
+		}
+		if err = xml.Unmarshal(body, &resp); err != nil {
+			return fmt.Errorf("Invalid SQS SendMessageBatch response: %v", err)
+		}
+		if err = batchErrors("SQS SendMessageBatch", resp.Failed); err != nil {
+			return err
+		}
+		events = events[n:]
+	}
+	return nil
+}
+
+// addAWSMessage adds the event's batch entry parameters with the given prefix
+func addAWSMessage(params url.Values, prefix, bodyParam, attrPrefix string, id int, ev *audit.Event) error {
+	msg, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	params.Set(prefix+"Id", strconv.Itoa(id))
+	params.Set(prefix+bodyParam, string(msg))
+	attrs := eventAttributes(ev)
+	names := make([]string, 0, len(attrs))
+	for name := range attrs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for i, name := range names {
+		attr := prefix + attrPrefix + strconv.Itoa(i+1) + "."
+		params.Set(attr+"Name", name)
+		params.Set(attr+"Value.DataType", "String")
+		params.Set(attr+"Value.StringValue", attrs[name])
+	}
+	return nil
+}
@@ -0,0 +1,282 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package export publishes session lifecycle (audit) events to public cloud messaging services: GCP Pub/Sub topics,
+// AWS SNS topics & SQS queues. Events are queued without blocking accounting & published in batches, a full queue
+// drops new events
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"magma/feg/gateway/services/aaa/audit"
+	"magma/feg/gateway/services/aaa/metrics"
+)
+
+// Defaults
+const (
+	DefaultBatchSize       = 100
+	DefaultFlushIntervalMs = 1000
+	DefaultQueueSize       = 10000
+	DefaultTimeoutMs       = 10000
+)
+
+// Publisher publishes a batch of events to a cloud messaging service
+type Publisher interface {
+	// Name identifies the publisher in logs & metrics
+	Name() string
+	// MaxBatch returns the maximum number of events the service accepts in one publish call, 0 - unlimited
+	MaxBatch() int
+	Publish(ctx context.Context, events []*audit.Event) error
+}
+
+// Config - events export configuration
+type Config struct {
+	BatchSize       int `json:"batch_size"`        // max events per batch, capped by the service's limit
+	FlushIntervalMs int `json:"flush_interval_ms"` // max delay of a queued event
+	QueueSize       int `json:"queue_size"`        // max queued events per publisher, new events are dropped if full
+	TimeoutMs       int `json:"timeout_ms"`        // publish call timeout
+
+	PubSub []PubSubConfig `json:"gcp_pubsub"`
+	SNS    []SNSConfig    `json:"aws_sns"`
+	SQS    []SQSConfig    `json:"aws_sqs"`
+}
+
+// Filter - events filter of a destination, a destination of wholesale partners' streams exports only the events of
+// the partners' sessions
+type Filter struct {
+	// OperatorNames - RFC 5580 Operator-Names of the exported sessions' events, all events are exported if empty
+	OperatorNames []string `json:"operator_names,omitempty"`
+}
+
+// matches returns true if the filter passes the event
+func (f Filter) matches(ev *audit.Event) bool {
+	if len(f.OperatorNames) == 0 {
+		return true
+	}
+	for _, name := range f.OperatorNames {
+		if name == ev.OperatorName {
+			return true
+		}
+	}
+	return false
+}
+
+// ReadConfig reads JSON export configuration from the given file & applies defaults
+func ReadConfig(path string) (*Config, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &Config{}
+	if err = json.Unmarshal(b, cfg); err != nil {
+		return nil, fmt.Errorf("Invalid events export configuration %s: %v", path, err)
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = DefaultBatchSize
+	}
+	if cfg.FlushIntervalMs <= 0 {
+		cfg.FlushIntervalMs = DefaultFlushIntervalMs
+	}
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = DefaultQueueSize
+	}
+	if cfg.TimeoutMs <= 0 {
+		cfg.TimeoutMs = DefaultTimeoutMs
+	}
+	return cfg, nil
+}
+
+// NewExporters creates a started Exporter per configured destination
+func NewExporters(cfg *Config) ([]*Exporter, error) {
+	var (
+		publishers []Publisher
+		filters    []Filter
+	)
+	for _, c := range cfg.PubSub {
+		p, err := NewPubSub(c)
+		if err != nil {
+			return nil, err
+		}
+		publishers, filters = append(publishers, p), append(filters, c.Filter)
+	}
+	for _, c := range cfg.SNS {
+		p, err := NewSNS(c)
+		if err != nil {
+			return nil, err
+		}
+		publishers, filters = append(publishers, p), append(filters, c.Filter)
+	}
+	for _, c := range cfg.SQS {
+		p, err := NewSQS(c)
+		if err != nil {
+			return nil, err
+		}
+		publishers, filters = append(publishers, p), append(filters, c.Filter)
+	}
+	if len(publishers) == 0 {
+		return nil, fmt.Errorf("No events export destinations are configured")
+	}
+	var res []*Exporter
+	for i, p := range publishers {
+		e := NewExporter(p, cfg.BatchSize, cfg.QueueSize,
+			time.Duration(cfg.FlushIntervalMs)*time.Millisecond, time.Duration(cfg.TimeoutMs)*time.Millisecond)
+		e.filter = filters[i]
+		e.Start()
+		res = append(res, e)
+	}
+	return res, nil
+}
+
+// Exporter batches events of one publisher, it implements audit.Sink
+type Exporter struct {
+	publisher     Publisher
+	filter        Filter
+	batchSize     int
+	flushInterval time.Duration
+	timeout       time.Duration
+	queue         chan *audit.Event
+	done          chan struct{}
+	stopOnce      sync.Once
+	stopped       chan struct{}
+}
+
+// NewExporter returns a new, not started Exporter
+func NewExporter(p Publisher, batchSize, queueSize int, flushInterval, timeout time.Duration) *Exporter {
+	if max := p.MaxBatch(); max > 0 && (batchSize <= 0 || batchSize > max) {
+		batchSize = max
+	}
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+	if queueSize <= 0 {
+		queueSize = DefaultQueueSize
+	}
+	if flushInterval <= 0 {
+		flushInterval = DefaultFlushIntervalMs * time.Millisecond
+	}
+	if timeout <= 0 {
+		timeout = DefaultTimeoutMs * time.Millisecond
+	}
+	return &Exporter{
+		publisher:     p,
+		batchSize:     batchSize,
+		flushInterval: flushInterval,
+		timeout:       timeout,
+		queue:         make(chan *audit.Event, queueSize),
+		done:          make(chan struct{}),
+		stopped:       make(chan struct{}),
+	}
+}
+
+// Name returns the exporter's publisher name
+func (e *Exporter) Name() string {
+	return e.publisher.Name()
+}
+
+// Log implements audit.Sink, the event is queued for publishing unless the exporter's filter drops it. Events
+// which don't fit into the queue are dropped & counted, the accounting is never blocked by a slow destination
+func (e *Exporter) Log(ev *audit.Event) error {
+	if ev == nil || !e.filter.matches(ev) {
+		return nil
+	}
+	select {
+	case e.queue <- ev:
+	default:
+		metrics.ExportedEvents.WithLabelValues(e.Name(), "dropped").Inc()
+	}
+	return nil
+}
+
+// Start starts the exporter's publishing loop
+func (e *Exporter) Start() {
+	go e.run()
+}
+
+// Stop publishes the queued events & stops the exporter
+func (e *Exporter) Stop() {
+	e.stopOnce.Do(func() { close(e.done) })
+	<-e.stopped
+}
+
+func (e *Exporter) run() {
+	defer close(e.stopped)
+	ticker := time.NewTicker(e.flushInterval)
+	defer ticker.Stop()
+	batch := make([]*audit.Event, 0, e.batchSize)
+	flush := func() {
+		if len(batch) > 0 {
+			e.publish(batch)
+			batch = make([]*audit.Event, 0, e.batchSize)
+		}
+	}
+	for {
+		select {
+		case ev := <-e.queue:
+			batch = append(batch, ev)
+			if len(batch) >= e.batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-e.done:
+			for {
+				select {
+				case ev := <-e.queue:
+					batch = append(batch, ev)
+					if len(batch) >= e.batchSize {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+func (e *Exporter) publish(batch []*audit.Event) {
+	ctx, cancel := context.WithTimeout(context.Background(), e.timeout)
+	defer cancel()
+	if err := e.publisher.Publish(ctx, batch); err != nil {
+		metrics.ExportedEvents.WithLabelValues(e.Name(), "failed").Add(float64(len(batch)))
+		log.Printf("Error publishing %d events to %s: %v", len(batch), e.Name(), err)
+		return
+	}
+	metrics.ExportedEvents.WithLabelValues(e.Name(), "published").Add(float64(len(batch)))
+}
+
+// do sends the request & returns the response body of a successful (2xx) response
+func do(ctx context.Context, client *http.Client, req *http.Request) ([]byte, error) {
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		if len(body) > 512 {
+			body = body[:512]
+		}
+		return nil, fmt.Errorf("%s %s: %s: %s", req.Method, req.URL.Host, resp.Status, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
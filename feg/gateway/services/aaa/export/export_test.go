@@ -0,0 +1,198 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package export
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+
+	"magma/feg/gateway/services/aaa/audit"
+)
+
+type fakePublisher struct {
+	sync.Mutex
+	batches [][]*audit.Event
+}
+
+func (p *fakePublisher) Name() string  { return "fake" }
+func (p *fakePublisher) MaxBatch() int { return 3 }
+func (p *fakePublisher) Publish(ctx context.Context, events []*audit.Event) error {
+	p.Lock()
+	defer p.Unlock()
+	p.batches = append(p.batches, events)
+	return nil
+}
+
+func TestExporter(t *testing.T) {
+	p := &fakePublisher{}
+	// batch size is capped by the publisher's limit
+	e := NewExporter(p, 100, 10, time.Hour, time.Second)
+	e.Start()
+	for i := 0; i < 7; i++ {
+		assert.NoError(t, e.Log(audit.NewEvent(audit.Start, "sid", audit.Now(), audit.Timestamp{})))
+	}
+	// queued events are published on stop
+	e.Stop()
+	p.Lock()
+	defer p.Unlock()
+	if assert.Len(t, p.batches, 3) {
+		assert.Len(t, p.batches[0], 3)
+		assert.Len(t, p.batches[1], 3)
+		assert.Len(t, p.batches[2], 1)
+	}
+
+	// full queue drops events without blocking
+	e = NewExporter(p, 1, 1, time.Hour, time.Second)
+	assert.NoError(t, e.Log(&audit.Event{}))
+	assert.NoError(t, e.Log(&audit.Event{}))
+	assert.Len(t, e.queue, 1)
+}
+
+func TestExporterFilter(t *testing.T) {
+	p := &fakePublisher{}
+	e := NewExporter(p, 10, 10, time.Hour, time.Second)
+	e.filter = Filter{OperatorNames: []string{"1partner.example.com"}}
+	e.Start()
+	for _, name := range []string{"1partner.example.com", "1other.example.com", ""} {
+		ev := audit.NewEvent(audit.Start, "sid", audit.Now(), audit.Timestamp{})
+		ev.OperatorName = name
+		assert.NoError(t, e.Log(ev))
+	}
+	e.Stop()
+	p.Lock()
+	defer p.Unlock()
+	if assert.Len(t, p.batches, 1) && assert.Len(t, p.batches[0], 1) {
+		assert.Equal(t, "1partner.example.com", p.batches[0][0].OperatorName)
+	}
+}
+
+func TestAWSSignature(t *testing.T) {
+	// AWS Signature Version 4 documentation example
+	c := &awsClient{
+		service: "iam",
+		region:  "us-east-1",
+		creds:   awsCredentials{accessKeyID: "AKIDEXAMPLE", secretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"},
+	}
+	req, err := http.NewRequest(http.MethodGet, "https://iam.amazonaws.com/?Action=ListUsers&Version=2010-05-08", nil)
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	c.sign(req, nil, time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+	assert.Equal(t,
+		"AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/iam/aws4_request, "+
+			"SignedHeaders=content-type;host;x-amz-date, "+
+			"Signature=5d672d79c15b13162d9279b0855cfba6789a8edb4c82c400e06b5924a6f2b5d7",
+		req.Header.Get("Authorization"))
+	assert.Equal(t, "20150830T123600Z", req.Header.Get("X-Amz-Date"))
+}
+
+func TestSQS(t *testing.T) {
+	var requests []map[string][]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseForm())
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/"))
+		requests = append(requests, r.PostForm)
+		w.Write([]byte(`<SendMessageBatchResponse><SendMessageBatchResult></SendMessageBatchResult>` +
+			`</SendMessageBatchResponse>`))
+	}))
+	defer srv.Close()
+
+	p, err := NewSQS(SQSConfig{
+		AWSConfig: AWSConfig{Region: "us-west-2", AccessKeyID: "AKID", SecretAccessKey: "secret", Endpoint: srv.URL},
+		QueueURL:  "https://sqs.us-west-2.amazonaws.com/123/events",
+	})
+	assert.NoError(t, err)
+	var events []*audit.Event
+	for i := 0; i < 12; i++ {
+		ev := audit.NewEvent(audit.Stop, "sid", audit.Now(), audit.Timestamp{})
+		ev.Apn = "internet"
+		events = append(events, ev)
+	}
+	assert.NoError(t, p.Publish(context.Background(), events))
+	if assert.Len(t, requests, 2) {
+		assert.Equal(t, "SendMessageBatch", requests[0]["Action"][0])
+		assert.Equal(t, "https://sqs.us-west-2.amazonaws.com/123/events", requests[0]["QueueUrl"][0])
+		assert.Contains(t, requests[0], "SendMessageBatchRequestEntry.10.MessageBody")
+		assert.NotContains(t, requests[1], "SendMessageBatchRequestEntry.3.MessageBody")
+		assert.Equal(t, "apn", requests[1]["SendMessageBatchRequestEntry.1.MessageAttribute.1.Name"][0])
+		assert.Equal(t, "internet", requests[1]["SendMessageBatchRequestEntry.1.MessageAttribute.1.Value.StringValue"][0])
+		var ev audit.Event
+		assert.NoError(t, json.Unmarshal([]byte(requests[1]["SendMessageBatchRequestEntry.2.MessageBody"][0]), &ev))
+		assert.Equal(t, audit.Stop, ev.Type)
+	}
+
+	_, err = NewSQS(SQSConfig{AWSConfig: AWSConfig{Region: "us-west-2"}, QueueURL: "q"})
+	if len(os.Getenv("AWS_ACCESS_KEY_ID")) == 0 {
+		assert.Error(t, err)
+	}
+}
+
+func TestPubSub(t *testing.T) {
+	var tokenRequests int
+	var published struct {
+		Messages []pubSubMessage `json:"messages"`
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, jwtBearerGrant, r.PostForm.Get("grant_type"))
+		assert.Equal(t, 3, len(strings.Split(r.PostForm.Get("assertion"), ".")))
+		tokenRequests++
+		w.Write([]byte(`{"access_token": "token1", "expires_in": 3600}`))
+	})
+	mux.HandleFunc("/v1/projects/proj/topics/events:publish", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token1", r.Header.Get("Authorization"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&published))
+		w.Write([]byte(`{"messageIds": ["1"]}`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	assert.NoError(t, err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)})
+	keyJSON, err := json.Marshal(map[string]string{
+		"client_email": "aaa@proj.iam.gserviceaccount.com",
+		"private_key":  string(keyPEM),
+		"token_uri":    srv.URL + "/token",
+	})
+	assert.NoError(t, err)
+	f, err := ioutil.TempFile("", "pubsub_key")
+	assert.NoError(t, err)
+	defer os.Remove(f.Name())
+	_, err = f.Write(keyJSON)
+	assert.NoError(t, err)
+	f.Close()
+
+	p, err := NewPubSub(PubSubConfig{Project: "proj", Topic: "events", CredentialsFile: f.Name(), Endpoint: srv.URL})
+	assert.NoError(t, err)
+	ev := audit.NewEvent(audit.Start, "sid1", audit.Now(), audit.Timestamp{})
+	assert.NoError(t, p.Publish(context.Background(), []*audit.Event{ev}))
+	assert.NoError(t, p.Publish(context.Background(), []*audit.Event{ev, ev}))
+	assert.Equal(t, 1, tokenRequests) // the token is cached
+	if assert.Len(t, published.Messages, 2) {
+		assert.Equal(t, "start", published.Messages[0].Attributes["event"])
+		var decoded audit.Event
+		assert.NoError(t, json.Unmarshal(published.Messages[0].Data, &decoded))
+		assert.Equal(t, "sid1", decoded.SessionId)
+	}
+}
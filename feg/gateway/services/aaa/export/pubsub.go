@@ -0,0 +1,265 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package export
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"magma/feg/gateway/services/aaa/audit"
+)
+
+const (
+	pubSubEndpoint    = "https://pubsub.googleapis.com"
+	pubSubScope       = "https://www.googleapis.com/auth/pubsub"
+	pubSubMaxBatch    = 1000
+	gceTokenURL       = "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/token"
+	googleCredentials = "GOOGLE_APPLICATION_CREDENTIALS"
+	jwtBearerGrant    = "urn:ietf:params:oauth:grant-type:jwt-bearer"
+)
+
+// PubSubConfig - GCP Pub/Sub topic destination
+type PubSubConfig struct {
+	Project string `json:"project"`
+	Topic   string `json:"topic"`
+	// CredentialsFile - service account JSON key file, if not set GOOGLE_APPLICATION_CREDENTIALS is used & if it's
+	// not set either, the GCE metadata server provides the instance's service account credentials
+	CredentialsFile string `json:"credentials_file"`
+	// Endpoint - Pub/Sub API endpoint override (regional endpoints, emulator)
+	Endpoint string `json:"endpoint"`
+	Filter
+}
+
+// PubSub publishes events as JSON messages to a GCP Pub/Sub topic
+type PubSub struct {
+	url    string
+	name   string
+	client *http.Client
+	tokens *googleTokenSource
+}
+
+// NewPubSub returns a new Pub/Sub topic publisher
+func NewPubSub(cfg PubSubConfig) (*PubSub, error) {
+	if len(cfg.Project) == 0 || len(cfg.Topic) == 0 {
+		return nil, fmt.Errorf("Pub/Sub project & topic are required")
+	}
+	endpoint := cfg.Endpoint
+	if len(endpoint) == 0 {
+		endpoint = pubSubEndpoint
+	}
+	credsFile := cfg.CredentialsFile
+	if len(credsFile) == 0 {
+		credsFile = os.Getenv(googleCredentials)
+	}
+	client := &http.Client{}
+	tokens := &googleTokenSource{client: client, tokenURL: gceTokenURL, gce: true}
+	if len(credsFile) > 0 {
+		key, err := readServiceAccountKey(credsFile)
+		if err != nil {
+			return nil, err
+		}
+		tokens = &googleTokenSource{client: client, key: key, tokenURL: key.TokenURI}
+	}
+	return &PubSub{
+		url: fmt.Sprintf("%s/v1/projects/%s/topics/%s:publish",
+			strings.TrimSuffix(endpoint, "/"), url.PathEscape(cfg.Project), url.PathEscape(cfg.Topic)),
+		name:   fmt.Sprintf("pubsub:%s/%s", cfg.Project, cfg.Topic),
+		client: client,
+		tokens: tokens,
+	}, nil
+}
+
+// Name implements Publisher
+func (p *PubSub) Name() string {
+	return p.name
+}
+
+// MaxBatch implements Publisher
+func (p *PubSub) MaxBatch() int {
+	return pubSubMaxBatch
+}
+
+type pubSubMessage struct {
+	Data       []byte            `json:"data"` // base64 encoded by encoding/json
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// Publish implements Publisher
+func (p *PubSub) Publish(ctx context.Context, events []*audit.Event) error {
+	req := struct {
+		Messages []pubSubMessage `json:"messages"`
+	}{}
+	for _, ev := range events {
+		data, err := json.Marshal(ev)
+		if err != nil {
+			return err
+		}
+		req.Messages = append(req.Messages, pubSubMessage{Data: data, Attributes: eventAttributes(ev)})
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	token, err := p.tokens.token(ctx)
+	if err != nil {
+		return fmt.Errorf("Pub/Sub credentials error: %v", err)
+	}
+	httpReq, err := http.NewRequest(http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Bearer "+token)
+	_, err = do(ctx, p.client, httpReq)
+	return err
+}
+
+// eventAttributes returns message attributes allowing subscribers to filter events without parsing them
+func eventAttributes(ev *audit.Event) map[string]string {
+	attrs := map[string]string{"event": string(ev.Type)}
+	if len(ev.Apn) > 0 {
+		attrs["apn"] = ev.Apn
+	}
+	return attrs
+}
+
+// serviceAccountKey - the used fields of a GCP service account JSON key
+type serviceAccountKey struct {
+	ClientEmail  string `json:"client_email"`
+	PrivateKeyID string `json:"private_key_id"`
+	PrivateKey   string `json:"private_key"`
+	TokenURI     string `json:"token_uri"`
+
+	rsaKey *rsa.PrivateKey
+}
+
+func readServiceAccountKey(path string) (*serviceAccountKey, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	key := &serviceAccountKey{}
+	if err = json.Unmarshal(b, key); err != nil {
+		return nil, fmt.Errorf("Invalid service account key %s: %v", path, err)
+	}
+	if len(key.ClientEmail) == 0 || len(key.TokenURI) == 0 {
+		return nil, fmt.Errorf("Invalid service account key %s: missing client_email or token_uri", path)
+	}
+	block, _ := pem.Decode([]byte(key.PrivateKey))
+	if block == nil {
+		return nil, fmt.Errorf("Invalid service account key %s: private_key is not PEM encoded", path)
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		if parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes); err != nil {
+			return nil, fmt.Errorf("Invalid service account key %s: %v", path, err)
+		}
+	}
+	var ok bool
+	if key.rsaKey, ok = parsed.(*rsa.PrivateKey); !ok {
+		return nil, fmt.Errorf("Invalid service account key %s: not an RSA key", path)
+	}
+	return key, nil
+}
+
+// googleTokenSource provides cached OAuth2 access tokens of a service account key or of the GCE instance
+type googleTokenSource struct {
+	client   *http.Client
+	key      *serviceAccountKey
+	tokenURL string
+	gce      bool
+
+	mu      sync.Mutex
+	current string
+	expiry  time.Time
+}
+
+func (ts *googleTokenSource) token(ctx context.Context) (string, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	if len(ts.current) > 0 && time.Now().Add(time.Minute).Before(ts.expiry) {
+		return ts.current, nil
+	}
+	var req *http.Request
+	var err error
+	if ts.gce {
+		if req, err = http.NewRequest(http.MethodGet, ts.tokenURL, nil); err != nil {
+			return "", err
+		}
+		req.Header.Set("Metadata-Flavor", "Google")
+	} else {
+		assertion, err := ts.key.assertion(time.Now())
+		if err != nil {
+			return "", err
+		}
+		form := url.Values{"grant_type": {jwtBearerGrant}, "assertion": {assertion}}
+		if req, err = http.NewRequest(http.MethodPost, ts.tokenURL, strings.NewReader(form.Encode())); err != nil {
+			return "", err
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	body, err := do(ctx, ts.client, req)
+	if err != nil {
+		return "", err
+	}
+	var resp struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err = json.Unmarshal(body, &resp); err != nil {
+		return "", fmt.Errorf("Invalid token response: %v", err)
+	}
+	if len(resp.AccessToken) == 0 {
+		return "", fmt.Errorf("Empty access token")
+	}
+	ts.current, ts.expiry = resp.AccessToken, time.Now().Add(time.Duration(resp.ExpiresIn)*time.Second)
+	return ts.current, nil
+}
+
+// assertion returns the key's signed JWT assertion for the Pub/Sub scope
+func (key *serviceAccountKey) assertion(now time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": key.PrivateKeyID})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]interface{}{
+		"iss":   key.ClientEmail,
+		"scope": pubSubScope,
+		"aud":   key.TokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	hash := sha256.Sum256([]byte(unsigned))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key.rsaKey, crypto.SHA256, hash[:])
+	if err != nil {
+		return "", err
+	}
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(sig), nil
+}
@@ -0,0 +1,179 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package failuremode configures the handling of sessions created while session manager's Gx (PCRF) or Gy (OCS)
+// are in a failure or bypass mode. Such sessions are allowed, flagged for later usage reconciliation, moved via CoA
+// to a restricted profile or denied, as configured for the interface's mode
+package failuremode
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"magma/feg/gateway/services/aaa/protos"
+	lte_protos "magma/lte/cloud/go/protos"
+)
+
+// Interface - credit control interface of session manager
+type Interface string
+
+const (
+	// Gx - policy control (PCRF)
+	Gx Interface = "gx"
+	// Gy - online charging (OCS)
+	Gy Interface = "gy"
+)
+
+// Mode - failure handling mode of an interface, empty if the interface is in service
+type Mode string
+
+const (
+	// ModeFailure - the session was created while the interface failed
+	ModeFailure Mode = "failure"
+	// ModeBypass - the interface is bypassed & doesn't control the session
+	ModeBypass Mode = "bypass"
+)
+
+// Action - handling of sessions created in a failure mode
+type Action string
+
+// Actions in the order of precedence, a session with both interfaces in failure modes gets the higher precedence
+// action of the two
+const (
+	// ActionAllow - the sessions are allowed, the default action
+	ActionAllow Action = "allow"
+	// ActionFlag - the sessions are allowed & flagged for later usage reconciliation
+	ActionFlag Action = "flag"
+	// ActionRestrict - the sessions are flagged & moved to the restricted profile
+	ActionRestrict Action = "restrict"
+	// ActionDeny - the sessions are denied & disconnected
+	ActionDeny Action = "deny"
+)
+
+var precedence = map[Action]int{ActionAllow: 0, ActionFlag: 1, ActionRestrict: 2, ActionDeny: 3}
+
+// Attribute - context attribute of flagged & restricted sessions, set to their interfaces' failure modes
+const Attribute = "credit_control_failure"
+
+// Profile - restricted VLAN, filter & maximum bandwidth (bits per second), any of them may be omitted
+type Profile struct {
+	VlanID           uint32 `json:"vlan_id,omitempty"`
+	FilterID         string `json:"filter_id,omitempty"`
+	MaxBandwidthUp   uint32 `json:"max_bandwidth_up,omitempty"`
+	MaxBandwidthDown uint32 `json:"max_bandwidth_down,omitempty"`
+}
+
+// Config - actions of the interfaces' failure modes, modes without an action are allowed
+type Config struct {
+	Profile *Profile        `json:"profile,omitempty"` // restricted profile
+	Gx      map[Mode]Action `json:"gx,omitempty"`
+	Gy      map[Mode]Action `json:"gy,omitempty"`
+}
+
+// ReadConfig reads & validates JSON failure modes configuration from the given file
+func ReadConfig(path string) (*Config, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &Config{}
+	if err = json.Unmarshal(b, cfg); err != nil {
+		return nil, fmt.Errorf("Invalid failure modes configuration %s: %v", path, err)
+	}
+	if err = cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("Invalid failure modes configuration %s: %v", path, err)
+	}
+	return cfg, nil
+}
+
+// Validate returns an error if a mode or action is unknown or the restrict action has no valid restricted profile
+func (cfg *Config) Validate() error {
+	for iface, actions := range map[Interface]map[Mode]Action{Gx: cfg.Gx, Gy: cfg.Gy} {
+		for mode, action := range actions {
+			if mode != ModeFailure && mode != ModeBypass {
+				return fmt.Errorf("unknown %s failure mode '%s'", iface, mode)
+			}
+			if _, ok := precedence[action]; !ok {
+				return fmt.Errorf("unknown action '%s' of %s %s mode", action, iface, mode)
+			}
+			if action == ActionRestrict && cfg.Profile.empty() {
+				return fmt.Errorf("missing restricted profile of %s %s mode", iface, mode)
+			}
+		}
+	}
+	if p := cfg.Profile; p != nil && (p.MaxBandwidthUp == 0) != (p.MaxBandwidthDown == 0) {
+		return errors.New("restricted profile must set both or neither of max_bandwidth_up & max_bandwidth_down")
+	}
+	return nil
+}
+
+// Decide returns the action of a session created in the given interfaces' modes & the modes' description
+// (e.g. gx:failure,gy:bypass), ActionAllow & an empty description if both interfaces are in service
+func (cfg *Config) Decide(gx, gy Mode) (Action, string) {
+	action, modes := ActionAllow, []string{}
+	for _, m := range []struct {
+		iface   Interface
+		mode    Mode
+		actions map[Mode]Action
+	}{{Gx, gx, cfg.gx()}, {Gy, gy, cfg.gy()}} {
+		if len(m.mode) == 0 {
+			continue
+		}
+		modes = append(modes, string(m.iface)+":"+string(m.mode))
+		if a, ok := m.actions[m.mode]; ok && precedence[a] > precedence[action] {
+			action = a
+		}
+	}
+	sort.Strings(modes)
+	return action, strings.Join(modes, ",")
+}
+
+// ModeOf returns the mode of session manager's credit control mode
+func ModeOf(mode lte_protos.LocalCreateSessionResponse_CreditControlMode) Mode {
+	switch mode {
+	case lte_protos.LocalCreateSessionResponse_FAILURE:
+		return ModeFailure
+	case lte_protos.LocalCreateSessionResponse_BYPASS:
+		return ModeBypass
+	default:
+		return ""
+	}
+}
+
+// Change returns the CoA moving the session to the restricted profile
+func (p *Profile) Change(aaaCtx *protos.Context) *protos.ChangeRequest {
+	return &protos.ChangeRequest{
+		Ctx:              aaaCtx,
+		MaxBandwidthUp:   p.MaxBandwidthUp,
+		MaxBandwidthDown: p.MaxBandwidthDown,
+		VlanId:           p.VlanID,
+		FilterId:         p.FilterID,
+	}
+}
+
+func (cfg *Config) gx() map[Mode]Action {
+	if cfg == nil {
+		return nil
+	}
+	return cfg.Gx
+}
+
+func (cfg *Config) gy() map[Mode]Action {
+	if cfg == nil {
+		return nil
+	}
+	return cfg.Gy
+}
+
+func (p *Profile) empty() bool {
+	return p == nil || (p.VlanID == 0 && len(p.FilterID) == 0 && p.MaxBandwidthUp == 0 && p.MaxBandwidthDown == 0)
+}
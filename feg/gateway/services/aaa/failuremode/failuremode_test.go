@@ -0,0 +1,79 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package failuremode
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"magma/feg/gateway/services/aaa/protos"
+	lte_protos "magma/lte/cloud/go/protos"
+)
+
+func TestReadConfig(t *testing.T) {
+	f, err := ioutil.TempFile("", "failuremode")
+	assert.NoError(t, err)
+	defer os.Remove(f.Name())
+	_, err = f.WriteString(`{
+		"profile": {"filter_id": "restricted", "max_bandwidth_up": 1000000, "max_bandwidth_down": 2000000},
+		"gx": {"failure": "restrict", "bypass": "flag"},
+		"gy": {"failure": "deny"}
+	}`)
+	assert.NoError(t, err)
+	f.Close()
+
+	cfg, err := ReadConfig(f.Name())
+	assert.NoError(t, err)
+	action, modes := cfg.Decide(ModeFailure, "")
+	assert.Equal(t, ActionRestrict, action)
+	assert.Equal(t, "gx:failure", modes)
+	action, modes = cfg.Decide(ModeBypass, ModeBypass)
+	assert.Equal(t, ActionFlag, action)
+	assert.Equal(t, "gx:bypass,gy:bypass", modes)
+	// the higher precedence action of the two interfaces
+	action, _ = cfg.Decide(ModeFailure, ModeFailure)
+	assert.Equal(t, ActionDeny, action)
+	action, modes = cfg.Decide("", "")
+	assert.Equal(t, ActionAllow, action)
+	assert.Empty(t, modes)
+
+	aaaCtx := &protos.Context{SessionId: "sid1"}
+	assert.Equal(t, &protos.ChangeRequest{
+		Ctx: aaaCtx, FilterId: "restricted", MaxBandwidthUp: 1000000, MaxBandwidthDown: 2000000,
+	}, cfg.Profile.Change(aaaCtx))
+}
+
+func TestDecideWithoutConfig(t *testing.T) {
+	var cfg *Config
+	action, modes := cfg.Decide(ModeFailure, ModeBypass)
+	assert.Equal(t, ActionAllow, action)
+	assert.Equal(t, "gx:failure,gy:bypass", modes)
+}
+
+func TestValidate(t *testing.T) {
+	assert.Error(t, (&Config{Gx: map[Mode]Action{"down": ActionDeny}}).Validate())
+	assert.Error(t, (&Config{Gy: map[Mode]Action{ModeFailure: "drop"}}).Validate())
+	// restrict actions need a profile
+	assert.Error(t, (&Config{Gx: map[Mode]Action{ModeFailure: ActionRestrict}}).Validate())
+	assert.Error(t, (&Config{Profile: &Profile{}, Gx: map[Mode]Action{ModeFailure: ActionRestrict}}).Validate())
+	assert.Error(t, (&Config{Profile: &Profile{MaxBandwidthUp: 1}}).Validate())
+	assert.NoError(t, (&Config{
+		Profile: &Profile{VlanID: 1},
+		Gx:      map[Mode]Action{ModeFailure: ActionRestrict, ModeBypass: ActionAllow},
+	}).Validate())
+}
+
+func TestModeOf(t *testing.T) {
+	assert.Equal(t, Mode(""), ModeOf(lte_protos.LocalCreateSessionResponse_NORMAL))
+	assert.Equal(t, ModeFailure, ModeOf(lte_protos.LocalCreateSessionResponse_FAILURE))
+	assert.Equal(t, ModeBypass, ModeOf(lte_protos.LocalCreateSessionResponse_BYPASS))
+}
@@ -0,0 +1,245 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package fairshare implements fair scheduling of the AAA server's authentication & accounting calls under CPU
+// pressure. The server's call capacity is split into reserved shares of the workloads, every workload's token bucket
+// is refilled at the rate of its share. Tokens of a full bucket spill over into the other workload's bucket, so a busy
+// workload uses the idle capacity of the other one, while an accounting retransmit storm can't take the capacity
+// reserved for new users' logins. Calls of an empty bucket wait for their workload's next token up to the max wait &
+// their deadline, or are rejected
+package fairshare
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"magma/feg/gateway/services/aaa/metrics"
+)
+
+// Workload - scheduled workload of calls
+type Workload int
+
+const (
+	// Auth - authentication calls, every EAP round of an authentication is a call
+	Auth Workload = iota
+	// Accounting - accounting calls
+	Accounting
+	numWorkloads
+)
+
+func (w Workload) String() string {
+	switch w {
+	case Auth:
+		return "auth"
+	case Accounting:
+		return "accounting"
+	}
+	return fmt.Sprintf("workload_%d", int(w))
+}
+
+// Call results
+const (
+	ResultAdmitted = "admitted" // admitted right away
+	ResultDelayed  = "delayed"  // admitted after waiting for a token
+	ResultRejected = "rejected" // the wait for a token is over the max wait or the call's deadline
+	ResultExpired  = "expired"  // the call's context was done while waiting for a token
+)
+
+// Defaults
+const (
+	DefaultAuthShare = 0.3
+	DefaultMaxWait   = 500 * time.Millisecond
+)
+
+// ServiceWorkloads - workloads of the scheduled services' calls, calls of other services are never scheduled
+var ServiceWorkloads = map[string]Workload{
+	"aaa.protos.authenticator": Auth,
+	"aaa.protos.accounting":    Accounting,
+}
+
+// Config - fair scheduling configuration
+type Config struct {
+	// Rate - capacity of the server in calls per second, shared by all workloads
+	Rate float64
+	// Burst - calls admitted at once by full buckets, split between the workloads by their shares
+	Burst int
+	// AuthShare - share of the capacity reserved for authentications (0, 1), the rest is reserved for accounting
+	AuthShare float64
+	// MaxWait - maximum wait of a call for its workload's token, calls over it are rejected
+	MaxWait time.Duration
+}
+
+type bucket struct {
+	rate   float64 // tokens per second
+	burst  float64
+	tokens float64 // negative - tokens reserved by waiting calls ahead of their refill
+}
+
+// Scheduler - token buckets of the workloads
+type Scheduler struct {
+	maxWait time.Duration
+	now     func() time.Time
+
+	mu      sync.Mutex
+	buckets [numWorkloads]bucket
+	last    time.Time // time of the last refill
+}
+
+// New returns a new Scheduler of the given configuration with full buckets, zero AuthShare & MaxWait are the
+// defaults, Burst below one second of the Rate is one second of the Rate
+func New(cfg Config) (*Scheduler, error) {
+	if cfg.Rate <= 0 {
+		return nil, fmt.Errorf("Invalid fair scheduling rate: %v calls/s", cfg.Rate)
+	}
+	if cfg.AuthShare == 0 {
+		cfg.AuthShare = DefaultAuthShare
+	}
+	if cfg.AuthShare <= 0 || cfg.AuthShare >= 1 {
+		return nil, fmt.Errorf("Invalid fair scheduling auth share: %v, must be in (0, 1)", cfg.AuthShare)
+	}
+	if cfg.MaxWait <= 0 {
+		cfg.MaxWait = DefaultMaxWait
+	}
+	burst := float64(cfg.Burst)
+	if burst < cfg.Rate {
+		burst = cfg.Rate
+	}
+	s := &Scheduler{maxWait: cfg.MaxWait, now: time.Now, last: time.Now()}
+	for w, share := range [numWorkloads]float64{Auth: cfg.AuthShare, Accounting: 1 - cfg.AuthShare} {
+		b := bucket{rate: cfg.Rate * share, burst: burst * share}
+		if b.burst < 1 {
+			b.burst = 1
+		}
+		b.tokens = b.burst
+		s.buckets[w] = b
+	}
+	return s, nil
+}
+
+// Wait waits for a token of the workload's call, it returns ResourceExhausted error if the call is rejected or its
+// context is done while waiting. A nil Scheduler admits all calls
+func (s *Scheduler) Wait(ctx context.Context, w Workload, method string) error {
+	if s == nil {
+		return nil
+	}
+	maxWait := s.maxWait
+	if deadline, ok := ctx.Deadline(); ok {
+		if left := deadline.Sub(s.now()); left < maxWait {
+			maxWait = left
+		}
+	}
+	wait, ok := s.reserve(w, maxWait)
+	if !ok {
+		metrics.ScheduledCalls.WithLabelValues(w.String(), ResultRejected).Inc()
+		return status.Errorf(
+			codes.ResourceExhausted, "%s is rejected: %s capacity is exhausted, retry after %v", method, w, wait)
+	}
+	if wait <= 0 {
+		metrics.ScheduledCalls.WithLabelValues(w.String(), ResultAdmitted).Inc()
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		metrics.ScheduledCalls.WithLabelValues(w.String(), ResultDelayed).Inc()
+		return nil
+	case <-ctx.Done():
+	}
+	s.cancel(w)
+	metrics.ScheduledCalls.WithLabelValues(w.String(), ResultExpired).Inc()
+	return status.Errorf(codes.ResourceExhausted, "%s is rejected: %v while waiting for %s capacity", method, ctx.Err(), w)
+}
+
+// UnaryServerInterceptor schedules calls of the ServiceWorkloads services, a nil Scheduler passes all calls through
+func (s *Scheduler) UnaryServerInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+
+	if s == nil {
+		return handler(ctx, req)
+	}
+	// full method: /<service>/<method>
+	service := strings.SplitN(strings.TrimPrefix(info.FullMethod, "/"), "/", 2)[0]
+	w, ok := ServiceWorkloads[service]
+	if !ok {
+		return handler(ctx, req)
+	}
+	if err := s.Wait(ctx, w, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// reserve takes a token of the workload's bucket & returns the wait for the token, it returns false & the wait if
+// the wait is over maxWait
+func (s *Scheduler) reserve(w Workload, maxWait time.Duration) (time.Duration, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.refillUnsafe()
+	b := &s.buckets[w]
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0, true
+	}
+	wait := time.Duration(-b.tokens / b.rate * float64(time.Second))
+	if wait > maxWait {
+		b.tokens++
+		return wait, false
+	}
+	return wait, true
+}
+
+// cancel returns the token reserved by an expired call
+func (s *Scheduler) cancel(w Workload) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buckets[w].tokens++
+}
+
+// refillUnsafe refills the buckets at their rates since the last refill, tokens over a bucket's burst spill over into
+// the other buckets with room, s.mu must be held
+func (s *Scheduler) refillUnsafe() {
+	now := s.now()
+	elapsed := now.Sub(s.last).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+	s.last = now
+	var spill float64
+	for w := range s.buckets {
+		b := &s.buckets[w]
+		b.tokens += b.rate * elapsed
+		if b.tokens > b.burst {
+			spill += b.tokens - b.burst
+			b.tokens = b.burst
+		}
+	}
+	for w := range s.buckets {
+		if spill <= 0 {
+			return
+		}
+		b := &s.buckets[w]
+		if room := b.burst - b.tokens; room > 0 {
+			if room > spill {
+				room = spill
+			}
+			b.tokens += room
+			spill -= room
+		}
+	}
+}
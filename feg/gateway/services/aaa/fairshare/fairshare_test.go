@@ -0,0 +1,83 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package fairshare
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestScheduler(t *testing.T) {
+	s, err := New(Config{Rate: 10, AuthShare: 0.3, MaxWait: 200 * time.Millisecond})
+	assert.NoError(t, err)
+	now := time.Unix(1500000000, 0)
+	s.now, s.last = func() time.Time { return now }, now
+	ctx := context.Background()
+
+	// an accounting storm exhausts the accounting share, then waits up to the max wait & is rejected
+	for i := 0; i < 7; i++ {
+		assert.NoError(t, s.Wait(ctx, Accounting, "start"))
+	}
+	wait, ok := s.reserve(Accounting, time.Second)
+	assert.True(t, ok)
+	assert.Equal(t, 1000*time.Millisecond/7, wait)
+	err = s.Wait(ctx, Accounting, "start")
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// tokens of the idle auth bucket spill over into the accounting bucket
+	now = now.Add(100 * time.Millisecond)
+	assert.NoError(t, s.Wait(ctx, Auth, "handle"))
+	assert.InDelta(t, 0, s.buckets[Accounting].tokens, 1e-9)
+
+	// the rest of the auth share is reserved for logins
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, s.Wait(ctx, Auth, "handle"))
+	}
+	assert.Error(t, s.Wait(ctx, Auth, "handle"))
+
+	// calls don't wait past their deadlines & expired calls return their tokens
+	deadline, cancel := context.WithDeadline(ctx, now.Add(10*time.Millisecond))
+	defer cancel()
+	err = s.Wait(deadline, Auth, "handle")
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	err = s.Wait(canceled, Accounting, "stop")
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.InDelta(t, 0, s.buckets[Accounting].tokens, 1e-9)
+
+	_, err = New(Config{Rate: 10, AuthShare: 1})
+	assert.Error(t, err)
+	_, err = New(Config{})
+	assert.Error(t, err)
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	s, err := New(Config{Rate: 1, AuthShare: 0.5})
+	assert.NoError(t, err)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+	call := func(s *Scheduler, method string) error {
+		_, err := s.UnaryServerInterceptor(
+			context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return err
+	}
+	// the buckets hold a single token, the second call of a scheduled service waits over the max wait
+	assert.NoError(t, call(s, "/aaa.protos.accounting/start"))
+	assert.Error(t, call(s, "/aaa.protos.accounting/start"))
+	assert.NoError(t, call(s, "/aaa.protos.authenticator/handle"))
+	assert.NoError(t, call(s, "/aaa.protos.session_admin/list"), "other services aren't scheduled")
+	assert.NoError(t, call(s, "/aaa.protos.session_admin/list"))
+	assert.NoError(t, call(nil, "/aaa.protos.accounting/start"))
+}
@@ -0,0 +1,209 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package fingerprint implements device classification hints of UEs, e.g. DHCP fingerprints classified by pipelined
+// or user agents seen by captive portals. Hints are attached to sessions' contexts as context attributes, so they
+// are carried by session analytics (audit events) & CreateSession calls' metadata, enabling device type usage
+// breakdowns
+package fingerprint
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+// Hint sources
+const (
+	SourceDHCP      = "dhcp"       // DHCP fingerprint (options list, vendor class) classified by pipelined
+	SourceUserAgent = "user_agent" // HTTP User-Agent seen by a captive portal
+)
+
+// Context attributes of device hints
+const (
+	TypeAttribute   = "device_type"
+	OSAttribute     = "device_os"
+	VendorAttribute = "device_vendor"
+	ModelAttribute  = "device_model"
+	SourceAttribute = "device_hint_source"
+)
+
+// metadataPrefix - prefix of outgoing GRPC metadata keys of device hints, e.g. x-device-type
+const metadataPrefix = "x-"
+
+// Pending hints defaults
+const (
+	DefaultTTL        = 5 * time.Minute
+	DefaultMaxPending = 10000
+)
+
+// Hint - device classification hint, empty fields are unknown
+type Hint struct {
+	Source string `json:"source"`
+	Type   string `json:"type,omitempty"` // e.g. phone, tablet, laptop, iot
+	OS     string `json:"os,omitempty"`
+	Vendor string `json:"vendor,omitempty"`
+	Model  string `json:"model,omitempty"`
+}
+
+// Validate returns an error if the hint's source is unknown, the hint is empty or its fields exceed the context
+// attributes size limits
+func (h *Hint) Validate() error {
+	if h == nil {
+		return fmt.Errorf("nil device hint")
+	}
+	if h.Source != SourceDHCP && h.Source != SourceUserAgent {
+		return fmt.Errorf("unknown device hint source '%s'", h.Source)
+	}
+	attrs := h.Attributes()
+	if len(attrs) == 1 {
+		return fmt.Errorf("empty device hint")
+	}
+	for k, v := range attrs {
+		if err := protos.ValidateAttribute(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Attributes returns the context attributes of the hint's known fields
+func (h *Hint) Attributes() map[string]string {
+	attrs := map[string]string{}
+	if h == nil {
+		return attrs
+	}
+	for k, v := range map[string]string{
+		SourceAttribute: h.Source,
+		TypeAttribute:   h.Type,
+		OSAttribute:     h.OS,
+		VendorAttribute: h.Vendor,
+		ModelAttribute:  h.Model,
+	} {
+		if len(v) > 0 {
+			attrs[k] = v
+		}
+	}
+	return attrs
+}
+
+// FromAttributes returns the device hint of the context attributes or nil if they carry none
+func FromAttributes(attrs map[string]string) *Hint {
+	h := &Hint{
+		Source: attrs[SourceAttribute],
+		Type:   attrs[TypeAttribute],
+		OS:     attrs[OSAttribute],
+		Vendor: attrs[VendorAttribute],
+		Model:  attrs[ModelAttribute],
+	}
+	if len(h.Type) == 0 && len(h.OS) == 0 && len(h.Vendor) == 0 && len(h.Model) == 0 {
+		return nil
+	}
+	return h
+}
+
+// AppendToOutgoingContext returns ctx with the hint's known fields appended to its outgoing GRPC metadata
+func AppendToOutgoingContext(ctx context.Context, h *Hint) context.Context {
+	if h == nil {
+		return ctx
+	}
+	var kv []string
+	for k, v := range h.Attributes() {
+		kv = append(kv, metadataPrefix+k, v)
+	}
+	return metadata.AppendToOutgoingContext(ctx, kv...)
+}
+
+type pendingHint struct {
+	hint    *Hint
+	expires time.Time
+}
+
+// Pending keeps hints of UEs without a session yet by MAC address, e.g. DHCP fingerprints of UEs which are not
+// accounted yet, until the UE's session takes them or they expire
+type Pending struct {
+	sync.Mutex
+	ttl   time.Duration
+	max   int
+	hints map[string]pendingHint
+}
+
+// NewPending returns new pending hints expiring after ttl, DefaultTTL if ttl is not positive
+func NewPending(ttl time.Duration) *Pending {
+	p := &Pending{max: DefaultMaxPending, hints: map[string]pendingHint{}}
+	p.SetTTL(ttl)
+	return p
+}
+
+// SetTTL sets the time pending hints wait for their UEs' sessions, DefaultTTL if ttl is not positive
+func (p *Pending) SetTTL(ttl time.Duration) {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	p.Lock()
+	p.ttl = ttl
+	p.Unlock()
+}
+
+// Put keeps the hint of the UE's MAC address, replacing its previous hint. It returns false if the MAC address is
+// invalid or the maximum number of hints is pending
+func (p *Pending) Put(mac string, h *Hint) bool {
+	key, ok := macKey(mac)
+	if !ok || h == nil {
+		return false
+	}
+	p.Lock()
+	defer p.Unlock()
+	now := time.Now()
+	if _, ok = p.hints[key]; !ok && len(p.hints) >= p.max {
+		for k, ph := range p.hints {
+			if now.After(ph.expires) {
+				delete(p.hints, k)
+			}
+		}
+		if len(p.hints) >= p.max {
+			return false
+		}
+	}
+	p.hints[key] = pendingHint{hint: h, expires: now.Add(p.ttl)}
+	return true
+}
+
+// Take removes & returns the pending hint of the UE's MAC address, nil if there is none or it expired
+func (p *Pending) Take(mac string) *Hint {
+	key, ok := macKey(mac)
+	if p == nil || !ok {
+		return nil
+	}
+	p.Lock()
+	defer p.Unlock()
+	ph, ok := p.hints[key]
+	if !ok {
+		return nil
+	}
+	delete(p.hints, key)
+	if time.Now().After(ph.expires) {
+		return nil
+	}
+	return ph.hint
+}
+
+// macKey returns the canonical form of the MAC address & false if it's invalid
+func macKey(mac string) (string, bool) {
+	hw, err := net.ParseMAC(mac)
+	if err != nil {
+		return "", false
+	}
+	return hw.String(), true
+}
@@ -0,0 +1,71 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package fingerprint
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestHintAttributes(t *testing.T) {
+	h := &Hint{Source: SourceDHCP, Type: "phone", OS: "android"}
+	assert.NoError(t, h.Validate())
+	attrs := h.Attributes()
+	assert.Equal(t, map[string]string{
+		SourceAttribute: SourceDHCP, TypeAttribute: "phone", OSAttribute: "android"}, attrs)
+	assert.Equal(t, h, FromAttributes(attrs))
+	assert.Nil(t, FromAttributes(map[string]string{"other": "value", SourceAttribute: SourceDHCP}))
+
+	assert.Error(t, (&Hint{Source: "unknown", Type: "phone"}).Validate())
+	assert.Error(t, (&Hint{Source: SourceUserAgent}).Validate())
+	assert.Error(t, (&Hint{Source: SourceUserAgent, Model: strings.Repeat("m", 300)}).Validate())
+	var nilHint *Hint
+	assert.Error(t, nilHint.Validate())
+}
+
+func TestAppendToOutgoingContext(t *testing.T) {
+	ctx := AppendToOutgoingContext(context.Background(), &Hint{Source: SourceUserAgent, Type: "laptop"})
+	md, ok := metadata.FromOutgoingContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"laptop"}, md.Get("x-device_type"))
+	assert.Equal(t, []string{SourceUserAgent}, md.Get("x-device_hint_source"))
+
+	ctx = context.Background()
+	assert.Equal(t, ctx, AppendToOutgoingContext(ctx, nil))
+}
+
+func TestPending(t *testing.T) {
+	p := NewPending(time.Minute)
+	h := &Hint{Source: SourceDHCP, Type: "phone"}
+	assert.False(t, p.Put("not a mac", h))
+	assert.True(t, p.Put("AA-BB-CC-DD-EE-FF", h))
+	assert.Equal(t, h, p.Take("aa:bb:cc:dd:ee:ff"))
+	assert.Nil(t, p.Take("aa:bb:cc:dd:ee:ff"))
+
+	p.max = 1
+	assert.True(t, p.Put("aa:bb:cc:dd:ee:01", h))
+	assert.False(t, p.Put("aa:bb:cc:dd:ee:02", h))
+	assert.True(t, p.Put("aa:bb:cc:dd:ee:01", h))
+
+	// expired hints are not taken & make room for new ones
+	p.SetTTL(time.Nanosecond)
+	assert.True(t, p.Put("aa:bb:cc:dd:ee:01", h))
+	time.Sleep(time.Millisecond)
+	assert.True(t, p.Put("aa:bb:cc:dd:ee:02", h))
+	time.Sleep(time.Millisecond)
+	assert.Nil(t, p.Take("aa:bb:cc:dd:ee:02"))
+
+	var nilPending *Pending
+	assert.Nil(t, nilPending.Take("aa:bb:cc:dd:ee:ff"))
+}
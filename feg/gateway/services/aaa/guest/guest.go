@@ -0,0 +1,112 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package guest classifies time limited guest sessions (e.g. free trial venue Wi-Fi) by their APN or NAI realm.
+// Guest sessions carry a hard maximum duration: the NAS gets it as the Access-Accept's Session-Timeout & the AAA
+// terminates guest sessions outliving it
+package guest
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+const (
+	// Class - session class of guest sessions
+	Class = "guest"
+	// ClassAttribute - context attribute of the session's class
+	ClassAttribute = "session_class"
+	// TimeoutAttribute - context attribute of the session's Session-Timeout in seconds, returned to the NAS
+	TimeoutAttribute = "session_timeout"
+	// Grace - extra time given to the NAS to end an expired guest session by its Session-Timeout before the AAA
+	// terminates it
+	Grace = 5 * time.Second
+)
+
+// Config - guest sessions' APNs, realms & their maximum duration
+type Config struct {
+	APNs        []string
+	Realms      []string
+	MaxDuration time.Duration
+}
+
+// Validate returns an error if the configuration has no APNs & realms or its maximum duration isn't a positive
+// number of seconds
+func (cfg *Config) Validate() error {
+	if len(cfg.APNs) == 0 && len(cfg.Realms) == 0 {
+		return fmt.Errorf("guest sessions require APNs or realms")
+	}
+	if cfg.MaxDuration < time.Second {
+		return fmt.Errorf("invalid guest sessions maximum duration %v, must be at least 1s", cfg.MaxDuration)
+	}
+	return nil
+}
+
+// IsGuest returns true if the session's APN or realm is a guest APN or realm
+func (cfg *Config) IsGuest(aaaCtx *protos.Context) bool {
+	if cfg == nil {
+		return false
+	}
+	for _, apn := range cfg.APNs {
+		if strings.EqualFold(apn, aaaCtx.GetApn()) {
+			return true
+		}
+	}
+	realm := Realm(aaaCtx)
+	if len(realm) == 0 {
+		return false
+	}
+	for _, r := range cfg.Realms {
+		if strings.EqualFold(r, realm) {
+			return true
+		}
+	}
+	return false
+}
+
+// Classify marks the session as a guest session with its Session-Timeout, if it's a guest session. Returns true if
+// the session is a guest session
+func (cfg *Config) Classify(aaaCtx *protos.Context) (bool, error) {
+	if !cfg.IsGuest(aaaCtx) {
+		return false, nil
+	}
+	if err := aaaCtx.SetAttribute(ClassAttribute, Class); err != nil {
+		return true, err
+	}
+	return true, aaaCtx.SetUintAttribute(TimeoutAttribute, uint64(cfg.MaxDuration/time.Second))
+}
+
+// IsGuestSession returns true if the session was classified as a guest session
+func IsGuestSession(aaaCtx *protos.Context) bool {
+	class, ok := aaaCtx.GetAttribute(ClassAttribute)
+	return ok && class == Class
+}
+
+// Realm returns the realm of the session's NAI, the outer identity's realm if the identity has none
+func Realm(aaaCtx *protos.Context) string {
+	for _, id := range []string{aaaCtx.GetIdentity(), aaaCtx.GetOuterIdentity()} {
+		if i := strings.LastIndex(id, "@"); i >= 0 && i < len(id)-1 {
+			return id[i+1:]
+		}
+	}
+	return ""
+}
+
+// ParseList returns the non empty entries of the comma separated list
+func ParseList(list string) []string {
+	var res []string
+	for _, entry := range strings.Split(list, ",") {
+		if entry = strings.TrimSpace(entry); len(entry) > 0 {
+			res = append(res, entry)
+		}
+	}
+	return res
+}
@@ -0,0 +1,63 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package guest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+func TestValidate(t *testing.T) {
+	assert.Error(t, (&Config{MaxDuration: time.Hour}).Validate())
+	assert.Error(t, (&Config{APNs: []string{"trial"}}).Validate())
+	assert.Error(t, (&Config{APNs: []string{"trial"}, MaxDuration: time.Millisecond}).Validate())
+	assert.NoError(t, (&Config{Realms: []string{"venue.example"}, MaxDuration: time.Hour}).Validate())
+}
+
+func TestClassify(t *testing.T) {
+	cfg := &Config{
+		APNs:        []string{"Trial"},
+		Realms:      ParseList(" venue.example, ,guest.example"),
+		MaxDuration: 30 * time.Minute,
+	}
+	assert.Equal(t, []string{"venue.example", "guest.example"}, cfg.Realms)
+
+	for _, aaaCtx := range []*protos.Context{
+		{Apn: "trial"},
+		{Apn: "internet", Identity: "0001010000000001@VENUE.example"},
+		{Apn: "internet", OuterIdentity: "anonymous@guest.example"},
+	} {
+		guest, err := cfg.Classify(aaaCtx)
+		assert.NoError(t, err)
+		assert.True(t, guest)
+		assert.True(t, IsGuestSession(aaaCtx))
+		tout, ok := aaaCtx.GetUintAttribute(TimeoutAttribute)
+		assert.True(t, ok)
+		assert.Equal(t, uint64(1800), tout)
+	}
+
+	for _, aaaCtx := range []*protos.Context{
+		{Apn: "internet", Identity: "0001010000000001@wlan.mnc001.mcc001.3gppnetwork.org"},
+		{Apn: "internet", Identity: "user@"},
+		{},
+	} {
+		guest, err := cfg.Classify(aaaCtx)
+		assert.NoError(t, err)
+		assert.False(t, guest)
+		assert.False(t, IsGuestSession(aaaCtx))
+		assert.Empty(t, aaaCtx.GetAttributes())
+	}
+
+	var disabled *Config
+	assert.False(t, disabled.IsGuest(&protos.Context{Apn: "trial"}))
+}
@@ -0,0 +1,284 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package health periodically checks the AAA server's components (session table, session manager & Radius server
+// connectivity, configuration staleness) & reports their health to orchestrator, systemd & k8s probes via the
+// standard GRPC health service & a local HTTP /healthz endpoint, so unhealthy gateways are restarted or drained
+// automatically.
+//
+// The GRPC health service reports the overall readiness as the "" service & each component's health as the
+// component's service, e.g. "sessiond"
+package health
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"magma/feg/gateway/registry"
+	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/aaa/panics"
+	"magma/feg/gateway/services/aaa/slo"
+	managed_configs "magma/orc8r/gateway/mconfig"
+)
+
+// DefaultInterval - default interval of the components' checks
+const DefaultInterval = 10 * time.Second
+
+// Components' statuses
+const (
+	StatusOK      = "ok"
+	StatusFailing = "failing"
+)
+
+// Sessions - name of the session table component
+const Sessions = "sessions"
+
+// Check returns the component's status detail & an error if the component is failing
+type Check func() (detail string, err error)
+
+// Config - health checker configuration
+type Config struct {
+	Interval time.Duration // checks interval, 0 - DefaultInterval
+	// Sessions returns the session table size, MaxSessions - the table's limit, 0 - unlimited. A full table fails the
+	// readiness, so new UEs are directed to other gateways
+	Sessions    func() int
+	MaxSessions int
+	Checks      map[string]Check // the components' checks by component name
+}
+
+// Component - a component's health
+type Component struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// Report - the latest checks' results
+type Report struct {
+	Healthy    bool        `json:"healthy"`
+	Time       time.Time   `json:"time"`
+	Sessions   int         `json:"sessions"`
+	Components []Component `json:"components"`
+}
+
+// Checker - periodic health checker of the AAA server's components
+type Checker struct {
+	cfg    Config
+	server *grpchealth.Server
+
+	mu     sync.RWMutex
+	report *Report
+	done   chan struct{}
+}
+
+// New returns a new Checker, the server is reported healthy until its first check
+func New(cfg Config) (*Checker, error) {
+	if cfg.Sessions == nil {
+		return nil, fmt.Errorf("missing session table size")
+	}
+	if cfg.MaxSessions < 0 {
+		return nil, fmt.Errorf("invalid max sessions: %d", cfg.MaxSessions)
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultInterval
+	}
+	return &Checker{
+		cfg:    cfg,
+		server: grpchealth.NewServer(),
+		report: &Report{Healthy: true, Time: time.Now()},
+		done:   make(chan struct{}),
+	}, nil
+}
+
+// Register registers the GRPC health service on the server
+func (c *Checker) Register(srv *grpc.Server) {
+	healthpb.RegisterHealthServer(srv, c.server)
+}
+
+// Start starts periodic checks, the first check runs immediately
+func (c *Checker) Start() {
+	go func() {
+		ticker := time.NewTicker(c.cfg.Interval)
+		defer ticker.Stop()
+		for {
+			c.Check()
+			select {
+			case <-ticker.C:
+			case <-c.done:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops periodic checks
+func (c *Checker) Stop() {
+	close(c.done)
+}
+
+// Check checks all components, updates the GRPC health service's statuses & returns the report
+func (c *Checker) Check() *Report {
+	defer panics.Recover("health_check")
+	report := &Report{Healthy: true, Time: time.Now(), Sessions: c.cfg.Sessions()}
+	sessions := Component{Name: Sessions, Status: StatusOK, Detail: fmt.Sprintf("%d sessions", report.Sessions)}
+	if c.cfg.MaxSessions > 0 {
+		sessions.Detail = fmt.Sprintf("%d of max %d sessions", report.Sessions, c.cfg.MaxSessions)
+		if report.Sessions >= c.cfg.MaxSessions {
+			sessions.Status = StatusFailing
+		}
+	}
+	report.Components = append(report.Components, sessions)
+
+	names := make([]string, 0, len(c.cfg.Checks))
+	for name := range c.cfg.Checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		detail, err := c.cfg.Checks[name]()
+		comp := Component{Name: name, Status: StatusOK, Detail: detail}
+		if err != nil {
+			comp.Status, comp.Detail = StatusFailing, err.Error()
+		}
+		report.Components = append(report.Components, comp)
+	}
+
+	for _, comp := range report.Components {
+		serving, gauge := healthpb.HealthCheckResponse_SERVING, 1.0
+		if comp.Status != StatusOK {
+			report.Healthy = false
+			serving, gauge = healthpb.HealthCheckResponse_NOT_SERVING, 0
+			log.Printf("Health check of %s failed: %s", comp.Name, comp.Detail)
+		}
+		c.server.SetServingStatus(comp.Name, serving)
+		metrics.ComponentHealth.WithLabelValues(comp.Name).Set(gauge)
+	}
+	if report.Healthy {
+		c.server.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	} else {
+		c.server.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+	c.mu.Lock()
+	c.report = report
+	c.mu.Unlock()
+	return report
+}
+
+// Report returns the latest checks' report
+func (c *Checker) Report() *Report {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.report
+}
+
+// ServeHTTP serves the latest report as JSON, unhealthy servers' reports with 503 Service Unavailable
+func (c *Checker) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	report := c.Report()
+	w.Header().Set("Content-Type", "application/json")
+	if !report.Healthy {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(report)
+}
+
+// ServeHTTPEndpoint serves the /healthz endpoint on the address in the background
+func (c *Checker) ServeHTTPEndpoint(addr string) error {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("health endpoint listen error: %v", err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/healthz", c)
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		log.Printf("Health endpoint error: %v", srv.Serve(lis))
+	}()
+	return nil
+}
+
+// connectivityCodes - codes of calls which didn't reach the dependency
+var connectivityCodes = map[codes.Code]bool{codes.Unavailable: true, codes.DeadlineExceeded: true}
+
+// SessionManagerCheck fails if the latest CreateSession or EndSession call didn't reach session manager, the
+// calls' results & latencies are the check's detail
+func SessionManagerCheck() Check {
+	return func() (string, error) {
+		var latest slo.Call
+		var details []string
+		for _, method := range []string{"CreateSession", "EndSession"} {
+			call, ok := slo.LastCall(slo.SessionD, method)
+			if !ok {
+				details = append(details, method+": no calls")
+				continue
+			}
+			details = append(details, fmt.Sprintf("%s: %s in %v, %v ago",
+				method, call.Code, call.Latency.Round(time.Millisecond), time.Since(call.Time).Round(time.Second)))
+			if call.Time.After(latest.Time) {
+				latest = call
+			}
+		}
+		detail := strings.Join(details, "; ")
+		if connectivityCodes[latest.Code] {
+			return detail, fmt.Errorf("session manager is unreachable: %s", detail)
+		}
+		return detail, nil
+	}
+}
+
+// getConnection returns the service's connection, replaced by tests
+var getConnection = func(service string) (interface{ GetState() connectivity.State }, error) {
+	return registry.GetConnection(service)
+}
+
+// RegistryCheck fails if the service registry's connection to the service can't be established
+func RegistryCheck(service string) Check {
+	return func() (string, error) {
+		conn, err := getConnection(service)
+		if err != nil {
+			return "", fmt.Errorf("%s connection error: %v", service, err)
+		}
+		state := conn.GetState()
+		if state == connectivity.TransientFailure || state == connectivity.Shutdown {
+			return "", fmt.Errorf("%s connection is %s", service, state)
+		}
+		return fmt.Sprintf("%s connection is %s", service, state), nil
+	}
+}
+
+// MconfigCheck fails if the service's mconfig changed since the applied configuration was loaded at start, the
+// service must be restarted to apply the change
+func MconfigCheck(service string, applied proto.Message, loaded time.Time) Check {
+	return func() (string, error) {
+		current := proto.Clone(applied)
+		current.Reset()
+		if err := managed_configs.GetServiceConfigs(service, current); err != nil {
+			return "", fmt.Errorf("mconfig error: %v", err)
+		}
+		if !proto.Equal(applied, current) {
+			return "", fmt.Errorf("mconfig changed since it was loaded at %s, restart required",
+				loaded.Format(time.RFC3339))
+		}
+		return fmt.Sprintf("mconfig loaded at %s", loaded.Format(time.RFC3339)), nil
+	}
+}
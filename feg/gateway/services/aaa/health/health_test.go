@@ -0,0 +1,137 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package health
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"magma/feg/gateway/services/aaa/slo"
+)
+
+func TestChecker(t *testing.T) {
+	_, err := New(Config{})
+	assert.Error(t, err, "the session table size is required")
+
+	sessions := 5
+	var radiusErr error
+	c, err := New(Config{
+		Sessions:    func() int { return sessions },
+		MaxSessions: 10,
+		Checks: map[string]Check{
+			"radius": func() (string, error) { return "radius connection is READY", radiusErr },
+		},
+	})
+	assert.NoError(t, err)
+	assert.True(t, c.Report().Healthy, "healthy until the first check")
+
+	report := c.Check()
+	assert.True(t, report.Healthy)
+	assert.Equal(t, 5, report.Sessions)
+	assert.Equal(t, []Component{
+		{Name: Sessions, Status: StatusOK, Detail: "5 of max 10 sessions"},
+		{Name: "radius", Status: StatusOK, Detail: "radius connection is READY"},
+	}, report.Components)
+	assertServing(t, c, "", healthpb.HealthCheckResponse_SERVING)
+	assertServing(t, c, "radius", healthpb.HealthCheckResponse_SERVING)
+
+	// a failing component fails the readiness
+	radiusErr = errors.New("radius connection is TRANSIENT_FAILURE")
+	report = c.Check()
+	assert.False(t, report.Healthy)
+	assert.Equal(t, Component{Name: "radius", Status: StatusFailing, Detail: radiusErr.Error()}, report.Components[1])
+	assertServing(t, c, "", healthpb.HealthCheckResponse_NOT_SERVING)
+	assertServing(t, c, "radius", healthpb.HealthCheckResponse_NOT_SERVING)
+	assertServing(t, c, Sessions, healthpb.HealthCheckResponse_SERVING)
+
+	w := httptest.NewRecorder()
+	c.ServeHTTP(w, httptest.NewRequest("GET", "/healthz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	served := &Report{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), served))
+	assert.Equal(t, report.Components, served.Components)
+
+	// a full session table fails the readiness
+	radiusErr, sessions = nil, 10
+	assert.False(t, c.Check().Healthy)
+	assertServing(t, c, Sessions, healthpb.HealthCheckResponse_NOT_SERVING)
+
+	sessions = 9
+	c.Check()
+	w = httptest.NewRecorder()
+	c.ServeHTTP(w, httptest.NewRequest("GET", "/healthz", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func assertServing(t *testing.T, c *Checker, service string, expected healthpb.HealthCheckResponse_ServingStatus) {
+	res, err := c.server.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+	if assert.NoError(t, err) {
+		assert.Equal(t, expected, res.GetStatus(), service)
+	}
+}
+
+func TestSessionManagerCheck(t *testing.T) {
+	check := SessionManagerCheck()
+	slo.Observe(slo.SessionD, "EndSession", time.Now(), nil)
+	_, err := check()
+	assert.NoError(t, err)
+
+	slo.Observe(slo.SessionD, "CreateSession", time.Now(), status.Errorf(codes.PermissionDenied, "rejected"))
+	detail, err := check()
+	assert.NoError(t, err, "rejected sessions reached session manager")
+	assert.Contains(t, detail, "CreateSession: PermissionDenied")
+
+	slo.Observe(slo.SessionD, "CreateSession", time.Now(), status.Errorf(codes.Unavailable, "connection refused"))
+	_, err = check()
+	assert.Error(t, err)
+
+	slo.Observe(slo.SessionD, "EndSession", time.Now(), nil)
+	_, err = check()
+	assert.NoError(t, err, "the latest call reached session manager")
+}
+
+type fakeConn connectivity.State
+
+func (c fakeConn) GetState() connectivity.State {
+	return connectivity.State(c)
+}
+
+func TestRegistryCheck(t *testing.T) {
+	defer func(f func(string) (interface{ GetState() connectivity.State }, error)) { getConnection = f }(getConnection)
+	var state connectivity.State
+	var connErr error
+	getConnection = func(string) (interface{ GetState() connectivity.State }, error) {
+		return fakeConn(state), connErr
+	}
+	check := RegistryCheck("RADIUS")
+
+	state = connectivity.Ready
+	detail, err := check()
+	assert.NoError(t, err)
+	assert.Equal(t, "RADIUS connection is READY", detail)
+
+	state = connectivity.TransientFailure
+	_, err = check()
+	assert.Error(t, err)
+
+	connErr = errors.New("unknown service")
+	_, err = check()
+	assert.Error(t, err)
+}
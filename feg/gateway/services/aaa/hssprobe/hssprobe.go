@@ -0,0 +1,213 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package hssprobe periodically validates the HSS reachability over SWx with a lightweight health transaction (MAR
+// of a probe user) & reflects it into the AAA server's health status, so authentication failures caused by HSS
+// outages are attributed to the HSS & NASes can fail over to another AAA server.
+//
+// Any Diameter answer, including errors such as DIAMETER_ERROR_USER_UNKNOWN, proves the HSS is reachable, only
+// transport failures & timeouts are probe failures. The probe user should be unknown to the HSS, so its probes are
+// never answered from the SWx proxy's auth vectors cache
+package hssprobe
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	fegprotos "magma/feg/cloud/go/protos"
+	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/aaa/panics"
+	"magma/feg/gateway/services/swx_proxy"
+	orcprotos "magma/orc8r/cloud/go/protos"
+)
+
+// Defaults
+const (
+	DefaultInterval         = 30 * time.Second
+	DefaultTimeout          = 5 * time.Second
+	DefaultFailureThreshold = 3
+)
+
+// Probe results
+const (
+	resultReachable   = "reachable"
+	resultUnreachable = "unreachable"
+)
+
+// minDiameterResultCode - GRPC codes of Diameter answers are their (1xxx - 5xxx) Diameter result codes
+const minDiameterResultCode = 1000
+
+// Config - HSS prober configuration
+type Config struct {
+	UserName         string        // probe user's IMSI
+	Interval         time.Duration // probes interval, 0 - DefaultInterval
+	Timeout          time.Duration // probe answer timeout, 0 - DefaultTimeout
+	FailureThreshold int           // consecutive probe failures marking the HSS unreachable, 0 - DefaultFailureThreshold
+}
+
+// authenticate sends the SWx MAR, replaced by tests
+var authenticate = swx_proxy.Authenticate
+
+// Prober - HSS reachability prober
+type Prober struct {
+	cfg Config
+
+	mu          sync.RWMutex
+	reachable   bool
+	failures    int       // consecutive probe failures
+	lastErr     error     // last probe failure
+	lastSuccess time.Time // last successful probe
+	inFlight    bool
+	done        chan struct{}
+}
+
+// New returns a new Prober, the HSS is considered reachable until the prober's failure threshold is reached
+func New(cfg Config) (*Prober, error) {
+	if len(cfg.UserName) == 0 {
+		return nil, fmt.Errorf("missing HSS probe user name")
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultInterval
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = DefaultFailureThreshold
+	}
+	return &Prober{cfg: cfg, reachable: true, done: make(chan struct{})}, nil
+}
+
+// Start starts periodic probes, the first probe is sent immediately
+func (p *Prober) Start() {
+	go func() {
+		ticker := time.NewTicker(p.cfg.Interval)
+		defer ticker.Stop()
+		for {
+			p.Probe()
+			select {
+			case <-ticker.C:
+			case <-p.done:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops periodic probes
+func (p *Prober) Stop() {
+	close(p.done)
+}
+
+// Reachable returns true if the HSS is reachable. A nil Prober always reports a reachable HSS
+func (p *Prober) Reachable() bool {
+	if p == nil {
+		return true
+	}
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.reachable
+}
+
+// Probe sends a single health transaction & updates the HSS reachability with its result, probes blocked past their
+// timeout are failures & no new probe is sent until they complete
+func (p *Prober) Probe() {
+	p.mu.Lock()
+	if p.inFlight {
+		p.mu.Unlock()
+		p.update(fmt.Errorf("previous probe is not answered"))
+		return
+	}
+	p.inFlight = true
+	p.mu.Unlock()
+
+	result := make(chan error, 1)
+	go func() {
+		defer panics.Recover("hss_probe")
+		defer func() {
+			p.mu.Lock()
+			p.inFlight = false
+			p.mu.Unlock()
+		}()
+		_, err := authenticate(&fegprotos.AuthenticationRequest{
+			UserName:             p.cfg.UserName,
+			SipNumAuthVectors:    1,
+			AuthenticationScheme: fegprotos.AuthenticationScheme_EAP_AKA,
+		})
+		result <- err
+	}()
+	timer := time.NewTimer(p.cfg.Timeout)
+	defer timer.Stop()
+	select {
+	case err := <-result:
+		if isAnswered(err) {
+			err = nil
+		}
+		p.update(err)
+	case <-timer.C:
+		p.update(fmt.Errorf("probe timed out after %v", p.cfg.Timeout))
+	}
+}
+
+// update applies the probe's result
+func (p *Prober) update(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err == nil {
+		metrics.HSSProbes.WithLabelValues(resultReachable).Inc()
+		if !p.reachable {
+			log.Printf("HSS is reachable again after %d failed probes", p.failures)
+		}
+		p.reachable, p.failures, p.lastErr, p.lastSuccess = true, 0, nil, time.Now()
+		metrics.HSSReachable.Set(1)
+		return
+	}
+	metrics.HSSProbes.WithLabelValues(resultUnreachable).Inc()
+	p.failures++
+	p.lastErr = err
+	if p.reachable && p.failures >= p.cfg.FailureThreshold {
+		p.reachable = false
+		metrics.HSSReachable.Set(0)
+		log.Printf("HSS is unreachable after %d failed probes: %v", p.failures, err)
+	}
+}
+
+// isAnswered returns true if the SWx call was answered by the HSS
+func isAnswered(err error) bool {
+	return err == nil || status.Code(err) >= minDiameterResultCode
+}
+
+// GetHealthStatus returns the AAA server's health status, unhealthy while the HSS is unreachable
+func (p *Prober) GetHealthStatus(ctx context.Context, req *orcprotos.Void) (*fegprotos.HealthStatus, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if !p.reachable {
+		return &fegprotos.HealthStatus{
+			Health: fegprotos.HealthStatus_UNHEALTHY,
+			HealthMessage: fmt.Sprintf("HSS is unreachable: %d failed probes, last success: %v, last error: %v",
+				p.failures, p.lastSuccess, p.lastErr),
+		}, nil
+	}
+	return &fegprotos.HealthStatus{Health: fegprotos.HealthStatus_HEALTHY, HealthMessage: "HSS is reachable"}, nil
+}
+
+// Disable isn't supported, the AAA server's functionality can't be disabled
+func (p *Prober) Disable(ctx context.Context, req *fegprotos.DisableMessage) (*orcprotos.Void, error) {
+	return &orcprotos.Void{}, status.Errorf(codes.Unimplemented, "AAA server cannot be disabled")
+}
+
+// Enable is a no-op, the AAA server is always enabled
+func (p *Prober) Enable(ctx context.Context, req *orcprotos.Void) (*orcprotos.Void, error) {
+	return &orcprotos.Void{}, nil
+}
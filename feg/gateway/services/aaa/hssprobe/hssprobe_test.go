@@ -0,0 +1,106 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package hssprobe
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	fegprotos "magma/feg/cloud/go/protos"
+	orcprotos "magma/orc8r/cloud/go/protos"
+)
+
+// fakeHSS answers probes with its current error, blocking ones until released
+type fakeHSS struct {
+	sync.Mutex
+	err     error
+	block   chan struct{}
+	probes  int
+	lastReq *fegprotos.AuthenticationRequest
+}
+
+func (h *fakeHSS) authenticate(req *fegprotos.AuthenticationRequest) (*fegprotos.AuthenticationAnswer, error) {
+	h.Lock()
+	h.probes++
+	h.lastReq = req
+	err, block := h.err, h.block
+	h.Unlock()
+	if block != nil {
+		<-block
+	}
+	return &fegprotos.AuthenticationAnswer{}, err
+}
+
+func (h *fakeHSS) set(err error, block chan struct{}) {
+	h.Lock()
+	h.err, h.block = err, block
+	h.Unlock()
+}
+
+func TestProber(t *testing.T) {
+	defer func(a func(*fegprotos.AuthenticationRequest) (*fegprotos.AuthenticationAnswer, error)) {
+		authenticate = a
+	}(authenticate)
+	hss := &fakeHSS{}
+	authenticate = hss.authenticate
+
+	_, err := New(Config{})
+	assert.Error(t, err)
+	p, err := New(Config{UserName: "001010000000999", Timeout: 20 * time.Millisecond, FailureThreshold: 2})
+	assert.NoError(t, err)
+	assert.True(t, p.Reachable())
+
+	p.Probe()
+	assert.True(t, p.Reachable())
+	assert.Equal(t, "001010000000999", hss.lastReq.GetUserName())
+
+	// Diameter errors are HSS answers
+	hss.set(status.Errorf(codes.Code(5001), "Diameter Error: 5001 (USER_UNKNOWN)"), nil)
+	p.Probe()
+	assert.True(t, p.Reachable())
+
+	// transport failures mark the HSS unreachable after the failure threshold
+	hss.set(status.Errorf(codes.DataLoss, "no diameter connection"), nil)
+	p.Probe()
+	assert.True(t, p.Reachable())
+	p.Probe()
+	assert.False(t, p.Reachable())
+	health, err := p.GetHealthStatus(context.Background(), &orcprotos.Void{})
+	assert.NoError(t, err)
+	assert.Equal(t, fegprotos.HealthStatus_UNHEALTHY, health.GetHealth())
+	assert.Contains(t, health.GetHealthMessage(), "no diameter connection")
+
+	// a single answered probe restores reachability
+	hss.set(nil, nil)
+	p.Probe()
+	assert.True(t, p.Reachable())
+	health, err = p.GetHealthStatus(context.Background(), &orcprotos.Void{})
+	assert.NoError(t, err)
+	assert.Equal(t, fegprotos.HealthStatus_HEALTHY, health.GetHealth())
+
+	// blocked probes time out & no new probe is sent while they are pending
+	release := make(chan struct{})
+	hss.set(nil, release)
+	p.Probe()
+	p.Probe()
+	assert.False(t, p.Reachable())
+	hss.Lock()
+	assert.Equal(t, 6, hss.probes)
+	hss.Unlock()
+	close(release)
+
+	var disabled *Prober
+	assert.True(t, disabled.Reachable())
+}
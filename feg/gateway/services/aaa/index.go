@@ -0,0 +1,91 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package aaa
+
+import (
+	"net"
+	"strings"
+
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+// Standard session table indexes
+const (
+	IMSIIndex        = "imsi"
+	MACIndex         = "mac"
+	IPIndex          = "ip"
+	APNIndex         = "apn"
+	CorrelationIndex = "correlation"
+)
+
+// IndexKey returns the session context's key in an index, sessions with empty keys are not indexed
+type IndexKey func(pc *protos.Context) string
+
+// StandardIndexes - keys of the standard session table indexes by their names
+var StandardIndexes = map[string]IndexKey{
+	IMSIIndex:        func(pc *protos.Context) string { return pc.GetImsi() },
+	MACIndex:         func(pc *protos.Context) string { return MACKey(pc.GetMacAddr()) },
+	IPIndex:          func(pc *protos.Context) string { return strings.TrimSpace(pc.GetIpAddr()) },
+	APNIndex:         func(pc *protos.Context) string { return strings.ToLower(pc.GetApn()) },
+	CorrelationIndex: func(pc *protos.Context) string { return pc.GetCorrelationId() },
+}
+
+// MACKey returns the MAC index key of the MAC address in any notation, empty string for invalid addresses
+func MACKey(mac string) string {
+	hw, err := net.ParseMAC(strings.TrimSpace(mac))
+	if err != nil {
+		return ""
+	}
+	return hw.String()
+}
+
+// SessionIndexer is implemented by session tables maintaining secondary indexes of their sessions, the indexes are
+// maintained as sessions are added, removed & their contexts are changed
+type SessionIndexer interface {
+	// AddIndex registers the named index of the given key, the table's sessions are indexed right away
+	AddIndex(name string, key IndexKey) error
+	// LookupIndex returns the sessions of the key in the named index, ok is false if the index isn't registered
+	LookupIndex(name, key string) (sessions []Session, ok bool)
+}
+
+// AddStandardIndexes registers the standard indexes of the session table if it maintains indexes
+func AddStandardIndexes(st SessionTable) error {
+	indexer, ok := st.(SessionIndexer)
+	if !ok {
+		return nil
+	}
+	for name, key := range StandardIndexes {
+		if err := indexer.AddIndex(name, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FindSessions returns the sessions of the key in the named index, sessions of tables without the index are
+// scanned for the key of a standard index, nil is returned if the table can neither look up nor list its sessions
+func FindSessions(st SessionTable, name, key string) []Session {
+	if indexer, ok := st.(SessionIndexer); ok {
+		if sessions, ok := indexer.LookupIndex(name, key); ok {
+			return sessions
+		}
+	}
+	indexKey, ok := StandardIndexes[name]
+	lister, listable := st.(SessionLister)
+	if !ok || !listable || len(key) == 0 {
+		return nil
+	}
+	var res []Session
+	for _, s := range lister.ListSessions() {
+		if indexKey(s.Session.GetCtx()) == key {
+			res = append(res, s.Session)
+		}
+	}
+	return res
+}
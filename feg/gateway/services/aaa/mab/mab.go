@@ -0,0 +1,55 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package mab implements MAC Authentication Bypass (MAB) of non-EAP devices (e.g. IoT devices) by a provisioned
+// MAC allow-list
+package mab
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"golang.org/x/net/context"
+)
+
+// Realm of the devices' synthetic identities
+const Realm = "mab"
+
+// ErrNotAllowed is returned by allow-lists for devices not in the allow-list
+var ErrNotAllowed = errors.New("MAC address is not allowed")
+
+// Device - allow-listed device & the subscriber its sessions are created for
+type Device struct {
+	MAC  string
+	IMSI string
+	APN  string // APN of the device's sessions, empty - the APN requested by the NAS
+}
+
+// AllowList looks devices up by their MAC addresses
+type AllowList interface {
+	// Lookup returns the device of the normalized MAC address (see NormalizeMAC) or ErrNotAllowed if the device is
+	// not in the allow-list. Remote lookups are bounded by the ctx deadline
+	Lookup(ctx context.Context, mac string) (*Device, error)
+}
+
+// NormalizeMAC returns the MAC address in lower case & colon separated notation or an error if it isn't a 48 bit
+// MAC address
+func NormalizeMAC(mac string) (string, error) {
+	hw, err := net.ParseMAC(strings.TrimSpace(mac))
+	if err != nil || len(hw) != 6 {
+		return "", fmt.Errorf("invalid MAC address '%s'", mac)
+	}
+	return hw.String(), nil
+}
+
+// Identity returns the synthetic identity of the device's sessions, e.g. 0a1b2c3d4e5f@mab
+func Identity(mac string) string {
+	return strings.Replace(mac, ":", "", -1) + "@" + Realm
+}
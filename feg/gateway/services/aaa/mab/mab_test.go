@@ -0,0 +1,28 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package mab
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeMAC(t *testing.T) {
+	for _, mac := range []string{"0a:1b:2c:3d:4e:5f", "0A-1B-2C-3D-4E-5F", " 0a1b.2c3d.4e5f "} {
+		normalized, err := NormalizeMAC(mac)
+		assert.NoError(t, err)
+		assert.Equal(t, "0a:1b:2c:3d:4e:5f", normalized)
+	}
+	for _, mac := range []string{"", "0a:1b:2c:3d:4e", "0a:1b:2c:3d:4e:5f:60:71"} {
+		_, err := NormalizeMAC(mac)
+		assert.Error(t, err)
+	}
+	assert.Equal(t, "0a1b2c3d4e5f@mab", Identity("0a:1b:2c:3d:4e:5f"))
+}
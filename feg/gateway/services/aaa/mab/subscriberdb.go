@@ -0,0 +1,48 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package mab
+
+import (
+	"strings"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"magma/feg/gateway/registry"
+	"magma/feg/gateway/services/aaa/slo"
+	lteprotos "magma/lte/cloud/go/protos"
+)
+
+const imsiPrefix = "IMSI"
+
+// SubscriberDB is the MAC allow-list provisioned in subscriberdb
+type SubscriberDB struct{}
+
+// Lookup implements AllowList
+func (SubscriberDB) Lookup(ctx context.Context, mac string) (*Device, error) {
+	conn, err := registry.GetConnection(registry.SUBSCRIBERDB)
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	entry, err := lteprotos.NewSubscriberDBClient(conn).GetMACAllowListEntry(ctx, &lteprotos.MACLookup{MacAddr: mac})
+	slo.Observe(slo.SubscriberDB, "GetMACAllowListEntry", start, err)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, ErrNotAllowed
+		}
+		return nil, err
+	}
+	if len(entry.GetSid().GetId()) == 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "MAC allow-list entry of %s has no subscriber", mac)
+	}
+	return &Device{MAC: mac, IMSI: strings.TrimPrefix(entry.GetSid().GetId(), imsiPrefix), APN: entry.GetApn()}, nil
+}
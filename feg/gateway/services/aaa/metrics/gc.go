@@ -0,0 +1,106 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+const imsiLabel = "imsi"
+
+// labeledVec is implemented by both CounterVec & GaugeVec
+type labeledVec interface {
+	prometheus.Collector
+	Delete(labels prometheus.Labels) bool
+}
+
+// imsiVecs lists all metrics partitioned by IMSI
+var imsiVecs = []labeledVec{
+	SessionTimeouts, SessionStart, SessionStop, OctetsIn, OctetsOut, AcctStop, SessionTerminate, UsageAnomalies}
+
+// inactiveIMSIs keeps the time each IMSI without an active session was first seen by PruneIMSILabels
+var inactiveIMSIs = struct {
+	sync.Mutex
+	since map[string]time.Time
+}{since: map[string]time.Time{}}
+
+// PruneIMSILabels removes all label values of IMSIs which have had no active session (isActive returns false) for
+// at least the retention period, as seen by successive PruneIMSILabels calls. It returns the number of removed
+// metric label value sets. Without pruning, per IMSI metrics' cardinality grows without bound with subscribers churn
+func PruneIMSILabels(isActive func(imsi string) bool, retention time.Duration) int {
+	now := time.Now()
+	inactiveIMSIs.Lock()
+	defer inactiveIMSIs.Unlock()
+
+	seen := map[string]bool{}
+	var pruned int
+	for _, vec := range imsiVecs {
+		for _, labels := range collectLabels(vec) {
+			imsi, ok := labels[imsiLabel]
+			if !ok {
+				continue
+			}
+			stale, checked := seen[imsi]
+			if !checked {
+				stale = isStale(imsi, isActive, retention, now)
+				seen[imsi] = stale
+			}
+			if stale && vec.Delete(labels) {
+				pruned++
+			}
+		}
+	}
+	// Forget IMSIs no longer present in any metric
+	for imsi := range inactiveIMSIs.since {
+		if stale, ok := seen[imsi]; !ok || stale {
+			delete(inactiveIMSIs.since, imsi)
+		}
+	}
+	return pruned
+}
+
+// isStale must be called with inactiveIMSIs locked
+func isStale(imsi string, isActive func(imsi string) bool, retention time.Duration, now time.Time) bool {
+	if isActive(imsi) {
+		delete(inactiveIMSIs.since, imsi)
+		return false
+	}
+	since, ok := inactiveIMSIs.since[imsi]
+	if !ok {
+		inactiveIMSIs.since[imsi] = now
+		since = now
+	}
+	return now.Sub(since) >= retention
+}
+
+// collectLabels returns label sets of all vec's children
+func collectLabels(vec prometheus.Collector) []prometheus.Labels {
+	ch := make(chan prometheus.Metric)
+	go func() {
+		vec.Collect(ch)
+		close(ch)
+	}()
+	var res []prometheus.Labels
+	for m := range ch {
+		pb := &dto.Metric{}
+		if m.Write(pb) != nil {
+			continue
+		}
+		labels := make(prometheus.Labels, len(pb.GetLabel()))
+		for _, pair := range pb.GetLabel() {
+			labels[pair.GetName()] = pair.GetValue()
+		}
+		res = append(res, labels)
+	}
+	return res
+}
@@ -8,7 +8,13 @@ LICENSE file in the root directory of this source tree.
 
 package metrics
 
-import "github.com/prometheus/client_golang/prometheus"
+import (
+	"fbc/lib/go/rolling"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"magma/feg/gateway/services/aaa/persisted"
+)
 
 // Prometheus counters are monotonically increasing
 // Counters reset to zero on service restart
@@ -40,6 +46,46 @@ var (
 		[]string{"apn", "imsi"},
 	)
 
+	SessionEvictions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "session_evictions",
+			Help: "Sessions evicted from a full session table, partitioned by APN, preemption policy",
+		},
+		[]string{"apn", "policy"},
+	)
+
+	SessionRejects = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "session_rejects",
+			Help: "New sessions rejected by a full session table, partitioned by APN, preemption policy",
+		},
+		[]string{"apn", "policy"},
+	)
+
+	TrafficChecks = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "traffic_checks",
+			Help: "Subscriber traffic inactivity checks, partitioned by result: active (idle timeout restarted), idle, error",
+		},
+		[]string{"result"},
+	)
+
+	CapacityRejects = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "session_capacity_rejects",
+			Help: "New sessions rejected by their APN's concurrent sessions limit, partitioned by APN",
+		},
+		[]string{"apn"},
+	)
+
+	DuplicateIMSIs = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "duplicate_imsi_sessions",
+			Help: "New sessions of IMSIs with a session from a different MAC or AP, partitioned by outcome: permit, roam, clone",
+		},
+		[]string{"outcome"},
+	)
+
 	SessionStart = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "session_start",
@@ -94,10 +140,776 @@ var (
 		},
 		[]string{"apn", "imsi"},
 	)
+
+	// Usage anomalies
+	UsageAnomalies = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "usage_anomalies",
+			Help: "Detected uplink/downlink usage anomalies, partitioned by anomaly type, APN, IMSI",
+		},
+		[]string{"type", "apn", "imsi"},
+	)
+
+	// APN authorization rejections
+	APNAuthRejects = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "apn_auth_rejects",
+			Help: "Sessions rejected by APN authorization, partitioned by APN, rejection reason",
+		},
+		[]string{"apn", "reason"},
+	)
+
+	// Time of day policies
+	TimePolicyActions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "time_policy_actions",
+			Help: "Time of day policy actions applied to sessions, partitioned by APN, policy window, action",
+		},
+		[]string{"apn", "window", "action"},
+	)
+
+	// Recovered panics
+	Panics = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "recovered_panics",
+			Help: "Panics recovered in GRPC handlers & background goroutines, partitioned by location",
+		},
+		[]string{"location"},
+	)
+
+	// Calls without deadlines
+	CallsWithoutDeadline = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "calls_without_deadline",
+			Help: "GRPC calls made without a deadline, partitioned by method, direction (inbound/outbound)",
+		},
+		[]string{"method", "direction"},
+	)
+
+	// Session events export
+	ExportedEvents = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "exported_events",
+			Help: "Session events exported to cloud destinations, partitioned by destination, result",
+		},
+		[]string{"destination", "result"},
+	)
+
+	// Auth vectors prefetch
+	PrefetchRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "auth_vectors_prefetch",
+			Help: "Auth vectors prefetch requests of recently active subscribers, partitioned by result",
+		},
+		[]string{"result"},
+	)
+
+	// Out of order accounting Stop/Start of subscribers' consecutive sessions
+	AcctReorders = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "accounting_reorders",
+			Help: "Out of order accounting Stop/Start of consecutive sessions, partitioned by event: detected, corrected",
+		},
+		[]string{"event"},
+	)
+
+	// Policy hook decisions on new sessions
+	PolicyDecisions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "policy_decisions",
+			Help: "Policy endpoint decisions on new sessions, partitioned by event, outcome",
+		},
+		[]string{"event", "outcome"},
+	)
+
+	AcctShed = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "accounting_shed",
+			Help: "Accounting calls shed under overload, partitioned by method, reason: queue_full, expired",
+		},
+		[]string{"method", "reason"},
+	)
+
+	// ScheduledCalls counts the authentication & accounting calls of the fair scheduling
+	ScheduledCalls = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "scheduled_calls",
+			Help: "Fairly scheduled calls, partitioned by workload: auth, accounting & result: admitted, delayed, " +
+				"rejected, expired",
+		},
+		[]string{"workload", "result"},
+	)
+
+	// LTE to Wi-Fi handovers
+	Handovers = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "lte_wifi_handovers",
+			Help: "LTE to Wi-Fi handovers, partitioned by result: signaled, reused, expired",
+		},
+		[]string{"result"},
+	)
+
+	// SessionAttributes counts session attributes over the size limits
+	SessionAttributes = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "session_attributes_limited",
+			Help: "Session attributes over the size limits, partitioned by action: dropped, spilled, spill_error",
+		},
+		[]string{"action"},
+	)
+
+	// VendorErrors counts failed auth & accounting calls by AP vendor
+	VendorErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ap_vendor_errors",
+			Help: "Failed auth & accounting calls, partitioned by AP vendor, method & code " +
+				"(GRPC status code or EAP Failure)",
+		},
+		[]string{"vendor", "method", "code"},
+	)
+
+	// DependencyLatency - latencies of downstream dependency calls
+	DependencyLatency = prometheus.NewSummaryVec(
+		prometheus.SummaryOpts{
+			Name:       "dependency_latency",
+			Help:       "Latency of downstream dependency calls (seconds), partitioned by dependency, method",
+			Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+		},
+		[]string{"dependency", "method"},
+	)
+
+	// DependencySLOBreaches counts downstream dependency calls over their dependency's latency budget
+	DependencySLOBreaches = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "dependency_slo_breaches",
+			Help: "Downstream dependency calls over the dependency's latency budget, partitioned by dependency, method",
+		},
+		[]string{"dependency", "method"},
+	)
+
+	// DeviceHints counts device classification hints
+	DeviceHints = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "device_hints",
+			Help: "Device classification hints, partitioned by source, result: applied, pending, dropped",
+		},
+		[]string{"source", "result"},
+	)
+
+	// Quarantines counts security triggers' quarantine & disconnect actions
+	Quarantines = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "security_quarantines",
+			Help: "Security triggers' actions, partitioned by trigger, result: quarantined, disconnected, failed",
+		},
+		[]string{"trigger", "result"},
+	)
+
+	// FailureModeSessions counts sessions created in session manager's Gx/Gy failure modes
+	FailureModeSessions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "failure_mode_sessions",
+			Help: "Sessions created in Gx/Gy failure modes, partitioned by modes, action: allow, flag, restrict, deny",
+		},
+		[]string{"modes", "action"},
+	)
+
+	// UsageReports counts Interim-Update usage reports to session manager
+	UsageReports = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "usage_reports",
+			Help: "Interim-Update usage reports to session manager, partitioned by result: success, failure, unsupported",
+		},
+		[]string{"result"},
+	)
+
+	// FlushedSessions counts sessions flushed by their NAS's Accounting-On/Off
+	FlushedSessions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "flushed_sessions",
+			Help: "Sessions flushed by their NAS's Accounting-On or Accounting-Off, partitioned by status: on, off",
+		},
+		[]string{"status"},
+	)
+
+	// DirectoryUpdates counts the subscribers' directory record publishes
+	DirectoryUpdates = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "directory_updates",
+			Help: "Directory record publishes, partitioned by operation: update, delete & result: success, failure",
+		},
+		[]string{"op", "result"},
+	)
+
+	// SubscriberSessions is the number of simultaneous sessions of subscribers with aggregated usage
+	SubscriberSessions = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "subscriber_sessions",
+			Help: "Number of the subscriber's simultaneous sessions, partitioned by IMSI",
+		},
+		[]string{"imsi"},
+	)
+
+	// SubscriberOctetsIn & SubscriberOctetsOut are the combined usages of subscribers' simultaneous sessions
+	SubscriberOctetsIn = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "subscriber_octets_in",
+			Help: "Combined Acct-Input-Octets of the subscriber's simultaneous sessions, partitioned by IMSI",
+		},
+		[]string{"imsi"},
+	)
+	SubscriberOctetsOut = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "subscriber_octets_out",
+			Help: "Combined Acct-Output-Octets of the subscriber's simultaneous sessions, partitioned by IMSI",
+		},
+		[]string{"imsi"},
+	)
+
+	// SubscriberQuotaChecks counts quota checks of subscribers' combined usage
+	SubscriberQuotaChecks = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "subscriber_quota_checks",
+			Help: "Quota checks of subscribers' combined usage, partitioned by result: passed, exceeded",
+		},
+		[]string{"result"},
+	)
+
+	// GuestSessions counts time limited guest sessions' lifecycle events
+	GuestSessions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "guest_sessions",
+			Help: "Guest sessions' events, partitioned by APN, event: admitted, expired, expiry_failed",
+		},
+		[]string{"apn", "event"},
+	)
+
+	// SessionLifetimeExpirations counts sessions terminated after their maximum duration
+	SessionLifetimeExpirations = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "session_lifetime_expirations",
+			Help: "Sessions terminated after their maximum duration, partitioned by APN, result: success, failure",
+		},
+		[]string{"apn", "result"},
+	)
+
+	// HSSProbes counts SWx health transactions of the HSS prober
+	HSSProbes = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "hss_probes",
+			Help: "HSS reachability probes, partitioned by result: reachable, unreachable",
+		},
+		[]string{"result"},
+	)
+
+	// HSSReachable is 1 while the HSS prober considers the HSS reachable
+	HSSReachable = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "hss_reachable",
+			Help: "HSS reachability as seen by the HSS prober: 1 - reachable, 0 - unreachable",
+		},
+	)
+
+	// CanarySessions counts the synthetic canary sessions run through the whole AAA path
+	CanarySessions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "canary_sessions",
+			Help: "Synthetic canary sessions, partitioned by canary, result: success, failure",
+		},
+		[]string{"canary", "result"},
+	)
+
+	// CanaryFailures counts the failed steps of the canary sessions
+	CanaryFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "canary_failures",
+			Help: "Failed canary session steps, partitioned by canary, step: auth, start, interim, stop",
+		},
+		[]string{"canary", "step"},
+	)
+
+	// CanaryLatency - latency of the canary sessions' steps & whole sessions
+	CanaryLatency = prometheus.NewSummaryVec(
+		prometheus.SummaryOpts{
+			Name: "canary_latency",
+			Help: "Latency of canary session steps & whole sessions (seconds), partitioned by canary, " +
+				"step: auth, start, interim, stop, session",
+			Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+		},
+		[]string{"canary", "step"},
+	)
+
+	// CanaryUp is 1 while the canary's last session succeeded
+	CanaryUp = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "canary_up",
+			Help: "Canary's last session result: 1 - success, 0 - failure, partitioned by canary",
+		},
+		[]string{"canary"},
+	)
+
+	// AuthHSSOutages counts authentication failures while the HSS is unreachable
+	AuthHSSOutages = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "auth_hss_outage_failures",
+			Help: "Authentication failures attributed to HSS outages, not answered so NASes fail over",
+		},
+	)
+
+	// RetainedBytes - bytes of a data set's purgeable files kept by its retention policy
+	RetainedBytes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "retention_retained_bytes",
+			Help: "Bytes of a data set's purgeable files kept after the last purge, partitioned by data set",
+		},
+		[]string{"target"},
+	)
+
+	// PurgedFiles & PurgedBytes count files removed by retention policies
+	PurgedFiles = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "retention_purged_files",
+			Help: "Files removed by retention policies, partitioned by data set",
+		},
+		[]string{"target"},
+	)
+	PurgedBytes = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "retention_purged_bytes",
+			Help: "Bytes of files removed by retention policies, partitioned by data set",
+		},
+		[]string{"target"},
+	)
+
+	// ImplausibleUsage counts Interim-Updates of implausible usage jumps, typically of mistaken NAS octets units
+	ImplausibleUsage = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "implausible_usage",
+			Help: "Interim-Updates of implausible usage, partitioned by APN, reason: rate, packet_size",
+		},
+		[]string{"apn", "reason"},
+	)
+
+	// QuirkAdjustments counts requests adjusted to their NAS's interop quirks
+	QuirkAdjustments = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "nas_quirk_adjustments",
+			Help: "Requests adjusted to their NAS's interop quirks, partitioned by quirk",
+		},
+		[]string{"quirk"},
+	)
+
+	// AcctQueueItems counts Accounting Starts' & Stops' session manager calls queued while session manager is
+	// unavailable & their replays
+	AcctQueueItems = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "acct_queue_items",
+			Help: "Queued session manager calls of accounting requests, partitioned by operation & result " +
+				"(queued, full, replayed, dropped)",
+		},
+		[]string{"op", "result"},
+	)
+	AcctQueueLength = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "acct_queue_length",
+			Help: "Number of session manager calls of accounting requests waiting for replay",
+		},
+	)
+
+	// APSessions, APChurn & APThroughput - the APs' load of the last AP capacity report interval
+	APSessions = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ap_sessions",
+			Help: "Concurrent sessions of the AP at the end of the AP capacity report interval, partitioned by AP",
+		},
+		[]string{"ap"},
+	)
+	APChurn = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ap_session_churn",
+			Help: "Sessions started & ended per minute at the AP over the report interval, partitioned by AP",
+		},
+		[]string{"ap"},
+	)
+	APThroughput = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ap_throughput_bps",
+			Help: "Average throughput of the AP's sessions over the AP capacity report interval (bits per second), " +
+				"partitioned by AP, direction: in, out",
+		},
+		[]string{"ap", "direction"},
+	)
+
+	// APCapacityReports counts the AP capacity reports sent to the HTTP endpoint
+	APCapacityReports = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ap_capacity_reports",
+			Help: "AP capacity reports sent to the HTTP endpoint, partitioned by result: sent, failed",
+		},
+		[]string{"result"},
+	)
+
+	// TerminateRaces counts session manager's TerminateSession calls of sessions already removed by their NAS's Stop
+	TerminateRaces = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "session_terminate_races",
+			Help: "Terminate Session Calls of sessions already stopped by their NAS",
+		},
+	)
+
+	// DuplicateAcctRequests counts the NASes' retransmissions of processed accounting Starts & Stops
+	DuplicateAcctRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "accounting_duplicates",
+			Help: "Retransmitted accounting requests acknowledged as duplicates, partitioned by status: start, stop",
+		},
+		[]string{"status"},
+	)
+
+	// NASAddressChanges counts the sessions' accounting requests arriving from another NAS host
+	NASAddressChanges = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "nas_address_changes",
+			Help: "Accounting requests of sessions arriving from another NAS host, e.g. after controller failovers",
+		},
+	)
+
+	// SessionDuration - durations of the ended sessions, from their accounting Start
+	SessionDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "session_duration_seconds",
+			Help:    "Duration of sessions ended by Stop, timeout or termination (seconds), partitioned by APN",
+			Buckets: []float64{60, 300, 900, 1800, 3600, 2 * 3600, 4 * 3600, 8 * 3600, 12 * 3600, 24 * 3600},
+		},
+		[]string{"apn"},
+	)
+
+	// CreateSessionFailures & EndSessionFailures count the failed session manager calls of sessions' starts & ends, by
+	// their errors' code & class (transient, permanent, config or security)
+	CreateSessionFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "create_session_failures",
+			Help: "Failed session manager CreateSession calls, partitioned by GRPC status code & error class",
+		},
+		[]string{"code", "class"},
+	)
+	EndSessionFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "end_session_failures",
+			Help: "Failed session manager EndSession calls, partitioned by GRPC status code & error class",
+		},
+		[]string{"code", "class"},
+	)
+
+	// CreateSessionFailureActions counts the Accounting Starts whose session manager CreateSession failed
+	CreateSessionFailureActions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "create_session_failure_actions",
+			Help: "Failed CreateSessions of Accounting Starts, partitioned by the applied policy: reject, disconnect",
+		},
+		[]string{"policy"},
+	)
+
+	// PartnerSessions, PartnerOctetsIn & PartnerOctetsOut - wholesale partners' sessions & usage by Operator-Name
+	PartnerSessions = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "partner_sessions",
+			Help: "Active sessions of wholesale partners, partitioned by the partner of the sessions' Operator-Name",
+		},
+		[]string{"partner"},
+	)
+	PartnerOctetsIn = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "partner_octets_in",
+			Help: "Acct-Input-Octets of wholesale partners' sessions, partitioned by partner",
+		},
+		[]string{"partner"},
+	)
+	PartnerOctetsOut = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "partner_octets_out",
+			Help: "Acct-Output-Octets of wholesale partners' sessions, partitioned by partner",
+		},
+		[]string{"partner"},
+	)
+
+	// InjectedAcctFaults counts the simulated accounting faults of synthetic test subscribers' sessions
+	InjectedAcctFaults = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "injected_acct_faults",
+			Help: "Simulated accounting faults of synthetic test sessions, partitioned by fault",
+		},
+		[]string{"fault"},
+	)
+
+	// ProxiedAcct counts the accounting requests mirrored to upstream RADIUS accounting servers
+	ProxiedAcct = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "proxied_acct_requests",
+			Help: "Accounting requests mirrored to upstream accounting servers, partitioned by server, status, result",
+		},
+		[]string{"server", "status", "result"},
+	)
+
+	// ProxiedAcctRetransmits counts retransmissions of accounting requests unanswered by upstream servers
+	ProxiedAcctRetransmits = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "proxied_acct_retransmits",
+			Help: "Retransmissions of accounting requests unanswered by upstream accounting servers, partitioned by server",
+		},
+		[]string{"server"},
+	)
+
+	// CoATransactions counts the CoA & Disconnect-Requests sent to the sessions' NASes
+	CoATransactions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "coa_transactions",
+			Help: "CoA & Disconnect-Requests sent to NASes, partitioned by kind, trigger, result: ack, nak, error",
+		},
+		[]string{"kind", "trigger", "result"},
+	)
+
+	// ComponentHealth reports the health checks' results of the AAA server's components
+	ComponentHealth = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "component_health",
+			Help: "Health of the AAA server's components, partitioned by component: 1 - ok, 0 - failing",
+		},
+		[]string{"component"},
+	)
+
+	// CorrelatedAcct counts the accounting requests of unknown sessions resolved to sessions by correlation IDs
+	CorrelatedAcct = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "correlated_accounting",
+			Help: "Accounting of unknown sessions by correlation ID, by type, result: resolved, ambiguous, not_found",
+		},
+		[]string{"type", "result"},
+	)
+
+	// MetricsPushes counts the pushes of the AAA server's metrics to metricsd
+	MetricsPushes = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "metrics_pushes",
+			Help: "Pushes of the metrics to metricsd, partitioned by result: ok, error",
+		},
+		[]string{"result"},
+	)
+
+	// PendingDisconnects reports the failed Disconnect-Requests awaiting their NASes' reachability by NAS
+	PendingDisconnects = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "pending_disconnects",
+			Help: "Failed Disconnect-Requests retried once their NAS's accounting proves it reachable, by NAS",
+		},
+		[]string{"nas"},
+	)
+
+	// DisconnectRetries counts the retries of the pending Disconnect-Requests
+	DisconnectRetries = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "disconnect_retries",
+			Help: "Retries of pending Disconnect-Requests, partitioned by result: ack, nak, failed, dropped",
+		},
+		[]string{"result"},
+	)
+
+	// QuotaEnforcements counts the enforcements of session manager's decisions on sessions with exhausted quotas
+	QuotaEnforcements = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "quota_enforcements",
+			Help: "Enforcements of sessions' exhausted quotas, partitioned by action (disconnect, redirect) & result",
+		},
+		[]string{"action", "result"},
+	)
+
+	// EventTimestampSkew - Event-Timestamp skews of the NASes' accounting requests, broken NAS clocks skew constantly
+	EventTimestampSkew = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "accounting_event_timestamp_skew_seconds",
+			Help:    "Accounting requests' arrival less their Acct-Delay-Time & Event-Timestamp (seconds), partitioned by NAS",
+			Buckets: []float64{-3600, -300, -60, -10, -1, 1, 10, 60, 300, 3600},
+		},
+		[]string{"nas"},
+	)
+
+	// StaleAcctRequests counts the accounting requests with Event-Timestamps skewed beyond the maximum
+	StaleAcctRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "accounting_stale_requests",
+			Help: "Accounting requests with Event-Timestamps skewed beyond the maximum, partitioned by NAS & action",
+		},
+		[]string{"nas", "action"},
+	)
+
+	// LateAcctRequests counts the accounting requests of recently terminated sessions, acknowledged without errors
+	LateAcctRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "accounting_late_requests",
+			Help: "Accounting requests of recently terminated sessions, partitioned by status: interim, stop & by " +
+				"the sessions' termination reason",
+		},
+		[]string{"status", "reason"},
+	)
+
+	// ThrottledRequests counts the requests rejected by their MAC address' or IMSI's rate limit
+	ThrottledRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "throttled_requests",
+			Help: "Requests rejected by their rate limits, partitioned by method, rate limit key: mac, imsi",
+		},
+		[]string{"method", "key"},
+	)
+
+	// RadiusEndpointHealthy is 1 while the Radius server authorization endpoint passes its health checks
+	RadiusEndpointHealthy = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "radius_endpoint_healthy",
+			Help: "Radius server authorization endpoints' health: 1 - healthy, 0 - unhealthy, partitioned by endpoint",
+		},
+		[]string{"endpoint"},
+	)
+
+	// RadiusFailovers counts the Disconnect & CoA calls failed over from an unreachable Radius server endpoint
+	RadiusFailovers = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "radius_failovers",
+			Help: "Radius server authorization calls failed over to the next endpoint, partitioned by the failed endpoint",
+		},
+		[]string{"endpoint"},
+	)
+
+	// UsageRecords counts the sessions' usage records exported to flow collectors
+	UsageRecords = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "usage_records",
+			Help: "Session usage records exported to flow collectors, partitioned by collector, result",
+		},
+		[]string{"collector", "result"},
+	)
+
+	// EnrichmentCallouts counts the enrichments of exported events
+	EnrichmentCallouts = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "enrichment_callouts",
+			Help: "Enrichments of exported events, partitioned by outcome: enriched, cached, failed, bypassed",
+		},
+		[]string{"outcome"},
+	)
+
+	// ResyncedSessions counts the sessions of the startup session table resynchronizations with the session managers
+	ResyncedSessions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "session_resync_sessions",
+			Help: "Sessions of the startup session manager resynchronizations, partitioned by result: in_sync, " +
+				"reconciled - rebuilt from the session manager's, orphaned - ended in the session manager, " +
+				"unmanaged - unknown to the session manager",
+		},
+		[]string{"result"},
+	)
+
+	// DroppedSessionEvents counts the session lifecycle events dropped for SubscribeEvents clients falling behind
+	DroppedSessionEvents = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "session_events_dropped",
+			Help: "Session lifecycle events dropped for SubscribeEvents streams which fall behind",
+		},
+	)
+
+	// SessionManagerRetries counts the retries of failed session manager calls
+	SessionManagerRetries = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "session_manager_retries",
+			Help: "Retries of failed session manager calls, partitioned by method",
+		},
+		[]string{"method"},
+	)
+
+	// SessionManagerCircuit counts session manager circuit breakers' state changes & the calls they rejected
+	SessionManagerCircuit = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "session_manager_circuit",
+			Help: "Session manager circuit breaker events, partitioned by service, event: open, half_open, closed, " +
+				"rejected",
+		},
+		[]string{"service", "event"},
+	)
+
+	// EarlyAcctResponses counts Accounting-Responses sent before their backend calls completed & the calls' late
+	// results
+	EarlyAcctResponses = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "early_acct_responses",
+			Help: "Accounting-Responses sent on the response deadline, partitioned by method & result " +
+				"(responded, late_success, late_failure)",
+		},
+		[]string{"method", "result"},
+	)
+
+	// AuthOutcomes counts EAP authentication successes & failures over the rolling.DefaultWindows, unlike
+	// Prometheus rate() its success rates are available to on-gateway decision logic
+	AuthOutcomes = rolling.NewDefault()
+
+	// Persisted - restart-safe totals, unlike the Prometheus counters they don't reset on service restart once the
+	// set is loaded from its file
+	Persisted       = persisted.NewSet()
+	SessionsServed  = Persisted.Counter("sessions")
+	OctetsInServed  = Persisted.Counter("octets_in")
+	OctetsOutServed = Persisted.Counter("octets_out")
 )
 
+// persistedTotals returns counters exposing the Persisted totals
+func persistedTotals() []prometheus.Collector {
+	total := func(name, help string, c *persisted.Counter) prometheus.Collector {
+		return prometheus.NewCounterFunc(
+			prometheus.CounterOpts{Name: name, Help: help},
+			func() float64 { return float64(c.Value()) },
+		)
+	}
+	return []prometheus.Collector{
+		total("sessions_total_persisted", "Sessions served, persisted across service restarts", SessionsServed),
+		total("octets_in_total_persisted", "Inbound data usage, persisted across service restarts", OctetsInServed),
+		total("octets_out_total_persisted", "Outbound data usage, persisted across service restarts", OctetsOutServed),
+	}
+}
+
+// authSuccessRates returns gauges of the AuthOutcomes' success rates, one per rolling.DefaultWindows window
+func authSuccessRates() []prometheus.Collector {
+	gauges := make([]prometheus.Collector, 0, len(rolling.DefaultWindows))
+	for _, w := range rolling.DefaultWindows {
+		window := w
+		gauges = append(gauges, prometheus.NewGaugeFunc(
+			prometheus.GaugeOpts{
+				Name:        "eap_auth_success_rate",
+				Help:        "EAP authentication success rate over a sliding window, 1 if the window has no authentications",
+				ConstLabels: prometheus.Labels{"window": rolling.Label(window)},
+			},
+			func() float64 { return AuthOutcomes.Stats(window).SuccessRate() },
+		))
+	}
+	return gauges
+}
+
 func init() {
 	prometheus.MustRegister(Auth, Sessions, SessionStart,
 		SessionStop, CreateSessionLatency, OctetsIn, OctetsOut,
-		SessionTimeouts, AcctStop, SessionTerminate)
+		SessionTimeouts, AcctStop, SessionTerminate, UsageAnomalies, APNAuthRejects,
+		SessionEvictions, SessionRejects, TimePolicyActions, Panics, CallsWithoutDeadline,
+		ExportedEvents, PrefetchRequests, AcctReorders, PolicyDecisions,
+		Handovers, DuplicateIMSIs, AcctShed, CapacityRejects,
+		TrafficChecks, VendorErrors, SessionAttributes, DependencyLatency, DependencySLOBreaches,
+		DeviceHints, Quarantines, GuestSessions, SessionLifetimeExpirations, HSSProbes, HSSReachable, AuthHSSOutages,
+		RetainedBytes, PurgedFiles, PurgedBytes, QuirkAdjustments, ImplausibleUsage, AcctQueueItems, AcctQueueLength,
+		SessionManagerRetries, SessionManagerCircuit, EarlyAcctResponses, FailureModeSessions, UsageReports,
+		FlushedSessions, SubscriberSessions, SubscriberOctetsIn, SubscriberOctetsOut, SubscriberQuotaChecks,
+		DirectoryUpdates, CanarySessions, CanaryFailures, CanaryLatency, CanaryUp, APSessions, APChurn, APThroughput,
+		APCapacityReports, TerminateRaces, DroppedSessionEvents, DuplicateAcctRequests,
+		CreateSessionFailureActions, NASAddressChanges, SessionDuration, CreateSessionFailures, EndSessionFailures,
+		PartnerSessions, PartnerOctetsIn, PartnerOctetsOut, InjectedAcctFaults,
+		ProxiedAcct, ProxiedAcctRetransmits, CoATransactions, ComponentHealth, CorrelatedAcct,
+		MetricsPushes, PendingDisconnects, DisconnectRetries, QuotaEnforcements, EventTimestampSkew, StaleAcctRequests,
+		ResyncedSessions, LateAcctRequests, EnrichmentCallouts, ThrottledRequests, RadiusEndpointHealthy, RadiusFailovers,
+		UsageRecords, ScheduledCalls)
+	prometheus.MustRegister(authSuccessRates()...)
+	prometheus.MustRegister(persistedTotals()...)
 }
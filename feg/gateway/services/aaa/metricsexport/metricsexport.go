@@ -0,0 +1,260 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package metricsexport dual-writes the AAA server's metrics: it serves them on a local /metrics endpoint for on-box
+// debugging & pushes them to orchestrator's metricsd for cloud dashboards. Both sinks get the same samples, named &
+// labeled as the gateway's metricsd collector names & labels the samples it polls via service303, so the same queries
+// work on-box & in the cloud without separate instrumentation
+package metricsexport
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	dto "github.com/prometheus/client_model/go"
+
+	"magma/feg/gateway/registry"
+	"magma/feg/gateway/services/aaa/deadlines"
+	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/aaa/panics"
+	orc8r_protos "magma/orc8r/cloud/go/protos"
+)
+
+// DefaultPushInterval - default interval of the pushes to metricsd, metricsd's default collection interval
+const DefaultPushInterval = time.Minute
+
+// Labels added to the samples, as the gateway's metricsd collector & orchestrator's metricsd label them
+const (
+	ServiceLabel = "service"
+	GatewayLabel = "gatewayId"
+)
+
+// metricsdService - orchestrator's metricsd service name
+const metricsdService = "metricsd"
+
+// Config - metrics exporter configuration
+type Config struct {
+	Service string // the service label of the samples, e.g. aaa_server
+	// Addr - local /metrics endpoint listen address, e.g. 127.0.0.1:9105, "" - the endpoint is disabled
+	Addr string
+	// NetworkID & GatewayID - the network & gateway of the samples pushed to metricsd, the samples aren't pushed if
+	// the network ID is empty
+	NetworkID    string
+	GatewayID    string
+	PushInterval time.Duration       // pushes interval, 0 - DefaultPushInterval
+	Gatherer     prometheus.Gatherer // the exported metrics, nil - the default registry's metrics
+}
+
+// Exporter - dual-writer of the metrics to the local endpoint & metricsd
+type Exporter struct {
+	cfg  Config
+	done chan struct{}
+}
+
+// New returns a new Exporter of the configuration
+func New(cfg Config) (*Exporter, error) {
+	if len(cfg.Service) == 0 {
+		return nil, fmt.Errorf("missing service name")
+	}
+	if len(cfg.Addr) == 0 && len(cfg.NetworkID) == 0 {
+		return nil, fmt.Errorf("either the local endpoint address or the metricsd network ID is required")
+	}
+	if cfg.PushInterval <= 0 {
+		cfg.PushInterval = DefaultPushInterval
+	}
+	if cfg.Gatherer == nil {
+		cfg.Gatherer = prometheus.DefaultGatherer
+	}
+	return &Exporter{cfg: cfg, done: make(chan struct{})}, nil
+}
+
+// Gather implements prometheus.Gatherer, the gathered samples are labeled with the service label
+func (e *Exporter) Gather() ([]*dto.MetricFamily, error) {
+	families, err := e.cfg.Gatherer.Gather()
+	for _, family := range families {
+		for _, m := range family.GetMetric() {
+			m.Label = withLabel(m.GetLabel(), ServiceLabel, e.cfg.Service)
+		}
+	}
+	return families, err
+}
+
+// withLabel returns the label pairs sorted by name with the label added, unless it's already present
+func withLabel(labels []*dto.LabelPair, name, value string) []*dto.LabelPair {
+	i := sort.Search(len(labels), func(i int) bool { return labels[i].GetName() >= name })
+	if i < len(labels) && labels[i].GetName() == name {
+		return labels
+	}
+	res := make([]*dto.LabelPair, 0, len(labels)+1)
+	res = append(res, labels[:i]...)
+	res = append(res, &dto.LabelPair{Name: proto.String(name), Value: proto.String(value)})
+	return append(res, labels[i:]...)
+}
+
+// Start serves the local endpoint & starts periodic pushes to metricsd, as configured
+func (e *Exporter) Start() error {
+	if len(e.cfg.Addr) > 0 {
+		if err := e.serveHTTPEndpoint(); err != nil {
+			return err
+		}
+		log.Printf("Metrics endpoint on %s is enabled", e.cfg.Addr)
+	}
+	if len(e.cfg.NetworkID) > 0 {
+		go e.run()
+		log.Printf("Metrics are pushed to metricsd every %v", e.cfg.PushInterval)
+	}
+	return nil
+}
+
+// Stop stops periodic pushes
+func (e *Exporter) Stop() {
+	close(e.done)
+}
+
+// Handler returns the /metrics endpoint handler
+func (e *Exporter) Handler() http.Handler {
+	return promhttp.HandlerFor(e, promhttp.HandlerOpts{ErrorHandling: promhttp.ContinueOnError})
+}
+
+func (e *Exporter) serveHTTPEndpoint() error {
+	lis, err := net.Listen("tcp", e.cfg.Addr)
+	if err != nil {
+		return fmt.Errorf("metrics endpoint listen error: %v", err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", e.Handler())
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		log.Printf("Metrics endpoint error: %v", srv.Serve(lis))
+	}()
+	return nil
+}
+
+func (e *Exporter) run() {
+	ticker := time.NewTicker(e.cfg.PushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := e.Push(); err != nil {
+				log.Printf("Metrics push error: %v", err)
+			}
+		case <-e.done:
+			return
+		}
+	}
+}
+
+// getClient returns a metricsd client & its connection's closer, replaced by tests
+var getClient = func() (orc8r_protos.MetricsControllerClient, func(), error) {
+	conn, err := registry.NewCloudRegistry().GetCloudConnection(metricsdService)
+	if err != nil {
+		return nil, nil, err
+	}
+	return orc8r_protos.NewMetricsControllerClient(conn), func() { conn.Close() }, nil
+}
+
+// Push pushes the current samples to metricsd
+func (e *Exporter) Push() error {
+	defer panics.Recover("metrics_push")
+	families, err := e.Gather()
+	if err != nil {
+		log.Printf("Metrics gathering error: %v", err) // the gathered samples are pushed regardless
+	}
+	samples := Samples(families, time.Now())
+	if len(e.cfg.GatewayID) > 0 {
+		for _, s := range samples {
+			s.Labels = append(s.Labels, &orc8r_protos.LabelPair{Name: GatewayLabel, Value: e.cfg.GatewayID})
+		}
+	}
+	client, closeConn, err := getClient()
+	if err != nil {
+		metrics.MetricsPushes.WithLabelValues("error").Inc()
+		return fmt.Errorf("metricsd connection error: %v", err)
+	}
+	defer closeConn()
+	ctx, cancel := deadlines.Background()
+	defer cancel()
+	_, err = client.Push(ctx, &orc8r_protos.PushedMetricsContainer{NetworkId: e.cfg.NetworkID, Metrics: samples})
+	if err != nil {
+		metrics.MetricsPushes.WithLabelValues("error").Inc()
+		return err
+	}
+	metrics.MetricsPushes.WithLabelValues("ok").Inc()
+	return nil
+}
+
+// Samples flattens the metric families into the samples of the Prometheus text exposition format: summaries &
+// histograms are flattened into their quantiles' or buckets' samples, _sum & _count samples. Samples without
+// timestamps are timestamped with the given time
+func Samples(families []*dto.MetricFamily, now time.Time) []*orc8r_protos.PushedMetric {
+	nowMs := now.UnixNano() / int64(time.Millisecond)
+	var res []*orc8r_protos.PushedMetric
+	for _, family := range families {
+		name := family.GetName()
+		for _, m := range family.GetMetric() {
+			ts := m.GetTimestampMs()
+			if ts == 0 {
+				ts = nowMs
+			}
+			add := func(name string, value float64, extra ...string) {
+				s := &orc8r_protos.PushedMetric{MetricName: name, Value: value, TimestampMS: ts}
+				for _, l := range m.GetLabel() {
+					s.Labels = append(s.Labels, &orc8r_protos.LabelPair{Name: l.GetName(), Value: l.GetValue()})
+				}
+				if len(extra) == 2 {
+					s.Labels = append(s.Labels, &orc8r_protos.LabelPair{Name: extra[0], Value: extra[1]})
+				}
+				res = append(res, s)
+			}
+			switch family.GetType() {
+			case dto.MetricType_COUNTER:
+				add(name, m.GetCounter().GetValue())
+			case dto.MetricType_GAUGE:
+				add(name, m.GetGauge().GetValue())
+			case dto.MetricType_UNTYPED:
+				add(name, m.GetUntyped().GetValue())
+			case dto.MetricType_SUMMARY:
+				for _, q := range m.GetSummary().GetQuantile() {
+					add(name, q.GetValue(), "quantile", formatFloat(q.GetQuantile()))
+				}
+				add(name+"_sum", m.GetSummary().GetSampleSum())
+				add(name+"_count", float64(m.GetSummary().GetSampleCount()))
+			case dto.MetricType_HISTOGRAM:
+				inf := false
+				for _, b := range m.GetHistogram().GetBucket() {
+					inf = math.IsInf(b.GetUpperBound(), 1)
+					add(name+"_bucket", float64(b.GetCumulativeCount()), "le", formatFloat(b.GetUpperBound()))
+				}
+				if !inf {
+					add(name+"_bucket", float64(m.GetHistogram().GetSampleCount()), "le", "+Inf")
+				}
+				add(name+"_sum", m.GetHistogram().GetSampleSum())
+				add(name+"_count", float64(m.GetHistogram().GetSampleCount()))
+			}
+		}
+	}
+	return res
+}
+
+// formatFloat formats quantiles & bucket bounds as the text exposition format does
+func formatFloat(f float64) string {
+	if math.IsInf(f, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
@@ -0,0 +1,103 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package metricsexport
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+
+	orc8r_protos "magma/orc8r/cloud/go/protos"
+)
+
+type pushRecorder struct {
+	orc8r_protos.MetricsControllerClient
+	pushed *orc8r_protos.PushedMetricsContainer
+	err    error
+}
+
+func (r *pushRecorder) Push(
+	_ context.Context, in *orc8r_protos.PushedMetricsContainer, _ ...grpc.CallOption) (*orc8r_protos.Void, error) {
+
+	r.pushed = in
+	return &orc8r_protos.Void{}, r.err
+}
+
+func newTestExporter(t *testing.T) *Exporter {
+	reg := prometheus.NewRegistry()
+	sessions := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "sessions", Help: "sessions"}, []string{"apn"})
+	latency := prometheus.NewHistogram(
+		prometheus.HistogramOpts{Name: "latency_ms", Help: "latency", Buckets: []float64{10, 100}})
+	reg.MustRegister(sessions, latency)
+	sessions.WithLabelValues("internet").Set(3)
+	latency.Observe(5)
+	latency.Observe(50)
+
+	e, err := New(Config{Service: "aaa_server", Addr: "127.0.0.1:0", GatewayID: "gw1", Gatherer: reg})
+	assert.NoError(t, err)
+	return e
+}
+
+func TestExporter(t *testing.T) {
+	_, err := New(Config{Service: "aaa_server"})
+	assert.Error(t, err, "neither sink is configured")
+	e := newTestExporter(t)
+
+	// the local endpoint's samples are labeled as metricsd labels the polled samples
+	w := httptest.NewRecorder()
+	e.Handler().ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	assert.Contains(t, w.Body.String(), `sessions{apn="internet",service="aaa_server"} 3`)
+	assert.Contains(t, w.Body.String(), `latency_ms_bucket{service="aaa_server",le="10"} 1`)
+}
+
+func TestPush(t *testing.T) {
+	defer func(f func() (orc8r_protos.MetricsControllerClient, func(), error)) { getClient = f }(getClient)
+	recorder := &pushRecorder{}
+	getClient = func() (orc8r_protos.MetricsControllerClient, func(), error) { return recorder, func() {}, nil }
+	e := newTestExporter(t)
+	e.cfg.NetworkID = "network1"
+
+	assert.NoError(t, e.Push())
+	assert.Equal(t, "network1", recorder.pushed.GetNetworkId())
+	samples := map[string]float64{}
+	for _, s := range recorder.pushed.GetMetrics() {
+		labels := ""
+		for _, l := range s.GetLabels() {
+			labels += l.GetName() + "=" + l.GetValue() + ","
+		}
+		samples[s.GetMetricName()+"{"+labels+"}"] = s.GetValue()
+	}
+	// the pushed samples are named as the local endpoint's samples
+	assert.Equal(t, map[string]float64{
+		"sessions{apn=internet,service=aaa_server,gatewayId=gw1,}":     3,
+		"latency_ms_bucket{service=aaa_server,le=10,gatewayId=gw1,}":   1,
+		"latency_ms_bucket{service=aaa_server,le=100,gatewayId=gw1,}":  2,
+		"latency_ms_bucket{service=aaa_server,le=+Inf,gatewayId=gw1,}": 2,
+		"latency_ms_sum{service=aaa_server,gatewayId=gw1,}":            55,
+		"latency_ms_count{service=aaa_server,gatewayId=gw1,}":          2,
+	}, samples)
+
+	recorder.err = errors.New("unavailable")
+	assert.Error(t, e.Push())
+}
+
+func TestSamplesTimestamps(t *testing.T) {
+	now := time.Unix(1000, 0)
+	families, err := newTestExporter(t).Gather()
+	assert.NoError(t, err)
+	for _, s := range Samples(families, now) {
+		assert.Equal(t, int64(1000000), s.GetTimestampMS())
+	}
+}
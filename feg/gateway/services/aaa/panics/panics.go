@@ -0,0 +1,158 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package panics converts recovered panics of GRPC handlers & background goroutines into metrics, logs and
+// breadcrumbs. Breadcrumbs - the last N panics with their stacks, are persisted in a local file, so intermittent
+// crashes & crash loops in the field can be diagnosed after restarts
+package panics
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"magma/feg/gateway/services/aaa/metrics"
+)
+
+// DefaultMaxBreadcrumbs - default number of the last panics kept in the breadcrumbs file
+const DefaultMaxBreadcrumbs = 20
+
+// Breadcrumb - a recorded panic
+type Breadcrumb struct {
+	Time         time.Time `json:"time"`
+	Location     string    `json:"location"` // GRPC method or goroutine name
+	Value        string    `json:"value"`
+	Stack        string    `json:"stack"`
+	Pid          int       `json:"pid"`
+	ProcessStart time.Time `json:"process_start"`
+}
+
+var processStart = time.Now().Round(0)
+
+var breadcrumbs struct {
+	sync.Mutex
+	path   string
+	max    int
+	crumbs []Breadcrumb
+}
+
+// SetBreadcrumbsFile enables persisting of the last max panics into the given file, the file's existing
+// breadcrumbs are kept & the last one is logged
+func SetBreadcrumbsFile(path string, max int) error {
+	if max <= 0 {
+		max = DefaultMaxBreadcrumbs
+	}
+	crumbs, err := ReadBreadcrumbs(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if n := len(crumbs); n > 0 {
+		last := crumbs[n-1]
+		log.Printf("%d recorded panics in %s, the last one at %s in %s: %s",
+			n, path, last.Time.Format(time.RFC3339), last.Location, last.Value)
+	}
+	if len(crumbs) > max {
+		crumbs = crumbs[len(crumbs)-max:]
+	}
+	breadcrumbs.Lock()
+	breadcrumbs.path, breadcrumbs.max, breadcrumbs.crumbs = path, max, crumbs
+	breadcrumbs.Unlock()
+	return nil
+}
+
+// ReadBreadcrumbs returns breadcrumbs persisted in the given file, the oldest first
+func ReadBreadcrumbs(path string) ([]Breadcrumb, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var crumbs []Breadcrumb
+	if err = json.Unmarshal(b, &crumbs); err != nil {
+		return nil, fmt.Errorf("Invalid breadcrumbs file %s: %v", path, err)
+	}
+	return crumbs, nil
+}
+
+// Handle records the recovered panic value v of the given location: counts it, logs it with its stack & adds
+// its breadcrumb
+func Handle(location string, v interface{}) {
+	crumb := Breadcrumb{
+		Time:         time.Now().Round(0),
+		Location:     location,
+		Value:        fmt.Sprint(v),
+		Stack:        string(debug.Stack()),
+		Pid:          os.Getpid(),
+		ProcessStart: processStart,
+	}
+	metrics.Panics.WithLabelValues(location).Inc()
+	log.Printf("Recovered panic in %s: %s\n%s", location, crumb.Value, crumb.Stack)
+
+	breadcrumbs.Lock()
+	defer breadcrumbs.Unlock()
+	if len(breadcrumbs.path) == 0 {
+		return
+	}
+	breadcrumbs.crumbs = append(breadcrumbs.crumbs, crumb)
+	if len(breadcrumbs.crumbs) > breadcrumbs.max {
+		breadcrumbs.crumbs = breadcrumbs.crumbs[len(breadcrumbs.crumbs)-breadcrumbs.max:]
+	}
+	if err := writeFile(breadcrumbs.path, breadcrumbs.crumbs); err != nil {
+		log.Printf("Error writing panic breadcrumbs to %s: %v", breadcrumbs.path, err)
+	}
+}
+
+// Recover recovers & records a panic of the calling goroutine, it must be deferred directly:
+//
+//	go func() {
+//		defer panics.Recover("name")
+//		...
+//	}()
+func Recover(location string) {
+	if v := recover(); v != nil {
+		Handle(location, v)
+	}
+}
+
+// UnaryServerInterceptor recovers & records panics of GRPC handlers, the panicking RPC fails with Internal error
+func UnaryServerInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (resp interface{}, err error) {
+
+	defer func() {
+		if v := recover(); v != nil {
+			Handle(info.FullMethod, v)
+			resp, err = nil, status.Errorf(codes.Internal, "Internal error in %s", info.FullMethod)
+		}
+	}()
+	return handler(ctx, req)
+}
+
+// writeFile atomically replaces the breadcrumbs file
+func writeFile(path string, crumbs []Breadcrumb) error {
+	b, err := json.MarshalIndent(crumbs, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err = ioutil.WriteFile(tmp, b, 0640); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,76 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package panics_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"magma/feg/gateway/services/aaa/panics"
+)
+
+func TestBreadcrumbs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "panics_test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "breadcrumbs.json")
+
+	assert.NoError(t, panics.SetBreadcrumbsFile(path, 2))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer panics.Recover("test_goroutine")
+		panic("goroutine failure")
+	}()
+	<-done
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/aaa.protos.accounting/start"}
+	resp, err := panics.UnaryServerInterceptor(context.Background(), nil, info,
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			var m map[string]int
+			m["nil map"]++
+			return "unreachable", nil
+		})
+	assert.Nil(t, resp)
+	assert.Equal(t, codes.Internal, status.Code(err))
+
+	crumbs, err := panics.ReadBreadcrumbs(path)
+	assert.NoError(t, err)
+	assert.Len(t, crumbs, 2)
+	assert.Equal(t, "test_goroutine", crumbs[0].Location)
+	assert.Equal(t, "goroutine failure", crumbs[0].Value)
+	assert.True(t, strings.Contains(crumbs[0].Stack, "panics_test.TestBreadcrumbs"))
+	assert.Equal(t, info.FullMethod, crumbs[1].Location)
+	assert.Equal(t, os.Getpid(), crumbs[1].Pid)
+
+	// only the last max panics are kept, existing breadcrumbs survive a restart
+	panics.Handle("third", "third failure")
+	assert.NoError(t, panics.SetBreadcrumbsFile(path, 2))
+	crumbs, err = panics.ReadBreadcrumbs(path)
+	assert.NoError(t, err)
+	assert.Len(t, crumbs, 2)
+	assert.Equal(t, info.FullMethod, crumbs[0].Location)
+	assert.Equal(t, "third", crumbs[1].Location)
+
+	// handlers without panics are not affected
+	resp, err = panics.UnaryServerInterceptor(context.Background(), nil, info,
+		func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil })
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+}
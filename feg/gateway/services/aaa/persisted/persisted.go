@@ -0,0 +1,147 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package persisted implements restart-safe counters of long horizon totals (e.g. sessions served), their values
+// are periodically saved to a local file & restored from it when the AAA starts, so the totals don't reset on every
+// deploy
+package persisted
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultSaveInterval - default interval of saving the counters
+const DefaultSaveInterval = time.Minute
+
+// Counter - monotonic counter persisted by its Set
+type Counter struct {
+	value uint64
+}
+
+// Add adds delta to the counter
+func (c *Counter) Add(delta uint64) {
+	atomic.AddUint64(&c.value, delta)
+}
+
+// Inc increments the counter
+func (c *Counter) Inc() {
+	c.Add(1)
+}
+
+// Value returns the counter's value
+func (c *Counter) Value() uint64 {
+	return atomic.LoadUint64(&c.value)
+}
+
+// Set - named counters persisted in one file
+type Set struct {
+	mu       sync.Mutex
+	path     string
+	counters map[string]*Counter
+}
+
+// NewSet returns a new, not yet persisted, set of counters
+func NewSet() *Set {
+	return &Set{counters: map[string]*Counter{}}
+}
+
+// Counter returns the set's counter of the given name, a new counter is added to the set if it has none
+func (s *Set) Counter(name string) *Counter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.counters[name]
+	if !ok {
+		c = &Counter{}
+		s.counters[name] = c
+	}
+	return c
+}
+
+// Values returns the counters' values by name
+func (s *Set) Values() map[string]uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	values := make(map[string]uint64, len(s.counters))
+	for name, c := range s.counters {
+		values[name] = c.Value()
+	}
+	return values
+}
+
+// Load restores the counters from the given file & persists them into it from then on. Restored values are added
+// to the counts made before the set is loaded, a missing file restores nothing
+func (s *Set) Load(path string) error {
+	b, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	values := map[string]uint64{}
+	if len(b) > 0 {
+		if err = json.Unmarshal(b, &values); err != nil {
+			return fmt.Errorf("Invalid persisted counters file %s: %v", path, err)
+		}
+	}
+	for name, v := range values {
+		s.Counter(name).Add(v)
+	}
+	s.mu.Lock()
+	s.path = path
+	s.mu.Unlock()
+	return nil
+}
+
+// Save writes the counters into the set's file, it's a no-op if the set isn't loaded
+func (s *Set) Save() error {
+	s.mu.Lock()
+	path := s.path
+	s.mu.Unlock()
+	if len(path) == 0 {
+		return nil
+	}
+	data, err := json.MarshalIndent(s.Values(), "", "  ")
+	if err != nil {
+		return err
+	}
+	// write & rename, so a crash while saving doesn't lose the previously saved values
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return fmt.Errorf("Error creating persisted counters temp file: %v", err)
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("Error writing persisted counters file %s: %v", path, err)
+	}
+	return nil
+}
+
+// Run saves the counters every interval, it never returns
+func (s *Set) Run(interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultSaveInterval
+	}
+	for range time.Tick(interval) {
+		if err := s.Save(); err != nil {
+			log.Print(err)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package persisted
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestartSafeCounters(t *testing.T) {
+	dir, err := ioutil.TempDir("", "persisted_counters")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "counters.json")
+
+	// the first process starts without a counters file
+	set := NewSet()
+	sessions := set.Counter("sessions")
+	assert.True(t, sessions == set.Counter("sessions"))
+	assert.NoError(t, set.Save()) // not loaded, nothing is saved
+	require.NoError(t, set.Load(path))
+	sessions.Inc()
+	sessions.Inc()
+	set.Counter("octets_in").Add(1000)
+	require.NoError(t, set.Save())
+
+	// the next process counts before its counters are restored, restored values are added
+	set = NewSet()
+	set.Counter("sessions").Inc()
+	require.NoError(t, set.Load(path))
+	assert.Equal(t, map[string]uint64{"sessions": 3, "octets_in": 1000}, set.Values())
+	set.Counter("octets_in").Add(24)
+	require.NoError(t, set.Save())
+
+	set = NewSet()
+	require.NoError(t, set.Load(path))
+	assert.Equal(t, uint64(1024), set.Counter("octets_in").Value())
+
+	files, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, files, 1) // no leftover temp files
+}
+
+func TestLoadInvalidFile(t *testing.T) {
+	f, err := ioutil.TempFile("", "persisted_counters")
+	require.NoError(t, err)
+	defer os.Remove(f.Name())
+	_, err = f.WriteString("not json")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	set := NewSet()
+	assert.Error(t, set.Load(f.Name()))
+	assert.NoError(t, set.Save()) // the invalid file isn't overwritten
+	b, err := ioutil.ReadFile(f.Name())
+	require.NoError(t, err)
+	assert.Equal(t, "not json", string(b))
+}
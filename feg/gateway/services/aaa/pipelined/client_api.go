@@ -0,0 +1,46 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// pipelined package defines local pipelined client API used to detect sessions' traffic inactivity
+package pipelined
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"golang.org/x/net/context"
+
+	"magma/feg/gateway/registry"
+	"magma/feg/gateway/services/aaa/deadlines"
+	"magma/lte/cloud/go/protos"
+	orcprotos "magma/orc8r/cloud/go/protos"
+)
+
+// GetSubscriberTraffic returns the total traffic (bytes sent & received) of the subscribers' enforced flows by their
+// subscriber IDs (IMSI prefixed IMSIs). Pipelined keeps flow stats by subscriber, the totals are cumulative &
+// only their changes are meaningful
+func GetSubscriberTraffic(ctx context.Context) (map[string]uint64, error) {
+	conn, err := registry.GetConnection(registry.PIPELINED)
+	if err != nil {
+		errMsg := fmt.Sprintf("Pipelined client initialization error: %s", err)
+		log.Print(errMsg)
+		return nil, errors.New(errMsg)
+	}
+	ctx, cancel := deadlines.Check(ctx, "Pipelined.GetPolicyUsage", deadlines.Outbound)
+	defer cancel()
+	table, err := protos.NewPipelinedClient(conn).GetPolicyUsage(ctx, &orcprotos.Void{})
+	if err != nil {
+		return nil, err
+	}
+	traffic := map[string]uint64{}
+	for _, r := range table.GetRecords() {
+		traffic[r.GetSid()] += r.GetBytesTx() + r.GetBytesRx()
+	}
+	return traffic, nil
+}
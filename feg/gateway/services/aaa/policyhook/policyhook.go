@@ -0,0 +1,159 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package policyhook consults an operator provided policy endpoint (e.g. a fraud system) before new sessions are
+// accepted, the endpoint may veto the session or annotate it with context attributes
+package policyhook
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+
+	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+// Session events pending a policy decision
+const (
+	EventStart         = "start"
+	EventCreateSession = "create_session"
+)
+
+// Decision outcomes
+const (
+	OutcomeAllow        = "allow"
+	OutcomeVeto         = "veto"
+	OutcomeFailedOpen   = "failed_open"
+	OutcomeFailedClosed = "failed_closed"
+)
+
+// Endpoint decides on new sessions
+type Endpoint interface {
+	Decide(ctx context.Context, req *protos.PolicyDecisionRequest) (*protos.PolicyDecision, error)
+}
+
+// NewEndpoint returns the policy endpoint of the given URL: grpc://host:port for policy_hook gRPC service endpoints,
+// http(s)://... for HTTP endpoints accepting JSON encoded policy_decision_request POSTs
+func NewEndpoint(endpointURL string) (Endpoint, error) {
+	u, err := url.Parse(endpointURL)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid policy endpoint URL '%s': %v", endpointURL, err)
+	}
+	switch u.Scheme {
+	case "grpc":
+		conn, err := grpc.Dial(u.Host, grpc.WithInsecure())
+		if err != nil {
+			return nil, err
+		}
+		return grpcEndpoint{protos.NewPolicyHookClient(conn)}, nil
+	case "http", "https":
+		return httpEndpoint{url: endpointURL, client: &http.Client{}}, nil
+	default:
+		return nil, fmt.Errorf("Unsupported policy endpoint URL scheme '%s'", u.Scheme)
+	}
+}
+
+type grpcEndpoint struct {
+	client protos.PolicyHookClient
+}
+
+func (e grpcEndpoint) Decide(ctx context.Context, req *protos.PolicyDecisionRequest) (*protos.PolicyDecision, error) {
+	return e.client.Decide(ctx, req)
+}
+
+type httpEndpoint struct {
+	url    string
+	client *http.Client
+}
+
+func (e httpEndpoint) Decide(ctx context.Context, req *protos.PolicyDecisionRequest) (*protos.PolicyDecision, error) {
+	var body bytes.Buffer
+	if err := (&jsonpb.Marshaler{OrigName: true}).Marshal(&body, req); err != nil {
+		return nil, err
+	}
+	httpReq, err := http.NewRequest(http.MethodPost, e.url, &body)
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	res, err := e.client.Do(httpReq.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Policy endpoint response status: %s", res.Status)
+	}
+	decision := &protos.PolicyDecision{}
+	if err = (&jsonpb.Unmarshaler{AllowUnknownFields: true}).Unmarshal(res.Body, decision); err != nil {
+		return nil, fmt.Errorf("Invalid policy decision: %v", err)
+	}
+	return decision, nil
+}
+
+// VetoError - a session rejected by the policy endpoint, or by the hook failing closed
+type VetoError struct {
+	SessionID string
+	Reason    string
+	Err       error
+}
+
+func (e *VetoError) Error() string {
+	msg := fmt.Sprintf("Session %s was vetoed by policy: %s", e.SessionID, e.Reason)
+	if e.Err != nil {
+		msg += fmt.Sprintf(" (%v)", e.Err)
+	}
+	return msg
+}
+
+// Hook consults the policy endpoint with strict timeouts, endpoint failures either allow (fail open) or reject
+// (fail closed) the session
+type Hook struct {
+	endpoint Endpoint
+	timeout  time.Duration
+	failOpen bool
+}
+
+// New returns a Hook of the endpoint, its decisions are bounded by the timeout
+func New(endpoint Endpoint, timeout time.Duration, failOpen bool) *Hook {
+	return &Hook{endpoint: endpoint, timeout: timeout, failOpen: failOpen}
+}
+
+// Decide returns the annotations of the allowed session or a *VetoError if the session is rejected
+func (h *Hook) Decide(ctx context.Context, event string, aaaCtx *protos.Context) (map[string]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+	// the session keys are never shared with the policy endpoint
+	reqCtx := proto.Clone(aaaCtx).(*protos.Context)
+	reqCtx.Msk = nil
+	decision, err := h.endpoint.Decide(ctx, &protos.PolicyDecisionRequest{Event: event, Ctx: reqCtx})
+	switch {
+	case err != nil && h.failOpen:
+		metrics.PolicyDecisions.WithLabelValues(event, OutcomeFailedOpen).Inc()
+		log.Printf("Policy decision on session %s failed, session is allowed: %v", aaaCtx.GetSessionId(), err)
+		return nil, nil
+	case err != nil:
+		metrics.PolicyDecisions.WithLabelValues(event, OutcomeFailedClosed).Inc()
+		return nil, &VetoError{SessionID: aaaCtx.GetSessionId(), Reason: "policy decision failed", Err: err}
+	case decision.GetVeto():
+		metrics.PolicyDecisions.WithLabelValues(event, OutcomeVeto).Inc()
+		return nil, &VetoError{SessionID: aaaCtx.GetSessionId(), Reason: decision.GetReason()}
+	default:
+		metrics.PolicyDecisions.WithLabelValues(event, OutcomeAllow).Inc()
+		return decision.GetAnnotations(), nil
+	}
+}
@@ -0,0 +1,111 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package policyhook
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+type endpointFunc func(ctx context.Context, req *protos.PolicyDecisionRequest) (*protos.PolicyDecision, error)
+
+func (f endpointFunc) Decide(ctx context.Context, req *protos.PolicyDecisionRequest) (*protos.PolicyDecision, error) {
+	return f(ctx, req)
+}
+
+func TestHookDecide(t *testing.T) {
+	aaaCtx := &protos.Context{SessionId: "sid1", Imsi: "123456789012345", Apn: "apn1", Msk: []byte("secret")}
+	decision := &protos.PolicyDecision{}
+	var endpointErr error
+	endpoint := endpointFunc(func(ctx context.Context, req *protos.PolicyDecisionRequest) (*protos.PolicyDecision, error) {
+		assert.Equal(t, EventStart, req.GetEvent())
+		assert.Equal(t, "sid1", req.GetCtx().GetSessionId())
+		assert.Empty(t, req.GetCtx().GetMsk())
+		_, ok := ctx.Deadline()
+		assert.True(t, ok)
+		return decision, endpointErr
+	})
+	hook := New(endpoint, time.Second, false)
+
+	annotations, err := hook.Decide(context.Background(), EventStart, aaaCtx)
+	assert.NoError(t, err)
+	assert.Empty(t, annotations)
+
+	decision.Annotations = map[string]string{"risk": "low"}
+	annotations, err = hook.Decide(context.Background(), EventStart, aaaCtx)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"risk": "low"}, annotations)
+
+	decision.Veto, decision.Reason = true, "fraud"
+	_, err = hook.Decide(context.Background(), EventStart, aaaCtx)
+	assert.Equal(t, &VetoError{SessionID: "sid1", Reason: "fraud"}, err)
+
+	endpointErr = errors.New("unreachable")
+	_, err = hook.Decide(context.Background(), EventStart, aaaCtx)
+	assert.IsType(t, &VetoError{}, err)
+
+	hook = New(endpoint, time.Second, true)
+	annotations, err = hook.Decide(context.Background(), EventStart, aaaCtx)
+	assert.NoError(t, err)
+	assert.Empty(t, annotations)
+}
+
+func TestHookTimeout(t *testing.T) {
+	endpoint := endpointFunc(func(ctx context.Context, req *protos.PolicyDecisionRequest) (*protos.PolicyDecision, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	start := time.Now()
+	_, err := New(endpoint, 10*time.Millisecond, false).Decide(
+		context.Background(), EventCreateSession, &protos.Context{SessionId: "sid1"})
+	assert.IsType(t, &VetoError{}, err)
+	assert.True(t, time.Since(start) < time.Second)
+}
+
+func TestHTTPEndpoint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Event string `json:"event"`
+			Ctx   struct {
+				SessionID string `json:"session_id"`
+			} `json:"ctx"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		if req.Event != EventStart {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`{"veto": true, "reason": "fraud", "annotations": {"session": "` + req.Ctx.SessionID + `"}}`))
+	}))
+	defer server.Close()
+
+	endpoint, err := NewEndpoint(server.URL)
+	assert.NoError(t, err)
+	decision, err := endpoint.Decide(context.Background(),
+		&protos.PolicyDecisionRequest{Event: EventStart, Ctx: &protos.Context{SessionId: "sid1"}})
+	assert.NoError(t, err)
+	assert.True(t, decision.GetVeto())
+	assert.Equal(t, "fraud", decision.GetReason())
+	assert.Equal(t, map[string]string{"session": "sid1"}, decision.GetAnnotations())
+
+	_, err = endpoint.Decide(context.Background(), &protos.PolicyDecisionRequest{Event: EventCreateSession})
+	assert.Error(t, err)
+
+	_, err = NewEndpoint("ftp://policy")
+	assert.Error(t, err)
+}
@@ -0,0 +1,120 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package prefetch keeps auth vectors of the most recently active subscribers cached by the SWx proxy, so EAP-AKA
+// authentications of returning subscribers don't wait for the HSS. Subscribers' activity is reported by accounting
+package prefetch
+
+import (
+	"container/list"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	swx_protos "magma/feg/cloud/go/protos"
+	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/swx_proxy"
+)
+
+const imsiPrefix = "IMSI"
+
+// Fetcher replenishes the cached auth vectors of the subscriber
+type Fetcher func(imsi string) error
+
+// SwxFetcher requests the SWx proxy to replenish the subscriber's cached vectors, if running low
+func SwxFetcher(imsi string) error {
+	_, err := swx_proxy.Authenticate(&swx_protos.AuthenticationRequest{
+		UserName:             imsi,
+		SipNumAuthVectors:    1,
+		AuthenticationScheme: swx_protos.AuthenticationScheme_EAP_AKA,
+		RetrieveUserProfile:  true, // as EAP-AKA authentications, so the cached vectors can be used by them
+		Prefetch:             true,
+	})
+	return err
+}
+
+type subscriber struct {
+	imsi     string
+	lastSeen time.Time
+}
+
+// Prefetcher tracks the most recently active subscribers & periodically prefetches their auth vectors
+type Prefetcher struct {
+	mu             sync.Mutex
+	maxSubscribers int
+	maxIdle        time.Duration
+	recent         *list.List // of *subscriber, most recently seen first
+	byImsi         map[string]*list.Element
+	fetch          Fetcher
+}
+
+// New returns a Prefetcher of up to maxSubscribers subscribers seen within the last maxIdle
+func New(maxSubscribers int, maxIdle time.Duration, fetch Fetcher) *Prefetcher {
+	return &Prefetcher{
+		maxSubscribers: maxSubscribers,
+		maxIdle:        maxIdle,
+		recent:         list.New(),
+		byImsi:         map[string]*list.Element{},
+		fetch:          fetch,
+	}
+}
+
+// Seen records the subscriber's activity, the least recently seen subscriber is forgotten when there are too many
+func (p *Prefetcher) Seen(imsi string) {
+	imsi = strings.TrimPrefix(imsi, imsiPrefix)
+	if len(imsi) == 0 {
+		return
+	}
+	now := time.Now()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if e, ok := p.byImsi[imsi]; ok {
+		e.Value.(*subscriber).lastSeen = now
+		p.recent.MoveToFront(e)
+		return
+	}
+	p.byImsi[imsi] = p.recent.PushFront(&subscriber{imsi: imsi, lastSeen: now})
+	for p.recent.Len() > p.maxSubscribers {
+		delete(p.byImsi, p.recent.Remove(p.recent.Back()).(*subscriber).imsi)
+	}
+}
+
+// Subscribers forgets the subscribers idle for longer than maxIdle & returns the rest, most recently seen first
+func (p *Prefetcher) Subscribers() []string {
+	stale := time.Now().Add(-p.maxIdle)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for e := p.recent.Back(); e != nil && e.Value.(*subscriber).lastSeen.Before(stale); e = p.recent.Back() {
+		delete(p.byImsi, p.recent.Remove(e).(*subscriber).imsi)
+	}
+	res := make([]string, 0, p.recent.Len())
+	for e := p.recent.Front(); e != nil; e = e.Next() {
+		res = append(res, e.Value.(*subscriber).imsi)
+	}
+	return res
+}
+
+// Prefetch prefetches auth vectors of all tracked subscribers, most recently seen first
+func (p *Prefetcher) Prefetch() {
+	for _, imsi := range p.Subscribers() {
+		if err := p.fetch(imsi); err != nil {
+			metrics.PrefetchRequests.WithLabelValues("failure").Inc()
+			log.Printf("Auth vectors prefetch for IMSI %s failed: %v", imsi, err)
+			continue
+		}
+		metrics.PrefetchRequests.WithLabelValues("success").Inc()
+	}
+}
+
+// Run prefetches auth vectors of the tracked subscribers every interval, it never returns
+func (p *Prefetcher) Run(interval time.Duration) {
+	for range time.Tick(interval) {
+		p.Prefetch()
+	}
+}
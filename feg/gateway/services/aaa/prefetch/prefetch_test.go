@@ -0,0 +1,48 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package prefetch
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrefetcher(t *testing.T) {
+	var fetched []string
+	p := New(2, time.Hour, func(imsi string) error {
+		fetched = append(fetched, imsi)
+		if imsi == "001010000000003" {
+			return fmt.Errorf("HSS error")
+		}
+		return nil
+	})
+	p.Seen("IMSI001010000000001")
+	p.Seen("001010000000002")
+	p.Seen("IMSI001010000000001")
+	p.Seen("")
+	assert.Equal(t, []string{"001010000000001", "001010000000002"}, p.Subscribers())
+
+	// the least recently seen subscriber is forgotten
+	p.Seen("IMSI001010000000003")
+	assert.Equal(t, []string{"001010000000003", "001010000000001"}, p.Subscribers())
+
+	// failures don't stop the prefetch of other subscribers
+	p.Prefetch()
+	assert.Equal(t, []string{"001010000000003", "001010000000001"}, fetched)
+
+	// idle subscribers are forgotten
+	p.maxIdle = time.Millisecond
+	time.Sleep(time.Millisecond * 5)
+	p.Seen("IMSI001010000000002")
+	assert.Equal(t, []string{"001010000000002"}, p.Subscribers())
+	assert.Len(t, p.byImsi, 1)
+}
@@ -99,11 +99,14 @@ func (StopRequestTerminateCause) EnumDescriptor() ([]byte, []int) {
 
 // update_request with usages & included context
 type UpdateRequest struct {
-	OctetsIn             uint32   `protobuf:"varint,1,opt,name=octets_in,json=octetsIn,proto3" json:"octets_in,omitempty"`
-	OctetsOut            uint32   `protobuf:"varint,2,opt,name=octets_out,json=octetsOut,proto3" json:"octets_out,omitempty"`
-	PacketsIn            uint32   `protobuf:"varint,3,opt,name=packets_in,json=packetsIn,proto3" json:"packets_in,omitempty"`
-	PacketsOut           uint32   `protobuf:"varint,4,opt,name=packets_out,json=packetsOut,proto3" json:"packets_out,omitempty"`
-	Ctx                  *Context `protobuf:"bytes,5,opt,name=ctx,proto3" json:"ctx,omitempty"`
+	OctetsIn   uint32   `protobuf:"varint,1,opt,name=octets_in,json=octetsIn,proto3" json:"octets_in,omitempty"`
+	OctetsOut  uint32   `protobuf:"varint,2,opt,name=octets_out,json=octetsOut,proto3" json:"octets_out,omitempty"`
+	PacketsIn  uint32   `protobuf:"varint,3,opt,name=packets_in,json=packetsIn,proto3" json:"packets_in,omitempty"`
+	PacketsOut uint32   `protobuf:"varint,4,opt,name=packets_out,json=packetsOut,proto3" json:"packets_out,omitempty"`
+	Ctx        *Context `protobuf:"bytes,5,opt,name=ctx,proto3" json:"ctx,omitempty"`
+	// Acct-Input/Output-Gigawords (RFC 2869), the number of times octets_in & octets_out wrapped around 2^32
+	GigawordsIn          uint32   `protobuf:"varint,6,opt,name=gigawords_in,json=gigawordsIn,proto3" json:"gigawords_in,omitempty"`
+	GigawordsOut         uint32   `protobuf:"varint,7,opt,name=gigawords_out,json=gigawordsOut,proto3" json:"gigawords_out,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -168,13 +171,35 @@ func (m *UpdateRequest) GetCtx() *Context {
 	return nil
 }
 
+func (m *UpdateRequest) GetGigawordsIn() uint32 {
+	if m != nil {
+		return m.GigawordsIn
+	}
+	return 0
+}
+
+func (m *UpdateRequest) GetGigawordsOut() uint32 {
+	if m != nil {
+		return m.GigawordsOut
+	}
+	return 0
+}
+
 // stop_request - ctx with termination cause: https://tools.ietf.org/html/rfc2866#page-20
 type StopRequest struct {
-	Cause                StopRequestTerminateCause `protobuf:"varint,1,opt,name=cause,proto3,enum=aaa.protos.StopRequestTerminateCause" json:"cause,omitempty"`
-	Ctx                  *Context                  `protobuf:"bytes,2,opt,name=ctx,proto3" json:"ctx,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
-	XXX_unrecognized     []byte                    `json:"-"`
-	XXX_sizecache        int32                     `json:"-"`
+	Cause StopRequestTerminateCause `protobuf:"varint,1,opt,name=cause,proto3,enum=aaa.protos.StopRequestTerminateCause" json:"cause,omitempty"`
+	Ctx   *Context                  `protobuf:"bytes,2,opt,name=ctx,proto3" json:"ctx,omitempty"`
+	// final usages, as Interim-Updates' usages, & duration (Acct-Session-Time, seconds) of the session
+	OctetsIn             uint32   `protobuf:"varint,3,opt,name=octets_in,json=octetsIn,proto3" json:"octets_in,omitempty"`
+	OctetsOut            uint32   `protobuf:"varint,4,opt,name=octets_out,json=octetsOut,proto3" json:"octets_out,omitempty"`
+	PacketsIn            uint32   `protobuf:"varint,5,opt,name=packets_in,json=packetsIn,proto3" json:"packets_in,omitempty"`
+	PacketsOut           uint32   `protobuf:"varint,6,opt,name=packets_out,json=packetsOut,proto3" json:"packets_out,omitempty"`
+	SessionTime          uint32   `protobuf:"varint,7,opt,name=session_time,json=sessionTime,proto3" json:"session_time,omitempty"`
+	GigawordsIn          uint32   `protobuf:"varint,8,opt,name=gigawords_in,json=gigawordsIn,proto3" json:"gigawords_in,omitempty"`
+	GigawordsOut         uint32   `protobuf:"varint,9,opt,name=gigawords_out,json=gigawordsOut,proto3" json:"gigawords_out,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *StopRequest) Reset()         { *m = StopRequest{} }
@@ -215,12 +240,65 @@ func (m *StopRequest) GetCtx() *Context {
 	return nil
 }
 
-// acct_resp message - RPC message definition for Accounting-Response (currently empty)
+func (m *StopRequest) GetOctetsIn() uint32 {
+	if m != nil {
+		return m.OctetsIn
+	}
+	return 0
+}
+
+func (m *StopRequest) GetOctetsOut() uint32 {
+	if m != nil {
+		return m.OctetsOut
+	}
+	return 0
+}
+
+func (m *StopRequest) GetPacketsIn() uint32 {
+	if m != nil {
+		return m.PacketsIn
+	}
+	return 0
+}
+
+func (m *StopRequest) GetPacketsOut() uint32 {
+	if m != nil {
+		return m.PacketsOut
+	}
+	return 0
+}
+
+func (m *StopRequest) GetSessionTime() uint32 {
+	if m != nil {
+		return m.SessionTime
+	}
+	return 0
+}
+
+func (m *StopRequest) GetGigawordsIn() uint32 {
+	if m != nil {
+		return m.GigawordsIn
+	}
+	return 0
+}
+
+func (m *StopRequest) GetGigawordsOut() uint32 {
+	if m != nil {
+		return m.GigawordsOut
+	}
+	return 0
+}
+
+// acct_resp message - RPC message definition for Accounting-Response attributes
 // see: https://tools.ietf.org/html/rfc2866#section-4.2
 type AcctResp struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	// acct_interim_interval - session's desired Acct-Interim-Interval in seconds, 0 - unchanged
+	AcctInterimInterval  uint32                     `protobuf:"varint,1,opt,name=acct_interim_interval,json=acctInterimInterval,proto3" json:"acct_interim_interval,omitempty"`
+	Attributes           []*AcctRespRadiusAttribute `protobuf:"bytes,2,rep,name=attributes,proto3" json:"attributes,omitempty"`
+	Detail               string                     `protobuf:"bytes,3,opt,name=detail,proto3" json:"detail,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
+	XXX_unrecognized     []byte                     `json:"-"`
+	XXX_sizecache        int32                      `json:"-"`
 }
 
 func (m *AcctResp) Reset()         { *m = AcctResp{} }
@@ -238,67 +316,1283 @@ func (m *AcctResp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 func (dst *AcctResp) XXX_Merge(src proto.Message) {
 	xxx_messageInfo_AcctResp.Merge(dst, src)
 }
-func (m *AcctResp) XXX_Size() int {
-	return xxx_messageInfo_AcctResp.Size(m)
+func (m *AcctResp) XXX_Size() int {
+	return xxx_messageInfo_AcctResp.Size(m)
+}
+func (m *AcctResp) XXX_DiscardUnknown() {
+	xxx_messageInfo_AcctResp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AcctResp proto.InternalMessageInfo
+
+func (m *AcctResp) GetAcctInterimInterval() uint32 {
+	if m != nil {
+		return m.AcctInterimInterval
+	}
+	return 0
+}
+
+func (m *AcctResp) GetAttributes() []*AcctRespRadiusAttribute {
+	if m != nil {
+		return m.Attributes
+	}
+	return nil
+}
+
+func (m *AcctResp) GetDetail() string {
+	if m != nil {
+		return m.Detail
+	}
+	return ""
+}
+
+// radius_attribute - an attribute to include in the Accounting-Response
+type AcctRespRadiusAttribute struct {
+	Type                 uint32   `protobuf:"varint,1,opt,name=type,proto3" json:"type,omitempty"`
+	VendorId             uint32   `protobuf:"varint,2,opt,name=vendor_id,json=vendorId,proto3" json:"vendor_id,omitempty"`
+	VendorType           uint32   `protobuf:"varint,3,opt,name=vendor_type,json=vendorType,proto3" json:"vendor_type,omitempty"`
+	Value                []byte   `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AcctRespRadiusAttribute) Reset()         { *m = AcctRespRadiusAttribute{} }
+func (m *AcctRespRadiusAttribute) String() string { return proto.CompactTextString(m) }
+func (*AcctRespRadiusAttribute) ProtoMessage()    {}
+func (*AcctRespRadiusAttribute) Descriptor() ([]byte, []int) {
+	return fileDescriptor_accounting_8f9562d18431439e, []int{2, 0}
+}
+func (m *AcctRespRadiusAttribute) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AcctRespRadiusAttribute.Unmarshal(m, b)
+}
+func (m *AcctRespRadiusAttribute) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AcctRespRadiusAttribute.Marshal(b, m, deterministic)
+}
+func (dst *AcctRespRadiusAttribute) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AcctRespRadiusAttribute.Merge(dst, src)
+}
+func (m *AcctRespRadiusAttribute) XXX_Size() int {
+	return xxx_messageInfo_AcctRespRadiusAttribute.Size(m)
+}
+func (m *AcctRespRadiusAttribute) XXX_DiscardUnknown() {
+	xxx_messageInfo_AcctRespRadiusAttribute.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AcctRespRadiusAttribute proto.InternalMessageInfo
+
+func (m *AcctRespRadiusAttribute) GetType() uint32 {
+	if m != nil {
+		return m.Type
+	}
+	return 0
+}
+
+func (m *AcctRespRadiusAttribute) GetVendorId() uint32 {
+	if m != nil {
+		return m.VendorId
+	}
+	return 0
+}
+
+func (m *AcctRespRadiusAttribute) GetVendorType() uint32 {
+	if m != nil {
+		return m.VendorType
+	}
+	return 0
+}
+
+func (m *AcctRespRadiusAttribute) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+type TerminateSessionRequest struct {
+	RadiusSessionId string `protobuf:"bytes,1,opt,name=radius_session_id,json=radiusSessionId,proto3" json:"radius_session_id,omitempty"`
+	Imsi            string `protobuf:"bytes,2,opt,name=imsi,proto3" json:"imsi,omitempty"`
+	// reason & optional reply_message are passed to the NAS, reply_message overrides the reason's default message
+	Reason               TerminateReason `protobuf:"varint,3,opt,name=reason,proto3,enum=aaa.protos.TerminateReason" json:"reason,omitempty"`
+	ReplyMessage         string          `protobuf:"bytes,4,opt,name=reply_message,json=replyMessage,proto3" json:"reply_message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *TerminateSessionRequest) Reset()         { *m = TerminateSessionRequest{} }
+func (m *TerminateSessionRequest) String() string { return proto.CompactTextString(m) }
+func (*TerminateSessionRequest) ProtoMessage()    {}
+func (*TerminateSessionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_accounting_8f9562d18431439e, []int{3}
+}
+func (m *TerminateSessionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TerminateSessionRequest.Unmarshal(m, b)
+}
+func (m *TerminateSessionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TerminateSessionRequest.Marshal(b, m, deterministic)
+}
+func (dst *TerminateSessionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TerminateSessionRequest.Merge(dst, src)
+}
+func (m *TerminateSessionRequest) XXX_Size() int {
+	return xxx_messageInfo_TerminateSessionRequest.Size(m)
+}
+func (m *TerminateSessionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TerminateSessionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TerminateSessionRequest proto.InternalMessageInfo
+
+func (m *TerminateSessionRequest) GetRadiusSessionId() string {
+	if m != nil {
+		return m.RadiusSessionId
+	}
+	return ""
+}
+
+func (m *TerminateSessionRequest) GetImsi() string {
+	if m != nil {
+		return m.Imsi
+	}
+	return ""
+}
+
+func (m *TerminateSessionRequest) GetReason() TerminateReason {
+	if m != nil {
+		return m.Reason
+	}
+	return TerminateReason_UNSPECIFIED_REASON
+}
+
+func (m *TerminateSessionRequest) GetReplyMessage() string {
+	if m != nil {
+		return m.ReplyMessage
+	}
+	return ""
+}
+
+// session_usage - subscriber's session usage as reported by session manager
+// octets_in is the uplink (received from UE) & octets_out is the downlink (sent to UE) usage
+type SessionUsage struct {
+	Imsi      string `protobuf:"bytes,1,opt,name=imsi,proto3" json:"imsi,omitempty"`
+	OctetsIn  uint64 `protobuf:"varint,2,opt,name=octets_in,json=octetsIn,proto3" json:"octets_in,omitempty"`
+	OctetsOut uint64 `protobuf:"varint,3,opt,name=octets_out,json=octetsOut,proto3" json:"octets_out,omitempty"`
+	// session_id - the session's RADIUS session ID, the subscriber's sessions are reconciled separately
+	SessionId            string   `protobuf:"bytes,4,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SessionUsage) Reset()         { *m = SessionUsage{} }
+func (m *SessionUsage) String() string { return proto.CompactTextString(m) }
+func (*SessionUsage) ProtoMessage()    {}
+func (*SessionUsage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_accounting_8f9562d18431439e, []int{4}
+}
+func (m *SessionUsage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SessionUsage.Unmarshal(m, b)
+}
+func (m *SessionUsage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SessionUsage.Marshal(b, m, deterministic)
+}
+func (dst *SessionUsage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SessionUsage.Merge(dst, src)
+}
+func (m *SessionUsage) XXX_Size() int {
+	return xxx_messageInfo_SessionUsage.Size(m)
+}
+func (m *SessionUsage) XXX_DiscardUnknown() {
+	xxx_messageInfo_SessionUsage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SessionUsage proto.InternalMessageInfo
+
+func (m *SessionUsage) GetImsi() string {
+	if m != nil {
+		return m.Imsi
+	}
+	return ""
+}
+
+func (m *SessionUsage) GetOctetsIn() uint64 {
+	if m != nil {
+		return m.OctetsIn
+	}
+	return 0
+}
+
+func (m *SessionUsage) GetOctetsOut() uint64 {
+	if m != nil {
+		return m.OctetsOut
+	}
+	return 0
+}
+
+func (m *SessionUsage) GetSessionId() string {
+	if m != nil {
+		return m.SessionId
+	}
+	return ""
+}
+
+// reconciliation_request - usages reported by session manager to reconcile with locally accumulated usages
+type ReconciliationRequest struct {
+	Reported []*SessionUsage `protobuf:"bytes,1,rep,name=reported,proto3" json:"reported,omitempty"`
+	// divergence threshold in percents, entries with larger divergence are marked as diverged
+	Threshold            float64  `protobuf:"fixed64,2,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReconciliationRequest) Reset()         { *m = ReconciliationRequest{} }
+func (m *ReconciliationRequest) String() string { return proto.CompactTextString(m) }
+func (*ReconciliationRequest) ProtoMessage()    {}
+func (*ReconciliationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_accounting_8f9562d18431439e, []int{5}
+}
+func (m *ReconciliationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReconciliationRequest.Unmarshal(m, b)
+}
+func (m *ReconciliationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReconciliationRequest.Marshal(b, m, deterministic)
+}
+func (dst *ReconciliationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReconciliationRequest.Merge(dst, src)
+}
+func (m *ReconciliationRequest) XXX_Size() int {
+	return xxx_messageInfo_ReconciliationRequest.Size(m)
+}
+func (m *ReconciliationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReconciliationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReconciliationRequest proto.InternalMessageInfo
+
+func (m *ReconciliationRequest) GetReported() []*SessionUsage {
+	if m != nil {
+		return m.Reported
+	}
+	return nil
+}
+
+func (m *ReconciliationRequest) GetThreshold() float64 {
+	if m != nil {
+		return m.Threshold
+	}
+	return 0
+}
+
+// reconciliation_entry - reconciliation result for a single session
+type ReconciliationEntry struct {
+	SessionId         string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Imsi              string `protobuf:"bytes,2,opt,name=imsi,proto3" json:"imsi,omitempty"`
+	LocalOctetsIn     uint64 `protobuf:"varint,3,opt,name=local_octets_in,json=localOctetsIn,proto3" json:"local_octets_in,omitempty"`
+	LocalOctetsOut    uint64 `protobuf:"varint,4,opt,name=local_octets_out,json=localOctetsOut,proto3" json:"local_octets_out,omitempty"`
+	ReportedOctetsIn  uint64 `protobuf:"varint,5,opt,name=reported_octets_in,json=reportedOctetsIn,proto3" json:"reported_octets_in,omitempty"`
+	ReportedOctetsOut uint64 `protobuf:"varint,6,opt,name=reported_octets_out,json=reportedOctetsOut,proto3" json:"reported_octets_out,omitempty"`
+	// divergence of the total usage in percents of the larger of local & reported totals
+	Divergence float64 `protobuf:"fixed64,7,opt,name=divergence,proto3" json:"divergence,omitempty"`
+	Diverged   bool    `protobuf:"varint,8,opt,name=diverged,proto3" json:"diverged,omitempty"`
+	// flagged - the session was created in a Gx/Gy failure mode, its usage must be reconciled with the PCRF/OCS
+	Flagged              bool     `protobuf:"varint,9,opt,name=flagged,proto3" json:"flagged,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReconciliationEntry) Reset()         { *m = ReconciliationEntry{} }
+func (m *ReconciliationEntry) String() string { return proto.CompactTextString(m) }
+func (*ReconciliationEntry) ProtoMessage()    {}
+func (*ReconciliationEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_accounting_8f9562d18431439e, []int{6}
+}
+func (m *ReconciliationEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReconciliationEntry.Unmarshal(m, b)
+}
+func (m *ReconciliationEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReconciliationEntry.Marshal(b, m, deterministic)
+}
+func (dst *ReconciliationEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReconciliationEntry.Merge(dst, src)
+}
+func (m *ReconciliationEntry) XXX_Size() int {
+	return xxx_messageInfo_ReconciliationEntry.Size(m)
+}
+func (m *ReconciliationEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReconciliationEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReconciliationEntry proto.InternalMessageInfo
+
+func (m *ReconciliationEntry) GetSessionId() string {
+	if m != nil {
+		return m.SessionId
+	}
+	return ""
+}
+
+func (m *ReconciliationEntry) GetImsi() string {
+	if m != nil {
+		return m.Imsi
+	}
+	return ""
+}
+
+func (m *ReconciliationEntry) GetLocalOctetsIn() uint64 {
+	if m != nil {
+		return m.LocalOctetsIn
+	}
+	return 0
+}
+
+func (m *ReconciliationEntry) GetLocalOctetsOut() uint64 {
+	if m != nil {
+		return m.LocalOctetsOut
+	}
+	return 0
+}
+
+func (m *ReconciliationEntry) GetReportedOctetsIn() uint64 {
+	if m != nil {
+		return m.ReportedOctetsIn
+	}
+	return 0
+}
+
+func (m *ReconciliationEntry) GetReportedOctetsOut() uint64 {
+	if m != nil {
+		return m.ReportedOctetsOut
+	}
+	return 0
+}
+
+func (m *ReconciliationEntry) GetDivergence() float64 {
+	if m != nil {
+		return m.Divergence
+	}
+	return 0
+}
+
+func (m *ReconciliationEntry) GetDiverged() bool {
+	if m != nil {
+		return m.Diverged
+	}
+	return false
+}
+
+func (m *ReconciliationEntry) GetFlagged() bool {
+	if m != nil {
+		return m.Flagged
+	}
+	return false
+}
+
+// reconciliation_report - reconciliation results for all sessions known locally or to session manager
+type ReconciliationReport struct {
+	Entries              []*ReconciliationEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	DivergedCount        uint32                 `protobuf:"varint,2,opt,name=diverged_count,json=divergedCount,proto3" json:"diverged_count,omitempty"`
+	FlaggedCount         uint32                 `protobuf:"varint,3,opt,name=flagged_count,json=flaggedCount,proto3" json:"flagged_count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *ReconciliationReport) Reset()         { *m = ReconciliationReport{} }
+func (m *ReconciliationReport) String() string { return proto.CompactTextString(m) }
+func (*ReconciliationReport) ProtoMessage()    {}
+func (*ReconciliationReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_accounting_8f9562d18431439e, []int{7}
+}
+func (m *ReconciliationReport) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReconciliationReport.Unmarshal(m, b)
+}
+func (m *ReconciliationReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReconciliationReport.Marshal(b, m, deterministic)
+}
+func (dst *ReconciliationReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReconciliationReport.Merge(dst, src)
+}
+func (m *ReconciliationReport) XXX_Size() int {
+	return xxx_messageInfo_ReconciliationReport.Size(m)
+}
+func (m *ReconciliationReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReconciliationReport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReconciliationReport proto.InternalMessageInfo
+
+func (m *ReconciliationReport) GetEntries() []*ReconciliationEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+func (m *ReconciliationReport) GetDivergedCount() uint32 {
+	if m != nil {
+		return m.DivergedCount
+	}
+	return 0
+}
+
+func (m *ReconciliationReport) GetFlaggedCount() uint32 {
+	if m != nil {
+		return m.FlaggedCount
+	}
+	return 0
+}
+
+// session_bandwidth_request - session's maximum bandwidth change, bandwidths are in bits per second
+type SessionBandwidthRequest struct {
+	SessionId        string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	MaxBandwidthUp   uint32 `protobuf:"varint,2,opt,name=max_bandwidth_up,json=maxBandwidthUp,proto3" json:"max_bandwidth_up,omitempty"`
+	MaxBandwidthDown uint32 `protobuf:"varint,3,opt,name=max_bandwidth_down,json=maxBandwidthDown,proto3" json:"max_bandwidth_down,omitempty"`
+	// duration_sec - the change is reverted after duration_sec seconds, 0 - the change is permanent & the given
+	// bandwidths become the session's base bandwidths
+	DurationSec uint32 `protobuf:"varint,4,opt,name=duration_sec,json=durationSec,proto3" json:"duration_sec,omitempty"`
+	// base_bandwidth_up/down - bandwidths to revert to, if not set the session's last permanent bandwidths are used
+	BaseBandwidthUp      uint32   `protobuf:"varint,5,opt,name=base_bandwidth_up,json=baseBandwidthUp,proto3" json:"base_bandwidth_up,omitempty"`
+	BaseBandwidthDown    uint32   `protobuf:"varint,6,opt,name=base_bandwidth_down,json=baseBandwidthDown,proto3" json:"base_bandwidth_down,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SessionBandwidthRequest) Reset()         { *m = SessionBandwidthRequest{} }
+func (m *SessionBandwidthRequest) String() string { return proto.CompactTextString(m) }
+func (*SessionBandwidthRequest) ProtoMessage()    {}
+func (*SessionBandwidthRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_accounting_8f9562d18431439e, []int{8}
+}
+func (m *SessionBandwidthRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SessionBandwidthRequest.Unmarshal(m, b)
+}
+func (m *SessionBandwidthRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SessionBandwidthRequest.Marshal(b, m, deterministic)
+}
+func (dst *SessionBandwidthRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SessionBandwidthRequest.Merge(dst, src)
+}
+func (m *SessionBandwidthRequest) XXX_Size() int {
+	return xxx_messageInfo_SessionBandwidthRequest.Size(m)
+}
+func (m *SessionBandwidthRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SessionBandwidthRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SessionBandwidthRequest proto.InternalMessageInfo
+
+func (m *SessionBandwidthRequest) GetSessionId() string {
+	if m != nil {
+		return m.SessionId
+	}
+	return ""
+}
+
+func (m *SessionBandwidthRequest) GetMaxBandwidthUp() uint32 {
+	if m != nil {
+		return m.MaxBandwidthUp
+	}
+	return 0
+}
+
+func (m *SessionBandwidthRequest) GetMaxBandwidthDown() uint32 {
+	if m != nil {
+		return m.MaxBandwidthDown
+	}
+	return 0
+}
+
+func (m *SessionBandwidthRequest) GetDurationSec() uint32 {
+	if m != nil {
+		return m.DurationSec
+	}
+	return 0
+}
+
+func (m *SessionBandwidthRequest) GetBaseBandwidthUp() uint32 {
+	if m != nil {
+		return m.BaseBandwidthUp
+	}
+	return 0
+}
+
+func (m *SessionBandwidthRequest) GetBaseBandwidthDown() uint32 {
+	if m != nil {
+		return m.BaseBandwidthDown
+	}
+	return 0
+}
+
+// subscriber_usage_request - subscriber to watch the live usage of
+type SubscriberUsageRequest struct {
+	Imsi                 string   `protobuf:"bytes,1,opt,name=imsi,proto3" json:"imsi,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubscriberUsageRequest) Reset()         { *m = SubscriberUsageRequest{} }
+func (m *SubscriberUsageRequest) String() string { return proto.CompactTextString(m) }
+func (*SubscriberUsageRequest) ProtoMessage()    {}
+func (*SubscriberUsageRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_accounting_8f9562d18431439e, []int{9}
+}
+func (m *SubscriberUsageRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscriberUsageRequest.Unmarshal(m, b)
+}
+func (m *SubscriberUsageRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscriberUsageRequest.Marshal(b, m, deterministic)
+}
+func (dst *SubscriberUsageRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscriberUsageRequest.Merge(dst, src)
+}
+func (m *SubscriberUsageRequest) XXX_Size() int {
+	return xxx_messageInfo_SubscriberUsageRequest.Size(m)
+}
+func (m *SubscriberUsageRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscriberUsageRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscriberUsageRequest proto.InternalMessageInfo
+
+func (m *SubscriberUsageRequest) GetImsi() string {
+	if m != nil {
+		return m.Imsi
+	}
+	return ""
+}
+
+// subscriber_usage_update - incremental usage of a subscriber's session derived from Interim-Updates
+type SubscriberUsageUpdate struct {
+	Imsi      string `protobuf:"bytes,1,opt,name=imsi,proto3" json:"imsi,omitempty"`
+	SessionId string `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// delta_octets_in/out - usage since the session's previous update sent on the stream
+	DeltaOctetsIn  uint64 `protobuf:"varint,3,opt,name=delta_octets_in,json=deltaOctetsIn,proto3" json:"delta_octets_in,omitempty"`
+	DeltaOctetsOut uint64 `protobuf:"varint,4,opt,name=delta_octets_out,json=deltaOctetsOut,proto3" json:"delta_octets_out,omitempty"`
+	// total_octets_in/out - session's usage accumulated from its Interim-Updates
+	TotalOctetsIn        uint64   `protobuf:"varint,5,opt,name=total_octets_in,json=totalOctetsIn,proto3" json:"total_octets_in,omitempty"`
+	TotalOctetsOut       uint64   `protobuf:"varint,6,opt,name=total_octets_out,json=totalOctetsOut,proto3" json:"total_octets_out,omitempty"`
+	TimeMs               int64    `protobuf:"varint,7,opt,name=time_ms,json=timeMs,proto3" json:"time_ms,omitempty"`
+	SessionEnded         bool     `protobuf:"varint,8,opt,name=session_ended,json=sessionEnded,proto3" json:"session_ended,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubscriberUsageUpdate) Reset()         { *m = SubscriberUsageUpdate{} }
+func (m *SubscriberUsageUpdate) String() string { return proto.CompactTextString(m) }
+func (*SubscriberUsageUpdate) ProtoMessage()    {}
+func (*SubscriberUsageUpdate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_accounting_8f9562d18431439e, []int{10}
+}
+func (m *SubscriberUsageUpdate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscriberUsageUpdate.Unmarshal(m, b)
+}
+func (m *SubscriberUsageUpdate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscriberUsageUpdate.Marshal(b, m, deterministic)
+}
+func (dst *SubscriberUsageUpdate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscriberUsageUpdate.Merge(dst, src)
+}
+func (m *SubscriberUsageUpdate) XXX_Size() int {
+	return xxx_messageInfo_SubscriberUsageUpdate.Size(m)
+}
+func (m *SubscriberUsageUpdate) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscriberUsageUpdate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscriberUsageUpdate proto.InternalMessageInfo
+
+func (m *SubscriberUsageUpdate) GetImsi() string {
+	if m != nil {
+		return m.Imsi
+	}
+	return ""
+}
+
+func (m *SubscriberUsageUpdate) GetSessionId() string {
+	if m != nil {
+		return m.SessionId
+	}
+	return ""
+}
+
+func (m *SubscriberUsageUpdate) GetDeltaOctetsIn() uint64 {
+	if m != nil {
+		return m.DeltaOctetsIn
+	}
+	return 0
+}
+
+func (m *SubscriberUsageUpdate) GetDeltaOctetsOut() uint64 {
+	if m != nil {
+		return m.DeltaOctetsOut
+	}
+	return 0
+}
+
+func (m *SubscriberUsageUpdate) GetTotalOctetsIn() uint64 {
+	if m != nil {
+		return m.TotalOctetsIn
+	}
+	return 0
+}
+
+func (m *SubscriberUsageUpdate) GetTotalOctetsOut() uint64 {
+	if m != nil {
+		return m.TotalOctetsOut
+	}
+	return 0
+}
+
+func (m *SubscriberUsageUpdate) GetTimeMs() int64 {
+	if m != nil {
+		return m.TimeMs
+	}
+	return 0
+}
+
+func (m *SubscriberUsageUpdate) GetSessionEnded() bool {
+	if m != nil {
+		return m.SessionEnded
+	}
+	return false
+}
+
+// handover_request - session manager's notification of a subscriber's LTE to Wi-Fi handover, the subscriber's next
+// Wi-Fi session continues the LTE session's charging context (& quota) instead of creating a new one
+type HandoverRequest struct {
+	Imsi string `protobuf:"bytes,1,opt,name=imsi,proto3" json:"imsi,omitempty"`
+	// apn - the Wi-Fi session's APN continuing the charging context, any APN if empty
+	Apn string `protobuf:"bytes,2,opt,name=apn,proto3" json:"apn,omitempty"`
+	// session_id - session manager's ID of the LTE session
+	SessionId            string   `protobuf:"bytes,3,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HandoverRequest) Reset()         { *m = HandoverRequest{} }
+func (m *HandoverRequest) String() string { return proto.CompactTextString(m) }
+func (*HandoverRequest) ProtoMessage()    {}
+func (*HandoverRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_accounting_8f9562d18431439e, []int{11}
+}
+func (m *HandoverRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HandoverRequest.Unmarshal(m, b)
+}
+func (m *HandoverRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HandoverRequest.Marshal(b, m, deterministic)
+}
+func (dst *HandoverRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HandoverRequest.Merge(dst, src)
+}
+func (m *HandoverRequest) XXX_Size() int {
+	return xxx_messageInfo_HandoverRequest.Size(m)
+}
+func (m *HandoverRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HandoverRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HandoverRequest proto.InternalMessageInfo
+
+func (m *HandoverRequest) GetImsi() string {
+	if m != nil {
+		return m.Imsi
+	}
+	return ""
+}
+
+func (m *HandoverRequest) GetApn() string {
+	if m != nil {
+		return m.Apn
+	}
+	return ""
+}
+
+func (m *HandoverRequest) GetSessionId() string {
+	if m != nil {
+		return m.SessionId
+	}
+	return ""
+}
+
+// capacity_error - error details of a session rejected by its APN's concurrent sessions limit, attached to
+// RESOURCE_EXHAUSTED errors of Start & Authenticator calls
+type CapacityError struct {
+	Apn                  string   `protobuf:"bytes,1,opt,name=apn,proto3" json:"apn,omitempty"`
+	MaxSessions          uint32   `protobuf:"varint,2,opt,name=max_sessions,json=maxSessions,proto3" json:"max_sessions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CapacityError) Reset()         { *m = CapacityError{} }
+func (m *CapacityError) String() string { return proto.CompactTextString(m) }
+func (*CapacityError) ProtoMessage()    {}
+func (*CapacityError) Descriptor() ([]byte, []int) {
+	return fileDescriptor_accounting_8f9562d18431439e, []int{12}
+}
+func (m *CapacityError) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CapacityError.Unmarshal(m, b)
+}
+func (m *CapacityError) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CapacityError.Marshal(b, m, deterministic)
+}
+func (dst *CapacityError) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CapacityError.Merge(dst, src)
+}
+func (m *CapacityError) XXX_Size() int {
+	return xxx_messageInfo_CapacityError.Size(m)
+}
+func (m *CapacityError) XXX_DiscardUnknown() {
+	xxx_messageInfo_CapacityError.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CapacityError proto.InternalMessageInfo
+
+func (m *CapacityError) GetApn() string {
+	if m != nil {
+		return m.Apn
+	}
+	return ""
+}
+
+func (m *CapacityError) GetMaxSessions() uint32 {
+	if m != nil {
+		return m.MaxSessions
+	}
+	return 0
+}
+
+// device_hint_request - device classification hint of a UE, e.g. a DHCP fingerprint classified by pipelined or a
+// captive portal's user agent. The hint is attached to the UE's session, identified by session_id or, if not set,
+// by mac_addr. Hints of UEs without a session yet are kept until the UE's session starts
+type DeviceHintRequest struct {
+	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	MacAddr   string `protobuf:"bytes,2,opt,name=mac_addr,json=macAddr,proto3" json:"mac_addr,omitempty"`
+	// source - dhcp or user_agent
+	Source string `protobuf:"bytes,3,opt,name=source,proto3" json:"source,omitempty"`
+	// device_type - e.g. phone, tablet, laptop, iot
+	DeviceType           string   `protobuf:"bytes,4,opt,name=device_type,json=deviceType,proto3" json:"device_type,omitempty"`
+	Os                   string   `protobuf:"bytes,5,opt,name=os,proto3" json:"os,omitempty"`
+	Vendor               string   `protobuf:"bytes,6,opt,name=vendor,proto3" json:"vendor,omitempty"`
+	Model                string   `protobuf:"bytes,7,opt,name=model,proto3" json:"model,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeviceHintRequest) Reset()         { *m = DeviceHintRequest{} }
+func (m *DeviceHintRequest) String() string { return proto.CompactTextString(m) }
+func (*DeviceHintRequest) ProtoMessage()    {}
+func (*DeviceHintRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_accounting_8f9562d18431439e, []int{13}
+}
+func (m *DeviceHintRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeviceHintRequest.Unmarshal(m, b)
+}
+func (m *DeviceHintRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeviceHintRequest.Marshal(b, m, deterministic)
+}
+func (dst *DeviceHintRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeviceHintRequest.Merge(dst, src)
+}
+func (m *DeviceHintRequest) XXX_Size() int {
+	return xxx_messageInfo_DeviceHintRequest.Size(m)
+}
+func (m *DeviceHintRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeviceHintRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeviceHintRequest proto.InternalMessageInfo
+
+func (m *DeviceHintRequest) GetSessionId() string {
+	if m != nil {
+		return m.SessionId
+	}
+	return ""
+}
+
+func (m *DeviceHintRequest) GetMacAddr() string {
+	if m != nil {
+		return m.MacAddr
+	}
+	return ""
+}
+
+func (m *DeviceHintRequest) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *DeviceHintRequest) GetDeviceType() string {
+	if m != nil {
+		return m.DeviceType
+	}
+	return ""
+}
+
+func (m *DeviceHintRequest) GetOs() string {
+	if m != nil {
+		return m.Os
+	}
+	return ""
+}
+
+func (m *DeviceHintRequest) GetVendor() string {
+	if m != nil {
+		return m.Vendor
+	}
+	return ""
+}
+
+func (m *DeviceHintRequest) GetModel() string {
+	if m != nil {
+		return m.Model
+	}
+	return ""
+}
+
+// security_event_request - security trigger of an active session, e.g. a blocklist hit. The session is quarantined
+// or disconnected, as configured for the trigger
+type SecurityEventRequest struct {
+	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// trigger - clone or blocklist
+	Trigger string `protobuf:"bytes,2,opt,name=trigger,proto3" json:"trigger,omitempty"`
+	// reason - trigger's details for the logs & audit, e.g. the matched blocklist entry
+	Reason               string   `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SecurityEventRequest) Reset()         { *m = SecurityEventRequest{} }
+func (m *SecurityEventRequest) String() string { return proto.CompactTextString(m) }
+func (*SecurityEventRequest) ProtoMessage()    {}
+func (*SecurityEventRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_accounting_8f9562d18431439e, []int{14}
+}
+func (m *SecurityEventRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SecurityEventRequest.Unmarshal(m, b)
+}
+func (m *SecurityEventRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SecurityEventRequest.Marshal(b, m, deterministic)
+}
+func (dst *SecurityEventRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SecurityEventRequest.Merge(dst, src)
+}
+func (m *SecurityEventRequest) XXX_Size() int {
+	return xxx_messageInfo_SecurityEventRequest.Size(m)
+}
+func (m *SecurityEventRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SecurityEventRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SecurityEventRequest proto.InternalMessageInfo
+
+func (m *SecurityEventRequest) GetSessionId() string {
+	if m != nil {
+		return m.SessionId
+	}
+	return ""
+}
+
+func (m *SecurityEventRequest) GetTrigger() string {
+	if m != nil {
+		return m.Trigger
+	}
+	return ""
+}
+
+func (m *SecurityEventRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+// change_session_request - session manager's policy change of an active session (e.g. bandwidth re-class, VLAN or
+// filter change) pushed to the NAS via CoA instead of terminating the session, only the set fields are changed
+type ChangeSessionRequest struct {
+	RadiusSessionId string `protobuf:"bytes,1,opt,name=radius_session_id,json=radiusSessionId,proto3" json:"radius_session_id,omitempty"`
+	Imsi            string `protobuf:"bytes,2,opt,name=imsi,proto3" json:"imsi,omitempty"`
+	// maximum session bandwidth in bits per second, the session's new base bandwidth
+	MaxBandwidthUp       uint32   `protobuf:"varint,3,opt,name=max_bandwidth_up,json=maxBandwidthUp,proto3" json:"max_bandwidth_up,omitempty"`
+	MaxBandwidthDown     uint32   `protobuf:"varint,4,opt,name=max_bandwidth_down,json=maxBandwidthDown,proto3" json:"max_bandwidth_down,omitempty"`
+	VlanId               uint32   `protobuf:"varint,5,opt,name=vlan_id,json=vlanId,proto3" json:"vlan_id,omitempty"`
+	FilterId             string   `protobuf:"bytes,6,opt,name=filter_id,json=filterId,proto3" json:"filter_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChangeSessionRequest) Reset()         { *m = ChangeSessionRequest{} }
+func (m *ChangeSessionRequest) String() string { return proto.CompactTextString(m) }
+func (*ChangeSessionRequest) ProtoMessage()    {}
+func (*ChangeSessionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_accounting_8f9562d18431439e, []int{15}
+}
+func (m *ChangeSessionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChangeSessionRequest.Unmarshal(m, b)
+}
+func (m *ChangeSessionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChangeSessionRequest.Marshal(b, m, deterministic)
+}
+func (dst *ChangeSessionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChangeSessionRequest.Merge(dst, src)
+}
+func (m *ChangeSessionRequest) XXX_Size() int {
+	return xxx_messageInfo_ChangeSessionRequest.Size(m)
+}
+func (m *ChangeSessionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChangeSessionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChangeSessionRequest proto.InternalMessageInfo
+
+func (m *ChangeSessionRequest) GetRadiusSessionId() string {
+	if m != nil {
+		return m.RadiusSessionId
+	}
+	return ""
+}
+
+func (m *ChangeSessionRequest) GetImsi() string {
+	if m != nil {
+		return m.Imsi
+	}
+	return ""
+}
+
+func (m *ChangeSessionRequest) GetMaxBandwidthUp() uint32 {
+	if m != nil {
+		return m.MaxBandwidthUp
+	}
+	return 0
+}
+
+func (m *ChangeSessionRequest) GetMaxBandwidthDown() uint32 {
+	if m != nil {
+		return m.MaxBandwidthDown
+	}
+	return 0
+}
+
+func (m *ChangeSessionRequest) GetVlanId() uint32 {
+	if m != nil {
+		return m.VlanId
+	}
+	return 0
+}
+
+func (m *ChangeSessionRequest) GetFilterId() string {
+	if m != nil {
+		return m.FilterId
+	}
+	return ""
+}
+
+// acct_on_off_request - NAS's Accounting-On or Accounting-Off: the NAS (re)started or is going down & all its
+// sessions are gone
+type AcctOnOffRequest struct {
+	// off - Accounting-Off, Accounting-On otherwise
+	Off bool `protobuf:"varint,1,opt,name=off,proto3" json:"off,omitempty"`
+	// nas_identifier - matched against the sessions' nas_identifier context attribute
+	NasIdentifier string `protobuf:"bytes,2,opt,name=nas_identifier,json=nasIdentifier,proto3" json:"nas_identifier,omitempty"`
+	// called_station_id - matched against the AP MAC of the sessions' Called-Station-Id
+	CalledStationId      string   `protobuf:"bytes,3,opt,name=called_station_id,json=calledStationId,proto3" json:"called_station_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AcctOnOffRequest) Reset()         { *m = AcctOnOffRequest{} }
+func (m *AcctOnOffRequest) String() string { return proto.CompactTextString(m) }
+func (*AcctOnOffRequest) ProtoMessage()    {}
+func (*AcctOnOffRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_accounting_8f9562d18431439e, []int{16}
+}
+func (m *AcctOnOffRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AcctOnOffRequest.Unmarshal(m, b)
+}
+func (m *AcctOnOffRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AcctOnOffRequest.Marshal(b, m, deterministic)
+}
+func (dst *AcctOnOffRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AcctOnOffRequest.Merge(dst, src)
+}
+func (m *AcctOnOffRequest) XXX_Size() int {
+	return xxx_messageInfo_AcctOnOffRequest.Size(m)
+}
+func (m *AcctOnOffRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AcctOnOffRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AcctOnOffRequest proto.InternalMessageInfo
+
+func (m *AcctOnOffRequest) GetOff() bool {
+	if m != nil {
+		return m.Off
+	}
+	return false
+}
+
+func (m *AcctOnOffRequest) GetNasIdentifier() string {
+	if m != nil {
+		return m.NasIdentifier
+	}
+	return ""
+}
+
+func (m *AcctOnOffRequest) GetCalledStationId() string {
+	if m != nil {
+		return m.CalledStationId
+	}
+	return ""
+}
+
+// session_events_request - filters of the streamed session lifecycle events, all events are streamed if not set
+type SessionEventsRequest struct {
+	// types - event types: start, interim, stop, timeout, terminate, ... as in the audit log
+	Types []string `protobuf:"bytes,1,rep,name=types,proto3" json:"types,omitempty"`
+	// imsi - only the events of the subscriber's sessions
+	Imsi                 string   `protobuf:"bytes,2,opt,name=imsi,proto3" json:"imsi,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SessionEventsRequest) Reset()         { *m = SessionEventsRequest{} }
+func (m *SessionEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*SessionEventsRequest) ProtoMessage()    {}
+func (*SessionEventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_accounting_8f9562d18431439e, []int{17}
+}
+func (m *SessionEventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SessionEventsRequest.Unmarshal(m, b)
+}
+func (m *SessionEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SessionEventsRequest.Marshal(b, m, deterministic)
+}
+func (dst *SessionEventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SessionEventsRequest.Merge(dst, src)
+}
+func (m *SessionEventsRequest) XXX_Size() int {
+	return xxx_messageInfo_SessionEventsRequest.Size(m)
+}
+func (m *SessionEventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SessionEventsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SessionEventsRequest proto.InternalMessageInfo
+
+func (m *SessionEventsRequest) GetTypes() []string {
+	if m != nil {
+		return m.Types
+	}
+	return nil
+}
+
+func (m *SessionEventsRequest) GetImsi() string {
+	if m != nil {
+		return m.Imsi
+	}
+	return ""
+}
+
+// session_event - a session lifecycle event
+type SessionEvent struct {
+	Type       string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	SessionId  string `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Imsi       string `protobuf:"bytes,3,opt,name=imsi,proto3" json:"imsi,omitempty"`
+	Apn        string `protobuf:"bytes,4,opt,name=apn,proto3" json:"apn,omitempty"`
+	TimeMs     int64  `protobuf:"varint,5,opt,name=time_ms,json=timeMs,proto3" json:"time_ms,omitempty"`
+	DurationMs int64  `protobuf:"varint,6,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	// octets_in/out - session's usage accumulated from its Interim-Updates
+	OctetsIn  uint64 `protobuf:"varint,7,opt,name=octets_in,json=octetsIn,proto3" json:"octets_in,omitempty"`
+	OctetsOut uint64 `protobuf:"varint,8,opt,name=octets_out,json=octetsOut,proto3" json:"octets_out,omitempty"`
+	// reason - termination's reason or security event's trigger, if any
+	Reason               string   `protobuf:"bytes,9,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SessionEvent) Reset()         { *m = SessionEvent{} }
+func (m *SessionEvent) String() string { return proto.CompactTextString(m) }
+func (*SessionEvent) ProtoMessage()    {}
+func (*SessionEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_accounting_8f9562d18431439e, []int{18}
+}
+func (m *SessionEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SessionEvent.Unmarshal(m, b)
+}
+func (m *SessionEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SessionEvent.Marshal(b, m, deterministic)
+}
+func (dst *SessionEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SessionEvent.Merge(dst, src)
+}
+func (m *SessionEvent) XXX_Size() int {
+	return xxx_messageInfo_SessionEvent.Size(m)
+}
+func (m *SessionEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_SessionEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SessionEvent proto.InternalMessageInfo
+
+func (m *SessionEvent) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *SessionEvent) GetSessionId() string {
+	if m != nil {
+		return m.SessionId
+	}
+	return ""
+}
+
+func (m *SessionEvent) GetImsi() string {
+	if m != nil {
+		return m.Imsi
+	}
+	return ""
+}
+
+func (m *SessionEvent) GetApn() string {
+	if m != nil {
+		return m.Apn
+	}
+	return ""
+}
+
+func (m *SessionEvent) GetTimeMs() int64 {
+	if m != nil {
+		return m.TimeMs
+	}
+	return 0
+}
+
+func (m *SessionEvent) GetDurationMs() int64 {
+	if m != nil {
+		return m.DurationMs
+	}
+	return 0
+}
+
+func (m *SessionEvent) GetOctetsIn() uint64 {
+	if m != nil {
+		return m.OctetsIn
+	}
+	return 0
 }
-func (m *AcctResp) XXX_DiscardUnknown() {
-	xxx_messageInfo_AcctResp.DiscardUnknown(m)
+
+func (m *SessionEvent) GetOctetsOut() uint64 {
+	if m != nil {
+		return m.OctetsOut
+	}
+	return 0
 }
 
-var xxx_messageInfo_AcctResp proto.InternalMessageInfo
+func (m *SessionEvent) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
 
-type TerminateSessionRequest struct {
-	RadiusSessionId      string   `protobuf:"bytes,1,opt,name=radius_session_id,json=radiusSessionId,proto3" json:"radius_session_id,omitempty"`
-	Imsi                 string   `protobuf:"bytes,2,opt,name=imsi,proto3" json:"imsi,omitempty"`
+// rate_limit_error - error details of a request throttled by its MAC address' or IMSI's rate limit, attached to
+// RESOURCE_EXHAUSTED errors of accounting & Authenticator calls
+type RateLimitError struct {
+	// key - the throttled request's rate limit key: mac or imsi
+	Key   string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// retry_after_ms - the wait for the key's next accepted request
+	RetryAfterMs         uint32   `protobuf:"varint,3,opt,name=retry_after_ms,json=retryAfterMs,proto3" json:"retry_after_ms,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *TerminateSessionRequest) Reset()         { *m = TerminateSessionRequest{} }
-func (m *TerminateSessionRequest) String() string { return proto.CompactTextString(m) }
-func (*TerminateSessionRequest) ProtoMessage()    {}
-func (*TerminateSessionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_accounting_8f9562d18431439e, []int{3}
+func (m *RateLimitError) Reset()         { *m = RateLimitError{} }
+func (m *RateLimitError) String() string { return proto.CompactTextString(m) }
+func (*RateLimitError) ProtoMessage()    {}
+func (*RateLimitError) Descriptor() ([]byte, []int) {
+	return fileDescriptor_accounting_8f9562d18431439e, []int{19}
 }
-func (m *TerminateSessionRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_TerminateSessionRequest.Unmarshal(m, b)
+func (m *RateLimitError) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RateLimitError.Unmarshal(m, b)
 }
-func (m *TerminateSessionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_TerminateSessionRequest.Marshal(b, m, deterministic)
+func (m *RateLimitError) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RateLimitError.Marshal(b, m, deterministic)
 }
-func (dst *TerminateSessionRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TerminateSessionRequest.Merge(dst, src)
+func (dst *RateLimitError) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RateLimitError.Merge(dst, src)
 }
-func (m *TerminateSessionRequest) XXX_Size() int {
-	return xxx_messageInfo_TerminateSessionRequest.Size(m)
+func (m *RateLimitError) XXX_Size() int {
+	return xxx_messageInfo_RateLimitError.Size(m)
 }
-func (m *TerminateSessionRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_TerminateSessionRequest.DiscardUnknown(m)
+func (m *RateLimitError) XXX_DiscardUnknown() {
+	xxx_messageInfo_RateLimitError.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_TerminateSessionRequest proto.InternalMessageInfo
+var xxx_messageInfo_RateLimitError proto.InternalMessageInfo
 
-func (m *TerminateSessionRequest) GetRadiusSessionId() string {
+func (m *RateLimitError) GetKey() string {
 	if m != nil {
-		return m.RadiusSessionId
+		return m.Key
 	}
 	return ""
 }
 
-func (m *TerminateSessionRequest) GetImsi() string {
+func (m *RateLimitError) GetValue() string {
 	if m != nil {
-		return m.Imsi
+		return m.Value
 	}
 	return ""
 }
 
+func (m *RateLimitError) GetRetryAfterMs() uint32 {
+	if m != nil {
+		return m.RetryAfterMs
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*UpdateRequest)(nil), "aaa.protos.update_request")
 	proto.RegisterType((*StopRequest)(nil), "aaa.protos.stop_request")
 	proto.RegisterType((*AcctResp)(nil), "aaa.protos.acct_resp")
+	proto.RegisterType((*AcctRespRadiusAttribute)(nil), "aaa.protos.acct_resp.radius_attribute")
 	proto.RegisterType((*TerminateSessionRequest)(nil), "aaa.protos.terminate_session_request")
+	proto.RegisterType((*SessionUsage)(nil), "aaa.protos.session_usage")
+	proto.RegisterType((*ReconciliationRequest)(nil), "aaa.protos.reconciliation_request")
+	proto.RegisterType((*ReconciliationEntry)(nil), "aaa.protos.reconciliation_entry")
+	proto.RegisterType((*ReconciliationReport)(nil), "aaa.protos.reconciliation_report")
+	proto.RegisterType((*SessionBandwidthRequest)(nil), "aaa.protos.session_bandwidth_request")
+	proto.RegisterType((*SubscriberUsageRequest)(nil), "aaa.protos.subscriber_usage_request")
+	proto.RegisterType((*SubscriberUsageUpdate)(nil), "aaa.protos.subscriber_usage_update")
+	proto.RegisterType((*HandoverRequest)(nil), "aaa.protos.handover_request")
+	proto.RegisterType((*CapacityError)(nil), "aaa.protos.capacity_error")
+	proto.RegisterType((*DeviceHintRequest)(nil), "aaa.protos.device_hint_request")
+	proto.RegisterType((*SecurityEventRequest)(nil), "aaa.protos.security_event_request")
 	proto.RegisterEnum("aaa.protos.StopRequestTerminateCause", StopRequestTerminateCause_name, StopRequestTerminateCause_value)
+	proto.RegisterType((*ChangeSessionRequest)(nil), "aaa.protos.change_session_request")
+	proto.RegisterType((*AcctOnOffRequest)(nil), "aaa.protos.acct_on_off_request")
+	proto.RegisterType((*SessionEventsRequest)(nil), "aaa.protos.session_events_request")
+	proto.RegisterType((*SessionEvent)(nil), "aaa.protos.session_event")
+	proto.RegisterType((*RateLimitError)(nil), "aaa.protos.rate_limit_error")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -327,6 +1621,29 @@ type AccountingClient interface {
 	CreateSession(ctx context.Context, in *Context, opts ...grpc.CallOption) (*AcctResp, error)
 	// terminate_session is an "inbound" RPC from session manager to notify accounting of a client session termination
 	TerminateSession(ctx context.Context, in *TerminateSessionRequest, opts ...grpc.CallOption) (*AcctResp, error)
+	// reconcile returns a report comparing locally accumulated Interim-Update usage with usage reported by session manager
+	Reconcile(ctx context.Context, in *ReconciliationRequest, opts ...grpc.CallOption) (*ReconciliationReport, error)
+	// set_session_bandwidth changes the session's maximum bandwidth via CoA, temporary changes (boosts) are
+	// automatically reverted
+	SetSessionBandwidth(ctx context.Context, in *SessionBandwidthRequest, opts ...grpc.CallOption) (*AcctResp, error)
+	// watch_subscriber_usage streams live usage updates of all the subscriber's sessions until the client cancels
+	WatchSubscriberUsage(ctx context.Context, in *SubscriberUsageRequest, opts ...grpc.CallOption) (Accounting_WatchSubscriberUsageClient, error)
+	// handover is an "inbound" RPC from session manager to notify accounting of an upcoming LTE to Wi-Fi handover
+	Handover(ctx context.Context, in *HandoverRequest, opts ...grpc.CallOption) (*AcctResp, error)
+	// device_hint is an "inbound" RPC of device classification integrations (pipelined, captive portals) attaching
+	// a device classification hint to the UE's session analytics & session manager's session creation
+	DeviceHint(ctx context.Context, in *DeviceHintRequest, opts ...grpc.CallOption) (*AcctResp, error)
+	// security_event is an "inbound" RPC of security integrations (e.g. blocklists) reporting a security trigger of
+	// an active session, the session is quarantined via CoA or disconnected, as configured for the trigger
+	SecurityEvent(ctx context.Context, in *SecurityEventRequest, opts ...grpc.CallOption) (*AcctResp, error)
+	// change_session is an "inbound" RPC from session manager to push a policy change of an active session to the NAS
+	// via CoA
+	ChangeSession(ctx context.Context, in *ChangeSessionRequest, opts ...grpc.CallOption) (*AcctResp, error)
+	// Acct-Status-Type Accounting-On & Accounting-Off, all sessions of the NAS are flushed
+	AcctOnOff(ctx context.Context, in *AcctOnOffRequest, opts ...grpc.CallOption) (*AcctResp, error)
+	// subscribe_events streams the session lifecycle events (start, interim, stop, timeout, terminate, ...) until the
+	// client cancels, so analytics or captive portals react to sessions' changes without polling the session table
+	SubscribeEvents(ctx context.Context, in *SessionEventsRequest, opts ...grpc.CallOption) (Accounting_SubscribeEventsClient, error)
 }
 
 type accountingClient struct {
@@ -382,6 +1699,133 @@ func (c *accountingClient) TerminateSession(ctx context.Context, in *TerminateSe
 	return out, nil
 }
 
+func (c *accountingClient) Reconcile(ctx context.Context, in *ReconciliationRequest, opts ...grpc.CallOption) (*ReconciliationReport, error) {
+	out := new(ReconciliationReport)
+	err := c.cc.Invoke(ctx, "/aaa.protos.accounting/reconcile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountingClient) SetSessionBandwidth(ctx context.Context, in *SessionBandwidthRequest, opts ...grpc.CallOption) (*AcctResp, error) {
+	out := new(AcctResp)
+	err := c.cc.Invoke(ctx, "/aaa.protos.accounting/set_session_bandwidth", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountingClient) WatchSubscriberUsage(ctx context.Context, in *SubscriberUsageRequest, opts ...grpc.CallOption) (Accounting_WatchSubscriberUsageClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Accounting_serviceDesc.Streams[0], "/aaa.protos.accounting/watch_subscriber_usage", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &accountingWatchSubscriberUsageClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Accounting_WatchSubscriberUsageClient interface {
+	Recv() (*SubscriberUsageUpdate, error)
+	grpc.ClientStream
+}
+
+type accountingWatchSubscriberUsageClient struct {
+	grpc.ClientStream
+}
+
+func (x *accountingWatchSubscriberUsageClient) Recv() (*SubscriberUsageUpdate, error) {
+	m := new(SubscriberUsageUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *accountingClient) Handover(ctx context.Context, in *HandoverRequest, opts ...grpc.CallOption) (*AcctResp, error) {
+	out := new(AcctResp)
+	err := c.cc.Invoke(ctx, "/aaa.protos.accounting/handover", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountingClient) DeviceHint(ctx context.Context, in *DeviceHintRequest, opts ...grpc.CallOption) (*AcctResp, error) {
+	out := new(AcctResp)
+	err := c.cc.Invoke(ctx, "/aaa.protos.accounting/device_hint", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountingClient) SecurityEvent(ctx context.Context, in *SecurityEventRequest, opts ...grpc.CallOption) (*AcctResp, error) {
+	out := new(AcctResp)
+	err := c.cc.Invoke(ctx, "/aaa.protos.accounting/security_event", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountingClient) ChangeSession(ctx context.Context, in *ChangeSessionRequest, opts ...grpc.CallOption) (*AcctResp, error) {
+	out := new(AcctResp)
+	err := c.cc.Invoke(ctx, "/aaa.protos.accounting/change_session", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountingClient) AcctOnOff(ctx context.Context, in *AcctOnOffRequest, opts ...grpc.CallOption) (*AcctResp, error) {
+	out := new(AcctResp)
+	err := c.cc.Invoke(ctx, "/aaa.protos.accounting/acct_on_off", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountingClient) SubscribeEvents(ctx context.Context, in *SessionEventsRequest, opts ...grpc.CallOption) (Accounting_SubscribeEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Accounting_serviceDesc.Streams[1], "/aaa.protos.accounting/subscribe_events", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &accountingSubscribeEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Accounting_SubscribeEventsClient interface {
+	Recv() (*SessionEvent, error)
+	grpc.ClientStream
+}
+
+type accountingSubscribeEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *accountingSubscribeEventsClient) Recv() (*SessionEvent, error) {
+	m := new(SessionEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // AccountingServer is the server API for Accounting service.
 type AccountingServer interface {
 	// External Radius Server RPCs
@@ -398,6 +1842,29 @@ type AccountingServer interface {
 	CreateSession(context.Context, *Context) (*AcctResp, error)
 	// terminate_session is an "inbound" RPC from session manager to notify accounting of a client session termination
 	TerminateSession(context.Context, *TerminateSessionRequest) (*AcctResp, error)
+	// reconcile returns a report comparing locally accumulated Interim-Update usage with usage reported by session manager
+	Reconcile(context.Context, *ReconciliationRequest) (*ReconciliationReport, error)
+	// set_session_bandwidth changes the session's maximum bandwidth via CoA, temporary changes (boosts) are
+	// automatically reverted
+	SetSessionBandwidth(context.Context, *SessionBandwidthRequest) (*AcctResp, error)
+	// watch_subscriber_usage streams live usage updates of all the subscriber's sessions until the client cancels
+	WatchSubscriberUsage(*SubscriberUsageRequest, Accounting_WatchSubscriberUsageServer) error
+	// handover is an "inbound" RPC from session manager to notify accounting of an upcoming LTE to Wi-Fi handover
+	Handover(context.Context, *HandoverRequest) (*AcctResp, error)
+	// device_hint is an "inbound" RPC of device classification integrations (pipelined, captive portals) attaching
+	// a device classification hint to the UE's session analytics & session manager's session creation
+	DeviceHint(context.Context, *DeviceHintRequest) (*AcctResp, error)
+	// security_event is an "inbound" RPC of security integrations (e.g. blocklists) reporting a security trigger of
+	// an active session, the session is quarantined via CoA or disconnected, as configured for the trigger
+	SecurityEvent(context.Context, *SecurityEventRequest) (*AcctResp, error)
+	// change_session is an "inbound" RPC from session manager to push a policy change of an active session to the NAS
+	// via CoA
+	ChangeSession(context.Context, *ChangeSessionRequest) (*AcctResp, error)
+	// Acct-Status-Type Accounting-On & Accounting-Off, all sessions of the NAS are flushed
+	AcctOnOff(context.Context, *AcctOnOffRequest) (*AcctResp, error)
+	// subscribe_events streams the session lifecycle events (start, interim, stop, timeout, terminate, ...) until the
+	// client cancels, so analytics or captive portals react to sessions' changes without polling the session table
+	SubscribeEvents(*SessionEventsRequest, Accounting_SubscribeEventsServer) error
 }
 
 func RegisterAccountingServer(s *grpc.Server, srv AccountingServer) {
@@ -494,6 +1961,174 @@ func _Accounting_TerminateSession_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Accounting_Reconcile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReconciliationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountingServer).Reconcile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/aaa.protos.accounting/Reconcile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountingServer).Reconcile(ctx, req.(*ReconciliationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Accounting_SetSessionBandwidth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SessionBandwidthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountingServer).SetSessionBandwidth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/aaa.protos.accounting/SetSessionBandwidth",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountingServer).SetSessionBandwidth(ctx, req.(*SessionBandwidthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Accounting_WatchSubscriberUsage_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscriberUsageRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AccountingServer).WatchSubscriberUsage(m, &accountingWatchSubscriberUsageServer{stream})
+}
+
+type Accounting_WatchSubscriberUsageServer interface {
+	Send(*SubscriberUsageUpdate) error
+	grpc.ServerStream
+}
+
+type accountingWatchSubscriberUsageServer struct {
+	grpc.ServerStream
+}
+
+func (x *accountingWatchSubscriberUsageServer) Send(m *SubscriberUsageUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Accounting_Handover_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HandoverRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountingServer).Handover(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/aaa.protos.accounting/Handover",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountingServer).Handover(ctx, req.(*HandoverRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Accounting_DeviceHint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeviceHintRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountingServer).DeviceHint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/aaa.protos.accounting/DeviceHint",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountingServer).DeviceHint(ctx, req.(*DeviceHintRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Accounting_SecurityEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SecurityEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountingServer).SecurityEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/aaa.protos.accounting/SecurityEvent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountingServer).SecurityEvent(ctx, req.(*SecurityEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Accounting_ChangeSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountingServer).ChangeSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/aaa.protos.accounting/ChangeSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountingServer).ChangeSession(ctx, req.(*ChangeSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Accounting_AcctOnOff_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcctOnOffRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountingServer).AcctOnOff(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/aaa.protos.accounting/AcctOnOff",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountingServer).AcctOnOff(ctx, req.(*AcctOnOffRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Accounting_SubscribeEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SessionEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AccountingServer).SubscribeEvents(m, &accountingSubscribeEventsServer{stream})
+}
+
+type Accounting_SubscribeEventsServer interface {
+	Send(*SessionEvent) error
+	grpc.ServerStream
+}
+
+type accountingSubscribeEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *accountingSubscribeEventsServer) Send(m *SessionEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _Accounting_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "aaa.protos.accounting",
 	HandlerType: (*AccountingServer)(nil),
@@ -518,52 +2153,169 @@ var _Accounting_serviceDesc = grpc.ServiceDesc{
 			MethodName: "terminate_session",
 			Handler:    _Accounting_TerminateSession_Handler,
 		},
+		{
+			MethodName: "reconcile",
+			Handler:    _Accounting_Reconcile_Handler,
+		},
+		{
+			MethodName: "set_session_bandwidth",
+			Handler:    _Accounting_SetSessionBandwidth_Handler,
+		},
+		{
+			MethodName: "handover",
+			Handler:    _Accounting_Handover_Handler,
+		},
+		{
+			MethodName: "device_hint",
+			Handler:    _Accounting_DeviceHint_Handler,
+		},
+		{
+			MethodName: "security_event",
+			Handler:    _Accounting_SecurityEvent_Handler,
+		},
+		{
+			MethodName: "change_session",
+			Handler:    _Accounting_ChangeSession_Handler,
+		},
+		{
+			MethodName: "acct_on_off",
+			Handler:    _Accounting_AcctOnOff_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "watch_subscriber_usage",
+			Handler:       _Accounting_WatchSubscriberUsage_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "subscribe_events",
+			Handler:       _Accounting_SubscribeEvents_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "accounting.proto",
 }
 
 func init() { proto.RegisterFile("accounting.proto", fileDescriptor_accounting_8f9562d18431439e) }
 
 var fileDescriptor_accounting_8f9562d18431439e = []byte{
-	// 611 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x53, 0x5b, 0x6f, 0xd3, 0x30,
-	0x14, 0x5e, 0x6f, 0x63, 0x3d, 0x6d, 0x53, 0xd7, 0x13, 0xa2, 0x0c, 0x21, 0xa6, 0x4a, 0x13, 0x13,
-	0x0f, 0xad, 0x34, 0xc4, 0x03, 0x2f, 0x93, 0xd2, 0xc6, 0x88, 0x88, 0x36, 0x29, 0x76, 0xb2, 0x07,
-	0x78, 0x88, 0x4c, 0x6a, 0xaa, 0x08, 0x35, 0x29, 0xb1, 0x03, 0xe3, 0x77, 0xf1, 0x6f, 0x10, 0x8f,
-	0xfc, 0x10, 0xe4, 0x24, 0xbd, 0x70, 0xa9, 0x10, 0x4f, 0x89, 0xbf, 0x8b, 0xfd, 0x9d, 0xe3, 0x63,
-	0x40, 0x3c, 0x0c, 0x93, 0x2c, 0x56, 0x51, 0xbc, 0x1c, 0xae, 0xd3, 0x44, 0x25, 0x18, 0x38, 0xe7,
-	0xc5, 0xaf, 0x3c, 0xeb, 0x84, 0x49, 0xac, 0xc4, 0xad, 0x2a, 0xd6, 0x83, 0xaf, 0x15, 0x30, 0xb2,
-	0xf5, 0x82, 0x2b, 0x11, 0xa4, 0xe2, 0x63, 0x26, 0xa4, 0xc2, 0x0f, 0xa0, 0x99, 0x84, 0x4a, 0x28,
-	0x19, 0x44, 0x71, 0xbf, 0x72, 0x5e, 0xb9, 0xec, 0xd0, 0x93, 0x02, 0xb0, 0x63, 0xfc, 0x10, 0xa0,
-	0x24, 0x93, 0x4c, 0xf5, 0xab, 0x39, 0x5b, 0xca, 0xdd, 0x4c, 0x69, 0x7a, 0xcd, 0xc3, 0x0f, 0xa5,
-	0xb9, 0x56, 0xd0, 0x25, 0x62, 0xc7, 0xf8, 0x11, 0xb4, 0x36, 0xb4, 0xb6, 0xd7, 0x73, 0x7e, 0xe3,
-	0xd0, 0xfe, 0x0b, 0xa8, 0x85, 0xea, 0xb6, 0xdf, 0x38, 0xaf, 0x5c, 0xb6, 0xae, 0x4e, 0x87, 0xbb,
-	0xdc, 0xc3, 0x32, 0x36, 0xd5, 0xfc, 0xe0, 0x7b, 0x0d, 0xda, 0x52, 0x25, 0xeb, 0x6d, 0xe6, 0x6b,
-	0x68, 0x84, 0x3c, 0x93, 0x22, 0xcf, 0x6b, 0x5c, 0x5d, 0xee, 0x3b, 0xf7, 0x85, 0x43, 0x25, 0xd2,
-	0x55, 0x14, 0xeb, 0x72, 0x73, 0x3d, 0x2d, 0x6c, 0x9b, 0x73, 0xab, 0xff, 0x38, 0xf7, 0x47, 0x15,
-	0xba, 0xbf, 0xed, 0x80, 0x3b, 0xd0, 0xf4, 0x1d, 0x8b, 0xbc, 0xb0, 0x1d, 0x62, 0xa1, 0x23, 0x8c,
-	0xa0, 0xed, 0x33, 0x42, 0x03, 0x4a, 0x5e, 0xfb, 0x84, 0x79, 0xa8, 0xa2, 0x91, 0xa9, 0xcb, 0xbc,
-	0x60, 0x62, 0x52, 0x6a, 0x13, 0x8a, 0xaa, 0x5b, 0x84, 0x11, 0x7a, 0x63, 0x4f, 0x08, 0xaa, 0x69,
-	0xc4, 0xb6, 0xa6, 0x24, 0xf0, 0xec, 0x19, 0x71, 0x7d, 0x0f, 0xd5, 0xf1, 0x29, 0x74, 0x19, 0x61,
-	0xcc, 0x76, 0x9d, 0x2d, 0xd8, 0xc0, 0x5d, 0x68, 0x99, 0xd6, 0xcc, 0x76, 0x02, 0x4a, 0x18, 0xf1,
-	0xd0, 0xb1, 0xf6, 0x6d, 0x80, 0xb1, 0xeb, 0x7a, 0xe8, 0x0e, 0x36, 0x00, 0xe6, 0x2e, 0xf5, 0x02,
-	0x42, 0xa9, 0x4b, 0xd1, 0x89, 0x8e, 0xe7, 0x98, 0xac, 0x5c, 0x36, 0xf5, 0x0e, 0x7a, 0xb9, 0x49,
-	0x07, 0x5a, 0x5f, 0x00, 0xb9, 0xbf, 0x85, 0x7b, 0xd0, 0xc9, 0xfd, 0xbe, 0xe3, 0x10, 0x62, 0x11,
-	0x0b, 0xb5, 0x31, 0x06, 0x23, 0x87, 0xe6, 0x94, 0x90, 0xd9, 0xdc, 0x23, 0x16, 0xea, 0x6c, 0x31,
-	0xe6, 0xb3, 0x39, 0x71, 0xb4, 0xce, 0xc0, 0xf7, 0xe0, 0xb4, 0xac, 0x28, 0xf0, 0x1d, 0xf3, 0xc6,
-	0xb4, 0xa7, 0xe6, 0x78, 0x4a, 0x50, 0x17, 0xb7, 0xe1, 0x64, 0x62, 0x4e, 0xa7, 0x63, 0x73, 0xf2,
-	0x0a, 0x21, 0x7d, 0x62, 0xde, 0xa1, 0x22, 0x52, 0x4f, 0xd7, 0xf0, 0x52, 0x77, 0x63, 0x93, 0x09,
-	0x0f, 0x5a, 0xd0, 0xe4, 0x61, 0xa8, 0x82, 0x54, 0xc8, 0xf5, 0xe0, 0x2d, 0xdc, 0xdf, 0xb5, 0x5c,
-	0x0a, 0x29, 0xa3, 0x24, 0xde, 0xde, 0xfb, 0x13, 0xe8, 0xa5, 0x7c, 0x11, 0x65, 0x72, 0xcb, 0x44,
-	0x8b, 0x7c, 0x06, 0x9a, 0xb4, 0x5b, 0x10, 0xac, 0xc0, 0xed, 0x05, 0xc6, 0x50, 0x8f, 0x56, 0x32,
-	0xca, 0x2f, 0xb9, 0x49, 0xf3, 0xff, 0xab, 0x6f, 0x55, 0x80, 0xdd, 0x73, 0xc1, 0xcf, 0xa0, 0x21,
-	0x15, 0x4f, 0x15, 0xfe, 0xdb, 0x08, 0x9c, 0xdd, 0xdd, 0x07, 0x77, 0x01, 0x8f, 0x30, 0x01, 0x23,
-	0x8a, 0x95, 0x48, 0xa3, 0x55, 0x50, 0xbc, 0x25, 0x7c, 0xb6, 0x2f, 0xfd, 0xf5, 0x7d, 0x1d, 0xde,
-	0xe6, 0x39, 0xd4, 0xf5, 0xac, 0xe2, 0xfe, 0xa1, 0xe9, 0x3d, 0x6c, 0xbd, 0x06, 0x23, 0x4c, 0xc5,
-	0x5e, 0x87, 0xfe, 0xb3, 0x02, 0x06, 0xbd, 0x3f, 0x9a, 0x8c, 0x2f, 0xf6, 0xd5, 0x07, 0xef, 0xe0,
-	0xe0, 0xa6, 0xe3, 0xc7, 0x6f, 0x2e, 0x56, 0x7c, 0xb9, 0xe2, 0xa3, 0xf7, 0x62, 0x39, 0x5a, 0x72,
-	0x25, 0x3e, 0xf3, 0x2f, 0x23, 0x29, 0xd2, 0x4f, 0x51, 0x28, 0xe4, 0x88, 0x73, 0x3e, 0x2a, 0x4c,
-	0xef, 0x8e, 0xf3, 0xef, 0xd3, 0x9f, 0x01, 0x00, 0x00, 0xff, 0xff, 0xbd, 0x4f, 0xda, 0x1f, 0xba,
-	0x04, 0x00, 0x00,
+	// 1865 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xad, 0x58, 0x4b, 0x73, 0xe3, 0x54,
+	0x16, 0x6e, 0x3f, 0x63, 0x9d, 0xc4, 0x8e, 0xa2, 0xd0, 0xe9, 0x24, 0x03, 0x03, 0x08, 0x1a, 0xba,
+	0x28, 0x2a, 0x99, 0x0a, 0xcc, 0x62, 0x66, 0x41, 0x95, 0x93, 0x08, 0x70, 0x4d, 0x12, 0xf7, 0xc8,
+	0x0e, 0x54, 0xcd, 0x46, 0x28, 0xd2, 0x8d, 0xa3, 0xc2, 0xb6, 0x3c, 0xd2, 0x75, 0xd2, 0x61, 0x31,
+	0xff, 0x61, 0xd6, 0xb3, 0xe0, 0x1f, 0xb0, 0x64, 0xc5, 0x8e, 0x2a, 0xb6, 0xfc, 0x09, 0x6a, 0x7e,
+	0xc2, 0x6c, 0x66, 0xc3, 0x39, 0xf7, 0x21, 0x4b, 0x8e, 0xed, 0x6e, 0xaa, 0x58, 0x45, 0xf7, 0x3b,
+	0x8f, 0x7b, 0xee, 0x79, 0x3b, 0x60, 0xfa, 0x41, 0x10, 0x4f, 0xc7, 0x3c, 0x1a, 0x0f, 0x0e, 0x26,
+	0x49, 0xcc, 0x63, 0x0b, 0x7c, 0xdf, 0x97, 0x9f, 0xe9, 0x7e, 0x33, 0x88, 0xc7, 0x9c, 0xbd, 0xe0,
+	0xf2, 0x6c, 0xff, 0xbf, 0x04, 0xad, 0xe9, 0x24, 0xf4, 0x39, 0xf3, 0x12, 0xf6, 0xcf, 0x29, 0x4b,
+	0xb9, 0xf5, 0x07, 0x30, 0xe2, 0x80, 0x33, 0x9e, 0x7a, 0xd1, 0x78, 0xb7, 0xf4, 0x56, 0xe9, 0x59,
+	0xd3, 0x6d, 0x48, 0xa0, 0x33, 0xb6, 0xde, 0x00, 0x50, 0xc4, 0x78, 0xca, 0x77, 0xcb, 0x82, 0xaa,
+	0xd8, 0xbb, 0x53, 0x4e, 0xe4, 0x89, 0x1f, 0x7c, 0xad, 0x84, 0x2b, 0x92, 0xac, 0x10, 0x94, 0x7e,
+	0x13, 0xd6, 0x35, 0x99, 0xc4, 0xab, 0x82, 0xae, 0x25, 0x48, 0xfe, 0x29, 0x54, 0x02, 0xfe, 0x62,
+	0xb7, 0x86, 0x84, 0xf5, 0xa3, 0xed, 0x83, 0x99, 0xdd, 0x07, 0xca, 0x6c, 0x97, 0xe8, 0xd6, 0xdb,
+	0xb0, 0x31, 0x88, 0x06, 0xfe, 0x5d, 0x9c, 0x84, 0xe2, 0xa2, 0xba, 0x50, 0xb4, 0x9e, 0x61, 0x78,
+	0xd5, 0x3b, 0xd0, 0x9c, 0xb1, 0xd0, 0x65, 0x6b, 0x82, 0x67, 0x26, 0x87, 0xd7, 0xd9, 0x3f, 0xd6,
+	0x60, 0x23, 0xe5, 0xf1, 0x24, 0x7b, 0xfb, 0x27, 0x50, 0x0b, 0xfc, 0x69, 0xca, 0xc4, 0xbb, 0x5b,
+	0x47, 0xcf, 0xf2, 0x16, 0xe4, 0x19, 0x0f, 0x38, 0x4b, 0x46, 0xd1, 0x98, 0xdc, 0x26, 0xf8, 0x5d,
+	0x29, 0xa6, 0xed, 0x2f, 0xbf, 0xc4, 0xfe, 0x82, 0x8b, 0x2b, 0x2b, 0x5d, 0x5c, 0x5d, 0xed, 0xe2,
+	0xda, 0x4b, 0x5c, 0x5c, 0x7f, 0xe0, 0x62, 0xf4, 0x5d, 0xca, 0xd2, 0x34, 0x8a, 0xc7, 0x1e, 0x8f,
+	0x46, 0x4c, 0xf9, 0x65, 0x5d, 0x61, 0x7d, 0x84, 0x1e, 0xb8, 0xb7, 0xf1, 0x0a, 0xee, 0x35, 0x16,
+	0xb8, 0xf7, 0x97, 0x32, 0x6c, 0xce, 0x39, 0xca, 0x6a, 0x82, 0x71, 0x79, 0x71, 0xea, 0x7c, 0xda,
+	0xb9, 0x70, 0x4e, 0xcd, 0x47, 0x96, 0x09, 0x1b, 0x97, 0x3d, 0xc7, 0xf5, 0x5c, 0xe7, 0xef, 0x97,
+	0x4e, 0xaf, 0x6f, 0x96, 0x08, 0x39, 0xeb, 0xf6, 0xfa, 0xde, 0x49, 0xdb, 0x75, 0x3b, 0x8e, 0x6b,
+	0x96, 0x33, 0x04, 0xf9, 0xbe, 0xe8, 0x9c, 0x38, 0x66, 0x85, 0x90, 0xce, 0xe9, 0x99, 0xe3, 0xf5,
+	0x3b, 0xe7, 0x4e, 0xf7, 0xb2, 0x6f, 0x56, 0xad, 0x6d, 0xd8, 0xec, 0x39, 0xbd, 0x5e, 0xa7, 0x7b,
+	0x91, 0x81, 0x35, 0x6b, 0x13, 0xd6, 0xdb, 0xa7, 0xe7, 0x9d, 0x0b, 0xd4, 0xde, 0x73, 0xfa, 0x66,
+	0x9d, 0xe4, 0x34, 0x70, 0xdc, 0xed, 0xf6, 0xcd, 0x35, 0xab, 0x05, 0xf0, 0xbc, 0xeb, 0xf6, 0x3d,
+	0xc7, 0x75, 0xbb, 0xae, 0xd9, 0x20, 0xf3, 0x2e, 0xda, 0x3d, 0x75, 0x34, 0x48, 0x03, 0x1d, 0xb5,
+	0x75, 0x40, 0xfc, 0x12, 0x10, 0xf2, 0xeb, 0xd6, 0x16, 0x34, 0x85, 0xfc, 0xe5, 0xc5, 0x85, 0xe3,
+	0x9c, 0xe2, 0x93, 0x36, 0x2c, 0x0b, 0x5a, 0x02, 0x7a, 0xee, 0x3a, 0xce, 0xf9, 0xf3, 0x3e, 0x62,
+	0xcd, 0x0c, 0xeb, 0x5d, 0xf6, 0x9e, 0x3b, 0x17, 0xc4, 0xd7, 0xb2, 0x9e, 0xc0, 0xb6, 0x7a, 0x11,
+	0x4a, 0xb7, 0xbf, 0x68, 0x77, 0xce, 0xda, 0xc7, 0x67, 0x8e, 0xb9, 0x69, 0x6d, 0x40, 0xe3, 0xa4,
+	0x7d, 0x76, 0x76, 0xdc, 0x3e, 0xf9, 0x9b, 0x69, 0xd2, 0x8d, 0xc2, 0x43, 0xd2, 0xa4, 0x2d, 0x7a,
+	0xc3, 0xe7, 0xe4, 0x0d, 0x6d, 0x93, 0x65, 0xff, 0xa7, 0x0c, 0x06, 0xd6, 0x3c, 0xc7, 0xe4, 0x4c,
+	0x27, 0xd6, 0x11, 0x3c, 0x16, 0x87, 0x08, 0xf3, 0x2d, 0x89, 0x46, 0xf2, 0xef, 0xad, 0x3f, 0x54,
+	0xa5, 0xbc, 0x4d, 0xc4, 0x8e, 0xa4, 0x75, 0x14, 0xc9, 0xfa, 0x14, 0xc0, 0xe7, 0x3c, 0x89, 0xae,
+	0xa6, 0x9c, 0xa5, 0x98, 0xbd, 0x15, 0xcc, 0xde, 0xf7, 0xf2, 0xd9, 0x9b, 0xa9, 0x3f, 0x48, 0xfc,
+	0x30, 0x9a, 0xa6, 0x5e, 0xc6, 0xee, 0xe6, 0x24, 0xad, 0x1d, 0xa8, 0x87, 0x8c, 0xfb, 0xd1, 0x50,
+	0x24, 0xb5, 0xe1, 0xaa, 0xd3, 0xfe, 0x37, 0x60, 0xce, 0xcb, 0xa1, 0x4b, 0xaa, 0xfc, 0x7e, 0xc2,
+	0x94, 0x59, 0xe2, 0x9b, 0xea, 0xe2, 0x96, 0x8d, 0xc3, 0x38, 0xf1, 0xa2, 0x50, 0x35, 0x97, 0x86,
+	0x04, 0x3a, 0x21, 0x65, 0xb6, 0x22, 0x0a, 0x39, 0x59, 0x36, 0x20, 0xa1, 0x3e, 0x49, 0xbf, 0x06,
+	0x35, 0x7c, 0xcc, 0x94, 0x89, 0x9a, 0xd9, 0x70, 0xe5, 0xc1, 0xfe, 0xbe, 0x04, 0x7b, 0xb3, 0x24,
+	0xd4, 0xa9, 0xaf, 0x0b, 0xfe, 0x03, 0xd8, 0x52, 0x96, 0x69, 0x0a, 0xde, 0x5c, 0x12, 0xc6, 0x6f,
+	0x4a, 0x42, 0x4f, 0xe2, 0x68, 0x00, 0x5a, 0x1c, 0x8d, 0xd2, 0x48, 0x18, 0x66, 0xb8, 0xe2, 0xdb,
+	0xfa, 0x18, 0xea, 0x09, 0xf3, 0xd3, 0x58, 0x96, 0x71, 0xeb, 0xe8, 0xf5, 0xbc, 0xd7, 0x66, 0xd7,
+	0x4a, 0x1e, 0x57, 0xf1, 0x52, 0xf5, 0x24, 0x6c, 0x32, 0xbc, 0xf7, 0x46, 0xa8, 0xdc, 0x1f, 0x48,
+	0x8b, 0x0d, 0x77, 0x43, 0x80, 0xe7, 0x12, 0xb3, 0xff, 0x05, 0x4d, 0x6d, 0xd3, 0x94, 0x80, 0xec,
+	0xfe, 0x52, 0xee, 0xfe, 0x42, 0x27, 0x21, 0xc3, 0xaa, 0x4b, 0x3b, 0x49, 0x45, 0x50, 0x8b, 0x9d,
+	0x24, 0xf7, 0x68, 0x69, 0x82, 0x91, 0xea, 0xe7, 0xda, 0x23, 0xd8, 0x49, 0x18, 0xb6, 0xad, 0x20,
+	0x1a, 0x46, 0x3e, 0xcf, 0x3b, 0xed, 0xcf, 0xd0, 0x40, 0x4b, 0xe3, 0x84, 0x33, 0xf2, 0x15, 0x25,
+	0xcb, 0x5e, 0xa1, 0x51, 0xe6, 0xad, 0x76, 0x33, 0x56, 0xeb, 0x75, 0x30, 0xf8, 0x0d, 0x26, 0xd1,
+	0x4d, 0x3c, 0x94, 0xd1, 0x2d, 0xb9, 0x33, 0xc0, 0xfe, 0xb9, 0x0c, 0xaf, 0xcd, 0xdd, 0xc7, 0xc6,
+	0x3c, 0xb9, 0x9f, 0x33, 0xb3, 0x34, 0x67, 0xe6, 0xc2, 0xa8, 0xbc, 0x07, 0x9b, 0xc3, 0x38, 0xf0,
+	0x87, 0x5e, 0xb1, 0xcb, 0x56, 0xdd, 0xa6, 0x80, 0xbb, 0xda, 0x41, 0xcf, 0xc0, 0x2c, 0xf0, 0xe9,
+	0x86, 0x5b, 0x75, 0x5b, 0x39, 0x46, 0xf2, 0xd5, 0x87, 0x60, 0xe9, 0x77, 0xe4, 0x94, 0xd6, 0x04,
+	0xaf, 0xa9, 0x29, 0x99, 0xde, 0x03, 0xd8, 0x9e, 0xe7, 0xd6, 0xcd, 0xb8, 0xea, 0x6e, 0x15, 0xd9,
+	0x49, 0xfb, 0x1f, 0x01, 0xc2, 0xe8, 0x96, 0x25, 0x03, 0x36, 0x0e, 0x64, 0x47, 0x2e, 0xb9, 0x39,
+	0xc4, 0xda, 0x87, 0x86, 0x3a, 0x85, 0xa2, 0x19, 0x37, 0xdc, 0xec, 0x6c, 0xed, 0xc2, 0xda, 0xf5,
+	0xd0, 0x1f, 0x10, 0xc9, 0x10, 0x24, 0x7d, 0xb4, 0xbf, 0x2d, 0xc1, 0xe3, 0x07, 0x11, 0xa4, 0xab,
+	0xad, 0xbf, 0xc2, 0x1a, 0xf9, 0x36, 0xc2, 0x62, 0x97, 0xf1, 0x7b, 0x2b, 0x1f, 0xbf, 0x45, 0x51,
+	0x70, 0xb5, 0x00, 0x8e, 0xb8, 0x96, 0xbe, 0xdb, 0x13, 0x7b, 0x86, 0x2a, 0xd4, 0xa6, 0x46, 0x4f,
+	0x08, 0xa4, 0x14, 0x57, 0x76, 0x28, 0x2e, 0x59, 0xaf, 0x1b, 0x0a, 0x14, 0x4c, 0xf6, 0xbf, 0xcb,
+	0xb0, 0xa7, 0x63, 0x7b, 0xe5, 0x8f, 0xc3, 0xbb, 0x28, 0xe4, 0x37, 0x59, 0x9a, 0xbd, 0x24, 0xf0,
+	0x18, 0xbc, 0x91, 0xff, 0x22, 0x27, 0x37, 0x9d, 0x28, 0x53, 0x5a, 0x88, 0x1f, 0x6b, 0xf8, 0x72,
+	0x42, 0xc1, 0x2b, 0x72, 0x86, 0xf1, 0x9d, 0x9e, 0xbb, 0x66, 0x9e, 0xf7, 0x14, 0x71, 0x9a, 0x7e,
+	0xe1, 0x34, 0x91, 0x6f, 0x4f, 0x59, 0xa0, 0x26, 0xf0, 0xba, 0xc6, 0x7a, 0x2c, 0xa0, 0xae, 0x71,
+	0xe5, 0xa7, 0xac, 0x78, 0xb7, 0x1c, 0xc5, 0x9b, 0x44, 0xc8, 0x5f, 0x8e, 0xb9, 0x30, 0xc7, 0x2b,
+	0x6e, 0x97, 0x83, 0x79, 0xab, 0xc0, 0x4d, 0xd7, 0xdb, 0x07, 0xb0, 0x9b, 0x4e, 0xaf, 0xd2, 0x00,
+	0xdb, 0x24, 0x4b, 0x64, 0x0d, 0x65, 0x1e, 0x59, 0xd0, 0x01, 0xec, 0xef, 0xca, 0xf0, 0xe4, 0x81,
+	0x80, 0x5c, 0xe9, 0x16, 0x76, 0x8c, 0xa2, 0x57, 0xcb, 0xf3, 0x5e, 0xc5, 0xd2, 0x09, 0xd9, 0x90,
+	0xfb, 0x0f, 0x4b, 0x47, 0xc0, 0xf9, 0xd2, 0x29, 0xf0, 0xe5, 0x4a, 0x27, 0xc7, 0x48, 0xc9, 0x8d,
+	0x1a, 0x79, 0xcc, 0x0b, 0xc5, 0x28, 0xeb, 0xa6, 0x29, 0xe0, 0xbc, 0xc6, 0x02, 0xdf, 0xac, 0x62,
+	0x5a, 0x39, 0x46, 0xd2, 0xf8, 0x04, 0xd6, 0x68, 0x75, 0xf1, 0x46, 0xa9, 0xa8, 0x95, 0x8a, 0x5b,
+	0xa7, 0xe3, 0x79, 0x4a, 0x49, 0xa7, 0xdf, 0x86, 0x63, 0x21, 0x2b, 0x16, 0xbd, 0xf0, 0x38, 0x84,
+	0xd9, 0x5f, 0x82, 0x79, 0x83, 0x1e, 0x8f, 0x31, 0x5b, 0x57, 0x39, 0x16, 0x07, 0x6d, 0xc5, 0x9f,
+	0x8c, 0x95, 0x87, 0xe8, 0x73, 0xce, 0x75, 0x95, 0xf9, 0x86, 0xe9, 0x40, 0x2b, 0xf0, 0x71, 0xd3,
+	0x8a, 0xf8, 0xbd, 0xc7, 0x92, 0x24, 0x4e, 0xb4, 0x8a, 0xd2, 0x4c, 0x05, 0x26, 0x17, 0xa5, 0xa2,
+	0x12, 0x4a, 0x55, 0xc2, 0xae, 0x23, 0xa6, 0xe6, 0x4c, 0x6a, 0xff, 0x54, 0x82, 0xed, 0x90, 0xdd,
+	0x46, 0x01, 0xf3, 0x6e, 0x70, 0x78, 0xbf, 0x6a, 0x39, 0xec, 0x41, 0x63, 0xe4, 0x07, 0x9e, 0x1f,
+	0x86, 0x89, 0xb2, 0x79, 0x0d, 0xcf, 0x6d, 0x3c, 0xd2, 0x58, 0x4e, 0xe3, 0x69, 0x12, 0x30, 0x3d,
+	0x96, 0xe5, 0x89, 0x26, 0xaa, 0xba, 0x48, 0x4c, 0x54, 0x39, 0x01, 0x40, 0x42, 0x62, 0xa2, 0xb6,
+	0xa0, 0x1c, 0xa7, 0x22, 0x5a, 0x86, 0x8b, 0x5f, 0xa4, 0x48, 0xce, 0x5b, 0x11, 0x18, 0x54, 0x24,
+	0x4f, 0x34, 0x79, 0x47, 0x31, 0x86, 0x5d, 0x84, 0xc3, 0x70, 0xe5, 0xc1, 0x8e, 0x60, 0x07, 0xeb,
+	0x67, 0x9a, 0x08, 0x7f, 0x20, 0xe7, 0x2b, 0x3f, 0x05, 0x5b, 0x1a, 0xf6, 0x1a, 0x6c, 0x13, 0xd9,
+	0x4b, 0xd4, 0x91, 0x0c, 0xc8, 0x8d, 0x5b, 0x43, 0x0f, 0x54, 0xfb, 0xbf, 0x25, 0xd8, 0x09, 0x30,
+	0xaa, 0x83, 0xdf, 0x7f, 0xc2, 0x2f, 0x6a, 0x33, 0x95, 0xdf, 0xd0, 0x66, 0xaa, 0x4b, 0xda, 0x0c,
+	0x26, 0xf1, 0xed, 0xd0, 0x17, 0xd6, 0xc8, 0xce, 0x51, 0xa7, 0x23, 0x1a, 0x81, 0x23, 0xfd, 0x3a,
+	0x1a, 0xe2, 0xee, 0x40, 0x24, 0xe9, 0xe7, 0x86, 0x04, 0x30, 0xc7, 0xbe, 0x01, 0xb1, 0xc0, 0x79,
+	0xf8, 0x8c, 0xf8, 0xfa, 0x3a, 0x7b, 0x24, 0x26, 0x1a, 0x1e, 0xc5, 0xb3, 0x1a, 0x2e, 0x7d, 0x52,
+	0x9b, 0x1e, 0xfb, 0x58, 0x6c, 0x21, 0xfa, 0x3d, 0xba, 0x8e, 0x32, 0x57, 0x36, 0x11, 0xed, 0x64,
+	0x20, 0x79, 0x07, 0xe7, 0xdc, 0x10, 0xbb, 0x74, 0xca, 0x65, 0xcb, 0xcb, 0x32, 0x7b, 0x53, 0x12,
+	0x7a, 0x12, 0xc7, 0xbb, 0x8f, 0x29, 0x9e, 0xaa, 0xba, 0x28, 0x9c, 0x69, 0x76, 0x3d, 0xc6, 0x9f,
+	0x32, 0x48, 0x4e, 0x13, 0x8c, 0xbf, 0x38, 0x2c, 0xf2, 0xa6, 0xfd, 0xbf, 0x52, 0xae, 0x44, 0x49,
+	0x49, 0x61, 0x0f, 0x34, 0xd4, 0x1e, 0xf8, 0x92, 0x1e, 0xa5, 0x15, 0x57, 0x1e, 0x56, 0x6b, 0x75,
+	0x56, 0x6a, 0xb9, 0x2e, 0x51, 0x2b, 0x74, 0x09, 0x4a, 0x7b, 0xdd, 0xe0, 0x91, 0x58, 0x17, 0x44,
+	0xd0, 0x10, 0x32, 0x14, 0x96, 0xaa, 0xb5, 0x95, 0x4b, 0x55, 0x63, 0x7e, 0xa9, 0x9a, 0x65, 0xa8,
+	0x51, 0xc8, 0xd0, 0xaf, 0x68, 0x05, 0xc6, 0x4d, 0x70, 0x18, 0x8d, 0x22, 0x3e, 0x6b, 0x0f, 0x5f,
+	0xb3, 0x7b, 0xdd, 0x1e, 0xf0, 0x73, 0xb6, 0xc2, 0xca, 0x37, 0xcb, 0x83, 0xf5, 0x2e, 0xb4, 0x12,
+	0x86, 0x43, 0xd8, 0xf3, 0xaf, 0x29, 0x2d, 0xd0, 0x66, 0x35, 0x4c, 0x05, 0xda, 0x26, 0xf0, 0x3c,
+	0x3d, 0xfa, 0xa1, 0x81, 0x5b, 0x7c, 0xf6, 0xd3, 0x1f, 0x97, 0xb4, 0x1a, 0x86, 0x14, 0x87, 0xfd,
+	0xa2, 0x9f, 0xa1, 0xfb, 0x8f, 0x17, 0x6e, 0xf7, 0xf6, 0x23, 0x0b, 0x9b, 0x98, 0xfe, 0xe5, 0xa0,
+	0x86, 0xc8, 0x7e, 0x9e, 0xb5, 0xf8, 0xbf, 0x82, 0xe5, 0x6a, 0xfe, 0x02, 0x55, 0xfa, 0xbd, 0x6c,
+	0xed, 0x2e, 0xfb, 0x05, 0xbd, 0x5c, 0xf4, 0x13, 0x6c, 0xa3, 0xe8, 0xb4, 0xd9, 0xb2, 0xfe, 0x1b,
+	0x5f, 0xd0, 0x83, 0xad, 0x07, 0xfb, 0xbe, 0xf5, 0x74, 0xf1, 0x5e, 0x3e, 0xd7, 0x2c, 0x96, 0x2b,
+	0xed, 0x83, 0xa1, 0xd7, 0x22, 0x66, 0xd9, 0x2b, 0xb6, 0x25, 0xad, 0xe9, 0xed, 0x95, 0x3c, 0xb4,
+	0x85, 0xa1, 0xd6, 0x2f, 0xe1, 0x71, 0xca, 0xb8, 0xf7, 0x60, 0x05, 0x2a, 0x9a, 0xbb, 0x74, 0x43,
+	0x5a, 0x6e, 0xee, 0x00, 0x76, 0xee, 0x7c, 0x1e, 0xdc, 0x78, 0xf3, 0x9b, 0x81, 0xf5, 0x6e, 0x41,
+	0xf3, 0x92, 0x45, 0x63, 0xff, 0x9d, 0x95, 0x5c, 0x32, 0x09, 0xec, 0x47, 0x7f, 0x2a, 0x59, 0x6d,
+	0x68, 0xe8, 0x61, 0x6a, 0x15, 0x7e, 0xfb, 0xcc, 0x8f, 0xd8, 0xe5, 0xb6, 0x7e, 0x96, 0x4d, 0x21,
+	0x1a, 0x77, 0xd6, 0x9b, 0x79, 0xbe, 0x05, 0x73, 0x70, 0xb9, 0xa2, 0x73, 0x68, 0x15, 0xe7, 0x4d,
+	0x31, 0x50, 0x8b, 0x67, 0xd1, 0x4a, 0x75, 0xc5, 0x91, 0x52, 0x54, 0xb7, 0x78, 0xdc, 0xac, 0x7c,
+	0x66, 0xae, 0x73, 0x17, 0x9f, 0xb9, 0xa0, 0xa5, 0x2f, 0x57, 0x74, 0x09, 0x66, 0x16, 0x11, 0xd5,
+	0x88, 0xe7, 0x1f, 0xba, 0xa8, 0x49, 0xef, 0xef, 0x2d, 0xe5, 0xa1, 0x48, 0x1e, 0xbf, 0xff, 0x8f,
+	0xa7, 0x23, 0x7f, 0x30, 0xf2, 0x0f, 0xaf, 0xd9, 0xe0, 0x70, 0x80, 0xf1, 0xbd, 0xf3, 0xef, 0x0f,
+	0x53, 0x96, 0x50, 0x00, 0xd2, 0x43, 0x14, 0x3d, 0x94, 0xa2, 0x57, 0x75, 0xf1, 0xf7, 0xa3, 0x5f,
+	0x01, 0x5d, 0xfd, 0xce, 0xb2, 0x68, 0x14, 0x00, 0x00,
 }
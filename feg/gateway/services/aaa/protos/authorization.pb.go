@@ -43,13 +43,21 @@ func (x CoaResponseCoaResponseTypeEnum) String() string {
 	return proto.EnumName(CoaResponseCoaResponseTypeEnum_name, int32(x))
 }
 func (CoaResponseCoaResponseTypeEnum) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_authorization_52a017d59f3b37af, []int{2, 0}
+	return fileDescriptor_authorization_52a017d59f3b37af, []int{3, 0}
 }
 
 // update_request with usages & included context
 type ChangeRequest struct {
-	Ctx                  *Context `protobuf:"bytes,1,opt,name=ctx,proto3" json:"ctx,omitempty"`
-	JsonTrficClasses     string   `protobuf:"bytes,2,opt,name=json_trfic_classes,json=jsonTrficClasses,proto3" json:"json_trfic_classes,omitempty"`
+	Ctx              *Context `protobuf:"bytes,1,opt,name=ctx,proto3" json:"ctx,omitempty"`
+	JsonTrficClasses string   `protobuf:"bytes,2,opt,name=json_trfic_classes,json=jsonTrficClasses,proto3" json:"json_trfic_classes,omitempty"`
+	// maximum session bandwidth in bits per second, sent as WISPr-Bandwidth-Max-Up/Down if set
+	MaxBandwidthUp   uint32 `protobuf:"varint,3,opt,name=max_bandwidth_up,json=maxBandwidthUp,proto3" json:"max_bandwidth_up,omitempty"`
+	MaxBandwidthDown uint32 `protobuf:"varint,4,opt,name=max_bandwidth_down,json=maxBandwidthDown,proto3" json:"max_bandwidth_down,omitempty"`
+	// quarantine - moves the session to the quarantine profile, if set
+	Quarantine *QuarantineProfile `protobuf:"bytes,5,opt,name=quarantine,proto3" json:"quarantine,omitempty"`
+	// policy VLAN (Tunnel-Private-Group-Id) & filter (Filter-Id) of the session, sent if set
+	VlanId               uint32   `protobuf:"varint,6,opt,name=vlan_id,json=vlanId,proto3" json:"vlan_id,omitempty"`
+	FilterId             string   `protobuf:"bytes,7,opt,name=filter_id,json=filterId,proto3" json:"filter_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -93,18 +101,112 @@ func (m *ChangeRequest) GetJsonTrficClasses() string {
 	return ""
 }
 
-type DisconnectRequest struct {
-	Ctx                  *Context `protobuf:"bytes,1,opt,name=ctx,proto3" json:"ctx,omitempty"`
+func (m *ChangeRequest) GetMaxBandwidthUp() uint32 {
+	if m != nil {
+		return m.MaxBandwidthUp
+	}
+	return 0
+}
+
+func (m *ChangeRequest) GetMaxBandwidthDown() uint32 {
+	if m != nil {
+		return m.MaxBandwidthDown
+	}
+	return 0
+}
+
+func (m *ChangeRequest) GetQuarantine() *QuarantineProfile {
+	if m != nil {
+		return m.Quarantine
+	}
+	return nil
+}
+
+func (m *ChangeRequest) GetVlanId() uint32 {
+	if m != nil {
+		return m.VlanId
+	}
+	return 0
+}
+
+func (m *ChangeRequest) GetFilterId() string {
+	if m != nil {
+		return m.FilterId
+	}
+	return ""
+}
+
+// quarantine_profile - restricted network access of sessions quarantined by security events, the NAS is sent the
+// profile's VLAN (Tunnel-Private-Group-Id), filter (Filter-Id) & remediation page URL (WISPr-Redirection-URL)
+type QuarantineProfile struct {
+	VlanId               uint32   `protobuf:"varint,1,opt,name=vlan_id,json=vlanId,proto3" json:"vlan_id,omitempty"`
+	FilterId             string   `protobuf:"bytes,2,opt,name=filter_id,json=filterId,proto3" json:"filter_id,omitempty"`
+	RedirectUrl          string   `protobuf:"bytes,3,opt,name=redirect_url,json=redirectUrl,proto3" json:"redirect_url,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
+func (m *QuarantineProfile) Reset()         { *m = QuarantineProfile{} }
+func (m *QuarantineProfile) String() string { return proto.CompactTextString(m) }
+func (*QuarantineProfile) ProtoMessage()    {}
+func (*QuarantineProfile) Descriptor() ([]byte, []int) {
+	return fileDescriptor_authorization_52a017d59f3b37af, []int{1}
+}
+func (m *QuarantineProfile) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QuarantineProfile.Unmarshal(m, b)
+}
+func (m *QuarantineProfile) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QuarantineProfile.Marshal(b, m, deterministic)
+}
+func (dst *QuarantineProfile) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QuarantineProfile.Merge(dst, src)
+}
+func (m *QuarantineProfile) XXX_Size() int {
+	return xxx_messageInfo_QuarantineProfile.Size(m)
+}
+func (m *QuarantineProfile) XXX_DiscardUnknown() {
+	xxx_messageInfo_QuarantineProfile.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QuarantineProfile proto.InternalMessageInfo
+
+func (m *QuarantineProfile) GetVlanId() uint32 {
+	if m != nil {
+		return m.VlanId
+	}
+	return 0
+}
+
+func (m *QuarantineProfile) GetFilterId() string {
+	if m != nil {
+		return m.FilterId
+	}
+	return ""
+}
+
+func (m *QuarantineProfile) GetRedirectUrl() string {
+	if m != nil {
+		return m.RedirectUrl
+	}
+	return ""
+}
+
+type DisconnectRequest struct {
+	Ctx *Context `protobuf:"bytes,1,opt,name=ctx,proto3" json:"ctx,omitempty"`
+	// reason & reply_message of the disconnect, sent to the NAS as Reply-Message (& optionally Error-Cause)
+	Reason               TerminateReason `protobuf:"varint,2,opt,name=reason,proto3,enum=aaa.protos.TerminateReason" json:"reason,omitempty"`
+	ReplyMessage         string          `protobuf:"bytes,3,opt,name=reply_message,json=replyMessage,proto3" json:"reply_message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
 func (m *DisconnectRequest) Reset()         { *m = DisconnectRequest{} }
 func (m *DisconnectRequest) String() string { return proto.CompactTextString(m) }
 func (*DisconnectRequest) ProtoMessage()    {}
 func (*DisconnectRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_authorization_52a017d59f3b37af, []int{1}
+	return fileDescriptor_authorization_52a017d59f3b37af, []int{2}
 }
 func (m *DisconnectRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DisconnectRequest.Unmarshal(m, b)
@@ -131,6 +233,20 @@ func (m *DisconnectRequest) GetCtx() *Context {
 	return nil
 }
 
+func (m *DisconnectRequest) GetReason() TerminateReason {
+	if m != nil {
+		return m.Reason
+	}
+	return TerminateReason_UNSPECIFIED_REASON
+}
+
+func (m *DisconnectRequest) GetReplyMessage() string {
+	if m != nil {
+		return m.ReplyMessage
+	}
+	return ""
+}
+
 type CoaResponse struct {
 	CoaResponseType      CoaResponseCoaResponseTypeEnum `protobuf:"varint,1,opt,name=coa_response_type,json=coaResponseType,proto3,enum=aaa.protos.CoaResponseCoaResponseTypeEnum" json:"coa_response_type,omitempty"`
 	Ctx                  *Context                       `protobuf:"bytes,2,opt,name=ctx,proto3" json:"ctx,omitempty"`
@@ -143,7 +259,7 @@ func (m *CoaResponse) Reset()         { *m = CoaResponse{} }
 func (m *CoaResponse) String() string { return proto.CompactTextString(m) }
 func (*CoaResponse) ProtoMessage()    {}
 func (*CoaResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_authorization_52a017d59f3b37af, []int{2}
+	return fileDescriptor_authorization_52a017d59f3b37af, []int{3}
 }
 func (m *CoaResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CoaResponse.Unmarshal(m, b)
@@ -179,6 +295,7 @@ func (m *CoaResponse) GetCtx() *Context {
 
 func init() {
 	proto.RegisterType((*ChangeRequest)(nil), "aaa.protos.change_request")
+	proto.RegisterType((*QuarantineProfile)(nil), "aaa.protos.quarantine_profile")
 	proto.RegisterType((*DisconnectRequest)(nil), "aaa.protos.disconnect_request")
 	proto.RegisterType((*CoaResponse)(nil), "aaa.protos.coa_response")
 	proto.RegisterEnum("aaa.protos.CoaResponseCoaResponseTypeEnum", CoaResponseCoaResponseTypeEnum_name, CoaResponseCoaResponseTypeEnum_value)
@@ -292,25 +409,37 @@ var _Authorization_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("authorization.proto", fileDescriptor_authorization_52a017d59f3b37af) }
 
 var fileDescriptor_authorization_52a017d59f3b37af = []byte{
-	// 317 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x51, 0xdf, 0x4b, 0x02, 0x41,
-	0x10, 0xf6, 0x14, 0x8c, 0xa6, 0x34, 0x5b, 0x21, 0xc4, 0x87, 0x90, 0x03, 0x49, 0x22, 0x6e, 0xc1,
-	0x1e, 0x7b, 0xc9, 0x7c, 0x09, 0x84, 0x1e, 0x0e, 0x9f, 0xea, 0x61, 0x99, 0xb6, 0xf1, 0xbc, 0xc8,
-	0xdd, 0x6b, 0x77, 0x2d, 0xed, 0x5f, 0xe9, 0x7f, 0xe9, 0x6f, 0x8b, 0xf3, 0x8c, 0xf3, 0xb0, 0x1f,
-	0xf4, 0xb4, 0x33, 0xf3, 0xcd, 0xf7, 0xcd, 0xec, 0x37, 0xd0, 0xc4, 0xb9, 0x9b, 0x6a, 0x13, 0xbf,
-	0xa1, 0x8b, 0xb5, 0x0a, 0x12, 0xa3, 0x9d, 0x66, 0x80, 0x88, 0x59, 0x68, 0xdb, 0x35, 0xa9, 0x95,
-	0xa3, 0x85, 0xcb, 0x72, 0x9f, 0xa0, 0x2e, 0xa7, 0xa8, 0x22, 0x12, 0x86, 0x9e, 0xe7, 0x64, 0x1d,
-	0xeb, 0x42, 0x45, 0xba, 0x45, 0xcb, 0xeb, 0x78, 0xbd, 0xbd, 0x7e, 0x33, 0xc8, 0xa9, 0xc1, 0x9a,
-	0x19, 0xa6, 0x38, 0x3b, 0x03, 0xf6, 0x68, 0xb5, 0x12, 0xce, 0x4c, 0x62, 0x29, 0xe4, 0x13, 0x5a,
-	0x4b, 0xb6, 0x55, 0xee, 0x78, 0xbd, 0xdd, 0xb0, 0x91, 0x22, 0xe3, 0x14, 0x18, 0x66, 0x75, 0xff,
-	0x02, 0xd8, 0x43, 0x6c, 0xa5, 0x56, 0x8a, 0xa4, 0xfb, 0xe7, 0x28, 0xff, 0xc3, 0x83, 0x7d, 0xa9,
-	0x51, 0x18, 0xb2, 0x89, 0x56, 0x96, 0xd8, 0x1d, 0x1c, 0x6e, 0xe6, 0xc2, 0x2d, 0x13, 0x5a, 0xa9,
-	0xd4, 0xfb, 0xbc, 0xa8, 0x92, 0x37, 0x05, 0x5b, 0x0c, 0x41, 0x6a, 0x3e, 0x0b, 0x0f, 0xa4, 0xc6,
-	0x70, 0x5d, 0x1e, 0x2f, 0x13, 0xfa, 0x5a, 0xaa, 0xfc, 0xc7, 0x52, 0xa7, 0x70, 0xf4, 0xbd, 0x22,
-	0xdb, 0x81, 0xca, 0xcd, 0x60, 0xd4, 0x28, 0xa5, 0xc1, 0x60, 0x38, 0x6a, 0x78, 0xfd, 0x77, 0x0f,
-	0x6a, 0x85, 0xbb, 0xb0, 0x4b, 0xa8, 0x66, 0xb6, 0xb3, 0x76, 0x61, 0x42, 0xe1, 0x14, 0xed, 0xd6,
-	0x4f, 0x9f, 0xf1, 0x4b, 0xec, 0x1a, 0x20, 0x77, 0x94, 0x1d, 0x6f, 0x76, 0x6e, 0x3b, 0xfd, 0x9b,
-	0xd2, 0xd5, 0xc9, 0x6d, 0x77, 0x86, 0xd1, 0x0c, 0xf9, 0x84, 0x22, 0x1e, 0xa1, 0xa3, 0x57, 0x5c,
-	0x72, 0x4b, 0xe6, 0x25, 0x96, 0x64, 0x39, 0x22, 0xf2, 0x8c, 0x77, 0x5f, 0x5d, 0xbd, 0xe7, 0x9f,
-	0x01, 0x00, 0x00, 0xff, 0xff, 0x80, 0x35, 0xb2, 0x4e, 0x64, 0x02, 0x00, 0x00,
+	// 501 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x95, 0x53, 0xc1, 0x6e, 0xd3, 0x40,
+	0x10, 0xad, 0x13, 0x48, 0x9a, 0x69, 0x12, 0xdc, 0xad, 0x04, 0x56, 0x40, 0x08, 0x8c, 0xaa, 0x56,
+	0x08, 0xc5, 0x52, 0xe0, 0x8c, 0x68, 0xcb, 0x81, 0xaa, 0x82, 0x83, 0xd5, 0x5e, 0xe0, 0xb0, 0xda,
+	0xda, 0x13, 0x67, 0x91, 0xbd, 0x76, 0x77, 0xd7, 0x4d, 0xc2, 0x87, 0x70, 0xe1, 0x33, 0xb8, 0xf3,
+	0x6d, 0xac, 0xed, 0x44, 0xb1, 0x15, 0x5a, 0x89, 0x93, 0x77, 0xdf, 0xbc, 0xf7, 0x66, 0x66, 0xc7,
+	0x03, 0x07, 0x2c, 0xd7, 0xb3, 0x54, 0xf2, 0x1f, 0x4c, 0xf3, 0x54, 0x8c, 0x33, 0x99, 0xea, 0x94,
+	0x00, 0x63, 0xac, 0x3a, 0xaa, 0xd1, 0x20, 0x48, 0x85, 0xc6, 0x85, 0xae, 0xee, 0xee, 0xef, 0x16,
+	0x0c, 0x83, 0x19, 0x13, 0x11, 0x52, 0x89, 0x37, 0x39, 0x2a, 0x4d, 0x0e, 0xa1, 0x1d, 0xe8, 0x85,
+	0x63, 0xbd, 0xb0, 0x8e, 0xf7, 0x26, 0x07, 0xe3, 0x8d, 0x76, 0xbc, 0x92, 0xfa, 0x45, 0x9c, 0xbc,
+	0x01, 0xf2, 0x5d, 0xa5, 0x82, 0x6a, 0x39, 0xe5, 0x01, 0x0d, 0x62, 0xa6, 0x14, 0x2a, 0xa7, 0x65,
+	0x54, 0x3d, 0xdf, 0x2e, 0x22, 0x97, 0x45, 0xe0, 0xac, 0xc2, 0xc9, 0x31, 0xd8, 0x09, 0x5b, 0xd0,
+	0x6b, 0x26, 0xc2, 0x39, 0x0f, 0xf5, 0x8c, 0xe6, 0x99, 0xd3, 0x36, 0xdc, 0x81, 0x3f, 0x34, 0xf8,
+	0xe9, 0x1a, 0xbe, 0xca, 0x0a, 0xdf, 0x26, 0x33, 0x4c, 0xe7, 0xc2, 0x79, 0x50, 0x72, 0xed, 0x3a,
+	0xf7, 0xa3, 0xc1, 0xc9, 0x7b, 0x80, 0x9b, 0x9c, 0x49, 0x26, 0x34, 0x17, 0xe8, 0x3c, 0x2c, 0x6b,
+	0x7e, 0x5e, 0xaf, 0x79, 0x13, 0xa5, 0x06, 0x99, 0xf2, 0x18, 0xfd, 0x9a, 0x82, 0x3c, 0x81, 0xee,
+	0x6d, 0xcc, 0x04, 0xe5, 0xa1, 0xd3, 0x29, 0x53, 0x74, 0x8a, 0xeb, 0x79, 0x48, 0x9e, 0x42, 0xcf,
+	0x90, 0x35, 0xca, 0x22, 0xd4, 0x2d, 0xbb, 0xda, 0xad, 0x80, 0xf3, 0xd0, 0x4d, 0x80, 0x6c, 0xfb,
+	0xd6, 0xbd, 0xac, 0xbb, 0xbd, 0x5a, 0x4d, 0x2f, 0xf2, 0x12, 0xfa, 0x12, 0x43, 0x2e, 0x31, 0xd0,
+	0x34, 0x97, 0x71, 0xf9, 0x2a, 0x3d, 0x7f, 0x6f, 0x8d, 0x5d, 0xc9, 0xd8, 0xfd, 0x69, 0x01, 0x09,
+	0xb9, 0x32, 0xcf, 0x2f, 0x0a, 0xd6, 0x7f, 0x0e, 0xea, 0x1d, 0x74, 0x24, 0x32, 0x33, 0x90, 0x32,
+	0xf5, 0x70, 0xf2, 0xac, 0xce, 0x34, 0x35, 0x24, 0x5c, 0x30, 0x5d, 0x8c, 0xbf, 0xe0, 0xf8, 0x2b,
+	0x2e, 0x79, 0x05, 0x03, 0x89, 0x59, 0xbc, 0xa4, 0x09, 0x2a, 0xc5, 0x22, 0x5c, 0xd5, 0xd5, 0x2f,
+	0xc1, 0xcf, 0x15, 0xe6, 0xfe, 0xb1, 0xa0, 0x1f, 0xa4, 0xcc, 0x68, 0x55, 0x96, 0x0a, 0x85, 0xe4,
+	0x1b, 0xec, 0xd7, 0xef, 0x54, 0x2f, 0x33, 0x2c, 0x0b, 0x1c, 0x4e, 0xbc, 0x66, 0x81, 0x1b, 0xd2,
+	0x78, 0x4b, 0x41, 0x51, 0xe4, 0x89, 0xff, 0xc8, 0xe0, 0xfe, 0x0a, 0xbe, 0x34, 0xe8, 0xba, 0xdf,
+	0xd6, 0xfd, 0xfd, 0xba, 0xaf, 0xe1, 0xf1, 0xbf, 0x1d, 0x49, 0x17, 0xda, 0x5f, 0x4e, 0x2e, 0xec,
+	0x9d, 0xe2, 0x70, 0x72, 0x76, 0x61, 0x5b, 0x93, 0x5f, 0x16, 0x0c, 0x1a, 0x1b, 0x43, 0x3e, 0x40,
+	0xa7, 0xda, 0x07, 0x32, 0x6a, 0x64, 0x68, 0xec, 0xc8, 0xc8, 0xb9, 0xab, 0x19, 0x77, 0x87, 0x7c,
+	0x02, 0xd8, 0x0c, 0x8b, 0x34, 0x7e, 0xc6, 0xed, 0x21, 0xde, 0xe7, 0x74, 0x7a, 0xf4, 0xf5, 0x30,
+	0x61, 0x51, 0xc2, 0xbc, 0x29, 0x46, 0x5e, 0x64, 0x86, 0x34, 0x67, 0x4b, 0x4f, 0xa1, 0xbc, 0xe5,
+	0x01, 0x2a, 0xcf, 0xe8, 0xbc, 0x4a, 0x77, 0xdd, 0x29, 0xbf, 0x6f, 0xff, 0x02, 0x2b, 0xff, 0xd4,
+	0x19, 0xfe, 0x03, 0x00, 0x00,
 }
@@ -0,0 +1,40 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package protos
+
+import (
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// NewCapacityError returns RESOURCE_EXHAUSTED error of a session rejected by the APN's concurrent sessions limit,
+// the error carries capacity_error details
+func NewCapacityError(apn string, maxSessions uint32) error {
+	st := status.New(codes.ResourceExhausted, fmt.Sprintf("APN %s is at its capacity of %d sessions", apn, maxSessions))
+	if detailed, err := st.WithDetails(&CapacityError{Apn: apn, MaxSessions: maxSessions}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// GetCapacityError returns the capacity_error details of the error, nil if err is not a capacity error
+func GetCapacityError(err error) *CapacityError {
+	st, ok := status.FromError(err)
+	if !ok || err == nil || st.Code() != codes.ResourceExhausted {
+		return nil
+	}
+	for _, d := range st.Details() {
+		if ce, ok := d.(*CapacityError); ok {
+			return ce
+		}
+	}
+	return nil
+}
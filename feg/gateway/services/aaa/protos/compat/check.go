@@ -0,0 +1,68 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package compat
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Check returns descriptions of the backward incompatible changes from the old to the new schema, an empty list if
+// the new schema is compatible. Adding messages, fields & enum values is compatible; removing, renumbering,
+// renaming (JSON encoded contexts are persisted by the Radius server) or changing the type of any of them is not
+func Check(old, new *Schema) []string {
+	var problems []string
+	for msg, oldFields := range old.Messages {
+		newFields, ok := new.Messages[msg]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("message %s was removed", msg))
+			continue
+		}
+		for num, of := range oldFields {
+			nf, ok := newFields[num]
+			switch {
+			case !ok:
+				problems = append(problems, fmt.Sprintf("field %s.%s = %d was removed", msg, of.Name, num))
+			case nf.Name != of.Name:
+				problems = append(problems, fmt.Sprintf("field %s.%s = %d was renamed to %s", msg, of.Name, num, nf.Name))
+			case nf.Type != of.Type || nf.TypeName != of.TypeName:
+				problems = append(problems, fmt.Sprintf("field %s.%s = %d type changed from %s to %s",
+					msg, of.Name, num, typeOf(of), typeOf(nf)))
+			case nf.Label != of.Label:
+				problems = append(problems, fmt.Sprintf("field %s.%s = %d label changed from %s to %s",
+					msg, of.Name, num, of.Label, nf.Label))
+			}
+		}
+	}
+	for enum, oldValues := range old.Enums {
+		newValues, ok := new.Enums[enum]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("enum %s was removed", enum))
+			continue
+		}
+		for num, ov := range oldValues {
+			nv, ok := newValues[num]
+			switch {
+			case !ok:
+				problems = append(problems, fmt.Sprintf("enum value %s.%s = %d was removed", enum, ov, num))
+			case nv != ov:
+				problems = append(problems, fmt.Sprintf("enum value %s.%s = %d was renamed to %s", enum, ov, num, nv))
+			}
+		}
+	}
+	sort.Strings(problems)
+	return problems
+}
+
+func typeOf(f Field) string {
+	if len(f.TypeName) > 0 {
+		return f.TypeName
+	}
+	return f.Type
+}
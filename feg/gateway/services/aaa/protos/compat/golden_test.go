@@ -0,0 +1,114 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package compat_test
+
+import (
+	"encoding/hex"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+
+	"magma/feg/gateway/services/aaa/protos"
+	lte_protos "magma/lte/cloud/go/protos"
+)
+
+var (
+	shortCtx = &protos.Context{SessionId: "sid1", Imsi: "001010000000001"}
+	ctxV1    = &protos.Context{
+		SessionId: "sid1",
+		Imsi:      "001010000000001",
+		Msk:       []byte{0xde, 0xad, 0xbe, 0xef},
+		Identity:  "0001010000000001@wlan.mnc001.mcc001.3gppnetwork.org",
+		Msisdn:    "5551234",
+		Apn:       "magma.wifi",
+		MacAddr:   "aa:bb:cc:dd:ee:ff",
+		IpAddr:    "10.0.0.1",
+	}
+)
+
+func ctxV3() *protos.Context {
+	ctx := proto.Clone(ctxV1).(*protos.Context)
+	ctx.OuterIdentity = "anonymous@wlan.mnc001.mcc001.3gppnetwork.org"
+	ctx.Attributes = map[string]string{"nas_port_type": "19", "venue": "cafe"}
+	return ctx
+}
+
+// goldenCases - messages encoded by current & previous versions, each must decode into the current protos without
+// loss. Latest versions must also encode into exactly the same bytes
+var goldenCases = []struct {
+	file     string
+	expected proto.Message
+	latest   bool
+}{
+	{"context_v1", ctxV1, false},
+	{"context_v3", ctxV3(), true},
+	{"update_request_v1", &protos.UpdateRequest{
+		OctetsIn: 1000, OctetsOut: 20000, PacketsIn: 10, PacketsOut: 20, Ctx: shortCtx}, true},
+	{"stop_request_v1", &protos.StopRequest{Cause: protos.StopRequest_NAS_REQUEST, Ctx: shortCtx}, true},
+	{"change_request_v1", &protos.ChangeRequest{Ctx: shortCtx, JsonTrficClasses: `{"up":1}`}, false},
+	{"change_request_v2", &protos.ChangeRequest{
+		Ctx: shortCtx, MaxBandwidthUp: 1000000, MaxBandwidthDown: 5000000}, true},
+	{"local_create_session_request_v1", &lte_protos.LocalCreateSessionRequest{
+		Sid:             &lte_protos.SubscriberID{Id: "IMSI001010000000001", Type: lte_protos.SubscriberID_IMSI},
+		UeIpv4:          "10.0.0.1",
+		Msisdn:          []byte("5551234"),
+		RatType:         lte_protos.RATType_TGPP_WLAN,
+		HardwareAddr:    []byte{0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff},
+		RadiusSessionId: "sid1",
+	}, true},
+}
+
+func readGolden(t *testing.T, name string) []byte {
+	b, err := ioutil.ReadFile(filepath.Join("testdata", "golden", name+".hex"))
+	assert.NoError(t, err)
+	var hexStr string
+	for _, line := range strings.Split(string(b), "\n") {
+		if !strings.HasPrefix(line, "#") {
+			hexStr += strings.TrimSpace(line)
+		}
+	}
+	res, err := hex.DecodeString(hexStr)
+	assert.NoError(t, err)
+	return res
+}
+
+func marshalDeterministic(t *testing.T, msg proto.Message) []byte {
+	buf := proto.NewBuffer(nil)
+	buf.SetDeterministic(true)
+	assert.NoError(t, buf.Marshal(msg))
+	return buf.Bytes()
+}
+
+func TestGoldenMessages(t *testing.T) {
+	for _, tc := range goldenCases {
+		golden := readGolden(t, tc.file)
+		actual := reflect.New(reflect.TypeOf(tc.expected).Elem()).Interface().(proto.Message)
+		assert.NoError(t, proto.Unmarshal(golden, actual), tc.file)
+		// proto.Equal also compares unknown fields, so fields unknown to the current protos fail the comparison
+		assert.True(t, proto.Equal(tc.expected, actual), "%s decoded into %v, expected %v", tc.file, actual, tc.expected)
+		if tc.latest {
+			assert.Equal(t, golden, marshalDeterministic(t, tc.expected), "%s encoding changed", tc.file)
+		}
+	}
+}
+
+func TestUnknownFieldsPreserved(t *testing.T) {
+	// A message of a newer version must pass through the current version without dropping the newer fields
+	golden := readGolden(t, "context_unknown")
+	ctx := &protos.Context{}
+	assert.NoError(t, proto.Unmarshal(golden, ctx))
+	assert.Equal(t, ctxV3().GetAttributes(), ctx.GetAttributes())
+	assert.NotEmpty(t, ctx.XXX_unrecognized)
+	assert.Equal(t, golden, marshalDeterministic(t, ctx))
+}
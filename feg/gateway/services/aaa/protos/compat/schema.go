@@ -0,0 +1,255 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package compat verifies that AAA protos evolve in a backward compatible way, so mixed versions of the gateway,
+// Radius server & session manager don't silently drop or misinterpret fields during rolling upgrades
+package compat
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/golang/protobuf/descriptor"
+	"github.com/golang/protobuf/proto"
+	dpb "github.com/golang/protobuf/protoc-gen-go/descriptor"
+
+	"magma/feg/gateway/services/aaa/protos"
+	lte_protos "magma/lte/cloud/go/protos"
+)
+
+// Field - wire relevant properties of a message field
+type Field struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Label    string `json:"label"`
+	TypeName string `json:"type_name,omitempty"`
+}
+
+// Schema - fields of messages & values of enums by their full names
+type Schema struct {
+	Messages map[string]map[int32]Field  `json:"messages"`
+	Enums    map[string]map[int32]string `json:"enums"`
+}
+
+// Roots returns messages exchanged by AAA with the Radius server & session manager
+func Roots() []descriptor.Message {
+	return []descriptor.Message{
+		// context.proto
+		&protos.Context{},
+		&protos.Void{},
+		// eap.proto
+		&protos.Eap{},
+		&protos.EapIdentity{},
+		&protos.EapMethodList{},
+		// accounting.proto
+		&protos.UpdateRequest{},
+		&protos.StopRequest{},
+		&protos.AcctResp{},
+		&protos.TerminateSessionRequest{},
+		&protos.ReconciliationRequest{},
+		&protos.ReconciliationReport{},
+		&protos.SessionBandwidthRequest{},
+		&protos.SubscriberUsageRequest{},
+		&protos.SubscriberUsageUpdate{},
+		&protos.HandoverRequest{},
+		&protos.CapacityError{},
+		&protos.DeviceHintRequest{},
+		&protos.SecurityEventRequest{},
+		&protos.ChangeSessionRequest{},
+		&protos.AcctOnOffRequest{},
+		&protos.SessionEventsRequest{},
+		&protos.SessionEvent{},
+		&protos.RateLimitError{},
+		// authorization.proto
+		&protos.ChangeRequest{},
+		&protos.QuarantineProfile{},
+		&protos.DisconnectRequest{},
+		&protos.CoaResponse{},
+		// userdb.proto
+		&protos.UserCredentials{},
+		&protos.UserName{},
+		&protos.DisableUserRequest{},
+		&protos.UserAuthResult{},
+		// policy_hook.proto
+		&protos.PolicyDecisionRequest{},
+		&protos.PolicyDecision{},
+		// enrichment.proto
+		&protos.EnrichmentRequest{},
+		&protos.Enrichment{},
+		// session_admin.proto
+		&protos.SessionPatchRequest{},
+		&protos.FieldChange{},
+		&protos.SessionPatchResult{},
+		&protos.ExportSessionsRequest{},
+		&protos.ExportedSession{},
+		&protos.SessionExport{},
+		&protos.ImportSessionsRequest{},
+		&protos.ImportSessionsResult{},
+		&protos.DumpEffectiveConfigRequest{},
+		&protos.Setting{},
+		&protos.EffectiveConfig{},
+		&protos.GetAuthRatesRequest{},
+		&protos.WindowStats{},
+		&protos.AuthRates{},
+		&protos.ListSessionsRequest{},
+		&protos.SessionList{},
+		&protos.GetSessionRequest{},
+		&protos.TerminateSessionsRequest{},
+		&protos.TerminateSessionsResult{},
+		&protos.GetServiceInfoRequest{},
+		&protos.ServiceInfo{},
+		&protos.AccountingFaults{},
+		&protos.InjectAccountingFaultsRequest{},
+		&protos.InjectedAccountingFaults{},
+		&protos.CoaAttribute{},
+		&protos.CoaTransaction{},
+		&protos.ListCoaTransactionsRequest{},
+		&protos.CoaTransactionList{},
+		// session manager
+		&lte_protos.LocalCreateSessionRequest{},
+		&lte_protos.LocalCreateSessionResponse{},
+		&lte_protos.LocalEndSessionResponse{},
+		&lte_protos.LocalSessionUsage{},
+		&lte_protos.SubscriberID{},
+	}
+}
+
+// Take returns the schema of the given top level messages & all messages & enums they reference
+func Take(roots ...descriptor.Message) (*Schema, error) {
+	idx := &index{
+		files:    map[string]bool{},
+		messages: map[string]*dpb.DescriptorProto{},
+		enums:    map[string]*dpb.EnumDescriptorProto{},
+	}
+	var queue []string
+	for _, msg := range roots {
+		fd, md := descriptor.ForMessage(msg)
+		if err := idx.addFile(fd); err != nil {
+			return nil, err
+		}
+		queue = append(queue, "."+fd.GetPackage()+"."+md.GetName())
+	}
+	s := &Schema{Messages: map[string]map[int32]Field{}, Enums: map[string]map[int32]string{}}
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		if _, ok := s.Messages[name[1:]]; ok {
+			continue
+		}
+		md, ok := idx.messages[name]
+		if !ok {
+			return nil, fmt.Errorf("Unresolved message type %s", name)
+		}
+		fields := map[int32]Field{}
+		for _, fd := range md.GetField() {
+			fields[fd.GetNumber()] = Field{
+				Name:     fd.GetName(),
+				Type:     fd.GetType().String(),
+				Label:    fd.GetLabel().String(),
+				TypeName: fd.GetTypeName(),
+			}
+			switch fd.GetType() {
+			case dpb.FieldDescriptorProto_TYPE_MESSAGE:
+				queue = append(queue, fd.GetTypeName())
+			case dpb.FieldDescriptorProto_TYPE_ENUM:
+				ed, ok := idx.enums[fd.GetTypeName()]
+				if !ok {
+					return nil, fmt.Errorf("Unresolved enum type %s", fd.GetTypeName())
+				}
+				values := map[int32]string{}
+				for _, v := range ed.GetValue() {
+					values[v.GetNumber()] = v.GetName()
+				}
+				s.Enums[fd.GetTypeName()[1:]] = values
+			}
+		}
+		s.Messages[name[1:]] = fields
+	}
+	return s, nil
+}
+
+// ReadSchema reads JSON schema snapshot from the given file
+func ReadSchema(path string) (*Schema, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	s := &Schema{}
+	if err = json.Unmarshal(b, s); err != nil {
+		return nil, fmt.Errorf("Invalid schema snapshot %s: %v", path, err)
+	}
+	return s, nil
+}
+
+// Write writes the schema as a JSON snapshot to the given file
+func (s *Schema) Write(path string) error {
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(b, '\n'), 0644)
+}
+
+// index - messages & enums of the loaded files by their fully qualified names (.package.Message.Nested)
+type index struct {
+	files    map[string]bool
+	messages map[string]*dpb.DescriptorProto
+	enums    map[string]*dpb.EnumDescriptorProto
+}
+
+func (idx *index) addFile(fd *dpb.FileDescriptorProto) error {
+	if idx.files[fd.GetName()] {
+		return nil
+	}
+	idx.files[fd.GetName()] = true
+	idx.addTypes("."+fd.GetPackage(), fd.GetMessageType(), fd.GetEnumType())
+
+	// Referenced types may be defined in imported files, skip imports which are not linked in
+	for _, dep := range fd.GetDependency() {
+		gz := proto.FileDescriptor(dep)
+		if gz == nil || idx.files[dep] {
+			continue
+		}
+		depFd, err := decodeFileDescriptor(gz)
+		if err != nil {
+			return fmt.Errorf("Error decoding %s descriptor: %v", dep, err)
+		}
+		if err = idx.addFile(depFd); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (idx *index) addTypes(prefix string, msgs []*dpb.DescriptorProto, enums []*dpb.EnumDescriptorProto) {
+	for _, ed := range enums {
+		idx.enums[prefix+"."+ed.GetName()] = ed
+	}
+	for _, md := range msgs {
+		name := prefix + "." + md.GetName()
+		idx.messages[name] = md
+		idx.addTypes(name, md.GetNestedType(), md.GetEnumType())
+	}
+}
+
+func decodeFileDescriptor(gz []byte) (*dpb.FileDescriptorProto, error) {
+	r, err := gzip.NewReader(bytes.NewReader(gz))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	fd := &dpb.FileDescriptorProto{}
+	return fd, proto.Unmarshal(b, fd)
+}
@@ -0,0 +1,81 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package compat_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"magma/feg/gateway/services/aaa/protos/compat"
+)
+
+func TestSchemaSnapshot(t *testing.T) {
+	current, err := compat.Take(compat.Roots()...)
+	assert.NoError(t, err)
+	snapshot, err := compat.ReadSchema(filepath.Join("testdata", "schema.json"))
+	assert.NoError(t, err)
+
+	assert.Empty(t, compat.Check(snapshot, current), "backward incompatible AAA protos changes")
+	// The snapshot must include all current fields to protect them from future incompatible changes
+	assert.Empty(t, compat.Check(current, snapshot),
+		"outdated schema snapshot, run: aaa_proto_compat -update -snapshot %s", filepath.Join("testdata", "schema.json"))
+}
+
+func TestCheck(t *testing.T) {
+	old := &compat.Schema{
+		Messages: map[string]map[int32]compat.Field{
+			"aaa.protos.m": {
+				1: {Name: "a", Type: "TYPE_STRING", Label: "LABEL_OPTIONAL"},
+				2: {Name: "b", Type: "TYPE_UINT32", Label: "LABEL_OPTIONAL"},
+				3: {Name: "c", Type: "TYPE_STRING", Label: "LABEL_OPTIONAL"},
+				4: {Name: "d", Type: "TYPE_MESSAGE", Label: "LABEL_OPTIONAL", TypeName: ".aaa.protos.n"},
+			},
+			"aaa.protos.n": {},
+		},
+		Enums: map[string]map[int32]string{"aaa.protos.e": {0: "ZERO", 1: "ONE"}},
+	}
+	assert.Empty(t, compat.Check(old, old))
+
+	compatible := &compat.Schema{
+		Messages: map[string]map[int32]compat.Field{
+			"aaa.protos.m": {
+				1: {Name: "a", Type: "TYPE_STRING", Label: "LABEL_OPTIONAL"},
+				2: {Name: "b", Type: "TYPE_UINT32", Label: "LABEL_OPTIONAL"},
+				3: {Name: "c", Type: "TYPE_STRING", Label: "LABEL_OPTIONAL"},
+				4: {Name: "d", Type: "TYPE_MESSAGE", Label: "LABEL_OPTIONAL", TypeName: ".aaa.protos.n"},
+				5: {Name: "added", Type: "TYPE_BYTES", Label: "LABEL_REPEATED"},
+			},
+			"aaa.protos.n":     {},
+			"aaa.protos.added": {},
+		},
+		Enums: map[string]map[int32]string{"aaa.protos.e": {0: "ZERO", 1: "ONE", 2: "TWO"}},
+	}
+	assert.Empty(t, compat.Check(old, compatible))
+
+	incompatible := &compat.Schema{
+		Messages: map[string]map[int32]compat.Field{
+			"aaa.protos.m": {
+				1: {Name: "renamed", Type: "TYPE_STRING", Label: "LABEL_OPTIONAL"},
+				2: {Name: "b", Type: "TYPE_UINT64", Label: "LABEL_OPTIONAL"},
+				3: {Name: "c", Type: "TYPE_STRING", Label: "LABEL_REPEATED"},
+			},
+		},
+		Enums: map[string]map[int32]string{"aaa.protos.e": {0: "ZERO", 1: "UNO"}},
+	}
+	assert.Equal(t, []string{
+		"enum value aaa.protos.e.ONE = 1 was renamed to UNO",
+		"field aaa.protos.m.a = 1 was renamed to renamed",
+		"field aaa.protos.m.b = 2 type changed from TYPE_UINT32 to TYPE_UINT64",
+		"field aaa.protos.m.c = 3 label changed from LABEL_OPTIONAL to LABEL_REPEATED",
+		"field aaa.protos.m.d = 4 was removed",
+		"message aaa.protos.n was removed",
+	}, compat.Check(old, incompatible))
+}
@@ -0,0 +1,106 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package protos
+
+import (
+	"fmt"
+	"net"
+)
+
+// UEAddresses - validated & normalized UE addresses of a session, addresses the UE doesn't have are empty
+type UEAddresses struct {
+	IPv4       string
+	IPv6       string
+	IPv6Prefix string // delegated prefix in CIDR notation
+}
+
+// UEAddresses returns the context's validated & normalized UE addresses. NASes not aware of ipv6_addr may report
+// the IPv6 address of an IPv6 only UE as ip_addr, such ip_addr is used as the UE's IPv6 address
+func (m *Context) UEAddresses() (UEAddresses, error) {
+	var res UEAddresses
+	if ipv6 := m.GetIpv6Addr(); len(ipv6) > 0 {
+		ip := net.ParseIP(ipv6)
+		if ip == nil || ip.To4() != nil {
+			return res, fmt.Errorf("invalid IPv6 address: '%s'", ipv6)
+		}
+		res.IPv6 = ip.String()
+	}
+	if ipv4 := m.GetIpAddr(); len(ipv4) > 0 {
+		ip := net.ParseIP(ipv4)
+		switch {
+		case ip == nil:
+			return res, fmt.Errorf("invalid IP address: '%s'", ipv4)
+		case ip.To4() != nil:
+			res.IPv4 = ip.To4().String()
+		case len(res.IPv6) == 0:
+			res.IPv6 = ip.String()
+		case !ip.Equal(net.ParseIP(res.IPv6)):
+			return res, fmt.Errorf("IPv6 ip_addr %s doesn't match ipv6_addr %s", ipv4, res.IPv6)
+		}
+	}
+	if prefix := m.GetIpv6Prefix(); len(prefix) > 0 {
+		ip, network, err := net.ParseCIDR(prefix)
+		if err != nil || ip.To4() != nil {
+			return res, fmt.Errorf("invalid IPv6 prefix: '%s'", prefix)
+		}
+		res.IPv6Prefix = network.String()
+	}
+	return res, nil
+}
+
+// APMAC returns the upper case hex digits of the AP MAC address prefix of the Called-Station-Id:
+// <AP MAC>[:<SSID>], the MAC address may be in any of the colon, dash, dot or no separators notations
+func APMAC(calledStationID string) (string, bool) {
+	mac, _, ok := splitCalledStationID(calledStationID)
+	return mac, ok
+}
+
+// SSID returns the SSID of the Called-Station-Id: <AP MAC>[:<SSID>], Called-Station-Ids without the AP MAC address
+// prefix are returned as is
+func SSID(calledStationID string) string {
+	if _, ssid, ok := splitCalledStationID(calledStationID); ok {
+		return ssid
+	}
+	return calledStationID
+}
+
+// CalledStationID returns the Called-Station-Id of the context: its original Called-Station-Id if its APN was
+// replaced by a canonical APN, its APN otherwise
+func (m *Context) CalledStationID() string {
+	if id, ok := m.GetAttribute(CalledStationIDAttribute); ok && len(id) > 0 {
+		return id
+	}
+	return m.GetApn()
+}
+
+// splitCalledStationID returns the AP MAC address digits & the SSID of the Called-Station-Id
+func splitCalledStationID(calledStationID string) (string, string, bool) {
+	var digits []byte
+	i := 0
+	for ; i < len(calledStationID) && len(digits) < 12; i++ {
+		c := calledStationID[i]
+		switch {
+		case c >= '0' && c <= '9', c >= 'A' && c <= 'F':
+			digits = append(digits, c)
+		case c >= 'a' && c <= 'f':
+			digits = append(digits, c-'a'+'A')
+		case c == ':' || c == '-' || c == '.':
+		default:
+			return "", "", false
+		}
+	}
+	// the MAC address must be followed by the SSID separator or end the Called-Station-Id
+	if len(digits) < 12 || (i < len(calledStationID) && calledStationID[i] != ':') {
+		return "", "", false
+	}
+	if i < len(calledStationID) {
+		i++ // the SSID separator
+	}
+	return string(digits), calledStationID[i:], true
+}
@@ -0,0 +1,78 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package protos_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+func TestUEAddresses(t *testing.T) {
+	addrs, err := (&protos.Context{}).UEAddresses()
+	assert.NoError(t, err)
+	assert.Equal(t, protos.UEAddresses{}, addrs)
+
+	// dual stack
+	addrs, err = (&protos.Context{
+		IpAddr:     "10.0.0.1",
+		Ipv6Addr:   "2001:DB8::0:1",
+		Ipv6Prefix: "2001:db8:1::1/56",
+	}).UEAddresses()
+	assert.NoError(t, err)
+	assert.Equal(t, protos.UEAddresses{IPv4: "10.0.0.1", IPv6: "2001:db8::1", IPv6Prefix: "2001:db8:1::/56"}, addrs)
+
+	// IPv6 only UE reported by a NAS not aware of ipv6_addr
+	addrs, err = (&protos.Context{IpAddr: "2001:db8::2"}).UEAddresses()
+	assert.NoError(t, err)
+	assert.Equal(t, protos.UEAddresses{IPv6: "2001:db8::2"}, addrs)
+	addrs, err = (&protos.Context{IpAddr: "2001:db8::2", Ipv6Addr: "2001:db8::2"}).UEAddresses()
+	assert.NoError(t, err)
+	assert.Equal(t, protos.UEAddresses{IPv6: "2001:db8::2"}, addrs)
+
+	for _, invalid := range []*protos.Context{
+		{IpAddr: "10.0.0"},
+		{Ipv6Addr: "10.0.0.1"},
+		{Ipv6Addr: "::ffff:10.0.0.1"},
+		{Ipv6Addr: "2001:db8::g"},
+		{IpAddr: "2001:db8::2", Ipv6Addr: "2001:db8::3"},
+		{Ipv6Prefix: "2001:db8:1::"},
+		{Ipv6Prefix: "10.0.0.0/8"},
+	} {
+		_, err = invalid.UEAddresses()
+		assert.Error(t, err, "%v", invalid)
+	}
+}
+
+func TestAPMAC(t *testing.T) {
+	for _, calledStationID := range []string{
+		"00-11-22-AA-BB-CC:magma.wifi", "00:11:22:aa:bb:cc", "0011.22aa.bbcc:ssid", "001122AABBCC"} {
+		mac, ok := protos.APMAC(calledStationID)
+		assert.True(t, ok, calledStationID)
+		assert.Equal(t, "001122AABBCC", mac, calledStationID)
+	}
+	for _, calledStationID := range []string{"", "magma.wifi", "00:11:22:aa:bb", "00:11:22:aa:bb:cc:dd"} {
+		_, ok := protos.APMAC(calledStationID)
+		assert.False(t, ok, calledStationID)
+	}
+}
+
+func TestSSID(t *testing.T) {
+	assert.Equal(t, "magma.wifi", protos.SSID("00-11-22-AA-BB-CC:magma.wifi"))
+	assert.Equal(t, "", protos.SSID("00:11:22:aa:bb:cc"))
+	assert.Equal(t, "magma.wifi", protos.SSID("magma.wifi"))
+
+	aaaCtx := &protos.Context{Apn: "00-11-22-AA-BB-CC:Venue Guest"}
+	assert.Equal(t, "00-11-22-AA-BB-CC:Venue Guest", aaaCtx.CalledStationID())
+	aaaCtx.Apn = "magma.wifi"
+	assert.NoError(t, aaaCtx.SetAttribute(protos.CalledStationIDAttribute, "00-11-22-AA-BB-CC:Venue Guest"))
+	assert.Equal(t, "00-11-22-AA-BB-CC:Venue Guest", aaaCtx.CalledStationID())
+}
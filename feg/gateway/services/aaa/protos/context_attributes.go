@@ -0,0 +1,155 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package protos
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Context attributes size limits
+const (
+	MaxAttributes        = 32
+	MaxAttributeKeyLen   = 64
+	MaxAttributeValueLen = 253 // maximum RADIUS attribute value length
+)
+
+// NASIdentifierAttribute the context attribute of the session's NAS-Identifier, the NAS's sessions are flushed by
+// it on Accounting-On/Off
+const NASIdentifierAttribute = "nas_identifier"
+
+// MultiSessionIDAttribute the context attribute of the session's Acct-Multi-Session-Id, sessions of the same
+// multi-session (e.g. fast roams across the APs of one ESS) are accounted as one logical session
+const MultiSessionIDAttribute = "acct_multi_session_id"
+
+// NASAddressAttribute the context attribute of the source "host:port" address of the session's latest accounting
+// request, the session's CoA & Disconnect-Requests are sent to its host, so they follow NAS failovers & NAT changes
+const NASAddressAttribute = "nas_address"
+
+// EventTimeAttribute the context attribute of the accounting request's event time in Unix seconds: its
+// Event-Timestamp or its arrival time less its Acct-Delay-Time. Retransmissions of a request have the same event time
+const EventTimeAttribute = "acct_event_time"
+
+// EventSkewAttribute the context attribute of the accounting request's Event-Timestamp skew in seconds: its arrival
+// time less its Acct-Delay-Time & Event-Timestamp, positive for stale & replayed requests or NAS clocks running behind.
+// It's only set if the request has an Event-Timestamp
+const EventSkewAttribute = "acct_event_skew"
+
+// OperatorNameAttribute the context attribute of the session's RFC 5580 Operator-Name: its namespace ID followed by
+// the name of the operator serving the session, e.g. the roaming partner of a wholesale Wi-Fi host's shared SSID
+const OperatorNameAttribute = "operator_name"
+
+// CalledStationIDAttribute the context attribute of the session's original Called-Station-Id if its APN was
+// replaced by the canonical APN of its alias, the AP MAC address of the session is parsed from it
+const CalledStationIDAttribute = "called_station_id"
+
+// CorrelationClassPrefix the prefix of the RADIUS Class of the sessions' Access-Accepts: the session ID echoed by the
+// NAS in the accounting of the attachment, including its roams & AP handoffs, correlates the accounting to the session
+const CorrelationClassPrefix = "magma:"
+
+// CorrelationClass returns the RADIUS Class correlating the attachment's accounting to the session
+func CorrelationClass(sid string) string {
+	return CorrelationClassPrefix + sid
+}
+
+// ParseCorrelationClass returns the session ID of the RADIUS Class, false if the Class isn't a correlation Class
+func ParseCorrelationClass(class string) (string, bool) {
+	if !strings.HasPrefix(class, CorrelationClassPrefix) || len(class) == len(CorrelationClassPrefix) {
+		return "", false
+	}
+	return class[len(CorrelationClassPrefix):], true
+}
+
+// ValidateAttribute returns an error if the key or value exceed the attribute size limits
+func ValidateAttribute(key, value string) error {
+	if len(key) == 0 || len(key) > MaxAttributeKeyLen {
+		return fmt.Errorf("invalid attribute key '%s': length must be 1-%d", key, MaxAttributeKeyLen)
+	}
+	if len(value) > MaxAttributeValueLen {
+		return fmt.Errorf("attribute '%s' value is too long: %d > %d", key, len(value), MaxAttributeValueLen)
+	}
+	return nil
+}
+
+// ValidateAttributes returns an error if the attributes exceed any of the size limits
+func ValidateAttributes(attrs map[string]string) error {
+	if len(attrs) > MaxAttributes {
+		return fmt.Errorf("too many attributes: %d > %d", len(attrs), MaxAttributes)
+	}
+	for k, v := range attrs {
+		if err := ValidateAttribute(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetAttribute sets the context's string attribute
+func (m *Context) SetAttribute(key, value string) error {
+	if err := ValidateAttribute(key, value); err != nil {
+		return err
+	}
+	if _, ok := m.Attributes[key]; !ok && len(m.Attributes) >= MaxAttributes {
+		return fmt.Errorf("cannot set attribute '%s': context already has %d attributes", key, MaxAttributes)
+	}
+	if m.Attributes == nil {
+		m.Attributes = map[string]string{}
+	}
+	m.Attributes[key] = value
+	return nil
+}
+
+// SetUintAttribute sets the context's unsigned integer attribute
+func (m *Context) SetUintAttribute(key string, value uint64) error {
+	return m.SetAttribute(key, strconv.FormatUint(value, 10))
+}
+
+// SetIntAttribute sets the context's signed integer attribute
+func (m *Context) SetIntAttribute(key string, value int64) error {
+	return m.SetAttribute(key, strconv.FormatInt(value, 10))
+}
+
+// GetAttribute returns the context's string attribute & true if the attribute is set
+func (m *Context) GetAttribute(key string) (string, bool) {
+	value, ok := m.GetAttributes()[key]
+	return value, ok
+}
+
+// GetUintAttribute returns the context's unsigned integer attribute & true if the attribute is set & is an integer
+func (m *Context) GetUintAttribute(key string) (uint64, bool) {
+	value, ok := m.GetAttribute(key)
+	if !ok {
+		return 0, false
+	}
+	u, err := strconv.ParseUint(value, 10, 64)
+	return u, err == nil
+}
+
+// GetIntAttribute returns the context's signed integer attribute & true if the attribute is set & is an integer
+func (m *Context) GetIntAttribute(key string) (int64, bool) {
+	value, ok := m.GetAttribute(key)
+	if !ok {
+		return 0, false
+	}
+	i, err := strconv.ParseInt(value, 10, 64)
+	return i, err == nil
+}
+
+// MergeAttributes sets all given attributes, overwriting existing ones with the same keys. Attributes exceeding the
+// size limits are skipped, the returned error describes the first of them
+func (m *Context) MergeAttributes(attrs map[string]string) error {
+	var res error
+	for k, v := range attrs {
+		if err := m.SetAttribute(k, v); err != nil && res == nil {
+			res = err
+		}
+	}
+	return res
+}
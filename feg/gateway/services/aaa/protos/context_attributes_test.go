@@ -0,0 +1,63 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package protos_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+func TestContextAttributes(t *testing.T) {
+	ctx := &protos.Context{}
+	_, ok := ctx.GetAttribute("nas_port_type")
+	assert.False(t, ok)
+
+	assert.NoError(t, ctx.SetAttribute("nas_port_type", "wireless"))
+	assert.NoError(t, ctx.SetUintAttribute("session_timeout", 3600))
+	v, ok := ctx.GetAttribute("nas_port_type")
+	assert.True(t, ok)
+	assert.Equal(t, "wireless", v)
+	u, ok := ctx.GetUintAttribute("session_timeout")
+	assert.True(t, ok)
+	assert.Equal(t, uint64(3600), u)
+	_, ok = ctx.GetUintAttribute("nas_port_type")
+	assert.False(t, ok)
+
+	assert.Error(t, ctx.SetAttribute("", "v"))
+	assert.Error(t, ctx.SetAttribute(strings.Repeat("k", protos.MaxAttributeKeyLen+1), "v"))
+	assert.Error(t, ctx.SetAttribute("k", strings.Repeat("v", protos.MaxAttributeValueLen+1)))
+
+	attrs := map[string]string{}
+	for i := 0; i < protos.MaxAttributes; i++ {
+		attrs[fmt.Sprintf("attr%d", i)] = "v"
+	}
+	assert.NoError(t, protos.ValidateAttributes(attrs))
+	assert.Error(t, ctx.MergeAttributes(attrs)) // the context already has 2 attributes
+	assert.Len(t, ctx.GetAttributes(), protos.MaxAttributes)
+	assert.NoError(t, ctx.SetAttribute("nas_port_type", "ethernet")) // overwriting doesn't add an attribute
+
+	attrs["one_too_many"] = "v"
+	assert.Error(t, protos.ValidateAttributes(attrs))
+}
+
+func TestCorrelationClass(t *testing.T) {
+	sid, ok := protos.ParseCorrelationClass(protos.CorrelationClass("ap1__ue1"))
+	assert.True(t, ok)
+	assert.Equal(t, "ap1__ue1", sid)
+
+	for _, class := range []string{"", "magma:", "other-server", "MAGMA:ap1__ue1"} {
+		_, ok = protos.ParseCorrelationClass(class)
+		assert.False(t, ok, class)
+	}
+}
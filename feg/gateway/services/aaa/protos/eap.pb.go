@@ -273,6 +273,9 @@ type AuthenticatorClient interface {
 	Handle(ctx context.Context, in *Eap, opts ...grpc.CallOption) (*Eap, error)
 	// supported_methods returns sorted list (ascending, by type) of registered EAP Provider Methods
 	SupportedMethods(ctx context.Context, in *Void, opts ...grpc.CallOption) (*EapMethodList, error)
+	// mac_auth_bypass authenticates a non-EAP device by its context's MAC address (MAC Authentication Bypass) &
+	// returns the authenticated session's context, devices not in the MAC allow-list are denied
+	MacAuthBypass(ctx context.Context, in *Context, opts ...grpc.CallOption) (*Context, error)
 }
 
 type authenticatorClient struct {
@@ -310,6 +313,15 @@ func (c *authenticatorClient) SupportedMethods(ctx context.Context, in *Void, op
 	return out, nil
 }
 
+func (c *authenticatorClient) MacAuthBypass(ctx context.Context, in *Context, opts ...grpc.CallOption) (*Context, error) {
+	out := new(Context)
+	err := c.cc.Invoke(ctx, "/aaa.protos.authenticator/mac_auth_bypass", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AuthenticatorServer is the server API for Authenticator service.
 type AuthenticatorServer interface {
 	// handle_identity passes Identity EAP payload to corresponding method provider & returns corresponding
@@ -320,6 +332,9 @@ type AuthenticatorServer interface {
 	Handle(context.Context, *Eap) (*Eap, error)
 	// supported_methods returns sorted list (ascending, by type) of registered EAP Provider Methods
 	SupportedMethods(context.Context, *Void) (*EapMethodList, error)
+	// mac_auth_bypass authenticates a non-EAP device by its context's MAC address (MAC Authentication Bypass) &
+	// returns the authenticated session's context, devices not in the MAC allow-list are denied
+	MacAuthBypass(context.Context, *Context) (*Context, error)
 }
 
 func RegisterAuthenticatorServer(s *grpc.Server, srv AuthenticatorServer) {
@@ -380,6 +395,24 @@ func _Authenticator_SupportedMethods_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Authenticator_MacAuthBypass_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Context)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticatorServer).MacAuthBypass(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/aaa.protos.authenticator/MacAuthBypass",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticatorServer).MacAuthBypass(ctx, req.(*Context))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Authenticator_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "aaa.protos.authenticator",
 	HandlerType: (*AuthenticatorServer)(nil),
@@ -396,6 +429,10 @@ var _Authenticator_serviceDesc = grpc.ServiceDesc{
 			MethodName: "supported_methods",
 			Handler:    _Authenticator_SupportedMethods_Handler,
 		},
+		{
+			MethodName: "mac_auth_bypass",
+			Handler:    _Authenticator_MacAuthBypass_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "eap.proto",
@@ -544,35 +581,36 @@ var _EapRouter_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("eap.proto", fileDescriptor_eap_3f6c89a3797c2922) }
 
 var fileDescriptor_eap_3f6c89a3797c2922 = []byte{
-	// 470 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x92, 0x5f, 0x8b, 0xd3, 0x4c,
-	0x14, 0xc6, 0x3b, 0xcd, 0xd2, 0x76, 0xcf, 0x36, 0x6f, 0xa7, 0xf3, 0x82, 0x96, 0x7a, 0x53, 0x0a,
-	0x8b, 0x65, 0x95, 0x16, 0x2a, 0xde, 0x0a, 0xd5, 0xdd, 0x85, 0x65, 0x77, 0x8b, 0xb4, 0xea, 0x85,
-	0x37, 0xe5, 0x98, 0x39, 0x4d, 0x07, 0xd3, 0xcc, 0x98, 0x99, 0x68, 0xfb, 0x85, 0xfc, 0x1c, 0x82,
-	0xdf, 0x4b, 0x65, 0x36, 0x59, 0x0c, 0xc5, 0x3b, 0xaf, 0xbc, 0xca, 0xf9, 0xe5, 0xfc, 0x7b, 0x4e,
-	0x9e, 0xc0, 0x31, 0xa1, 0x19, 0x9b, 0x4c, 0x3b, 0x2d, 0x00, 0x11, 0x8b, 0xd0, 0xf6, 0xc3, 0x48,
-	0xa7, 0x8e, 0x76, 0xae, 0xe0, 0xe1, 0x25, 0x04, 0x84, 0x46, 0xf4, 0xa0, 0x69, 0x70, 0x9f, 0x68,
-	0x94, 0x3d, 0x36, 0x60, 0xa3, 0xf6, 0xe2, 0x1e, 0xc5, 0x29, 0x04, 0x91, 0xdb, 0xf5, 0xea, 0x03,
-	0x36, 0x3a, 0x99, 0xfe, 0x3f, 0xfe, 0x3d, 0x69, 0x5c, 0x0e, 0x5a, 0xf8, 0xfc, 0x30, 0x86, 0x36,
-	0xa1, 0x59, 0x29, 0x49, 0xa9, 0x53, 0x6e, 0xff, 0xd7, 0x03, 0xc5, 0x03, 0x68, 0x6c, 0xc9, 0x6d,
-	0xb4, 0xec, 0x05, 0x03, 0x36, 0x0a, 0x17, 0x25, 0x0d, 0x9f, 0x40, 0xc7, 0x2f, 0x2a, 0x68, 0x95,
-	0x28, 0xeb, 0xfc, 0xae, 0x02, 0xed, 0xfd, 0xae, 0x12, 0xcf, 0xbe, 0x32, 0x68, 0xf9, 0x6a, 0xb7,
-	0x37, 0x24, 0xda, 0xd0, 0x5a, 0x90, 0xa5, 0xec, 0x33, 0x49, 0x5e, 0xf3, 0x74, 0x55, 0x8a, 0xe5,
-	0x4c, 0x70, 0x68, 0xcf, 0xb5, 0x53, 0x6b, 0x15, 0xa1, 0x53, 0x3a, 0xe5, 0x75, 0xf1, 0x1f, 0xc0,
-	0x0d, 0xc5, 0x18, 0xed, 0x57, 0x73, 0xfc, 0xc8, 0x03, 0xd1, 0x85, 0xf0, 0xf6, 0xfc, 0xf9, 0xea,
-	0xd5, 0x06, 0x93, 0x84, 0xd2, 0x98, 0xf8, 0x91, 0x08, 0xa1, 0x75, 0xb1, 0x33, 0x98, 0x4a, 0x92,
-	0xfc, 0x07, 0x13, 0x5d, 0x68, 0x5f, 0xec, 0x0c, 0x65, 0x6a, 0x4b, 0xa9, 0xc3, 0x84, 0xff, 0x64,
-	0xa2, 0x09, 0xc1, 0x9b, 0x9b, 0x25, 0x0f, 0x7d, 0xb0, 0xbc, 0xba, 0xe5, 0xc2, 0x07, 0xb3, 0xeb,
-	0x19, 0x7f, 0xe8, 0xf7, 0xcf, 0xae, 0x67, 0xaf, 0x7d, 0x35, 0x9f, 0x9e, 0xcd, 0x0b, 0x9d, 0x91,
-	0x96, 0x24, 0x42, 0x38, 0x7e, 0x9b, 0x4a, 0x5a, 0xab, 0xf4, 0x4e, 0xe8, 0x09, 0x34, 0x17, 0xf4,
-	0x29, 0x27, 0xeb, 0x38, 0x2b, 0x6f, 0x30, 0x3a, 0xb5, 0xc4, 0xeb, 0x3e, 0xb5, 0xcc, 0xa3, 0x88,
-	0xac, 0xe5, 0x81, 0x87, 0x4b, 0x54, 0x49, 0x9e, 0x11, 0x3f, 0x9a, 0x7e, 0x67, 0x10, 0x62, 0xee,
-	0x36, 0xfe, 0xc0, 0x08, 0x9d, 0xce, 0xc4, 0x0b, 0xe8, 0x6c, 0x30, 0x95, 0x09, 0x55, 0x3c, 0xaa,
-	0x7e, 0xfc, 0xaa, 0x7b, 0xfd, 0xce, 0x41, 0x66, 0x58, 0x13, 0x4f, 0xa1, 0x51, 0xf4, 0x8b, 0xc3,
-	0xe4, 0x9f, 0xaa, 0xcf, 0xa1, 0x6b, 0x73, 0x63, 0x74, 0xe6, 0x48, 0x96, 0x5e, 0x59, 0xc1, 0xab,
-	0x75, 0xef, 0xb4, 0x92, 0xfd, 0x47, 0x87, 0x0a, 0x2a, 0xb6, 0x0e, 0x6b, 0xd3, 0x6f, 0x0c, 0xc0,
-	0xbf, 0xcd, 0x74, 0xee, 0xe8, 0x9f, 0x3c, 0xe1, 0xe5, 0xe3, 0xf7, 0xa7, 0x5b, 0x8c, 0xb7, 0x38,
-	0x59, 0x53, 0x3c, 0x89, 0xd1, 0xd1, 0x17, 0xdc, 0x4f, 0xfc, 0x4f, 0xa8, 0x22, 0xb2, 0x13, 0x44,
-	0x9c, 0x14, 0xad, 0x1f, 0x1a, 0x77, 0xcf, 0x67, 0xbf, 0x02, 0x00, 0x00, 0xff, 0xff, 0xdf, 0xde,
-	0xb8, 0xf3, 0xb7, 0x03, 0x00, 0x00,
+	// 482 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd5, 0x52, 0xc1, 0x6e, 0xd3, 0x40,
+	0x10, 0x8d, 0x13, 0x94, 0xa4, 0xd3, 0x98, 0x6c, 0x17, 0x09, 0xa2, 0x70, 0x41, 0x96, 0x2a, 0x50,
+	0xa9, 0x62, 0x29, 0x88, 0x23, 0x48, 0x85, 0xb6, 0x52, 0x45, 0x1b, 0xa1, 0x04, 0x38, 0x70, 0xb1,
+	0xb6, 0xf6, 0xc4, 0x59, 0xe1, 0x78, 0x8d, 0x77, 0x0d, 0xf1, 0x95, 0x8f, 0xe1, 0x3b, 0xf8, 0x32,
+	0xe8, 0x6c, 0xed, 0x0a, 0x2b, 0xca, 0x8d, 0x13, 0xa7, 0x9d, 0xb7, 0xf3, 0xe6, 0xcd, 0x9b, 0x9d,
+	0x85, 0x3d, 0x14, 0xd9, 0x24, 0xcb, 0x95, 0x51, 0x1c, 0x84, 0x10, 0x55, 0xa8, 0xc7, 0x6e, 0xa8,
+	0x52, 0x83, 0x1b, 0x53, 0x61, 0xef, 0x1c, 0x3a, 0xc4, 0xe3, 0x23, 0xe8, 0x65, 0xa2, 0x4c, 0x94,
+	0x88, 0x46, 0xce, 0x13, 0xe7, 0xd9, 0x60, 0x7e, 0x07, 0xf9, 0x21, 0x74, 0x42, 0xb3, 0x19, 0xb5,
+	0xe9, 0x76, 0x7f, 0xfa, 0x60, 0xf2, 0x57, 0x69, 0x52, 0x0b, 0xcd, 0x6d, 0xde, 0x8b, 0x61, 0x40,
+	0x3a, 0x81, 0x8c, 0x30, 0x35, 0xd2, 0x94, 0xff, 0x2c, 0xc8, 0x1f, 0x42, 0x77, 0x8d, 0x66, 0xa5,
+	0xa2, 0x51, 0x87, 0x98, 0xee, 0xbc, 0x46, 0xde, 0x73, 0x18, 0xda, 0x46, 0x15, 0x0a, 0x12, 0xa9,
+	0x8d, 0xed, 0x55, 0x41, 0x7d, 0xd7, 0xab, 0x86, 0x47, 0x3f, 0x1d, 0xe8, 0x5b, 0xb6, 0x29, 0x33,
+	0xe4, 0x03, 0xe8, 0xcf, 0x51, 0x63, 0xfe, 0x0d, 0x23, 0xd6, 0xb2, 0xe8, 0xa2, 0x36, 0xcb, 0x1c,
+	0xce, 0x60, 0x30, 0x53, 0x46, 0x2e, 0x65, 0x28, 0x8c, 0x54, 0x29, 0x6b, 0xf3, 0xfb, 0x00, 0x97,
+	0x18, 0x8b, 0xb0, 0x0c, 0x66, 0xe2, 0x0b, 0xeb, 0xf0, 0x03, 0x70, 0xaf, 0x4e, 0x5f, 0x06, 0x6f,
+	0x57, 0x22, 0x49, 0x30, 0x8d, 0x91, 0xdd, 0xe3, 0x2e, 0xf4, 0xcf, 0x36, 0x99, 0x48, 0x23, 0x12,
+	0xfc, 0xed, 0x10, 0x63, 0x40, 0x10, 0x73, 0xb9, 0x26, 0x59, 0x91, 0xb0, 0x3f, 0x0e, 0xef, 0x41,
+	0xe7, 0xc3, 0xe5, 0x82, 0xb9, 0x36, 0x58, 0x5c, 0x5c, 0x31, 0x6e, 0x83, 0x93, 0x77, 0x27, 0xec,
+	0x91, 0xed, 0x4f, 0xc1, 0x7b, 0xcb, 0x66, 0xd3, 0xa3, 0x59, 0xe5, 0x33, 0x54, 0x11, 0x92, 0xec,
+	0xde, 0x47, 0xd2, 0x5c, 0xca, 0xf4, 0xd6, 0xe8, 0x3e, 0xf4, 0xe6, 0xf8, 0xb5, 0x40, 0x6d, 0xc8,
+	0x67, 0x35, 0x43, 0xa6, 0x52, 0x8d, 0xe4, 0x91, 0x52, 0x8b, 0x22, 0x0c, 0x51, 0x6b, 0x32, 0x48,
+	0xe0, 0x5c, 0xc8, 0xa4, 0xc8, 0xc9, 0xda, 0xf4, 0x47, 0x1b, 0x5c, 0x51, 0x98, 0x95, 0x1d, 0x90,
+	0x46, 0x52, 0x39, 0x7f, 0x0d, 0xc3, 0x15, 0x59, 0x4d, 0xb0, 0xb1, 0xa3, 0xe6, 0xe3, 0x37, 0xb7,
+	0x37, 0x1e, 0x6e, 0x65, 0xbc, 0x16, 0x3f, 0x86, 0x6e, 0x55, 0xcf, 0xb7, 0x93, 0xbb, 0xd8, 0xa7,
+	0x70, 0xa0, 0x8b, 0x2c, 0x53, 0xb9, 0xc1, 0xa8, 0xde, 0x95, 0xe6, 0xac, 0xc9, 0xfb, 0xa4, 0x64,
+	0x34, 0x7e, 0xbc, 0xed, 0xa0, 0xb1, 0x56, 0x52, 0x79, 0x05, 0xc3, 0xb5, 0x08, 0x03, 0x3b, 0x48,
+	0x70, 0x5d, 0x66, 0x42, 0x6b, 0xbe, 0xeb, 0xc3, 0x8c, 0x77, 0x5d, 0x7a, 0xad, 0xe9, 0x2f, 0x07,
+	0xc0, 0x8a, 0xe6, 0xaa, 0x30, 0xf8, 0x5f, 0xbe, 0xc0, 0x9b, 0xa7, 0x9f, 0x0f, 0xd7, 0x22, 0x5e,
+	0x0b, 0x7f, 0x89, 0xb1, 0x1f, 0x0b, 0x83, 0xdf, 0x45, 0xe9, 0xdb, 0x3f, 0x2c, 0x69, 0xeb, 0x3e,
+	0x95, 0xfa, 0x55, 0xe9, 0x75, 0xf7, 0xf6, 0x7c, 0x71, 0x03, 0x2a, 0x1f, 0x43, 0x7b, 0xf6, 0x03,
+	0x00, 0x00,
 }
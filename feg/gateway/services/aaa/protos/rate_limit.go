@@ -0,0 +1,43 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package protos
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// NewRateLimitError returns RESOURCE_EXHAUSTED error of a request throttled by the rate limit of its key (mac or
+// imsi), the error carries rate_limit_error details with the Retry-After hint
+func NewRateLimitError(method, key, value string, retryAfter time.Duration) error {
+	retryMs := uint32((retryAfter + time.Millisecond - 1) / time.Millisecond)
+	st := status.New(codes.ResourceExhausted,
+		fmt.Sprintf("%s of %s %s is rate limited, retry after %d ms", method, key, value, retryMs))
+	if detailed, err := st.WithDetails(&RateLimitError{Key: key, Value: value, RetryAfterMs: retryMs}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// GetRateLimitError returns the rate_limit_error details of the error, nil if err is not a rate limit error
+func GetRateLimitError(err error) *RateLimitError {
+	st, ok := status.FromError(err)
+	if !ok || err == nil || st.Code() != codes.ResourceExhausted {
+		return nil
+	}
+	for _, d := range st.Details() {
+		if rle, ok := d.(*RateLimitError); ok {
+			return rle
+		}
+	}
+	return nil
+}
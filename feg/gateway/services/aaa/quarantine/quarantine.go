@@ -0,0 +1,141 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package quarantine configures the actions of security triggers (IMSI clone detection, blocklist hits of active
+// sessions). Instead of being disconnected, sessions of triggers with the quarantine action are moved via CoA to a
+// quarantine VLAN or filter profile, so their users are redirected to a remediation page
+package quarantine
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+// Trigger - security event triggering a session's disconnect or quarantine
+type Trigger string
+
+const (
+	// TriggerClone - the session's IMSI is used by another UE
+	TriggerClone Trigger = "clone"
+	// TriggerBlocklist - the session's subscriber or UE hit a blocklist mid-session
+	TriggerBlocklist Trigger = "blocklist"
+)
+
+// Action - handling of a trigger's sessions
+type Action string
+
+const (
+	// ActionDisconnect - the sessions are disconnected, the default action
+	ActionDisconnect Action = "disconnect"
+	// ActionQuarantine - the sessions are moved to the quarantine profile
+	ActionQuarantine Action = "quarantine"
+)
+
+// Attribute - context attribute of quarantined sessions, set to the quarantine's trigger
+const Attribute = "quarantine_trigger"
+
+// Profile - quarantine VLAN, filter & remediation page, any of them may be omitted
+type Profile struct {
+	VlanID      uint32 `json:"vlan_id,omitempty"`
+	FilterID    string `json:"filter_id,omitempty"`
+	RedirectURL string `json:"redirect_url,omitempty"`
+}
+
+// Rule - trigger's action, quarantine rules without a profile use the configuration's default profile
+type Rule struct {
+	Action  Action   `json:"action"`
+	Profile *Profile `json:"profile,omitempty"`
+}
+
+// Config - actions of security triggers, triggers without a rule are disconnected
+type Config struct {
+	Profile  *Profile          `json:"profile,omitempty"` // default quarantine profile
+	Triggers map[Trigger]*Rule `json:"triggers"`
+}
+
+// ReadConfig reads & validates JSON quarantine configuration from the given file
+func ReadConfig(path string) (*Config, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &Config{}
+	if err = json.Unmarshal(b, cfg); err != nil {
+		return nil, fmt.Errorf("Invalid quarantine configuration %s: %v", path, err)
+	}
+	if err = cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("Invalid quarantine configuration %s: %v", path, err)
+	}
+	return cfg, nil
+}
+
+// Validate returns an error if a trigger or action is unknown or a quarantine rule has no profile
+func (cfg *Config) Validate() error {
+	for trigger, rule := range cfg.Triggers {
+		if _, err := ParseTrigger(string(trigger)); err != nil {
+			return err
+		}
+		if rule == nil {
+			return fmt.Errorf("missing rule of trigger '%s'", trigger)
+		}
+		switch rule.Action {
+		case ActionDisconnect:
+		case ActionQuarantine:
+			profile := rule.Profile
+			if profile == nil {
+				profile = cfg.Profile
+			}
+			if profile.empty() {
+				return fmt.Errorf("missing quarantine profile of trigger '%s'", trigger)
+			}
+		default:
+			return fmt.Errorf("unknown action '%s' of trigger '%s'", rule.Action, trigger)
+		}
+	}
+	return nil
+}
+
+// Action returns the trigger's action & its quarantine profile, ActionDisconnect & nil if the trigger has no rule
+func (cfg *Config) Action(trigger Trigger) (Action, *Profile) {
+	if cfg == nil {
+		return ActionDisconnect, nil
+	}
+	rule, ok := cfg.Triggers[trigger]
+	if !ok || rule == nil || rule.Action != ActionQuarantine {
+		return ActionDisconnect, nil
+	}
+	if rule.Profile != nil {
+		return ActionQuarantine, rule.Profile
+	}
+	return ActionQuarantine, cfg.Profile
+}
+
+// ParseTrigger returns the trigger of the given name
+func ParseTrigger(name string) (Trigger, error) {
+	switch trigger := Trigger(name); trigger {
+	case TriggerClone, TriggerBlocklist:
+		return trigger, nil
+	default:
+		return "", fmt.Errorf("unknown security trigger '%s'", name)
+	}
+}
+
+// Proto returns the CoA quarantine profile of the profile
+func (p *Profile) Proto() *protos.QuarantineProfile {
+	if p == nil {
+		return nil
+	}
+	return &protos.QuarantineProfile{VlanId: p.VlanID, FilterId: p.FilterID, RedirectUrl: p.RedirectURL}
+}
+
+func (p *Profile) empty() bool {
+	return p == nil || (p.VlanID == 0 && len(p.FilterID) == 0 && len(p.RedirectURL) == 0)
+}
@@ -0,0 +1,77 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package quarantine
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+func TestReadConfig(t *testing.T) {
+	f, err := ioutil.TempFile("", "quarantine")
+	assert.NoError(t, err)
+	defer os.Remove(f.Name())
+	_, err = f.WriteString(`{
+		"profile": {"vlan_id": 999, "redirect_url": "https://remediation"},
+		"triggers": {
+			"clone": {"action": "quarantine"},
+			"blocklist": {"action": "quarantine", "profile": {"filter_id": "blocked"}}
+		}
+	}`)
+	assert.NoError(t, err)
+	f.Close()
+
+	cfg, err := ReadConfig(f.Name())
+	assert.NoError(t, err)
+	action, profile := cfg.Action(TriggerClone)
+	assert.Equal(t, ActionQuarantine, action)
+	assert.Equal(t, &protos.QuarantineProfile{VlanId: 999, RedirectUrl: "https://remediation"}, profile.Proto())
+	action, profile = cfg.Action(TriggerBlocklist)
+	assert.Equal(t, ActionQuarantine, action)
+	assert.Equal(t, &Profile{FilterID: "blocked"}, profile)
+}
+
+func TestAction(t *testing.T) {
+	var cfg *Config
+	action, profile := cfg.Action(TriggerClone)
+	assert.Equal(t, ActionDisconnect, action)
+	assert.Nil(t, profile)
+
+	cfg = &Config{Triggers: map[Trigger]*Rule{TriggerClone: {Action: ActionDisconnect}}}
+	assert.NoError(t, cfg.Validate())
+	action, _ = cfg.Action(TriggerClone)
+	assert.Equal(t, ActionDisconnect, action)
+	action, _ = cfg.Action(TriggerBlocklist)
+	assert.Equal(t, ActionDisconnect, action)
+}
+
+func TestValidate(t *testing.T) {
+	assert.Error(t, (&Config{Triggers: map[Trigger]*Rule{"unknown": {Action: ActionDisconnect}}}).Validate())
+	assert.Error(t, (&Config{Triggers: map[Trigger]*Rule{TriggerClone: {Action: "drop"}}}).Validate())
+	assert.Error(t, (&Config{Triggers: map[Trigger]*Rule{TriggerClone: nil}}).Validate())
+	// quarantine rules need a profile
+	assert.Error(t, (&Config{Triggers: map[Trigger]*Rule{TriggerClone: {Action: ActionQuarantine}}}).Validate())
+	assert.Error(t, (&Config{
+		Profile:  &Profile{},
+		Triggers: map[Trigger]*Rule{TriggerClone: {Action: ActionQuarantine}},
+	}).Validate())
+	assert.NoError(t, (&Config{
+		Triggers: map[Trigger]*Rule{TriggerClone: {Action: ActionQuarantine, Profile: &Profile{VlanID: 1}}},
+	}).Validate())
+
+	_, err := ParseTrigger("blocklist")
+	assert.NoError(t, err)
+	_, err = ParseTrigger("other")
+	assert.Error(t, err)
+}
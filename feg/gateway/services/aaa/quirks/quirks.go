@@ -0,0 +1,90 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package quirks decodes the interop quirks of a session's NAS, configured per NAS on the RADIUS server & passed
+// to the AAA as a context attribute, and adjusts the AAA's parsing & matching of the NAS's requests to them
+package quirks
+
+import (
+	"net"
+	"strings"
+
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+// Quirks - bitmask of a NAS's known interop quirks, the bits match the RADIUS server's quirks
+type Quirks uint32
+
+const (
+	// StopBeforeFinalInterim - the NAS may send a session's final Interim-Update after its Stop
+	StopBeforeFinalInterim Quirks = 1 << iota
+	// UppercaseDashedMAC - the NAS sends MAC addresses in upper case & dash separated, e.g. AA-BB-CC-DD-EE-FF
+	UppercaseDashedMAC
+	// NoAcctSessionIDOnInterim - the NAS omits Acct-Session-Id from Interim-Updates
+	NoAcctSessionIDOnInterim
+	// KilobyteOctets - the NAS reports Acct-Input/Output-Octets in kilobytes (1024 octets)
+	KilobyteOctets
+)
+
+// Kilobyte - octets of a kilobyte of NASes with the KilobyteOctets quirk
+const Kilobyte = 1024
+
+// Attribute - context attribute of the NAS's quirks bitmask in decimal
+const Attribute = "nas_quirks"
+
+// Has returns true if all of the quirk's bits are set
+func (q Quirks) Has(quirk Quirks) bool {
+	return q&quirk == quirk
+}
+
+// FromContext returns the quirks of the context's NAS, none if the context has no valid quirks attribute
+func FromContext(aaaCtx *protos.Context) Quirks {
+	q, ok := aaaCtx.GetUintAttribute(Attribute)
+	if !ok || q > uint64(^uint32(0)) {
+		return 0
+	}
+	return Quirks(q)
+}
+
+// AcceptOrphanInterim returns true if an Interim-Update of an unknown session must be acknowledged rather than
+// failed, i.e. the NAS may send the session's final Interim-Update after its Stop
+func AcceptOrphanInterim(aaaCtx *protos.Context) bool {
+	return FromContext(aaaCtx).Has(StopBeforeFinalInterim)
+}
+
+// OctetsUnit returns the number of octets of a unit of the context's NAS's octets counters
+func OctetsUnit(aaaCtx *protos.Context) uint64 {
+	if FromContext(aaaCtx).Has(KilobyteOctets) {
+		return Kilobyte
+	}
+	return 1
+}
+
+// Normalize rewrites the context's NAS formatted values to the AAA's canonical formats, it returns true if the
+// context was changed
+func Normalize(aaaCtx *protos.Context) bool {
+	if aaaCtx == nil || !FromContext(aaaCtx).Has(UppercaseDashedMAC) {
+		return false
+	}
+	mac := NormalizeMAC(aaaCtx.GetMacAddr())
+	if mac == aaaCtx.GetMacAddr() {
+		return false
+	}
+	aaaCtx.MacAddr = mac
+	return true
+}
+
+// NormalizeMAC returns the MAC address in lower case & colon separated notation, values which aren't 48 bit MAC
+// addresses are returned as is
+func NormalizeMAC(mac string) string {
+	hw, err := net.ParseMAC(strings.TrimSpace(mac))
+	if err != nil || len(hw) != 6 {
+		return mac
+	}
+	return hw.String()
+}
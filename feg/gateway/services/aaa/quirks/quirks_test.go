@@ -0,0 +1,68 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package quirks
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+func withQuirks(q Quirks, aaaCtx *protos.Context) *protos.Context {
+	aaaCtx.Attributes = map[string]string{Attribute: strconv.FormatUint(uint64(q), 10)}
+	return aaaCtx
+}
+
+func TestFromContext(t *testing.T) {
+	assert.Equal(t, Quirks(0), FromContext(nil))
+	assert.Equal(t, Quirks(0), FromContext(&protos.Context{}))
+	assert.Equal(t, Quirks(0), FromContext(&protos.Context{Attributes: map[string]string{Attribute: "x"}}))
+	assert.Equal(t, Quirks(0), FromContext(&protos.Context{Attributes: map[string]string{Attribute: "4294967296"}}))
+
+	q := FromContext(withQuirks(StopBeforeFinalInterim|NoAcctSessionIDOnInterim, &protos.Context{}))
+	assert.True(t, q.Has(StopBeforeFinalInterim))
+	assert.True(t, q.Has(NoAcctSessionIDOnInterim))
+	assert.False(t, q.Has(UppercaseDashedMAC))
+	assert.False(t, q.Has(StopBeforeFinalInterim|UppercaseDashedMAC))
+}
+
+func TestStopBeforeFinalInterim(t *testing.T) {
+	assert.False(t, AcceptOrphanInterim(&protos.Context{SessionId: "sid"}))
+	assert.False(t, AcceptOrphanInterim(withQuirks(UppercaseDashedMAC, &protos.Context{SessionId: "sid"})))
+	assert.True(t, AcceptOrphanInterim(withQuirks(StopBeforeFinalInterim, &protos.Context{SessionId: "sid"})))
+}
+
+func TestUppercaseDashedMAC(t *testing.T) {
+	// without the quirk MACs are kept as sent
+	aaaCtx := &protos.Context{MacAddr: "AA-BB-CC-DD-EE-FF"}
+	assert.False(t, Normalize(aaaCtx))
+	assert.Equal(t, "AA-BB-CC-DD-EE-FF", aaaCtx.GetMacAddr())
+
+	aaaCtx = withQuirks(UppercaseDashedMAC, &protos.Context{MacAddr: "AA-BB-CC-DD-EE-FF"})
+	assert.True(t, Normalize(aaaCtx))
+	assert.Equal(t, "aa:bb:cc:dd:ee:ff", aaaCtx.GetMacAddr())
+	assert.False(t, Normalize(aaaCtx))
+
+	aaaCtx = withQuirks(UppercaseDashedMAC, &protos.Context{MacAddr: "not a mac"})
+	assert.False(t, Normalize(aaaCtx))
+	assert.Equal(t, "not a mac", aaaCtx.GetMacAddr())
+	assert.False(t, Normalize(nil))
+
+	assert.Equal(t, "aa:bb:cc:dd:ee:ff", NormalizeMAC(" aa:BB:cc:DD:ee:FF "))
+	assert.Equal(t, "0011.2233.4455", NormalizeMAC("0011.2233.4455.6677"))
+}
+
+func TestKilobyteOctets(t *testing.T) {
+	assert.Equal(t, uint64(1), OctetsUnit(nil))
+	assert.Equal(t, uint64(1), OctetsUnit(withQuirks(UppercaseDashedMAC, &protos.Context{})))
+	assert.Equal(t, uint64(Kilobyte), OctetsUnit(withQuirks(KilobyteOctets|UppercaseDashedMAC, &protos.Context{})))
+}
@@ -0,0 +1,283 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package radiusauthz sends the AAA server's dynamic authorization requests (Radius Disconnect & CoA) to a list of
+// Radius server endpoints with failover, so session terminations & policy changes survive a restart of a single
+// Radius server container.
+//
+// Endpoints are tried in their configured order, the first endpoint is the primary. Endpoints failing their periodic
+// health checks or calls are skipped until they are healthy again, unless all endpoints are unhealthy. Only calls
+// which didn't reach their endpoint (Unavailable) are retried on the next endpoint
+package radiusauthz
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/status"
+
+	"magma/feg/gateway/registry"
+	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/aaa/panics"
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+// DefaultCheckInterval - default interval of the endpoints' health checks
+const DefaultCheckInterval = 10 * time.Second
+
+// ParseEndpoints parses comma separated list of Radius server endpoints, either service registry names (e.g.
+// configured in service_registry.yml) or host:port addresses. Addresses are added to the registry as
+// RADIUS@host:port services
+func ParseEndpoints(list string) ([]string, error) {
+	var res []string
+	for _, endpoint := range strings.Split(list, ",") {
+		endpoint = strings.TrimSpace(endpoint)
+		if len(endpoint) == 0 {
+			continue
+		}
+		if strings.Contains(endpoint, ":") {
+			host, portStr, err := net.SplitHostPort(endpoint)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid Radius endpoint address '%s': %v", endpoint, err)
+			}
+			port, err := strconv.Atoi(portStr)
+			if err != nil || port <= 0 || port > 0xFFFF {
+				return nil, fmt.Errorf("Invalid port of Radius endpoint address '%s'", endpoint)
+			}
+			service := registry.RADIUS + "@" + endpoint
+			registry.AddService(service, host, port)
+			endpoint = service
+		}
+		res = append(res, endpoint)
+	}
+	return res, nil
+}
+
+// conn - the endpoint's connection
+type conn interface {
+	GetState() connectivity.State
+	client() protos.AuthorizationClient
+}
+
+type grpcConn struct {
+	*grpc.ClientConn
+}
+
+func (c grpcConn) client() protos.AuthorizationClient {
+	return protos.NewAuthorizationClient(c.ClientConn)
+}
+
+// getConnection returns the endpoint's connection, replaced by tests
+var getConnection = func(endpoint string) (conn, error) {
+	c, err := registry.GetConnection(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return grpcConn{c}, nil
+}
+
+type endpoint struct {
+	name    string
+	healthy bool
+	lastErr error
+}
+
+// Endpoints - Radius server authorization client failing over between its endpoints
+type Endpoints struct {
+	interval time.Duration
+
+	mu        sync.RWMutex
+	endpoints []*endpoint
+	done      chan struct{}
+}
+
+// New returns a new Endpoints of the endpoints' service registry names, endpoints are healthy until their first
+// check or failed call. Zero interval - DefaultCheckInterval
+func New(endpoints []string, interval time.Duration) (*Endpoints, error) {
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("missing Radius server endpoints")
+	}
+	if interval <= 0 {
+		interval = DefaultCheckInterval
+	}
+	res := &Endpoints{interval: interval, done: make(chan struct{})}
+	for _, name := range endpoints {
+		res.endpoints = append(res.endpoints, &endpoint{name: name, healthy: true})
+		metrics.RadiusEndpointHealthy.WithLabelValues(name).Set(1)
+	}
+	return res, nil
+}
+
+// Start starts periodic health checks of the endpoints, the first check runs immediately
+func (e *Endpoints) Start() {
+	go func() {
+		ticker := time.NewTicker(e.interval)
+		defer ticker.Stop()
+		for {
+			e.CheckEndpoints()
+			select {
+			case <-ticker.C:
+			case <-e.done:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops periodic health checks
+func (e *Endpoints) Stop() {
+	close(e.done)
+}
+
+// CheckEndpoints checks all endpoints' connections concurrently, endpoints which can't be connected or whose
+// connections are failing are unhealthy
+func (e *Endpoints) CheckEndpoints() {
+	var wg sync.WaitGroup
+	for _, ep := range e.list() {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			defer panics.Recover("radius_endpoint_check")
+			c, err := getConnection(name)
+			if err == nil {
+				if state := c.GetState(); state == connectivity.TransientFailure || state == connectivity.Shutdown {
+					err = fmt.Errorf("connection is %s", state)
+				}
+			}
+			e.update(name, err)
+		}(ep.name)
+	}
+	wg.Wait()
+}
+
+// Check returns the endpoints' health, it fails if all endpoints are unhealthy
+func (e *Endpoints) Check() (string, error) {
+	var details []string
+	var healthy int
+	for _, ep := range e.list() {
+		if ep.healthy {
+			healthy++
+			details = append(details, ep.name+": healthy")
+		} else {
+			details = append(details, fmt.Sprintf("%s: %v", ep.name, ep.lastErr))
+		}
+	}
+	detail := strings.Join(details, "; ")
+	if healthy == 0 {
+		return detail, fmt.Errorf("all Radius server endpoints are unhealthy: %s", detail)
+	}
+	return detail, nil
+}
+
+// Change implements protos.AuthorizationClient
+func (e *Endpoints) Change(
+	ctx context.Context, in *protos.ChangeRequest, opts ...grpc.CallOption) (*protos.CoaResponse, error) {
+
+	var res *protos.CoaResponse
+	err := e.call(ctx, func(client protos.AuthorizationClient) (err error) {
+		res, err = client.Change(ctx, in, opts...)
+		return err
+	})
+	return res, err
+}
+
+// Disconnect implements protos.AuthorizationClient
+func (e *Endpoints) Disconnect(
+	ctx context.Context, in *protos.DisconnectRequest, opts ...grpc.CallOption) (*protos.CoaResponse, error) {
+
+	var res *protos.CoaResponse
+	err := e.call(ctx, func(client protos.AuthorizationClient) (err error) {
+		res, err = client.Disconnect(ctx, in, opts...)
+		return err
+	})
+	return res, err
+}
+
+// call calls the healthy endpoints in order until a call reaches its endpoint, the unhealthy endpoints are the last
+// resort. Endpoints whose calls are Unavailable become unhealthy, their calls fail over to the next endpoint unless
+// the call's context is done
+func (e *Endpoints) call(ctx context.Context, call func(protos.AuthorizationClient) error) error {
+	var err error
+	var failed string // the previous endpoint whose call failed
+	for _, name := range e.order() {
+		if len(failed) > 0 {
+			if ctx.Err() != nil {
+				return err
+			}
+			metrics.RadiusFailovers.WithLabelValues(failed).Inc()
+		}
+		c, connErr := getConnection(name)
+		if connErr != nil {
+			err = status.Errorf(codes.Unavailable, "Error getting Radius endpoint %s RPC Connection: %v", name, connErr)
+		} else {
+			err = call(c.client())
+		}
+		if status.Code(err) != codes.Unavailable {
+			return err
+		}
+		e.update(name, err)
+		failed = name
+	}
+	return err
+}
+
+// order returns the endpoints' names in their calls' order: healthy endpoints followed by the unhealthy ones
+func (e *Endpoints) order() []string {
+	var healthy, unhealthy []string
+	for _, ep := range e.list() {
+		if ep.healthy {
+			healthy = append(healthy, ep.name)
+		} else {
+			unhealthy = append(unhealthy, ep.name)
+		}
+	}
+	return append(healthy, unhealthy...)
+}
+
+// list returns copies of the endpoints
+func (e *Endpoints) list() []endpoint {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	res := make([]endpoint, len(e.endpoints))
+	for i, ep := range e.endpoints {
+		res[i] = *ep
+	}
+	return res
+}
+
+// update sets the endpoint's health, nil err - healthy
+func (e *Endpoints) update(name string, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, ep := range e.endpoints {
+		if ep.name != name {
+			continue
+		}
+		if healthy := err == nil; healthy != ep.healthy {
+			if healthy {
+				log.Printf("Radius server endpoint %s is healthy again", name)
+				metrics.RadiusEndpointHealthy.WithLabelValues(name).Set(1)
+			} else {
+				log.Printf("Radius server endpoint %s is unhealthy: %v", name, err)
+				metrics.RadiusEndpointHealthy.WithLabelValues(name).Set(0)
+			}
+			ep.healthy = healthy
+		}
+		ep.lastErr = err
+		return
+	}
+}
@@ -0,0 +1,131 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package radiusauthz
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/status"
+
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+type radiusEndpoint struct {
+	protos.AuthorizationClient
+	name  string
+	state connectivity.State
+	err   error
+	calls *[]string
+}
+
+func (r *radiusEndpoint) GetState() connectivity.State {
+	return r.state
+}
+
+func (r *radiusEndpoint) client() protos.AuthorizationClient {
+	return r
+}
+
+func (r *radiusEndpoint) Disconnect(
+	ctx context.Context, in *protos.DisconnectRequest, opts ...grpc.CallOption) (*protos.CoaResponse, error) {
+
+	*r.calls = append(*r.calls, r.name)
+	if r.err != nil {
+		return nil, r.err
+	}
+	return &protos.CoaResponse{CoaResponseType: protos.CoaResponse_ACK}, nil
+}
+
+func TestEndpoints(t *testing.T) {
+	var calls []string
+	radius := map[string]*radiusEndpoint{
+		"RADIUS":                 {name: "RADIUS", state: connectivity.Ready, calls: &calls},
+		"RADIUS@10.0.0.2:9108":   {name: "RADIUS@10.0.0.2:9108", state: connectivity.Ready, calls: &calls},
+		"RADIUS@10.0.0.3:9108":   {name: "RADIUS@10.0.0.3:9108", state: connectivity.Ready, calls: &calls},
+		"RADIUS@10.0.0.4:9108":   nil,
+		"RADIUS@10.0.0.255:9108": nil,
+	}
+	defer func(orig func(string) (conn, error)) { getConnection = orig }(getConnection)
+	getConnection = func(endpoint string) (conn, error) {
+		if r := radius[endpoint]; r != nil {
+			return r, nil
+		}
+		return nil, fmt.Errorf("%s connection timed out", endpoint)
+	}
+
+	names, err := ParseEndpoints("RADIUS, 10.0.0.2:9108,10.0.0.3:9108")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"RADIUS", "RADIUS@10.0.0.2:9108", "RADIUS@10.0.0.3:9108"}, names)
+	_, err = ParseEndpoints("10.0.0.2:radius")
+	assert.Error(t, err)
+	_, err = New(nil, 0)
+	assert.Error(t, err)
+	e, err := New(names, 0)
+	assert.NoError(t, err)
+	req := &protos.DisconnectRequest{Ctx: &protos.Context{SessionId: "sid1"}}
+
+	// the primary is called while it's healthy
+	res, err := e.Disconnect(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, protos.CoaResponse_ACK, res.GetCoaResponseType())
+	assert.Equal(t, []string{"RADIUS"}, calls)
+
+	// unreachable primary fails over to the next endpoint & is skipped until it's healthy again
+	radius["RADIUS"].err = status.Errorf(codes.Unavailable, "connection refused")
+	calls = nil
+	_, err = e.Disconnect(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"RADIUS", "RADIUS@10.0.0.2:9108"}, calls)
+	calls = nil
+	_, err = e.Disconnect(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"RADIUS@10.0.0.2:9108"}, calls)
+
+	// errors of calls which reached their endpoint aren't failed over
+	radius["RADIUS@10.0.0.2:9108"].err = status.Errorf(codes.Unknown, "NAS timeout")
+	calls = nil
+	_, err = e.Disconnect(context.Background(), req)
+	assert.Equal(t, codes.Unknown, status.Code(err))
+	assert.Equal(t, []string{"RADIUS@10.0.0.2:9108"}, calls)
+	radius["RADIUS@10.0.0.2:9108"].err = nil
+
+	// health checks restore recovered endpoints & fail endpoints with failing connections
+	radius["RADIUS"].err = nil
+	radius["RADIUS@10.0.0.2:9108"].state = connectivity.TransientFailure
+	e.CheckEndpoints()
+	calls = nil
+	_, err = e.Disconnect(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"RADIUS"}, calls)
+	detail, err := e.Check()
+	assert.NoError(t, err)
+	assert.Contains(t, detail, "RADIUS@10.0.0.2:9108: connection is TRANSIENT_FAILURE")
+
+	// all endpoints unhealthy - they are all tried & the check fails
+	e, err = New([]string{"RADIUS@10.0.0.4:9108", "RADIUS@10.0.0.255:9108"}, 0)
+	assert.NoError(t, err)
+	e.CheckEndpoints()
+	_, err = e.Check()
+	assert.Error(t, err)
+	_, err = e.Disconnect(context.Background(), req)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Contains(t, err.Error(), "RADIUS@10.0.0.255:9108")
+
+	// calls aren't failed over past their context
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = e.Disconnect(ctx, req)
+	assert.Contains(t, err.Error(), "RADIUS@10.0.0.4:9108")
+}
@@ -0,0 +1,105 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package ratelimit implements token bucket rate limits of AAA requests by key (e.g. the requests' MAC address or
+// IMSI), so a misbehaving client or an AP loop flooding the AAA server can't overwhelm the session manager
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// sweepInterval - interval of removing the buckets refilled to their burst, idle keys' buckets are equivalent to
+// new buckets
+const sweepInterval = time.Minute
+
+type bucket struct {
+	tokens float64
+	last   time.Time // time of the last refill
+}
+
+// Limiter - token buckets of keys, every key's bucket holds up to burst tokens & is refilled at the rate of tokens
+// per second. Every request takes a token, requests of empty buckets are throttled
+type Limiter struct {
+	rate  float64
+	burst float64
+	now   func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+// New returns a new Limiter of the given rate per second & burst, burst below 1 is 1. Returns nil Limiter, not
+// limiting any key, for non positive rate
+func New(rate float64, burst int) *Limiter {
+	if rate <= 0 {
+		return nil
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &Limiter{rate: rate, burst: float64(burst), now: time.Now, buckets: map[string]*bucket{}}
+}
+
+// Allow takes a token of the key's bucket & returns true, or returns false & the wait for the key's next token if
+// the bucket is empty. Nil Limiter & empty key always allow
+func (l *Limiter) Allow(key string) (bool, time.Duration) {
+	if l == nil || len(key) == 0 {
+		return true, 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	l.sweep(now)
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[key] = b
+	}
+	b.refill(now, l.rate, l.burst)
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	wait := time.Duration(math.Ceil((1 - b.tokens) / l.rate * float64(time.Second)))
+	return false, wait
+}
+
+// Len returns the number of the keys' buckets
+func (l *Limiter) Len() int {
+	if l == nil {
+		return 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.buckets)
+}
+
+func (b *bucket) refill(now time.Time, rate, burst float64) {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = math.Min(burst, b.tokens+elapsed.Seconds()*rate)
+		b.last = now
+	}
+}
+
+// sweep removes the buckets refilled to their burst once per sweep interval, l.mu must be held
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for key, b := range l.buckets {
+		b.refill(now, l.rate, l.burst)
+		if b.tokens >= l.burst {
+			delete(l.buckets, key)
+		}
+	}
+}
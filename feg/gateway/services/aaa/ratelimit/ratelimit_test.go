@@ -0,0 +1,55 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiter(t *testing.T) {
+	now := time.Unix(1500000000, 0)
+	l := New(2, 3)
+	l.now = func() time.Time { return now }
+
+	// the burst is allowed, then requests are throttled until the bucket is refilled at the rate
+	for i := 0; i < 3; i++ {
+		ok, _ := l.Allow("0a:1b:2c:3d:4e:5f")
+		assert.True(t, ok)
+	}
+	ok, wait := l.Allow("0a:1b:2c:3d:4e:5f")
+	assert.False(t, ok)
+	assert.Equal(t, 500*time.Millisecond, wait)
+	ok, _ = l.Allow("0a:1b:2c:3d:4e:60")
+	assert.True(t, ok, "keys are limited independently")
+
+	now = now.Add(250 * time.Millisecond)
+	ok, wait = l.Allow("0a:1b:2c:3d:4e:5f")
+	assert.False(t, ok)
+	assert.Equal(t, 250*time.Millisecond, wait)
+	now = now.Add(250 * time.Millisecond)
+	ok, _ = l.Allow("0a:1b:2c:3d:4e:5f")
+	assert.True(t, ok)
+
+	// refilled buckets of idle keys are swept
+	now = now.Add(sweepInterval)
+	ok, _ = l.Allow("0a:1b:2c:3d:4e:61")
+	assert.True(t, ok)
+	assert.Equal(t, 1, l.Len())
+
+	// nil Limiter & empty keys aren't limited
+	var unlimited *Limiter
+	ok, _ = unlimited.Allow("0a:1b:2c:3d:4e:5f")
+	assert.True(t, ok)
+	assert.Nil(t, New(0, 10))
+	ok, _ = l.Allow("")
+	assert.True(t, ok)
+}
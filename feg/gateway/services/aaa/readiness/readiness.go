@@ -0,0 +1,129 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package readiness implements warm-up of the AAA server's downstream connections at start. Connections to all
+// dependencies are pre-dialed (& cached by the service registry), the server should only start serving once its
+// required dependencies are reachable
+package readiness
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"fbc/lib/go/retry"
+	"golang.org/x/net/context"
+
+	"magma/feg/gateway/registry"
+)
+
+// Dependency - downstream service of the AAA server
+type Dependency struct {
+	Service  string // service registry name
+	Required bool   // required dependencies must be reachable before the server starts serving
+}
+
+// dial connects to the service & caches the connection in the service registry
+var dial = func(service string) error {
+	_, err := registry.GetConnection(service)
+	return err
+}
+
+// retryInterval - delay between connection attempts to an unreachable dependency
+var retryInterval = time.Second
+
+// ParseDependencies parses comma separated lists of required & optional service registry names, services listed
+// in both lists are required
+func ParseDependencies(required, optional string) []Dependency {
+	byService := map[string]bool{}
+	for _, list := range []struct {
+		services string
+		required bool
+	}{{optional, false}, {required, true}} {
+		for _, service := range strings.Split(list.services, ",") {
+			service = strings.ToUpper(strings.TrimSpace(service))
+			if len(service) > 0 {
+				byService[service] = byService[service] || list.required
+			}
+		}
+	}
+	var deps []Dependency
+	for service, req := range byService {
+		deps = append(deps, Dependency{Service: service, Required: req})
+	}
+	sort.Slice(deps, func(i, j int) bool { return deps[i].Service < deps[j].Service })
+	return deps
+}
+
+// WarmUp dials all dependencies concurrently & waits up to timeout for the required ones to become reachable,
+// optional dependencies are dialed in the background. Returns an error listing required dependencies which are
+// not reachable within the timeout
+func WarmUp(deps []Dependency, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	dialer, interval := dial, retryInterval
+	type result struct {
+		service string
+		err     error
+	}
+	results := make(chan result, len(deps))
+	pending := map[string]error{}
+	for _, d := range deps {
+		if d.Required {
+			pending[d.Service] = fmt.Errorf("timeout")
+		}
+		go func(d Dependency) {
+			err := connect(dialer, d.Service, deadline, interval)
+			if err != nil {
+				log.Printf("Dependency %s (required: %t) is not reachable: %v", d.Service, d.Required, err)
+			} else {
+				log.Printf("Dependency %s (required: %t) is ready", d.Service, d.Required)
+			}
+			if d.Required {
+				results <- result{d.Service, err}
+			}
+		}(d)
+	}
+	timer := time.NewTimer(time.Until(deadline))
+	defer timer.Stop()
+	for waiting := len(pending); waiting > 0; waiting-- {
+		select {
+		case res := <-results:
+			if res.err == nil {
+				delete(pending, res.service)
+			} else {
+				pending[res.service] = res.err
+			}
+		case <-timer.C:
+			return notReadyError(pending)
+		}
+	}
+	return notReadyError(pending)
+}
+
+// connect dials the service until it's reachable or the next attempt would pass the deadline
+func connect(dial func(string) error, service string, deadline time.Time, interval time.Duration) error {
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+	retrier := &retry.Retrier{MaxAttempts: math.MaxInt32, Policy: retry.Constant(interval)}
+	return retrier.Do(ctx, func(context.Context) error { return dial(service) })
+}
+
+func notReadyError(pending map[string]error) error {
+	if len(pending) == 0 {
+		return nil
+	}
+	var msgs []string
+	for service, err := range pending {
+		msgs = append(msgs, fmt.Sprintf("%s: %v", service, err))
+	}
+	sort.Strings(msgs)
+	return fmt.Errorf("Required dependencies are not ready: %s", strings.Join(msgs, "; "))
+}
@@ -0,0 +1,80 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package readiness
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDependencies(t *testing.T) {
+	deps := ParseDependencies("sessiond, RADIUS", "radius,subscriberdb,,")
+	assert.Equal(t, []Dependency{
+		{Service: "RADIUS", Required: true},
+		{Service: "SESSIOND", Required: true},
+		{Service: "SUBSCRIBERDB", Required: false},
+	}, deps)
+	assert.Empty(t, ParseDependencies("", ""))
+}
+
+// fakeDialer fails each service's first failures[service] dials
+type fakeDialer struct {
+	sync.Mutex
+	failures map[string]int
+	dials    map[string]int
+}
+
+func (d *fakeDialer) dial(service string) error {
+	d.Lock()
+	defer d.Unlock()
+	d.dials[service]++
+	if d.dials[service] <= d.failures[service] {
+		return fmt.Errorf("%s is unavailable", service)
+	}
+	return nil
+}
+
+func TestWarmUp(t *testing.T) {
+	defer func(d func(string) error, ri time.Duration) { dial, retryInterval = d, ri }(dial, retryInterval)
+	retryInterval = time.Millisecond
+
+	// required dependencies become reachable after retries, optional ones don't block
+	d := &fakeDialer{failures: map[string]int{"SESSIOND": 3, "SUBSCRIBERDB": 1000000}, dials: map[string]int{}}
+	dial = d.dial
+	err := WarmUp([]Dependency{
+		{Service: "SESSIOND", Required: true},
+		{Service: "RADIUS", Required: true},
+		{Service: "SUBSCRIBERDB", Required: false},
+	}, time.Second)
+	assert.NoError(t, err)
+	d.Lock()
+	assert.Equal(t, 4, d.dials["SESSIOND"])
+	assert.Equal(t, 1, d.dials["RADIUS"])
+	d.Unlock()
+
+	// unreachable required dependency
+	d = &fakeDialer{failures: map[string]int{"SESSIOND": 1000000}, dials: map[string]int{}}
+	dial = d.dial
+	err = WarmUp([]Dependency{{Service: "SESSIOND", Required: true}, {Service: "RADIUS", Required: true}},
+		20*time.Millisecond)
+	assert.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "SESSIOND"))
+	assert.False(t, strings.Contains(err.Error(), "RADIUS"))
+
+	// dial blocking past the timeout
+	dial = func(string) error { time.Sleep(time.Second); return nil }
+	err = WarmUp([]Dependency{{Service: "RADIUS", Required: true}}, 10*time.Millisecond)
+	assert.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "RADIUS: timeout"))
+}
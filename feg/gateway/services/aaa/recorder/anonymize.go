@@ -0,0 +1,224 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package recorder
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// eapHeaderLen - EAP Code, Identifier, Length & Type, the kept part of recorded EAP payloads
+const eapHeaderLen = 5
+
+// Anonymizer replaces subscriber identifiers of AAA messages with pseudonyms. Pseudonyms are derived from the
+// identifiers with a keyed hash, so the same identifier gets the same pseudonym in all calls recorded with the same
+// key & the relations between calls (e.g. sessions of the same subscriber) are kept
+type Anonymizer struct {
+	key []byte
+}
+
+// NewAnonymizer returns an Anonymizer with the given pseudonyms key
+func NewAnonymizer(key []byte) *Anonymizer {
+	return &Anonymizer{key: key}
+}
+
+// stringFields - anonymizers of message string fields by their Go names
+var stringFields = map[string]func(a *Anonymizer, s string) string{
+	"Imsi":          (*Anonymizer).digits,
+	"Msisdn":        (*Anonymizer).digits,
+	"Identity":      (*Anonymizer).identity,
+	"OuterIdentity": (*Anonymizer).identity,
+	"Username":      (*Anonymizer).identity,
+	"UserName":      (*Anonymizer).identity,
+	"MacAddr":       (*Anonymizer).mac,
+	"IpAddr":        (*Anonymizer).ip,
+	"UeIpv4":        (*Anonymizer).ip,
+	"Ipv6Addr":      (*Anonymizer).ip,
+	"UeIpv6":        (*Anonymizer).ip,
+	"Ipv6Prefix":    (*Anonymizer).prefix,
+	"UeIpv6Prefix":  (*Anonymizer).prefix,
+	"Password":      redact,
+	"Token":         redact,
+}
+
+// bytesFields - anonymizers of message bytes fields by their Go names
+var bytesFields = map[string]func(b []byte) []byte{
+	"Msk":     zero(0),
+	"Payload": zero(eapHeaderLen), // EAP payloads keep their shape: code, type & length
+}
+
+// Message returns an anonymized copy of the message
+func (a *Anonymizer) Message(msg proto.Message) proto.Message {
+	if msg == nil || reflect.ValueOf(msg).IsNil() {
+		return msg
+	}
+	res := proto.Clone(msg)
+	a.walk(reflect.ValueOf(res))
+	return res
+}
+
+func (a *Anonymizer) walk(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			a.walk(v.Elem())
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			f, fv := t.Field(i), v.Field(i)
+			if len(f.PkgPath) > 0 || strings.HasPrefix(f.Name, "XXX_") || !fv.CanSet() {
+				continue
+			}
+			if anonymize, ok := stringFields[f.Name]; ok && fv.Kind() == reflect.String {
+				if s := fv.String(); len(s) > 0 {
+					fv.SetString(anonymize(a, s))
+				}
+				continue
+			}
+			if anonymize, ok := bytesFields[f.Name]; ok && fv.Type() == reflect.TypeOf([]byte(nil)) {
+				fv.SetBytes(anonymize(fv.Bytes()))
+				continue
+			}
+			a.walk(fv)
+		}
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			a.walk(v.Index(i))
+		}
+	case reflect.Map:
+		// map values aren't addressable, only maps of messages (pointers) are walked
+		if v.Type().Elem().Kind() != reflect.Ptr {
+			return
+		}
+		for _, k := range v.MapKeys() {
+			a.walk(v.MapIndex(k))
+		}
+	}
+}
+
+// hash returns the keyed hash of the value of the given kind
+func (a *Anonymizer) hash(kind, value string) []byte {
+	mac := hmac.New(sha256.New, a.key)
+	mac.Write([]byte(kind))
+	mac.Write([]byte{0})
+	mac.Write([]byte(value))
+	return mac.Sum(nil)
+}
+
+// digits returns a pseudonym of the same length of the digits of s, non digits (e.g. IMSI prefix) are kept & don't
+// change the pseudonym
+func (a *Anonymizer) digits(s string) string {
+	h := a.hash("digits", strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, s))
+	res := []byte(s)
+	j := 0
+	for i, c := range res {
+		if c >= '0' && c <= '9' {
+			res[i] = '0' + h[j%len(h)]%10
+			j++
+		}
+	}
+	return string(res)
+}
+
+// identity returns a pseudonym of the NAI's user part, the realm & the leading EAP method hint digit are kept
+func (a *Anonymizer) identity(s string) string {
+	user, realm := s, ""
+	if i := strings.LastIndex(s, "@"); i >= 0 {
+		user, realm = s[:i], s[i:]
+	}
+	prefix := ""
+	if len(user) > 0 && user[0] >= '0' && user[0] <= '9' {
+		prefix = user[:1]
+	}
+	return prefix + "anon-" + hex.EncodeToString(a.hash("identity", user))[:16] + realm
+}
+
+// mac returns a locally administered unicast MAC address pseudonym in the MAC's notation
+func (a *Anonymizer) mac(s string) string {
+	hw, err := net.ParseMAC(s)
+	if err != nil || len(hw) != 6 {
+		return "anon-" + hex.EncodeToString(a.hash("mac", s))[:12]
+	}
+	h := a.hash("mac", hw.String())
+	res := net.HardwareAddr(h[:6])
+	res[0] = res[0]&0xfc | 0x02
+	pseudonym := res.String()
+	if s == strings.ToUpper(s) {
+		pseudonym = strings.ToUpper(pseudonym)
+	}
+	if strings.Contains(s, "-") {
+		pseudonym = strings.Replace(pseudonym, ":", "-", -1)
+	}
+	return pseudonym
+}
+
+// ip returns a pseudonym address of the same family, IPv4 pseudonyms are in 10.0.0.0/8
+func (a *Anonymizer) ip(s string) string {
+	ip := net.ParseIP(s)
+	h := a.hash("ip", s)
+	switch {
+	case ip == nil:
+		return "anon-" + hex.EncodeToString(h)[:12]
+	case ip.To4() != nil:
+		return fmt.Sprintf("10.%d.%d.%d", h[0], h[1], h[2])
+	default:
+		res := net.IP(append([]byte{0xfd}, h[:15]...))
+		return res.String()
+	}
+}
+
+// prefix returns an anonymized prefix of the same length, e.g. a /56 prefix of fd00::/8
+func (a *Anonymizer) prefix(s string) string {
+	_, network, err := net.ParseCIDR(s)
+	if err != nil {
+		return a.ip(s)
+	}
+	ones, bits := network.Mask.Size()
+	ip := net.ParseIP(a.ip(network.IP.String())).Mask(net.CIDRMask(ones, bits))
+	return (&net.IPNet{IP: ip, Mask: network.Mask}).String()
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func redact(*Anonymizer, string) string {
+	return "redacted"
+}
+
+// zero returns a function zeroing all but the first keep bytes
+func zero(keep int) func(b []byte) []byte {
+	return func(b []byte) []byte {
+		if len(b) == 0 {
+			return b
+		}
+		res := make([]byte, len(b))
+		copy(res, b[:min(keep, len(b))])
+		return res
+	}
+}
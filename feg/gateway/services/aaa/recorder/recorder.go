@@ -0,0 +1,184 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package recorder records anonymized request/response pairs of AAA GRPC calls into replayable fixtures & replays
+// them against AAA services, so production traffic shapes can drive regression tests.
+//
+// Fixtures are JSON lines files of Records. Subscriber identifiers (IMSI, MSISDN, identities, MAC & IP addresses)
+// are replaced with keyed pseudonyms, credentials & keys are redacted & EAP payloads are reduced to their headers
+package recorder
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultMaxRecords - default maximum number of recorded calls
+const DefaultMaxRecords = 10000
+
+// Record - recorded call
+type Record struct {
+	Method       string          `json:"method"` // full GRPC method, e.g. /aaa.protos.accounting/Start
+	Time         time.Time       `json:"time"`
+	DurationNs   int64           `json:"duration_ns"`
+	RequestType  string          `json:"request_type"`
+	Request      json.RawMessage `json:"request"`
+	ResponseType string          `json:"response_type,omitempty"`
+	Response     json.RawMessage `json:"response,omitempty"`
+	Code         string          `json:"code"` // GRPC status code
+	Error        string          `json:"error,omitempty"`
+}
+
+// Config - recording configuration
+type Config struct {
+	Path       string   // fixtures file path, records are appended
+	Methods    []string // recorded methods, full or their suffixes (e.g. accounting/Start), empty - all methods
+	MaxRecords int      // recording stops after MaxRecords calls, 0 - DefaultMaxRecords
+	Key        []byte   // pseudonyms key, random if empty
+}
+
+// Recorder records calls of the GRPC server it intercepts
+type Recorder struct {
+	sync.Mutex
+	cfg        Config
+	anonymizer *Anonymizer
+	file       *os.File
+	records    int
+}
+
+// New returns a new Recorder appending to the configured fixtures file
+func New(cfg Config) (*Recorder, error) {
+	if cfg.MaxRecords <= 0 {
+		cfg.MaxRecords = DefaultMaxRecords
+	}
+	if len(cfg.Key) == 0 {
+		cfg.Key = make([]byte, 32)
+		if _, err := rand.Read(cfg.Key); err != nil {
+			return nil, err
+		}
+	}
+	f, err := os.OpenFile(cfg.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0640)
+	if err != nil {
+		return nil, err
+	}
+	return &Recorder{cfg: cfg, anonymizer: NewAnonymizer(cfg.Key), file: f}, nil
+}
+
+// UnaryServerInterceptor records the call, if its method is recorded. A nil Recorder records nothing
+func (r *Recorder) UnaryServerInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+
+	if r == nil || !r.recorded(info.FullMethod) {
+		return handler(ctx, req)
+	}
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	duration := time.Since(start)
+	if recErr := r.record(info.FullMethod, start, duration, req, resp, err); recErr != nil {
+		log.Printf("Error recording %s call: %v", info.FullMethod, recErr)
+	}
+	return resp, err
+}
+
+// Close closes the fixtures file
+func (r *Recorder) Close() error {
+	if r == nil {
+		return nil
+	}
+	r.Lock()
+	defer r.Unlock()
+	return r.file.Close()
+}
+
+// recorded returns true if the method is recorded & the maximum number of records isn't reached
+func (r *Recorder) recorded(method string) bool {
+	r.Lock()
+	full := r.records >= r.cfg.MaxRecords
+	r.Unlock()
+	if full {
+		return false
+	}
+	if len(r.cfg.Methods) == 0 {
+		return true
+	}
+	for _, m := range r.cfg.Methods {
+		if strings.HasSuffix(method, m) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Recorder) record(
+	method string, start time.Time, duration time.Duration, req, resp interface{}, callErr error) error {
+
+	rec := &Record{
+		Method:     method,
+		Time:       start,
+		DurationNs: int64(duration),
+		Code:       status.Code(callErr).String(),
+	}
+	if callErr != nil {
+		rec.Error = status.Convert(callErr).Message()
+	}
+	var err error
+	if rec.RequestType, rec.Request, err = r.marshal(req); err != nil {
+		return err
+	}
+	if rec.ResponseType, rec.Response, err = r.marshal(resp); err != nil {
+		return err
+	}
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+
+	r.Lock()
+	defer r.Unlock()
+	if r.records >= r.cfg.MaxRecords {
+		return nil
+	}
+	r.records++
+	if r.records == r.cfg.MaxRecords {
+		log.Printf("Recorded %d calls into %s, recording is stopped", r.records, r.cfg.Path)
+	}
+	// records are written unbuffered, so recordings of crashed processes are usable
+	_, err = r.file.Write(append(line, '\n'))
+	return err
+}
+
+// marshal returns the type name & JSON of the anonymized message, empty for nil or non proto messages
+func (r *Recorder) marshal(v interface{}) (string, json.RawMessage, error) {
+	msg, ok := v.(proto.Message)
+	if !ok || msg == nil || reflect.ValueOf(msg).IsNil() {
+		return "", nil, nil
+	}
+	msg = r.anonymizer.Message(msg)
+	var buf bytes.Buffer
+	if err := (&jsonpb.Marshaler{OrigName: true}).Marshal(&buf, msg); err != nil {
+		return "", nil, fmt.Errorf("failed marshaling %T: %v", msg, err)
+	}
+	return proto.MessageName(msg), json.RawMessage(buf.Bytes()), nil
+}
@@ -0,0 +1,137 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package recorder
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+type testAccounting struct{}
+
+func (testAccounting) Start(ctx context.Context, aaaCtx *protos.Context) (*protos.AcctResp, error) {
+	if len(aaaCtx.GetImsi()) == 0 {
+		return &protos.AcctResp{}, status.Errorf(codes.InvalidArgument, "Missing IMSI")
+	}
+	return &protos.AcctResp{AcctInterimInterval: 300}, nil
+}
+
+func TestAnonymizer(t *testing.T) {
+	a := NewAnonymizer([]byte("key"))
+	aaaCtx := &protos.Context{
+		SessionId:     "sid1",
+		Imsi:          "IMSI001010000000001",
+		Msisdn:        "15551234567",
+		Identity:      "0001010000000001@wlan.mnc001.mcc001.3gppnetwork.org",
+		OuterIdentity: "anonymous@wlan.mnc001.mcc001.3gppnetwork.org",
+		MacAddr:       "AA-BB-CC-DD-EE-FF",
+		IpAddr:        "192.168.1.10",
+		Ipv6Addr:      "2001:db8::10",
+		Ipv6Prefix:    "2001:db8:1::/56",
+		Msk:           []byte{1, 2, 3},
+		Apn:           "ap1",
+	}
+	eap := &protos.Eap{Payload: []byte{2, 1, 0, 8, 23, 5, 6, 7}, Ctx: aaaCtx}
+
+	res := a.Message(eap).(*protos.Eap)
+	anon := res.GetCtx()
+	assert.Equal(t, []byte{2, 1, 0, 8, 23, 0, 0, 0}, res.GetPayload())
+	assert.Equal(t, []byte{0, 0, 0}, anon.GetMsk())
+	assert.Equal(t, "sid1", anon.GetSessionId())
+	assert.Equal(t, "ap1", anon.GetApn())
+	assert.NotEqual(t, aaaCtx.GetImsi(), anon.GetImsi())
+	assert.Len(t, anon.GetImsi(), len(aaaCtx.GetImsi()))
+	assert.Equal(t, "IMSI", anon.GetImsi()[:4])
+	assert.Len(t, anon.GetMsisdn(), len(aaaCtx.GetMsisdn()))
+	assert.Regexp(t, `^0anon-[0-9a-f]{16}@wlan\.mnc001\.mcc001\.3gppnetwork\.org$`, anon.GetIdentity())
+	assert.Regexp(t, `^anon-[0-9a-f]{16}@wlan`, anon.GetOuterIdentity())
+	assert.Regexp(t, `^[0-9A-F]{2}(-[0-9A-F]{2}){5}$`, anon.GetMacAddr())
+	assert.NotEqual(t, aaaCtx.GetMacAddr(), anon.GetMacAddr())
+	assert.Regexp(t, `^10\.`, anon.GetIpAddr())
+	assert.Regexp(t, `^fd`, anon.GetIpv6Addr())
+	assert.Regexp(t, `^fd[0-9a-f:]+/56$`, anon.GetIpv6Prefix())
+	// the original message is not modified
+	assert.Equal(t, "IMSI001010000000001", eap.GetCtx().GetImsi())
+
+	// pseudonyms are stable & don't depend on the identifiers' notations
+	again := a.Message(&protos.Context{Imsi: "001010000000001", MacAddr: "aa:bb:cc:dd:ee:ff"}).(*protos.Context)
+	assert.Equal(t, anon.GetImsi()[4:], again.GetImsi())
+	assert.Equal(t, anon.GetMacAddr(), strings.Replace(strings.ToUpper(again.GetMacAddr()), ":", "-", -1))
+	other := NewAnonymizer([]byte("other")).Message(aaaCtx).(*protos.Context)
+	assert.NotEqual(t, anon.GetImsi(), other.GetImsi())
+
+	creds := a.Message(&protos.UserCredentials{Username: "user@realm", Password: "secret"}).(*protos.UserCredentials)
+	assert.Equal(t, "redacted", creds.GetPassword())
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "recorder_test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "fixtures.jsonl")
+	r, err := New(Config{Path: path, Methods: []string{"accounting/Start"}, MaxRecords: 2})
+	assert.NoError(t, err)
+
+	srv := testAccounting{}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.Start(ctx, req.(*protos.Context))
+	}
+	start := &grpc.UnaryServerInfo{FullMethod: "/aaa.protos.accounting/Start"}
+	stop := &grpc.UnaryServerInfo{FullMethod: "/aaa.protos.accounting/Stop"}
+	ctx := context.Background()
+	for _, call := range []struct {
+		info *grpc.UnaryServerInfo
+		req  *protos.Context
+	}{
+		{start, &protos.Context{SessionId: "sid1", Imsi: "001010000000001"}},
+		{stop, &protos.Context{SessionId: "sid1", Imsi: "001010000000001"}}, // not recorded method
+		{start, &protos.Context{SessionId: "sid2"}},
+		{start, &protos.Context{SessionId: "sid3", Imsi: "001010000000003"}}, // over MaxRecords
+	} {
+		_, err = r.UnaryServerInterceptor(ctx, call.req, call.info, handler)
+		assert.Equal(t, call.req.GetImsi() == "", err != nil)
+	}
+	assert.NoError(t, r.Close())
+
+	records, err := ReadRecords(path)
+	assert.NoError(t, err)
+	assert.Len(t, records, 2)
+	assert.Equal(t, "/aaa.protos.accounting/Start", records[0].Method)
+	assert.Equal(t, "aaa.protos.context", records[0].RequestType)
+	assert.Equal(t, "OK", records[0].Code)
+	assert.NotContains(t, string(records[0].Request), "001010000000001")
+	assert.Equal(t, "InvalidArgument", records[1].Code)
+	assert.Equal(t, "Missing IMSI", records[1].Error)
+
+	replay := ServerHandler(map[string]interface{}{"aaa.protos.accounting": srv})
+	mismatches, err := Replay(ctx, records, replay, true)
+	assert.NoError(t, err)
+	assert.Empty(t, mismatches)
+
+	// a regression changing the calls' results is reported
+	records[1].Code = "OK"
+	mismatches, err = Replay(ctx, records, replay, true)
+	assert.NoError(t, err)
+	assert.Len(t, mismatches, 1)
+	assert.Equal(t, "InvalidArgument", mismatches[0].Code)
+
+	_, err = replay(ctx, "/aaa.protos.accounting/Unknown", &protos.Context{})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
@@ -0,0 +1,171 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package recorder
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxRecordLen - maximum length of a fixtures file line
+const maxRecordLen = 4 * 1024 * 1024
+
+// Handler handles a replayed call of the given full GRPC method
+type Handler func(ctx context.Context, method string, req proto.Message) (proto.Message, error)
+
+// Mismatch - replayed call which result differs from the recorded one
+type Mismatch struct {
+	Record   *Record
+	Code     string        // replayed call's status code
+	Response proto.Message // replayed call's response
+	Err      error         // replayed call's error
+}
+
+// String returns the mismatch description
+func (m *Mismatch) String() string {
+	if m.Code != m.Record.Code {
+		return fmt.Sprintf("%s at %v: code %s != recorded %s (%v)",
+			m.Record.Method, m.Record.Time, m.Code, m.Record.Code, m.Err)
+	}
+	return fmt.Sprintf("%s at %v: response %v != recorded %s",
+		m.Record.Method, m.Record.Time, m.Response, string(m.Record.Response))
+}
+
+// ReadRecords reads the records of the fixtures file
+func ReadRecords(path string) ([]*Record, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var records []*Record
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), maxRecordLen)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		rec := &Record{}
+		if err = json.Unmarshal(scanner.Bytes(), rec); err != nil {
+			return nil, fmt.Errorf("invalid record at %s:%d: %v", path, line, err)
+		}
+		records = append(records, rec)
+	}
+	return records, scanner.Err()
+}
+
+// RequestMessage returns the recorded request
+func (rec *Record) RequestMessage() (proto.Message, error) {
+	return decode(rec.RequestType, rec.Request)
+}
+
+// ResponseMessage returns the recorded response, nil if the call had none
+func (rec *Record) ResponseMessage() (proto.Message, error) {
+	if len(rec.ResponseType) == 0 {
+		return nil, nil
+	}
+	return decode(rec.ResponseType, rec.Response)
+}
+
+// Replay replays the recorded calls in order with the handler & returns the calls with status codes &, if
+// compareResponses is set, responses differing from the recorded ones
+func Replay(ctx context.Context, records []*Record, handler Handler, compareResponses bool) ([]*Mismatch, error) {
+	var mismatches []*Mismatch
+	for _, rec := range records {
+		req, err := rec.RequestMessage()
+		if err != nil {
+			return mismatches, fmt.Errorf("%s request: %v", rec.Method, err)
+		}
+		resp, callErr := handler(ctx, rec.Method, req)
+		m := &Mismatch{Record: rec, Code: status.Code(callErr).String(), Response: resp, Err: callErr}
+		if m.Code != rec.Code {
+			mismatches = append(mismatches, m)
+			continue
+		}
+		if !compareResponses {
+			continue
+		}
+		recorded, err := rec.ResponseMessage()
+		if err != nil {
+			return mismatches, fmt.Errorf("%s response: %v", rec.Method, err)
+		}
+		if !equal(resp, recorded) {
+			mismatches = append(mismatches, m)
+		}
+	}
+	return mismatches, nil
+}
+
+// ServerHandler returns a Handler calling the unary methods of the GRPC service implementations by their service
+// names, e.g. aaa.protos.accounting
+func ServerHandler(servers map[string]interface{}) Handler {
+	return func(ctx context.Context, method string, req proto.Message) (proto.Message, error) {
+		parts := strings.Split(strings.TrimPrefix(method, "/"), "/")
+		if len(parts) != 2 {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid method %s", method)
+		}
+		srv, ok := servers[parts[0]]
+		if !ok {
+			return nil, status.Errorf(codes.Unimplemented, "Unknown service %s", parts[0])
+		}
+		m := reflect.ValueOf(srv).MethodByName(parts[1])
+		if !m.IsValid() || m.Type().NumIn() != 2 || m.Type().NumOut() != 2 {
+			return nil, status.Errorf(codes.Unimplemented, "Unknown unary method %s", method)
+		}
+		out := m.Call([]reflect.Value{reflect.ValueOf(ctx), reflect.ValueOf(req)})
+		var err error
+		if e := out[1].Interface(); e != nil {
+			err = e.(error)
+		}
+		resp, _ := out[0].Interface().(proto.Message)
+		return resp, err
+	}
+}
+
+// decode returns the message of the registered type from its JSON
+func decode(typ string, raw json.RawMessage) (proto.Message, error) {
+	t := proto.MessageType(typ)
+	if t == nil {
+		return nil, fmt.Errorf("unknown message type %s", typ)
+	}
+	msg, ok := reflect.New(t.Elem()).Interface().(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a message", typ)
+	}
+	if err := (&jsonpb.Unmarshaler{AllowUnknownFields: true}).Unmarshal(bytes.NewReader(raw), msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// equal returns true if both responses are equal, nil responses only equal nil or empty responses
+func equal(a, b proto.Message) bool {
+	isNil := func(m proto.Message) bool { return m == nil || reflect.ValueOf(m).IsNil() }
+	switch {
+	case isNil(a) && isNil(b):
+		return true
+	case isNil(a):
+		return proto.Size(b) == 0
+	case isNil(b):
+		return proto.Size(a) == 0
+	default:
+		return proto.Equal(a, b)
+	}
+}
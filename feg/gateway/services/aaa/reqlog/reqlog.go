@@ -0,0 +1,150 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package reqlog logs every AAA call as a structured record: its method, session ID, redacted IMSI, result code,
+// error class & duration, so successful calls are visible & failed calls can be correlated with their sessions
+package reqlog
+
+import (
+	"path"
+	"strings"
+	"time"
+
+	"fbc/lib/go/retry"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"magma/feg/gateway/services/aaa/protos"
+	"magma/feg/gateway/services/eap"
+)
+
+// Off - level disabling the call logging
+const Off = "off"
+
+// Redacted IMSI digits: the PLMN (MCC & MNC) & the last digits are kept, so records of the same subscriber are
+// still recognizable
+const (
+	imsiKeptPrefix = 5
+	imsiKeptSuffix = 2
+	imsiPrefix     = "IMSI"
+)
+
+// Logger logs AAA calls as structured records
+type Logger struct {
+	log   *zap.Logger
+	level zapcore.Level // level of successful calls' records, failed calls are logged at least at warn level
+}
+
+// New returns a Logger of calls into log, successful calls are logged at the given level
+func New(log *zap.Logger, level zapcore.Level) *Logger {
+	return &Logger{log: log, level: level}
+}
+
+// NewProduction returns a Logger of calls as JSON records to stderr, successful calls are logged at the given level
+// (debug, info, warn or error). Returns nil Logger, logging nothing, for Off level
+func NewProduction(level string) (*Logger, error) {
+	if strings.EqualFold(level, Off) {
+		return nil, nil
+	}
+	var lvl zapcore.Level
+	if err := lvl.Set(level); err != nil {
+		return nil, err
+	}
+	cfg := zap.NewProductionConfig()
+	cfg.Level = zap.NewAtomicLevelAt(lvl)
+	cfg.Sampling = nil // every call is logged
+	cfg.DisableStacktrace = true
+	log, err := cfg.Build()
+	if err != nil {
+		return nil, err
+	}
+	return New(log.Named("rpc"), lvl), nil
+}
+
+// UnaryServerInterceptor logs the call once it's handled, it's a pass through for nil Logger
+func (l *Logger) UnaryServerInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+
+	if l == nil {
+		return handler(ctx, req)
+	}
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	duration := time.Since(start)
+
+	level, code := l.level, status.Code(err).String()
+	if err != nil {
+		if level < zapcore.WarnLevel {
+			level = zapcore.WarnLevel
+		}
+	} else if e, ok := resp.(*protos.Eap); ok && eap.Packet(e.GetPayload()).Code() == eap.FailureCode {
+		code = protos.EapCode_Failure.String()
+	}
+	ce := l.log.Check(level, "AAA call")
+	if ce == nil {
+		return resp, err
+	}
+	sid, imsi := sessionOf(req, resp)
+	fields := []zap.Field{
+		zap.String("method", path.Base(info.FullMethod)),
+		zap.String("session_id", sid),
+		zap.String("imsi", RedactIMSI(imsi)),
+		zap.String("code", code),
+		zap.Duration("duration", duration),
+	}
+	if err != nil {
+		fields = append(fields,
+			zap.String("error", status.Convert(err).Message()), zap.Stringer("error_class", retry.ClassOf(err)))
+	}
+	ce.Write(fields...)
+	return resp, err
+}
+
+// RedactIMSI returns the IMSI with its subscriber digits, but the last ones, masked
+func RedactIMSI(imsi string) string {
+	digits := strings.TrimPrefix(imsi, imsiPrefix)
+	if len(digits) <= imsiKeptPrefix+imsiKeptSuffix {
+		return strings.Repeat("*", len(imsi))
+	}
+	masked := strings.Repeat("*", len(digits)-imsiKeptPrefix-imsiKeptSuffix)
+	return imsi[:len(imsi)-len(digits)] + digits[:imsiKeptPrefix] + masked + digits[len(digits)-imsiKeptSuffix:]
+}
+
+// sessionOf returns the session ID & IMSI of the call, taken from the request's context or the response's one, e.g.
+// the IMSI of an EAP-Identity is only known once it's handled
+func sessionOf(req, resp interface{}) (sid, imsi string) {
+	for _, msg := range []interface{}{req, resp} {
+		if c := messageContext(msg); c != nil {
+			if len(sid) == 0 {
+				sid = c.GetSessionId()
+			}
+			if len(imsi) == 0 {
+				imsi = c.GetImsi()
+			}
+		} else if r, ok := msg.(interface{ GetSessionId() string }); ok && len(sid) == 0 {
+			sid = r.GetSessionId()
+		}
+	}
+	return sid, imsi
+}
+
+func messageContext(msg interface{}) *protos.Context {
+	switch m := msg.(type) {
+	case *protos.Context:
+		return m
+	case interface{ GetCtx() *protos.Context }:
+		return m.GetCtx()
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package reqlog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+func TestRedactIMSI(t *testing.T) {
+	assert.Equal(t, "00101********23", RedactIMSI("001010000000123"))
+	assert.Equal(t, "IMSI00101********23", RedactIMSI("IMSI001010000000123"))
+	assert.Equal(t, "*****", RedactIMSI("00101"))
+	assert.Equal(t, "", RedactIMSI(""))
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	l := New(zap.New(core), zapcore.InfoLevel)
+	info := &grpc.UnaryServerInfo{FullMethod: "/aaa.accounting/start"}
+	aaaCtx := &protos.Context{SessionId: "sid1", Imsi: "001010000000123"}
+
+	// successful calls are logged at the configured level
+	resp, err := l.UnaryServerInterceptor(context.Background(), aaaCtx, info,
+		func(context.Context, interface{}) (interface{}, error) { return &protos.AcctResp{}, nil })
+	require.NoError(t, err)
+	assert.NotNil(t, resp)
+	require.Equal(t, 1, logs.Len())
+	entry := logs.AllUntimed()[0]
+	assert.Equal(t, zapcore.InfoLevel, entry.Level)
+	fields := entry.ContextMap()
+	assert.Equal(t, "start", fields["method"])
+	assert.Equal(t, "sid1", fields["session_id"])
+	assert.Equal(t, "00101********23", fields["imsi"])
+	assert.Equal(t, "OK", fields["code"])
+	assert.Contains(t, fields, "duration")
+	assert.NotContains(t, fields, "error")
+	assert.NotContains(t, fields, "error_class")
+
+	// failed calls are logged at least at warn level with their error, the IMSI may come from the response
+	_, err = l.UnaryServerInterceptor(context.Background(), &protos.StopRequest{Ctx: &protos.Context{SessionId: "sid2"}},
+		&grpc.UnaryServerInfo{FullMethod: "/aaa.accounting/stop"},
+		func(context.Context, interface{}) (interface{}, error) {
+			return &protos.AcctResp{}, status.Error(codes.FailedPrecondition, "session sid2 is not found")
+		})
+	assert.Error(t, err)
+	require.Equal(t, 2, logs.Len())
+	entry = logs.AllUntimed()[1]
+	assert.Equal(t, zapcore.WarnLevel, entry.Level)
+	fields = entry.ContextMap()
+	assert.Equal(t, "sid2", fields["session_id"])
+	assert.Equal(t, "FailedPrecondition", fields["code"])
+	assert.Equal(t, "session sid2 is not found", fields["error"])
+	assert.Equal(t, "permanent", fields["error_class"])
+
+	// calls below the configured level aren't logged
+	quiet, quietLogs := observer.New(zapcore.InfoLevel)
+	l = New(zap.New(quiet), zapcore.DebugLevel)
+	_, err = l.UnaryServerInterceptor(context.Background(), aaaCtx, info,
+		func(context.Context, interface{}) (interface{}, error) { return &protos.AcctResp{}, nil })
+	assert.NoError(t, err)
+	assert.Equal(t, 0, quietLogs.Len())
+
+	// nil Logger is a pass through
+	l = nil
+	_, err = l.UnaryServerInterceptor(context.Background(), aaaCtx, info,
+		func(context.Context, interface{}) (interface{}, error) { return &protos.AcctResp{}, nil })
+	assert.NoError(t, err)
+}
+
+func TestNewProduction(t *testing.T) {
+	l, err := NewProduction("off")
+	assert.NoError(t, err)
+	assert.Nil(t, l)
+	l, err = NewProduction("debug")
+	assert.NoError(t, err)
+	assert.NotNil(t, l)
+	_, err = NewProduction("verbose")
+	assert.Error(t, err)
+}
@@ -0,0 +1,246 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package retention bounds the disk space of the AAA server's persisted data. Append only files (e.g. the audit log)
+// are rotated once they reach their size limit & a purge job removes rotated files older than their data set's age
+// limit or over its size limit, so long running gateways with small disks don't fill up
+package retention
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/aaa/panics"
+)
+
+// DefaultPurgeInterval is the default interval of the purge job
+const DefaultPurgeInterval = time.Hour
+
+// rotatedTimeFormat - sortable UTC time suffix of rotated files
+const rotatedTimeFormat = "20060102T150405.000000000"
+
+// Policy - age & total size limits of a data set's files, a zero limit is unlimited
+type Policy struct {
+	MaxAge   time.Duration
+	MaxBytes int64
+}
+
+// Target - files of a data set subject to a retention policy
+type Target struct {
+	Name    string // data set name, e.g. audit_log
+	Pattern string // glob pattern of the data set's purgeable files
+	Policy  Policy
+}
+
+// TargetConfig - JSON configuration of a data set's retention, MaxAge is a duration string, e.g. 168h
+type TargetConfig struct {
+	Name     string `json:"name"`
+	Pattern  string `json:"pattern"`
+	MaxAge   string `json:"max_age,omitempty"`
+	MaxBytes int64  `json:"max_bytes,omitempty"`
+}
+
+// ReadTargets reads & validates JSON retention targets (a list of TargetConfig) from the given file, so operators
+// can bound other persisted data sets, e.g. session history dumps, checkpoints or GRPC recordings
+func ReadTargets(path string) ([]Target, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cfgs []TargetConfig
+	if err = json.Unmarshal(b, &cfgs); err != nil {
+		return nil, fmt.Errorf("Invalid retention configuration %s: %v", path, err)
+	}
+	targets := make([]Target, 0, len(cfgs))
+	for _, cfg := range cfgs {
+		t, err := cfg.Target()
+		if err != nil {
+			return nil, fmt.Errorf("Invalid retention configuration %s: %v", path, err)
+		}
+		targets = append(targets, t)
+	}
+	return targets, nil
+}
+
+// Target returns the configured target, an error if the target is unnamed, its pattern is invalid or it has no
+// limits
+func (cfg TargetConfig) Target() (Target, error) {
+	t := Target{Name: cfg.Name, Pattern: cfg.Pattern, Policy: Policy{MaxBytes: cfg.MaxBytes}}
+	if len(cfg.Name) == 0 {
+		return t, fmt.Errorf("missing name of retention target with pattern '%s'", cfg.Pattern)
+	}
+	if _, err := filepath.Match(cfg.Pattern, ""); err != nil || len(cfg.Pattern) == 0 {
+		return t, fmt.Errorf("invalid pattern '%s' of retention target '%s'", cfg.Pattern, cfg.Name)
+	}
+	if len(cfg.MaxAge) > 0 {
+		age, err := time.ParseDuration(cfg.MaxAge)
+		if err != nil || age < 0 {
+			return t, fmt.Errorf("invalid max_age '%s' of retention target '%s'", cfg.MaxAge, cfg.Name)
+		}
+		t.Policy.MaxAge = age
+	}
+	if t.Policy.MaxAge == 0 && t.Policy.MaxBytes <= 0 {
+		return t, fmt.Errorf("retention target '%s' requires max_age and/or max_bytes", cfg.Name)
+	}
+	return t, nil
+}
+
+// Purge removes the target's files modified before the policy's MaxAge & the oldest files over its MaxBytes, it
+// returns the number of removed files & their bytes
+func Purge(t Target, now time.Time) (files int, bytes int64, err error) {
+	paths, err := filepath.Glob(t.Pattern)
+	if err != nil {
+		return 0, 0, err
+	}
+	type file struct {
+		path string
+		info os.FileInfo
+	}
+	found := make([]file, 0, len(paths))
+	for _, p := range paths {
+		fi, err := os.Stat(p)
+		if err != nil || !fi.Mode().IsRegular() {
+			continue
+		}
+		found = append(found, file{path: p, info: fi})
+	}
+	// newest first, so the size budget is spent on the most recent data
+	sort.Slice(found, func(i, j int) bool { return found[i].info.ModTime().After(found[j].info.ModTime()) })
+
+	var retained int64
+	for _, f := range found {
+		fi := f.info
+		expired := t.Policy.MaxAge > 0 && now.Sub(fi.ModTime()) > t.Policy.MaxAge
+		oversize := t.Policy.MaxBytes > 0 && retained+fi.Size() > t.Policy.MaxBytes
+		if !expired && !oversize {
+			retained += fi.Size()
+			continue
+		}
+		if rmErr := os.Remove(f.path); rmErr != nil {
+			if err == nil {
+				err = rmErr
+			}
+			retained += fi.Size()
+			continue
+		}
+		files++
+		bytes += fi.Size()
+	}
+	metrics.RetainedBytes.WithLabelValues(t.Name).Set(float64(retained))
+	metrics.PurgedFiles.WithLabelValues(t.Name).Add(float64(files))
+	metrics.PurgedBytes.WithLabelValues(t.Name).Add(float64(bytes))
+	return files, bytes, err
+}
+
+// Run purges the targets every interval, it never returns
+func Run(targets []Target, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultPurgeInterval
+	}
+	for _, t := range targets {
+		log.Printf("%s retention: max age %v, max bytes %d, purge interval %v",
+			t.Name, t.Policy.MaxAge, t.Policy.MaxBytes, interval)
+	}
+	for {
+		for _, t := range targets {
+			func() {
+				defer panics.Recover("retention_purge")
+				files, bytes, err := Purge(t, time.Now())
+				if err != nil {
+					log.Printf("Error purging %s: %v", t.Name, err)
+				}
+				if files > 0 {
+					log.Printf("Purged %d %s files, %d bytes", files, t.Name, bytes)
+				}
+			}()
+		}
+		time.Sleep(interval)
+	}
+}
+
+// File - an append only file rotated once it reaches its size limit, rotated files are renamed to
+// <path>.<UTC rotation time> & can be purged by a Target of the Segments pattern
+type File struct {
+	mu       sync.Mutex
+	path     string
+	maxBytes int64
+	f        *os.File
+	size     int64
+	now      func() time.Time
+}
+
+// OpenFile opens the file for appending, the file is rotated once it reaches maxBytes, 0 - never rotated
+func OpenFile(path string, maxBytes int64) (*File, error) {
+	rf := &File{path: path, maxBytes: maxBytes, now: time.Now}
+	if err := rf.open(); err != nil {
+		return nil, err
+	}
+	return rf, nil
+}
+
+// Segments returns the glob pattern of the file's rotated segments
+func (rf *File) Segments() string {
+	return rf.path + ".*"
+}
+
+// Write appends b to the file, the file is rotated before the write if b would take it over its size limit.
+// Writes are never split, so a record written by a single Write stays in a single file
+func (rf *File) Write(b []byte) (int, error) {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+	if rf.maxBytes > 0 && rf.size > 0 && rf.size+int64(len(b)) > rf.maxBytes {
+		if err := rf.rotate(); err != nil {
+			log.Printf("Error rotating %s: %v", rf.path, err)
+		}
+	}
+	n, err := rf.f.Write(b)
+	rf.size += int64(n)
+	return n, err
+}
+
+// Close closes the file
+func (rf *File) Close() error {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+	return rf.f.Close()
+}
+
+func (rf *File) open() error {
+	f, err := os.OpenFile(rf.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0640)
+	if err != nil {
+		return err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	rf.f, rf.size = f, fi.Size()
+	return nil
+}
+
+func (rf *File) rotate() error {
+	if err := rf.f.Close(); err != nil {
+		log.Printf("Error closing %s before rotation: %v", rf.path, err)
+	}
+	rotated := fmt.Sprintf("%s.%s", rf.path, rf.now().UTC().Format(rotatedTimeFormat))
+	// the file is reopened even if it can't be renamed, appending to it rather than losing records
+	renameErr := os.Rename(rf.path, rotated)
+	if err := rf.open(); err != nil {
+		return err
+	}
+	return renameErr
+}
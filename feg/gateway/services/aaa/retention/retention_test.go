@@ -0,0 +1,98 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package retention
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileRotation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "retention")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "audit.log")
+
+	f, err := OpenFile(path, 10)
+	require.NoError(t, err)
+	now := time.Unix(1000, 0)
+	f.now = func() time.Time { now = now.Add(time.Second); return now }
+	for _, rec := range []string{"0123456\n", "abc\n", "de\n", "0123456789abcdef\n"} {
+		n, err := f.Write([]byte(rec))
+		require.NoError(t, err)
+		assert.Equal(t, len(rec), n)
+	}
+	require.NoError(t, f.Close())
+
+	segments, err := filepath.Glob(f.Segments())
+	require.NoError(t, err)
+	require.Len(t, segments, 2)
+	// records are never split between the files
+	b, _ := ioutil.ReadFile(segments[0])
+	assert.Equal(t, "0123456\n", string(b))
+	b, _ = ioutil.ReadFile(segments[1])
+	assert.Equal(t, "abc\nde\n", string(b))
+	b, _ = ioutil.ReadFile(path)
+	assert.Equal(t, "0123456789abcdef\n", string(b))
+
+	// reopened files keep their size
+	f, err = OpenFile(path, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(17), f.size)
+	f.Close()
+}
+
+func TestPurge(t *testing.T) {
+	dir, err := ioutil.TempDir("", "retention")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	now := time.Now()
+	for i, age := range []time.Duration{time.Hour, 2 * time.Hour, 3 * time.Hour, 50 * time.Hour} {
+		path := filepath.Join(dir, "audit.log."+string('a'+byte(i)))
+		require.NoError(t, ioutil.WriteFile(path, []byte(strings.Repeat("x", 100)), 0640))
+		require.NoError(t, os.Chtimes(path, now.Add(-age), now.Add(-age)))
+	}
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "audit.log"), []byte("active"), 0640))
+
+	// the oldest file is over the max age, the third one is over the size limit
+	target := Target{
+		Name:    "audit_log",
+		Pattern: filepath.Join(dir, "audit.log.*"),
+		Policy:  Policy{MaxAge: 48 * time.Hour, MaxBytes: 250},
+	}
+	files, bytes, err := Purge(target, now)
+	require.NoError(t, err)
+	assert.Equal(t, 2, files)
+	assert.Equal(t, int64(200), bytes)
+	left, _ := filepath.Glob(filepath.Join(dir, "*"))
+	assert.Equal(t, []string{
+		filepath.Join(dir, "audit.log"), filepath.Join(dir, "audit.log.a"), filepath.Join(dir, "audit.log.b")}, left)
+}
+
+func TestTargetConfig(t *testing.T) {
+	target, err := TargetConfig{Name: "history", Pattern: "/var/history/*.json", MaxAge: "168h"}.Target()
+	require.NoError(t, err)
+	assert.Equal(t, Policy{MaxAge: 168 * time.Hour}, target.Policy)
+
+	_, err = TargetConfig{Name: "history", Pattern: "/var/history/*.json"}.Target()
+	assert.Error(t, err)
+	_, err = TargetConfig{Pattern: "/var/history/*.json", MaxBytes: 10}.Target()
+	assert.Error(t, err)
+	_, err = TargetConfig{Name: "history", Pattern: "[", MaxBytes: 10}.Target()
+	assert.Error(t, err)
+	_, err = TargetConfig{Name: "history", Pattern: "*", MaxAge: "week"}.Target()
+	assert.Error(t, err)
+}
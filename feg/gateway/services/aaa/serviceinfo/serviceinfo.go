@@ -0,0 +1,99 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package serviceinfo describes what an AAA service actually runs, for fleet tooling & the services' startup
+// banners: the service's build, identified at link time, its configuration & its loaded modules
+package serviceinfo
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"runtime"
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"google.golang.org/grpc"
+
+	"magma/feg/gateway/services/aaa/protos"
+	"magma/feg/gateway/settings"
+)
+
+var (
+	// Version - the build's version, set at link time, e.g.
+	//   go build -ldflags "-X magma/feg/gateway/services/aaa/serviceinfo.Version=1.1.0"
+	Version = "dev"
+	// Commit - the build's source revision, set at link time
+	Commit string
+	// BuildTime - the build's time, set at link time
+	BuildTime string
+)
+
+// started - the service's start time, the package is initialized when the service's process starts
+var started = time.Now()
+
+// New returns the info of the service's build, host & start time
+func New(service string) *protos.ServiceInfo {
+	host, _ := os.Hostname()
+	return &protos.ServiceInfo{
+		Service:   service,
+		Version:   Version,
+		Commit:    Commit,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+		Host:      host,
+		StartedMs: started.UnixNano() / int64(time.Millisecond),
+	}
+}
+
+// Describe returns the info of the service's build, the hash & non default settings of the loader's effective
+// configuration, the GRPC services of the server & the given EAP methods. The loader & server may be nil
+func Describe(
+	service string, config *settings.Loader, grpcServer *grpc.Server, eapMethods []string) *protos.ServiceInfo {
+
+	info := New(service)
+	if config != nil {
+		effective := config.Effective()
+		info.ConfigHash = ConfigHash(effective)
+		for _, s := range effective {
+			if s.Source != settings.Default {
+				info.Flags = append(info.Flags, &protos.Setting{Name: s.Name, Value: s.Value, Source: string(s.Source)})
+			}
+		}
+	}
+	if grpcServer != nil {
+		for name := range grpcServer.GetServiceInfo() {
+			info.GrpcServices = append(info.GrpcServices, name)
+		}
+		sort.Strings(info.GrpcServices)
+	}
+	info.EapMethods = eapMethods
+	return info
+}
+
+// ConfigHash returns hex SHA-256 of the effective settings' names & values, regardless of their sources. Values of
+// secret settings are redacted by the loader, so the hash doesn't disclose them & doesn't change with them
+func ConfigHash(effective []settings.Setting) string {
+	h := sha256.New()
+	for _, s := range effective {
+		fmt.Fprintf(h, "%q=%q\n", s.Name, s.Value)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Banner returns the service info as a single line JSON object, logged by the services on startup
+func Banner(info *protos.ServiceInfo) string {
+	var buf bytes.Buffer
+	if err := (&jsonpb.Marshaler{OrigName: true}).Marshal(&buf, info); err != nil {
+		return info.String()
+	}
+	return buf.String()
+}
@@ -0,0 +1,75 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package serviceinfo_test
+
+import (
+	"encoding/json"
+	"flag"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+
+	"magma/feg/gateway/services/aaa/protos"
+	"magma/feg/gateway/services/aaa/serviceinfo"
+	"magma/feg/gateway/settings"
+)
+
+func load(t *testing.T, args ...string) *settings.Loader {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.String("audit_log", "", "")
+	fs.String("admin_token", "", "")
+	fs.Int("max_sessions", 100, "")
+	assert.NoError(t, fs.Parse(args))
+	loader := settings.New("serviceinfo_test")
+	assert.NoError(t, loader.Apply(fs))
+	return loader
+}
+
+func TestDescribe(t *testing.T) {
+	config := load(t, "-audit_log=/var/log/aaa.log", "-admin_token=t0ken")
+	grpcServer := grpc.NewServer()
+	for _, name := range []string{"aaa.protos.user_db", "aaa.protos.accounting"} {
+		grpcServer.RegisterService(&grpc.ServiceDesc{ServiceName: name, HandlerType: (*interface{})(nil)}, struct{}{})
+	}
+
+	info := serviceinfo.Describe("aaa_server", config, grpcServer, []string{"<Magma EAP-AKA Method Provider>"})
+	assert.Equal(t, "aaa_server", info.GetService())
+	assert.Equal(t, serviceinfo.Version, info.GetVersion())
+	assert.Equal(t, runtime.Version(), info.GetGoVersion())
+	assert.NotZero(t, info.GetStartedMs())
+	assert.Equal(t, []*protos.Setting{
+		{Name: "admin_token", Value: settings.Redacted, Source: "flag"},
+		{Name: "audit_log", Value: "/var/log/aaa.log", Source: "flag"},
+	}, info.GetFlags())
+	assert.Equal(t, []string{"aaa.protos.accounting", "aaa.protos.user_db"}, info.GetGrpcServices())
+	assert.Equal(t, []string{"<Magma EAP-AKA Method Provider>"}, info.GetEapMethods())
+
+	// equal configurations have equal hashes, regardless of the settings' sources & secrets
+	assert.Len(t, info.GetConfigHash(), 64)
+	assert.Equal(t, info.GetConfigHash(),
+		serviceinfo.Describe("aaa_server", load(t, "-audit_log=/var/log/aaa.log", "-admin_token=other"), nil, nil).
+			GetConfigHash())
+	assert.NotEqual(t, info.GetConfigHash(),
+		serviceinfo.Describe("aaa_server", load(t, "-audit_log=/var/log/aaa.log", "-max_sessions=10"), nil, nil).
+			GetConfigHash())
+
+	var banner map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(serviceinfo.Banner(info)), &banner))
+	assert.Equal(t, info.GetConfigHash(), banner["config_hash"])
+	assert.Equal(t, "aaa_server", banner["service"])
+}
+
+func TestDescribeNoConfig(t *testing.T) {
+	info := serviceinfo.Describe("aaa_server", nil, nil, nil)
+	assert.Empty(t, info.GetConfigHash())
+	assert.Empty(t, info.GetFlags())
+	assert.Empty(t, info.GetGrpcServices())
+}
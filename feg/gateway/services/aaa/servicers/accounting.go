@@ -10,10 +10,12 @@ LICENSE file in the root directory of this source tree.
 package servicers
 
 import (
+	"log"
 	"net"
 	"strings"
 	"time"
 
+	"fbc/lib/go/retry"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -21,85 +23,399 @@ import (
 	"magma/feg/cloud/go/protos/mconfig"
 	"magma/feg/gateway/registry"
 	"magma/feg/gateway/services/aaa"
+	"magma/feg/gateway/services/aaa/acctfaults"
+	"magma/feg/gateway/services/aaa/acctproxy"
+	"magma/feg/gateway/services/aaa/acctqueue"
+	"magma/feg/gateway/services/aaa/aggregate"
+	"magma/feg/gateway/services/aaa/anomaly"
+	"magma/feg/gateway/services/aaa/apnalias"
+	"magma/feg/gateway/services/aaa/apnauth"
+	"magma/feg/gateway/services/aaa/audit"
+	"magma/feg/gateway/services/aaa/charging"
+	"magma/feg/gateway/services/aaa/coalog"
+	"magma/feg/gateway/services/aaa/deadlines"
+	"magma/feg/gateway/services/aaa/directory"
+	"magma/feg/gateway/services/aaa/failuremode"
+	"magma/feg/gateway/services/aaa/fingerprint"
+	"magma/feg/gateway/services/aaa/guest"
+	"magma/feg/gateway/services/aaa/hssprobe"
+	"magma/feg/gateway/services/aaa/mab"
 	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/aaa/panics"
+	"magma/feg/gateway/services/aaa/policyhook"
+	"magma/feg/gateway/services/aaa/prefetch"
 	"magma/feg/gateway/services/aaa/protos"
+	"magma/feg/gateway/services/aaa/quarantine"
+	"magma/feg/gateway/services/aaa/quirks"
+	"magma/feg/gateway/services/aaa/radiusauthz"
+	"magma/feg/gateway/services/aaa/ratelimit"
 	"magma/feg/gateway/services/aaa/session_manager"
+	"magma/feg/gateway/services/aaa/staticrules"
+	"magma/feg/gateway/services/aaa/timepolicy"
 	lte_protos "magma/lte/cloud/go/protos"
 )
 
 type accountingService struct {
-	sessions    aaa.SessionTable
-	config      *mconfig.AAAConfig
-	sessionTout time.Duration // Idle Session Timeout
+	sessions      aaa.SessionTable
+	config        *mconfig.AAAConfig
+	sessionTout   time.Duration // Idle Session Timeout
+	apns          *apnOverrides // accounting settings overrides by APN
+	trafficTout   time.Duration // idle timeout of sessions without Interim-Update traffic, 0 - sessionTout
+	anomalies     *anomaly.Detector
+	usage         *usageTable // Interim-Update usage accumulated for reconciliation
+	apnAuth       apnauth.Authorizer
+	policyHook    *policyhook.Hook
+	bandwidths    *bandwidthTable // base bandwidths & pending bandwidth change reverts
+	starts        *startTable     // accounting start timestamps
+	audit         audit.Sink
+	timePolicy    *timepolicy.Policy
+	policies      *policyTable // scheduled time policy checks
+	watchers      *usageWatchers
+	prefetcher    *prefetch.Prefetcher
+	reorder       *reorderTable  // Stop/Start reordering of subscribers' consecutive sessions
+	handovers     *handoverTable // pending LTE to Wi-Fi handovers
+	duplicateIMSI DuplicateIMSIPolicy
+	createFailure CreateSessionFailurePolicy
+	capacity      *capacityTable  // admitted sessions of APNs with concurrent sessions limits
+	attributes    *attributeStore // per session attribute limits, nil - unlimited
+	staticRules   *staticrules.Config
+	chargingHints *charging.Config     // per APN & realm charging characteristics & rating groups, nil - no hints
+	apnAliases    *apnalias.Config     // canonical APNs of SSIDs & Called-Station-Ids, nil - APNs aren't aliased
+	deviceHints   *fingerprint.Pending // device hints of UEs without a session yet
+	quarantine    *quarantine.Config   // security triggers' actions, nil - disconnect
+	failureModes  *failuremode.Config  // actions of Gx/Gy failure modes, nil - allow
+	guests        *guest.Config        // guest sessions' APNs, realms & maximum duration, nil - no guest sessions
+	guestSessions *guestTable          // guest sessions' scheduled expirations
+	lifetimes     *lifetimeTable       // sessions' maximum durations & scheduled expirations
+	hssProber     *hssprobe.Prober     // HSS reachability, nil - the HSS is assumed reachable
+	acctQueue     *acctqueue.Queue     // session manager calls queued while it's unavailable, nil - no queueing
+	aggregates    *aggregate.Table     // combined usage of subscribers' simultaneous sessions, nil - no aggregation
+	quotaHook     aggregate.QuotaHook  // quota check of subscribers' combined usage, nil - no quota
+	directory     *directory.Publisher // directory records of the started sessions, nil - not published
+	multiSessions *multiSessionTable   // sessions grouped by their NAS's Acct-Multi-Session-Id
+	ops           *sessionOps          // sessions' operations ordered by priority
+	startedByNAS  *processedTable      // sessions' recently processed NAS Starts
+	stoppedByNAS  *processedTable      // sessions recently stopped by their NAS
+	terminated    *terminatedTable     // recently removed sessions, their late accounting requests are acknowledged
+	events        *eventSubscribers    // SubscribeEvents streams, one of the audit sinks
+	macAllowList  mab.AllowList        // devices authenticated by MAC Authentication Bypass, nil - no MAB
+	faults        *acctfaults.Injector // accounting faults of synthetic test sessions, nil - no fault injection
+	acctProxy     *acctproxy.Proxy     // upstream accounting servers mirroring the accounting, nil - no mirroring
+	coaLog        *coalog.Log          // sent CoA & Disconnect-Requests, nil - not logged
+	// failed Disconnect-Requests retried once their NASes are reachable
+	disconnects *pendingDisconnectTable
+	// maximum Event-Timestamp skew of accounting requests, nil - not validated
+	eventTimestamps *eventTimestampPolicy
+	// maximum plausible Interim-Update usage rate in octets per second, 0 - not checked
+	maxUsageRate float64
+	// Accounting-Responses' deadline, calls not completed within it are acknowledged early, 0 - no early responses
+	responseDeadline time.Duration
+	// accounting responses with the desired Acct-Interim-Intervals by APN
+	acctResps map[string]*protos.AcctResp
+	// request rate limits by MAC address & IMSI, nil - not limited
+	macLimiter, imsiLimiter *ratelimit.Limiter
+	// Radius server endpoints of Disconnect & CoA requests, a radiusauthz.Endpoints
+	radius protos.AuthorizationClient
 }
 
 const (
 	imsiPrefix = "IMSI"
 )
 
+// NAS interop quirks' adjustments
+const (
+	quirkStopBeforeFinalInterim = "stop_before_final_interim"
+	quirkUppercaseDashedMAC     = "uppercase_dashed_mac"
+	quirkKilobyteOctets         = "kilobyte_octets"
+)
+
 // NewEapAuthenticator returns a new instance of EAP Auth service
 func NewAccountingService(sessions aaa.SessionTable, cfg *mconfig.AAAConfig) (*accountingService, error) {
+	radius, err := radiusauthz.New([]string{registry.RADIUS}, 0)
+	if err != nil {
+		return nil, err
+	}
+	events := newEventSubscribers()
 	return &accountingService{
-		sessions:    sessions,
-		config:      cfg,
-		sessionTout: GetIdleSessionTimeout(cfg),
+		sessions:      sessions,
+		config:        cfg,
+		sessionTout:   GetIdleSessionTimeout(cfg),
+		apns:          newAPNOverrides(cfg),
+		usage:         newUsageTable(),
+		bandwidths:    newBandwidthTable(),
+		policies:      newPolicyTable(),
+		starts:        newStartTable(),
+		watchers:      newUsageWatchers(),
+		reorder:       newReorderTable(),
+		handovers:     newHandoverTable(),
+		duplicateIMSI: DuplicateIMSIPermit,
+		createFailure: createSessionFailurePolicy(cfg.GetCreateSessionFailurePolicy()),
+		capacity:      newCapacityTable(cfg.GetApnMaxSessions()),
+		deviceHints:   fingerprint.NewPending(fingerprint.DefaultTTL),
+		guestSessions: newGuestTable(),
+		lifetimes:     newLifetimeTable(cfg),
+		multiSessions: newMultiSessionTable(),
+		ops:           newSessionOps(),
+		startedByNAS:  newProcessedTable(),
+		stoppedByNAS:  newProcessedTable(),
+		terminated:    newTerminatedTable(),
+		disconnects:   newPendingDisconnectTable(),
+		events:        events,
+		audit:         audit.Sinks{events},
+		maxUsageRate:  DefaultMaxUsageRate,
+		radius:        radius,
 	}, nil
 }
 
+// SetAnomalyDetector enables usage anomaly detection on Interim-Updates, nil disables it
+func (srv *accountingService) SetAnomalyDetector(d *anomaly.Detector) {
+	srv.anomalies = d
+}
+
+// SetAPNAuthorizer enables subscriber to APN authorization of new sessions, nil disables it
+func (srv *accountingService) SetAPNAuthorizer(a apnauth.Authorizer) {
+	srv.apnAuth = a
+}
+
+// SetPolicyHook enables policy endpoint decisions on new sessions, nil disables them
+func (srv *accountingService) SetPolicyHook(h *policyhook.Hook) {
+	srv.policyHook = h
+}
+
+// SetHSSProber sets the HSS reachability prober, authentication failures during HSS outages are returned as errors
+// & so not answered by Radius, NASes fail over to another AAA server. Nil disables it
+func (srv *accountingService) SetHSSProber(p *hssprobe.Prober) {
+	srv.hssProber = p
+}
+
+// SetAPNAliases enables replacement of the sessions' APNs by the canonical APNs of their aliases, nil disables it
+func (srv *accountingService) SetAPNAliases(cfg *apnalias.Config) {
+	srv.apnAliases = cfg
+}
+
+// aliasAPN replaces the context's APN by the canonical APN of its alias, if any
+func (srv *accountingService) aliasAPN(aaaCtx *protos.Context) {
+	if srv != nil {
+		srv.apnAliases.Apply(aaaCtx)
+	}
+}
+
+// SetStaticRules enables installation of the configured static rules at session creation, nil disables it
+func (srv *accountingService) SetStaticRules(cfg *staticrules.Config) {
+	srv.staticRules = cfg
+}
+
+// SetChargingHints enables the configured charging hints of the sessions created in session manager, nil disables it
+func (srv *accountingService) SetChargingHints(cfg *charging.Config) {
+	srv.chargingHints = cfg
+}
+
+// SetPrefetcher enables auth vectors prefetch of subscribers with accounting activity, nil disables it
+func (srv *accountingService) SetPrefetcher(p *prefetch.Prefetcher) {
+	srv.prefetcher = p
+}
+
 // Start implements Radius Acct-Status-Type: Start endpoint
 func (srv *accountingService) Start(ctx context.Context, aaaCtx *protos.Context) (*protos.AcctResp, error) {
 	if aaaCtx == nil {
 		return &protos.AcctResp{}, status.Errorf(codes.InvalidArgument, "Nil AAA Context")
 	}
+	if err := srv.throttle("Start", aaaCtx); err != nil {
+		return &protos.AcctResp{}, err
+	}
+	srv.aliasAPN(aaaCtx)
+	if err := srv.checkEventTimestamp("Start", aaaCtx); err != nil {
+		return &protos.AcctResp{}, err
+	}
+	srv.nasReachable(aaaCtx)
+	return srv.respondWithin(ctx, "Start", aaaCtx, true, func(ctx context.Context) (*protos.AcctResp, error) {
+		return srv.start(ctx, aaaCtx)
+	})
+}
+
+func (srv *accountingService) start(ctx context.Context, aaaCtx *protos.Context) (*protos.AcctResp, error) {
 	sid := aaaCtx.GetSessionId()
 	s := srv.sessions.GetSession(sid)
 	if s == nil {
 		return &protos.AcctResp{}, status.Errorf(
 			codes.FailedPrecondition, "Accounting Start: Session %s was not authenticated", sid)
 	}
+	eventTime := requestEventTime(aaaCtx)
+	if srv.startedByNAS.isDuplicate(sid, eventTime) {
+		// the NAS's retransmission of a processed Start, the session is already created
+		metrics.DuplicateAcctRequests.WithLabelValues(duplicateStart).Inc()
+		return srv.acctResp(s.GetCtx()), nil
+	}
+	if quirks.Normalize(aaaCtx) {
+		metrics.QuirkAdjustments.WithLabelValues(quirkUppercaseDashedMAC).Inc()
+	}
+	srv.checkNASAddress(s, aaaCtx)
+	srv.mergeAttributes(s, aaaCtx.GetAttributes())
+	srv.correlate(s, aaaCtx)
+	srv.applyPendingDeviceHint(s)
+	srv.awaitPreviousStop(ctx, s.GetCtx())
 	var err error
-	if srv.config.GetAccountingEnabled() && !srv.config.GetCreateSessionOnAuth() {
-		_, err = srv.CreateSession(ctx, aaaCtx)
-	} else {
-		srv.sessions.SetTimeout(sid, srv.sessionTout, srv.timeoutSessionNotifier)
+	settings := srv.settings(s.GetCtx())
+	if settings.accountingEnabled && !settings.createSessionOnAuth {
+		if _, err = srv.CreateSession(ctx, aaaCtx); err != nil && !disconnectsSession(err) {
+			srv.createSessionFailed(s.GetCtx(), err)
+		}
+	} else if err = srv.authorizeSession(ctx, aaaCtx, policyhook.EventStart); err == nil {
+		srv.sessions.SetTimeout(sid, settings.idleTimeout, srv.timeoutSessionNotifier)
+		srv.auditEvent(audit.Start, s.GetCtx())
+		go srv.applyTimePolicy(sid)
 	}
-	return &protos.AcctResp{}, err
+	if disconnectsSession(err) {
+		srv.disconnectUnauthorized(s.GetCtx())
+	}
+	if err != nil {
+		return &protos.AcctResp{}, err
+	}
+	srv.started(s.GetCtx())
+	srv.updateDirectory(s.GetCtx(), aaaCtx)
+	srv.seen(s.GetCtx())
+	srv.resumeQuarantine(s.GetCtx())
+	srv.scheduleGuestExpiration(s.GetCtx())
+	srv.startedByNAS.add(sid, makeSID(s.GetCtx().GetImsi()).GetId(), eventTime)
+	srv.proxyStart(s.GetCtx())
+	return srv.acctResp(s.GetCtx()), nil
 }
 
 // InterimUpdate implements Radius Acct-Status-Type: Interim-Update endpoint
-func (srv *accountingService) InterimUpdate(_ context.Context, ur *protos.UpdateRequest) (*protos.AcctResp, error) {
+func (srv *accountingService) InterimUpdate(ctx context.Context, ur *protos.UpdateRequest) (*protos.AcctResp, error) {
 	if ur == nil {
 		return &protos.AcctResp{}, status.Errorf(codes.InvalidArgument, "Nil Update Request")
 	}
-	sid := ur.GetCtx().GetSessionId()
+	if err := srv.throttle("Update", ur.GetCtx()); err != nil {
+		return &protos.AcctResp{}, err
+	}
+	srv.aliasAPN(ur.GetCtx())
+	if err := srv.checkEventTimestamp("Update", ur.GetCtx()); err != nil {
+		return &protos.AcctResp{}, err
+	}
+	srv.nasReachable(ur.GetCtx())
+	if err := contextError(ctx); err != nil {
+		return &protos.AcctResp{}, err
+	}
+	sid := srv.resolveSessionID("interim", ur.GetCtx())
+	defer srv.ops.begin(sid, opInterim)()
 	s := srv.sessions.GetSession(sid)
+	if s == nil && quirks.AcceptOrphanInterim(ur.GetCtx()) {
+		// the NAS's final Interim-Update of a session it has already stopped, acknowledged so it isn't retransmitted
+		metrics.QuirkAdjustments.WithLabelValues(quirkStopBeforeFinalInterim).Inc()
+		return &protos.AcctResp{}, nil
+	}
+	if s == nil && srv.lateRequest(sid, lateInterim) {
+		// the NAS's Interim-Update racing the session's termination
+		return &protos.AcctResp{}, nil
+	}
 	if s == nil {
 		return &protos.AcctResp{}, status.Errorf(
 			codes.FailedPrecondition, "Accounting Update: Session %s was not authenticated", sid)
 	}
-	srv.sessions.SetTimeout(sid, srv.sessionTout, srv.timeoutSessionNotifier)
+	if srv.dropInterim(s.GetCtx()) {
+		return srv.acctResp(s.GetCtx()), nil
+	}
+	srv.checkNASAddress(s, ur.GetCtx())
+	srv.mergeAttributes(s, ur.GetCtx().GetAttributes())
 
-	metrics.OctetsIn.WithLabelValues(s.GetCtx().GetApn(), s.GetCtx().GetImsi()).Add(float64(ur.GetOctetsIn()))
-	metrics.OctetsOut.WithLabelValues(s.GetCtx().GetApn(), s.GetCtx().GetImsi()).Add(float64(ur.GetOctetsOut()))
+	sessionCtx := s.GetCtx()
+	apn, imsi := sessionCtx.GetApn(), sessionCtx.GetImsi()
+	// counters of NASes reporting other units than octets are normalized before they're metered & reported
+	unit := quirks.OctetsUnit(sessionCtx)
+	if unit != 1 {
+		metrics.QuirkAdjustments.WithLabelValues(quirkKilobyteOctets).Inc()
+	}
+	metrics.OctetsIn.WithLabelValues(apn, imsi).Add(float64(octetsCounter(ur.GetGigawordsIn(), ur.GetOctetsIn()) * unit))
+	metrics.OctetsOut.WithLabelValues(apn, imsi).Add(
+		float64(octetsCounter(ur.GetGigawordsOut(), ur.GetOctetsOut()) * unit))
+	previous, _ := srv.usage.get(sid)
+	usage, deltaIn, deltaOut := srv.usage.update(sid, imsi, ur, unit)
+	srv.sessions.SetTimeout(
+		sid, srv.interimTimeout(srv.settings(sessionCtx).idleTimeout, usage), srv.timeoutSessionNotifier)
+	srv.checkUsage(sessionCtx, previous, usage, deltaIn, deltaOut)
+	metrics.OctetsInServed.Add(deltaIn)
+	metrics.OctetsOutServed.Add(deltaOut)
+	srv.publishUsage(sid, usage, deltaIn, deltaOut, false)
+	decision := srv.reportUsage(ctx, sessionCtx, usage)
+	srv.aggregateUsage(sessionCtx, usage)
+	srv.updateDirectory(sessionCtx, ur.GetCtx())
+	srv.auditEvent(audit.Interim, sessionCtx)
+	srv.proxyInterim(sessionCtx, usage)
+	srv.seen(sessionCtx)
 
-	return &protos.AcctResp{}, nil
+	if srv.anomalies != nil {
+		// Acct-Input-Octets are received from the UE (uplink), Acct-Output-Octets are sent to the UE (downlink)
+		ev := srv.anomalies.Update(sid, imsi, apn, uint64(ur.GetOctetsIn())*unit, uint64(ur.GetOctetsOut())*unit)
+		if ev != nil {
+			srv.reportAnomaly(sessionCtx, ev)
+		}
+	}
+	srv.enforceQuota(s, decision)
+	return srv.acctResp(sessionCtx), nil
 }
 
 // Stop implements Radius Acct-Status-Type: Stop endpoint
-func (srv *accountingService) Stop(_ context.Context, req *protos.StopRequest) (*protos.AcctResp, error) {
+func (srv *accountingService) Stop(ctx context.Context, req *protos.StopRequest) (*protos.AcctResp, error) {
 	if req == nil {
 		return &protos.AcctResp{}, status.Errorf(codes.InvalidArgument, "Nil Stop Request")
 	}
-	sid := req.GetCtx().GetSessionId()
+	if err := srv.throttle("Stop", req.GetCtx()); err != nil {
+		return &protos.AcctResp{}, err
+	}
+	srv.aliasAPN(req.GetCtx())
+	if err := srv.checkEventTimestamp("Stop", req.GetCtx()); err != nil {
+		return &protos.AcctResp{}, err
+	}
+	// the stopped session's pending Disconnect is moot
+	srv.disconnects.remove(req.GetCtx())
+	srv.nasReachable(req.GetCtx())
+	if srv.delayStop(req) {
+		return &protos.AcctResp{}, nil
+	}
+	// the stopped session can't be corrected, late failures are only counted
+	return srv.respondWithin(ctx, "Stop", req.GetCtx(), false, func(ctx context.Context) (*protos.AcctResp, error) {
+		return srv.stop(ctx, req)
+	})
+}
+
+func (srv *accountingService) stop(ctx context.Context, req *protos.StopRequest) (*protos.AcctResp, error) {
+	sid := srv.resolveSessionID("stop", req.GetCtx())
 	s := srv.sessions.RemoveSession(sid)
+	var final *lte_protos.LocalEndSessionRequest
+	if s != nil {
+		final = srv.finalUsage(s.GetCtx(), req)
+	}
+	srv.forgetSession(sid, audit.Stop, s)
+	if s == nil && srv.stoppedByNAS.isDuplicate(sid, requestEventTime(req.GetCtx())) {
+		// the NAS's retransmission of a processed Stop
+		metrics.DuplicateAcctRequests.WithLabelValues(duplicateStop).Inc()
+		return &protos.AcctResp{}, nil
+	}
+	if s == nil && srv.lateRequest(sid, lateStop) {
+		// the NAS's Stop of a session already terminated by session manager, a policy or its timeout
+		return &protos.AcctResp{}, nil
+	}
 	if s == nil {
 		return &protos.AcctResp{}, status.Errorf(
 			codes.FailedPrecondition, "Accounting Stop: Session %s is not found", sid)
 	}
+	srv.stoppedByNAS.add(sid, makeSID(s.GetCtx().GetImsi()).GetId(), requestEventTime(req.GetCtx()))
+	if reqSid := req.GetCtx().GetSessionId(); reqSid != sid {
+		// retransmissions of the roamed Stop aren't correlated, the session is already removed
+		srv.stoppedByNAS.add(reqSid, makeSID(s.GetCtx().GetImsi()).GetId(), requestEventTime(req.GetCtx()))
+	}
+	srv.proxyStop(s.GetCtx(), req, final)
 	var err error
-	if srv.config.GetAccountingEnabled() {
-		_, err = session_manager.EndSession(makeSID(req.GetCtx().GetImsi()))
+	if srv.isSuperseded(s.GetCtx()) {
+		// late Stop of a session already followed by the subscriber's next session, which must not be ended
+		metrics.AcctReorders.WithLabelValues(reorderDetected).Inc()
+		metrics.AcctReorders.WithLabelValues(reorderCorrected).Inc()
+		log.Printf("Late Accounting Stop of superseded session %s, session manager session is kept", sid)
+	} else if srv.settings(s.GetCtx()).accountingEnabled {
+		err = srv.endManagedSessionWithUsage(ctx, s.GetCtx(), final)
 	}
+	srv.stopped(s.GetCtx())
 	metrics.AcctStop.WithLabelValues(s.GetCtx().GetApn(), s.GetCtx().GetImsi())
 
 	return &protos.AcctResp{}, err
@@ -110,23 +426,61 @@ func (srv *accountingService) CreateSession(grpcCtx context.Context, aaaCtx *pro
 
 	startime := time.Now()
 
-	mac, err := net.ParseMAC(aaaCtx.GetMacAddr())
+	if err := srv.authorizeSession(grpcCtx, aaaCtx, policyhook.EventCreateSession); err != nil {
+		return &protos.AcctResp{}, err
+	}
+	if s := srv.sessions.GetSession(aaaCtx.GetSessionId()); s != nil {
+		srv.applyPendingDeviceHint(s)
+	}
+	if srv.continueHandover(aaaCtx) {
+		srv.sessionCreated(aaaCtx)
+		metrics.CreateSessionLatency.Observe(time.Since(startime).Seconds())
+		return &protos.AcctResp{}, nil
+	}
+	addrs, err := aaaCtx.UEAddresses()
 	if err != nil {
-		return &protos.AcctResp{}, status.Errorf(
-			codes.InvalidArgument,
-			"Invalid MAC Address: %v", err)
+		srv.capacity.releaseSession(aaaCtx.GetSessionId())
+		return &protos.AcctResp{}, status.Errorf(codes.InvalidArgument, "Invalid UE Address: %v", err)
 	}
 	req := &lte_protos.LocalCreateSessionRequest{
-		Sid:             makeSID(aaaCtx.GetImsi()),
-		UeIpv4:          aaaCtx.GetIpAddr(),
-		Msisdn:          ([]byte)(aaaCtx.GetMsisdn()),
-		RatType:         lte_protos.RATType_TGPP_WLAN,
-		HardwareAddr:    mac,
-		RadiusSessionId: aaaCtx.GetSessionId(),
-	}
-	_, err = session_manager.CreateSession(req)
+		Sid:          makeSID(aaaCtx.GetImsi()),
+		UeIpv4:       addrs.IPv4,
+		UeIpv6:       addrs.IPv6,
+		UeIpv6Prefix: addrs.IPv6Prefix,
+		Msisdn:       ([]byte)(aaaCtx.GetMsisdn()),
+	}
+	// Older session managers are not aware of WLAN sessions, only include WLAN fields if supported
+	if session_manager.GetAPNCapabilities(aaaCtx.GetApn()).WLANSessions {
+		mac, err := net.ParseMAC(aaaCtx.GetMacAddr())
+		if err != nil {
+			srv.capacity.releaseSession(aaaCtx.GetSessionId())
+			return &protos.AcctResp{}, status.Errorf(
+				codes.InvalidArgument,
+				"Invalid MAC Address: %v", err)
+		}
+		req.RatType = lte_protos.RATType_TGPP_WLAN
+		req.HardwareAddr = mac
+		req.RadiusSessionId = aaaCtx.GetSessionId()
+	}
+	// Static rules of the session are activated by session manager without waiting for the PCRF's rules
+	if rules := srv.staticRules.Rules(aaaCtx.GetImsi(), aaaCtx.GetApn()); !rules.Empty() {
+		req.StaticRuleIds, req.RuleBaseNames = rules.StaticRuleIDs, rules.RuleBaseNames
+	}
+	// Charging hints map the sessions of the APN's SSIDs & the identity's realm onto their own Gy rating groups
+	if hints := srv.chargingHints.Hints(aaaCtx.GetApn(), guest.Realm(aaaCtx)); !hints.Empty() {
+		req.ChargingCharacteristics, req.RatingGroups = hints.ChargingCharacteristics, hints.RatingGroups
+	}
+	// Device hints are passed as the call's metadata, session manager's request has no fields for them
+	res, err := srv.createManagedSession(
+		fingerprint.AppendToOutgoingContext(grpcCtx, srv.deviceHint(aaaCtx)), aaaCtx, req)
 	if err == nil {
-		srv.sessions.SetTimeout(req.GetRadiusSessionId(), srv.sessionTout, srv.timeoutSessionNotifier)
+		err = srv.applyFailureModes(grpcCtx, aaaCtx, res)
+	}
+	if err == nil {
+		srv.sessionCreated(aaaCtx)
+	} else {
+		srv.capacity.releaseSession(aaaCtx.GetSessionId()) // the session was not created & doesn't use its capacity
+		metrics.CreateSessionFailures.WithLabelValues(status.Code(err).String(), retry.ClassOf(err).String()).Inc()
 	}
 
 	metrics.CreateSessionLatency.Observe(time.Since(startime).Seconds())
@@ -134,57 +488,71 @@ func (srv *accountingService) CreateSession(grpcCtx context.Context, aaaCtx *pro
 	return &protos.AcctResp{}, err
 }
 
+// sessionCreated starts the idle timeout & time policy of the session created in session manager & publishes its
+// directory record, sessions created on auth are published before their Accounting Start
+func (srv *accountingService) sessionCreated(aaaCtx *protos.Context) {
+	sessionCtx := aaaCtx
+	if s := srv.sessions.GetSession(aaaCtx.GetSessionId()); s != nil {
+		sessionCtx = s.GetCtx()
+		srv.updateDirectory(sessionCtx, aaaCtx) // the NAS's context may lack the session's IMSI & MAC
+	}
+	srv.sessions.SetTimeout(aaaCtx.GetSessionId(), srv.settings(sessionCtx).idleTimeout, srv.timeoutSessionNotifier)
+	srv.auditEvent(audit.Start, aaaCtx)
+	go srv.applyTimePolicy(aaaCtx.GetSessionId())
+}
+
 // TerminateSession is an "inbound" RPC from session manager to notify accounting of a client session termination
 func (srv *accountingService) TerminateSession(
 	ctx context.Context, req *protos.TerminateSessionRequest) (*protos.AcctResp, error) {
 
 	sid := req.GetRadiusSessionId()
+	// the session is removed before its queued Interim-Updates run, so they don't re-arm its idle timeout
+	end := srv.ops.begin(sid, opTerminate)
 	s := srv.sessions.RemoveSession(sid)
+	srv.forgetSession(sid, audit.Terminate, s)
+	end()
+	if p, stopped := srv.stoppedByNAS.get(sid); s == nil && stopped && p.imsi == req.GetImsi() {
+		// session manager's termination raced the NAS's Stop, which already ended the session
+		metrics.TerminateRaces.Inc()
+		log.Printf("Terminate Session: session %s of %s is already stopped by its NAS", sid, req.GetImsi())
+		return &protos.AcctResp{Detail: alreadyTerminated}, nil
+	}
 	if s == nil {
 		return &protos.AcctResp{}, status.Errorf(codes.FailedPrecondition, "Session %s is not found", sid)
 	}
 	metrics.SessionTerminate.WithLabelValues(s.GetCtx().GetApn(), s.GetCtx().GetImsi())
 
-	s.Lock()
-	defer s.Unlock()
-	imsi := s.GetCtx().GetImsi()
-	if !strings.HasPrefix(imsi, imsiPrefix) {
-		imsi = imsiPrefix + imsi
-	}
+	imsi := makeSID(s.GetCtx().GetImsi()).GetId()
 	if imsi != req.GetImsi() {
 		return &protos.AcctResp{}, status.Errorf(
 			codes.InvalidArgument, "Mismatched IMSI: %s != %s of session %s", req.GetImsi(), imsi, sid)
 	}
-	conn, err := registry.GetConnection(registry.RADIUS)
-	if err != nil {
-		return &protos.AcctResp{}, status.Errorf(
-			codes.Unavailable, "Error getting Radius RPC Connection: %v", err)
-	}
-	radcli := protos.NewAuthorizationClient(conn)
-	_, err = radcli.Disconnect(ctx, &protos.DisconnectRequest{Ctx: s.GetCtx()})
+	_, err := srv.newAuthorizationClient().Disconnect(ctx, &protos.DisconnectRequest{
+		Ctx: s.GetCtx(), Reason: req.GetReason(), ReplyMessage: req.GetReplyMessage()})
 
 	return &protos.AcctResp{}, err
 }
 
 // EndTimedOutSession is an "inbound" -> session manager AND "outbound" -> Radius server notification of a timed out
 // session. It should be called for a timed out and recently removed from the sessions table session.
-func (srv *accountingService) EndTimedOutSession(aaaCtx *protos.Context) error {
+func (srv *accountingService) EndTimedOutSession(ctx context.Context, aaaCtx *protos.Context) error {
+	return srv.endSession(ctx, aaaCtx, protos.TerminateReason_SESSION_IDLE)
+}
+
+// endSession ends the removed session in session manager & disconnects its UE with the given termination reason
+func (srv *accountingService) endSession(
+	ctx context.Context, aaaCtx *protos.Context, reason protos.TerminateReason) error {
+
 	if aaaCtx == nil {
 		return status.Errorf(codes.InvalidArgument, "Nil AAA Context")
 	}
 	var err, radErr error
 
-	if srv.config.GetAccountingEnabled() {
-		_, err = session_manager.EndSession(makeSID(aaaCtx.GetImsi()))
+	if srv.settings(aaaCtx).accountingEnabled {
+		err = srv.endManagedSession(ctx, aaaCtx)
 	}
 
-	conn, radErr := registry.GetConnection(registry.RADIUS)
-	if radErr != nil {
-		radErr = status.Errorf(codes.Unavailable, "Session Timeout Notification Radius Connection Error: %v", radErr)
-	} else {
-		_, radErr = protos.NewAuthorizationClient(conn).Disconnect(
-			context.Background(), &protos.DisconnectRequest{Ctx: aaaCtx})
-	}
+	radErr = srv.disconnect(ctx, aaaCtx, reason)
 	if radErr != nil {
 		if err != nil {
 			err = status.Errorf(
@@ -196,13 +564,110 @@ func (srv *accountingService) EndTimedOutSession(aaaCtx *protos.Context) error {
 	return err
 }
 
+// disconnect disconnects the session's UE via Radius server with the given termination reason
+func (srv *accountingService) disconnect(
+	ctx context.Context, aaaCtx *protos.Context, reason protos.TerminateReason) error {
+
+	_, err := srv.newAuthorizationClient().Disconnect(ctx, &protos.DisconnectRequest{Ctx: aaaCtx, Reason: reason})
+	return err
+}
+
 func (srv *accountingService) timeoutSessionNotifier(s aaa.Session) error {
 	if srv != nil && s != nil {
-		return srv.EndTimedOutSession(s.GetCtx())
+		srv.forgetSession(s.GetCtx().GetSessionId(), audit.Timeout, s)
+		ctx, cancel := deadlines.Background()
+		defer cancel()
+		ctx = coalog.WithTrigger(ctx, "session_timeout", "")
+		return srv.EndTimedOutSession(ctx, s.GetCtx())
 	}
 	return nil
 }
 
+// reportAnomaly logs & counts the anomaly event and, if configured, rate limits the session via Radius CoA
+func (srv *accountingService) reportAnomaly(aaaCtx *protos.Context, ev *anomaly.Event) {
+	log.Printf("Usage anomaly: %s", ev)
+	metrics.UsageAnomalies.WithLabelValues(string(ev.Type), ev.Apn, ev.Imsi).Inc()
+
+	trafficClasses := srv.anomalies.Config().CoATrafficClasses
+	if len(trafficClasses) == 0 {
+		return
+	}
+	go func() {
+		defer panics.Recover("anomaly_coa")
+		ctx, cancel := deadlines.Background()
+		defer cancel()
+		ctx = coalog.WithTrigger(ctx, "anomaly_coa", string(ev.Type))
+		_, err := srv.newAuthorizationClient().Change(
+			ctx, &protos.ChangeRequest{Ctx: aaaCtx, JsonTrficClasses: trafficClasses})
+		if err != nil {
+			log.Printf("Anomaly CoA for session %s failed: %v", ev.SessionId, err)
+		}
+	}()
+}
+
+// authorizeAPN returns PermissionDenied error if the subscriber is not authorized to use the session's APN
+func (srv *accountingService) authorizeAPN(ctx context.Context, aaaCtx *protos.Context) error {
+	if srv.apnAuth == nil {
+		return nil
+	}
+	err := srv.apnAuth.Authorize(ctx, aaaCtx.GetImsi(), aaaCtx.GetApn())
+	if err == nil {
+		return nil
+	}
+	reason := apnauth.ReasonError
+	if rejectErr, ok := err.(*apnauth.RejectError); ok {
+		reason = rejectErr.Reason
+	}
+	metrics.APNAuthRejects.WithLabelValues(aaaCtx.GetApn(), reason).Inc()
+	log.Printf("APN authorization of session %s failed: %v", aaaCtx.GetSessionId(), err)
+	return status.Errorf(codes.PermissionDenied, "%v", err)
+}
+
+// authorizeSession authorizes the new session's APN, consults the policy endpoint, if enabled, which may veto
+// the session or annotate its context & admits the authorized session to its APN's capacity
+func (srv *accountingService) authorizeSession(ctx context.Context, aaaCtx *protos.Context, event string) error {
+	if err := srv.authorizeAPN(ctx, aaaCtx); err != nil {
+		return err
+	}
+	if srv.policyHook != nil {
+		s := srv.sessions.GetSession(aaaCtx.GetSessionId())
+		if s != nil {
+			aaaCtx = s.GetCtx() // the authenticated session's context is more complete than the request's
+		}
+		annotations, err := srv.policyHook.Decide(ctx, event, aaaCtx)
+		if err != nil {
+			log.Printf("Policy decision on session %s: %v", aaaCtx.GetSessionId(), err)
+			return status.Errorf(codes.PermissionDenied, "%v", err)
+		}
+		if s != nil {
+			srv.mergeAttributes(s, annotations)
+		}
+	}
+	return srv.admitSession(aaaCtx)
+}
+
+// disconnectUnauthorized removes an established session rejected by authorization & disconnects its UE
+func (srv *accountingService) disconnectUnauthorized(aaaCtx *protos.Context) {
+	srv.removeAndDisconnect(aaaCtx, "unauthorized_disconnect", protos.TerminateReason_SUBSCRIPTION_ENDED)
+}
+
+// removeAndDisconnect removes an established session & disconnects its UE via Radius Disconnect-Request, op names the
+// disconnect in the logs & panic reports
+func (srv *accountingService) removeAndDisconnect(aaaCtx *protos.Context, op string, reason protos.TerminateReason) {
+	sid := aaaCtx.GetSessionId()
+	srv.forgetSession(sid, audit.Terminate, srv.sessions.RemoveSession(sid))
+	go func() {
+		defer panics.Recover(op)
+		ctx, cancel := deadlines.Background()
+		defer cancel()
+		ctx = coalog.WithTrigger(ctx, op, "")
+		_, err := srv.newAuthorizationClient().Disconnect(ctx, &protos.DisconnectRequest{Ctx: aaaCtx, Reason: reason})
+		if err != nil {
+			log.Printf("Session %s %s failed: %v", sid, op, err)
+		}
+	}()
+}
+
 func makeSID(imsi string) *lte_protos.SubscriberID {
 	if !strings.HasPrefix(imsi, imsiPrefix) {
 		imsi = imsiPrefix + imsi
@@ -210,14 +675,9 @@ func makeSID(imsi string) *lte_protos.SubscriberID {
 	return &lte_protos.SubscriberID{Id: imsi, Type: lte_protos.SubscriberID_IMSI}
 }
 
-func isThruthy(value string) bool {
-	value = strings.TrimSpace(value)
-	if len(value) == 0 {
-		return false
-	}
-	value = strings.ToLower(value)
-	if value == "0" || strings.HasPrefix(value, "false") || strings.HasPrefix(value, "n") {
-		return false
+// seen reports the subscriber's accounting activity to the auth vectors prefetcher, if enabled
+func (srv *accountingService) seen(aaaCtx *protos.Context) {
+	if srv.prefetcher != nil {
+		srv.prefetcher.Seen(aaaCtx.GetImsi())
 	}
-	return true
 }
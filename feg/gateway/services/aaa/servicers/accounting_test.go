@@ -0,0 +1,1686 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package servicers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"fbc/lib/go/radius"
+	"fbc/lib/go/radius/rfc2866"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"magma/feg/cloud/go/protos/mconfig"
+	"magma/feg/gateway/services/aaa"
+	"magma/feg/gateway/services/aaa/acctfaults"
+	"magma/feg/gateway/services/aaa/acctproxy"
+	"magma/feg/gateway/services/aaa/adminauth"
+	"magma/feg/gateway/services/aaa/apnalias"
+	"magma/feg/gateway/services/aaa/audit"
+	"magma/feg/gateway/services/aaa/coalog"
+	"magma/feg/gateway/services/aaa/directory"
+	"magma/feg/gateway/services/aaa/failuremode"
+	"magma/feg/gateway/services/aaa/mab"
+	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/aaa/policyhook"
+	"magma/feg/gateway/services/aaa/protos"
+	"magma/feg/gateway/services/aaa/quirks"
+	"magma/feg/gateway/services/aaa/ratelimit"
+	"magma/feg/gateway/services/aaa/store"
+	lte_protos "magma/lte/cloud/go/protos"
+)
+
+type policyEndpoint struct {
+	decision *protos.PolicyDecision
+	err      error
+}
+
+func (e *policyEndpoint) Decide(context.Context, *protos.PolicyDecisionRequest) (*protos.PolicyDecision, error) {
+	return e.decision, e.err
+}
+
+// newTestAccounting returns an accounting service of a memory session table with the given sessions
+func newTestAccounting(t *testing.T, sessions ...*protos.Context) *accountingService {
+	srv, err := NewAccountingService(store.NewMemorySessionTable(), &mconfig.AAAConfig{})
+	assert.NoError(t, err)
+	for _, aaaCtx := range sessions {
+		_, err = srv.sessions.AddSession(aaaCtx, time.Minute, nil)
+		assert.NoError(t, err)
+	}
+	return srv
+}
+
+func TestContinueHandover(t *testing.T) {
+	aaaCtx := &protos.Context{SessionId: "sid1", Imsi: "IMSI123456789012345", Apn: "apn1"}
+	srv := newTestAccounting(t, aaaCtx)
+	s := srv.sessions.GetSession("sid1")
+
+	// no handover is signaled
+	assert.False(t, srv.continueHandover(aaaCtx))
+
+	_, err := srv.Handover(context.Background(),
+		&protos.HandoverRequest{Imsi: "IMSI123456789012345", Apn: "APN1", SessionId: "lte-sid"})
+	assert.NoError(t, err)
+	assert.True(t, srv.continueHandover(aaaCtx))
+	ltesid, ok := s.GetCtx().GetAttribute(HandoverSessionAttribute)
+	assert.True(t, ok)
+	assert.Equal(t, "lte-sid", ltesid)
+	assert.Empty(t, aaaCtx.GetAttributes(), "the session's previous context is not modified")
+
+	// handovers are continued once & expire
+	assert.False(t, srv.continueHandover(aaaCtx))
+	srv.SetHandoverWindow(0)
+	_, err = srv.Handover(context.Background(), &protos.HandoverRequest{Imsi: "123456789012345", SessionId: "lte-sid"})
+	assert.NoError(t, err)
+	time.Sleep(time.Millisecond)
+	assert.False(t, srv.continueHandover(aaaCtx))
+}
+
+func TestHandover(t *testing.T) {
+	srv := newTestAccounting(t)
+	_, err := srv.Handover(context.Background(), &protos.HandoverRequest{Imsi: "IMSI", SessionId: "lte-sid"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// handovers of other APNs are kept for the subscriber's session of the signaled APN
+	_, err = srv.Handover(context.Background(),
+		&protos.HandoverRequest{Imsi: "123456789012345", Apn: "apn1", SessionId: "lte-sid"})
+	assert.NoError(t, err)
+	assert.False(t, srv.continueHandover(&protos.Context{SessionId: "sid1", Imsi: "123456789012345", Apn: "apn2"}))
+	assert.True(t, srv.continueHandover(&protos.Context{SessionId: "sid2", Imsi: "123456789012345", Apn: "APN1"}))
+	assert.Empty(t, srv.handovers.pending)
+
+	// expired handovers are dropped by the next signaled handover
+	srv.SetHandoverWindow(0)
+	_, err = srv.Handover(context.Background(), &protos.HandoverRequest{Imsi: "123456789012345"})
+	assert.NoError(t, err)
+	time.Sleep(time.Millisecond)
+	_, err = srv.Handover(context.Background(), &protos.HandoverRequest{Imsi: "IMSI123456789012346"})
+	assert.NoError(t, err)
+	assert.Len(t, srv.handovers.pending, 1)
+	assert.Contains(t, srv.handovers.pending, "123456789012346")
+}
+
+func TestAuthorizeSession(t *testing.T) {
+	aaaCtx := &protos.Context{SessionId: "sid1", Imsi: "123456789012345", Apn: "apn1"}
+	srv := newTestAccounting(t, aaaCtx)
+	s := srv.sessions.GetSession("sid1")
+
+	// sessions are authorized without a policy endpoint
+	assert.NoError(t, srv.authorizeSession(context.Background(), aaaCtx, policyhook.EventStart))
+
+	endpoint := &policyEndpoint{
+		decision: &protos.PolicyDecision{Annotations: map[string]string{"tier": "gold"}},
+	}
+	srv.SetPolicyHook(policyhook.New(endpoint, time.Second, false))
+	assert.NoError(t, srv.authorizeSession(context.Background(), aaaCtx, policyhook.EventStart))
+	tier, ok := s.GetCtx().GetAttribute("tier")
+	assert.True(t, ok)
+	assert.Equal(t, "gold", tier)
+
+	endpoint.decision = &protos.PolicyDecision{Veto: true, Reason: "blocked"}
+	assert.Error(t, srv.authorizeSession(context.Background(), aaaCtx, policyhook.EventStart))
+
+	endpoint.decision, endpoint.err = nil, errors.New("policy endpoint unavailable")
+	assert.Error(t, srv.authorizeSession(context.Background(), aaaCtx, policyhook.EventStart))
+}
+
+func TestReorderTablePurge(t *testing.T) {
+	ctx1 := &protos.Context{SessionId: "sid1", Imsi: "123456789012345", Apn: "apn1"}
+	ctx2 := &protos.Context{SessionId: "sid2", Imsi: "123456789012345", Apn: "apn1"}
+	srv := newTestAccounting(t, ctx1)
+	srv.SetReorderWindow(10 * time.Millisecond)
+	srv.started(ctx1)
+	assert.Len(t, srv.reorder.started, 1)
+	assert.Len(t, srv.reorder.sessions, 1)
+
+	// the Start of the next session is held up to the window & its pending Stop is forgotten
+	srv.awaitPreviousStop(context.Background(), ctx2)
+	assert.Empty(t, srv.reorder.stops)
+
+	// the Stop of the previous session releases the held Start
+	srv.SetReorderWindow(time.Minute)
+	released := make(chan struct{})
+	go func() {
+		srv.awaitPreviousStop(context.Background(), ctx2)
+		close(released)
+	}()
+	for held := false; !held; time.Sleep(time.Millisecond) {
+		srv.reorder.Lock()
+		held = len(srv.reorder.stops) == 1
+		srv.reorder.Unlock()
+	}
+	srv.stopped(ctx1)
+	<-released
+	assert.Empty(t, srv.reorder.stops)
+	assert.Empty(t, srv.reorder.started)
+	assert.Empty(t, srv.reorder.sessions)
+
+	// sessions removed without a Stop are forgotten
+	_, err := srv.sessions.AddSession(ctx2, time.Minute, nil)
+	assert.NoError(t, err)
+	srv.started(ctx1)
+	srv.started(ctx2)
+	srv.clearSessionState("sid1")
+	assert.Equal(t, map[string]string{"123456789012345/apn1": "sid2"}, srv.reorder.started)
+	assert.Equal(t, map[string]string{"sid2": "123456789012345/apn1"}, srv.reorder.sessions)
+	srv.clearSessionState("sid2")
+	assert.Empty(t, srv.reorder.started)
+	assert.Empty(t, srv.reorder.sessions)
+
+	// disabled reordering doesn't track sessions
+	srv.started(ctx2)
+	srv.SetReorderWindow(0)
+	assert.Empty(t, srv.reorder.started)
+	srv.started(ctx2)
+	assert.Empty(t, srv.reorder.sessions)
+}
+
+func TestReconcile(t *testing.T) {
+	srv := newTestAccounting(t,
+		&protos.Context{SessionId: "sid1", Imsi: "IMSI001010000000001"},
+		&protos.Context{
+			SessionId: "sid2", Imsi: "001010000000002", Attributes: map[string]string{failuremode.Attribute: "1"}},
+		&protos.Context{SessionId: "sid3", Imsi: "001010000000001"})
+
+	srv.usage.update("sid1", "IMSI001010000000001", &protos.UpdateRequest{OctetsIn: 1000, OctetsOut: 1000}, 1)
+	// NAS counter reset
+	u, deltaIn, deltaOut := srv.usage.update(
+		"sid1", "IMSI001010000000001", &protos.UpdateRequest{OctetsIn: 100, OctetsOut: 1000}, 1)
+	assert.Equal(t, uint64(1100), u.octetsIn)
+	assert.Equal(t, uint64(1000), u.octetsOut)
+	assert.Equal(t, uint64(100), deltaIn)
+	assert.Equal(t, uint64(0), deltaOut)
+	// counters wrapped around 2^32 are extended by their Gigawords
+	srv.usage.update("sid3", "001010000000001", &protos.UpdateRequest{OctetsIn: math.MaxUint32 - 99}, 1)
+	u, deltaIn, _ = srv.usage.update(
+		"sid3", "001010000000001", &protos.UpdateRequest{OctetsIn: 100, GigawordsIn: 1}, 1)
+	assert.Equal(t, uint64(1<<32+100), u.octetsIn)
+	assert.Equal(t, uint64(200), deltaIn)
+	srv.usage.update("sid2", "001010000000002", &protos.UpdateRequest{OctetsIn: 1000}, 1)
+
+	// the subscriber's sessions are reconciled separately
+	report, err := srv.Reconcile(context.Background(), &protos.ReconciliationRequest{
+		Threshold: 5,
+		Reported: []*protos.SessionUsage{
+			{SessionId: "sid1", Imsi: "001010000000001", OctetsIn: 1100, OctetsOut: 1000},
+			{SessionId: "sid3", Imsi: "001010000000001", OctetsIn: 1<<32 + 100},
+			{SessionId: "sid2", Imsi: "IMSI001010000000002", OctetsIn: 500},
+			{SessionId: "sid4", Imsi: "001010000000003", OctetsIn: 10},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, report.GetEntries(), 4)
+	assert.Equal(t, uint32(2), report.GetDivergedCount())
+	assert.Equal(t, uint32(1), report.GetFlaggedCount())
+
+	// entries are sorted by divergence, sessions known to one side only are fully diverged
+	reportedOnly, sid2 := report.GetEntries()[0], report.GetEntries()[1]
+	assert.Equal(t, "sid4", reportedOnly.GetSessionId())
+	assert.Equal(t, "001010000000003", reportedOnly.GetImsi())
+	assert.Equal(t, float64(100), reportedOnly.GetDivergence())
+	assert.True(t, reportedOnly.GetDiverged())
+
+	assert.Equal(t, "sid2", sid2.GetSessionId())
+	assert.Equal(t, float64(50), sid2.GetDivergence())
+	assert.True(t, sid2.GetDiverged())
+	assert.True(t, sid2.GetFlagged())
+
+	reconciled := map[string]*protos.ReconciliationEntry{}
+	for _, entry := range report.GetEntries()[2:] {
+		reconciled[entry.GetSessionId()] = entry
+	}
+	sid1 := reconciled["sid1"]
+	assert.Equal(t, "001010000000001", sid1.GetImsi())
+	assert.Equal(t, uint64(1100), sid1.GetLocalOctetsIn())
+	assert.Equal(t, uint64(1100), sid1.GetReportedOctetsIn())
+	assert.Equal(t, float64(0), sid1.GetDivergence())
+	assert.False(t, sid1.GetDiverged())
+	assert.False(t, sid1.GetFlagged())
+	sid3 := reconciled["sid3"]
+	assert.Equal(t, uint64(1<<32+100), sid3.GetLocalOctetsIn())
+	assert.Equal(t, float64(0), sid3.GetDivergence())
+
+	_, err = srv.Reconcile(context.Background(), &protos.ReconciliationRequest{Threshold: -1})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = srv.Reconcile(context.Background(), &protos.ReconciliationRequest{
+		Reported: []*protos.SessionUsage{{Imsi: "001010000000001", OctetsIn: 1100}}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+type usageStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	updates chan *protos.SubscriberUsageUpdate
+}
+
+func (s *usageStream) Context() context.Context { return s.ctx }
+
+func (s *usageStream) Send(u *protos.SubscriberUsageUpdate) error {
+	s.updates <- u
+	return nil
+}
+
+func TestWatchSubscriberUsage(t *testing.T) {
+	srv := newTestAccounting(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &usageStream{ctx: ctx, updates: make(chan *protos.SubscriberUsageUpdate, 10)}
+	err := srv.WatchSubscriberUsage(&protos.SubscriberUsageRequest{Imsi: "IMSI"}, stream)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	done := make(chan error)
+	go func() {
+		done <- srv.WatchSubscriberUsage(&protos.SubscriberUsageRequest{Imsi: "IMSI001010000000001"}, stream)
+	}()
+	for watching := false; !watching; time.Sleep(time.Millisecond) {
+		srv.watchers.Lock()
+		watching = srv.watchers.count == 1
+		srv.watchers.Unlock()
+	}
+
+	// only the watched subscriber's updates are streamed
+	srv.publishUsage("sid2", localUsage{imsi: "001010000000002", octetsIn: 5}, 5, 0, false)
+	srv.publishUsage("sid1", localUsage{imsi: "IMSI001010000000001", octetsIn: 10, octetsOut: 20}, 10, 20, false)
+	u := <-stream.updates
+	assert.Equal(t, "001010000000001", u.GetImsi())
+	assert.Equal(t, "sid1", u.GetSessionId())
+	assert.Equal(t, uint64(10), u.GetDeltaOctetsIn())
+	assert.Equal(t, uint64(20), u.GetTotalOctetsOut())
+	assert.False(t, u.GetSessionEnded())
+
+	srv.publishSessionEnd("sid1", "IMSI001010000000001")
+	u = <-stream.updates
+	assert.Equal(t, "sid1", u.GetSessionId())
+	assert.True(t, u.GetSessionEnded())
+
+	cancel()
+	assert.NoError(t, <-done)
+	assert.Empty(t, srv.watchers.byImsi)
+	assert.Equal(t, 0, srv.watchers.count)
+}
+
+func TestUsageWatchers(t *testing.T) {
+	// updates of the same session are merged while the stream is busy
+	w := &usageWatcher{notify: make(chan struct{}, 1)}
+	w.push(&protos.SubscriberUsageUpdate{SessionId: "sid1", DeltaOctetsIn: 1, TotalOctetsIn: 1, TimeMs: 1})
+	w.push(&protos.SubscriberUsageUpdate{SessionId: "sid2", DeltaOctetsIn: 7, TotalOctetsIn: 7, TimeMs: 1})
+	w.push(&protos.SubscriberUsageUpdate{
+		SessionId: "sid1", DeltaOctetsIn: 2, TotalOctetsIn: 3, TimeMs: 2, SessionEnded: true})
+	w.push(&protos.SubscriberUsageUpdate{SessionId: "sid1", DeltaOctetsIn: 4, TotalOctetsIn: 7, TimeMs: 3})
+	pending := w.take()
+	assert.Len(t, pending, 2)
+	assert.Equal(t, &protos.SubscriberUsageUpdate{
+		SessionId: "sid1", DeltaOctetsIn: 7, TotalOctetsIn: 7, TimeMs: 3, SessionEnded: true}, pending[0])
+	assert.Equal(t, "sid2", pending[1].GetSessionId())
+	assert.Empty(t, w.take())
+
+	// the number of watchers is limited
+	uw := newUsageWatchers()
+	var first *usageWatcher
+	for i := 0; i < MaxUsageWatchers; i++ {
+		w, err := uw.add("001010000000001")
+		assert.NoError(t, err)
+		if first == nil {
+			first = w
+		}
+	}
+	_, err := uw.add("001010000000002")
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	uw.remove("001010000000001", first)
+	uw.remove("001010000000001", first)
+	assert.Equal(t, MaxUsageWatchers-1, uw.count)
+	_, err = uw.add("001010000000002")
+	assert.NoError(t, err)
+}
+
+func TestResolveDuplicateIMSI(t *testing.T) {
+	policy, err := ParseDuplicateIMSIPolicy("")
+	assert.NoError(t, err)
+	assert.Equal(t, DuplicateIMSIPermit, policy)
+	policy, err = ParseDuplicateIMSIPolicy("clone")
+	assert.NoError(t, err)
+	assert.Equal(t, DuplicateIMSIClone, policy)
+	_, err = ParseDuplicateIMSIPolicy("reject")
+	assert.Error(t, err)
+
+	existing := &protos.Context{SessionId: "sid1", Imsi: "123456789012345", MacAddr: "aa:bb:cc:dd:ee:01", Apn: "ap1"}
+	otherMac := &protos.Context{SessionId: "sid2", Imsi: "123456789012345", MacAddr: "aa:bb:cc:dd:ee:02", Apn: "ap1"}
+	otherAp := &protos.Context{SessionId: "sid2", Imsi: "123456789012345", MacAddr: "AA:BB:CC:DD:EE:01", Apn: "ap2"}
+	srv := newTestAccounting(t, existing)
+
+	// a new session of the same UE & AP replaces the existing one regardless of the policy
+	srv.SetDuplicateIMSIPolicy(DuplicateIMSIClone)
+	sameUE := &protos.Context{SessionId: "sid2", Imsi: "123456789012345", MacAddr: "AA:BB:CC:DD:EE:01", Apn: "AP1"}
+	assert.NoError(t, srv.resolveDuplicateIMSI(sameUE))
+	assert.NotNil(t, srv.sessions.GetSession("sid1"))
+
+	srv.SetDuplicateIMSIPolicy(DuplicateIMSIPermit)
+	assert.NoError(t, srv.resolveDuplicateIMSI(otherMac))
+	assert.NotNil(t, srv.sessions.GetSession("sid1"))
+
+	srv.SetDuplicateIMSIPolicy(DuplicateIMSIRoam)
+	assert.NoError(t, srv.resolveDuplicateIMSI(otherMac))
+	assert.Nil(t, srv.sessions.GetSession("sid1"))
+
+	// sessions of a cloned IMSI are rejected & the existing one is ended
+	srv.SetDuplicateIMSIPolicy(DuplicateIMSIClone)
+	_, err = srv.sessions.AddSession(existing, time.Minute, nil)
+	assert.NoError(t, err)
+	err = srv.resolveDuplicateIMSI(otherMac)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.Nil(t, srv.sessions.GetSession("sid1"))
+
+	// a new session of the existing session's MAC on another AP is a roam
+	_, err = srv.sessions.AddSession(existing, time.Minute, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, srv.resolveDuplicateIMSI(otherAp))
+	assert.Nil(t, srv.sessions.GetSession("sid1"))
+
+	// sessions without IMSI (e.g. of EAP-TLS certificates) aren't duplicates of each other
+	noIMSI := &protos.Context{SessionId: "sid3", Identity: "user1@wlan.example.com", MacAddr: "aa:bb:cc:dd:ee:03"}
+	_, err = srv.sessions.AddSession(noIMSI, time.Minute, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, srv.resolveDuplicateIMSI(
+		&protos.Context{SessionId: "sid4", Identity: "user2@wlan.example.com", MacAddr: "aa:bb:cc:dd:ee:04"}))
+	assert.NotNil(t, srv.sessions.GetSession("sid3"))
+}
+
+func TestCheckTraffic(t *testing.T) {
+	srv := newTestAccounting(t,
+		&protos.Context{SessionId: "sid1", Imsi: "IMSI001010000000001"},
+		&protos.Context{SessionId: "sid2", Imsi: "001010000000002"})
+	timeouts := func() map[string]time.Duration {
+		res := map[string]time.Duration{}
+		for _, st := range srv.sessions.(aaa.SessionLister).ListSessions() {
+			res[st.Session.GetCtx().GetSessionId()] = st.Timeout
+		}
+		return res
+	}
+	traffic := map[string]uint64{"001010000000001": 100, "IMSI001010000000002": 100, "001010000000003": 100}
+	var trafficErr error
+	source := func(context.Context) (map[string]uint64, error) { return traffic, trafficErr }
+	table := &trafficTable{last: map[string]uint64{}}
+
+	// the first check only records the counters
+	srv.checkTraffic(table, source)
+	assert.Equal(t, map[string]uint64{"001010000000001": 100, "001010000000002": 100, "001010000000003": 100},
+		table.last)
+	assert.True(t, timeouts()["sid1"] <= time.Minute)
+
+	// failed checks keep the previous counters
+	trafficErr = errors.New("pipelined unavailable")
+	srv.checkTraffic(table, source)
+	assert.Len(t, table.last, 3)
+
+	// reset counters are traffic too
+	traffic, trafficErr = map[string]uint64{"001010000000001": 10, "IMSI001010000000002": 100}, nil
+	srv.checkTraffic(table, source)
+	assert.True(t, timeouts()["sid1"] > time.Minute)
+	assert.True(t, timeouts()["sid2"] <= time.Minute)
+	assert.Len(t, table.last, 2)
+
+	traffic = map[string]uint64{"001010000000001": 10, "IMSI001010000000002": 200}
+	srv.checkTraffic(table, source)
+	assert.True(t, timeouts()["sid2"] > time.Minute)
+}
+
+func TestTrafficIdleTimeout(t *testing.T) {
+	srv := newTestAccounting(t, &protos.Context{SessionId: "sid1", Imsi: "123456789012345", Apn: "ap1"})
+	timeout := func() time.Duration {
+		for _, st := range srv.sessions.(aaa.SessionLister).ListSessions() {
+			return st.Timeout
+		}
+		return 0
+	}
+	interim := func(octetsIn uint32) {
+		_, err := srv.InterimUpdate(context.Background(),
+			&protos.UpdateRequest{OctetsIn: octetsIn, Ctx: &protos.Context{SessionId: "sid1"}})
+		assert.NoError(t, err)
+	}
+	srv.SetTrafficIdleTimeout(time.Minute)
+	interim(100)
+	assert.True(t, timeout() > time.Minute, "Interim-Updates with traffic restart the idle session timeout")
+
+	// Interim-Updates without traffic don't extend the timeout past the last traffic plus the traffic idle timeout
+	interim(100)
+	assert.True(t, timeout() <= time.Minute)
+	assert.True(t, timeout() > 50*time.Second)
+	srv.usage.sessions["sid1"].active = time.Now().Add(-2 * time.Minute)
+	interim(100)
+	assert.True(t, timeout() <= minTrafficIdleTimeout)
+
+	// traffic seen by pipelined is traffic too
+	assert.True(t, srv.touchSession("123456789012345"))
+	interim(100)
+	assert.True(t, timeout() > 50*time.Second)
+
+	srv.SetTrafficIdleTimeout(0)
+	interim(100)
+	assert.True(t, timeout() > time.Minute)
+}
+
+func TestPatchSession(t *testing.T) {
+	srv := newTestAccounting(t,
+		&protos.Context{SessionId: "sid1", Imsi: "123456789012345", Msisdn: "100", Msk: []byte{1, 2, 3}})
+	auth, err := adminauth.New(&adminauth.Config{Tokens: []adminauth.TokenGrant{
+		{Name: "ops", Token: "operator-token", Role: adminauth.Operator},
+		{Name: "viewer", Token: "viewer-token", Role: adminauth.ReadOnly},
+	}})
+	assert.NoError(t, err)
+	_, err = NewSessionAdminService(nil, auth, nil, nil)
+	assert.Error(t, err)
+	_, err = NewSessionAdminService(srv, nil, nil, nil)
+	assert.Error(t, err)
+	admin, err := NewSessionAdminService(srv, auth, nil, nil)
+	assert.NoError(t, err)
+	tokenCtx := func(token string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+	}
+	ctx := tokenCtx("operator-token")
+
+	_, err = admin.PatchSession(context.Background(), &protos.SessionPatchRequest{SessionId: "sid1", Operator: "jdoe"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = admin.PatchSession(
+		tokenCtx("viewer-token"), &protos.SessionPatchRequest{SessionId: "sid1", Operator: "jdoe"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = admin.PatchSession(ctx, &protos.SessionPatchRequest{SessionId: "sid1"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = admin.PatchSession(ctx, &protos.SessionPatchRequest{SessionId: "sid2", Operator: "jdoe"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	for _, fields := range []map[string]string{
+		{"imsi": "123456789012346"},
+		{"msisdn": "12ab"},
+		{"msisdn": "1234567890123456"},
+		{"mac_addr": "aa:bb"},
+		{"ip_addr": "10.0.0"},
+		{"ipv6_addr": "10.0.0.1"},
+	} {
+		_, err = admin.PatchSession(
+			ctx, &protos.SessionPatchRequest{SessionId: "sid1", Operator: "jdoe", Fields: fields})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), "fields: %v", fields)
+	}
+
+	res, err := admin.PatchSession(ctx, &protos.SessionPatchRequest{
+		SessionId:  "sid1",
+		Operator:   "jdoe",
+		Fields:     map[string]string{"msisdn": "123456", "ip_addr": "10.0.0.1"},
+		Attributes: map[string]string{"tier": "gold"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []*protos.FieldChange{
+		{Field: "msisdn", OldValue: "100", NewValue: "123456"},
+		{Field: "ip_addr", NewValue: "10.0.0.1"},
+		{Field: "attributes.tier", NewValue: "gold"},
+	}, res.GetChanges())
+	assert.Equal(t, "123456", res.GetCtx().GetMsisdn())
+	assert.Empty(t, res.GetCtx().GetMsk(), "the result doesn't expose the session's MSK")
+
+	aaaCtx := srv.sessions.GetSession("sid1").GetCtx()
+	assert.Equal(t, "10.0.0.1", aaaCtx.GetIpAddr())
+	assert.Equal(t, []byte{1, 2, 3}, aaaCtx.GetMsk())
+	tier, ok := aaaCtx.GetAttribute("tier")
+	assert.True(t, ok)
+	assert.Equal(t, "gold", tier)
+}
+
+func TestRespondWithin(t *testing.T) {
+	aaaCtx := &protos.Context{SessionId: "sid1", Imsi: "123456789012345"}
+	srv := newTestAccounting(t, aaaCtx)
+	callErr := status.Errorf(codes.Unavailable, "session manager unavailable")
+	failing := func(context.Context) (*protos.AcctResp, error) { return &protos.AcctResp{}, callErr }
+
+	// without a deadline & within it, the call's result is returned
+	_, err := srv.respondWithin(context.Background(), "Start", aaaCtx, false, failing)
+	assert.Equal(t, callErr, err)
+	srv.SetResponseDeadline(time.Minute)
+	_, err = srv.respondWithin(context.Background(), "Start", aaaCtx, false, failing)
+	assert.Equal(t, callErr, err)
+
+	// late calls are responded & complete after their RPC is canceled
+	srv.SetResponseDeadline(time.Millisecond)
+	release, completed := make(chan struct{}), make(chan error, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	resp, err := srv.respondWithin(ctx, "Start", aaaCtx, false, func(ctx context.Context) (*protos.AcctResp, error) {
+		<-release
+		completed <- ctx.Err()
+		return &protos.AcctResp{}, callErr
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, srv.acctResp(aaaCtx), resp)
+	cancel()
+	close(release)
+	assert.NoError(t, <-completed)
+}
+
+type auditRecorder struct {
+	events []*audit.Event
+}
+
+func (r *auditRecorder) Log(ev *audit.Event) error {
+	r.events = append(r.events, ev)
+	return nil
+}
+
+func TestMultiSession(t *testing.T) {
+	roamed := map[string]string{protos.MultiSessionIDAttribute: "ess1-ue1"}
+	ctx1 := &protos.Context{SessionId: "sid1", Imsi: "123456789012345", Apn: "ap1", Attributes: roamed}
+	ctx2 := &protos.Context{SessionId: "sid2", Imsi: "123456789012345", Apn: "ap2", Attributes: roamed}
+	ctx3 := &protos.Context{SessionId: "sid3", Imsi: "123456789012346", Apn: "ap1"}
+	srv := newTestAccounting(t, ctx1, ctx3)
+	recorder := &auditRecorder{}
+	srv.AddAuditSink(recorder)
+
+	srv.usage.update("sid1", ctx1.GetImsi(), &protos.UpdateRequest{OctetsIn: 100, OctetsOut: 200}, 1)
+	srv.auditEvent(audit.Interim, ctx1)
+	assert.Equal(t, &audit.MultiSession{Id: "ess1-ue1", Sessions: 1, OctetsIn: 100, OctetsOut: 200},
+		recorder.events[0].MultiSession)
+
+	// the multi-session's record accumulates the usage of its ended sessions
+	srv.forgetSession("sid1", audit.Stop, srv.sessions.RemoveSession("sid1"))
+	_, err := srv.sessions.AddSession(ctx2, time.Minute, nil)
+	assert.NoError(t, err)
+	srv.usage.update("sid2", ctx2.GetImsi(), &protos.UpdateRequest{OctetsIn: 50, OctetsOut: 50}, 1)
+	srv.auditEvent(audit.Interim, ctx2)
+	assert.Equal(t, &audit.MultiSession{Id: "ess1-ue1", Sessions: 2, OctetsIn: 150, OctetsOut: 250},
+		recorder.events[2].MultiSession)
+
+	// sessions of NASes without multi-sessions aren't grouped
+	srv.auditEvent(audit.Interim, ctx3)
+	assert.Nil(t, recorder.events[3].MultiSession)
+
+	srv.forgetSession("sid2", audit.Stop, srv.sessions.RemoveSession("sid2"))
+	assert.Empty(t, srv.multiSessions.ids)
+	assert.Len(t, srv.multiSessions.groups, 1, "the multi-session lingers for its next session")
+}
+
+func TestSessionOps(t *testing.T) {
+	ops := newSessionOps()
+	waiting := func(priority int) int {
+		ops.mu.Lock()
+		defer ops.mu.Unlock()
+		if q, ok := ops.queues["sid1"]; ok {
+			return q.waiting[priority]
+		}
+		return 0
+	}
+	awaitQueued := func(priority int) {
+		for i := 0; waiting(priority) == 0; i++ {
+			if i > 100 {
+				t.Fatalf("operation of priority %d isn't queued", priority)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	end := ops.begin("sid1", opInterim)
+	otherEnd := ops.begin("sid2", opInterim) // other sessions' operations aren't serialized
+	otherEnd()
+
+	// the termination queued after an Interim-Update runs first
+	order := make(chan string, 2)
+	run := func(name string, priority int) {
+		defer ops.begin("sid1", priority)()
+		order <- name
+	}
+	go run("interim", opInterim)
+	awaitQueued(opInterim)
+	go run("terminate", opTerminate)
+	awaitQueued(opTerminate)
+	end()
+	assert.Equal(t, "terminate", <-order)
+	assert.Equal(t, "interim", <-order)
+
+	// the queues of sessions without operations are removed
+	for i := 0; ; i++ {
+		ops.mu.Lock()
+		n := len(ops.queues)
+		ops.mu.Unlock()
+		if n == 0 {
+			break
+		}
+		if i > 100 {
+			t.Fatalf("%d session queues aren't removed", n)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// testAllowList - MAC allow-list of the devices by MAC address
+type testAllowList map[string]*mab.Device
+
+func (l testAllowList) Lookup(_ context.Context, mac string) (*mab.Device, error) {
+	if device, ok := l[mac]; ok {
+		return device, nil
+	}
+	return nil, mab.ErrNotAllowed
+}
+
+func TestMacAuthBypass(t *testing.T) {
+	acct := newTestAccounting(t)
+	cfg := &mconfig.AAAConfig{}
+	auth, err := NewEapAuthenticator(acct.sessions, cfg, acct)
+	assert.NoError(t, err)
+	device := &protos.Context{SessionId: "sid1", MacAddr: "0A-1B-2C-3D-4E-5F", Apn: "iot"}
+
+	// MAB requires the mconfig's MacAuthBypass & an allow-list
+	_, err = auth.MacAuthBypass(context.Background(), device)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	cfg.MacAuthBypass = true
+	_, err = auth.MacAuthBypass(context.Background(), device)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	acct.SetMACAllowList(testAllowList{
+		"0a:1b:2c:3d:4e:5f": {MAC: "0a:1b:2c:3d:4e:5f", IMSI: "001010000000001"},
+		"0a:1b:2c:3d:4e:60": {MAC: "0a:1b:2c:3d:4e:60", IMSI: "001010000000002", APN: "iot-metering"},
+	})
+	recorder := &auditRecorder{}
+	acct.AddAuditSink(recorder)
+
+	// allow-listed devices' sessions get synthetic identities & are kept for their accounting
+	resp, err := auth.MacAuthBypass(context.Background(), device)
+	assert.NoError(t, err)
+	assert.Equal(t, "0a1b2c3d4e5f@mab", resp.GetIdentity())
+	assert.Equal(t, "001010000000001", resp.GetImsi())
+	assert.Equal(t, "iot", resp.GetApn())
+	session := acct.sessions.GetSession("sid1")
+	assert.NotNil(t, session)
+	assert.Equal(t, "0a:1b:2c:3d:4e:5f", session.GetCtx().GetMacAddr())
+
+	resp, err = auth.MacAuthBypass(context.Background(),
+		&protos.Context{SessionId: "sid2", MacAddr: "0a:1b:2c:3d:4e:60", Apn: "iot"})
+	assert.NoError(t, err)
+	assert.Equal(t, "iot-metering", resp.GetApn(), "the allow-listed APN overrides the NAS's")
+
+	// devices not in the allow-list are denied
+	_, err = auth.MacAuthBypass(context.Background(), &protos.Context{SessionId: "sid3", MacAddr: "0a:1b:2c:3d:4e:61"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.Nil(t, acct.sessions.GetSession("sid3"))
+	_, err = auth.MacAuthBypass(context.Background(), &protos.Context{SessionId: "sid3", MacAddr: "0a1b2c"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// authentications are audited with their outcomes & latencies
+	if assert.Len(t, recorder.events, 3) {
+		assert.Equal(t, audit.AuthSuccess, recorder.events[0].Type)
+		assert.Equal(t, "001010000000001", recorder.events[0].Imsi)
+		assert.Equal(t, "0a:1b:2c:3d:4e:5f", recorder.events[0].MacAddr)
+		assert.Equal(t, audit.OutcomeSuccess, recorder.events[0].Outcome)
+		assert.NotEmpty(t, recorder.events[0].Latency)
+		assert.Equal(t, "iot-metering", recorder.events[1].Apn)
+		assert.Equal(t, audit.AuthFailure, recorder.events[2].Type)
+		assert.Equal(t, audit.OutcomeFailure, recorder.events[2].Outcome)
+		assert.Equal(t, "sid3", recorder.events[2].SessionId)
+	}
+}
+
+func TestSessionLifetime(t *testing.T) {
+	srv, err := NewAccountingService(store.NewMemorySessionTable(), &mconfig.AAAConfig{
+		MaxSessionDurationMs:    3600000,
+		ApnMaxSessionDurationMs: map[string]uint32{"IoT": 0, "guest": 60000},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, time.Hour, srv.lifetimes.duration("internet"))
+	assert.Equal(t, time.Minute, srv.lifetimes.duration("Guest"))
+	assert.Equal(t, time.Duration(0), srv.lifetimes.duration("iot"), "APNs override the maximum duration")
+
+	// sessions keep their first scheduled expiration & unlimited APNs' sessions aren't scheduled
+	srv.scheduleLifetimeExpiration(&protos.Context{SessionId: "sid1", Apn: "guest"})
+	timer := srv.lifetimes.expirations["sid1"]
+	assert.NotNil(t, timer)
+	srv.scheduleLifetimeExpiration(&protos.Context{SessionId: "sid1", Apn: "guest"})
+	assert.True(t, timer == srv.lifetimes.expirations["sid1"])
+	srv.scheduleLifetimeExpiration(&protos.Context{SessionId: "sid2", Apn: "iot"})
+	assert.NotContains(t, srv.lifetimes.expirations, "sid2")
+
+	// ended sessions' expirations are canceled
+	srv.clearSessionState("sid1")
+	assert.Empty(t, srv.lifetimes.expirations)
+	assert.False(t, timer.Stop())
+}
+
+func TestListSessions(t *testing.T) {
+	srv := newTestAccounting(t,
+		&protos.Context{SessionId: "sid2", Imsi: "123456789012345", Apn: "Internet", MacAddr: "0A:1B:2C:3D:4E:5F"},
+		&protos.Context{SessionId: "sid1", Imsi: "123456789012345", Apn: "iot"},
+		&protos.Context{SessionId: "sid3", Imsi: "123456789012346", Apn: "internet"})
+	auth, err := adminauth.New(&adminauth.Config{Tokens: []adminauth.TokenGrant{
+		{Name: "ops", Token: "operator-token", Role: adminauth.Operator},
+		{Name: "viewer", Token: "viewer-token", Role: adminauth.ReadOnly},
+	}})
+	assert.NoError(t, err)
+	admin, err := NewSessionAdminService(srv, auth, nil, nil)
+	assert.NoError(t, err)
+	tokenCtx := func(token string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+	}
+	ctx := tokenCtx("viewer-token")
+	sids := func(l *protos.SessionList) []string {
+		var res []string
+		for _, s := range l.GetSessions() {
+			res = append(res, s.GetCtx().GetSessionId())
+		}
+		return res
+	}
+
+	_, err = admin.ListSessions(context.Background(), &protos.ListSessionsRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	l, err := admin.ListSessions(ctx, &protos.ListSessionsRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sid1", "sid2", "sid3"}, sids(l))
+	l, err = admin.ListSessions(ctx, &protos.ListSessionsRequest{Imsi: "IMSI123456789012345"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sid1", "sid2"}, sids(l))
+	l, err = admin.ListSessions(ctx, &protos.ListSessionsRequest{Imsi: "123456789012345", Apn: "INTERNET"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sid2"}, sids(l))
+	l, err = admin.ListSessions(ctx, &protos.ListSessionsRequest{MacAddr: "0a-1b-2c-3d-4e-5f"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sid2"}, sids(l))
+	_, err = admin.ListSessions(ctx, &protos.ListSessionsRequest{MacAddr: "0a1b"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	s, err := admin.GetSession(ctx, &protos.GetSessionRequest{SessionId: "sid3"})
+	assert.NoError(t, err)
+	assert.Equal(t, "123456789012346", s.GetCtx().GetImsi())
+	_, err = admin.GetSession(ctx, &protos.GetSessionRequest{SessionId: "sid4"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = admin.GetSession(ctx, &protos.GetSessionRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// only operators terminate sessions & their terminations are audited
+	_, err = admin.TerminateSessionByImsi(
+		ctx, &protos.TerminateSessionsRequest{Imsi: "123456789012345", Operator: "jdoe"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	ctx = tokenCtx("operator-token")
+	_, err = admin.TerminateSessionByImsi(ctx, &protos.TerminateSessionsRequest{Imsi: "123456789012345"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = admin.TerminateSessionByImsi(
+		ctx, &protos.TerminateSessionsRequest{Imsi: "123456789012347", Operator: "jdoe"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	recorder := &auditRecorder{}
+	srv.AddAuditSink(recorder)
+	assert.Equal(t, "sid1", srv.removeByOperator("sid1", "jdoe", "fraud").GetSessionId())
+	assert.Nil(t, srv.removeByOperator("sid1", "jdoe", "fraud"))
+	assert.Nil(t, srv.sessions.GetSession("sid1"))
+	assert.Len(t, recorder.events, 1)
+	assert.Equal(t, audit.Terminate, recorder.events[0].Type)
+	assert.Equal(t, "jdoe", recorder.events[0].Operator)
+	assert.Equal(t, "fraud", recorder.events[0].Reason)
+}
+
+func TestDirectoryRecords(t *testing.T) {
+	srv := newTestAccounting(t, &protos.Context{
+		SessionId: "sid1", Imsi: "IMSI123456789012345", MacAddr: "0a:1b:2c:3d:4e:5f", IpAddr: "10.0.0.1"})
+	publisher := directory.NewPublisher(nil) // records are kept, but not published
+	srv.SetDirectory(publisher)
+
+	// sessions created on auth are registered with their table contexts, before their Accounting Start
+	srv.sessionCreated(&protos.Context{SessionId: "sid1"})
+	r, ok := publisher.Get("123456789012345")
+	assert.True(t, ok)
+	assert.Equal(t, directory.Record{SessionID: "sid1", IPv4: "10.0.0.1", MAC: "0a:1b:2c:3d:4e:5f"}, r)
+
+	// ended sessions' records are removed
+	srv.forgetSession("sid1", audit.Stop, srv.sessions.RemoveSession("sid1"))
+	_, ok = publisher.Get("123456789012345")
+	assert.False(t, ok)
+}
+
+func TestUsageUnits(t *testing.T) {
+	kilobytes := map[string]string{quirks.Attribute: strconv.Itoa(int(quirks.KilobyteOctets))}
+	srv := newTestAccounting(t,
+		&protos.Context{SessionId: "sid1", Imsi: "123456789012345", Apn: "ap1", Attributes: kilobytes},
+		&protos.Context{SessionId: "sid2", Imsi: "123456789012346", Apn: "ap1"})
+
+	// kilobyte counters are normalized to octets, including their wrap arounds
+	for _, ur := range []*protos.UpdateRequest{
+		{OctetsIn: 10, OctetsOut: 20, Ctx: &protos.Context{SessionId: "sid1"}},
+		{OctetsIn: 30, OctetsOut: 20, Ctx: &protos.Context{SessionId: "sid1"}},
+		{OctetsIn: 100, OctetsOut: 200, Ctx: &protos.Context{SessionId: "sid2"}},
+	} {
+		_, err := srv.InterimUpdate(context.Background(), ur)
+		assert.NoError(t, err)
+	}
+	u, _ := srv.usage.get("sid1")
+	assert.Equal(t, uint64(30*quirks.Kilobyte), u.octetsIn)
+	assert.Equal(t, uint64(20*quirks.Kilobyte), u.octetsOut)
+	u, _ = srv.usage.get("sid2")
+	assert.Equal(t, uint64(100), u.octetsIn)
+	assert.Equal(t, uint64(200), u.octetsOut)
+
+	// fewer octets than packets & rates over the maximum are implausible
+	aaaCtx := &protos.Context{SessionId: "sid2", Apn: "ap1"}
+	now := time.Now()
+	previous := localUsage{packetsIn: 10, updated: now.Add(-time.Second)}
+	assert.Empty(t, srv.checkUsage(aaaCtx, previous, localUsage{packetsIn: 20, updated: now}, 10000, 0))
+	assert.Equal(t, []string{implausiblePacketSize},
+		srv.checkUsage(aaaCtx, previous, localUsage{packetsIn: 20, updated: now}, 100, 0))
+	assert.Equal(t, []string{implausibleRate},
+		srv.checkUsage(aaaCtx, previous, localUsage{updated: now}, DefaultMaxUsageRate, 1))
+	assert.Empty(t, srv.checkUsage(aaaCtx, localUsage{}, localUsage{updated: now}, DefaultMaxUsageRate, 1),
+		"the first usage of a session has no rate")
+	srv.SetMaxUsageRate(0)
+	assert.Empty(t, srv.checkUsage(aaaCtx, previous, localUsage{updated: now}, DefaultMaxUsageRate, 1))
+}
+
+func TestFinalUsage(t *testing.T) {
+	kilobytes := map[string]string{quirks.Attribute: strconv.Itoa(int(quirks.KilobyteOctets))}
+	sid1 := &protos.Context{SessionId: "sid1", Imsi: "123456789012345", Apn: "ap1", Attributes: kilobytes}
+	sid2 := &protos.Context{SessionId: "sid2", Imsi: "123456789012346", Apn: "ap1"}
+	srv := newTestAccounting(t, sid1, sid2)
+	_, err := srv.InterimUpdate(context.Background(),
+		&protos.UpdateRequest{OctetsIn: 10, OctetsOut: 20, PacketsIn: 1, PacketsOut: 2, Ctx: sid1})
+	assert.NoError(t, err)
+
+	// the Stop's final counters are accumulated as Interim-Updates' are
+	final := srv.finalUsage(sid1, &protos.StopRequest{OctetsIn: 15, OctetsOut: 40, PacketsIn: 3, PacketsOut: 4,
+		SessionTime: 600})
+	if assert.NotNil(t, final) {
+		assert.Equal(t, uint32(600), final.GetSessionTime())
+		assert.Equal(t, "sid1", final.GetFinalUsage().GetRadiusSessionId())
+		assert.Equal(t, uint64(15*quirks.Kilobyte), final.GetFinalUsage().GetOctetsIn())
+		assert.Equal(t, uint64(40*quirks.Kilobyte), final.GetFinalUsage().GetOctetsOut())
+		assert.Equal(t, uint64(3), final.GetFinalUsage().GetPacketsIn())
+		assert.Equal(t, uint64(4), final.GetFinalUsage().GetPacketsOut())
+	}
+
+	// Stops without counters end sessions with their Interim-Updates' usage
+	srv.usage.remove("sid1")
+	_, err = srv.InterimUpdate(context.Background(), &protos.UpdateRequest{OctetsIn: 100, Ctx: sid2})
+	assert.NoError(t, err)
+	final = srv.finalUsage(sid2, &protos.StopRequest{})
+	if assert.NotNil(t, final) {
+		assert.Equal(t, uint64(100), final.GetFinalUsage().GetOctetsIn())
+		assert.Zero(t, final.GetSessionTime())
+	}
+
+	// sessions without any usage end without a final usage
+	assert.Nil(t, srv.finalUsage(sid1, &protos.StopRequest{}))
+}
+
+func TestTerminateStoppedSession(t *testing.T) {
+	aaaCtx := &protos.Context{SessionId: "sid1", Imsi: "123456789012345", Apn: "apn1"}
+	srv := newTestAccounting(t, aaaCtx)
+	_, err := srv.Stop(context.Background(), &protos.StopRequest{Ctx: aaaCtx})
+	assert.NoError(t, err)
+
+	// session manager's termination racing the NAS's Stop succeeds
+	res, err := srv.TerminateSession(context.Background(),
+		&protos.TerminateSessionRequest{RadiusSessionId: "sid1", Imsi: "IMSI123456789012345"})
+	assert.NoError(t, err)
+	assert.Equal(t, alreadyTerminated, res.GetDetail())
+
+	// unknown sessions & other subscribers' sessions are still not found
+	_, err = srv.TerminateSession(context.Background(),
+		&protos.TerminateSessionRequest{RadiusSessionId: "sid1", Imsi: "IMSI123456789012346"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, err = srv.TerminateSession(context.Background(),
+		&protos.TerminateSessionRequest{RadiusSessionId: "sid2", Imsi: "IMSI123456789012345"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+type eventStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	events chan *protos.SessionEvent
+}
+
+func (s *eventStream) Context() context.Context { return s.ctx }
+
+func (s *eventStream) Send(ev *protos.SessionEvent) error {
+	s.events <- ev
+	return nil
+}
+
+func TestSubscribeEvents(t *testing.T) {
+	aaaCtx := &protos.Context{SessionId: "sid1", Imsi: "IMSI001010000000001", Apn: "00-11-22-AA-BB-CC:magma.wifi"}
+	srv := newTestAccounting(t, aaaCtx)
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &eventStream{ctx: ctx, events: make(chan *protos.SessionEvent, 10)}
+	done := make(chan error)
+	go func() {
+		done <- srv.SubscribeEvents(&protos.SessionEventsRequest{Types: []string{"interim", "stop"}}, stream)
+	}()
+	for subscribed := false; !subscribed; time.Sleep(time.Millisecond) {
+		srv.events.Lock()
+		subscribed = len(srv.events.subscribers) == 1
+		srv.events.Unlock()
+	}
+
+	// only the subscribed event types are streamed
+	srv.auditEvent(audit.Start, aaaCtx)
+	_, err := srv.InterimUpdate(context.Background(), &protos.UpdateRequest{OctetsIn: 100, OctetsOut: 200, Ctx: aaaCtx})
+	assert.NoError(t, err)
+	_, err = srv.Stop(context.Background(), &protos.StopRequest{Ctx: aaaCtx})
+	assert.NoError(t, err)
+
+	ev := <-stream.events
+	assert.Equal(t, "interim", ev.GetType())
+	assert.Equal(t, "sid1", ev.GetSessionId())
+	assert.Equal(t, "001010000000001", ev.GetImsi())
+	assert.Equal(t, "00-11-22-AA-BB-CC:magma.wifi", ev.GetApn())
+	assert.Equal(t, uint64(100), ev.GetOctetsIn())
+	assert.Equal(t, uint64(200), ev.GetOctetsOut())
+	assert.NotZero(t, ev.GetTimeMs())
+	ev = <-stream.events
+	assert.Equal(t, "stop", ev.GetType())
+	assert.Equal(t, uint64(100), ev.GetOctetsIn())
+
+	cancel()
+	assert.NoError(t, <-done)
+	assert.Empty(t, srv.events.subscribers)
+
+	// other subscribers' events aren't streamed
+	s, err := srv.events.add(&protos.SessionEventsRequest{Imsi: "IMSI001010000000002"})
+	assert.NoError(t, err)
+	assert.NoError(t, srv.events.Log(audit.NewEvent(audit.Start, "sid1", audit.Now(), audit.Timestamp{})))
+	assert.Empty(t, s.events)
+}
+
+func TestDuplicateAcctRequests(t *testing.T) {
+	aaaCtx := &protos.Context{SessionId: "sid1", Imsi: "123456789012345", Apn: "apn1",
+		Attributes: map[string]string{protos.EventTimeAttribute: "1600000000"}}
+	srv := newTestAccounting(t, aaaCtx)
+	_, err := srv.Start(context.Background(), aaaCtx)
+	assert.NoError(t, err)
+
+	// retransmitted Starts are acknowledged without starting the session again
+	_, err = srv.Start(context.Background(), aaaCtx)
+	assert.NoError(t, err)
+	assert.True(t, srv.startedByNAS.isDuplicate("sid1", 1600000001), "event times within the tolerance")
+	assert.False(t, srv.startedByNAS.isDuplicate("sid1", 1600000005))
+	assert.False(t, srv.startedByNAS.isDuplicate("sid1", 0))
+
+	stop := &protos.StopRequest{Ctx: &protos.Context{SessionId: "sid1",
+		Attributes: map[string]string{protos.EventTimeAttribute: "1600000600"}}}
+	_, err = srv.Stop(context.Background(), stop)
+	assert.NoError(t, err)
+	// retransmitted Stops succeed, Stops of other events still fail unless they're late requests of the stopped session
+	_, err = srv.Stop(context.Background(), stop)
+	assert.NoError(t, err)
+	srv.SetTerminatedLinger(0)
+	_, err = srv.Stop(context.Background(), &protos.StopRequest{Ctx: &protos.Context{SessionId: "sid1",
+		Attributes: map[string]string{protos.EventTimeAttribute: "1600000900"}}})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, err = srv.Stop(context.Background(), &protos.StopRequest{Ctx: &protos.Context{SessionId: "sid2"}})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestEventTimestampPolicy(t *testing.T) {
+	action, err := ParseEventTimestampAction("")
+	assert.NoError(t, err)
+	assert.Equal(t, EventTimestampFlag, action)
+	_, err = ParseEventTimestampAction("drop")
+	assert.Error(t, err)
+
+	srv := newTestAccounting(t, &protos.Context{SessionId: "sid1", Imsi: "123456789012345", Apn: "apn1"})
+	interim := func(skew string) error {
+		_, err := srv.InterimUpdate(context.Background(), &protos.UpdateRequest{Ctx: &protos.Context{
+			SessionId: "sid1", Attributes: map[string]string{protos.EventSkewAttribute: skew}}})
+		return err
+	}
+	srv.SetEventTimestampPolicy(time.Minute, EventTimestampFlag)
+	assert.NoError(t, interim("3600"), "flagged requests are processed")
+
+	srv.SetEventTimestampPolicy(time.Minute, EventTimestampReject)
+	assert.NoError(t, interim("59"))
+	assert.NoError(t, interim("-60"))
+	assert.Equal(t, codes.InvalidArgument, status.Code(interim("3600")), "stale & replayed requests are rejected")
+	assert.Equal(t, codes.InvalidArgument, status.Code(interim("-61")), "NAS clocks running ahead are rejected")
+	_, err = srv.Stop(context.Background(), &protos.StopRequest{Ctx: &protos.Context{SessionId: "sid1",
+		Attributes: map[string]string{protos.EventSkewAttribute: "-3600"}}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.NotNil(t, srv.sessions.GetSession("sid1"), "the rejected Stop doesn't stop the session")
+
+	// requests without Event-Timestamps are accepted
+	_, err = srv.InterimUpdate(context.Background(), &protos.UpdateRequest{Ctx: &protos.Context{SessionId: "sid1"}})
+	assert.NoError(t, err)
+	srv.SetEventTimestampPolicy(0, EventTimestampReject)
+	assert.NoError(t, interim("3600"))
+
+	assert.Equal(t, "nas1", nasLabel(&protos.Context{IpAddr: "10.0.0.1", Attributes: map[string]string{
+		protos.NASIdentifierAttribute: "nas1", protos.NASAddressAttribute: "192.168.1.1:1812"}}))
+	assert.Equal(t, "192.168.1.1", nasLabel(&protos.Context{IpAddr: "10.0.0.1", Attributes: map[string]string{
+		protos.NASAddressAttribute: "192.168.1.1:1812"}}), "the UE address isn't the NAS's")
+	assert.Equal(t, unknownNAS, nasLabel(&protos.Context{IpAddr: "10.0.0.1"}))
+}
+
+func TestResyncSessions(t *testing.T) {
+	srv := newTestAccounting(t,
+		&protos.Context{SessionId: "sid1", Imsi: "001010000000001", Apn: "apn1"},
+		&protos.Context{SessionId: "sid2", Imsi: "001010000000002", Apn: "apn1"})
+	listed := []*lte_protos.LocalSessionInfo{
+		{Sid: &lte_protos.SubscriberID{Id: "IMSI001010000000001"}, Apn: "apn1", RadiusSessionId: "sid1"},
+		{Sid: &lte_protos.SubscriberID{Id: "IMSI001010000000003"}, SessionId: "IMSI001010000000003-1234",
+			Apn: "apn1", MacAddr: "01-23-45-67-89-AB", UeIpv4: "10.0.0.3", RadiusSessionId: "sid3"},
+		{Sid: &lte_protos.SubscriberID{Id: "IMSI001010000000004"}, SessionId: "IMSI001010000000004-5678", Apn: "apn1"},
+	}
+	res, orphans := srv.resync(listed, true)
+	assert.Equal(t, ResyncResult{InSync: 1, Reconciled: 1, Orphaned: 1, Unmanaged: 1}, res)
+	if assert.Len(t, orphans, 1) {
+		assert.Equal(t, "IMSI001010000000004", orphans[0].GetSid().GetId(), "sessions without RADIUS IDs are orphaned")
+	}
+	s := srv.sessions.GetSession("sid3")
+	if assert.NotNil(t, s) {
+		assert.Equal(t, "001010000000003", s.GetCtx().GetImsi())
+		assert.Equal(t, "01-23-45-67-89-AB", s.GetCtx().GetMacAddr())
+		assert.Equal(t, "10.0.0.3", s.GetCtx().GetIpAddr())
+	}
+
+	// the table's sessions aren't counted as unmanaged if a session manager couldn't be listed
+	res, orphans = srv.resync(listed[:1], false)
+	assert.Equal(t, ResyncResult{InSync: 1}, res)
+	assert.Empty(t, orphans)
+}
+
+func TestCreateSessionFailurePolicy(t *testing.T) {
+	policy, err := ParseCreateSessionFailurePolicy("")
+	assert.NoError(t, err)
+	assert.Equal(t, CreateSessionFailureReject, policy)
+	_, err = ParseCreateSessionFailurePolicy("kick")
+	assert.Error(t, err)
+	assert.Equal(t, CreateSessionFailureReject, createSessionFailurePolicy("kick"))
+
+	aaaCtx := &protos.Context{SessionId: "sid1", Imsi: "123456789012345"}
+	srv := newTestAccounting(t, aaaCtx)
+	assert.Equal(t, CreateSessionFailureReject, srv.createFailure)
+	// rejected Starts' sessions are kept
+	srv.createSessionFailed(aaaCtx, status.Errorf(codes.Unavailable, "session manager is unavailable"))
+	assert.NotNil(t, srv.sessions.GetSession("sid1"))
+
+	srv, err = NewAccountingService(
+		store.NewMemorySessionTable(), &mconfig.AAAConfig{CreateSessionFailurePolicy: "disconnect"})
+	assert.NoError(t, err)
+	assert.Equal(t, CreateSessionFailureDisconnect, srv.createFailure)
+	_, err = srv.sessions.AddSession(aaaCtx, time.Minute, nil)
+	assert.NoError(t, err)
+	srv.createSessionFailed(aaaCtx, status.Errorf(codes.Unavailable, "session manager is unavailable"))
+	assert.Nil(t, srv.sessions.GetSession("sid1"))
+}
+
+func TestNASAddressChange(t *testing.T) {
+	srv := newTestAccounting(t, &protos.Context{SessionId: "sid1", Imsi: "123456789012345", Apn: "ap1"})
+	var buf bytes.Buffer
+	srv.audit = audit.NewLogger(&buf)
+	interim := func(addr string) {
+		_, err := srv.InterimUpdate(context.Background(), &protos.UpdateRequest{Ctx: &protos.Context{
+			SessionId: "sid1", Attributes: map[string]string{protos.NASAddressAttribute: addr}}})
+		assert.NoError(t, err)
+	}
+	nasAddress := func() string {
+		addr, _ := srv.sessions.GetSession("sid1").GetCtx().GetAttribute(protos.NASAddressAttribute)
+		return addr
+	}
+	rebinds := func() (res []audit.Event) {
+		dec := json.NewDecoder(bytes.NewReader(buf.Bytes()))
+		for ev := (audit.Event{}); dec.Decode(&ev) == nil; ev = (audit.Event{}) {
+			if ev.Type == audit.Rebind {
+				res = append(res, ev)
+			}
+		}
+		return res
+	}
+
+	// the first address is the session's binding, port changes only update it
+	interim("10.0.0.1:1813")
+	interim("10.0.0.1:32768")
+	assert.Equal(t, "10.0.0.1:32768", nasAddress())
+	assert.Empty(t, rebinds())
+
+	// accounting from another NAS host rebinds the session
+	interim("10.0.0.2:1813")
+	assert.Equal(t, "10.0.0.2:1813", nasAddress())
+	if evs := rebinds(); assert.Len(t, evs, 1) {
+		assert.Equal(t, []audit.Change{{Field: protos.NASAddressAttribute, Old: "10.0.0.1:32768", New: "10.0.0.2:1813"}},
+			evs[0].Changes)
+	}
+}
+
+func TestSessionDuration(t *testing.T) {
+	srv := newTestAccounting(t, &protos.Context{SessionId: "sid1", Imsi: "123456789012345", Apn: "duration_apn"})
+	durations := func() *dto.Histogram {
+		m := &dto.Metric{}
+		assert.NoError(t, metrics.SessionDuration.WithLabelValues("duration_apn").(prometheus.Histogram).Write(m))
+		return m.GetHistogram()
+	}
+	// sessions without accounting Start have no duration
+	srv.forgetSession("sid1", audit.Timeout, srv.sessions.RemoveSession("sid1"))
+	assert.Equal(t, uint64(0), durations().GetSampleCount())
+
+	aaaCtx := &protos.Context{SessionId: "sid2", Imsi: "123456789012345", Apn: "duration_apn"}
+	_, err := srv.sessions.AddSession(aaaCtx, time.Minute, nil)
+	assert.NoError(t, err)
+	start := audit.Now()
+	start.Mono -= 2 * time.Minute
+	srv.starts.starts["sid2"] = start
+	srv.forgetSession("sid2", audit.Stop, srv.sessions.RemoveSession("sid2"))
+	if h := durations(); assert.Equal(t, uint64(1), h.GetSampleCount()) {
+		assert.InDelta(t, 120, h.GetSampleSum(), 1)
+	}
+}
+
+func TestGetServiceInfo(t *testing.T) {
+	srv := newTestAccounting(t)
+	auth, err := adminauth.New(&adminauth.Config{Tokens: []adminauth.TokenGrant{
+		{Name: "viewer", Token: "viewer-token", Role: adminauth.ReadOnly},
+	}})
+	assert.NoError(t, err)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer viewer-token"))
+
+	admin, err := NewSessionAdminService(srv, auth, nil, nil)
+	assert.NoError(t, err)
+	_, err = admin.GetServiceInfo(ctx, &protos.GetServiceInfoRequest{})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	admin, err = NewSessionAdminService(srv, auth, nil, func() *protos.ServiceInfo {
+		return &protos.ServiceInfo{Service: "aaa_server", Version: "1.1.0", ConfigHash: "abc"}
+	})
+	assert.NoError(t, err)
+	_, err = admin.GetServiceInfo(context.Background(), &protos.GetServiceInfoRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	info, err := admin.GetServiceInfo(ctx, &protos.GetServiceInfoRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, "1.1.0", info.GetVersion())
+	assert.Equal(t, "abc", info.GetConfigHash())
+}
+
+func TestAccountingFaults(t *testing.T) {
+	aaaCtx := &protos.Context{SessionId: "sid1", Imsi: "IMSI001010000000001", Apn: "apn1"}
+	srv := newTestAccounting(t, aaaCtx, &protos.Context{SessionId: "sid2", Imsi: "001010000000002", Apn: "apn1"})
+	recorder := &auditRecorder{}
+	srv.AddAuditSink(recorder)
+	auth, err := adminauth.New(&adminauth.Config{Tokens: []adminauth.TokenGrant{
+		{Name: "oncall", Token: "op-token", Role: adminauth.Operator},
+		{Name: "tester", Token: "admin-token", Role: adminauth.Admin},
+	}})
+	assert.NoError(t, err)
+	admin, err := NewSessionAdminService(srv, auth, nil, nil)
+	assert.NoError(t, err)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer admin-token"))
+	inject := func(ctx context.Context, faults *protos.AccountingFaults) (*protos.InjectedAccountingFaults, error) {
+		return admin.InjectAccountingFaults(
+			ctx, &protos.InjectAccountingFaultsRequest{Faults: faults, Operator: "tester"})
+	}
+
+	// fault injection is disabled by default
+	_, err = inject(ctx, &protos.AccountingFaults{Imsi: "001010000000001", DropInterims: 1})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	srv.SetFaultInjector(acctfaults.New([]string{"001010000000001"}))
+	_, err = inject(metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer op-token")),
+		&protos.AccountingFaults{Imsi: "001010000000001", DropInterims: 1})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = inject(ctx, &protos.AccountingFaults{Imsi: "001010000000002", DropInterims: 1})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "faults of real subscribers can't be injected")
+	_, err = admin.InjectAccountingFaults(ctx, &protos.InjectAccountingFaultsRequest{
+		Faults: &protos.AccountingFaults{Imsi: "001010000000001", DropInterims: 1}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "the operator is required")
+
+	injected, err := inject(ctx, &protos.AccountingFaults{
+		Imsi: "IMSI001010000000001", DropInterims: 1, StopDelayMs: 60000, ClockSkewMs: -5000})
+	assert.NoError(t, err)
+	assert.Equal(t, []*protos.AccountingFaults{
+		{Imsi: "001010000000001", DropInterims: 1, StopDelayMs: 60000, ClockSkewMs: -5000},
+	}, injected.GetFaults())
+
+	// the dropped Interim-Update is acknowledged, but not accounted
+	_, err = srv.InterimUpdate(context.Background(), &protos.UpdateRequest{Ctx: aaaCtx, OctetsIn: 100})
+	assert.NoError(t, err)
+	_, ok := srv.usage.get("sid1")
+	assert.False(t, ok)
+
+	// audited events are skewed
+	before := time.Now()
+	srv.auditEvent(audit.Interim, aaaCtx)
+	if assert.Len(t, recorder.events, 1) {
+		assert.True(t, recorder.events[0].Time.Before(before.Add(-4*time.Second)))
+	}
+
+	// the delayed Stop is acknowledged, the session is stopped after the delay
+	_, err = srv.Stop(context.Background(), &protos.StopRequest{Ctx: aaaCtx})
+	assert.NoError(t, err)
+	assert.NotNil(t, srv.sessions.GetSession("sid1"))
+
+	// no faults clear the subscriber's faults
+	injected, err = inject(ctx, &protos.AccountingFaults{Imsi: "001010000000001"})
+	assert.NoError(t, err)
+	assert.Empty(t, injected.GetFaults())
+	srv.auditEvent(audit.Interim, aaaCtx)
+	assert.False(t, recorder.events[1].Time.Before(before))
+}
+
+func TestAcctProxy(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer conn.Close()
+	received := make(chan *radius.Packet, 10)
+	go (&radius.PacketServer{
+		SecretSource: radius.StaticSecretSource([]byte("secret")),
+		Handler: radius.HandlerFunc(func(w radius.ResponseWriter, r *radius.Request) {
+			received <- r.Packet
+			w.Write(r.Response(radius.CodeAccountingResponse))
+		}),
+	}).Serve(conn)
+	proxy, err := acctproxy.New(&acctproxy.Config{Servers: []acctproxy.ServerConfig{
+		{Address: conn.LocalAddr().String(), Secret: "secret", TimeoutMs: 1000, QueueSize: 10}}})
+	assert.NoError(t, err)
+	proxy.Start()
+	defer proxy.Stop()
+
+	aaaCtx := &protos.Context{SessionId: "sid1", Imsi: "123456789012345", Apn: "ap1"}
+	srv := newTestAccounting(t, aaaCtx)
+	srv.SetAcctProxy(proxy)
+	_, err = srv.InterimUpdate(context.Background(), &protos.UpdateRequest{Ctx: aaaCtx, OctetsIn: 100, OctetsOut: 200})
+	assert.NoError(t, err)
+	_, err = srv.Stop(context.Background(), &protos.StopRequest{
+		Ctx: aaaCtx, OctetsIn: 150, OctetsOut: 300, SessionTime: 60, Cause: protos.StopRequest_IDLE_TIMEOUT})
+	assert.NoError(t, err)
+
+	// the upstream server gets the session's accumulated usage
+	for _, expected := range []struct {
+		status    rfc2866.AcctStatusType
+		octetsOut rfc2866.AcctOutputOctets
+	}{
+		{rfc2866.AcctStatusType_Value_InterimUpdate, 200},
+		{rfc2866.AcctStatusType_Value_Stop, 300},
+	} {
+		select {
+		case p := <-received:
+			assert.Equal(t, expected.status, rfc2866.AcctStatusType_Get(p))
+			assert.Equal(t, "sid1", rfc2866.AcctSessionID_GetString(p))
+			assert.Equal(t, expected.octetsOut, rfc2866.AcctOutputOctets_Get(p))
+		case <-time.After(5 * time.Second):
+			assert.Fail(t, "the accounting request wasn't mirrored")
+			return
+		}
+	}
+}
+
+type nakAuthorizationClient struct {
+	protos.AuthorizationClient
+}
+
+func (nakAuthorizationClient) Change(
+	context.Context, *protos.ChangeRequest, ...grpc.CallOption) (*protos.CoaResponse, error) {
+	return &protos.CoaResponse{CoaResponseType: protos.CoaResponse_NAK}, nil
+}
+
+func (nakAuthorizationClient) Disconnect(
+	context.Context, *protos.DisconnectRequest, ...grpc.CallOption) (*protos.CoaResponse, error) {
+	return nil, status.Errorf(codes.DeadlineExceeded, "NAS didn't respond")
+}
+
+func TestCoALog(t *testing.T) {
+	aaaCtx := &protos.Context{SessionId: "sid1", Imsi: "IMSI001010000000001", Apn: "apn1"}
+	srv := newTestAccounting(t, aaaCtx)
+	recorder := &auditRecorder{}
+	srv.AddAuditSink(recorder)
+	auth, err := adminauth.New(&adminauth.Config{Tokens: []adminauth.TokenGrant{
+		{Name: "viewer", Token: "ro-token", Role: adminauth.ReadOnly},
+	}})
+	assert.NoError(t, err)
+	admin, err := NewSessionAdminService(srv, auth, nil, nil)
+	assert.NoError(t, err)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer ro-token"))
+	radcli := authorizationClient{AuthorizationClient: nakAuthorizationClient{}, srv: srv}
+
+	// the log is disabled by default
+	_, err = radcli.Change(context.Background(), &protos.ChangeRequest{Ctx: aaaCtx, MaxBandwidthUp: 1000})
+	assert.NoError(t, err)
+	_, err = admin.ListCoaTransactions(ctx, &protos.ListCoaTransactionsRequest{})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.Empty(t, recorder.events)
+
+	srv.SetCoALog(coalog.New(10))
+	bg := coalog.WithTrigger(context.Background(), "time_policy", "night")
+	_, err = radcli.Change(bg, &protos.ChangeRequest{Ctx: aaaCtx, MaxBandwidthUp: 1000, MaxBandwidthDown: 2000})
+	assert.NoError(t, err)
+	_, err = radcli.Change(bg, &protos.ChangeRequest{Ctx: aaaCtx, MaxBandwidthUp: 1000, MaxBandwidthDown: 2000})
+	assert.NoError(t, err)
+	_, err = radcli.Disconnect(context.Background(), &protos.DisconnectRequest{Ctx: aaaCtx})
+	assert.Error(t, err)
+
+	list, err := admin.ListCoaTransactions(ctx, &protos.ListCoaTransactionsRequest{Imsi: "001010000000001"})
+	assert.NoError(t, err)
+	if assert.Len(t, list.GetTransactions(), 3) {
+		disconnect, retry, first := list.Transactions[0], list.Transactions[1], list.Transactions[2]
+		assert.Equal(t, "disconnect", disconnect.GetKind())
+		assert.Equal(t, coalog.ResultError, disconnect.GetResult())
+		assert.Equal(t, "time_policy", first.GetTrigger())
+		assert.Equal(t, "night", first.GetTriggerDetail())
+		assert.Equal(t, coalog.ResultNAK, first.GetResult())
+		assert.Equal(t, []*protos.CoaAttribute{
+			{Name: "max_bandwidth_down", Value: "2000"}, {Name: "max_bandwidth_up", Value: "1000"},
+		}, first.GetAttributes())
+		assert.Equal(t, uint32(1), first.GetAttempt())
+		assert.Equal(t, uint32(2), retry.GetAttempt(), "the rejected CoA is retried")
+	}
+	list, err = admin.ListCoaTransactions(ctx, &protos.ListCoaTransactionsRequest{Trigger: "time_policy", Limit: 1})
+	assert.NoError(t, err)
+	assert.Len(t, list.GetTransactions(), 1)
+
+	// the transactions are audited
+	if assert.Len(t, recorder.events, 3) {
+		assert.Equal(t, audit.CoA, recorder.events[0].Type)
+		assert.Equal(t, "time_policy", recorder.events[0].Reason)
+		assert.Equal(t, coalog.ResultNAK, recorder.events[0].CoA.Result)
+	}
+}
+
+func TestCorrelatedAccounting(t *testing.T) {
+	ctx1 := &protos.Context{SessionId: "ap1__ue1", Imsi: "123456789012345", MacAddr: "0a:1b:2c:3d:4e:01"}
+	ctx2 := &protos.Context{SessionId: "ap1__ue2", Imsi: "123456789012346", MacAddr: "0a:1b:2c:3d:4e:02"}
+	ctx3 := &protos.Context{SessionId: "ap1__ue3", Imsi: "123456789012347", MacAddr: "0a:1b:2c:3d:4e:03"}
+	srv := newTestAccounting(t, ctx1, ctx2, ctx3)
+	assert.NoError(t, aaa.AddStandardIndexes(srv.sessions))
+
+	// started sessions are indexed by their correlation IDs
+	srv.correlate(srv.sessions.GetSession("ap1__ue2"), &protos.Context{CorrelationId: "ess1-ue2"})
+	srv.correlate(srv.sessions.GetSession("ap1__ue3"), &protos.Context{CorrelationId: "ess1-ue2"})
+	assert.Len(t, aaa.FindSessions(srv.sessions, aaa.CorrelationIndex, "ess1-ue2"), 2)
+	assert.Empty(t, ctx2.GetCorrelationId(), "the session's previous context is not modified")
+
+	// accounting of a roam is resolved by the session ID of its correlation Class
+	roamed := &protos.Context{SessionId: "ap2__ue1", CorrelationId: "ap1__ue1"}
+	_, err := srv.InterimUpdate(context.Background(), &protos.UpdateRequest{OctetsIn: 100, Ctx: roamed})
+	assert.NoError(t, err)
+	u, _ := srv.usage.get("ap1__ue1")
+	assert.Equal(t, uint64(100), u.octetsIn)
+
+	// sessions sharing a correlation ID are told apart by their MAC addresses
+	roamed = &protos.Context{SessionId: "ap2__ue2", CorrelationId: "ess1-ue2"}
+	_, err = srv.InterimUpdate(context.Background(), &protos.UpdateRequest{OctetsIn: 100, Ctx: roamed})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	roamed.MacAddr = "0A-1B-2C-3D-4E-02"
+	_, err = srv.InterimUpdate(context.Background(), &protos.UpdateRequest{OctetsIn: 200, Ctx: roamed})
+	assert.NoError(t, err)
+	u, _ = srv.usage.get("ap1__ue2")
+	assert.Equal(t, uint64(200), u.octetsIn)
+
+	// unknown correlation IDs aren't resolved
+	_, err = srv.InterimUpdate(context.Background(),
+		&protos.UpdateRequest{Ctx: &protos.Context{SessionId: "ap2__ue4", CorrelationId: "ess1-ue4"}})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// the roam's Stop ends the correlated session, its retransmissions are duplicates
+	roamed = &protos.Context{SessionId: "ap2__ue1", CorrelationId: "ap1__ue1"}
+	_, err = srv.Stop(context.Background(), &protos.StopRequest{Ctx: roamed})
+	assert.NoError(t, err)
+	assert.Nil(t, srv.sessions.GetSession("ap1__ue1"))
+	_, err = srv.Stop(context.Background(), &protos.StopRequest{Ctx: roamed})
+	assert.NoError(t, err)
+}
+
+type disconnectClient struct {
+	protos.AuthorizationClient
+	err error
+}
+
+func (c *disconnectClient) Disconnect(
+	context.Context, *protos.DisconnectRequest, ...grpc.CallOption) (*protos.CoaResponse, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &protos.CoaResponse{CoaResponseType: protos.CoaResponse_ACK}, nil
+}
+
+func TestPendingDisconnects(t *testing.T) {
+	nas1 := map[string]string{protos.NASAddressAttribute: "10.0.0.1:3799"}
+	zombie := &protos.Context{SessionId: "sid1", Imsi: "123456789012345", Attributes: nas1}
+	srv := newTestAccounting(t)
+	fake := &disconnectClient{err: status.Errorf(codes.DeadlineExceeded, "NAS didn't respond")}
+	radcli := authorizationClient{AuthorizationClient: fake, srv: srv}
+
+	// failed Disconnects are pending by their NASes, Disconnects of sessions of unknown NASes aren't tracked
+	_, err := radcli.Disconnect(context.Background(), &protos.DisconnectRequest{Ctx: zombie})
+	assert.Error(t, err)
+	_, err = radcli.Disconnect(context.Background(), &protos.DisconnectRequest{Ctx: &protos.Context{SessionId: "sid2"}})
+	assert.Error(t, err)
+	assert.Len(t, srv.disconnects.byNAS, 1)
+	assert.Len(t, srv.disconnects.byNAS["10.0.0.1"], 1)
+
+	// failed retries are pending again until their last attempt
+	pending := srv.disconnects.take("10.0.0.1")
+	if assert.Len(t, pending, 1) {
+		srv.retryDisconnect(radcli, pending[0])
+	}
+	pending = srv.disconnects.take("10.0.0.1")
+	if assert.Len(t, pending, 1) {
+		assert.Equal(t, 2, pending[0].attempts)
+		pending[0].attempts = maxDisconnectAttempts - 1
+		srv.retryDisconnect(radcli, pending[0])
+	}
+	assert.Empty(t, srv.disconnects.take("10.0.0.1"))
+
+	// delivered retries aren't pending anymore
+	_, err = radcli.Disconnect(context.Background(), &protos.DisconnectRequest{Ctx: zombie})
+	assert.Error(t, err)
+	fake.err = nil
+	pending = srv.disconnects.take("10.0.0.1")
+	if assert.Len(t, pending, 1) {
+		srv.retryDisconnect(radcli, pending[0])
+	}
+	assert.Empty(t, srv.disconnects.byNAS)
+
+	// Disconnects of sessions established again aren't retried
+	fake.err = status.Errorf(codes.DeadlineExceeded, "NAS didn't respond")
+	_, err = radcli.Disconnect(context.Background(), &protos.DisconnectRequest{Ctx: zombie})
+	assert.Error(t, err)
+	_, err = srv.sessions.AddSession(zombie, time.Minute, nil)
+	assert.NoError(t, err)
+	fake.err = nil
+	pending = srv.disconnects.take("10.0.0.1")
+	if assert.Len(t, pending, 1) {
+		srv.retryDisconnect(radcli, pending[0])
+	}
+	assert.Empty(t, srv.disconnects.byNAS)
+
+	// the NAS's Stop of the zombie session clears its pending Disconnect
+	fake.err = status.Errorf(codes.DeadlineExceeded, "NAS didn't respond")
+	_, err = radcli.Disconnect(context.Background(), &protos.DisconnectRequest{Ctx: zombie})
+	assert.Error(t, err)
+	srv.disconnects.remove(&protos.Context{SessionId: "sid1", Attributes: map[string]string{
+		protos.NASAddressAttribute: "10.0.0.1:41000"}})
+	assert.Empty(t, srv.disconnects.byNAS)
+}
+
+func TestQuotaEnforcement(t *testing.T) {
+	sessions := []*protos.Context{
+		{SessionId: "sid1", Imsi: "123456789012345"},
+		{SessionId: "sid2", Imsi: "123456789012346", Attributes: map[string]string{
+			quotaRedirectAttribute: "http://portal.example.com/topup"}},
+		{SessionId: "sid3", Imsi: "123456789012347"},
+		{SessionId: "sid4", Imsi: "123456789012348"},
+	}
+	srv := newTestAccounting(t, sessions...)
+
+	// sessions with available quotas & sessions of unreported usage are left alone
+	srv.enforceQuota(srv.sessions.GetSession("sid1"), nil)
+	srv.enforceQuota(srv.sessions.GetSession("sid1"), &lte_protos.LocalSessionUsageResponse{})
+	assert.NotNil(t, srv.sessions.GetSession("sid1"))
+
+	// sessions are redirected once
+	redirect := &lte_protos.LocalSessionUsageResponse{
+		QuotaExhausted: true,
+		FinalAction:    lte_protos.ChargingCredit_REDIRECT,
+		RedirectServer: &lte_protos.RedirectServer{
+			RedirectAddressType:   lte_protos.RedirectServer_URL,
+			RedirectServerAddress: "http://portal.example.com/topup",
+		},
+	}
+	srv.enforceQuota(srv.sessions.GetSession("sid2"), redirect)
+	assert.NotNil(t, srv.sessions.GetSession("sid2"))
+
+	// sessions which can't be redirected & sessions of other final unit actions are terminated
+	srv.enforceQuota(srv.sessions.GetSession("sid3"), &lte_protos.LocalSessionUsageResponse{
+		QuotaExhausted: true,
+		FinalAction:    lte_protos.ChargingCredit_REDIRECT,
+		RedirectServer: &lte_protos.RedirectServer{
+			RedirectAddressType:   lte_protos.RedirectServer_SIP_URI,
+			RedirectServerAddress: "sip:topup@example.com",
+		},
+	})
+	assert.Nil(t, srv.sessions.GetSession("sid3"))
+	srv.enforceQuota(srv.sessions.GetSession("sid4"), &lte_protos.LocalSessionUsageResponse{
+		QuotaExhausted: true, FinalAction: lte_protos.ChargingCredit_RESTRICT_ACCESS})
+	assert.Nil(t, srv.sessions.GetSession("sid4"))
+}
+
+func TestRedirectURL(t *testing.T) {
+	assert.Equal(t, "http://portal.example.com/topup", redirectURL(&lte_protos.RedirectServer{
+		RedirectAddressType: lte_protos.RedirectServer_URL, RedirectServerAddress: "http://portal.example.com/topup"}))
+	assert.Equal(t, "http://10.0.0.1/", redirectURL(&lte_protos.RedirectServer{
+		RedirectAddressType: lte_protos.RedirectServer_IPV4, RedirectServerAddress: "10.0.0.1"}))
+	assert.Equal(t, "http://[2001:db8::1]/", redirectURL(&lte_protos.RedirectServer{
+		RedirectAddressType: lte_protos.RedirectServer_IPV6, RedirectServerAddress: "2001:db8::1"}))
+	assert.Empty(t, redirectURL(&lte_protos.RedirectServer{
+		RedirectAddressType: lte_protos.RedirectServer_SIP_URI, RedirectServerAddress: "sip:topup@example.com"}))
+	assert.Empty(t, redirectURL(nil))
+}
+
+func TestLateAcctRequests(t *testing.T) {
+	srv := newTestAccounting(t,
+		&protos.Context{SessionId: "sid1", Imsi: "123456789012345", Apn: "late_apn"},
+		&protos.Context{SessionId: "sid2", Imsi: "123456789012346", Apn: "late_apn"})
+	late := func(status, reason string) float64 {
+		m := &dto.Metric{}
+		assert.NoError(t, metrics.LateAcctRequests.WithLabelValues(status, reason).Write(m))
+		return m.GetCounter().GetValue()
+	}
+	interims, stops := late(lateInterim, string(audit.Terminate)), late(lateStop, string(audit.Terminate))
+
+	// the late Interim-Updates & Stop of the terminated session are acknowledged & counted
+	srv.forgetSession("sid1", audit.Terminate, srv.sessions.RemoveSession("sid1"))
+	_, err := srv.InterimUpdate(context.Background(),
+		&protos.UpdateRequest{OctetsIn: 100, Ctx: &protos.Context{SessionId: "sid1"}})
+	assert.NoError(t, err)
+	_, err = srv.Stop(context.Background(), &protos.StopRequest{Ctx: &protos.Context{SessionId: "sid1"}})
+	assert.NoError(t, err)
+	assert.Equal(t, interims+1, late(lateInterim, string(audit.Terminate)))
+	assert.Equal(t, stops+1, late(lateStop, string(audit.Terminate)))
+	_, ok := srv.usage.get("sid1")
+	assert.False(t, ok, "the late Interim-Update's usage isn't accounted")
+
+	// unknown sessions still fail
+	_, err = srv.InterimUpdate(context.Background(), &protos.UpdateRequest{Ctx: &protos.Context{SessionId: "sid3"}})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// sessions terminated past the linger time aren't remembered
+	srv.SetTerminatedLinger(time.Millisecond)
+	srv.forgetSession("sid2", audit.Timeout, srv.sessions.RemoveSession("sid2"))
+	time.Sleep(2 * time.Millisecond)
+	_, err = srv.InterimUpdate(context.Background(), &protos.UpdateRequest{Ctx: &protos.Context{SessionId: "sid2"}})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestAPNOverrides(t *testing.T) {
+	srv, err := NewAccountingService(store.NewMemorySessionTable(), &mconfig.AAAConfig{
+		IdleSessionTimeoutMs:    3600000,
+		AccountingEnabled:       true,
+		MaxSessionDurationMs:    3600000,
+		ApnMaxSessionDurationMs: map[string]uint32{"venue.ssid": 600000},
+		ApnOverrides: map[string]*mconfig.AAAConfig_ApnOverride{
+			"Venue.SSID": {IdleSessionTimeoutMs: 60000, MaxSessionDurationMs: 120000, Accounting: "disabled"},
+			"onauth":     {CreateSessionOnAuth: "enabled"},
+			"invalid":    {Accounting: "maybe"},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, apnSettings{idleTimeout: time.Hour, accountingEnabled: true}, srv.apns.get("internet"))
+	assert.Equal(t, apnSettings{idleTimeout: time.Minute}, srv.apns.get("venue.ssid"))
+	assert.Equal(t,
+		apnSettings{idleTimeout: time.Hour, accountingEnabled: true, createSessionOnAuth: true}, srv.apns.get("onauth"))
+	assert.Equal(t, srv.apns.get("internet"), srv.apns.get("invalid"), "invalid overrides are ignored")
+	assert.Equal(t, 2*time.Minute, srv.lifetimes.duration("venue.ssid"), "overrides' durations take precedence")
+	assert.Equal(t, time.Hour, srv.lifetimes.duration("onauth"))
+
+	// the Start of a session of an APN without accounting isn't created in session manager & gets its idle timeout
+	_, err = srv.sessions.AddSession(
+		&protos.Context{SessionId: "sid1", Imsi: "123456789012345", Apn: "venue.ssid"}, time.Hour, nil)
+	assert.NoError(t, err)
+	_, err = srv.Start(context.Background(), &protos.Context{SessionId: "sid1", Imsi: "123456789012345"})
+	assert.NoError(t, err)
+	sessions := srv.sessions.(aaa.SessionLister).ListSessions()
+	if assert.Len(t, sessions, 1) {
+		assert.Equal(t, time.Minute, sessions[0].Timeout)
+	}
+}
+
+func TestRateLimits(t *testing.T) {
+	aaaCtx := &protos.Context{SessionId: "sid1", Imsi: "123456789012345", Apn: "apn1", MacAddr: "0A-1B-2C-3D-4E-5F"}
+	srv := newTestAccounting(t, aaaCtx)
+	srv.SetRateLimits(ratelimit.New(1, 2), ratelimit.New(1, 1))
+
+	// the IMSI's burst is exhausted first, the error carries the Retry-After hint
+	_, err := srv.Start(context.Background(), aaaCtx)
+	assert.NoError(t, err)
+	_, err = srv.InterimUpdate(context.Background(), &protos.UpdateRequest{Ctx: aaaCtx})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	if rle := protos.GetRateLimitError(err); assert.NotNil(t, rle) {
+		assert.Equal(t, "imsi", rle.GetKey())
+		assert.Equal(t, "123456789012345", rle.GetValue())
+		assert.True(t, rle.GetRetryAfterMs() > 0 && rle.GetRetryAfterMs() <= 1000)
+	}
+
+	// MAC addresses are limited in their normalized notation, requests of other keys aren't limited
+	err = srv.throttle("Stop", &protos.Context{SessionId: "sid2", MacAddr: "0a:1b:2c:3d:4e:5f"})
+	assert.Equal(t, "mac", protos.GetRateLimitError(err).GetKey())
+	assert.NoError(t, srv.throttle("Stop", &protos.Context{SessionId: "sid3", MacAddr: "0a:1b:2c:3d:4e:60"}))
+	assert.NoError(t, srv.throttle("Stop", &protos.Context{SessionId: "sid4"}))
+
+	srv.SetRateLimits(nil, nil)
+	assert.NoError(t, srv.throttle("Stop", aaaCtx))
+}
+
+func TestAPNAliases(t *testing.T) {
+	acct := newTestAccounting(t)
+	cfg := &mconfig.AAAConfig{MacAuthBypass: true}
+	auth, err := NewEapAuthenticator(acct.sessions, cfg, acct)
+	assert.NoError(t, err)
+	acct.SetMACAllowList(testAllowList{
+		"0a:1b:2c:3d:4e:5f": {MAC: "0a:1b:2c:3d:4e:5f", IMSI: "001010000000001"},
+	})
+	acct.SetAPNAliases(&apnalias.Config{Aliases: map[string]string{"venue guest": "magma.wifi"}})
+	recorder := &auditRecorder{}
+	acct.AddAuditSink(recorder)
+
+	// the session's APN is the canonical APN of its SSID, its AP is still known by its original Called-Station-Id
+	resp, err := auth.MacAuthBypass(context.Background(),
+		&protos.Context{SessionId: "sid1", MacAddr: "0a:1b:2c:3d:4e:5f", Apn: "00-11-22-AA-BB-CC:Venue Guest"})
+	assert.NoError(t, err)
+	assert.Equal(t, "magma.wifi", resp.GetApn())
+	sessionCtx := acct.sessions.GetSession("sid1").GetCtx()
+	assert.Equal(t, "magma.wifi", sessionCtx.GetApn())
+	assert.Equal(t, "00-11-22-AA-BB-CC:Venue Guest", sessionCtx.CalledStationID())
+	ap, _ := protos.APMAC(sessionCtx.CalledStationID())
+	assert.Equal(t, "001122AABBCC", ap)
+	if assert.Len(t, recorder.events, 1) {
+		assert.Equal(t, "magma.wifi", recorder.events[0].Apn)
+		assert.Equal(t, "00-11-22-AA-BB-CC:Venue Guest", recorder.events[0].CalledStationId)
+	}
+
+	// accounting requests' contexts are aliased as well, APNs without alias are kept
+	aaaCtx := &protos.Context{SessionId: "sid1", Apn: "00-11-22-AA-BB-CC:Venue Guest"}
+	acct.aliasAPN(aaaCtx)
+	assert.Equal(t, "magma.wifi", aaaCtx.GetApn())
+	aaaCtx = &protos.Context{SessionId: "sid2", Apn: "00-11-22-AA-BB-CC:Staff"}
+	acct.aliasAPN(aaaCtx)
+	assert.Equal(t, "00-11-22-AA-BB-CC:Staff", aaaCtx.GetApn())
+	assert.Empty(t, aaaCtx.GetAttributes())
+}
@@ -0,0 +1,133 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// package servcers implements WiFi AAA GRPC services
+package servicers
+
+import (
+	"log"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"magma/feg/gateway/services/aaa/acctfaults"
+	"magma/feg/gateway/services/aaa/adminauth"
+	"magma/feg/gateway/services/aaa/audit"
+	"magma/feg/gateway/services/aaa/deadlines"
+	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/aaa/panics"
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+// Injected accounting faults
+const (
+	faultInterimDropped = "interim_dropped"
+	faultStopDelayed    = "stop_delayed"
+	faultClockSkewed    = "clock_skewed"
+)
+
+// SetFaultInjector enables injection of accounting faults of synthetic test subscribers' sessions by the session admin
+// API, nil disables it
+func (srv *accountingService) SetFaultInjector(i *acctfaults.Injector) {
+	srv.faults = i
+}
+
+// dropInterim returns true if the session's Interim-Update is dropped by an injected fault
+func (srv *accountingService) dropInterim(aaaCtx *protos.Context) bool {
+	if !srv.faults.DropInterim(aaaCtx.GetImsi()) {
+		return false
+	}
+	metrics.InjectedAcctFaults.WithLabelValues(faultInterimDropped).Inc()
+	log.Printf("Injected fault: Interim-Update of session %s is dropped", aaaCtx.GetSessionId())
+	return true
+}
+
+// delayStop accounts the session's Stop after the injected delay of its subscriber in background & returns true, or
+// returns false if the Stop isn't delayed
+func (srv *accountingService) delayStop(req *protos.StopRequest) bool {
+	if srv.faults == nil {
+		return false
+	}
+	sid := req.GetCtx().GetSessionId()
+	s := srv.sessions.GetSession(sid)
+	if s == nil {
+		return false
+	}
+	delay := srv.faults.StopDelay(s.GetCtx().GetImsi())
+	if delay <= 0 {
+		return false
+	}
+	metrics.InjectedAcctFaults.WithLabelValues(faultStopDelayed).Inc()
+	log.Printf("Injected fault: Accounting Stop of session %s is delayed by %v", sid, delay)
+	time.AfterFunc(delay, func() {
+		defer panics.Recover("delayed_stop")
+		ctx, cancel := context.WithTimeout(context.Background(), deadlines.GetDefaultTimeout())
+		defer cancel()
+		if _, err := srv.stop(ctx, req); err != nil {
+			log.Printf("Delayed Accounting Stop of session %s failed: %v", sid, err)
+		}
+	})
+	return true
+}
+
+// skewClock offsets the audited event's times by the injected clock skew of the event's subscriber
+func (srv *accountingService) skewClock(ev *audit.Event) {
+	skew := srv.faults.ClockSkew(ev.Imsi)
+	if skew == 0 {
+		return
+	}
+	metrics.InjectedAcctFaults.WithLabelValues(faultClockSkewed).Inc()
+	ev.Time = ev.Time.Add(skew)
+	if ev.SessionStart != nil {
+		start := ev.SessionStart.Add(skew)
+		ev.SessionStart = &start
+	}
+}
+
+// InjectAccountingFaults sets the simulated accounting faults of a synthetic test subscriber's sessions & returns all
+// injected faults
+func (srv *sessionAdminService) InjectAccountingFaults(
+	ctx context.Context, req *protos.InjectAccountingFaultsRequest) (*protos.InjectedAccountingFaults, error) {
+
+	if err := srv.authorize(ctx, "InjectAccountingFaults", adminauth.Admin); err != nil {
+		return nil, err
+	}
+	if srv.acct.faults == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "Accounting fault injection is disabled")
+	}
+	if req.GetFaults() == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Missing accounting faults")
+	}
+	if len(req.GetOperator()) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "Missing operator")
+	}
+	f := req.GetFaults()
+	faults := acctfaults.Faults{
+		DropInterims: f.GetDropInterims(),
+		StopDelay:    time.Duration(f.GetStopDelayMs()) * time.Millisecond,
+		ClockSkew:    time.Duration(f.GetClockSkewMs()) * time.Millisecond,
+	}
+	if err := srv.acct.faults.Set(f.GetImsi(), faults); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid accounting faults: %v", err)
+	}
+	log.Printf("Accounting faults %+v of IMSI %s are injected by %s", faults, f.GetImsi(), req.GetOperator())
+
+	res := &protos.InjectedAccountingFaults{}
+	imsis, injected := srv.acct.faults.Faults()
+	for i, imsi := range imsis {
+		res.Faults = append(res.Faults, &protos.AccountingFaults{
+			Imsi:         imsi,
+			DropInterims: injected[i].DropInterims,
+			StopDelayMs:  uint32(injected[i].StopDelay / time.Millisecond),
+			ClockSkewMs:  int64(injected[i].ClockSkew / time.Millisecond),
+		})
+	}
+	return res, nil
+}
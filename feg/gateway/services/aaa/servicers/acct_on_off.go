@@ -0,0 +1,114 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSDstyle license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// package servcers implements WiFi AAA GRPC services
+package servicers
+
+import (
+	"log"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"magma/feg/gateway/services/aaa"
+	"magma/feg/gateway/services/aaa/audit"
+	"magma/feg/gateway/services/aaa/deadlines"
+	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/aaa/panics"
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+// Accounting-On/Off statuses
+const (
+	acctOn  = "on"
+	acctOff = "off"
+)
+
+// AcctOnOff handles the NAS's Accounting-On or Accounting-Off: the NAS (re)started or is going down, so all its
+// sessions are gone. The sessions of the NAS's NAS-Identifier or AP MAC are removed & ended in session manager
+// instead of lingering until their idle timeout
+func (srv *accountingService) AcctOnOff(ctx context.Context, req *protos.AcctOnOffRequest) (*protos.AcctResp, error) {
+	if req == nil {
+		return &protos.AcctResp{}, status.Errorf(codes.InvalidArgument, "Nil Accounting On/Off Request")
+	}
+	nasID := req.GetNasIdentifier()
+	mac, macOK := protos.APMAC(req.GetCalledStationId())
+	if len(nasID) == 0 && !macOK {
+		return &protos.AcctResp{}, status.Errorf(
+			codes.InvalidArgument, "Accounting On/Off: missing NAS-Identifier & Called-Station-Id AP MAC")
+	}
+	lister, ok := srv.sessions.(aaa.SessionLister)
+	if !ok {
+		return &protos.AcctResp{}, status.Errorf(codes.Unimplemented, "Sessions table can't list its sessions")
+	}
+	acctStatus := acctOn
+	if req.GetOff() {
+		acctStatus = acctOff
+	}
+
+	var flushed, superseded []*protos.Context
+	for _, st := range lister.ListSessions() {
+		aaaCtx := st.Session.GetCtx()
+		if !sameNAS(aaaCtx, nasID, mac, macOK) {
+			continue
+		}
+		// superseded sessions are determined before any removal, their subscribers' next sessions may be flushed too
+		if srv.isSuperseded(aaaCtx) {
+			superseded = append(superseded, aaaCtx)
+		} else {
+			flushed = append(flushed, aaaCtx)
+		}
+	}
+	for _, aaaCtx := range append(flushed, superseded...) {
+		sid := aaaCtx.GetSessionId()
+		srv.forgetSession(sid, audit.Flush, srv.sessions.RemoveSession(sid))
+		srv.stopped(aaaCtx)
+	}
+	count := len(flushed) + len(superseded)
+	metrics.FlushedSessions.WithLabelValues(acctStatus).Add(float64(count))
+	log.Printf("Accounting-%s of NAS '%s' (Called-Station-Id: '%s'): flushed %d sessions",
+		acctStatus, nasID, req.GetCalledStationId(), count)
+
+	if len(flushed) > 0 {
+		go func() {
+			defer panics.Recover("acct_on_off_flush")
+			for _, aaaCtx := range flushed {
+				if srv.settings(aaaCtx).accountingEnabled {
+					srv.endFlushedSession(aaaCtx)
+				}
+			}
+		}()
+	}
+	return &protos.AcctResp{}, nil
+}
+
+// endFlushedSession ends the flushed session in session manager, each session gets its own deadline so a slow
+// session manager doesn't fail the NAS's remaining sessions
+func (srv *accountingService) endFlushedSession(aaaCtx *protos.Context) {
+	ctx, cancel := deadlines.Background()
+	defer cancel()
+	if err := srv.endManagedSession(ctx, aaaCtx); err != nil {
+		log.Printf("Error ending flushed session %s in session manager: %v", aaaCtx.GetSessionId(), err)
+	}
+}
+
+// sameNAS returns true if the session belongs to the NAS: its NAS-Identifier or its Called-Station-Id's AP MAC match
+func sameNAS(aaaCtx *protos.Context, nasID string, mac string, macOK bool) bool {
+	if len(nasID) > 0 {
+		if id, ok := aaaCtx.GetAttribute(protos.NASIdentifierAttribute); ok && id == nasID {
+			return true
+		}
+	}
+	if macOK {
+		if sessionMAC, ok := protos.APMAC(aaaCtx.CalledStationID()); ok && sessionMAC == mac {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// package servcers implements WiFi AAA GRPC services
+package servicers
+
+import (
+	"time"
+
+	"fbc/lib/go/radius/rfc2866"
+
+	"magma/feg/gateway/services/aaa/acctproxy"
+	"magma/feg/gateway/services/aaa/audit"
+	"magma/feg/gateway/services/aaa/protos"
+	lte_protos "magma/lte/cloud/go/protos"
+)
+
+// SetAcctProxy enables mirroring of the sessions' accounting to upstream accounting servers, nil disables it
+func (srv *accountingService) SetAcctProxy(p *acctproxy.Proxy) {
+	srv.acctProxy = p
+}
+
+// proxyStart mirrors the session's Accounting Start
+func (srv *accountingService) proxyStart(aaaCtx *protos.Context) {
+	if srv.acctProxy != nil {
+		srv.acctProxy.Forward(acctproxy.NewRequest(rfc2866.AcctStatusType_Value_Start, aaaCtx))
+	}
+}
+
+// proxyInterim mirrors the session's Interim-Update with the session's accumulated usage & duration
+func (srv *accountingService) proxyInterim(aaaCtx *protos.Context, usage localUsage) {
+	if srv.acctProxy == nil {
+		return
+	}
+	req := acctproxy.NewRequest(rfc2866.AcctStatusType_Value_InterimUpdate, aaaCtx)
+	req.OctetsIn, req.OctetsOut = usage.octetsIn, usage.octetsOut
+	req.PacketsIn, req.PacketsOut = uint32(usage.packetsIn), uint32(usage.packetsOut)
+	if start := srv.starts.get(aaaCtx.GetSessionId()); !start.IsZero() {
+		req.SessionTime = uint32(audit.Now().Sub(start) / time.Second)
+	}
+	srv.acctProxy.Forward(req)
+}
+
+// proxyStop mirrors the session's Accounting Stop with the session's final usage, if known, & the NAS's termination
+// cause & duration
+func (srv *accountingService) proxyStop(
+	aaaCtx *protos.Context, stop *protos.StopRequest, final *lte_protos.LocalEndSessionRequest) {
+
+	if srv.acctProxy == nil {
+		return
+	}
+	req := acctproxy.NewRequest(rfc2866.AcctStatusType_Value_Stop, aaaCtx)
+	usage := final.GetFinalUsage()
+	req.OctetsIn, req.OctetsOut = usage.GetOctetsIn(), usage.GetOctetsOut()
+	req.PacketsIn, req.PacketsOut = uint32(usage.GetPacketsIn()), uint32(usage.GetPacketsOut())
+	req.SessionTime = stop.GetSessionTime()
+	// termination causes of the stop request are the RFC 2866 Acct-Terminate-Cause values
+	req.TerminateCause = rfc2866.AcctTerminateCause(stop.GetCause())
+	srv.acctProxy.Forward(req)
+}
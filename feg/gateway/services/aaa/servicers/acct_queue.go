@@ -0,0 +1,221 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// package servcers implements WiFi AAA GRPC services
+package servicers
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"fbc/lib/go/retry"
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"magma/feg/gateway/services/aaa/acctqueue"
+	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/aaa/protos"
+	"magma/feg/gateway/services/aaa/session_manager"
+	lte_protos "magma/lte/cloud/go/protos"
+)
+
+// Accounting queue items' results
+const (
+	acctItemQueued   = "queued"
+	acctItemFull     = "full"
+	acctItemReplayed = "replayed"
+	acctItemDropped  = "dropped"
+)
+
+// idempotencyKeyHeader - outgoing GRPC metadata key of replayed calls' idempotency keys, session manager answers
+// the replays of calls it's already handling or has handled without applying them again
+const idempotencyKeyHeader = "x-idempotency-key"
+
+// SetAcctQueue enables queueing of Accounting Starts' & Stops' session manager calls failed while the session
+// manager is unavailable, queued calls are acknowledged to the NAS & replayed by RunAcctQueue. Nil disables it
+func (srv *accountingService) SetAcctQueue(q *acctqueue.Queue) {
+	srv.acctQueue = q
+	if q != nil {
+		metrics.AcctQueueLength.Set(float64(q.Len()))
+	}
+}
+
+// RunAcctQueue replays the queued session manager calls every interval, it never returns
+func (srv *accountingService) RunAcctQueue(interval time.Duration) {
+	if interval <= 0 {
+		interval = acctqueue.DefaultReplayInterval
+	}
+	for range time.Tick(interval) {
+		if srv.acctQueue.Len() == 0 {
+			continue
+		}
+		sent, err := srv.acctQueue.Replay(srv.replayAcct)
+		metrics.AcctQueueLength.Set(float64(srv.acctQueue.Len()))
+		if err != nil {
+			log.Printf("Accounting queue replay stopped after %d calls, %d calls are left: %v",
+				sent, srv.acctQueue.Len(), err)
+		}
+	}
+}
+
+// createManagedSession creates the session in session manager, the call is queued if session manager is unavailable
+// or the subscriber has queued calls. The response of a queued call is empty
+func (srv *accountingService) createManagedSession(ctx context.Context, aaaCtx *protos.Context,
+	req *lte_protos.LocalCreateSessionRequest) (*lte_protos.LocalCreateSessionResponse, error) {
+
+	if srv.acctQueue != nil && srv.acctQueue.Pending(req.GetSid().GetId()) {
+		return &lte_protos.LocalCreateSessionResponse{}, srv.queueAcct(acctqueue.Start, aaaCtx, req, nil)
+	}
+	res, err := session_manager.CreateSession(ctx, aaaCtx.GetApn(), req)
+	if srv.acctQueue != nil && sessionManagerUnavailable(err) {
+		return &lte_protos.LocalCreateSessionResponse{}, srv.queueAcct(acctqueue.Start, aaaCtx, req, err)
+	}
+	return res, err
+}
+
+// endManagedSession ends the session's subscriber session in session manager, the call is queued if session manager
+// is unavailable or the subscriber has queued calls
+func (srv *accountingService) endManagedSession(ctx context.Context, aaaCtx *protos.Context) error {
+	return srv.endManagedSessionWithUsage(ctx, aaaCtx, nil)
+}
+
+// endManagedSessionWithUsage ends the session's subscriber session as endManagedSession does, with the session's
+// final usage, if known, for session managers supporting it
+func (srv *accountingService) endManagedSessionWithUsage(
+	ctx context.Context, aaaCtx *protos.Context, final *lte_protos.LocalEndSessionRequest) error {
+
+	sid, apn := makeSID(aaaCtx.GetImsi()), aaaCtx.GetApn()
+	op, req := acctqueue.Stop, proto.Message(sid)
+	end := func() error {
+		_, err := session_manager.EndSession(ctx, apn, sid)
+		return err
+	}
+	if final != nil && session_manager.GetAPNCapabilities(apn).FinalUsage {
+		final.Sid = sid
+		op, req = acctqueue.StopWithUsage, final
+		end = func() error {
+			_, err := session_manager.EndSessionWithUsage(ctx, apn, final)
+			return err
+		}
+	}
+	if srv.acctQueue != nil && srv.acctQueue.Pending(sid.GetId()) {
+		return srv.queueAcct(op, aaaCtx, req, nil)
+	}
+	err := end()
+	if srv.acctQueue != nil && sessionManagerUnavailable(err) {
+		return srv.queueAcct(op, aaaCtx, req, err)
+	}
+	if err != nil {
+		metrics.EndSessionFailures.WithLabelValues(status.Code(err).String(), retry.ClassOf(err).String()).Inc()
+	}
+	return err
+}
+
+// queueAcct queues the session manager call of the session's operation, cause is the call's error, if any, returned
+// when the queue is full
+func (srv *accountingService) queueAcct(
+	op acctqueue.Op, aaaCtx *protos.Context, req proto.Message, cause error) error {
+
+	payload, err := proto.Marshal(req)
+	if err != nil {
+		return status.Errorf(codes.Internal, "Error serializing queued %s of session %s: %v",
+			op, aaaCtx.GetSessionId(), err)
+	}
+	_, err = srv.acctQueue.Push(acctqueue.Item{
+		Key:       acctqueue.Key(op, aaaCtx.GetSessionId()),
+		Op:        op,
+		SessionId: aaaCtx.GetSessionId(),
+		Imsi:      makeSID(aaaCtx.GetImsi()).GetId(),
+		Apn:       aaaCtx.GetApn(),
+		Payload:   payload,
+	})
+	if err != nil {
+		metrics.AcctQueueItems.WithLabelValues(string(op), acctItemFull).Inc()
+		if cause != nil {
+			return cause
+		}
+		return status.Errorf(codes.Unavailable, "Accounting %s of session %s: %v", op, aaaCtx.GetSessionId(), err)
+	}
+	metrics.AcctQueueItems.WithLabelValues(string(op), acctItemQueued).Inc()
+	metrics.AcctQueueLength.Set(float64(srv.acctQueue.Len()))
+	return nil
+}
+
+// replayAcct replays the queued session manager call with its idempotency key, it returns an error, retrying the
+// call later, only if session manager is still unavailable. Calls rejected by session manager are dropped
+func (srv *accountingService) replayAcct(item acctqueue.Item) error {
+	ctx := metadata.AppendToOutgoingContext(context.Background(), idempotencyKeyHeader, item.Key)
+	var err error
+	switch item.Op {
+	case acctqueue.Start:
+		req := &lte_protos.LocalCreateSessionRequest{}
+		if err = proto.Unmarshal(item.Payload, req); err == nil {
+			_, err = session_manager.CreateSession(ctx, item.Apn, req)
+			if sessionManagerUnavailable(err) {
+				return err
+			}
+			if status.Code(err) == codes.AlreadyExists {
+				err = nil // the call was handled before session manager became unavailable
+			}
+		}
+	case acctqueue.Stop:
+		req := &lte_protos.SubscriberID{}
+		if err = proto.Unmarshal(item.Payload, req); err == nil {
+			_, err = session_manager.EndSession(ctx, item.Apn, req)
+			if sessionManagerUnavailable(err) {
+				return err
+			}
+			if status.Code(err) == codes.NotFound {
+				err = nil // the session is already ended
+			}
+		}
+	case acctqueue.StopWithUsage:
+		req := &lte_protos.LocalEndSessionRequest{}
+		if err = proto.Unmarshal(item.Payload, req); err == nil {
+			_, err = session_manager.EndSessionWithUsage(ctx, item.Apn, req)
+			if status.Code(err) == codes.Unimplemented {
+				// session manager was downgraded while the call was queued, the final usage is dropped
+				_, err = session_manager.EndSession(ctx, item.Apn, req.GetSid())
+			}
+			if sessionManagerUnavailable(err) {
+				return err
+			}
+			if status.Code(err) == codes.NotFound {
+				err = nil // the session is already ended
+			}
+		}
+	default:
+		err = fmt.Errorf("Unknown operation %s", item.Op)
+	}
+	if err == nil {
+		metrics.AcctQueueItems.WithLabelValues(string(item.Op), acctItemReplayed).Inc()
+		return nil
+	}
+	metrics.AcctQueueItems.WithLabelValues(string(item.Op), acctItemDropped).Inc()
+	log.Printf("Dropped queued %s of session %s queued at %v: %v",
+		item.Op, item.SessionId, item.Queued.Format(time.RFC3339), err)
+	if item.Op == acctqueue.Start {
+		// the NAS's session was acknowledged, but it's not created in session manager
+		if s := srv.sessions.GetSession(item.SessionId); s != nil {
+			srv.disconnectUnauthorized(s.GetCtx())
+		} else {
+			srv.capacity.releaseSession(item.SessionId)
+		}
+	}
+	return nil
+}
+
+// sessionManagerUnavailable returns true if the session manager call failed transiently, e.g. while session manager
+// restarts, so it's queued & replayed instead of failing
+func sessionManagerUnavailable(err error) bool {
+	return retry.ClassOf(err) == retry.ClassTransient // client errors aren't GRPC statuses & are transient
+}
@@ -0,0 +1,119 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSDstyle license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// package servcers implements WiFi AAA GRPC services
+package servicers
+
+import (
+	"log"
+
+	"magma/feg/gateway/services/aaa/aggregate"
+	"magma/feg/gateway/services/aaa/audit"
+	"magma/feg/gateway/services/aaa/coalog"
+	"magma/feg/gateway/services/aaa/deadlines"
+	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/aaa/panics"
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+// Quota check results of subscribers' combined usage
+const (
+	quotaPassed   = "passed"
+	quotaExceeded = "exceeded"
+)
+
+// SetSubscriberAggregation enables aggregation of the usage of subscribers' simultaneous sessions when the duplicate
+// IMSI policy permits multiple devices per IMSI. The combined usage is checked by the quota hook on every
+// Interim-Update, nil hook - no quota. Disabled by default
+func (srv *accountingService) SetSubscriberAggregation(hook aggregate.QuotaHook) {
+	srv.aggregates = aggregate.NewTable()
+	srv.quotaHook = hook
+}
+
+// aggregateUsage adds the session's accumulated usage to its subscriber's combined usage & terminates all the
+// subscriber's sessions if the combined usage exceeds the subscriber's quota
+func (srv *accountingService) aggregateUsage(aaaCtx *protos.Context, usage localUsage) {
+	if srv.aggregates == nil || srv.duplicateIMSI != DuplicateIMSIPermit {
+		return
+	}
+	imsi := aaaCtx.GetImsi()
+	combined := srv.aggregates.Update(imsi, aaaCtx.GetSessionId(), aggregate.Usage{
+		OctetsIn:   usage.octetsIn,
+		OctetsOut:  usage.octetsOut,
+		PacketsIn:  usage.packetsIn,
+		PacketsOut: usage.packetsOut,
+	})
+	setSubscriberMetrics(imsi, combined)
+	if srv.quotaHook == nil {
+		return
+	}
+	if err := srv.quotaHook.CheckQuota(imsi, combined); err != nil {
+		metrics.SubscriberQuotaChecks.WithLabelValues(quotaExceeded).Inc()
+		log.Printf("Subscriber quota exceeded, %d sessions are terminated: %v", combined.Sessions, err)
+		srv.terminateSubscriber(imsi)
+		return
+	}
+	metrics.SubscriberQuotaChecks.WithLabelValues(quotaPassed).Inc()
+}
+
+// terminateSubscriber removes all the subscriber's sessions, ends its session manager session & disconnects its UEs
+func (srv *accountingService) terminateSubscriber(imsi string) {
+	var removed []*protos.Context
+	for _, sid := range srv.aggregates.Sessions(imsi) {
+		s := srv.sessions.RemoveSession(sid)
+		srv.forgetSession(sid, audit.Terminate, s)
+		if s != nil {
+			srv.stopped(s.GetCtx())
+			removed = append(removed, s.GetCtx())
+		}
+	}
+	if len(removed) == 0 {
+		return
+	}
+	go func() {
+		defer panics.Recover("subscriber_quota_terminate")
+		ctx, cancel := deadlines.Background()
+		defer cancel()
+		ctx = coalog.WithTrigger(ctx, "subscriber_quota_terminate", imsi)
+		// all the subscriber's devices share its session manager session
+		if srv.settings(removed[0]).accountingEnabled {
+			if err := srv.endManagedSession(ctx, removed[0]); err != nil {
+				log.Printf("Error ending session manager session of IMSI %s over its quota: %v", imsi, err)
+			}
+		}
+		for _, aaaCtx := range removed {
+			if err := srv.disconnect(ctx, aaaCtx, protos.TerminateReason_QUOTA_EXHAUSTED); err != nil {
+				log.Printf("Error disconnecting session %s over its subscriber's quota: %v", aaaCtx.GetSessionId(), err)
+			}
+		}
+	}()
+}
+
+// removeAggregateUsage removes the session's usage from its subscriber's combined usage
+func (srv *accountingService) removeAggregateUsage(sid string) {
+	if srv.aggregates == nil {
+		return
+	}
+	if imsi, combined, ok := srv.aggregates.Remove(sid); ok {
+		setSubscriberMetrics(imsi, combined)
+	}
+}
+
+// setSubscriberMetrics exports the subscriber's combined usage, the metrics of subscribers without sessions are
+// deleted
+func setSubscriberMetrics(imsi string, combined aggregate.Usage) {
+	if combined.Sessions == 0 {
+		metrics.SubscriberSessions.DeleteLabelValues(imsi)
+		metrics.SubscriberOctetsIn.DeleteLabelValues(imsi)
+		metrics.SubscriberOctetsOut.DeleteLabelValues(imsi)
+		return
+	}
+	metrics.SubscriberSessions.WithLabelValues(imsi).Set(float64(combined.Sessions))
+	metrics.SubscriberOctetsIn.WithLabelValues(imsi).Set(float64(combined.OctetsIn))
+	metrics.SubscriberOctetsOut.WithLabelValues(imsi).Set(float64(combined.OctetsOut))
+}
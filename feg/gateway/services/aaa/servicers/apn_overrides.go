@@ -0,0 +1,88 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// package servcers implements WiFi AAA GRPC services
+package servicers
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	"magma/feg/cloud/go/protos/mconfig"
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+// APN override flag values, empty - the global setting
+const (
+	apnOverrideEnabled  = "enabled"
+	apnOverrideDisabled = "disabled"
+)
+
+// apnSettings - effective accounting settings of an APN's sessions
+type apnSettings struct {
+	idleTimeout         time.Duration
+	accountingEnabled   bool
+	createSessionOnAuth bool
+}
+
+// apnOverrides - global accounting settings & their overrides by lower case APN
+type apnOverrides struct {
+	global apnSettings
+	apns   map[string]apnSettings
+}
+
+func newAPNOverrides(cfg *mconfig.AAAConfig) *apnOverrides {
+	o := &apnOverrides{
+		global: apnSettings{
+			idleTimeout:         GetIdleSessionTimeout(cfg),
+			accountingEnabled:   cfg.GetAccountingEnabled(),
+			createSessionOnAuth: cfg.GetCreateSessionOnAuth(),
+		},
+		apns: map[string]apnSettings{},
+	}
+	for apn, override := range cfg.GetApnOverrides() {
+		settings := o.global
+		if override.GetIdleSessionTimeoutMs() > 0 {
+			settings.idleTimeout = time.Millisecond * time.Duration(override.GetIdleSessionTimeoutMs())
+		}
+		settings.accountingEnabled = overrideFlag(apn, "Accounting", override.GetAccounting(), settings.accountingEnabled)
+		settings.createSessionOnAuth = overrideFlag(
+			apn, "CreateSessionOnAuth", override.GetCreateSessionOnAuth(), settings.createSessionOnAuth)
+		o.apns[strings.ToLower(apn)] = settings
+	}
+	return o
+}
+
+// overrideFlag returns the APN's override of the global flag, invalid overrides are ignored
+func overrideFlag(apn, name, override string, global bool) bool {
+	switch strings.ToLower(override) {
+	case "":
+		return global
+	case apnOverrideEnabled:
+		return true
+	case apnOverrideDisabled:
+		return false
+	default:
+		log.Printf("Invalid %s override '%s' of APN '%s', using the global setting %t", name, override, apn, global)
+		return global
+	}
+}
+
+// get returns the effective settings of the APN's sessions
+func (o *apnOverrides) get(apn string) apnSettings {
+	if s, ok := o.apns[strings.ToLower(apn)]; ok {
+		return s
+	}
+	return o.global
+}
+
+// settings returns the effective accounting settings of the session of the context's APN
+func (srv *accountingService) settings(aaaCtx *protos.Context) apnSettings {
+	return srv.apns.get(aaaCtx.GetApn())
+}
@@ -0,0 +1,229 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// package servcers implements WiFi AAA GRPC services
+package servicers
+
+import (
+	"fmt"
+	"hash/fnv"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+
+	"magma/feg/gateway/services/aaa"
+	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/aaa/protos"
+	"magma/feg/gateway/services/aaa/spill"
+)
+
+// Session attributes limit actions
+const (
+	attributeDropped    = "dropped"
+	attributeSpilled    = "spilled"
+	attributeSpillError = "spill_error"
+)
+
+// spillRefPrefix - prefix of the references replacing spilled attribute values in session contexts
+const spillRefPrefix = "spill:"
+
+// AttributeLimits - per session attributes memory limits
+type AttributeLimits struct {
+	// MaxBytes - maximum total size of a session's attribute keys & values kept in memory, attributes over the
+	// limit are dropped. 0 - unlimited
+	MaxBytes int
+	// SpillBytes - values of at least SpillBytes are spilled to the spill store & replaced by references in the
+	// session context. 0 - values are never spilled
+	SpillBytes int
+}
+
+// attributeStore enforces the sessions' attribute limits & spills large attribute values
+type attributeStore struct {
+	limits AttributeLimits
+	spill  spill.Store
+}
+
+// SetAttributeLimits sets per session attribute limits, values over limits.SpillBytes are spilled to the given store
+// if it's not nil
+func (srv *accountingService) SetAttributeLimits(limits AttributeLimits, store spill.Store) error {
+	if limits.MaxBytes < 0 || limits.SpillBytes < 0 {
+		return fmt.Errorf("Invalid session attribute limits: %+v", limits)
+	}
+	if limits.SpillBytes > 0 && store == nil {
+		return fmt.Errorf("Attribute spilling requires a spill store")
+	}
+	srv.attributes = &attributeStore{limits: limits, spill: store}
+	return nil
+}
+
+// mergeAttributes merges NAS attributes of an accounting request into the session's context. The context is
+// replaced rather than modified, so its previous readers are not affected
+func (srv *accountingService) mergeAttributes(s aaa.Session, attrs map[string]string) {
+	if len(attrs) == 0 {
+		return
+	}
+	stored, blobs := srv.attributes.spillable(attrs)
+	if !attributesChanged(s.GetCtx(), stored) {
+		// Interim-Updates mostly repeat the session's attributes, don't copy the context for nothing
+		return
+	}
+	sid := s.GetCtx().GetSessionId()
+	old, aaaCtx, err := srv.sessions.UpdateCtx(sid, func(aaaCtx *protos.Context) error {
+		if err := srv.attributes.merge(aaaCtx, stored); err != nil {
+			log.Printf("Session %s attributes: %v", sid, err)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("Session %s attributes: %v", sid, err)
+		return
+	}
+	srv.attributes.spillChanged(aaaCtx, old.GetAttributes(), blobs)
+}
+
+// attributesChanged returns true if any of the attributes isn't set to its value in the context
+func attributesChanged(aaaCtx *protos.Context, attrs map[string]string) bool {
+	for k, v := range attrs {
+		if current, ok := aaaCtx.GetAttribute(k); !ok || current != v {
+			return true
+		}
+	}
+	return false
+}
+
+// spillable returns the attributes to store in the session context, spillable values are replaced by references,
+// & the spillable values by their keys
+func (a *attributeStore) spillable(attrs map[string]string) (map[string]string, map[string]string) {
+	if a == nil || a.limits.SpillBytes == 0 {
+		return attrs, nil
+	}
+	var stored, blobs map[string]string
+	for k, v := range attrs {
+		if len(v) < a.limits.SpillBytes {
+			continue
+		}
+		if stored == nil {
+			stored, blobs = make(map[string]string, len(attrs)), map[string]string{}
+			for key, value := range attrs {
+				stored[key] = value
+			}
+		}
+		stored[k], blobs[k] = spillRef(v), v
+	}
+	if stored == nil {
+		return attrs, nil
+	}
+	return stored, blobs
+}
+
+// merge merges the attributes into the context, attributes over the context's size limits are dropped. Attributes
+// are merged in their keys order, so the same attributes are always dropped
+func (a *attributeStore) merge(aaaCtx *protos.Context, attrs map[string]string) error {
+	if a == nil || a.limits.MaxBytes == 0 {
+		return aaaCtx.MergeAttributes(attrs)
+	}
+	size := attributesSize(aaaCtx.GetAttributes())
+	keys := make([]string, 0, len(attrs))
+	for k := range attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var res error
+	for _, k := range keys {
+		v := attrs[k]
+		newSize := size + len(k) + len(v)
+		if current, ok := aaaCtx.GetAttribute(k); ok {
+			newSize -= len(k) + len(current)
+		}
+		if newSize > a.limits.MaxBytes {
+			metrics.SessionAttributes.WithLabelValues(attributeDropped).Inc()
+			if res == nil {
+				res = fmt.Errorf("attribute '%s' is dropped: attributes size %d > %d", k, newSize, a.limits.MaxBytes)
+			}
+			continue
+		}
+		if err := aaaCtx.SetAttribute(k, v); err != nil {
+			if res == nil {
+				res = err
+			}
+			continue
+		}
+		size = newSize
+	}
+	return res
+}
+
+// spillChanged spills the values of the merged attributes whose references changed
+func (a *attributeStore) spillChanged(aaaCtx *protos.Context, previous, blobs map[string]string) {
+	sid := aaaCtx.GetSessionId()
+	for k, v := range blobs {
+		ref, ok := aaaCtx.GetAttribute(k)
+		if !ok || ref != spillRef(v) || previous[k] == ref {
+			continue // dropped or already spilled
+		}
+		if err := a.spill.Put(sid, k, v); err != nil {
+			metrics.SessionAttributes.WithLabelValues(attributeSpillError).Inc()
+			log.Printf("Error spilling session %s attribute '%s': %v", sid, k, err)
+			continue
+		}
+		metrics.SessionAttributes.WithLabelValues(attributeSpilled).Inc()
+	}
+}
+
+// resolve returns the context with spilled attribute values restored, the given context is not modified
+func (a *attributeStore) resolve(aaaCtx *protos.Context) *protos.Context {
+	if a == nil || a.spill == nil {
+		return aaaCtx
+	}
+	var resolved *protos.Context
+	for k, v := range aaaCtx.GetAttributes() {
+		if !strings.HasPrefix(v, spillRefPrefix) {
+			continue
+		}
+		value, err := a.spill.Get(aaaCtx.GetSessionId(), k)
+		if err != nil || spillRef(value) != v {
+			log.Printf("Error restoring session %s attribute '%s': %v", aaaCtx.GetSessionId(), k, err)
+			continue
+		}
+		if resolved == nil {
+			resolved = proto.Clone(aaaCtx).(*protos.Context)
+		}
+		resolved.Attributes[k] = value
+	}
+	if resolved == nil {
+		return aaaCtx
+	}
+	return resolved
+}
+
+// remove removes the ended session's spilled values
+func (a *attributeStore) remove(sid string) {
+	if a == nil || a.spill == nil {
+		return
+	}
+	if err := a.spill.Remove(sid); err != nil {
+		log.Printf("Error removing session %s spilled attributes: %v", sid, err)
+	}
+}
+
+// spillRef returns the reference of the spilled value
+func spillRef(value string) string {
+	h := fnv.New64a()
+	h.Write([]byte(value))
+	return fmt.Sprintf("%s%d:%016x", spillRefPrefix, len(value), h.Sum64())
+}
+
+func attributesSize(attrs map[string]string) int {
+	var size int
+	for k, v := range attrs {
+		size += len(k) + len(v)
+	}
+	return size
+}
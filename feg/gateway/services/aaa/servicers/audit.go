@@ -0,0 +1,153 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSDstyle license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// package servcers implements WiFi AAA GRPC services
+package servicers
+
+import (
+	"log"
+	"sync"
+
+	"magma/feg/gateway/services/aaa"
+	"magma/feg/gateway/services/aaa/audit"
+	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+// startTable keeps sessions' accounting start timestamps
+type startTable struct {
+	sync.Mutex
+	starts map[string]audit.Timestamp
+}
+
+func newStartTable() *startTable {
+	return &startTable{starts: map[string]audit.Timestamp{}}
+}
+
+func (t *startTable) get(sid string) audit.Timestamp {
+	t.Lock()
+	defer t.Unlock()
+	return t.starts[sid]
+}
+
+func (t *startTable) remove(sid string) {
+	t.Lock()
+	delete(t.starts, sid)
+	t.Unlock()
+}
+
+// AddAuditSink adds a destination of the session lifecycle events, e.g. the audit log or a cloud exporter
+func (srv *accountingService) AddAuditSink(s audit.Sink) {
+	if s == nil {
+		return
+	}
+	if sinks, ok := srv.audit.(audit.Sinks); ok {
+		srv.audit = append(sinks, s)
+	} else {
+		srv.audit = audit.Sinks{s}
+	}
+}
+
+// auditEvent records the session's lifecycle event, Start events also record the session's start timestamp
+func (srv *accountingService) auditEvent(typ audit.EventType, aaaCtx *protos.Context) {
+	srv.auditEventWith(typ, aaaCtx, nil)
+}
+
+// auditEventWith records the session's event, the event's details are set by the given details func, if not nil
+func (srv *accountingService) auditEventWith(
+	typ audit.EventType, aaaCtx *protos.Context, details func(ev *audit.Event)) {
+
+	now := audit.Now()
+	sid := aaaCtx.GetSessionId()
+	if typ == audit.Start {
+		// A session created on authentication may get its Start later, keep the first start
+		srv.starts.Lock()
+		if _, ok := srv.starts.starts[sid]; !ok {
+			srv.starts.starts[sid] = now
+		}
+		srv.starts.Unlock()
+	}
+	start := srv.starts.get(sid)
+	multi := srv.trackMultiSession(aaaCtx)
+	if srv.audit == nil {
+		return
+	}
+	ev := audit.NewEvent(typ, sid, now, start)
+	ev.Imsi, ev.MacAddr, ev.Apn = aaaCtx.GetImsi(), aaaCtx.GetMacAddr(), aaaCtx.GetApn()
+	ev.IpAddr, ev.Ipv6Addr = aaaCtx.GetIpAddr(), aaaCtx.GetIpv6Addr()
+	ev.CalledStationId, _ = aaaCtx.GetAttribute(protos.CalledStationIDAttribute)
+	ev.OperatorName, _ = aaaCtx.GetAttribute(protos.OperatorNameAttribute)
+	srv.usage.mu.Lock()
+	if u, ok := srv.usage.sessions[sid]; ok {
+		ev.OctetsIn, ev.OctetsOut = u.octetsIn, u.octetsOut
+	}
+	srv.usage.mu.Unlock()
+	ev.Device = srv.deviceHint(aaaCtx)
+	ev.MultiSession = multi
+	if details != nil {
+		details(ev)
+	}
+	srv.skewClock(ev)
+	if err := srv.audit.Log(ev); err != nil {
+		log.Printf("Error writing %s audit event of session %s: %v", typ, sid, err)
+	}
+}
+
+// auditAuth records the outcome of the session's authentication & the latency of its request handled since started,
+// failures' reason is optional. It's a no-op without accounting service (auth only mode)
+func (srv *accountingService) auditAuth(aaaCtx *protos.Context, success bool, reason string, started audit.Timestamp) {
+	if srv == nil || srv.audit == nil {
+		return
+	}
+	typ, outcome := audit.AuthSuccess, audit.OutcomeSuccess
+	if !success {
+		typ, outcome = audit.AuthFailure, audit.OutcomeFailure
+	}
+	now := audit.Now()
+	ev := audit.NewEvent(typ, aaaCtx.GetSessionId(), now, audit.Timestamp{})
+	ev.Imsi, ev.MacAddr, ev.Apn = aaaCtx.GetImsi(), aaaCtx.GetMacAddr(), aaaCtx.GetApn()
+	ev.CalledStationId, _ = aaaCtx.GetAttribute(protos.CalledStationIDAttribute)
+	ev.OperatorName, _ = aaaCtx.GetAttribute(protos.OperatorNameAttribute)
+	ev.Outcome, ev.Reason = outcome, reason
+	ev.SetLatency(now.Sub(started))
+	srv.skewClock(ev)
+	if err := srv.audit.Log(ev); err != nil {
+		log.Printf("Error writing %s audit event of session %s: %v", typ, ev.SessionId, err)
+	}
+}
+
+// forgetSession audits, records the duration of & publishes the end of the removed session (if found) & removes all
+// its per session state
+func (srv *accountingService) forgetSession(sid string, typ audit.EventType, s aaa.Session) {
+	if s != nil {
+		srv.auditEvent(typ, s.GetCtx())
+		if start := srv.starts.get(sid); !start.IsZero() {
+			metrics.SessionDuration.WithLabelValues(s.GetCtx().GetApn()).Observe(audit.Now().Sub(start).Seconds())
+		}
+		srv.publishSessionEnd(sid, s.GetCtx().GetImsi())
+		srv.terminated.add(sid, typ)
+	}
+	srv.clearSessionState(sid)
+}
+
+// clearSessionState removes all per session state of the removed session
+func (srv *accountingService) clearSessionState(sid string) {
+	srv.anomalies.Remove(sid)
+	srv.usage.remove(sid)
+	srv.removeAggregateUsage(sid)
+	srv.bandwidths.remove(sid)
+	srv.policies.remove(sid)
+	srv.starts.remove(sid)
+	srv.capacity.releaseSession(sid)
+	srv.attributes.remove(sid)
+	srv.guestSessions.remove(sid)
+	srv.lifetimes.remove(sid)
+	srv.removeDirectory(sid)
+	srv.reorder.remove(sid)
+	srv.multiSessions.remove(sid)
+}
@@ -0,0 +1,40 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// package servcers implements WiFi AAA GRPC services
+package servicers
+
+import (
+	"time"
+
+	"golang.org/x/net/context"
+
+	"fbc/lib/go/rolling"
+	"magma/feg/gateway/services/aaa/adminauth"
+	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+// GetAuthRates returns the EAP authentication success rates of the last 1, 5 & 15 minutes
+func (srv *sessionAdminService) GetAuthRates(
+	ctx context.Context, _ *protos.GetAuthRatesRequest) (*protos.AuthRates, error) {
+
+	if err := srv.authorize(ctx, "GetAuthRates", adminauth.ReadOnly); err != nil {
+		return nil, err
+	}
+	res := &protos.AuthRates{}
+	for _, s := range metrics.AuthOutcomes.Windows(rolling.DefaultWindows...) {
+		res.Windows = append(res.Windows, &protos.WindowStats{
+			WindowSec:   uint32(s.Window / time.Second),
+			Successes:   s.Successes,
+			Failures:    s.Failures,
+			SuccessRate: s.SuccessRate(),
+		})
+	}
+	return res, nil
+}
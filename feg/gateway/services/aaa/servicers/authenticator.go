@@ -11,7 +11,6 @@ package servicers
 
 import (
 	"log"
-	"time"
 
 	"golang.org/x/net/context"
 	"google.golang.org/grpc/codes"
@@ -19,18 +18,21 @@ import (
 
 	"magma/feg/cloud/go/protos/mconfig"
 	"magma/feg/gateway/services/aaa"
+	"magma/feg/gateway/services/aaa/audit"
 	"magma/feg/gateway/services/aaa/metrics"
 	"magma/feg/gateway/services/aaa/protos"
 	"magma/feg/gateway/services/eap"
 	"magma/feg/gateway/services/eap/client"
+	"magma/feg/gateway/services/eap/providers/aka"
 )
 
 type eapAuth struct {
 	supportedMethods []byte
 	sessions         aaa.SessionTable // AAA SessionTable, if Nil -> Auth only mode
 	config           *mconfig.AAAConfig
-	sessionTout      time.Duration // Idle Session Timeout
+	apns             *apnOverrides // accounting settings overrides by APN
 	accounting       *accountingService
+	inner            bool // runs inner methods instead of the top level methods
 }
 
 // NewEapAuthenticator returns a new instance of EAP Auth service
@@ -43,15 +45,32 @@ func NewEapAuthenticator(
 		supportedMethods: client.SupportedTypes(),
 		sessions:         sessions,
 		config:           cfg,
-		sessionTout:      GetIdleSessionTimeout(cfg),
+		apns:             newAPNOverrides(cfg),
 		accounting:       acct}, nil
 }
 
+// NewInnerAuthenticator returns an in process authenticator of the EAP authenticator's sessions running inner methods
+// (e.g. EAP-GTC) without their tunnels, for the AAA server's own synthetic sessions only. NASes' sessions never run
+// inner methods as top level methods, their credentials would be sent without the tunnels' protection
+func NewInnerAuthenticator(auth protos.AuthenticatorServer) (protos.AuthenticatorServer, error) {
+	outer, ok := auth.(*eapAuth)
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "Not an EAP authenticator: %T", auth)
+	}
+	inner := *outer
+	inner.inner = true
+	return &inner, nil
+}
+
 // HandleIdentity passes Identity EAP payload to corresponding method provider & returns corresponding
 // EAP result
 // NOTE: Identity Request is handled by APs & does not involve EAP Authenticator's support
 func (srv *eapAuth) HandleIdentity(ctx context.Context, in *protos.EapIdentity) (*protos.Eap, error) {
-	resp, err := client.HandleIdentityResponse(uint8(in.GetMethod()), &protos.Eap{Payload: in.Payload, Ctx: in.Ctx})
+	handleIdentity := client.HandleIdentityResponse
+	if srv.inner {
+		handleIdentity = client.HandleInnerIdentityResponse
+	}
+	resp, err := handleIdentity(uint8(in.GetMethod()), &protos.Eap{Payload: in.Payload, Ctx: in.Ctx})
 	if err != nil && resp != nil && len(resp.GetPayload()) > 0 {
 		log.Printf("EAP HandleIdentity Error: %v", err)
 		err = nil
@@ -61,10 +80,20 @@ func (srv *eapAuth) HandleIdentity(ctx context.Context, in *protos.EapIdentity)
 
 // Handle handles passed EAP payload & returns corresponding EAP result
 func (srv *eapAuth) Handle(ctx context.Context, in *protos.Eap) (*protos.Eap, error) {
-	resp, err := client.Handle(in)
+	started := audit.Now()
+	if err := srv.accounting.throttle("Authenticate", in.GetCtx()); err != nil {
+		return nil, err
+	}
+	srv.accounting.aliasAPN(in.GetCtx())
+	handle := client.Handle
+	if srv.inner {
+		handle = client.HandleInner
+	}
+	resp, err := handle(in)
 	if resp == nil {
 		return resp, err
 	}
+	defer srv.auditEapOutcome(in, resp, started)
 	method := eap.Packet(resp.GetPayload()).Type()
 	if method == uint8(protos.EapType_Reserved) {
 		method = eap.Packet(in.GetPayload()).Type()
@@ -74,37 +103,95 @@ func (srv *eapAuth) Handle(ctx context.Context, in *protos.Eap) (*protos.Eap, er
 		protos.EapCode(eap.Packet(resp.GetPayload()).Code()).String(),
 		protos.EapType(method).String(),
 		in.GetCtx().GetApn()).Inc()
+	if eap.Packet(resp.GetPayload()).IsSuccess() {
+		metrics.AuthOutcomes.Success()
+	} else if isAuthFailure(resp.GetPayload()) {
+		metrics.AuthOutcomes.Failure()
+	}
 
+	if isAuthFailure(resp.GetPayload()) && !srv.hssReachable() {
+		// the failure is attributed to the HSS outage, the UE isn't rejected & Radius doesn't answer, so the NAS
+		// fails over to another AAA server
+		metrics.AuthHSSOutages.Inc()
+		log.Printf("EAP authentication of session %s failed during HSS outage: %v", in.GetCtx().GetSessionId(), err)
+		return resp, status.Errorf(codes.Unavailable, "EAP authentication failed, HSS is unreachable")
+	}
 	if err != nil && len(resp.GetPayload()) > 0 {
 		// log error, but do not return it to Radius. EAP will carry its own error
 		log.Printf("EAP Handle Error: %v", err)
 		return resp, nil
 	}
 	if srv.sessions != nil && eap.Packet(resp.Payload).IsSuccess() {
-		if srv.config.GetAccountingEnabled() && srv.config.GetCreateSessionOnAuth() {
-			if srv.accounting == nil {
-				resp.Payload[eap.EapMsgCode] = eap.FailureCode
-				return resp, status.Errorf(
-					codes.Unavailable,
-					"Cannot Create Session on Auth: accounting service is missing")
-			}
-			_, err = srv.accounting.CreateSession(ctx, resp.Ctx)
-			if err != nil {
-				resp.Payload[eap.EapMsgCode] = eap.FailureCode
-				return resp, err
-			}
-		}
-		// Add Session & overwrite an existing session with the same ID if present,
-		// otherwise a UE can get stuck on buggy/non-unique AP or Radius session generation
-		_, err := srv.sessions.AddSession(resp.Ctx, srv.sessionTout, srv.accounting.timeoutSessionNotifier, true)
-		if err != nil {
-			return resp, status.Errorf(
-				codes.Internal, "Error adding a new session for SID: %s: %v", resp.Ctx.GetSessionId(), err)
+		if err = srv.acceptSession(ctx, resp.Ctx); err != nil {
+			resp.Payload[eap.EapMsgCode] = eap.FailureCode
 		}
 	}
 	return resp, err
 }
 
+// auditEapOutcome records the EAP authentication's outcome, if the response is the EAP success or failure
+func (srv *eapAuth) auditEapOutcome(in, resp *protos.Eap, started audit.Timestamp) {
+	aaaCtx := resp.GetCtx()
+	if aaaCtx == nil {
+		aaaCtx = in.GetCtx()
+	}
+	if eap.Packet(resp.GetPayload()).IsSuccess() {
+		srv.accounting.auditAuth(aaaCtx, true, "", started)
+	} else if isAuthFailure(resp.GetPayload()) {
+		reason := ""
+		if !srv.hssReachable() {
+			reason = "HSS is unreachable"
+		}
+		srv.accounting.auditAuth(aaaCtx, false, reason, started)
+	}
+}
+
+// acceptSession applies the session policies to the authenticated session, creates its session manager session if
+// configured & adds it to the sessions table
+func (srv *eapAuth) acceptSession(ctx context.Context, aaaCtx *protos.Context) error {
+	if err := srv.accounting.resolveDuplicateIMSI(aaaCtx); err != nil {
+		return err
+	}
+	srv.accounting.classifyGuest(aaaCtx)
+	srv.accounting.applyAcceptTimePolicy(aaaCtx)
+	settings := srv.apns.get(aaaCtx.GetApn())
+	if settings.accountingEnabled && settings.createSessionOnAuth {
+		if srv.accounting == nil {
+			// a configuration error, the NAS's retransmissions would fail alike
+			return status.Errorf(codes.Unimplemented, "Cannot Create Session on Auth: accounting service is missing")
+		}
+		if _, err := srv.accounting.CreateSession(ctx, aaaCtx); err != nil {
+			return err
+		}
+	}
+	// Add Session & overwrite an existing session with the same ID if present,
+	// otherwise a UE can get stuck on buggy/non-unique AP or Radius session generation
+	_, err := srv.sessions.AddSession(aaaCtx, settings.idleTimeout, srv.accounting.timeoutSessionNotifier, true)
+	if err != nil {
+		return status.Errorf(codes.Internal, "Error adding a new session for SID: %s: %v", aaaCtx.GetSessionId(), err)
+	}
+	srv.accounting.scheduleLifetimeExpiration(aaaCtx)
+	return nil
+}
+
+// isAuthFailure returns true if the EAP payload is an EAP-Failure or an EAP-AKA failure notification
+func isAuthFailure(payload eap.Packet) bool {
+	switch payload.Code() {
+	case eap.FailureCode:
+		return true
+	case eap.RequestCode:
+		return payload.Type() == aka.TYPE && len(payload) > eap.EapSubtype &&
+			aka.Subtype(payload[eap.EapSubtype]) == aka.SubtypeNotification
+	default:
+		return false
+	}
+}
+
+// hssReachable returns true if the HSS is reachable or its reachability isn't probed
+func (srv *eapAuth) hssReachable() bool {
+	return srv.accounting == nil || srv.accounting.hssProber.Reachable()
+}
+
 // SupportedMethods returns sorted list (ascending, by type) of registered EAP Provider Methods
 func (srv *eapAuth) SupportedMethods(ctx context.Context, in *protos.Void) (*protos.EapMethodList, error) {
 	return &protos.EapMethodList{Methods: srv.supportedMethods}, nil
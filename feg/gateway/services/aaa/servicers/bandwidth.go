@@ -0,0 +1,180 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSDstyle license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// package servcers implements WiFi AAA GRPC services
+package servicers
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"magma/feg/gateway/services/aaa/coalog"
+	"magma/feg/gateway/services/aaa/deadlines"
+	"magma/feg/gateway/services/aaa/panics"
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+type bandwidth struct {
+	up, down uint32
+}
+
+type sessionBandwidth struct {
+	base   *bandwidth  // last permanent bandwidth, nil if unknown
+	revert *time.Timer // pending temporary change revert, nil if none
+}
+
+// bandwidthTable keeps sessions' base bandwidths & pending reverts of temporary bandwidth changes
+type bandwidthTable struct {
+	sync.Mutex
+	sessions map[string]*sessionBandwidth
+}
+
+func newBandwidthTable() *bandwidthTable {
+	return &bandwidthTable{sessions: map[string]*sessionBandwidth{}}
+}
+
+// remove forgets the session & cancels its pending revert if any
+func (t *bandwidthTable) remove(sid string) {
+	t.Lock()
+	if sb, ok := t.sessions[sid]; ok {
+		if sb.revert != nil {
+			sb.revert.Stop()
+		}
+		delete(t.sessions, sid)
+	}
+	t.Unlock()
+}
+
+// setBase sets the session's base bandwidth & cancels its pending revert if any
+func (t *bandwidthTable) setBase(sid string, bw bandwidth) {
+	t.Lock()
+	sb, ok := t.sessions[sid]
+	if !ok {
+		sb = &sessionBandwidth{}
+		t.sessions[sid] = sb
+	} else if sb.revert != nil {
+		sb.revert.Stop()
+		sb.revert = nil
+	}
+	sb.base = &bw
+	t.Unlock()
+}
+
+// SetSessionBandwidth changes the session's maximum bandwidth via CoA, temporary changes are reverted to the request's
+// base bandwidth or, if not given, to the session's last permanent bandwidth after the requested duration
+func (srv *accountingService) SetSessionBandwidth(
+	ctx context.Context, req *protos.SessionBandwidthRequest) (*protos.AcctResp, error) {
+
+	if req == nil {
+		return &protos.AcctResp{}, status.Errorf(codes.InvalidArgument, "Nil Session Bandwidth Request")
+	}
+	if req.GetMaxBandwidthUp() == 0 || req.GetMaxBandwidthDown() == 0 {
+		return &protos.AcctResp{}, status.Errorf(codes.InvalidArgument,
+			"Invalid bandwidth: up %d, down %d", req.GetMaxBandwidthUp(), req.GetMaxBandwidthDown())
+	}
+	sid := req.GetSessionId()
+	s := srv.sessions.GetSession(sid)
+	if s == nil {
+		return &protos.AcctResp{}, status.Errorf(codes.FailedPrecondition, "Session %s is not found", sid)
+	}
+	aaaCtx := s.GetCtx()
+	bw := bandwidth{up: req.GetMaxBandwidthUp(), down: req.GetMaxBandwidthDown()}
+
+	t := srv.bandwidths
+	t.Lock()
+	var base *bandwidth
+	if sb, ok := t.sessions[sid]; ok {
+		base = sb.base
+	}
+	t.Unlock()
+	if req.GetBaseBandwidthUp() > 0 && req.GetBaseBandwidthDown() > 0 {
+		base = &bandwidth{up: req.GetBaseBandwidthUp(), down: req.GetBaseBandwidthDown()}
+	}
+	duration := time.Duration(req.GetDurationSec()) * time.Second
+	if duration > 0 && base == nil {
+		return &protos.AcctResp{}, status.Errorf(codes.FailedPrecondition,
+			"Unknown base bandwidth of session %s, temporary bandwidth change cannot be reverted", sid)
+	}
+	// Don't hold the table lock while waiting for the CoA response
+	if err := srv.changeBandwidth(ctx, aaaCtx, bw); err != nil {
+		return &protos.AcctResp{}, err
+	}
+
+	t.Lock()
+	if srv.sessions.GetSession(sid) == nil {
+		// the session ended while waiting for the CoA response & its bandwidth state is already removed
+		t.Unlock()
+		log.Printf("Session %s ended while its bandwidth was set to up: %d, down: %d", sid, bw.up, bw.down)
+		return &protos.AcctResp{}, nil
+	}
+	sb, ok := t.sessions[sid]
+	if !ok {
+		sb = &sessionBandwidth{}
+		t.sessions[sid] = sb
+	} else if sb.revert != nil {
+		sb.revert.Stop()
+		sb.revert = nil
+	}
+	if duration == 0 {
+		sb.base = &bw
+	} else {
+		sb.base = base
+		sb.revert = time.AfterFunc(duration, func() { srv.revertBandwidth(sid, sb) })
+	}
+	t.Unlock()
+	log.Printf("Session %s bandwidth set to up: %d, down: %d for %v", sid, bw.up, bw.down, duration)
+	return &protos.AcctResp{}, nil
+}
+
+// revertBandwidth reverts the session's temporary bandwidth change to the session's base bandwidth
+func (srv *accountingService) revertBandwidth(sid string, sb *sessionBandwidth) {
+	defer panics.Recover("bandwidth_revert")
+	t := srv.bandwidths
+	t.Lock()
+	if t.sessions[sid] != sb || sb.revert == nil {
+		t.Unlock() // the session ended or its bandwidth was changed again
+		return
+	}
+	sb.revert = nil
+	base := *sb.base
+	t.Unlock()
+
+	s := srv.sessions.GetSession(sid)
+	if s == nil {
+		return
+	}
+	ctx, cancel := deadlines.Background()
+	defer cancel()
+	ctx = coalog.WithTrigger(ctx, "bandwidth_revert", "")
+	if err := srv.changeBandwidth(ctx, s.GetCtx(), base); err != nil {
+		log.Printf("Failed to revert session %s bandwidth: %v", sid, err)
+		return
+	}
+	log.Printf("Session %s bandwidth reverted to up: %d, down: %d", sid, base.up, base.down)
+}
+
+// changeBandwidth sends a CoA with the given maximum bandwidth for the session
+func (srv *accountingService) changeBandwidth(ctx context.Context, aaaCtx *protos.Context, bw bandwidth) error {
+	resp, err := srv.newAuthorizationClient().Change(ctx, &protos.ChangeRequest{
+		Ctx:              aaaCtx,
+		MaxBandwidthUp:   bw.up,
+		MaxBandwidthDown: bw.down,
+	})
+	if err != nil {
+		return err
+	}
+	if resp.GetCoaResponseType() != protos.CoaResponse_ACK {
+		return status.Errorf(codes.Aborted, "Bandwidth change of session %s was rejected", aaaCtx.GetSessionId())
+	}
+	return nil
+}
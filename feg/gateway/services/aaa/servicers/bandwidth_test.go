@@ -0,0 +1,263 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package servicers
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+// bandwidthClient an authorization client keeping the bandwidths of the CoAs it answers
+type bandwidthClient struct {
+	protos.AuthorizationClient
+	sync.Mutex
+	changes  []bandwidth
+	nak      bool // the CoAs are NAKed
+	err      error
+	onChange func() // called before the CoA is answered, nil - none
+}
+
+func (c *bandwidthClient) Change(
+	_ context.Context, in *protos.ChangeRequest, _ ...grpc.CallOption) (*protos.CoaResponse, error) {
+	if c.onChange != nil {
+		c.onChange()
+	}
+	c.Lock()
+	defer c.Unlock()
+	if c.err != nil {
+		return nil, c.err
+	}
+	c.changes = append(c.changes, bandwidth{up: in.GetMaxBandwidthUp(), down: in.GetMaxBandwidthDown()})
+	if c.nak {
+		return &protos.CoaResponse{CoaResponseType: protos.CoaResponse_NAK}, nil
+	}
+	return &protos.CoaResponse{CoaResponseType: protos.CoaResponse_ACK}, nil
+}
+
+func (c *bandwidthClient) sent() []bandwidth {
+	c.Lock()
+	defer c.Unlock()
+	var sent []bandwidth
+	return append(sent, c.changes...)
+}
+
+// sessionBandwidthOf returns the session's bandwidth state, nil if it has none
+func sessionBandwidthOf(srv *accountingService, sid string) *sessionBandwidth {
+	srv.bandwidths.Lock()
+	defer srv.bandwidths.Unlock()
+	return srv.bandwidths.sessions[sid]
+}
+
+func TestSetSessionBandwidth(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		base    *bandwidth // the session's base bandwidth before the request, nil - unknown
+		req     *protos.SessionBandwidthRequest
+		nak     bool
+		err     error
+		code    codes.Code
+		sent    []bandwidth
+		newBase *bandwidth // the session's base bandwidth after the request, nil - unknown
+		reverts bool
+	}{
+		{name: "nil request", code: codes.InvalidArgument},
+		{
+			name: "zero bandwidth",
+			req:  &protos.SessionBandwidthRequest{SessionId: "sid1", MaxBandwidthDown: 2000},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "unknown session",
+			req:  &protos.SessionBandwidthRequest{SessionId: "sid2", MaxBandwidthUp: 1000, MaxBandwidthDown: 2000},
+			code: codes.FailedPrecondition,
+		},
+		{
+			name:    "permanent change",
+			req:     &protos.SessionBandwidthRequest{SessionId: "sid1", MaxBandwidthUp: 1000, MaxBandwidthDown: 2000},
+			sent:    []bandwidth{{1000, 2000}},
+			newBase: &bandwidth{1000, 2000},
+		},
+		{
+			name: "temporary change of unknown base",
+			req: &protos.SessionBandwidthRequest{
+				SessionId: "sid1", MaxBandwidthUp: 1000, MaxBandwidthDown: 2000, DurationSec: 60},
+			code: codes.FailedPrecondition,
+		},
+		{
+			name: "temporary change of the session's base",
+			base: &bandwidth{5000, 10000},
+			req: &protos.SessionBandwidthRequest{
+				SessionId: "sid1", MaxBandwidthUp: 1000, MaxBandwidthDown: 2000, DurationSec: 60},
+			sent:    []bandwidth{{1000, 2000}},
+			newBase: &bandwidth{5000, 10000},
+			reverts: true,
+		},
+		{
+			name: "temporary change of the request's base",
+			base: &bandwidth{5000, 10000},
+			req: &protos.SessionBandwidthRequest{SessionId: "sid1", MaxBandwidthUp: 1000, MaxBandwidthDown: 2000,
+				DurationSec: 60, BaseBandwidthUp: 3000, BaseBandwidthDown: 6000},
+			sent:    []bandwidth{{1000, 2000}},
+			newBase: &bandwidth{3000, 6000},
+			reverts: true,
+		},
+		{
+			name:    "rejected change",
+			base:    &bandwidth{5000, 10000},
+			req:     &protos.SessionBandwidthRequest{SessionId: "sid1", MaxBandwidthUp: 1000, MaxBandwidthDown: 2000},
+			nak:     true,
+			code:    codes.Aborted,
+			sent:    []bandwidth{{1000, 2000}},
+			newBase: &bandwidth{5000, 10000},
+		},
+		{
+			name:    "failed change",
+			base:    &bandwidth{5000, 10000},
+			req:     &protos.SessionBandwidthRequest{SessionId: "sid1", MaxBandwidthUp: 1000, MaxBandwidthDown: 2000},
+			err:     status.Errorf(codes.DeadlineExceeded, "NAS didn't respond"),
+			code:    codes.DeadlineExceeded,
+			newBase: &bandwidth{5000, 10000},
+		},
+	} {
+		srv := newTestAccounting(t, &protos.Context{SessionId: "sid1", Imsi: "123456789012345"})
+		client := &bandwidthClient{nak: tc.nak, err: tc.err}
+		srv.radius = client
+		if tc.base != nil {
+			srv.bandwidths.setBase("sid1", *tc.base)
+		}
+
+		_, err := srv.SetSessionBandwidth(context.Background(), tc.req)
+		assert.Equal(t, tc.code, status.Code(err), tc.name)
+		assert.Equal(t, tc.sent, client.sent(), tc.name)
+		sb := sessionBandwidthOf(srv, "sid1")
+		if tc.newBase == nil {
+			assert.Nil(t, sb, tc.name)
+			continue
+		}
+		if assert.NotNil(t, sb, tc.name) {
+			assert.Equal(t, tc.newBase, sb.base, tc.name)
+			assert.Equal(t, tc.reverts, sb.revert != nil, tc.name)
+		}
+		srv.bandwidths.remove("sid1") // stops the pending revert
+	}
+}
+
+func TestRevertBandwidth(t *testing.T) {
+	temporary := &protos.SessionBandwidthRequest{SessionId: "sid1", MaxBandwidthUp: 1000, MaxBandwidthDown: 2000,
+		DurationSec: 60, BaseBandwidthUp: 5000, BaseBandwidthDown: 10000}
+	for _, tc := range []struct {
+		name string
+		// before is called between the temporary change & its revert
+		before func(srv *accountingService)
+		sent   []bandwidth
+	}{
+		{
+			name:   "reverted change",
+			before: func(*accountingService) {},
+			sent:   []bandwidth{{1000, 2000}, {5000, 10000}},
+		},
+		{
+			name: "changed again",
+			before: func(srv *accountingService) {
+				_, err := srv.SetSessionBandwidth(context.Background(), &protos.SessionBandwidthRequest{
+					SessionId: "sid1", MaxBandwidthUp: 3000, MaxBandwidthDown: 6000})
+				assert.NoError(t, err)
+			},
+			sent: []bandwidth{{1000, 2000}, {3000, 6000}},
+		},
+		{
+			name: "ended session",
+			before: func(srv *accountingService) {
+				srv.sessions.RemoveSession("sid1")
+				srv.clearSessionState("sid1")
+			},
+			sent: []bandwidth{{1000, 2000}},
+		},
+		{
+			name: "session ending",
+			before: func(srv *accountingService) {
+				// the session is removed but its state isn't cleared yet
+				srv.sessions.RemoveSession("sid1")
+			},
+			sent: []bandwidth{{1000, 2000}},
+		},
+	} {
+		srv := newTestAccounting(t, &protos.Context{SessionId: "sid1", Imsi: "123456789012345"})
+		client := &bandwidthClient{}
+		srv.radius = client
+		_, err := srv.SetSessionBandwidth(context.Background(), temporary)
+		assert.NoError(t, err, tc.name)
+		sb := sessionBandwidthOf(srv, "sid1")
+		if !assert.NotNil(t, sb, tc.name) || !assert.NotNil(t, sb.revert, tc.name) {
+			continue
+		}
+		sb.revert.Stop() // reverted below instead of after the change's duration
+
+		tc.before(srv)
+		srv.revertBandwidth("sid1", sb)
+		assert.Equal(t, tc.sent, client.sent(), tc.name)
+		if sb = sessionBandwidthOf(srv, "sid1"); sb != nil {
+			assert.Nil(t, sb.revert, tc.name)
+		}
+		srv.bandwidths.remove("sid1")
+	}
+}
+
+func TestBandwidthRacesTeardown(t *testing.T) {
+	// the session ends while its bandwidth change waits for the CoA response, its bandwidth state isn't restored
+	srv := newTestAccounting(t, &protos.Context{SessionId: "sid1", Imsi: "123456789012345"})
+	client := &bandwidthClient{onChange: func() {
+		srv.sessions.RemoveSession("sid1")
+		srv.clearSessionState("sid1")
+	}}
+	srv.radius = client
+	_, err := srv.SetSessionBandwidth(context.Background(), &protos.SessionBandwidthRequest{SessionId: "sid1",
+		MaxBandwidthUp: 1000, MaxBandwidthDown: 2000, DurationSec: 60, BaseBandwidthUp: 5000, BaseBandwidthDown: 10000})
+	assert.NoError(t, err)
+	assert.Nil(t, sessionBandwidthOf(srv, "sid1"), "the ended session's revert is pending")
+
+	// reverts racing the session's teardown send at most one CoA & leave no state behind
+	for i := 0; i < 100; i++ {
+		srv = newTestAccounting(t, &protos.Context{SessionId: "sid1", Imsi: "123456789012345"})
+		client = &bandwidthClient{}
+		srv.radius = client
+		srv.bandwidths.setBase("sid1", bandwidth{5000, 10000})
+		_, err = srv.SetSessionBandwidth(context.Background(), &protos.SessionBandwidthRequest{
+			SessionId: "sid1", MaxBandwidthUp: 1000, MaxBandwidthDown: 2000, DurationSec: 60})
+		assert.NoError(t, err)
+		sb := sessionBandwidthOf(srv, "sid1")
+		if !assert.NotNil(t, sb) {
+			return
+		}
+		sb.revert.Stop()
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			srv.revertBandwidth("sid1", sb)
+		}()
+		go func() {
+			defer wg.Done()
+			srv.sessions.RemoveSession("sid1")
+			srv.clearSessionState("sid1")
+		}()
+		wg.Wait()
+		assert.True(t, len(client.sent()) <= 2, "the revert is sent once")
+		assert.Nil(t, sessionBandwidthOf(srv, "sid1"))
+	}
+}
@@ -0,0 +1,97 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// package servcers implements WiFi AAA GRPC services
+package servicers
+
+import (
+	"log"
+	"strings"
+	"sync"
+
+	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+// capacityTable keeps admitted sessions of APNs with concurrent sessions limits
+type capacityTable struct {
+	sync.Mutex
+	limits   map[string]uint32 // max sessions by lower case APN
+	admitted map[string]map[string]struct{}
+}
+
+func newCapacityTable(limits map[string]uint32) *capacityTable {
+	t := &capacityTable{limits: map[string]uint32{}, admitted: map[string]map[string]struct{}{}}
+	for apn, max := range limits {
+		t.limits[strings.ToLower(apn)] = max
+	}
+	return t
+}
+
+// admitSession admits the session to its APN & returns a capacity error if the APN is at its concurrent sessions
+// limit, sessions already admitted are always admitted
+func (srv *accountingService) admitSession(aaaCtx *protos.Context) error {
+	t := srv.capacity
+	apn := strings.ToLower(aaaCtx.GetApn())
+	max, limited := t.limits[apn]
+	if !limited {
+		return nil
+	}
+	sid := aaaCtx.GetSessionId()
+	t.Lock()
+	defer t.Unlock()
+	sessions := t.admitted[apn]
+	if _, ok := sessions[sid]; ok {
+		return nil
+	}
+	if uint32(len(sessions)) >= max {
+		metrics.CapacityRejects.WithLabelValues(aaaCtx.GetApn()).Inc()
+		log.Printf("Session %s of IMSI %s is rejected: APN %s is at its capacity of %d sessions",
+			sid, aaaCtx.GetImsi(), aaaCtx.GetApn(), max)
+		return protos.NewCapacityError(aaaCtx.GetApn(), max)
+	}
+	if sessions == nil {
+		sessions = map[string]struct{}{}
+		t.admitted[apn] = sessions
+	}
+	sessions[sid] = struct{}{}
+	return nil
+}
+
+// releaseSession releases the ended session's APN capacity
+func (t *capacityTable) releaseSession(sid string) {
+	if len(t.limits) == 0 {
+		return
+	}
+	t.Lock()
+	for _, sessions := range t.admitted {
+		delete(sessions, sid)
+	}
+	t.Unlock()
+}
+
+// moveSession moves the admitted session to the given APN, the APN's limit is not enforced: the session is live
+// already & an operator moved it
+func (t *capacityTable) moveSession(sid, apn string) {
+	if len(t.limits) == 0 {
+		return
+	}
+	apn = strings.ToLower(apn)
+	t.Lock()
+	defer t.Unlock()
+	for _, sessions := range t.admitted {
+		delete(sessions, sid)
+	}
+	if _, limited := t.limits[apn]; !limited {
+		return
+	}
+	if t.admitted[apn] == nil {
+		t.admitted[apn] = map[string]struct{}{}
+	}
+	t.admitted[apn][sid] = struct{}{}
+}
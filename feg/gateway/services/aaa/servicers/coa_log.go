@@ -0,0 +1,105 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// package servcers implements WiFi AAA GRPC services
+package servicers
+
+import (
+	"log"
+	"sort"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"magma/feg/gateway/services/aaa/adminauth"
+	"magma/feg/gateway/services/aaa/audit"
+	"magma/feg/gateway/services/aaa/coalog"
+	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+// SetCoALog enables the transaction log of the CoA & Disconnect-Requests sent to the sessions' NASes, nil disables it
+func (srv *accountingService) SetCoALog(l *coalog.Log) {
+	srv.coaLog = l
+}
+
+// recordCoA completes, logs & audits the transaction of the sent request, nil transactions aren't logged
+func (srv *accountingService) recordCoA(tx *coalog.Transaction, res *protos.CoaResponse, err error) {
+	if tx == nil {
+		return
+	}
+	tx.Done(res, err)
+	srv.coaLog.Record(tx)
+	metrics.CoATransactions.WithLabelValues(string(tx.Kind), tx.Trigger.Source, tx.Result).Inc()
+	if srv.audit == nil {
+		return
+	}
+	// Not audited by auditEventWith, Disconnect-Requests' sessions are about to end & mustn't be tracked again
+	ev := audit.NewEvent(audit.CoA, tx.SessionID, audit.Now(), srv.starts.get(tx.SessionID))
+	ev.Imsi, ev.Reason, ev.CoA = tx.Imsi, tx.Trigger.Source, tx
+	if err := srv.audit.Log(ev); err != nil {
+		log.Printf("Error writing %s audit event of session %s: %v", audit.CoA, tx.SessionID, err)
+	}
+}
+
+// ListCoaTransactions returns the logged CoA & Disconnect-Requests matching the request's filters, the latest first
+func (srv *sessionAdminService) ListCoaTransactions(
+	ctx context.Context, req *protos.ListCoaTransactionsRequest) (*protos.CoaTransactionList, error) {
+
+	if err := srv.authorize(ctx, "ListCoaTransactions", adminauth.ReadOnly); err != nil {
+		return nil, err
+	}
+	if srv.acct.coaLog == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "CoA transaction log is disabled")
+	}
+	q := coalog.Query{
+		SessionID: req.GetSessionId(),
+		Imsi:      req.GetImsi(),
+		Source:    req.GetTrigger(),
+		Result:    req.GetResult(),
+		Limit:     int(req.GetLimit()),
+	}
+	if req.GetSinceMs() > 0 {
+		q.Since = time.Unix(0, req.GetSinceMs()*int64(time.Millisecond))
+	}
+	res := &protos.CoaTransactionList{}
+	for _, tx := range srv.acct.coaLog.List(q) {
+		res.Transactions = append(res.Transactions, coaTransactionProto(tx))
+	}
+	return res, nil
+}
+
+func coaTransactionProto(tx coalog.Transaction) *protos.CoaTransaction {
+	res := &protos.CoaTransaction{
+		Id:            tx.ID,
+		TimeMs:        tx.Time.UnixNano() / int64(time.Millisecond),
+		Kind:          string(tx.Kind),
+		SessionId:     tx.SessionID,
+		Imsi:          tx.Imsi,
+		NasAddress:    tx.NASAddress,
+		NasIdentifier: tx.NASIdentifier,
+		Trigger:       tx.Trigger.Source,
+		TriggerDetail: tx.Trigger.Detail,
+		RequestId:     tx.Trigger.RequestID,
+		Result:        tx.Result,
+		Error:         tx.Error,
+		Attempt:       uint32(tx.Attempt),
+		LatencyMs:     tx.LatencyMs,
+	}
+	names := make([]string, 0, len(tx.Attributes))
+	for name := range tx.Attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		res.Attributes = append(res.Attributes, &protos.CoaAttribute{Name: name, Value: tx.Attributes[name]})
+	}
+	return res
+}
@@ -12,8 +12,17 @@ package servicers
 import (
 	"time"
 
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
 	"magma/feg/cloud/go/protos/mconfig"
 	"magma/feg/gateway/services/aaa"
+	"magma/feg/gateway/services/aaa/coalog"
+	"magma/feg/gateway/services/aaa/protos"
+	"magma/feg/gateway/services/aaa/radiusauthz"
+	"magma/feg/gateway/services/aaa/slo"
 )
 
 // GetIdleSessionTimeout returns Idle Session Timeout Duration if set in mconfigs or DefaultSessionTimeout otherwise
@@ -25,3 +34,65 @@ func GetIdleSessionTimeout(cfg *mconfig.AAAConfig) time.Duration {
 	}
 	return aaa.DefaultSessionTimeout
 }
+
+// contextError returns a GRPC status error if the call's context is already canceled or past its deadline
+func contextError(ctx context.Context) error {
+	switch ctx.Err() {
+	case nil:
+		return nil
+	case context.DeadlineExceeded:
+		return status.Errorf(codes.DeadlineExceeded, "%v", ctx.Err())
+	default:
+		return status.Errorf(codes.Canceled, "%v", ctx.Err())
+	}
+}
+
+// authorizationClient observes Radius server authorization calls' latencies against the radius_authz budget &
+// records the calls in the CoA transaction log
+type authorizationClient struct {
+	protos.AuthorizationClient
+	srv *accountingService
+}
+
+// newAuthorizationClient returns the authorization client of the Radius server endpoints
+func (srv *accountingService) newAuthorizationClient() protos.AuthorizationClient {
+	return authorizationClient{AuthorizationClient: srv.radius, srv: srv}
+}
+
+// SetRadiusEndpoints sets the Radius server endpoints of Disconnect & CoA requests, nil is ignored
+func (srv *accountingService) SetRadiusEndpoints(endpoints *radiusauthz.Endpoints) {
+	if endpoints != nil {
+		srv.radius = endpoints
+	}
+}
+
+// Change implements protos.AuthorizationClient
+func (c authorizationClient) Change(
+	ctx context.Context, in *protos.ChangeRequest, opts ...grpc.CallOption) (*protos.CoaResponse, error) {
+
+	var tx *coalog.Transaction
+	if c.srv.coaLog != nil {
+		tx = coalog.NewTransaction(ctx, coalog.KindCoA, in.GetCtx(), coalog.ChangeAttributes(in))
+	}
+	start := time.Now()
+	res, err := c.AuthorizationClient.Change(ctx, in, opts...)
+	slo.Observe(slo.RadiusAuthz, "Change", start, err)
+	c.srv.recordCoA(tx, res, err)
+	return res, err
+}
+
+// Disconnect implements protos.AuthorizationClient
+func (c authorizationClient) Disconnect(
+	ctx context.Context, in *protos.DisconnectRequest, opts ...grpc.CallOption) (*protos.CoaResponse, error) {
+
+	var tx *coalog.Transaction
+	if c.srv.coaLog != nil {
+		tx = coalog.NewTransaction(ctx, coalog.KindDisconnect, in.GetCtx(), coalog.DisconnectAttributes(in))
+	}
+	start := time.Now()
+	res, err := c.AuthorizationClient.Disconnect(ctx, in, opts...)
+	slo.Observe(slo.RadiusAuthz, "Disconnect", start, err)
+	c.srv.recordCoA(tx, res, err)
+	c.srv.disconnectDone(ctx, in, err)
+	return res, err
+}
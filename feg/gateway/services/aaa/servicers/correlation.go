@@ -0,0 +1,93 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// package servcers implements WiFi AAA GRPC services
+package servicers
+
+import (
+	"log"
+
+	"magma/feg/gateway/services/aaa"
+	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+// Correlation results
+const (
+	correlationResolved  = "resolved"
+	correlationAmbiguous = "ambiguous"
+	correlationNotFound  = "not_found"
+)
+
+// resolveSessionID returns the session ID of the accounting request's session: the request's session ID or, if the
+// request's session isn't found, e.g. of a roam or AP handoff, the session ID of its correlated session
+func (srv *accountingService) resolveSessionID(acctType string, reqCtx *protos.Context) string {
+	sid := reqCtx.GetSessionId()
+	if len(reqCtx.GetCorrelationId()) == 0 || srv.sessions.GetSession(sid) != nil {
+		return sid
+	}
+	s := srv.correlatedSession(acctType, reqCtx)
+	if s == nil {
+		return sid
+	}
+	resolved := s.GetCtx().GetSessionId()
+	log.Printf("Accounting %s of session %s is correlated to session %s by %s",
+		acctType, sid, resolved, reqCtx.GetCorrelationId())
+	return resolved
+}
+
+// correlatedSession returns the session of the request's correlation ID: the session of the correlation Class's
+// session ID or the session indexed by the ID. Sessions sharing the ID are told apart by the request's MAC address,
+// nil is returned if the session isn't found or is ambiguous
+func (srv *accountingService) correlatedSession(acctType string, reqCtx *protos.Context) aaa.Session {
+	cid := reqCtx.GetCorrelationId()
+	if s := srv.sessions.GetSession(cid); s != nil {
+		metrics.CorrelatedAcct.WithLabelValues(acctType, correlationResolved).Inc()
+		return s
+	}
+	sessions := aaa.FindSessions(srv.sessions, aaa.CorrelationIndex, cid)
+	if len(sessions) > 1 {
+		mac := aaa.MACKey(reqCtx.GetMacAddr())
+		var matching []aaa.Session
+		for _, s := range sessions {
+			if len(mac) > 0 && aaa.MACKey(s.GetCtx().GetMacAddr()) == mac {
+				matching = append(matching, s)
+			}
+		}
+		if len(matching) != 1 {
+			metrics.CorrelatedAcct.WithLabelValues(acctType, correlationAmbiguous).Inc()
+			log.Printf("Accounting %s of session %s: %d sessions of correlation ID %s",
+				acctType, reqCtx.GetSessionId(), len(sessions), cid)
+			return nil
+		}
+		sessions = matching
+	}
+	if len(sessions) == 0 {
+		metrics.CorrelatedAcct.WithLabelValues(acctType, correlationNotFound).Inc()
+		return nil
+	}
+	metrics.CorrelatedAcct.WithLabelValues(acctType, correlationResolved).Inc()
+	return sessions[0]
+}
+
+// correlate sets the session's correlation ID to the started session's correlation ID, so the accounting of its
+// roams & AP handoffs is resolved to it
+func (srv *accountingService) correlate(s aaa.Session, reqCtx *protos.Context) {
+	cid := reqCtx.GetCorrelationId()
+	if len(cid) == 0 || s.GetCtx().GetCorrelationId() == cid {
+		return
+	}
+	sid := s.GetCtx().GetSessionId()
+	_, _, err := srv.sessions.UpdateCtx(sid, func(aaaCtx *protos.Context) error {
+		aaaCtx.CorrelationId = cid
+		return nil
+	})
+	if err != nil {
+		log.Printf("Session %s correlation: %v", sid, err)
+	}
+}
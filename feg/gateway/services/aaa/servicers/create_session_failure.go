@@ -0,0 +1,63 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// package servcers implements WiFi AAA GRPC services
+package servicers
+
+import (
+	"fmt"
+	"log"
+
+	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+// CreateSessionFailurePolicy defines how an Accounting Start whose session manager CreateSession fails is handled
+type CreateSessionFailurePolicy string
+
+const (
+	// CreateSessionFailureReject - the Start is rejected, the NAS may keep its client online without session manager
+	// state
+	CreateSessionFailureReject CreateSessionFailurePolicy = "reject"
+	// CreateSessionFailureDisconnect - the Start is rejected, the session is removed & its UE disconnected, so its
+	// traffic isn't left unaccounted
+	CreateSessionFailureDisconnect CreateSessionFailurePolicy = "disconnect"
+)
+
+// ParseCreateSessionFailurePolicy returns the CreateSession failure policy of the given name, empty name -
+// CreateSessionFailureReject
+func ParseCreateSessionFailurePolicy(name string) (CreateSessionFailurePolicy, error) {
+	switch policy := CreateSessionFailurePolicy(name); policy {
+	case "":
+		return CreateSessionFailureReject, nil
+	case CreateSessionFailureReject, CreateSessionFailureDisconnect:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("Unknown CreateSession failure policy: '%s'", name)
+	}
+}
+
+// createSessionFailurePolicy returns the configured CreateSession failure policy, invalid policies are rejects
+func createSessionFailurePolicy(name string) CreateSessionFailurePolicy {
+	policy, err := ParseCreateSessionFailurePolicy(name)
+	if err != nil {
+		log.Printf("%v, using '%s'", err, CreateSessionFailureReject)
+		return CreateSessionFailureReject
+	}
+	return policy
+}
+
+// createSessionFailed applies the CreateSession failure policy to the started session
+func (srv *accountingService) createSessionFailed(aaaCtx *protos.Context, err error) {
+	metrics.CreateSessionFailureActions.WithLabelValues(string(srv.createFailure)).Inc()
+	log.Printf("CreateSession of session %s (IMSI: %s) failed, policy: %s: %v",
+		aaaCtx.GetSessionId(), aaaCtx.GetImsi(), srv.createFailure, err)
+	if srv.createFailure == CreateSessionFailureDisconnect {
+		srv.removeAndDisconnect(aaaCtx, "create_session_failure_disconnect", protos.TerminateReason_POLICY)
+	}
+}
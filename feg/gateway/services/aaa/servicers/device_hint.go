@@ -0,0 +1,107 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSDstyle license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// package servcers implements WiFi AAA GRPC services
+package servicers
+
+import (
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"magma/feg/gateway/services/aaa"
+	"magma/feg/gateway/services/aaa/fingerprint"
+	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+// Device hint results
+const (
+	deviceHintApplied = "applied"
+	deviceHintPending = "pending"
+	deviceHintDropped = "dropped"
+)
+
+// SetDeviceHintTTL sets the time hints of UEs without a session wait for the UEs' sessions
+func (srv *accountingService) SetDeviceHintTTL(ttl time.Duration) {
+	srv.deviceHints.SetTTL(ttl)
+}
+
+// DeviceHint is an "inbound" RPC of device classification integrations (pipelined's DHCP fingerprinting, captive
+// portals' user agents) attaching the hint to the UE's session. Hints of UEs without a session are kept by MAC
+// address until the UE's session starts
+func (srv *accountingService) DeviceHint(
+	ctx context.Context, req *protos.DeviceHintRequest) (*protos.AcctResp, error) {
+
+	hint := &fingerprint.Hint{
+		Source: req.GetSource(),
+		Type:   req.GetDeviceType(),
+		OS:     req.GetOs(),
+		Vendor: req.GetVendor(),
+		Model:  req.GetModel(),
+	}
+	if err := hint.Validate(); err != nil {
+		metrics.DeviceHints.WithLabelValues(req.GetSource(), deviceHintDropped).Inc()
+		return &protos.AcctResp{}, status.Errorf(codes.InvalidArgument, "Invalid device hint: %v", err)
+	}
+	var s aaa.Session
+	if sid := req.GetSessionId(); len(sid) > 0 {
+		if s = srv.sessions.GetSession(sid); s == nil {
+			metrics.DeviceHints.WithLabelValues(hint.Source, deviceHintDropped).Inc()
+			return &protos.AcctResp{}, status.Errorf(codes.FailedPrecondition, "Session %s is not found", sid)
+		}
+	} else if len(req.GetMacAddr()) == 0 {
+		metrics.DeviceHints.WithLabelValues(hint.Source, deviceHintDropped).Inc()
+		return &protos.AcctResp{}, status.Errorf(codes.InvalidArgument, "Missing session ID & MAC address")
+	} else if s = srv.findSessionByMAC(req.GetMacAddr()); s == nil {
+		if !srv.deviceHints.Put(req.GetMacAddr(), hint) {
+			metrics.DeviceHints.WithLabelValues(hint.Source, deviceHintDropped).Inc()
+			return &protos.AcctResp{}, status.Errorf(
+				codes.ResourceExhausted, "Device hint of %s can not be kept pending", req.GetMacAddr())
+		}
+		metrics.DeviceHints.WithLabelValues(hint.Source, deviceHintPending).Inc()
+		return &protos.AcctResp{}, nil
+	}
+	srv.mergeAttributes(s, hint.Attributes())
+	metrics.DeviceHints.WithLabelValues(hint.Source, deviceHintApplied).Inc()
+	return &protos.AcctResp{}, nil
+}
+
+// applyPendingDeviceHint attaches the pending hint of the session's UE, if any, to the session
+func (srv *accountingService) applyPendingDeviceHint(s aaa.Session) {
+	if hint := srv.deviceHints.Take(s.GetCtx().GetMacAddr()); hint != nil {
+		srv.mergeAttributes(s, hint.Attributes())
+		metrics.DeviceHints.WithLabelValues(hint.Source, deviceHintApplied).Inc()
+	}
+}
+
+// deviceHint returns the device hint of the session, the stored session's context is preferred to the given one,
+// which may be a request's context without the session's hint
+func (srv *accountingService) deviceHint(aaaCtx *protos.Context) *fingerprint.Hint {
+	if s := srv.sessions.GetSession(aaaCtx.GetSessionId()); s != nil {
+		if hint := fingerprint.FromAttributes(s.GetCtx().GetAttributes()); hint != nil {
+			return hint
+		}
+	}
+	return fingerprint.FromAttributes(aaaCtx.GetAttributes())
+}
+
+// findSessionByMAC returns the session of the UE's MAC address or nil if not found or the sessions table can neither
+// look up nor list its sessions
+func (srv *accountingService) findSessionByMAC(mac string) aaa.Session {
+	key := aaa.MACKey(mac)
+	if len(key) == 0 {
+		return nil
+	}
+	if sessions := aaa.FindSessions(srv.sessions, aaa.MACIndex, key); len(sessions) > 0 {
+		return sessions[0]
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSDstyle license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// package servcers implements WiFi AAA GRPC services
+package servicers
+
+import (
+	"log"
+
+	"magma/feg/gateway/services/aaa/directory"
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+// SetDirectory enables publishing of the started sessions' UE addresses & access points to the directory, nil
+// disables it
+func (srv *accountingService) SetDirectory(p *directory.Publisher) {
+	srv.directory = p
+}
+
+// updateDirectory publishes the session's UE addresses & access point. The UE addresses reported by the NAS's
+// request, if any, replace the published ones, which replace the session's ones
+func (srv *accountingService) updateDirectory(aaaCtx, reported *protos.Context) {
+	if srv.directory == nil {
+		return
+	}
+	imsi, sid := normalizeImsi(aaaCtx.GetImsi()), aaaCtx.GetSessionId()
+	ap, ok := protos.APMAC(aaaCtx.CalledStationID())
+	if !ok {
+		ap = aaaCtx.CalledStationID()
+	}
+	r := directory.Record{SessionID: sid, MAC: aaaCtx.GetMacAddr(), AP: ap}
+	addrs, err := aaaCtx.UEAddresses()
+	if err != nil {
+		addrs = protos.UEAddresses{} // invalid session addresses are rejected by CreateSession
+	}
+	if published, ok := srv.directory.Get(imsi); ok && published.SessionID == sid {
+		addrs = protos.UEAddresses{IPv4: published.IPv4, IPv6: published.IPv6, IPv6Prefix: published.IPv6Prefix}
+	}
+	if len(reported.GetIpAddr()) > 0 || len(reported.GetIpv6Addr()) > 0 || len(reported.GetIpv6Prefix()) > 0 {
+		if addrs, err = reported.UEAddresses(); err != nil {
+			log.Printf("Invalid UE addresses reported for session %s: %v", sid, err)
+			return
+		}
+	}
+	r.IPv4, r.IPv6, r.IPv6Prefix = addrs.IPv4, addrs.IPv6, addrs.IPv6Prefix
+	srv.directory.Update(imsi, r)
+}
+
+// patchDirectory publishes the patched session's UE addresses & access point, if its record is published
+func (srv *accountingService) patchDirectory(aaaCtx *protos.Context) {
+	if srv.directory == nil {
+		return
+	}
+	if r, ok := srv.directory.Get(normalizeImsi(aaaCtx.GetImsi())); ok && r.SessionID == aaaCtx.GetSessionId() {
+		srv.updateDirectory(aaaCtx, aaaCtx) // the patched addresses replace the published ones
+	}
+}
+
+// removeDirectory deletes the ended session's directory record
+func (srv *accountingService) removeDirectory(sid string) {
+	if srv.directory != nil {
+		srv.directory.Remove(sid)
+	}
+}
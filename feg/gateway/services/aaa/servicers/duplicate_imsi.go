@@ -0,0 +1,150 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSDstyle license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// package servcers implements WiFi AAA GRPC services
+package servicers
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"magma/feg/gateway/services/aaa"
+	"magma/feg/gateway/services/aaa/audit"
+	"magma/feg/gateway/services/aaa/coalog"
+	"magma/feg/gateway/services/aaa/deadlines"
+	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/aaa/panics"
+	"magma/feg/gateway/services/aaa/protos"
+	"magma/feg/gateway/services/aaa/quarantine"
+)
+
+// DuplicateIMSIPolicy defines how a new session of a subscriber with a session from a different MAC or AP is handled
+type DuplicateIMSIPolicy string
+
+const (
+	// DuplicateIMSIPermit - both sessions are kept (e.g. multi-device subscriptions)
+	DuplicateIMSIPermit DuplicateIMSIPolicy = "permit"
+	// DuplicateIMSIRoam - the subscriber moved, the existing session is migrated to the new one: the existing
+	// session is removed & its NAS session disconnected, the session manager's session is kept
+	DuplicateIMSIRoam DuplicateIMSIPolicy = "roam"
+	// DuplicateIMSIClone - sessions of the same IMSI from different MACs are a cloned SIM: the new session is
+	// rejected, the existing session is ended & a security event is recorded. A new session from the existing
+	// session's MAC is handled as a roam
+	DuplicateIMSIClone DuplicateIMSIPolicy = "clone"
+)
+
+// ParseDuplicateIMSIPolicy returns the duplicate IMSI policy of the given name, empty name - DuplicateIMSIPermit
+func ParseDuplicateIMSIPolicy(name string) (DuplicateIMSIPolicy, error) {
+	switch policy := DuplicateIMSIPolicy(name); policy {
+	case "":
+		return DuplicateIMSIPermit, nil
+	case DuplicateIMSIPermit, DuplicateIMSIRoam, DuplicateIMSIClone:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("Unknown duplicate IMSI policy: '%s'", name)
+	}
+}
+
+// SetDuplicateIMSIPolicy sets the handling of new sessions of subscribers with a session from a different MAC or AP
+func (srv *accountingService) SetDuplicateIMSIPolicy(policy DuplicateIMSIPolicy) {
+	srv.duplicateIMSI = policy
+}
+
+// resolveDuplicateIMSI applies the duplicate IMSI policy to the newly authenticated session, it returns
+// PermissionDenied error if the new session must be rejected
+func (srv *accountingService) resolveDuplicateIMSI(aaaCtx *protos.Context) error {
+	if srv == nil || srv.sessions == nil || len(aaaCtx.GetImsi()) == 0 {
+		return nil // sessions without IMSI (e.g. of EAP-TLS certificates not mapped to IMSIs) aren't duplicates
+	}
+	sid := aaaCtx.GetSessionId()
+	existingSid := srv.sessions.FindSession(aaaCtx.GetImsi())
+	if len(existingSid) == 0 || existingSid == sid {
+		return nil
+	}
+	existing := srv.sessions.GetSession(existingSid)
+	if existing == nil {
+		return nil
+	}
+	existingCtx := existing.GetCtx()
+	sameMac := strings.EqualFold(existingCtx.GetMacAddr(), aaaCtx.GetMacAddr())
+	if sameMac && strings.EqualFold(existingCtx.GetApn(), aaaCtx.GetApn()) {
+		return nil // the same UE & AP, the new session replaces the existing one
+	}
+	policy := srv.duplicateIMSI
+	if policy == DuplicateIMSIClone && sameMac {
+		policy = DuplicateIMSIRoam
+	}
+	switch policy {
+	case DuplicateIMSIRoam:
+		metrics.DuplicateIMSIs.WithLabelValues(string(DuplicateIMSIRoam)).Inc()
+		log.Printf("IMSI %s roamed from session %s (MAC: %s, AP: %s) to session %s (MAC: %s, AP: %s)",
+			aaaCtx.GetImsi(), existingSid, existingCtx.GetMacAddr(), existingCtx.GetApn(),
+			sid, aaaCtx.GetMacAddr(), aaaCtx.GetApn())
+		srv.forgetSession(existingSid, audit.Terminate, srv.sessions.RemoveSession(existingSid))
+		go srv.disconnectDuplicate(existingCtx, false)
+		return nil
+	case DuplicateIMSIClone:
+		metrics.DuplicateIMSIs.WithLabelValues(string(DuplicateIMSIClone)).Inc()
+		if action, profile := srv.quarantine.Action(quarantine.TriggerClone); action == quarantine.ActionQuarantine {
+			log.Printf("SECURITY: IMSI %s clone detected; session %s (MAC: %s, AP: %s) & session %s (MAC: %s, AP: %s) "+
+				"are quarantined", aaaCtx.GetImsi(), existingSid, existingCtx.GetMacAddr(), existingCtx.GetApn(),
+				sid, aaaCtx.GetMacAddr(), aaaCtx.GetApn())
+			srv.auditEvent(audit.Clone, aaaCtx)
+			srv.auditEvent(audit.Clone, existingCtx)
+			// the new session is quarantined once it's started, the existing one right away
+			if err := aaaCtx.SetAttribute(quarantine.Attribute, string(quarantine.TriggerClone)); err != nil {
+				log.Printf("Session %s quarantine: %v", sid, err)
+			}
+			go srv.quarantineDuplicate(existing, profile)
+			return nil
+		}
+		log.Printf("SECURITY: IMSI %s clone detected; session %s (MAC: %s, AP: %s) & session %s (MAC: %s, AP: %s) "+
+			"are disconnected", aaaCtx.GetImsi(), existingSid, existingCtx.GetMacAddr(), existingCtx.GetApn(),
+			sid, aaaCtx.GetMacAddr(), aaaCtx.GetApn())
+		srv.auditEvent(audit.Clone, aaaCtx)
+		srv.auditEvent(audit.Clone, existingCtx)
+		srv.forgetSession(existingSid, audit.Terminate, srv.sessions.RemoveSession(existingSid))
+		go srv.disconnectDuplicate(existingCtx, srv.settings(existingCtx).accountingEnabled)
+		return status.Errorf(codes.PermissionDenied, "IMSI %s clone detected, session %s is rejected",
+			aaaCtx.GetImsi(), sid)
+	default:
+		metrics.DuplicateIMSIs.WithLabelValues(string(DuplicateIMSIPermit)).Inc()
+		return nil
+	}
+}
+
+// quarantineDuplicate moves the existing session of a cloned IMSI to the quarantine profile
+func (srv *accountingService) quarantineDuplicate(s aaa.Session, profile *quarantine.Profile) {
+	defer panics.Recover("duplicate_imsi_quarantine")
+	ctx, cancel := deadlines.Background()
+	defer cancel()
+	ctx = coalog.WithTrigger(ctx, "duplicate_imsi_quarantine", "")
+	srv.quarantineSession(ctx, s, quarantine.TriggerClone, profile)
+}
+
+// disconnectDuplicate disconnects the removed session's UE &, if endSession is set, ends the subscriber's session
+// in session manager
+func (srv *accountingService) disconnectDuplicate(aaaCtx *protos.Context, endSession bool) {
+	defer panics.Recover("duplicate_imsi_disconnect")
+	ctx, cancel := deadlines.Background()
+	defer cancel()
+	ctx = coalog.WithTrigger(ctx, "duplicate_imsi_disconnect", "")
+	var err error
+	if endSession {
+		err = srv.endSession(ctx, aaaCtx, protos.TerminateReason_POLICY)
+	} else {
+		err = srv.disconnect(ctx, aaaCtx, protos.TerminateReason_POLICY)
+	}
+	if err != nil {
+		log.Printf("Duplicate IMSI session %s disconnect failed: %v", aaaCtx.GetSessionId(), err)
+	}
+}
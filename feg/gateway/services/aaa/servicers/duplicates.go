@@ -0,0 +1,104 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// package servcers implements WiFi AAA GRPC services
+package servicers
+
+import (
+	"sync"
+	"time"
+
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+// alreadyTerminated - detail of the successful TerminateSession responses of sessions already stopped by their NAS
+const alreadyTerminated = "already terminated"
+
+// Duplicate accounting requests' Acct-Status-Types
+const (
+	duplicateStart = "start"
+	duplicateStop  = "stop"
+)
+
+// processedLinger - time a session's processed Start or Stop is remembered for, so the NAS's retransmissions of it
+// are acknowledged as duplicates & session manager's TerminateSession racing the NAS's Stop succeeds
+const processedLinger = time.Minute
+
+// eventTimeTolerance - maximum event time difference of a request's retransmissions in seconds, event times derived
+// from their Acct-Delay-Time are rounded to seconds
+const eventTimeTolerance = 1
+
+// processedTable - the sessions' recently processed NAS Starts or Stops by session ID, kept up to the linger time
+type processedTable struct {
+	sync.Mutex
+	sessions  map[string]processedRequest
+	nextPurge time.Time
+}
+
+type processedRequest struct {
+	imsi      string
+	eventTime uint64 // Unix seconds, 0 if unknown
+	processed time.Time
+}
+
+func newProcessedTable() *processedTable {
+	return &processedTable{sessions: map[string]processedRequest{}}
+}
+
+// add remembers the session's processed request of the given event time
+func (t *processedTable) add(sid, imsi string, eventTime uint64) {
+	now := time.Now()
+	t.Lock()
+	t.purge(now)
+	t.sessions[sid] = processedRequest{imsi: imsi, eventTime: eventTime, processed: now}
+	t.Unlock()
+}
+
+// get returns the session's request processed within the linger time, if any
+func (t *processedTable) get(sid string) (processedRequest, bool) {
+	t.Lock()
+	defer t.Unlock()
+	p, ok := t.sessions[sid]
+	if !ok || time.Since(p.processed) >= processedLinger {
+		return processedRequest{}, false
+	}
+	return p, true
+}
+
+// isDuplicate returns true if the session's request of the given event time was processed within the linger time,
+// requests without event times are duplicates of processed requests without event times
+func (t *processedTable) isDuplicate(sid string, eventTime uint64) bool {
+	p, ok := t.get(sid)
+	if !ok {
+		return false
+	}
+	if p.eventTime == 0 || eventTime == 0 {
+		return p.eventTime == eventTime
+	}
+	return p.eventTime <= eventTime+eventTimeTolerance && eventTime <= p.eventTime+eventTimeTolerance
+}
+
+// purge deletes the requests processed past the linger time, at most once per linger time. It must be called with
+// the lock held
+func (t *processedTable) purge(now time.Time) {
+	if now.Before(t.nextPurge) {
+		return
+	}
+	t.nextPurge = now.Add(processedLinger)
+	for sid, p := range t.sessions {
+		if now.Sub(p.processed) >= processedLinger {
+			delete(t.sessions, sid)
+		}
+	}
+}
+
+// requestEventTime returns the event time of the NAS's accounting request, 0 if the NAS didn't report it
+func requestEventTime(aaaCtx *protos.Context) uint64 {
+	eventTime, _ := aaaCtx.GetUintAttribute(protos.EventTimeAttribute)
+	return eventTime
+}
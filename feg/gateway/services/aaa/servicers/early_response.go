@@ -0,0 +1,115 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// package servcers implements WiFi AAA GRPC services
+package servicers
+
+import (
+	"log"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"magma/feg/gateway/services/aaa/deadlines"
+	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/aaa/panics"
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+// Early Accounting-Responses' results
+const (
+	earlyResponded   = "responded"
+	earlyLateSuccess = "late_success"
+	earlyLateFailure = "late_failure"
+)
+
+// SetResponseDeadline enables early Accounting-Responses: Accounting Starts & Stops not completed within the deadline
+// are acknowledged to the NAS, so it doesn't fail over to its secondary accounting server, while their backend calls
+// complete asynchronously. Sessions of late failed Starts are disconnected. 0 disables early responses
+func (srv *accountingService) SetResponseDeadline(deadline time.Duration) {
+	srv.responseDeadline = deadline
+}
+
+// respondWithin returns the call's result if the call completes within the response deadline, otherwise it returns
+// the session's Accounting-Response & the call completes with its own deadline. A late failure of the session's
+// call is corrected by disconnecting the session if correct is true
+func (srv *accountingService) respondWithin(
+	ctx context.Context,
+	method string,
+	aaaCtx *protos.Context,
+	correct bool,
+	call func(context.Context) (*protos.AcctResp, error)) (*protos.AcctResp, error) {
+
+	if srv.responseDeadline <= 0 {
+		return call(ctx)
+	}
+	callCtx, cancel := context.WithTimeout(detached{ctx}, deadlines.GetDefaultTimeout())
+	type result struct {
+		resp *protos.AcctResp
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		res := result{err: status.Errorf(codes.Internal, "Internal error in Accounting %s", method)}
+		defer func() { done <- res }() // a panicking call fails, the deferred send follows panics.Recover
+		defer panics.Recover("early_response")
+		res.resp, res.err = call(callCtx)
+	}()
+	timer := time.NewTimer(srv.responseDeadline)
+	defer timer.Stop()
+	select {
+	case res := <-done:
+		cancel()
+		return res.resp, res.err
+	case <-timer.C:
+	}
+
+	metrics.EarlyAcctResponses.WithLabelValues(method, earlyResponded).Inc()
+	sid := aaaCtx.GetSessionId()
+	resp := srv.acctResp(aaaCtx)
+	if s := srv.sessions.GetSession(sid); s != nil {
+		resp = srv.acctResp(s.GetCtx())
+	}
+	go func() {
+		defer panics.Recover("early_response_completion")
+		res := <-done
+		cancel()
+		if res.err == nil {
+			metrics.EarlyAcctResponses.WithLabelValues(method, earlyLateSuccess).Inc()
+			return
+		}
+		metrics.EarlyAcctResponses.WithLabelValues(method, earlyLateFailure).Inc()
+		log.Printf("Early responded Accounting %s of session %s failed: %v", method, sid, res.err)
+		if !correct {
+			return
+		}
+		if s := srv.sessions.GetSession(sid); s != nil {
+			srv.disconnectUnauthorized(s.GetCtx())
+		} else if !disconnectsSession(res.err) {
+			srv.disconnectUnauthorized(aaaCtx) // the NAS's session was acknowledged, but it's not authenticated
+		}
+	}()
+	return resp, nil
+}
+
+// disconnectsSession returns true if the Start's error already disconnected the session
+func disconnectsSession(err error) bool {
+	return status.Code(err) == codes.PermissionDenied || protos.GetCapacityError(err) != nil
+}
+
+// detached - context keeping the values (e.g. metadata) of its parent, but not its deadline or cancellation, for
+// calls outliving their RPC
+type detached struct {
+	context.Context
+}
+
+func (detached) Deadline() (deadline time.Time, ok bool) { return }
+func (detached) Done() <-chan struct{}                   { return nil }
+func (detached) Err() error                              { return nil }
@@ -0,0 +1,51 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// package servcers implements WiFi AAA GRPC services
+package servicers
+
+import (
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"magma/feg/gateway/registry"
+	"magma/feg/gateway/services/aaa/adminauth"
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+// DumpEffectiveConfig returns the AAA service's effective configuration & the sources of its settings
+func (srv *sessionAdminService) DumpEffectiveConfig(
+	ctx context.Context, _ *protos.DumpEffectiveConfigRequest) (*protos.EffectiveConfig, error) {
+
+	if err := srv.authorize(ctx, "DumpEffectiveConfig", adminauth.ReadOnly); err != nil {
+		return nil, err
+	}
+	if srv.config == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "Effective configuration is not available")
+	}
+	effective := srv.config.Effective()
+	res := &protos.EffectiveConfig{Service: registry.AAA_SERVER, Settings: make([]*protos.Setting, 0, len(effective))}
+	for _, s := range effective {
+		res.Settings = append(res.Settings, &protos.Setting{Name: s.Name, Value: s.Value, Source: string(s.Source)})
+	}
+	return res, nil
+}
+
+// GetServiceInfo returns the AAA service's version, build, configuration hash, non default settings & loaded modules
+func (srv *sessionAdminService) GetServiceInfo(
+	ctx context.Context, _ *protos.GetServiceInfoRequest) (*protos.ServiceInfo, error) {
+
+	if err := srv.authorize(ctx, "GetServiceInfo", adminauth.ReadOnly); err != nil {
+		return nil, err
+	}
+	if srv.info == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "Service info is not available")
+	}
+	return srv.info(), nil
+}
@@ -0,0 +1,103 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// package servcers implements WiFi AAA GRPC services
+package servicers
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+// EventTimestampAction defines how accounting requests with Event-Timestamps skewed beyond the maximum are handled
+type EventTimestampAction string
+
+const (
+	// EventTimestampFlag - skewed requests are counted & logged, but processed
+	EventTimestampFlag EventTimestampAction = "flag"
+	// EventTimestampReject - skewed requests are rejected, i.e. not answered by Radius
+	EventTimestampReject EventTimestampAction = "reject"
+)
+
+// unknownNAS - NAS label of the requests without NAS-Identifier & NAS address
+const unknownNAS = "unknown"
+
+// eventTimestampPolicy - maximum Event-Timestamp skew of accounting requests & the action on requests skewed beyond it
+type eventTimestampPolicy struct {
+	maxSkew time.Duration
+	action  EventTimestampAction
+}
+
+// ParseEventTimestampAction returns the Event-Timestamp action of the given name, empty name - EventTimestampFlag
+func ParseEventTimestampAction(name string) (EventTimestampAction, error) {
+	switch action := EventTimestampAction(name); action {
+	case "":
+		return EventTimestampFlag, nil
+	case EventTimestampFlag, EventTimestampReject:
+		return action, nil
+	default:
+		return "", fmt.Errorf("Unknown Event-Timestamp action: '%s'", name)
+	}
+}
+
+// SetEventTimestampPolicy enables Event-Timestamp freshness validation of accounting requests: requests whose
+// Event-Timestamp is skewed more than maxSkew from their arrival are flagged or rejected. The skews of all requests
+// with Event-Timestamps are metered once a policy is set, non positive maxSkew disables the validation
+func (srv *accountingService) SetEventTimestampPolicy(maxSkew time.Duration, action EventTimestampAction) {
+	if maxSkew <= 0 {
+		srv.eventTimestamps = nil
+		return
+	}
+	srv.eventTimestamps = &eventTimestampPolicy{maxSkew: maxSkew, action: action}
+}
+
+// checkEventTimestamp meters the Event-Timestamp skew of the NAS's accounting request & returns an error if the
+// request is skewed beyond the maximum & must be rejected. Requests without Event-Timestamps are always accepted
+func (srv *accountingService) checkEventTimestamp(method string, aaaCtx *protos.Context) error {
+	policy := srv.eventTimestamps
+	if policy == nil {
+		return nil
+	}
+	seconds, ok := aaaCtx.GetIntAttribute(protos.EventSkewAttribute)
+	if !ok {
+		return nil
+	}
+	nas := nasLabel(aaaCtx)
+	metrics.EventTimestampSkew.WithLabelValues(nas).Observe(float64(seconds))
+	skew := time.Duration(seconds) * time.Second
+	if skew <= policy.maxSkew && skew >= -policy.maxSkew {
+		return nil
+	}
+	metrics.StaleAcctRequests.WithLabelValues(nas, string(policy.action)).Inc()
+	log.Printf("Accounting %s of session %s from NAS %s has Event-Timestamp skewed by %v, max: %v, action: %s",
+		method, aaaCtx.GetSessionId(), nas, skew, policy.maxSkew, policy.action)
+	if policy.action == EventTimestampReject {
+		return status.Errorf(codes.InvalidArgument, "Accounting %s: Session %s Event-Timestamp is skewed by %v",
+			method, aaaCtx.GetSessionId(), skew)
+	}
+	return nil
+}
+
+// nasLabel returns the metrics label of the request's NAS: its NAS-Identifier or host, ports aren't included since
+// NASes may send every request from another port
+func nasLabel(aaaCtx *protos.Context) string {
+	if nasID, ok := aaaCtx.GetAttribute(protos.NASIdentifierAttribute); ok && len(nasID) > 0 {
+		return nasID
+	}
+	if addr, ok := aaaCtx.GetAttribute(protos.NASAddressAttribute); ok && len(addr) > 0 {
+		return nasHost(addr)
+	}
+	return unknownNAS
+}
@@ -0,0 +1,94 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSDstyle license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// package servcers implements WiFi AAA GRPC services
+package servicers
+
+import (
+	"log"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"magma/feg/gateway/services/aaa/coalog"
+	"magma/feg/gateway/services/aaa/deadlines"
+	"magma/feg/gateway/services/aaa/failuremode"
+	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/aaa/panics"
+	"magma/feg/gateway/services/aaa/protos"
+	lte_protos "magma/lte/cloud/go/protos"
+)
+
+// SetFailureModes sets the actions of sessions created in Gx/Gy failure modes, nil - all such sessions are allowed
+func (srv *accountingService) SetFailureModes(cfg *failuremode.Config) {
+	srv.failureModes = cfg
+}
+
+// applyFailureModes applies the configured action to the session created in session manager's Gx/Gy failure modes:
+// the session is flagged for reconciliation, moved to the restricted profile or ended in session manager & denied
+func (srv *accountingService) applyFailureModes(
+	ctx context.Context, aaaCtx *protos.Context, res *lte_protos.LocalCreateSessionResponse) error {
+
+	action, modes := srv.failureModes.Decide(
+		failuremode.ModeOf(res.GetGxMode()), failuremode.ModeOf(res.GetGyMode()))
+	if len(modes) == 0 {
+		return nil
+	}
+	sid := aaaCtx.GetSessionId()
+	metrics.FailureModeSessions.WithLabelValues(modes, string(action)).Inc()
+	log.Printf("Session %s (IMSI: %s) was created in %s mode, action: %s", sid, aaaCtx.GetImsi(), modes, action)
+
+	switch action {
+	case failuremode.ActionDeny:
+		if err := srv.endManagedSession(ctx, aaaCtx); err != nil {
+			log.Printf("Failed to end denied session %s in session manager: %v", sid, err)
+		}
+		return status.Errorf(codes.PermissionDenied, "Session %s is denied in %s mode", sid, modes)
+	case failuremode.ActionFlag, failuremode.ActionRestrict:
+		if s := srv.sessions.GetSession(sid); s != nil {
+			srv.mergeAttributes(s, map[string]string{failuremode.Attribute: modes})
+		}
+	}
+	if action == failuremode.ActionRestrict {
+		go srv.restrictSession(aaaCtx, srv.failureModes.Profile)
+	}
+	return nil
+}
+
+// restrictSession moves the session to the restricted profile via Radius CoA, the profile's bandwidth becomes the
+// session's base bandwidth
+func (srv *accountingService) restrictSession(aaaCtx *protos.Context, profile *failuremode.Profile) {
+	defer panics.Recover("failure_mode_coa")
+	sid := aaaCtx.GetSessionId()
+	ctx, cancel := deadlines.Background()
+	defer cancel()
+	ctx = coalog.WithTrigger(ctx, "failure_mode_coa", "")
+	resp, err := srv.newAuthorizationClient().Change(ctx, profile.Change(aaaCtx))
+	if err == nil && resp.GetCoaResponseType() != protos.CoaResponse_ACK {
+		err = status.Errorf(codes.Aborted, "CoA was rejected")
+	}
+	if err != nil {
+		log.Printf("Restriction CoA for session %s failed: %v", sid, err)
+		return
+	}
+	if profile.MaxBandwidthUp > 0 {
+		srv.bandwidths.setBase(sid, bandwidth{up: profile.MaxBandwidthUp, down: profile.MaxBandwidthDown})
+	}
+	log.Printf("Session %s is restricted", sid)
+}
+
+// flagged returns true if the session was flagged for reconciliation by its Gx/Gy failure modes
+func (srv *accountingService) flagged(sid string) bool {
+	s := srv.sessions.GetSession(sid)
+	if s == nil {
+		return false
+	}
+	_, ok := s.GetCtx().GetAttribute(failuremode.Attribute)
+	return ok
+}
@@ -0,0 +1,109 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSDstyle license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// package servcers implements WiFi AAA GRPC services
+package servicers
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"magma/feg/gateway/services/aaa/audit"
+	"magma/feg/gateway/services/aaa/coalog"
+	"magma/feg/gateway/services/aaa/deadlines"
+	"magma/feg/gateway/services/aaa/guest"
+	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/aaa/panics"
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+// Guest sessions' events
+const (
+	guestAdmitted     = "admitted"
+	guestExpired      = "expired"
+	guestExpiryFailed = "expiry_failed"
+)
+
+// guestTable keeps guest sessions' scheduled expirations
+type guestTable struct {
+	sync.Mutex
+	expirations map[string]*time.Timer
+}
+
+func newGuestTable() *guestTable {
+	return &guestTable{expirations: map[string]*time.Timer{}}
+}
+
+// remove forgets the session & cancels its scheduled expiration if any
+func (t *guestTable) remove(sid string) {
+	t.Lock()
+	if timer, ok := t.expirations[sid]; ok {
+		timer.Stop()
+		delete(t.expirations, sid)
+	}
+	t.Unlock()
+}
+
+// SetGuestSessions enables time limited guest sessions, nil disables them
+func (srv *accountingService) SetGuestSessions(cfg *guest.Config) {
+	srv.guests = cfg
+}
+
+// classifyGuest marks the authenticated session as a guest session with its Session-Timeout, if its APN or realm is
+// a guest APN or realm, & schedules its expiration
+func (srv *accountingService) classifyGuest(aaaCtx *protos.Context) {
+	if srv == nil || srv.guests == nil {
+		return
+	}
+	isGuest, err := srv.guests.Classify(aaaCtx)
+	if err != nil {
+		log.Printf("Error classifying guest session %s: %v", aaaCtx.GetSessionId(), err)
+	}
+	if isGuest {
+		metrics.GuestSessions.WithLabelValues(aaaCtx.GetApn(), guestAdmitted).Inc()
+		srv.scheduleGuestExpiration(aaaCtx)
+	}
+}
+
+// scheduleGuestExpiration schedules the guest session's termination after its maximum duration, sessions with a
+// scheduled expiration keep it, so the maximum duration counts from the session's authentication
+func (srv *accountingService) scheduleGuestExpiration(aaaCtx *protos.Context) {
+	if srv.guests == nil || !guest.IsGuestSession(aaaCtx) {
+		return
+	}
+	sid := aaaCtx.GetSessionId()
+	t := srv.guestSessions
+	t.Lock()
+	if _, ok := t.expirations[sid]; !ok {
+		t.expirations[sid] = time.AfterFunc(srv.guests.MaxDuration+guest.Grace, func() { srv.expireGuest(sid) })
+	}
+	t.Unlock()
+}
+
+// expireGuest removes the guest session, ends it in session manager & disconnects its UE
+func (srv *accountingService) expireGuest(sid string) {
+	defer panics.Recover("guest_expiration")
+	s := srv.sessions.RemoveSession(sid)
+	srv.forgetSession(sid, audit.Terminate, s)
+	if s == nil {
+		return
+	}
+	aaaCtx := s.GetCtx()
+	log.Printf("Guest session %s (IMSI: %s) reached its maximum duration %v, terminating",
+		sid, aaaCtx.GetImsi(), srv.guests.MaxDuration)
+	ctx, cancel := deadlines.Background()
+	defer cancel()
+	ctx = coalog.WithTrigger(ctx, "guest_expiration", "")
+	if err := srv.endSession(ctx, aaaCtx, protos.TerminateReason_GUEST_TIME_LIMIT); err != nil {
+		metrics.GuestSessions.WithLabelValues(aaaCtx.GetApn(), guestExpiryFailed).Inc()
+		log.Printf("Guest session %s termination failed: %v", sid, err)
+		return
+	}
+	metrics.GuestSessions.WithLabelValues(aaaCtx.GetApn(), guestExpired).Inc()
+}
@@ -0,0 +1,127 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSDstyle license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// package servcers implements WiFi AAA GRPC services
+package servicers
+
+import (
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+// DefaultHandoverWindow - time a signaled LTE to Wi-Fi handover waits for the subscriber's Wi-Fi session
+const DefaultHandoverWindow = 30 * time.Second
+
+// HandoverSessionAttribute - context attribute of Wi-Fi sessions continuing an LTE session, set to its session ID
+const HandoverSessionAttribute = "handover_session_id"
+
+// Handover results
+const (
+	handoverSignaled = "signaled"
+	handoverReused   = "reused"
+	handoverExpired  = "expired"
+)
+
+type handover struct {
+	apn       string
+	sessionID string
+	expires   time.Time
+}
+
+// handoverTable keeps signaled LTE to Wi-Fi handovers by IMSI, pending the subscriber's Wi-Fi session
+type handoverTable struct {
+	sync.Mutex
+	window  time.Duration
+	pending map[string]handover
+}
+
+func newHandoverTable() *handoverTable {
+	return &handoverTable{window: DefaultHandoverWindow, pending: map[string]handover{}}
+}
+
+// SetHandoverWindow sets the time a signaled handover waits for the subscriber's Wi-Fi session
+func (srv *accountingService) SetHandoverWindow(window time.Duration) {
+	srv.handovers.Lock()
+	srv.handovers.window = window
+	srv.handovers.Unlock()
+}
+
+// Handover is an "inbound" RPC from session manager to notify accounting of an upcoming LTE to Wi-Fi handover, the
+// subscriber's next Wi-Fi session continues the LTE session's charging context instead of creating a new one
+func (srv *accountingService) Handover(ctx context.Context, req *protos.HandoverRequest) (*protos.AcctResp, error) {
+	imsi := strings.TrimPrefix(req.GetImsi(), imsiPrefix)
+	if len(imsi) == 0 {
+		return &protos.AcctResp{}, status.Errorf(codes.InvalidArgument, "Missing IMSI")
+	}
+	t := srv.handovers
+	t.Lock()
+	now := time.Now()
+	for pendingImsi, h := range t.pending {
+		if now.After(h.expires) {
+			delete(t.pending, pendingImsi)
+			metrics.Handovers.WithLabelValues(handoverExpired).Inc()
+		}
+	}
+	t.pending[imsi] = handover{apn: req.GetApn(), sessionID: req.GetSessionId(), expires: now.Add(t.window)}
+	t.Unlock()
+	metrics.Handovers.WithLabelValues(handoverSignaled).Inc()
+	log.Printf("LTE to Wi-Fi handover of IMSI%s (LTE session: %s) is pending", imsi, req.GetSessionId())
+	return &protos.AcctResp{}, nil
+}
+
+// takeHandover returns the pending handover of the Wi-Fi session's subscriber & true if the session continues the
+// charging context of the subscriber's LTE session
+func (srv *accountingService) takeHandover(aaaCtx *protos.Context) (handover, bool) {
+	imsi := strings.TrimPrefix(aaaCtx.GetImsi(), imsiPrefix)
+	t := srv.handovers
+	t.Lock()
+	defer t.Unlock()
+	h, ok := t.pending[imsi]
+	if !ok {
+		return h, false
+	}
+	if time.Now().After(h.expires) {
+		delete(t.pending, imsi)
+		metrics.Handovers.WithLabelValues(handoverExpired).Inc()
+		return h, false
+	}
+	if len(h.apn) > 0 && !strings.EqualFold(h.apn, aaaCtx.GetApn()) {
+		return h, false
+	}
+	delete(t.pending, imsi)
+	metrics.Handovers.WithLabelValues(handoverReused).Inc()
+	return h, true
+}
+
+// continueHandover returns true if the new Wi-Fi session continues the charging context of its subscriber's LTE
+// session, the session manager's session of the subscriber is then kept rather than created
+func (srv *accountingService) continueHandover(aaaCtx *protos.Context) bool {
+	s := srv.sessions.GetSession(aaaCtx.GetSessionId())
+	if s != nil {
+		aaaCtx = s.GetCtx()
+	}
+	h, ok := srv.takeHandover(aaaCtx)
+	if !ok {
+		return false
+	}
+	if s != nil && len(h.sessionID) > 0 {
+		srv.mergeAttributes(s, map[string]string{HandoverSessionAttribute: h.sessionID})
+	}
+	log.Printf("Session %s continues LTE session %s of IMSI%s after handover",
+		aaaCtx.GetSessionId(), h.sessionID, strings.TrimPrefix(aaaCtx.GetImsi(), imsiPrefix))
+	return true
+}
@@ -0,0 +1,129 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSDstyle license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// package servcers implements WiFi AAA GRPC services
+package servicers
+
+import (
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"magma/feg/gateway/services/aaa/deadlines"
+	"magma/feg/gateway/services/aaa/metrics"
+)
+
+// Traffic check results
+const (
+	trafficActive = "active"
+	trafficIdle   = "idle"
+	trafficError  = "error"
+)
+
+// minTrafficIdleTimeout - idle timeout of sessions past their traffic idle timeout
+const minTrafficIdleTimeout = time.Second
+
+// TrafficSource returns subscribers' cumulative traffic counters by IMSI
+type TrafficSource func(ctx context.Context) (map[string]uint64, error)
+
+// trafficTable keeps subscribers' traffic counters of the previous traffic check
+type trafficTable struct {
+	sync.Mutex
+	last map[string]uint64
+}
+
+// MonitorTraffic checks subscribers' traffic every interval & restarts the idle timeouts of the sessions of
+// subscribers with traffic since the previous check, so sessions of NASes with long (or no) Acct-Interim-Intervals
+// only time out after true traffic inactivity. MonitorTraffic never returns
+func (srv *accountingService) MonitorTraffic(source TrafficSource, interval time.Duration) {
+	if interval >= srv.sessionTout {
+		log.Printf("Traffic check interval %v is not shorter than idle session timeout %v", interval, srv.sessionTout)
+	}
+	t := &trafficTable{last: map[string]uint64{}}
+	for range time.Tick(interval) {
+		srv.checkTraffic(t, source)
+	}
+}
+
+// checkTraffic restarts the idle timeouts of the sessions of subscribers with traffic since the previous check
+func (srv *accountingService) checkTraffic(t *trafficTable, source TrafficSource) {
+	ctx, cancel := deadlines.Background()
+	defer cancel()
+	traffic, err := source(ctx)
+	if err != nil {
+		metrics.TrafficChecks.WithLabelValues(trafficError).Inc()
+		log.Printf("Traffic check error: %v", err)
+		return
+	}
+	last := make(map[string]uint64, len(traffic))
+	t.Lock()
+	defer t.Unlock()
+	for imsi, bytes := range traffic {
+		imsi = strings.TrimPrefix(imsi, imsiPrefix)
+		last[imsi] = bytes
+		previous, known := t.last[imsi]
+		if !known {
+			continue
+		}
+		// counters may also be reset (e.g. flows reinstalled), any change is the subscriber's activity
+		if previous == bytes {
+			metrics.TrafficChecks.WithLabelValues(trafficIdle).Inc()
+		} else if srv.touchSession(imsi) {
+			metrics.TrafficChecks.WithLabelValues(trafficActive).Inc()
+		}
+	}
+	t.last = last
+}
+
+// touchSession restarts the idle timeout of the subscriber's session, it returns false if the subscriber has no
+// session
+func (srv *accountingService) touchSession(imsi string) bool {
+	sid := srv.sessions.FindSession(imsi)
+	if len(sid) == 0 {
+		sid = srv.sessions.FindSession(imsiPrefix + imsi)
+	}
+	if len(sid) == 0 {
+		return false
+	}
+	s := srv.sessions.GetSession(sid)
+	if s == nil || !srv.sessions.SetTimeout(sid, srv.settings(s.GetCtx()).idleTimeout, srv.timeoutSessionNotifier) {
+		return false
+	}
+	srv.usage.touch(sid)
+	return true
+}
+
+// SetTrafficIdleTimeout sets the idle timeout of sessions whose Interim-Updates report no traffic: Interim-Updates
+// with traffic restart the idle session timeout, Interim-Updates without traffic don't extend it past the session's
+// last traffic plus the traffic idle timeout, so associated but idle devices are cleaned up. 0 - all Interim-Updates
+// restart the idle session timeout
+func (srv *accountingService) SetTrafficIdleTimeout(tout time.Duration) {
+	if tout > srv.sessionTout {
+		log.Printf("Traffic idle timeout %v is longer than idle session timeout %v", tout, srv.sessionTout)
+	}
+	srv.trafficTout = tout
+}
+
+// interimTimeout returns the idle timeout restarted by the Interim-Update of the given usage of a session with the
+// given idle session timeout
+func (srv *accountingService) interimTimeout(idle time.Duration, usage localUsage) time.Duration {
+	if srv.trafficTout <= 0 {
+		return idle
+	}
+	tout := srv.trafficTout - time.Since(usage.active)
+	if tout >= idle {
+		return idle
+	}
+	if tout < minTrafficIdleTimeout {
+		tout = minTrafficIdleTimeout // the session times out right after the Interim-Update
+	}
+	return tout
+}
@@ -0,0 +1,45 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSDstyle license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// package servcers implements WiFi AAA GRPC services
+package servicers
+
+import (
+	"strings"
+
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+// DefaultInterimIntervalAPN - interim intervals key of APNs without their own interval
+const DefaultInterimIntervalAPN = "*"
+
+// emptyAcctResp the accounting response of sessions without a desired Acct-Interim-Interval
+var emptyAcctResp = &protos.AcctResp{}
+
+// SetAcctInterimIntervals sets desired Acct-Interim-Intervals (in seconds) of sessions by their APN, returned to
+// the NAS in Accounting-Responses, nil disables them
+func (srv *accountingService) SetAcctInterimIntervals(intervals map[string]uint32) {
+	resps := map[string]*protos.AcctResp{}
+	for apn, interval := range intervals {
+		resps[strings.ToLower(apn)] = &protos.AcctResp{AcctInterimInterval: interval}
+	}
+	srv.acctResps = resps
+}
+
+// acctResp returns the accounting response of the session. Responses are shared by all sessions of an APN, so
+// high rate Interim-Updates don't allocate a response each, they are read only & must never be modified
+func (srv *accountingService) acctResp(aaaCtx *protos.Context) *protos.AcctResp {
+	resp, ok := srv.acctResps[strings.ToLower(aaaCtx.GetApn())]
+	if !ok {
+		resp, ok = srv.acctResps[DefaultInterimIntervalAPN]
+	}
+	if !ok {
+		return emptyAcctResp
+	}
+	return resp
+}
@@ -0,0 +1,111 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSDstyle license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// package servcers implements WiFi AAA GRPC services
+package servicers
+
+import (
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"magma/feg/cloud/go/protos/mconfig"
+	"magma/feg/gateway/services/aaa/audit"
+	"magma/feg/gateway/services/aaa/coalog"
+	"magma/feg/gateway/services/aaa/deadlines"
+	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/aaa/panics"
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+// lifetimeTable keeps sessions' maximum durations & their scheduled expirations
+type lifetimeTable struct {
+	sync.Mutex
+	maxDuration time.Duration            // sessions' maximum duration, 0 - unlimited
+	apnDuration map[string]time.Duration // maximum durations of APNs overriding maxDuration, by lower case APN
+	expirations map[string]*time.Timer
+}
+
+func newLifetimeTable(cfg *mconfig.AAAConfig) *lifetimeTable {
+	t := &lifetimeTable{
+		maxDuration: time.Millisecond * time.Duration(cfg.GetMaxSessionDurationMs()),
+		apnDuration: map[string]time.Duration{},
+		expirations: map[string]*time.Timer{},
+	}
+	for apn, ms := range cfg.GetApnMaxSessionDurationMs() {
+		t.apnDuration[strings.ToLower(apn)] = time.Millisecond * time.Duration(ms)
+	}
+	// the APN overrides' durations take precedence
+	for apn, override := range cfg.GetApnOverrides() {
+		if ms := override.GetMaxSessionDurationMs(); ms > 0 {
+			t.apnDuration[strings.ToLower(apn)] = time.Millisecond * time.Duration(ms)
+		}
+	}
+	return t
+}
+
+// duration returns the maximum duration of the APN's sessions, 0 - unlimited
+func (t *lifetimeTable) duration(apn string) time.Duration {
+	if d, ok := t.apnDuration[strings.ToLower(apn)]; ok {
+		return d
+	}
+	return t.maxDuration
+}
+
+// remove forgets the session & cancels its scheduled expiration if any
+func (t *lifetimeTable) remove(sid string) {
+	t.Lock()
+	if timer, ok := t.expirations[sid]; ok {
+		timer.Stop()
+		delete(t.expirations, sid)
+	}
+	t.Unlock()
+}
+
+// scheduleLifetimeExpiration schedules the session's termination after its APN's maximum duration regardless of its
+// activity, sessions with a scheduled expiration keep it, so the maximum duration counts from the first
+// authentication & re-authentication doesn't extend it
+func (srv *accountingService) scheduleLifetimeExpiration(aaaCtx *protos.Context) {
+	if srv == nil || srv.lifetimes == nil {
+		return
+	}
+	t := srv.lifetimes
+	d := t.duration(aaaCtx.GetApn())
+	if d <= 0 {
+		return
+	}
+	sid := aaaCtx.GetSessionId()
+	t.Lock()
+	if _, ok := t.expirations[sid]; !ok {
+		t.expirations[sid] = time.AfterFunc(d, func() { srv.expireLifetime(sid, d) })
+	}
+	t.Unlock()
+}
+
+// expireLifetime removes the session which reached its maximum duration, ends it in session manager & disconnects
+// its UE the same way as timed out sessions are ended
+func (srv *accountingService) expireLifetime(sid string, d time.Duration) {
+	defer panics.Recover("lifetime_expiration")
+	s := srv.sessions.RemoveSession(sid)
+	srv.forgetSession(sid, audit.Timeout, s)
+	if s == nil {
+		return
+	}
+	aaaCtx := s.GetCtx()
+	log.Printf("Session %s (IMSI: %s) reached its maximum duration %v, terminating", sid, aaaCtx.GetImsi(), d)
+	ctx, cancel := deadlines.Background()
+	defer cancel()
+	ctx = coalog.WithTrigger(ctx, "lifetime_expiration", "")
+	if err := srv.endSession(ctx, aaaCtx, protos.TerminateReason_SESSION_LIFETIME); err != nil {
+		metrics.SessionLifetimeExpirations.WithLabelValues(aaaCtx.GetApn(), "failure").Inc()
+		log.Printf("Session %s termination after its maximum duration failed: %v", sid, err)
+		return
+	}
+	metrics.SessionLifetimeExpirations.WithLabelValues(aaaCtx.GetApn(), "success").Inc()
+}
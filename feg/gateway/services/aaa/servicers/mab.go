@@ -0,0 +1,79 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// package servcers implements WiFi AAA GRPC services
+package servicers
+
+import (
+	"log"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"magma/feg/gateway/services/aaa/audit"
+	"magma/feg/gateway/services/aaa/mab"
+	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+// mabMethod - the method label of MAC Authentication Bypass authentication metrics
+const mabMethod = "MAB"
+
+// SetMACAllowList sets the allow-list of the devices authenticated by MAC Authentication Bypass, MAB also requires
+// the mconfig's MacAuthBypass. Nil disables MAB
+func (srv *accountingService) SetMACAllowList(l mab.AllowList) {
+	srv.macAllowList = l
+}
+
+// MacAuthBypass authenticates a non-EAP device by its context's MAC address (MAC Authentication Bypass) & returns
+// the authenticated session's context, its identity is the device's synthetic identity & its IMSI is the allow-listed
+// subscriber's. Devices not in the MAC allow-list are denied. The authenticated sessions are accounted like
+// EAP authenticated sessions
+func (srv *eapAuth) MacAuthBypass(ctx context.Context, aaaCtx *protos.Context) (*protos.Context, error) {
+	started := audit.Now()
+	if !srv.config.GetMacAuthBypass() || srv.accounting == nil || srv.accounting.macAllowList == nil {
+		return aaaCtx, status.Errorf(codes.FailedPrecondition, "MAC Authentication Bypass is disabled")
+	}
+	mac, err := mab.NormalizeMAC(aaaCtx.GetMacAddr())
+	if err != nil {
+		return aaaCtx, status.Errorf(codes.InvalidArgument, "MAC Authentication Bypass of session %s: %v",
+			aaaCtx.GetSessionId(), err)
+	}
+	if err = srv.accounting.throttle("MacAuthBypass", aaaCtx); err != nil {
+		return aaaCtx, err
+	}
+	srv.accounting.aliasAPN(aaaCtx)
+	device, err := srv.accounting.macAllowList.Lookup(ctx, mac)
+	if err == mab.ErrNotAllowed {
+		metrics.Auth.WithLabelValues(protos.EapCode_Failure.String(), mabMethod, aaaCtx.GetApn()).Inc()
+		metrics.AuthOutcomes.Failure()
+		srv.accounting.auditAuth(aaaCtx, false, "device is not allowed", started)
+		return aaaCtx, status.Errorf(codes.PermissionDenied, "device %s is not allowed", mac)
+	}
+	if err != nil {
+		return aaaCtx, status.Errorf(codes.Unavailable, "MAC allow-list lookup of device %s failed: %v", mac, err)
+	}
+	aaaCtx.MacAddr = mac
+	aaaCtx.Identity = mab.Identity(mac)
+	aaaCtx.Imsi = device.IMSI
+	if len(device.APN) > 0 {
+		aaaCtx.Apn = device.APN
+	}
+	if srv.sessions != nil {
+		if err = srv.acceptSession(ctx, aaaCtx); err != nil {
+			log.Printf("MAC Authentication Bypass session %s of device %s failed: %v", aaaCtx.GetSessionId(), mac, err)
+			srv.accounting.auditAuth(aaaCtx, false, err.Error(), started)
+			return aaaCtx, err
+		}
+	}
+	metrics.Auth.WithLabelValues(protos.EapCode_Success.String(), mabMethod, aaaCtx.GetApn()).Inc()
+	metrics.AuthOutcomes.Success()
+	srv.accounting.auditAuth(aaaCtx, true, "", started)
+	return aaaCtx, nil
+}
@@ -0,0 +1,189 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// package servcers implements WiFi AAA GRPC services
+package servicers
+
+import (
+	"log"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"magma/feg/gateway/services/aaa"
+	"magma/feg/gateway/services/aaa/adminauth"
+	"magma/feg/gateway/services/aaa/audit"
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+// sessionExportVersion - version of the session export format
+const sessionExportVersion = 1
+
+// ExportSessions returns the gateway's session table (contexts, timers & counters) for a planned migration of the
+// gateway's subscribers to another gateway
+func (srv *sessionAdminService) ExportSessions(
+	ctx context.Context, req *protos.ExportSessionsRequest) (*protos.SessionExport, error) {
+
+	// a session dump is read only, while releasing the sessions drops them from this gateway
+	required := adminauth.ReadOnly
+	if req.GetRelease() {
+		required = adminauth.Admin
+	}
+	if err := srv.authorize(ctx, "ExportSessions", required); err != nil {
+		return nil, err
+	}
+	lister, ok := srv.acct.sessions.(aaa.SessionLister)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "Session table does not support session listing")
+	}
+	listed := lister.ListSessions()
+	res := &protos.SessionExport{
+		Version:      sessionExportVersion,
+		ExportedAtMs: time.Now().UnixNano() / int64(time.Millisecond),
+		Sessions:     make([]*protos.ExportedSession, 0, len(listed)),
+	}
+	for _, st := range listed {
+		res.Sessions = append(res.Sessions, srv.acct.exportSession(st))
+	}
+	if req.GetRelease() {
+		for _, exported := range res.Sessions {
+			srv.acct.releaseSession(exported.GetCtx().GetSessionId())
+		}
+	}
+	log.Printf("Exported %d sessions, released: %t", len(res.Sessions), req.GetRelease())
+	return res, nil
+}
+
+// ImportSessions adds the exported sessions of another gateway to the gateway's session table
+func (srv *sessionAdminService) ImportSessions(
+	ctx context.Context, req *protos.ImportSessionsRequest) (*protos.ImportSessionsResult, error) {
+
+	if err := srv.authorize(ctx, "ImportSessions", adminauth.Admin); err != nil {
+		return nil, err
+	}
+	export := req.GetExport()
+	if export == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Nil Session Export")
+	}
+	if export.GetVersion() != sessionExportVersion {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"Unsupported session export version %d, expected %d", export.GetVersion(), sessionExportVersion)
+	}
+	res := &protos.ImportSessionsResult{}
+	for _, exported := range export.GetSessions() {
+		if err := srv.acct.importSession(exported, req.GetOverwrite()); err != nil {
+			log.Printf("Session %s import is skipped: %v", exported.GetCtx().GetSessionId(), err)
+			res.SkippedSessionIds = append(res.SkippedSessionIds, exported.GetCtx().GetSessionId())
+			continue
+		}
+		res.Imported++
+	}
+	log.Printf("Imported %d sessions, skipped: %d", res.Imported, len(res.SkippedSessionIds))
+	return res, nil
+}
+
+// exportSession returns the exported session, the session's keys are not exported
+func (srv *accountingService) exportSession(st aaa.SessionTimeout) *protos.ExportedSession {
+	aaaCtx := st.Session.GetCtx()
+	// spilled attribute values are exported, so the target gateway doesn't need this gateway's spill store
+	aaaCtx = proto.Clone(srv.attributes.resolve(aaaCtx)).(*protos.Context)
+	aaaCtx.Msk = nil
+
+	sid := aaaCtx.GetSessionId()
+	exported := &protos.ExportedSession{Ctx: aaaCtx, IdleTimeoutMs: uint64(st.Timeout / time.Millisecond)}
+	if start := srv.starts.get(sid); !start.IsZero() {
+		exported.StartTimeMs = start.Wall.UnixNano() / int64(time.Millisecond)
+	}
+	if u, ok := srv.usage.get(sid); ok {
+		exported.OctetsIn, exported.OctetsOut = u.octetsIn, u.octetsOut
+		exported.LastOctetsIn, exported.LastOctetsOut = uint32(u.lastIn), uint32(u.lastOut)
+		exported.LastGigawordsIn, exported.LastGigawordsOut = uint32(u.lastIn>>32), uint32(u.lastOut>>32)
+	}
+	return exported
+}
+
+// releaseSession removes the exported session without disconnecting it or ending its session manager session
+func (srv *accountingService) releaseSession(sid string) {
+	s := srv.sessions.RemoveSession(sid)
+	if s == nil {
+		return // ended while exported
+	}
+	srv.auditEvent(audit.Export, s.GetCtx())
+	srv.stopped(s.GetCtx())
+	srv.clearSessionState(sid)
+}
+
+// importSession adds the exported session to the session table & restores its timers & counters
+func (srv *accountingService) importSession(exported *protos.ExportedSession, overwrite bool) error {
+	aaaCtx := exported.GetCtx()
+	if len(aaaCtx.GetSessionId()) == 0 {
+		return status.Errorf(codes.InvalidArgument, "Missing session ID")
+	}
+	sid := aaaCtx.GetSessionId()
+	if !overwrite && srv.sessions.GetSession(sid) != nil {
+		return status.Errorf(codes.AlreadyExists, "Session %s already exist", sid)
+	}
+	tout := time.Duration(exported.GetIdleTimeoutMs()) * time.Millisecond
+	if tout == 0 {
+		tout = srv.settings(aaaCtx).idleTimeout
+	}
+	if _, err := srv.sessions.AddSession(aaaCtx, tout, srv.timeoutSessionNotifier, overwrite); err != nil {
+		return err
+	}
+	if exported.GetOctetsIn() > 0 || exported.GetOctetsOut() > 0 ||
+		exported.GetLastOctetsIn() > 0 || exported.GetLastOctetsOut() > 0 ||
+		exported.GetLastGigawordsIn() > 0 || exported.GetLastGigawordsOut() > 0 {
+
+		srv.usage.mu.Lock()
+		srv.usage.sessions[sid] = &localUsage{
+			imsi:      aaaCtx.GetImsi(),
+			octetsIn:  exported.GetOctetsIn(),
+			octetsOut: exported.GetOctetsOut(),
+			lastIn:    octetsCounter(exported.GetLastGigawordsIn(), exported.GetLastOctetsIn()),
+			lastOut:   octetsCounter(exported.GetLastGigawordsOut(), exported.GetLastOctetsOut()),
+			updated:   time.Now(),
+		}
+		srv.usage.mu.Unlock()
+	}
+	srv.capacity.moveSession(sid, aaaCtx.GetApn())
+	if exported.GetStartTimeMs() > 0 {
+		// the start's monotonic reading is relative to this process' start, so the sessions' durations stay monotonic
+		wall := time.Unix(0, exported.GetStartTimeMs()*int64(time.Millisecond))
+		now := audit.Now()
+		srv.starts.Lock()
+		srv.starts.starts[sid] = audit.Timestamp{Wall: wall, Mono: now.Mono - now.Wall.Sub(wall)}
+		srv.starts.Unlock()
+		srv.started(aaaCtx)
+		go srv.applyTimePolicy(sid)
+	}
+	srv.seen(aaaCtx)
+	srv.auditEvent(audit.Import, aaaCtx)
+	return nil
+}
+
+// RestoreSessions restores the sessions persisted by the session table before AAA server's restart, if the table
+// persists its sessions, & returns the number of restored sessions
+func (srv *accountingService) RestoreSessions() (int, error) {
+	restorer, ok := srv.sessions.(aaa.SessionRestorer)
+	if !ok {
+		return 0, nil
+	}
+	restored, err := restorer.Restore(srv.timeoutSessionNotifier)
+	if err != nil {
+		return 0, err
+	}
+	for _, s := range restored {
+		aaaCtx := s.GetCtx()
+		srv.capacity.moveSession(aaaCtx.GetSessionId(), aaaCtx.GetApn())
+		srv.seen(aaaCtx)
+	}
+	return len(restored), nil
+}
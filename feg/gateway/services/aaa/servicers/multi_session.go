@@ -0,0 +1,128 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// package servcers implements WiFi AAA GRPC services
+package servicers
+
+import (
+	"sync"
+	"time"
+
+	"magma/feg/gateway/services/aaa/audit"
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+// multiSessionLinger - time a multi-session without active sessions waits for its next session, e.g. of a fast roam
+// whose Stop precedes the Start on the new AP
+const multiSessionLinger = time.Minute
+
+type octets struct {
+	in, out uint64
+}
+
+// multiSession - usage of the sessions of a NAS multi-session
+type multiSession struct {
+	active map[string]octets // last known usage of the active sessions by session ID
+	ended  int               // number of the removed sessions
+	usage  octets            // usage of the removed sessions
+	idle   time.Time         // removal time of the last active session
+}
+
+// multiSessionTable groups sessions by their NAS's Acct-Multi-Session-Id, so the sessions of one multi-session are
+// reported as one logical accounting record. Multi-sessions are kept up to the linger time after their last session
+// is removed
+type multiSessionTable struct {
+	sync.Mutex
+	groups    map[string]*multiSession // by Acct-Multi-Session-Id
+	ids       map[string]string        // Acct-Multi-Session-Ids of the active sessions by session ID
+	nextPurge time.Time
+}
+
+func newMultiSessionTable() *multiSessionTable {
+	return &multiSessionTable{groups: map[string]*multiSession{}, ids: map[string]string{}}
+}
+
+// update sets the usage of the multi-session's active session & returns the multi-session's record
+func (t *multiSessionTable) update(id, sid string, usage octets) *audit.MultiSession {
+	now := time.Now()
+	t.Lock()
+	defer t.Unlock()
+	t.purge(now)
+	if prev, ok := t.ids[sid]; ok && prev != id {
+		t.removeLocked(sid, now)
+	}
+	g, ok := t.groups[id]
+	if !ok || (len(g.active) == 0 && now.Sub(g.idle) >= multiSessionLinger) {
+		g = &multiSession{active: map[string]octets{}}
+		t.groups[id] = g
+	}
+	g.active[sid] = usage
+	t.ids[sid] = id
+
+	res := &audit.MultiSession{Id: id, Sessions: len(g.active) + g.ended, OctetsIn: g.usage.in, OctetsOut: g.usage.out}
+	for _, u := range g.active {
+		res.OctetsIn += u.in
+		res.OctetsOut += u.out
+	}
+	return res
+}
+
+// remove moves the removed session's last known usage to its multi-session's usage of the removed sessions
+func (t *multiSessionTable) remove(sid string) {
+	t.Lock()
+	t.removeLocked(sid, time.Now())
+	t.Unlock()
+}
+
+// removeLocked must be called with the lock held
+func (t *multiSessionTable) removeLocked(sid string, now time.Time) {
+	id, ok := t.ids[sid]
+	if !ok {
+		return
+	}
+	delete(t.ids, sid)
+	g, ok := t.groups[id]
+	if !ok {
+		return
+	}
+	if u, ok := g.active[sid]; ok {
+		delete(g.active, sid)
+		g.ended++
+		g.usage.in += u.in
+		g.usage.out += u.out
+	}
+	if len(g.active) == 0 {
+		g.idle = now
+	}
+}
+
+// purge deletes the multi-sessions idle past the linger time, at most once per linger time. It must be called with
+// the lock held
+func (t *multiSessionTable) purge(now time.Time) {
+	if now.Before(t.nextPurge) {
+		return
+	}
+	t.nextPurge = now.Add(multiSessionLinger)
+	for id, g := range t.groups {
+		if len(g.active) == 0 && now.Sub(g.idle) >= multiSessionLinger {
+			delete(t.groups, id)
+		}
+	}
+}
+
+// trackMultiSession updates the session's multi-session with the session's accumulated usage & returns the
+// multi-session's record, nil if the session's NAS doesn't group its sessions
+func (srv *accountingService) trackMultiSession(aaaCtx *protos.Context) *audit.MultiSession {
+	id, ok := aaaCtx.GetAttribute(protos.MultiSessionIDAttribute)
+	if !ok || len(id) == 0 {
+		return nil
+	}
+	sid := aaaCtx.GetSessionId()
+	u, _ := srv.usage.get(sid)
+	return srv.multiSessions.update(id, sid, octets{in: u.octetsIn, out: u.octetsOut})
+}
@@ -0,0 +1,50 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// package servcers implements WiFi AAA GRPC services
+package servicers
+
+import (
+	"log"
+	"net"
+
+	"magma/feg/gateway/services/aaa"
+	"magma/feg/gateway/services/aaa/audit"
+	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+// checkNASAddress detects the session's accounting request arriving from another NAS host than its previous
+// accounting, e.g. after a controller failover or a NAT change. The session's NAS binding is updated by the merge of
+// the request's attributes, so its CoA & Disconnect-Requests follow the NAS. Source port changes only update the
+// binding, NASes may send every request from another port
+func (srv *accountingService) checkNASAddress(s aaa.Session, reqCtx *protos.Context) {
+	addr, ok := reqCtx.GetAttribute(protos.NASAddressAttribute)
+	if !ok {
+		return
+	}
+	sessionCtx := s.GetCtx()
+	previous, ok := sessionCtx.GetAttribute(protos.NASAddressAttribute)
+	if !ok || nasHost(previous) == nasHost(addr) {
+		return
+	}
+	metrics.NASAddressChanges.Inc()
+	log.Printf("Session %s (IMSI: %s) NAS address changed from %s to %s",
+		sessionCtx.GetSessionId(), sessionCtx.GetImsi(), previous, addr)
+	srv.auditEventWith(audit.Rebind, sessionCtx, func(ev *audit.Event) {
+		ev.Changes = []audit.Change{{Field: protos.NASAddressAttribute, Old: previous, New: addr}}
+	})
+}
+
+// nasHost returns the host of the NAS address, the address itself if it has no port
+func nasHost(addr string) string {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
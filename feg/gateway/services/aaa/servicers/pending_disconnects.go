@@ -0,0 +1,181 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// package servcers implements WiFi AAA GRPC services
+package servicers
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"magma/feg/gateway/services/aaa/coalog"
+	"magma/feg/gateway/services/aaa/deadlines"
+	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/aaa/panics"
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+const (
+	// pendingDisconnectLinger - time a failed Disconnect waits for its NAS to become reachable
+	pendingDisconnectLinger = 24 * time.Hour
+	// maxDisconnectAttempts - attempts of a Disconnect, including the failed one, before it's given up
+	maxDisconnectAttempts = 5
+)
+
+// Disconnect retry results
+const (
+	disconnectRetryAck     = "ack"
+	disconnectRetryNak     = "nak"
+	disconnectRetryFailed  = "failed"
+	disconnectRetryDropped = "dropped"
+)
+
+// pendingDisconnect - a failed Disconnect-Request of a session which may still be active on its NAS
+type pendingDisconnect struct {
+	req      *protos.DisconnectRequest
+	attempts int
+	failed   time.Time // time of the first failure
+}
+
+// pendingDisconnectTable keeps the failed Disconnect-Requests by their NASes, so the zombie sessions left on NASes
+// unreachable at the time are disconnected once the NASes' accounting proves them reachable again
+type pendingDisconnectTable struct {
+	sync.Mutex
+	byNAS map[string]map[string]*pendingDisconnect // by NAS, then by session ID
+}
+
+func newPendingDisconnectTable() *pendingDisconnectTable {
+	return &pendingDisconnectTable{byNAS: map[string]map[string]*pendingDisconnect{}}
+}
+
+// nasKey returns the NAS of the session context: its NAS host or, if the NAS address is unknown, its NAS-Identifier.
+// Empty string is returned if the context has neither
+func nasKey(aaaCtx *protos.Context) string {
+	if addr, ok := aaaCtx.GetAttribute(protos.NASAddressAttribute); ok && len(addr) > 0 {
+		return nasHost(addr)
+	}
+	id, _ := aaaCtx.GetAttribute(protos.NASIdentifierAttribute)
+	return id
+}
+
+// add adds the failed Disconnect of the given attempts, the first failure's time of retried Disconnects is kept
+func (t *pendingDisconnectTable) add(req *protos.DisconnectRequest, attempts int, failed time.Time) {
+	nas := nasKey(req.GetCtx())
+	if len(nas) == 0 {
+		return
+	}
+	t.Lock()
+	defer t.Unlock()
+	pending, ok := t.byNAS[nas]
+	if !ok {
+		pending = map[string]*pendingDisconnect{}
+		t.byNAS[nas] = pending
+	}
+	pending[req.GetCtx().GetSessionId()] = &pendingDisconnect{req: req, attempts: attempts, failed: failed}
+	metrics.PendingDisconnects.WithLabelValues(nas).Set(float64(len(pending)))
+}
+
+// remove removes the session's pending Disconnect, if any
+func (t *pendingDisconnectTable) remove(aaaCtx *protos.Context) {
+	nas := nasKey(aaaCtx)
+	t.Lock()
+	defer t.Unlock()
+	pending, ok := t.byNAS[nas]
+	if !ok {
+		return
+	}
+	delete(pending, aaaCtx.GetSessionId())
+	metrics.PendingDisconnects.WithLabelValues(nas).Set(float64(len(pending)))
+	if len(pending) == 0 {
+		delete(t.byNAS, nas)
+	}
+}
+
+// take removes & returns the NAS's pending Disconnects
+func (t *pendingDisconnectTable) take(nas string) []*pendingDisconnect {
+	t.Lock()
+	defer t.Unlock()
+	pending, ok := t.byNAS[nas]
+	if !ok {
+		return nil
+	}
+	delete(t.byNAS, nas)
+	metrics.PendingDisconnects.WithLabelValues(nas).Set(0)
+	res := make([]*pendingDisconnect, 0, len(pending))
+	for _, p := range pending {
+		res = append(res, p)
+	}
+	return res
+}
+
+// retryKey - context key of the retried Disconnects, their failures are tracked by the retry
+type retryKey struct{}
+
+// disconnectDone tracks the Disconnect's failure for a retry once its NAS is reachable, or clears the session's
+// pending Disconnect if it was delivered
+func (srv *accountingService) disconnectDone(ctx context.Context, req *protos.DisconnectRequest, err error) {
+	if ctx.Value(retryKey{}) != nil {
+		return
+	}
+	if err != nil {
+		srv.disconnects.add(req, 1, time.Now())
+	} else {
+		srv.disconnects.remove(req.GetCtx())
+	}
+}
+
+// nasReachable retries the pending Disconnects of the accounting request's NAS, the request proves the NAS is
+// reachable. Disconnects of sessions which were established again in the meantime are dropped
+func (srv *accountingService) nasReachable(reqCtx *protos.Context) {
+	nas := nasKey(reqCtx)
+	if len(nas) == 0 {
+		return
+	}
+	pending := srv.disconnects.take(nas)
+	if len(pending) == 0 {
+		return
+	}
+	log.Printf("NAS %s is reachable, retrying %d pending Disconnects", nas, len(pending))
+	go func() {
+		defer panics.Recover("disconnect_retry")
+		client := srv.newAuthorizationClient()
+		for _, p := range pending {
+			srv.retryDisconnect(client, p)
+		}
+	}()
+}
+
+// retryDisconnect retries the pending Disconnect, it's pending again if it fails before its last attempt
+func (srv *accountingService) retryDisconnect(client protos.AuthorizationClient, p *pendingDisconnect) {
+	sid := p.req.GetCtx().GetSessionId()
+	if srv.sessions.GetSession(sid) != nil || time.Since(p.failed) >= pendingDisconnectLinger {
+		metrics.DisconnectRetries.WithLabelValues(disconnectRetryDropped).Inc()
+		return
+	}
+	ctx, cancel := deadlines.Background()
+	defer cancel()
+	ctx = coalog.WithTrigger(context.WithValue(ctx, retryKey{}, true), "disconnect_retry", "")
+	res, err := client.Disconnect(ctx, p.req)
+	switch {
+	case err == nil && res.GetCoaResponseType() == protos.CoaResponse_ACK:
+		metrics.DisconnectRetries.WithLabelValues(disconnectRetryAck).Inc()
+		log.Printf("Pending Disconnect of session %s was delivered after %d attempts", sid, p.attempts+1)
+	case err == nil:
+		// the NAS has no such session anymore
+		metrics.DisconnectRetries.WithLabelValues(disconnectRetryNak).Inc()
+	case p.attempts+1 >= maxDisconnectAttempts:
+		metrics.DisconnectRetries.WithLabelValues(disconnectRetryDropped).Inc()
+		log.Printf("Pending Disconnect of session %s is given up after %d attempts: %v", sid, p.attempts+1, err)
+	default:
+		metrics.DisconnectRetries.WithLabelValues(disconnectRetryFailed).Inc()
+		srv.disconnects.add(p.req, p.attempts+1, p.failed)
+	}
+}
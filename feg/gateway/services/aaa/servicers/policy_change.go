@@ -0,0 +1,78 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSDstyle license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// package servcers implements WiFi AAA GRPC services
+package servicers
+
+import (
+	"log"
+	"strings"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"magma/feg/gateway/services/aaa/protos"
+	"magma/feg/gateway/services/aaa/quarantine"
+)
+
+// ChangeSession is an "inbound" RPC from session manager pushing a policy change (bandwidth re-class, VLAN or filter
+// change) of an active session to the NAS via CoA, instead of terminating the session. A changed bandwidth becomes
+// the session's base bandwidth, VLAN & filter changes of quarantined sessions are rejected
+func (srv *accountingService) ChangeSession(
+	ctx context.Context, req *protos.ChangeSessionRequest) (*protos.AcctResp, error) {
+
+	if req == nil {
+		return &protos.AcctResp{}, status.Errorf(codes.InvalidArgument, "Nil Change Session Request")
+	}
+	up, down := req.GetMaxBandwidthUp(), req.GetMaxBandwidthDown()
+	if (up == 0) != (down == 0) {
+		return &protos.AcctResp{}, status.Errorf(codes.InvalidArgument, "Invalid bandwidth: up %d, down %d", up, down)
+	}
+	if up == 0 && req.GetVlanId() == 0 && len(req.GetFilterId()) == 0 {
+		return &protos.AcctResp{}, status.Errorf(codes.InvalidArgument, "Empty policy change")
+	}
+	sid := req.GetRadiusSessionId()
+	s := srv.sessions.GetSession(sid)
+	if s == nil {
+		return &protos.AcctResp{}, status.Errorf(codes.FailedPrecondition, "Session %s is not found", sid)
+	}
+	aaaCtx := s.GetCtx()
+	imsi := aaaCtx.GetImsi()
+	if !strings.HasPrefix(imsi, imsiPrefix) {
+		imsi = imsiPrefix + imsi
+	}
+	if imsi != req.GetImsi() {
+		return &protos.AcctResp{}, status.Errorf(
+			codes.InvalidArgument, "Mismatched IMSI: %s != %s of session %s", req.GetImsi(), imsi, sid)
+	}
+	if trigger, quarantined := aaaCtx.GetAttribute(quarantine.Attribute); quarantined &&
+		(req.GetVlanId() > 0 || len(req.GetFilterId()) > 0) {
+		return &protos.AcctResp{}, status.Errorf(
+			codes.FailedPrecondition, "Session %s is quarantined by %s trigger", sid, trigger)
+	}
+	resp, err := srv.newAuthorizationClient().Change(ctx, &protos.ChangeRequest{
+		Ctx:              aaaCtx,
+		MaxBandwidthUp:   up,
+		MaxBandwidthDown: down,
+		VlanId:           req.GetVlanId(),
+		FilterId:         req.GetFilterId(),
+	})
+	if err != nil {
+		return &protos.AcctResp{}, err
+	}
+	if resp.GetCoaResponseType() != protos.CoaResponse_ACK {
+		return &protos.AcctResp{}, status.Errorf(codes.Aborted, "Policy change of session %s was rejected", sid)
+	}
+	if up > 0 {
+		srv.bandwidths.setBase(sid, bandwidth{up: up, down: down})
+	}
+	log.Printf("Session %s policy changed; bandwidth up: %d, down: %d, VLAN: %d, filter: '%s'",
+		sid, up, down, req.GetVlanId(), req.GetFilterId())
+	return &protos.AcctResp{}, nil
+}
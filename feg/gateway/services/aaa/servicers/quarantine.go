@@ -0,0 +1,118 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSDstyle license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// package servcers implements WiFi AAA GRPC services
+package servicers
+
+import (
+	"log"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"magma/feg/gateway/services/aaa"
+	"magma/feg/gateway/services/aaa/audit"
+	"magma/feg/gateway/services/aaa/coalog"
+	"magma/feg/gateway/services/aaa/deadlines"
+	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/aaa/panics"
+	"magma/feg/gateway/services/aaa/protos"
+	"magma/feg/gateway/services/aaa/quarantine"
+)
+
+// Security trigger action results
+const (
+	quarantineApplied      = "quarantined"
+	quarantineDisconnected = "disconnected"
+	quarantineFailed       = "failed"
+)
+
+// SetQuarantine sets the actions of security triggers, nil - sessions of all triggers are disconnected
+func (srv *accountingService) SetQuarantine(cfg *quarantine.Config) {
+	srv.quarantine = cfg
+}
+
+// SecurityEvent is an "inbound" RPC of security integrations (e.g. blocklists) reporting a security trigger of an
+// active session, the session is quarantined or disconnected, as configured for the trigger
+func (srv *accountingService) SecurityEvent(
+	ctx context.Context, req *protos.SecurityEventRequest) (*protos.AcctResp, error) {
+
+	trigger, err := quarantine.ParseTrigger(req.GetTrigger())
+	if err != nil {
+		return &protos.AcctResp{}, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	sid := req.GetSessionId()
+	s := srv.sessions.GetSession(sid)
+	if s == nil {
+		return &protos.AcctResp{}, status.Errorf(codes.FailedPrecondition, "Session %s is not found", sid)
+	}
+	log.Printf("SECURITY: %s trigger of session %s (IMSI: %s, MAC: %s): %s",
+		trigger, sid, s.GetCtx().GetImsi(), s.GetCtx().GetMacAddr(), req.GetReason())
+
+	if action, profile := srv.quarantine.Action(trigger); action == quarantine.ActionQuarantine {
+		return &protos.AcctResp{}, srv.quarantineSession(ctx, s, trigger, profile)
+	}
+	s = srv.sessions.RemoveSession(sid)
+	srv.forgetSession(sid, audit.Terminate, s)
+	if s == nil {
+		return &protos.AcctResp{}, status.Errorf(codes.FailedPrecondition, "Session %s is not found", sid)
+	}
+	if err = srv.endSession(ctx, s.GetCtx(), protos.TerminateReason_POLICY); err != nil {
+		metrics.Quarantines.WithLabelValues(string(trigger), quarantineFailed).Inc()
+		return &protos.AcctResp{}, err
+	}
+	metrics.Quarantines.WithLabelValues(string(trigger), quarantineDisconnected).Inc()
+	return &protos.AcctResp{}, nil
+}
+
+// quarantineSession marks the session as quarantined by the trigger, audits & moves it to the quarantine profile
+func (srv *accountingService) quarantineSession(
+	ctx context.Context, s aaa.Session, trigger quarantine.Trigger, profile *quarantine.Profile) error {
+
+	srv.mergeAttributes(s, map[string]string{quarantine.Attribute: string(trigger)})
+	srv.auditEventWith(audit.Quarantine, s.GetCtx(), func(ev *audit.Event) { ev.Reason = string(trigger) })
+	return srv.sendQuarantine(ctx, s.GetCtx(), trigger, profile)
+}
+
+// resumeQuarantine moves the started session, marked as quarantined when it was authenticated, to the quarantine
+// profile of its trigger
+func (srv *accountingService) resumeQuarantine(aaaCtx *protos.Context) {
+	trigger, ok := aaaCtx.GetAttribute(quarantine.Attribute)
+	if !ok {
+		return
+	}
+	action, profile := srv.quarantine.Action(quarantine.Trigger(trigger))
+	if action != quarantine.ActionQuarantine {
+		return
+	}
+	srv.auditEventWith(audit.Quarantine, aaaCtx, func(ev *audit.Event) { ev.Reason = trigger })
+	go func() {
+		defer panics.Recover("quarantine_coa")
+		ctx, cancel := deadlines.Background()
+		defer cancel()
+		ctx = coalog.WithTrigger(ctx, "quarantine_coa", trigger)
+		srv.sendQuarantine(ctx, aaaCtx, quarantine.Trigger(trigger), profile)
+	}()
+}
+
+// sendQuarantine moves the session to the quarantine profile via Radius CoA
+func (srv *accountingService) sendQuarantine(
+	ctx context.Context, aaaCtx *protos.Context, trigger quarantine.Trigger, profile *quarantine.Profile) error {
+
+	_, err := srv.newAuthorizationClient().Change(
+		ctx, &protos.ChangeRequest{Ctx: aaaCtx, Quarantine: profile.Proto()})
+	if err != nil {
+		metrics.Quarantines.WithLabelValues(string(trigger), quarantineFailed).Inc()
+		log.Printf("Quarantine CoA for session %s failed: %v", aaaCtx.GetSessionId(), err)
+		return err
+	}
+	metrics.Quarantines.WithLabelValues(string(trigger), quarantineApplied).Inc()
+	log.Printf("Session %s is quarantined by %s trigger", aaaCtx.GetSessionId(), trigger)
+	return nil
+}
@@ -0,0 +1,120 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// package servcers implements WiFi AAA GRPC services
+package servicers
+
+import (
+	"log"
+
+	"magma/feg/gateway/services/aaa"
+	"magma/feg/gateway/services/aaa/audit"
+	"magma/feg/gateway/services/aaa/coalog"
+	"magma/feg/gateway/services/aaa/deadlines"
+	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/aaa/panics"
+	"magma/feg/gateway/services/aaa/protos"
+	lte_protos "magma/lte/cloud/go/protos"
+)
+
+// Quota enforcement actions & results
+const (
+	quotaActionDisconnect = "disconnect"
+	quotaActionRedirect   = "redirect"
+	quotaEnforced         = "success"
+	quotaNotEnforced      = "failure"
+)
+
+// quotaRedirectAttribute - AAA context attribute of the redirect URL of a session redirected by its exhausted quota
+const quotaRedirectAttribute = "quota_redirect_url"
+
+// enforceQuota enforces session manager's decision on the session of the reported Interim-Update usage: a session
+// whose quota is exhausted is redirected via Radius CoA if its final unit action is REDIRECT, otherwise, or if the
+// redirect fails, the session is removed, ended in session manager & its UE is disconnected
+func (srv *accountingService) enforceQuota(s aaa.Session, decision *lte_protos.LocalSessionUsageResponse) {
+	if !decision.GetQuotaExhausted() {
+		return
+	}
+	aaaCtx := s.GetCtx()
+	sid := aaaCtx.GetSessionId()
+	if decision.GetFinalAction() == lte_protos.ChargingCredit_REDIRECT {
+		if _, redirected := aaaCtx.GetAttribute(quotaRedirectAttribute); redirected {
+			return // the session was redirected on a previous Interim-Update
+		}
+		if url := redirectURL(decision.GetRedirectServer()); len(url) > 0 {
+			srv.mergeAttributes(s, map[string]string{quotaRedirectAttribute: url})
+			go srv.redirectExhausted(s.GetCtx(), url)
+			return
+		}
+		log.Printf("Session %s: unsupported quota redirect server %v, the session is disconnected",
+			sid, decision.GetRedirectServer())
+	}
+	srv.terminateExhausted(sid)
+}
+
+// redirectExhausted redirects the session with exhausted quota to the redirect URL via Radius CoA, the session is
+// terminated if the NAS doesn't apply the redirect
+func (srv *accountingService) redirectExhausted(aaaCtx *protos.Context, url string) {
+	defer panics.Recover("quota_redirect")
+	sid := aaaCtx.GetSessionId()
+	ctx, cancel := deadlines.Background()
+	defer cancel()
+	ctx = coalog.WithTrigger(ctx, "quota_redirect", url)
+	res, err := srv.newAuthorizationClient().Change(
+		ctx, &protos.ChangeRequest{Ctx: aaaCtx, Quarantine: &protos.QuarantineProfile{RedirectUrl: url}})
+	if err != nil || res.GetCoaResponseType() != protos.CoaResponse_ACK {
+		metrics.QuotaEnforcements.WithLabelValues(quotaActionRedirect, quotaNotEnforced).Inc()
+		log.Printf("Quota redirect CoA for session %s failed (%v), the session is disconnected", sid, err)
+		srv.terminateExhausted(sid)
+		return
+	}
+	metrics.QuotaEnforcements.WithLabelValues(quotaActionRedirect, quotaEnforced).Inc()
+	log.Printf("Session %s quota is exhausted, the session is redirected to %s", sid, url)
+}
+
+// terminateExhausted removes the session with exhausted quota, ends it in session manager & disconnects its UE
+func (srv *accountingService) terminateExhausted(sid string) {
+	s := srv.sessions.RemoveSession(sid)
+	srv.forgetSession(sid, audit.Terminate, s)
+	if s == nil {
+		return
+	}
+	aaaCtx := s.GetCtx()
+	srv.stopped(aaaCtx)
+	log.Printf("Session %s (IMSI: %s) quota is exhausted, terminating", sid, aaaCtx.GetImsi())
+	go func() {
+		defer panics.Recover("quota_disconnect")
+		ctx, cancel := deadlines.Background()
+		defer cancel()
+		ctx = coalog.WithTrigger(ctx, "quota_disconnect", "")
+		if err := srv.endSession(ctx, aaaCtx, protos.TerminateReason_QUOTA_EXHAUSTED); err != nil {
+			metrics.QuotaEnforcements.WithLabelValues(quotaActionDisconnect, quotaNotEnforced).Inc()
+			log.Printf("Session %s termination after its quota exhaustion failed: %v", sid, err)
+			return
+		}
+		metrics.QuotaEnforcements.WithLabelValues(quotaActionDisconnect, quotaEnforced).Inc()
+	}()
+}
+
+// redirectURL returns the redirect URL of the final unit action's redirect server, empty string if the server's
+// address can't be redirected to by NASes (e.g. SIP URIs)
+func redirectURL(server *lte_protos.RedirectServer) string {
+	addr := server.GetRedirectServerAddress()
+	if len(addr) == 0 {
+		return ""
+	}
+	switch server.GetRedirectAddressType() {
+	case lte_protos.RedirectServer_URL:
+		return addr
+	case lte_protos.RedirectServer_IPV4:
+		return "http://" + addr + "/"
+	case lte_protos.RedirectServer_IPV6:
+		return "http://[" + addr + "]/"
+	}
+	return ""
+}
@@ -0,0 +1,52 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// package servcers implements WiFi AAA GRPC services
+package servicers
+
+import (
+	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/aaa/protos"
+	"magma/feg/gateway/services/aaa/quirks"
+	"magma/feg/gateway/services/aaa/ratelimit"
+)
+
+// Rate limit keys
+const (
+	rateLimitMAC  = "mac"
+	rateLimitIMSI = "imsi"
+)
+
+// SetRateLimits sets the rate limits of the accounting & authentication requests by their MAC address & IMSI, every
+// EAP round of an authentication is a request. Nil limiter disables its limit
+func (srv *accountingService) SetRateLimits(mac, imsi *ratelimit.Limiter) {
+	srv.macLimiter, srv.imsiLimiter = mac, imsi
+}
+
+// throttle returns RESOURCE_EXHAUSTED rate limit error if the request's MAC address or IMSI is over its rate limit,
+// throttled requests are only counted, a flood isn't logged. Requests without MAC address or IMSI aren't limited by
+// the missing key
+func (srv *accountingService) throttle(method string, aaaCtx *protos.Context) error {
+	if srv == nil || (srv.macLimiter == nil && srv.imsiLimiter == nil) {
+		return nil
+	}
+	for _, limit := range []struct {
+		key     string
+		value   string
+		limiter *ratelimit.Limiter
+	}{
+		{rateLimitMAC, quirks.NormalizeMAC(aaaCtx.GetMacAddr()), srv.macLimiter},
+		{rateLimitIMSI, aaaCtx.GetImsi(), srv.imsiLimiter},
+	} {
+		if ok, retryAfter := limit.limiter.Allow(limit.value); !ok {
+			metrics.ThrottledRequests.WithLabelValues(method, limit.key).Inc()
+			return protos.NewRateLimitError(method, limit.key, limit.value, retryAfter)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSDstyle license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// package servcers implements WiFi AAA GRPC services
+package servicers
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+// localUsage - session's usage accumulated from Interim-Updates
+type localUsage struct {
+	imsi                          string
+	octetsIn, octetsOut           uint64 // accumulated usage
+	packetsIn, packetsOut         uint64
+	lastIn, lastOut               uint64 // last reported Acct-Input/Output-Octets & Gigawords, in the NAS's units
+	lastPacketsIn, lastPacketsOut uint32 // last reported Acct-Input/Output-Packets
+	updated                       time.Time
+	active                        time.Time // first Interim-Update or last traffic
+}
+
+// usageTable - synchronized map of accumulated session usages by session ID
+type usageTable struct {
+	sessions map[string]*localUsage
+	mu       sync.Mutex
+}
+
+func newUsageTable() *usageTable {
+	return &usageTable{sessions: map[string]*localUsage{}}
+}
+
+// update accumulates Interim-Update's cumulative octets & packets counters, a counter going backwards is treated as
+// a NAS side counter reset. The octets counters wrapping around 2^32 are extended by their Gigawords & are in units
+// of the given number of octets, as reported by the NAS. Returns the updated usage & the accumulated octets deltas
+func (ut *usageTable) update(sid, imsi string, ur *protos.UpdateRequest, unit uint64) (localUsage, uint64, uint64) {
+	ut.mu.Lock()
+	defer ut.mu.Unlock()
+	u, ok := ut.sessions[sid]
+	if !ok {
+		u = &localUsage{active: time.Now()}
+		ut.sessions[sid] = u
+	}
+	octetsIn := octetsCounter(ur.GetGigawordsIn(), ur.GetOctetsIn())
+	octetsOut := octetsCounter(ur.GetGigawordsOut(), ur.GetOctetsOut())
+	deltaIn := octetsDelta(u.lastIn, octetsIn) * unit
+	deltaOut := octetsDelta(u.lastOut, octetsOut) * unit
+	u.imsi = imsi
+	u.octetsIn += deltaIn
+	u.octetsOut += deltaOut
+	u.lastIn, u.lastOut = octetsIn, octetsOut
+	u.packetsIn += uint64(counterDelta(u.lastPacketsIn, ur.GetPacketsIn()))
+	u.packetsOut += uint64(counterDelta(u.lastPacketsOut, ur.GetPacketsOut()))
+	u.lastPacketsIn, u.lastPacketsOut = ur.GetPacketsIn(), ur.GetPacketsOut()
+	u.updated = time.Now()
+	if deltaIn > 0 || deltaOut > 0 {
+		u.active = u.updated
+	}
+	return *u, deltaIn, deltaOut
+}
+
+// touch records the session's traffic reported by other sources than its Interim-Updates
+func (ut *usageTable) touch(sid string) {
+	ut.mu.Lock()
+	if u, ok := ut.sessions[sid]; ok {
+		u.active = time.Now()
+	}
+	ut.mu.Unlock()
+}
+
+// get returns the session's accumulated usage
+func (ut *usageTable) get(sid string) (localUsage, bool) {
+	ut.mu.Lock()
+	defer ut.mu.Unlock()
+	u, ok := ut.sessions[sid]
+	if !ok {
+		return localUsage{}, false
+	}
+	return *u, true
+}
+
+func (ut *usageTable) remove(sid string) {
+	ut.mu.Lock()
+	delete(ut.sessions, sid)
+	ut.mu.Unlock()
+}
+
+// snapshot returns a copy of all accumulated usages
+func (ut *usageTable) snapshot() map[string]localUsage {
+	ut.mu.Lock()
+	defer ut.mu.Unlock()
+	res := make(map[string]localUsage, len(ut.sessions))
+	for sid, u := range ut.sessions {
+		res[sid] = *u
+	}
+	return res
+}
+
+func counterDelta(prev, curr uint32) uint32 {
+	if curr < prev {
+		return curr
+	}
+	return curr - prev
+}
+
+// octetsCounter returns the 64 bit octets counter of the NAS's 32 bit counter & the number of times it wrapped
+// around, its Acct-Input/Output-Gigawords
+func octetsCounter(gigawords, octets uint32) uint64 {
+	return uint64(gigawords)<<32 | uint64(octets)
+}
+
+func octetsDelta(prev, curr uint64) uint64 {
+	if curr < prev {
+		return curr
+	}
+	return curr - prev
+}
+
+// Reconcile returns a report comparing usage accumulated from Interim-Updates with usage reported by session manager
+// for the same sessions, matched by their session IDs so each of a subscriber's sessions is reconciled separately.
+// Sessions known only to one side are included in the report as fully diverged.
+func (srv *accountingService) Reconcile(
+	_ context.Context, req *protos.ReconciliationRequest) (*protos.ReconciliationReport, error) {
+
+	if req == nil {
+		return &protos.ReconciliationReport{}, status.Errorf(codes.InvalidArgument, "Nil Reconciliation Request")
+	}
+	threshold := req.GetThreshold()
+	if threshold < 0 {
+		return &protos.ReconciliationReport{}, status.Errorf(
+			codes.InvalidArgument, "Invalid divergence threshold: %f", threshold)
+	}
+	reported := map[string]*protos.SessionUsage{}
+	for _, u := range req.GetReported() {
+		if u == nil {
+			continue
+		}
+		if len(u.GetSessionId()) == 0 {
+			return &protos.ReconciliationReport{}, status.Errorf(
+				codes.InvalidArgument, "Missing session ID of IMSI %s usage", u.GetImsi())
+		}
+		reported[u.GetSessionId()] = u
+	}
+	report := &protos.ReconciliationReport{}
+	for sid, u := range srv.usage.snapshot() {
+		imsi := normalizeImsi(u.imsi)
+		entry := &protos.ReconciliationEntry{
+			SessionId:      sid,
+			Imsi:           imsi,
+			LocalOctetsIn:  u.octetsIn,
+			LocalOctetsOut: u.octetsOut,
+			Flagged:        srv.flagged(sid),
+		}
+		if r, ok := reported[sid]; ok {
+			entry.ReportedOctetsIn, entry.ReportedOctetsOut = r.GetOctetsIn(), r.GetOctetsOut()
+			delete(reported, sid)
+		}
+		report.Entries = append(report.Entries, entry)
+	}
+	for sid, r := range reported {
+		report.Entries = append(report.Entries, &protos.ReconciliationEntry{
+			SessionId:         sid,
+			Imsi:              normalizeImsi(r.GetImsi()),
+			ReportedOctetsIn:  r.GetOctetsIn(),
+			ReportedOctetsOut: r.GetOctetsOut(),
+		})
+	}
+	for _, entry := range report.Entries {
+		entry.Divergence = divergence(
+			entry.LocalOctetsIn+entry.LocalOctetsOut, entry.ReportedOctetsIn+entry.ReportedOctetsOut)
+		if entry.Divergence > threshold {
+			entry.Diverged = true
+			report.DivergedCount++
+		}
+		if entry.Flagged {
+			report.FlaggedCount++
+		}
+	}
+	sort.Slice(report.Entries, func(i, j int) bool {
+		return report.Entries[i].Divergence > report.Entries[j].Divergence
+	})
+	return report, nil
+}
+
+// divergence returns the difference between local & reported usage in percents of the larger of the two
+func divergence(local, reported uint64) float64 {
+	max, diff := local, local-reported
+	if reported > local {
+		max, diff = reported, reported-local
+	}
+	if max == 0 {
+		return 0
+	}
+	return float64(diff) * 100 / float64(max)
+}
+
+func normalizeImsi(imsi string) string {
+	return strings.TrimPrefix(imsi, imsiPrefix)
+}
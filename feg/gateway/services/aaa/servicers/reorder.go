@@ -0,0 +1,164 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSDstyle license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// package servcers implements WiFi AAA GRPC services
+package servicers
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+// Accounting reorder events
+const (
+	reorderDetected  = "detected"
+	reorderCorrected = "corrected"
+)
+
+// reorderTable tracks the last started session of each subscriber & APN, so a new session's Start arriving before
+// the Stop of the subscriber's previous session can be held until the Stop is applied. Session manager sessions are
+// identified by IMSI & APN, applying the previous session's Stop after the new session's Start would end the new one.
+// The started sessions are tracked until they are removed & the held Starts' Stop waits for up to the reorder window
+type reorderTable struct {
+	sync.Mutex
+	window   time.Duration
+	started  map[string]string       // subscriber key -> SID of the last started session
+	sessions map[string]string       // SID -> subscriber key of the tracked started sessions
+	stops    map[string]*pendingStop // SID -> Stop awaited by the held Starts
+}
+
+// pendingStop is closed when the session's Stop is applied, it's removed once all its held Starts stop waiting
+type pendingStop struct {
+	stopped chan struct{}
+	waiters int
+}
+
+func newReorderTable() *reorderTable {
+	return &reorderTable{started: map[string]string{}, sessions: map[string]string{}, stops: map[string]*pendingStop{}}
+}
+
+func subscriberKey(aaaCtx *protos.Context) string {
+	return aaaCtx.GetImsi() + "/" + aaaCtx.GetApn()
+}
+
+// SetReorderWindow sets the maximum time a session's Start is held waiting for the Stop of the subscriber's previous
+// session, 0 disables reordering & forgets the tracked sessions
+func (srv *accountingService) SetReorderWindow(window time.Duration) {
+	t := srv.reorder
+	t.Lock()
+	t.window = window
+	if window <= 0 {
+		t.started, t.sessions = map[string]string{}, map[string]string{}
+	}
+	t.Unlock()
+}
+
+// awaitPreviousStop holds the session's Start up to the reorder window if the subscriber's previously started
+// session is still active, i.e. its Stop may be still in flight
+func (srv *accountingService) awaitPreviousStop(ctx context.Context, aaaCtx *protos.Context) {
+	t := srv.reorder
+	key, sid := subscriberKey(aaaCtx), aaaCtx.GetSessionId()
+	t.Lock()
+	window, prev := t.window, t.started[key]
+	if window <= 0 || len(prev) == 0 || prev == sid || srv.sessions.GetSession(prev) == nil {
+		t.Unlock()
+		return
+	}
+	pending, ok := t.stops[prev]
+	if !ok {
+		pending = &pendingStop{stopped: make(chan struct{})}
+		t.stops[prev] = pending
+	}
+	pending.waiters++
+	t.Unlock()
+
+	metrics.AcctReorders.WithLabelValues(reorderDetected).Inc()
+	timer := time.NewTimer(window)
+	defer timer.Stop()
+	select {
+	case <-pending.stopped:
+		metrics.AcctReorders.WithLabelValues(reorderCorrected).Inc()
+		log.Printf("Start of session %s was applied after the Stop of previous session %s", sid, prev)
+		return
+	case <-timer.C:
+		log.Printf("Start of session %s was not preceded by the Stop of previous session %s within %v",
+			sid, prev, window)
+	case <-ctx.Done():
+	}
+	// the previous session may never be stopped, its pending Stop is forgotten with its last held Start
+	t.Lock()
+	pending.waiters--
+	if pending.waiters == 0 && t.stops[prev] == pending {
+		delete(t.stops, prev)
+	}
+	t.Unlock()
+}
+
+// started records the session as the subscriber's last started session
+func (srv *accountingService) started(aaaCtx *protos.Context) {
+	t := srv.reorder
+	t.Lock()
+	if t.window > 0 {
+		key, sid := subscriberKey(aaaCtx), aaaCtx.GetSessionId()
+		t.started[key] = sid
+		t.sessions[sid] = key
+	}
+	t.Unlock()
+}
+
+// isSuperseded returns true if a newer session of the stopped session's subscriber was already started, the late
+// Stop of the superseded session must not end the newer session
+func (srv *accountingService) isSuperseded(aaaCtx *protos.Context) bool {
+	t := srv.reorder
+	t.Lock()
+	defer t.Unlock()
+	if t.window <= 0 {
+		return false
+	}
+	last, ok := t.started[subscriberKey(aaaCtx)]
+	if !ok || last == aaaCtx.GetSessionId() {
+		return false
+	}
+	return srv.sessions.GetSession(last) != nil
+}
+
+// stopped releases Starts held for the session's Stop
+func (srv *accountingService) stopped(aaaCtx *protos.Context) {
+	t := srv.reorder
+	key, sid := subscriberKey(aaaCtx), aaaCtx.GetSessionId()
+	t.Lock()
+	if t.started[key] == sid {
+		delete(t.started, key)
+	}
+	delete(t.sessions, sid)
+	pending, ok := t.stops[sid]
+	delete(t.stops, sid)
+	t.Unlock()
+	if ok {
+		close(pending.stopped)
+	}
+}
+
+// remove forgets the removed session, the subscriber's next session isn't held for the Stop of a session which no
+// longer exists
+func (t *reorderTable) remove(sid string) {
+	t.Lock()
+	if key, ok := t.sessions[sid]; ok {
+		if t.started[key] == sid {
+			delete(t.started, key)
+		}
+		delete(t.sessions, sid)
+	}
+	t.Unlock()
+}
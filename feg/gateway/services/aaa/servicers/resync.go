@@ -0,0 +1,169 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// package servcers implements WiFi AAA GRPC services
+package servicers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"magma/feg/gateway/services/aaa"
+	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/aaa/protos"
+	"magma/feg/gateway/services/aaa/session_manager"
+	lte_protos "magma/lte/cloud/go/protos"
+)
+
+// resyncRetryInterval - interval of the session managers' listing retries while they're unavailable, e.g. sessiond
+// is still starting
+const resyncRetryInterval = 5 * time.Second
+
+// ResyncResult - session counts of a session table resynchronization with the session managers
+type ResyncResult struct {
+	// InSync - session manager's sessions known to the session table
+	InSync int
+	// Reconciled - session manager's sessions rebuilt in the session table
+	Reconciled int
+	// Orphaned - session manager's sessions which couldn't be rebuilt & are ended
+	Orphaned int
+	// Unmanaged - session table's sessions unknown to the session managers, e.g. authenticated but not started
+	Unmanaged int
+}
+
+// ResyncSessions resynchronizes the session table with the CWF sessions of all routed session managers, e.g. after
+// an AAA server restart which lost the table's sessions: session manager's sessions unknown to the table are rebuilt
+// from their RADIUS session IDs, or ended if they can't be rebuilt, so they don't outlive their NAS sessions. The
+// table's sessions unknown to the session managers are only counted, unless a session manager couldn't be listed.
+// Unavailable session managers are retried until ctx is done
+func (srv *accountingService) ResyncSessions(ctx context.Context) (ResyncResult, error) {
+	var (
+		listed []*lte_protos.LocalSessionInfo
+		errs   []string
+	)
+	for _, service := range session_manager.Services() {
+		sessions, err := listManagedSessions(ctx, service)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", service, err))
+			continue
+		}
+		listed = append(listed, sessions...)
+	}
+	res, orphans := srv.resync(listed, len(errs) == 0)
+	for _, info := range orphans {
+		orphan := &protos.Context{Imsi: info.GetSid().GetId(), Apn: info.GetApn()}
+		if err := srv.endManagedSession(ctx, orphan); err != nil {
+			log.Printf("Error ending orphaned session manager session of %s: %v", orphan.GetImsi(), err)
+		}
+	}
+	metrics.ResyncedSessions.WithLabelValues("in_sync").Add(float64(res.InSync))
+	metrics.ResyncedSessions.WithLabelValues("reconciled").Add(float64(res.Reconciled))
+	metrics.ResyncedSessions.WithLabelValues("orphaned").Add(float64(res.Orphaned))
+	metrics.ResyncedSessions.WithLabelValues("unmanaged").Add(float64(res.Unmanaged))
+	if len(errs) > 0 {
+		return res, errors.New("Error listing session manager sessions: " + strings.Join(errs, "; "))
+	}
+	return res, nil
+}
+
+// listManagedSessions lists the session manager's CWF sessions, retrying while the session manager is unavailable
+func listManagedSessions(ctx context.Context, service string) ([]*lte_protos.LocalSessionInfo, error) {
+	req := &lte_protos.LocalListSessionsRequest{RatType: lte_protos.RATType_TGPP_WLAN}
+	for {
+		res, err := session_manager.ListSessions(ctx, service, req)
+		if err == nil {
+			return res.GetSessions(), nil
+		}
+		if !sessionManagerUnavailable(err) {
+			return nil, err
+		}
+		log.Printf("Session manager %s is unavailable for sessions resync, retrying in %v: %v",
+			service, resyncRetryInterval, err)
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(resyncRetryInterval):
+		}
+	}
+}
+
+// resync rebuilds the listed session manager's sessions missing in the session table & returns the resync's counts
+// with the sessions which couldn't be rebuilt. The table's sessions missing in the listing are counted if the
+// listing is complete
+func (srv *accountingService) resync(
+	listed []*lte_protos.LocalSessionInfo, complete bool) (ResyncResult, []*lte_protos.LocalSessionInfo) {
+
+	var (
+		res     ResyncResult
+		orphans []*lte_protos.LocalSessionInfo
+	)
+	managed := map[string]bool{}
+	for _, info := range listed {
+		imsi := makeSID(info.GetSid().GetId()).GetId()
+		managed[imsi] = true
+		if srv.findSubscriberSession(imsi) {
+			res.InSync++
+			continue
+		}
+		if err := srv.rebuildSession(info); err != nil {
+			log.Printf("Orphaned session manager session %s of %s can't be rebuilt: %v",
+				info.GetSessionId(), imsi, err)
+			orphans = append(orphans, info)
+			res.Orphaned++
+			continue
+		}
+		log.Printf("Rebuilt session %s of %s from session manager session %s",
+			info.GetRadiusSessionId(), imsi, info.GetSessionId())
+		res.Reconciled++
+	}
+	if lister, ok := srv.sessions.(aaa.SessionLister); ok && complete {
+		for _, st := range lister.ListSessions() {
+			imsi := st.Session.GetCtx().GetImsi()
+			if !managed[makeSID(imsi).GetId()] {
+				res.Unmanaged++
+			}
+		}
+	}
+	return res, orphans
+}
+
+// findSubscriberSession returns true if the session table has a session of the subscriber, the table's IMSIs may
+// or may not have the IMSI prefix
+func (srv *accountingService) findSubscriberSession(imsi string) bool {
+	return len(srv.sessions.FindSession(normalizeImsi(imsi))) > 0 || len(srv.sessions.FindSession(imsi)) > 0
+}
+
+// rebuildSession adds the session manager's session to the session table, by its RADIUS session ID. The rebuilt
+// session times out like other sessions if its NAS doesn't account it
+func (srv *accountingService) rebuildSession(info *lte_protos.LocalSessionInfo) error {
+	sid := info.GetRadiusSessionId()
+	if len(sid) == 0 {
+		return status.Errorf(codes.FailedPrecondition, "Missing RADIUS session ID")
+	}
+	aaaCtx := &protos.Context{
+		SessionId: sid,
+		Imsi:      normalizeImsi(info.GetSid().GetId()),
+		Msisdn:    info.GetMsisdn(),
+		Apn:       info.GetApn(),
+		MacAddr:   info.GetMacAddr(),
+		IpAddr:    info.GetUeIpv4(),
+	}
+	if _, err := srv.sessions.AddSession(aaaCtx, srv.settings(aaaCtx).idleTimeout, srv.timeoutSessionNotifier); err != nil {
+		return err
+	}
+	srv.capacity.moveSession(sid, aaaCtx.GetApn())
+	srv.seen(aaaCtx)
+	return nil
+}
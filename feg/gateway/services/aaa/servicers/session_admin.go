@@ -0,0 +1,209 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// package servcers implements WiFi AAA GRPC services
+package servicers
+
+import (
+	"log"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"magma/feg/gateway/services/aaa/adminauth"
+	"magma/feg/gateway/services/aaa/audit"
+	"magma/feg/gateway/services/aaa/protos"
+	"magma/feg/gateway/settings"
+)
+
+const maxMsisdnLen = 15
+
+// editableFields - setters of the session context fields editable by the admin API by their proto names
+var editableFields = map[string]func(aaaCtx *protos.Context, value string){
+	"identity":       func(c *protos.Context, v string) { c.Identity = v },
+	"msisdn":         func(c *protos.Context, v string) { c.Msisdn = v },
+	"apn":            func(c *protos.Context, v string) { c.Apn = v },
+	"mac_addr":       func(c *protos.Context, v string) { c.MacAddr = v },
+	"ip_addr":        func(c *protos.Context, v string) { c.IpAddr = v },
+	"ipv6_addr":      func(c *protos.Context, v string) { c.Ipv6Addr = v },
+	"ipv6_prefix":    func(c *protos.Context, v string) { c.Ipv6Prefix = v },
+	"outer_identity": func(c *protos.Context, v string) { c.OuterIdentity = v },
+}
+
+type sessionAdminService struct {
+	acct   *accountingService
+	auth   *adminauth.Authorizer
+	config *settings.Loader
+	info   func() *protos.ServiceInfo
+}
+
+// NewSessionAdminService returns the session admin service of the accounting service's sessions, calls are
+// authorized by the callers' roles granted by the given authorizer. The effective configuration is dumped from the
+// given settings loader & the service info is reported by the given info function
+func NewSessionAdminService(
+	acct *accountingService,
+	auth *adminauth.Authorizer,
+	config *settings.Loader,
+	info func() *protos.ServiceInfo) (protos.SessionAdminServer, error) {
+
+	if acct == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Nil accounting service")
+	}
+	if auth == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Nil admin authorizer")
+	}
+	return &sessionAdminService{acct: acct, auth: auth, config: config, info: info}, nil
+}
+
+// PatchSession changes the live session's context & records the changes in the audit log
+func (srv *sessionAdminService) PatchSession(
+	ctx context.Context, req *protos.SessionPatchRequest) (*protos.SessionPatchResult, error) {
+
+	if err := srv.authorize(ctx, "PatchSession", adminauth.Operator); err != nil {
+		return nil, err
+	}
+	if req == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Nil Session Patch Request")
+	}
+	if len(req.GetOperator()) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "Missing operator")
+	}
+	if err := validatePatch(req); err != nil {
+		return nil, err
+	}
+	sid := req.GetSessionId()
+	s := srv.acct.sessions.GetSession(sid)
+	if s == nil {
+		return nil, status.Errorf(codes.NotFound, "Session %s is not found", sid)
+	}
+	old, aaaCtx, err := srv.acct.sessions.UpdateCtx(sid, func(aaaCtx *protos.Context) error {
+		for _, field := range sortedKeys(req.GetFields()) {
+			editableFields[field](aaaCtx, req.GetFields()[field])
+		}
+		if err := srv.acct.attributes.merge(aaaCtx, req.GetAttributes()); err != nil {
+			return status.Errorf(codes.InvalidArgument, "Invalid attributes: %v", err)
+		}
+		return nil
+	})
+	if _, ok := status.FromError(err); !ok {
+		return nil, status.Errorf(codes.NotFound, "%v", err) // the session was removed meanwhile
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	changes := contextChanges(old, aaaCtx)
+	if old.GetApn() != aaaCtx.GetApn() {
+		srv.acct.capacity.moveSession(sid, aaaCtx.GetApn())
+	}
+	srv.acct.patchDirectory(aaaCtx)
+	auditChanges := make([]audit.Change, 0, len(changes))
+	for _, c := range changes {
+		auditChanges = append(auditChanges, audit.Change{Field: c.GetField(), Old: c.GetOldValue(), New: c.GetNewValue()})
+	}
+	srv.acct.auditEventWith(audit.Patch, aaaCtx, func(ev *audit.Event) {
+		ev.Operator, ev.Reason, ev.Changes = req.GetOperator(), req.GetReason(), auditChanges
+	})
+	log.Printf("Session %s of IMSI %s is patched by %s: %d changes", sid, aaaCtx.GetImsi(), req.GetOperator(), len(changes))
+
+	resCtx := proto.Clone(aaaCtx).(*protos.Context)
+	resCtx.Msk = nil
+	return &protos.SessionPatchResult{Ctx: resCtx, Changes: changes}, nil
+}
+
+// authorize verifies that the caller's role permits the method's calls
+func (srv *sessionAdminService) authorize(ctx context.Context, method string, required adminauth.Role) error {
+	principal, err := srv.auth.Authorize(ctx, required)
+	if err != nil {
+		log.Printf("Session admin %s call denied: %v", method, err)
+		return err
+	}
+	if required != adminauth.ReadOnly {
+		log.Printf("Session admin %s call by %s (%s)", method, principal.Name, principal.Role)
+	}
+	return nil
+}
+
+// validatePatch verifies that all patched fields are editable & their values are valid
+func validatePatch(req *protos.SessionPatchRequest) error {
+	for field, value := range req.GetFields() {
+		if _, ok := editableFields[field]; !ok {
+			return status.Errorf(codes.InvalidArgument, "Field '%s' is not editable", field)
+		}
+		switch field {
+		case "msisdn":
+			if len(value) > maxMsisdnLen || strings.IndexFunc(value, func(r rune) bool { return r < '0' || r > '9' }) >= 0 {
+				return status.Errorf(codes.InvalidArgument, "Invalid MSISDN: '%s'", value)
+			}
+		case "mac_addr":
+			if _, err := net.ParseMAC(value); err != nil {
+				return status.Errorf(codes.InvalidArgument, "Invalid MAC Address '%s': %v", value, err)
+			}
+		case "ip_addr":
+			if net.ParseIP(value) == nil {
+				return status.Errorf(codes.InvalidArgument, "Invalid IP Address: '%s'", value)
+			}
+		case "ipv6_addr":
+			if _, err := (&protos.Context{Ipv6Addr: value}).UEAddresses(); err != nil {
+				return status.Errorf(codes.InvalidArgument, "Invalid IPv6 Address: '%s'", value)
+			}
+		case "ipv6_prefix":
+			if _, err := (&protos.Context{Ipv6Prefix: value}).UEAddresses(); err != nil {
+				return status.Errorf(codes.InvalidArgument, "Invalid IPv6 Prefix: '%s'", value)
+			}
+		}
+	}
+	if err := protos.ValidateAttributes(req.GetAttributes()); err != nil {
+		return status.Errorf(codes.InvalidArgument, "Invalid attributes: %v", err)
+	}
+	return nil
+}
+
+// contextChanges returns changed fields & attributes of the patched context, attributes are named "attributes.<key>"
+func contextChanges(old, patched *protos.Context) []*protos.FieldChange {
+	var changes []*protos.FieldChange
+	fields := []struct {
+		name     string
+		old, new string
+	}{
+		{"identity", old.GetIdentity(), patched.GetIdentity()},
+		{"msisdn", old.GetMsisdn(), patched.GetMsisdn()},
+		{"apn", old.GetApn(), patched.GetApn()},
+		{"mac_addr", old.GetMacAddr(), patched.GetMacAddr()},
+		{"ip_addr", old.GetIpAddr(), patched.GetIpAddr()},
+		{"ipv6_addr", old.GetIpv6Addr(), patched.GetIpv6Addr()},
+		{"ipv6_prefix", old.GetIpv6Prefix(), patched.GetIpv6Prefix()},
+		{"outer_identity", old.GetOuterIdentity(), patched.GetOuterIdentity()},
+	}
+	for _, f := range fields {
+		if f.old != f.new {
+			changes = append(changes, &protos.FieldChange{Field: f.name, OldValue: f.old, NewValue: f.new})
+		}
+	}
+	for _, k := range sortedKeys(patched.GetAttributes()) {
+		if v, ok := old.GetAttribute(k); !ok || v != patched.GetAttributes()[k] {
+			changes = append(changes,
+				&protos.FieldChange{Field: "attributes." + k, OldValue: v, NewValue: patched.GetAttributes()[k]})
+		}
+	}
+	return changes
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,129 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// package servcers implements WiFi AAA GRPC services
+package servicers
+
+import (
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"magma/feg/gateway/services/aaa/audit"
+	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+// MaxEventSubscribers - maximum number of concurrent SubscribeEvents streams
+const MaxEventSubscribers = 64
+
+// eventBacklog - maximum number of a SubscribeEvents stream's pending events, the events of slower clients are
+// dropped, so they never block accounting
+const eventBacklog = 1024
+
+// eventSubscriber - a SubscribeEvents stream's filters & pending events
+type eventSubscriber struct {
+	types  map[string]bool // all types if empty
+	imsi   string          // normalized, all subscribers if empty
+	events chan *protos.SessionEvent
+}
+
+func (s *eventSubscriber) matches(ev *protos.SessionEvent) bool {
+	return (len(s.types) == 0 || s.types[ev.Type]) && (len(s.imsi) == 0 || s.imsi == ev.Imsi)
+}
+
+// eventSubscribers - SubscribeEvents streams, it implements audit.Sink
+type eventSubscribers struct {
+	sync.Mutex
+	subscribers map[*eventSubscriber]struct{}
+}
+
+func newEventSubscribers() *eventSubscribers {
+	return &eventSubscribers{subscribers: map[*eventSubscriber]struct{}{}}
+}
+
+func (es *eventSubscribers) add(req *protos.SessionEventsRequest) (*eventSubscriber, error) {
+	es.Lock()
+	defer es.Unlock()
+	if len(es.subscribers) >= MaxEventSubscribers {
+		return nil, status.Errorf(
+			codes.ResourceExhausted, "Too many session event subscribers, maximum: %d", MaxEventSubscribers)
+	}
+	s := &eventSubscriber{
+		types:  map[string]bool{},
+		imsi:   normalizeImsi(req.GetImsi()),
+		events: make(chan *protos.SessionEvent, eventBacklog),
+	}
+	for _, typ := range req.GetTypes() {
+		s.types[typ] = true
+	}
+	es.subscribers[s] = struct{}{}
+	return s, nil
+}
+
+func (es *eventSubscribers) remove(s *eventSubscriber) {
+	es.Lock()
+	delete(es.subscribers, s)
+	es.Unlock()
+}
+
+// Log implements audit.Sink, the event is queued to all subscribers whose filters it matches
+func (es *eventSubscribers) Log(ev *audit.Event) error {
+	es.Lock()
+	defer es.Unlock()
+	if ev == nil || len(es.subscribers) == 0 {
+		return nil
+	}
+	sev := &protos.SessionEvent{
+		Type:       string(ev.Type),
+		SessionId:  ev.SessionId,
+		Imsi:       normalizeImsi(ev.Imsi),
+		Apn:        ev.Apn,
+		TimeMs:     ev.Time.UnixNano() / int64(time.Millisecond),
+		DurationMs: ev.DurationNs / int64(time.Millisecond),
+		OctetsIn:   ev.OctetsIn,
+		OctetsOut:  ev.OctetsOut,
+		Reason:     ev.Reason,
+	}
+	for s := range es.subscribers {
+		if !s.matches(sev) {
+			continue
+		}
+		select {
+		case s.events <- sev:
+		default:
+			metrics.DroppedSessionEvents.Inc()
+		}
+	}
+	return nil
+}
+
+// SubscribeEvents streams the session lifecycle events matching the request's filters until the client cancels
+func (srv *accountingService) SubscribeEvents(
+	req *protos.SessionEventsRequest, stream protos.Accounting_SubscribeEventsServer) error {
+
+	s, err := srv.events.add(req)
+	if err != nil {
+		return err
+	}
+	defer srv.events.remove(s)
+
+	ctx := stream.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev := <-s.events:
+			if err = stream.Send(ev); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,178 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSDstyle license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// package servcers implements WiFi AAA GRPC services
+package servicers
+
+import (
+	"log"
+	"sort"
+	"strings"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"magma/feg/gateway/services/aaa"
+	"magma/feg/gateway/services/aaa/adminauth"
+	"magma/feg/gateway/services/aaa/audit"
+	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+// ListSessions returns the live sessions matching the request's filters, ordered by their session IDs
+func (srv *sessionAdminService) ListSessions(
+	ctx context.Context, req *protos.ListSessionsRequest) (*protos.SessionList, error) {
+
+	if err := srv.authorize(ctx, "ListSessions", adminauth.ReadOnly); err != nil {
+		return nil, err
+	}
+	filters := map[string]string{}
+	if imsi := strings.TrimPrefix(req.GetImsi(), imsiPrefix); len(imsi) > 0 {
+		filters[aaa.IMSIIndex] = imsi
+	}
+	if len(req.GetMacAddr()) > 0 {
+		mac := aaa.MACKey(req.GetMacAddr())
+		if len(mac) == 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid MAC Address '%s'", req.GetMacAddr())
+		}
+		filters[aaa.MACIndex] = mac
+	}
+	if len(req.GetApn()) > 0 {
+		filters[aaa.APNIndex] = strings.ToLower(req.GetApn())
+	}
+	listed, err := srv.listSessions(func(aaaCtx *protos.Context) bool {
+		for index, key := range filters {
+			if aaa.StandardIndexes[index](aaaCtx) != key {
+				return false
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &protos.SessionList{Sessions: listed}, nil
+}
+
+// GetSession returns the live session
+func (srv *sessionAdminService) GetSession(
+	ctx context.Context, req *protos.GetSessionRequest) (*protos.ExportedSession, error) {
+
+	if err := srv.authorize(ctx, "GetSession", adminauth.ReadOnly); err != nil {
+		return nil, err
+	}
+	sid := req.GetSessionId()
+	if len(sid) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "Missing session ID")
+	}
+	listed, err := srv.listSessions(func(aaaCtx *protos.Context) bool { return aaaCtx.GetSessionId() == sid })
+	if err != nil {
+		return nil, err
+	}
+	if len(listed) == 0 {
+		return nil, status.Errorf(codes.NotFound, "Session %s is not found", sid)
+	}
+	return listed[0], nil
+}
+
+// TerminateSessionByImsi ends all live sessions of the IMSI in session manager, disconnects their UEs & records the
+// terminations in the audit log
+func (srv *sessionAdminService) TerminateSessionByImsi(
+	ctx context.Context, req *protos.TerminateSessionsRequest) (*protos.TerminateSessionsResult, error) {
+
+	if err := srv.authorize(ctx, "TerminateSessionByImsi", adminauth.Operator); err != nil {
+		return nil, err
+	}
+	imsi := strings.TrimPrefix(req.GetImsi(), imsiPrefix)
+	if len(imsi) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "Missing IMSI")
+	}
+	if len(req.GetOperator()) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "Missing operator")
+	}
+	sessions := aaa.FindSessions(srv.acct.sessions, aaa.IMSIIndex, imsi)
+	if len(sessions) == 0 {
+		// the subscriber's latest session, if the sessions table can neither look up nor list its sessions
+		if s := srv.acct.sessions.GetSession(srv.acct.sessions.FindSession(imsi)); s != nil {
+			sessions = append(sessions, s)
+		}
+	}
+	res := &protos.TerminateSessionsResult{}
+	var removed []*protos.Context
+	for _, s := range sessions {
+		aaaCtx := srv.acct.removeByOperator(s.GetCtx().GetSessionId(), req.GetOperator(), req.GetReason())
+		if aaaCtx != nil {
+			removed = append(removed, aaaCtx)
+			res.SessionIds = append(res.SessionIds, aaaCtx.GetSessionId())
+		}
+	}
+	if len(removed) == 0 {
+		return nil, status.Errorf(codes.NotFound, "No sessions of IMSI %s are found", imsi)
+	}
+	sort.Strings(res.SessionIds)
+	log.Printf("%d sessions of IMSI %s are terminated by %s: %s",
+		len(removed), imsi, req.GetOperator(), req.GetReason())
+
+	// all the subscriber's sessions share its session manager session
+	var errs []string
+	if srv.acct.settings(removed[0]).accountingEnabled {
+		if err := srv.acct.endManagedSession(ctx, removed[0]); err != nil {
+			errs = append(errs, "session manager: "+err.Error())
+		}
+	}
+	for _, aaaCtx := range removed {
+		if err := srv.acct.disconnect(ctx, aaaCtx, protos.TerminateReason_ADMIN_ACTION); err != nil {
+			errs = append(errs, "Radius: "+err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return res, status.Errorf(codes.Internal, "Sessions of IMSI %s are removed, termination errors: %s",
+			imsi, strings.Join(errs, "; "))
+	}
+	return res, nil
+}
+
+// listSessions returns the exported sessions matching the filter, ordered by their session IDs
+func (srv *sessionAdminService) listSessions(
+	filter func(aaaCtx *protos.Context) bool) ([]*protos.ExportedSession, error) {
+
+	lister, ok := srv.acct.sessions.(aaa.SessionLister)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "Session table does not support session listing")
+	}
+	var res []*protos.ExportedSession
+	for _, st := range lister.ListSessions() {
+		if filter(st.Session.GetCtx()) {
+			res = append(res, srv.acct.exportSession(st))
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].GetCtx().GetSessionId() < res[j].GetCtx().GetSessionId() })
+	return res, nil
+}
+
+// removeByOperator removes the session terminated by the operator, audits its termination & returns its context,
+// nil if the session is already gone
+func (srv *accountingService) removeByOperator(sid, operator, reason string) *protos.Context {
+	// the session is removed before its queued Interim-Updates run, so they don't re-arm its idle timeout
+	end := srv.ops.begin(sid, opTerminate)
+	defer end()
+	s := srv.sessions.RemoveSession(sid)
+	if s == nil {
+		return nil
+	}
+	aaaCtx := s.GetCtx()
+	srv.auditEventWith(audit.Terminate, aaaCtx, func(ev *audit.Event) {
+		ev.Operator, ev.Reason = operator, reason
+	})
+	srv.publishSessionEnd(sid, aaaCtx.GetImsi())
+	srv.clearSessionState(sid)
+	srv.stopped(aaaCtx)
+	metrics.SessionTerminate.WithLabelValues(aaaCtx.GetApn(), aaaCtx.GetImsi()).Inc()
+	return aaaCtx
+}
@@ -0,0 +1,77 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// package servcers implements WiFi AAA GRPC services
+package servicers
+
+import "sync"
+
+// Priorities of a session's operations
+const (
+	opInterim = iota
+	opTerminate
+	numOpPriorities
+)
+
+// sessionOps serializes the operations of each session & runs a session's queued operations by their priorities, so
+// a session manager initiated termination isn't delayed by, and isn't undone by, the session's queued Interim-Updates
+type sessionOps struct {
+	mu     sync.Mutex
+	queues map[string]*sessionOpQueue // by session ID, of the sessions with running or queued operations
+}
+
+type sessionOpQueue struct {
+	ready   *sync.Cond // broadcast when the session's running operation ends
+	running bool
+	waiting [numOpPriorities]int // queued operations by priority
+}
+
+func newSessionOps() *sessionOps {
+	return &sessionOps{queues: map[string]*sessionOpQueue{}}
+}
+
+// begin waits until the session has neither a running operation nor a queued operation of a higher priority & returns
+// the operation's end, which must be called once the operation is done
+func (o *sessionOps) begin(sid string, priority int) (end func()) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	q, ok := o.queues[sid]
+	if !ok {
+		q = &sessionOpQueue{ready: sync.NewCond(&o.mu)}
+		o.queues[sid] = q
+	}
+	q.waiting[priority]++
+	for q.running || q.waitingAbove(priority) {
+		q.ready.Wait()
+	}
+	q.waiting[priority]--
+	q.running = true
+	return func() {
+		o.mu.Lock()
+		q.running = false
+		if q.idle() {
+			delete(o.queues, sid)
+		} else {
+			q.ready.Broadcast()
+		}
+		o.mu.Unlock()
+	}
+}
+
+func (q *sessionOpQueue) waitingAbove(priority int) bool {
+	for p := priority + 1; p < numOpPriorities; p++ {
+		if q.waiting[p] > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (q *sessionOpQueue) idle() bool {
+	return !q.running && !q.waitingAbove(-1)
+}
@@ -0,0 +1,106 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// package servcers implements WiFi AAA GRPC services
+package servicers
+
+import (
+	"sync"
+	"time"
+
+	"magma/feg/gateway/services/aaa/audit"
+	"magma/feg/gateway/services/aaa/metrics"
+)
+
+// DefaultTerminatedLinger - time a terminated session is remembered for, so its NAS's late Interim-Updates & Stops
+// are acknowledged
+const DefaultTerminatedLinger = 5 * time.Minute
+
+// Late accounting requests' Acct-Status-Types
+const (
+	lateInterim = "interim"
+	lateStop    = "stop"
+)
+
+// terminatedTable - negative cache of the recently removed sessions' removal reasons by session ID, kept up to the
+// linger time. Accounting requests racing a session's termination are expected after every normal termination, e.g.
+// an Interim-Update sent before the NAS processed the Disconnect-Request, & aren't errors
+type terminatedTable struct {
+	sync.Mutex
+	linger    time.Duration // 0 - terminated sessions aren't remembered
+	sessions  map[string]terminatedSession
+	nextPurge time.Time
+}
+
+type terminatedSession struct {
+	reason     audit.EventType
+	terminated time.Time
+}
+
+func newTerminatedTable() *terminatedTable {
+	return &terminatedTable{linger: DefaultTerminatedLinger, sessions: map[string]terminatedSession{}}
+}
+
+// SetTerminatedLinger sets the time the terminated sessions are remembered for, late accounting requests of sessions
+// terminated within it are acknowledged & counted instead of failing. 0 disables it
+func (srv *accountingService) SetTerminatedLinger(linger time.Duration) {
+	srv.terminated.Lock()
+	srv.terminated.linger = linger
+	if linger <= 0 {
+		srv.terminated.sessions = map[string]terminatedSession{}
+	}
+	srv.terminated.Unlock()
+}
+
+// add remembers the session's termination of the given reason
+func (t *terminatedTable) add(sid string, reason audit.EventType) {
+	now := time.Now()
+	t.Lock()
+	defer t.Unlock()
+	if t.linger <= 0 {
+		return
+	}
+	t.purge(now)
+	t.sessions[sid] = terminatedSession{reason: reason, terminated: now}
+}
+
+// get returns the reason of the session's termination within the linger time, if any
+func (t *terminatedTable) get(sid string) (audit.EventType, bool) {
+	t.Lock()
+	defer t.Unlock()
+	s, ok := t.sessions[sid]
+	if !ok || time.Since(s.terminated) >= t.linger {
+		return "", false
+	}
+	return s.reason, true
+}
+
+// purge deletes the sessions terminated past the linger time, at most once per linger time. It must be called with
+// the lock held
+func (t *terminatedTable) purge(now time.Time) {
+	if now.Before(t.nextPurge) {
+		return
+	}
+	t.nextPurge = now.Add(t.linger)
+	for sid, s := range t.sessions {
+		if now.Sub(s.terminated) >= t.linger {
+			delete(t.sessions, sid)
+		}
+	}
+}
+
+// lateRequest returns true if the session of the NAS's accounting request was terminated within the linger time,
+// the late request is counted & should be acknowledged
+func (srv *accountingService) lateRequest(sid, typ string) bool {
+	reason, ok := srv.terminated.get(sid)
+	if !ok {
+		return false
+	}
+	metrics.LateAcctRequests.WithLabelValues(typ, string(reason)).Inc()
+	return true
+}
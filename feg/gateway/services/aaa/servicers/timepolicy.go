@@ -0,0 +1,186 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSDstyle license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// package servcers implements WiFi AAA GRPC services
+package servicers
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"magma/feg/gateway/services/aaa/audit"
+	"magma/feg/gateway/services/aaa/coalog"
+	"magma/feg/gateway/services/aaa/deadlines"
+	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/aaa/panics"
+	"magma/feg/gateway/services/aaa/protos"
+	"magma/feg/gateway/services/aaa/timepolicy"
+)
+
+type sessionPolicy struct {
+	check   *time.Timer        // next scheduled policy check
+	limited *timepolicy.Window // currently applied RateLimit window, nil if none
+}
+
+// policyTable keeps sessions' scheduled time policy checks & applied rate limits
+type policyTable struct {
+	sync.Mutex
+	sessions map[string]*sessionPolicy
+}
+
+func newPolicyTable() *policyTable {
+	return &policyTable{sessions: map[string]*sessionPolicy{}}
+}
+
+// remove forgets the session & cancels its scheduled policy check if any
+func (t *policyTable) remove(sid string) {
+	t.Lock()
+	if sp, ok := t.sessions[sid]; ok {
+		if sp.check != nil {
+			sp.check.Stop()
+		}
+		delete(t.sessions, sid)
+	}
+	t.Unlock()
+}
+
+// SetTimePolicy enables time of day policies of established sessions, nil disables them
+func (srv *accountingService) SetTimePolicy(p *timepolicy.Policy) {
+	srv.timePolicy = p
+}
+
+// applyAcceptTimePolicy sets the maximum bandwidth of the APN's currently active RateLimit window into the newly
+// authenticated session's context, so the NAS gets it with the Access-Accept rather than with a CoA after the
+// session starts
+func (srv *accountingService) applyAcceptTimePolicy(aaaCtx *protos.Context) {
+	if srv == nil || srv.timePolicy == nil {
+		return
+	}
+	active := srv.timePolicy.Active(aaaCtx.GetApn(), time.Now())
+	if active == nil || active.Action != timepolicy.RateLimit {
+		return
+	}
+	err := aaaCtx.SetUintAttribute(timepolicy.BandwidthUpAttribute, uint64(active.MaxBandwidthUp))
+	if err == nil {
+		err = aaaCtx.SetUintAttribute(timepolicy.BandwidthDownAttribute, uint64(active.MaxBandwidthDown))
+	}
+	if err == nil {
+		err = aaaCtx.SetAttribute(timepolicy.WindowAttribute, active.Name)
+	}
+	if err != nil {
+		log.Printf("Time policy '%s' rate limit of session %s at Accept failed: %v",
+			active.Name, aaaCtx.GetSessionId(), err)
+		return
+	}
+	metrics.TimePolicyActions.WithLabelValues(aaaCtx.GetApn(), active.Name, string(active.Action)).Inc()
+}
+
+// applyTimePolicy applies the session's currently active policy window & schedules the next check at the APN's
+// next window start or end
+func (srv *accountingService) applyTimePolicy(sid string) {
+	if srv.timePolicy == nil {
+		return
+	}
+	defer panics.Recover("time_policy")
+	s := srv.sessions.GetSession(sid)
+	if s == nil {
+		srv.policies.remove(sid)
+		return
+	}
+	aaaCtx := s.GetCtx()
+	apn := aaaCtx.GetApn()
+	now := time.Now()
+	active := srv.timePolicy.Active(apn, now)
+
+	if active != nil && active.Action == timepolicy.Terminate {
+		srv.policies.remove(sid)
+		srv.terminateByPolicy(sid, active)
+		return
+	}
+
+	t := srv.policies
+	t.Lock()
+	sp, ok := t.sessions[sid]
+	if !ok {
+		sp = &sessionPolicy{}
+		t.sessions[sid] = sp
+		// the window active at Accept was already applied with the Access-Accept
+		if name, ok := aaaCtx.GetAttribute(timepolicy.WindowAttribute); ok && active != nil && name == active.Name {
+			sp.limited = active
+		}
+	} else if sp.check != nil {
+		sp.check.Stop()
+	}
+	limited := sp.limited
+	if next := srv.timePolicy.NextChange(apn, now); !next.IsZero() {
+		sp.check = time.AfterFunc(next.Sub(now), func() { srv.applyTimePolicy(sid) })
+	}
+	t.Unlock()
+
+	// Don't hold the table lock while waiting for the CoA response
+	ctx, cancel := deadlines.Background()
+	defer cancel()
+	switch {
+	case active != nil && active != limited:
+		bw := bandwidth{up: active.MaxBandwidthUp, down: active.MaxBandwidthDown}
+		if err := srv.changeBandwidth(coalog.WithTrigger(ctx, "time_policy", active.Name), aaaCtx, bw); err != nil {
+			log.Printf("Time policy '%s' rate limit of session %s failed: %v", active.Name, sid, err)
+			return
+		}
+		metrics.TimePolicyActions.WithLabelValues(apn, active.Name, string(active.Action)).Inc()
+		log.Printf("Time policy '%s' limited session %s bandwidth to up: %d, down: %d", active.Name, sid, bw.up, bw.down)
+	case active == nil && limited != nil:
+		srv.bandwidths.Lock()
+		var base *bandwidth
+		if sb, ok := srv.bandwidths.sessions[sid]; ok && sb.base != nil {
+			base = &bandwidth{up: sb.base.up, down: sb.base.down}
+		}
+		srv.bandwidths.Unlock()
+		if up, down := srv.timePolicy.BaseBandwidth(); base == nil && up > 0 && down > 0 {
+			base = &bandwidth{up: up, down: down}
+		}
+		if base == nil {
+			log.Printf("Time policy '%s' ended: unknown base bandwidth of session %s, rate limit is kept", limited.Name, sid)
+		} else if err := srv.changeBandwidth(
+			coalog.WithTrigger(ctx, "time_policy", limited.Name), aaaCtx, *base); err != nil {
+			log.Printf("Time policy '%s' rate limit removal of session %s failed: %v", limited.Name, sid, err)
+			return
+		} else {
+			log.Printf("Time policy '%s' ended, session %s bandwidth restored to up: %d, down: %d",
+				limited.Name, sid, base.up, base.down)
+		}
+	default:
+		return
+	}
+	t.Lock()
+	if t.sessions[sid] == sp {
+		sp.limited = active
+	}
+	t.Unlock()
+}
+
+// terminateByPolicy removes the session, ends it in session manager & disconnects its UE
+func (srv *accountingService) terminateByPolicy(sid string, w *timepolicy.Window) {
+	s := srv.sessions.RemoveSession(sid)
+	if s == nil {
+		return
+	}
+	srv.forgetSession(sid, audit.Terminate, s)
+	metrics.TimePolicyActions.WithLabelValues(s.GetCtx().GetApn(), w.Name, string(w.Action)).Inc()
+	log.Printf("Time policy '%s' terminates session %s", w.Name, sid)
+	go func() {
+		defer panics.Recover("time_policy_termination")
+		ctx, cancel := deadlines.Background()
+		defer cancel()
+		ctx = coalog.WithTrigger(ctx, "time_policy_termination", w.Name)
+		if err := srv.endSession(ctx, s.GetCtx(), protos.TerminateReason_POLICY); err != nil {
+			log.Printf("Time policy '%s' termination of session %s failed: %v", w.Name, sid, err)
+		}
+	}()
+}
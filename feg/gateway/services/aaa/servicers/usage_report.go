@@ -0,0 +1,103 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSDstyle license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// package servcers implements WiFi AAA GRPC services
+package servicers
+
+import (
+	"log"
+
+	"golang.org/x/net/context"
+
+	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/aaa/protos"
+	"magma/feg/gateway/services/aaa/quirks"
+	"magma/feg/gateway/services/aaa/session_manager"
+	lte_protos "magma/lte/cloud/go/protos"
+)
+
+// Usage reports' results
+const (
+	usageReportSuccess     = "success"
+	usageReportFailure     = "failure"
+	usageReportUnsupported = "unsupported"
+)
+
+// reportUsage reports the session's cumulative Interim-Update usage to session manager if the mconfig's
+// ReportInterimUsage is set & returns session manager's decision on the session, nil if the usage isn't reported.
+// Failed reports don't fail the Interim-Update, the session's next report carries its cumulative usage
+func (srv *accountingService) reportUsage(
+	ctx context.Context, aaaCtx *protos.Context, usage localUsage) *lte_protos.LocalSessionUsageResponse {
+
+	if !srv.config.GetReportInterimUsage() || !srv.settings(aaaCtx).accountingEnabled {
+		return nil
+	}
+	sid := makeSID(aaaCtx.GetImsi())
+	if srv.acctQueue != nil && srv.acctQueue.Pending(sid.GetId()) {
+		return nil // the session isn't created in session manager yet
+	}
+	apn := aaaCtx.GetApn()
+	if !session_manager.GetAPNCapabilities(apn).SessionUsage {
+		metrics.UsageReports.WithLabelValues(usageReportUnsupported).Inc()
+		return nil
+	}
+	decision, err := session_manager.ReportSessionUsage(ctx, apn, &lte_protos.LocalSessionUsage{
+		Sid:             sid,
+		RadiusSessionId: aaaCtx.GetSessionId(),
+		OctetsIn:        usage.octetsIn,
+		PacketsIn:       usage.packetsIn,
+		OctetsOut:       usage.octetsOut,
+		PacketsOut:      usage.packetsOut,
+	})
+	if err != nil {
+		metrics.UsageReports.WithLabelValues(usageReportFailure).Inc()
+		log.Printf("Error reporting session %s usage to session manager: %v", aaaCtx.GetSessionId(), err)
+		return nil
+	}
+	metrics.UsageReports.WithLabelValues(usageReportSuccess).Inc()
+	return decision
+}
+
+// finalUsage accumulates the Stop's final counters, if the NAS reports them, into the stopped session's usage &
+// returns the session's final usage & duration for session manager, nil if neither is known. It must be called
+// before the session's state is forgotten
+func (srv *accountingService) finalUsage(
+	sessionCtx *protos.Context, req *protos.StopRequest) *lte_protos.LocalEndSessionRequest {
+
+	sid := sessionCtx.GetSessionId()
+	usage, ok := srv.usage.get(sid)
+	if req.GetOctetsIn() > 0 || req.GetOctetsOut() > 0 || req.GetGigawordsIn() > 0 || req.GetGigawordsOut() > 0 ||
+		req.GetPacketsIn() > 0 || req.GetPacketsOut() > 0 {
+
+		var deltaIn, deltaOut uint64
+		usage, deltaIn, deltaOut = srv.usage.update(sid, sessionCtx.GetImsi(), &protos.UpdateRequest{
+			OctetsIn:     req.GetOctetsIn(),
+			OctetsOut:    req.GetOctetsOut(),
+			PacketsIn:    req.GetPacketsIn(),
+			PacketsOut:   req.GetPacketsOut(),
+			GigawordsIn:  req.GetGigawordsIn(),
+			GigawordsOut: req.GetGigawordsOut(),
+		}, quirks.OctetsUnit(sessionCtx))
+		metrics.OctetsInServed.Add(deltaIn)
+		metrics.OctetsOutServed.Add(deltaOut)
+		ok = true
+	}
+	if !ok && req.GetSessionTime() == 0 {
+		return nil
+	}
+	return &lte_protos.LocalEndSessionRequest{
+		FinalUsage: &lte_protos.LocalSessionUsage{
+			RadiusSessionId: sid,
+			OctetsIn:        usage.octetsIn,
+			PacketsIn:       usage.packetsIn,
+			OctetsOut:       usage.octetsOut,
+			PacketsOut:      usage.packetsOut,
+		},
+		SessionTime: req.GetSessionTime(),
+	}
+}
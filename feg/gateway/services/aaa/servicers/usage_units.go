@@ -0,0 +1,63 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSDstyle license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// package servcers implements WiFi AAA GRPC services
+package servicers
+
+import (
+	"log"
+
+	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+// DefaultMaxUsageRate - default maximum plausible usage rate of a session, in octets per second (10Gbps)
+const DefaultMaxUsageRate = 10e9 / 8
+
+// minPacketOctets - size of the smallest IP packet, fewer octets than packets reported are not octets
+const minPacketOctets = 20
+
+// Implausible Interim-Update usage reasons
+const (
+	implausibleRate       = "rate"
+	implausiblePacketSize = "packet_size"
+)
+
+// SetMaxUsageRate sets the maximum plausible usage rate of a session in octets per second, sessions reporting higher
+// rates are warned of, 0 disables the check
+func (srv *accountingService) SetMaxUsageRate(rate float64) {
+	srv.maxUsageRate = rate
+}
+
+// checkUsage warns of implausible jumps of the Interim-Update's usage since the session's previous usage, typically
+// of NASes reporting their octets counters in other units than octets & missing the KilobyteOctets quirk, or having
+// it configured by mistake. Returns the reasons the usage is implausible, if any
+func (srv *accountingService) checkUsage(
+	aaaCtx *protos.Context, previous, usage localUsage, deltaIn, deltaOut uint64) []string {
+
+	var reasons []string
+	sid, apn := aaaCtx.GetSessionId(), aaaCtx.GetApn()
+	packets := usage.packetsIn + usage.packetsOut - previous.packetsIn - previous.packetsOut
+	if packets > 0 && deltaIn+deltaOut < packets*minPacketOctets {
+		reasons = append(reasons, implausiblePacketSize)
+		metrics.ImplausibleUsage.WithLabelValues(apn, implausiblePacketSize).Inc()
+		log.Printf("Implausible usage of session %s: %d octets of %d packets, are its NAS's octets in kilobytes?",
+			sid, deltaIn+deltaOut, packets)
+	}
+	elapsed := usage.updated.Sub(previous.updated).Seconds()
+	if srv.maxUsageRate <= 0 || previous.updated.IsZero() || elapsed <= 0 {
+		return reasons
+	}
+	if rate := float64(deltaIn+deltaOut) / elapsed; rate > srv.maxUsageRate {
+		reasons = append(reasons, implausibleRate)
+		metrics.ImplausibleUsage.WithLabelValues(apn, implausibleRate).Inc()
+		log.Printf("Implausible usage of session %s: %.0f octets/sec over %.1f sec, is its NAS's octets unit right?",
+			sid, rate, elapsed)
+	}
+	return reasons
+}
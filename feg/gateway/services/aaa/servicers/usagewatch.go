@@ -0,0 +1,167 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSDstyle license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// package servcers implements WiFi AAA GRPC services
+package servicers
+
+import (
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+// MaxUsageWatchers - maximum number of concurrent WatchSubscriberUsage streams
+const MaxUsageWatchers = 256
+
+// usageWatcher - a WatchSubscriberUsage stream's pending updates, updates of the same session are merged while
+// the stream is busy, so slow clients never block accounting & don't lose usage
+type usageWatcher struct {
+	mu      sync.Mutex
+	pending []*protos.SubscriberUsageUpdate
+	notify  chan struct{}
+}
+
+func (w *usageWatcher) push(u *protos.SubscriberUsageUpdate) {
+	w.mu.Lock()
+	merged := false
+	for _, p := range w.pending {
+		if p.SessionId == u.SessionId {
+			p.DeltaOctetsIn += u.DeltaOctetsIn
+			p.DeltaOctetsOut += u.DeltaOctetsOut
+			p.TotalOctetsIn, p.TotalOctetsOut, p.TimeMs = u.TotalOctetsIn, u.TotalOctetsOut, u.TimeMs
+			p.SessionEnded = p.SessionEnded || u.SessionEnded
+			merged = true
+			break
+		}
+	}
+	if !merged {
+		c := *u
+		w.pending = append(w.pending, &c)
+	}
+	w.mu.Unlock()
+	select {
+	case w.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (w *usageWatcher) take() []*protos.SubscriberUsageUpdate {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	res := w.pending
+	w.pending = nil
+	return res
+}
+
+// usageWatchers - WatchSubscriberUsage streams by normalized IMSI
+type usageWatchers struct {
+	sync.Mutex
+	byImsi map[string]map[*usageWatcher]struct{}
+	count  int
+}
+
+func newUsageWatchers() *usageWatchers {
+	return &usageWatchers{byImsi: map[string]map[*usageWatcher]struct{}{}}
+}
+
+func (uw *usageWatchers) add(imsi string) (*usageWatcher, error) {
+	uw.Lock()
+	defer uw.Unlock()
+	if uw.count >= MaxUsageWatchers {
+		return nil, status.Errorf(codes.ResourceExhausted, "Too many usage watchers, maximum: %d", MaxUsageWatchers)
+	}
+	w := &usageWatcher{notify: make(chan struct{}, 1)}
+	ws, ok := uw.byImsi[imsi]
+	if !ok {
+		ws = map[*usageWatcher]struct{}{}
+		uw.byImsi[imsi] = ws
+	}
+	ws[w] = struct{}{}
+	uw.count++
+	return w, nil
+}
+
+func (uw *usageWatchers) remove(imsi string, w *usageWatcher) {
+	uw.Lock()
+	defer uw.Unlock()
+	if ws, ok := uw.byImsi[imsi]; ok {
+		if _, ok = ws[w]; ok {
+			delete(ws, w)
+			uw.count--
+		}
+		if len(ws) == 0 {
+			delete(uw.byImsi, imsi)
+		}
+	}
+}
+
+// publish pushes the update to all watchers of its subscriber
+func (uw *usageWatchers) publish(u *protos.SubscriberUsageUpdate) {
+	uw.Lock()
+	defer uw.Unlock()
+	for w := range uw.byImsi[u.Imsi] {
+		w.push(u)
+	}
+}
+
+// WatchSubscriberUsage streams live usage updates of all the subscriber's sessions until the client cancels
+func (srv *accountingService) WatchSubscriberUsage(
+	req *protos.SubscriberUsageRequest, stream protos.Accounting_WatchSubscriberUsageServer) error {
+
+	imsi := normalizeImsi(req.GetImsi())
+	if len(imsi) == 0 {
+		return status.Errorf(codes.InvalidArgument, "Missing IMSI")
+	}
+	w, err := srv.watchers.add(imsi)
+	if err != nil {
+		return err
+	}
+	defer srv.watchers.remove(imsi, w)
+
+	ctx := stream.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-w.notify:
+			for _, u := range w.take() {
+				if err = stream.Send(u); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// publishUsage publishes the session's accumulated usage & its delta to the subscriber's usage watchers
+func (srv *accountingService) publishUsage(sid string, u localUsage, deltaIn, deltaOut uint64, ended bool) {
+	srv.watchers.publish(&protos.SubscriberUsageUpdate{
+		Imsi:           normalizeImsi(u.imsi),
+		SessionId:      sid,
+		DeltaOctetsIn:  deltaIn,
+		DeltaOctetsOut: deltaOut,
+		TotalOctetsIn:  u.octetsIn,
+		TotalOctetsOut: u.octetsOut,
+		TimeMs:         u.updated.UnixNano() / int64(time.Millisecond),
+		SessionEnded:   ended,
+	})
+}
+
+// publishSessionEnd publishes the removed session's final usage to the subscriber's usage watchers
+func (srv *accountingService) publishSessionEnd(sid, imsi string) {
+	u, ok := srv.usage.get(sid)
+	if !ok {
+		u.imsi = imsi
+	}
+	u.updated = time.Now()
+	srv.publishUsage(sid, u, 0, 0, true)
+}
@@ -0,0 +1,94 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// package servcers implements WiFi AAA GRPC services
+package servicers
+
+import (
+	"log"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"magma/feg/gateway/services/aaa/adminauth"
+	"magma/feg/gateway/services/aaa/protos"
+	"magma/feg/gateway/services/aaa/userdb"
+)
+
+type userDbService struct {
+	db   *userdb.UserDB
+	auth *adminauth.Authorizer
+}
+
+// NewUserDbService returns a new instance of local users management & authentication service, management calls are
+// authorized by the callers' admin roles granted by the given authorizer & all denied if it is nil
+func NewUserDbService(db *userdb.UserDB, auth *adminauth.Authorizer) (protos.UserDbServer, error) {
+	if db == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Nil User DB")
+	}
+	return &userDbService{db: db, auth: auth}, nil
+}
+
+// AddUser adds a new or overwrites an existing local user
+func (srv *userDbService) AddUser(ctx context.Context, in *protos.UserCredentials) (*protos.Void, error) {
+	if err := srv.authorize(ctx, "AddUser"); err != nil {
+		return &protos.Void{}, err
+	}
+	if in == nil {
+		return &protos.Void{}, status.Errorf(codes.InvalidArgument, "Nil User Credentials")
+	}
+	if err := srv.db.AddUser(in.GetUsername(), in.GetPassword()); err != nil {
+		return &protos.Void{}, status.Errorf(codes.InvalidArgument, "Add User Error: %v", err)
+	}
+	return &protos.Void{}, nil
+}
+
+// RemoveUser removes the local user
+func (srv *userDbService) RemoveUser(ctx context.Context, in *protos.UserName) (*protos.Void, error) {
+	if err := srv.authorize(ctx, "RemoveUser"); err != nil {
+		return &protos.Void{}, err
+	}
+	if err := srv.db.RemoveUser(in.GetUsername()); err != nil {
+		return &protos.Void{}, status.Errorf(codes.NotFound, "Remove User Error: %v", err)
+	}
+	return &protos.Void{}, nil
+}
+
+// DisableUser disables or re-enables the local user
+func (srv *userDbService) DisableUser(ctx context.Context, in *protos.DisableUserRequest) (*protos.Void, error) {
+	if err := srv.authorize(ctx, "DisableUser"); err != nil {
+		return &protos.Void{}, err
+	}
+	if err := srv.db.SetDisabled(in.GetUsername(), in.GetDisabled()); err != nil {
+		return &protos.Void{}, status.Errorf(codes.NotFound, "Disable User Error: %v", err)
+	}
+	return &protos.Void{}, nil
+}
+
+// Authenticate verifies the given user credentials, all failures have the same reason so callers can't tell unknown
+// users apart
+func (srv *userDbService) Authenticate(
+	_ context.Context, in *protos.UserCredentials) (*protos.UserAuthResult, error) {
+
+	if err := srv.db.Authenticate(in.GetUsername(), in.GetPassword()); err != nil {
+		return &protos.UserAuthResult{Success: false, Reason: userdb.ErrAuthFailed.Error()}, nil
+	}
+	return &protos.UserAuthResult{Success: true}, nil
+}
+
+// authorize verifies that the caller's role permits users management
+func (srv *userDbService) authorize(ctx context.Context, method string) error {
+	principal, err := srv.auth.Authorize(ctx, adminauth.Admin)
+	if err != nil {
+		log.Printf("User DB %s call denied: %v", method, err)
+		return err
+	}
+	log.Printf("User DB %s call by %s (%s)", method, principal.Name, principal.Role)
+	return nil
+}
@@ -0,0 +1,71 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package servicers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"magma/feg/gateway/services/aaa/adminauth"
+	"magma/feg/gateway/services/aaa/protos"
+	"magma/feg/gateway/services/aaa/userdb"
+)
+
+func TestUserDbService(t *testing.T) {
+	db, err := userdb.NewUserDB("")
+	assert.NoError(t, err)
+	auth, err := adminauth.New(&adminauth.Config{Tokens: []adminauth.TokenGrant{
+		{Name: "admin", Token: "admin-token", Role: adminauth.Admin},
+		{Name: "noc", Token: "op-token", Role: adminauth.Operator},
+	}})
+	assert.NoError(t, err)
+	srv, err := NewUserDbService(db, auth)
+	assert.NoError(t, err)
+	tokenCtx := func(token string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+	}
+	creds := &protos.UserCredentials{Username: "user1", Password: "pass1"}
+
+	// management calls require the admin role
+	_, err = srv.AddUser(context.Background(), creds)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = srv.AddUser(tokenCtx("op-token"), creds)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = srv.AddUser(tokenCtx("admin-token"), creds)
+	assert.NoError(t, err)
+	_, err = srv.DisableUser(tokenCtx("op-token"), &protos.DisableUserRequest{Username: "user1", Disabled: true})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = srv.RemoveUser(tokenCtx("op-token"), &protos.UserName{Username: "user1"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	res, err := srv.Authenticate(context.Background(), creds)
+	assert.NoError(t, err)
+	assert.True(t, res.GetSuccess())
+
+	// wrong passwords & unknown users fail for the same reason
+	res, err = srv.Authenticate(context.Background(), &protos.UserCredentials{Username: "user1", Password: "pass2"})
+	assert.NoError(t, err)
+	assert.False(t, res.GetSuccess())
+	assert.Equal(t, userdb.ErrAuthFailed.Error(), res.GetReason())
+	res, err = srv.Authenticate(context.Background(), &protos.UserCredentials{Username: "user2", Password: "pass1"})
+	assert.NoError(t, err)
+	assert.False(t, res.GetSuccess())
+	assert.Equal(t, userdb.ErrAuthFailed.Error(), res.GetReason())
+
+	// all management calls are denied without an authorizer
+	srv, err = NewUserDbService(db, nil)
+	assert.NoError(t, err)
+	_, err = srv.RemoveUser(tokenCtx("admin-token"), &protos.UserName{Username: "user1"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
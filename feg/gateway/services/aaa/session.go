@@ -11,6 +11,7 @@ LICENSE file in the root directory of this source tree.
 //go:generate protoc -I protos --go_out=plugins=grpc,paths=source_relative:protos protos/context.proto protos/eap.proto
 //go:generate protoc -I protos --go_out=plugins=grpc,paths=source_relative:protos protos/accounting.proto
 //go:generate protoc -I protos --go_out=plugins=grpc,paths=source_relative:protos protos/authorization.proto
+//go:generate protoc -I protos --go_out=plugins=grpc,paths=source_relative:protos protos/userdb.proto
 //
 package aaa
 
@@ -34,21 +35,51 @@ func CreateSessionId() string {
 
 // Session - struct to save an authenticated session state
 type Session interface {
-	// Lock - locks the Session's mutex
-	Lock()
-	// Unlock - unlocks the Session's mutex
-	Unlock()
-	// GetCtx returns AAA Session Context
+	// GetCtx returns AAA Session Context, an immutable snapshot which must not be modified, the session's context is
+	// changed by its table's UpdateCtx
 	GetCtx() *protos.Context
-	// SetCtx sets AAA Session Context - must be called on a Locked session
-	SetCtx(ctx *protos.Context)
 	// StopTimeout - stops the session's timeout if possible, returns if the timeout was successfully stopped
 	StopTimeout() bool
 }
 
+// SessionTimeout - a session & its remaining idle timeout, 0 if the session has no timeout
+type SessionTimeout struct {
+	Session Session
+	Timeout time.Duration
+}
+
+// SessionLister is implemented by session tables which can list all their sessions
+type SessionLister interface {
+	// ListSessions returns all sessions of the table with their remaining idle timeouts
+	ListSessions() []SessionTimeout
+}
+
+// SessionCounter is implemented by session tables which can count their sessions
+type SessionCounter interface {
+	// Count returns the number of the table's sessions
+	Count() int
+}
+
+// SessionRestorer is implemented by session tables which persist their sessions across AAA server restarts
+type SessionRestorer interface {
+	// Restore loads the persisted sessions into the table & returns the restored sessions, the notifier is called on
+	// the restored sessions' timeouts
+	Restore(notifier TimeoutNotifier) ([]Session, error)
+}
+
+// SIDNormalizing is implemented by session tables which can match differently formatted IDs of the same session
+type SIDNormalizing interface {
+	// SetSIDNormalizer sets the normalization of the session IDs the table's sessions are added & looked up by, it
+	// must be set before the table's first session is added
+	SetSIDNormalizer(normalize func(sid string) string)
+}
+
 // TimeoutNotifier is a callback function to be called on session timeout
 type TimeoutNotifier func(Session) error
 
+// CtxMutator changes a copy of a session's context, its error cancels the context update
+type CtxMutator func(ctx *protos.Context) error
+
 // SessionTable - synchronized map of authenticated sessions
 type SessionTable interface {
 	// AddSession - adds a new session to the table & returns the newly created session pointer.
@@ -63,4 +94,8 @@ type SessionTable interface {
 	RemoveSession(sid string) Session
 	// SetTimeout - [Re]sets the session's cleanup timeout to fire after tout duration
 	SetTimeout(sid string, tout time.Duration, callback TimeoutNotifier) bool
+	// UpdateCtx - replaces the session's context with a copy changed by the mutator, the table's indexes & stored
+	// sessions are updated. Concurrent updates of a session are serialized, the mutator must not block on outbound
+	// calls. Returns the session's previous & updated contexts
+	UpdateCtx(sid string, mutator CtxMutator) (old, updated *protos.Context, err error)
 }
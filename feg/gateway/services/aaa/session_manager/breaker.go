@@ -0,0 +1,84 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package session_manager
+
+import (
+	"log"
+	"sync"
+
+	"fbc/lib/go/retry"
+	"golang.org/x/net/context"
+
+	"magma/feg/gateway/services/aaa/metrics"
+)
+
+// rejected - SessionManagerCircuit event of calls rejected by an open circuit
+const rejected = "rejected"
+
+// breakers - circuit breakers of the session manager services, so a session manager blip doesn't open the circuits
+// of other APNs' session managers. No circuit breakers by default
+var breakers struct {
+	sync.Mutex
+	config   *retry.BreakerConfig
+	services map[string]*retry.Breaker
+}
+
+// SetBreaker validates the configuration & sets the circuit breakers of session manager services' calls, nil
+// disables them
+func SetBreaker(cfg *retry.BreakerConfig) error {
+	if cfg != nil {
+		if _, err := retry.NewBreaker(*cfg); err != nil {
+			return err
+		}
+	}
+	breakers.Lock()
+	breakers.config = cfg
+	breakers.services = map[string]*retry.Breaker{}
+	breakers.Unlock()
+	return nil
+}
+
+// breakerOf returns the circuit breaker of the session manager service, nil if circuit breakers are disabled
+func breakerOf(service string) *retry.Breaker {
+	breakers.Lock()
+	defer breakers.Unlock()
+	if breakers.config == nil {
+		return nil
+	}
+	if b, ok := breakers.services[service]; ok {
+		return b
+	}
+	b, _ := retry.NewBreaker(*breakers.config) // validated by SetBreaker
+	b.OnStateChange = func(s retry.State) {
+		metrics.SessionManagerCircuit.WithLabelValues(service, s.String()).Inc()
+		log.Printf("Session manager %s circuit is %s", service, s)
+	}
+	breakers.services[service] = b
+	return b
+}
+
+// call calls op through the service's circuit breaker, a failed op is retried as per the retry configuration & each
+// of its retries is counted. A call rejected by the open circuit fails with retry.ErrOpen without retries
+func call(ctx context.Context, service, method string, op func(ctx context.Context) error) error {
+	attempts := 0
+	err := breakerOf(service).Do(ctx, func(ctx context.Context) error {
+		return retrier.Do(ctx, func(ctx context.Context) error {
+			if attempts++; attempts > 1 {
+				metrics.SessionManagerRetries.WithLabelValues(method).Inc()
+			}
+			err := op(ctx)
+			checkConnectionError(service, err)
+			return err
+		})
+	})
+	if err == retry.ErrOpen {
+		metrics.SessionManagerCircuit.WithLabelValues(service, rejected).Inc()
+	}
+	return err
+}
@@ -0,0 +1,65 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package session_manager
+
+import (
+	"testing"
+
+	"fbc/lib/go/retry"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCall(t *testing.T) {
+	defer SetRetry(nil)
+	defer SetBreaker(nil)
+
+	unavailable := status.Error(codes.Unavailable, "sessiond restarting")
+	calls := 0
+	// fails the first failures calls
+	failing := func(failures int) func(context.Context) error {
+		calls = 0
+		return func(context.Context) error {
+			if calls++; calls <= failures {
+				return unavailable
+			}
+			return nil
+		}
+	}
+
+	// no retries & circuit breakers by default
+	assert.Equal(t, unavailable, call(context.Background(), "SESSIOND", "CreateSession", failing(1)))
+	assert.Equal(t, 1, calls)
+
+	SetRetry(&retry.Retrier{MaxAttempts: 3})
+	assert.NoError(t, call(context.Background(), "SESSIOND", "CreateSession", failing(2)))
+	assert.Equal(t, 3, calls)
+
+	assert.Error(t, SetBreaker(&retry.BreakerConfig{Failures: 1, FailureCodes: []string{"NoSuchCode"}}))
+	assert.NoError(t, SetBreaker(&retry.BreakerConfig{Failures: 2, CooldownMs: 60000}))
+	// a retried call is a single failure
+	assert.Equal(t, unavailable, call(context.Background(), "SESSIOND", "EndSession", failing(3)))
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, retry.Closed, breakerOf("SESSIOND").State())
+	assert.Equal(t, unavailable, call(context.Background(), "SESSIOND", "EndSession", failing(3)))
+	assert.Equal(t, retry.Open, breakerOf("SESSIOND").State())
+
+	op := failing(0)
+	assert.Equal(t, retry.ErrOpen, call(context.Background(), "SESSIOND", "CreateSession", op))
+	assert.Equal(t, 0, calls)
+	// other session managers' circuits are closed
+	assert.NoError(t, call(context.Background(), "SESSIOND_OFFLOAD", "CreateSession", op))
+	assert.Equal(t, 1, calls)
+
+	assert.NoError(t, SetBreaker(nil))
+	assert.Nil(t, breakerOf("SESSIOND"))
+	assert.NoError(t, call(context.Background(), "SESSIOND", "CreateSession", op))
+}
@@ -0,0 +1,193 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package session_manager
+
+import (
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"magma/feg/gateway/registry"
+	"magma/feg/gateway/services/aaa/deadlines"
+	orcprotos "magma/orc8r/cloud/go/protos"
+)
+
+// Capabilities describes the features supported by the local session manager AAA talks to
+type Capabilities struct {
+	// Version is the session manager's version as reported by its Service303 GetServiceInfo, empty if unknown
+	Version string
+	// WLANSessions - session manager supports WLAN sessions, LocalCreateSessionRequest's RatType WLAN,
+	// HardwareAddr & RadiusSessionId fields
+	WLANSessions bool
+	// SessionUsage - session manager supports ReportSessionUsage of sessions metered by their access network
+	SessionUsage bool
+	// FinalUsage - session manager supports EndSessionWithUsage, ending sessions with their final usage
+	FinalUsage bool
+	// ListSessions - session manager supports ListSessions of its active sessions
+	ListSessions bool
+}
+
+// legacyCapabilities are assumed for session managers which don't report their version
+var legacyCapabilities = Capabilities{}
+
+// currentCapabilities are assumed for session managers reporting a version AAA doesn't recognize
+// and while the session manager is not reachable
+var currentCapabilities = Capabilities{WLANSessions: true, SessionUsage: true, FinalUsage: true, ListSessions: true}
+
+// capabilityVersions lists minimal session manager major.minor versions supporting each capability
+var capabilityVersions = []struct {
+	major, minor int
+	enable       func(*Capabilities)
+}{
+	{1, 0, func(c *Capabilities) { c.WLANSessions = true }},
+	{1, 1, func(c *Capabilities) { c.SessionUsage = true }},
+	{1, 2, func(c *Capabilities) { c.FinalUsage = true }},
+	{1, 3, func(c *Capabilities) { c.ListSessions = true }},
+}
+
+// negotiated - capabilities of session managers by their service registry names
+var negotiated struct {
+	sync.Mutex
+	caps map[string]*Capabilities
+	// resets - number of resets of the session managers' capabilities, negotiations overlapping a reset are dropped
+	resets map[string]uint64
+}
+
+// GetCapabilities returns the negotiated capabilities of the default session manager, the capabilities are
+// negotiated on first use and after each session manager connection loss. If the session manager is not reachable,
+// current capabilities are returned and the negotiation is retried on the next call
+func GetCapabilities() Capabilities {
+	return getCapabilities(serviceOf(""))
+}
+
+// GetAPNCapabilities returns the negotiated capabilities of the given APN's session manager, see GetCapabilities
+func GetAPNCapabilities(apn string) Capabilities {
+	return getCapabilities(serviceOf(apn))
+}
+
+// PrefetchCapabilities negotiates capabilities of all routed session managers which are not negotiated yet
+func PrefetchCapabilities() {
+	for _, service := range Services() {
+		getCapabilities(service)
+	}
+}
+
+func getCapabilities(service string) Capabilities {
+	negotiated.Lock()
+	if caps, ok := negotiated.caps[service]; ok {
+		negotiated.Unlock()
+		return *caps
+	}
+	resets := negotiated.resets[service]
+	negotiated.Unlock()
+
+	// the negotiation's RPC doesn't block the callers of other session managers & negotiated capabilities
+	caps, err := negotiate(service)
+	if err != nil {
+		log.Printf("Session manager %s capabilities negotiation error: %v", service, err)
+		return currentCapabilities
+	}
+	log.Printf("Negotiated session manager %s capabilities: %+v", service, caps)
+	negotiated.Lock()
+	defer negotiated.Unlock()
+	if negotiated.resets[service] != resets {
+		return caps // the session manager's connection was lost during the negotiation
+	}
+	if negotiated.caps == nil {
+		negotiated.caps = map[string]*Capabilities{}
+	}
+	negotiated.caps[service] = &caps
+	return caps
+}
+
+// NegotiateCapabilities forces default session manager capabilities renegotiation & returns the new capabilities
+func NegotiateCapabilities() Capabilities {
+	service := serviceOf("")
+	resetCapabilities(service)
+	return getCapabilities(service)
+}
+
+func resetCapabilities(service string) {
+	negotiated.Lock()
+	delete(negotiated.caps, service)
+	if negotiated.resets == nil {
+		negotiated.resets = map[string]uint64{}
+	}
+	negotiated.resets[service]++
+	negotiated.Unlock()
+}
+
+// checkConnectionError resets the negotiated capabilities if err indicates that the session manager may have
+// been restarted (and possibly upgraded or downgraded) or doesn't implement a called RPC
+func checkConnectionError(service string, err error) {
+	if err == nil {
+		return
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.Unimplemented:
+		resetCapabilities(service)
+	}
+}
+
+func negotiate(service string) (Capabilities, error) {
+	conn, err := registry.GetConnection(service)
+	if err != nil {
+		return currentCapabilities, err
+	}
+	ctx, cancel := deadlines.Background()
+	defer cancel()
+	info, err := orcprotos.NewService303Client(conn).GetServiceInfo(ctx, &orcprotos.Void{})
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			return legacyCapabilities, nil
+		}
+		return currentCapabilities, err
+	}
+	return capabilitiesForVersion(info.GetVersion()), nil
+}
+
+// capabilitiesForVersion returns capabilities of the given session manager version
+func capabilitiesForVersion(version string) Capabilities {
+	major, minor, ok := parseVersion(version)
+	if !ok {
+		log.Printf("Unrecognized session manager version '%s', assuming current capabilities", version)
+		caps := currentCapabilities
+		caps.Version = version
+		return caps
+	}
+	caps := legacyCapabilities
+	caps.Version = version
+	for _, cv := range capabilityVersions {
+		if major > cv.major || (major == cv.major && minor >= cv.minor) {
+			cv.enable(&caps)
+		}
+	}
+	return caps
+}
+
+// parseVersion parses major & minor numbers of 'major.minor[.patch...]' versions
+func parseVersion(version string) (major, minor int, ok bool) {
+	parts := strings.SplitN(strings.TrimPrefix(strings.TrimSpace(version), "v"), ".", 3)
+	if len(parts) < 2 {
+		return 0, 0, false
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, false
+	}
+	minor, err = strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, false
+	}
+	return major, minor, true
+}
@@ -0,0 +1,63 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package session_manager
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"magma/feg/gateway/registry"
+)
+
+func TestCapabilitiesForVersion(t *testing.T) {
+	caps := capabilitiesForVersion("1.0")
+	assert.Equal(t, "1.0", caps.Version)
+	assert.True(t, caps.WLANSessions)
+	assert.False(t, caps.SessionUsage)
+
+	assert.True(t, capabilitiesForVersion("v2.3.1").WLANSessions)
+	assert.True(t, capabilitiesForVersion("v2.3.1").SessionUsage)
+	assert.True(t, capabilitiesForVersion("1.1").SessionUsage)
+	assert.False(t, capabilitiesForVersion("1.1").FinalUsage)
+	assert.True(t, capabilitiesForVersion("1.2").FinalUsage)
+	assert.False(t, capabilitiesForVersion("1.2").ListSessions)
+	assert.True(t, capabilitiesForVersion("1.3").ListSessions)
+	assert.False(t, capabilitiesForVersion("0.9").WLANSessions)
+
+	// unrecognized versions are assumed to be current
+	caps = capabilitiesForVersion("dev")
+	assert.Equal(t, "dev", caps.Version)
+	assert.True(t, caps.WLANSessions)
+	assert.True(t, caps.SessionUsage)
+	assert.True(t, caps.FinalUsage)
+	assert.True(t, caps.ListSessions)
+}
+
+func TestCheckConnectionError(t *testing.T) {
+	negotiated.caps = map[string]*Capabilities{
+		registry.SESSION_MANAGER: {Version: "1.0"},
+		"SESSIOND_OFFLOAD":       {Version: "1.0"},
+	}
+	checkConnectionError(registry.SESSION_MANAGER, errors.New("not a grpc status"))
+	assert.NotNil(t, negotiated.caps[registry.SESSION_MANAGER])
+	checkConnectionError(registry.SESSION_MANAGER, status.Errorf(codes.InvalidArgument, "invalid"))
+	assert.NotNil(t, negotiated.caps[registry.SESSION_MANAGER])
+
+	resets := negotiated.resets[registry.SESSION_MANAGER]
+	checkConnectionError(registry.SESSION_MANAGER, status.Errorf(codes.Unavailable, "connection lost"))
+	assert.Nil(t, negotiated.caps[registry.SESSION_MANAGER])
+	// negotiations overlapping the reset don't restore the lost session manager's capabilities
+	assert.Equal(t, resets+1, negotiated.resets[registry.SESSION_MANAGER])
+	// other session managers' capabilities are kept
+	assert.NotNil(t, negotiated.caps["SESSIOND_OFFLOAD"])
+}
@@ -13,10 +13,16 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"time"
 
+	"fbc/lib/go/retry"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"magma/feg/gateway/registry"
+	"magma/feg/gateway/services/aaa/deadlines"
+	"magma/feg/gateway/services/aaa/slo"
 	"magma/lte/cloud/go/protos"
 )
 
@@ -24,12 +30,23 @@ type sessionManagerClient struct {
 	protos.LocalSessionManagerClient
 }
 
+// retrier retries failed CreateSession & EndSession calls, no retries by default
+var retrier = retry.NoRetry
+
+// SetRetry sets the retries of failed session manager calls, nil disables retries
+func SetRetry(r *retry.Retrier) {
+	if r == nil {
+		r = retry.NoRetry
+	}
+	retrier = r
+}
+
 // getSessionManagerClient is a utility function to get a RPC connection to the
-// Local SessionManager service
-func getSessionManagerClient() (*sessionManagerClient, error) {
-	conn, err := registry.GetConnection(registry.SESSION_MANAGER)
+// Local SessionManager service registered under the given name
+func getSessionManagerClient(service string) (*sessionManagerClient, error) {
+	conn, err := registry.GetConnection(service)
 	if err != nil {
-		errMsg := fmt.Sprintf("Local SessionManager client initialization error: %s", err)
+		errMsg := fmt.Sprintf("Local SessionManager %s client initialization error: %s", service, err)
 		log.Printf(errMsg)
 		return nil, errors.New(errMsg)
 	}
@@ -38,34 +55,126 @@ func getSessionManagerClient() (*sessionManagerClient, error) {
 
 func ReportRuleStats(in *protos.RuleRecordTable) error {
 	if in == nil {
-		return errors.New("Nil RuleRecordTable Request")
+		return retry.Permanent(errors.New("Nil RuleRecordTable Request"))
 	}
-	cli, err := getSessionManagerClient()
+	service := serviceOf("")
+	cli, err := getSessionManagerClient(service)
 	if err != nil {
 		return err
 	}
+	start := time.Now()
 	_, err = cli.ReportRuleStats(context.Background(), in)
+	slo.Observe(slo.SessionD, "ReportRuleStats", start, err)
+	checkConnectionError(service, err)
 	return err
 }
 
-func CreateSession(in *protos.LocalCreateSessionRequest) (*protos.LocalCreateSessionResponse, error) {
+// CreateSession creates the session in the session manager of the given APN, calls without ctx deadline are
+// bounded by the default deadline. Failed calls are retried & fail fast while the session manager's circuit is open
+func CreateSession(ctx context.Context, apn string, in *protos.LocalCreateSessionRequest) (*protos.LocalCreateSessionResponse, error) {
 	if in == nil {
-		return nil, errors.New("Nil LocalCreateSessionRequest")
+		return nil, retry.Permanent(errors.New("Nil LocalCreateSessionRequest"))
+	}
+	service := serviceOf(apn)
+	cli, err := getSessionManagerClient(service)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := deadlines.Check(ctx, "SessionManager.CreateSession", deadlines.Outbound)
+	defer cancel()
+	var res *protos.LocalCreateSessionResponse
+	start := time.Now()
+	err = call(ctx, service, "CreateSession", func(ctx context.Context) error {
+		res, err = cli.CreateSession(ctx, in)
+		return err
+	})
+	slo.Observe(slo.SessionD, "CreateSession", start, err)
+	return res, err
+}
+
+// EndSession ends the subscriber's session in the session manager of the given APN
+func EndSession(ctx context.Context, apn string, in *protos.SubscriberID) (*protos.LocalEndSessionResponse, error) {
+	if in == nil {
+		return nil, retry.Permanent(errors.New("Nil SubscriberID"))
+	}
+	service := serviceOf(apn)
+	cli, err := getSessionManagerClient(service)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := deadlines.Check(ctx, "SessionManager.EndSession", deadlines.Outbound)
+	defer cancel()
+	var res *protos.LocalEndSessionResponse
+	start := time.Now()
+	err = call(ctx, service, "EndSession", func(ctx context.Context) error {
+		res, err = cli.EndSession(ctx, in)
+		return err
+	})
+	slo.Observe(slo.SessionD, "EndSession", start, err)
+	return res, err
+}
+
+// EndSessionWithUsage ends the subscriber's session in the session manager of the given APN with the session's final
+// usage & duration, for session managers supporting FinalUsage
+func EndSessionWithUsage(ctx context.Context, apn string, in *protos.LocalEndSessionRequest) (*protos.LocalEndSessionResponse, error) {
+	if in == nil {
+		return nil, retry.Permanent(errors.New("Nil LocalEndSessionRequest"))
+	}
+	service := serviceOf(apn)
+	cli, err := getSessionManagerClient(service)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := deadlines.Check(ctx, "SessionManager.EndSessionWithUsage", deadlines.Outbound)
+	defer cancel()
+	var res *protos.LocalEndSessionResponse
+	start := time.Now()
+	err = call(ctx, service, "EndSessionWithUsage", func(ctx context.Context) error {
+		res, err = cli.EndSessionWithUsage(ctx, in)
+		return err
+	})
+	slo.Observe(slo.SessionD, "EndSessionWithUsage", start, err)
+	return res, err
+}
+
+// ListSessions lists the active sessions of the given session manager service, see Services(), for session managers
+// supporting ListSessions
+func ListSessions(ctx context.Context, service string, in *protos.LocalListSessionsRequest) (*protos.LocalListSessionsResponse, error) {
+	if in == nil {
+		return nil, retry.Permanent(errors.New("Nil LocalListSessionsRequest"))
+	}
+	if !getCapabilities(service).ListSessions {
+		return nil, status.Errorf(codes.Unimplemented, "Session manager %s doesn't support ListSessions", service)
 	}
-	cli, err := getSessionManagerClient()
+	cli, err := getSessionManagerClient(service)
 	if err != nil {
 		return nil, err
 	}
-	return cli.CreateSession(context.Background(), in)
+	ctx, cancel := deadlines.Check(ctx, "SessionManager.ListSessions", deadlines.Outbound)
+	defer cancel()
+	start := time.Now()
+	res, err := cli.ListSessions(ctx, in)
+	slo.Observe(slo.SessionD, "ListSessions", start, err)
+	checkConnectionError(service, err)
+	return res, err
 }
 
-func EndSession(in *protos.SubscriberID) (*protos.LocalEndSessionResponse, error) {
+// ReportSessionUsage reports the session's cumulative usage to the session manager of the given APN & returns its
+// decision on the session. Reports aren't retried, the session's next report supersedes a failed one
+func ReportSessionUsage(ctx context.Context, apn string, in *protos.LocalSessionUsage) (*protos.LocalSessionUsageResponse, error) {
 	if in == nil {
-		return nil, errors.New("Nil SubscriberID")
+		return nil, retry.Permanent(errors.New("Nil LocalSessionUsage"))
 	}
-	cli, err := getSessionManagerClient()
+	service := serviceOf(apn)
+	cli, err := getSessionManagerClient(service)
 	if err != nil {
 		return nil, err
 	}
-	return cli.EndSession(context.Background(), in)
+	ctx, cancel := deadlines.Check(ctx, "SessionManager.ReportSessionUsage", deadlines.Outbound)
+	defer cancel()
+	start := time.Now()
+	res, err := cli.ReportSessionUsage(ctx, in)
+	slo.Observe(slo.SessionD, "ReportSessionUsage", start, err)
+	checkConnectionError(service, err)
+	return res, err
 }
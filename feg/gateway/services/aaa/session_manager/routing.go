@@ -0,0 +1,144 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package session_manager
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"magma/feg/gateway/registry"
+)
+
+// Route - session manager endpoint of an APN, either a service registry name or a host:port address
+type Route struct {
+	APN string `json:"apn"` // case insensitive session APN
+	// Service is the service registry name of the session manager (e.g. SESSIOND or a relay configured in
+	// service_registry.yml)
+	Service string `json:"service,omitempty"`
+	// Address is the host:port of the session manager, used if Service is empty
+	Address string `json:"address,omitempty"`
+}
+
+// RoutingConfig - session manager routing table, sessions of APNs without a route use the Default route
+type RoutingConfig struct {
+	Default *Route  `json:"default,omitempty"` // optional, APN is ignored, the local SESSIOND if not set
+	Routes  []Route `json:"routes"`
+}
+
+// routing - current APN to session manager service registry name table
+var routing struct {
+	sync.RWMutex
+	defaultService string
+	apns           map[string]string
+}
+
+// ReadRoutingConfig reads JSON routing configuration from the given file
+func ReadRoutingConfig(path string) (RoutingConfig, error) {
+	cfg := RoutingConfig{}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return cfg, err
+	}
+	if err = json.Unmarshal(b, &cfg); err != nil {
+		return cfg, fmt.Errorf("Invalid session manager routing configuration %s: %v", path, err)
+	}
+	return cfg, nil
+}
+
+// SetRouting validates the configuration, adds routes' addresses to the service registry & replaces the current
+// routing table
+func SetRouting(cfg RoutingConfig) error {
+	defaultService := registry.SESSION_MANAGER
+	if cfg.Default != nil {
+		service, err := routeService(*cfg.Default)
+		if err != nil {
+			return fmt.Errorf("Invalid default route: %v", err)
+		}
+		defaultService = service
+	}
+	apns := map[string]string{}
+	for _, r := range cfg.Routes {
+		apn := strings.ToLower(strings.TrimSpace(r.APN))
+		if len(apn) == 0 {
+			return fmt.Errorf("Missing APN of route %+v", r)
+		}
+		if _, ok := apns[apn]; ok {
+			return fmt.Errorf("Duplicate route of APN '%s'", apn)
+		}
+		service, err := routeService(r)
+		if err != nil {
+			return fmt.Errorf("Invalid route of APN '%s': %v", apn, err)
+		}
+		apns[apn] = service
+	}
+	routing.Lock()
+	routing.defaultService, routing.apns = defaultService, apns
+	routing.Unlock()
+	return nil
+}
+
+// routeService returns the service registry name of the route, address routes are added to the registry
+func routeService(r Route) (string, error) {
+	if len(r.Service) > 0 {
+		if len(r.Address) > 0 {
+			return "", fmt.Errorf("both service & address are set")
+		}
+		return r.Service, nil
+	}
+	host, portStr, err := net.SplitHostPort(r.Address)
+	if err != nil {
+		return "", err
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil || port <= 0 || port > 0xFFFF {
+		return "", fmt.Errorf("invalid port '%s'", portStr)
+	}
+	service := registry.SESSION_MANAGER + "@" + r.Address
+	registry.AddService(service, host, port)
+	return service, nil
+}
+
+// serviceOf returns the service registry name of the APN's session manager
+func serviceOf(apn string) string {
+	routing.RLock()
+	defer routing.RUnlock()
+	if service, ok := routing.apns[strings.ToLower(apn)]; ok {
+		return service
+	}
+	if len(routing.defaultService) > 0 {
+		return routing.defaultService
+	}
+	return registry.SESSION_MANAGER
+}
+
+// Services returns service registry names of all routed session managers, the default one first
+func Services() []string {
+	routing.RLock()
+	defer routing.RUnlock()
+	def := routing.defaultService
+	if len(def) == 0 {
+		def = registry.SESSION_MANAGER
+	}
+	seen := map[string]bool{def: true}
+	var res []string
+	for _, service := range routing.apns {
+		if !seen[service] {
+			seen[service] = true
+			res = append(res, service)
+		}
+	}
+	sort.Strings(res)
+	return append([]string{def}, res...)
+}
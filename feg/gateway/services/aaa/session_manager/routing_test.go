@@ -0,0 +1,65 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package session_manager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"magma/feg/gateway/registry"
+)
+
+func TestRouting(t *testing.T) {
+	defer SetRouting(RoutingConfig{})
+
+	// no routes, everything goes to the local session manager
+	assert.NoError(t, SetRouting(RoutingConfig{}))
+	assert.Equal(t, registry.SESSION_MANAGER, serviceOf("magma.wifi"))
+	assert.Equal(t, []string{registry.SESSION_MANAGER}, Services())
+
+	err := SetRouting(RoutingConfig{
+		Routes: []Route{
+			{APN: "Offload.Wifi", Address: "10.0.0.2:50065"},
+			{APN: "ims", Service: "FEG_RELAY"},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, registry.SESSION_MANAGER+"@10.0.0.2:50065", serviceOf("offload.wifi"))
+	assert.Equal(t, registry.SESSION_MANAGER+"@10.0.0.2:50065", serviceOf("OFFLOAD.WIFI"))
+	assert.Equal(t, "FEG_RELAY", serviceOf("ims"))
+	assert.Equal(t, registry.SESSION_MANAGER, serviceOf("magma.wifi"))
+	assert.Equal(t, []string{registry.SESSION_MANAGER, "FEG_RELAY", registry.SESSION_MANAGER + "@10.0.0.2:50065"},
+		Services())
+	addr, err := registry.GetServiceAddress(registry.SESSION_MANAGER + "@10.0.0.2:50065")
+	assert.NoError(t, err)
+	assert.Equal(t, "10.0.0.2:50065", addr)
+
+	// default route
+	assert.NoError(t, SetRouting(RoutingConfig{Default: &Route{Service: "FEG_RELAY"}}))
+	assert.Equal(t, "FEG_RELAY", serviceOf("magma.wifi"))
+}
+
+func TestRoutingErrors(t *testing.T) {
+	defer SetRouting(RoutingConfig{})
+	assert.NoError(t, SetRouting(RoutingConfig{Routes: []Route{{APN: "ims", Service: "FEG_RELAY"}}}))
+
+	for _, cfg := range []RoutingConfig{
+		{Routes: []Route{{Service: "FEG_RELAY"}}},
+		{Routes: []Route{{APN: "ims", Service: "FEG_RELAY", Address: "10.0.0.2:50065"}}},
+		{Routes: []Route{{APN: "ims", Address: "10.0.0.2"}}},
+		{Routes: []Route{{APN: "ims", Address: "10.0.0.2:0"}}},
+		{Routes: []Route{{APN: "ims", Service: "A"}, {APN: "IMS", Service: "B"}}},
+		{Default: &Route{}},
+	} {
+		assert.Error(t, SetRouting(cfg), "%+v", cfg)
+	}
+	// invalid configurations don't change the current routing
+	assert.Equal(t, "FEG_RELAY", serviceOf("ims"))
+}
@@ -0,0 +1,212 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package shedding implements load shedding of the AAA server's accounting calls. Under overload, calls wait in
+// per priority class queues & are served by weighted round robin, so Stops & Terminates (which release sessions &
+// end charging) are preferred over Starts & Interim-Updates. Calls of full queues are rejected & calls whose
+// deadlines expire while queued are dropped
+package shedding
+
+import (
+	"fmt"
+	"sync"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"magma/feg/gateway/services/aaa/metrics"
+)
+
+// Class - call priority class
+type Class int
+
+const (
+	// High - calls ending sessions: Stops & Terminates
+	High Class = iota
+	// Low - calls creating or updating sessions: Starts & Interim-Updates
+	Low
+	numClasses
+)
+
+// Shed reasons
+const (
+	ReasonQueueFull = "queue_full"
+	ReasonExpired   = "expired"
+)
+
+// MethodClasses - priority classes of the shed methods, calls of other methods are never queued
+var MethodClasses = map[string]Class{
+	"/aaa.protos.accounting/stop":              High,
+	"/aaa.protos.accounting/terminate_session": High,
+	"/aaa.protos.accounting/start":             Low,
+	"/aaa.protos.accounting/interim_update":    Low,
+	"/aaa.protos.accounting/create_session":    Low,
+}
+
+// Queue - a priority class queue configuration
+type Queue struct {
+	// Size - maximum number of queued calls, calls of a full queue are rejected
+	Size int
+	// Weight - number of the class' calls served in a round robin round while other classes are queued
+	Weight int
+}
+
+// Config - load shedding configuration
+type Config struct {
+	// MaxConcurrent - maximum number of concurrently served calls, calls over the limit are queued or shed
+	MaxConcurrent int
+	High          Queue
+	Low           Queue
+}
+
+// DefaultConfig returns the default queues of the given concurrency
+func DefaultConfig(maxConcurrent int) Config {
+	return Config{
+		MaxConcurrent: maxConcurrent,
+		High:          Queue{Size: 1000, Weight: 4},
+		Low:           Queue{Size: 100, Weight: 1},
+	}
+}
+
+type waiter struct {
+	ready chan struct{}
+}
+
+// Shedder limits the number of concurrently served calls & queues or sheds the rest
+type Shedder struct {
+	mu      sync.Mutex
+	max     int
+	running int
+	queues  [numClasses][]*waiter
+	limits  [numClasses]Queue
+	credits [numClasses]int // weighted round robin credits left in the current round
+}
+
+// New returns a Shedder of the given configuration
+func New(cfg Config) (*Shedder, error) {
+	if cfg.MaxConcurrent <= 0 {
+		return nil, fmt.Errorf("Invalid max concurrent calls: %d", cfg.MaxConcurrent)
+	}
+	s := &Shedder{max: cfg.MaxConcurrent}
+	s.limits[High], s.limits[Low] = cfg.High, cfg.Low
+	for class, q := range s.limits {
+		if q.Size < 0 || q.Weight <= 0 {
+			return nil, fmt.Errorf("Invalid class %d queue size %d or weight %d", class, q.Size, q.Weight)
+		}
+	}
+	return s, nil
+}
+
+// Acquire waits for a slot of the given class' call, it returns ResourceExhausted error if the call is shed. The
+// slot of a successful Acquire must be released with Release
+func (s *Shedder) Acquire(ctx context.Context, class Class, method string) error {
+	s.mu.Lock()
+	if s.running < s.max && s.queuedUnsafe() == 0 {
+		s.running++
+		s.mu.Unlock()
+		return nil
+	}
+	if len(s.queues[class]) >= s.limits[class].Size {
+		s.mu.Unlock()
+		metrics.AcctShed.WithLabelValues(method, ReasonQueueFull).Inc()
+		return status.Errorf(codes.ResourceExhausted, "%s is shed: queue is full", method)
+	}
+	w := &waiter{ready: make(chan struct{})}
+	s.queues[class] = append(s.queues[class], w)
+	s.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+	}
+	s.mu.Lock()
+	queued := s.removeUnsafe(class, w)
+	s.mu.Unlock()
+	if !queued {
+		// the slot was handed over while the call expired, pass it on
+		s.Release()
+	}
+	metrics.AcctShed.WithLabelValues(method, ReasonExpired).Inc()
+	return status.Errorf(codes.ResourceExhausted, "%s is shed: %v while queued", method, ctx.Err())
+}
+
+// Release releases the call's slot, the slot is handed over to the next queued call if any
+func (s *Shedder) Release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	class, ok := s.nextUnsafe()
+	if !ok {
+		s.running--
+		return
+	}
+	w := s.queues[class][0]
+	s.queues[class][0] = nil
+	s.queues[class] = s.queues[class][1:]
+	close(w.ready)
+}
+
+// UnaryServerInterceptor queues or sheds calls of the MethodClasses methods, a nil Shedder passes all calls through
+func (s *Shedder) UnaryServerInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+
+	class, ok := MethodClasses[info.FullMethod]
+	if s == nil || !ok {
+		return handler(ctx, req)
+	}
+	if err := s.Acquire(ctx, class, info.FullMethod); err != nil {
+		return nil, err
+	}
+	defer s.Release()
+	return handler(ctx, req)
+}
+
+// nextUnsafe selects the class of the next served call by weighted round robin, a new round starts when all
+// queued classes have used their credits
+func (s *Shedder) nextUnsafe() (Class, bool) {
+	for round := 0; round < 2; round++ {
+		for class := High; class < numClasses; class++ {
+			if len(s.queues[class]) > 0 && s.credits[class] > 0 {
+				s.credits[class]--
+				return class, true
+			}
+		}
+		if s.queuedUnsafe() == 0 {
+			return 0, false
+		}
+		for class := range s.credits {
+			s.credits[class] = s.limits[class].Weight
+		}
+	}
+	return 0, false
+}
+
+func (s *Shedder) queuedUnsafe() int {
+	var n int
+	for _, q := range s.queues {
+		n += len(q)
+	}
+	return n
+}
+
+// removeUnsafe removes the waiter from its class queue & returns true if it was still queued
+func (s *Shedder) removeUnsafe(class Class, w *waiter) bool {
+	q := s.queues[class]
+	for i := range q {
+		if q[i] == w {
+			s.queues[class] = append(q[:i], q[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package shedding
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestShedderPriority(t *testing.T) {
+	s, err := New(Config{MaxConcurrent: 1, High: Queue{Size: 10, Weight: 2}, Low: Queue{Size: 10, Weight: 1}})
+	assert.NoError(t, err)
+	assert.NoError(t, s.Acquire(context.Background(), Low, "start"))
+
+	var mu sync.Mutex
+	var served []Class
+	var wg sync.WaitGroup
+	enqueue := func(class Class, queued int) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, s.Acquire(context.Background(), class, "call"))
+			mu.Lock()
+			served = append(served, class)
+			mu.Unlock()
+			s.Release()
+		}()
+		// wait for the call to be queued
+		for queuedOf(s, class) < queued {
+			time.Sleep(time.Millisecond)
+		}
+	}
+	for i := 0; i < 3; i++ {
+		enqueue(Low, i+1)
+	}
+	for i := 0; i < 3; i++ {
+		enqueue(High, i+1)
+	}
+	s.Release()
+	wg.Wait()
+	// 2 High per Low while both are queued
+	assert.Equal(t, []Class{High, High, Low, High, Low, Low}, served)
+	assert.Equal(t, 0, s.running)
+}
+
+func queuedOf(s *Shedder, class Class) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.queues[class])
+}
+
+func TestShedderShed(t *testing.T) {
+	s, err := New(Config{MaxConcurrent: 1, High: Queue{Size: 1, Weight: 1}, Low: Queue{Size: 0, Weight: 1}})
+	assert.NoError(t, err)
+	assert.NoError(t, s.Acquire(context.Background(), High, "stop"))
+
+	// full queue
+	err = s.Acquire(context.Background(), Low, "start")
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// expired while queued
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err = s.Acquire(ctx, High, "stop")
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, 0, queuedOf(s, High))
+
+	s.Release()
+	assert.Equal(t, 0, s.running)
+	assert.NoError(t, s.Acquire(context.Background(), Low, "start"))
+
+	_, err = New(Config{})
+	assert.Error(t, err)
+	_, err = New(Config{MaxConcurrent: 1, High: Queue{Size: 1}, Low: Queue{Size: 1, Weight: 1}})
+	assert.Error(t, err)
+}
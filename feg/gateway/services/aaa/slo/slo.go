@@ -0,0 +1,161 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package slo tracks latencies of the AAA server's downstream dependency calls against configured per dependency
+// latency budgets. Calls over budget are logged as structured breach events & counted, so backend slowness is
+// distinguishable from gateway problems
+package slo
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"magma/feg/gateway/services/aaa/metrics"
+)
+
+// Downstream dependencies
+const (
+	SessionD     = "sessiond"
+	RadiusAuthz  = "radius_authz"
+	SubscriberDB = "subscriberdb"
+)
+
+// Dependencies - all tracked downstream dependencies
+var Dependencies = []string{SessionD, RadiusAuthz, SubscriberDB}
+
+// Breach - structured latency budget breach event
+type Breach struct {
+	Dependency string    `json:"dependency"`
+	Method     string    `json:"method"`
+	LatencyMs  float64   `json:"latency_ms"`
+	BudgetMs   float64   `json:"budget_ms"`
+	Code       string    `json:"code"` // GRPC status code of the call
+	Time       time.Time `json:"time"`
+}
+
+// String returns JSON representation of the breach
+func (b *Breach) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	res, err := json.Marshal(b)
+	if err != nil {
+		return fmt.Sprintf("%+v", *b)
+	}
+	return string(res)
+}
+
+// Call - the result of a dependency's method call
+type Call struct {
+	Time    time.Time // the call's completion time
+	Latency time.Duration
+	Code    codes.Code // GRPC status code of the call
+}
+
+var (
+	mu      sync.RWMutex
+	budgets = map[string]time.Duration{}
+
+	callsMu   sync.Mutex
+	lastCalls = map[string]Call{} // the latest calls by dependency/method
+)
+
+// ParseBudgets parses a comma separated dependency:duration list of latency budgets, e.g. sessiond:200ms
+func ParseBudgets(list string) (map[string]time.Duration, error) {
+	res := map[string]time.Duration{}
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if len(entry) == 0 {
+			continue
+		}
+		parts := strings.SplitN(entry, ":", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("Invalid latency budget '%s', expected dependency:duration", entry)
+		}
+		budget, err := time.ParseDuration(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return nil, fmt.Errorf("Invalid latency budget '%s': %v", entry, err)
+		}
+		res[strings.TrimSpace(parts[0])] = budget
+	}
+	return res, nil
+}
+
+// SetBudgets sets the latency budgets of dependencies, calls of dependencies without a budget are never breaches
+func SetBudgets(newBudgets map[string]time.Duration) error {
+	res := map[string]time.Duration{}
+	for dependency, budget := range newBudgets {
+		if !isDependency(dependency) {
+			return fmt.Errorf("Unknown dependency '%s', expected one of %v", dependency, Dependencies)
+		}
+		if budget <= 0 {
+			return fmt.Errorf("Invalid latency budget of %s: %s, must be positive", dependency, budget)
+		}
+		res[dependency] = budget
+	}
+	mu.Lock()
+	budgets = res
+	mu.Unlock()
+	return nil
+}
+
+// GetBudget returns the latency budget of the dependency, 0 if it has no budget
+func GetBudget(dependency string) time.Duration {
+	mu.RLock()
+	defer mu.RUnlock()
+	return budgets[dependency]
+}
+
+// LastCall returns the latest observed call of the dependency's method, false if the method wasn't called yet
+func LastCall(dependency, method string) (Call, bool) {
+	callsMu.Lock()
+	defer callsMu.Unlock()
+	call, ok := lastCalls[dependency+"/"+method]
+	return call, ok
+}
+
+// Observe records the latency of the dependency's method call started at start & failed with err, if any. It
+// returns the breach event of calls over the dependency's budget, nil otherwise
+func Observe(dependency, method string, start time.Time, err error) *Breach {
+	latency := time.Since(start)
+	metrics.DependencyLatency.WithLabelValues(dependency, method).Observe(latency.Seconds())
+	callsMu.Lock()
+	lastCalls[dependency+"/"+method] = Call{Time: time.Now(), Latency: latency, Code: status.Code(err)}
+	callsMu.Unlock()
+	budget := GetBudget(dependency)
+	if budget <= 0 || latency <= budget {
+		return nil
+	}
+	breach := &Breach{
+		Dependency: dependency,
+		Method:     method,
+		LatencyMs:  float64(latency) / float64(time.Millisecond),
+		BudgetMs:   float64(budget) / float64(time.Millisecond),
+		Code:       status.Code(err).String(),
+		Time:       time.Now(),
+	}
+	metrics.DependencySLOBreaches.WithLabelValues(dependency, method).Inc()
+	log.Printf("Dependency SLO breach: %s", breach)
+	return breach
+}
+
+func isDependency(name string) bool {
+	for _, dependency := range Dependencies {
+		if dependency == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package slo_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+
+	"magma/feg/gateway/services/aaa/slo"
+)
+
+func TestParseBudgets(t *testing.T) {
+	budgets, err := slo.ParseBudgets("sessiond:200ms, subscriberdb: 1s,")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]time.Duration{
+		slo.SessionD:     200 * time.Millisecond,
+		slo.SubscriberDB: time.Second,
+	}, budgets)
+
+	_, err = slo.ParseBudgets("sessiond")
+	assert.Error(t, err)
+	_, err = slo.ParseBudgets("sessiond:fast")
+	assert.Error(t, err)
+
+	assert.Error(t, slo.SetBudgets(map[string]time.Duration{"unknown": time.Second}))
+	assert.Error(t, slo.SetBudgets(map[string]time.Duration{slo.SessionD: 0}))
+}
+
+func TestObserve(t *testing.T) {
+	assert.NoError(t, slo.SetBudgets(map[string]time.Duration{slo.SessionD: 100 * time.Millisecond}))
+	defer slo.SetBudgets(nil)
+
+	assert.Nil(t, slo.Observe(slo.SessionD, "CreateSession", time.Now(), nil))
+	assert.Nil(t, slo.Observe(slo.SubscriberDB, "GetSubscriberData", time.Now().Add(-time.Hour), nil))
+
+	breach := slo.Observe(slo.SessionD, "CreateSession", time.Now().Add(-time.Second), errors.New("failed"))
+	assert.NotNil(t, breach)
+	assert.Equal(t, slo.SessionD, breach.Dependency)
+	assert.Equal(t, "CreateSession", breach.Method)
+	assert.Equal(t, float64(100), breach.BudgetMs)
+	assert.True(t, breach.LatencyMs >= 1000)
+	assert.Equal(t, "Unknown", breach.Code)
+
+	call, ok := slo.LastCall(slo.SessionD, "CreateSession")
+	assert.True(t, ok)
+	assert.Equal(t, codes.Unknown, call.Code)
+	assert.True(t, call.Latency >= time.Second)
+	_, ok = slo.LastCall(slo.SessionD, "EndSession")
+	assert.False(t, ok)
+}
@@ -0,0 +1,78 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package spill stores large session attribute values out of the AAA server's memory, so the memory used by sessions
+// of NASes sending giant vendor attribute sets stays bounded
+package spill
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// Store - storage of spilled session attribute values
+type Store interface {
+	// Put stores the value of the session's attribute, overwriting the previous value if any
+	Put(sid, key, value string) error
+	// Get returns the stored value of the session's attribute
+	Get(sid, key string) (string, error)
+	// Remove removes all stored values of the session
+	Remove(sid string) error
+}
+
+// DirStore - Store keeping each session's values in files of the session's directory
+type DirStore struct {
+	dir string
+}
+
+// NewDirStore returns a DirStore of the given directory, the directory is created if it does not exist
+func NewDirStore(dir string) (*DirStore, error) {
+	if len(dir) == 0 {
+		return nil, fmt.Errorf("Empty spill directory")
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &DirStore{dir: dir}, nil
+}
+
+// Put stores the value of the session's attribute, the value's file is replaced atomically
+func (s *DirStore) Put(sid, key, value string) error {
+	sessionDir := s.sessionDir(sid)
+	if err := os.MkdirAll(sessionDir, 0700); err != nil {
+		return err
+	}
+	path := filepath.Join(sessionDir, hex.EncodeToString([]byte(key)))
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, []byte(value), 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Get returns the stored value of the session's attribute
+func (s *DirStore) Get(sid, key string) (string, error) {
+	b, err := ioutil.ReadFile(filepath.Join(s.sessionDir(sid), hex.EncodeToString([]byte(key))))
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// Remove removes all stored values of the session
+func (s *DirStore) Remove(sid string) error {
+	return os.RemoveAll(s.sessionDir(sid))
+}
+
+// sessionDir returns the session's directory, IDs & keys are hex encoded so they can't escape the store's directory
+func (s *DirStore) sessionDir(sid string) string {
+	return filepath.Join(s.dir, hex.EncodeToString([]byte(sid)))
+}
@@ -0,0 +1,51 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package spill_test
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"magma/feg/gateway/services/aaa/spill"
+)
+
+func TestDirStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "aaa_spill")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	s, err := spill.NewDirStore(dir)
+	assert.NoError(t, err)
+	blob := strings.Repeat("x", 4096)
+	assert.NoError(t, s.Put("sid1", "vendor/blob", blob))
+	assert.NoError(t, s.Put("sid1", "other", "a"))
+	assert.NoError(t, s.Put("../sid2", "other", "b"))
+
+	v, err := s.Get("sid1", "vendor/blob")
+	assert.NoError(t, err)
+	assert.Equal(t, blob, v)
+	assert.NoError(t, s.Put("sid1", "other", "c"))
+	v, err = s.Get("sid1", "other")
+	assert.NoError(t, err)
+	assert.Equal(t, "c", v)
+
+	assert.NoError(t, s.Remove("sid1"))
+	_, err = s.Get("sid1", "vendor/blob")
+	assert.Error(t, err)
+	v, err = s.Get("../sid2", "other")
+	assert.NoError(t, err)
+	assert.Equal(t, "b", v)
+
+	_, err = spill.NewDirStore("")
+	assert.Error(t, err)
+}
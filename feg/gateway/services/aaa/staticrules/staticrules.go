@@ -0,0 +1,115 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package staticrules implements per APN & subscriber static rules installed by session manager at session creation
+package staticrules
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+)
+
+const (
+	imsiPrefix = "IMSI"
+	// AnyAPN - rules of all APNs without their own rules
+	AnyAPN = "*"
+)
+
+// Rules - static rules & static rule base names installed at session creation
+type Rules struct {
+	StaticRuleIDs []string `json:"static_rule_ids"`
+	RuleBaseNames []string `json:"rule_base_names"`
+}
+
+// Config - static rules configuration
+type Config struct {
+	// APNs maps APNs to the rules of their sessions, APN * - all APNs without their own rules
+	APNs map[string]Rules `json:"apns"`
+	// Subscribers maps IMSIs to the rules installed for their sessions in addition to their APNs' rules
+	Subscribers map[string]Rules `json:"subscribers"`
+}
+
+// ReadConfig reads the static rules configuration from the given JSON file
+func ReadConfig(path string) (*Config, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &Config{}
+	if err = json.Unmarshal(b, cfg); err != nil {
+		return nil, fmt.Errorf("Invalid static rules configuration %s: %v", path, err)
+	}
+	apns := make(map[string]Rules, len(cfg.APNs))
+	for apn, rules := range cfg.APNs {
+		if err = rules.validate(); err != nil {
+			return nil, fmt.Errorf("Invalid static rules of APN '%s': %v", apn, err)
+		}
+		apns[strings.ToLower(apn)] = rules
+	}
+	subscribers := make(map[string]Rules, len(cfg.Subscribers))
+	for imsi, rules := range cfg.Subscribers {
+		if err = rules.validate(); err != nil {
+			return nil, fmt.Errorf("Invalid static rules of IMSI %s: %v", imsi, err)
+		}
+		subscribers[strings.TrimPrefix(imsi, imsiPrefix)] = rules
+	}
+	cfg.APNs, cfg.Subscribers = apns, subscribers
+	return cfg, nil
+}
+
+// Rules returns the rules of the subscriber's session of the APN, the APN's rules are followed by the subscriber's
+func (c *Config) Rules(imsi, apn string) Rules {
+	if c == nil {
+		return Rules{}
+	}
+	apnRules, ok := c.APNs[strings.ToLower(apn)]
+	if !ok {
+		apnRules = c.APNs[AnyAPN]
+	}
+	subscriberRules := c.Subscribers[strings.TrimPrefix(imsi, imsiPrefix)]
+	return Rules{
+		StaticRuleIDs: merge(apnRules.StaticRuleIDs, subscriberRules.StaticRuleIDs),
+		RuleBaseNames: merge(apnRules.RuleBaseNames, subscriberRules.RuleBaseNames),
+	}
+}
+
+// Empty returns true if there are no rules
+func (r Rules) Empty() bool {
+	return len(r.StaticRuleIDs) == 0 && len(r.RuleBaseNames) == 0
+}
+
+func (r Rules) validate() error {
+	for _, names := range [][]string{r.StaticRuleIDs, r.RuleBaseNames} {
+		for _, name := range names {
+			if len(strings.TrimSpace(name)) == 0 {
+				return fmt.Errorf("empty rule name")
+			}
+		}
+	}
+	return nil
+}
+
+// merge returns the names of both lists without duplicates, in their lists order
+func merge(first, second []string) []string {
+	if len(second) == 0 {
+		return first
+	}
+	res := make([]string, 0, len(first)+len(second))
+	seen := make(map[string]bool, len(first)+len(second))
+	for _, names := range [][]string{first, second} {
+		for _, name := range names {
+			if !seen[name] {
+				seen[name] = true
+				res = append(res, name)
+			}
+		}
+	}
+	return res
+}
@@ -0,0 +1,65 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package staticrules
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeConfig(t *testing.T, cfg string) string {
+	f, err := ioutil.TempFile("", "static_rules")
+	assert.NoError(t, err)
+	_, err = f.WriteString(cfg)
+	assert.NoError(t, err)
+	f.Close()
+	return f.Name()
+}
+
+func TestRules(t *testing.T) {
+	path := writeConfig(t, `{
+		"apns": {
+			"Internet": {"static_rule_ids": ["allow_dns", "allow_all"], "rule_base_names": ["wifi_base"]},
+			"*": {"static_rule_ids": ["allow_dns"]}
+		},
+		"subscribers": {
+			"IMSI001010000000001": {"static_rule_ids": ["allow_all", "vip"], "rule_base_names": ["vip_base"]}
+		}
+	}`)
+	defer os.Remove(path)
+
+	cfg, err := ReadConfig(path)
+	assert.NoError(t, err)
+
+	assert.Equal(t,
+		Rules{StaticRuleIDs: []string{"allow_dns", "allow_all"}, RuleBaseNames: []string{"wifi_base"}},
+		cfg.Rules("001010000000002", "internet"))
+	assert.Equal(t,
+		Rules{StaticRuleIDs: []string{"allow_dns", "allow_all", "vip"}, RuleBaseNames: []string{"wifi_base", "vip_base"}},
+		cfg.Rules("001010000000001", "INTERNET"))
+	assert.Equal(t, Rules{StaticRuleIDs: []string{"allow_dns"}}, cfg.Rules("IMSI001010000000002", "guest"))
+
+	var noRules *Config
+	assert.True(t, noRules.Rules("001010000000001", "internet").Empty())
+}
+
+func TestReadInvalidConfig(t *testing.T) {
+	path := writeConfig(t, `{"apns": {"internet": {"static_rule_ids": [""]}}}`)
+	defer os.Remove(path)
+	_, err := ReadConfig(path)
+	assert.Error(t, err)
+
+	path2 := writeConfig(t, `{"apns": []}`)
+	defer os.Remove(path2)
+	_, err = ReadConfig(path2)
+	assert.Error(t, err)
+}
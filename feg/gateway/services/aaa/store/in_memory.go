@@ -18,70 +18,110 @@ import (
 	"time"
 	"unsafe"
 
+	"github.com/golang/protobuf/proto"
+
 	"magma/feg/gateway/services/aaa"
 	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/aaa/panics"
 	"magma/feg/gateway/services/aaa/protos"
 )
 
 // Session - struct to save an authenticated session state
 type memSession struct {
-	*protos.Context
+	ctx             unsafe.Pointer // *protos.Context, the current immutable context snapshot
+	sid             string
 	imsi            string
-	cleanupTimerCtx unsafe.Pointer // *cleanupTimerCtx
-	mu              sync.Mutex
+	cleanupTimerCtx unsafe.Pointer    // *cleanupTimerCtx
+	lastActive      int64             // UnixNano time of the last session timeout [re]set
+	timeout         int64             // the last set session timeout, ns
+	mu              sync.Mutex        // serializes the session's context updates & their change notifications
+	removed         int32             // set to 1 when the session is removed from its table
+	persisted       []byte            // the last stored session context, if the table persists its sessions
+	keys            map[string]string // the session's keys by index name, guarded by the table lock
+	queued          int64             // the session's deadline queued in its shard's timeout wheel, UnixNano
+	wheelIdx        int               // index of the queued deadline in the wheel, -1 if not queued
 }
 
-// Lock - locks the Session's mutex
-func (s *memSession) Lock() {
-	if s != nil {
-		s.mu.Lock()
-	}
-}
-
-// Unlock - unlocks the Session's mutex
-func (s *memSession) Unlock() {
-	if s != nil {
-		s.mu.Unlock()
-	}
-}
-
-// GetCtx returns AAA Session Context
+// GetCtx returns AAA Session Context, the returned snapshot is never changed
 func (s *memSession) GetCtx() *protos.Context {
 	if s != nil {
-		return s.Context
+		return (*protos.Context)(atomic.LoadPointer(&s.ctx))
 	}
 	return nil
 }
 
-// SetCtx sets AAA Session Context - must be called on a Locked session
-func (s *memSession) SetCtx(pc *protos.Context) {
-	if s != nil {
-		s.Context = pc
-	}
+// setCtx replaces the session's context snapshot, it must be called with the session's mutex held
+func (s *memSession) setCtx(pc *protos.Context) {
+	atomic.StorePointer(&s.ctx, unsafe.Pointer(pc))
 }
 
 // StopTimeout - stops the session's timeout if possible, returns if the timeout was successfully stopped
 func (s *memSession) StopTimeout() bool {
 	if s != nil {
-		if ctx := atomic.SwapPointer(&s.cleanupTimerCtx, nil); ctx != nil {
-			if t := atomic.SwapPointer(&((*cleanupTimerCtx)(ctx).sessionTimerPtr), nil); t != nil {
-				return (*time.Timer)(t).Stop()
-			}
+		if ctx := (*cleanupTimerCtx)(atomic.SwapPointer(&s.cleanupTimerCtx, nil)); ctx != nil {
+			ctx.owner.shard(ctx.sidKey).wheel.cancel(s)
+			return true
 		}
 	}
 	return false
 }
 
-// SessionTable - synchronized map of authenticated sessions
+// SessionTable - synchronized map of authenticated sessions, sharded by SID
 type memSessionTable struct {
-	sm   map[string]*memSession
-	sids map[string]string // Session IDs by IMSI: SID[IMSI]
-	rwl  sync.RWMutex      // R/W lock synchronizing maps access
+	shards    []*sessionShard
+	count     int               // number of sessions, guarded by the table lock
+	sids      map[string]string // Session IDs by IMSI: SID[IMSI]
+	rwl       sync.RWMutex      // table lock synchronizing sessions' adds & removes, IMSI map & indexes access
+	limits    Limits
+	updated   func(s *memSession)      // context update hook of the table's sessions
+	indexes   map[string]*sessionIndex // secondary indexes by name
+	normalize SIDNormalizer            // session IDs' normalization, nil - session IDs are matched as is
+	scheduler *Scheduler               // scheduler of the sessions' timeouts
 }
 
 // NewSessionTable - returns a new initialized session table
 func NewMemorySessionTable() aaa.SessionTable {
-	return &memSessionTable{sm: map[string]*memSession{}, sids: map[string]string{}}
+	return newMemSessionTable(DefaultShards, Limits{})
+}
+
+// NewMemorySessionTableWithLimits - returns a new initialized session table enforcing the given limits
+func NewMemorySessionTableWithLimits(limits Limits) (aaa.SessionTable, error) {
+	return NewShardedMemorySessionTable(DefaultShards, limits)
+}
+
+// NewShardedMemorySessionTable - returns a new initialized session table of the given number of shards enforcing the
+// given limits, a single shard table serializes all its sessions' lookups & timeout resets
+func NewShardedMemorySessionTable(shards int, limits Limits) (aaa.SessionTable, error) {
+	if shards < 1 {
+		return nil, fmt.Errorf("Invalid number of session table shards: %d", shards)
+	}
+	if err := limits.Validate(); err != nil {
+		return nil, err
+	}
+	return newMemSessionTable(shards, limits), nil
+}
+
+func newMemSessionTable(shards int, limits Limits) *memSessionTable {
+	st := &memSessionTable{
+		shards:    make([]*sessionShard, shards),
+		sids:      map[string]string{},
+		limits:    limits,
+		scheduler: newSystemScheduler(),
+	}
+	for i := range st.shards {
+		st.shards[i] = &sessionShard{sm: map[string]*memSession{}, wheel: timeoutWheel{sched: st.scheduler}}
+	}
+	return st
+}
+
+// setScheduler sets the scheduler of the table's session timeouts, it must be set before the first session is added
+func (st *memSessionTable) setScheduler(sched *Scheduler) {
+	st.scheduler = sched
+	for _, sh := range st.shards {
+		sh.wheel.mu.Lock()
+		sh.wheel.sched = sched
+		sh.wheel.mu.Unlock()
+	}
 }
 
 // AddSession - adds a new session to the table & returns the newly created session pointer.
@@ -90,13 +130,24 @@ func NewMemorySessionTable() aaa.SessionTable {
 func (st *memSessionTable) AddSession(
 	pc *protos.Context, tout time.Duration, notifier aaa.TimeoutNotifier, overwrite ...bool) (aaa.Session, error) {
 
+	s, err := st.addSession(pc, tout, notifier, len(overwrite) > 0 && overwrite[0], false)
+	if s == nil {
+		return nil, err // don't return nil *memSession as non nil aaa.Session
+	}
+	return s, err
+}
+
+// addSession adds the session to the table, restored sessions of a previous process are not counted as served again
+func (st *memSessionTable) addSession(
+	pc *protos.Context, tout time.Duration, notifier aaa.TimeoutNotifier, overwrite, restored bool) (*memSession, error) {
+
 	if st == nil {
 		return nil, fmt.Errorf("Nil SessionTable")
 	}
 	if pc == nil {
 		return nil, fmt.Errorf("Nil Session Context")
 	}
-	sid := strings.TrimSpace(pc.SessionId)
+	sid := st.key(strings.TrimSpace(pc.SessionId))
 	if len(sid) == 0 {
 		return nil, fmt.Errorf("Empty Session Id")
 	}
@@ -105,35 +156,43 @@ func (st *memSessionTable) AddSession(
 	}
 
 	imsi := pc.GetImsi()
-	s := &memSession{Context: pc, imsi: imsi}
+	s := &memSession{ctx: unsafe.Pointer(pc), sid: sid, imsi: imsi, wheelIdx: -1}
+	var evicted *memSession
 	st.rwl.Lock()
-	if oldSession, ok := st.sm[sid]; ok {
-		if len(overwrite) > 0 && overwrite[0] {
+	if oldSession, ok := st.lookupUnsafe(sid); ok {
+		if overwrite {
 			oldSession.StopTimeout()
 			if oldSession != nil {
-				oldImsi := oldSession.imsi
-				if oldImsi != imsi {
-					if oldSid, ok := st.sids[oldImsi]; ok && oldSid == sid {
-						delete(st.sids, oldImsi)
-					}
-				}
+				st.deleteUnsafe(oldSession)
 				log.Printf("Session with SID: %s already exist, will overwrite. Old IMSI: %s, New IMSI: %s",
-					sid, oldImsi, imsi)
+					sid, oldSession.imsi, imsi)
 			}
 		} else {
 			st.rwl.Unlock() // return old session is "best effort", done outside of the table lock
 			return oldSession, fmt.Errorf("Session with SID: %s already exist", sid)
 		}
+	} else if st.limits.MaxSessions > 0 && st.count >= st.limits.MaxSessions {
+		evicted = st.preemptUnsafe(pc.GetApn())
+		if evicted == nil {
+			st.rwl.Unlock()
+			metrics.SessionRejects.WithLabelValues(pc.GetApn(), string(st.limits.Policy)).Inc()
+			return nil, fmt.Errorf("Session table is full (%d sessions), SID: %s is rejected", st.limits.MaxSessions, sid)
+		}
 	}
 
-	st.sm[sid] = s
-	st.sids[imsi] = sid
-	apn := s.GetApn()
+	st.insertUnsafe(s)
+	apn := pc.GetApn()
 	st.rwl.Unlock()
 
 	setTimeoutUnsafe(st, sid, tout, s, notifier)
+	if evicted != nil {
+		st.evict(evicted)
+	}
 
 	metrics.Sessions.WithLabelValues(apn).Inc()
+	if !restored {
+		metrics.SessionsServed.Inc()
+	}
 	metrics.SessionStart.WithLabelValues(apn, imsi, sid).SetToCurrentTime()
 
 	return s, nil
@@ -141,13 +200,13 @@ func (st *memSessionTable) AddSession(
 
 // GetSession returns session corresponding to the given sid or nil if not found
 func (st *memSessionTable) GetSession(sid string) aaa.Session {
-	var s *memSession
 	if st != nil {
-		st.rwl.RLock()
-		s, _ = st.sm[sid]
-		st.rwl.RUnlock()
+		sid = st.key(sid)
+		if s := st.shard(sid).get(sid); s != nil {
+			return s // don't return nil *memSession as non nil aaa.Session
+		}
 	}
-	return s
+	return nil
 }
 
 // FindSession returns session corresponding to the given sid or nil if not found
@@ -165,82 +224,229 @@ func (st *memSessionTable) RemoveSession(sid string) aaa.Session {
 	var s *memSession
 	if st != nil {
 		var found bool
+		sid = st.key(sid)
 		st.rwl.Lock()
-		if s, found = st.sm[sid]; found {
-			delete(st.sm, sid)
-			if oldSid, ok := st.sids[s.imsi]; ok && oldSid == sid {
-				delete(st.sids, s.imsi)
-			}
+		if s, found = st.lookupUnsafe(sid); found {
+			st.deleteUnsafe(s)
 		}
 		st.rwl.Unlock()
 		if found && s != nil {
 			s.StopTimeout()
-			apn := s.GetApn()
+			apn := s.GetCtx().GetApn()
 			metrics.Sessions.WithLabelValues(apn).Dec()
-			metrics.SessionStop.WithLabelValues(apn, s.GetImsi(), sid).SetToCurrentTime()
+			metrics.SessionStop.WithLabelValues(apn, s.imsi, sid).SetToCurrentTime()
 		}
 	}
 	return s
 }
 
-// SetTimeout - [Re]sets the session's cleanup timeout to fire after tout duration
+// SetTimeout - [Re]sets the session's cleanup timeout to fire after tout duration, only the session's shard is locked
 func (st *memSessionTable) SetTimeout(sid string, tout time.Duration, notifier aaa.TimeoutNotifier) bool {
 	var res bool
 	if tout > 0 && st != nil && len(sid) > 0 {
-		st.rwl.Lock()
-		if s, ok := st.sm[sid]; ok && s != nil {
+		sid = st.key(sid)
+		sh := st.shard(sid)
+		sh.rwl.RLock() // the session can't be removed while its timeout is set
+		if s, ok := sh.sm[sid]; ok && s != nil {
 			setTimeoutUnsafe(st, sid, tout, s, notifier)
 			res = true
 		}
-		st.rwl.Unlock()
+		sh.rwl.RUnlock()
 	}
 	return res
 }
 
+// UpdateCtx - replaces the session's context with a copy changed by the mutator & updates the session's indexes,
+// the stored contexts are never changed in place, so their readers need no locking. The mutator's error cancels the
+// update. Returns the session's previous & updated contexts
+func (st *memSessionTable) UpdateCtx(
+	sid string, mutator aaa.CtxMutator) (old, updated *protos.Context, err error) {
+
+	if st == nil {
+		return nil, nil, fmt.Errorf("Nil SessionTable")
+	}
+	key := st.key(sid)
+	s := st.shard(key).get(key)
+	if s == nil {
+		return nil, nil, fmt.Errorf("Session with SID: %s is not found", sid)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	old = s.GetCtx()
+	updated = proto.Clone(old).(*protos.Context)
+	if err = mutator(updated); err != nil {
+		return old, old, err
+	}
+	if st.key(strings.TrimSpace(updated.GetSessionId())) != s.sid {
+		return old, old, fmt.Errorf("Session ID of session %s can't be changed to %s", sid, updated.GetSessionId())
+	}
+	s.setCtx(updated)
+	st.reindex(s)
+	if st.updated != nil {
+		st.updated(s)
+	}
+	return old, updated, nil
+}
+
+// SetSIDNormalizer sets the normalization of the session IDs the table's sessions are added & looked up by, it must
+// be set before the table's first session is added
+func (st *memSessionTable) SetSIDNormalizer(normalize func(sid string) string) {
+	st.normalize = normalize
+}
+
+// key returns the normalized session ID the table's maps are keyed by
+func (st *memSessionTable) key(sid string) string {
+	if st.normalize != nil {
+		return st.normalize(sid)
+	}
+	return sid
+}
+
+// lookupUnsafe returns the session of the SID, lookupUnsafe must be called with the table lock held
+func (st *memSessionTable) lookupUnsafe(sid string) (*memSession, bool) {
+	s, ok := st.shard(sid).sm[sid]
+	return s, ok
+}
+
+// insertUnsafe adds the session to its shard, the IMSI map & the indexes, insertUnsafe must be called with the table
+// lock held
+func (st *memSessionTable) insertUnsafe(s *memSession) {
+	sh := st.shard(s.sid)
+	sh.rwl.Lock()
+	sh.sm[s.sid] = s
+	sh.rwl.Unlock()
+	st.count++
+	st.sids[s.imsi] = s.sid
+	st.indexUnsafe(s)
+}
+
+// deleteUnsafe removes the session from its shard, the IMSI map & the indexes, deleteUnsafe must be called with the
+// table lock held
+func (st *memSessionTable) deleteUnsafe(s *memSession) {
+	sh := st.shard(s.sid)
+	sh.rwl.Lock()
+	delete(sh.sm, s.sid)
+	sh.rwl.Unlock()
+	st.count--
+	st.unindexUnsafe(s)
+	if oldSid, ok := st.sids[s.imsi]; ok && oldSid == s.sid {
+		delete(st.sids, s.imsi)
+	}
+}
+
+// Compact rebuilds the table's maps, Go maps never shrink & keep the memory of their peak size after deletions
+func (st *memSessionTable) Compact() {
+	if st == nil {
+		return
+	}
+	st.rwl.Lock()
+	for _, sh := range st.shards {
+		sm := make(map[string]*memSession, len(sh.sm))
+		for sid, s := range sh.sm {
+			sm[sid] = s
+		}
+		sh.rwl.Lock()
+		sh.sm = sm
+		sh.rwl.Unlock()
+		sh.wheel.compact()
+	}
+	sids := make(map[string]string, len(st.sids))
+	for imsi, sid := range st.sids {
+		sids[imsi] = sid
+	}
+	st.sids = sids
+	st.compactIndexesUnsafe()
+	st.rwl.Unlock()
+}
+
+// Count returns the number of the table's sessions
+func (st *memSessionTable) Count() int {
+	if st == nil {
+		return 0
+	}
+	st.rwl.RLock()
+	defer st.rwl.RUnlock()
+	return st.count
+}
+
+// ListSessions returns all sessions of the table with their remaining idle timeouts
+func (st *memSessionTable) ListSessions() []aaa.SessionTimeout {
+	if st == nil {
+		return nil
+	}
+	now := st.scheduler.now()
+	st.rwl.RLock()
+	res := make([]aaa.SessionTimeout, 0, st.count)
+	for _, sh := range st.shards {
+		for _, s := range sh.sm {
+			var remaining time.Duration
+			if atomic.LoadPointer(&s.cleanupTimerCtx) != nil {
+				remaining = time.Duration(atomic.LoadInt64(&s.lastActive) + atomic.LoadInt64(&s.timeout) - now)
+				if remaining < aaa.MinimalSessionTimeout {
+					remaining = aaa.MinimalSessionTimeout
+				}
+			}
+			res = append(res, aaa.SessionTimeout{Session: s, Timeout: remaining})
+		}
+	}
+	st.rwl.RUnlock()
+	return res
+}
+
 type cleanupTimerCtx struct {
-	owner           *memSessionTable
-	sidKey          string
-	s               *memSession
-	notifyRoutine   aaa.TimeoutNotifier
-	sessionTimerPtr unsafe.Pointer
+	owner         *memSessionTable
+	sidKey        string
+	s             *memSession
+	notifyRoutine aaa.TimeoutNotifier
 }
 
+// setTimeoutUnsafe [re]sets the session's timeout extended by the scheduler's jitter, the new deadline is queued in the
+// shard's timeout wheel only if it's earlier than the queued one. The deadline is stored before the context, so the
+// wheel never expires the new context by the old deadline
 func setTimeoutUnsafe(st *memSessionTable, sid string, tout time.Duration, s *memSession, notifier aaa.TimeoutNotifier) {
 	var ctx = &cleanupTimerCtx{owner: st, sidKey: sid, s: s, notifyRoutine: notifier}
-	newTimer := time.AfterFunc(tout, func() { cleanupTimer(ctx) })
-	atomic.StorePointer(&ctx.sessionTimerPtr, unsafe.Pointer(newTimer))
+	tout = st.scheduler.jittered(tout)
+	now := st.scheduler.now()
+	atomic.StoreInt64(&s.timeout, int64(tout))
+	atomic.StoreInt64(&s.lastActive, now)
 	atomic.StorePointer(&s.cleanupTimerCtx, unsafe.Pointer(ctx))
+	st.shard(sid).wheel.schedule(s, now+int64(tout))
 }
 
 func cleanupTimer(ctx *cleanupTimerCtx) {
-	if ctx != nil && ctx.s != nil && ctx.owner != nil {
-		var deleted bool
+	defer panics.Recover("session_timeout")
+	if removeTimedOut(ctx) {
+		notifyTimedOut(ctx)
+	}
+}
 
+// removeTimedOut removes the timed out session from its table, false if the session was removed or its timeout reset
+// since it timed out
+func removeTimedOut(ctx *cleanupTimerCtx) bool {
+	var deleted bool
+	if ctx != nil && ctx.s != nil && ctx.owner != nil {
 		ctx.owner.rwl.Lock()
-		if ctx.owner.sm != nil {
-			if ms, ok := ctx.owner.sm[ctx.sidKey]; ok && ms == ctx.s {
-				if atomic.CompareAndSwapPointer((*unsafe.Pointer)(&ms.cleanupTimerCtx), unsafe.Pointer(ctx), nil) {
-					delete(ctx.owner.sm, ctx.sidKey)
-					if oldSid, ok := ctx.owner.sids[ms.imsi]; ok && oldSid == ctx.sidKey {
-						delete(ctx.owner.sids, ms.imsi)
-					}
-					deleted = true
-				}
+		if ms, ok := ctx.owner.lookupUnsafe(ctx.sidKey); ok && ms == ctx.s {
+			if atomic.CompareAndSwapPointer((*unsafe.Pointer)(&ms.cleanupTimerCtx), unsafe.Pointer(ctx), nil) {
+				ctx.owner.deleteUnsafe(ms)
+				deleted = true
 			}
 		}
 		ctx.owner.rwl.Unlock()
+	}
+	return deleted
+}
 
-		if deleted {
-			var notifyResult error
-			s := ctx.s
-			if ctx.notifyRoutine != nil {
-				notifyResult = ctx.notifyRoutine(s)
-			}
-			log.Printf(
-				"Timed out session '%s' for SessionId: %s; IMSI: %s; Identity: %s; MAC: %s; IP: %s; notify result: %v",
-				ctx.sidKey, s.GetSessionId(), s.GetImsi(), s.GetIdentity(), s.GetMacAddr(), s.GetIpAddr(), notifyResult)
-
-			metrics.SessionTimeouts.WithLabelValues(s.GetApn(), s.GetImsi()).Inc()
-		}
+// notifyTimedOut notifies the removed timed out session
+func notifyTimedOut(ctx *cleanupTimerCtx) {
+	var notifyResult error
+	if ctx.notifyRoutine != nil {
+		notifyResult = ctx.notifyRoutine(ctx.s)
 	}
+	s := ctx.s.GetCtx()
+	log.Printf(
+		"Timed out session '%s' for SessionId: %s; IMSI: %s; Identity: %s; MAC: %s; IP: %s; notify result: %v",
+		ctx.sidKey, s.GetSessionId(), s.GetImsi(), s.GetIdentity(), s.GetMacAddr(), s.GetIpAddr(), notifyResult)
+
+	metrics.SessionTimeouts.WithLabelValues(s.GetApn(), s.GetImsi()).Inc()
 }
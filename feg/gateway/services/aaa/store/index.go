@@ -0,0 +1,150 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package store
+
+import (
+	"fmt"
+
+	"magma/feg/gateway/services/aaa"
+)
+
+// sessionIndex - secondary index of the table's sessions
+type sessionIndex struct {
+	key  aaa.IndexKey
+	sids map[string]map[string]struct{} // SIDs by key
+}
+
+func (idx *sessionIndex) add(key, sid string) {
+	sids, ok := idx.sids[key]
+	if !ok {
+		sids = map[string]struct{}{}
+		idx.sids[key] = sids
+	}
+	sids[sid] = struct{}{}
+}
+
+func (idx *sessionIndex) remove(key, sid string) {
+	if sids, ok := idx.sids[key]; ok {
+		delete(sids, sid)
+		if len(sids) == 0 {
+			delete(idx.sids, key)
+		}
+	}
+}
+
+// AddIndex registers the named index of the given key, the table's sessions are indexed right away
+func (st *memSessionTable) AddIndex(name string, key aaa.IndexKey) error {
+	if st == nil {
+		return fmt.Errorf("Nil SessionTable")
+	}
+	if len(name) == 0 || key == nil {
+		return fmt.Errorf("Index must have a name & a key")
+	}
+	st.rwl.Lock()
+	defer st.rwl.Unlock()
+	if _, ok := st.indexes[name]; ok {
+		return fmt.Errorf("Index '%s' already exists", name)
+	}
+	if st.indexes == nil {
+		st.indexes = map[string]*sessionIndex{}
+	}
+	idx := &sessionIndex{key: key, sids: map[string]map[string]struct{}{}}
+	st.indexes[name] = idx
+	for _, sh := range st.shards {
+		for sid, s := range sh.sm {
+			if k := key(s.GetCtx()); len(k) > 0 {
+				idx.add(k, sid)
+				if s.keys == nil {
+					s.keys = map[string]string{}
+				}
+				s.keys[name] = k
+			}
+		}
+	}
+	return nil
+}
+
+// LookupIndex returns the sessions of the key in the named index, ok is false if the index isn't registered
+func (st *memSessionTable) LookupIndex(name, key string) ([]aaa.Session, bool) {
+	if st == nil {
+		return nil, false
+	}
+	st.rwl.RLock()
+	defer st.rwl.RUnlock()
+	idx, ok := st.indexes[name]
+	if !ok {
+		return nil, false
+	}
+	var res []aaa.Session
+	for sid := range idx.sids[key] {
+		if s, found := st.lookupUnsafe(sid); found {
+			res = append(res, s)
+		}
+	}
+	return res, true
+}
+
+// indexUnsafe adds the session to all indexes of its non empty keys, indexUnsafe must be called with the table
+// lock held
+func (st *memSessionTable) indexUnsafe(s *memSession) {
+	if len(st.indexes) == 0 {
+		return
+	}
+	s.keys = make(map[string]string, len(st.indexes))
+	for name, idx := range st.indexes {
+		if k := idx.key(s.GetCtx()); len(k) > 0 {
+			idx.add(k, s.sid)
+			s.keys[name] = k
+		}
+	}
+}
+
+// unindexUnsafe removes the session from all indexes, unindexUnsafe must be called with the table lock held
+func (st *memSessionTable) unindexUnsafe(s *memSession) {
+	for name, k := range s.keys {
+		if idx, ok := st.indexes[name]; ok {
+			idx.remove(k, s.sid)
+		}
+	}
+	s.keys = nil
+}
+
+// reindex updates the indexes of the updated session if its keys were changed, sessions no longer in the table are
+// not indexed
+func (st *memSessionTable) reindex(s *memSession) {
+	st.rwl.RLock()
+	changed := false
+	for name, idx := range st.indexes {
+		if idx.key(s.GetCtx()) != s.keys[name] {
+			changed = true
+			break
+		}
+	}
+	st.rwl.RUnlock()
+	if !changed {
+		return
+	}
+	st.rwl.Lock()
+	if current, ok := st.lookupUnsafe(s.sid); ok && current == s {
+		st.unindexUnsafe(s)
+		st.indexUnsafe(s)
+	}
+	st.rwl.Unlock()
+}
+
+// compactIndexesUnsafe rebuilds the indexes' maps, compactIndexesUnsafe must be called with the table lock held
+func (st *memSessionTable) compactIndexesUnsafe() {
+	for _, idx := range st.indexes {
+		sids := make(map[string]map[string]struct{}, len(idx.sids))
+		for k, keySids := range idx.sids {
+			sids[k] = keySids
+		}
+		idx.sids = sids
+	}
+}
@@ -0,0 +1,86 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package store_test
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"magma/feg/gateway/services/aaa"
+	"magma/feg/gateway/services/aaa/protos"
+	"magma/feg/gateway/services/aaa/store"
+)
+
+// sids returns the sorted session IDs of the sessions
+func sids(sessions []aaa.Session) []string {
+	var res []string
+	for _, s := range sessions {
+		res = append(res, s.GetCtx().GetSessionId())
+	}
+	sort.Strings(res)
+	return res
+}
+
+func TestSessionIndexes(t *testing.T) {
+	st := store.NewMemorySessionTable()
+	_, err := st.AddSession(&protos.Context{SessionId: "sid1", Imsi: "1", MacAddr: "0A-1B-2C-3D-4E-5F", Apn: "IoT"},
+		time.Minute, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, aaa.AddStandardIndexes(st))
+	assert.Error(t, st.(aaa.SessionIndexer).AddIndex(aaa.MACIndex, aaa.StandardIndexes[aaa.MACIndex]))
+
+	// existing sessions are indexed on registration, new sessions as they are added
+	_, err = st.AddSession(&protos.Context{SessionId: "sid2", Imsi: "2", MacAddr: "0a:1b:2c:3d:4e:60", Apn: "iot"},
+		time.Minute, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sid1"}, sids(aaa.FindSessions(st, aaa.MACIndex, "0a:1b:2c:3d:4e:5f")))
+	assert.Equal(t, []string{"sid1", "sid2"}, sids(aaa.FindSessions(st, aaa.APNIndex, "iot")))
+	assert.Empty(t, aaa.FindSessions(st, aaa.IPIndex, ""))
+
+	// sessions are reindexed when their contexts are updated
+	_, _, err = st.UpdateCtx("sid2", func(c *protos.Context) error {
+		c.MacAddr, c.IpAddr, c.Apn = "", "10.0.0.2", "internet"
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sid2"}, sids(aaa.FindSessions(st, aaa.IPIndex, "10.0.0.2")))
+	assert.Equal(t, []string{"sid1"}, sids(aaa.FindSessions(st, aaa.APNIndex, "iot")))
+	assert.Empty(t, aaa.FindSessions(st, aaa.MACIndex, "0a:1b:2c:3d:4e:60"))
+
+	// overwritten, removed & timed out sessions are unindexed
+	_, err = st.AddSession(&protos.Context{SessionId: "sid2", Imsi: "3", Apn: "internet"}, time.Minute, nil, true)
+	assert.NoError(t, err)
+	assert.Empty(t, aaa.FindSessions(st, aaa.IPIndex, "10.0.0.2"))
+	assert.Equal(t, []string{"sid2"}, sids(aaa.FindSessions(st, aaa.IMSIIndex, "3")))
+	st.RemoveSession("sid1")
+	assert.Empty(t, aaa.FindSessions(st, aaa.APNIndex, "iot"))
+	st.SetTimeout("sid2", aaa.MinimalSessionTimeout, nil)
+	time.Sleep(aaa.MinimalSessionTimeout * 5)
+	assert.Empty(t, aaa.FindSessions(st, aaa.APNIndex, "internet"))
+
+	// removed sessions can't be updated & indexed again
+	_, _, err = st.UpdateCtx("sid2", func(c *protos.Context) error {
+		c.Apn = "iot"
+		return nil
+	})
+	assert.Error(t, err)
+	assert.Empty(t, aaa.FindSessions(st, aaa.APNIndex, "iot"))
+}
+
+func TestFindSessionsScan(t *testing.T) {
+	// sessions of tables without the index are scanned
+	st := store.NewMemorySessionTable()
+	_, err := st.AddSession(&protos.Context{SessionId: "sid1", MacAddr: "0A-1B-2C-3D-4E-5F"}, time.Minute, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sid1"}, sids(aaa.FindSessions(st, aaa.MACIndex, aaa.MACKey("0a1b.2c3d.4e5f"))))
+	assert.Empty(t, aaa.FindSessions(st, "unknown", "0a:1b:2c:3d:4e:5f"))
+}
@@ -0,0 +1,46 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package store
+
+import (
+	"log"
+	"time"
+
+	"magma/feg/gateway/services/aaa"
+	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/aaa/panics"
+)
+
+// compactor is implemented by session tables which can release memory of removed sessions
+type compactor interface {
+	Compact()
+}
+
+// RunMaintenance runs Maintain every interval, it never returns
+func RunMaintenance(st aaa.SessionTable, interval, retention time.Duration) {
+	log.Printf("Session table maintenance interval: %v, inactive IMSI metrics retention: %v", interval, retention)
+	for {
+		time.Sleep(interval)
+		func() {
+			defer panics.Recover("session_table_maintenance")
+			Maintain(st, retention)
+		}()
+	}
+}
+
+// Maintain compacts the session table & prunes metrics of IMSIs without active sessions for at least
+// the retention period
+func Maintain(st aaa.SessionTable, retention time.Duration) {
+	start := time.Now()
+	if c, ok := st.(compactor); ok {
+		c.Compact()
+	}
+	pruned := metrics.PruneIMSILabels(func(imsi string) bool { return len(st.FindSession(imsi)) > 0 }, retention)
+	log.Printf("Session table maintenance done in %v, pruned %d inactive IMSI metrics", time.Since(start), pruned)
+}
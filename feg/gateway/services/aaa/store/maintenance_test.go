@@ -0,0 +1,65 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package store_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+
+	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/aaa/protos"
+	"magma/feg/gateway/services/aaa/store"
+)
+
+func countIMSISeries(t *testing.T, imsi string) int {
+	families, err := prometheus.DefaultGatherer.Gather()
+	assert.NoError(t, err)
+	var count int
+	for _, f := range families {
+		for _, m := range f.GetMetric() {
+			for _, l := range m.GetLabel() {
+				if l.GetName() == "imsi" && l.GetValue() == imsi {
+					count++
+				}
+			}
+		}
+	}
+	return count
+}
+
+func TestMaintain(t *testing.T) {
+	const (
+		activeImsi   = "001010000000101"
+		inactiveImsi = "001010000000102"
+	)
+	st := store.NewMemorySessionTable()
+	_, err := st.AddSession(&protos.Context{SessionId: "sid101", Imsi: activeImsi, Apn: "apn"}, time.Minute, nil)
+	assert.NoError(t, err)
+	_, err = st.AddSession(&protos.Context{SessionId: "sid102", Imsi: inactiveImsi, Apn: "apn"}, time.Minute, nil)
+	assert.NoError(t, err)
+	metrics.OctetsIn.WithLabelValues("apn", activeImsi).Add(10)
+	metrics.OctetsIn.WithLabelValues("apn", inactiveImsi).Add(10)
+	assert.NotNil(t, st.RemoveSession("sid102"))
+
+	assert.Equal(t, 2, countIMSISeries(t, activeImsi))   // session_start & octets_in
+	assert.Equal(t, 3, countIMSISeries(t, inactiveImsi)) // session_start, session_stop & octets_in
+
+	// The inactive IMSI is kept for the retention period after it's first seen inactive
+	store.Maintain(st, time.Hour)
+	assert.Equal(t, 3, countIMSISeries(t, inactiveImsi))
+
+	store.Maintain(st, 0)
+	assert.Equal(t, 0, countIMSISeries(t, inactiveImsi))
+	assert.Equal(t, 2, countIMSISeries(t, activeImsi))
+	assert.Equal(t, "sid101", st.FindSession(activeImsi))
+	assert.NotNil(t, st.GetSession("sid101"))
+}
@@ -67,10 +67,8 @@ func runTest(t *testing.T, st aaa.SessionTable, c chan struct{}) {
 	assert.Error(t, err)
 	assert.Equal(t, sharedSid, st.FindSession(sharedImsi))
 
-	shared.Lock()
 	assert.Equal(t, shared, sharedSession)
 	assert.Equal(t, sharedImsi, shared.GetCtx().GetImsi())
-	shared.Unlock()
 
 	sid := aaa.CreateSessionId()
 	imsi := strconv.FormatUint(rand.Uint64(), 10)[:15]
@@ -84,20 +82,20 @@ func runTest(t *testing.T, st aaa.SessionTable, c chan struct{}) {
 
 	shared = st.GetSession(sharedSid)
 	assert.Equal(t, shared, sharedSession)
-	shared.Lock()
-	shared.GetCtx().Identity = time.Now().String()
-	shared.Unlock()
+	_, _, err = st.UpdateCtx(sharedSid, func(c *protos.Context) error {
+		c.Identity = time.Now().String()
+		return nil
+	})
+	assert.NoError(t, err)
 
 	// Test Find session
 	s1 := st.GetSession(sid)
 	assert.Equal(t, s, s1)
 	checkSid := st.FindSession(imsi)
 	assert.Equal(t, sid, checkSid)
-	s1.Lock()
 
 	// Test timeout cleanup
 	time.Sleep(time.Millisecond * 300)
-	s1.Unlock()
 
 	assert.NotEqual(t, 0, atomic.LoadInt32((*int32)(&done)))
 	s2 := st.GetSession(sid)
@@ -136,3 +134,24 @@ func runTest(t *testing.T, st aaa.SessionTable, c chan struct{}) {
 	success = st.SetTimeout(sid, time.Millisecond*10, nil)
 	assert.False(t, success)
 }
+
+func TestListSessions(t *testing.T) {
+	st := store.NewMemorySessionTable()
+	_, err := st.AddSession(&protos.Context{SessionId: "sid1", Imsi: "001010000000001"}, time.Minute, nil)
+	assert.NoError(t, err)
+	_, err = st.AddSession(&protos.Context{SessionId: "sid2", Imsi: "001010000000002"}, time.Hour, nil)
+	assert.NoError(t, err)
+	st.GetSession("sid2").StopTimeout()
+
+	listed := st.(aaa.SessionLister).ListSessions()
+	assert.Len(t, listed, 2)
+	timeouts := map[string]time.Duration{}
+	for _, s := range listed {
+		timeouts[s.Session.GetCtx().GetSessionId()] = s.Timeout
+	}
+	assert.True(t, timeouts["sid1"] > 50*time.Second && timeouts["sid1"] <= time.Minute)
+	assert.Equal(t, time.Duration(0), timeouts["sid2"])
+	assert.Equal(t, 2, st.(aaa.SessionCounter).Count())
+	st.RemoveSession("sid1")
+	assert.Equal(t, 1, st.(aaa.SessionCounter).Count())
+}
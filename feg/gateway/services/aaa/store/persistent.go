@@ -0,0 +1,248 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package store
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	"magma/feg/gateway/object_store"
+	"magma/feg/gateway/services/aaa"
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+// Session table types selectable by AAA server's configuration
+const (
+	MemoryTable = "memory"
+	RedisTable  = "redis"
+)
+
+// sessionsHash - Redis hash of the persisted sessions
+const sessionsHash = "aaa_sessions"
+
+// persistedSession - stored session record, Deadline is the session's idle timeout expiration, Unix ms
+type persistedSession struct {
+	Ctx      []byte `json:"ctx"`
+	Deadline int64  `json:"deadline,omitempty"`
+}
+
+// PersistentSessionTable - in memory session table writing its sessions through to an object store, the stored
+// sessions are restored into the table after AAA server restarts. Sessions are stored when they are added, when their
+// timeouts are [re]set & when their contexts are changed by UpdateCtx. Sessions' MSKs are not stored
+type PersistentSessionTable struct {
+	*memSessionTable
+	store object_store.ObjectMap
+}
+
+// NewPersistentSessionTable returns a new session table enforcing the given limits & persisting its sessions in the
+// given store, the stored sessions are loaded by Restore
+func NewPersistentSessionTable(limits Limits, store object_store.ObjectMap) (*PersistentSessionTable, error) {
+	if store == nil {
+		return nil, fmt.Errorf("Nil session store")
+	}
+	if err := limits.Validate(); err != nil {
+		return nil, err
+	}
+	st := &PersistentSessionTable{
+		memSessionTable: newMemSessionTable(DefaultShards, limits),
+		store:           store,
+	}
+	st.memSessionTable.updated = st.persistChanged
+	return st, nil
+}
+
+// NewRedisSessionStore returns the Redis sessions store of the REDIS service from the service registry
+func NewRedisSessionStore() (object_store.ObjectMap, error) {
+	client, err := object_store.NewRedisClient()
+	if err != nil {
+		return nil, fmt.Errorf("Error creating Redis client: %v", err)
+	}
+	return NewRedisSessionStoreWithClient(client), nil
+}
+
+// NewRedisSessionStoreWithClient returns the sessions store of the given Redis client
+func NewRedisSessionStoreWithClient(client object_store.RedisClient) object_store.ObjectMap {
+	return object_store.NewRedisMap(client, sessionsHash, serializeSession, deserializeSession)
+}
+
+// AddSession - adds a new session to the table & stores it, see memSessionTable.AddSession
+func (st *PersistentSessionTable) AddSession(
+	pc *protos.Context, tout time.Duration, notifier aaa.TimeoutNotifier, overwrite ...bool) (aaa.Session, error) {
+
+	s, err := st.memSessionTable.addSession(pc, tout, st.forgetting(notifier), len(overwrite) > 0 && overwrite[0], false)
+	if s == nil {
+		return nil, err
+	}
+	if err == nil {
+		st.persist(s)
+	}
+	return s, err
+}
+
+// RemoveSession - removes the session with the given SID from the table & the store and returns it
+func (st *PersistentSessionTable) RemoveSession(sid string) aaa.Session {
+	s := st.memSessionTable.RemoveSession(sid)
+	if ms, ok := s.(*memSession); ok && ms != nil {
+		atomic.StoreInt32(&ms.removed, 1) // the removed session's later changes aren't stored
+		st.forget(ms.sid)
+		return ms
+	}
+	return nil
+}
+
+// SetTimeout - [Re]sets the session's cleanup timeout & stores the session with its new deadline
+func (st *PersistentSessionTable) SetTimeout(sid string, tout time.Duration, notifier aaa.TimeoutNotifier) bool {
+	if !st.memSessionTable.SetTimeout(sid, tout, st.forgetting(notifier)) {
+		return false
+	}
+	sid = st.key(sid)
+	if s := st.shard(sid).get(sid); s != nil {
+		st.persist(s)
+	}
+	return true
+}
+
+// Restore loads the stored sessions into the table & returns the restored sessions. Sessions are restored with their
+// remaining idle timeouts, sessions expired while AAA server was down time out right away & the notifier ends them
+func (st *PersistentSessionTable) Restore(notifier aaa.TimeoutNotifier) ([]aaa.Session, error) {
+	stored, err := st.store.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("Error loading stored sessions: %v", err)
+	}
+	now := time.Unix(0, st.scheduler.now())
+	var res []aaa.Session
+	for sid, obj := range stored {
+		record, ok := obj.(*persistedSession)
+		pc := &protos.Context{}
+		if ok {
+			err = proto.Unmarshal(record.Ctx, pc)
+		}
+		// the sessions stored before the session ID rules changed are re-stored by their new normalized IDs
+		if !ok || err != nil || st.key(strings.TrimSpace(pc.GetSessionId())) != st.key(sid) {
+			log.Printf("Dropping invalid stored session %s: %v", sid, err)
+			st.forget(sid)
+			continue
+		}
+		tout := aaa.MinimalSessionTimeout
+		if record.Deadline > 0 {
+			if remaining := time.Unix(0, record.Deadline*int64(time.Millisecond)).Sub(now); remaining > tout {
+				tout = remaining
+			}
+		}
+		s, err := st.memSessionTable.addSession(pc, tout, st.forgetting(notifier), false, true)
+		if err != nil {
+			log.Printf("Error restoring stored session %s: %v", sid, err)
+			continue
+		}
+		if s.sid != sid {
+			st.forget(sid)
+			st.persist(s)
+		} else {
+			s.mu.Lock()
+			s.persisted = record.Ctx
+			s.mu.Unlock()
+		}
+		res = append(res, s)
+	}
+	return res, nil
+}
+
+// persist stores the session with its current deadline, store errors are logged, the session stays in the table
+func (st *PersistentSessionTable) persist(s *memSession) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ctx, err := marshalCtx(s.GetCtx()); err != nil {
+		log.Printf("Error storing session %s: %v", s.sid, err)
+	} else {
+		st.write(s, ctx)
+	}
+}
+
+// persistChanged stores the updated session if its context was changed since it was stored, it's called with the
+// session's mutex held
+func (st *PersistentSessionTable) persistChanged(s *memSession) {
+	if atomic.LoadInt32(&s.removed) != 0 {
+		return
+	}
+	ctx, err := marshalCtx(s.GetCtx())
+	if err != nil {
+		log.Printf("Error storing session %s: %v", s.sid, err)
+		return
+	}
+	if !bytes.Equal(ctx, s.persisted) {
+		st.write(s, ctx)
+	}
+}
+
+// write stores the locked session's serialized context with the session's current deadline
+func (st *PersistentSessionTable) write(s *memSession, ctx []byte) {
+	record := &persistedSession{Ctx: ctx}
+	if tout := atomic.LoadInt64(&s.timeout); tout > 0 {
+		record.Deadline = (atomic.LoadInt64(&s.lastActive) + tout) / int64(time.Millisecond)
+	}
+	if err := st.store.Set(s.sid, record); err != nil {
+		log.Printf("Error storing session %s: %v", s.sid, err)
+		return
+	}
+	s.persisted = ctx
+}
+
+// marshalCtx returns the stored serialization of the session context, map fields are serialized in order so
+// unchanged contexts serialize the same
+func marshalCtx(pc *protos.Context) ([]byte, error) {
+	pc = proto.Clone(pc).(*protos.Context)
+	pc.Msk = nil
+	buf := proto.NewBuffer(nil)
+	buf.SetDeterministic(true)
+	err := buf.Marshal(pc)
+	return buf.Bytes(), err
+}
+
+// forget removes the session's record from the store
+func (st *PersistentSessionTable) forget(sid string) {
+	if err := st.store.Delete(sid); err != nil {
+		log.Printf("Error removing stored session %s: %v", sid, err)
+	}
+}
+
+// forgetting returns the notifier removing timed out & evicted sessions from the store before notifying them
+func (st *PersistentSessionTable) forgetting(notifier aaa.TimeoutNotifier) aaa.TimeoutNotifier {
+	return func(s aaa.Session) error {
+		sid := st.key(strings.TrimSpace(s.GetCtx().GetSessionId()))
+		if st.memSessionTable.GetSession(sid) == nil {
+			st.forget(sid) // unless the session was re-added since it timed out
+		}
+		if notifier != nil {
+			return notifier(s)
+		}
+		return nil
+	}
+}
+
+func serializeSession(obj interface{}) (string, error) {
+	record, ok := obj.(*persistedSession)
+	if !ok {
+		return "", fmt.Errorf("Invalid stored session type: %T", obj)
+	}
+	b, err := json.Marshal(record)
+	return string(b), err
+}
+
+func deserializeSession(serialized string) (interface{}, error) {
+	record := &persistedSession{}
+	err := json.Unmarshal([]byte(serialized), record)
+	return record, err
+}
@@ -0,0 +1,198 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package store_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"magma/feg/gateway/services/aaa"
+	"magma/feg/gateway/services/aaa/protos"
+	"magma/feg/gateway/services/aaa/store"
+)
+
+type mockRedisClient struct {
+	sync.Mutex
+	dataMap map[string]string
+	sets    int
+}
+
+func (client *mockRedisClient) HSet(hash string, field string, value string) error {
+	client.Lock()
+	defer client.Unlock()
+	client.dataMap[field] = value
+	client.sets++
+	return nil
+}
+
+func (client *mockRedisClient) HGet(hash string, field string) (string, error) {
+	client.Lock()
+	defer client.Unlock()
+	str, ok := client.dataMap[field]
+	if !ok {
+		return "", fmt.Errorf("Not found: %s", field)
+	}
+	return str, nil
+}
+
+func (client *mockRedisClient) HGetAll(hash string) (map[string]string, error) {
+	client.Lock()
+	defer client.Unlock()
+	res := map[string]string{}
+	for k, v := range client.dataMap {
+		res[k] = v
+	}
+	return res, nil
+}
+
+func (client *mockRedisClient) HDel(hash string, field string) error {
+	client.Lock()
+	defer client.Unlock()
+	delete(client.dataMap, field)
+	return nil
+}
+
+func (client *mockRedisClient) len() int {
+	client.Lock()
+	defer client.Unlock()
+	return len(client.dataMap)
+}
+
+func (client *mockRedisClient) setCount() int {
+	client.Lock()
+	defer client.Unlock()
+	return client.sets
+}
+
+func TestPersistentSessionTable(t *testing.T) {
+	client := &mockRedisClient{dataMap: map[string]string{}}
+	st, err := store.NewPersistentSessionTable(store.Limits{}, store.NewRedisSessionStoreWithClient(client))
+	require.NoError(t, err)
+
+	_, err = st.AddSession(
+		&protos.Context{SessionId: "sid1", Imsi: "001010000000001", Apn: "apn1", Msk: []byte("secret")}, time.Hour, nil)
+	require.NoError(t, err)
+	_, err = st.AddSession(&protos.Context{SessionId: "sid2", Imsi: "001010000000002"}, time.Hour, nil)
+	require.NoError(t, err)
+	_, err = st.AddSession(&protos.Context{SessionId: "sid3", Imsi: "001010000000003"}, time.Hour, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 3, client.len())
+	assert.NotNil(t, st.RemoveSession("sid3"))
+	assert.Nil(t, st.RemoveSession("sid3"))
+	assert.Equal(t, 2, client.len())
+
+	// AAA server restart
+	var timedOut sync.WaitGroup
+	timedOut.Add(1)
+	notifier := func(s aaa.Session) error {
+		timedOut.Done()
+		return nil
+	}
+	restarted, err := store.NewPersistentSessionTable(store.Limits{}, store.NewRedisSessionStoreWithClient(client))
+	require.NoError(t, err)
+	restored, err := restarted.Restore(notifier)
+	require.NoError(t, err)
+	assert.Len(t, restored, 2)
+	s := restarted.GetSession("sid1")
+	require.NotNil(t, s)
+	assert.Equal(t, "001010000000001", s.GetCtx().GetImsi())
+	assert.Equal(t, "apn1", s.GetCtx().GetApn())
+	assert.Empty(t, s.GetCtx().GetMsk())
+	assert.Equal(t, "sid2", restarted.FindSession("001010000000002"))
+
+	// the restored session times out with the new timeout & is removed from the store
+	assert.True(t, restarted.SetTimeout("sid2", aaa.MinimalSessionTimeout, notifier))
+	timedOut.Wait()
+	assert.Nil(t, restarted.GetSession("sid2"))
+	assert.Equal(t, 1, client.len())
+
+	// invalid records are dropped
+	client.dataMap["sid4"] = "invalid"
+	restarted, err = store.NewPersistentSessionTable(store.Limits{}, store.NewRedisSessionStoreWithClient(client))
+	require.NoError(t, err)
+	restored, err = restarted.Restore(nil)
+	require.NoError(t, err)
+	assert.Len(t, restored, 1)
+}
+
+func TestPersistentSessionChanges(t *testing.T) {
+	client := &mockRedisClient{dataMap: map[string]string{}}
+	st, err := store.NewPersistentSessionTable(store.Limits{}, store.NewRedisSessionStoreWithClient(client))
+	require.NoError(t, err)
+	s, err := st.AddSession(&protos.Context{SessionId: "sid1", Imsi: "001010000000001"}, time.Hour, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, client.setCount())
+
+	update := func(st aaa.SessionTable, mutator aaa.CtxMutator) {
+		_, _, err := st.UpdateCtx("sid1", mutator)
+		assert.NoError(t, err)
+	}
+	// unchanged sessions aren't stored again
+	update(st, func(*protos.Context) error { return nil })
+	assert.Equal(t, 1, client.setCount())
+
+	// updated contexts are stored, the snapshots the session had aren't changed
+	old := s.GetCtx()
+	update(st, func(c *protos.Context) error {
+		c.Apn, c.Msk = "apn1", []byte("secret")
+		return nil
+	})
+	assert.Equal(t, 2, client.setCount())
+	assert.Empty(t, old.GetApn())
+	update(st, func(c *protos.Context) error {
+		c.MacAddr = "AA-BB-CC-DD-EE-FF"
+		return c.SetAttribute("tier", "gold")
+	})
+	assert.Equal(t, 3, client.setCount())
+	update(st, func(c *protos.Context) error {
+		c.Msk = []byte("new secret") // MSKs aren't stored
+		return nil
+	})
+	assert.Equal(t, 3, client.setCount())
+	// failed updates change nothing
+	_, _, err = st.UpdateCtx("sid1", func(c *protos.Context) error {
+		c.Apn = "apn2"
+		return fmt.Errorf("failed")
+	})
+	assert.Error(t, err)
+	assert.Equal(t, "apn1", s.GetCtx().GetApn())
+	assert.Equal(t, 3, client.setCount())
+
+	// AAA server restart
+	restarted, err := store.NewPersistentSessionTable(store.Limits{}, store.NewRedisSessionStoreWithClient(client))
+	require.NoError(t, err)
+	_, err = restarted.Restore(nil)
+	require.NoError(t, err)
+	restoredSession := restarted.GetSession("sid1")
+	require.NotNil(t, restoredSession)
+	assert.Equal(t, "apn1", restoredSession.GetCtx().GetApn())
+	assert.Equal(t, "AA-BB-CC-DD-EE-FF", restoredSession.GetCtx().GetMacAddr())
+	tier, ok := restoredSession.GetCtx().GetAttribute("tier")
+	assert.True(t, ok)
+	assert.Equal(t, "gold", tier)
+	assert.Empty(t, restoredSession.GetCtx().GetMsk())
+	// restored sessions aren't stored again until they change
+	update(restarted, func(*protos.Context) error { return nil })
+	assert.Equal(t, 3, client.setCount())
+
+	// removed sessions can't be updated
+	assert.NotNil(t, restarted.RemoveSession("sid1"))
+	_, _, err = restarted.UpdateCtx("sid1", func(c *protos.Context) error {
+		c.Apn = "apn2"
+		return nil
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 3, client.setCount())
+	assert.Equal(t, 0, client.len())
+}
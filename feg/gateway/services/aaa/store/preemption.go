@@ -0,0 +1,115 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package store
+
+import (
+	"fmt"
+	"log"
+	"sync/atomic"
+
+	"magma/feg/gateway/services/aaa"
+	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/aaa/panics"
+)
+
+// PreemptionPolicy defines how a full session table handles new sessions
+type PreemptionPolicy string
+
+const (
+	// PreemptReject - new sessions are rejected
+	PreemptReject PreemptionPolicy = "reject"
+	// PreemptOldestIdle - the session with the oldest activity is evicted
+	PreemptOldestIdle PreemptionPolicy = "evict_oldest_idle"
+	// PreemptLowestPriorityAPN - the oldest idle session of the lowest priority APN is evicted, if the new session's
+	// APN priority is lower than the priorities of all existing sessions, the new session is rejected
+	PreemptLowestPriorityAPN PreemptionPolicy = "evict_lowest_priority_apn"
+)
+
+// Limits - session table capacity limits
+type Limits struct {
+	// MaxSessions - maximum number of sessions in the table, 0 - unlimited
+	MaxSessions int
+	Policy      PreemptionPolicy
+	// APNPriorities - APN priorities for PreemptLowestPriorityAPN policy, higher value - higher priority,
+	// APNs not in the map have priority 0
+	APNPriorities map[string]int
+}
+
+// Validate validates the limits & defaults empty policy to PreemptReject
+func (l *Limits) Validate() error {
+	if l.MaxSessions < 0 {
+		return fmt.Errorf("Invalid max sessions: %d", l.MaxSessions)
+	}
+	switch l.Policy {
+	case "":
+		l.Policy = PreemptReject
+	case PreemptReject, PreemptOldestIdle, PreemptLowestPriorityAPN:
+	default:
+		return fmt.Errorf("Unknown session preemption policy: '%s'", l.Policy)
+	}
+	return nil
+}
+
+// preemptUnsafe selects a session to evict according to the table's policy & removes it from the table maps,
+// it returns nil if no session should be evicted & the new session must be rejected.
+// preemptUnsafe must be called with the table lock held
+func (st *memSessionTable) preemptUnsafe(newApn string) *memSession {
+	var victim *memSession
+	switch st.limits.Policy {
+	case PreemptOldestIdle:
+		for _, sh := range st.shards {
+			for _, s := range sh.sm {
+				if victim == nil || atomic.LoadInt64(&s.lastActive) < atomic.LoadInt64(&victim.lastActive) {
+					victim = s
+				}
+			}
+		}
+	case PreemptLowestPriorityAPN:
+		var victimPriority int
+		for _, sh := range st.shards {
+			for _, s := range sh.sm {
+				priority := st.limits.APNPriorities[s.GetCtx().GetApn()]
+				if victim == nil || priority < victimPriority ||
+					(priority == victimPriority && atomic.LoadInt64(&s.lastActive) < atomic.LoadInt64(&victim.lastActive)) {
+					victim, victimPriority = s, priority
+				}
+			}
+		}
+		if victim != nil && victimPriority > st.limits.APNPriorities[newApn] {
+			victim = nil
+		}
+	}
+	if victim != nil {
+		st.deleteUnsafe(victim)
+	}
+	return victim
+}
+
+// evict ends the session already removed from the table, the session's timeout notifier is called
+// asynchronously to notify session manager & Radius server of the session termination
+func (st *memSessionTable) evict(s *memSession) {
+	var notifier aaa.TimeoutNotifier
+	if ctx := (*cleanupTimerCtx)(atomic.LoadPointer(&s.cleanupTimerCtx)); ctx != nil {
+		notifier = ctx.notifyRoutine
+	}
+	s.StopTimeout()
+	apn := s.GetCtx().GetApn()
+	metrics.Sessions.WithLabelValues(apn).Dec()
+	metrics.SessionStop.WithLabelValues(apn, s.imsi, s.sid).SetToCurrentTime()
+	metrics.SessionEvictions.WithLabelValues(apn, string(st.limits.Policy)).Inc()
+	go func() {
+		defer panics.Recover("session_eviction")
+		var notifyResult error
+		if notifier != nil {
+			notifyResult = notifier(s)
+		}
+		log.Printf("Evicted session '%s' for IMSI: %s; APN: %s; policy: %s; notify result: %v",
+			s.sid, s.imsi, apn, st.limits.Policy, notifyResult)
+	}()
+}
@@ -0,0 +1,96 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package store_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"magma/feg/gateway/services/aaa"
+	"magma/feg/gateway/services/aaa/protos"
+	"magma/feg/gateway/services/aaa/store"
+)
+
+func addSession(t *testing.T, st aaa.SessionTable, sid, imsi, apn string, notifier aaa.TimeoutNotifier) error {
+	_, err := st.AddSession(&protos.Context{SessionId: sid, Imsi: imsi, Apn: apn}, time.Minute, notifier)
+	return err
+}
+
+func TestPreemptReject(t *testing.T) {
+	st, err := store.NewMemorySessionTableWithLimits(store.Limits{MaxSessions: 2})
+	assert.NoError(t, err)
+	assert.NoError(t, addSession(t, st, "sid1", "imsi1", "apn", nil))
+	assert.NoError(t, addSession(t, st, "sid2", "imsi2", "apn", nil))
+	assert.Error(t, addSession(t, st, "sid3", "imsi3", "apn", nil))
+	assert.Nil(t, st.GetSession("sid3"))
+
+	// Overwriting an existing session doesn't require additional capacity
+	_, err = st.AddSession(&protos.Context{SessionId: "sid2", Imsi: "imsi2"}, time.Minute, nil, true)
+	assert.NoError(t, err)
+
+	assert.NotNil(t, st.RemoveSession("sid1"))
+	assert.NoError(t, addSession(t, st, "sid3", "imsi3", "apn", nil))
+}
+
+func TestPreemptOldestIdle(t *testing.T) {
+	st, err := store.NewMemorySessionTableWithLimits(store.Limits{MaxSessions: 2, Policy: store.PreemptOldestIdle})
+	assert.NoError(t, err)
+
+	evicted := make(chan string, 1)
+	notifier := func(s aaa.Session) error {
+		evicted <- s.GetCtx().GetSessionId()
+		return nil
+	}
+	assert.NoError(t, addSession(t, st, "sid1", "imsi1", "apn", notifier))
+	time.Sleep(time.Millisecond)
+	assert.NoError(t, addSession(t, st, "sid2", "imsi2", "apn", notifier))
+	time.Sleep(time.Millisecond)
+	assert.True(t, st.SetTimeout("sid1", time.Minute, notifier)) // sid1 activity
+
+	assert.NoError(t, addSession(t, st, "sid3", "imsi3", "apn", notifier))
+	select {
+	case sid := <-evicted:
+		assert.Equal(t, "sid2", sid)
+	case <-time.After(time.Second):
+		assert.Fail(t, "evicted session notifier was not called")
+	}
+	assert.Nil(t, st.GetSession("sid2"))
+	assert.Empty(t, st.FindSession("imsi2"))
+	assert.NotNil(t, st.GetSession("sid1"))
+	assert.NotNil(t, st.GetSession("sid3"))
+}
+
+func TestPreemptLowestPriorityAPN(t *testing.T) {
+	st, err := store.NewMemorySessionTableWithLimits(store.Limits{
+		MaxSessions:   2,
+		Policy:        store.PreemptLowestPriorityAPN,
+		APNPriorities: map[string]int{"ims": 10, "internet": 5},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, addSession(t, st, "sid1", "imsi1", "internet", nil))
+	assert.NoError(t, addSession(t, st, "sid2", "imsi2", "ims", nil))
+
+	// Lower priority than all existing sessions - rejected
+	assert.Error(t, addSession(t, st, "sid3", "imsi3", "guest", nil))
+
+	// Higher priority - the lowest priority session is evicted
+	assert.NoError(t, addSession(t, st, "sid4", "imsi4", "ims", nil))
+	assert.Nil(t, st.GetSession("sid1"))
+	assert.NotNil(t, st.GetSession("sid2"))
+	assert.NotNil(t, st.GetSession("sid4"))
+}
+
+func TestLimitsValidation(t *testing.T) {
+	_, err := store.NewMemorySessionTableWithLimits(store.Limits{MaxSessions: -1})
+	assert.Error(t, err)
+	_, err = store.NewMemorySessionTableWithLimits(store.Limits{Policy: "evict_random"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,176 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package store
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"magma/feg/gateway/services/aaa"
+	"magma/feg/gateway/services/aaa/panics"
+)
+
+// Clock - time source & timers of the session timeouts' scheduler, tests inject fake clocks for deterministic timeouts
+type Clock interface {
+	Now() time.Time
+	// AfterFunc calls f in its own goroutine after the duration elapses
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer - a Clock's timer
+type Timer interface {
+	Reset(d time.Duration) bool
+	Stop() bool
+}
+
+// systemClock - the system's time & runtime timers
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) AfterFunc(d time.Duration, f func()) Timer {
+	return time.AfterFunc(d, f)
+}
+
+// SchedulerConfig - configuration of the session timeouts' scheduler
+type SchedulerConfig struct {
+	// Jitter - maximum random extension of the sessions' timeouts as a fraction of the timeout (0-1), so the
+	// sessions created in a burst, e.g. after an AP reboot, don't all time out at once. 0 - no jitter
+	Jitter float64
+	// BatchInterval - the timed out sessions are expired in batches at most once per interval, 0 - expired as they
+	// time out
+	BatchInterval time.Duration
+	// MaxConcurrentExpiries - maximum number of timed out sessions cleaned up & notified concurrently, 0 - unlimited
+	MaxConcurrentExpiries int
+	// Clock - nil - the system clock
+	Clock Clock
+}
+
+// Scheduler - schedules & expires the session timeouts of a session table
+type Scheduler struct {
+	clock  Clock
+	jitter float64
+	batch  int64         // batch interval, ns
+	sem    chan struct{} // concurrent expiries' semaphore, nil - unlimited
+
+	rndMu sync.Mutex
+	rnd   *rand.Rand
+}
+
+// scheduled is implemented by session tables whose session timeouts can be scheduled by a Scheduler
+type scheduled interface {
+	setScheduler(sched *Scheduler)
+}
+
+// NewScheduler returns a new session timeouts' scheduler of the given configuration
+func NewScheduler(cfg SchedulerConfig) (*Scheduler, error) {
+	if cfg.Jitter < 0 || cfg.Jitter > 1 {
+		return nil, fmt.Errorf("Invalid session timeout jitter: %v, must be 0-1", cfg.Jitter)
+	}
+	if cfg.BatchInterval < 0 {
+		return nil, fmt.Errorf("Invalid session expiry batch interval: %v", cfg.BatchInterval)
+	}
+	if cfg.MaxConcurrentExpiries < 0 {
+		return nil, fmt.Errorf("Invalid maximum concurrent session expiries: %d", cfg.MaxConcurrentExpiries)
+	}
+	sched := newSystemScheduler()
+	sched.jitter = cfg.Jitter
+	sched.batch = int64(cfg.BatchInterval)
+	if cfg.MaxConcurrentExpiries > 0 {
+		sched.sem = make(chan struct{}, cfg.MaxConcurrentExpiries)
+	}
+	if cfg.Clock != nil {
+		sched.clock = cfg.Clock
+	}
+	return sched, nil
+}
+
+// newSystemScheduler returns the scheduler of the system clock without jitter, batching & concurrency limits
+func newSystemScheduler() *Scheduler {
+	return &Scheduler{clock: systemClock{}, rnd: rand.New(rand.NewSource(time.Now().UnixNano()))}
+}
+
+// SetScheduler sets the scheduler of the session table's timeouts, it must be set before the table's first session
+// is added
+func SetScheduler(st aaa.SessionTable, sched *Scheduler) error {
+	s, ok := st.(scheduled)
+	if !ok {
+		return fmt.Errorf("Session table %T doesn't support timeout schedulers", st)
+	}
+	if sched == nil {
+		return fmt.Errorf("Nil session timeout scheduler")
+	}
+	s.setScheduler(sched)
+	return nil
+}
+
+// now returns the scheduler clock's time, UnixNano
+func (sched *Scheduler) now() int64 {
+	return sched.clock.Now().UnixNano()
+}
+
+// jittered returns the timeout extended by a random jitter of up to the scheduler's fraction of it, timeouts are
+// never shortened, so sessions don't time out before their NAS's accounting is due
+func (sched *Scheduler) jittered(tout time.Duration) time.Duration {
+	if sched.jitter <= 0 || tout <= 0 {
+		return tout
+	}
+	sched.rndMu.Lock()
+	f := sched.rnd.Float64()
+	sched.rndMu.Unlock()
+	return tout + time.Duration(f*sched.jitter*float64(tout))
+}
+
+// due returns the time the wheel's timer is armed for to expire the deadline, the deadline rounded up to the batch
+// interval, so the deadlines of an interval are expired together
+func (sched *Scheduler) due(deadline int64) int64 {
+	if sched.batch <= 0 {
+		return deadline
+	}
+	if rem := deadline % sched.batch; rem != 0 {
+		deadline += sched.batch - rem
+	}
+	return deadline
+}
+
+// dispatch cleans up the batch of timed out sessions concurrently, so a slow notifier doesn't delay others. The
+// batch's sessions are removed right away, while their notifications are limited to the scheduler's concurrency, so
+// the expiries of a burst don't flood the notifiers' dependencies, e.g. sessiond & the NASes' Disconnects
+func (sched *Scheduler) dispatch(expired []*cleanupTimerCtx) {
+	if sched.sem == nil {
+		for _, ctx := range expired {
+			go cleanupTimer(ctx)
+		}
+		return
+	}
+	if len(expired) == 0 {
+		return
+	}
+	go func() {
+		defer panics.Recover("session_timeout")
+		removed := expired[:0]
+		for _, ctx := range expired {
+			if removeTimedOut(ctx) {
+				removed = append(removed, ctx)
+			}
+		}
+		for _, ctx := range removed {
+			sched.sem <- struct{}{}
+			go func(ctx *cleanupTimerCtx) {
+				defer func() { <-sched.sem }()
+				defer panics.Recover("session_timeout")
+				notifyTimedOut(ctx)
+			}(ctx)
+		}
+	}()
+}
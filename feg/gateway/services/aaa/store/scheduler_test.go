@@ -0,0 +1,202 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package store_test
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"magma/feg/gateway/services/aaa"
+	"magma/feg/gateway/services/aaa/protos"
+	"magma/feg/gateway/services/aaa/store"
+)
+
+// fakeClock - deterministic clock, its timers fire only when the clock is advanced
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+type fakeTimer struct {
+	clock  *fakeClock
+	due    time.Time
+	f      func()
+	active bool
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Unix(1600000000, 0)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) AfterFunc(d time.Duration, f func()) store.Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &fakeTimer{clock: c, due: c.now.Add(d), f: f, active: true}
+	c.timers = append(c.timers, t)
+	return t
+}
+
+// advance moves the clock forward & fires the due timers, including the timers re-armed by the fired ones
+func (c *fakeClock) advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	c.mu.Unlock()
+	for {
+		var due []func()
+		c.mu.Lock()
+		for _, t := range c.timers {
+			if t.active && !t.due.After(c.now) {
+				t.active = false
+				due = append(due, t.f)
+			}
+		}
+		c.mu.Unlock()
+		if len(due) == 0 {
+			return
+		}
+		for _, f := range due {
+			f()
+		}
+	}
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	active := t.active
+	t.due, t.active = t.clock.now.Add(d), true
+	return active
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	active := t.active
+	t.active = false
+	return active
+}
+
+// waitFor waits up to a second for the condition, the timed out sessions are cleaned up asynchronously
+func waitFor(t *testing.T, cond func() bool, msgAndArgs ...interface{}) {
+	for deadline := time.Now().Add(time.Second); !cond() && time.Now().Before(deadline); {
+		time.Sleep(time.Millisecond)
+	}
+	assert.True(t, cond(), msgAndArgs...)
+}
+
+type scheduledTable interface {
+	aaa.SessionTable
+	aaa.SessionLister
+	aaa.SessionCounter
+}
+
+func newScheduledTable(t *testing.T, cfg store.SchedulerConfig) scheduledTable {
+	sched, err := store.NewScheduler(cfg)
+	require.NoError(t, err)
+	st := store.NewMemorySessionTable()
+	require.NoError(t, store.SetScheduler(st, sched))
+	return st.(scheduledTable)
+}
+
+func TestSchedulerConfig(t *testing.T) {
+	_, err := store.NewScheduler(store.SchedulerConfig{Jitter: 1.5})
+	assert.Error(t, err)
+	_, err = store.NewScheduler(store.SchedulerConfig{BatchInterval: -time.Second})
+	assert.Error(t, err)
+	_, err = store.NewScheduler(store.SchedulerConfig{MaxConcurrentExpiries: -1})
+	assert.Error(t, err)
+	assert.Error(t, store.SetScheduler(store.NewMemorySessionTable(), nil))
+}
+
+func TestSchedulerJitter(t *testing.T) {
+	clock := newFakeClock()
+	st := newScheduledTable(t, store.SchedulerConfig{Jitter: 0.5, Clock: clock})
+	var expired int32
+	notifier := func(aaa.Session) error {
+		atomic.AddInt32(&expired, 1)
+		return nil
+	}
+	for i := 0; i < 100; i++ {
+		_, err := st.AddSession(&protos.Context{SessionId: fmt.Sprintf("sid%d", i)}, time.Minute, notifier)
+		require.NoError(t, err)
+	}
+
+	timeouts := map[time.Duration]struct{}{}
+	for _, s := range st.ListSessions() {
+		assert.True(t, s.Timeout >= time.Minute && s.Timeout <= 90*time.Second, "timeout: %v", s.Timeout)
+		timeouts[s.Timeout] = struct{}{}
+	}
+	assert.True(t, len(timeouts) > 1, "the burst's timeouts are spread")
+
+	// the timeouts are never shortened & are all over by the maximum jitter
+	clock.advance(time.Minute - time.Millisecond)
+	assert.Equal(t, 100, st.Count())
+	clock.advance(30 * time.Second)
+	waitFor(t, func() bool { return st.Count() == 0 })
+	waitFor(t, func() bool { return atomic.LoadInt32(&expired) == 100 })
+}
+
+func TestSchedulerBatches(t *testing.T) {
+	clock := newFakeClock()
+	st := newScheduledTable(t, store.SchedulerConfig{BatchInterval: 10 * time.Second, Clock: clock})
+	_, err := st.AddSession(&protos.Context{SessionId: "sid1"}, 61*time.Second, nil)
+	require.NoError(t, err)
+	_, err = st.AddSession(&protos.Context{SessionId: "sid2"}, 62*time.Second, nil)
+	require.NoError(t, err)
+
+	// the clock starts at a multiple of the interval, so both deadlines are due at 70s
+	clock.advance(65 * time.Second)
+	assert.Equal(t, 2, st.Count())
+	clock.advance(5 * time.Second)
+	waitFor(t, func() bool { return st.Count() == 0 })
+}
+
+func TestSchedulerConcurrentExpiries(t *testing.T) {
+	clock := newFakeClock()
+	st := newScheduledTable(t, store.SchedulerConfig{MaxConcurrentExpiries: 2, Clock: clock})
+	var running, maxRunning, notified int32
+	release := make(chan struct{})
+	notifier := func(aaa.Session) error {
+		n := atomic.AddInt32(&running, 1)
+		for m := atomic.LoadInt32(&maxRunning); n > m && !atomic.CompareAndSwapInt32(&maxRunning, m, n); {
+			m = atomic.LoadInt32(&maxRunning)
+		}
+		<-release
+		atomic.AddInt32(&running, -1)
+		atomic.AddInt32(&notified, 1)
+		return nil
+	}
+	for i := 0; i < 10; i++ {
+		_, err := st.AddSession(&protos.Context{SessionId: fmt.Sprintf("sid%d", i)}, time.Minute, notifier)
+		require.NoError(t, err)
+	}
+
+	clock.advance(time.Minute)
+	waitFor(t, func() bool { return st.Count() == 0 },
+		"the timed out sessions are removed before they're notified")
+	waitFor(t, func() bool { return atomic.LoadInt32(&running) == 2 })
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&notified))
+	close(release)
+	waitFor(t, func() bool { return atomic.LoadInt32(&notified) == 10 })
+	assert.Equal(t, int32(2), atomic.LoadInt32(&maxRunning))
+}
@@ -0,0 +1,168 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package store
+
+import (
+	"container/heap"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultShards - default number of the memory session table's shards
+const DefaultShards = 64
+
+// sessionShard - a hash bucket of the table's sessions by SID. The shard's map is only modified with both the table
+// lock & the shard's lock held, so it can be read holding either one of them: Interim-Updates' session lookups &
+// timeout resets only lock the session's shard, adds, removes & the table wide operations hold the table lock
+type sessionShard struct {
+	rwl   sync.RWMutex
+	sm    map[string]*memSession
+	wheel timeoutWheel
+}
+
+// get returns the shard's session of the SID, nil if not found
+func (sh *sessionShard) get(sid string) *memSession {
+	sh.rwl.RLock()
+	s := sh.sm[sid]
+	sh.rwl.RUnlock()
+	return s
+}
+
+// shard returns the shard of the SID, FNV-1a hash of the SID selects the shard
+func (st *memSessionTable) shard(sid string) *sessionShard {
+	const (
+		offset32 = 2166136261
+		prime32  = 16777619
+	)
+	h := uint32(offset32)
+	for i := 0; i < len(sid); i++ {
+		h ^= uint32(sid[i])
+		h *= prime32
+	}
+	return st.shards[h%uint32(len(st.shards))]
+}
+
+// timeoutWheel - the session timeouts of a shard, a queue of the sessions' deadlines served by a single runtime timer
+// armed for the earliest one. Every session has at most one queued deadline: extended timeouts aren't requeued when
+// they are reset, the session is requeued with its current deadline when the queued one expires instead, so
+// the frequent timeout resets of Interim-Updates are just a few atomic stores
+type timeoutWheel struct {
+	mu    sync.Mutex
+	sched *Scheduler
+	queue timeoutQueue
+	timer Timer
+	armed int64 // time the timer is armed for, UnixNano, 0 if not armed
+}
+
+// schedule queues the session's deadline unless an earlier deadline of the session is already queued
+func (w *timeoutWheel) schedule(s *memSession, deadline int64) {
+	w.mu.Lock()
+	if s.wheelIdx >= 0 {
+		if deadline < s.queued {
+			s.queued = deadline
+			heap.Fix(&w.queue, s.wheelIdx)
+		}
+	} else {
+		s.queued = deadline
+		heap.Push(&w.queue, s)
+	}
+	if due := w.sched.due(deadline); w.armed == 0 || due < w.armed {
+		w.arm(due)
+	}
+	w.mu.Unlock()
+}
+
+// cancel removes the session's queued deadline, if any
+func (w *timeoutWheel) cancel(s *memSession) {
+	w.mu.Lock()
+	if s.wheelIdx >= 0 {
+		heap.Remove(&w.queue, s.wheelIdx)
+	}
+	w.mu.Unlock()
+}
+
+// compact rebuilds the wheel's queue, its slice keeps the capacity of the peak number of queued deadlines
+func (w *timeoutWheel) compact() {
+	w.mu.Lock()
+	w.queue = append(make(timeoutQueue, 0, len(w.queue)), w.queue...)
+	w.mu.Unlock()
+}
+
+// arm [re]arms the timer to fire at the due time, arm must be called with the wheel lock held
+func (w *timeoutWheel) arm(due int64) {
+	w.armed = due
+	d := time.Duration(due - w.sched.now())
+	if w.timer == nil {
+		w.timer = w.sched.clock.AfterFunc(d, w.expire)
+	} else {
+		w.timer.Reset(d)
+	}
+}
+
+// expire dequeues the expired deadlines, the sessions whose timeouts were extended are requeued with their current
+// deadlines & the sessions which timed out are dispatched for cleanup as a batch
+func (w *timeoutWheel) expire() {
+	var expired []*cleanupTimerCtx
+	now := w.sched.now()
+	w.mu.Lock()
+	w.armed = 0
+	for len(w.queue) > 0 && w.queue[0].queued <= now {
+		s := w.queue[0]
+		ctx := (*cleanupTimerCtx)(atomic.LoadPointer(&s.cleanupTimerCtx))
+		if ctx == nil {
+			heap.Pop(&w.queue)
+			continue
+		}
+		if deadline := atomic.LoadInt64(&s.lastActive) + atomic.LoadInt64(&s.timeout); deadline > now {
+			s.queued = deadline
+			heap.Fix(&w.queue, 0)
+			continue
+		}
+		heap.Pop(&w.queue)
+		expired = append(expired, ctx)
+	}
+	if len(w.queue) > 0 {
+		w.arm(w.sched.due(w.queue[0].queued))
+	}
+	w.mu.Unlock()
+	w.sched.dispatch(expired)
+}
+
+// timeoutQueue - min heap of the sessions by their queued deadlines
+type timeoutQueue []*memSession
+
+func (q timeoutQueue) Len() int {
+	return len(q)
+}
+
+func (q timeoutQueue) Less(i, j int) bool {
+	return q[i].queued < q[j].queued
+}
+
+func (q timeoutQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].wheelIdx, q[j].wheelIdx = i, j
+}
+
+func (q *timeoutQueue) Push(x interface{}) {
+	s := x.(*memSession)
+	s.wheelIdx = len(*q)
+	*q = append(*q, s)
+}
+
+func (q *timeoutQueue) Pop() interface{} {
+	old := *q
+	n := len(old) - 1
+	s := old[n]
+	old[n] = nil
+	s.wheelIdx = -1
+	*q = old[:n]
+	return s
+}
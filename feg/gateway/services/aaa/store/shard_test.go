@@ -0,0 +1,92 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package store_test
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"magma/feg/gateway/services/aaa"
+	"magma/feg/gateway/services/aaa/protos"
+	"magma/feg/gateway/services/aaa/store"
+)
+
+func TestShardedSessionTable(t *testing.T) {
+	_, err := store.NewShardedMemorySessionTable(0, store.Limits{})
+	assert.Error(t, err)
+
+	st, err := store.NewShardedMemorySessionTable(4, store.Limits{MaxSessions: 100})
+	assert.NoError(t, err)
+	for i := 0; i < 100; i++ {
+		_, err = st.AddSession(&protos.Context{SessionId: fmt.Sprintf("sid%d", i), Imsi: fmt.Sprintf("00101%010d", i)},
+			time.Minute, nil)
+		assert.NoError(t, err)
+	}
+	_, err = st.AddSession(&protos.Context{SessionId: "sid100", Imsi: "001010000000100"}, time.Minute, nil)
+	assert.Error(t, err, "the limit is enforced across the shards")
+
+	assert.Len(t, st.(aaa.SessionLister).ListSessions(), 100)
+	for i := 0; i < 100; i++ {
+		sid := fmt.Sprintf("sid%d", i)
+		assert.NotNil(t, st.GetSession(sid))
+		assert.Equal(t, sid, st.FindSession(fmt.Sprintf("00101%010d", i)))
+		assert.True(t, st.SetTimeout(sid, time.Hour, nil))
+	}
+	for i := 0; i < 50; i++ {
+		assert.NotNil(t, st.RemoveSession(fmt.Sprintf("sid%d", i)))
+	}
+	st.(interface{ Compact() }).Compact()
+	assert.Len(t, st.(aaa.SessionLister).ListSessions(), 50)
+	_, err = st.AddSession(&protos.Context{SessionId: "sid100", Imsi: "001010000000100"}, time.Minute, nil)
+	assert.NoError(t, err)
+}
+
+func TestTimeoutWheel(t *testing.T) {
+	st, err := store.NewShardedMemorySessionTable(2, store.Limits{})
+	assert.NoError(t, err)
+	var timedOut int32
+	notifier := func(aaa.Session) error {
+		atomic.AddInt32(&timedOut, 1)
+		return nil
+	}
+
+	// extended timeouts are requeued, the session doesn't time out by its first deadline
+	_, err = st.AddSession(&protos.Context{SessionId: "extended"}, 50*time.Millisecond, notifier)
+	assert.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		time.Sleep(20 * time.Millisecond)
+		assert.True(t, st.SetTimeout("extended", 50*time.Millisecond, notifier))
+	}
+	assert.NotNil(t, st.GetSession("extended"))
+	assert.Equal(t, int32(0), atomic.LoadInt32(&timedOut))
+	time.Sleep(300 * time.Millisecond)
+	assert.Nil(t, st.GetSession("extended"))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&timedOut))
+
+	// shortened timeouts fire at the new deadline
+	_, err = st.AddSession(&protos.Context{SessionId: "shortened"}, time.Hour, notifier)
+	assert.NoError(t, err)
+	assert.True(t, st.SetTimeout("shortened", 10*time.Millisecond, notifier))
+	time.Sleep(200 * time.Millisecond)
+	assert.Nil(t, st.GetSession("shortened"))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&timedOut))
+
+	// stopped timeouts never fire
+	s, err := st.AddSession(&protos.Context{SessionId: "stopped"}, 10*time.Millisecond, notifier)
+	assert.NoError(t, err)
+	assert.True(t, s.StopTimeout())
+	assert.False(t, s.StopTimeout())
+	time.Sleep(100 * time.Millisecond)
+	assert.NotNil(t, st.GetSession("stopped"))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&timedOut))
+}
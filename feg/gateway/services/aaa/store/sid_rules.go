@@ -0,0 +1,96 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Session ID matching rules, NASes formatting Acct-Session-Id differently in their authentication & accounting
+// phases (case, prefixes) are matched to the same session
+const (
+	// SIDExact - session IDs are matched as is
+	SIDExact = "exact"
+	// SIDCaseInsensitive - session IDs are matched regardless of their case
+	SIDCaseInsensitive = "case_insensitive"
+	// SIDRegexExtract - session IDs are matched by the part extracted by the rule's pattern, the pattern's first
+	// capture group or its whole match if it has no groups, session IDs not matching the pattern are kept as is
+	SIDRegexExtract = "regex_extract"
+)
+
+// SIDRule - session ID normalization rule
+type SIDRule struct {
+	Match   string `json:"match"`
+	Pattern string `json:"pattern,omitempty"` // SIDRegexExtract's regular expression
+}
+
+// SIDNormalizer returns the normalized session ID the session table's lookups use
+type SIDNormalizer func(sid string) string
+
+// ParseSIDRules parses the JSON list of session ID rules, e.g.
+// [{"match":"regex_extract","pattern":"^(?:acct-)?(.+)$"},{"match":"case_insensitive"}]
+// & returns their normalizer
+func ParseSIDRules(rules string) (SIDNormalizer, error) {
+	var parsed []SIDRule
+	if err := json.Unmarshal([]byte(rules), &parsed); err != nil {
+		return nil, fmt.Errorf("Invalid session ID rules: %v", err)
+	}
+	return NewSIDNormalizer(parsed)
+}
+
+// NewSIDNormalizer returns the normalizer applying the rules in order, the rules must be idempotent, i.e. normalized
+// session IDs must normalize to themselves, since the table's lookups may be given the normalized session IDs
+func NewSIDNormalizer(rules []SIDRule) (SIDNormalizer, error) {
+	var steps []func(string) string
+	for i, rule := range rules {
+		switch rule.Match {
+		case SIDExact:
+		case SIDCaseInsensitive:
+			steps = append(steps, strings.ToLower)
+		case SIDRegexExtract:
+			re, err := regexp.Compile(rule.Pattern)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid session ID rule %d pattern '%s': %v", i, rule.Pattern, err)
+			}
+			steps = append(steps, extractor(re))
+		default:
+			return nil, fmt.Errorf("Unknown session ID rule %d match: '%s'", i, rule.Match)
+		}
+	}
+	if len(steps) == 0 {
+		return nil, nil
+	}
+	return func(sid string) string {
+		for _, step := range steps {
+			sid = step(sid)
+		}
+		return sid
+	}, nil
+}
+
+// extractor returns the session ID's part extracted by the pattern
+func extractor(re *regexp.Regexp) func(string) string {
+	return func(sid string) string {
+		m := re.FindStringSubmatch(sid)
+		if len(m) == 0 {
+			return sid
+		}
+		extracted := m[0]
+		if len(m) > 1 {
+			extracted = m[1]
+		}
+		if len(extracted) == 0 {
+			return sid // an empty session ID never matches
+		}
+		return extracted
+	}
+}
@@ -0,0 +1,78 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package store_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"magma/feg/gateway/services/aaa"
+	"magma/feg/gateway/services/aaa/protos"
+	"magma/feg/gateway/services/aaa/store"
+)
+
+func TestSIDRules(t *testing.T) {
+	normalize, err := store.ParseSIDRules(
+		`[{"match":"exact"},{"match":"case_insensitive"},{"match":"regex_extract","pattern":"^(?:acct-)?([^@]+)"}]`)
+	require.NoError(t, err)
+	assert.Equal(t, "abc123", normalize("ACCT-ABC123@nas1"))
+	assert.Equal(t, "abc123", normalize("abc123"))
+	assert.Equal(t, "@nas1", normalize("@nas1"), "session IDs not matching the pattern are kept")
+
+	normalize, err = store.ParseSIDRules(`[{"match":"exact"}]`)
+	require.NoError(t, err)
+	assert.Nil(t, normalize)
+
+	_, err = store.ParseSIDRules(`[{"match":"fuzzy"}]`)
+	assert.Error(t, err)
+	_, err = store.ParseSIDRules(`[{"match":"regex_extract","pattern":"("}]`)
+	assert.Error(t, err)
+	_, err = store.ParseSIDRules(`{"match":"exact"}`)
+	assert.Error(t, err)
+}
+
+func TestSessionTableSIDRules(t *testing.T) {
+	normalize, err := store.ParseSIDRules(`[{"match":"case_insensitive"}]`)
+	require.NoError(t, err)
+	st := store.NewMemorySessionTable()
+	st.(aaa.SIDNormalizing).SetSIDNormalizer(normalize)
+
+	_, err = st.AddSession(&protos.Context{SessionId: "Sid1", Imsi: "001010000000001"}, time.Hour, nil)
+	require.NoError(t, err)
+	_, err = st.AddSession(&protos.Context{SessionId: "SID1", Imsi: "001010000000002"}, time.Hour, nil)
+	assert.Error(t, err, "the session already exists")
+	s := st.GetSession("sID1")
+	require.NotNil(t, s)
+	assert.Equal(t, "001010000000001", s.GetCtx().GetImsi())
+	assert.Equal(t, "sid1", st.FindSession("001010000000001"))
+	assert.True(t, st.SetTimeout("SID1", time.Hour, nil))
+	assert.NotNil(t, st.RemoveSession("SiD1"))
+	assert.Nil(t, st.GetSession("sid1"))
+
+	// the persistent table's sessions are stored by their normalized IDs
+	client := &mockRedisClient{dataMap: map[string]string{}}
+	persistent, err := store.NewPersistentSessionTable(store.Limits{}, store.NewRedisSessionStoreWithClient(client))
+	require.NoError(t, err)
+	_, err = persistent.AddSession(&protos.Context{SessionId: "Sid2", Imsi: "001010000000002"}, time.Hour, nil)
+	require.NoError(t, err)
+	restarted, err := store.NewPersistentSessionTable(store.Limits{}, store.NewRedisSessionStoreWithClient(client))
+	require.NoError(t, err)
+	restarted.SetSIDNormalizer(normalize)
+	restored, err := restarted.Restore(nil)
+	require.NoError(t, err)
+	assert.Len(t, restored, 1, "the sessions stored before the rules are restored")
+	assert.NotNil(t, restarted.GetSession("SID2"))
+	assert.Contains(t, client.dataMap, "sid2")
+	assert.NotContains(t, client.dataMap, "Sid2")
+	assert.NotNil(t, restarted.RemoveSession("sID2"))
+	assert.Equal(t, 0, client.len())
+}
@@ -0,0 +1,258 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package timepolicy implements time of day session policies, such as free nights or venue closing hours
+package timepolicy
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+)
+
+// Context attributes of the RateLimit window applied to a new session, returned to the NAS with the Access-Accept
+const (
+	// BandwidthUpAttribute & BandwidthDownAttribute - session's maximum bandwidth in bits per second
+	BandwidthUpAttribute   = "max_bandwidth_up"
+	BandwidthDownAttribute = "max_bandwidth_down"
+	// WindowAttribute - name of the window applied at Accept, so it's not applied again via CoA
+	WindowAttribute = "time_policy_window"
+)
+
+// Action applied to sessions within a policy window
+type Action string
+
+const (
+	// Terminate - sessions are terminated when the window starts & new sessions are terminated right after start
+	Terminate Action = "terminate"
+	// RateLimit - sessions' bandwidth is limited within the window & restored when the window ends
+	RateLimit Action = "rate_limit"
+)
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// Window - a recurring daily time window & the action applied to sessions within it
+type Window struct {
+	// Name identifies the window in logs & metrics
+	Name string `json:"name"`
+	// APNs the window applies to, empty - all APNs
+	APNs []string `json:"apns"`
+	// Days of week the window starts on (sun, mon, ... sat), empty - every day
+	Days []string `json:"days"`
+	// Start & End - local "HH:MM" times, an End at or before Start ends the window on the next day
+	Start string `json:"start"`
+	End   string `json:"end"`
+	// Action applied to sessions within the window
+	Action Action `json:"action"`
+	// MaxBandwidthUp & MaxBandwidthDown - RateLimit action's bandwidth in bits per second
+	MaxBandwidthUp   uint32 `json:"max_bandwidth_up"`
+	MaxBandwidthDown uint32 `json:"max_bandwidth_down"`
+
+	start, end int     // minutes since midnight
+	days       [7]bool // indexed by time.Weekday
+	apns       map[string]bool
+	loc        *time.Location // time zone of the window's times
+}
+
+// Config - time of day policy configuration
+type Config struct {
+	// Timezone - IANA time zone name of the windows' times, empty - the gateway's local time zone
+	Timezone string   `json:"timezone"`
+	Windows  []Window `json:"windows"`
+	// BaseBandwidthUp & BaseBandwidthDown - bandwidth restored when a RateLimit window ends if the session's base
+	// bandwidth is unknown, 0 - the window's bandwidth is kept
+	BaseBandwidthUp   uint32 `json:"base_bandwidth_up"`
+	BaseBandwidthDown uint32 `json:"base_bandwidth_down"`
+}
+
+// Policy evaluates configured time windows
+type Policy struct {
+	windows        []*Window
+	baseUp, baseDn uint32
+}
+
+// ReadConfig reads time of day policy JSON configuration from the given file & returns the Policy
+func ReadConfig(path string) (*Policy, error) {
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewPolicy(cfg)
+}
+
+// LoadConfig reads time of day policy JSON configuration from the given file
+func LoadConfig(path string) (Config, error) {
+	cfg := Config{}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return cfg, err
+	}
+	if err = json.Unmarshal(b, &cfg); err != nil {
+		return cfg, fmt.Errorf("Invalid time policy configuration %s: %v", path, err)
+	}
+	return cfg, nil
+}
+
+// NewPolicy validates the configurations & returns a new Policy of their windows, each configuration's windows are
+// in its own time zone & the first configured base bandwidth is used
+func NewPolicy(cfgs ...Config) (*Policy, error) {
+	p := &Policy{}
+	for _, cfg := range cfgs {
+		loc := time.Local
+		if len(cfg.Timezone) > 0 {
+			var err error
+			if loc, err = time.LoadLocation(cfg.Timezone); err != nil {
+				return nil, fmt.Errorf("Invalid time policy timezone '%s': %v", cfg.Timezone, err)
+			}
+		}
+		for i := range cfg.Windows {
+			w := cfg.Windows[i]
+			if err := w.init(); err != nil {
+				return nil, fmt.Errorf("Invalid time policy window '%s': %v", w.Name, err)
+			}
+			w.loc = loc
+			p.windows = append(p.windows, &w)
+		}
+		if p.baseUp == 0 && p.baseDn == 0 && cfg.BaseBandwidthUp > 0 && cfg.BaseBandwidthDown > 0 {
+			p.baseUp, p.baseDn = cfg.BaseBandwidthUp, cfg.BaseBandwidthDown
+		}
+	}
+	return p, nil
+}
+
+// BaseBandwidth returns the configured base bandwidth, zeros if none
+func (p *Policy) BaseBandwidth() (up, down uint32) {
+	if p == nil {
+		return 0, 0
+	}
+	return p.baseUp, p.baseDn
+}
+
+func (w *Window) init() error {
+	var err error
+	if w.start, err = parseClock(w.Start); err != nil {
+		return err
+	}
+	if w.end, err = parseClock(w.End); err != nil {
+		return err
+	}
+	switch w.Action {
+	case Terminate:
+	case RateLimit:
+		if w.MaxBandwidthUp == 0 || w.MaxBandwidthDown == 0 {
+			return fmt.Errorf("rate_limit action requires max_bandwidth_up & max_bandwidth_down")
+		}
+	default:
+		return fmt.Errorf("unknown action '%s'", w.Action)
+	}
+	if len(w.Days) == 0 {
+		for d := range w.days {
+			w.days[d] = true
+		}
+	}
+	for _, day := range w.Days {
+		d, ok := weekdays[strings.ToLower(strings.TrimSpace(day))]
+		if !ok {
+			return fmt.Errorf("unknown day '%s'", day)
+		}
+		w.days[d] = true
+	}
+	if len(w.APNs) > 0 {
+		w.apns = map[string]bool{}
+		for _, apn := range w.APNs {
+			w.apns[strings.ToLower(apn)] = true
+		}
+	}
+	return nil
+}
+
+func parseClock(clock string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(clock))
+	if err != nil {
+		return 0, fmt.Errorf("invalid time '%s', expected HH:MM", clock)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func (w *Window) appliesTo(apn string) bool {
+	return w.apns == nil || w.apns[strings.ToLower(apn)]
+}
+
+// activeAt returns true if the window is active at the given time
+func (w *Window) activeAt(t time.Time) bool {
+	t = t.In(w.loc)
+	minute := t.Hour()*60 + t.Minute()
+	today, yesterday := t.Weekday(), (t.Weekday()+6)%7
+	if w.end > w.start {
+		return w.days[today] && minute >= w.start && minute < w.end
+	}
+	// The window spans midnight (or the whole day if Start == End)
+	return (w.days[today] && minute >= w.start) || (w.days[yesterday] && minute < w.end)
+}
+
+// Active returns the window active for the APN at the given time, nil if none. Terminate windows take precedence
+// over RateLimit windows, otherwise the first configured window wins
+func (p *Policy) Active(apn string, now time.Time) *Window {
+	if p == nil {
+		return nil
+	}
+	var active *Window
+	for _, w := range p.windows {
+		if !w.appliesTo(apn) || !w.activeAt(now) {
+			continue
+		}
+		if w.Action == Terminate {
+			return w
+		}
+		if active == nil {
+			active = w
+		}
+	}
+	return active
+}
+
+// NextChange returns the time of the next start or end of any of the APN's windows after the given time, the zero
+// time if no window applies to the APN
+func (p *Policy) NextChange(apn string, now time.Time) time.Time {
+	var next time.Time
+	if p == nil {
+		return next
+	}
+	for _, w := range p.windows {
+		if !w.appliesTo(apn) {
+			continue
+		}
+		for _, minute := range []int{w.start, w.end} {
+			t := nextClock(now.In(w.loc), minute)
+			if next.IsZero() || t.Before(next) {
+				next = t
+			}
+		}
+	}
+	return next
+}
+
+// nextClock returns the first time after now at the given minute since midnight of now's time zone
+func nextClock(now time.Time, minute int) time.Time {
+	y, m, d := now.Date()
+	t := time.Date(y, m, d, minute/60, minute%60, 0, 0, now.Location())
+	if !t.After(now) {
+		t = time.Date(y, m, d+1, minute/60, minute%60, 0, 0, now.Location())
+	}
+	return t
+}
@@ -0,0 +1,117 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package timepolicy_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"magma/feg/gateway/services/aaa/timepolicy"
+)
+
+func at(day, hour, min int) time.Time {
+	// 2019-07-01 is a Monday
+	return time.Date(2019, 7, day, hour, min, 0, 0, time.UTC)
+}
+
+func TestPolicy(t *testing.T) {
+	p, err := timepolicy.NewPolicy(timepolicy.Config{
+		Timezone: "UTC",
+		Windows: []timepolicy.Window{
+			{Name: "free_nights", Start: "23:00", End: "06:00", Action: timepolicy.RateLimit,
+				MaxBandwidthUp: 1000000, MaxBandwidthDown: 5000000},
+			{Name: "closing", APNs: []string{"Venue"}, Days: []string{"sat"}, Start: "22:00", End: "23:30",
+				Action: timepolicy.Terminate},
+		},
+	})
+	assert.NoError(t, err)
+
+	assert.Nil(t, p.Active("internet", at(1, 12, 0)))
+	w := p.Active("internet", at(1, 23, 0))
+	if assert.NotNil(t, w) {
+		assert.Equal(t, "free_nights", w.Name)
+	}
+	assert.NotNil(t, p.Active("internet", at(2, 5, 59)))
+	assert.Nil(t, p.Active("internet", at(2, 6, 0)))
+
+	// Terminate windows take precedence, the closing window only applies on Saturdays to the venue APN
+	assert.Nil(t, p.Active("venue", at(5, 22, 30)))
+	w = p.Active("venue", at(6, 23, 15))
+	if assert.NotNil(t, w) {
+		assert.Equal(t, timepolicy.Terminate, w.Action)
+	}
+	w = p.Active("internet", at(6, 23, 15))
+	if assert.NotNil(t, w) {
+		assert.Equal(t, timepolicy.RateLimit, w.Action)
+	}
+
+	assert.Equal(t, at(1, 23, 0), p.NextChange("internet", at(1, 12, 0)))
+	assert.Equal(t, at(2, 6, 0), p.NextChange("internet", at(1, 23, 0)))
+	assert.Equal(t, at(1, 22, 0), p.NextChange("venue", at(1, 12, 0)))
+}
+
+func TestPolicyValidation(t *testing.T) {
+	_, err := timepolicy.NewPolicy(timepolicy.Config{
+		Windows: []timepolicy.Window{{Name: "bad", Start: "25:00", End: "06:00", Action: timepolicy.Terminate}}})
+	assert.Error(t, err)
+	_, err = timepolicy.NewPolicy(timepolicy.Config{
+		Windows: []timepolicy.Window{{Name: "bad", Start: "23:00", End: "06:00", Action: timepolicy.RateLimit}}})
+	assert.Error(t, err)
+	_, err = timepolicy.NewPolicy(timepolicy.Config{
+		Windows: []timepolicy.Window{{Name: "bad", Start: "23:00", End: "06:00", Action: "block"}}})
+	assert.Error(t, err)
+	_, err = timepolicy.NewPolicy(timepolicy.Config{
+		Windows: []timepolicy.Window{{Name: "bad", Days: []string{"someday"}, Start: "23:00", End: "06:00",
+			Action: timepolicy.Terminate}}})
+	assert.Error(t, err)
+	_, err = timepolicy.NewPolicy(timepolicy.Config{Timezone: "Nowhere/City"})
+	assert.Error(t, err)
+}
+
+func TestPolicyConfigs(t *testing.T) {
+	// the file policy's windows are in UTC, the bandwidth schedule's in UTC+2
+	p, err := timepolicy.NewPolicy(
+		timepolicy.Config{
+			Timezone: "UTC",
+			Windows: []timepolicy.Window{{Name: "closing", APNs: []string{"venue"}, Start: "22:00", End: "23:00",
+				Action: timepolicy.Terminate}},
+		},
+		timepolicy.Config{
+			Timezone: "Etc/GMT-2",
+			Windows: []timepolicy.Window{{Name: "happy_hour", Days: []string{"fri"}, Start: "17:00", End: "19:00",
+				Action: timepolicy.RateLimit, MaxBandwidthUp: 10000000, MaxBandwidthDown: 50000000}},
+			BaseBandwidthUp:   1000000,
+			BaseBandwidthDown: 5000000,
+		})
+	assert.NoError(t, err)
+
+	up, down := p.BaseBandwidth()
+	assert.Equal(t, uint32(1000000), up)
+	assert.Equal(t, uint32(5000000), down)
+
+	assert.Nil(t, p.Active("venue", at(5, 17, 0)))
+	w := p.Active("venue", at(5, 15, 0))
+	if assert.NotNil(t, w) {
+		assert.Equal(t, "happy_hour", w.Name)
+	}
+	w = p.Active("venue", at(5, 22, 0))
+	if assert.NotNil(t, w) {
+		assert.Equal(t, "closing", w.Name)
+	}
+	assert.Equal(t, at(5, 15, 0), p.NextChange("internet", at(5, 12, 0)).UTC())
+	assert.Equal(t, at(5, 17, 0), p.NextChange("internet", at(5, 15, 0)).UTC())
+	assert.Equal(t, at(5, 15, 0), p.NextChange("venue", at(5, 12, 0)).UTC())
+
+	var none *timepolicy.Policy
+	up, down = none.BaseBandwidth()
+	assert.Zero(t, up)
+	assert.Zero(t, down)
+}
@@ -0,0 +1,205 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package usageexport
+
+import (
+	"encoding/binary"
+	"net"
+	"time"
+
+	"magma/feg/gateway/services/aaa/audit"
+)
+
+// IPFIX (RFC 7011) constants
+const (
+	ipfixVersion       = 10
+	ipfixHeaderLen     = 16
+	ipfixSetHeaderLen  = 4
+	ipfixTemplateSetID = 2
+	ipfixVarLen        = 0xFFFF // variable length field specifier
+
+	// flowEndReason values
+	endReasonActiveTimeout = 0x02 // Interim-Update, the session is still active
+	endReasonEndOfFlow     = 0x03 // Stop
+)
+
+// Template IDs of the usage records by the IP address family
+const (
+	templateIPv4 = 256
+	templateIPv6 = 257
+)
+
+// IANA IPFIX Information Elements of the usage records
+const (
+	ieSourceIPv4Address        = 8
+	ieSourceIPv6Address        = 27
+	ieSourceMacAddress         = 56
+	ieFlowEndReason            = 136
+	ieWlanSSID                 = 147
+	ieFlowStartMilliseconds    = 152
+	ieFlowEndMilliseconds      = 153
+	ieFlowDurationMilliseconds = 161
+	ieInitiatorOctets          = 231
+	ieResponderOctets          = 232
+	ieUserName                 = 371
+)
+
+type fieldSpec struct {
+	id, length uint16
+}
+
+// usageFields returns the usage record template's fields with the IP address of the given IE & length. The UE is
+// the flow's initiator: octets in (uplink) are initiatorOctets, octets out (downlink) are responderOctets. The IMSI is
+// the userName & the APN is the wlanSSID
+func usageFields(ipIE, ipLen uint16) []fieldSpec {
+	return []fieldSpec{
+		{ieUserName, ipfixVarLen},
+		{ieSourceMacAddress, 6},
+		{ieWlanSSID, ipfixVarLen},
+		{ipIE, ipLen},
+		{ieInitiatorOctets, 8},
+		{ieResponderOctets, 8},
+		{ieFlowStartMilliseconds, 8},
+		{ieFlowEndMilliseconds, 8},
+		{ieFlowDurationMilliseconds, 4},
+		{ieFlowEndReason, 1},
+	}
+}
+
+var templates = []struct {
+	id     uint16
+	fields []fieldSpec
+}{
+	{templateIPv4, usageFields(ieSourceIPv4Address, net.IPv4len)},
+	{templateIPv6, usageFields(ieSourceIPv6Address, net.IPv6len)},
+}
+
+// ipfixEncoder encodes records as IPFIX messages of one Observation Domain, it's not safe for concurrent use
+type ipfixEncoder struct {
+	domain   uint32
+	sequence uint32 // data records sent so far, the sequence number of the next message
+}
+
+func (enc *ipfixEncoder) encode(records []*Record, now time.Time, max int, withTemplates bool) [][]byte {
+	var res [][]byte
+	if withTemplates {
+		set := []byte{0, ipfixTemplateSetID, 0, 0}
+		for _, t := range templates {
+			set = putUint16(set, t.id)
+			set = putUint16(set, uint16(len(t.fields)))
+			for _, f := range t.fields {
+				set = putUint16(set, f.id)
+				set = putUint16(set, f.length)
+			}
+		}
+		binary.BigEndian.PutUint16(set[2:], uint16(len(set)))
+		res = append(res, enc.message(now, set, 0))
+	}
+	var (
+		sets     []byte
+		template uint16
+		count    uint32
+	)
+	set := -1 // offset of the current set in sets, -1 - none
+	closeSet := func() {
+		if set >= 0 {
+			binary.BigEndian.PutUint16(sets[set+2:], uint16(len(sets)-set))
+		}
+		set = -1
+	}
+	for _, r := range records {
+		id, data := encodeRecord(r)
+		if len(sets) > 0 && ipfixHeaderLen+len(sets)+ipfixSetHeaderLen+len(data) > max {
+			closeSet()
+			res, sets = append(res, enc.message(now, sets, count)), nil
+			count = 0
+		}
+		if set < 0 || id != template {
+			closeSet()
+			set, template = len(sets), id
+			sets = append(sets, 0, 0, 0, 0)
+			binary.BigEndian.PutUint16(sets[set:], id)
+		}
+		sets = append(sets, data...)
+		count++
+	}
+	if len(sets) > 0 {
+		closeSet()
+		res = append(res, enc.message(now, sets, count))
+	}
+	return res
+}
+
+// message returns the IPFIX message of the sets & advances the sequence number by the message's data records
+func (enc *ipfixEncoder) message(now time.Time, sets []byte, records uint32) []byte {
+	msg := make([]byte, ipfixHeaderLen, ipfixHeaderLen+len(sets))
+	binary.BigEndian.PutUint16(msg[0:], ipfixVersion)
+	binary.BigEndian.PutUint16(msg[2:], uint16(ipfixHeaderLen+len(sets)))
+	binary.BigEndian.PutUint32(msg[4:], uint32(now.Unix()))
+	binary.BigEndian.PutUint32(msg[8:], enc.sequence)
+	binary.BigEndian.PutUint32(msg[12:], enc.domain)
+	enc.sequence += records
+	return append(msg, sets...)
+}
+
+// encodeRecord returns the record's template ID & data record, records without an IPv6 address use the IPv4
+// template, unknown addresses are 0.0.0.0
+func encodeRecord(r *Record) (uint16, []byte) {
+	id, ip := uint16(templateIPv4), net.IPv4zero.To4()
+	if parsed := net.ParseIP(r.IpAddr); parsed != nil {
+		if v4 := parsed.To4(); v4 != nil {
+			ip = v4
+		} else {
+			id, ip = templateIPv6, parsed.To16()
+		}
+	}
+	mac, err := net.ParseMAC(r.MacAddr)
+	if err != nil || len(mac) != 6 {
+		mac = make(net.HardwareAddr, 6)
+	}
+	reason := byte(endReasonActiveTimeout)
+	if r.Event == audit.Stop {
+		reason = endReasonEndOfFlow
+	}
+	data := putString(nil, r.Imsi)
+	data = append(data, mac...)
+	data = putString(data, r.Apn)
+	data = append(data, ip...)
+	data = putUint64(data, r.OctetsIn)
+	data = putUint64(data, r.OctetsOut)
+	data = putUint64(data, uint64(r.Start.UnixNano()/int64(time.Millisecond)))
+	data = putUint64(data, uint64(r.End.UnixNano()/int64(time.Millisecond)))
+	data = putUint32(data, uint32(r.DurationMs))
+	return id, append(data, reason)
+}
+
+// putString appends the variable length encoding of s, truncated to the maximum length of 65535 bytes
+func putString(b []byte, s string) []byte {
+	if len(s) > 0xFFFF {
+		s = s[:0xFFFF]
+	}
+	if len(s) < 255 {
+		b = append(b, byte(len(s)))
+	} else {
+		b = putUint16(append(b, 255), uint16(len(s)))
+	}
+	return append(b, s...)
+}
+
+func putUint16(b []byte, v uint16) []byte {
+	return append(b, byte(v>>8), byte(v))
+}
+
+func putUint32(b []byte, v uint32) []byte {
+	return append(b, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
+
+func putUint64(b []byte, v uint64) []byte {
+	return putUint32(putUint32(b, uint32(v>>32)), uint32(v))
+}
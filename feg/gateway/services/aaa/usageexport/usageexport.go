@@ -0,0 +1,305 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package usageexport exports per-session usage records (IMSI, MAC, APN, IP, octets in/out & duration) of the
+// sessions' Interim-Updates & Stops to the operators' flow collectors, as IPFIX (RFC 7011) or JSON lines over UDP
+// or TCP. Records are queued without blocking accounting & sent in batches, a full queue drops new records
+package usageexport
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"magma/feg/gateway/services/aaa/audit"
+	"magma/feg/gateway/services/aaa/metrics"
+)
+
+// Formats & transports
+const (
+	FormatIPFIX = "ipfix"
+	FormatJSON  = "json"
+
+	TransportUDP = "udp"
+	TransportTCP = "tcp"
+)
+
+// Defaults
+const (
+	DefaultBatchSize       = 100
+	DefaultFlushInterval   = time.Second
+	DefaultQueueSize       = 10000
+	DefaultTimeout         = 5 * time.Second
+	DefaultTemplateRefresh = 10 * time.Minute
+)
+
+// Message size limits, UDP messages fit into a datagram of the common 1500 bytes MTU, TCP messages are limited by
+// the IPFIX message length field
+const (
+	maxUDPMessage = 1400
+	maxTCPMessage = 0xFFFF
+)
+
+// Config - usage export configuration of a collector
+type Config struct {
+	Collector string // host:port of the collector
+	Format    string // ipfix or json
+	Transport string // udp or tcp
+
+	BatchSize     int           // max records per batch
+	FlushInterval time.Duration // max delay of a queued record
+	QueueSize     int           // max queued records, new records are dropped if full
+	Timeout       time.Duration // connect & write timeout
+	// TemplateRefresh - interval of resending the IPFIX templates over UDP, TCP connections send them once
+	TemplateRefresh time.Duration
+	// ObservationDomain - IPFIX Observation Domain ID of the exported records
+	ObservationDomain uint32
+}
+
+// Record - usage record of a session's Interim-Update or Stop
+type Record struct {
+	Event     audit.EventType `json:"event"`
+	SessionId string          `json:"session_id"`
+	Imsi      string          `json:"imsi,omitempty"`
+	MacAddr   string          `json:"mac_addr,omitempty"`
+	Apn       string          `json:"apn,omitempty"`
+	IpAddr    string          `json:"ip_addr,omitempty"`
+	OctetsIn  uint64          `json:"octets_in"`
+	OctetsOut uint64          `json:"octets_out"`
+	// Start & End - the session's start, the record's time if unknown, & the record's time
+	Start      time.Time `json:"start"`
+	End        time.Time `json:"end"`
+	DurationMs uint64    `json:"duration_ms"`
+}
+
+// NewRecord returns the usage record of an Interim or Stop event, nil for other events
+func NewRecord(ev *audit.Event) *Record {
+	if ev == nil || (ev.Type != audit.Interim && ev.Type != audit.Stop) {
+		return nil
+	}
+	r := &Record{
+		Event:     ev.Type,
+		SessionId: ev.SessionId,
+		Imsi:      ev.Imsi,
+		MacAddr:   ev.MacAddr,
+		Apn:       ev.Apn,
+		IpAddr:    ev.IpAddr,
+		OctetsIn:  ev.OctetsIn,
+		OctetsOut: ev.OctetsOut,
+		Start:     ev.Time,
+		End:       ev.Time,
+	}
+	if len(r.IpAddr) == 0 {
+		r.IpAddr = ev.Ipv6Addr
+	}
+	if ev.SessionStart != nil {
+		r.Start = *ev.SessionStart
+	}
+	if ev.DurationNs > 0 {
+		r.DurationMs = uint64(time.Duration(ev.DurationNs) / time.Millisecond)
+	}
+	return r
+}
+
+// encoder encodes records into messages of up to max bytes, a message is never split across writes
+type encoder interface {
+	// encode returns the messages of the records, templates - the messages start with the format's templates
+	encode(records []*Record, now time.Time, max int, templates bool) [][]byte
+}
+
+// jsonEncoder encodes records as JSON lines, a message holds as many lines as fit
+type jsonEncoder struct{}
+
+func (jsonEncoder) encode(records []*Record, _ time.Time, max int, _ bool) [][]byte {
+	var (
+		res [][]byte
+		msg []byte
+	)
+	for _, r := range records {
+		line, err := json.Marshal(r)
+		if err != nil {
+			continue
+		}
+		line = append(line, '\n')
+		if len(msg) > 0 && len(msg)+len(line) > max {
+			res, msg = append(res, msg), nil
+		}
+		msg = append(msg, line...)
+	}
+	if len(msg) > 0 {
+		res = append(res, msg)
+	}
+	return res
+}
+
+// Exporter batches usage records of one collector, it implements audit.Sink
+type Exporter struct {
+	cfg     Config
+	enc     encoder
+	max     int
+	conn    net.Conn
+	sentTpl time.Time // the time the IPFIX templates were last sent over the connection
+	now     func() time.Time
+
+	queue    chan *Record
+	done     chan struct{}
+	stopOnce sync.Once
+	stopped  chan struct{}
+}
+
+// New returns a new, not started Exporter of the collector's configuration, zero settings are the defaults
+func New(cfg Config) (*Exporter, error) {
+	if _, _, err := net.SplitHostPort(cfg.Collector); err != nil {
+		return nil, fmt.Errorf("Invalid usage export collector address '%s': %v", cfg.Collector, err)
+	}
+	cfg.Transport = strings.ToLower(strings.TrimSpace(cfg.Transport))
+	cfg.Format = strings.ToLower(strings.TrimSpace(cfg.Format))
+	e := &Exporter{now: time.Now}
+	switch cfg.Transport {
+	case "", TransportUDP:
+		cfg.Transport, e.max = TransportUDP, maxUDPMessage
+	case TransportTCP:
+		e.max = maxTCPMessage
+	default:
+		return nil, fmt.Errorf("unknown usage export transport '%s'", cfg.Transport)
+	}
+	switch cfg.Format {
+	case "", FormatIPFIX:
+		cfg.Format, e.enc = FormatIPFIX, &ipfixEncoder{domain: cfg.ObservationDomain}
+	case FormatJSON:
+		e.enc = jsonEncoder{}
+	default:
+		return nil, fmt.Errorf("unknown usage export format '%s'", cfg.Format)
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = DefaultBatchSize
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = DefaultFlushInterval
+	}
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = DefaultQueueSize
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+	if cfg.TemplateRefresh <= 0 {
+		cfg.TemplateRefresh = DefaultTemplateRefresh
+	}
+	e.cfg = cfg
+	e.queue = make(chan *Record, cfg.QueueSize)
+	e.done = make(chan struct{})
+	e.stopped = make(chan struct{})
+	return e, nil
+}
+
+// Name returns the exporter's collector, e.g. ipfix+udp://10.0.0.1:4739
+func (e *Exporter) Name() string {
+	return fmt.Sprintf("%s+%s://%s", e.cfg.Format, e.cfg.Transport, e.cfg.Collector)
+}
+
+// Log implements audit.Sink, the usage record of Interim & Stop events is queued for export. Records which don't
+// fit into the queue are dropped & counted, the accounting is never blocked by a slow collector
+func (e *Exporter) Log(ev *audit.Event) error {
+	r := NewRecord(ev)
+	if r == nil {
+		return nil
+	}
+	select {
+	case e.queue <- r:
+	default:
+		metrics.UsageRecords.WithLabelValues(e.cfg.Collector, "dropped").Inc()
+	}
+	return nil
+}
+
+// Start starts the exporter's sending loop
+func (e *Exporter) Start() {
+	go e.run()
+}
+
+// Stop sends the queued records & stops the exporter
+func (e *Exporter) Stop() {
+	e.stopOnce.Do(func() { close(e.done) })
+	<-e.stopped
+}
+
+func (e *Exporter) run() {
+	defer close(e.stopped)
+	ticker := time.NewTicker(e.cfg.FlushInterval)
+	defer ticker.Stop()
+	batch := make([]*Record, 0, e.cfg.BatchSize)
+	flush := func() {
+		if len(batch) > 0 {
+			e.send(batch)
+			batch = make([]*Record, 0, e.cfg.BatchSize)
+		}
+	}
+	for {
+		select {
+		case r := <-e.queue:
+			batch = append(batch, r)
+			if len(batch) >= e.cfg.BatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-e.done:
+			for {
+				select {
+				case r := <-e.queue:
+					batch = append(batch, r)
+					if len(batch) >= e.cfg.BatchSize {
+						flush()
+					}
+				default:
+					flush()
+					if e.conn != nil {
+						e.conn.Close()
+					}
+					return
+				}
+			}
+		}
+	}
+}
+
+// send writes the batch's messages to the collector's connection, dialing it if needed. A failed write closes the
+// connection, the next batch redials it
+func (e *Exporter) send(batch []*Record) {
+	if e.conn == nil {
+		conn, err := net.DialTimeout(e.cfg.Transport, e.cfg.Collector, e.cfg.Timeout)
+		if err != nil {
+			metrics.UsageRecords.WithLabelValues(e.cfg.Collector, "failed").Add(float64(len(batch)))
+			log.Printf("Error connecting to usage export collector %s: %v", e.Name(), err)
+			return
+		}
+		e.conn, e.sentTpl = conn, time.Time{}
+	}
+	now := e.now()
+	// TCP connections send the templates once, UDP collectors may miss them & get them every refresh interval
+	templates := e.sentTpl.IsZero() || (e.cfg.Transport == TransportUDP && now.Sub(e.sentTpl) >= e.cfg.TemplateRefresh)
+	for _, msg := range e.enc.encode(batch, now, e.max, templates) {
+		e.conn.SetWriteDeadline(time.Now().Add(e.cfg.Timeout))
+		if _, err := e.conn.Write(msg); err != nil {
+			metrics.UsageRecords.WithLabelValues(e.cfg.Collector, "failed").Add(float64(len(batch)))
+			log.Printf("Error sending %d usage records to %s: %v", len(batch), e.Name(), err)
+			e.conn.Close()
+			e.conn = nil
+			return
+		}
+	}
+	if templates {
+		e.sentTpl = now
+	}
+	metrics.UsageRecords.WithLabelValues(e.cfg.Collector, "exported").Add(float64(len(batch)))
+}
@@ -0,0 +1,148 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package usageexport
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"magma/feg/gateway/services/aaa/audit"
+)
+
+func usageEvent(typ audit.EventType, ip string) *audit.Event {
+	start := audit.Now()
+	end := audit.Timestamp{Wall: start.Wall.Add(90 * time.Second), Mono: start.Mono + 90*time.Second}
+	ev := audit.NewEvent(typ, "sid1", end, start)
+	ev.Imsi, ev.MacAddr, ev.Apn, ev.IpAddr = "001010000000001", "0a:1b:2c:3d:4e:5f", "venue.ssid", ip
+	ev.OctetsIn, ev.OctetsOut = 1000, 20000
+	return ev
+}
+
+func TestNewRecord(t *testing.T) {
+	assert.Nil(t, NewRecord(audit.NewEvent(audit.Start, "sid1", audit.Now(), audit.Timestamp{})))
+	r := NewRecord(usageEvent(audit.Stop, "192.168.1.10"))
+	if assert.NotNil(t, r) {
+		assert.Equal(t, audit.Stop, r.Event)
+		assert.Equal(t, "192.168.1.10", r.IpAddr)
+		assert.Equal(t, uint64(90000), r.DurationMs)
+		assert.Equal(t, 90*time.Second, r.End.Sub(r.Start))
+	}
+	_, err := New(Config{Collector: "10.0.0.1"})
+	assert.Error(t, err)
+	_, err = New(Config{Collector: "10.0.0.1:4739", Format: "netflow"})
+	assert.Error(t, err)
+	_, err = New(Config{Collector: "10.0.0.1:4739", Transport: "sctp"})
+	assert.Error(t, err)
+}
+
+func TestIPFIXOverUDP(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer pc.Close()
+	e, err := New(Config{Collector: pc.LocalAddr().String(), ObservationDomain: 7, TemplateRefresh: time.Minute})
+	assert.NoError(t, err)
+	now := time.Unix(1500000000, 0)
+	e.now = func() time.Time { return now }
+
+	// the templates precede the first records
+	e.send([]*Record{
+		NewRecord(usageEvent(audit.Interim, "192.168.1.10")), NewRecord(usageEvent(audit.Stop, "2001:db8::1"))})
+	msg := readMessage(t, pc)
+	assert.Equal(t, uint16(ipfixVersion), binary.BigEndian.Uint16(msg))
+	assert.Equal(t, uint32(7), binary.BigEndian.Uint32(msg[12:]))
+	assert.Equal(t, uint16(ipfixTemplateSetID), binary.BigEndian.Uint16(msg[16:]))
+
+	// records of different address families are sent in sets of their templates
+	msg = readMessage(t, pc)
+	assert.Equal(t, uint32(0), binary.BigEndian.Uint32(msg[8:]), "sequence number")
+	v4 := msg[ipfixHeaderLen:]
+	assert.Equal(t, uint16(templateIPv4), binary.BigEndian.Uint16(v4))
+	data := v4[ipfixSetHeaderLen:]
+	assert.Equal(t, "001010000000001", string(data[1:1+data[0]]))
+	data = data[1+data[0]:]
+	assert.Equal(t, "0a:1b:2c:3d:4e:5f", net.HardwareAddr(data[:6]).String())
+	data = data[6:]
+	assert.Equal(t, "venue.ssid", string(data[1:1+data[0]]))
+	data = data[1+data[0]:]
+	assert.Equal(t, "192.168.1.10", net.IP(data[:4]).String())
+	assert.Equal(t, uint64(1000), binary.BigEndian.Uint64(data[4:]))
+	assert.Equal(t, uint64(20000), binary.BigEndian.Uint64(data[12:]))
+	assert.Equal(t, uint32(90000), binary.BigEndian.Uint32(data[36:]))
+	assert.Equal(t, byte(endReasonActiveTimeout), data[40])
+	v6 := v4[binary.BigEndian.Uint16(v4[2:]):]
+	assert.Equal(t, uint16(templateIPv6), binary.BigEndian.Uint16(v6))
+	assert.Equal(t, byte(endReasonEndOfFlow), v6[len(v6)-1])
+
+	// templates are resent every refresh interval
+	e.send([]*Record{NewRecord(usageEvent(audit.Interim, ""))})
+	msg = readMessage(t, pc)
+	assert.Equal(t, uint16(templateIPv4), binary.BigEndian.Uint16(msg[16:]))
+	assert.Equal(t, uint32(2), binary.BigEndian.Uint32(msg[8:]), "sequence number")
+	now = now.Add(time.Minute)
+	e.send([]*Record{NewRecord(usageEvent(audit.Interim, ""))})
+	msg = readMessage(t, pc)
+	assert.Equal(t, uint16(ipfixTemplateSetID), binary.BigEndian.Uint16(msg[16:]))
+
+	// large batches are split into datagram sized messages
+	var batch []*Record
+	for i := 0; i < 40; i++ {
+		batch = append(batch, NewRecord(usageEvent(audit.Interim, "192.168.1.10")))
+	}
+	messages := e.enc.encode(batch, now, e.max, false)
+	assert.True(t, len(messages) > 1)
+	for _, m := range messages {
+		assert.True(t, len(m) <= maxUDPMessage)
+		assert.Equal(t, uint16(len(m)), binary.BigEndian.Uint16(m[2:]))
+	}
+}
+
+func TestJSONOverTCP(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer l.Close()
+	e, err := New(Config{Collector: l.Addr().String(), Format: FormatJSON, Transport: TransportTCP, BatchSize: 2})
+	assert.NoError(t, err)
+	e.Start()
+	assert.NoError(t, e.Log(usageEvent(audit.Start, "192.168.1.10")))
+	assert.NoError(t, e.Log(usageEvent(audit.Interim, "192.168.1.10")))
+	assert.NoError(t, e.Log(usageEvent(audit.Stop, "192.168.1.10")))
+	e.Stop()
+
+	conn, err := l.Accept()
+	assert.NoError(t, err)
+	defer conn.Close()
+	scanner := bufio.NewScanner(conn)
+	var records []Record
+	for scanner.Scan() {
+		var r Record
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &r))
+		records = append(records, r)
+	}
+	if assert.Len(t, records, 2, "start events have no usage records") {
+		assert.Equal(t, audit.Interim, records[0].Event)
+		assert.Equal(t, audit.Stop, records[1].Event)
+		assert.Equal(t, "001010000000001", records[1].Imsi)
+		assert.Equal(t, uint64(20000), records[1].OctetsOut)
+	}
+}
+
+func readMessage(t *testing.T, pc net.PacketConn) []byte {
+	buf := make([]byte, maxTCPMessage)
+	pc.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, _, err := pc.ReadFrom(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(n), binary.BigEndian.Uint16(buf[2:]), "message length")
+	return buf[:n]
+}
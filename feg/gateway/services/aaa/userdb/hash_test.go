@@ -0,0 +1,41 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package userdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestFailedUsersCompareHash(t *testing.T) {
+	db, err := NewUserDB("")
+	assert.NoError(t, err)
+	assert.NoError(t, db.AddUser("user1", "pass1"))
+	assert.NoError(t, db.AddUser("user2", "pass2"))
+	assert.NoError(t, db.SetDisabled("user2", true))
+
+	var compared [][]byte
+	compareHash = func(hash, password []byte) error {
+		compared = append(compared, hash)
+		return bcrypt.CompareHashAndPassword(hash, password)
+	}
+	defer func() { compareHash = bcrypt.CompareHashAndPassword }()
+
+	// wrong password, disabled & unknown users compare a hash of the same cost, so they take as long to fail
+	for _, user := range []string{"user1", "user2", "user3"} {
+		compared = nil
+		assert.Equal(t, ErrAuthFailed, db.Authenticate(user, "wrong"))
+		assert.Len(t, compared, 1, user)
+		cost, err := bcrypt.Cost(compared[0])
+		assert.NoError(t, err)
+		assert.Equal(t, db.cost, cost, user)
+	}
+}
@@ -0,0 +1,188 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package userdb provides a local, file backed users credentials store for AAA deployments without SIM infrastructure
+package userdb
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// DefaultCost is the bcrypt cost used for newly added users
+const DefaultCost = bcrypt.DefaultCost
+
+// compareHash compares the password with the bcrypt hash, replaced by tests
+var compareHash = bcrypt.CompareHashAndPassword
+
+// ErrAuthFailed is returned for all failed authentications, it doesn't tell unknown, disabled & wrong password users
+// apart
+var ErrAuthFailed = errors.New("Authentication failed")
+
+// User - persisted user record, only bcrypt hash of the user's password is stored
+type User struct {
+	Hash     []byte `json:"hash"`
+	Disabled bool   `json:"disabled,omitempty"`
+}
+
+// UserDB - synchronized, file backed map of local users
+type UserDB struct {
+	path  string
+	cost  int
+	users map[string]*User
+	rwl   sync.RWMutex
+	// dummy - hash compared with the passwords of unknown & disabled users, so they take as long to authenticate
+	dummy []byte
+}
+
+// NewUserDB returns a new user DB backed by the given file, existing users are loaded from the file if it exists
+func NewUserDB(path string) (*UserDB, error) {
+	db := &UserDB{path: path, cost: DefaultCost, users: map[string]*User{}}
+	dummy, err := bcrypt.GenerateFromPassword([]byte("unknown user"), db.cost)
+	if err != nil {
+		return nil, fmt.Errorf("Error hashing dummy password: %v", err)
+	}
+	db.dummy = dummy
+	if len(path) == 0 {
+		return db, nil // memory only DB
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return db, nil
+		}
+		return nil, fmt.Errorf("Error reading user DB file %s: %v", path, err)
+	}
+	if len(data) > 0 {
+		if err = json.Unmarshal(data, &db.users); err != nil {
+			return nil, fmt.Errorf("Error parsing user DB file %s: %v", path, err)
+		}
+	}
+	return db, nil
+}
+
+// AddUser adds a new user or overwrites credentials of an existing one
+func (db *UserDB) AddUser(username, password string) error {
+	username = strings.TrimSpace(username)
+	if len(username) == 0 {
+		return fmt.Errorf("Empty user name")
+	}
+	if len(password) == 0 {
+		return fmt.Errorf("Empty password for user %s", username)
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), db.cost)
+	if err != nil {
+		return fmt.Errorf("Error hashing password for user %s: %v", username, err)
+	}
+	db.rwl.Lock()
+	defer db.rwl.Unlock()
+	old := db.users[username]
+	db.users[username] = &User{Hash: hash}
+	if err = db.persistUnsafe(); err != nil {
+		db.restoreUnsafe(username, old)
+	}
+	return err
+}
+
+// RemoveUser removes the user with the given name
+func (db *UserDB) RemoveUser(username string) error {
+	db.rwl.Lock()
+	defer db.rwl.Unlock()
+	old, ok := db.users[username]
+	if !ok {
+		return fmt.Errorf("User %s is not found", username)
+	}
+	delete(db.users, username)
+	err := db.persistUnsafe()
+	if err != nil {
+		db.restoreUnsafe(username, old)
+	}
+	return err
+}
+
+// SetDisabled disables or re-enables the user with the given name
+func (db *UserDB) SetDisabled(username string, disabled bool) error {
+	db.rwl.Lock()
+	defer db.rwl.Unlock()
+	old, ok := db.users[username]
+	if !ok {
+		return fmt.Errorf("User %s is not found", username)
+	}
+	if old.Disabled == disabled {
+		return nil
+	}
+	db.users[username] = &User{Hash: old.Hash, Disabled: disabled}
+	err := db.persistUnsafe()
+	if err != nil {
+		db.restoreUnsafe(username, old)
+	}
+	return err
+}
+
+// Authenticate verifies the given user's credentials, returns nil if the user exists, is enabled
+// and the password matches, ErrAuthFailed otherwise. Passwords of unknown & disabled users are compared with a dummy
+// hash, so their failures take as long as wrong passwords' ones
+func (db *UserDB) Authenticate(username, password string) error {
+	db.rwl.RLock()
+	u, ok := db.users[username]
+	db.rwl.RUnlock()
+	if !ok || u.Disabled {
+		compareHash(db.dummy, []byte(password))
+		return ErrAuthFailed
+	}
+	if compareHash(u.Hash, []byte(password)) != nil {
+		return ErrAuthFailed
+	}
+	return nil
+}
+
+// persistUnsafe writes the user map into the DB file, must be called under the DB write lock
+func (db *UserDB) persistUnsafe() error {
+	if len(db.path) == 0 {
+		return nil
+	}
+	data, err := json.MarshalIndent(db.users, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(db.path), filepath.Base(db.path)+".tmp")
+	if err != nil {
+		return fmt.Errorf("Error creating user DB temp file: %v", err)
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), 0600)
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), db.path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("Error writing user DB file %s: %v", db.path, err)
+	}
+	return nil
+}
+
+func (db *UserDB) restoreUnsafe(username string, old *User) {
+	if old == nil {
+		delete(db.users, username)
+	} else {
+		db.users[username] = old
+	}
+}
@@ -0,0 +1,75 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package userdb_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"magma/feg/gateway/services/aaa/userdb"
+)
+
+func TestUserDB(t *testing.T) {
+	dir, err := ioutil.TempDir("", "aaa_userdb")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "users.json")
+
+	db, err := userdb.NewUserDB(path)
+	assert.NoError(t, err)
+
+	assert.Error(t, db.AddUser("", "pass"))
+	assert.Error(t, db.AddUser("user1", ""))
+	assert.NoError(t, db.AddUser("user1", "pass1"))
+	assert.NoError(t, db.AddUser("user2", "pass2"))
+
+	assert.NoError(t, db.Authenticate("user1", "pass1"))
+	assert.Error(t, db.Authenticate("user1", "pass2"))
+	assert.Error(t, db.Authenticate("user3", "pass1"))
+
+	// Disable & re-enable
+	assert.NoError(t, db.SetDisabled("user2", true))
+	assert.Error(t, db.Authenticate("user2", "pass2"))
+	assert.Error(t, db.SetDisabled("user3", true))
+
+	// Reload from file
+	db, err = userdb.NewUserDB(path)
+	assert.NoError(t, err)
+	assert.NoError(t, db.Authenticate("user1", "pass1"))
+	assert.Error(t, db.Authenticate("user2", "pass2"))
+	assert.NoError(t, db.SetDisabled("user2", false))
+	assert.NoError(t, db.Authenticate("user2", "pass2"))
+
+	// Make sure clear text passwords are not persisted
+	data, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "pass1")
+
+	// Remove
+	assert.NoError(t, db.RemoveUser("user1"))
+	assert.Error(t, db.RemoveUser("user1"))
+	assert.Error(t, db.Authenticate("user1", "pass1"))
+}
+
+func TestAuthenticateFailures(t *testing.T) {
+	db, err := userdb.NewUserDB("")
+	assert.NoError(t, err)
+	assert.NoError(t, db.AddUser("user1", "pass1"))
+	assert.NoError(t, db.AddUser("user2", "pass2"))
+	assert.NoError(t, db.SetDisabled("user2", true))
+
+	// unknown, disabled & wrong password users fail alike
+	assert.Equal(t, userdb.ErrAuthFailed, db.Authenticate("user1", "pass2"))
+	assert.Equal(t, userdb.ErrAuthFailed, db.Authenticate("user2", "pass2"))
+	assert.Equal(t, userdb.ErrAuthFailed, db.Authenticate("user3", "pass1"))
+}
@@ -0,0 +1,257 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package wholesale attributes the usage of wholesale Wi-Fi sessions to their partners, the operators of the RFC 5580
+// Operator-Name of the sessions' accounting, so a Wi-Fi host sharing its SSIDs with roaming partners can bill each
+// partner its own usage. The partners' sessions & usage are aggregated into metrics partitioned by partner & the
+// partners' session events are split into one event file per partner
+package wholesale
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"magma/feg/gateway/services/aaa/audit"
+	"magma/feg/gateway/services/aaa/metrics"
+	"magma/feg/gateway/services/aaa/retention"
+)
+
+const (
+	// OtherPartner - the partner of the sessions of Operator-Names which aren't configured partners
+	OtherPartner = "other"
+	// MaxPartners - maximum number of partners of a configuration without partners, the sessions of further
+	// Operator-Names are attributed to OtherPartner
+	MaxPartners = 256
+	// fileExt - extension of the partners' event files
+	fileExt = ".json"
+)
+
+// Config - partner usage split configuration
+type Config struct {
+	// Partners - partner names by Operator-Name, several Operator-Names (e.g. of different namespaces) may belong
+	// to one partner. If empty, every Operator-Name is a partner of its own
+	Partners map[string]string `json:"partners"`
+	// Dir - directory of the partners' event files <dir>/<partner>.json, events aren't split into files if empty.
+	// Rotated files can be purged by a retention target of the Segments pattern
+	Dir string `json:"dir"`
+	// RotateBytes - size of a partner's event file rotating it, 0 - never rotated
+	RotateBytes int64 `json:"rotate_bytes"`
+}
+
+// ReadConfig reads JSON partner usage split configuration from the given file
+func ReadConfig(path string) (*Config, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &Config{}
+	if err = json.Unmarshal(b, cfg); err != nil {
+		return nil, fmt.Errorf("Invalid wholesale partners configuration %s: %v", path, err)
+	}
+	for name, partner := range cfg.Partners {
+		if len(name) == 0 || len(partner) == 0 {
+			return nil, fmt.Errorf("Invalid wholesale partner '%s' of Operator-Name '%s' in %s", partner, name, path)
+		}
+	}
+	return cfg, nil
+}
+
+// Usage - a partner's sessions & their usage since the AAA server started
+type Usage struct {
+	Partner   string `json:"partner"`
+	Sessions  int    `json:"sessions"` // active sessions
+	Starts    int    `json:"starts"`   // sessions started
+	OctetsIn  uint64 `json:"octets_in"`
+	OctetsOut uint64 `json:"octets_out"`
+}
+
+type session struct {
+	partner             string
+	octetsIn, octetsOut uint64 // last reported accumulated usage
+}
+
+// Split aggregates the wholesale sessions' events by partner & writes them into the partners' event files, it
+// implements audit.Sink. Sessions without an Operator-Name aren't wholesale sessions & are ignored
+type Split struct {
+	cfg Config
+
+	mu       sync.Mutex
+	sessions map[string]*session     // by session ID
+	usage    map[string]*Usage       // by partner
+	files    map[string]*partnerFile // by partner
+}
+
+// partnerFile - a partner's event file
+type partnerFile struct {
+	*audit.Logger
+	f *retention.File
+}
+
+// NewSplit returns a new Split of the configuration, the event files' directory is created if it doesn't exist
+func NewSplit(cfg Config) (*Split, error) {
+	if len(cfg.Dir) > 0 {
+		if err := os.MkdirAll(cfg.Dir, 0750); err != nil {
+			return nil, fmt.Errorf("Error creating wholesale partners' event files directory: %v", err)
+		}
+	}
+	return &Split{
+		cfg:      cfg,
+		sessions: map[string]*session{},
+		usage:    map[string]*Usage{},
+		files:    map[string]*partnerFile{},
+	}, nil
+}
+
+// Segments returns the glob pattern of the partners' rotated event files
+func (sp *Split) Segments() string {
+	return filepath.Join(sp.cfg.Dir, "*"+fileExt+".*")
+}
+
+// partner returns the partner of the Operator-Name, it must be called with the lock held
+func (sp *Split) partner(operatorName string) string {
+	if len(sp.cfg.Partners) > 0 {
+		if partner, ok := sp.cfg.Partners[operatorName]; ok {
+			return partner
+		}
+		return OtherPartner
+	}
+	if _, ok := sp.usage[operatorName]; !ok && len(sp.usage) >= MaxPartners {
+		return OtherPartner
+	}
+	return operatorName
+}
+
+// Log implements audit.Sink, Start, Import & Interim events add sessions to their partner, session end events remove
+// them & all events add the sessions' usage since their previous event to their partner. The event is written into
+// the partner's event file
+func (sp *Split) Log(ev *audit.Event) error {
+	if ev == nil {
+		return nil
+	}
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+	s, ok := sp.sessions[ev.SessionId]
+	if !ok {
+		if len(ev.OperatorName) == 0 {
+			return nil
+		}
+		// sessions of Interim-Updates without a Start were started before the AAA (re)started
+		s = &session{partner: sp.partner(ev.OperatorName), octetsIn: ev.OctetsIn, octetsOut: ev.OctetsOut}
+	}
+	u := sp.usageOf(s.partner)
+	switch ev.Type {
+	case audit.Start, audit.Import, audit.Interim:
+		if !ok {
+			sp.sessions[ev.SessionId] = s
+			u.Sessions++
+			metrics.PartnerSessions.WithLabelValues(s.partner).Inc()
+			if ev.Type == audit.Start {
+				u.Starts++
+			}
+		}
+	case audit.Stop, audit.Timeout, audit.Terminate, audit.Flush, audit.Export:
+		if ok {
+			delete(sp.sessions, ev.SessionId)
+			u.Sessions--
+			metrics.PartnerSessions.WithLabelValues(s.partner).Dec()
+		}
+	}
+	sp.addUsage(s, u, ev)
+	return sp.write(s.partner, ev)
+}
+
+func (sp *Split) usageOf(partner string) *Usage {
+	u, ok := sp.usage[partner]
+	if !ok {
+		u = &Usage{Partner: partner}
+		sp.usage[partner] = u
+	}
+	return u
+}
+
+// addUsage adds the session's usage since its previous event to its partner
+func (sp *Split) addUsage(s *session, u *Usage, ev *audit.Event) {
+	if ev.OctetsIn > s.octetsIn {
+		u.OctetsIn += ev.OctetsIn - s.octetsIn
+		metrics.PartnerOctetsIn.WithLabelValues(s.partner).Add(float64(ev.OctetsIn - s.octetsIn))
+		s.octetsIn = ev.OctetsIn
+	}
+	if ev.OctetsOut > s.octetsOut {
+		u.OctetsOut += ev.OctetsOut - s.octetsOut
+		metrics.PartnerOctetsOut.WithLabelValues(s.partner).Add(float64(ev.OctetsOut - s.octetsOut))
+		s.octetsOut = ev.OctetsOut
+	}
+}
+
+// write writes the event into the partner's event file, the file is opened on the partner's first event
+func (sp *Split) write(partner string, ev *audit.Event) error {
+	if len(sp.cfg.Dir) == 0 {
+		return nil
+	}
+	pf, ok := sp.files[partner]
+	if !ok {
+		path := filepath.Join(sp.cfg.Dir, fileName(partner)+fileExt)
+		f, err := retention.OpenFile(path, sp.cfg.RotateBytes)
+		if err != nil {
+			return fmt.Errorf("Error opening wholesale partner %s event file: %v", partner, err)
+		}
+		log.Printf("Wholesale partner %s events are written to %s", partner, path)
+		pf = &partnerFile{Logger: audit.NewLogger(f), f: f}
+		sp.files[partner] = pf
+	}
+	return pf.Log(ev)
+}
+
+// Usage returns the partners' usage sorted by partner
+func (sp *Split) Usage() []Usage {
+	sp.mu.Lock()
+	res := make([]Usage, 0, len(sp.usage))
+	for _, u := range sp.usage {
+		res = append(res, *u)
+	}
+	sp.mu.Unlock()
+	sort.Slice(res, func(i, j int) bool { return res[i].Partner < res[j].Partner })
+	return res
+}
+
+// Close closes the partners' event files
+func (sp *Split) Close() error {
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+	var first error
+	for partner, pf := range sp.files {
+		if err := pf.f.Close(); err != nil && first == nil {
+			first = err
+		}
+		delete(sp.files, partner)
+	}
+	return first
+}
+
+// fileName returns the file name of the partner, characters other than letters, digits, '-', '_' & '.' are
+// replaced by '_' so Operator-Names of NASes never escape the event files' directory
+func fileName(partner string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		}
+		return '_'
+	}, partner)
+	if strings.Trim(name, ".") == "" {
+		name = strings.Repeat("_", len(name)+1)
+	}
+	return name
+}
@@ -0,0 +1,125 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package wholesale
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"magma/feg/gateway/services/aaa/audit"
+)
+
+func event(typ audit.EventType, sid, operatorName string, octetsIn, octetsOut uint64) *audit.Event {
+	ev := audit.NewEvent(typ, sid, audit.Now(), audit.Timestamp{})
+	ev.OperatorName, ev.OctetsIn, ev.OctetsOut = operatorName, octetsIn, octetsOut
+	return ev
+}
+
+func TestSplit(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wholesale")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	sp, err := NewSplit(Config{
+		Partners: map[string]string{"1partner-a.example.com": "partner-a", "0PTNA": "partner-a", "1b.example.com": "b"},
+		Dir:      dir,
+	})
+	assert.NoError(t, err)
+	for _, ev := range []*audit.Event{
+		event(audit.Start, "sid1", "1partner-a.example.com", 0, 0),
+		event(audit.Start, "sid2", "0PTNA", 0, 0),
+		event(audit.Start, "sid3", "1b.example.com", 0, 0),
+		event(audit.Start, "sid4", "1unknown.example.com", 0, 0),
+		event(audit.Start, "sid5", "", 0, 0), // not a wholesale session
+		event(audit.Interim, "sid1", "1partner-a.example.com", 1000, 2000),
+		event(audit.Interim, "sid1", "1partner-a.example.com", 1500, 4000),
+		event(audit.Stop, "sid2", "0PTNA", 100, 200),
+		event(audit.Interim, "sid5", "", 9000, 9000),
+		// a session started before the AAA restarted
+		event(audit.Interim, "sid6", "1b.example.com", 5000, 5000),
+		event(audit.Interim, "sid6", "1b.example.com", 6000, 5000),
+		event(audit.Timeout, "sid4", "1unknown.example.com", 10, 20),
+		nil,
+	} {
+		assert.NoError(t, sp.Log(ev))
+	}
+	assert.Equal(t, []Usage{
+		{Partner: "b", Sessions: 2, Starts: 1, OctetsIn: 1000},
+		{Partner: OtherPartner, Sessions: 0, Starts: 1, OctetsIn: 10, OctetsOut: 20},
+		{Partner: "partner-a", Sessions: 1, Starts: 2, OctetsIn: 1600, OctetsOut: 4200},
+	}, sp.Usage())
+
+	// every partner's events are written into its own file
+	events := map[string][]string{}
+	for _, partner := range []string{"partner-a", "b", OtherPartner} {
+		f, err := os.Open(filepath.Join(dir, partner+".json"))
+		assert.NoError(t, err)
+		for sc := bufio.NewScanner(f); sc.Scan(); {
+			var ev audit.Event
+			assert.NoError(t, json.Unmarshal(sc.Bytes(), &ev))
+			events[partner] = append(events[partner], ev.SessionId)
+		}
+		f.Close()
+	}
+	assert.Equal(t, map[string][]string{
+		"partner-a":  {"sid1", "sid2", "sid1", "sid1", "sid2"},
+		"b":          {"sid3", "sid6", "sid6"},
+		OtherPartner: {"sid4", "sid4"},
+	}, events)
+	assert.NoError(t, sp.Close())
+}
+
+func TestSplitPerOperatorName(t *testing.T) {
+	sp, err := NewSplit(Config{})
+	assert.NoError(t, err)
+	for i := 0; i < MaxPartners+2; i++ {
+		assert.NoError(t, sp.Log(event(audit.Start, fmt.Sprintf("sid%d", i), fmt.Sprintf("1partner%d.example.com", i), 0, 0)))
+	}
+	usage := sp.Usage()
+	assert.Len(t, usage, MaxPartners+1)
+	for _, u := range usage {
+		if u.Partner == OtherPartner {
+			assert.Equal(t, 2, u.Sessions)
+		} else {
+			assert.Equal(t, 1, u.Sessions)
+		}
+	}
+}
+
+func TestFileName(t *testing.T) {
+	assert.Equal(t, "1partner.example.com", fileName("1partner.example.com"))
+	assert.Equal(t, ".._.._etc_passwd", fileName("../../etc/passwd"))
+	assert.Equal(t, "___", fileName(".."))
+	assert.Equal(t, "_", fileName(""))
+}
+
+func TestReadConfig(t *testing.T) {
+	f, err := ioutil.TempFile("", "wholesale")
+	assert.NoError(t, err)
+	defer os.Remove(f.Name())
+	_, err = f.WriteString(`{"partners": {"1partner.example.com": "partner"}, "dir": "/var/opt/magma/partners"}`)
+	assert.NoError(t, err)
+	f.Close()
+	cfg, err := ReadConfig(f.Name())
+	assert.NoError(t, err)
+	assert.Equal(t,
+		&Config{Partners: map[string]string{"1partner.example.com": "partner"}, Dir: "/var/opt/magma/partners"},
+		cfg)
+
+	assert.NoError(t, ioutil.WriteFile(f.Name(), []byte(`{"partners": {"1partner.example.com": ""}}`), 0640))
+	_, err = ReadConfig(f.Name())
+	assert.Error(t, err)
+}
@@ -32,6 +32,18 @@ const (
 // EAP result
 // NOTE: Identity Request is handled by APs & does not involve EAP Authenticator's support
 func HandleIdentityResponse(providerType uint8, msg *protos.Eap) (*protos.Eap, error) {
+	return handleIdentityResponse(registry.GetProvider, providerType, msg)
+}
+
+// HandleInnerIdentityResponse passes Identity EAP payload of a tunneled method's inner method to the corresponding
+// inner method provider & returns corresponding EAP result
+func HandleInnerIdentityResponse(providerType uint8, msg *protos.Eap) (*protos.Eap, error) {
+	return handleIdentityResponse(registry.GetInnerProvider, providerType, msg)
+}
+
+func handleIdentityResponse(
+	getProvider func(uint8) providers.Method, providerType uint8, msg *protos.Eap) (*protos.Eap, error) {
+
 	if msg == nil {
 		return nil, errors.New("Nil EAP Request")
 	}
@@ -50,7 +62,7 @@ func HandleIdentityResponse(providerType uint8, msg *protos.Eap) (*protos.Eap, e
 			msg.Ctx.Identity = string(td)
 		}
 	}
-	p := registry.GetProvider(providerType)
+	p := getProvider(providerType)
 	if p == nil {
 		return newFailureMsg(msg), unsupportedProviderError(providerType)
 	}
@@ -66,6 +78,15 @@ func SupportedTypes() []uint8 {
 
 // Handle handles passed EAP payload & returns corresponding EAP result
 func Handle(msg *protos.Eap) (*protos.Eap, error) {
+	return handle(registry.GetProvider, msg)
+}
+
+// HandleInner handles passed EAP payload of a tunneled method's inner method & returns corresponding EAP result
+func HandleInner(msg *protos.Eap) (*protos.Eap, error) {
+	return handle(registry.GetInnerProvider, msg)
+}
+
+func handle(getProvider func(uint8) providers.Method, msg *protos.Eap) (*protos.Eap, error) {
 	if msg == nil {
 		return nil, errors.New("Nil EAP Message")
 	}
@@ -83,7 +104,7 @@ func Handle(msg *protos.Eap) (*protos.Eap, error) {
 		td := eap.Packet(msg.Payload).TypeDataUnsafe()
 		for _, method = range td {
 			// Find first supported desired auth type
-			p = registry.GetProvider(method)
+			p = getProvider(method)
 			if p != nil {
 				// a matching handler is found, call it with a simulated EAP Identity (1) Request,
 				// use previously saved identity (if any) to create the request
@@ -100,7 +121,7 @@ func Handle(msg *protos.Eap) (*protos.Eap, error) {
 			}
 		}
 	} else {
-		p = registry.GetProvider(method)
+		p = getProvider(method)
 	}
 	if p == nil {
 		feap := newFailureMsg(msg)
@@ -11,7 +11,7 @@ package eap
 import "crypto/md5"
 
 // EncodeMsMppeKey implements RFC 2048 encoding for MS-MPPE-Send-Key & MS-MPPE-Recv-Key radius keys,
-// returns padded & encoded key.
+// returns padded & encoded key. RFC 2548 mandates MD5, the encoding isn't subject to the EAP crypto provider
 // See: https://www.ietf.org/rfc/rfc2548.txt 2.4.2, 2.4.3
 func EncodeMsMppeKey(salt, key, authenticatorKey, sharedSecret []byte) []byte {
 	l := len(key) + 1
@@ -0,0 +1,18 @@
+//go:build boringcrypto
+// +build boringcrypto
+
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package eap
+
+import "crypto/boring"
+
+func init() {
+	fipsModule = boring.Enabled
+}
@@ -0,0 +1,132 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package eap
+
+import (
+	"crypto"
+	"crypto/hmac"
+	_ "crypto/md5"    // register MD5 for crypto.Hash
+	_ "crypto/sha1"   // register SHA-1 for crypto.Hash
+	_ "crypto/sha256" // register SHA-224 & SHA-256 for crypto.Hash
+	_ "crypto/sha512" // register SHA-384 & SHA-512 for crypto.Hash
+	"fmt"
+	"hash"
+	"sync"
+)
+
+// Crypto provider names
+const (
+	StdCryptoProvider  = "std"
+	FIPSCryptoProvider = "fips"
+)
+
+// CryptoProvider - the hash & HMAC implementations of the EAP methods' key derivations & MACs
+type CryptoProvider interface {
+	// Name returns the provider's name
+	Name() string
+	// NewHash returns a new hash of the algorithm, an error if the provider doesn't permit the algorithm
+	NewHash(h crypto.Hash) (hash.Hash, error)
+	// NewHMAC returns a new HMAC of the hash algorithm & key, an error if the provider doesn't permit the algorithm
+	NewHMAC(h crypto.Hash, key []byte) (hash.Hash, error)
+}
+
+// stdCrypto - Go's standard library implementations of all algorithms
+type stdCrypto struct{}
+
+func (stdCrypto) Name() string {
+	return StdCryptoProvider
+}
+
+func (stdCrypto) NewHash(h crypto.Hash) (hash.Hash, error) {
+	if !h.Available() {
+		return nil, fmt.Errorf("Unavailable hash algorithm: %d", h)
+	}
+	return h.New(), nil
+}
+
+func (p stdCrypto) NewHMAC(h crypto.Hash, key []byte) (hash.Hash, error) {
+	if _, err := p.NewHash(h); err != nil {
+		return nil, err
+	}
+	return hmac.New(h.New, key), nil
+}
+
+// fipsCrypto - FIPS 140-2 approved algorithms of the FIPS validated BoringCrypto module, Go's standard library
+// crypto packages are backed by it in binaries built by a boringcrypto Go toolchain
+type fipsCrypto struct {
+	stdCrypto
+}
+
+// fipsApproved - FIPS 140-2 approved hash algorithms, MD5 isn't
+var fipsApproved = map[crypto.Hash]bool{
+	crypto.SHA1:   true,
+	crypto.SHA224: true,
+	crypto.SHA256: true,
+	crypto.SHA384: true,
+	crypto.SHA512: true,
+}
+
+// fipsModule returns true if the FIPS validated crypto module backs the standard library crypto, it's replaced by
+// boringcrypto builds
+var fipsModule = func() bool { return false }
+
+func (fipsCrypto) Name() string {
+	return FIPSCryptoProvider
+}
+
+func (p fipsCrypto) NewHash(h crypto.Hash) (hash.Hash, error) {
+	if !fipsApproved[h] {
+		return nil, fmt.Errorf("Hash algorithm %d is not FIPS approved", h)
+	}
+	return p.stdCrypto.NewHash(h)
+}
+
+func (p fipsCrypto) NewHMAC(h crypto.Hash, key []byte) (hash.Hash, error) {
+	if !fipsApproved[h] {
+		return nil, fmt.Errorf("HMAC hash algorithm %d is not FIPS approved", h)
+	}
+	return p.stdCrypto.NewHMAC(h, key)
+}
+
+var (
+	cryptoMu       sync.RWMutex
+	cryptoProvider CryptoProvider = stdCrypto{}
+)
+
+// NewCryptoProvider returns the crypto provider of the given name, the FIPS provider is only available in binaries
+// built with the FIPS validated crypto module
+func NewCryptoProvider(name string) (CryptoProvider, error) {
+	switch name {
+	case StdCryptoProvider, "":
+		return stdCrypto{}, nil
+	case FIPSCryptoProvider:
+		if !fipsModule() {
+			return nil, fmt.Errorf("FIPS crypto provider requires a boringcrypto build")
+		}
+		return fipsCrypto{}, nil
+	}
+	return nil, fmt.Errorf("Unknown crypto provider: %s", name)
+}
+
+// SetCryptoProvider sets the crypto provider of the process' EAP methods, it should be called on startup
+func SetCryptoProvider(p CryptoProvider) {
+	if p == nil {
+		p = stdCrypto{}
+	}
+	cryptoMu.Lock()
+	cryptoProvider = p
+	cryptoMu.Unlock()
+}
+
+// Crypto returns the crypto provider of the process' EAP methods, the standard library provider by default
+func Crypto() CryptoProvider {
+	cryptoMu.RLock()
+	defer cryptoMu.RUnlock()
+	return cryptoProvider
+}
@@ -0,0 +1,59 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package eap
+
+import (
+	"crypto"
+	"encoding/hex"
+	"testing"
+)
+
+func TestCryptoProviders(t *testing.T) {
+	std, err := NewCryptoProvider(StdCryptoProvider)
+	if err != nil || std.Name() != StdCryptoProvider {
+		t.Fatalf("Unexpected std provider: %v, %v", std, err)
+	}
+	if _, err = std.NewHash(crypto.MD5); err != nil {
+		t.Errorf("Unexpected std MD5 error: %v", err)
+	}
+	if _, err = NewCryptoProvider("unknown"); err == nil {
+		t.Error("Expected unknown provider error")
+	}
+	if _, err = NewCryptoProvider(FIPSCryptoProvider); (err == nil) != fipsModule() {
+		t.Errorf("Unexpected FIPS provider error: %v", err)
+	}
+
+	// the FIPS provider permits the approved algorithms only
+	var fips CryptoProvider = fipsCrypto{}
+	if _, err = fips.NewHash(crypto.MD5); err == nil {
+		t.Error("Expected FIPS MD5 error")
+	}
+	if _, err = fips.NewHMAC(crypto.MD5, []byte("key")); err == nil {
+		t.Error("Expected FIPS HMAC-MD5 error")
+	}
+	for _, p := range []CryptoProvider{std, fips} {
+		h, err := p.NewHMAC(crypto.SHA1, []byte("key"))
+		if err != nil {
+			t.Fatalf("Unexpected %s HMAC-SHA1 error: %v", p.Name(), err)
+		}
+		h.Write([]byte("The quick brown fox jumps over the lazy dog"))
+		if mac := hex.EncodeToString(h.Sum(nil)); mac != "de7c9b85b8b78aa6bc8a7a36f70a90701c9db4d9" {
+			t.Errorf("Unexpected %s HMAC-SHA1: %s", p.Name(), mac)
+		}
+	}
+
+	SetCryptoProvider(fips)
+	if Crypto().Name() != FIPSCryptoProvider {
+		t.Errorf("Unexpected provider: %s", Crypto().Name())
+	}
+	SetCryptoProvider(nil)
+	if Crypto().Name() != StdCryptoProvider {
+		t.Errorf("Unexpected default provider: %s", Crypto().Name())
+	}
+}
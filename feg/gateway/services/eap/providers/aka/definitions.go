@@ -110,6 +110,7 @@ const (
 	DefaultErrorNotificationTimeout    = time.Second * 10
 	DefaultSessionTimeout              = time.Hour * 12
 	DefaultSessionAuthenticatedTimeout = time.Second * 5
+	DefaultResponseCacheTTL            = time.Second * 30
 )
 
 type IMSI string
@@ -15,7 +15,9 @@ import (
 
 	"magma/feg/cloud/go/protos/mconfig"
 	"magma/feg/gateway/registry"
+	"magma/feg/gateway/services/eap"
 	"magma/feg/gateway/services/eap/protos"
+	"magma/feg/gateway/services/eap/providers/aka"
 	"magma/feg/gateway/services/eap/providers/aka/servicers"
 	_ "magma/feg/gateway/services/eap/providers/aka/servicers/handlers"
 	"magma/orc8r/cloud/go/service"
@@ -24,11 +26,24 @@ import (
 
 const EapAkaServiceName = "eap_aka"
 
+var cryptoProvider = flag.String("crypto_provider", eap.StdCryptoProvider,
+	"Crypto provider of the AKA key derivation & MACs: std or fips (requires a boringcrypto build)")
+
 func init() {
 	flag.Parse()
 }
 
 func main() {
+	crypto, err := eap.NewCryptoProvider(*cryptoProvider)
+	if err == nil {
+		err = aka.CheckCryptoProvider(crypto)
+	}
+	if err != nil {
+		log.Fatalf("Invalid EAP AKA crypto provider: %v", err)
+	}
+	eap.SetCryptoProvider(crypto)
+	log.Printf("EAP AKA crypto provider: %s", crypto.Name())
+
 	// Create the EAP AKA Provider service
 	srv, err := service.NewServiceWithOptions(registry.ModuleName, registry.EAP_AKA)
 	if err != nil {
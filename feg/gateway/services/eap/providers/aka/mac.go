@@ -10,8 +10,9 @@ LICENSE file in the root directory of this source tree.
 package aka
 
 import (
-	"crypto/hmac"
-	"crypto/sha1"
+	"crypto"
+	"fmt"
+	"hash"
 	"math/big"
 
 	"magma/feg/gateway/services/eap"
@@ -27,13 +28,28 @@ func GenMac(data, K_aut []byte) []byte {
 	return HmacSha1(data, K_aut)[:16]
 }
 
-// HmacSha1 - SHA1 based HMAC
+// HmacSha1 - SHA1 based HMAC of the EAP crypto provider
 func HmacSha1(data, key []byte) []byte {
-	h := hmac.New(sha1.New, key)
+	h, err := eap.Crypto().NewHMAC(crypto.SHA1, key)
+	if err != nil {
+		panic(err) // providers are checked by CheckCryptoProvider on startup
+	}
 	h.Write(data)
 	return h.Sum(nil)[:]
 }
 
+// CheckCryptoProvider returns an error if the crypto provider doesn't permit the AKA algorithms. The FIPS 186-2 PRF
+// of the AKA keys (XSum) is computed by this package from the SHA-1 block function, crypto modules don't expose it
+func CheckCryptoProvider(p eap.CryptoProvider) error {
+	if _, err := p.NewHash(crypto.SHA1); err != nil {
+		return fmt.Errorf("%s crypto provider doesn't support EAP-AKA: %v", p.Name(), err)
+	}
+	if _, err := p.NewHMAC(crypto.SHA1, nil); err != nil {
+		return fmt.Errorf("%s crypto provider doesn't support EAP-AKA: %v", p.Name(), err)
+	}
+	return nil
+}
+
 // MakeAKAKeys returns generated K_encr, K_aut, MSK, EMSK keys for AKA Authentication (RFC 4187, section 7)
 func MakeAKAKeys(identity, IK, CK []byte) (K_encr, K_aut, MSK, EMSK []byte) {
 	x := XSum(MK(identity, IK, CK))
@@ -42,13 +58,22 @@ func MakeAKAKeys(identity, IK, CK []byte) (K_encr, K_aut, MSK, EMSK []byte) {
 
 // MK calculates & returns AKA Master Key: MK = SHA1(Identity|IK|CK)
 func MK(identity, IK, CK []byte) []byte {
-	d := sha1.New()
+	d := newSha1()
 	d.Write(identity)
 	d.Write(IK)
 	d.Write(CK)
 	return d.Sum(nil)
 }
 
+// newSha1 returns a new SHA1 hash of the EAP crypto provider
+func newSha1() hash.Hash {
+	h, err := eap.Crypto().NewHash(crypto.SHA1)
+	if err != nil {
+		panic(err) // providers are checked by CheckCryptoProvider on startup
+	}
+	return h
+}
+
 /* XSum generates 160 byte long byte slice of concatenated x_0..X_3 calculated according to RFC 4187, Appendix A
  *
  * let XKEY := MK,
@@ -39,6 +39,15 @@ var (
 		Help: "Total number of EAP-AKA Session Timeouts",
 	})
 
+	ResponseCacheHits = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "response_cache_hits_total",
+		Help: "Total number of retransmitted EAP-AKA requests answered from the response cache",
+	})
+	ResponseCacheMisses = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "response_cache_misses_total",
+		Help: "Total number of EAP-AKA requests not found in the response cache",
+	})
+
 	// Method Handlers metrics
 	IdentityRequests = prometheus.NewCounter(prometheus.CounterOpts{
 		Name: "identity_requests_total",
@@ -98,7 +107,8 @@ var (
 
 func init() {
 	prometheus.MustRegister(Requests, FailedRequests, FailureNotifications,
-		SwxFailures, SessionTimeouts, IdentityRequests, FailedIdentityRequests,
+		SwxFailures, SessionTimeouts, ResponseCacheHits, ResponseCacheMisses, IdentityRequests,
+		FailedIdentityRequests,
 		ChallengeRequests, FailedChallengeRequests, ResyncRequests, FailedResyncRequests,
 		PeerAuthReject, PeerClientError, PeerNotification, PeerFailures, SWxLatency, AuthLatency)
 }
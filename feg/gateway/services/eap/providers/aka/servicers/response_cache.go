@@ -0,0 +1,117 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// package servicers implements EAP-AKA GRPC service
+package servicers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+type responseCacheKey [sha256.Size]byte
+
+type cachedResponse struct {
+	resp     *protos.Eap
+	rand     []byte // RAND of the conversation the response was computed for
+	identity string // identity of the conversation the response was computed for
+	expires  time.Time
+}
+
+// responseCache keeps computed EAP-AKA responses for a short time, so NAS retransmissions of the same EAP
+// message are answered with the same response rather than re-deriving keys or re-querying vectors
+type responseCache struct {
+	mu        sync.Mutex
+	entries   map[responseCacheKey]*cachedResponse
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func newResponseCache() *responseCache {
+	return &responseCache{entries: map[responseCacheKey]*cachedResponse{}, now: time.Now}
+}
+
+func responseKey(sessionId string, payload []byte) responseCacheKey {
+	h := sha256.New()
+	h.Write([]byte(sessionId))
+	h.Write([]byte{0})
+	h.Write(payload)
+	var key responseCacheKey
+	copy(key[:], h.Sum(nil))
+	return key
+}
+
+// get returns a copy of the response cached for the session's EAP payload if it's not expired & was computed for
+// the conversation's current RAND & identity
+func (c *responseCache) get(key responseCacheKey, rand []byte, identity string) *protos.Eap {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	if !c.now().Before(entry.expires) {
+		delete(c.entries, key)
+		return nil
+	}
+	if entry.identity != identity || !bytes.Equal(entry.rand, rand) {
+		return nil
+	}
+	return proto.Clone(entry.resp).(*protos.Eap)
+}
+
+// put caches a copy of the response for ttl, expired entries are swept at most once per ttl
+func (c *responseCache) put(key responseCacheKey, resp *protos.Eap, rand []byte, identity string, ttl time.Duration) {
+	now := c.now()
+	entry := &cachedResponse{
+		resp:     proto.Clone(resp).(*protos.Eap),
+		rand:     append([]byte(nil), rand...),
+		identity: identity,
+		expires:  now.Add(ttl),
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if now.Sub(c.lastSweep) >= ttl {
+		for k, e := range c.entries {
+			if !now.Before(e.expires) {
+				delete(c.entries, k)
+			}
+		}
+		c.lastSweep = now
+	}
+	c.entries[key] = entry
+}
+
+// len returns number of cached responses, including not yet swept expired ones
+func (c *responseCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// conversationState returns RAND & identity of the session's current EAP-AKA conversation
+func (s *EapAkaSrv) conversationState(sessionId string) (rand []byte, identity string) {
+	var uc *UserCtx
+	s.rwl.RLock()
+	if sessionCtx, ok := s.sessions[sessionId]; ok && sessionCtx != nil {
+		uc = sessionCtx.UserCtx
+	}
+	s.rwl.RUnlock()
+	if uc != nil {
+		uc.mu.Lock()
+		rand, identity = append([]byte(nil), uc.Rand...), uc.Identity
+		uc.mu.Unlock()
+	}
+	return rand, identity
+}
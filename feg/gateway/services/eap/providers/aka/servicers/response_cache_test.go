@@ -0,0 +1,83 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package servicers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"magma/feg/gateway/services/aaa/protos"
+	"magma/feg/gateway/services/eap/providers/aka"
+)
+
+func TestResponseCache(t *testing.T) {
+	now := time.Unix(1000, 0)
+	c := newResponseCache()
+	c.now = func() time.Time { return now }
+
+	rand := []byte{1, 2, 3, 4}
+	payload := []byte{2, 1, 0, 8, 23, 1, 0, 0}
+	key := responseKey("sid1", payload)
+	assert.NotEqual(t, key, responseKey("sid2", payload))
+	assert.Nil(t, c.get(key, rand, "0001010000000001@wlan"))
+
+	resp := &protos.Eap{Payload: []byte{3, 1, 0, 4}, Ctx: &protos.Context{SessionId: "sid1", Msk: []byte{9, 9}}}
+	c.put(key, resp, rand, "0001010000000001@wlan", time.Second*30)
+	resp.Ctx.Msk[0] = 0 // cached responses are copies
+
+	cached := c.get(key, []byte{1, 2, 3, 4}, "0001010000000001@wlan")
+	if assert.NotNil(t, cached) {
+		assert.Equal(t, []byte{3, 1, 0, 4}, cached.GetPayload())
+		assert.Equal(t, []byte{9, 9}, cached.GetCtx().GetMsk())
+		cached.Ctx.Msk[0] = 0
+		assert.Equal(t, []byte{9, 9}, c.get(key, rand, "0001010000000001@wlan").GetCtx().GetMsk())
+	}
+
+	// the conversation moved on, e.g. resynchronized with a new RAND
+	assert.Nil(t, c.get(key, []byte{5, 6, 7, 8}, "0001010000000001@wlan"))
+	assert.Nil(t, c.get(key, rand, "0001010000000002@wlan"))
+
+	// expired responses are removed
+	now = now.Add(time.Second * 30)
+	assert.Nil(t, c.get(key, rand, "0001010000000001@wlan"))
+	assert.Equal(t, 0, c.len())
+
+	// not retransmitted responses are swept
+	c.put(responseKey("sid1", payload), resp, rand, "", time.Second)
+	c.put(responseKey("sid2", payload), resp, rand, "", time.Second)
+	assert.Equal(t, 2, c.len())
+	now = now.Add(time.Second * 2)
+	c.put(responseKey("sid3", payload), resp, rand, "", time.Second)
+	assert.Equal(t, 1, c.len())
+}
+
+func TestConversationState(t *testing.T) {
+	s, err := NewEapAkaService(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, aka.DefaultResponseCacheTTL, s.ResponseCacheTTL())
+
+	rand, identity := s.conversationState("sid1")
+	assert.Nil(t, rand)
+	assert.Empty(t, identity)
+
+	uc := s.InitSession("sid1", "001010000000001")
+	uc.Rand, uc.Identity = []byte{1, 2, 3, 4}, "0001010000000001@wlan"
+	s.UpdateSessionUnlockCtx(uc, time.Minute)
+
+	rand, identity = s.conversationState("sid1")
+	assert.Equal(t, []byte{1, 2, 3, 4}, rand)
+	assert.Equal(t, "0001010000000001@wlan", identity)
+
+	s.RemoveSession("sid1")
+	rand, identity = s.conversationState("sid1")
+	assert.Nil(t, rand)
+	assert.Empty(t, identity)
+}
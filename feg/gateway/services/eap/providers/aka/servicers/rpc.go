@@ -68,7 +68,28 @@ func (s *EapAkaSrv) Handle(ctx context.Context, req *protos.Eap) (*protos.Eap, e
 			identifier, aka.NOTIFICATION_FAILURE, codes.NotFound, eapCtx,
 			"Unsuported Subtype: %d", p[eap.EapSubtype])
 	}
+	ttl := s.ResponseCacheTTL()
+	if ttl <= 0 || len(eapCtx.GetSessionId()) == 0 {
+		rp, err := h(s, eapCtx, p)
+		failure = err != nil
+		return &protos.Eap{Payload: rp, Ctx: eapCtx}, err
+	}
+	// NAS retransmissions of the same message within the same conversation (RAND & identity) are answered
+	// with the already computed response
+	key := responseKey(eapCtx.GetSessionId(), p)
+	rand, identity := s.conversationState(eapCtx.GetSessionId())
+	if cached := s.responses.get(key, rand, identity); cached != nil {
+		metrics.ResponseCacheHits.Inc()
+		failure = false
+		return cached, nil
+	}
+	metrics.ResponseCacheMisses.Inc()
 	rp, err := h(s, eapCtx, p)
 	failure = err != nil
-	return &protos.Eap{Payload: rp, Ctx: eapCtx}, err
+	resp := &protos.Eap{Payload: rp, Ctx: eapCtx}
+	if !failure {
+		rand, identity = s.conversationState(eapCtx.GetSessionId())
+		s.responses.put(key, resp, rand, identity, ttl)
+	}
+	return resp, err
 }
@@ -47,7 +47,8 @@ type touts struct {
 	challengeTimeout,
 	errorNotificationTimeout,
 	sessionTimeout,
-	sessionAuthenticatedTimeout time.Duration
+	sessionAuthenticatedTimeout,
+	responseCacheTTL time.Duration
 }
 
 type plmnIdVal struct {
@@ -64,6 +65,9 @@ type EapAkaSrv struct {
 	plmnIds map[string]plmnIdVal
 
 	timeouts touts
+
+	// Responses cached for NAS retransmissions
+	responses *responseCache
 }
 
 var defaultTimeouts = touts{
@@ -71,6 +75,7 @@ var defaultTimeouts = touts{
 	errorNotificationTimeout:    aka.DefaultErrorNotificationTimeout,
 	sessionTimeout:              aka.DefaultSessionTimeout,
 	sessionAuthenticatedTimeout: aka.DefaultSessionAuthenticatedTimeout,
+	responseCacheTTL:            aka.DefaultResponseCacheTTL,
 }
 
 func (s *EapAkaSrv) ChallengeTimeout() time.Duration {
@@ -105,12 +110,22 @@ func (s *EapAkaSrv) SetSessionAuthenticatedTimeout(tout time.Duration) {
 	atomic.StoreInt64((*int64)(&s.timeouts.sessionAuthenticatedTimeout), int64(tout))
 }
 
+// ResponseCacheTTL returns for how long computed responses are cached for retransmissions, 0 - caching is disabled
+func (s *EapAkaSrv) ResponseCacheTTL() time.Duration {
+	return time.Duration(atomic.LoadInt64((*int64)(&s.timeouts.responseCacheTTL)))
+}
+
+func (s *EapAkaSrv) SetResponseCacheTTL(tout time.Duration) {
+	atomic.StoreInt64((*int64)(&s.timeouts.responseCacheTTL), int64(tout))
+}
+
 // NewEapAkaService creates new Aka Service 'object'
 func NewEapAkaService(config *mconfig.EapAkaConfig) (*EapAkaSrv, error) {
 	service := &EapAkaSrv{
-		sessions: map[string]*SessionCtx{},
-		plmnIds:  map[string]plmnIdVal{},
-		timeouts: defaultTimeouts,
+		sessions:  map[string]*SessionCtx{},
+		plmnIds:   map[string]plmnIdVal{},
+		timeouts:  defaultTimeouts,
+		responses: newResponseCache(),
 	}
 	if config != nil {
 		if config.Timeout != nil {
@@ -0,0 +1,74 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package gtc implements EAP-GTC (RFC 3748, 5.6) provider authenticating the local users of the AAA user DB.
+// GTC responses carry the users' clear text passwords, so the method is meant to run inside a protected tunnel
+package gtc
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"magma/feg/gateway/services/aaa/protos"
+	"magma/feg/gateway/services/aaa/userdb"
+	"magma/feg/gateway/services/eap"
+	"magma/feg/gateway/services/eap/providers"
+)
+
+const (
+	// TYPE - EAP-GTC Type
+	TYPE = uint8(6)
+	// Prompt - the message displayed by the peers when asking for the user's password
+	Prompt = "Password"
+)
+
+// GTC Provider Implementation
+type providerImpl struct {
+	db *userdb.UserDB
+}
+
+// New returns EAP-GTC provider of the given user DB's users
+func New(db *userdb.UserDB) providers.Method {
+	return providerImpl{db: db}
+}
+
+// String returns EAP GTC Provider name/info
+func (providerImpl) String() string {
+	return "<Magma EAP-GTC Method Provider>"
+}
+
+// EAPType returns EAP GTC Type - 6
+func (providerImpl) EAPType() uint8 {
+	return TYPE
+}
+
+// Handle asks for the password of the identity's user & authenticates the user by the GTC response. The user name is
+// the EAP identity, kept in the context
+func (p providerImpl) Handle(msg *protos.Eap) (*protos.Eap, error) {
+	if msg == nil {
+		return nil, errors.New("Invalid EAP GTC Message")
+	}
+	packet := eap.Packet(msg.GetPayload())
+	switch packet.Type() {
+	case uint8(protos.EapType_Identity):
+		// unknown users are asked for their password as well, so the request doesn't tell them apart
+		data := append([]byte{TYPE}, Prompt...)
+		return &protos.Eap{Payload: eap.NewPacket(eap.RequestCode, packet.Identifier()+1, data), Ctx: msg.Ctx}, nil
+	case TYPE:
+		username := msg.GetCtx().GetIdentity()
+		if err := p.db.Authenticate(username, string(packet.TypeData())); err != nil {
+			log.Printf("EAP-GTC authentication of session %s failed: %v", msg.GetCtx().GetSessionId(), err)
+			return &protos.Eap{Payload: packet.Failure(), Ctx: msg.Ctx}, nil
+		}
+		return &protos.Eap{Payload: eap.NewPacket(eap.SuccessCode, packet.Identifier(), nil), Ctx: msg.Ctx}, nil
+	default:
+		return &protos.Eap{Payload: packet.Failure(), Ctx: msg.Ctx}, fmt.Errorf(
+			"Unexpected EAP Method Type for EAP-GTC: %d", packet.Type())
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package gtc_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"magma/feg/gateway/services/aaa/protos"
+	"magma/feg/gateway/services/aaa/userdb"
+	"magma/feg/gateway/services/eap"
+	"magma/feg/gateway/services/eap/client"
+	"magma/feg/gateway/services/eap/providers/gtc"
+	"magma/feg/gateway/services/eap/providers/registry"
+)
+
+func TestGTCProvider(t *testing.T) {
+	db, err := userdb.NewUserDB("")
+	assert.NoError(t, err)
+	assert.NoError(t, db.AddUser("user1@lab", "pass1"))
+	registry.RegisterInner(gtc.New(db))
+
+	// EAP-GTC is an inner method only
+	assert.NotContains(t, client.SupportedTypes(), gtc.TYPE)
+	identity := append([]byte{client.EapMethodIdentity}, "user1@lab"...)
+	resp, err := client.HandleIdentityResponse(gtc.TYPE,
+		&protos.Eap{Payload: eap.NewPacket(eap.ResponseCode, 1, identity), Ctx: &protos.Context{SessionId: "sid1"}})
+	assert.Error(t, err)
+	assert.Equal(t, uint8(eap.FailureCode), eap.Packet(resp.GetPayload()).Code())
+	password := append([]byte{gtc.TYPE}, "pass1"...)
+	resp, err = client.Handle(&protos.Eap{
+		Payload: eap.NewPacket(eap.ResponseCode, 2, password),
+		Ctx:     &protos.Context{SessionId: "sid1", Identity: "user1@lab"},
+	})
+	assert.Error(t, err)
+	assert.Equal(t, uint8(eap.FailureCode), eap.Packet(resp.GetPayload()).Code())
+
+	for _, tc := range []struct {
+		user, password string
+		success        bool
+	}{
+		{"user1@lab", "pass1", true},
+		{"user1@lab", "pass2", false},
+		{"user2@lab", "pass1", false},
+	} {
+		ctx := &protos.Context{SessionId: "sid1"}
+		identity := append([]byte{client.EapMethodIdentity}, tc.user...)
+		resp, err := client.HandleInnerIdentityResponse(gtc.TYPE,
+			&protos.Eap{Payload: eap.NewPacket(eap.ResponseCode, 1, identity), Ctx: ctx})
+		assert.NoError(t, err)
+		req := eap.Packet(resp.GetPayload())
+		assert.Equal(t, uint8(eap.RequestCode), req.Code())
+		assert.Equal(t, gtc.TYPE, req.Type())
+		assert.Equal(t, uint8(2), req.Identifier())
+		assert.Equal(t, gtc.Prompt, string(req.TypeData()))
+		assert.Equal(t, tc.user, resp.GetCtx().GetIdentity())
+
+		password := append([]byte{gtc.TYPE}, tc.password...)
+		resp, err = client.HandleInner(
+			&protos.Eap{Payload: eap.NewPacket(eap.ResponseCode, 2, password), Ctx: resp.GetCtx()})
+		assert.NoError(t, err)
+		assert.Equal(t, tc.success, eap.Packet(resp.GetPayload()).IsSuccess(), "user %s", tc.user)
+		if !tc.success {
+			assert.Equal(t, uint8(eap.FailureCode), eap.Packet(resp.GetPayload()).Code())
+		}
+	}
+}
@@ -24,6 +24,8 @@ var (
 	eapProviderRegistry               = map[uint8]providers.Method{}
 	supportedTypes                    = []uint8{}
 	registryMu          *sync.RWMutex = new(sync.RWMutex)
+	// innerProviderRegistry - providers of the inner methods run within tunneled methods' protected tunnels
+	innerProviderRegistry = map[uint8]providers.Method{}
 )
 
 // Register adds (registers) the provider to the internal registry, if a provider for the same type is already
@@ -68,6 +70,35 @@ func SupportedTypes() []uint8 {
 	return res
 }
 
+// RegisterInner adds (registers) the provider of an inner method, run only within the protected tunnel of a tunneled
+// method (PEAP, TTLS), e.g. EAP-GTC which carries clear text passwords. Inner providers are not supported types, the
+// top level EAP messages of their types fail as unsupported.
+// RegisterInner returns the previously registered inner provider for the type or nil if none was registered before
+func RegisterInner(p providers.Method) (oldProvider providers.Method) {
+	typ := p.EAPType()
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	oldProvider, previousExists := innerProviderRegistry[typ]
+	innerProviderRegistry[typ] = p
+	if previousExists {
+		log.Printf(
+			"EAP Inner Provider is already registered for type %d: %s. Will overwrite with: %s",
+			typ, oldProvider, p)
+	}
+	return
+}
+
+// GetInnerProvider returns registered inner Method provider for EAP type
+func GetInnerProvider(typ uint8) providers.Method {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	p, found := innerProviderRegistry[typ]
+	if found {
+		return p
+	}
+	return nil
+}
+
 // Sort interface
 type typesSlice []uint8
 
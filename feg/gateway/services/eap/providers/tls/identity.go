@@ -0,0 +1,87 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package tls
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+)
+
+// IdentityMapper returns the identity & IMSI of the session of the client's verified certificate, sessions of the
+// certificates it returns an error for are rejected
+type IdentityMapper func(cert *x509.Certificate) (identity, imsi string, err error)
+
+// SubjectIdentity maps the certificate to its subject's common name, or its first e-mail SAN if the subject has no
+// common name. The IMSI is the identity's user name if it is an IMSI, e.g. 001010000000001@wlan.example.com
+func SubjectIdentity(cert *x509.Certificate) (string, string, error) {
+	identity := cert.Subject.CommonName
+	if len(identity) == 0 && len(cert.EmailAddresses) > 0 {
+		identity = cert.EmailAddresses[0]
+	}
+	if len(identity) == 0 {
+		return "", "", errors.New("certificate has neither subject common name nor e-mail")
+	}
+	imsi := strings.SplitN(identity, "@", 2)[0]
+	if !isIMSI(imsi) {
+		imsi = ""
+	}
+	return identity, imsi, nil
+}
+
+// IMSIMap maps the certificates' identities (see SubjectIdentity) to their IMSIs, certificates of identities not in
+// the map are rejected
+type IMSIMap map[string]string
+
+// ReadIMSIMap reads the JSON identity to IMSI map file
+func ReadIMSIMap(path string) (IMSIMap, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	m := IMSIMap{}
+	if err = json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("invalid EAP-TLS IMSI map %s: %v", path, err)
+	}
+	for identity, imsi := range m {
+		if !isIMSI(imsi) {
+			return nil, fmt.Errorf("invalid IMSI '%s' of EAP-TLS identity '%s'", imsi, identity)
+		}
+	}
+	return m, nil
+}
+
+// MapIdentity is the IdentityMapper of the map
+func (m IMSIMap) MapIdentity(cert *x509.Certificate) (string, string, error) {
+	identity, _, err := SubjectIdentity(cert)
+	if err != nil {
+		return "", "", err
+	}
+	imsi, ok := m[identity]
+	if !ok {
+		return "", "", fmt.Errorf("unknown identity '%s'", identity)
+	}
+	return identity, imsi, nil
+}
+
+// isIMSI returns true if s is 6 to 15 digits
+func isIMSI(s string) bool {
+	if len(s) < 6 || len(s) > 15 {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
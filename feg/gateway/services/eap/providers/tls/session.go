@@ -0,0 +1,269 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package tls
+
+import (
+	"bytes"
+	cryptotls "crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"magma/feg/gateway/services/aaa/protos"
+	"magma/feg/gateway/services/eap"
+)
+
+// session is an EAP-TLS session's TLS handshake, run by its own goroutine over the session's transport. Peer's
+// fragmented messages are reassembled before they are passed to the handshake & the handshake's messages are
+// fragmented, each fragment is acknowledged by the peer before the next one is sent
+type session struct {
+	mu           sync.Mutex
+	conn         *cryptotls.Conn
+	transport    *transport
+	handshake    chan error // receives the handshake's result
+	mapIdentity  IdentityMapper
+	fragmentSize int
+	timeout      time.Duration
+	lastUsed     time.Time
+
+	rx        []byte // peer's TLS message being reassembled
+	rxLen     int    // peer's TLS message length, if included
+	tx        []byte // handshake's TLS message not yet sent
+	txSent    int    // sent bytes of tx
+	completed bool   // the handshake completed, the peer's acknowledgement of tx's last fragment ends the session
+
+	identity, imsi string
+	msk            []byte
+}
+
+func newSession(config *cryptotls.Config, mapIdentity IdentityMapper, fragmentSize int, timeout time.Duration) (
+	*session, error) {
+
+	t := newTransport()
+	s := &session{
+		conn:         cryptotls.Server(t, config),
+		transport:    t,
+		handshake:    make(chan error, 1),
+		mapIdentity:  mapIdentity,
+		fragmentSize: fragmentSize,
+		timeout:      timeout,
+		lastUsed:     time.Now(),
+	}
+	go func() {
+		s.handshake <- s.conn.Handshake()
+	}()
+	// the handshake waits for the peer's ClientHello
+	if _, err := s.await(); err != nil {
+		s.close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// handle handles the peer's EAP-TLS response & returns the next EAP-TLS request or EAP-Success. The session's context
+// is set to the authenticated client's identity, IMSI & MSK on success
+func (s *session) handle(packet eap.Packet, ctx *protos.Context) (eap.Packet, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastUsed = time.Now()
+	flags, length, data, err := parseResponse(packet.TypeData())
+	if err != nil {
+		return nil, err
+	}
+	identifier := packet.Identifier()
+	if s.txSent < len(s.tx) || s.completed {
+		// the peer acknowledges the sent fragment
+		if flags&FlagMore != 0 || len(data) > 0 {
+			return nil, errors.New("Unexpected EAP-TLS data instead of fragment acknowledgement")
+		}
+		if s.txSent < len(s.tx) {
+			return s.nextFragment(identifier + 1), nil
+		}
+		ctx.Identity, ctx.Imsi, ctx.Msk = s.identity, s.imsi, s.msk
+		return eap.NewPacket(eap.SuccessCode, identifier, nil), nil
+	}
+	if flags&FlagLength != 0 && len(s.rx) == 0 {
+		if length > MaxMessageSize {
+			return nil, fmt.Errorf("EAP-TLS Message Length %d exceeds %d", length, MaxMessageSize)
+		}
+		s.rxLen = int(length)
+	}
+	if len(s.rx)+len(data) > MaxMessageSize {
+		return nil, fmt.Errorf("EAP-TLS message exceeds %d bytes", MaxMessageSize)
+	}
+	s.rx = append(s.rx, data...)
+	if flags&FlagMore != 0 {
+		// acknowledge the fragment
+		return newRequest(identifier+1, 0, nil), nil
+	}
+	if s.rxLen > 0 && len(s.rx) != s.rxLen {
+		return nil, fmt.Errorf("EAP-TLS message of %d bytes, its Message Length is %d", len(s.rx), s.rxLen)
+	}
+	rx := s.rx
+	s.rx, s.rxLen = nil, 0
+	if len(rx) == 0 {
+		return nil, errors.New("Empty EAP-TLS message")
+	}
+	if err = s.step(rx); err != nil {
+		return nil, err
+	}
+	if len(s.tx) == 0 {
+		return nil, errors.New("EAP-TLS handshake has no message for the peer")
+	}
+	return s.nextFragment(identifier + 1), nil
+}
+
+// step passes the peer's TLS message to the handshake & sets tx to the handshake's messages. The client's identity,
+// IMSI & MSK are set once the handshake completes
+func (s *session) step(msg []byte) error {
+	select {
+	case s.transport.in <- msg:
+	case err := <-s.handshake:
+		return fmt.Errorf("EAP-TLS handshake ended: %v", err)
+	}
+	completed, err := s.await()
+	if err != nil {
+		return err
+	}
+	s.tx, s.txSent = s.transport.flush(), 0
+	if !completed {
+		return nil
+	}
+	state := s.conn.ConnectionState()
+	if len(state.PeerCertificates) == 0 {
+		return errors.New("EAP-TLS client has no certificate")
+	}
+	if s.identity, s.imsi, err = s.mapIdentity(state.PeerCertificates[0]); err != nil {
+		return fmt.Errorf("EAP-TLS client certificate '%s' identity error: %v",
+			state.PeerCertificates[0].Subject.CommonName, err)
+	}
+	keys, err := state.ExportKeyingMaterial(mskLabel, nil, 2*mskLen)
+	if err != nil {
+		return fmt.Errorf("EAP-TLS key derivation error: %v", err)
+	}
+	s.msk, s.completed = keys[:mskLen], true
+	return nil
+}
+
+// await waits until the handshake waits for the peer's next message or completes, it returns true if the handshake
+// completed successfully
+func (s *session) await() (bool, error) {
+	timer := time.NewTimer(s.timeout)
+	defer timer.Stop()
+	select {
+	case <-s.transport.idle:
+		return false, nil
+	case err := <-s.handshake:
+		if err != nil {
+			return false, fmt.Errorf("EAP-TLS handshake error: %v", err)
+		}
+		return true, nil
+	case <-timer.C:
+		return false, errors.New("EAP-TLS handshake timed out")
+	}
+}
+
+// nextFragment returns EAP-TLS request of tx's next fragment, the first fragment of a fragmented message includes
+// the message's length
+func (s *session) nextFragment(identifier uint8) eap.Packet {
+	var (
+		flags  uint8
+		header []byte
+	)
+	fragment := s.tx[s.txSent:]
+	if len(fragment) > s.fragmentSize {
+		fragment, flags = fragment[:s.fragmentSize], FlagMore
+		if s.txSent == 0 {
+			flags |= FlagLength
+			header = make([]byte, 4)
+			binary.BigEndian.PutUint32(header, uint32(len(s.tx)))
+		}
+	}
+	s.txSent += len(fragment)
+	return newRequest(identifier, flags, append(header, fragment...))
+}
+
+func (s *session) idleSince(now time.Time) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return now.Sub(s.lastUsed)
+}
+
+// close ends the handshake goroutine of an incomplete handshake
+func (s *session) close() {
+	s.transport.close()
+}
+
+// transport is the in-memory net.Conn of a session's handshake. Reads of the handshake get the peer's messages
+// passed by step & signal idle when the handshake waits for the peer's next message, writes are buffered until
+// flushed into the next EAP-TLS requests
+type transport struct {
+	in      chan []byte
+	idle    chan struct{}
+	pending []byte
+	out     bytes.Buffer
+
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+func newTransport() *transport {
+	return &transport{in: make(chan []byte), idle: make(chan struct{}, 1), closed: make(chan struct{})}
+}
+
+func (t *transport) Read(b []byte) (int, error) {
+	if len(t.pending) == 0 {
+		select {
+		case t.idle <- struct{}{}:
+		case <-t.closed:
+			return 0, io.EOF
+		}
+		select {
+		case t.pending = <-t.in:
+		case <-t.closed:
+			return 0, io.EOF
+		}
+	}
+	n := copy(b, t.pending)
+	t.pending = t.pending[n:]
+	return n, nil
+}
+
+func (t *transport) Write(b []byte) (int, error) {
+	return t.out.Write(b)
+}
+
+// flush returns & clears the buffered writes, it must be called while the handshake waits for the peer's message or
+// after the handshake ended
+func (t *transport) flush() []byte {
+	res := append([]byte(nil), t.out.Bytes()...)
+	t.out.Reset()
+	return res
+}
+
+// close makes the pending & future reads fail, so the handshake ends
+func (t *transport) close() {
+	t.closeOnce.Do(func() { close(t.closed) })
+}
+
+func (t *transport) Close() error                     { return nil }
+func (t *transport) LocalAddr() net.Addr              { return eapAddr{} }
+func (t *transport) RemoteAddr() net.Addr             { return eapAddr{} }
+func (t *transport) SetDeadline(time.Time) error      { return nil }
+func (t *transport) SetReadDeadline(time.Time) error  { return nil }
+func (t *transport) SetWriteDeadline(time.Time) error { return nil }
+
+type eapAddr struct{}
+
+func (eapAddr) Network() string { return "eap" }
+func (eapAddr) String() string  { return "eap-tls" }
@@ -0,0 +1,231 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package tls implements EAP-TLS (RFC 5216) provider authenticating clients by their certificates. The TLS handshake
+// is run by crypto/tls over the EAP-TLS messages of the session, TLS 1.3 (RFC 9190) isn't supported
+package tls
+
+import (
+	cryptotls "crypto/tls"
+	"crypto/x509"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"sync"
+	"time"
+
+	"magma/feg/gateway/services/aaa/protos"
+	"magma/feg/gateway/services/eap"
+	"magma/feg/gateway/services/eap/providers"
+)
+
+const (
+	// TYPE - EAP-TLS Type
+	TYPE = uint8(protos.EapType_TLS)
+
+	// EAP-TLS Flags
+	FlagLength = uint8(0x80) // TLS Message Length is included
+	FlagMore   = uint8(0x40) // more fragments follow
+	FlagStart  = uint8(0x20) // EAP-TLS Start
+
+	// DefaultFragmentSize - default maximum TLS data bytes of an EAP-TLS request
+	DefaultFragmentSize = 1024
+	// DefaultSessionTimeout - default time an EAP-TLS session waits for the peer's next response
+	DefaultSessionTimeout = time.Minute
+	// MaxMessageSize - maximum size of the peer's reassembled TLS message
+	MaxMessageSize = 64 * 1024
+
+	// mskLabel - RFC 5216, 2.3 key derivation label, the key material is MSK (64 bytes) followed by EMSK (64 bytes)
+	mskLabel = "client EAP encryption"
+	mskLen   = 64
+)
+
+// Config of EAP-TLS provider
+type Config struct {
+	ServerCert, ServerKey string // server certificate & key PEM file paths
+	CABundle              string // PEM file path of the CAs of the clients' certificates
+	// MapIdentity maps the clients' certificates to the sessions' identities & IMSIs, nil - SubjectIdentity
+	MapIdentity    IdentityMapper
+	FragmentSize   int           // 0 - DefaultFragmentSize
+	SessionTimeout time.Duration // 0 - DefaultSessionTimeout
+}
+
+// TLS Provider Implementation
+type providerImpl struct {
+	tlsConfig    *cryptotls.Config
+	mapIdentity  IdentityMapper
+	fragmentSize int
+	timeout      time.Duration
+
+	mu        sync.Mutex
+	sessions  map[string]*session // by session ID
+	nextPurge time.Time
+}
+
+// New returns EAP-TLS provider of the given configuration
+func New(cfg Config) (providers.Method, error) {
+	cert, err := cryptotls.LoadX509KeyPair(cfg.ServerCert, cfg.ServerKey)
+	if err != nil {
+		return nil, fmt.Errorf("Error loading EAP-TLS server certificate: %v", err)
+	}
+	cas, err := ioutil.ReadFile(cfg.CABundle)
+	if err != nil {
+		return nil, fmt.Errorf("Error loading EAP-TLS CA bundle: %v", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(cas) {
+		return nil, fmt.Errorf("EAP-TLS CA bundle %s has no certificates", cfg.CABundle)
+	}
+	p := &providerImpl{
+		tlsConfig: &cryptotls.Config{
+			Certificates: []cryptotls.Certificate{cert},
+			ClientCAs:    pool,
+			ClientAuth:   cryptotls.RequireAndVerifyClientCert,
+			MinVersion:   cryptotls.VersionTLS12,
+			MaxVersion:   cryptotls.VersionTLS12,
+			// the session is resumed by neither tickets nor IDs, every session authenticates the client's certificate
+			SessionTicketsDisabled: true,
+		},
+		mapIdentity:  cfg.MapIdentity,
+		fragmentSize: cfg.FragmentSize,
+		timeout:      cfg.SessionTimeout,
+		sessions:     map[string]*session{},
+	}
+	if p.mapIdentity == nil {
+		p.mapIdentity = SubjectIdentity
+	}
+	if p.fragmentSize <= 0 {
+		p.fragmentSize = DefaultFragmentSize
+	}
+	if p.timeout <= 0 {
+		p.timeout = DefaultSessionTimeout
+	}
+	return p, nil
+}
+
+// String returns EAP TLS Provider name/info
+func (*providerImpl) String() string {
+	return "<Magma EAP-TLS Method Provider>"
+}
+
+// EAPType returns EAP TLS Type - 13
+func (*providerImpl) EAPType() uint8 {
+	return TYPE
+}
+
+// Handle starts the session's EAP-TLS handshake on its Identity response & handles the session's EAP-TLS responses.
+// The identity & IMSI of the successfully authenticated sessions are the ones mapped from their clients' certificates
+func (p *providerImpl) Handle(msg *protos.Eap) (*protos.Eap, error) {
+	if msg == nil {
+		return nil, errors.New("Invalid EAP TLS Message")
+	}
+	packet := eap.Packet(msg.GetPayload())
+	sid := msg.GetCtx().GetSessionId()
+	switch packet.Type() {
+	case uint8(protos.EapType_Identity):
+		if len(sid) == 0 {
+			return &protos.Eap{Payload: packet.Failure(), Ctx: msg.Ctx}, errors.New("Missing EAP-TLS Session ID")
+		}
+		if err := p.start(sid); err != nil {
+			return &protos.Eap{Payload: packet.Failure(), Ctx: msg.Ctx}, err
+		}
+		return &protos.Eap{Payload: newRequest(packet.Identifier()+1, FlagStart, nil), Ctx: msg.Ctx}, nil
+	case TYPE:
+		s := p.get(sid)
+		if s == nil {
+			return &protos.Eap{Payload: packet.Failure(), Ctx: msg.Ctx}, fmt.Errorf(
+				"No EAP-TLS handshake of session %s", sid)
+		}
+		resp, err := s.handle(packet, msg.Ctx)
+		if err != nil {
+			log.Printf("EAP-TLS authentication of session %s failed: %v", sid, err)
+			p.remove(sid)
+			return &protos.Eap{Payload: packet.Failure(), Ctx: msg.Ctx}, nil
+		}
+		if resp.IsSuccess() {
+			p.remove(sid)
+		}
+		return &protos.Eap{Payload: resp, Ctx: msg.Ctx}, nil
+	default:
+		return &protos.Eap{Payload: packet.Failure(), Ctx: msg.Ctx}, fmt.Errorf(
+			"Unexpected EAP Method Type for EAP-TLS: %d", packet.Type())
+	}
+}
+
+// start starts a new TLS handshake of the session, replacing the session's previous one, if any
+func (p *providerImpl) start(sid string) error {
+	s, err := newSession(p.tlsConfig, p.mapIdentity, p.fragmentSize, p.timeout)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	p.mu.Lock()
+	p.purge(now)
+	previous := p.sessions[sid]
+	p.sessions[sid] = s
+	p.mu.Unlock()
+	if previous != nil {
+		previous.close()
+	}
+	return nil
+}
+
+func (p *providerImpl) get(sid string) *session {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.sessions[sid]
+}
+
+func (p *providerImpl) remove(sid string) {
+	p.mu.Lock()
+	s := p.sessions[sid]
+	delete(p.sessions, sid)
+	p.mu.Unlock()
+	if s != nil {
+		s.close()
+	}
+}
+
+// purge closes the sessions idle past the session timeout, at most once per session timeout. It must be called with
+// the lock held
+func (p *providerImpl) purge(now time.Time) {
+	if now.Before(p.nextPurge) {
+		return
+	}
+	p.nextPurge = now.Add(p.timeout)
+	for sid, s := range p.sessions {
+		if s.idleSince(now) >= p.timeout {
+			delete(p.sessions, sid)
+			go s.close()
+		}
+	}
+}
+
+// newRequest returns EAP-TLS request of the flags & TLS data
+func newRequest(identifier, flags uint8, data []byte) eap.Packet {
+	typeData := make([]byte, 0, 2+len(data))
+	typeData = append(typeData, TYPE, flags)
+	return eap.NewPacket(eap.RequestCode, identifier, append(typeData, data...))
+}
+
+// parseResponse returns the flags, TLS Message Length (if included) & TLS data of EAP-TLS response type data
+func parseResponse(typeData []byte) (flags uint8, length uint32, data []byte, err error) {
+	if len(typeData) < 1 {
+		return 0, 0, nil, errors.New("Missing EAP-TLS Flags")
+	}
+	flags, data = typeData[0], typeData[1:]
+	if flags&FlagLength != 0 {
+		if len(data) < 4 {
+			return flags, 0, nil, errors.New("Missing EAP-TLS Message Length")
+		}
+		length, data = binary.BigEndian.Uint32(data), data[4:]
+	}
+	return flags, length, data, nil
+}
@@ -0,0 +1,235 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package tls
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	cryptotls "crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/binary"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"magma/feg/gateway/services/aaa/protos"
+	"magma/feg/gateway/services/eap"
+)
+
+const peerFragmentSize = 300
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCA(t *testing.T, name string) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+	return &testCA{cert: cert, key: key}
+}
+
+// issue returns PEM certificate & key of the subject
+func (ca *testCA) issue(t *testing.T, subject string, usage x509.ExtKeyUsage) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: subject},
+		DNSNames:     []string{subject},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	assert.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+}
+
+// testPeer is an EAP-TLS peer running crypto/tls client handshake over the same in-memory transport as the provider
+type testPeer struct {
+	conn      *cryptotls.Conn
+	transport *transport
+	handshake chan error
+}
+
+func newTestPeer(t *testing.T, ca *testCA, certPEM, keyPEM []byte) *testPeer {
+	cert, err := cryptotls.X509KeyPair(certPEM, keyPEM)
+	assert.NoError(t, err)
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	tr := newTransport()
+	peer := &testPeer{
+		conn: cryptotls.Client(tr, &cryptotls.Config{
+			Certificates: []cryptotls.Certificate{cert},
+			RootCAs:      roots,
+			ServerName:   "aaa.example.com",
+			MaxVersion:   cryptotls.VersionTLS12,
+		}),
+		transport: tr,
+		handshake: make(chan error, 1),
+	}
+	go func() {
+		peer.handshake <- peer.conn.Handshake()
+	}()
+	return peer
+}
+
+// step passes the server's message to the handshake, if any, & returns the peer's next message
+func (peer *testPeer) step(t *testing.T, msg []byte) (out []byte, completed bool) {
+	if msg != nil {
+		peer.transport.in <- msg
+	}
+	select {
+	case <-peer.transport.idle:
+	case err := <-peer.handshake:
+		assert.NoError(t, err)
+		completed = true
+	case <-time.After(5 * time.Second):
+		t.Fatal("EAP-TLS peer handshake timed out")
+	}
+	return peer.transport.flush(), completed
+}
+
+// authenticate runs EAP-TLS authentication of the peer & returns the provider's last response
+func authenticate(t *testing.T, p *providerImpl, peer *testPeer) *protos.Eap {
+	ctx := &protos.Context{SessionId: "sid1"}
+	identity := append([]byte{uint8(protos.EapType_Identity)}, "anonymous@wlan.example.com"...)
+	resp, err := p.Handle(&protos.Eap{Payload: eap.NewPacket(eap.ResponseCode, 1, identity), Ctx: ctx})
+	assert.NoError(t, err)
+	start := eap.Packet(resp.GetPayload())
+	assert.Equal(t, []byte{FlagStart}, start.TypeData())
+
+	out, _ := peer.step(t, nil)
+	var (
+		rx        []byte
+		completed bool
+	)
+	for i := 0; i < 100; i++ {
+		id := eap.Packet(resp.GetPayload()).Identifier()
+		var flags uint8
+		fragment := out
+		if len(out) > peerFragmentSize {
+			fragment, flags = out[:peerFragmentSize], FlagMore
+		}
+		out = out[len(fragment):]
+		resp, err = p.Handle(&protos.Eap{
+			Payload: eap.NewPacket(eap.ResponseCode, id, append([]byte{TYPE, flags}, fragment...)), Ctx: ctx})
+		assert.NoError(t, err)
+		packet := eap.Packet(resp.GetPayload())
+		if packet.Code() != eap.RequestCode {
+			return resp
+		}
+		assert.Equal(t, id+1, packet.Identifier())
+		reqFlags, _, data, err := parseResponse(packet.TypeData())
+		assert.NoError(t, err)
+		if flags&FlagMore != 0 {
+			assert.Empty(t, data, "acknowledgement of the peer's fragment")
+			continue
+		}
+		rx = append(rx, data...)
+		if reqFlags&FlagMore == 0 && !completed {
+			out, completed = peer.step(t, rx)
+			rx = nil
+		}
+	}
+	t.Fatal("EAP-TLS authentication didn't end")
+	return nil
+}
+
+func TestEAPTLSProvider(t *testing.T) {
+	dir, err := ioutil.TempDir("", "eap_tls")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	ca := newTestCA(t, "Test CA")
+	serverCert, serverKey := ca.issue(t, "aaa.example.com", x509.ExtKeyUsageServerAuth)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "server.crt"), serverCert, 0600))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "server.key"), serverKey, 0600))
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw})
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "ca.pem"), caPEM, 0600))
+	method, err := New(Config{
+		ServerCert:   filepath.Join(dir, "server.crt"),
+		ServerKey:    filepath.Join(dir, "server.key"),
+		CABundle:     filepath.Join(dir, "ca.pem"),
+		FragmentSize: 200,
+	})
+	assert.NoError(t, err)
+	p := method.(*providerImpl)
+	assert.Equal(t, TYPE, p.EAPType())
+
+	// the client's certificate of a trusted CA authenticates the session
+	clientCert, clientKey := ca.issue(t, "001010000000001@wlan.example.com", x509.ExtKeyUsageClientAuth)
+	peer := newTestPeer(t, ca, clientCert, clientKey)
+	resp := authenticate(t, p, peer)
+	assert.True(t, eap.Packet(resp.GetPayload()).IsSuccess())
+	assert.Equal(t, "001010000000001@wlan.example.com", resp.GetCtx().GetIdentity())
+	assert.Equal(t, "001010000000001", resp.GetCtx().GetImsi())
+	state := peer.conn.ConnectionState()
+	keys, err := state.ExportKeyingMaterial(mskLabel, nil, 2*mskLen)
+	assert.NoError(t, err)
+	assert.Equal(t, keys[:mskLen], resp.GetCtx().GetMsk())
+	assert.Nil(t, p.get("sid1"))
+
+	// the identity map rejects unknown identities
+	p.mapIdentity = IMSIMap{"user@wlan.example.com": "001010000000002"}.MapIdentity
+	resp = authenticate(t, p, newTestPeer(t, ca, clientCert, clientKey))
+	assert.Equal(t, uint8(eap.FailureCode), eap.Packet(resp.GetPayload()).Code())
+	p.mapIdentity = SubjectIdentity
+
+	// certificates of untrusted CAs are rejected
+	otherCA := newTestCA(t, "Other CA")
+	otherCert, otherKey := otherCA.issue(t, "001010000000003", x509.ExtKeyUsageClientAuth)
+	resp = authenticate(t, p, newTestPeer(t, ca, otherCert, otherKey))
+	assert.Equal(t, uint8(eap.FailureCode), eap.Packet(resp.GetPayload()).Code())
+	assert.Empty(t, resp.GetCtx().GetMsk())
+	assert.Nil(t, p.get("sid1"))
+}
+
+func TestNextFragment(t *testing.T) {
+	s := &session{tx: make([]byte, 250), fragmentSize: 100}
+	first := s.nextFragment(2)
+	flags, length, data, err := parseResponse(first.TypeData())
+	assert.NoError(t, err)
+	assert.Equal(t, FlagLength|FlagMore, flags)
+	assert.Equal(t, uint32(250), length)
+	assert.Len(t, data, 100)
+
+	second := s.nextFragment(3)
+	assert.Equal(t, []byte{FlagMore}, second.TypeData()[:1])
+	last := s.nextFragment(4)
+	assert.Equal(t, uint8(0), last.TypeData()[0])
+	assert.Len(t, last.TypeData(), 51)
+	assert.Equal(t, len(s.tx), s.txSent)
+	assert.Equal(t, uint32(250), binary.BigEndian.Uint32(first.TypeData()[1:5]))
+}
@@ -8,6 +8,8 @@ LICENSE file in the root directory of this source tree.
 
 package credit_control
 
+import "fmt"
+
 type CreditRequestType uint8
 
 const (
@@ -53,3 +55,26 @@ func GetRequestKey(ns RequestKeyNamespace, sessionID string, requestNumber uint3
 		RequestNumber: requestNumber,
 	}
 }
+
+// FailureHandling is the handling of the sessions created while their Gx or Gy server fails, like the
+// Credit-Control-Failure-Handling AVP (RFC 4006)
+type FailureHandling uint8
+
+const (
+	// FailureTerminate - the sessions aren't created, the default
+	FailureTerminate FailureHandling = iota
+	// FailureContinue - the sessions are created without the failed server
+	FailureContinue
+)
+
+// ParseFailureHandling returns the failure handling of its name (terminate|continue), empty - terminate
+func ParseFailureHandling(name string) (FailureHandling, error) {
+	switch name {
+	case "", "terminate":
+		return FailureTerminate, nil
+	case "continue":
+		return FailureContinue, nil
+	default:
+		return FailureTerminate, fmt.Errorf("Invalid failure handling: %s", name)
+	}
+}
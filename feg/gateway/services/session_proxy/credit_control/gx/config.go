@@ -33,9 +33,11 @@ const (
 	PCRF91CompliantEnv      = "PCRF_91_COMPLIANT"
 	DisableDestHostEnv      = "DISABLE_DEST_HOST"
 	DisableEUIIPv6IfNoIPEnv = "DISABLE_EUI64_IPV6_IF_NO_IP"
+	GxFailureHandlingEnv    = "GX_FAILURE_HANDLING"
 
 	PCRF91CompliantFlag      = "pcrf_91_compliant"
 	DisableEUIIPv6IfNoIPFlag = "disable_eui64_ipv6_prefix"
+	GxFailureHandlingFlag    = "gx_failure_handling"
 )
 
 var (
@@ -43,8 +45,21 @@ var (
 		PCRF91CompliantFlag, false, "Set to support 29.212 release 9.1 compliant PCRF")
 	disableEUIIpIfEmpty = flag.Bool(
 		DisableEUIIPv6IfNoIPFlag, false, "Don't use MAC based EUI-64 IPv6 address for Gx CCR if IP is not provided")
+	_ = flag.String(
+		GxFailureHandlingFlag, "", "Handling of sessions whose initial policy request fails (terminate|continue)")
 )
 
+// GetFailureHandling returns the handling of sessions whose initial policy request fails based on the flags or
+// environment variables, the sessions of continue handling get their requested static rules only
+func GetFailureHandling() credit_control.FailureHandling {
+	handling, err := credit_control.ParseFailureHandling(
+		diameter.GetValueOrEnv(GxFailureHandlingFlag, GxFailureHandlingEnv, ""))
+	if err != nil {
+		log.Printf("Invalid Gx failure handling: %v, sessions will be terminated", err)
+	}
+	return handling
+}
+
 // GetPCRFConfiguration returns the server configuration for the set PCRF
 func GetPCRFConfiguration() *diameter.DiameterServerConfig {
 	configsPtr := &mconfig.SessionProxyConfig{}
@@ -11,8 +11,6 @@ package gx
 import (
 	"math/rand"
 	"net"
-	"os"
-	"strings"
 	"time"
 
 	"github.com/fiorix/go-diameter/diam"
@@ -22,6 +20,7 @@ import (
 
 	"magma/feg/gateway/diameter"
 	"magma/feg/gateway/services/session_proxy/credit_control"
+	"magma/feg/gateway/settings"
 )
 
 // PolicyClient is an interface to define something that sends requests over Gx.
@@ -57,25 +56,12 @@ func NewConnectedGxClient(
 
 	return &GxClient{
 		diamClient:          diamClient,
-		pcrf91Compliant:     *pcrf91Compliant || isThruthy(os.Getenv(PCRF91CompliantEnv)),
-		dontUseEUIIpIfEmpty: *disableEUIIpIfEmpty || isThruthy(os.Getenv(DisableEUIIPv6IfNoIPEnv)),
+		pcrf91Compliant:     *pcrf91Compliant || settings.EnvBool(PCRF91CompliantEnv, false),
+		dontUseEUIIpIfEmpty: *disableEUIIpIfEmpty || settings.EnvBool(DisableEUIIPv6IfNoIPEnv, false),
 	}
 
 }
 
-// isThruthy returns true for any value not "false", "0", "no..."
-func isThruthy(value string) bool {
-	value = strings.TrimSpace(value)
-	if len(value) == 0 {
-		return false
-	}
-	value = strings.ToLower(value)
-	if value == "0" || strings.HasPrefix(value, "false") || strings.HasPrefix(value, "no") {
-		return false
-	}
-	return true
-}
-
 // NewGxClient contructs a new GxClient with the magma diameter settings
 func NewGxClient(
 	clientCfg *diameter.DiameterClientConfig,
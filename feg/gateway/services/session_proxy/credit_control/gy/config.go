@@ -28,6 +28,7 @@ const (
 	GyDiamRealmEnv          = "GY_DIAM_REALM"
 	GyDiamProductEnv        = "GY_DIAM_PRODUCT"
 	GyInitMethodEnv         = "GY_INIT_METHOD"
+	GyFailureHandlingEnv    = "GY_FAILURE_HANDLING"
 	GyLocalAddr             = "GY_LOCAL_ADDR"
 	OCSHostEnv              = "OCS_HOST"
 	OCSRealmEnv             = "OCS_REALM"
@@ -36,12 +37,15 @@ const (
 	DisableDestHostEnv      = "DISABLE_DEST_HOST"
 
 	GyInitMethodFlag         = "gy_init_method"
+	GyFailureHandlingFlag    = "gy_failure_handling"
 	OCSApnOverwriteFlag      = "ocs_apn_overwrite"
 	OCSServiceIdentifierFlag = "ocs_service_identifier_overwrite"
 )
 
 var (
 	_ = flag.String(GyInitMethodFlag, "", "Gy init method (per_key|per_session)")
+	_ = flag.String(
+		GyFailureHandlingFlag, "", "Handling of sessions whose initial credit request fails (terminate|continue)")
 	_ = flag.String(OCSApnOverwriteFlag, "", "OCS APN to use instead of request's APN")
 	_ = flag.String(OCSServiceIdentifierFlag, "", "OCS ServiceIdentifier to use in Gy requests")
 )
@@ -82,6 +86,17 @@ func GetInitMethod() InitMethod {
 	return initMethod
 }
 
+// GetFailureHandling returns the handling of sessions whose initial credit request fails based on the flags or
+// environment variables, the sessions of continue handling bypass Gy
+func GetFailureHandling() credit_control.FailureHandling {
+	handling, err := credit_control.ParseFailureHandling(
+		diameter.GetValueOrEnv(GyFailureHandlingFlag, GyFailureHandlingEnv, ""))
+	if err != nil {
+		log.Printf("Invalid Gy failure handling: %v, sessions will be terminated", err)
+	}
+	return handling
+}
+
 // GetOCSConfiguration returns the server configuration for the set OCS
 func GetOCSConfiguration() *diameter.DiameterServerConfig {
 	configsPtr := &mconfig.SessionProxyConfig{}
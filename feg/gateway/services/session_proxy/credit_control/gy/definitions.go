@@ -63,6 +63,8 @@ type CreditControlRequest struct {
 	Msisdn        []byte
 	Qos           *QosRequestInfo
 	Credits       []*UsedCredits
+	// ChargingCharacteristics - 3GPP charging characteristics of the session (TS 32.298), e.g. 0800, empty - not sent
+	ChargingCharacteristics string
 }
 
 type QosRequestInfo struct {
@@ -295,6 +295,11 @@ func getServiceInfoAvp(server *diameter.DiameterServerConfig, request *CreditCon
 	if len(request.GcID) > 0 {
 		psInfoGrp.AddAVP(diam.NewAVP(avp.TGPPChargingID, avp.Vbit, diameter.Vendor3GPP, datatype.OctetString(request.GcID)))
 	}
+	if len(request.ChargingCharacteristics) > 0 {
+		psInfoGrp.AddAVP(diam.NewAVP(
+			avp.TGPPChargingCharacteristics, avp.Vbit, diameter.Vendor3GPP,
+			datatype.UTF8String(request.ChargingCharacteristics)))
+	}
 	/********************** TBD - doesn't work with current TASA OCS*********************
 	if request.Qos != nil {
 		qosGrp := &diam.GroupedAVP{
@@ -65,19 +65,20 @@ func getCCRInitRequest(
 	}
 
 	return &gy.CreditControlRequest{
-		SessionID:     pReq.SessionId,
-		RequestNumber: 0,
-		IMSI:          imsi,
-		UeIPV4:        pReq.UeIpv4,
-		SpgwIPV4:      pReq.SpgwIpv4,
-		Apn:           pReq.Apn,
-		Msisdn:        pReq.Msisdn,
-		Imei:          pReq.Imei,
-		PlmnID:        pReq.PlmnId,
-		UserLocation:  pReq.UserLocation,
-		GcID:          pReq.GcId,
-		Qos:           qos,
-		Type:          credit_control.CRTInit,
+		SessionID:               pReq.SessionId,
+		RequestNumber:           0,
+		IMSI:                    imsi,
+		UeIPV4:                  pReq.UeIpv4,
+		SpgwIPV4:                pReq.SpgwIpv4,
+		Apn:                     pReq.Apn,
+		Msisdn:                  pReq.Msisdn,
+		Imei:                    pReq.Imei,
+		PlmnID:                  pReq.PlmnId,
+		UserLocation:            pReq.UserLocation,
+		GcID:                    pReq.GcId,
+		Qos:                     qos,
+		Type:                    credit_control.CRTInit,
+		ChargingCharacteristics: pReq.ChargingCharacteristics,
 	}
 }
 
@@ -109,20 +110,21 @@ func getCCRInitialCreditRequest(
 		usedCredits = append(usedCredits, &gy.UsedCredits{RatingGroup: key})
 	}
 	return &gy.CreditControlRequest{
-		SessionID:     pReq.SessionId,
-		RequestNumber: 1,
-		IMSI:          imsi,
-		UeIPV4:        pReq.UeIpv4,
-		SpgwIPV4:      pReq.SpgwIpv4,
-		Apn:           pReq.Apn,
-		Msisdn:        pReq.Msisdn,
-		Imei:          pReq.Imei,
-		PlmnID:        pReq.PlmnId,
-		UserLocation:  pReq.UserLocation,
-		GcID:          pReq.GcId,
-		Qos:           qos,
-		Credits:       usedCredits,
-		Type:          msgType,
+		SessionID:               pReq.SessionId,
+		RequestNumber:           1,
+		IMSI:                    imsi,
+		UeIPV4:                  pReq.UeIpv4,
+		SpgwIPV4:                pReq.SpgwIpv4,
+		Apn:                     pReq.Apn,
+		Msisdn:                  pReq.Msisdn,
+		Imei:                    pReq.Imei,
+		PlmnID:                  pReq.PlmnId,
+		UserLocation:            pReq.UserLocation,
+		GcID:                    pReq.GcId,
+		Qos:                     qos,
+		Credits:                 usedCredits,
+		Type:                    msgType,
+		ChargingCharacteristics: pReq.ChargingCharacteristics,
 	}
 }
 
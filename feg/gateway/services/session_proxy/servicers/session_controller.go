@@ -16,6 +16,7 @@ import (
 	fegprotos "magma/feg/cloud/go/protos"
 	"magma/feg/gateway/diameter"
 	"magma/feg/gateway/policydb"
+	"magma/feg/gateway/services/session_proxy/credit_control"
 	"magma/feg/gateway/services/session_proxy/credit_control/gx"
 	"magma/feg/gateway/services/session_proxy/credit_control/gy"
 	"magma/feg/gateway/services/session_proxy/metrics"
@@ -44,6 +45,9 @@ type SessionControllerConfig struct {
 	PCRFConfig     *diameter.DiameterServerConfig
 	RequestTimeout time.Duration
 	InitMethod     gy.InitMethod
+	// GxFailureHandling & GyFailureHandling - handling of the sessions whose initial Gx & Gy requests fail
+	GxFailureHandling credit_control.FailureHandling
+	GyFailureHandling credit_control.FailureHandling
 }
 
 type ruleTiming struct {
@@ -77,14 +81,20 @@ func (srv *CentralSessionController) CreateSession(
 	glog.V(2).Info("Trying to create session")
 	imsi := removeSidPrefix(request.Subscriber.Id)
 	sessionID := request.SessionId
+	gxMode, gyMode := protos.LocalCreateSessionResponse_NORMAL, protos.LocalCreateSessionResponse_NORMAL
 	gxCCAInit, err := srv.sendInitialGxRequest(imsi, request)
 	metrics.UpdateGxRecentRequestMetrics(err)
 	if err != nil {
 		metrics.PcrfCcrInitSendFailures.Inc()
 		glog.Errorf("Failed to send initial Gx request: %s", err)
-		return nil, err
+		if srv.cfg.GxFailureHandling != credit_control.FailureContinue {
+			return nil, err
+		}
+		// the session is created in Gx failure mode with its requested static rules only
+		gxCCAInit, gxMode = &gx.CreditControlAnswer{}, protos.LocalCreateSessionResponse_FAILURE
+	} else {
+		metrics.PcrfCcrInitRequests.Inc()
 	}
-	metrics.PcrfCcrInitRequests.Inc()
 
 	var ruleNames []string
 	var ruleDefs []*gx.RuleDefinition
@@ -95,6 +105,8 @@ func (srv *CentralSessionController) CreateSession(
 		}
 		ruleDefs = append(ruleDefs, rule.RuleDefinitions...)
 	}
+	requestedRules := srv.getRequestedRuleIDs(request)
+	ruleNames = append(ruleNames, requestedRules...)
 
 	policyRules := getPolicyRulesFromDefinitions(ruleDefs)
 	keys, err := srv.dbClient.GetChargingKeysForRules(ruleNames, policyRules)
@@ -102,46 +114,92 @@ func (srv *CentralSessionController) CreateSession(
 		glog.Errorf("Failed to get charging keys for rules: %s", err)
 		return nil, err
 	}
-	keys = removeDuplicateChargingKeys(keys)
+	// rating groups requested by the session's creator (e.g. per SSID) are charged without their own rules
+	keys = removeDuplicateChargingKeys(append(keys, request.GetRatingGroups()...))
 	credits := []*protos.CreditUpdateResponse{}
 
 	if len(keys) > 0 {
-		if srv.cfg.InitMethod == gy.PerSessionInit {
-			_, err = srv.sendSingleCreditRequest(getCCRInitRequest(imsi, request))
-			metrics.UpdateGyRecentRequestMetrics(err)
-			if err != nil {
-				metrics.OcsCcrInitSendFailures.Inc()
-				glog.Errorf("Failed to send first single credit request: %s", err)
+		credits, err = srv.getInitialCredits(imsi, request, keys)
+		if err != nil {
+			if srv.cfg.GyFailureHandling != credit_control.FailureContinue {
 				return nil, err
 			}
-			metrics.OcsCcrInitRequests.Inc()
+			// the session is created without credits & bypasses Gy
+			credits, gyMode = []*protos.CreditUpdateResponse{}, protos.LocalCreateSessionResponse_BYPASS
 		}
-
-		updateRequest := getCCRInitialCreditRequest(imsi, request, keys, srv.cfg.InitMethod)
-		ans, err := srv.sendSingleCreditRequest(updateRequest)
-		metrics.UpdateGyRecentRequestMetrics(err)
-		if err != nil {
-			metrics.OcsCcrInitSendFailures.Inc()
-			glog.Errorf("Failed to send second single credit request: %s", err)
-			return nil, err
-		}
-		metrics.OcsCcrInitRequests.Inc()
-		credits = getInitialCreditResponsesFromCCA(ans, updateRequest)
 	}
 
 	staticRules, dynamicRules := gx.ParseRuleInstallAVPs(
 		srv.dbClient,
 		gxCCAInit.RuleInstallAVP,
 	)
+	staticRules = addRequestedStaticRules(staticRules, requestedRules)
 
 	return &protos.CreateSessionResponse{
 		Credits:       credits,
 		StaticRules:   staticRules,
 		DynamicRules:  dynamicRules,
 		UsageMonitors: getUsageMonitorsFromCCA(imsi, sessionID, gxCCAInit),
+		GxMode:        gxMode,
+		GyMode:        gyMode,
 	}, nil
 }
 
+// getInitialCredits requests the initial credits of the session's charging keys from the OCS
+func (srv *CentralSessionController) getInitialCredits(
+	imsi string,
+	request *protos.CreateSessionRequest,
+	keys []uint32,
+) ([]*protos.CreditUpdateResponse, error) {
+	if srv.cfg.InitMethod == gy.PerSessionInit {
+		_, err := srv.sendSingleCreditRequest(getCCRInitRequest(imsi, request))
+		metrics.UpdateGyRecentRequestMetrics(err)
+		if err != nil {
+			metrics.OcsCcrInitSendFailures.Inc()
+			glog.Errorf("Failed to send first single credit request: %s", err)
+			return nil, err
+		}
+		metrics.OcsCcrInitRequests.Inc()
+	}
+
+	updateRequest := getCCRInitialCreditRequest(imsi, request, keys, srv.cfg.InitMethod)
+	ans, err := srv.sendSingleCreditRequest(updateRequest)
+	metrics.UpdateGyRecentRequestMetrics(err)
+	if err != nil {
+		metrics.OcsCcrInitSendFailures.Inc()
+		glog.Errorf("Failed to send second single credit request: %s", err)
+		return nil, err
+	}
+	metrics.OcsCcrInitRequests.Inc()
+	return getInitialCreditResponsesFromCCA(ans, updateRequest), nil
+}
+
+// getRequestedRuleIDs returns the static rules requested by the session's creator, the requested rule base
+// names are resolved to their rules
+func (srv *CentralSessionController) getRequestedRuleIDs(request *protos.CreateSessionRequest) []string {
+	ruleIDs := append([]string{}, request.GetStaticRuleIds()...)
+	if len(request.GetRuleBaseNames()) > 0 {
+		ruleIDs = append(ruleIDs, srv.dbClient.GetRuleIDsForBaseNames(request.GetRuleBaseNames())...)
+	}
+	return ruleIDs
+}
+
+// addRequestedStaticRules adds the requested static rules not installed by the PCRF to the session's static rules,
+// the requested rules are active for the session's lifetime
+func addRequestedStaticRules(staticRules []*protos.StaticRuleInstall, ruleIDs []string) []*protos.StaticRuleInstall {
+	installed := make(map[string]bool, len(staticRules))
+	for _, rule := range staticRules {
+		installed[rule.RuleId] = true
+	}
+	for _, id := range ruleIDs {
+		if !installed[id] {
+			staticRules = append(staticRules, &protos.StaticRuleInstall{RuleId: id})
+			installed[id] = true
+		}
+	}
+	return staticRules
+}
+
 func removeDuplicateChargingKeys(keysIn []uint32) []uint32 {
 	keysOut := []uint32{}
 	keyMap := make(map[uint32]bool)
@@ -315,6 +315,143 @@ func standardUsageTest(
 	assert.Equal(t, &orcprotos.Void{}, void)
 }
 
+func TestSessionControllerRequestedStaticRules(t *testing.T) {
+	mocks := &sessionMocks{
+		gy:       &MockCreditClient{},
+		gx:       &MockPolicyClient{},
+		policydb: &MockPolicyDBClient{},
+	}
+	srv := servicers.NewCentralSessionController(
+		mocks.gy,
+		mocks.gx,
+		mocks.policydb,
+		getTestConfig(gy.PerKeyInit),
+	)
+
+	mocks.gx.On("SendCreditControlRequest", mock.Anything, mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		done := args.Get(1).(chan interface{})
+		request := args.Get(2).(*gx.CreditControlRequest)
+		done <- &gx.CreditControlAnswer{
+			ResultCode:     uint32(diameter.SuccessCode),
+			SessionID:      request.SessionID,
+			RequestNumber:  request.RequestNumber,
+			RuleInstallAVP: []*gx.RuleInstallAVP{{RuleNames: []string{"static_rule_1"}}},
+		}
+	}).Once()
+	mocks.policydb.On("GetRuleIDsForBaseNames", []string{"wifi_base"}).Return([]string{"base_rule_1"}).Once()
+	mocks.policydb.On(
+		"GetChargingKeysForRules",
+		[]string{"static_rule_1", "static_rule_1", "wifi_rule", "base_rule_1"},
+		mock.Anything,
+	).Return([]uint32{}, nil).Once()
+
+	createResponse, err := srv.CreateSession(context.Background(), &protos.CreateSessionRequest{
+		Subscriber:    &protos.SubscriberID{Id: IMSI1},
+		SessionId:     "00101-1234",
+		StaticRuleIds: []string{"static_rule_1", "wifi_rule"},
+		RuleBaseNames: []string{"wifi_base"},
+	})
+	assert.NoError(t, err)
+	mocks.gx.AssertExpectations(t)
+	mocks.policydb.AssertExpectations(t)
+
+	ruleIDs := []string{}
+	for _, staticRule := range createResponse.StaticRules {
+		ruleIDs = append(ruleIDs, staticRule.RuleId)
+	}
+	assert.ElementsMatch(t, []string{"static_rule_1", "wifi_rule", "base_rule_1"}, ruleIDs)
+	assert.Empty(t, createResponse.Credits)
+}
+
+func TestSessionControllerRequestedRatingGroups(t *testing.T) {
+	mocks := &sessionMocks{
+		gy:       &MockCreditClient{},
+		gx:       &MockPolicyClient{},
+		policydb: &MockPolicyDBClient{},
+	}
+	srv := servicers.NewCentralSessionController(
+		mocks.gy,
+		mocks.gx,
+		mocks.policydb,
+		getTestConfig(gy.PerKeyInit),
+	)
+
+	mocks.gx.On("SendCreditControlRequest", mock.Anything, mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		done := args.Get(1).(chan interface{})
+		request := args.Get(2).(*gx.CreditControlRequest)
+		done <- &gx.CreditControlAnswer{
+			ResultCode:     uint32(diameter.SuccessCode),
+			SessionID:      request.SessionID,
+			RequestNumber:  request.RequestNumber,
+			RuleInstallAVP: []*gx.RuleInstallAVP{{RuleNames: []string{"static_rule_1"}}},
+		}
+	}).Once()
+	mocks.policydb.On("GetChargingKeysForRules", []string{"static_rule_1"}, mock.Anything).Return([]uint32{1}, nil).Once()
+	// the requested rating groups are requested along with the rules' rating groups, with the charging characteristics
+	mocks.gy.On(
+		"SendCreditControlRequest",
+		mock.Anything,
+		mock.Anything,
+		mock.MatchedBy(func(request *gy.CreditControlRequest) bool {
+			ratingGroups := []uint32{}
+			for _, credit := range request.Credits {
+				ratingGroups = append(ratingGroups, credit.RatingGroup)
+			}
+			return request.Type == credit_control.CRTInit && request.ChargingCharacteristics == "0800" &&
+				assert.ObjectsAreEqual([]uint32{1, 42}, ratingGroups)
+		}),
+	).Return(nil).Run(returnDefaultGyResponse).Once()
+
+	createResponse, err := srv.CreateSession(context.Background(), &protos.CreateSessionRequest{
+		Subscriber:              &protos.SubscriberID{Id: IMSI1},
+		SessionId:               "00101-1234",
+		ChargingCharacteristics: "0800",
+		RatingGroups:            []uint32{42, 1},
+	})
+	assert.NoError(t, err)
+	mocks.gx.AssertExpectations(t)
+	mocks.gy.AssertExpectations(t)
+	mocks.policydb.AssertExpectations(t)
+	assert.Equal(t, 2, len(createResponse.Credits))
+}
+
+func TestSessionControllerFailureHandling(t *testing.T) {
+	mocks := &sessionMocks{
+		gy:       &MockCreditClient{},
+		gx:       &MockPolicyClient{},
+		policydb: &MockPolicyDBClient{},
+	}
+	cfg := getTestConfig(gy.PerKeyInit)
+	srv := servicers.NewCentralSessionController(mocks.gy, mocks.gx, mocks.policydb, cfg)
+	createRequest := &protos.CreateSessionRequest{
+		Subscriber:    &protos.SubscriberID{Id: IMSI1},
+		SessionId:     "00101-1234",
+		StaticRuleIds: []string{"wifi_rule"},
+	}
+
+	// sessions aren't created by default if the PCRF fails
+	mocks.gx.On("SendCreditControlRequest", mock.Anything, mock.Anything, mock.Anything).
+		Return(fmt.Errorf("PCRF unreachable")).Twice()
+	_, err := srv.CreateSession(context.Background(), createRequest)
+	assert.Error(t, err)
+
+	// sessions are created in Gx failure mode with their requested rules & bypass Gy if the OCS fails
+	cfg.GxFailureHandling, cfg.GyFailureHandling = credit_control.FailureContinue, credit_control.FailureContinue
+	mocks.policydb.On("GetChargingKeysForRules", []string{"wifi_rule"}, mock.Anything).Return([]uint32{1}, nil).Once()
+	mocks.gy.On("SendCreditControlRequest", mock.Anything, mock.Anything, mock.Anything).
+		Return(fmt.Errorf("OCS unreachable")).Once()
+	createResponse, err := srv.CreateSession(context.Background(), createRequest)
+	assert.NoError(t, err)
+	mocks.gx.AssertExpectations(t)
+	mocks.gy.AssertExpectations(t)
+	mocks.policydb.AssertExpectations(t)
+	assert.Equal(t, protos.LocalCreateSessionResponse_FAILURE, createResponse.GetGxMode())
+	assert.Equal(t, protos.LocalCreateSessionResponse_BYPASS, createResponse.GetGyMode())
+	assert.Equal(t, []*protos.StaticRuleInstall{{RuleId: "wifi_rule"}}, createResponse.GetStaticRules())
+	assert.Empty(t, createResponse.GetCredits())
+	assert.Empty(t, createResponse.GetUsageMonitors())
+}
+
 func TestSessionControllerTimeouts(t *testing.T) {
 	mocks := &sessionMocks{
 		gy:       &MockCreditClient{},
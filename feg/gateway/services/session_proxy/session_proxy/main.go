@@ -57,10 +57,12 @@ func main() {
 	initMethod := gy.GetInitMethod()
 
 	controllerCfg := &servicers.SessionControllerConfig{
-		OCSConfig:      gy.GetOCSConfiguration(),
-		PCRFConfig:     gx.GetPCRFConfiguration(),
-		RequestTimeout: 3 * time.Second,
-		InitMethod:     initMethod,
+		OCSConfig:         gy.GetOCSConfiguration(),
+		PCRFConfig:        gx.GetPCRFConfiguration(),
+		RequestTimeout:    3 * time.Second,
+		InitMethod:        initMethod,
+		GxFailureHandling: gx.GetFailureHandling(),
+		GyFailureHandling: gy.GetFailureHandling(),
 	}
 	cloudReg := registry.NewCloudRegistry()
 	policyDBClient, err := policydb.NewRedisPolicyDBClient(cloudReg)
@@ -133,6 +133,41 @@ func (swxCache *Impl) Put(ans *protos.AuthenticationAnswer) *protos.Authenticati
 	return &res
 }
 
+// Count returns the number of cached vectors of the user
+func (swxCache *Impl) Count(imsi string) int {
+	swxCache.mu.Lock()
+	defer swxCache.mu.Unlock()
+	if ent, found := swxCache.data.vectors[imsi]; found {
+		return len(ent.ans.SipAuthVectors)
+	}
+	return 0
+}
+
+// Add appends all ans vectors to the user's cached vectors (if any), the newer user profile replaces the cached one.
+// Unlike Put, Add doesn't extract a vector for the caller, it's used to replenish the cache ahead of authentications
+func (swxCache *Impl) Add(ans *protos.AuthenticationAnswer) {
+	if ans == nil || len(ans.UserName) == 0 || len(ans.SipAuthVectors) == 0 {
+		return
+	}
+	swxCache.mu.Lock()
+	defer swxCache.mu.Unlock()
+	ent, found := swxCache.data.vectors[ans.UserName]
+	if !found {
+		ent = &authEnt{lastUsed: time.Now(), ans: ans}
+		swxCache.data.vectors[ans.UserName] = ent
+		heap.Push(&swxCache.data, ent)
+		return
+	}
+	merged := *ans // copy answer
+	merged.SipAuthVectors = append(append([]*protos.AuthenticationAnswer_SIPAuthVector{},
+		ent.ans.SipAuthVectors...), ans.SipAuthVectors...)
+	if merged.UserProfile == nil {
+		merged.UserProfile = ent.ans.UserProfile
+	}
+	ent.ans, ent.lastUsed = &merged, time.Now()
+	heap.Fix(&swxCache.data, ent.idx)
+}
+
 // ClearAll removes all cached entities & re-initializes the cache
 func (swxCache *Impl) ClearAll() {
 	swxCache.mu.Lock()
@@ -72,3 +72,33 @@ func TestSwxCacheGC(t *testing.T) {
 	_, err = srv.StopService(context.Background(), &orcprotos.Void{})
 	assert.NoError(t, err)
 }
+
+func TestSwxCacheAdd(t *testing.T) {
+	cache, done := cache.NewExt(time.Minute, time.Hour)
+	defer func() { done <- struct{}{} }()
+
+	vector := func(i int) *protos.AuthenticationAnswer_SIPAuthVector {
+		return &protos.AuthenticationAnswer_SIPAuthVector{RandAutn: []byte(strconv.Itoa(i))}
+	}
+	assert.Equal(t, 0, cache.Count(test.BASE_IMSI))
+	cache.Add(&protos.AuthenticationAnswer{
+		UserName:       test.BASE_IMSI,
+		SipAuthVectors: []*protos.AuthenticationAnswer_SIPAuthVector{vector(1), vector(2)},
+		UserProfile:    &protos.AuthenticationAnswer_UserProfile{Msisdn: "12345"},
+	})
+	assert.Equal(t, 2, cache.Count(test.BASE_IMSI))
+
+	// added vectors are appended to the cached ones & used after them
+	cache.Add(&protos.AuthenticationAnswer{
+		UserName:       test.BASE_IMSI,
+		SipAuthVectors: []*protos.AuthenticationAnswer_SIPAuthVector{vector(3)},
+	})
+	assert.Equal(t, 3, cache.Count(test.BASE_IMSI))
+	for i := 1; i <= 3; i++ {
+		ans := cache.Get(test.BASE_IMSI)
+		assert.Equal(t, []byte(strconv.Itoa(i)), ans.GetSipAuthVectors()[0].GetRandAutn())
+		assert.Equal(t, "12345", ans.GetUserProfile().GetMsisdn())
+	}
+	assert.Equal(t, 0, cache.Count(test.BASE_IMSI))
+	assert.Nil(t, cache.Get(test.BASE_IMSI))
+}
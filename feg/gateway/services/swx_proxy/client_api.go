@@ -20,6 +20,7 @@ import (
 	"magma/feg/cloud/go/protos"
 	"magma/feg/gateway/registry"
 
+	"fbc/lib/go/retry"
 	"github.com/golang/glog"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
@@ -32,6 +33,17 @@ type swxProxyClient struct {
 	cc *grpc.ClientConn
 }
 
+// retrier retries failed Swx Proxy calls, no retries by default
+var retrier = retry.NoRetry
+
+// SetRetry sets the retries of failed Swx Proxy calls, nil disables retries
+func SetRetry(r *retry.Retrier) {
+	if r == nil {
+		r = retry.NoRetry
+	}
+	retrier = r
+}
+
 // getSwxProxyClient is a utility function to get a RPC connection to the
 // Swx Proxy service
 func getSwxProxyClient() (*swxProxyClient, error) {
@@ -65,7 +77,12 @@ func Authenticate(req *protos.AuthenticationRequest) (*protos.AuthenticationAnsw
 	if err != nil {
 		return nil, err
 	}
-	return cli.Authenticate(context.Background(), req)
+	var ans *protos.AuthenticationAnswer
+	err = retrier.Do(context.Background(), func(ctx context.Context) error {
+		ans, err = cli.Authenticate(ctx, req)
+		return err
+	})
+	return ans, err
 }
 
 // Register sends SAR (Code 301) over diameter connection with ServerAssignmentType
@@ -80,7 +97,12 @@ func Register(req *protos.RegistrationRequest) (*protos.RegistrationAnswer, erro
 	if err != nil {
 		return nil, err
 	}
-	return cli.Register(context.Background(), req)
+	var ans *protos.RegistrationAnswer
+	err = retrier.Do(context.Background(), func(ctx context.Context) error {
+		ans, err = cli.Register(ctx, req)
+		return err
+	})
+	return ans, err
 }
 
 // Deregister sends SAR (Code 301) over diameter connection with ServerAssignmentType
@@ -95,7 +117,12 @@ func Deregister(req *protos.RegistrationRequest) (*protos.RegistrationAnswer, er
 	if err != nil {
 		return nil, err
 	}
-	return cli.Deregister(context.Background(), req)
+	var ans *protos.RegistrationAnswer
+	err = retrier.Do(context.Background(), func(ctx context.Context) error {
+		ans, err = cli.Deregister(ctx, req)
+		return err
+	})
+	return ans, err
 }
 
 func verifyAuthenticationRequest(req *protos.AuthenticationRequest) error {
@@ -73,6 +73,13 @@ var (
 		Name: "unauthorized_auth_requests_total",
 		Help: "Total number of authentication requests for un-authorized users",
 	})
+	PrefetchRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "prefetch_requests_total",
+			Help: "Total number of auth vector prefetch requests, by result (fetched, skipped, failed)",
+		},
+		[]string{"result"},
+	)
 
 	// Latency Metrics
 	MARLatency = prometheus.NewSummary(prometheus.SummaryOpts{
@@ -105,7 +112,7 @@ var (
 func init() {
 	prometheus.MustRegister(MARRequests, MARSendFailures, SARRequests,
 		SARSendFailures, SwxTimeouts, SwxUnparseableMsg, SwxInvalidSessions,
-		SwxResultCodes, SwxExperimentalResultCodes, UnauthorizedAuthAttempts,
+		SwxResultCodes, SwxExperimentalResultCodes, UnauthorizedAuthAttempts, PrefetchRequests,
 		MARLatency, SARLatency, AuthLatency, RegisterLatency, DeregisterLatency)
 }
 
@@ -31,6 +31,9 @@ import (
 
 const MinRequestedVectors uint32 = 5
 
+// PrefetchLowWatermark - prefetch requests only replenish users' cached vectors when fewer than this remain
+const PrefetchLowWatermark = 2
+
 // AuthenticateImpl sends MAR over diameter connection,
 // waits (blocks) for MAA & returns its RPC representation
 func (s *swxProxy) AuthenticateImpl(req *protos.AuthenticationRequest) (*protos.AuthenticationAnswer, error) {
@@ -42,6 +45,9 @@ func (s *swxProxy) AuthenticateImpl(req *protos.AuthenticationRequest) (*protos.
 		return res, status.Errorf(codes.InvalidArgument, err.Error())
 	}
 	res.UserName = req.GetUserName()
+	if req.GetPrefetch() {
+		return res, s.prefetch(req)
+	}
 	shouldSendSar := s.config.VerifyAuthorization || req.GetRetrieveUserProfile()
 
 	if s.cache != nil {
@@ -111,6 +117,43 @@ func (s *swxProxy) AuthenticateImpl(req *protos.AuthenticationRequest) (*protos.
 	return res, err
 }
 
+// prefetch replenishes the cached vectors of the user when running low, so the user's next authentications
+// don't wait for the HSS
+func (s *swxProxy) prefetch(req *protos.AuthenticationRequest) error {
+	if s.cache == nil {
+		return status.Errorf(codes.FailedPrecondition, "Auth vectors cache is disabled, cannot prefetch vectors")
+	}
+	if s.cache.Count(req.GetUserName()) >= PrefetchLowWatermark {
+		metrics.PrefetchRequests.WithLabelValues("skipped").Inc()
+		return nil
+	}
+	fetchReq := *req
+	fetchReq.Prefetch, fetchReq.ResyncInfo = false, nil
+	if fetchReq.SipNumAuthVectors < MinRequestedVectors {
+		fetchReq.SipNumAuthVectors = MinRequestedVectors
+	}
+	maa, err := s.sendMAR(&fetchReq)
+	if err != nil {
+		metrics.PrefetchRequests.WithLabelValues("failed").Inc()
+		return err
+	}
+	ans := &protos.AuthenticationAnswer{
+		UserName:       req.GetUserName(),
+		SipAuthVectors: getSIPAuthenticationVectors(maa.SIPAuthDataItems),
+	}
+	if s.config.VerifyAuthorization || req.GetRetrieveUserProfile() {
+		profile, authorized, err := s.retrieveUserProfile(req.GetUserName())
+		if !authorized {
+			metrics.PrefetchRequests.WithLabelValues("failed").Inc()
+			return err
+		}
+		ans.UserProfile = profile
+	}
+	s.cache.Add(ans)
+	metrics.PrefetchRequests.WithLabelValues("fetched").Inc()
+	return nil
+}
+
 func (s *swxProxy) sendMAR(req *protos.AuthenticationRequest) (*MAA, error) {
 	sid := s.genSID()
 	ch := make(chan interface{})
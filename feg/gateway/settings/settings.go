@@ -0,0 +1,225 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package settings implements layered gateway service settings: service flags may be set by the command line,
+// environment variables, a settings file & the service's mconfig, in this precedence order. The effective settings
+// & their sources are recorded, so gateways' effective configuration is discoverable
+package settings
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"magma/orc8r/cloud/go/util"
+)
+
+// Source - source of a setting's effective value
+type Source string
+
+// Setting sources, in ascending precedence order
+const (
+	Default Source = "default"
+	Mconfig Source = "mconfig"
+	File    Source = "file"
+	Env     Source = "env"
+	Flag    Source = "flag"
+)
+
+// Redacted - dumped value of secret settings
+const Redacted = "<redacted>"
+
+// secretNameParts - settings with names containing any of the parts are redacted in effective configuration dumps
+var secretNameParts = []string{"token", "secret", "password", "key"}
+
+// Setting - a setting's effective value & its source
+type Setting struct {
+	Name   string
+	Value  string
+	Source Source
+}
+
+// Loader layers settings sources over a flag set's defaults
+type Loader struct {
+	envPrefix string
+	file      map[string]string
+	mconfig   map[string]string
+
+	mu        sync.RWMutex
+	effective []Setting
+}
+
+// New returns a settings loader, environment variables of flags are named <envPrefix>_<FLAG_NAME>, with flag name's
+// dashes & dots replaced by underscores
+func New(envPrefix string) *Loader {
+	return &Loader{envPrefix: envPrefix, file: map[string]string{}, mconfig: map[string]string{}}
+}
+
+// AddFile adds settings of the JSON settings file, the file is a JSON object of setting names to their values.
+// Settings of later added files override settings of earlier added ones
+func (l *Loader) AddFile(path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var raw map[string]interface{}
+	if err = json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("Invalid settings file %s: %v", path, err)
+	}
+	for name, v := range raw {
+		switch value := v.(type) {
+		case string:
+			l.file[name] = value
+		case bool, float64:
+			l.file[name] = fmt.Sprint(value)
+		default:
+			return fmt.Errorf("Invalid settings file %s: setting '%s' is not a string, number or boolean", path, name)
+		}
+	}
+	return nil
+}
+
+// AddMconfig adds settings of the service's mconfig. Values of settings not matching any flag are consumed by the
+// service directly, they are reported as mconfig settings of the effective configuration
+func (l *Loader) AddMconfig(values map[string]string) {
+	for name, value := range values {
+		l.mconfig[name] = value
+	}
+}
+
+// EnvName returns the environment variable name of the flag
+func (l *Loader) EnvName(flagName string) string {
+	name := strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(flagName))
+	if len(l.envPrefix) == 0 {
+		return name
+	}
+	return strings.ToUpper(l.envPrefix) + "_" + name
+}
+
+// boolFlag - flag.Value of boolean flags, the flag package's boolFlag interface
+type boolFlag interface {
+	IsBoolFlag() bool
+}
+
+// Apply sets the flag set's flags not set on the command line from the loader's sources & records the effective
+// settings. The flag set must be parsed
+func (l *Loader) Apply(fs *flag.FlagSet) error {
+	explicit := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { explicit[f.Name] = true })
+
+	var (
+		effective []Setting
+		errs      []string
+	)
+	known := map[string]bool{}
+	fs.VisitAll(func(f *flag.Flag) {
+		known[f.Name] = true
+		if explicit[f.Name] {
+			effective = append(effective, Setting{Name: f.Name, Value: f.Value.String(), Source: Flag})
+			return
+		}
+		value, source, ok := l.lookup(f.Name)
+		if !ok {
+			effective = append(effective, Setting{Name: f.Name, Value: f.Value.String(), Source: Default})
+			return
+		}
+		if bf, isBool := f.Value.(boolFlag); isBool && bf.IsBoolFlag() {
+			// boolean settings accept the same spellings as the services' boolean environment variables
+			if b, err := ParseBool(value); err == nil {
+				value = strconv.FormatBool(b)
+			}
+		}
+		if err := fs.Set(f.Name, value); err != nil {
+			errs = append(errs, fmt.Sprintf("%s setting '%s' => '%s': %v", source, f.Name, value, err))
+			return
+		}
+		log.Printf("Using %s setting: %s => %s", source, f.Name, redact(f.Name, value))
+		effective = append(effective, Setting{Name: f.Name, Value: f.Value.String(), Source: source})
+	})
+	for name := range l.file {
+		if !known[name] {
+			errs = append(errs, fmt.Sprintf("unknown file setting '%s'", name))
+		}
+	}
+	for name, value := range l.mconfig {
+		if !known[name] {
+			effective = append(effective, Setting{Name: name, Value: value, Source: Mconfig})
+		}
+	}
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return fmt.Errorf("Invalid settings: %s", strings.Join(errs, "; "))
+	}
+	sort.Slice(effective, func(i, j int) bool { return effective[i].Name < effective[j].Name })
+	l.mu.Lock()
+	l.effective = effective
+	l.mu.Unlock()
+	return nil
+}
+
+// Effective returns the effective settings recorded by the last Apply ordered by names, values of secret settings
+// are redacted
+func (l *Loader) Effective() []Setting {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	res := make([]Setting, len(l.effective))
+	for i, s := range l.effective {
+		s.Value = redact(s.Name, s.Value)
+		res[i] = s
+	}
+	return res
+}
+
+// lookup returns the flag's value from the highest precedence source which has it
+func (l *Loader) lookup(name string) (string, Source, bool) {
+	if value, ok := os.LookupEnv(l.EnvName(name)); ok {
+		return value, Env, true
+	}
+	if value, ok := l.file[name]; ok {
+		return value, File, true
+	}
+	if value, ok := l.mconfig[name]; ok {
+		return value, Mconfig, true
+	}
+	return "", Default, false
+}
+
+// ParseBool parses boolean setting values the way magma services parse their boolean environment variables (see
+// util.IsTruthy): empty, "0", "off", "false..." & "n..." values are false, all others are true
+func ParseBool(value string) (bool, error) {
+	if len(strings.TrimSpace(value)) == 0 {
+		return false, fmt.Errorf("Invalid boolean value: '%s'", value)
+	}
+	return util.IsTruthy(value), nil
+}
+
+// EnvBool returns the boolean value of the environment variable, or defaultValue if the variable is not set or empty
+func EnvBool(name string, defaultValue bool) bool {
+	res, err := ParseBool(os.Getenv(name))
+	if err != nil {
+		return defaultValue
+	}
+	return res
+}
+
+func redact(name, value string) string {
+	lower := strings.ToLower(name)
+	for _, part := range secretNameParts {
+		if strings.Contains(lower, part) && len(value) > 0 {
+			return Redacted
+		}
+	}
+	return value
+}
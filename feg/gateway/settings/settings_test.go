@@ -0,0 +1,129 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package settings_test
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"magma/feg/gateway/settings"
+)
+
+func TestApplyPrecedence(t *testing.T) {
+	dir, err := ioutil.TempDir("", "settings_test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "settings.json")
+	err = ioutil.WriteFile(path,
+		[]byte(`{"max_sessions": 100, "timeout": "5s", "enabled": true, "admin_token": "t0ken", "name": "file"}`), 0644)
+	assert.NoError(t, err)
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	maxSessions := fs.Int("max_sessions", 0, "")
+	timeout := fs.Duration("timeout", time.Second, "")
+	enabled := fs.Bool("enabled", false, "")
+	name := fs.String("name", "default", "")
+	fs.String("admin_token", "", "")
+	verbose := fs.Bool("verbose", false, "")
+	fs.String("untouched", "default", "")
+	assert.NoError(t, fs.Parse([]string{"-max_sessions=10"}))
+
+	os.Setenv("TEST_TIMEOUT", "7s")
+	defer os.Unsetenv("TEST_TIMEOUT")
+	os.Setenv("TEST_VERBOSE", "Yes")
+	defer os.Unsetenv("TEST_VERBOSE")
+
+	loader := settings.New("test")
+	assert.NoError(t, loader.AddFile(path))
+	loader.AddMconfig(map[string]string{"name": "mconfig", "IdleSessionTimeoutMs": "3000"})
+	assert.NoError(t, loader.Apply(fs))
+
+	assert.Equal(t, 10, *maxSessions)
+	assert.Equal(t, 7*time.Second, *timeout)
+	assert.True(t, *enabled)
+	assert.True(t, *verbose)
+	assert.Equal(t, "file", *name)
+	assert.Equal(t, []settings.Setting{
+		{Name: "IdleSessionTimeoutMs", Value: "3000", Source: settings.Mconfig},
+		{Name: "admin_token", Value: settings.Redacted, Source: settings.File},
+		{Name: "enabled", Value: "true", Source: settings.File},
+		{Name: "max_sessions", Value: "10", Source: settings.Flag},
+		{Name: "name", Value: "file", Source: settings.File},
+		{Name: "timeout", Value: "7s", Source: settings.Env},
+		{Name: "untouched", Value: "default", Source: settings.Default},
+		{Name: "verbose", Value: "true", Source: settings.Env},
+	}, loader.Effective())
+}
+
+func TestApplyErrors(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Int("max_sessions", 0, "")
+	assert.NoError(t, fs.Parse(nil))
+
+	loader := settings.New("test")
+	loader.AddMconfig(map[string]string{"max_sessions": "many"})
+	assert.Error(t, loader.Apply(fs))
+
+	dir, err := ioutil.TempDir("", "settings_test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "settings.json")
+	assert.NoError(t, ioutil.WriteFile(path, []byte(`{"unknown": "1"}`), 0644))
+	loader = settings.New("test")
+	assert.NoError(t, loader.AddFile(path))
+	assert.Error(t, loader.Apply(fs))
+
+	assert.NoError(t, ioutil.WriteFile(path, []byte(`{"max_sessions": [1]}`), 0644))
+	assert.Error(t, settings.New("test").AddFile(path))
+}
+
+func TestParseBool(t *testing.T) {
+	for _, tc := range []struct {
+		value    string
+		expected bool
+	}{
+		{"true", true},
+		{"TRUE", true},
+		{" true ", true},
+		{"1", true},
+		{"yes", true},
+		{"On", true},
+		{"enabled", true},
+		{"false", false},
+		{"False", false},
+		{"0", false},
+		{"no", false},
+		{"n", false},
+		{"nope", false},
+		{"OFF", false},
+	} {
+		res, err := settings.ParseBool(tc.value)
+		assert.NoError(t, err, tc.value)
+		assert.Equal(t, tc.expected, res, tc.value)
+	}
+	_, err := settings.ParseBool(" ")
+	assert.Error(t, err)
+
+	os.Setenv("TEST_SETTINGS_BOOL", "yes")
+	defer os.Unsetenv("TEST_SETTINGS_BOOL")
+	assert.True(t, settings.EnvBool("TEST_SETTINGS_BOOL", false))
+	os.Setenv("TEST_SETTINGS_BOOL", "maybe")
+	assert.True(t, settings.EnvBool("TEST_SETTINGS_BOOL", false))
+	os.Setenv("TEST_SETTINGS_BOOL", "no")
+	assert.False(t, settings.EnvBool("TEST_SETTINGS_BOOL", true))
+	os.Setenv("TEST_SETTINGS_BOOL", "")
+	assert.True(t, settings.EnvBool("TEST_SETTINGS_BOOL", true))
+	assert.False(t, settings.EnvBool("TEST_SETTINGS_BOOL_UNSET", false))
+}
@@ -0,0 +1,63 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// aaa_proto_compat checks the AAA protos of this build for backward incompatible changes against a schema
+// snapshot & optionally updates the snapshot with compatible changes (new messages, fields & enum values)
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"magma/feg/gateway/services/aaa/protos/compat"
+)
+
+var (
+	snapshotPath = flag.String(
+		"snapshot", "services/aaa/protos/compat/testdata/schema.json", "AAA protos schema snapshot JSON file path")
+	update = flag.Bool("update", false, "Update the snapshot if the current protos are compatible with it")
+	force  = flag.Bool("force", false, "With -update, update the snapshot even if the protos are not compatible")
+)
+
+func main() {
+	flag.Parse()
+
+	current, err := compat.Take(compat.Roots()...)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error getting current AAA protos schema: %v\n", err)
+		os.Exit(2)
+	}
+	snapshot, err := compat.ReadSchema(*snapshotPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading schema snapshot: %v\n", err)
+		os.Exit(2)
+	}
+	problems := compat.Check(snapshot, current)
+	for _, p := range problems {
+		fmt.Printf("INCOMPATIBLE: %s\n", p)
+	}
+	if len(problems) > 0 && !(*update && *force) {
+		fmt.Printf("%d backward incompatible changes found\n", len(problems))
+		os.Exit(1)
+	}
+	added := compat.Check(current, snapshot) // entries of the current schema missing from the snapshot
+	if !*update {
+		if len(added) > 0 {
+			fmt.Printf("AAA protos are compatible, the snapshot misses %d new entries, rerun with -update\n", len(added))
+		} else {
+			fmt.Println("AAA protos are compatible")
+		}
+		return
+	}
+	if err = current.Write(*snapshotPath); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing schema snapshot: %v\n", err)
+		os.Exit(2)
+	}
+	fmt.Printf("Snapshot %s updated\n", *snapshotPath)
+}
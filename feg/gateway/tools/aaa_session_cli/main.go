@@ -0,0 +1,201 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// aaa_session_cli lists, gets & terminates live sessions of AAA servers via their session admin service
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+
+	"magma/feg/gateway/registry"
+	"magma/feg/gateway/services/aaa/protos"
+	"magma/orc8r/cloud/go/tools/commands"
+)
+
+var (
+	cmdRegistry = new(commands.Map) // manages the commands which this CLI supports
+	token       = flag.String("token", os.Getenv("AAA_ADMIN_TOKEN"), "Session admin bearer token")
+	timeout     = flag.Duration("timeout", 10*time.Second, "RPC timeout")
+
+	imsi, macAddr, apn, sessionID, operator, reason string
+
+	dropInterims         uint
+	stopDelay, clockSkew time.Duration
+
+	trigger, result string
+	limit           uint
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Printf("\nUsage: \033[1m%s [OPTIONS] command [COMMAND OPTIONS]\033[0m\n\n", filepath.Base(os.Args[0]))
+		flag.PrintDefaults()
+		fmt.Println("\nCommands:")
+		cmdRegistry.Usage()
+	}
+	flag.Parse()
+	cmdName := flag.Arg(0)
+	if len(flag.Args()) < 1 || cmdName == "" || cmdName == "help" {
+		flag.Usage()
+		os.Exit(1)
+	}
+	cmd := cmdRegistry.Get(cmdName)
+	if cmd == nil {
+		fmt.Println("\nInvalid Command: ", cmdName)
+		flag.Usage()
+		os.Exit(1)
+	}
+	args := flag.Args()[1:]
+	cmd.Flags().Parse(args)
+	os.Exit(cmd.Handle(args))
+}
+
+// call calls the session admin service with the CLI's token & timeout
+func call(rpc func(ctx context.Context, cli protos.SessionAdminClient) (proto.Message, error)) int {
+	conn, err := registry.GetConnection(registry.AAA_SERVER)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to connect to AAA server: %v\n", err)
+		return 1
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	if len(*token) > 0 {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+*token)
+	}
+	res, err := rpc(ctx, protos.NewSessionAdminClient(conn))
+	// results of failed calls are printed too, terminations may fail after removing some sessions
+	if res != nil && !reflect.ValueOf(res).IsNil() {
+		s, merr := (&jsonpb.Marshaler{Indent: "  ", OrigName: true}).MarshalToString(res)
+		if merr != nil {
+			fmt.Fprintf(os.Stderr, "Failed to marshal the result: %v\n", merr)
+			return 1
+		}
+		fmt.Println(s)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func listSessions(_ *commands.Command, _ []string) int {
+	return call(func(ctx context.Context, cli protos.SessionAdminClient) (proto.Message, error) {
+		return cli.ListSessions(ctx, &protos.ListSessionsRequest{Imsi: imsi, MacAddr: macAddr, Apn: apn})
+	})
+}
+
+func getSession(cmd *commands.Command, _ []string) int {
+	if len(sessionID) == 0 {
+		fmt.Fprintln(os.Stderr, "Error: Session ID missing")
+		cmd.Usage()
+		return 1
+	}
+	return call(func(ctx context.Context, cli protos.SessionAdminClient) (proto.Message, error) {
+		return cli.GetSession(ctx, &protos.GetSessionRequest{SessionId: sessionID})
+	})
+}
+
+func terminateSessions(cmd *commands.Command, _ []string) int {
+	if len(imsi) == 0 || len(operator) == 0 {
+		fmt.Fprintln(os.Stderr, "Error: IMSI & operator are required")
+		cmd.Usage()
+		return 1
+	}
+	return call(func(ctx context.Context, cli protos.SessionAdminClient) (proto.Message, error) {
+		return cli.TerminateSessionByImsi(
+			ctx, &protos.TerminateSessionsRequest{Imsi: imsi, Operator: operator, Reason: reason})
+	})
+}
+
+func getServiceInfo(_ *commands.Command, _ []string) int {
+	return call(func(ctx context.Context, cli protos.SessionAdminClient) (proto.Message, error) {
+		return cli.GetServiceInfo(ctx, &protos.GetServiceInfoRequest{})
+	})
+}
+
+func injectFaults(cmd *commands.Command, _ []string) int {
+	if len(imsi) == 0 || len(operator) == 0 {
+		fmt.Fprintln(os.Stderr, "Error: IMSI & operator are required")
+		cmd.Usage()
+		return 1
+	}
+	return call(func(ctx context.Context, cli protos.SessionAdminClient) (proto.Message, error) {
+		return cli.InjectAccountingFaults(ctx, &protos.InjectAccountingFaultsRequest{
+			Faults: &protos.AccountingFaults{
+				Imsi:         imsi,
+				DropInterims: uint32(dropInterims),
+				StopDelayMs:  uint32(stopDelay / time.Millisecond),
+				ClockSkewMs:  int64(clockSkew / time.Millisecond),
+			},
+			Operator: operator,
+		})
+	})
+}
+
+func listCoATransactions(_ *commands.Command, _ []string) int {
+	return call(func(ctx context.Context, cli protos.SessionAdminClient) (proto.Message, error) {
+		return cli.ListCoaTransactions(ctx, &protos.ListCoaTransactionsRequest{
+			SessionId: sessionID, Imsi: imsi, Trigger: trigger, Result: result, Limit: uint32(limit)})
+	})
+}
+
+func addCommand(name, descr string, handler commands.Handler) *flag.FlagSet {
+	cmd := cmdRegistry.Add(name, descr, handler)
+	f := cmd.Flags()
+	f.Usage = func() {
+		fmt.Fprintf(os.Stderr, // std Usage() & PrintDefaults() use Stderr
+			"\tUsage: %s [OPTIONS] %s [%s OPTIONS]\n", os.Args[0], cmd.Name(), cmd.Name())
+		f.PrintDefaults()
+	}
+	return f
+}
+
+func init() {
+	listFlags := addCommand("LIST", "List live sessions", listSessions)
+	listFlags.StringVar(&imsi, "imsi", "", "Only list sessions of the IMSI")
+	listFlags.StringVar(&macAddr, "mac", "", "Only list sessions of the UE MAC address")
+	listFlags.StringVar(&apn, "apn", "", "Only list sessions of the APN")
+
+	getFlags := addCommand("GET", "Get live session by session ID", getSession)
+	getFlags.StringVar(&sessionID, "sid", "", "Session ID")
+
+	termFlags := addCommand("TERMINATE", "Terminate all live sessions of the IMSI", terminateSessions)
+	termFlags.StringVar(&imsi, "imsi", "", "IMSI of the sessions to terminate")
+	termFlags.StringVar(&operator, "operator", "", "Operator terminating the sessions, recorded in the audit log")
+	termFlags.StringVar(&reason, "reason", "", "Termination reason, recorded in the audit log")
+
+	addCommand("INFO", "Get the AAA service's version, build, configuration hash & loaded modules", getServiceInfo)
+
+	faultFlags := addCommand("FAULTS",
+		"Inject accounting faults of a synthetic test subscriber's sessions, no faults clear the subscriber's faults",
+		injectFaults)
+	faultFlags.StringVar(&imsi, "imsi", "", "IMSI of the synthetic test subscriber")
+	faultFlags.StringVar(&operator, "operator", "", "Operator injecting the faults")
+	faultFlags.UintVar(&dropInterims, "drop_interims", 0, "Number of the subscriber's Interim-Updates to drop")
+	faultFlags.DurationVar(&stopDelay, "stop_delay", 0, "Delay of the subscriber's Accounting Stops")
+	faultFlags.DurationVar(&clockSkew, "clock_skew", 0, "Clock skew of the subscriber's audited events' times")
+
+	coaFlags := addCommand("COA", "List the latest CoA & Disconnect-Requests sent to the sessions' NASes",
+		listCoATransactions)
+	coaFlags.StringVar(&sessionID, "sid", "", "Only list requests of the session ID")
+	coaFlags.StringVar(&imsi, "imsi", "", "Only list requests of the IMSI's sessions")
+	coaFlags.StringVar(&trigger, "trigger", "", "Only list requests of the triggering RPC or policy event")
+	coaFlags.StringVar(&result, "result", "", "Only list requests with the result: ack, nak or error")
+	coaFlags.UintVar(&limit, "limit", 100, "Maximum number of listed requests, 0 - all")
+}
@@ -0,0 +1,185 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package debughttp implements the in place diagnostics HTTP endpoint of the radius server & the AAA services: pprof
+// profiles, expvar variables & Go runtime metrics. The endpoint listens on loopback addresses only, unless remote
+// access is explicitly allowed, & optionally requires a bearer token
+package debughttp
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// DefaultAddr - default debug endpoint listen address
+const DefaultAddr = "127.0.0.1:6060"
+
+// Config - debug endpoint configuration
+type Config struct {
+	Addr        string // listen address, host:port
+	Token       string // bearer token required by the endpoint, no authorization if empty
+	AllowRemote bool   // allows listening on non loopback addresses
+}
+
+// NewConfig returns the configuration of the endpoint listening on the address, its token is read from the token
+// file if the file path is not empty
+func NewConfig(addr, tokenFile string, allowRemote bool) (*Config, error) {
+	cfg := &Config{Addr: addr, AllowRemote: allowRemote}
+	if len(tokenFile) > 0 {
+		token, err := ioutil.ReadFile(tokenFile)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.Token = strings.TrimSpace(string(token)); len(cfg.Token) == 0 {
+			return nil, fmt.Errorf("empty debug endpoint token file %s", tokenFile)
+		}
+	}
+	return cfg, cfg.Validate()
+}
+
+// Validate checks that the endpoint listens on a loopback address unless remote access is allowed
+func (cfg *Config) Validate() error {
+	host, _, err := net.SplitHostPort(cfg.Addr)
+	if err != nil {
+		return fmt.Errorf("invalid debug endpoint address '%s': %v", cfg.Addr, err)
+	}
+	if cfg.AllowRemote {
+		return nil
+	}
+	if host == "localhost" {
+		return nil
+	}
+	if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+		return fmt.Errorf("debug endpoint address '%s' is not a loopback address & remote access is not allowed",
+			cfg.Addr)
+	}
+	return nil
+}
+
+var started = time.Now()
+
+// Handler returns the debug endpoint handler, requests must carry the bearer token if it's not empty
+func Handler(token string) http.Handler {
+	mux := http.NewServeMux()
+	// the pprof index serves the named profiles (heap, goroutine, block, mutex...) under its path
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.Handle("/debug/vars", expvar.Handler())
+	mux.HandleFunc("/debug/runtime", serveRuntimeMetrics)
+	if len(token) == 0 {
+		return mux
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bearer := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(bearer), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "Invalid or missing debug endpoint token", http.StatusUnauthorized)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// Start validates the configuration & serves the debug endpoint in the background, the returned server is closed to
+// release its port. The endpoint's serving error, unless it's closed, is passed to onError, nil - the error is logged
+func Start(cfg *Config, onError func(error)) (*http.Server, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	lis, err := net.Listen("tcp", cfg.Addr)
+	if err != nil {
+		return nil, fmt.Errorf("debug endpoint listen error: %v", err)
+	}
+	srv := &http.Server{Handler: Handler(cfg.Token), ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		err := srv.Serve(lis)
+		switch {
+		case err == http.ErrServerClosed:
+		case onError != nil:
+			onError(err)
+		default:
+			log.Printf("Debug endpoint error: %v", err)
+		}
+	}()
+	return srv, nil
+}
+
+// RuntimeMetrics - Go runtime metrics of the process
+type RuntimeMetrics struct {
+	UptimeSec    float64 `json:"uptime_sec"`
+	Goroutines   int     `json:"goroutines"`
+	GoMaxProcs   int     `json:"gomaxprocs"`
+	NumCPU       int     `json:"num_cpu"`
+	CgoCalls     int64   `json:"cgo_calls"`
+	HeapAlloc    uint64  `json:"heap_alloc_bytes"`
+	HeapInuse    uint64  `json:"heap_inuse_bytes"`
+	HeapIdle     uint64  `json:"heap_idle_bytes"`
+	HeapReleased uint64  `json:"heap_released_bytes"`
+	HeapObjects  uint64  `json:"heap_objects"`
+	StackInuse   uint64  `json:"stack_inuse_bytes"`
+	Sys          uint64  `json:"sys_bytes"`
+	TotalAlloc   uint64  `json:"total_alloc_bytes"`
+	Mallocs      uint64  `json:"mallocs"`
+	Frees        uint64  `json:"frees"`
+	NumGC        uint32  `json:"num_gc"`
+	NextGC       uint64  `json:"next_gc_bytes"`
+	LastGCPause  string  `json:"last_gc_pause"`
+	TotalGCPause string  `json:"total_gc_pause"`
+	GCCPUPercent float64 `json:"gc_cpu_percent"`
+}
+
+// ReadRuntimeMetrics returns the current runtime metrics, reading them briefly stops the world
+func ReadRuntimeMetrics() *RuntimeMetrics {
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	res := &RuntimeMetrics{
+		UptimeSec:    time.Since(started).Seconds(),
+		Goroutines:   runtime.NumGoroutine(),
+		GoMaxProcs:   runtime.GOMAXPROCS(0),
+		NumCPU:       runtime.NumCPU(),
+		CgoCalls:     runtime.NumCgoCall(),
+		HeapAlloc:    ms.HeapAlloc,
+		HeapInuse:    ms.HeapInuse,
+		HeapIdle:     ms.HeapIdle,
+		HeapReleased: ms.HeapReleased,
+		HeapObjects:  ms.HeapObjects,
+		StackInuse:   ms.StackInuse,
+		Sys:          ms.Sys,
+		TotalAlloc:   ms.TotalAlloc,
+		Mallocs:      ms.Mallocs,
+		Frees:        ms.Frees,
+		NumGC:        ms.NumGC,
+		NextGC:       ms.NextGC,
+		TotalGCPause: time.Duration(ms.PauseTotalNs).String(),
+		GCCPUPercent: ms.GCCPUFraction * 100,
+	}
+	if ms.NumGC > 0 {
+		res.LastGCPause = time.Duration(ms.PauseNs[(ms.NumGC+255)%256]).String()
+	}
+	return res
+}
+
+func serveRuntimeMetrics(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(ReadRuntimeMetrics())
+}
@@ -0,0 +1,75 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package debughttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	for _, addr := range []string{"127.0.0.1:6060", "localhost:6060", "[::1]:6060"} {
+		assert.NoError(t, (&Config{Addr: addr}).Validate(), addr)
+	}
+	for _, addr := range []string{":6060", "0.0.0.0:6060", "10.0.0.1:6060", "gw.example.com:6060"} {
+		assert.Error(t, (&Config{Addr: addr}).Validate(), addr)
+		assert.NoError(t, (&Config{Addr: addr, AllowRemote: true}).Validate(), addr)
+	}
+	assert.Error(t, (&Config{Addr: "6060", AllowRemote: true}).Validate())
+}
+
+func TestHandler(t *testing.T) {
+	get := func(h http.Handler, path, token string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, path, nil)
+		if len(token) > 0 {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+	open := Handler("")
+	for _, path := range []string{"/debug/pprof/", "/debug/pprof/goroutine?debug=1", "/debug/vars", "/debug/runtime"} {
+		assert.Equal(t, http.StatusOK, get(open, path, "").Code, path)
+	}
+	w := get(open, "/debug/runtime", "")
+	m := &RuntimeMetrics{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), m))
+	assert.True(t, m.Goroutines > 0)
+	assert.True(t, m.HeapAlloc > 0)
+
+	// with a token, only its bearers are served
+	authorized := Handler("secret")
+	assert.Equal(t, http.StatusUnauthorized, get(authorized, "/debug/vars", "").Code)
+	assert.Equal(t, http.StatusUnauthorized, get(authorized, "/debug/vars", "guess").Code)
+	assert.Equal(t, http.StatusOK, get(authorized, "/debug/vars", "secret").Code)
+}
+
+func TestStart(t *testing.T) {
+	_, err := Start(&Config{Addr: "0.0.0.0:0"}, nil)
+	assert.Error(t, err, "remote access is not allowed")
+
+	errs := make(chan error, 1)
+	srv, err := Start(&Config{Addr: "127.0.0.1:0"}, func(err error) { errs <- err })
+	assert.NoError(t, err)
+	if assert.NotNil(t, srv) {
+		// closing the endpoint releases its port & isn't an error
+		assert.NoError(t, srv.Close())
+		select {
+		case err = <-errs:
+			assert.Fail(t, "closed endpoint error", "%v", err)
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
@@ -0,0 +1,315 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package radius
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/md5"
+	"errors"
+	"net"
+	"sync"
+	"time"
+)
+
+// ErrNoFreeIdentifier is returned by StreamClient.Exchange when all the 256
+// identifiers of the connection are used by outstanding requests.
+var ErrNoFreeIdentifier = errors.New("radius: no free identifier on the connection")
+
+// StreamClient is a RADIUS client that exchanges packets with RADIUS servers
+// over persistent stream connections, e.g. RADIUS over TLS (RadSec, RFC
+// 6614). Requests to a server are multiplexed over a single connection, which
+// is reopened by the next request once it fails.
+//
+// Identifiers are unique per connection, so the client assigns the requests'
+// identifiers & restores the original identifier in the responses. Packets
+// are re-encoded with the client's secret, the Message-Authenticator is
+// recalculated, but attributes hidden with the packet's secret (e.g.
+// User-Password) are not re-hidden.
+type StreamClient struct {
+	// Dial opens a connection to the server at addr, e.g. a TLS connection.
+	// Defaults to a TCP connection.
+	Dial func(ctx context.Context, addr string) (net.Conn, error)
+
+	// Secret of the exchanged packets, RadSecSecret if empty.
+	Secret []byte
+
+	// Timeout of exchanges whose context has no deadline, zero means no
+	// timeout.
+	Timeout time.Duration
+
+	// InsecureSkipVerify controls whether the client should skip verifying
+	// response packets received.
+	InsecureSkipVerify bool
+
+	mu    sync.Mutex
+	conns map[string]*streamConn
+}
+
+// streamConn an open connection of the client & its outstanding requests
+type streamConn struct {
+	dialed  chan struct{} // closed once the connection is dialed, conn is nil if the dial failed
+	conn    net.Conn
+	writeMu sync.Mutex
+
+	mu      sync.Mutex
+	pending map[byte]chan []byte
+	next    byte
+	err     error // set when the connection failed, no requests are accepted then
+}
+
+// Exchange sends the packet to the given server and waits for a response. ctx
+// must be non-nil.
+func (c *StreamClient) Exchange(ctx context.Context, packet *Packet, addr string) (*Packet, error) {
+	if ctx == nil {
+		panic("nil context")
+	}
+	if _, ok := ctx.Deadline(); !ok && c.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.Timeout)
+		defer cancel()
+	}
+	secret := c.Secret
+	if len(secret) == 0 {
+		secret = []byte(RadSecSecret)
+	}
+
+	sc, err := c.connect(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+	identifier, responses, err := sc.reserve()
+	if err != nil {
+		return nil, err
+	}
+	defer sc.release(identifier, responses)
+
+	request := *packet
+	request.Identifier, request.Secret = identifier, secret
+	wire, err := request.Encode()
+	if err != nil {
+		return nil, err
+	}
+	signMessageAuthenticator(wire, secret)
+
+	sc.writeMu.Lock()
+	if deadline, ok := ctx.Deadline(); ok {
+		sc.conn.SetWriteDeadline(deadline)
+	}
+	_, err = sc.conn.Write(wire)
+	sc.writeMu.Unlock()
+	if err != nil {
+		c.drop(addr, sc, err)
+		return nil, err
+	}
+
+	select {
+	case incoming, ok := <-responses:
+		if !ok {
+			return nil, sc.failure()
+		}
+		if !c.InsecureSkipVerify && !IsAuthenticResponse(incoming, wire, secret) {
+			return nil, &NonAuthenticResponseError{}
+		}
+		received, err := Parse(incoming, secret)
+		if err != nil {
+			return nil, err
+		}
+		received.Identifier, received.Secret = packet.Identifier, packet.Secret
+		return received, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Close closes the client's connections, failing their outstanding requests.
+func (c *StreamClient) Close() error {
+	c.mu.Lock()
+	conns := c.conns
+	c.conns = nil
+	c.mu.Unlock()
+	for _, sc := range conns {
+		<-sc.dialed
+		sc.fail(errors.New("radius: client closed"))
+	}
+	return nil
+}
+
+// connect returns the open connection to addr, dialing it if there is none.
+// Concurrent requests to addr wait for a single dial, other servers' requests
+// aren't blocked by it
+func (c *StreamClient) connect(ctx context.Context, addr string) (*streamConn, error) {
+	c.mu.Lock()
+	sc, ok := c.conns[addr]
+	if !ok {
+		sc = &streamConn{dialed: make(chan struct{}), pending: make(map[byte]chan []byte)}
+		if c.conns == nil {
+			c.conns = make(map[string]*streamConn)
+		}
+		c.conns[addr] = sc
+	}
+	c.mu.Unlock()
+	if ok {
+		select {
+		case <-sc.dialed:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if sc.conn == nil {
+			return nil, sc.failure()
+		}
+		return sc, nil
+	}
+
+	dial := c.Dial
+	if dial == nil {
+		dial = func(ctx context.Context, addr string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, "tcp", addr)
+		}
+	}
+	conn, err := dial(ctx, addr)
+	if err != nil {
+		c.mu.Lock()
+		if c.conns[addr] == sc {
+			delete(c.conns, addr)
+		}
+		c.mu.Unlock()
+		sc.mu.Lock()
+		sc.err = err
+		sc.mu.Unlock()
+		close(sc.dialed)
+		return nil, err
+	}
+	sc.conn = conn
+	close(sc.dialed)
+	go func() {
+		c.drop(addr, sc, sc.readResponses())
+	}()
+	return sc, nil
+}
+
+// drop fails the connection & removes it from the client, so the next request
+// reopens it
+func (c *StreamClient) drop(addr string, sc *streamConn, err error) {
+	c.mu.Lock()
+	if c.conns[addr] == sc {
+		delete(c.conns, addr)
+	}
+	c.mu.Unlock()
+	sc.fail(err)
+}
+
+// readResponses dispatches the connection's responses to their requests until
+// the connection fails
+func (sc *streamConn) readResponses() error {
+	var buff [MaxPacketLength]byte
+	for {
+		wire, err := ReadPacket(sc.conn, buff[:])
+		if err != nil {
+			return err
+		}
+		sc.mu.Lock()
+		responses, ok := sc.pending[wire[1]]
+		if ok {
+			// the request's channel is buffered & receives a single response,
+			// responses to abandoned or answered requests are dropped
+			delete(sc.pending, wire[1])
+			responses <- append([]byte(nil), wire...)
+		}
+		sc.mu.Unlock()
+	}
+}
+
+// reserve reserves a free identifier of the connection for a request
+func (sc *streamConn) reserve() (byte, chan []byte, error) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if sc.err != nil {
+		return 0, nil, sc.err
+	}
+	for i := 0; i < 256; i++ {
+		identifier := sc.next
+		sc.next++
+		if _, used := sc.pending[identifier]; !used {
+			responses := make(chan []byte, 1)
+			sc.pending[identifier] = responses
+			return identifier, responses, nil
+		}
+	}
+	return 0, nil, ErrNoFreeIdentifier
+}
+
+// release frees the request's identifier, unless its response was received &
+// the identifier was reserved by another request since
+func (sc *streamConn) release(identifier byte, responses chan []byte) {
+	sc.mu.Lock()
+	if sc.pending[identifier] == responses {
+		delete(sc.pending, identifier)
+	}
+	sc.mu.Unlock()
+}
+
+// fail closes the connection & fails its outstanding requests
+func (sc *streamConn) fail(err error) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if sc.err != nil {
+		return
+	}
+	if err == nil {
+		err = errors.New("radius: connection closed")
+	}
+	sc.err = err
+	sc.conn.Close()
+	for identifier, responses := range sc.pending {
+		close(responses)
+		delete(sc.pending, identifier)
+	}
+}
+
+func (sc *streamConn) failure() error {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	return sc.err
+}
+
+// signMessageAuthenticator recalculates the Message-Authenticator of the
+// encoded request, if it has one (RFC 3579 section 3.2 & RFC 5176 section
+// 3.4), & the Request Authenticator of the requests covering it
+func signMessageAuthenticator(wire []byte, secret []byte) {
+	const messageAuthenticatorType = 80
+	var offset int
+	for i := 20; i+2 <= len(wire) && wire[i+1] >= 2; i += int(wire[i+1]) {
+		if wire[i] == messageAuthenticatorType && wire[i+1] == byte(MessageAuthenticatorAttrLength) {
+			offset = i + 2
+			break
+		}
+	}
+	if offset == 0 {
+		return
+	}
+	code := Code(wire[0])
+	if code != CodeAccessRequest {
+		// signed with a zero Request Authenticator, which is then calculated
+		// over the signed request
+		copy(wire[4:20], make([]byte, 16))
+	}
+	copy(wire[offset:offset+16], make([]byte, 16))
+	hash := hmac.New(md5.New, secret)
+	hash.Write(wire)
+	hash.Sum(wire[offset:offset])
+	if code == CodeAccessRequest {
+		return
+	}
+	auth := md5.New()
+	auth.Write(wire)
+	auth.Write(secret)
+	auth.Sum(wire[4:4])
+}
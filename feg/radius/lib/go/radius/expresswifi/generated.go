@@ -776,3 +776,181 @@ func XWFMSISDN_SetString(p *radius.Packet, value string) (err error) {
 	}
 	return _FacebookExpressWiFi_SetVendor(p, 7, a)
 }
+
+func XWFPartnerID_Add(p *radius.Packet, value []byte) (err error) {
+	var a radius.Attribute
+	a, err = radius.NewBytes(value)
+	if err != nil {
+		return
+	}
+	return _FacebookExpressWiFi_AddVendor(p, 8, a)
+}
+
+func XWFPartnerID_AddString(p *radius.Packet, value string) (err error) {
+	var a radius.Attribute
+	a, err = radius.NewString(value)
+	if err != nil {
+		return
+	}
+	return _FacebookExpressWiFi_AddVendor(p, 8, a)
+}
+
+func XWFPartnerID_Get(p *radius.Packet) (value []byte) {
+	value, _ = XWFPartnerID_Lookup(p)
+	return
+}
+
+func XWFPartnerID_GetString(p *radius.Packet) (value string) {
+	return string(XWFPartnerID_Get(p))
+}
+
+func XWFPartnerID_Gets(p *radius.Packet) (values [][]byte, err error) {
+	var i []byte
+	for _, attr := range _FacebookExpressWiFi_GetsVendor(p, 8) {
+		i = radius.Bytes(attr)
+		if err != nil {
+			return
+		}
+		values = append(values, i)
+	}
+	return
+}
+
+func XWFPartnerID_GetStrings(p *radius.Packet) (values []string, err error) {
+	var i string
+	for _, attr := range _FacebookExpressWiFi_GetsVendor(p, 8) {
+		i = radius.String(attr)
+		if err != nil {
+			return
+		}
+		values = append(values, i)
+	}
+	return
+}
+
+func XWFPartnerID_Lookup(p *radius.Packet) (value []byte, err error) {
+	a, ok := _FacebookExpressWiFi_LookupVendor(p, 8)
+	if !ok {
+		err = radius.ErrNoAttribute
+		return
+	}
+	value = radius.Bytes(a)
+	return
+}
+
+func XWFPartnerID_LookupString(p *radius.Packet) (value string, err error) {
+	a, ok := _FacebookExpressWiFi_LookupVendor(p, 8)
+	if !ok {
+		err = radius.ErrNoAttribute
+		return
+	}
+	value = radius.String(a)
+	return
+}
+
+func XWFPartnerID_Set(p *radius.Packet, value []byte) (err error) {
+	var a radius.Attribute
+	a, err = radius.NewBytes(value)
+	if err != nil {
+		return
+	}
+	return _FacebookExpressWiFi_SetVendor(p, 8, a)
+}
+
+func XWFPartnerID_SetString(p *radius.Packet, value string) (err error) {
+	var a radius.Attribute
+	a, err = radius.NewString(value)
+	if err != nil {
+		return
+	}
+	return _FacebookExpressWiFi_SetVendor(p, 8, a)
+}
+
+func XWFCampaignID_Add(p *radius.Packet, value []byte) (err error) {
+	var a radius.Attribute
+	a, err = radius.NewBytes(value)
+	if err != nil {
+		return
+	}
+	return _FacebookExpressWiFi_AddVendor(p, 9, a)
+}
+
+func XWFCampaignID_AddString(p *radius.Packet, value string) (err error) {
+	var a radius.Attribute
+	a, err = radius.NewString(value)
+	if err != nil {
+		return
+	}
+	return _FacebookExpressWiFi_AddVendor(p, 9, a)
+}
+
+func XWFCampaignID_Get(p *radius.Packet) (value []byte) {
+	value, _ = XWFCampaignID_Lookup(p)
+	return
+}
+
+func XWFCampaignID_GetString(p *radius.Packet) (value string) {
+	return string(XWFCampaignID_Get(p))
+}
+
+func XWFCampaignID_Gets(p *radius.Packet) (values [][]byte, err error) {
+	var i []byte
+	for _, attr := range _FacebookExpressWiFi_GetsVendor(p, 9) {
+		i = radius.Bytes(attr)
+		if err != nil {
+			return
+		}
+		values = append(values, i)
+	}
+	return
+}
+
+func XWFCampaignID_GetStrings(p *radius.Packet) (values []string, err error) {
+	var i string
+	for _, attr := range _FacebookExpressWiFi_GetsVendor(p, 9) {
+		i = radius.String(attr)
+		if err != nil {
+			return
+		}
+		values = append(values, i)
+	}
+	return
+}
+
+func XWFCampaignID_Lookup(p *radius.Packet) (value []byte, err error) {
+	a, ok := _FacebookExpressWiFi_LookupVendor(p, 9)
+	if !ok {
+		err = radius.ErrNoAttribute
+		return
+	}
+	value = radius.Bytes(a)
+	return
+}
+
+func XWFCampaignID_LookupString(p *radius.Packet) (value string, err error) {
+	a, ok := _FacebookExpressWiFi_LookupVendor(p, 9)
+	if !ok {
+		err = radius.ErrNoAttribute
+		return
+	}
+	value = radius.String(a)
+	return
+}
+
+func XWFCampaignID_Set(p *radius.Packet, value []byte) (err error) {
+	var a radius.Attribute
+	a, err = radius.NewBytes(value)
+	if err != nil {
+		return
+	}
+	return _FacebookExpressWiFi_SetVendor(p, 9, a)
+}
+
+func XWFCampaignID_SetString(p *radius.Packet, value string) (err error) {
+	var a radius.Attribute
+	a, err = radius.NewString(value)
+	if err != nil {
+		return
+	}
+	return _FacebookExpressWiFi_SetVendor(p, 9, a)
+}
@@ -17,6 +17,7 @@ import (
 	"net"
 	"sync"
 	"sync/atomic"
+	"time"
 )
 
 type packetResponseWriter struct {
@@ -42,7 +43,7 @@ func (r *packetResponseWriter) Write(packet *Packet) error {
 	// because this creates a circular dependecy.
 	_, hasEapMessage := packet.Lookup(Type(79))
 	if hasEapMessage && packet.Code.ImpliesMessageAuthenticatorNeeded() {
-		encoded = r.addMessageAuthenticator(encoded)
+		encoded = addMessageAuthenticator(encoded, r.requestAuthenticator, r.secret)
 	}
 
 	if _, err := r.conn.WriteTo(encoded, r.addr); err != nil {
@@ -58,7 +59,9 @@ func (c Code) ImpliesMessageAuthenticatorNeeded() bool {
 	return c == CodeAccessAccept || c == CodeAccessReject || c == CodeAccessChallenge
 }
 
-func (r *packetResponseWriter) addMessageAuthenticator(encoded []byte) []byte {
+// addMessageAuthenticator appends the Message-Authenticator to the encoded
+// response of the request & recalculates the Response Authenticator
+func addMessageAuthenticator(encoded []byte, requestAuthenticator [16]byte, secret []byte) []byte {
 	// Fix the size
 	size := binary.BigEndian.Uint16(encoded[2:4]) + MessageAuthenticatorAttrLength
 	binary.BigEndian.PutUint16(encoded[2:4], uint16(size))
@@ -67,7 +70,7 @@ func (r *packetResponseWriter) addMessageAuthenticator(encoded []byte) []byte {
 	zeroedOutMsgAuthenticator := [16]byte{}
 	allBytes := [][]byte{
 		encoded[:4],
-		r.requestAuthenticator[:],
+		requestAuthenticator[:],
 		encoded[20:],
 		[]byte{80, 18},
 		zeroedOutMsgAuthenticator[:],
@@ -78,16 +81,16 @@ func (r *packetResponseWriter) addMessageAuthenticator(encoded []byte) []byte {
 	}
 
 	// Calculate Message Authenticator & Overwrite
-	hash := hmac.New(md5.New, r.secret)
+	hash := hmac.New(md5.New, secret)
 	hash.Write(radiusMsg)
 	encoded = hash.Sum(radiusMsg[:len(radiusMsg)-16])
 
 	// Re-calc the Response Authenticator
 	resAuth := md5.New()
 	resAuth.Write(encoded[:4])
-	resAuth.Write(r.requestAuthenticator[:])
+	resAuth.Write(requestAuthenticator[:])
 	resAuth.Write(encoded[20:])
-	resAuth.Write(r.secret)
+	resAuth.Write(secret)
 	resAuth.Sum(encoded[4:4:20])
 
 	return encoded
@@ -103,10 +106,20 @@ type PacketServer struct {
 	SecretSource SecretSource
 	Handler      Handler
 
+	// The size of the socket receive buffer (SO_RCVBUF) in bytes, the system
+	// default is used if not positive. The kernel drops packets arriving
+	// while the buffer is full, so bursty loads need a large enough buffer.
+	ReadBufferSize int
+
 	// Skip incoming packet authenticity validation.
 	// This should only be set to true for debugging purposes.
 	InsecureSkipVerify bool
 
+	// RequestTap, if set, is called with the wire format of every authentic
+	// request before it's handled, e.g. to capture the raw requests. The wire
+	// bytes are only valid during the call.
+	RequestTap func(wire []byte, remoteAddr net.Addr)
+
 	// Channel to indicate when server is listenning and ready to serve requests
 	Ready chan bool
 
@@ -121,6 +134,51 @@ type PacketServer struct {
 
 // TODO: logger on PacketServer
 
+// packetBufferPool reusable buffers for incoming packets
+var packetBufferPool = sync.Pool{
+	New: func() interface{} {
+		b := make([]byte, 0, MaxPacketLength)
+		return &b
+	},
+}
+
+// copyPacket copies the read packet into a pooled buffer, the buffer is released once the packet is parsed
+func copyPacket(b []byte) *[]byte {
+	pooled := packetBufferPool.Get().(*[]byte)
+	*pooled = append((*pooled)[:0], b...)
+	return pooled
+}
+
+// releasePacket returns the packet's buffer to the pool, Parse copies attributes so it can be reused after parsing
+func releasePacket(pooled *[]byte) {
+	packetBufferPool.Put(pooled)
+}
+
+// parseRequest authenticates & parses an incoming packet
+func (s *PacketServer) parseRequest(ctx context.Context, buff []byte, remoteAddr net.Addr) (*Packet, []byte, error) {
+	secret, err := s.SecretSource.RADIUSSecret(ctx, remoteAddr)
+	if err != nil {
+		// TODO: log only if server is not shutting down?
+		return nil, nil, err
+	}
+	if len(secret) == 0 {
+		return nil, nil, errors.New("radius: empty secret")
+	}
+
+	if !s.InsecureSkipVerify && !IsAuthenticRequest(buff, secret) {
+		return nil, nil, errors.New("radius: request is not authentic")
+	}
+
+	packet, err := Parse(buff, secret)
+	if err != nil {
+		return nil, nil, err
+	}
+	if s.RequestTap != nil {
+		s.RequestTap(buff, remoteAddr)
+	}
+	return packet, secret, nil
+}
+
 // Serve accepts incoming connections on conn.
 func (s *PacketServer) Serve(conn net.PacketConn) error {
 	if s.Handler == nil {
@@ -196,25 +254,12 @@ func (s *PacketServer) Serve(conn net.PacketConn) error {
 			continue
 		}
 
-		buffCopy := append([]byte(nil), buff[:n]...)
+		pooled := copyPacket(buff[:n])
 
 		atomic.AddInt32(&s.activeCount, 1)
-		go func(buff []byte, remoteAddr net.Addr) {
-			secret, err := s.SecretSource.RADIUSSecret(ctx, remoteAddr)
-			if err != nil {
-				// TODO: log only if server is not shutting down?
-				return
-			}
-			if len(secret) == 0 {
-				return
-			}
-
-			if !s.InsecureSkipVerify && !IsAuthenticRequest(buff, secret) {
-				// TODO: log?
-				return
-			}
-
-			packet, err := Parse(buff, secret)
+		go func(pooled *[]byte, remoteAddr net.Addr) {
+			packet, secret, err := s.parseRequest(ctx, *pooled, remoteAddr)
+			releasePacket(pooled)
 			if err != nil {
 				// TODO: error logger
 				return
@@ -262,7 +307,7 @@ func (s *PacketServer) Serve(conn net.PacketConn) error {
 			}
 
 			s.Handler.ServeRADIUS(&response, &request)
-		}(buffCopy, remoteAddr)
+		}(pooled, remoteAddr)
 	}
 }
 
@@ -293,6 +338,19 @@ func (s *PacketServer) ListenAndServe() error {
 	}
 	defer pc.Close()
 
+	if s.ReadBufferSize > 0 {
+		err = errors.New("radius: ReadBufferSize requires a UDP network")
+		if udpConn, ok := pc.(*net.UDPConn); ok {
+			err = udpConn.SetReadBuffer(s.ReadBufferSize)
+		}
+		if err != nil {
+			if s.Ready != nil {
+				s.Ready <- false
+			}
+			return err
+		}
+	}
+
 	// Signal server is ready & serving requests
 	if s.Ready != nil {
 		s.Ready <- true
@@ -300,6 +358,60 @@ func (s *PacketServer) ListenAndServe() error {
 	return s.Serve(pc)
 }
 
+// PacketConns returns the connections the server is serving on.
+func (s *PacketServer) PacketConns() []net.PacketConn {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	conns := make([]net.PacketConn, 0, len(s.listeners))
+	for conn := range s.listeners {
+		conns = append(conns, conn)
+	}
+	return conns
+}
+
+// Drain stops reading packets from the server's listeners without closing
+// them, waits for running handlers to complete and then closes the listeners.
+// Unlike Shutdown, the running handlers' responses are still written, so a
+// process sharing the listeners' sockets can take over the server's traffic
+// without dropping the server's in-flight requests.
+//
+// Drain returns after all handlers have completed, or when ctx is canceled.
+func (s *PacketServer) Drain(ctx context.Context) error {
+	s.mu.Lock()
+
+	if len(s.listeners) == 0 {
+		s.mu.Unlock()
+		return nil
+	}
+
+	var listeners []net.PacketConn
+	if !s.shuttingDown {
+		s.shuttingDown = true
+		for listener := range s.listeners {
+			listeners = append(listeners, listener)
+			// unblocks the listener's read, Serve returns on errors while
+			// shutting down
+			listener.SetReadDeadline(time.Now())
+		}
+	}
+
+	ch := s.running
+	cancel := s.ctxDone
+	s.mu.Unlock()
+	defer func() {
+		cancel()
+		for _, listener := range listeners {
+			listener.Close()
+		}
+	}()
+	select {
+	case <-ch:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // Shutdown gracefully stops the server. It first closes all listeners (which
 // stops accepting new packets) and then waits for running handlers to complete.
 //
@@ -0,0 +1,60 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package radius
+
+import (
+	"context"
+	"net"
+	"testing"
+)
+
+// BenchmarkParseRequest compares the incoming packets' pooled buffers with the
+// per packet buffers they replaced. Pooling saves a packet sized allocation
+// per request, the GC pressure of busy servers, rather than CPU time, e.g. on
+// a single core Xeon VM:
+//
+//	BenchmarkParseRequest/pooled     1842126   662 ns/op   664 B/op   13 allocs/op
+//	BenchmarkParseRequest/unpooled   1836288   642 ns/op   840 B/op   14 allocs/op
+func BenchmarkParseRequest(b *testing.B) {
+	secret := []byte("123456")
+	request := New(CodeAccessRequest, secret)
+	request.Add(1, []byte("user@example.com"))       // User-Name
+	request.Add(30, []byte("AB-CD-EF-01-23-45:xwf")) // Called-Station-Id
+	request.Add(31, []byte("01-23-45-AB-CD-EF"))     // Calling-Station-Id
+	request.Add(79, make([]byte, 64))                // EAP-Message
+	request.Add(80, make([]byte, 16))                // Message-Authenticator
+	encoded, err := request.Encode()
+	if err != nil {
+		b.Fatal(err)
+	}
+	s := &PacketServer{SecretSource: StaticSecretSource(secret)}
+	remoteAddr := &net.UDPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 1812}
+	ctx := context.Background()
+
+	b.Run("pooled", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			pooled := copyPacket(encoded)
+			_, _, err := s.parseRequest(ctx, *pooled, remoteAddr)
+			releasePacket(pooled)
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("unpooled", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			buff := append([]byte(nil), encoded...)
+			if _, _, err := s.parseRequest(ctx, buff, remoteAddr); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
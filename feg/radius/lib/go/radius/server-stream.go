@@ -0,0 +1,349 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package radius
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+// RadSecSecret is the shared secret of RADIUS over TLS (RFC 6614 section
+// 2.3), the TLS session protects the packets instead of the secret.
+const RadSecSecret = "radsec"
+
+// DefaultHandshakeTimeout is the timeout of the TLS handshakes of the stream
+// server's connections, unless the server's HandshakeTimeout is set.
+const DefaultHandshakeTimeout = 10 * time.Second
+
+// ReadPacket reads an encoded RADIUS packet from a stream (e.g. a RadSec
+// connection) into buff, packets are delimited by their Length field. The
+// returned packet is a slice of buff, which must be at least MaxPacketLength
+// bytes long.
+func ReadPacket(r io.Reader, buff []byte) ([]byte, error) {
+	if _, err := io.ReadFull(r, buff[:4]); err != nil {
+		return nil, err
+	}
+	length := int(binary.BigEndian.Uint16(buff[2:4]))
+	if length < 20 || length > MaxPacketLength {
+		// the stream can't be resynchronized after an invalid length
+		return nil, errors.New("radius: invalid packet length")
+	}
+	if _, err := io.ReadFull(r, buff[4:length]); err != nil {
+		return nil, err
+	}
+	return buff[:length], nil
+}
+
+// StreamServer listens for RADIUS requests on stream connections, e.g. RADIUS
+// over TLS (RadSec, RFC 6614). Every connection is served until its peer
+// closes it, the connection's requests are handled concurrently & their
+// responses are written back over the connection.
+type StreamServer struct {
+	// The address on which the server listens. Defaults to :2083.
+	Addr string
+	// TLS configuration of the connections, connections are served in the
+	// clear (RADIUS over TCP, RFC 6613) if nil.
+	TLSConfig *tls.Config
+	// SecretSource supplies the secret of the connections' packets, RadSec
+	// connections use RadSecSecret.
+	SecretSource SecretSource
+	Handler      Handler
+
+	// KeepAlive the TCP keepalive period of the connections, keepalives are
+	// disabled if not positive.
+	KeepAlive time.Duration
+	// IdleTimeout closes connections receiving no requests for the timeout,
+	// connections are never closed by the server if not positive.
+	IdleTimeout time.Duration
+	// HandshakeTimeout the timeout of the connections' TLS handshakes,
+	// DefaultHandshakeTimeout if not positive.
+	HandshakeTimeout time.Duration
+
+	// Skip incoming packet authenticity validation.
+	// This should only be set to true for debugging purposes.
+	InsecureSkipVerify bool
+
+	// ErrorHandler is called with the errors of failed connections, e.g. TLS
+	// handshake failures of peers without valid certificates.
+	ErrorHandler func(remoteAddr net.Addr, err error)
+
+	// RequestTap, if set, is called with the wire format of every authentic
+	// request before it's handled, e.g. to capture the raw requests. The wire
+	// bytes are only valid during the call.
+	RequestTap func(wire []byte, remoteAddr net.Addr)
+
+	// Channel to indicate when server is listenning and ready to serve requests
+	Ready chan bool
+
+	mu           sync.Mutex
+	shuttingDown bool
+	listener     net.Listener
+	conns        map[net.Conn]struct{}
+	handlers     sync.WaitGroup
+}
+
+// streamResponseWriter writes the responses of a connection's requests
+type streamResponseWriter struct {
+	conn                 net.Conn
+	mu                   *sync.Mutex // serializes the connection's writes
+	requestAuthenticator [16]byte
+	secret               []byte
+}
+
+func (r *streamResponseWriter) Write(packet *Packet) error {
+	encoded, err := packet.Encode()
+	if err != nil {
+		return err
+	}
+	// See packetResponseWriter.Write
+	_, hasEapMessage := packet.Lookup(Type(79))
+	if hasEapMessage && packet.Code.ImpliesMessageAuthenticatorNeeded() {
+		encoded = addMessageAuthenticator(encoded, r.requestAuthenticator, r.secret)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, err = r.conn.Write(encoded)
+	return err
+}
+
+// Serve accepts incoming connections on ln, ln is closed when Serve returns.
+func (s *StreamServer) Serve(ln net.Listener) error {
+	if s.Handler == nil {
+		return errors.New("radius: nil Handler")
+	}
+	if s.SecretSource == nil {
+		return errors.New("radius: nil SecretSource")
+	}
+
+	s.mu.Lock()
+	if s.shuttingDown {
+		s.mu.Unlock()
+		return ErrServerShutdown
+	}
+	s.listener = ln
+	if s.conns == nil {
+		s.conns = make(map[net.Conn]struct{})
+	}
+	s.mu.Unlock()
+	defer ln.Close()
+
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			s.mu.Lock()
+			shuttingDown := s.shuttingDown
+			s.mu.Unlock()
+			if shuttingDown {
+				return nil
+			}
+			if ne, ok := err.(net.Error); ok && ne.Temporary() {
+				time.Sleep(5 * time.Millisecond)
+				continue
+			}
+			return err
+		}
+		if !s.track(conn) {
+			conn.Close()
+			return nil
+		}
+		go s.serveConn(conn)
+	}
+}
+
+// track adds the connection to the server's connections, false if the server
+// is shutting down
+func (s *StreamServer) track(conn net.Conn) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.shuttingDown {
+		return false
+	}
+	s.conns[conn] = struct{}{}
+	return true
+}
+
+func (s *StreamServer) untrack(conn net.Conn) {
+	s.mu.Lock()
+	delete(s.conns, conn)
+	s.mu.Unlock()
+}
+
+func (s *StreamServer) connError(remoteAddr net.Addr, err error) {
+	if s.ErrorHandler == nil || err == io.EOF {
+		return
+	}
+	s.mu.Lock()
+	shuttingDown := s.shuttingDown
+	s.mu.Unlock()
+	if !shuttingDown {
+		s.ErrorHandler(remoteAddr, err)
+	}
+}
+
+// serveConn reads the connection's requests until the connection fails or
+// idles out
+func (s *StreamServer) serveConn(conn net.Conn) {
+	defer func() {
+		conn.Close()
+		s.untrack(conn)
+	}()
+	remoteAddr := conn.RemoteAddr()
+	if tcpConn, ok := conn.(*net.TCPConn); ok && s.KeepAlive > 0 {
+		tcpConn.SetKeepAlive(true)
+		tcpConn.SetKeepAlivePeriod(s.KeepAlive)
+	}
+	if s.TLSConfig != nil {
+		tlsConn := tls.Server(conn, s.TLSConfig)
+		timeout := s.HandshakeTimeout
+		if timeout <= 0 {
+			timeout = DefaultHandshakeTimeout
+		}
+		tlsConn.SetDeadline(time.Now().Add(timeout))
+		if err := tlsConn.Handshake(); err != nil {
+			s.connError(remoteAddr, err)
+			return
+		}
+		tlsConn.SetDeadline(time.Time{})
+		conn = tlsConn
+	}
+
+	var (
+		ctx, cancel = context.WithCancel(context.Background())
+		writeLock   sync.Mutex
+		buff        [MaxPacketLength]byte
+	)
+	defer cancel()
+	for {
+		if s.IdleTimeout > 0 {
+			conn.SetReadDeadline(time.Now().Add(s.IdleTimeout))
+		}
+		wire, err := ReadPacket(conn, buff[:])
+		if err != nil {
+			s.connError(remoteAddr, err)
+			return
+		}
+		secret, err := s.SecretSource.RADIUSSecret(ctx, remoteAddr)
+		if err != nil || len(secret) == 0 {
+			s.connError(remoteAddr, errors.New("radius: no secret of the connection"))
+			return
+		}
+		if !s.InsecureSkipVerify && !IsAuthenticRequest(wire, secret) {
+			// a RadSec peer with a non-authentic request is misconfigured,
+			// its other requests would fail the same way
+			s.connError(remoteAddr, errors.New("radius: request is not authentic"))
+			return
+		}
+		packet, err := Parse(wire, secret)
+		if err != nil {
+			s.connError(remoteAddr, err)
+			return
+		}
+		if s.RequestTap != nil {
+			s.RequestTap(wire, remoteAddr)
+		}
+
+		s.mu.Lock()
+		if s.shuttingDown {
+			s.mu.Unlock()
+			return
+		}
+		s.handlers.Add(1)
+		s.mu.Unlock()
+		go func() {
+			defer s.handlers.Done()
+			response := streamResponseWriter{
+				conn:                 conn,
+				mu:                   &writeLock,
+				requestAuthenticator: packet.Authenticator,
+				secret:               secret,
+			}
+			request := Request{
+				LocalAddr:  conn.LocalAddr(),
+				RemoteAddr: remoteAddr,
+				Packet:     packet,
+				ctx:        ctx,
+			}
+			s.Handler.ServeRADIUS(&response, &request)
+		}()
+	}
+}
+
+// ListenAndServe starts a RADIUS server on the address given in s.
+func (s *StreamServer) ListenAndServe() error {
+	if s.Handler == nil {
+		return errors.New("radius: nil Handler")
+	}
+	if s.SecretSource == nil {
+		return errors.New("radius: nil SecretSource")
+	}
+
+	addrStr := ":2083"
+	if s.Addr != "" {
+		addrStr = s.Addr
+	}
+	ln, err := net.Listen("tcp", addrStr)
+	if err != nil {
+		if s.Ready != nil {
+			s.Ready <- false
+		}
+		return err
+	}
+
+	// Signal server is ready & serving requests
+	if s.Ready != nil {
+		s.Ready <- true
+	}
+	return s.Serve(ln)
+}
+
+// ListenAddr returns the address the server is listening on, nil if it's not
+// listening.
+func (s *StreamServer) ListenAddr() net.Addr {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.listener == nil {
+		return nil
+	}
+	return s.listener.Addr()
+}
+
+// Shutdown gracefully stops the server. It first closes the listener & the
+// connections (which stops reading new requests) and then waits for running
+// handlers to complete.
+//
+// Shutdown returns after all handlers have completed, or when ctx is canceled.
+func (s *StreamServer) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	s.shuttingDown = true
+	if s.listener != nil {
+		s.listener.Close()
+	}
+	for conn := range s.conns {
+		conn.Close()
+	}
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.handlers.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
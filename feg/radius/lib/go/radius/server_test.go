@@ -108,3 +108,102 @@ func RunTestServer(t *testing.T, handler radius.HandlerFunc, verify VerifyRespon
 		t.Fatal(clientErr)
 	}
 }
+
+func TestPacketServer_drain(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	secret := []byte("123456790")
+	handling := make(chan struct{})
+	release := make(chan struct{})
+	server := radius.PacketServer{
+		SecretSource: radius.StaticSecretSource(secret),
+		Handler: radius.HandlerFunc(func(w radius.ResponseWriter, r *radius.Request) {
+			close(handling)
+			<-release
+			w.Write(r.Response(radius.CodeAccessAccept))
+		}),
+	}
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.Serve(pc)
+	}()
+
+	type exchange struct {
+		response *radius.Packet
+		err      error
+	}
+	exchanged := make(chan exchange, 1)
+	go func() {
+		packet := radius.New(radius.CodeAccessRequest, secret)
+		UserName_SetString(packet, "tim")
+		response, err := radius.Exchange(context.Background(), packet, pc.LocalAddr().String())
+		exchanged <- exchange{response, err}
+	}()
+	<-handling
+
+	drained := make(chan error, 1)
+	go func() {
+		drained <- server.Drain(context.Background())
+	}()
+	// Serve stops reading while the in-flight request is handled
+	if err := <-serveErr; err != nil {
+		t.Fatal(err)
+	}
+	close(release)
+	if err := <-drained; err != nil {
+		t.Fatal(err)
+	}
+
+	// The in-flight request is still responded
+	res := <-exchanged
+	if res.err != nil {
+		t.Fatal(res.err)
+	}
+	if res.response.Code != radius.CodeAccessAccept {
+		t.Fatalf("expected CodeAccessAccept, got %s", res.response.Code)
+	}
+}
+
+func TestPacketServer_requestTap(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	secret := []byte("123456790")
+	tapped := make(chan []byte, 1)
+	server := radius.PacketServer{
+		SecretSource: radius.StaticSecretSource(secret),
+		Handler: radius.HandlerFunc(func(w radius.ResponseWriter, r *radius.Request) {
+			w.Write(r.Response(radius.CodeAccountingResponse))
+		}),
+		RequestTap: func(wire []byte, remoteAddr net.Addr) {
+			select {
+			case tapped <- append([]byte(nil), wire...):
+			default: // a retransmission
+			}
+		},
+	}
+	go server.Serve(pc)
+	defer server.Shutdown(context.Background())
+
+	packet := radius.New(radius.CodeAccountingRequest, secret)
+	UserName_SetString(packet, "tim")
+	if _, err := radius.Exchange(context.Background(), packet, pc.LocalAddr().String()); err != nil {
+		t.Fatal(err)
+	}
+	wire := <-tapped
+	if !radius.IsAuthenticRequest(wire, secret) {
+		t.Fatal("tapped request isn't authentic")
+	}
+	tappedPacket, err := radius.Parse(wire, secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tappedPacket.Identifier != packet.Identifier || UserName_GetString(tappedPacket) != "tim" {
+		t.Fatalf("unexpected tapped request %+v", tappedPacket)
+	}
+}
@@ -0,0 +1,158 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package radius_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"fbc/lib/go/radius"
+	. "fbc/lib/go/radius/rfc2865"
+)
+
+func startStreamServer(t *testing.T, server *radius.StreamServer) string {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go server.Serve(ln)
+	return ln.Addr().String()
+}
+
+func TestStreamServer_exchange(t *testing.T) {
+	server := radius.StreamServer{
+		SecretSource: radius.StaticSecretSource([]byte(radius.RadSecSecret)),
+		Handler: radius.HandlerFunc(func(w radius.ResponseWriter, r *radius.Request) {
+			response := r.Response(radius.CodeAccessAccept)
+			UserName_SetString(response, UserName_GetString(r.Packet))
+			w.Write(response)
+		}),
+	}
+	addr := startStreamServer(t, &server)
+	defer server.Shutdown(context.Background())
+
+	client := radius.StreamClient{Timeout: 5 * time.Second}
+	defer client.Close()
+
+	// requests of different NASes may have the same identifier, they are
+	// multiplexed over the connection with identifiers assigned by the client
+	var wg sync.WaitGroup
+	errs := make(chan error, 100)
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			packet := radius.New(radius.CodeAccessRequest, []byte("nas secret"))
+			packet.Identifier = 7
+			username := fmt.Sprintf("user%d", i)
+			UserName_SetString(packet, username)
+			response, err := client.Exchange(context.Background(), packet, addr)
+			if err != nil {
+				errs <- err
+				return
+			}
+			if response.Code != radius.CodeAccessAccept || response.Identifier != 7 ||
+				UserName_GetString(response) != username {
+				errs <- fmt.Errorf("unexpected response %v %d %s to %s",
+					response.Code, response.Identifier, UserName_GetString(response), username)
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestStreamServer_messageAuthenticator(t *testing.T) {
+	received := make(chan *radius.Packet, 1)
+	server := radius.StreamServer{
+		SecretSource: radius.StaticSecretSource([]byte(radius.RadSecSecret)),
+		Handler: radius.HandlerFunc(func(w radius.ResponseWriter, r *radius.Request) {
+			received <- r.Packet
+			w.Write(r.Response(radius.CodeAccountingResponse))
+		}),
+	}
+	addr := startStreamServer(t, &server)
+	defer server.Shutdown(context.Background())
+
+	// the Message-Authenticator of the NAS' secret is recalculated, the
+	// server verifies the re-signed Request Authenticator
+	packet := radius.New(radius.CodeAccountingRequest, []byte("nas secret"))
+	packet.Add(radius.Type(80), make(radius.Attribute, 16))
+	client := radius.StreamClient{Timeout: 5 * time.Second}
+	defer client.Close()
+	response, err := client.Exchange(context.Background(), packet, addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.Code != radius.CodeAccountingResponse {
+		t.Fatalf("expected Accounting-Response, got %v", response.Code)
+	}
+	authenticator, ok := (<-received).Lookup(radius.Type(80))
+	if !ok || bytes.Equal(authenticator, make([]byte, 16)) {
+		t.Fatalf("expected a Message-Authenticator, got %v", authenticator)
+	}
+}
+
+func TestStreamClient_reconnect(t *testing.T) {
+	server := radius.StreamServer{
+		SecretSource: radius.StaticSecretSource([]byte(radius.RadSecSecret)),
+		IdleTimeout:  50 * time.Millisecond,
+		Handler: radius.HandlerFunc(func(w radius.ResponseWriter, r *radius.Request) {
+			w.Write(r.Response(radius.CodeAccessReject))
+		}),
+	}
+	addr := startStreamServer(t, &server)
+	defer server.Shutdown(context.Background())
+
+	client := radius.StreamClient{Timeout: 5 * time.Second}
+	defer client.Close()
+	for i := 0; i < 2; i++ {
+		packet := radius.New(radius.CodeAccessRequest, []byte(radius.RadSecSecret))
+		response, err := client.Exchange(context.Background(), packet, addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if response.Code != radius.CodeAccessReject {
+			t.Fatalf("expected Access-Reject, got %v", response.Code)
+		}
+		// the idle connection is closed by the server & reopened by the next request
+		time.Sleep(200 * time.Millisecond)
+	}
+}
+
+func TestReadPacket(t *testing.T) {
+	buff := make([]byte, radius.MaxPacketLength)
+	packet := radius.New(radius.CodeAccessRequest, []byte(radius.RadSecSecret))
+	UserName_SetString(packet, "tim")
+	wire, err := packet.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream := bytes.NewReader(append(append([]byte(nil), wire...), wire...))
+	for i := 0; i < 2; i++ {
+		read, err := radius.ReadPacket(stream, buff)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(read, wire) {
+			t.Fatalf("expected %v, got %v", wire, read)
+		}
+	}
+	if _, err = radius.ReadPacket(bytes.NewReader([]byte{1, 2, 0, 4}), buff); err == nil {
+		t.Fatal("expected an invalid packet length error")
+	}
+}
@@ -0,0 +1,178 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package retry
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrOpen is returned by the calls rejected by an open circuit breaker. Its
+// gRPC code is Unavailable, so callers handle it as an unavailable backend.
+var ErrOpen = status.Error(codes.Unavailable, "circuit breaker is open")
+
+// State of a circuit breaker.
+type State int
+
+const (
+	// Closed calls are made.
+	Closed State = iota
+	// Open calls fail fast with ErrOpen.
+	Open
+	// HalfOpen a single trial call is made, its outcome closes or reopens
+	// the circuit.
+	HalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half_open"
+	}
+	return fmt.Sprintf("state(%d)", int(s))
+}
+
+// Breaker is the circuit breaker of a backend, so calls of an unavailable
+// backend fail fast instead of piling up on it. The circuit opens after
+// Failures consecutive failed calls & rejects calls for the Cooldown, then
+// lets a trial call through: its success closes the circuit, its failure
+// reopens it.
+type Breaker struct {
+	// Failures the number of consecutive failed calls opening the circuit, 0
+	// never opens it.
+	Failures int
+	// Cooldown the time the circuit is open for before a trial call.
+	Cooldown time.Duration
+	// IsFailure the classifier of the errors counted as failures, transient
+	// errors are if nil. Other errors count as successes, the backend did
+	// respond.
+	IsFailure Classifier
+	// OnStateChange is called, if set, on each state change, e.g. to count
+	// open circuits.
+	OnStateChange func(s State)
+
+	mu          sync.Mutex
+	state       State
+	consecutive int
+	opened      time.Time
+}
+
+// Do calls op unless the circuit is open, ErrOpen is returned then, & counts
+// its outcome. A nil Breaker always calls op.
+func (b *Breaker) Do(ctx context.Context, op func(ctx context.Context) error) error {
+	if b == nil || b.Failures <= 0 {
+		return op(ctx)
+	}
+	if !b.allow() {
+		return ErrOpen
+	}
+	err := op(ctx)
+	b.done(err)
+	return err
+}
+
+// State returns the current state of the circuit.
+func (b *Breaker) State() State {
+	if b == nil {
+		return Closed
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+func (b *Breaker) allow() bool {
+	b.mu.Lock()
+	switch b.state {
+	case Closed:
+		b.mu.Unlock()
+		return true
+	case Open:
+		if time.Since(b.opened) >= b.Cooldown {
+			b.setState(HalfOpen) // unlocks
+			return true
+		}
+	}
+	b.mu.Unlock()
+	return false // open or a trial call is in progress
+}
+
+func (b *Breaker) done(err error) {
+	isFailure := b.IsFailure
+	if isFailure == nil {
+		isFailure = Transient
+	}
+	b.mu.Lock()
+	if err == nil || !isFailure(err) {
+		b.consecutive = 0
+		if b.state == HalfOpen {
+			b.setState(Closed) // unlocks
+			return
+		}
+		b.mu.Unlock()
+		return
+	}
+	b.consecutive++
+	if b.state == HalfOpen || (b.state == Closed && b.consecutive >= b.Failures) {
+		b.opened = time.Now()
+		b.setState(Open) // unlocks
+		return
+	}
+	b.mu.Unlock()
+}
+
+// setState sets the state of the locked breaker, unlocks it & calls
+// OnStateChange.
+func (b *Breaker) setState(s State) {
+	b.state = s
+	b.mu.Unlock()
+	if b.OnStateChange != nil {
+		b.OnStateChange(s)
+	}
+}
+
+// BreakerConfig the declarative configuration of a Breaker.
+type BreakerConfig struct {
+	// Failures the number of consecutive failed calls opening the circuit.
+	Failures int `json:"failures"`
+	// CooldownMs the time the circuit is open for before a trial call.
+	CooldownMs int `json:"cooldownMs"`
+	// FailureCodes the gRPC status codes of the errors counted as failures,
+	// by name, transient errors are if not set.
+	FailureCodes []string `json:"failureCodes"`
+}
+
+// NewBreaker returns the configured Breaker.
+func NewBreaker(c BreakerConfig) (*Breaker, error) {
+	if c.Failures < 0 || c.CooldownMs < 0 {
+		return nil, fmt.Errorf("invalid circuit breaker configuration: %+v", c)
+	}
+	b := &Breaker{Failures: c.Failures, Cooldown: time.Duration(c.CooldownMs) * time.Millisecond, IsFailure: Transient}
+	if len(c.FailureCodes) > 0 {
+		failures := make([]codes.Code, 0, len(c.FailureCodes))
+		for _, name := range c.FailureCodes {
+			code, err := ParseCode(name)
+			if err != nil {
+				return nil, err
+			}
+			failures = append(failures, code)
+		}
+		b.IsFailure = Codes(failures...)
+	}
+	return b, nil
+}
@@ -0,0 +1,54 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package retry
+
+import "sync"
+
+// Budget throttles the retries of a client (shared by all its calls), so
+// retries don't multiply the load of an overloaded backend. As gRPC's retry
+// throttling: each failure spends a token, each success earns tokenRatio
+// tokens & retries are only allowed while more than half of the tokens are
+// left.
+type Budget struct {
+	mu         sync.Mutex
+	tokens     float64
+	maxTokens  float64
+	tokenRatio float64
+}
+
+// NewBudget returns a full budget of maxTokens tokens.
+func NewBudget(maxTokens, tokenRatio float64) *Budget {
+	return &Budget{tokens: maxTokens, maxTokens: maxTokens, tokenRatio: tokenRatio}
+}
+
+func (b *Budget) success() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	b.tokens += b.tokenRatio
+	if b.tokens > b.maxTokens {
+		b.tokens = b.maxTokens
+	}
+	b.mu.Unlock()
+}
+
+// failure spends a token & returns true if a retry is allowed.
+func (b *Budget) failure() bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens--
+	if b.tokens < 0 {
+		b.tokens = 0
+	}
+	return b.tokens > b.maxTokens/2
+}
@@ -0,0 +1,171 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package retry
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Class is the error taxonomy shared by the RADIUS server, the AAA & their
+// clients, so failed calls are retried, answered & counted alike whatever
+// their error's origin: a gRPC status, a Diameter result code, a RADIUS
+// Error-Cause or a client error.
+type Class int
+
+const (
+	// ClassNone the class of nil errors.
+	ClassNone Class = iota
+	// ClassTransient errors may succeed when retried, e.g. an unreachable or
+	// overloaded backend. Client errors without a gRPC status, e.g. network
+	// errors, are transient.
+	ClassTransient
+	// ClassPermanent errors fail again when retried, e.g. an invalid request
+	// or an unknown session.
+	ClassPermanent
+	// ClassConfig errors fail until the deployment is fixed, e.g. a missing
+	// service or a backend without the called method.
+	ClassConfig
+	// ClassSecurity errors are denied requests, e.g. an unauthenticated
+	// subscriber or an administratively prohibited request.
+	ClassSecurity
+)
+
+var classNames = [...]string{"none", "transient", "permanent", "config", "security"}
+
+// String returns the class's name, e.g. the value of its metrics label.
+func (c Class) String() string {
+	if c < 0 || int(c) >= len(classNames) {
+		return "unknown"
+	}
+	return classNames[c]
+}
+
+// codeClasses - classes of the gRPC status codes, other codes are permanent
+var codeClasses = map[codes.Code]Class{
+	codes.OK:                ClassNone,
+	codes.Canceled:          ClassTransient,
+	codes.Unknown:           ClassTransient,
+	codes.DeadlineExceeded:  ClassTransient,
+	codes.ResourceExhausted: ClassTransient,
+	codes.Aborted:           ClassTransient,
+	codes.Unavailable:       ClassTransient,
+	codes.Unimplemented:     ClassConfig,
+	codes.PermissionDenied:  ClassSecurity,
+	codes.Unauthenticated:   ClassSecurity,
+}
+
+// classCodes - gRPC status codes of the errors marked with a class which
+// have no status of their own
+var classCodes = map[Class]codes.Code{
+	ClassTransient: codes.Unavailable,
+	ClassPermanent: codes.InvalidArgument,
+	ClassConfig:    codes.Unimplemented,
+	ClassSecurity:  codes.PermissionDenied,
+}
+
+// Diameter result codes (RFC 6733) returned as gRPC status codes by the
+// Diameter proxies' calls
+const (
+	minDiameterResultCode          = 1000
+	minDiameterProtocolError       = 3000
+	minDiameterPermanentFailure    = 5000
+	diameterAuthenticationRejected = 4001
+	diameterAuthorizationRejected  = 5003
+)
+
+// CodeClass returns the class of errors with the gRPC status code. Diameter
+// result codes are transient for protocol errors (3xxx) & transient failures
+// (4xxx), permanent for permanent failures (5xxx) but rejections, which are
+// security errors.
+func CodeClass(code codes.Code) Class {
+	if code >= minDiameterResultCode {
+		switch {
+		case code == diameterAuthenticationRejected || code == diameterAuthorizationRejected:
+			return ClassSecurity
+		case code < minDiameterProtocolError:
+			return ClassNone
+		case code < minDiameterPermanentFailure:
+			return ClassTransient
+		default:
+			return ClassPermanent
+		}
+	}
+	if c, ok := codeClasses[code]; ok {
+		return c
+	}
+	return ClassPermanent
+}
+
+// ErrorCauseClass returns the class of the RADIUS Error-Cause (RFC 5176) of a
+// CoA-NAK or Disconnect-NAK, success causes (2xx) have no class.
+func ErrorCauseClass(cause uint32) Class {
+	switch {
+	case cause < 400:
+		return ClassNone
+	case cause == 403 || cause == 501: // NAS-Identification-Mismatch, Administratively-Prohibited
+		return ClassSecurity
+	case cause == 401 || cause == 402 || cause == 405 || cause == 406:
+		// Unsupported-Attribute, Missing-Attribute, Unsupported-Service &
+		// Unsupported-Extension: the NAS isn't configured for the request
+		return ClassConfig
+	case cause == 502 || cause == 505 || cause == 506:
+		// Proxy-Request-Not-Routable, Proxy-Processing-Error &
+		// Resources-Unavailable
+		return ClassTransient
+	default:
+		return ClassPermanent
+	}
+}
+
+// ClassOf returns the class of err: the class it's marked with, or the class
+// of its gRPC status code. Errors without a status have the Unknown code &
+// are transient.
+func ClassOf(err error) Class {
+	if err == nil {
+		return ClassNone
+	}
+	if c, ok := err.(classifiedError); ok {
+		return c.class
+	}
+	return CodeClass(status.Code(err))
+}
+
+type classifiedError struct {
+	error
+	class Class
+}
+
+// GRPCStatus returns the status of the marked error, errors without one have
+// their class's code, so errors returned by gRPC servers keep their class.
+func (e classifiedError) GRPCStatus() *status.Status {
+	if s, ok := status.FromError(e.error); ok {
+		return s
+	}
+	code, ok := classCodes[e.class]
+	if !ok {
+		code = codes.Unknown
+	}
+	return status.New(code, e.Error())
+}
+
+// WithClass marks err with the class c, overriding the class of its gRPC
+// status code, e.g. a capacity error which retries won't resolve.
+func WithClass(err error, c Class) error {
+	if err == nil {
+		return nil
+	}
+	return classifiedError{Unwrap(err), c}
+}
+
+// Transient classifies transient errors as retryable, the default classifier
+// of Retriers & Breakers.
+func Transient(err error) bool {
+	return ClassOf(err) == ClassTransient
+}
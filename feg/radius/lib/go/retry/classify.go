@@ -0,0 +1,71 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package retry
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Classifier reports whether a failed attempt's error is worth retrying.
+type Classifier func(err error) bool
+
+// Any classifies all errors, but permanent ones, as retryable.
+func Any(err error) bool {
+	return !IsPermanent(err)
+}
+
+// Codes classifies errors with one of the given gRPC status codes as
+// retryable. Errors of non gRPC calls have the Unknown code.
+func Codes(retryable ...codes.Code) Classifier {
+	set := make(map[codes.Code]bool, len(retryable))
+	for _, c := range retryable {
+		set[c] = true
+	}
+	return func(err error) bool {
+		return !IsPermanent(err) && set[status.Code(err)]
+	}
+}
+
+// ParseCode returns the gRPC status code of the given name, e.g.
+// "Unavailable" or "UNAVAILABLE".
+func ParseCode(name string) (codes.Code, error) {
+	normalized := strings.Replace(name, "_", "", -1)
+	for c := codes.OK; c <= codes.Unauthenticated; c++ {
+		if strings.EqualFold(c.String(), normalized) {
+			return c, nil
+		}
+	}
+	return codes.Unknown, fmt.Errorf("unknown gRPC status code '%s'", name)
+}
+
+// Permanent marks err as not retryable by any classifier, e.g. an HTTP
+// client error (4xx) response.
+func Permanent(err error) error {
+	return WithClass(err, ClassPermanent)
+}
+
+// IsPermanent returns true if err was marked by Permanent, or by WithClass
+// with another class than transient.
+func IsPermanent(err error) bool {
+	c, ok := err.(classifiedError)
+	return ok && c.class != ClassTransient
+}
+
+// Unwrap returns the error marked by Permanent or WithClass, other errors are
+// returned as is.
+func Unwrap(err error) error {
+	if c, ok := err.(classifiedError); ok {
+		return c.error
+	}
+	return err
+}
@@ -0,0 +1,8 @@
+module fbc/lib/go/retry
+
+go 1.12
+
+require (
+	github.com/stretchr/testify v1.3.0
+	google.golang.org/grpc v1.17.0
+)
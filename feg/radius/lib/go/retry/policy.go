@@ -0,0 +1,62 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package retry
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Policy computes the backoff delay before retrying a failed attempt.
+type Policy interface {
+	// Delay returns the delay before the retry of the given failed attempt, the first attempt is 1.
+	Delay(attempt int) time.Duration
+}
+
+// PolicyFunc adapts a function to the Policy interface.
+type PolicyFunc func(attempt int) time.Duration
+
+// Delay implements Policy.
+func (f PolicyFunc) Delay(attempt int) time.Duration { return f(attempt) }
+
+// Constant returns a policy retrying after the same delay every time.
+func Constant(delay time.Duration) Policy {
+	return PolicyFunc(func(int) time.Duration { return delay })
+}
+
+// Exponential returns a policy multiplying the delay by multiplier on every
+// attempt, starting at initial & capped at max (when positive).
+func Exponential(initial, max time.Duration, multiplier float64) Policy {
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	return PolicyFunc(func(attempt int) time.Duration {
+		delay := float64(initial) * math.Pow(multiplier, float64(attempt-1))
+		if max > 0 && delay > float64(max) {
+			return max
+		}
+		return time.Duration(delay)
+	})
+}
+
+// Jitter returns a policy randomizing the delays of p by up to +/- fraction,
+// so clients failing together don't retry together.
+func Jitter(p Policy, fraction float64) Policy {
+	if fraction <= 0 {
+		return p
+	}
+	if fraction > 1 {
+		fraction = 1
+	}
+	return PolicyFunc(func(attempt int) time.Duration {
+		delay := float64(p.Delay(attempt))
+		return time.Duration(delay * (1 + fraction*(2*rand.Float64()-1)))
+	})
+}
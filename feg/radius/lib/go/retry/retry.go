@@ -0,0 +1,134 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package retry implements the retries of failed client calls, shared by the
+// RADIUS server & AAA clients: backoff policies, retry budgets, circuit
+// breakers & the error taxonomy classifying the failures.
+package retry
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+)
+
+// Retrier retries failed calls as per its policy.
+type Retrier struct {
+	// MaxAttempts the maximum number of attempts of a call, the first
+	// included. 0 or 1 disable retries.
+	MaxAttempts int
+	// Policy the backoff policy, retries are immediate if nil.
+	Policy Policy
+	// Retryable the classifier of retryable errors, transient errors are
+	// retryable if nil.
+	Retryable Classifier
+	// Budget the optional budget throttling the retries.
+	Budget *Budget
+	// OnRetry is called, if set, before each retry with the failed attempt
+	// number & its error, e.g. to count retries.
+	OnRetry func(attempt int, err error)
+}
+
+// NoRetry a Retrier making single attempts.
+var NoRetry = &Retrier{MaxAttempts: 1}
+
+// Do calls op until it succeeds, its error isn't retryable, the attempts or
+// budget are exhausted or ctx is done. The last attempt's error is returned,
+// unwrapped if marked as permanent. Retries which would start after ctx's
+// deadline are not made.
+func (r *Retrier) Do(ctx context.Context, op func(ctx context.Context) error) error {
+	retryable := r.Retryable
+	if retryable == nil {
+		retryable = Transient
+	}
+	for attempt := 1; ; attempt++ {
+		err := op(ctx)
+		if err == nil {
+			r.Budget.success()
+			return nil
+		}
+		if attempt >= r.MaxAttempts || !retryable(err) || !r.Budget.failure() {
+			return Unwrap(err)
+		}
+		var delay time.Duration
+		if r.Policy != nil {
+			delay = r.Policy.Delay(attempt)
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(delay).After(deadline) {
+			return err
+		}
+		if r.OnRetry != nil {
+			r.OnRetry(attempt, err)
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// Config the declarative configuration of a Retrier.
+type Config struct {
+	// MaxAttempts the maximum number of attempts of a call, the first
+	// included.
+	MaxAttempts int `json:"maxAttempts"`
+	// InitialBackoffMs the delay before the first retry.
+	InitialBackoffMs int `json:"initialBackoffMs"`
+	// MaxBackoffMs caps the delays, unlimited if not set.
+	MaxBackoffMs int `json:"maxBackoffMs"`
+	// BackoffMultiplier the factor by which the delay grows on each retry,
+	// constant delays if not set.
+	BackoffMultiplier float64 `json:"backoffMultiplier"`
+	// Jitter the fraction by which delays are randomized.
+	Jitter float64 `json:"jitter"`
+	// RetryableCodes the retryable gRPC status codes, by name, transient
+	// errors are retryable if not set.
+	RetryableCodes []string `json:"retryableCodes"`
+	// BudgetMaxTokens & BudgetTokenRatio configure the client's retry budget,
+	// unlimited if not set.
+	BudgetMaxTokens  float64 `json:"budgetMaxTokens"`
+	BudgetTokenRatio float64 `json:"budgetTokenRatio"`
+}
+
+// New returns the configured Retrier.
+func New(c Config) (*Retrier, error) {
+	if c.MaxAttempts < 0 || c.InitialBackoffMs < 0 || c.MaxBackoffMs < 0 || c.BackoffMultiplier < 0 ||
+		c.Jitter < 0 || c.Jitter > 1 || c.BudgetMaxTokens < 0 || c.BudgetTokenRatio < 0 {
+		return nil, fmt.Errorf("invalid retry configuration: %+v", c)
+	}
+	r := &Retrier{MaxAttempts: c.MaxAttempts}
+	initial := time.Duration(c.InitialBackoffMs) * time.Millisecond
+	if c.BackoffMultiplier > 1 {
+		r.Policy = Exponential(initial, time.Duration(c.MaxBackoffMs)*time.Millisecond, c.BackoffMultiplier)
+	} else {
+		r.Policy = Constant(initial)
+	}
+	r.Policy = Jitter(r.Policy, c.Jitter)
+	if len(c.RetryableCodes) > 0 {
+		retryable := make([]codes.Code, 0, len(c.RetryableCodes))
+		for _, name := range c.RetryableCodes {
+			code, err := ParseCode(name)
+			if err != nil {
+				return nil, err
+			}
+			retryable = append(retryable, code)
+		}
+		r.Retryable = Codes(retryable...)
+	} else {
+		r.Retryable = Transient
+	}
+	if c.BudgetMaxTokens > 0 {
+		r.Budget = NewBudget(c.BudgetMaxTokens, c.BudgetTokenRatio)
+	}
+	return r, nil
+}
@@ -0,0 +1,267 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// failing returns an op failing with err the first failures times
+func failing(failures int, err error) (func(context.Context) error, *int) {
+	calls := 0
+	return func(context.Context) error {
+		calls++
+		if calls <= failures {
+			return err
+		}
+		return nil
+	}, &calls
+}
+
+func TestPolicies(t *testing.T) {
+	assert.Equal(t, 5*time.Millisecond, Constant(5*time.Millisecond).Delay(3))
+
+	exp := Exponential(10*time.Millisecond, 50*time.Millisecond, 2)
+	assert.Equal(t, 10*time.Millisecond, exp.Delay(1))
+	assert.Equal(t, 20*time.Millisecond, exp.Delay(2))
+	assert.Equal(t, 40*time.Millisecond, exp.Delay(3))
+	assert.Equal(t, 50*time.Millisecond, exp.Delay(4))
+
+	jittered := Jitter(Constant(100*time.Millisecond), 0.2)
+	for i := 0; i < 100; i++ {
+		delay := jittered.Delay(1)
+		assert.True(t, delay >= 80*time.Millisecond && delay <= 120*time.Millisecond, "delay: %v", delay)
+	}
+}
+
+func TestRetrierDo(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "unavailable")
+	r := &Retrier{MaxAttempts: 3, Retryable: Codes(codes.Unavailable)}
+
+	op, calls := failing(2, unavailable)
+	assert.NoError(t, r.Do(context.Background(), op))
+	assert.Equal(t, 3, *calls)
+
+	op, calls = failing(3, unavailable)
+	assert.Equal(t, unavailable, r.Do(context.Background(), op))
+	assert.Equal(t, 3, *calls)
+
+	invalid := status.Error(codes.InvalidArgument, "invalid")
+	op, calls = failing(1, invalid)
+	assert.Equal(t, invalid, r.Do(context.Background(), op))
+	assert.Equal(t, 1, *calls)
+
+	permanent := errors.New("permanent")
+	op, calls = failing(1, Permanent(permanent))
+	assert.Equal(t, permanent, (&Retrier{MaxAttempts: 3}).Do(context.Background(), op))
+	assert.Equal(t, 1, *calls)
+
+	op, calls = failing(1, unavailable)
+	assert.Equal(t, unavailable, NoRetry.Do(context.Background(), op))
+	assert.Equal(t, 1, *calls)
+}
+
+func TestRetrierDoContext(t *testing.T) {
+	r := &Retrier{MaxAttempts: 3, Policy: Constant(time.Hour)}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// Retries which would start after the deadline are not made
+	op, calls := failing(1, errors.New("failed"))
+	start := time.Now()
+	assert.Error(t, r.Do(ctx, op))
+	assert.Equal(t, 1, *calls)
+	assert.True(t, time.Since(start) < time.Second)
+
+	r.Policy = Constant(time.Minute)
+	ctx, cancel = context.WithCancel(context.Background())
+	op, calls = failing(1, errors.New("failed"))
+	r.OnRetry = func(attempt int, err error) { cancel() }
+	assert.Error(t, r.Do(ctx, op))
+	assert.Equal(t, 1, *calls)
+}
+
+func TestBudget(t *testing.T) {
+	b := NewBudget(4, 0.5)
+	assert.True(t, b.failure())  // 3 tokens
+	assert.False(t, b.failure()) // 2 tokens, not more than half
+	b.success()
+	assert.False(t, b.failure()) // 1.5 tokens
+	for i := 0; i < 10; i++ {
+		b.success()
+	}
+	assert.True(t, b.failure()) // capped at 4, 3 tokens
+
+	r := &Retrier{MaxAttempts: 5, Budget: NewBudget(4, 1)}
+	op, calls := failing(5, errors.New("failed"))
+	assert.Error(t, r.Do(context.Background(), op))
+	assert.Equal(t, 2, *calls)
+}
+
+func TestNew(t *testing.T) {
+	r, err := New(Config{
+		MaxAttempts:       3,
+		InitialBackoffMs:  10,
+		MaxBackoffMs:      30,
+		BackoffMultiplier: 2,
+		RetryableCodes:    []string{"UNAVAILABLE", "ResourceExhausted"},
+		BudgetMaxTokens:   10,
+		BudgetTokenRatio:  0.1,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, r.MaxAttempts)
+	assert.Equal(t, 20*time.Millisecond, r.Policy.Delay(2))
+	assert.Equal(t, 30*time.Millisecond, r.Policy.Delay(3))
+	assert.True(t, r.Retryable(status.Error(codes.Unavailable, "")))
+	assert.True(t, r.Retryable(status.Error(codes.ResourceExhausted, "")))
+	assert.False(t, r.Retryable(status.Error(codes.NotFound, "")))
+	assert.NotNil(t, r.Budget)
+
+	r, err = New(Config{MaxAttempts: 2, InitialBackoffMs: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Millisecond, r.Policy.Delay(2))
+	assert.True(t, r.Retryable(errors.New("any")))
+	assert.Nil(t, r.Budget)
+
+	_, err = New(Config{RetryableCodes: []string{"NoSuchCode"}})
+	assert.Error(t, err)
+	_, err = New(Config{Jitter: 2})
+	assert.Error(t, err)
+}
+
+func TestBreaker(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "unavailable")
+	var states []State
+	b := &Breaker{
+		Failures:      2,
+		Cooldown:      20 * time.Millisecond,
+		IsFailure:     Codes(codes.Unavailable),
+		OnStateChange: func(s State) { states = append(states, s) },
+	}
+	fail := func(context.Context) error { return unavailable }
+	succeed := func(context.Context) error { return nil }
+
+	assert.Equal(t, unavailable, b.Do(context.Background(), fail))
+	assert.NoError(t, b.Do(context.Background(), succeed)) // resets the consecutive failures
+	assert.Equal(t, unavailable, b.Do(context.Background(), fail))
+	// other errors aren't failures
+	invalid := status.Error(codes.InvalidArgument, "invalid")
+	assert.Equal(t, invalid, b.Do(context.Background(), func(context.Context) error { return invalid }))
+	assert.Equal(t, Closed, b.State())
+
+	assert.Equal(t, unavailable, b.Do(context.Background(), fail))
+	assert.Equal(t, unavailable, b.Do(context.Background(), fail))
+	assert.Equal(t, Open, b.State())
+	op, calls := failing(0, nil)
+	assert.Equal(t, ErrOpen, b.Do(context.Background(), op))
+	assert.Equal(t, 0, *calls)
+	assert.Equal(t, codes.Unavailable, status.Code(ErrOpen))
+
+	// the failed trial call reopens the circuit, the successful one closes it
+	time.Sleep(b.Cooldown)
+	assert.Equal(t, unavailable, b.Do(context.Background(), fail))
+	assert.Equal(t, ErrOpen, b.Do(context.Background(), op))
+	time.Sleep(b.Cooldown)
+	assert.NoError(t, b.Do(context.Background(), op))
+	assert.Equal(t, 1, *calls)
+	assert.Equal(t, Closed, b.State())
+	assert.Equal(t, []State{Open, HalfOpen, Open, HalfOpen, Closed}, states)
+
+	var disabled *Breaker
+	assert.Equal(t, unavailable, disabled.Do(context.Background(), fail))
+	assert.Equal(t, Closed, disabled.State())
+}
+
+func TestNewBreaker(t *testing.T) {
+	b, err := NewBreaker(BreakerConfig{Failures: 5, CooldownMs: 100, FailureCodes: []string{"Unavailable"}})
+	require.NoError(t, err)
+	assert.Equal(t, 5, b.Failures)
+	assert.Equal(t, 100*time.Millisecond, b.Cooldown)
+	assert.True(t, b.IsFailure(status.Error(codes.Unavailable, "")))
+	assert.False(t, b.IsFailure(status.Error(codes.NotFound, "")))
+
+	b, err = NewBreaker(BreakerConfig{Failures: 5})
+	require.NoError(t, err)
+	assert.True(t, b.IsFailure(errors.New("any")))
+
+	_, err = NewBreaker(BreakerConfig{FailureCodes: []string{"NoSuchCode"}})
+	assert.Error(t, err)
+	_, err = NewBreaker(BreakerConfig{Failures: -1})
+	assert.Error(t, err)
+}
+
+func TestClasses(t *testing.T) {
+	assert.Equal(t, ClassNone, ClassOf(nil))
+	assert.Equal(t, ClassTransient, ClassOf(errors.New("connection refused")))
+	assert.Equal(t, ClassTransient, ClassOf(status.Error(codes.DeadlineExceeded, "")))
+	assert.Equal(t, ClassPermanent, ClassOf(status.Error(codes.NotFound, "")))
+	assert.Equal(t, ClassConfig, ClassOf(status.Error(codes.Unimplemented, "")))
+	assert.Equal(t, ClassSecurity, ClassOf(status.Error(codes.Unauthenticated, "")))
+
+	// Diameter result codes
+	assert.Equal(t, ClassTransient, CodeClass(3004))
+	assert.Equal(t, ClassTransient, CodeClass(4181))
+	assert.Equal(t, ClassSecurity, CodeClass(4001))
+	assert.Equal(t, ClassPermanent, CodeClass(5001))
+
+	// RADIUS Error-Causes
+	assert.Equal(t, ClassNone, ErrorCauseClass(201))
+	assert.Equal(t, ClassConfig, ErrorCauseClass(405))
+	assert.Equal(t, ClassSecurity, ErrorCauseClass(501))
+	assert.Equal(t, ClassPermanent, ErrorCauseClass(503))
+	assert.Equal(t, ClassTransient, ErrorCauseClass(506))
+
+	assert.Equal(t, "security", ClassSecurity.String())
+	assert.Equal(t, "unknown", Class(42).String())
+}
+
+func TestWithClass(t *testing.T) {
+	assert.Nil(t, WithClass(nil, ClassPermanent))
+
+	// marked errors keep their status, errors without one get their class's code
+	exhausted := status.Error(codes.ResourceExhausted, "APN is at its capacity")
+	err := WithClass(exhausted, ClassPermanent)
+	assert.Equal(t, ClassPermanent, ClassOf(err))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.True(t, IsPermanent(err))
+	assert.Equal(t, exhausted, Unwrap(err))
+
+	err = WithClass(errors.New("accounting service is missing"), ClassConfig)
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+	assert.Equal(t, "accounting service is missing", status.Convert(err).Message())
+
+	// marking again replaces the class
+	err = WithClass(Permanent(exhausted), ClassTransient)
+	assert.Equal(t, ClassTransient, ClassOf(err))
+	assert.False(t, IsPermanent(err))
+	assert.True(t, Transient(err))
+
+	// the default classifiers retry & count transient errors only
+	r := &Retrier{MaxAttempts: 3}
+	op, calls := failing(3, status.Error(codes.InvalidArgument, "invalid"))
+	assert.Error(t, r.Do(context.Background(), op))
+	assert.Equal(t, 1, *calls)
+	op, calls = failing(2, status.Error(codes.Unavailable, "unavailable"))
+	assert.NoError(t, r.Do(context.Background(), op))
+	assert.Equal(t, 3, *calls)
+
+	b := &Breaker{Failures: 1, Cooldown: time.Minute}
+	assert.Error(t, b.Do(context.Background(), func(context.Context) error { return Permanent(errors.New("4xx")) }))
+	assert.Equal(t, Closed, b.State())
+	assert.Error(t, b.Do(context.Background(), func(context.Context) error { return errors.New("timeout") }))
+	assert.Equal(t, Open, b.State())
+}
@@ -0,0 +1,5 @@
+module fbc/lib/go/rolling
+
+go 1.12
+
+require github.com/stretchr/testify v1.3.0
@@ -0,0 +1,158 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package rolling counts successes & failures over sliding time windows,
+// e.g. the authentication success rate of the last 1, 5 & 15 minutes. Unlike
+// Prometheus rate(), the rates are available to on-gateway decision logic
+// such as circuit breakers.
+package rolling
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultResolution is the default width of a counter's buckets, windows
+// are accurate to a bucket.
+const DefaultResolution = 5 * time.Second
+
+// DefaultWindows are the 1m, 5m & 15m windows.
+var DefaultWindows = []time.Duration{time.Minute, 5 * time.Minute, 15 * time.Minute}
+
+// Stats are the successes & failures of a window.
+type Stats struct {
+	Window    time.Duration
+	Successes uint64
+	Failures  uint64
+}
+
+// Total returns the number of the window's outcomes.
+func (s Stats) Total() uint64 {
+	return s.Successes + s.Failures
+}
+
+// SuccessRate returns the window's success rate in the [0, 1] range, 1 if
+// the window has no outcomes, so idle windows never look unhealthy.
+func (s Stats) SuccessRate() float64 {
+	if s.Total() == 0 {
+		return 1
+	}
+	return float64(s.Successes) / float64(s.Total())
+}
+
+// Label returns the window's short name for metric labels, e.g. 5m for a 5 minutes window
+func Label(window time.Duration) string {
+	if window%time.Minute == 0 {
+		return fmt.Sprintf("%dm", window/time.Minute)
+	}
+	return fmt.Sprintf("%ds", window/time.Second)
+}
+
+type bucket struct {
+	slot      int64 // bucket's time slot: Unix time / resolution
+	successes uint64
+	failures  uint64
+}
+
+// Counter counts outcomes in a ring of time buckets covering its longest
+// window. Counters are safe for concurrent use.
+type Counter struct {
+	mu         sync.Mutex
+	resolution time.Duration
+	buckets    []bucket
+	now        func() time.Time
+}
+
+// New returns a counter of windows up to maxWindow with buckets of the given
+// resolution, DefaultResolution if resolution isn't positive.
+func New(maxWindow, resolution time.Duration) *Counter {
+	if resolution <= 0 {
+		resolution = DefaultResolution
+	}
+	n := int((maxWindow + resolution - 1) / resolution)
+	if n < 1 {
+		n = 1
+	}
+	// the current bucket is partial, one more bucket keeps the full window
+	return &Counter{resolution: resolution, buckets: make([]bucket, n+1), now: time.Now}
+}
+
+// NewDefault returns a counter of the DefaultWindows.
+func NewDefault() *Counter {
+	return New(DefaultWindows[len(DefaultWindows)-1], DefaultResolution)
+}
+
+// Success counts a success.
+func (c *Counter) Success() {
+	c.Add(true)
+}
+
+// Failure counts a failure.
+func (c *Counter) Failure() {
+	c.Add(false)
+}
+
+// Add counts the outcome. A nil counter counts nothing.
+func (c *Counter) Add(success bool) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	slot := c.slot()
+	b := &c.buckets[c.index(slot)]
+	if b.slot != slot {
+		*b = bucket{slot: slot}
+	}
+	if success {
+		b.successes++
+	} else {
+		b.failures++
+	}
+	c.mu.Unlock()
+}
+
+// Stats returns the outcomes of the window ending now, windows longer than
+// the counter's maximum window are truncated to it.
+func (c *Counter) Stats(window time.Duration) Stats {
+	res := Stats{Window: window}
+	if c == nil {
+		return res
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	current := c.slot()
+	n := int64((window + c.resolution - 1) / c.resolution)
+	if n > int64(len(c.buckets)) {
+		n = int64(len(c.buckets))
+	}
+	for slot := current - n + 1; slot <= current; slot++ {
+		if b := c.buckets[c.index(slot)]; b.slot == slot {
+			res.Successes += b.successes
+			res.Failures += b.failures
+		}
+	}
+	return res
+}
+
+// Windows returns the stats of each of the windows.
+func (c *Counter) Windows(windows ...time.Duration) []Stats {
+	res := make([]Stats, 0, len(windows))
+	for _, w := range windows {
+		res = append(res, c.Stats(w))
+	}
+	return res
+}
+
+func (c *Counter) slot() int64 {
+	return c.now().UnixNano() / int64(c.resolution)
+}
+
+func (c *Counter) index(slot int64) int {
+	return int(slot % int64(len(c.buckets)))
+}
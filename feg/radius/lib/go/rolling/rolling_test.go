@@ -0,0 +1,93 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package rolling
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time { return c.t }
+
+func TestCounter(t *testing.T) {
+	// Arrange
+	clock := &fakeClock{t: time.Unix(1000, 0)}
+	c := New(15*time.Minute, 5*time.Second)
+	c.now = clock.now
+
+	// Act
+	for i := 0; i < 9; i++ {
+		c.Success()
+	}
+	c.Failure()
+	clock.t = clock.t.Add(2 * time.Minute)
+	c.Failure()
+	c.Failure()
+
+	// Assert
+	stats := c.Stats(time.Minute)
+	require.Equal(t, Stats{Window: time.Minute, Successes: 0, Failures: 2}, stats)
+	require.Equal(t, 0.0, stats.SuccessRate())
+	stats = c.Stats(5 * time.Minute)
+	require.Equal(t, uint64(9), stats.Successes)
+	require.Equal(t, uint64(3), stats.Failures)
+	require.Equal(t, 0.75, stats.SuccessRate())
+
+	// Act: the first outcomes slide out of the 15m window
+	clock.t = clock.t.Add(14 * time.Minute)
+
+	// Assert
+	windows := c.Windows(DefaultWindows...)
+	require.Len(t, windows, 3)
+	require.Equal(t, uint64(0), windows[0].Total())
+	require.Equal(t, 1.0, windows[0].SuccessRate())
+	require.Equal(t, Stats{Window: 15 * time.Minute, Failures: 2}, windows[2])
+
+	// Act: buckets are reused after a full ring turn
+	clock.t = clock.t.Add(time.Hour)
+	c.Success()
+
+	// Assert
+	require.Equal(t, Stats{Window: time.Hour, Successes: 1}, c.Stats(time.Hour))
+	require.Equal(t, "15m", Label(windows[2].Window))
+	require.Equal(t, "30s", Label(30*time.Second))
+}
+
+func TestCounterConcurrency(t *testing.T) {
+	// Arrange
+	c := NewDefault()
+	var wg sync.WaitGroup
+
+	// Act
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				c.Add(i%2 == 0)
+				c.Stats(time.Minute)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	// Assert
+	stats := c.Stats(time.Minute)
+	require.Equal(t, uint64(500), stats.Successes)
+	require.Equal(t, uint64(500), stats.Failures)
+
+	var disabled *Counter
+	disabled.Success()
+	require.Equal(t, uint64(0), disabled.Stats(time.Minute).Total())
+}
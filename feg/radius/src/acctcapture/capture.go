@@ -0,0 +1,280 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package acctcapture captures the raw Accounting-Request packets received by the server, after their shared secret
+// validation, to rotating gzip compressed files, e.g. to settle billing disputes with the exact packets the NASes sent.
+//
+// A capture file starts with the Magic & its 8 byte creation time (unix nanoseconds), followed by the records. A
+// record is its 8 byte receive time (unix nanoseconds), the 1 byte length & the NAS's address, the 2 byte length &
+// the packet's wire format, followed by the 32 byte SHA-256 of the previous record's hash (or of the file's header,
+// for the first record) & the record, so altering a record breaks the hash chain of the file. Integers are big endian
+package acctcapture
+
+import (
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"fbc/cwf/radius/monitoring/counters"
+	"fbc/lib/go/radius"
+
+	"go.uber.org/zap"
+)
+
+// Magic starts every capture file
+const Magic = "RADACCT1"
+
+const (
+	filePrefix = "acct-"
+	fileExt    = ".cap.gz"
+)
+
+// Config configuration of the capture
+type Config struct {
+	Dir           string `json:"dir" required:"true"` // directory of the capture files, created if needed
+	MaxFileSizeMB int    `json:"maxFileSizeMB" default:"64"`
+	MaxFiles      int    `json:"maxFiles" default:"100"` // the oldest files are removed beyond it
+	// FlushIntervalSec interval of flushing the captured packets to the current file
+	FlushIntervalSec int `json:"flushIntervalSec" default:"1"`
+	// QueueSize packets queued for writing, packets are dropped while the queue is full
+	QueueSize int `json:"queueSize" default:"10000"`
+}
+
+// Capture writes the tapped Accounting-Requests to the capture files in the background
+type Capture struct {
+	cfg     Config
+	logger  *zap.Logger
+	records chan record
+	done    chan struct{}
+	closed  chan struct{}
+	once    sync.Once
+
+	// accessed by the writing goroutine only
+	files   []string // the capture files' names, oldest first
+	current *captureFile
+}
+
+type record struct {
+	time time.Time
+	addr string
+	wire []byte
+}
+
+// captureFile the capture file being written
+type captureFile struct {
+	file  *os.File
+	size  *countingWriter
+	gz    *gzip.Writer
+	chain [sha256.Size]byte
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// New returns a started capture of the directory, creating it if needed, with the files captured by previous runs
+func New(cfg Config, logger *zap.Logger) (*Capture, error) {
+	if err := os.MkdirAll(cfg.Dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create accounting capture directory %s: %s", cfg.Dir, err)
+	}
+	entries, err := ioutil.ReadDir(cfg.Dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read accounting capture directory %s: %s", cfg.Dir, err)
+	}
+	c := &Capture{
+		cfg:     cfg,
+		logger:  logger,
+		records: make(chan record, cfg.QueueSize),
+		done:    make(chan struct{}),
+		closed:  make(chan struct{}),
+	}
+	// ReadDir sorts the entries by name, i.e. oldest first
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasPrefix(entry.Name(), filePrefix) && strings.HasSuffix(entry.Name(), fileExt) {
+			c.files = append(c.files, entry.Name())
+		}
+	}
+	counters.AcctCaptureFiles.Record(int64(len(c.files)))
+	go c.run()
+	return c, nil
+}
+
+// Tap queues the Accounting-Request of the wire format for capturing, other packets are ignored. It's a RequestTap
+// of the radius PacketServer & StreamServer
+func (c *Capture) Tap(wire []byte, remoteAddr net.Addr) {
+	if len(wire) == 0 || radius.Code(wire[0]) != radius.CodeAccountingRequest {
+		return
+	}
+	r := record{time: time.Now(), wire: append([]byte(nil), wire...)}
+	if remoteAddr != nil {
+		r.addr = remoteAddr.String()
+	}
+	select {
+	case <-c.done:
+	case c.records <- r:
+	default:
+		counters.AcctCapturePacket.Start().Failure("queue_full")
+	}
+}
+
+// Close writes the queued packets & closes the current file
+func (c *Capture) Close() {
+	c.once.Do(func() { close(c.done) })
+	<-c.closed
+}
+
+func (c *Capture) run() {
+	defer close(c.closed)
+	ticker := time.NewTicker(time.Duration(c.cfg.FlushIntervalSec) * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case r := <-c.records:
+			c.write(r)
+		case <-ticker.C:
+			if c.current != nil {
+				if err := c.current.gz.Flush(); err != nil {
+					c.fail("flush", err)
+				}
+			}
+		case <-c.done:
+			for {
+				select {
+				case r := <-c.records:
+					c.write(r)
+				default:
+					c.closeFile()
+					return
+				}
+			}
+		}
+	}
+}
+
+// write writes the record to the current file, rotating it first if it's full
+func (c *Capture) write(r record) {
+	op := counters.AcctCapturePacket.Start()
+	if c.current != nil && c.current.size.n >= int64(c.cfg.MaxFileSizeMB)<<20 {
+		c.closeFile()
+	}
+	if c.current == nil {
+		if err := c.openFile(r.time); err != nil {
+			c.logger.Error("failed to create accounting capture file", zap.Error(err))
+			op.Failure("create_error")
+			return
+		}
+	}
+	if err := c.current.writeRecord(r); err != nil {
+		c.fail("write", err)
+		op.Failure("write_error")
+		return
+	}
+	op.Success()
+}
+
+func (c *Capture) openFile(now time.Time) error {
+	name := fmt.Sprintf("%s%020d-%d%s", filePrefix, now.UnixNano(), os.Getpid(), fileExt)
+	file, err := os.OpenFile(filepath.Join(c.cfg.Dir, name), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	size := &countingWriter{w: file}
+	current := &captureFile{file: file, size: size, gz: gzip.NewWriter(size)}
+	header := make([]byte, len(Magic)+8)
+	copy(header, Magic)
+	binary.BigEndian.PutUint64(header[len(Magic):], uint64(now.UnixNano()))
+	current.chain = sha256.Sum256(header)
+	if _, err = current.gz.Write(header); err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return err
+	}
+	c.current = current
+	c.files = append(c.files, name)
+	for len(c.files) > c.cfg.MaxFiles {
+		if err := os.Remove(filepath.Join(c.cfg.Dir, c.files[0])); err != nil && !os.IsNotExist(err) {
+			c.logger.Error("failed to remove accounting capture file", zap.String("file", c.files[0]), zap.Error(err))
+		}
+		c.files = c.files[1:]
+	}
+	counters.AcctCaptureFiles.Record(int64(len(c.files)))
+	return nil
+}
+
+// closeFile closes the current file, if any, the next record is written to a new file
+func (c *Capture) closeFile() {
+	if c.current == nil {
+		return
+	}
+	err := c.current.gz.Close()
+	if closeErr := c.current.file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		c.logger.Error("failed to close accounting capture file", zap.String("file", c.current.file.Name()),
+			zap.Error(err))
+	}
+	c.current = nil
+}
+
+// fail abandons the current file after its failed operation, so a new file is used for the next record
+func (c *Capture) fail(operation string, err error) {
+	c.logger.Error("accounting capture file "+operation+" failed", zap.String("file", c.current.file.Name()),
+		zap.Error(err))
+	c.current.file.Close()
+	c.current = nil
+}
+
+func (f *captureFile) writeRecord(r record) error {
+	addr := r.addr
+	if len(addr) > 255 {
+		addr = addr[:255]
+	}
+	buf := make([]byte, 0, 8+1+len(addr)+2+len(r.wire)+sha256.Size)
+	buf = appendUint64(buf, uint64(r.time.UnixNano()))
+	buf = append(buf, byte(len(addr)))
+	buf = append(buf, addr...)
+	buf = append(buf, byte(len(r.wire)>>8), byte(len(r.wire)))
+	buf = append(buf, r.wire...)
+	f.chain = chainHash(f.chain, buf)
+	buf = append(buf, f.chain[:]...)
+	_, err := f.gz.Write(buf)
+	return err
+}
+
+func appendUint64(b []byte, v uint64) []byte {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], v)
+	return append(b, buf[:]...)
+}
+
+// chainHash returns the hash of the record chained to the previous hash
+func chainHash(prev [sha256.Size]byte, rec []byte) [sha256.Size]byte {
+	h := sha256.New()
+	h.Write(prev[:])
+	h.Write(rec)
+	var res [sha256.Size]byte
+	copy(res[:], h.Sum(nil))
+	return res
+}
@@ -0,0 +1,166 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package acctcapture
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"fbc/lib/go/radius"
+	"fbc/lib/go/radius/rfc2866"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+var (
+	secret = []byte("123456")
+	nas    = &net.UDPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 1813}
+)
+
+func TestCaptureRoundTrip(t *testing.T) {
+	// Arrange
+	dir, err := ioutil.TempDir("", "acctcapture")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	capture, err := New(Config{Dir: dir, MaxFileSizeMB: 1, MaxFiles: 10, FlushIntervalSec: 1, QueueSize: 10},
+		zap.NewNop())
+	require.NoError(t, err)
+	acct := encode(t, radius.CodeAccountingRequest, "session1")
+
+	// Act
+	capture.Tap(acct, nas)
+	capture.Tap(encode(t, radius.CodeAccessRequest, ""), nas)
+	capture.Tap(encode(t, radius.CodeAccountingRequest, "session2"), nas)
+	capture.Close()
+
+	// Assert
+	files := captureFiles(t, dir)
+	require.Len(t, files, 1)
+	records, err := readFile(files[0])
+	require.NoError(t, err)
+	require.Len(t, records, 2, "only the Accounting-Requests are captured")
+	require.Equal(t, "10.0.0.1:1813", records[0].RemoteAddr)
+	require.Equal(t, acct, records[0].Packet)
+	require.True(t, radius.IsAuthenticRequest(records[1].Packet, secret))
+	packet, err := radius.Parse(records[1].Packet, secret)
+	require.NoError(t, err)
+	require.Equal(t, "session2", rfc2866.AcctSessionID_GetString(packet))
+}
+
+func TestCaptureRotation(t *testing.T) {
+	// Arrange
+	dir, err := ioutil.TempDir("", "acctcapture")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	stale := filepath.Join(dir, filePrefix+"00000000000000000001-1"+fileExt)
+	require.NoError(t, ioutil.WriteFile(stale, nil, 0600))
+	capture, err := New(Config{Dir: dir, MaxFiles: 2, FlushIntervalSec: 1, QueueSize: 10}, zap.NewNop())
+	require.NoError(t, err)
+
+	// Act, a zero maximum size rotates the file on every record
+	for i := 0; i < 3; i++ {
+		capture.Tap(encode(t, radius.CodeAccountingRequest, "session1"), nas)
+	}
+	capture.Close()
+
+	// Assert
+	files := captureFiles(t, dir)
+	require.Len(t, files, 2, "the oldest files, including the previous run's, are removed")
+	require.NotContains(t, files, stale)
+	for _, file := range files {
+		records, err := readFile(file)
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+	}
+}
+
+func TestReaderDetectsAlteredRecords(t *testing.T) {
+	// Arrange
+	dir, err := ioutil.TempDir("", "acctcapture")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	capture, err := New(Config{Dir: dir, MaxFileSizeMB: 1, MaxFiles: 10, FlushIntervalSec: 1, QueueSize: 10},
+		zap.NewNop())
+	require.NoError(t, err)
+	acct := encode(t, radius.CodeAccountingRequest, "session1")
+	capture.Tap(acct, nas)
+	capture.Tap(acct, nas)
+	capture.Close()
+	files := captureFiles(t, dir)
+	require.Len(t, files, 1)
+
+	// Act, the second record's packet is altered
+	f, err := os.Open(files[0])
+	require.NoError(t, err)
+	gz, err := gzip.NewReader(f)
+	require.NoError(t, err)
+	content, err := ioutil.ReadAll(gz)
+	f.Close()
+	require.NoError(t, err)
+	last := bytes.LastIndex(content, []byte("session1"))
+	content[last] = 'S'
+	var altered bytes.Buffer
+	w := gzip.NewWriter(&altered)
+	w.Write(content)
+	w.Close()
+
+	// Assert
+	r, err := NewReader(&altered)
+	require.NoError(t, err)
+	_, err = r.Next()
+	require.NoError(t, err)
+	_, err = r.Next()
+	require.Equal(t, ErrBrokenChain, err)
+}
+
+func encode(t *testing.T, code radius.Code, sessionID string) []byte {
+	packet := radius.New(code, secret)
+	if len(sessionID) > 0 {
+		rfc2866.AcctSessionID_SetString(packet, sessionID)
+	}
+	wire, err := packet.Encode()
+	require.NoError(t, err)
+	return wire
+}
+
+func captureFiles(t *testing.T, dir string) []string {
+	files, err := filepath.Glob(filepath.Join(dir, filePrefix+"*"+fileExt))
+	require.NoError(t, err)
+	return files
+}
+
+func readFile(name string) ([]*Record, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	r, err := NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	var records []*Record
+	for {
+		record, err := r.Next()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return records, err
+		}
+		records = append(records, record)
+	}
+}
@@ -0,0 +1,96 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// acctdecode decodes & verifies accounting capture files offline, printing their Accounting-Requests. Usage:
+//
+//	acctdecode [-secret <shared secret>] [-verify] <capture file>...
+//
+// The records' hash chains are always verified, the packets' authenticators are verified if the secret is given
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"fbc/cwf/radius/acctcapture"
+	"fbc/lib/go/radius"
+	"fbc/lib/go/radius/debug"
+)
+
+func main() {
+	secret := flag.String("secret", "", "The NASes' shared secret, the packets' authenticators are verified if set")
+	verify := flag.Bool("verify", false, "Only verify the files, printing the non-authentic packets' records only")
+	flag.Parse()
+	if flag.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "usage: acctdecode [-secret <shared secret>] [-verify] <capture file>...")
+		os.Exit(2)
+	}
+	ok := true
+	for _, name := range flag.Args() {
+		ok = decode(name, []byte(*secret), *verify) && ok
+	}
+	if !ok {
+		os.Exit(1)
+	}
+}
+
+// decode prints the file's records, it returns false if the file is corrupted, altered or has non-authentic packets
+func decode(name string, secret []byte, verifyOnly bool) bool {
+	f, err := os.Open(name)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+		return false
+	}
+	defer f.Close()
+	r, err := acctcapture.NewReader(f)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+		return false
+	}
+	fmt.Printf("# %s, created %s\n", name, r.Created.Format(time.RFC3339Nano))
+	config := &debug.Config{Dictionary: debug.IncludedDictionary}
+	ok := true
+	records := 0
+	for {
+		record, err := r.Next()
+		switch {
+		case err == io.EOF:
+			fmt.Printf("# %s: %d records\n", name, records)
+			return ok
+		case err == io.ErrUnexpectedEOF:
+			// the server didn't close the file, e.g. it crashed, the flushed records are intact
+			fmt.Printf("# %s: %d records, truncated\n", name, records)
+			return ok
+		case err != nil:
+			fmt.Fprintf(os.Stderr, "%s: record %d: %v\n", name, records+1, err)
+			return false
+		}
+		records++
+		status := ""
+		if len(secret) > 0 && !radius.IsAuthenticRequest(record.Packet, secret) {
+			status = " NOT AUTHENTIC"
+			ok = false
+		}
+		if verifyOnly && len(status) == 0 {
+			continue
+		}
+		fmt.Printf("%d %s %s%s\n", records, record.Time.Format(time.RFC3339Nano), record.RemoteAddr, status)
+		if verifyOnly {
+			continue
+		}
+		packet, err := radius.Parse(record.Packet, secret)
+		if err != nil {
+			fmt.Printf("  malformed packet: %v\n", err)
+			continue
+		}
+		fmt.Println(debug.DumpString(config, packet))
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package acctcapture
+
+import (
+	"bufio"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"io"
+	"time"
+)
+
+// Reader decodes the records of a capture file
+type Reader struct {
+	r       *bufio.Reader
+	chain   [sha256.Size]byte
+	Created time.Time // the file's creation time
+}
+
+// Record a captured Accounting-Request
+type Record struct {
+	Time       time.Time
+	RemoteAddr string // the NAS's address
+	Packet     []byte // the packet's wire format
+}
+
+// ErrBrokenChain is returned for a record not matching its hash, i.e. the capture file was altered
+var ErrBrokenChain = errors.New("acctcapture: record hash mismatch, the capture was altered")
+
+// NewReader returns the reader of the gzip compressed capture file
+func NewReader(r io.Reader) (*Reader, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	res := &Reader{r: bufio.NewReader(gz)}
+	header := make([]byte, len(Magic)+8)
+	if _, err = io.ReadFull(res.r, header); err != nil {
+		return nil, err
+	}
+	if string(header[:len(Magic)]) != Magic {
+		return nil, errors.New("acctcapture: not a capture file")
+	}
+	res.Created = time.Unix(0, int64(binary.BigEndian.Uint64(header[len(Magic):])))
+	res.chain = sha256.Sum256(header)
+	return res, nil
+}
+
+// Next returns the next record, io.EOF at the end of the file. io.ErrUnexpectedEOF is returned for a file which
+// wasn't closed, e.g. of a crashed server, after its flushed records. ErrBrokenChain is returned for altered records
+func (r *Reader) Next() (*Record, error) {
+	var fixed [9]byte
+	if _, err := io.ReadFull(r.r, fixed[:]); err != nil {
+		return nil, err
+	}
+	addr := make([]byte, fixed[8])
+	if err := readFull(r.r, addr); err != nil {
+		return nil, err
+	}
+	var length [2]byte
+	if err := readFull(r.r, length[:]); err != nil {
+		return nil, err
+	}
+	packet := make([]byte, binary.BigEndian.Uint16(length[:]))
+	if err := readFull(r.r, packet); err != nil {
+		return nil, err
+	}
+	var hash [sha256.Size]byte
+	if err := readFull(r.r, hash[:]); err != nil {
+		return nil, err
+	}
+
+	rec := make([]byte, 0, len(fixed)+len(addr)+len(length)+len(packet))
+	rec = append(append(append(append(rec, fixed[:]...), addr...), length[:]...), packet...)
+	if chainHash(r.chain, rec) != hash {
+		return nil, ErrBrokenChain
+	}
+	r.chain = hash
+	return &Record{
+		Time:       time.Unix(0, int64(binary.BigEndian.Uint64(fixed[:8]))),
+		RemoteAddr: string(addr),
+		Packet:     packet,
+	}, nil
+}
+
+// readFull reads a record's remainder, a record truncated at its start is io.ErrUnexpectedEOF
+func readFull(r io.Reader, b []byte) error {
+	_, err := io.ReadFull(r, b)
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
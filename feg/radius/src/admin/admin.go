@@ -0,0 +1,175 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package admin implements the radius server's admin GRPC service, providing runtime introspection: live
+// counters, the loaded pipeline, active EAP conversations, the recently logged errors, the authentication
+// success rates, the live packets and the server's build & configuration info
+package admin
+
+import (
+	"context"
+	"fbc/cwf/radius/admin/protos"
+	"fbc/cwf/radius/buildinfo"
+	"fbc/cwf/radius/modules/eap/authstate"
+	"fbc/cwf/radius/monitoring/counters"
+	"fbc/cwf/radius/server"
+	"fbc/lib/go/radius"
+	"fbc/lib/go/rolling"
+	"fmt"
+	"net"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// watchBuffer the number of packets buffered for a packet watcher, packets are dropped while its buffer is full
+const watchBuffer = 256
+
+// Service implements protos.AdminServer
+type Service struct {
+	server *server.Server
+	errors *ErrorRing
+	info   buildinfo.Info
+}
+
+// NewService creates an admin service of the given server & its build info, errors may be nil
+func NewService(srv *server.Server, errors *ErrorRing, info buildinfo.Info) *Service {
+	return &Service{server: srv, errors: errors, info: info}
+}
+
+// Start serves the admin service on the given TCP port in background
+func (s *Service) Start(port int, logger *zap.Logger) (*grpc.Server, error) {
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return nil, err
+	}
+	grpcServer := grpc.NewServer()
+	protos.RegisterAdminServer(grpcServer, s)
+	go func() {
+		if err := grpcServer.Serve(lis); err != nil {
+			logger.Error("admin service stopped serving", zap.Error(err))
+		}
+	}()
+	logger.Info("admin service is listening", zap.Int("port", port))
+	return grpcServer, nil
+}
+
+// GetCounters returns current values of all counters
+func (s *Service) GetCounters(ctx context.Context, _ *protos.Void) (*protos.Counters, error) {
+	res := &protos.Counters{}
+	for _, v := range counters.Snapshot() {
+		res.Counters = append(res.Counters, &protos.Counter{Name: v.Name, Tags: v.Tags, Value: v.Value})
+	}
+	return res, nil
+}
+
+// GetPipeline returns the loaded filters & listeners with their modules
+func (s *Service) GetPipeline(ctx context.Context, _ *protos.Void) (*protos.Pipeline, error) {
+	filters, listeners := s.server.Pipeline()
+	res := &protos.Pipeline{Filters: filters}
+	for _, l := range listeners {
+		res.Listeners = append(res.Listeners, &protos.Listener{
+			Name:              l.Name,
+			Type:              l.Type,
+			Modules:           l.Modules,
+			DuplicatesDropped: l.DuplicatesDropped,
+		})
+	}
+	return res, nil
+}
+
+// GetConversations returns the number of in progress EAP conversations
+func (s *Service) GetConversations(ctx context.Context, _ *protos.Void) (*protos.Conversations, error) {
+	return &protos.Conversations{ActiveEap: authstate.ActiveConversations()}, nil
+}
+
+// GetRecentErrors returns the last logged errors, the oldest first
+func (s *Service) GetRecentErrors(ctx context.Context, _ *protos.Void) (*protos.RecentErrors, error) {
+	res := &protos.RecentErrors{}
+	if s.errors == nil {
+		return res, nil
+	}
+	entries, total := s.errors.Entries()
+	res.Total = total
+	for _, e := range entries {
+		res.Errors = append(res.Errors, &protos.ErrorEntry{
+			TimeMs:  e.Time.UnixNano() / int64(time.Millisecond),
+			Logger:  e.Logger,
+			Message: e.Message,
+			Fields:  e.Fields,
+		})
+	}
+	return res, nil
+}
+
+// GetAuthRates returns the Access-Request success rates of the last 1, 5 & 15 minutes
+func (s *Service) GetAuthRates(ctx context.Context, _ *protos.Void) (*protos.AuthRates, error) {
+	res := &protos.AuthRates{}
+	for _, w := range counters.AuthOutcomes.Windows(rolling.DefaultWindows...) {
+		res.Windows = append(res.Windows, &protos.WindowStats{
+			WindowSec:   uint32(w.Window / time.Second),
+			Successes:   w.Successes,
+			Failures:    w.Failures,
+			SuccessRate: w.SuccessRate(),
+		})
+	}
+	return res, nil
+}
+
+// GetServiceInfo returns the server's version, build, configuration hash, enabled features & loaded pipeline
+func (s *Service) GetServiceInfo(ctx context.Context, _ *protos.Void) (*protos.ServiceInfo, error) {
+	pipeline, err := s.GetPipeline(ctx, &protos.Void{})
+	if err != nil {
+		return nil, err
+	}
+	return &protos.ServiceInfo{
+		Version:    s.info.Version,
+		Commit:     s.info.Commit,
+		BuildTime:  s.info.BuildTime,
+		GoVersion:  s.info.GoVersion,
+		Host:       s.info.Host,
+		StartedMs:  s.info.Started.UnixNano() / int64(time.Millisecond),
+		ConfigHash: s.info.ConfigHash,
+		Features:   s.info.Features,
+		Flags:      s.info.Flags,
+		Pipeline:   pipeline,
+	}, nil
+}
+
+// WatchPackets streams the decoded & redacted packets matching the filter until the watcher disconnects
+func (s *Service) WatchPackets(filter *protos.PacketFilter, stream protos.Admin_WatchPacketsServer) error {
+	f := server.PacketFilter{NAS: filter.GetNas(), IMSI: filter.GetImsi()}
+	for _, c := range filter.GetCodes() {
+		f.Codes = append(f.Codes, radius.Code(c))
+	}
+	packets, stop := s.server.WatchPackets(f, watchBuffer)
+	defer stop()
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case p := <-packets:
+			err := stream.Send(&protos.Packet{
+				TimeMs:     p.Time.UnixNano() / int64(time.Millisecond),
+				Listener:   p.Listener,
+				Outgoing:   p.Outgoing,
+				Nas:        p.NAS,
+				RemoteAddr: p.RemoteAddr,
+				Code:       uint32(p.Code),
+				Identifier: uint32(p.Identifier),
+				SessionId:  p.SessionID,
+				Dump:       p.Dump,
+				Dropped:    p.Dropped,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
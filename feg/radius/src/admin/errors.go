@@ -0,0 +1,128 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package admin
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// DefaultErrorRingSize default number of the last errors kept by an ErrorRing
+const DefaultErrorRingSize = 100
+
+// ErrorEntry an error level log entry
+type ErrorEntry struct {
+	Time    time.Time
+	Logger  string
+	Message string
+	Fields  map[string]string
+}
+
+// ErrorRing a zap core keeping the last error (and above) level log entries in a fixed size ring buffer
+type ErrorRing struct {
+	*ring
+	fields []zapcore.Field
+}
+
+type ring struct {
+	sync.Mutex
+	entries []ErrorEntry
+	next    int
+	total   uint64
+}
+
+// NewErrorRing creates an ErrorRing keeping the last size errors
+func NewErrorRing(size int) *ErrorRing {
+	if size <= 0 {
+		size = DefaultErrorRingSize
+	}
+	return &ErrorRing{ring: &ring{entries: make([]ErrorEntry, 0, size)}}
+}
+
+// WrapLogger returns a logger writing to both the given logger & the error ring
+func (r *ErrorRing) WrapLogger(logger *zap.Logger) *zap.Logger {
+	return logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return zapcore.NewTee(core, r)
+	}))
+}
+
+// Entries returns the kept errors, the oldest first, and the total number of errors logged
+func (r *ErrorRing) Entries() ([]ErrorEntry, uint64) {
+	r.Lock()
+	defer r.Unlock()
+	res := make([]ErrorEntry, 0, len(r.entries))
+	if len(r.entries) == cap(r.entries) {
+		res = append(res, r.entries[r.next:]...)
+		res = append(res, r.entries[:r.next]...)
+	} else {
+		res = append(res, r.entries...)
+	}
+	return res, r.total
+}
+
+// Enabled implements zapcore.LevelEnabler
+func (r *ErrorRing) Enabled(level zapcore.Level) bool {
+	return level >= zapcore.ErrorLevel
+}
+
+// With implements zapcore.Core
+func (r *ErrorRing) With(fields []zapcore.Field) zapcore.Core {
+	all := make([]zapcore.Field, 0, len(r.fields)+len(fields))
+	all = append(all, r.fields...)
+	all = append(all, fields...)
+	return &ErrorRing{ring: r.ring, fields: all}
+}
+
+// Check implements zapcore.Core
+func (r *ErrorRing) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if r.Enabled(entry.Level) {
+		return checked.AddCore(entry, r)
+	}
+	return checked
+}
+
+// Write implements zapcore.Core
+func (r *ErrorRing) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range r.fields {
+		f.AddTo(enc)
+	}
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+	e := ErrorEntry{
+		Time:    entry.Time,
+		Logger:  entry.LoggerName,
+		Message: entry.Message,
+		Fields:  make(map[string]string, len(enc.Fields)),
+	}
+	for k, v := range enc.Fields {
+		e.Fields[k] = fmt.Sprint(v)
+	}
+
+	r.Lock()
+	defer r.Unlock()
+	if len(r.entries) < cap(r.entries) {
+		r.entries = append(r.entries, e)
+	} else {
+		r.entries[r.next] = e
+		r.next = (r.next + 1) % cap(r.entries)
+	}
+	r.total++
+	return nil
+}
+
+// Sync implements zapcore.Core
+func (r *ErrorRing) Sync() error {
+	return nil
+}
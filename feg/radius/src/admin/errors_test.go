@@ -0,0 +1,37 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package admin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestErrorRing(t *testing.T) {
+	// Arrange
+	ring := NewErrorRing(2)
+	logger := ring.WrapLogger(zap.NewNop()).Named("test").With(zap.String("listener", "auth"))
+
+	// Act
+	logger.Info("not an error")
+	logger.Error("first", zap.Int("code", 1))
+	logger.Error("second", zap.Int("code", 2))
+	logger.Error("third", zap.Int("code", 3))
+	entries, total := ring.Entries()
+
+	// Assert
+	require.Equal(t, uint64(3), total)
+	require.Len(t, entries, 2)
+	require.Equal(t, "second", entries[0].Message)
+	require.Equal(t, "third", entries[1].Message)
+	require.Equal(t, "test", entries[1].Logger)
+	require.Equal(t, map[string]string{"listener": "auth", "code": "3"}, entries[1].Fields)
+}
@@ -0,0 +1,77 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package buildinfo describes what the radius server actually runs: its build, identified at link time (see run.sh),
+// & its configuration. The info is logged by the startup banner & reported by the admin service
+package buildinfo
+
+import (
+	"flag"
+	"fmt"
+	"runtime"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+var (
+	// Version the build's version, set at link time by -ldflags "-X fbc/cwf/radius/buildinfo.Version=<version>"
+	Version = "dev"
+	// Commit the build's source revision, set at link time
+	Commit string
+	// BuildTime the build's time, set at link time
+	BuildTime string
+)
+
+// started the server's start time, the package is initialized when the server process starts
+var started = time.Now()
+
+// Info the server's build, host & configuration
+type Info struct {
+	Version    string
+	Commit     string
+	BuildTime  string
+	GoVersion  string
+	Host       string
+	Started    time.Time
+	ConfigHash string   // hex SHA-256 of the configuration file
+	Features   []string // enabled optional features of the configuration
+	Flags      []string // command line flags set explicitly, name=value
+}
+
+// New returns the info of the server's build running on the host with the configuration of the given hash & features,
+// the explicitly set flags are read from the parsed flag set
+func New(host string, configHash string, features []string, flags *flag.FlagSet) Info {
+	info := Info{
+		Version:    Version,
+		Commit:     Commit,
+		BuildTime:  BuildTime,
+		GoVersion:  runtime.Version(),
+		Host:       host,
+		Started:    started,
+		ConfigHash: configHash,
+		Features:   features,
+	}
+	flags.Visit(func(f *flag.Flag) {
+		info.Flags = append(info.Flags, fmt.Sprintf("%s=%s", f.Name, f.Value))
+	})
+	return info
+}
+
+// Fields returns the info's log fields
+func (i Info) Fields() []zap.Field {
+	return []zap.Field{
+		zap.String("version", i.Version),
+		zap.String("commit", i.Commit),
+		zap.String("build_time", i.BuildTime),
+		zap.String("go_version", i.GoVersion),
+		zap.String("config_hash", i.ConfigHash),
+		zap.Strings("features", i.Features),
+		zap.Strings("flags", i.Flags),
+	}
+}
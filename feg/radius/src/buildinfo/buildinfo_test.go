@@ -0,0 +1,38 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package buildinfo
+
+import (
+	"flag"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	// Arrange
+	flags := flag.NewFlagSet("radius", flag.ContinueOnError)
+	flags.String("config", "radius.config.json", "")
+	flags.Bool("print-pipeline", false, "")
+	require.NoError(t, flags.Parse([]string{"-config", "/etc/radius.yaml"}))
+
+	// Act
+	info := New("gw1", "abc", []string{"admin"}, flags)
+
+	// Assert
+	require.Equal(t, Version, info.Version)
+	require.Equal(t, runtime.Version(), info.GoVersion)
+	require.Equal(t, "gw1", info.Host)
+	require.False(t, info.Started.IsZero())
+	require.Equal(t, "abc", info.ConfigHash)
+	require.Equal(t, []string{"admin"}, info.Features)
+	require.Equal(t, []string{"config=/etc/radius.yaml"}, info.Flags, "only explicitly set flags are reported")
+	require.Len(t, info.Fields(), 7)
+}
@@ -0,0 +1,247 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package certmanager
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// defaultReloadInterval how often certificate files are checked for changes
+const defaultReloadInterval = 30 * time.Second
+
+// Config certificate manager configuration
+type Config struct {
+	CertFile              string `json:"certFile"`
+	KeyFile               string `json:"keyFile"`
+	CAFile                string `json:"caFile"` // Optional, used to verify peers (mutual TLS)
+	ReloadIntervalSeconds int    `json:"reloadIntervalSeconds"`
+}
+
+// CertManager watches certificate, key & CA files and hot swaps them when
+// they change. TLS configurations obtained from the manager resolve the
+// certificate and CA pool on every handshake, so rotation affects new
+// connections only and established sessions are never dropped.
+type CertManager struct {
+	config  Config
+	logger  *zap.Logger
+	mu      sync.RWMutex
+	cert    *tls.Certificate
+	caPool  *x509.CertPool
+	modTime time.Time
+	stop    chan struct{}
+	stopped sync.Once
+}
+
+// New creates a certificate manager, loading & validating the configured
+// files. Watching starts with Start
+func New(config Config, logger *zap.Logger) (*CertManager, error) {
+	if config.CertFile == "" || config.KeyFile == "" {
+		return nil, errors.New("certificate manager requires both certFile and keyFile")
+	}
+	m := &CertManager{
+		config: config,
+		logger: logger,
+		stop:   make(chan struct{}),
+	}
+	if err := m.reload(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Start watches the certificate files for changes until Stop is called
+func (m *CertManager) Start() {
+	interval := defaultReloadInterval
+	if m.config.ReloadIntervalSeconds > 0 {
+		interval = time.Duration(m.config.ReloadIntervalSeconds) * time.Second
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if !m.changed() {
+					continue
+				}
+				if err := m.reload(); err != nil {
+					CertificateRotation.Failure("invalid_certificate")
+					m.logger.Error("failed rotating certificate, keeping current one", zap.Error(err))
+					continue
+				}
+				CertificateRotation.Success()
+				m.logger.Info("certificate rotated", zap.String("cert_file", m.config.CertFile))
+			case <-m.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops watching the certificate files
+func (m *CertManager) Stop() {
+	m.stopped.Do(func() { close(m.stop) })
+}
+
+// Certificate returns the current certificate
+func (m *CertManager) Certificate() *tls.Certificate {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.cert
+}
+
+// CAPool returns the current CA pool, nil if no CA file is configured
+func (m *CertManager) CAPool() *x509.CertPool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.caPool
+}
+
+// ServerTLSConfig returns a TLS configuration for listeners. Client
+// certificates are required & verified when a CA file is configured
+func (m *CertManager) ServerTLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			cfg := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*m.Certificate()},
+			}
+			if pool := m.CAPool(); pool != nil {
+				cfg.ClientCAs = pool
+				cfg.ClientAuth = tls.RequireAndVerifyClientCert
+			}
+			return cfg, nil
+		},
+	}
+}
+
+// ClientTLSConfig returns a TLS configuration for clients connecting to
+// serverName. The server is verified against the configured CA file, or the
+// system roots if none is configured
+func (m *CertManager) ClientTLSConfig(serverName string) *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: serverName,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return m.Certificate(), nil
+		},
+		// Verification is done in VerifyPeerCertificate against the
+		// current CA pool, which may change after the config is created
+		InsecureSkipVerify: true,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			return m.verifyServer(serverName, rawCerts)
+		},
+	}
+}
+
+func (m *CertManager) verifyServer(serverName string, rawCerts [][]byte) error {
+	if len(rawCerts) == 0 {
+		return errors.New("server did not present a certificate")
+	}
+	certs := make([]*x509.Certificate, len(rawCerts))
+	for i, raw := range rawCerts {
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return err
+		}
+		certs[i] = cert
+	}
+	opts := x509.VerifyOptions{
+		Roots:         m.CAPool(),
+		DNSName:       serverName,
+		Intermediates: x509.NewCertPool(),
+	}
+	for _, cert := range certs[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+	_, err := certs[0].Verify(opts)
+	return err
+}
+
+// changed returns true if any of the watched files was modified since the
+// last successful load
+func (m *CertManager) changed() bool {
+	latest, err := m.latestModTime()
+	if err != nil {
+		m.logger.Warn("failed checking certificate files", zap.Error(err))
+		return false
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return !latest.Equal(m.modTime)
+}
+
+func (m *CertManager) latestModTime() (time.Time, error) {
+	var latest time.Time
+	for _, file := range []string{m.config.CertFile, m.config.KeyFile, m.config.CAFile} {
+		if file == "" {
+			continue
+		}
+		info, err := os.Stat(file)
+		if err != nil {
+			return latest, err
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
+
+// reload loads & validates the certificate files and swaps them in only if
+// they are all valid
+func (m *CertManager) reload() error {
+	modTime, err := m.latestModTime()
+	if err != nil {
+		return err
+	}
+	cert, err := tls.LoadX509KeyPair(m.config.CertFile, m.config.KeyFile)
+	if err != nil {
+		return err
+	}
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	if now.Before(leaf.NotBefore) || now.After(leaf.NotAfter) {
+		return fmt.Errorf(
+			"certificate %s is not valid now, validity period is %s - %s",
+			m.config.CertFile, leaf.NotBefore, leaf.NotAfter,
+		)
+	}
+	cert.Leaf = leaf
+
+	var caPool *x509.CertPool
+	if m.config.CAFile != "" {
+		caBytes, err := ioutil.ReadFile(m.config.CAFile)
+		if err != nil {
+			return err
+		}
+		caPool = x509.NewCertPool()
+		if !caPool.AppendCertsFromPEM(caBytes) {
+			return fmt.Errorf("no valid CA certificates found in %s", m.config.CAFile)
+		}
+	}
+
+	m.mu.Lock()
+	m.cert, m.caPool, m.modTime = &cert, caPool, modTime
+	m.mu.Unlock()
+	return nil
+}
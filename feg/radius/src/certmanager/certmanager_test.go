@@ -0,0 +1,120 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package certmanager
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestRotation(t *testing.T) {
+	// Arrange
+	logger, err := zap.NewDevelopment()
+	require.NoError(t, err, "failed to get logger")
+	dir, err := ioutil.TempDir("", "certmanager")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	config := Config{
+		CertFile:              filepath.Join(dir, "cert.pem"),
+		KeyFile:               filepath.Join(dir, "key.pem"),
+		ReloadIntervalSeconds: 1,
+	}
+	writeCert(t, config, "first", time.Now().Add(time.Hour))
+	m, err := New(config, logger)
+	require.NoError(t, err)
+	m.Start()
+	defer m.Stop()
+	require.Equal(t, "first", m.Certificate().Leaf.Subject.CommonName)
+
+	// Act
+	writeCert(t, config, "second", time.Now().Add(time.Hour))
+	touch(t, config, time.Now().Add(time.Second))
+
+	// Assert
+	deadline := time.Now().Add(5 * time.Second)
+	for m.Certificate().Leaf.Subject.CommonName != "second" && time.Now().Before(deadline) {
+		time.Sleep(100 * time.Millisecond)
+	}
+	require.Equal(t, "second", m.Certificate().Leaf.Subject.CommonName)
+}
+
+func TestInvalidRotationKeepsCurrent(t *testing.T) {
+	// Arrange
+	logger, err := zap.NewDevelopment()
+	require.NoError(t, err, "failed to get logger")
+	dir, err := ioutil.TempDir("", "certmanager")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	config := Config{
+		CertFile: filepath.Join(dir, "cert.pem"),
+		KeyFile:  filepath.Join(dir, "key.pem"),
+	}
+	writeCert(t, config, "first", time.Now().Add(time.Hour))
+	m, err := New(config, logger)
+	require.NoError(t, err)
+
+	// Act
+	writeCert(t, config, "expired", time.Now().Add(-time.Minute))
+	err = m.reload()
+
+	// Assert
+	require.Error(t, err)
+	require.Equal(t, "first", m.Certificate().Leaf.Subject.CommonName)
+}
+
+func TestMissingFiles(t *testing.T) {
+	// Arrange
+	logger, err := zap.NewDevelopment()
+	require.NoError(t, err, "failed to get logger")
+
+	// Act
+	_, err = New(Config{CertFile: "/nonexistent/cert.pem", KeyFile: "/nonexistent/key.pem"}, logger)
+
+	// Assert
+	require.Error(t, err)
+}
+
+func writeCert(t *testing.T, config Config, commonName string, notAfter time.Time) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(
+		config.CertFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, ioutil.WriteFile(
+		config.KeyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600))
+}
+
+// touch bumps the files modification time, so the change is detected even
+// on file systems with coarse timestamps
+func touch(t *testing.T, config Config, modTime time.Time) {
+	require.NoError(t, os.Chtimes(config.CertFile, modTime, modTime))
+	require.NoError(t, os.Chtimes(config.KeyFile, modTime, modTime))
+}
@@ -0,0 +1,18 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package certmanager
+
+import (
+	"fbc/cwf/radius/monitoring/counters"
+)
+
+var (
+	// CertificateRotation rotating a changed certificate
+	CertificateRotation = counters.NewOperation("certificate_rotation")
+)
@@ -9,12 +9,19 @@ LICENSE file in the root directory of this source tree.
 package config
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"fbc/cwf/radius/acctcapture"
 	"fbc/cwf/radius/modules"
+	"fbc/cwf/radius/monitoring/counters"
 	"fbc/cwf/radius/monitoring/counters/census"
-	"fbc/cwf/radius/monitoring/ods"
-	"fbc/cwf/radius/monitoring/scuba"
+	"fbc/cwf/radius/monitoring/debug"
+	"fbc/cwf/radius/quirks"
+	"fmt"
 	"io/ioutil"
+	"sort"
 )
 
 // LiveTier name
@@ -23,8 +30,16 @@ const LiveTier = "live"
 type (
 	// ModuleDescriptor a descriptor for loading a single module
 	ModuleDescriptor struct {
-		Name   string               `json:"name"`
-		Config modules.ModuleConfig `json:"config"`
+		Name    string               `json:"name"`
+		Enabled *bool                `json:"enabled,omitempty"` // Optional, modules are enabled by default
+		Config  modules.ModuleConfig `json:"config"`
+	}
+
+	// ExternalModuleConfig an out-of-tree module, built as a Go plugin against the module SDK (modules/sdk), which
+	// listeners enable by its name like the built-in modules
+	ExternalModuleConfig struct {
+		Name string `json:"name" required:"true"`
+		Path string `json:"path" required:"true"` // path of the plugin's shared object
 	}
 
 	// ListenerConfig for a single listener (server has a listerner per each port)
@@ -74,28 +89,61 @@ type (
 		LoadBalance LoadBalanceConfig `json:"loadBalance"`
 		Listeners   []ListenerConfig  `json:"listeners"`
 		Filters     []string          `json:"filters"`
+		Handoff     *HandoffConfig    `json:"handoff"` // Optional, zero downtime upgrades are disabled if not set
+		Quirks      []quirks.Config   `json:"quirks"`  // Optional, interop quirks of known NASes
+		// Optional, out-of-tree modules loaded from Go plugins
+		ExternalModules []ExternalModuleConfig `json:"externalModules"`
+		// Optional, the raw Accounting-Requests aren't captured if not set
+		AcctCapture *acctcapture.Config `json:"acctCapture"`
+	}
+
+	// HandoffConfig configuration of zero downtime upgrades: a new server process takes over the UDP listeners'
+	// sockets & the in-flight sessions of the server process serving the handoff socket
+	HandoffConfig struct {
+		SocketPath string `json:"socketPath" required:"true"`
+		TimeoutSec int    `json:"timeoutSec" default:"10"` // timeout of draining the old process' in-flight requests
 	}
 
 	// MonitoringConfig ...
 	MonitoringConfig struct {
 		Census *census.Config `json:"census"`
-		Ods    *ods.Config    `json:"ods"`
-		Scuba  *scuba.Config  `json:"scuba"`
+		Ods    *OdsConfig     `json:"ods"`
+		Scuba  *ScubaConfig   `json:"scuba"`
+		Debug  *debug.Config  `json:"debug"` // Optional, the pprof & runtime metrics endpoint is disabled if not set
+		// Snapshot optional, the counters are written on graceful shutdowns & restored by the next start if set
+		Snapshot *counters.SnapshotConfig `json:"snapshot"`
+	}
+
+	// AdminConfig configuration of the admin GRPC service
+	AdminConfig struct {
+		Port          int `json:"port" required:"true"`
+		ErrorRingSize int `json:"errorRingSize" default:"100"` // number of the last logged errors kept
 	}
 
 	// RadiusConfig the configuration file format
 	RadiusConfig struct {
 		Monitoring *MonitoringConfig `json:"monitoring"`
 		Server     ServerConfig      `json:"server"`
+		Admin      *AdminConfig      `json:"admin"` // Optional, the admin service is disabled if not set
+		Hash       string            `json:"-"`     // hex SHA-256 of the configuration file, set by Read
 	}
 )
 
-// Read reads and parses a configuration file into a RadiusConfig
+// Read reads and parses a configuration file (JSON, or YAML if the file has a
+// .yaml/.yml extension) into a RadiusConfig, applies defaults and validates it
 func Read(filename string) (*RadiusConfig, error) {
 	configBytes, err := ioutil.ReadFile(filename)
 	if err != nil {
 		return nil, err
 	}
+	hash := sha256.Sum256(configBytes)
+
+	if isYAML(filename) {
+		configBytes, err = yamlToJSON(configBytes)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	var config RadiusConfig
 	err = json.Unmarshal(configBytes, &config)
@@ -103,5 +151,104 @@ func Read(filename string) (*RadiusConfig, error) {
 		return nil, err
 	}
 
+	err = ApplyDefaults(&config)
+	if err != nil {
+		return nil, err
+	}
+
+	config.Hash = hex.EncodeToString(hash[:])
 	return &config, nil
 }
+
+// Features returns the enabled optional features of the configuration, sorted
+func (c *RadiusConfig) Features() []string {
+	features := []string{}
+	enable := func(feature string, enabled bool) {
+		if enabled {
+			features = append(features, feature)
+		}
+	}
+	if c.Monitoring != nil {
+		enable("census", c.Monitoring.Census != nil)
+		enable("ods", FacebookSinks && c.Monitoring.Ods != nil)
+		enable("scuba", FacebookSinks && c.Monitoring.Scuba != nil)
+		enable("debug", c.Monitoring.Debug != nil)
+		enable("counters_snapshot", c.Monitoring.Snapshot != nil)
+	}
+	enable("acct_capture", c.Server.AcctCapture != nil)
+	enable("admin", c.Admin != nil)
+	enable("handoff", c.Server.Handoff != nil)
+	enable("load_balance", len(c.Server.LoadBalance.ServiceTiers) > 0)
+	enable("quirks", len(c.Server.Quirks) > 0)
+	enable("external_modules", len(c.Server.ExternalModules) > 0)
+	for _, l := range c.Server.Listeners {
+		if l.Type == "tls" {
+			enable("radsec", true)
+			break
+		}
+	}
+	sort.Strings(features)
+	return features
+}
+
+// Validate validates the server configuration
+func (c *ServerConfig) Validate() error {
+	if c.Secret == "" {
+		return NewValidationError("secret", "a shared secret is required")
+	}
+	if c.DedupWindow.Duration < 0 {
+		return NewValidationError("dedupWindow", "must not be negative, got %s", c.DedupWindow)
+	}
+	if len(c.Listeners) == 0 {
+		return NewValidationError("listeners", "at least one listener is required")
+	}
+	names := map[string]bool{}
+	for _, listener := range c.Listeners {
+		if names[listener.Name] {
+			return NewValidationError("listeners", "duplicate listener name '%s'", listener.Name)
+		}
+		names[listener.Name] = true
+	}
+	external := map[string]bool{}
+	for i, module := range c.ExternalModules {
+		if external[module.Name] {
+			return NewValidationError(
+				fmt.Sprintf("externalModules[%d].name", i), "duplicate external module name '%s'", module.Name)
+		}
+		external[module.Name] = true
+	}
+	for i, nas := range c.Quirks {
+		if _, err := quirks.Parse(nas.Quirks); err != nil {
+			return NewValidationError(fmt.Sprintf("quirks[%d].quirks", i), "%v", err)
+		}
+	}
+	return nil
+}
+
+// Validate validates the listener configuration
+func (c *ListenerConfig) Validate() error {
+	if c.Name == "" {
+		return NewValidationError("name", "listener name is required")
+	}
+	if !contains(ListenerTypes, c.Type) {
+		return NewValidationError("type", "unknown listener type '%s', expected one of %v", c.Type, ListenerTypes)
+	}
+	for _, module := range c.Modules {
+		if module.Name == "" {
+			return errors.New("module name is required")
+		}
+	}
+	if len(c.EnabledModules()) == 0 {
+		return NewValidationError("modules", "listener has no enabled modules")
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
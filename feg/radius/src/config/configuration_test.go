@@ -9,7 +9,17 @@ LICENSE file in the root directory of this source tree.
 package config
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"strings"
 	"testing"
+	"time"
+
+	"fbc/cwf/radius/acctcapture"
+	"fbc/cwf/radius/monitoring/counters"
 
 	"github.com/stretchr/testify/require"
 )
@@ -31,3 +41,122 @@ func TestLoadLBConfig(t *testing.T) {
 	require.NotEmpty(t, conf.Server.LoadBalance.LiveTier)
 	require.NotEmpty(t, conf.Server.LoadBalance.Canaries)
 }
+
+func TestInvalidServerConfig(t *testing.T) {
+	_, err := readString(t, `{"monitoring": {}, "server": {"listeners": [{"name": "auth", "type": "udp", "modules": [{"name": "eap"}]}]}}`)
+	require.Error(t, err)
+	require.Equal(t, "server.secret", err.(*ValidationError).Field)
+
+	_, err = readString(t, `{"monitoring": {}, "server": {"secret": "123456", "listeners": [{"name": "auth"}]}}`)
+	require.Error(t, err)
+	require.Equal(t, "server.listeners[0].type", err.(*ValidationError).Field)
+
+	_, err = readString(t, `{"monitoring": {}, "server": {"secret": "123456",
+		"listeners": [{"name": "acct", "type": "udp", "modules": [{"name": "magmaacct"}]}],
+		"quirks": [{"nas": "10.0.0.1", "quirks": ["uppercase_dashed_mac", "sends_stop_twice"]}]}}`)
+	require.Error(t, err)
+	require.Equal(t, "server.quirks[0].quirks", err.(*ValidationError).Field)
+	require.Contains(t, err.(*ValidationError).Reason, "sends_stop_twice")
+}
+
+func TestYAMLPipeline(t *testing.T) {
+	conf, err := readFile(t, "radius.config.*.yaml", `
+monitoring: {}
+server:
+  secret: "123456"
+  dedupWindow: 500ms
+  listeners:
+    - name: auth
+      type: udp
+      modules:
+        - name: analytics
+          enabled: false
+        - name: eap
+          config:
+            methods: []
+`)
+	require.NoError(t, err)
+	require.Equal(t, time.Millisecond*500, conf.Server.DedupWindow.Duration)
+	listener := conf.Server.Listeners[0]
+	require.Len(t, listener.Modules, 2)
+	require.False(t, listener.Modules[0].IsEnabled())
+	require.True(t, listener.Modules[1].IsEnabled())
+	require.Len(t, listener.EnabledModules(), 1)
+	require.Equal(t, "eap", listener.EnabledModules()[0].Name)
+
+	var out bytes.Buffer
+	require.NoError(t, conf.Server.WritePipeline(&out))
+	require.Equal(
+		t,
+		"filters: <none>\nlistener 'auth' (udp):\n  -  analytics (disabled)\n  1. eap [methods]\n",
+		out.String(),
+	)
+}
+
+func TestNoEnabledModules(t *testing.T) {
+	_, err := readString(t, `{"monitoring": {}, "server": {"secret": "123456", "listeners": [
+		{"name": "auth", "type": "udp", "modules": [{"name": "eap", "enabled": false}]}
+	]}}`)
+	require.Error(t, err)
+	require.Equal(t, "server.listeners[0].modules", err.(*ValidationError).Field)
+}
+
+func TestHashAndFeatures(t *testing.T) {
+	content := `{
+		"monitoring": {"debug": {"address": "127.0.0.1:6060"}},
+		"admin": {"port": 9100},
+		"server": {"secret": "123456", "listeners": [
+			{"name": "auth", "type": "udp", "modules": [{"name": "eap"}]},
+			{"name": "radsec", "type": "tls", "modules": [{"name": "eap"}]}
+		]}
+	}`
+	conf, err := readString(t, content)
+	require.NoError(t, err)
+	require.Equal(t, []string{"admin", "debug", "radsec"}, conf.Features())
+	sum := sha256.Sum256([]byte(content))
+	require.Equal(t, hex.EncodeToString(sum[:]), conf.Hash, "the file is hashed as is")
+
+	other, err := readString(t, strings.Replace(content, "9100", "9101", 1))
+	require.NoError(t, err)
+	require.NotEqual(t, conf.Hash, other.Hash)
+}
+
+func readString(t *testing.T, content string) (*RadiusConfig, error) {
+	return readFile(t, "radius.config.*.json", content)
+}
+
+func readFile(t *testing.T, pattern string, content string) (*RadiusConfig, error) {
+	file, err := ioutil.TempFile("", pattern)
+	require.NoError(t, err)
+	defer os.Remove(file.Name())
+	_, err = file.WriteString(content)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+	return Read(file.Name())
+}
+
+func TestAcctCaptureDefaults(t *testing.T) {
+	conf, err := readString(t, `{"monitoring": {}, "server": {"secret": "123456", "acctCapture": {"dir": "/tmp/acct"},
+		"listeners": [{"name": "acct", "type": "udp", "modules": [{"name": "eap"}]}]}}`)
+	require.NoError(t, err)
+	require.Equal(t, acctcapture.Config{
+		Dir: "/tmp/acct", MaxFileSizeMB: 64, MaxFiles: 100, FlushIntervalSec: 1, QueueSize: 10000,
+	}, *conf.Server.AcctCapture)
+	require.Equal(t, []string{"acct_capture"}, conf.Features())
+
+	_, err = readString(t, `{"monitoring": {}, "server": {"secret": "123456", "acctCapture": {},
+		"listeners": [{"name": "acct", "type": "udp", "modules": [{"name": "eap"}]}]}}`)
+	require.Error(t, err)
+}
+
+func TestCountersSnapshotDefaults(t *testing.T) {
+	conf, err := readString(t, `{"monitoring": {"snapshot": {"path": "/tmp/counters.json"}}, "server": {
+		"secret": "123456", "listeners": [{"name": "auth", "type": "udp", "modules": [{"name": "eap"}]}]}}`)
+	require.NoError(t, err)
+	require.Equal(t, counters.SnapshotConfig{Path: "/tmp/counters.json", MaxAgeSec: 300}, *conf.Monitoring.Snapshot)
+	require.Equal(t, []string{"counters_snapshot"}, conf.Features())
+
+	_, err = readString(t, `{"monitoring": {"snapshot": {}}, "server": {
+		"secret": "123456", "listeners": [{"name": "auth", "type": "udp", "modules": [{"name": "eap"}]}]}}`)
+	require.Error(t, err)
+}
@@ -0,0 +1,27 @@
+//go:build !oss
+// +build !oss
+
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package config
+
+import (
+	"fbc/cwf/radius/monitoring/ods"
+	"fbc/cwf/radius/monitoring/scuba"
+)
+
+// FacebookSinks true if the binary reports to ODS & logs to Scuba, i.e. it isn't built with the oss tag
+const FacebookSinks = true
+
+type (
+	// OdsConfig the configuration of reporting the counters to ODS
+	OdsConfig = ods.Config
+	// ScubaConfig the configuration of the structured logs' tables, exported to Scuba & the other exporters
+	ScubaConfig = scuba.Config
+)
@@ -0,0 +1,42 @@
+//go:build !oss
+// +build !oss
+
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScubaDefaults(t *testing.T) {
+	conf, err := readString(t, `{
+		"monitoring": {"scuba": {"AccessToken": "token"}},
+		"server": {"secret": "123456", "listeners": [{"name": "auth", "type": "udp", "modules": [{"name": "eap"}]}]}
+	}`)
+	require.NoError(t, err)
+	require.Equal(t, 2000, conf.Monitoring.Scuba.MessageQueueSize)
+	require.Equal(t, 2, conf.Monitoring.Scuba.FlushIntervalSec)
+	require.Equal(t, 15, conf.Monitoring.Scuba.BatchSize)
+	require.Equal(t, "https://graph.facebook.com/scribe_logs", conf.Monitoring.Scuba.GraphURL)
+}
+
+func TestInvalidScubaConfig(t *testing.T) {
+	_, err := readString(t, `{
+		"monitoring": {"scuba": {"batch_size": -1}},
+		"server": {"secret": "123456", "listeners": [{"name": "auth", "type": "udp", "modules": [{"name": "eap"}]}]}
+	}`)
+	require.Error(t, err)
+	verr, ok := err.(*ValidationError)
+	require.True(t, ok)
+	require.Equal(t, "monitoring.scuba", verr.Field)
+	require.Contains(t, verr.Reason, "batch_size")
+}
@@ -0,0 +1,23 @@
+//go:build oss
+// +build oss
+
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package config
+
+// FacebookSinks false, the binary is built with the oss tag: it has no ODS & Scuba (Facebook Graph API) code, the
+// counters are only exported by the census Prometheus endpoint & the logs are written to stdout
+const FacebookSinks = false
+
+type (
+	// OdsConfig the ods section, accepted so the same configuration files can be used by both builds & ignored
+	OdsConfig struct{}
+	// ScubaConfig the scuba section, accepted so the same configuration files can be used by both builds & ignored
+	ScubaConfig struct{}
+)
@@ -0,0 +1,28 @@
+//go:build oss
+// +build oss
+
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFacebookSinksIgnored(t *testing.T) {
+	conf, err := readString(t, `{
+		"monitoring": {"ods": {"access_token": "token"}, "scuba": {"batch_size": -1}},
+		"server": {"secret": "123456", "listeners": [{"name": "auth", "type": "udp", "modules": [{"name": "eap"}]}]}
+	}`)
+	require.NoError(t, err, "the sections are accepted & ignored")
+	require.NotNil(t, conf.Monitoring.Scuba)
+	require.Empty(t, conf.Features())
+}
@@ -0,0 +1,136 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// ListenerTypes the supported listener types
+var ListenerTypes = []string{"udp", "grpc", "sse", "tls"}
+
+// IsEnabled returns true unless the module is explicitly disabled in the
+// configuration (modules are enabled by default)
+func (d ModuleDescriptor) IsEnabled() bool {
+	return d.Enabled == nil || *d.Enabled
+}
+
+// EnabledModules returns the listener's enabled modules, in pipeline order
+func (c ListenerConfig) EnabledModules() []ModuleDescriptor {
+	var result []ModuleDescriptor
+	for _, module := range c.Modules {
+		if module.IsEnabled() {
+			result = append(result, module)
+		}
+	}
+	return result
+}
+
+// WritePipeline writes a human readable description of the packet pipeline
+// (filters, then each listener's modules in the order they are invoked)
+func (c *ServerConfig) WritePipeline(w io.Writer) error {
+	filters := "<none>"
+	if len(c.Filters) > 0 {
+		filters = strings.Join(c.Filters, " -> ")
+	}
+	if _, err := fmt.Fprintf(w, "filters: %s\n", filters); err != nil {
+		return err
+	}
+	for _, listener := range c.Listeners {
+		if _, err := fmt.Fprintf(w, "listener '%s' (%s):\n", listener.Name, listener.Type); err != nil {
+			return err
+		}
+		position := 0
+		for _, module := range listener.Modules {
+			var line string
+			if module.IsEnabled() {
+				position++
+				line = fmt.Sprintf("  %d. %s", position, module.Name)
+			} else {
+				line = fmt.Sprintf("  -  %s (disabled)", module.Name)
+			}
+			if len(module.Config) > 0 {
+				line += " " + describeModuleConfig(module.Config)
+			}
+			if _, err := fmt.Fprintln(w, line); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// describeModuleConfig lists the module configuration keys, values are
+// omitted as they may contain secrets
+func describeModuleConfig(config map[string]interface{}) string {
+	keys := make([]string, 0, len(config))
+	for key := range config {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return "[" + strings.Join(keys, ", ") + "]"
+}
+
+// isYAML returns true if the configuration file is a YAML file
+func isYAML(filename string) bool {
+	return strings.HasSuffix(filename, ".yaml") || strings.HasSuffix(filename, ".yml")
+}
+
+// yamlToJSON converts a YAML document to JSON, so the same json tags and
+// unmarshalers apply regardless of the configuration file format
+func yamlToJSON(content []byte) ([]byte, error) {
+	var doc interface{}
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, err
+	}
+	converted, err := convertYAML(doc)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(converted)
+}
+
+// convertYAML converts YAML maps (which may have non string keys) to JSON
+// compatible maps
+func convertYAML(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			strKey, ok := key.(string)
+			if !ok {
+				return nil, fmt.Errorf("unsupported non string key %v", key)
+			}
+			converted, err := convertYAML(item)
+			if err != nil {
+				return nil, err
+			}
+			result[strKey] = converted
+		}
+		return result, nil
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			converted, err := convertYAML(item)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = converted
+		}
+		return result, nil
+	default:
+		return v, nil
+	}
+}
@@ -0,0 +1,189 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Validator is implemented by configuration structs which validate their
+// own values (after defaults are applied)
+type Validator interface {
+	Validate() error
+}
+
+// ValidationError an actionable configuration error, pointing to the
+// offending field
+type ValidationError struct {
+	Field  string
+	Reason string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid configuration value for '%s': %s", e.Field, e.Reason)
+}
+
+// NewValidationError creates a new ValidationError
+func NewValidationError(field string, reason string, args ...interface{}) *ValidationError {
+	return &ValidationError{Field: field, Reason: fmt.Sprintf(reason, args...)}
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// ApplyDefaults walks the given struct pointer (and nested structs) and sets
+// every zero valued field tagged with `default:"..."` to the tag value. Zero
+// valued fields tagged with `required:"true"` result in a ValidationError.
+// Structs implementing Validator are validated after their defaults are set
+func ApplyDefaults(v interface{}) error {
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Ptr || value.IsNil() {
+		return errors.New("defaults can only be applied to a non nil pointer")
+	}
+	return applyDefaults(value.Elem(), "")
+}
+
+func applyDefaults(value reflect.Value, path string) error {
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if value.IsNil() {
+			return nil
+		}
+		return applyDefaults(value.Elem(), path)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if err := applyDefaults(value.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Struct:
+	default:
+		return nil
+	}
+
+	structType := value.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		fieldValue := value.Field(i)
+		if field.PkgPath != "" { // unexported
+			continue
+		}
+		fieldPath := joinPath(path, fieldName(field))
+		if isZero(fieldValue) {
+			if def, ok := field.Tag.Lookup("default"); ok {
+				if err := setFromString(fieldValue, def); err != nil {
+					return NewValidationError(fieldPath, "bad default value '%s': %s", def, err)
+				}
+			} else if field.Tag.Get("required") == "true" {
+				return NewValidationError(fieldPath, "value is required")
+			}
+		}
+		if err := applyDefaults(fieldValue, fieldPath); err != nil {
+			return err
+		}
+	}
+
+	if !value.CanAddr() {
+		return nil
+	}
+	if validator, ok := value.Addr().Interface().(Validator); ok {
+		if err := validator.Validate(); err != nil {
+			if verr, ok := err.(*ValidationError); ok {
+				verr.Field = joinPath(path, verr.Field)
+				return verr
+			}
+			return NewValidationError(path, "%s", err)
+		}
+	}
+	return nil
+}
+
+func setFromString(value reflect.Value, s string) error {
+	if value.Type() == durationType {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		value.SetInt(int64(d))
+		return nil
+	}
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		value.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetFloat(f)
+	case reflect.Slice:
+		if value.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported default for type %s", value.Type())
+		}
+		items := strings.Split(s, ",")
+		slice := reflect.MakeSlice(value.Type(), len(items), len(items))
+		for i, item := range items {
+			slice.Index(i).SetString(strings.TrimSpace(item))
+		}
+		value.Set(slice)
+	default:
+		return fmt.Errorf("unsupported default for type %s", value.Type())
+	}
+	return nil
+}
+
+func isZero(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Slice, reflect.Map:
+		return value.Len() == 0
+	default:
+		return reflect.DeepEqual(value.Interface(), reflect.Zero(value.Type()).Interface())
+	}
+}
+
+// fieldName the name of the field as it appears in the configuration file
+func fieldName(field reflect.StructField) string {
+	if name := strings.Split(field.Tag.Get("json"), ",")[0]; name != "" && name != "-" {
+		return name
+	}
+	return field.Name
+}
+
+func joinPath(path string, name string) string {
+	if path == "" {
+		return name
+	}
+	if name == "" {
+		return path
+	}
+	return path + "." + name
+}
@@ -1,14 +1,20 @@
 module fbc/cwf/radius
 
 replace (
+	fbc/lib/go/debughttp => ../lib/go/debughttp
 	fbc/lib/go/machine => ../lib/go/machine
 	fbc/lib/go/radius => ../lib/go/radius
+	fbc/lib/go/retry => ../lib/go/retry
+	fbc/lib/go/rolling => ../lib/go/rolling
 )
 
 require (
 	contrib.go.opencensus.io/exporter/prometheus v0.1.0
+	fbc/lib/go/debughttp v0.0.0-00010101000000-000000000000
 	fbc/lib/go/machine v0.0.0-00010101000000-000000000000
 	fbc/lib/go/radius v0.0.0-00010101000000-000000000000
+	fbc/lib/go/retry v0.0.0-00010101000000-000000000000
+	fbc/lib/go/rolling v0.0.0-00010101000000-000000000000
 	github.com/donovanhide/eventsource v0.0.0-20171031113327-3ed64d21fb0b
 	github.com/golang/protobuf v1.3.1
 	github.com/google/uuid v1.1.1
@@ -24,4 +30,5 @@ require (
 	go.uber.org/zap v1.10.0
 	golang.org/x/net v0.0.0-20190620200207-3b0461eec859
 	google.golang.org/grpc v1.21.1
+	gopkg.in/yaml.v2 v2.2.2
 )
@@ -0,0 +1,81 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package loader
+
+import (
+	"fbc/cwf/radius/config"
+	"fbc/cwf/radius/modules"
+	"fbc/cwf/radius/modules/sdk"
+	"fmt"
+	"plugin"
+
+	"go.uber.org/zap"
+)
+
+// WithExternalModules returns a loader of the static modules & the external modules, loaded from their Go plugins
+func (l StaticLoader) WithExternalModules(externalModules []config.ExternalModuleConfig) (StaticLoader, error) {
+	if len(externalModules) == 0 {
+		return l, nil
+	}
+	result := StaticLoader{logger: l.logger, filters: l.filters, modules: make(ModuleNameMap, len(l.modules))}
+	for name, mod := range l.modules {
+		result.modules[name] = mod
+	}
+	for i, external := range externalModules {
+		field := fmt.Sprintf("externalModules[%d]", i)
+		if _, ok := result.modules[external.Name]; ok {
+			return l, config.NewValidationError(field+".name", "module '%s' is already defined", external.Name)
+		}
+		module, err := openPlugin(external.Path)
+		if err != nil {
+			return l, config.NewValidationError(field+".path", "%s", err)
+		}
+		if err = result.addExternalModule(external.Name, module); err != nil {
+			return l, config.NewValidationError(field, "%s", err)
+		}
+		l.logger.Info(
+			"external module loaded",
+			zap.String("module_name", external.Name),
+			zap.String("path", external.Path),
+		)
+	}
+	return result, nil
+}
+
+// addExternalModule adds the external module under the given name, if it's compatible with the SDK
+func (l StaticLoader) addExternalModule(name string, module sdk.Module) error {
+	if err := sdk.Check(module); err != nil {
+		return err
+	}
+	l.modules[name] = func() modules.Module { return sdk.Adapt(module) }
+	return nil
+}
+
+// openPlugin returns the sdk.Module exported by the Go plugin
+func openPlugin(path string) (sdk.Module, error) {
+	p, err := plugin.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open plugin %s: %s", path, err)
+	}
+	symbol, err := p.Lookup(sdk.Symbol)
+	if err != nil {
+		return nil, fmt.Errorf("plugin %s doesn't export %s: %s", path, sdk.Symbol, err)
+	}
+	switch module := symbol.(type) {
+	case *sdk.Module:
+		if *module == nil {
+			return nil, fmt.Errorf("plugin %s exports a nil %s", path, sdk.Symbol)
+		}
+		return *module, nil
+	case sdk.Module:
+		return module, nil
+	default:
+		return nil, fmt.Errorf("plugin %s %s is a %T, not an sdk.Module", path, sdk.Symbol, symbol)
+	}
+}
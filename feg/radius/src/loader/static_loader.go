@@ -21,11 +21,15 @@ import (
 	modcoadynamic "fbc/cwf/radius/modules/coadynamic"
 	modcoafixed "fbc/cwf/radius/modules/coafixedip"
 	modcoanas "fbc/cwf/radius/modules/coanas"
+	modconcurrency "fbc/cwf/radius/modules/concurrencylimit"
 	modeap "fbc/cwf/radius/modules/eap"
 	modlbserve "fbc/cwf/radius/modules/lbserve"
 	modmagmaacct "fbc/cwf/radius/modules/magmaacct"
+	modmaintenance "fbc/cwf/radius/modules/maintenance"
 	modproxy "fbc/cwf/radius/modules/proxy"
+	modrejectcache "fbc/cwf/radius/modules/rejectcache"
 	modloopback "fbc/cwf/radius/modules/testloopback"
+	modxwfcheckout "fbc/cwf/radius/modules/xwfcheckout"
 	modxwfv3 "fbc/cwf/radius/modules/xwfv3"
 	"fbc/lib/go/radius"
 	"fmt"
@@ -52,19 +56,23 @@ type StaticLoader struct {
 
 // CWFModuleMap the available CWF modules with their names, for use by the configuration file
 var CWFModuleMap = ModuleNameMap{
-	"addmsisdn":    func() modules.Module { return NewModule(modmsisdn.Init, modmsisdn.Handle) },
-	"analytics":    func() modules.Module { return NewModule(modan.Init, modan.Handle) },
-	"eap":          func() modules.Module { return NewModule(modeap.Init, modeap.Handle) },
-	"lbserve":      func() modules.Module { return NewModule(modlbserve.Init, modlbserve.Handle) },
-	"proxy":        func() modules.Module { return NewModule(modproxy.Init, modproxy.Handle) },
-	"xwfv3":        func() modules.Module { return NewModule(modxwfv3.Init, modxwfv3.Handle) },
-	"testloopback": func() modules.Module { return NewModule(modloopback.Init, modloopback.Handle) },
-	"coafixedip":   func() modules.Module { return NewModule(modcoafixed.Init, modcoafixed.Handle) },
-	"coanas":       func() modules.Module { return NewModule(modcoanas.Init, modcoanas.Handle) },
-	"coadynamic":   func() modules.Module { return NewModule(modcoadynamic.Init, modcoadynamic.Handle) },
-	"adaptruckus":  func() modules.Module { return NewModule(modadaptruckus.Init, modadaptruckus.Handle) },
-	"alwaysaccept": func() modules.Module { return NewModule(modalwaysaccept.Init, modalwaysaccept.Handle) },
-	"magmaacct":    func() modules.Module { return NewModule(modmagmaacct.Init, modmagmaacct.Handle) },
+	"addmsisdn":        func() modules.Module { return NewModule(modmsisdn.Init, modmsisdn.Handle) },
+	"analytics":        func() modules.Module { return NewModule(modan.Init, modan.Handle) },
+	"eap":              func() modules.Module { return NewModule(modeap.Init, modeap.Handle) },
+	"lbserve":          func() modules.Module { return NewModule(modlbserve.Init, modlbserve.Handle) },
+	"proxy":            func() modules.Module { return NewModule(modproxy.Init, modproxy.Handle) },
+	"xwfv3":            func() modules.Module { return NewModule(modxwfv3.Init, modxwfv3.Handle) },
+	"testloopback":     func() modules.Module { return NewModule(modloopback.Init, modloopback.Handle) },
+	"coafixedip":       func() modules.Module { return NewModule(modcoafixed.Init, modcoafixed.Handle) },
+	"coanas":           func() modules.Module { return NewModule(modcoanas.Init, modcoanas.Handle) },
+	"coadynamic":       func() modules.Module { return NewModule(modcoadynamic.Init, modcoadynamic.Handle) },
+	"adaptruckus":      func() modules.Module { return NewModule(modadaptruckus.Init, modadaptruckus.Handle) },
+	"alwaysaccept":     func() modules.Module { return NewModule(modalwaysaccept.Init, modalwaysaccept.Handle) },
+	"magmaacct":        func() modules.Module { return NewModule(modmagmaacct.Init, modmagmaacct.Handle) },
+	"maintenance":      func() modules.Module { return NewModule(modmaintenance.Init, modmaintenance.Handle) },
+	"concurrencylimit": func() modules.Module { return NewModule(modconcurrency.Init, modconcurrency.Handle) },
+	"xwfcheckout":      func() modules.Module { return NewModule(modxwfcheckout.Init, modxwfcheckout.Handle) },
+	"rejectcache":      func() modules.Module { return NewModule(modrejectcache.Init, modrejectcache.Handle) },
 }
 
 var CWFFilterMap = FilterNameMap{
@@ -77,6 +85,28 @@ func NewStaticLoader(logger *zap.Logger) Loader {
 	return StaticLoader{logger: logger, modules: CWFModuleMap, filters: CWFFilterMap}
 }
 
+// ValidatePipeline verifies all filters & enabled modules in the configured
+// pipeline are known to the loader
+func (l StaticLoader) ValidatePipeline(serverConfig config.ServerConfig) error {
+	for _, name := range serverConfig.Filters {
+		if _, ok := l.filters[name]; !ok {
+			return config.NewValidationError("filters", "unknown filter '%s'", name)
+		}
+	}
+	for _, listener := range serverConfig.Listeners {
+		for _, module := range listener.EnabledModules() {
+			if _, ok := l.modules[module.Name]; !ok {
+				return config.NewValidationError(
+					fmt.Sprintf("listeners.%s.modules", listener.Name),
+					"unknown module '%s'",
+					module.Name,
+				)
+			}
+		}
+	}
+	return nil
+}
+
 // LoadFilter returns a module invocation interface
 func (l StaticLoader) LoadFilter(name string) (filters.Filter, error) {
 	logger := l.logger.With(zap.String("fiter_name", name))
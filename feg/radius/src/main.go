@@ -10,11 +10,12 @@ package main
 
 import (
 	"errors"
+	"fbc/cwf/radius/admin"
+	"fbc/cwf/radius/buildinfo"
 	"fbc/cwf/radius/config"
 	"fbc/cwf/radius/loader"
 	"fbc/cwf/radius/monitoring/counters"
-	"fbc/cwf/radius/monitoring/scuba"
-	"fbc/cwf/radius/monitoring/ods"
+	"fbc/cwf/radius/monitoring/debug"
 	"fbc/cwf/radius/server"
 	"flag"
 	"fmt"
@@ -23,7 +24,9 @@ import (
 	"os"
 	"os/signal"
 	"sort"
+	"sync/atomic"
 	"syscall"
+	"time"
 
 	"go.uber.org/zap"
 )
@@ -34,7 +37,9 @@ func main() {
 
 	// Get configuration
 	var configFilename string
-	flag.StringVar(&configFilename, "config", "radius.config.json", "The configuration filename")
+	var printPipeline bool
+	flag.StringVar(&configFilename, "config", "radius.config.json", "The configuration filename (JSON or YAML)")
+	flag.BoolVar(&printPipeline, "print-pipeline", false, "Validate & print the configured packet pipeline, then exit")
 	flag.Parse()
 	config, err := config.Read(configFilename)
 	if err != nil {
@@ -42,36 +47,102 @@ func main() {
 		return
 	}
 
+	// Validate the pipeline before initializing anything
+	staticLoader, err := loader.NewStaticLoader(logger).(loader.StaticLoader).WithExternalModules(
+		config.Server.ExternalModules)
+	if err != nil {
+		logger.Error("Failed loading external modules", zap.Error(err))
+		return
+	}
+	err = staticLoader.ValidatePipeline(config.Server)
+	if err != nil {
+		logger.Error("Invalid pipeline configuration", zap.Error(err))
+		return
+	}
+	if printPipeline {
+		config.Server.WritePipeline(os.Stdout)
+		return
+	}
+
 	// Initialize monitoring
 	logger, err = initMonitoring(config.Monitoring, logger)
 	if err != nil {
 		logger.Error("Failed initializing monitoring", zap.Error(err))
 		return
 	}
+	reloadFacebookSinksOnSIGHUP(config.Monitoring, configFilename, logger)
+
+	host := getHostIdentifier()
+	logger = logger.With(zap.String("host", host))
+	info := buildinfo.New(host, config.Hash, config.Features(), flag.CommandLine)
+	logger.Info("Starting radius server", info.Fields()...)
+
+	// Keep the recent errors for the admin service
+	var errorRing *admin.ErrorRing
+	if config.Admin != nil {
+		errorRing = admin.NewErrorRing(config.Admin.ErrorRingSize)
+		logger = errorRing.WrapLogger(logger)
+	}
 
-	logger = logger.With(zap.String("host", getHostIdentifier()))
-
-	loader := loader.NewStaticLoader(logger)
+	// Take the sockets & sessions of the upgraded server process over, it releases its ports first
+	handoff, err := server.TakeOver(config.Server.Handoff, logger)
+	if err != nil {
+		logger.Error("Failed taking over the previous server process", zap.Error(err))
+		return
+	}
 
 	// Create server
-	radiusServer, err := server.New(config.Server, logger, loader)
+	radiusServer, err := server.New(config.Server, logger, staticLoader)
 	if err != nil {
 		logger.Error("Failed creating server", zap.Error(err))
 		return
 	}
+	err = radiusServer.Resume(handoff)
+	if err != nil {
+		logger.Error("Failed resuming the previous server process' state", zap.Error(err))
+		return
+	}
+
+	// Start the admin service
+	if config.Admin != nil {
+		adminServer, err := admin.NewService(radiusServer, errorRing, info).Start(config.Admin.Port, logger)
+		if err != nil {
+			logger.Error("Failed starting admin service", zap.Error(err))
+			return
+		}
+		// The next server process starts its admin service on the same port
+		radiusServer.OnHandoff(adminServer.Stop)
+	}
+
+	// Start the debug endpoint, after the previous server process released its port
+	if config.Monitoring.Debug != nil {
+		debugServer, err := debug.Start(config.Monitoring.Debug, logger)
+		if err != nil {
+			logger.Error("Failed starting debug endpoint", zap.Error(err))
+			return
+		}
+		radiusServer.OnHandoff(func() { debugServer.Close() })
+	}
 
 	// Capture CTRL+C
+	var terminated int32
 	sigtermChannel := make(chan os.Signal, 1)
 	signal.Notify(sigtermChannel, os.Interrupt, syscall.SIGTERM)
 	go func() {
 		<-sigtermChannel
 		logger.Info("Received SIGTERM, existing")
+		atomic.StoreInt32(&terminated, 1)
 		radiusServer.Stop()
-		logger.Sync()
 	}()
 
 	// Start the server
 	radiusServer.Start()
+
+	// Save the monitoring state for the next start, the handoffs' new server process already took over
+	if atomic.LoadInt32(&terminated) == 1 {
+		shutdownMonitoring(config.Monitoring, logger)
+	}
+	logger.Sync()
 }
 
 func getHostIdentifier() string {
@@ -112,17 +183,32 @@ func initMonitoring(config *config.MonitoringConfig, logger *zap.Logger) (*zap.L
 		counters.Init(*config.Census, logger)
 	}
 
-	if config.Ods != nil {
-		ods.Init(config.Ods, logger)
-	}
-
-	if config.Scuba != nil {
-		scuba.Initialize(config.Scuba, logger)
-		result, err = scuba.NewLogger("goradius")
+	if config.Snapshot != nil {
+		restored, err := counters.RestoreSnapshot(
+			config.Snapshot.Path, time.Second*time.Duration(config.Snapshot.MaxAgeSec))
 		if err != nil {
-			return nil, err
+			logger.Warn("Failed restoring counters snapshot, the counters start from zero", zap.Error(err))
+		} else if restored > 0 {
+			logger.Info("Restored counters snapshot", zap.Int("values", restored))
 		}
 	}
 
+	// ODS & Scuba, unless built with the oss tag
+	result, err = initFacebookSinks(config, logger)
+	if err != nil {
+		return nil, err
+	}
+
 	return result, nil
 }
+
+// shutdownMonitoring writes the counters' snapshot & spools the queued scuba logs on a graceful shutdown, so the next
+// start resumes them
+func shutdownMonitoring(config *config.MonitoringConfig, logger *zap.Logger) {
+	if config.Snapshot != nil {
+		if err := counters.WriteSnapshot(config.Snapshot.Path); err != nil {
+			logger.Error("Failed writing counters snapshot", zap.Error(err))
+		}
+	}
+	shutdownFacebookSinks(config, logger)
+}
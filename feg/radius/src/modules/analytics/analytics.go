@@ -18,11 +18,13 @@ import (
 
 	"fbc/cwf/radius/modules"
 	"fbc/cwf/radius/modules/analytics/graphql"
+	"fbc/cwf/radius/modules/ctxattr"
 	"fbc/cwf/radius/session"
 	"fbc/lib/go/radius"
 	"fbc/lib/go/radius/rfc2865"
 	"fbc/lib/go/radius/rfc2866"
 	"fbc/lib/go/radius/rfc2869"
+	"fbc/lib/go/retry"
 
 	"github.com/mitchellh/mapstructure"
 	"go.uber.org/zap"
@@ -34,6 +36,11 @@ type Config struct {
 	GraphQLURL    string // the GraphQL endpoint to issue calls to
 	DryRunGraphQL bool   // true means all GraphQL operations will be skipped & assumed successful.
 	AllowPII      bool   // If true, PII will not be tokenized before sending to GraphQL
+
+	// Attributes NAS attributes to report as session context attributes, values are tokenized unless AllowPII
+	Attributes []ctxattr.Spec
+	// Retry optional retries of failed GraphQL calls, as HTTP errors have no gRPC code RetryableCodes must not be set
+	Retry *retry.Config
 }
 
 type (
@@ -47,6 +54,7 @@ type (
 )
 
 // Init module interface implementation
+//
 //nolint:deadcode
 func Init(logger *zap.Logger, config modules.ModuleConfig) (modules.Context, error) {
 	var ctx ModuleCtx
@@ -66,6 +74,17 @@ func Init(logger *zap.Logger, config modules.ModuleConfig) (modules.Context, err
 		logger.Warn("ANALYTICS IS SET TO ALLOW PII BE SENT OUT")
 	}
 
+	if err = ctxattr.Validate(ctx.cfg.Attributes); err != nil {
+		return nil, err
+	}
+
+	var retrier *retry.Retrier
+	if ctx.cfg.Retry != nil {
+		if retrier, err = retry.New(*ctx.cfg.Retry); err != nil {
+			return nil, err
+		}
+	}
+
 	ctx.graphQLOps = make(map[string]*Queue)
 	// Create client
 	ctx.graphqlClient = graphql.NewClient(graphql.ClientConfig{
@@ -76,6 +95,7 @@ func Init(logger *zap.Logger, config modules.ModuleConfig) (modules.Context, err
 				TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
 			},
 		},
+		Retrier: retrier,
 	})
 
 	return ctx, nil
@@ -102,7 +122,26 @@ func getSessionState(logger *zap.Logger, c *modules.RequestContext) (*session.St
 	return sessionState, nil
 }
 
+// sessionAttributes returns the configured NAS attributes of the packet merged with the attributes attached to the
+// session by other modules, the NAS attributes take precedence
+func sessionAttributes(specs []ctxattr.Spec, pkt *radius.Packet, state *session.State) map[string]string {
+	attrs := ctxattr.Extract(specs, pkt)
+	if len(state.Attributes) == 0 {
+		return attrs
+	}
+	if attrs == nil {
+		attrs = make(map[string]string, len(state.Attributes))
+	}
+	for k, v := range state.Attributes {
+		if _, ok := attrs[k]; !ok {
+			attrs[k] = v
+		}
+	}
+	return attrs
+}
+
 // Handle module interface implementation
+//
 //nolint:deadcode
 func Handle(m modules.Context, c *modules.RequestContext, r *radius.Request, next modules.Middleware) (*modules.Response, error) {
 	mCtx := m.(ModuleCtx)
@@ -137,6 +176,7 @@ func Handle(m modules.Context, c *modules.RequestContext, r *radius.Request, nex
 			CallingStationID:     rfc2865.CallingStationID_GetString(pkt),
 			FramedIPAddress:      framedIPAddr,
 			NormalizedMacAddress: normalizedMacAddress,
+			Attributes:           sessionAttributes(mCtx.cfg.Attributes, pkt, sessionState),
 		}
 
 		if !mCtx.cfg.AllowPII {
@@ -144,6 +184,7 @@ func Handle(m modules.Context, c *modules.RequestContext, r *radius.Request, nex
 			session.CallingStationID = tokenize(session.CallingStationID)
 			session.FramedIPAddress = tokenize(session.FramedIPAddress)
 			session.NormalizedMacAddress = tokenize(session.NormalizedMacAddress)
+			tokenizeAttributes(session.Attributes)
 		}
 
 		// Persist state before we fire an async task - so the session has a single state object
@@ -191,16 +232,22 @@ func Handle(m modules.Context, c *modules.RequestContext, r *radius.Request, nex
 
 			// Extract accounting octets
 			session := RadiusSession{
-				FBID:          sessionState.RadiusSessionFBID,
-				NASIdentifier: rfc2865.NASIdentifier_GetString(pkt),
-				AcctSessionID: rfc2866.AcctSessionID_GetString(pkt),
-				UploadBytes:   inputBytes,
-				DownloadBytes: outputBytes,
+				FBID:               sessionState.RadiusSessionFBID,
+				NASIdentifier:      rfc2865.NASIdentifier_GetString(pkt),
+				AcctSessionID:      rfc2866.AcctSessionID_GetString(pkt),
+				AcctMultiSessionID: rfc2866.AcctMultiSessionID_GetString(pkt),
+				UploadBytes:        inputBytes,
+				DownloadBytes:      outputBytes,
+				Attributes:         sessionAttributes(mCtx.cfg.Attributes, pkt, sessionState),
 			}
 
 			// Tokenize fields which might contain PII
 			if !mCtx.cfg.AllowPII {
 				session.AcctSessionID = tokenize(session.AcctSessionID)
+				if len(session.AcctMultiSessionID) > 0 {
+					session.AcctMultiSessionID = tokenize(session.AcctMultiSessionID)
+				}
+				tokenizeAttributes(session.Attributes)
 			}
 
 			// Send the request!
@@ -236,3 +283,10 @@ func tokenize(s string) string {
 	h.Write([]byte(s))
 	return base64.StdEncoding.EncodeToString(h.Sum(nil))
 }
+
+// tokenizeAttributes tokenizes all attribute values, which might contain PII
+func tokenizeAttributes(attrs map[string]string) {
+	for k, v := range attrs {
+		attrs[k] = tokenize(v)
+	}
+}
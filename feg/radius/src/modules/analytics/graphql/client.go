@@ -9,6 +9,7 @@ LICENSE file in the root directory of this source tree.
 package graphql
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -16,6 +17,7 @@ import (
 	"strings"
 	"time"
 
+	"fbc/lib/go/retry"
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
 )
@@ -49,6 +51,9 @@ type ClientConfig struct {
 	Endpoint string
 	// HTTPClient is an optional HTTP client. defaults to http.DefaultClient.
 	HTTPClient *http.Client
+	// Retrier optionally retries failed operations, transport errors & server
+	// errors (5xx, 429) are retryable, other errors are permanent.
+	Retrier *retry.Retrier
 }
 
 // NewClient creates a new libgraphql.Client by the given config.
@@ -56,6 +61,9 @@ func NewClient(c ClientConfig) *Client {
 	if c.HTTPClient == nil {
 		c.HTTPClient = &http.Client{Timeout: defaultClientTimeout}
 	}
+	if c.Retrier == nil {
+		c.Retrier = retry.NoRetry
+	}
 	return &Client{ClientConfig: c, auth: "Bearer " + c.Token}
 }
 
@@ -68,9 +76,17 @@ func (c *Client) Do(op Op) error {
 		return err
 	}
 	v.Add("variables", vars)
-	req, err := http.NewRequest(http.MethodPost, c.Endpoint, strings.NewReader(v.Encode()))
+	body := v.Encode()
+	// variables are encoded once, so retries of mutations keep their client mutation ID
+	return c.Retrier.Do(context.Background(), func(context.Context) error {
+		return c.do(op, body)
+	})
+}
+
+func (c *Client) do(op Op, body string) error {
+	req, err := http.NewRequest(http.MethodPost, c.Endpoint, strings.NewReader(body))
 	if err != nil {
-		return err
+		return retry.Permanent(err)
 	}
 	req.Header.Add("Authorization", c.auth)
 	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
@@ -80,10 +96,14 @@ func (c *Client) Do(op Op) error {
 	}
 	defer res.Body.Close()
 	if res.StatusCode != http.StatusOK {
-		return errors.Errorf("libgraphql: invalid status code: %s", res.Status)
+		err = errors.Errorf("libgraphql: invalid status code: %s", res.Status)
+		if res.StatusCode < http.StatusInternalServerError && res.StatusCode != http.StatusTooManyRequests {
+			return retry.Permanent(err)
+		}
+		return err
 	}
 	if err := json.NewDecoder(res.Body).Decode(op); err != nil {
-		return err
+		return retry.Permanent(err)
 	}
 	return nil
 }
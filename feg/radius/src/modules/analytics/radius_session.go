@@ -37,6 +37,9 @@ type RadiusSession struct {
 	// acct session id
 	AcctSessionID string `json:"acct_session_id,omitempty"`
 
+	// acct multi session id, groups the sessions of a multi-session (e.g. fast roams across the APs of one ESS)
+	AcctMultiSessionID string `json:"acct_multi_session_id,omitempty"`
+
 	// called station id
 	CalledStationID string `json:"called_station_id,omitempty" gorm:"index:radius_session_index"`
 
@@ -60,6 +63,9 @@ type RadiusSession struct {
 
 	// Vendor enum
 	Vendor int64 `json:"vendor,omitempty"`
+
+	// configured NAS context attributes
+	Attributes map[string]string `json:"attributes,omitempty" gorm:"-"`
 }
 
 // Session is a GraphQL response for create and update radius sessions.
@@ -75,35 +81,39 @@ type Session struct {
 }
 
 /*
- * CreateSessionOp holds the graphql create_cwfradius_session mutation.
- * Sample usage using GraphiQL tool:
+  - CreateSessionOp holds the graphql create_cwfradius_session mutation.
+  - Sample usage using GraphiQL tool:
+
 Query/Mutation:
 mutation create_cwfradius_session($data: CreateCwfradiusSessionData!) {
-mutation create_cwfradius_session($data: CreateCwfradiusSessionData!) {
-	create_cwfradius_session(data: $data) {
-		client_mutation_id
-    radius_session {
-      id
-    }
-  }
-}
+
+	mutation create_cwfradius_session($data: CreateCwfradiusSessionData!) {
+		create_cwfradius_session(data: $data) {
+			client_mutation_id
+	    radius_session {
+	      id
+	    }
+	  }
+	}
+
 Query Variables:
-{
-  "data": {
-    "client_mutation_id": 7,
-    "acct_session_id": "6",
-    "calling_station_id": "5",
-    "called_station_id": "4",
-    "normalized_mac_address": "aa:bb:cc:dd:ee:ff",
-    "nas_identifier": "nas",
-    "nas_ip_address": "nas ip",
-    "upload_bytes": 100,
-    "download_bytes": 100,
-    "radius_server_id": 0,
-    "vendor_name": "CAMBIUM",
-    "framed_ip_address": "1.1.1.1"
-  }
-}
+
+	{
+	  "data": {
+	    "client_mutation_id": 7,
+	    "acct_session_id": "6",
+	    "calling_station_id": "5",
+	    "called_station_id": "4",
+	    "normalized_mac_address": "aa:bb:cc:dd:ee:ff",
+	    "nas_identifier": "nas",
+	    "nas_ip_address": "nas ip",
+	    "upload_bytes": 100,
+	    "download_bytes": 100,
+	    "radius_server_id": 0,
+	    "vendor_name": "CAMBIUM",
+	    "framed_ip_address": "1.1.1.1"
+	  }
+	}
 */
 type CreateSessionOp struct {
 	Session *RadiusSession
@@ -132,7 +142,7 @@ mutation create_cwfradius_session($data: CreateCwfradiusSessionData!) {
 
 // Vars returns the variables for the create_cwfradius_session mutation.
 func (c *CreateSessionOp) Vars() (string, error) {
-	return graphql.Vars{
+	v := graphql.Vars{
 		"acct_session_id":        c.Session.AcctSessionID,
 		"called_station_id":      c.Session.CalledStationID,
 		"calling_station_id":     c.Session.CallingStationID,
@@ -144,7 +154,11 @@ func (c *CreateSessionOp) Vars() (string, error) {
 		"download_bytes":         c.Session.DownloadBytes,
 		"radius_server_id":       c.Session.RADIUSServerID,
 		"vendor_name":            Vendor(c.Session.Vendor).String(),
-	}.String()
+	}
+	if len(c.Session.Attributes) > 0 {
+		v["attributes"] = c.Session.Attributes
+	}
+	return v.String()
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface. Used by the graphql.Client.
@@ -244,6 +258,12 @@ func (u *UpdateSessionOp) Vars() (string, error) {
 		"radius_server_id":       u.Session.RADIUSServerID,
 		"vendor_name":            Vendor(u.Session.Vendor).String(),
 	}
+	if len(u.Session.AcctMultiSessionID) > 0 {
+		v["acct_multi_session_id"] = u.Session.AcctMultiSessionID
+	}
+	if len(u.Session.Attributes) > 0 {
+		v["attributes"] = u.Session.Attributes
+	}
 	return v.String()
 }
 
@@ -56,15 +56,20 @@ func Init(logger *zap.Logger, config modules.ModuleConfig) (modules.Context, err
 }
 
 // Handle module interface implementation
-// For radius requests we try to match the called and calling fields to the latest tracked called,calling,ip
+// For coa radius requests we use the session's NAS address known to the AAA or, if unknown, the latest tracked ip of
+// the session
 // For non coa radius requests we store a mapping of called,calling and ip
 func Handle(m modules.Context, c *modules.RequestContext, r *radius.Request, next modules.Middleware) (*modules.Response, error) {
 	mod := m.(ModuleCtx)
 	// We received a coa request
 	if r.Code == radius.CodeCoARequest || r.Code == radius.CodeDisconnectRequest {
-		target, err := radiusTracker.Get(r)
-		if err != nil {
-			return nil, err
+		// the AAA's NAS binding follows the session's accounting across RADIUS servers & restarts
+		target := c.NASAddress
+		if len(target) == 0 {
+			var err error
+			if target, err = radiusTracker.Get(r); err != nil {
+				return nil, err
+			}
 		}
 
 		destination := fmt.Sprintf("%s:%d", target, mod.port)
@@ -68,6 +68,17 @@ func TestCoaDynamic(t *testing.T) {
 	require.Nil(t, err)
 	require.NotNil(t, res)
 	require.Equal(t, res.Code, radius.CodeDisconnectACK)
+
+	// Sending a coa request of an untracked session to its NAS address known to the AAA
+	res, err = Handle(
+		ctx,
+		&modules.RequestContext{Logger: zap.NewNop(), NASAddress: "127.0.0.1"},
+		createRadiusRequest(radius.CodeDisconnectRequest, "session4"),
+		nil,
+	)
+	require.Nil(t, err)
+	require.Equal(t, uint32(3), atomic.LoadUint32(&radiusResponseCounter))
+	require.Equal(t, res.Code, radius.CodeDisconnectACK)
 }
 
 func generateRequest(ctx modules.Context, code radius.Code, t *testing.T, sessionID string, next ...bool) (*modules.Response, error) {
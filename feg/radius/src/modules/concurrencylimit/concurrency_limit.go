@@ -0,0 +1,221 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package concurrencylimit
+
+import (
+	"errors"
+	"fbc/cwf/radius/modules"
+	"fbc/cwf/radius/monitoring/counters"
+	"fbc/lib/go/radius"
+	"fbc/lib/go/radius/rfc2865"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"go.uber.org/zap"
+)
+
+const defaultGroup = "default"
+
+// ConcurrencyLimit counts requests admitted to the rest of the module chain,
+// the latency is the time spent waiting for a free slot
+var ConcurrencyLimit = counters.NewOperation("concurrency_limit")
+
+// Config the module configuration
+type Config struct {
+	// MaxConcurrent maximum number of requests handled concurrently by the
+	// modules following this one, 0 - unlimited
+	MaxConcurrent int
+
+	// MaxConcurrentPerNAS maximum number of requests of a single NAS handled
+	// concurrently by the modules following this one, 0 - unlimited
+	MaxConcurrentPerNAS int
+
+	// QueueTimeoutMs how long an excess request waits for a free slot before
+	// it is dropped, 0 - excess requests are dropped immediately
+	QueueTimeoutMs int
+
+	// Group module instances (e.g. of different listeners) with the same
+	// group share the limits, so the limits apply to the backends globally
+	Group string
+}
+
+// limiter semaphores shared by all module instances of a group
+type limiter struct {
+	global    chan struct{} // nil if unlimited
+	perNAS    int
+	nasSlots  map[string]chan struct{}
+	nasMu     sync.Mutex
+	maxGlobal int
+}
+
+var (
+	groups   = map[string]*limiter{}
+	groupsMu sync.Mutex
+)
+
+// ModuleCtx ...
+type ModuleCtx struct {
+	limiter      *limiter
+	queueTimeout time.Duration
+}
+
+// Init module interface implementation
+func Init(logger *zap.Logger, config modules.ModuleConfig) (modules.Context, error) {
+	var mConfig Config
+	err := mapstructure.Decode(config, &mConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if mConfig.MaxConcurrent < 0 || mConfig.MaxConcurrentPerNAS < 0 || mConfig.QueueTimeoutMs < 0 {
+		return nil, errors.New("concurrency limit module limits and queue timeout cannot be negative")
+	}
+	if mConfig.MaxConcurrent == 0 && mConfig.MaxConcurrentPerNAS == 0 {
+		return nil, errors.New("concurrency limit module requires MaxConcurrent and/or MaxConcurrentPerNAS")
+	}
+	if mConfig.Group == "" {
+		mConfig.Group = defaultGroup
+	}
+
+	l, err := getLimiter(mConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info(
+		"concurrency limit module initialized",
+		zap.String("group", mConfig.Group),
+		zap.Int("max_concurrent", mConfig.MaxConcurrent),
+		zap.Int("max_concurrent_per_nas", mConfig.MaxConcurrentPerNAS),
+		zap.Int("queue_timeout_ms", mConfig.QueueTimeoutMs),
+	)
+	return ModuleCtx{
+		limiter:      l,
+		queueTimeout: time.Duration(mConfig.QueueTimeoutMs) * time.Millisecond,
+	}, nil
+}
+
+// getLimiter returns the group's limiter, creating it on first use
+func getLimiter(config Config) (*limiter, error) {
+	groupsMu.Lock()
+	defer groupsMu.Unlock()
+	if l, ok := groups[config.Group]; ok {
+		if l.maxGlobal != config.MaxConcurrent || l.perNAS != config.MaxConcurrentPerNAS {
+			return nil, fmt.Errorf("concurrency limit group '%s' is already configured with different limits", config.Group)
+		}
+		return l, nil
+	}
+	l := &limiter{
+		maxGlobal: config.MaxConcurrent,
+		perNAS:    config.MaxConcurrentPerNAS,
+		nasSlots:  map[string]chan struct{}{},
+	}
+	if config.MaxConcurrent > 0 {
+		l.global = make(chan struct{}, config.MaxConcurrent)
+	}
+	groups[config.Group] = l
+	return l, nil
+}
+
+// Handle module interface implementation
+func Handle(m modules.Context, c *modules.RequestContext, r *radius.Request, next modules.Middleware) (*modules.Response, error) {
+	mCtx := m.(ModuleCtx)
+	counter := ConcurrencyLimit.Start()
+
+	// The deadline is shared by both limits, so the total wait is bounded
+	var deadline <-chan time.Time
+	if mCtx.queueTimeout > 0 {
+		timer := time.NewTimer(mCtx.queueTimeout)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+
+	nas := nasKey(r)
+	nasSlots := mCtx.limiter.nasSemaphore(nas)
+	if !acquire(nasSlots, deadline) {
+		counter.Failure("nas_limit")
+		return nil, fmt.Errorf("concurrency limit of NAS %s reached, request dropped", nas)
+	}
+	defer release(nasSlots)
+
+	if !acquire(mCtx.limiter.global, deadline) {
+		counter.Failure("global_limit")
+		return nil, errors.New("global concurrency limit reached, request dropped")
+	}
+	defer release(mCtx.limiter.global)
+
+	counter.Success()
+	return next(c, r)
+}
+
+// nasSemaphore returns the NAS's semaphore or nil if unlimited. Semaphores
+// are kept for the server's lifetime, the number of NASes is bounded by the
+// number of APs served
+func (l *limiter) nasSemaphore(nas string) chan struct{} {
+	if l.perNAS == 0 {
+		return nil
+	}
+	l.nasMu.Lock()
+	defer l.nasMu.Unlock()
+	slots, ok := l.nasSlots[nas]
+	if !ok {
+		slots = make(chan struct{}, l.perNAS)
+		l.nasSlots[nas] = slots
+	}
+	return slots
+}
+
+// acquire takes a slot of the semaphore, waiting until the deadline if no
+// slot is free. A nil semaphore is unlimited, a nil deadline does not wait
+func acquire(slots chan struct{}, deadline <-chan time.Time) bool {
+	if slots == nil {
+		return true
+	}
+	select {
+	case slots <- struct{}{}:
+		return true
+	default:
+	}
+	if deadline == nil {
+		return false
+	}
+	select {
+	case slots <- struct{}{}:
+		return true
+	case <-deadline:
+		return false
+	}
+}
+
+func release(slots chan struct{}) {
+	if slots != nil {
+		<-slots
+	}
+}
+
+// nasKey identifies the request's NAS by NAS-IP-Address, NAS-Identifier or
+// the request's source IP, in this order
+func nasKey(r *radius.Request) string {
+	if ip := rfc2865.NASIPAddress_Get(r.Packet); ip != nil {
+		return ip.String()
+	}
+	if id := rfc2865.NASIdentifier_GetString(r.Packet); id != "" {
+		return id
+	}
+	if r.RemoteAddr != nil {
+		if host, _, err := net.SplitHostPort(r.RemoteAddr.String()); err == nil {
+			return host
+		}
+		return r.RemoteAddr.String()
+	}
+	return ""
+}
@@ -0,0 +1,103 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package concurrencylimit
+
+import (
+	"fbc/cwf/radius/modules"
+	"fbc/lib/go/radius"
+	"fbc/lib/go/radius/rfc2865"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newRequest(nasIP string) *radius.Request {
+	packet := radius.New(radius.CodeAccessRequest, []byte{})
+	rfc2865.NASIPAddress_Set(packet, net.ParseIP(nasIP))
+	return &radius.Request{Packet: packet}
+}
+
+// blockingNext returns a next middleware blocking until release is closed,
+// entered is signaled once the middleware is called
+func blockingNext(entered chan struct{}, release chan struct{}) modules.Middleware {
+	return func(c *modules.RequestContext, r *radius.Request) (*modules.Response, error) {
+		entered <- struct{}{}
+		<-release
+		return &modules.Response{Code: radius.CodeAccessAccept}, nil
+	}
+}
+
+func TestPerNASLimit(t *testing.T) {
+	// Arrange
+	logger := zap.NewNop()
+	ctx, err := Init(logger, modules.ModuleConfig{"MaxConcurrentPerNAS": 1, "Group": "TestPerNASLimit"})
+	require.NoError(t, err)
+	entered, release := make(chan struct{}, 2), make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		_, err := Handle(ctx, &modules.RequestContext{Logger: logger}, newRequest("10.0.0.1"), blockingNext(entered, release))
+		done <- err
+	}()
+	<-entered
+
+	// Act
+	_, sameNASErr := Handle(ctx, &modules.RequestContext{Logger: logger}, newRequest("10.0.0.1"), blockingNext(entered, release))
+	close(release)
+	otherNASRes, otherNASErr := Handle(ctx, &modules.RequestContext{Logger: logger}, newRequest("10.0.0.2"), blockingNext(entered, release))
+
+	// Assert
+	require.Error(t, sameNASErr)
+	require.NoError(t, otherNASErr)
+	require.Equal(t, radius.CodeAccessAccept, otherNASRes.Code)
+	require.NoError(t, <-done)
+}
+
+func TestGlobalLimitQueueing(t *testing.T) {
+	// Arrange
+	logger := zap.NewNop()
+	ctx, err := Init(logger, modules.ModuleConfig{
+		"MaxConcurrent":  1,
+		"QueueTimeoutMs": 5000,
+		"Group":          "TestGlobalLimitQueueing",
+	})
+	require.NoError(t, err)
+	entered, release := make(chan struct{}, 2), make(chan struct{})
+	go func() {
+		_, _ = Handle(ctx, &modules.RequestContext{Logger: logger}, newRequest("10.0.0.1"), blockingNext(entered, release))
+	}()
+	<-entered
+
+	// Act
+	done := make(chan error, 1)
+	go func() {
+		_, err := Handle(ctx, &modules.RequestContext{Logger: logger}, newRequest("10.0.0.2"), blockingNext(entered, release))
+		done <- err
+	}()
+	close(release) // the first request completes & the queued one gets its slot
+
+	// Assert
+	require.NoError(t, <-done)
+}
+
+func TestInitValidation(t *testing.T) {
+	// Arrange
+	logger := zap.NewNop()
+
+	// Act
+	_, noLimitsErr := Init(logger, modules.ModuleConfig{"Group": "TestInitValidation"})
+	_, firstErr := Init(logger, modules.ModuleConfig{"MaxConcurrent": 10, "Group": "TestInitValidation"})
+	_, mismatchErr := Init(logger, modules.ModuleConfig{"MaxConcurrent": 20, "Group": "TestInitValidation"})
+
+	// Assert
+	require.Error(t, noLimitsErr)
+	require.NoError(t, firstErr)
+	require.Error(t, mismatchErr)
+}
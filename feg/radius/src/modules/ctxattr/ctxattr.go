@@ -0,0 +1,128 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package ctxattr
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+
+	"fbc/cwf/radius/modules/protos"
+	"fbc/lib/go/radius"
+	"fbc/lib/go/radius/rfc2865"
+)
+
+// Value formats
+const (
+	FormatString  = "string"
+	FormatInteger = "integer"
+	FormatIP      = "ip"
+	FormatHex     = "hex"
+)
+
+// Spec maps a RADIUS attribute to a context attribute
+type Spec struct {
+	// Key the context attribute key
+	Key string
+	// Type the RADIUS attribute type, Vendor-Specific (26) attributes also require VendorID & VendorType
+	Type int
+	// VendorID & VendorType identify a Vendor-Specific attribute
+	VendorID   uint32
+	VendorType int
+	// Format of the context attribute value: string (default), integer, ip or hex
+	Format string
+}
+
+// Validate returns an error if any of the specs is invalid
+func Validate(specs []Spec) error {
+	for _, s := range specs {
+		if err := protos.ValidateAttribute(s.Key, ""); err != nil {
+			return err
+		}
+		if s.Type <= 0 || s.Type > 255 {
+			return fmt.Errorf("invalid RADIUS attribute type %d of context attribute '%s'", s.Type, s.Key)
+		}
+		if s.Type == int(rfc2865.VendorSpecific_Type) && (s.VendorID == 0 || s.VendorType <= 0 || s.VendorType > 255) {
+			return fmt.Errorf("context attribute '%s' requires VendorID & VendorType", s.Key)
+		}
+		switch s.Format {
+		case "", FormatString, FormatInteger, FormatIP, FormatHex:
+		default:
+			return fmt.Errorf("unknown format '%s' of context attribute '%s'", s.Format, s.Key)
+		}
+	}
+	if len(specs) > protos.MaxAttributes {
+		return fmt.Errorf("too many context attributes: %d > %d", len(specs), protos.MaxAttributes)
+	}
+	return nil
+}
+
+// Extract returns the context attributes of the packet's RADIUS attributes, attributes missing from the packet or
+// not matching their format are skipped
+func Extract(specs []Spec, p *radius.Packet) map[string]string {
+	if len(specs) == 0 || p == nil {
+		return nil
+	}
+	attrs := map[string]string{}
+	for _, s := range specs {
+		value, ok := lookup(s, p)
+		if !ok {
+			continue
+		}
+		if formatted, ok := format(s.Format, value); ok && protos.ValidateAttribute(s.Key, formatted) == nil {
+			attrs[s.Key] = formatted
+		}
+	}
+	return attrs
+}
+
+func lookup(s Spec, p *radius.Packet) (radius.Attribute, bool) {
+	if s.Type != int(rfc2865.VendorSpecific_Type) {
+		return p.Lookup(radius.Type(s.Type))
+	}
+	for _, vsa := range p.Attributes[rfc2865.VendorSpecific_Type] {
+		vendorID, value, err := radius.VendorSpecific(vsa)
+		if err != nil || vendorID != s.VendorID {
+			continue
+		}
+		// The value holds one or more vendor type, length, data TLVs
+		for len(value) >= 2 {
+			length := int(value[1])
+			if length < 2 || length > len(value) {
+				break
+			}
+			if int(value[0]) == s.VendorType {
+				return value[2:length], true
+			}
+			value = value[length:]
+		}
+	}
+	return nil, false
+}
+
+func format(f string, value radius.Attribute) (string, bool) {
+	switch f {
+	case FormatInteger:
+		i, err := radius.Integer(value)
+		if err != nil {
+			return "", false
+		}
+		return strconv.FormatUint(uint64(i), 10), true
+	case FormatIP:
+		ip, err := radius.IPAddr(value)
+		if err != nil {
+			return "", false
+		}
+		return ip.String(), true
+	case FormatHex:
+		return hex.EncodeToString(value), true
+	default:
+		return radius.String(value), true
+	}
+}
@@ -0,0 +1,59 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package ctxattr
+
+import (
+	"net"
+	"testing"
+
+	"fbc/lib/go/radius"
+	"fbc/lib/go/radius/rfc2865"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtract(t *testing.T) {
+	// Arrange
+	specs := []Spec{
+		{Key: "nas_port_type", Type: 61, Format: FormatInteger},
+		{Key: "nas_ip", Type: int(rfc2865.NASIPAddress_Type), Format: FormatIP},
+		{Key: "nas_id", Type: int(rfc2865.NASIdentifier_Type)},
+		{Key: "location", Type: int(rfc2865.VendorSpecific_Type), VendorID: 14122, VendorType: 2},
+		{Key: "class", Type: int(rfc2865.Class_Type), Format: FormatHex},
+		{Key: "missing", Type: int(rfc2865.FilterID_Type)},
+	}
+	require.NoError(t, Validate(specs))
+	packet := radius.New(radius.CodeAccountingRequest, []byte{})
+	packet.Add(61, radius.NewInteger(19))
+	rfc2865.NASIPAddress_Set(packet, net.ParseIP("10.0.0.1"))
+	rfc2865.NASIdentifier_SetString(packet, "ap1")
+	rfc2865.Class_Set(packet, []byte{0xca, 0xfe})
+	vsa, err := radius.NewVendorSpecific(14122, radius.Attribute(append([]byte{2, 7}, "venue"...)))
+	require.NoError(t, err)
+	packet.Add(rfc2865.VendorSpecific_Type, vsa)
+
+	// Act
+	attrs := Extract(specs, packet)
+
+	// Assert
+	require.Equal(t, map[string]string{
+		"nas_port_type": "19",
+		"nas_ip":        "10.0.0.1",
+		"nas_id":        "ap1",
+		"location":      "venue",
+		"class":         "cafe",
+	}, attrs)
+}
+
+func TestValidate(t *testing.T) {
+	require.Error(t, Validate([]Spec{{Key: "", Type: 1}}))
+	require.Error(t, Validate([]Spec{{Key: "k", Type: 0}}))
+	require.Error(t, Validate([]Spec{{Key: "k", Type: int(rfc2865.VendorSpecific_Type)}}))
+	require.Error(t, Validate([]Spec{{Key: "k", Type: 1, Format: "float"}}))
+}
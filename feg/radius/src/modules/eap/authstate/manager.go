@@ -28,3 +28,10 @@ type Manager interface {
 	Get(authReq *radius.Packet, eaptype packet.EAPType) (*Container, error)
 	Reset(authReq *radius.Packet, eapType packet.EAPType) error
 }
+
+// Exporter a manager which can export & import all of its stored states, e.g. for handing in-flight EAP
+// conversations off to another server process
+type Exporter interface {
+	Export() map[string]Container
+	Import(states map[string]Container)
+}
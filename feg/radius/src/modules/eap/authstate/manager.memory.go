@@ -14,11 +14,20 @@ import (
 	"fbc/cwf/radius/monitoring/counters"
 	"fmt"
 	"sync"
+	"sync/atomic"
 
 	"fbc/lib/go/radius"
 	"fbc/lib/go/radius/rfc2865"
 )
 
+// activeConversations number of EAP auth states stored by all memory managers
+var activeConversations int64
+
+// ActiveConversations returns the number of EAP conversations (auth states) stored in memory
+func ActiveConversations() int64 {
+	return atomic.LoadInt64(&activeConversations)
+}
+
 // Manager an interface for EAP state management storage
 type memoryManager struct {
 	getOpCounter   counters.Operation
@@ -34,7 +43,12 @@ type memoryManager struct {
 // sent for each EAP packet
 func (m *memoryManager) Set(authReq *radius.Packet, eaptype packet.EAPType, state Container) error {
 	m.setOpCounter.Start()
-	m.storage.Store(getKey(authReq), state)
+	key := getKey(authReq)
+	if _, loaded := m.storage.LoadOrStore(key, state); loaded {
+		m.storage.Store(key, state)
+	} else {
+		atomic.AddInt64(&activeConversations, 1)
+	}
 	m.setOpCounter.Success()
 	return nil
 }
@@ -61,11 +75,38 @@ func (m *memoryManager) Get(authReq *radius.Packet, eaptype packet.EAPType) (*Co
 // Reset resets the value stored in auth state manager for the given auth request and eap packet type
 func (m *memoryManager) Reset(authReq *radius.Packet, eapType packet.EAPType) error {
 	m.resetOpCounter.Start()
-	m.storage.Delete(getKey(authReq))
+	key := getKey(authReq)
+	if _, ok := m.storage.Load(key); ok {
+		m.storage.Delete(key)
+		atomic.AddInt64(&activeConversations, -1)
+	}
 	m.resetOpCounter.Success()
 	return nil
 }
 
+// Export returns all stored states by their storage keys
+func (m *memoryManager) Export() map[string]Container {
+	res := map[string]Container{}
+	m.storage.Range(func(key, value interface{}) bool {
+		if state, ok := value.(Container); ok {
+			res[key.(string)] = state
+		}
+		return true
+	})
+	return res
+}
+
+// Import stores the states exported by another manager, overriding stored states with the same keys
+func (m *memoryManager) Import(states map[string]Container) {
+	for key, state := range states {
+		if _, loaded := m.storage.LoadOrStore(key, state); loaded {
+			m.storage.Store(key, state)
+		} else {
+			atomic.AddInt64(&activeConversations, 1)
+		}
+	}
+}
+
 // getKey Composes a storage key to store and access the EAP state under
 // at this point, a state is stored at device level (that is a unique device
 // may only engage in one auth flow at any given moment through this system)
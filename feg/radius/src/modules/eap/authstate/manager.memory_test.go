@@ -102,3 +102,25 @@ func createRadiusPacket(called string, calling string) radius.Packet {
 		},
 	}
 }
+
+func TestExportImport(t *testing.T) {
+	// Arrange
+	source := NewMemoryManager()
+	target := NewMemoryManager()
+	authReq := createRadiusPacket("called", "calling")
+	err := source.Set(&authReq, packet.EAPTypeAKA, Container{
+		LogCorrelationID: 17,
+		EapType:          packet.EAPTypeAKA,
+		ProtocolState:    "challenge",
+	})
+	require.Nil(t, err)
+
+	// Act
+	target.(Exporter).Import(source.(Exporter).Export())
+	state, err := target.Get(&authReq, packet.EAPTypeAKA)
+
+	// Assert
+	require.Nil(t, err)
+	assert.Equal(t, uint64(17), state.LogCorrelationID)
+	assert.Equal(t, "challenge", state.ProtocolState)
+}
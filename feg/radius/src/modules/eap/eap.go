@@ -9,6 +9,7 @@ LICENSE file in the root directory of this source tree.
 package eap
 
 import (
+	"encoding/json"
 	"errors"
 	"fbc/cwf/radius/modules"
 	"fbc/cwf/radius/modules/eap/authstate"
@@ -69,6 +70,29 @@ func Init(logger *zap.Logger, config modules.ModuleConfig) (modules.Context, err
 	return mCtx, nil
 }
 
+// ExportState exports the module's in-flight EAP conversations, for handing them off to the next server process
+func (m ModuleCtx) ExportState() ([]byte, error) {
+	exporter, ok := m.stateManager.(authstate.Exporter)
+	if !ok {
+		return nil, nil
+	}
+	return json.Marshal(exporter.Export())
+}
+
+// ImportState imports the in-flight EAP conversations exported by the previous server process
+func (m ModuleCtx) ImportState(state []byte) error {
+	importer, ok := m.stateManager.(authstate.Exporter)
+	if !ok || len(state) == 0 {
+		return nil
+	}
+	var states map[string]authstate.Container
+	if err := json.Unmarshal(state, &states); err != nil {
+		return err
+	}
+	importer.Import(states)
+	return nil
+}
+
 // GetMethod factory method, instatiates and initializes an EAP method
 func getMethod(method Method) (methods.EapMethod, error) {
 	switch method.Name {
@@ -0,0 +1,54 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package identity
+
+import "strings"
+
+// anonymousUser the conventional user part of anonymous identities (RFC 7542)
+const anonymousUser = "anonymous"
+
+// Split splits an NAI (user@realm) into its user and realm parts, realm is
+// empty if the identity has no realm
+func Split(identity string) (user string, realm string) {
+	idx := strings.LastIndex(identity, "@")
+	if idx < 0 {
+		return identity, ""
+	}
+	return identity[:idx], identity[idx+1:]
+}
+
+// Realm returns the realm part of the identity, empty if there's none
+func Realm(identity string) string {
+	_, realm := Split(identity)
+	return realm
+}
+
+// IsAnonymous returns true for identities which don't identify the
+// subscriber (e.g. "anonymous@realm" or "@realm"), such identities are
+// only good for realm routing
+func IsAnonymous(identity string) bool {
+	user, _ := Split(identity)
+	return user == "" || strings.EqualFold(user, anonymousUser)
+}
+
+// Redact returns the identity with its user part masked, so it can be logged
+// without exposing the subscriber
+func Redact(identity string) string {
+	if identity == "" {
+		return ""
+	}
+	user, realm := Split(identity)
+	if strings.EqualFold(user, anonymousUser) {
+		return identity
+	}
+	if realm == "" {
+		return "***"
+	}
+	return "***@" + realm
+}
@@ -0,0 +1,36 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package identity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdentity(t *testing.T) {
+	// Act
+	user, realm := Split("0001010000000001@wlan.mnc001.mcc001.3gppnetwork.org")
+
+	// Assert
+	require.Equal(t, "0001010000000001", user)
+	require.Equal(t, "wlan.mnc001.mcc001.3gppnetwork.org", realm)
+	require.Equal(t, "", Realm("0001010000000001"))
+	require.Equal(t, "realm", Realm("a@b@realm"))
+
+	require.True(t, IsAnonymous("anonymous@realm"))
+	require.True(t, IsAnonymous("Anonymous@realm"))
+	require.True(t, IsAnonymous("@realm"))
+	require.False(t, IsAnonymous("0001010000000001@realm"))
+
+	require.Equal(t, "***@realm", Redact("0001010000000001@realm"))
+	require.Equal(t, "***", Redact("0001010000000001"))
+	require.Equal(t, "anonymous@realm", Redact("anonymous@realm"))
+	require.Equal(t, "", Redact(""))
+}
@@ -14,6 +14,7 @@ import (
 	"errors"
 
 	"fbc/cwf/radius/modules"
+	"fbc/cwf/radius/modules/eap/identity"
 	"fbc/cwf/radius/modules/eap/methods"
 	"fbc/cwf/radius/modules/eap/methods/common"
 	"fbc/cwf/radius/modules/eap/packet"
@@ -27,6 +28,15 @@ import (
 	"google.golang.org/grpc"
 )
 
+const (
+	// sessionTimeoutAttribute - AAA context attribute of the session's Session-Timeout in seconds
+	sessionTimeoutAttribute = "session_timeout"
+	// bandwidthUpAttribute & bandwidthDownAttribute - AAA context attributes of the session's maximum bandwidth in
+	// bits per second (e.g. of a scheduled bandwidth profile)
+	bandwidthUpAttribute   = "max_bandwidth_up"
+	bandwidthDownAttribute = "max_bandwidth_down"
+)
+
 // EapAkaMagmaMethod Implementation ofthe EAP-AKA method impl with Magma binding
 type EapAkaMagmaMethod struct {
 	config    Config
@@ -35,7 +45,13 @@ type EapAkaMagmaMethod struct {
 
 // Config the aka-magma configuration
 type Config struct {
+	// FegEndpoint AAA server address: host:port, or unix:///path of its unix domain socket
 	FegEndpoint string
+
+	// ProtectIdentity when set, the subscriber identity is never logged and
+	// if the peer used an anonymous outer identity, it (rather than the real
+	// identity derived by the inner method) is returned in the User-Name
+	ProtectIdentity bool
 }
 
 // Create ...
@@ -95,10 +111,10 @@ func (m EapAkaMagmaMethod) Handle(
 			SessionId: sessionID,
 			MacAddr:   clientMac,
 		}
-		eapLogger.Debug("EAP state not found, created a new state", zap.Any("state", eapContext))
+		eapLogger.Debug("EAP state not found, created a new state", m.stateField(&eapContext))
 	} else {
 		eapContext.SessionId = sessionID // Always get the session id from RADIUS
-		eapLogger.Debug("EAP state unmarshaled successfully", zap.Any("state", eapContext))
+		eapLogger.Debug("EAP state unmarshaled successfully", m.stateField(&eapContext))
 		UnmarshalProtocolState.Success()
 
 		// Verify & warn if MAC address was already set on session but now changed
@@ -118,6 +134,9 @@ func (m EapAkaMagmaMethod) Handle(
 
 	var eapResponse *aaa.Eap
 	if eapPacket.EAPType == packet.EAPTypeIDENTITY {
+		// The outer identity may be anonymous, it is kept for realm routing
+		// only, the real identity is derived by the AKA method itself
+		eapContext.OuterIdentity = string(eapPacket.Data)
 		c.Logger.Debug("Handling EAP-Identity request")
 		eapResponse, err = m.akaClient.HandleIdentity(
 			context.Background(),
@@ -137,6 +156,14 @@ func (m EapAkaMagmaMethod) Handle(
 			},
 		)
 	}
+	if capacityErr := aaa.GetCapacityError(err); capacityErr != nil {
+		eapLogger.Warn(
+			"Session rejected, APN is at its capacity",
+			zap.String("apn", capacityErr.GetApn()),
+			zap.Uint32("max_sessions", capacityErr.GetMaxSessions()),
+		)
+		return capacityReject(eapPacket, state)
+	}
 	if err != nil {
 		eapLogger.Error("Failed handling EAP message", zap.Error(err))
 		return nil, err
@@ -151,7 +178,7 @@ func (m EapAkaMagmaMethod) Handle(
 		MarshalProtocolState.Failure(err.Error())
 		newProtocolState = []byte("{}")
 	} else {
-		eapLogger.Debug("EAP state marshaled successfully", zap.Any("state", postHandlerContext))
+		eapLogger.Debug("EAP state marshaled successfully", m.stateField(postHandlerContext))
 		MarshalProtocolState.Success()
 	}
 
@@ -182,11 +209,88 @@ func (m EapAkaMagmaMethod) Handle(
 		}
 		result.ExtraAttributes[rfc2865.VendorSpecific_Type] = keyingMaterialAttrs
 
+		// Add WISPr-Bandwidth-Max-Up/Down of sessions with a maximum bandwidth
+		up, _ := postHandlerContext.GetUintAttribute(bandwidthUpAttribute)
+		down, _ := postHandlerContext.GetUintAttribute(bandwidthDownAttribute)
+		bandwidthAttrs, err := common.GetBandwidthAttributes(uint32(up), uint32(down))
+		if err != nil {
+			return nil, err
+		}
+		result.ExtraAttributes[rfc2865.VendorSpecific_Type] =
+			append(result.ExtraAttributes[rfc2865.VendorSpecific_Type], bandwidthAttrs...)
+
 		// Add User-Name attribute, which is mandatory
 		result.ExtraAttributes[rfc2865.UserName_Type] =
 			[]radius.Attribute{
-				radius.Attribute([]byte(postHandlerContext.Identity)),
+				radius.Attribute([]byte(m.userName(postHandlerContext))),
 			}
+
+		// Add Class, echoed by the NAS in the accounting of the attachment's roams & AP handoffs
+		if sid := postHandlerContext.GetSessionId(); len(sid) > 0 {
+			result.ExtraAttributes[rfc2865.Class_Type] =
+				[]radius.Attribute{radius.Attribute(aaa.CorrelationClass(sid))}
+		}
+
+		// Add Session-Timeout of sessions with a maximum duration (e.g. guest sessions)
+		if tout, ok := postHandlerContext.GetUintAttribute(sessionTimeoutAttribute); ok && tout > 0 {
+			result.ExtraAttributes[rfc2865.SessionTimeout_Type] =
+				[]radius.Attribute{radius.NewInteger(uint32(tout))}
+		}
 	}
 	return result, nil
 }
+
+// userName returns the User-Name to report on Access-Accept, with identity
+// protection an anonymous outer identity is never replaced by the real one
+func (m EapAkaMagmaMethod) userName(ctx *aaa.Context) string {
+	outer := ctx.GetOuterIdentity()
+	if m.config.ProtectIdentity && outer != "" && identity.IsAnonymous(outer) {
+		return outer
+	}
+	return ctx.GetIdentity()
+}
+
+// stateField returns the EAP state as a log field, the MSK is never logged
+// and subscriber identifiers are redacted when identity protection is on
+func (m EapAkaMagmaMethod) stateField(ctx *aaa.Context) zap.Field {
+	state := aaa.Context{
+		SessionId:     ctx.GetSessionId(),
+		Identity:      ctx.GetIdentity(),
+		Imsi:          ctx.GetImsi(),
+		MacAddr:       ctx.GetMacAddr(),
+		IpAddr:        ctx.GetIpAddr(),
+		Ipv6Addr:      ctx.GetIpv6Addr(),
+		Ipv6Prefix:    ctx.GetIpv6Prefix(),
+		Msisdn:        ctx.GetMsisdn(),
+		Apn:           ctx.GetApn(),
+		OuterIdentity: ctx.GetOuterIdentity(),
+	}
+	if m.config.ProtectIdentity {
+		state.Identity = identity.Redact(state.Identity)
+		state.OuterIdentity = identity.Redact(state.OuterIdentity)
+		if state.Imsi != "" {
+			state.Imsi = "***"
+		}
+		if state.Msisdn != "" {
+			state.Msisdn = "***"
+		}
+	}
+	return zap.Any("state", state)
+}
+
+// capacityReject returns the EAP-Failure & Access-Reject of a session rejected
+// by its APN's concurrent sessions limit
+func capacityReject(p *packet.Packet, state string) (*methods.HandlerResponse, error) {
+	failure, err := packet.NewPacket(packet.CodeFAILURE, packet.EAPTypeNONE, p.Identifier, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &methods.HandlerResponse{
+		Packet:           failure,
+		RadiusCode:       radius.CodeAccessReject,
+		NewProtocolState: state,
+		ExtraAttributes: radius.Attributes{
+			rfc2865.ReplyMessage_Type: []radius.Attribute{radius.Attribute("Network is at capacity")},
+		},
+	}, nil
+}
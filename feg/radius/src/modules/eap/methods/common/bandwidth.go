@@ -0,0 +1,43 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package common
+
+import (
+	"fbc/lib/go/radius"
+)
+
+const (
+	// WISPrVendorID the IANA enterprise number of the Wi-Fi Alliance (WISPr)
+	WISPrVendorID = 14122
+	// WISPrBandwidthMaxUpType & WISPrBandwidthMaxDownType WISPr-Bandwidth-Max-Up & WISPr-Bandwidth-Max-Down vendor
+	// attribute types (bits per second)
+	WISPrBandwidthMaxUpType   = 7
+	WISPrBandwidthMaxDownType = 8
+)
+
+// GetBandwidthAttributes Generates WISPr-Bandwidth-Max-Up/Down Vendor-Specific
+// attributes of the non zero bandwidths
+func GetBandwidthAttributes(up, down uint32) ([]radius.Attribute, error) {
+	var attrs []radius.Attribute
+	for _, bw := range []struct {
+		typ   byte
+		value uint32
+	}{{WISPrBandwidthMaxUpType, up}, {WISPrBandwidthMaxDownType, down}} {
+		if bw.value == 0 {
+			continue
+		}
+		value := append([]byte{bw.typ, 6}, radius.NewInteger(bw.value)...)
+		vsa, err := radius.NewVendorSpecific(WISPrVendorID, radius.Attribute(value))
+		if err != nil {
+			return nil, err
+		}
+		attrs = append(attrs, vsa)
+	}
+	return attrs, nil
+}
@@ -0,0 +1,31 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBandwidthAttributes(t *testing.T) {
+	attrs, err := GetBandwidthAttributes(1000000, 0)
+	require.NoError(t, err)
+	require.Len(t, attrs, 1)
+	require.Equal(t, []byte{0x00, 0x00, 0x37, 0x2a, 7, 6, 0x00, 0x0f, 0x42, 0x40}, []byte(attrs[0]))
+
+	attrs, err = GetBandwidthAttributes(1000000, 5000000)
+	require.NoError(t, err)
+	require.Len(t, attrs, 2)
+	require.Equal(t, []byte{0x00, 0x00, 0x37, 0x2a, 8, 6, 0x00, 0x4c, 0x4b, 0x40}, []byte(attrs[1]))
+
+	attrs, err = GetBandwidthAttributes(0, 0)
+	require.NoError(t, err)
+	require.Empty(t, attrs)
+}
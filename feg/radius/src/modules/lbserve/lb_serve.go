@@ -13,8 +13,10 @@ import (
 	"errors"
 
 	"fbc/cwf/radius/modules"
+	"fbc/cwf/radius/modules/radsec"
 	"fbc/lib/go/radius"
 
+	"github.com/mitchellh/mapstructure"
 	"go.uber.org/zap"
 )
 
@@ -22,9 +24,28 @@ const errMissingRequiredUpstreamHostText = "session state required field upstrea
 
 var errMissingRequiredUpstreamHost = errors.New(errMissingRequiredUpstreamHostText)
 
+// Config configuration structure for LB serve module
+type Config struct {
+	// RadSec serves requests by the upstream hosts over RadSec (RADIUS over TLS) instead of UDP if set
+	RadSec *radsec.ClientConfig
+}
+
+// ModuleCtx ...
+type ModuleCtx struct {
+	client radsec.Exchanger
+}
+
 // Init module interface implementation
 func Init(logger *zap.Logger, config modules.ModuleConfig) (modules.Context, error) {
-	return nil, nil
+	var lbConfig Config
+	if err := mapstructure.Decode(config, &lbConfig); err != nil {
+		return nil, err
+	}
+	client, err := radsec.NewExchanger(lbConfig.RadSec, logger)
+	if err != nil {
+		return nil, err
+	}
+	return ModuleCtx{client: client}, nil
 }
 
 // Handle module interface implementation
@@ -43,7 +64,7 @@ func Handle(m modules.Context, c *modules.RequestContext, r *radius.Request, _ m
 		return nil, errMissingRequiredUpstreamHost
 	}
 
-	res, err := radius.Exchange(context.Background(), r.Packet, state.UpstreamHost)
+	res, err := m.(ModuleCtx).client.Exchange(context.Background(), r.Packet, state.UpstreamHost)
 	if err != nil {
 		c.Logger.Error("LB Serve received failed response", zap.Error(err))
 		return nil, err
@@ -12,28 +12,56 @@ import (
 	"context"
 	"encoding/binary"
 	"errors"
+	"fbc/cwf/radius/certmanager"
+	"fbc/cwf/radius/modules/ctxattr"
 	"fbc/cwf/radius/modules/protos"
+	"fbc/cwf/radius/quirks"
 	"fbc/cwf/radius/session"
 	"fmt"
+	"net"
 	"strings"
+	"time"
 
 	"fbc/cwf/radius/modules"
 	"fbc/lib/go/radius"
+	"fbc/lib/go/radius/rfc2865"
 	"fbc/lib/go/radius/rfc2866"
+	"fbc/lib/go/radius/rfc2869"
+	"fbc/lib/go/retry"
 
 	"github.com/mitchellh/mapstructure"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// unixScheme address prefix of unix domain socket endpoints
+const unixScheme = "unix://"
+
+// operatorNameType - RFC 5580 Operator-Name attribute type, the dictionaries have no RFC 5580 package
+const operatorNameType radius.Type = 126
+
+// IPv6 attribute types of the subscribers' addresses, the dictionaries have no RFC 4818 & RFC 6911 packages
+const (
+	// delegatedIPv6PrefixType - RFC 4818 Delegated-IPv6-Prefix attribute type
+	delegatedIPv6PrefixType radius.Type = 123
+	// framedIPv6AddressType - RFC 6911 Framed-IPv6-Address attribute type
+	framedIPv6AddressType radius.Type = 168
 )
 
 // Config configuration structure for proxy module
 type Config struct {
-	FegEndpoint string
+	FegEndpoint string              // AAA server address: host:port, or unix:///path of its unix domain socket
+	TLS         *certmanager.Config // Optional, connect over (m)TLS with hitless certificate rotation
+	Attributes  []ctxattr.Spec      // Optional, NAS attributes to propagate as AAA context attributes
+	Retry       *retry.Config       // Optional, retries of failed accounting calls
 }
 
 // ModuleCtx ...
 type ModuleCtx struct {
-	client protos.AccountingClient
+	client     protos.AccountingClient
+	attributes []ctxattr.Spec
+	retrier    *retry.Retrier
 }
 
 // Init module interface implementation
@@ -47,14 +75,43 @@ func Init(logger *zap.Logger, config modules.ModuleConfig) (modules.Context, err
 	if acctConfig.FegEndpoint == "" {
 		return nil, errors.New("magma acct module cannot be initialize with empty FegEndpoint value")
 	}
+	if err = ctxattr.Validate(acctConfig.Attributes); err != nil {
+		return nil, err
+	}
+	retrier := retry.NoRetry
+	if acctConfig.Retry != nil {
+		if retrier, err = retry.New(*acctConfig.Retry); err != nil {
+			return nil, err
+		}
+	}
 
 	// Initialize the client
-	conn, err := grpc.Dial(acctConfig.FegEndpoint, grpc.WithInsecure())
+	dialOpt := grpc.WithInsecure()
+	if acctConfig.TLS != nil {
+		if strings.HasPrefix(acctConfig.FegEndpoint, unixScheme) {
+			return nil, errors.New("magma acct module TLS is not supported over unix domain sockets")
+		}
+		certs, err := certmanager.New(*acctConfig.TLS, logger)
+		if err != nil {
+			return nil, err
+		}
+		certs.Start()
+		host, _, err := net.SplitHostPort(acctConfig.FegEndpoint)
+		if err != nil {
+			return nil, err
+		}
+		dialOpt = grpc.WithTransportCredentials(credentials.NewTLS(certs.ClientTLSConfig(host)))
+	}
+	conn, err := grpc.Dial(acctConfig.FegEndpoint, dialOpt)
 	if err != nil {
 		return nil, err
 	}
 
-	return ModuleCtx{client: protos.NewAccountingClient(conn)}, nil
+	return ModuleCtx{
+		client:     protos.NewAccountingClient(conn),
+		attributes: acctConfig.Attributes,
+		retrier:    retrier,
+	}, nil
 }
 
 // Handle module interface implementation
@@ -86,45 +143,151 @@ func Handle(m modules.Context, ctx *modules.RequestContext, r *radius.Request, _
 	}
 	acctType := rfc2866.AcctStatusType(binary.BigEndian.Uint32(acctTypeAttr))
 
-	// Restore Context
-	c := &protos.Context{
-		SessionId: ctx.SessionID,
-		Msisdn:    state.MSISDN,
-		MacAddr:   state.MACAddress,
-		IpAddr:    strings.Split(r.RemoteAddr.String(), ":")[0],
+	// Restore Context, the request messages are pooled & must not be retained once the request is handled
+	req := acquireAcctRequest()
+	defer releaseAcctRequest(req)
+	c := &req.ctx
+	c.SessionId = ctx.SessionID
+	c.Msisdn = state.MSISDN
+	c.MacAddr = state.MACAddress
+	c.IpAddr = remoteIP(r.RemoteAddr)
+	c.Ipv6Addr = framedIPv6Address(r.Packet)
+	c.Ipv6Prefix = delegatedIPv6Prefix(r.Packet)
+	c.Attributes = ctxattr.Extract(mCtx.attributes, r.Packet)
+	// Attributes attached to the session by other modules, the NAS attributes of the request take precedence
+	for k, v := range state.Attributes {
+		if _, ok := c.Attributes[k]; !ok {
+			if err := c.SetAttribute(k, v); err != nil {
+				ctx.Logger.Warn("dropping session context attribute", zap.String("key", k), zap.Error(err))
+			}
+		}
+	}
+	// The AAA adjusts the accounting of NASes with interop quirks, e.g. late final Interim-Updates
+	if ctx.Quirks != 0 {
+		if err := c.SetAttribute(quirks.Attribute, ctx.Quirks.AttributeValue()); err != nil {
+			ctx.Logger.Warn("dropping NAS quirks context attribute", zap.Error(err))
+		}
+	}
+
+	// Sessions are flushed by the NAS-Identifier of the NAS's Accounting-On/Off
+	if nasID := rfc2865.NASIdentifier_GetString(r.Packet); len(nasID) > 0 {
+		if err := c.SetAttribute(protos.NASIdentifierAttribute, nasID); err != nil {
+			ctx.Logger.Warn("dropping NAS-Identifier context attribute", zap.Error(err))
+		}
+	}
+
+	// Sessions of the same multi-session are grouped into one logical accounting record
+	if multiSessionID := rfc2866.AcctMultiSessionID_GetString(r.Packet); len(multiSessionID) > 0 {
+		if err := c.SetAttribute(protos.MultiSessionIDAttribute, multiSessionID); err != nil {
+			ctx.Logger.Warn("dropping Acct-Multi-Session-Id context attribute", zap.Error(err))
+		}
+	}
+
+	// Accounting of the attachment's roams & AP handoffs is resolved to its session by the correlation ID
+	c.CorrelationId = correlationID(r.Packet)
+
+	// The session's CoA & Disconnect-Requests follow its NAS's address changes, e.g. controller failovers
+	if r.RemoteAddr != nil {
+		if err := c.SetAttribute(protos.NASAddressAttribute, r.RemoteAddr.String()); err != nil {
+			ctx.Logger.Warn("dropping NAS address context attribute", zap.Error(err))
+		}
+	}
+
+	// The usage of wholesale SSIDs' sessions is attributed to their serving operators
+	if operatorName := r.Get(operatorNameType); len(operatorName) > 0 {
+		if err := c.SetAttribute(protos.OperatorNameAttribute, string(operatorName)); err != nil {
+			ctx.Logger.Warn("dropping Operator-Name context attribute", zap.Error(err))
+		}
+	}
+
+	// Retransmitted Starts & Stops are recognized by their event time
+	now := time.Now()
+	if at, ok := eventTime(r, now); ok {
+		if err := c.SetUintAttribute(protos.EventTimeAttribute, uint64(at.Unix())); err != nil {
+			ctx.Logger.Warn("dropping event time context attribute", zap.Error(err))
+		}
+	}
+	// The AAA flags or rejects stale & replayed requests & meters the NASes' clock skews
+	if skew, ok := eventSkew(r, now); ok {
+		if err := c.SetIntAttribute(protos.EventSkewAttribute, int64(skew/time.Second)); err != nil {
+			ctx.Logger.Warn("dropping event skew context attribute", zap.Error(err))
+		}
 	}
 
 	// Call magma client
+	var acctResp *protos.AcctResp
 	switch acctType {
-	case rfc2866.AcctStatusType_Value_AccountingOn:
+	case rfc2866.AcctStatusType_Value_AccountingOn, rfc2866.AcctStatusType_Value_AccountingOff:
+		onOffRequest := &protos.AcctOnOffRequest{
+			Off:             acctType == rfc2866.AcctStatusType_Value_AccountingOff,
+			NasIdentifier:   rfc2865.NASIdentifier_GetString(r.Packet),
+			CalledStationId: rfc2865.CalledStationID_GetString(r.Packet),
+		}
+		err = mCtx.retrier.Do(context.Background(), func(ctx context.Context) error {
+			acctResp, err = mCtx.client.AcctOnOff(ctx, onOffRequest)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		ctx.Logger.Debug("MagmaAccounting.AcctOnOff succeeded", zap.Any("request", onOffRequest))
+		break
 	case rfc2866.AcctStatusType_Value_Start:
-		_, err = mCtx.client.Start(context.Background(), c)
+		err = mCtx.retrier.Do(context.Background(), func(ctx context.Context) error {
+			acctResp, err = mCtx.client.Start(ctx, c)
+			if protos.GetCapacityError(err) != nil {
+				return retry.Permanent(err) // retries don't free the APN's capacity
+			}
+			return err
+		})
+		if capacityErr := protos.GetCapacityError(err); capacityErr != nil {
+			// RADIUS accounting has no rejection, the Start is acknowledged so
+			// the NAS stops retransmitting it & the session is disconnected by AAA
+			ctx.Logger.Warn(
+				"MagmaAccounting.Start rejected, APN is at its capacity",
+				zap.String("apn", capacityErr.GetApn()),
+				zap.Uint32("max_sessions", capacityErr.GetMaxSessions()),
+			)
+			acctResp, err = nil, nil
+		}
 		if err != nil {
 			return nil, err
 		}
 		ctx.Logger.Debug("MagmaAccounting.Start succeeded", zap.Any("context", c))
 		break
-	case rfc2866.AcctStatusType_Value_AccountingOff:
 	case rfc2866.AcctStatusType_Value_Stop:
-		stopRequest := &protos.StopRequest{
-			Cause: protos.StopRequest_NAS_REQUEST,
-			Ctx:   c,
-		}
-		_, err = mCtx.client.Stop(context.Background(), stopRequest)
+		stopRequest := &req.stop
+		stopRequest.Cause = protos.StopRequest_NAS_REQUEST
+		stopRequest.OctetsIn = getValue(r, rfc2866.AcctInputOctets_Type)
+		stopRequest.OctetsOut = getValue(r, rfc2866.AcctOutputOctets_Type)
+		stopRequest.GigawordsIn = getValue(r, rfc2869.AcctInputGigawords_Type)
+		stopRequest.GigawordsOut = getValue(r, rfc2869.AcctOutputGigawords_Type)
+		stopRequest.PacketsIn = getValue(r, rfc2866.AcctInputPackets_Type)
+		stopRequest.PacketsOut = getValue(r, rfc2866.AcctOutputPackets_Type)
+		stopRequest.SessionTime = getValue(r, rfc2866.AcctSessionTime_Type)
+		stopRequest.Ctx = c
+		err = mCtx.retrier.Do(context.Background(), func(ctx context.Context) error {
+			acctResp, err = mCtx.client.Stop(ctx, stopRequest)
+			return err
+		})
 		if err != nil {
 			return nil, err
 		}
 		ctx.Logger.Debug("MagmaAccounting.Stop succeeded", zap.Any("context", c))
 		break
 	case rfc2866.AcctStatusType_Value_InterimUpdate:
-		updateRequest := &protos.UpdateRequest{
-			OctetsIn:   getValue(r, rfc2866.AcctInputOctets_Type),
-			OctetsOut:  getValue(r, rfc2866.AcctOutputOctets_Type),
-			PacketsIn:  getValue(r, rfc2866.AcctInputPackets_Type),
-			PacketsOut: getValue(r, rfc2866.AcctOutputPackets_Type),
-			Ctx:        c,
-		}
-		_, err = mCtx.client.InterimUpdate(context.Background(), updateRequest)
+		updateRequest := &req.update
+		updateRequest.OctetsIn = getValue(r, rfc2866.AcctInputOctets_Type)
+		updateRequest.OctetsOut = getValue(r, rfc2866.AcctOutputOctets_Type)
+		updateRequest.GigawordsIn = getValue(r, rfc2869.AcctInputGigawords_Type)
+		updateRequest.GigawordsOut = getValue(r, rfc2869.AcctOutputGigawords_Type)
+		updateRequest.PacketsIn = getValue(r, rfc2866.AcctInputPackets_Type)
+		updateRequest.PacketsOut = getValue(r, rfc2866.AcctOutputPackets_Type)
+		updateRequest.Ctx = c
+		err = mCtx.retrier.Do(context.Background(), func(ctx context.Context) error {
+			acctResp, err = mCtx.client.InterimUpdate(ctx, updateRequest)
+			return err
+		})
 		if err != nil {
 			return nil, err
 		}
@@ -134,12 +297,15 @@ func Handle(m modules.Context, ctx *modules.RequestContext, r *radius.Request, _
 		return nil, fmt.Errorf("unknown Acct-Status-Type received: %d", acctType)
 	}
 
-	// Build response
+	// Build response, including attributes requested by accounting
+	attrs, err := responseAttributes(acctResp)
+	if err != nil {
+		return nil, err
+	}
+	attrs[rfc2866.AcctSessionID_Type] = []radius.Attribute{radius.Attribute(c.SessionId)}
 	result := &modules.Response{
-		Code: radius.CodeAccountingResponse,
-		Attributes: radius.Attributes{
-			rfc2866.AcctSessionID_Type: []radius.Attribute{radius.Attribute(c.SessionId)},
-		},
+		Code:       radius.CodeAccountingResponse,
+		Attributes: attrs,
 	}
 
 	ctx.Logger.Debug(
@@ -150,6 +316,67 @@ func Handle(m modules.Context, ctx *modules.RequestContext, r *radius.Request, _
 	return result, nil
 }
 
+// responseAttributes returns Accounting-Response attributes of the accounting response
+func responseAttributes(resp *protos.AcctResp) (radius.Attributes, error) {
+	attrs := radius.Attributes{}
+	if interval := resp.GetAcctInterimInterval(); interval > 0 {
+		attrs.Add(rfc2869.AcctInterimInterval_Type, radius.NewInteger(interval))
+	}
+	for _, a := range resp.GetAttributes() {
+		if a.GetVendorId() == 0 {
+			if a.GetType() == 0 || a.GetType() > 0xFF || a.GetType() == uint32(rfc2865.VendorSpecific_Type) ||
+				len(a.GetValue()) > 0xFF-2 {
+				return nil, fmt.Errorf(
+					"invalid accounting response attribute type: %d, length: %d", a.GetType(), len(a.GetValue()))
+			}
+			attrs.Add(radius.Type(a.GetType()), radius.Attribute(a.GetValue()))
+			continue
+		}
+		if a.GetVendorType() > 0xFF || len(a.GetValue()) > 0xFF-2 {
+			return nil, fmt.Errorf("invalid accounting response vendor %d attribute type: %d, length: %d",
+				a.GetVendorId(), a.GetVendorType(), len(a.GetValue()))
+		}
+		value := append([]byte{byte(a.GetVendorType()), byte(len(a.GetValue()) + 2)}, a.GetValue()...)
+		vsa, err := radius.NewVendorSpecific(a.GetVendorId(), radius.Attribute(value))
+		if err != nil {
+			return nil, fmt.Errorf("failed encoding vendor %d attribute: %s", a.GetVendorId(), err.Error())
+		}
+		attrs.Add(rfc2865.VendorSpecific_Type, vsa)
+	}
+	return attrs, nil
+}
+
+// remoteIP returns the IP of the NAS address, without formatting the whole
+// address (port included) as strings.Split(addr.String(), ":") does
+func remoteIP(addr net.Addr) string {
+	if udpAddr, ok := addr.(*net.UDPAddr); ok {
+		return udpAddr.IP.String()
+	}
+	return strings.Split(addr.String(), ":")[0]
+}
+
+// framedIPv6Address returns the packet's Framed-IPv6-Address, empty if it has none or it's invalid
+func framedIPv6Address(p *radius.Packet) string {
+	ip, err := radius.IPv6Addr(p.Get(framedIPv6AddressType))
+	if err != nil {
+		return ""
+	}
+	return ip.String()
+}
+
+// delegatedIPv6Prefix returns the packet's Delegated-IPv6-Prefix in CIDR notation, empty if it has none or it's
+// invalid. The attribute is a reserved octet, the prefix length & the prefix's significant octets (RFC 4818)
+func delegatedIPv6Prefix(p *radius.Packet) string {
+	a := p.Get(delegatedIPv6PrefixType)
+	if len(a) < 2 || a[1] > 128 || len(a)-2 > net.IPv6len || len(a)-2 < (int(a[1])+7)/8 {
+		return ""
+	}
+	ip := make(net.IP, net.IPv6len)
+	copy(ip, a[2:])
+	prefix := net.IPNet{IP: ip.Mask(net.CIDRMask(int(a[1]), 128)), Mask: net.CIDRMask(int(a[1]), 128)}
+	return prefix.String()
+}
+
 func getValue(r *radius.Request, t radius.Type) uint32 {
 	valueAttr, exists := r.Lookup(t)
 	var value uint32
@@ -158,3 +385,40 @@ func getValue(r *radius.Request, t radius.Type) uint32 {
 	}
 	return value
 }
+
+// eventTime returns the accounting request's event time: its Event-Timestamp or its arrival time less its
+// Acct-Delay-Time, & false if the request has neither
+func eventTime(r *radius.Request, now time.Time) (time.Time, bool) {
+	if at, err := rfc2869.EventTimestamp_Lookup(r.Packet); err == nil {
+		return at, true
+	}
+	if delay, err := rfc2866.AcctDelayTime_Lookup(r.Packet); err == nil {
+		return now.Add(-time.Duration(delay) * time.Second), true
+	}
+	return time.Time{}, false
+}
+
+// eventSkew returns the accounting request's Event-Timestamp skew: its arrival time less its Acct-Delay-Time &
+// Event-Timestamp, & false if the request has no Event-Timestamp
+func eventSkew(r *radius.Request, now time.Time) (time.Duration, bool) {
+	at, err := rfc2869.EventTimestamp_Lookup(r.Packet)
+	if err != nil {
+		return 0, false
+	}
+	if delay, err := rfc2866.AcctDelayTime_Lookup(r.Packet); err == nil {
+		now = now.Add(-time.Duration(delay) * time.Second)
+	}
+	return now.Sub(at), true
+}
+
+// correlationID returns the correlation ID of the accounting request: the session ID of its correlation Class, echoed
+// from the session's Access-Accept, or its Acct-Multi-Session-Id, & "" if the request has neither
+func correlationID(p *radius.Packet) string {
+	classes, _ := rfc2865.Class_GetStrings(p)
+	for _, class := range classes {
+		if sid, ok := protos.ParseCorrelationClass(class); ok {
+			return sid
+		}
+	}
+	return rfc2866.AcctMultiSessionID_GetString(p)
+}
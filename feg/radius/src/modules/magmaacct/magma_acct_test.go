@@ -0,0 +1,481 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package magmaacct
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"runtime"
+	"testing"
+	"time"
+
+	"fbc/cwf/radius/modules"
+	"fbc/cwf/radius/modules/protos"
+	"fbc/cwf/radius/quirks"
+	"fbc/cwf/radius/session"
+	"fbc/lib/go/radius"
+	"fbc/lib/go/radius/rfc2865"
+	"fbc/lib/go/radius/rfc2866"
+	"fbc/lib/go/radius/rfc2869"
+	"fbc/lib/go/retry"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+func TestResponseAttributes(t *testing.T) {
+	// Act
+	attrs, err := responseAttributes(nil)
+
+	// Assert
+	require.NoError(t, err)
+	require.Empty(t, attrs)
+
+	// Arrange
+	resp := &protos.AcctResp{
+		AcctInterimInterval: 300,
+		Attributes: []*protos.AcctRespRadiusAttribute{
+			{Type: uint32(rfc2865.ReplyMessage_Type), Value: []byte("hello")},
+			{VendorId: 14122, VendorType: 2, Value: []byte("loc")},
+		},
+	}
+
+	// Act
+	attrs, err = responseAttributes(resp)
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, []radius.Attribute{radius.NewInteger(300)}, attrs[rfc2869.AcctInterimInterval_Type])
+	require.Equal(t, []radius.Attribute{radius.Attribute("hello")}, attrs[rfc2865.ReplyMessage_Type])
+	require.Equal(t, []radius.Attribute{{0, 0, 0x37, 0x2a, 2, 5, 'l', 'o', 'c'}}, attrs[rfc2865.VendorSpecific_Type])
+
+	// Act & Assert
+	for _, a := range []*protos.AcctRespRadiusAttribute{
+		{Type: 0, Value: []byte("x")},
+		{Type: 256, Value: []byte("x")},
+		{Type: uint32(rfc2865.VendorSpecific_Type), Value: []byte("x")},
+		{Type: uint32(rfc2865.ReplyMessage_Type), Value: bytes.Repeat([]byte("x"), 254)},
+		{VendorId: 14122, VendorType: 256, Value: []byte("x")},
+		{VendorId: 14122, VendorType: 2, Value: bytes.Repeat([]byte("x"), 250)},
+	} {
+		_, err = responseAttributes(&protos.AcctResp{Attributes: []*protos.AcctRespRadiusAttribute{a}})
+		require.Error(t, err)
+	}
+}
+
+// benchAccountingClient an accounting client which marshals the requests, as
+// the RPC call does, but doesn't send them anywhere
+type benchAccountingClient struct {
+	protos.AccountingClient
+	resp *protos.AcctResp
+}
+
+func (c benchAccountingClient) InterimUpdate(
+	_ context.Context, in *protos.UpdateRequest, _ ...grpc.CallOption,
+) (*protos.AcctResp, error) {
+	if _, err := in.XXX_Marshal(nil, false); err != nil {
+		return nil, err
+	}
+	return c.resp, nil
+}
+
+func newInterimUpdateBench(b *testing.B) (modules.Context, *modules.RequestContext, *radius.Request) {
+	mCtx := ModuleCtx{
+		client:  benchAccountingClient{resp: &protos.AcctResp{AcctInterimInterval: 300}},
+		retrier: retry.NoRetry,
+	}
+	storage := session.NewSessionStorage(session.NewMultiSessionMemoryStorage(), "sessionID")
+	require.NoError(b, storage.Set(session.State{MSISDN: "123456789", MACAddress: "01-23-45-AB-CD-EF"}))
+	reqCtx := &modules.RequestContext{Logger: zap.NewNop(), SessionID: "sessionID", SessionStorage: storage}
+
+	packet := radius.New(radius.CodeAccountingRequest, []byte("123456"))
+	require.NoError(b, rfc2866.AcctStatusType_Set(packet, rfc2866.AcctStatusType_Value_InterimUpdate))
+	require.NoError(b, rfc2866.AcctSessionID_SetString(packet, "sessionID"))
+	require.NoError(b, rfc2866.AcctInputOctets_Set(packet, 1000))
+	require.NoError(b, rfc2866.AcctOutputOctets_Set(packet, 100000))
+	require.NoError(b, rfc2866.AcctInputPackets_Set(packet, 10))
+	require.NoError(b, rfc2866.AcctOutputPackets_Set(packet, 100))
+	r := &radius.Request{RemoteAddr: &net.UDPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 1813}, Packet: packet}
+	return mCtx, reqCtx, r
+}
+
+func BenchmarkHandleInterimUpdate(b *testing.B) {
+	// Arrange
+	mCtx, reqCtx, r := newInterimUpdateBench(b)
+
+	// Act
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := Handle(mCtx, reqCtx, r, nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkHandleInterimUpdateRate handles Interim-Updates at the rate of a
+// loaded server (20k/s) & reports the resulting GC cycles per second
+func BenchmarkHandleInterimUpdateRate(b *testing.B) {
+	const rate = 20000
+
+	// Arrange
+	mCtx, reqCtx, r := newInterimUpdateBench(b)
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	ticker := time.NewTicker(time.Second / rate)
+	defer ticker.Stop()
+
+	// Act
+	b.ReportAllocs()
+	b.ResetTimer()
+	start := time.Now()
+	for i := 0; i < b.N; i++ {
+		<-ticker.C
+		if _, err := Handle(mCtx, reqCtx, r, nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+	elapsed := time.Since(start)
+	b.StopTimer()
+
+	// Report
+	runtime.ReadMemStats(&after)
+	b.ReportMetric(float64(after.NumGC-before.NumGC)/elapsed.Seconds(), "gc/s")
+	b.ReportMetric(float64(after.PauseTotalNs-before.PauseTotalNs)/elapsed.Seconds(), "gc-pause-ns/s")
+}
+
+// capacityAccountingClient rejects all Starts with a capacity error
+type capacityAccountingClient struct {
+	protos.AccountingClient
+	starts *int
+}
+
+func (c capacityAccountingClient) Start(
+	_ context.Context, _ *protos.Context, _ ...grpc.CallOption,
+) (*protos.AcctResp, error) {
+	*c.starts++
+	return nil, protos.NewCapacityError("venue.ssid", 50)
+}
+
+func TestHandleStartAtCapacity(t *testing.T) {
+	// Arrange
+	var starts int
+	retrier, err := retry.New(retry.Config{MaxAttempts: 3, RetryableCodes: []string{"RESOURCE_EXHAUSTED"}})
+	require.NoError(t, err)
+	mCtx := ModuleCtx{client: capacityAccountingClient{starts: &starts}, retrier: retrier}
+	storage := session.NewSessionStorage(session.NewMultiSessionMemoryStorage(), "sessionID")
+	reqCtx := &modules.RequestContext{Logger: zap.NewNop(), SessionID: "sessionID", SessionStorage: storage}
+	packet := radius.New(radius.CodeAccountingRequest, []byte("123456"))
+	require.NoError(t, rfc2866.AcctStatusType_Set(packet, rfc2866.AcctStatusType_Value_Start))
+	require.NoError(t, rfc2866.AcctSessionID_SetString(packet, "sessionID"))
+	r := &radius.Request{RemoteAddr: &net.UDPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 1813}, Packet: packet}
+
+	// Act
+	res, err := Handle(mCtx, reqCtx, r, nil)
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, radius.CodeAccountingResponse, res.Code)
+	require.Equal(t, 1, starts)
+}
+
+// updateRecorder an accounting client keeping the contexts of the Interim-Updates
+type updateRecorder struct {
+	protos.AccountingClient
+	attrs *map[string]string
+}
+
+func (c updateRecorder) InterimUpdate(
+	_ context.Context, in *protos.UpdateRequest, _ ...grpc.CallOption,
+) (*protos.AcctResp, error) {
+	*c.attrs = in.GetCtx().GetAttributes()
+	return &protos.AcctResp{}, nil
+}
+
+func TestHandleInterimUpdateQuirks(t *testing.T) {
+	// Arrange
+	var attrs map[string]string
+	mCtx := ModuleCtx{client: updateRecorder{attrs: &attrs}, retrier: retry.NoRetry}
+	storage := session.NewSessionStorage(session.NewMultiSessionMemoryStorage(), "sessionID")
+	reqCtx := &modules.RequestContext{Logger: zap.NewNop(), SessionID: "sessionID", SessionStorage: storage}
+	packet := radius.New(radius.CodeAccountingRequest, []byte("123456"))
+	require.NoError(t, rfc2866.AcctStatusType_Set(packet, rfc2866.AcctStatusType_Value_InterimUpdate))
+	r := &radius.Request{RemoteAddr: &net.UDPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 1813}, Packet: packet}
+
+	// Act & Assert: NASes without quirks don't pass any
+	_, err := Handle(mCtx, reqCtx, r, nil)
+	require.NoError(t, err)
+	require.NotContains(t, attrs, quirks.Attribute)
+
+	// Act & Assert: the AAA acknowledges late final Interim-Updates of quirky NASes
+	reqCtx.Quirks = quirks.StopBeforeFinalInterim | quirks.NoAcctSessionIDOnInterim
+	_, err = Handle(mCtx, reqCtx, r, nil)
+	require.NoError(t, err)
+	require.Equal(t, "5", attrs[quirks.Attribute])
+}
+
+func TestHandleInterimUpdateMultiSession(t *testing.T) {
+	// Arrange
+	var attrs map[string]string
+	mCtx := ModuleCtx{client: updateRecorder{attrs: &attrs}, retrier: retry.NoRetry}
+	storage := session.NewSessionStorage(session.NewMultiSessionMemoryStorage(), "sessionID")
+	reqCtx := &modules.RequestContext{Logger: zap.NewNop(), SessionID: "sessionID", SessionStorage: storage}
+	packet := radius.New(radius.CodeAccountingRequest, []byte("123456"))
+	require.NoError(t, rfc2866.AcctStatusType_Set(packet, rfc2866.AcctStatusType_Value_InterimUpdate))
+	r := &radius.Request{RemoteAddr: &net.UDPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 1813}, Packet: packet}
+
+	// Act & Assert: sessions without a multi-session aren't grouped
+	_, err := Handle(mCtx, reqCtx, r, nil)
+	require.NoError(t, err)
+	require.NotContains(t, attrs, protos.MultiSessionIDAttribute)
+
+	// Act & Assert: the NAS's Acct-Multi-Session-Id is passed to the AAA
+	require.NoError(t, rfc2866.AcctMultiSessionID_SetString(packet, "ess1-0A0B0C0D0E0F"))
+	_, err = Handle(mCtx, reqCtx, r, nil)
+	require.NoError(t, err)
+	require.Equal(t, "ess1-0A0B0C0D0E0F", attrs[protos.MultiSessionIDAttribute])
+}
+
+func TestHandleInterimUpdateNASAddress(t *testing.T) {
+	// Arrange
+	var attrs map[string]string
+	mCtx := ModuleCtx{client: updateRecorder{attrs: &attrs}, retrier: retry.NoRetry}
+	storage := session.NewSessionStorage(session.NewMultiSessionMemoryStorage(), "sessionID")
+	reqCtx := &modules.RequestContext{Logger: zap.NewNop(), SessionID: "sessionID", SessionStorage: storage}
+	packet := radius.New(radius.CodeAccountingRequest, []byte("123456"))
+	require.NoError(t, rfc2866.AcctStatusType_Set(packet, rfc2866.AcctStatusType_Value_InterimUpdate))
+	r := &radius.Request{RemoteAddr: &net.UDPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 1813}, Packet: packet}
+
+	// Act & Assert: the request's source address is passed to the AAA
+	_, err := Handle(mCtx, reqCtx, r, nil)
+	require.NoError(t, err)
+	require.Equal(t, "10.0.0.1:1813", attrs[protos.NASAddressAttribute])
+	require.NotContains(t, attrs, protos.OperatorNameAttribute)
+
+	// Act & Assert: a failed over controller's requests carry its address
+	r.RemoteAddr = &net.UDPAddr{IP: net.IPv4(10, 0, 0, 2), Port: 32768}
+	_, err = Handle(mCtx, reqCtx, r, nil)
+	require.NoError(t, err)
+	require.Equal(t, "10.0.0.2:32768", attrs[protos.NASAddressAttribute])
+
+	// Act & Assert: the RFC 5580 Operator-Name is passed to the AAA
+	packet.Add(operatorNameType, radius.Attribute("1partner.example.com"))
+	_, err = Handle(mCtx, reqCtx, r, nil)
+	require.NoError(t, err)
+	require.Equal(t, "1partner.example.com", attrs[protos.OperatorNameAttribute])
+}
+
+// ctxRecorder an accounting client keeping the Context of the Interim-Updates
+type ctxRecorder struct {
+	protos.AccountingClient
+	ctx **protos.Context
+}
+
+func (c ctxRecorder) InterimUpdate(
+	_ context.Context, in *protos.UpdateRequest, _ ...grpc.CallOption,
+) (*protos.AcctResp, error) {
+	*c.ctx = proto.Clone(in.GetCtx()).(*protos.Context)
+	return &protos.AcctResp{}, nil
+}
+
+func TestHandleInterimUpdateIPv6(t *testing.T) {
+	// Arrange
+	var c *protos.Context
+	mCtx := ModuleCtx{client: ctxRecorder{ctx: &c}, retrier: retry.NoRetry}
+	storage := session.NewSessionStorage(session.NewMultiSessionMemoryStorage(), "sessionID")
+	reqCtx := &modules.RequestContext{Logger: zap.NewNop(), SessionID: "sessionID", SessionStorage: storage}
+	packet := radius.New(radius.CodeAccountingRequest, []byte("123456"))
+	require.NoError(t, rfc2866.AcctStatusType_Set(packet, rfc2866.AcctStatusType_Value_InterimUpdate))
+	r := &radius.Request{RemoteAddr: &net.UDPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 1813}, Packet: packet}
+
+	// Act & Assert: IPv4 only sessions have no IPv6 address & prefix
+	_, err := Handle(mCtx, reqCtx, r, nil)
+	require.NoError(t, err)
+	require.Empty(t, c.GetIpv6Addr())
+	require.Empty(t, c.GetIpv6Prefix())
+
+	// Act & Assert: the Framed-IPv6-Address & Delegated-IPv6-Prefix are passed to the AAA
+	ipv6Addr, err := radius.NewIPv6Addr(net.ParseIP("2001:db8::1"))
+	require.NoError(t, err)
+	packet.Add(framedIPv6AddressType, ipv6Addr)
+	packet.Add(delegatedIPv6PrefixType, radius.Attribute{0, 56, 0x20, 0x01, 0x0d, 0xb8, 0x00, 0x01, 0x00})
+	_, err = Handle(mCtx, reqCtx, r, nil)
+	require.NoError(t, err)
+	require.Equal(t, "10.0.0.1", c.GetIpAddr())
+	require.Equal(t, "2001:db8::1", c.GetIpv6Addr())
+	require.Equal(t, "2001:db8:1::/56", c.GetIpv6Prefix())
+}
+
+func TestDelegatedIPv6Prefix(t *testing.T) {
+	for _, tc := range []struct {
+		attr     radius.Attribute
+		expected string
+	}{
+		{radius.Attribute{0, 64, 0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0x01}, "2001:db8:0:1::/64"},
+		// bits past the prefix length are ignored
+		{radius.Attribute{0, 32, 0x20, 0x01, 0x0d, 0xb8, 0xff}, "2001:db8::/32"},
+		{radius.Attribute{0, 0}, "::/0"},
+		// fewer octets than the prefix length
+		{radius.Attribute{0, 64, 0x20, 0x01}, ""},
+		{radius.Attribute{0, 129, 0x20, 0x01}, ""},
+		{radius.Attribute{0}, ""},
+	} {
+		packet := radius.New(radius.CodeAccountingRequest, []byte("123456"))
+		packet.Add(delegatedIPv6PrefixType, tc.attr)
+		require.Equal(t, tc.expected, delegatedIPv6Prefix(packet), "%v", tc.attr)
+	}
+}
+
+// stopRecorder an accounting client keeping the Stop requests
+type stopRecorder struct {
+	protos.AccountingClient
+	request **protos.StopRequest
+}
+
+func (c stopRecorder) Stop(_ context.Context, in *protos.StopRequest, _ ...grpc.CallOption) (*protos.AcctResp, error) {
+	*c.request = in
+	return &protos.AcctResp{}, nil
+}
+
+func TestHandleStopUsage(t *testing.T) {
+	// Arrange
+	var request *protos.StopRequest
+	mCtx := ModuleCtx{client: stopRecorder{request: &request}, retrier: retry.NoRetry}
+	storage := session.NewSessionStorage(session.NewMultiSessionMemoryStorage(), "sessionID")
+	reqCtx := &modules.RequestContext{Logger: zap.NewNop(), SessionID: "sessionID", SessionStorage: storage}
+	packet := radius.New(radius.CodeAccountingRequest, []byte("123456"))
+	require.NoError(t, rfc2866.AcctStatusType_Set(packet, rfc2866.AcctStatusType_Value_Stop))
+	require.NoError(t, rfc2866.AcctInputOctets_Set(packet, 1000))
+	require.NoError(t, rfc2866.AcctOutputOctets_Set(packet, 100000))
+	require.NoError(t, rfc2869.AcctOutputGigawords_Set(packet, 2))
+	require.NoError(t, rfc2866.AcctInputPackets_Set(packet, 10))
+	require.NoError(t, rfc2866.AcctOutputPackets_Set(packet, 100))
+	require.NoError(t, rfc2866.AcctSessionTime_Set(packet, 3600))
+	r := &radius.Request{RemoteAddr: &net.UDPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 1813}, Packet: packet}
+
+	// Act
+	_, err := Handle(mCtx, reqCtx, r, nil)
+
+	// Assert: the Stop's final usage & session time are passed to the AAA
+	require.NoError(t, err)
+	require.NotNil(t, request)
+	require.Equal(t, protos.StopRequest_NAS_REQUEST, request.Cause)
+	require.Equal(t, uint32(1000), request.OctetsIn)
+	require.Equal(t, uint32(100000), request.OctetsOut)
+	require.Equal(t, uint32(0), request.GigawordsIn)
+	require.Equal(t, uint32(2), request.GigawordsOut)
+	require.Equal(t, uint32(10), request.PacketsIn)
+	require.Equal(t, uint32(100), request.PacketsOut)
+	require.Equal(t, uint32(3600), request.SessionTime)
+}
+
+// onOffRecorder an accounting client keeping the Accounting-On/Off requests
+type onOffRecorder struct {
+	protos.AccountingClient
+	requests *[]*protos.AcctOnOffRequest
+}
+
+func (c onOffRecorder) AcctOnOff(
+	_ context.Context, in *protos.AcctOnOffRequest, _ ...grpc.CallOption,
+) (*protos.AcctResp, error) {
+	*c.requests = append(*c.requests, in)
+	return &protos.AcctResp{}, nil
+}
+
+func TestHandleAccountingOnOff(t *testing.T) {
+	// Arrange
+	var requests []*protos.AcctOnOffRequest
+	mCtx := ModuleCtx{client: onOffRecorder{requests: &requests}, retrier: retry.NoRetry}
+	storage := session.NewSessionStorage(session.NewMultiSessionMemoryStorage(), "sessionID")
+	reqCtx := &modules.RequestContext{Logger: zap.NewNop(), SessionID: "sessionID", SessionStorage: storage}
+	packet := radius.New(radius.CodeAccountingRequest, []byte("123456"))
+	require.NoError(t, rfc2866.AcctStatusType_Set(packet, rfc2866.AcctStatusType_Value_AccountingOn))
+	require.NoError(t, rfc2865.NASIdentifier_SetString(packet, "ap1"))
+	require.NoError(t, rfc2865.CalledStationID_SetString(packet, "0A-0B-0C-0D-0E-0F:ssid"))
+	r := &radius.Request{RemoteAddr: &net.UDPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 1813}, Packet: packet}
+
+	// Act
+	res, err := Handle(mCtx, reqCtx, r, nil)
+	require.NoError(t, err)
+	require.NoError(t, rfc2866.AcctStatusType_Set(packet, rfc2866.AcctStatusType_Value_AccountingOff))
+	_, err = Handle(mCtx, reqCtx, r, nil)
+	require.NoError(t, err)
+
+	// Assert
+	require.Equal(t, radius.CodeAccountingResponse, res.Code)
+	require.Len(t, requests, 2)
+	require.False(t, requests[0].GetOff())
+	require.True(t, requests[1].GetOff())
+	require.Equal(t, "ap1", requests[1].GetNasIdentifier())
+	require.Equal(t, "0A-0B-0C-0D-0E-0F:ssid", requests[1].GetCalledStationId())
+}
+
+func TestEventTime(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	packet := radius.New(radius.CodeAccountingRequest, []byte("123456"))
+	r := &radius.Request{Packet: packet}
+
+	_, ok := eventTime(r, now)
+	require.False(t, ok)
+
+	// retransmissions' Acct-Delay-Time grows with their arrival time
+	require.NoError(t, rfc2866.AcctDelayTime_Set(packet, 5))
+	at, ok := eventTime(r, now)
+	require.True(t, ok)
+	require.Equal(t, now.Add(-5*time.Second), at)
+
+	// the Event-Timestamp takes precedence
+	require.NoError(t, rfc2869.EventTimestamp_Set(packet, time.Unix(1500000000, 0)))
+	at, ok = eventTime(r, now)
+	require.True(t, ok)
+	require.Equal(t, int64(1500000000), at.Unix())
+}
+
+func TestEventSkew(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	packet := radius.New(radius.CodeAccountingRequest, []byte("123456"))
+	r := &radius.Request{Packet: packet}
+
+	// the Acct-Delay-Time alone doesn't reveal the NAS's clock
+	require.NoError(t, rfc2866.AcctDelayTime_Set(packet, 5))
+	_, ok := eventSkew(r, now)
+	require.False(t, ok)
+
+	// the retransmission's delay isn't skew
+	require.NoError(t, rfc2869.EventTimestamp_Set(packet, now.Add(-65*time.Second)))
+	skew, ok := eventSkew(r, now)
+	require.True(t, ok)
+	require.Equal(t, time.Minute, skew)
+
+	// a NAS clock running ahead skews negatively
+	require.NoError(t, rfc2866.AcctDelayTime_Set(packet, 0))
+	require.NoError(t, rfc2869.EventTimestamp_Set(packet, now.Add(time.Hour)))
+	skew, ok = eventSkew(r, now)
+	require.True(t, ok)
+	require.Equal(t, -time.Hour, skew)
+}
+
+func TestCorrelationID(t *testing.T) {
+	packet := radius.New(radius.CodeAccountingRequest, []byte("123456"))
+	require.Empty(t, correlationID(packet))
+
+	// Act & Assert: the Acct-Multi-Session-Id correlates the accounting of NASes which don't echo the Class
+	require.NoError(t, rfc2866.AcctMultiSessionID_SetString(packet, "ess1-0A0B0C0D0E0F"))
+	require.Equal(t, "ess1-0A0B0C0D0E0F", correlationID(packet))
+
+	// Act & Assert: the correlation Class takes precedence, other servers' Classes are ignored
+	require.NoError(t, rfc2865.Class_AddString(packet, "other-server"))
+	require.Equal(t, "ess1-0A0B0C0D0E0F", correlationID(packet))
+	require.NoError(t, rfc2865.Class_AddString(packet, protos.CorrelationClass("ap1__ue1")))
+	require.Equal(t, "ap1__ue1", correlationID(packet))
+}
@@ -0,0 +1,41 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package magmaacct
+
+import (
+	"fbc/cwf/radius/modules/protos"
+	"sync"
+)
+
+// acctRequest the accounting RPC messages of a single RADIUS request. It is
+// pooled and reused across requests, as Interim-Updates arrive at high rates
+// and their messages are garbage right after being marshaled by the RPC call.
+// Nothing of it may be retained after the request is handled
+type acctRequest struct {
+	ctx    protos.Context
+	update protos.UpdateRequest
+	stop   protos.StopRequest
+}
+
+var acctRequestPool = sync.Pool{
+	New: func() interface{} {
+		return &acctRequest{}
+	},
+}
+
+func acquireAcctRequest() *acctRequest {
+	return acctRequestPool.Get().(*acctRequest)
+}
+
+func releaseAcctRequest(r *acctRequest) {
+	r.ctx.Reset()
+	r.update.Reset()
+	r.stop.Reset()
+	acctRequestPool.Put(r)
+}
@@ -0,0 +1,150 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package maintenance
+
+import (
+	"errors"
+	"fbc/cwf/radius/modules"
+	"fbc/cwf/radius/monitoring/counters"
+	"fbc/lib/go/radius"
+	"fbc/lib/go/radius/rfc2865"
+	"os"
+
+	"github.com/mitchellh/mapstructure"
+	"go.uber.org/zap"
+)
+
+const (
+	// WISPrVendorID the IANA enterprise number of the Wi-Fi Alliance (WISPr)
+	WISPrVendorID = 14122
+
+	// WISPrRedirectionURLType the WISPr-Redirection-URL vendor attribute type
+	WISPrRedirectionURLType = 4
+
+	defaultReplyMessage = "Service is temporarily unavailable, please try again later"
+)
+
+// MaintenanceReject counts new authentications rejected in maintenance mode
+var MaintenanceReject = counters.NewOperation("maintenance_reject")
+
+// Config the module configuration
+type Config struct {
+	// Enabled turns maintenance mode on regardless of ToggleFile
+	Enabled bool
+
+	// ToggleFile when set, maintenance mode is on for as long as this file
+	// exists, so operators can toggle it without restarting the server
+	ToggleFile string
+
+	// ReplyMessage the Reply-Message sent with every rejection
+	ReplyMessage string
+
+	// RedirectURL optional WISPr-Redirection-URL sent with every rejection
+	RedirectURL string
+}
+
+// ModuleCtx ...
+type ModuleCtx struct {
+	enabled      bool
+	toggleFile   string
+	replyMessage string
+	redirectURL  string
+}
+
+// Init module interface implementation
+func Init(logger *zap.Logger, config modules.ModuleConfig) (modules.Context, error) {
+	var mConfig Config
+	err := mapstructure.Decode(config, &mConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if !mConfig.Enabled && mConfig.ToggleFile == "" {
+		return nil, errors.New("maintenance module requires either Enabled or ToggleFile to be set")
+	}
+
+	if mConfig.ReplyMessage == "" {
+		mConfig.ReplyMessage = defaultReplyMessage
+	}
+
+	logger.Info(
+		"maintenance module initialized",
+		zap.Bool("enabled", mConfig.Enabled),
+		zap.String("toggle_file", mConfig.ToggleFile),
+	)
+	return ModuleCtx{
+		enabled:      mConfig.Enabled,
+		toggleFile:   mConfig.ToggleFile,
+		replyMessage: mConfig.ReplyMessage,
+		redirectURL:  mConfig.RedirectURL,
+	}, nil
+}
+
+// Handle module interface implementation
+func Handle(m modules.Context, c *modules.RequestContext, r *radius.Request, next modules.Middleware) (*modules.Response, error) {
+	mCtx := m.(ModuleCtx)
+
+	// Accounting, CoA and everything else which is not a new authentication
+	// keeps flowing while in maintenance
+	if r.Code != radius.CodeAccessRequest || !mCtx.isActive() {
+		return next(c, r)
+	}
+
+	// Re-authentication of (or a multi round-trip authentication already in
+	// progress for) an existing session is allowed
+	if c.SessionStorage != nil {
+		if state, err := c.SessionStorage.Get(); err == nil && state != nil {
+			return next(c, r)
+		}
+	}
+
+	counter := MaintenanceReject.Start()
+	response := &modules.Response{
+		Code:       radius.CodeAccessReject,
+		Attributes: radius.Attributes{},
+	}
+
+	replyMessage, err := radius.NewString(mCtx.replyMessage)
+	if err != nil {
+		counter.Failure("encode_reply_message")
+		return nil, errors.New("failed encoding Reply-Message: " + err.Error())
+	}
+	response.Attributes.Add(rfc2865.ReplyMessage_Type, replyMessage)
+
+	if mCtx.redirectURL != "" {
+		redirectAttr, err := newRedirectionURL(mCtx.redirectURL)
+		if err != nil {
+			counter.Failure("encode_redirect_url")
+			return nil, errors.New("failed encoding WISPr-Redirection-URL: " + err.Error())
+		}
+		response.Attributes.Add(rfc2865.VendorSpecific_Type, redirectAttr)
+	}
+
+	c.Logger.Debug("rejecting new authentication, server is in maintenance mode")
+	counter.Success()
+	return response, nil
+}
+
+// isActive returns true if maintenance mode is currently on
+func (m ModuleCtx) isActive() bool {
+	if m.enabled {
+		return true
+	}
+	_, err := os.Stat(m.toggleFile)
+	return err == nil
+}
+
+// newRedirectionURL encodes url as a WISPr-Redirection-URL vendor attribute
+func newRedirectionURL(url string) (radius.Attribute, error) {
+	if len(url) > 243 {
+		return nil, errors.New("url too long")
+	}
+	value := append([]byte{WISPrRedirectionURLType, byte(len(url) + 2)}, url...)
+	return radius.NewVendorSpecific(WISPrVendorID, radius.Attribute(value))
+}
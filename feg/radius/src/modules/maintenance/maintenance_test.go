@@ -0,0 +1,161 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package maintenance
+
+import (
+	"fbc/cwf/radius/modules"
+	"fbc/cwf/radius/session"
+	"fbc/lib/go/radius"
+	"fbc/lib/go/radius/rfc2865"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestRejectNewSession(t *testing.T) {
+	// Arrange
+	logger, err := zap.NewDevelopment()
+	require.NoError(t, err, "failed to get logger")
+	ctx, err := Init(logger, modules.ModuleConfig{
+		"Enabled":      true,
+		"ReplyMessage": "down for maintenance",
+		"RedirectURL":  "http://example.com/maintenance",
+	})
+	require.NoError(t, err, "failed to init")
+
+	// Act
+	res, err := Handle(
+		ctx,
+		newRequestContext(logger, session.NewMultiSessionMemoryStorage()),
+		&radius.Request{Packet: radius.New(radius.CodeAccessRequest, []byte{})},
+		func(c *modules.RequestContext, r *radius.Request) (*modules.Response, error) {
+			require.Fail(t, "next method is called but not expected to")
+			return nil, nil
+		},
+	)
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	require.Equal(t, radius.CodeAccessReject, res.Code)
+	require.Equal(t, "down for maintenance", radius.String(res.Attributes.Get(rfc2865.ReplyMessage_Type)))
+	vendorID, value, err := radius.VendorSpecific(res.Attributes.Get(rfc2865.VendorSpecific_Type))
+	require.NoError(t, err)
+	require.Equal(t, uint32(WISPrVendorID), vendorID)
+	require.Equal(t, byte(WISPrRedirectionURLType), value[0])
+	require.Equal(t, "http://example.com/maintenance", string(value[2:]))
+}
+
+func TestAllowExistingSession(t *testing.T) {
+	// Arrange
+	logger, err := zap.NewDevelopment()
+	require.NoError(t, err, "failed to get logger")
+	ctx, err := Init(logger, modules.ModuleConfig{"Enabled": true})
+	require.NoError(t, err, "failed to init")
+	storage := session.NewMultiSessionMemoryStorage()
+	require.NoError(t, storage.Set("session1", session.State{MSISDN: "1234"}))
+	called := false
+
+	// Act
+	_, err = Handle(
+		ctx,
+		newRequestContext(logger, storage),
+		&radius.Request{Packet: radius.New(radius.CodeAccessRequest, []byte{})},
+		func(c *modules.RequestContext, r *radius.Request) (*modules.Response, error) {
+			called = true
+			return nil, nil
+		},
+	)
+
+	// Assert
+	require.NoError(t, err)
+	require.True(t, called)
+}
+
+func TestAllowAccounting(t *testing.T) {
+	// Arrange
+	logger, err := zap.NewDevelopment()
+	require.NoError(t, err, "failed to get logger")
+	ctx, err := Init(logger, modules.ModuleConfig{"Enabled": true})
+	require.NoError(t, err, "failed to init")
+	called := false
+
+	// Act
+	_, err = Handle(
+		ctx,
+		newRequestContext(logger, session.NewMultiSessionMemoryStorage()),
+		&radius.Request{Packet: radius.New(radius.CodeAccountingRequest, []byte{})},
+		func(c *modules.RequestContext, r *radius.Request) (*modules.Response, error) {
+			called = true
+			return nil, nil
+		},
+	)
+
+	// Assert
+	require.NoError(t, err)
+	require.True(t, called)
+}
+
+func TestToggleFile(t *testing.T) {
+	// Arrange
+	logger, err := zap.NewDevelopment()
+	require.NoError(t, err, "failed to get logger")
+	dir, err := ioutil.TempDir("", "maintenance")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	toggleFile := dir + "/maintenance"
+	ctx, err := Init(logger, modules.ModuleConfig{"ToggleFile": toggleFile})
+	require.NoError(t, err, "failed to init")
+	next := func(c *modules.RequestContext, r *radius.Request) (*modules.Response, error) {
+		return &modules.Response{Code: radius.CodeAccessAccept}, nil
+	}
+	handle := func() *modules.Response {
+		res, err := Handle(
+			ctx,
+			newRequestContext(logger, session.NewMultiSessionMemoryStorage()),
+			&radius.Request{Packet: radius.New(radius.CodeAccessRequest, []byte{})},
+			next,
+		)
+		require.NoError(t, err)
+		return res
+	}
+
+	// Act and Assert
+	require.Equal(t, radius.CodeAccessAccept, handle().Code)
+	require.NoError(t, ioutil.WriteFile(toggleFile, []byte{}, 0644))
+	res := handle()
+	require.Equal(t, radius.CodeAccessReject, res.Code)
+	require.Equal(t, defaultReplyMessage, radius.String(res.Attributes.Get(rfc2865.ReplyMessage_Type)))
+	require.NoError(t, os.Remove(toggleFile))
+	require.Equal(t, radius.CodeAccessAccept, handle().Code)
+}
+
+func TestInitRequiresToggle(t *testing.T) {
+	// Arrange
+	logger, err := zap.NewDevelopment()
+	require.NoError(t, err, "failed to get logger")
+
+	// Act
+	_, err = Init(logger, modules.ModuleConfig{})
+
+	// Assert
+	require.Error(t, err)
+}
+
+func newRequestContext(logger *zap.Logger, storage session.GlobalStorage) *modules.RequestContext {
+	return &modules.RequestContext{
+		RequestID:      0,
+		Logger:         logger,
+		SessionID:      "session1",
+		SessionStorage: session.NewSessionStorage(storage, "session1"),
+	}
+}
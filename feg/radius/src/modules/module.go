@@ -16,6 +16,7 @@ package modules
 import (
 	"context"
 
+	"fbc/cwf/radius/quirks"
 	"fbc/cwf/radius/session"
 	"fbc/lib/go/radius"
 
@@ -33,6 +34,8 @@ type (
 		Logger         *zap.Logger
 		SessionID      string
 		SessionStorage session.Storage
+		Quirks         quirks.Quirks // interop quirks of the request's NAS
+		NASAddress     string        // the session's NAS host known to the AAA, CoA & Disconnect-Requests only
 	}
 
 	// Response the response of a plugin handler
@@ -49,6 +52,18 @@ type (
 	// Context is an instance that holds module-specific parameters
 	Context interface{}
 
+	// StatefulContext a module context holding in-flight state, which is handed off to the next server process
+	// on zero downtime upgrades
+	StatefulContext interface {
+		ExportState() ([]byte, error)
+		ImportState(state []byte) error
+	}
+
+	// ClosableContext a module context holding resources, which are released when the server stops
+	ClosableContext interface {
+		Close() error
+	}
+
 	// Module a pluggable RADIUS request handler
 	Module interface {
 		Init(loggert *zap.Logger, config ModuleConfig) (Context, error)
@@ -20,15 +20,74 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+// terminate_reason - reason of a network initiated session termination, it's carried to the NAS with the
+// Disconnect-Request, so APs, clients & captive portals can tell the termination flows apart
+type TerminateReason int32
+
+const (
+	TerminateReason_UNSPECIFIED_REASON TerminateReason = 0
+	TerminateReason_QUOTA_EXHAUSTED    TerminateReason = 1
+	TerminateReason_ADMIN_ACTION       TerminateReason = 2
+	TerminateReason_POLICY             TerminateReason = 3
+	TerminateReason_SUBSCRIPTION_ENDED TerminateReason = 4
+	TerminateReason_SESSION_IDLE       TerminateReason = 5
+	TerminateReason_GUEST_TIME_LIMIT   TerminateReason = 6
+	TerminateReason_SESSION_LIFETIME   TerminateReason = 7
+)
+
+var TerminateReason_name = map[int32]string{
+	0: "UNSPECIFIED_REASON",
+	1: "QUOTA_EXHAUSTED",
+	2: "ADMIN_ACTION",
+	3: "POLICY",
+	4: "SUBSCRIPTION_ENDED",
+	5: "SESSION_IDLE",
+	6: "GUEST_TIME_LIMIT",
+	7: "SESSION_LIFETIME",
+}
+
+var TerminateReason_value = map[string]int32{
+	"UNSPECIFIED_REASON": 0,
+	"QUOTA_EXHAUSTED":    1,
+	"ADMIN_ACTION":       2,
+	"POLICY":             3,
+	"SUBSCRIPTION_ENDED": 4,
+	"SESSION_IDLE":       5,
+	"GUEST_TIME_LIMIT":   6,
+	"SESSION_LIFETIME":   7,
+}
+
+func (x TerminateReason) String() string {
+	return proto.EnumName(TerminateReason_name, int32(x))
+}
+
+func (TerminateReason) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_b64063be2fc89884, []int{0}
+}
+
 type Context struct {
-	SessionId            string   `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
-	Imsi                 string   `protobuf:"bytes,2,opt,name=imsi,proto3" json:"imsi,omitempty"`
-	Msk                  []byte   `protobuf:"bytes,3,opt,name=msk,proto3" json:"msk,omitempty"`
-	Identity             string   `protobuf:"bytes,4,opt,name=identity,proto3" json:"identity,omitempty"`
-	Msisdn               string   `protobuf:"bytes,5,opt,name=msisdn,proto3" json:"msisdn,omitempty"`
-	Apn                  string   `protobuf:"bytes,6,opt,name=apn,proto3" json:"apn,omitempty"`
-	MacAddr              string   `protobuf:"bytes,7,opt,name=mac_addr,json=macAddr,proto3" json:"mac_addr,omitempty"`
-	IpAddr               string   `protobuf:"bytes,8,opt,name=ip_addr,json=ipAddr,proto3" json:"ip_addr,omitempty"`
+	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Imsi      string `protobuf:"bytes,2,opt,name=imsi,proto3" json:"imsi,omitempty"`
+	Msk       []byte `protobuf:"bytes,3,opt,name=msk,proto3" json:"msk,omitempty"`
+	Identity  string `protobuf:"bytes,4,opt,name=identity,proto3" json:"identity,omitempty"`
+	Msisdn    string `protobuf:"bytes,5,opt,name=msisdn,proto3" json:"msisdn,omitempty"`
+	Apn       string `protobuf:"bytes,6,opt,name=apn,proto3" json:"apn,omitempty"`
+	MacAddr   string `protobuf:"bytes,7,opt,name=mac_addr,json=macAddr,proto3" json:"mac_addr,omitempty"`
+	IpAddr    string `protobuf:"bytes,8,opt,name=ip_addr,json=ipAddr,proto3" json:"ip_addr,omitempty"`
+	// outer_identity - EAP-Response/Identity identity, may be anonymous (e.g. anonymous@realm) & is only
+	// used for realm routing, the subscriber's identity is derived from the inner EAP method
+	OuterIdentity string `protobuf:"bytes,9,opt,name=outer_identity,json=outerIdentity,proto3" json:"outer_identity,omitempty"`
+	// attributes - arbitrary NAS attributes propagated without a schema change, see context_attributes.go for
+	// the size limits
+	Attributes map[string]string `protobuf:"bytes,10,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// ipv6_addr - UE's IPv6 address of IPv6 only & dual stack sessions, ip_addr is the UE's IPv4 address
+	Ipv6Addr string `protobuf:"bytes,11,opt,name=ipv6_addr,json=ipv6Addr,proto3" json:"ipv6_addr,omitempty"`
+	// ipv6_prefix - UE's delegated IPv6 prefix (e.g. 2001:db8:1::/56), optional
+	Ipv6Prefix string `protobuf:"bytes,12,opt,name=ipv6_prefix,json=ipv6Prefix,proto3" json:"ipv6_prefix,omitempty"`
+	// correlation_id - the NAS's attachment correlation: the session ID echoed in the RADIUS Class of the session's
+	// Access-Accept or the Acct-Multi-Session-Id. Accounting of the attachment's roams & AP handoffs, which have
+	// other session IDs, is resolved to the session by it
+	CorrelationId        string   `protobuf:"bytes,13,opt,name=correlation_id,json=correlationId,proto3" json:"correlation_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -115,6 +174,41 @@ func (m *Context) GetIpAddr() string {
 	return ""
 }
 
+func (m *Context) GetOuterIdentity() string {
+	if m != nil {
+		return m.OuterIdentity
+	}
+	return ""
+}
+
+func (m *Context) GetAttributes() map[string]string {
+	if m != nil {
+		return m.Attributes
+	}
+	return nil
+}
+
+func (m *Context) GetIpv6Addr() string {
+	if m != nil {
+		return m.Ipv6Addr
+	}
+	return ""
+}
+
+func (m *Context) GetIpv6Prefix() string {
+	if m != nil {
+		return m.Ipv6Prefix
+	}
+	return ""
+}
+
+func (m *Context) GetCorrelationId() string {
+	if m != nil {
+		return m.CorrelationId
+	}
+	return ""
+}
+
 type Void struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -147,27 +241,45 @@ func (m *Void) XXX_DiscardUnknown() {
 var xxx_messageInfo_Void proto.InternalMessageInfo
 
 func init() {
+	proto.RegisterEnum("aaa.protos.TerminateReason", TerminateReason_name, TerminateReason_value)
 	proto.RegisterType((*Context)(nil), "aaa.protos.context")
+	proto.RegisterMapType((map[string]string)(nil), "aaa.protos.context.AttributesEntry")
 	proto.RegisterType((*Void)(nil), "aaa.protos.Void")
 }
 
 func init() { proto.RegisterFile("context.proto", fileDescriptor_b64063be2fc89884) }
 
 var fileDescriptor_b64063be2fc89884 = []byte{
-	// 227 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x3c, 0x8f, 0xc1, 0x4a, 0xc4, 0x30,
-	0x10, 0x86, 0xa9, 0x5b, 0xdb, 0xee, 0xa0, 0x20, 0x73, 0xd0, 0x28, 0x08, 0xcb, 0x82, 0xb8, 0x27,
-	0x73, 0xf0, 0x09, 0xf4, 0xe6, 0x75, 0x0f, 0x1e, 0xbc, 0x2c, 0x63, 0x27, 0x96, 0x41, 0x92, 0x94,
-	0x4e, 0x50, 0xf7, 0x0d, 0x7d, 0x2c, 0x69, 0x52, 0x3c, 0xe5, 0xfb, 0xff, 0x2f, 0xfc, 0x30, 0x70,
-	0xde, 0xc7, 0x90, 0xdc, 0x4f, 0x7a, 0x18, 0xa7, 0x98, 0x22, 0x02, 0x11, 0x15, 0xd4, 0xed, 0x6f,
-	0x05, 0xed, 0x62, 0xf1, 0x16, 0x40, 0x9d, 0xaa, 0xc4, 0x70, 0x10, 0x36, 0xd5, 0xa6, 0xda, 0xad,
-	0xf7, 0xeb, 0xa5, 0x79, 0x61, 0x44, 0xa8, 0xc5, 0xab, 0x98, 0x93, 0x2c, 0x32, 0xe3, 0x05, 0xac,
-	0xbc, 0x7e, 0x9a, 0xd5, 0xa6, 0xda, 0x9d, 0xed, 0x67, 0xc4, 0x1b, 0xe8, 0x84, 0x5d, 0x48, 0x92,
-	0x8e, 0xa6, 0xce, 0x3f, 0xff, 0x33, 0x5e, 0x42, 0xe3, 0x55, 0x94, 0x83, 0x39, 0xcd, 0x66, 0x49,
-	0xf3, 0x0a, 0x8d, 0xc1, 0x34, 0xb9, 0x9c, 0x11, 0xaf, 0xa1, 0xf3, 0xd4, 0x1f, 0x88, 0x79, 0x32,
-	0x6d, 0xae, 0x5b, 0x4f, 0xfd, 0x13, 0xf3, 0x84, 0x57, 0xd0, 0xca, 0x58, 0x4c, 0x57, 0x56, 0x64,
-	0x9c, 0xc5, 0xb6, 0x81, 0xfa, 0x35, 0x0a, 0x3f, 0xdf, 0xbf, 0xdd, 0x79, 0x1a, 0x3c, 0xd9, 0x0f,
-	0x37, 0xd8, 0x81, 0x92, 0xfb, 0xa6, 0xa3, 0x55, 0x37, 0x7d, 0x49, 0xef, 0xd4, 0x12, 0x91, 0x2d,
-	0xb7, 0xbf, 0x37, 0xf9, 0x7d, 0xfc, 0x0b, 0x00, 0x00, 0xff, 0xff, 0xbc, 0x10, 0xdc, 0x4a, 0x1f,
-	0x01, 0x00, 0x00,
+	// 495 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5d, 0x91, 0xdb, 0x6e, 0xd3, 0x40,
+	0x10, 0x86, 0xc9, 0xc9, 0x49, 0x26, 0x09, 0xb5, 0xb6, 0x08, 0x4c, 0x11, 0xa2, 0x2a, 0xaa, 0xa8,
+	0xb8, 0x48, 0x24, 0x2a, 0x21, 0x84, 0xc4, 0x85, 0x6b, 0x6f, 0x61, 0xa5, 0x9c, 0x88, 0x1d, 0x04,
+	0xdc, 0x58, 0xdb, 0x78, 0x1b, 0xad, 0x5a, 0x1f, 0xe4, 0xdd, 0x86, 0xe6, 0xb9, 0x78, 0x19, 0x1e,
+	0x87, 0xdd, 0xb5, 0x13, 0x2a, 0xae, 0x3c, 0xf3, 0xfd, 0x33, 0xb3, 0xbf, 0x67, 0x60, 0xb0, 0xca,
+	0x52, 0xc9, 0xee, 0xe5, 0x30, 0x2f, 0x32, 0x99, 0x21, 0xa0, 0x94, 0x96, 0xa1, 0x38, 0xf9, 0xd3,
+	0x80, 0x76, 0xa5, 0xa2, 0x97, 0x00, 0x82, 0x09, 0xc1, 0xb3, 0x34, 0xe2, 0xb1, 0x53, 0x3b, 0xae,
+	0x9d, 0x75, 0x17, 0xdd, 0x8a, 0x90, 0x18, 0x21, 0x68, 0xf2, 0x44, 0x70, 0xa7, 0x6e, 0x04, 0x13,
+	0x23, 0x1b, 0x1a, 0x89, 0xb8, 0x71, 0x1a, 0x0a, 0xf5, 0x17, 0x3a, 0x44, 0x47, 0xd0, 0xe1, 0x31,
+	0x4b, 0x25, 0x97, 0x5b, 0xa7, 0x69, 0x2a, 0xf7, 0x39, 0x7a, 0x0a, 0x96, 0x6a, 0x12, 0x71, 0xea,
+	0xb4, 0x8c, 0x52, 0x65, 0x7a, 0x0a, 0xcd, 0x53, 0xc7, 0x32, 0x50, 0x87, 0xe8, 0x39, 0x74, 0x12,
+	0xba, 0x8a, 0x68, 0x1c, 0x17, 0x4e, 0xdb, 0xe0, 0xb6, 0xca, 0x5d, 0x95, 0xa2, 0x67, 0xd0, 0xe6,
+	0x79, 0xa9, 0x74, 0xca, 0x29, 0x3c, 0x37, 0xc2, 0x29, 0x3c, 0xce, 0xee, 0x24, 0x2b, 0xa2, 0xfd,
+	0xfb, 0x5d, 0xa3, 0x0f, 0x0c, 0x25, 0x3b, 0x13, 0x1e, 0x00, 0x95, 0xb2, 0xe0, 0x57, 0x8a, 0x0a,
+	0x07, 0x8e, 0x1b, 0x67, 0xbd, 0x77, 0xaf, 0x87, 0xff, 0x56, 0x32, 0xdc, 0x2d, 0xcb, 0xdd, 0x57,
+	0xe1, 0x54, 0x16, 0xdb, 0xc5, 0x83, 0x36, 0xf4, 0x02, 0xba, 0x3c, 0xdf, 0xbc, 0x2f, 0x6d, 0xf4,
+	0xaa, 0xdf, 0x54, 0xc0, 0x18, 0x79, 0x05, 0x3d, 0x23, 0xe6, 0x05, 0xbb, 0xe6, 0xf7, 0x4e, 0xdf,
+	0xc8, 0xa0, 0xd1, 0xdc, 0x10, 0xed, 0x74, 0x95, 0x15, 0x05, 0xbb, 0xa5, 0xb2, 0x5a, 0xf6, 0xa0,
+	0x74, 0xfa, 0x80, 0x92, 0xf8, 0xe8, 0x13, 0x1c, 0xfc, 0xe7, 0x41, 0x6f, 0xea, 0x86, 0x6d, 0xab,
+	0xdb, 0xe8, 0x10, 0x3d, 0x81, 0xd6, 0x86, 0xde, 0xde, 0xb1, 0xea, 0x2c, 0x65, 0xf2, 0xb1, 0xfe,
+	0xa1, 0x76, 0x62, 0x41, 0xf3, 0x5b, 0xc6, 0xe3, 0xb7, 0xbf, 0x6b, 0x60, 0xab, 0x05, 0x24, 0x3c,
+	0xa5, 0x92, 0x45, 0x05, 0xa3, 0x22, 0x4b, 0xd5, 0x29, 0xd0, 0x72, 0x1a, 0xcc, 0xb1, 0x47, 0x2e,
+	0x09, 0xf6, 0xa3, 0x05, 0x76, 0x83, 0xd9, 0xd4, 0x7e, 0x84, 0x0e, 0xe1, 0xe0, 0xeb, 0x72, 0x16,
+	0xba, 0x11, 0xfe, 0xfe, 0xc5, 0x5d, 0x06, 0x21, 0xf6, 0xed, 0x9a, 0x7a, 0xb5, 0xef, 0xfa, 0x13,
+	0x32, 0x8d, 0x5c, 0x2f, 0x24, 0xaa, 0xac, 0x8e, 0x00, 0xac, 0xf9, 0x6c, 0x4c, 0xbc, 0x1f, 0x76,
+	0x43, 0x8f, 0x0a, 0x96, 0x17, 0x81, 0xb7, 0x20, 0x73, 0xad, 0x46, 0x78, 0xea, 0xab, 0xae, 0xa6,
+	0xee, 0x0a, 0x70, 0x10, 0x68, 0x44, 0xfc, 0x31, 0xb6, 0x5b, 0xca, 0xab, 0xfd, 0x79, 0x89, 0x83,
+	0x30, 0x0a, 0xc9, 0x04, 0x47, 0x63, 0x32, 0x21, 0xa1, 0x6d, 0x69, 0xba, 0xab, 0x1b, 0x93, 0x4b,
+	0xac, 0x35, 0xbb, 0x7d, 0xf1, 0xe6, 0xe7, 0x69, 0x42, 0xd7, 0x09, 0x1d, 0x5d, 0xb3, 0xf5, 0x68,
+	0xad, 0x9c, 0xff, 0xa2, 0xdb, 0x91, 0x60, 0xc5, 0x86, 0xaf, 0x98, 0x18, 0xa9, 0x73, 0x8d, 0xca,
+	0x73, 0x5d, 0x59, 0xe6, 0x7b, 0xfe, 0x17, 0x4f, 0xdb, 0xf1, 0x9a, 0xe5, 0x02, 0x00, 0x00,
 }
@@ -11,9 +11,14 @@ package proxy
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 
 	"fbc/cwf/radius/modules"
+	"fbc/cwf/radius/modules/eap/identity"
+	"fbc/cwf/radius/modules/radsec"
 	"fbc/lib/go/radius"
+	"fbc/lib/go/radius/rfc2865"
 
 	"github.com/mitchellh/mapstructure"
 	"go.uber.org/zap"
@@ -21,12 +26,24 @@ import (
 
 // Config configuration structure for proxy module
 type Config struct {
+	// Target the default proxy target
 	Target string
+
+	// RealmTargets proxy targets by the realm of the request's User-Name
+	// (i.e. the outer identity, which may be anonymous), requests of other
+	// realms are proxied to Target
+	RealmTargets map[string]string
+
+	// RadSec proxies requests to the targets over RadSec (RADIUS over TLS)
+	// instead of UDP if set
+	RadSec *radsec.ClientConfig
 }
 
 // ModuleCtx ...
 type ModuleCtx struct {
-	target string
+	target       string
+	realmTargets map[string]string
+	client       radsec.Exchanger
 }
 
 // Init module interface implementation
@@ -37,17 +54,34 @@ func Init(logger *zap.Logger, config modules.ModuleConfig) (modules.Context, err
 		return nil, err
 	}
 
-	if proxyConfig.Target == "" {
+	if proxyConfig.Target == "" && len(proxyConfig.RealmTargets) == 0 {
 		return nil, errors.New("proxy module cannot be initialize with empty Target value")
 	}
 
-	return ModuleCtx{target: proxyConfig.Target}, nil
+	// Realms are case insensitive (RFC 7542)
+	realmTargets := make(map[string]string, len(proxyConfig.RealmTargets))
+	for realm, target := range proxyConfig.RealmTargets {
+		if target == "" {
+			return nil, fmt.Errorf("proxy module cannot be initialized with empty target for realm '%s'", realm)
+		}
+		realmTargets[strings.ToLower(realm)] = target
+	}
+
+	client, err := radsec.NewExchanger(proxyConfig.RadSec, logger)
+	if err != nil {
+		return nil, err
+	}
+	return ModuleCtx{target: proxyConfig.Target, realmTargets: realmTargets, client: client}, nil
 }
 
 // Handle module interface implementation
 func Handle(m modules.Context, _ *modules.RequestContext, r *radius.Request, _ modules.Middleware) (*modules.Response, error) {
 	mCtx := m.(ModuleCtx)
-	res, err := radius.Exchange(context.Background(), r.Packet, mCtx.target)
+	target := mCtx.targetOf(r)
+	if target == "" {
+		return nil, errors.New("proxy module has no target for the request's realm")
+	}
+	res, err := mCtx.client.Exchange(context.Background(), r.Packet, target)
 	if err != nil {
 		return nil, err
 	}
@@ -57,3 +91,14 @@ func Handle(m modules.Context, _ *modules.RequestContext, r *radius.Request, _ m
 		Attributes: res.Attributes,
 	}, nil
 }
+
+// targetOf returns the proxy target of the request by its User-Name realm
+func (m ModuleCtx) targetOf(r *radius.Request) string {
+	if len(m.realmTargets) > 0 {
+		realm := identity.Realm(rfc2865.UserName_GetString(r.Packet))
+		if target, ok := m.realmTargets[strings.ToLower(realm)]; ok {
+			return target
+		}
+	}
+	return m.target
+}
@@ -108,3 +108,30 @@ func createRadiusRequest(calledStationID string, callingStationID string) *radiu
 	req.Packet = packet
 	return req
 }
+
+func TestRealmTargets(t *testing.T) {
+	// Arrange
+	logger, err := zap.NewDevelopment()
+	require.NoError(t, err, "failed to get logger")
+	mCtx, err := Init(logger, modules.ModuleConfig{
+		"target": "default:1812",
+		"realmTargets": map[string]string{
+			"Wlan.Mnc001.Mcc001.3gppnetwork.org": "home:1812",
+		},
+	})
+	require.NoError(t, err)
+	anonymous := createRadiusRequest("called", "calling")
+	rfc2865.UserName_SetString(anonymous.Packet, "anonymous@wlan.mnc001.mcc001.3gppnetwork.org")
+	other := createRadiusRequest("called", "calling")
+	rfc2865.UserName_SetString(other.Packet, "anonymous@other.org")
+
+	// Act
+	anonymousTarget := mCtx.(ModuleCtx).targetOf(anonymous)
+	otherTarget := mCtx.(ModuleCtx).targetOf(other)
+	noUserTarget := mCtx.(ModuleCtx).targetOf(createRadiusRequest("called", "calling"))
+
+	// Assert
+	require.Equal(t, "home:1812", anonymousTarget)
+	require.Equal(t, "default:1812", otherTarget)
+	require.Equal(t, "default:1812", noUserTarget)
+}
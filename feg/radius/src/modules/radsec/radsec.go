@@ -0,0 +1,97 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package radsec
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"time"
+
+	"fbc/cwf/radius/certmanager"
+	"fbc/lib/go/radius"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultKeepAliveSec default TCP keepalive period of the connections to upstream RadSec servers
+	DefaultKeepAliveSec = 30
+	// DefaultTimeoutSec default timeout of the exchanges with upstream RadSec servers
+	DefaultTimeoutSec = 10
+)
+
+// Exchanger exchanges a request with an upstream RADIUS server, over UDP (radius.DefaultClient) or RadSec
+type Exchanger interface {
+	Exchange(ctx context.Context, packet *radius.Packet, addr string) (*radius.Packet, error)
+}
+
+// ClientConfig configuration of a module's RadSec (RADIUS over TLS, RFC 6614) connections to its upstream servers
+type ClientConfig struct {
+	// TLS the client certificate & key, the servers are verified against the CA file (or the system roots) & their
+	// host names
+	TLS certmanager.Config
+	// KeepAliveSec the TCP keepalive period of the connections, DefaultKeepAliveSec if not set
+	KeepAliveSec int
+	// TimeoutSec the timeout of the exchanges, DefaultTimeoutSec if not set
+	TimeoutSec int
+}
+
+// NewExchanger returns an exchanger of the configuration, exchanging requests over UDP if the configuration is nil
+func NewExchanger(cfg *ClientConfig, logger *zap.Logger) (Exchanger, error) {
+	if cfg == nil {
+		return radius.DefaultClient, nil
+	}
+	return NewClient(*cfg, logger)
+}
+
+// NewClient returns a RadSec client of the configuration, the client's certificate is rotated when its files change
+func NewClient(cfg ClientConfig, logger *zap.Logger) (*radius.StreamClient, error) {
+	if cfg.KeepAliveSec < 0 || cfg.TimeoutSec < 0 {
+		return nil, errors.New("radsec client keepalive & timeout must not be negative")
+	}
+	certs, err := certmanager.New(cfg.TLS, logger)
+	if err != nil {
+		return nil, err
+	}
+	certs.Start()
+	keepAlive, timeout := cfg.KeepAliveSec, cfg.TimeoutSec
+	if keepAlive == 0 {
+		keepAlive = DefaultKeepAliveSec
+	}
+	if timeout == 0 {
+		timeout = DefaultTimeoutSec
+	}
+	dialer := net.Dialer{KeepAlive: time.Duration(keepAlive) * time.Second}
+	return &radius.StreamClient{
+		Dial: func(ctx context.Context, addr string) (net.Conn, error) {
+			host, _, err := net.SplitHostPort(addr)
+			if err != nil {
+				return nil, err
+			}
+			conn, err := dialer.DialContext(ctx, "tcp", addr)
+			if err != nil {
+				return nil, err
+			}
+			tlsConn := tls.Client(conn, certs.ClientTLSConfig(host))
+			if deadline, ok := ctx.Deadline(); ok {
+				tlsConn.SetDeadline(deadline)
+			}
+			if err = tlsConn.Handshake(); err != nil {
+				conn.Close()
+				logger.Warn("RadSec handshake failed", zap.String("server", addr), zap.Error(err))
+				return nil, err
+			}
+			tlsConn.SetDeadline(time.Time{})
+			return tlsConn, nil
+		},
+		Timeout: time.Duration(timeout) * time.Second,
+	}, nil
+}
@@ -0,0 +1,130 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package radsec
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"fbc/cwf/radius/certmanager"
+	"fbc/lib/go/radius"
+	"fbc/lib/go/radius/rfc2865"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestExchange(t *testing.T) {
+	// Arrange
+	logger, err := zap.NewDevelopment()
+	require.NoError(t, err, "failed to get logger")
+	dir, err := ioutil.TempDir("", "radsec")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	server := writeCert(t, dir, "server")
+	client := writeCert(t, dir, "client")
+	stranger := writeCert(t, dir, "stranger")
+
+	// mutual TLS, the peers' self signed certificates are their CAs
+	server.CAFile, client.CAFile, stranger.CAFile = client.CertFile, server.CertFile, server.CertFile
+	serverCerts, err := certmanager.New(server, logger)
+	require.NoError(t, err)
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	streamServer := &radius.StreamServer{
+		TLSConfig:    serverCerts.ServerTLSConfig(),
+		SecretSource: radius.StaticSecretSource([]byte(radius.RadSecSecret)),
+		Handler: radius.HandlerFunc(func(w radius.ResponseWriter, r *radius.Request) {
+			response := r.Response(radius.CodeAccessAccept)
+			rfc2865.UserName_SetString(response, rfc2865.UserName_GetString(r.Packet))
+			w.Write(response)
+		}),
+	}
+	go streamServer.Serve(ln)
+	defer streamServer.Shutdown(context.Background())
+
+	exchanger, err := NewExchanger(&ClientConfig{TLS: client, TimeoutSec: 5}, logger)
+	require.NoError(t, err)
+	request := radius.New(radius.CodeAccessRequest, []byte("secret"))
+	rfc2865.UserName_SetString(request, "tim")
+
+	// Act
+	response, err := exchanger.Exchange(context.Background(), request, ln.Addr().String())
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, radius.CodeAccessAccept, response.Code)
+	require.Equal(t, request.Identifier, response.Identifier)
+	require.Equal(t, "tim", rfc2865.UserName_GetString(response))
+
+	// Act
+	strangerClient, err := NewClient(ClientConfig{TLS: stranger, TimeoutSec: 5}, logger)
+	require.NoError(t, err)
+	_, err = strangerClient.Exchange(context.Background(), request, ln.Addr().String())
+
+	// Assert
+	require.Error(t, err, "clients without certificates of the server's CAs are rejected")
+}
+
+func TestNewExchanger(t *testing.T) {
+	// Act
+	exchanger, err := NewExchanger(nil, zap.NewNop())
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, radius.DefaultClient, exchanger)
+
+	// Act
+	_, err = NewExchanger(&ClientConfig{TLS: certmanager.Config{CertFile: "/nonexistent/cert.pem"}}, zap.NewNop())
+
+	// Assert
+	require.Error(t, err)
+}
+
+// writeCert writes a self signed certificate of 127.0.0.1 & its key
+func writeCert(t *testing.T, dir string, name string) certmanager.Config {
+	config := certmanager.Config{
+		CertFile: filepath.Join(dir, name+".pem"),
+		KeyFile:  filepath.Join(dir, name+".key"),
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(
+		config.CertFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, ioutil.WriteFile(
+		config.KeyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600))
+	return config
+}
@@ -0,0 +1,251 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package rejectcache caches Access-Rejects per (MAC, identity) & answers
+// repeated authentications of the same client from the cache with an
+// increasing delay, slowing down brute force attempts & reducing the load of
+// misconfigured clients hammering the gateway
+package rejectcache
+
+import (
+	"errors"
+	"fbc/cwf/radius/modules"
+	"fbc/cwf/radius/monitoring/counters"
+	"fbc/lib/go/radius"
+	"fbc/lib/go/radius/rfc2865"
+	"fbc/lib/go/radius/rfc2869"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultTTLMs       = 30000
+	defaultBaseDelayMs = 500
+	defaultMaxDelayMs  = 8000
+	defaultMaxEntries  = 10000
+
+	eapFailureCode = 4
+)
+
+// CachedReject counts Access-Requests answered from the cache, failures are
+// requests not cached since the cache is full
+var CachedReject = counters.NewOperation("reject_cache")
+
+// Config the module configuration
+type Config struct {
+	// FailureThreshold number of consecutive Access-Rejects of a client
+	// before its requests are answered from the cache, 0 - from the first one
+	FailureThreshold int
+
+	// TTLMs how long a client's Access-Reject is cached after its last
+	// rejection by the modules following this one
+	TTLMs int
+
+	// BaseDelayMs delay of the first cached Access-Reject, doubled for every
+	// following one up to MaxDelayMs
+	BaseDelayMs int
+
+	// MaxDelayMs the longest delay of a cached Access-Reject
+	MaxDelayMs int
+
+	// MaxEntries maximum number of clients with cached Access-Rejects
+	MaxEntries int
+}
+
+type entry struct {
+	failures int // consecutive Access-Rejects, including the cached ones
+	expires  time.Time
+	response modules.Response
+}
+
+type cache struct {
+	sync.Mutex
+	entries    map[string]*entry
+	threshold  int
+	ttl        time.Duration
+	baseDelay  time.Duration
+	maxDelay   time.Duration
+	maxEntries int
+	now        func() time.Time
+	sleep      func(time.Duration)
+}
+
+// ModuleCtx ...
+type ModuleCtx struct {
+	cache *cache
+}
+
+// Init module interface implementation
+func Init(logger *zap.Logger, config modules.ModuleConfig) (modules.Context, error) {
+	mConfig := Config{
+		TTLMs:       defaultTTLMs,
+		BaseDelayMs: defaultBaseDelayMs,
+		MaxDelayMs:  defaultMaxDelayMs,
+		MaxEntries:  defaultMaxEntries,
+	}
+	err := mapstructure.Decode(config, &mConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if mConfig.FailureThreshold < 0 || mConfig.TTLMs <= 0 || mConfig.BaseDelayMs < 0 ||
+		mConfig.MaxDelayMs < mConfig.BaseDelayMs || mConfig.MaxEntries <= 0 {
+		return nil, errors.New("reject cache module requires a positive TTLMs & MaxEntries, " +
+			"a non negative FailureThreshold & BaseDelayMs and MaxDelayMs not below BaseDelayMs")
+	}
+
+	logger.Info(
+		"reject cache module initialized",
+		zap.Int("failure_threshold", mConfig.FailureThreshold),
+		zap.Int("ttl_ms", mConfig.TTLMs),
+		zap.Int("base_delay_ms", mConfig.BaseDelayMs),
+		zap.Int("max_delay_ms", mConfig.MaxDelayMs),
+		zap.Int("max_entries", mConfig.MaxEntries),
+	)
+	return ModuleCtx{cache: &cache{
+		entries:    map[string]*entry{},
+		threshold:  mConfig.FailureThreshold,
+		ttl:        time.Duration(mConfig.TTLMs) * time.Millisecond,
+		baseDelay:  time.Duration(mConfig.BaseDelayMs) * time.Millisecond,
+		maxDelay:   time.Duration(mConfig.MaxDelayMs) * time.Millisecond,
+		maxEntries: mConfig.MaxEntries,
+		now:        time.Now,
+		sleep:      time.Sleep,
+	}}, nil
+}
+
+// Handle module interface implementation
+func Handle(m modules.Context, c *modules.RequestContext, r *radius.Request, next modules.Middleware) (*modules.Response, error) {
+	mCtx := m.(ModuleCtx)
+	if r.Code != radius.CodeAccessRequest {
+		return next(c, r)
+	}
+	key := clientKey(r)
+	if key == "" {
+		return next(c, r)
+	}
+
+	if response, delay, ok := mCtx.cache.lookup(key); ok {
+		counter := CachedReject.Start()
+		c.Logger.Debug(
+			"answering repeated authentication with a cached Access-Reject",
+			zap.String("client", key),
+			zap.Duration("delay", delay),
+		)
+		mCtx.cache.sleep(delay)
+		counter.Success()
+		return replyTo(r, response), nil
+	}
+
+	response, err := next(c, r)
+	if err != nil || response == nil {
+		return response, err
+	}
+	switch response.Code {
+	case radius.CodeAccessReject:
+		if !mCtx.cache.reject(key, response) {
+			CachedReject.Start().Failure("cache_full")
+		}
+	case radius.CodeAccessAccept:
+		mCtx.cache.accept(key)
+	}
+	return response, nil
+}
+
+// lookup returns the client's cached Access-Reject & how long to delay it,
+// ok is false if the client's requests are not answered from the cache
+func (c *cache) lookup(key string) (response modules.Response, delay time.Duration, ok bool) {
+	c.Lock()
+	defer c.Unlock()
+	e, found := c.entries[key]
+	if !found {
+		return response, 0, false
+	}
+	if !c.now().Before(e.expires) {
+		delete(c.entries, key)
+		return response, 0, false
+	}
+	if e.failures < c.threshold {
+		return response, 0, false
+	}
+	delay = c.baseDelay << uint(e.failures-c.threshold)
+	if delay > c.maxDelay || delay < c.baseDelay {
+		delay = c.maxDelay
+	}
+	e.failures++
+	return e.response, delay, true
+}
+
+// reject records the client's Access-Reject, false if the cache is full
+func (c *cache) reject(key string, response *modules.Response) bool {
+	c.Lock()
+	defer c.Unlock()
+	e, found := c.entries[key]
+	if !found {
+		if len(c.entries) >= c.maxEntries && c.evictExpired() == 0 {
+			return false
+		}
+		e = &entry{}
+		c.entries[key] = e
+	}
+	e.failures++
+	e.expires = c.now().Add(c.ttl)
+	e.response = *response
+	return true
+}
+
+// accept forgets the client's Access-Rejects
+func (c *cache) accept(key string) {
+	c.Lock()
+	delete(c.entries, key)
+	c.Unlock()
+}
+
+// evictExpired removes the expired entries & returns their number, the lock
+// must be held
+func (c *cache) evictExpired() int {
+	now := c.now()
+	evicted := 0
+	for key, e := range c.entries {
+		if !now.Before(e.expires) {
+			delete(c.entries, key)
+			evicted++
+		}
+	}
+	return evicted
+}
+
+// clientKey identifies the client by its MAC (Calling-Station-Id) & identity
+// (User-Name), empty if the request has neither
+func clientKey(r *radius.Request) string {
+	mac := strings.ToLower(rfc2865.CallingStationID_GetString(r.Packet))
+	identity := rfc2865.UserName_GetString(r.Packet)
+	if mac == "" && identity == "" {
+		return ""
+	}
+	return mac + "|" + identity
+}
+
+// replyTo returns a copy of the cached Access-Reject for the request. The
+// EAP-Failure of an EAP authentication is regenerated with the request's EAP
+// identifier, so the supplicant accepts it
+func replyTo(r *radius.Request, cached modules.Response) *modules.Response {
+	response := &modules.Response{Code: cached.Code, Attributes: radius.Attributes{}}
+	for t, values := range cached.Attributes {
+		response.Attributes[t] = append([]radius.Attribute(nil), values...)
+	}
+	if eap := rfc2869.EAPMessage_Get(r.Packet); len(eap) > 1 {
+		response.Attributes.Del(rfc2869.EAPMessage_Type)
+		response.Attributes.Add(rfc2869.EAPMessage_Type, radius.Attribute{eapFailureCode, eap[1], 0, 4})
+	}
+	return response
+}
@@ -0,0 +1,136 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package rejectcache
+
+import (
+	"fbc/cwf/radius/modules"
+	"fbc/lib/go/radius"
+	"fbc/lib/go/radius/rfc2865"
+	"fbc/lib/go/radius/rfc2869"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newRequest(mac, identity string, eapID byte) *radius.Request {
+	packet := radius.New(radius.CodeAccessRequest, []byte{})
+	rfc2865.CallingStationID_SetString(packet, mac)
+	rfc2865.UserName_SetString(packet, identity)
+	rfc2869.EAPMessage_Set(packet, []byte{2, eapID, 0, 5, 1})
+	return &radius.Request{Packet: packet}
+}
+
+// respondWith returns a next middleware answering with the code & counting its calls
+func respondWith(code *radius.Code, calls *int) modules.Middleware {
+	return func(c *modules.RequestContext, r *radius.Request) (*modules.Response, error) {
+		*calls++
+		res := &modules.Response{Code: *code, Attributes: radius.Attributes{}}
+		res.Attributes.Add(rfc2869.EAPMessage_Type, radius.Attribute{eapFailureCode, 1, 0, 4})
+		return res, nil
+	}
+}
+
+func TestCachedRejects(t *testing.T) {
+	// Arrange
+	logger, err := zap.NewDevelopment()
+	require.NoError(t, err, "failed to get logger")
+	ctx, err := Init(logger, modules.ModuleConfig{
+		"FailureThreshold": 2,
+		"TTLMs":            1000,
+		"BaseDelayMs":      100,
+		"MaxDelayMs":       300,
+	})
+	require.NoError(t, err, "failed to init")
+	now := time.Unix(1000, 0)
+	var delays []time.Duration
+	c := ctx.(ModuleCtx).cache
+	c.now = func() time.Time { return now }
+	c.sleep = func(d time.Duration) { delays = append(delays, d) }
+	reqCtx := &modules.RequestContext{Logger: logger}
+	code, calls := radius.CodeAccessReject, 0
+	next := respondWith(&code, &calls)
+
+	// Act: the first rejects reach the next module
+	for i := 0; i < 2; i++ {
+		res, err := Handle(ctx, reqCtx, newRequest("AA-BB", "user", byte(i)), next)
+		require.NoError(t, err)
+		require.Equal(t, radius.CodeAccessReject, res.Code)
+	}
+
+	// Assert
+	require.Equal(t, 2, calls)
+	require.Empty(t, delays)
+
+	// Act: the following ones are answered from the cache with growing delays
+	var res *modules.Response
+	for i := 0; i < 4; i++ {
+		res, err = Handle(ctx, reqCtx, newRequest("aa-bb", "user", 7), next)
+		require.NoError(t, err)
+	}
+
+	// Assert
+	require.Equal(t, 2, calls)
+	require.Equal(t, radius.CodeAccessReject, res.Code)
+	require.Equal(t, []byte{eapFailureCode, 7, 0, 4}, []byte(res.Attributes.Get(rfc2869.EAPMessage_Type)))
+	require.Equal(t, []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond,
+		300 * time.Millisecond}, delays)
+
+	// Act: other clients aren't affected
+	_, err = Handle(ctx, reqCtx, newRequest("aa-bb", "other", 1), next)
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, 3, calls)
+
+	// Act: the cached reject expires & the client is accepted
+	now = now.Add(time.Second)
+	code = radius.CodeAccessAccept
+	res, err = Handle(ctx, reqCtx, newRequest("aa-bb", "user", 8), next)
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, radius.CodeAccessAccept, res.Code)
+	require.Equal(t, 4, calls)
+	require.NotContains(t, c.entries, "aa-bb|user")
+}
+
+func TestCacheFull(t *testing.T) {
+	// Arrange
+	logger, err := zap.NewDevelopment()
+	require.NoError(t, err, "failed to get logger")
+	ctx, err := Init(logger, modules.ModuleConfig{"MaxEntries": 1})
+	require.NoError(t, err, "failed to init")
+	c := ctx.(ModuleCtx).cache
+	c.sleep = func(time.Duration) {}
+	reqCtx := &modules.RequestContext{Logger: logger}
+	code, calls := radius.CodeAccessReject, 0
+	next := respondWith(&code, &calls)
+
+	// Act
+	for _, identity := range []string{"user1", "user2", "user1", "user2"} {
+		_, err := Handle(ctx, reqCtx, newRequest("aa-bb", identity, 1), next)
+		require.NoError(t, err)
+	}
+
+	// Assert: only user1 is cached
+	require.Equal(t, 3, calls)
+	require.Len(t, c.entries, 1)
+	require.Contains(t, c.entries, "aa-bb|user1")
+}
+
+func TestInvalidConfig(t *testing.T) {
+	logger, err := zap.NewDevelopment()
+	require.NoError(t, err, "failed to get logger")
+	_, err = Init(logger, modules.ModuleConfig{"BaseDelayMs": 1000, "MaxDelayMs": 10})
+	require.Error(t, err)
+	_, err = Init(logger, modules.ModuleConfig{"FailureThreshold": -1})
+	require.Error(t, err)
+}
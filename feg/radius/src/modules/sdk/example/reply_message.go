@@ -0,0 +1,72 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Command example is an external module adding a configured Reply-Message to the Access-Accepts of the next modules.
+// Build it as a plugin & list it in the server configuration:
+//
+//	go build -buildmode=plugin -o /var/opt/radius/replymessage.so ./modules/sdk/example
+//
+//	"externalModules": [{"name": "replymessage", "path": "/var/opt/radius/replymessage.so"}]
+//
+// The plugin must be built with the same Go version & dependency versions as the server
+package main
+
+import (
+	"fbc/cwf/radius/modules/sdk"
+	"fbc/lib/go/radius"
+	"fbc/lib/go/radius/rfc2865"
+
+	"go.uber.org/zap"
+)
+
+// Module the exported external module, looked up by the server
+var Module sdk.Module = replyMessage{}
+
+type replyMessage struct{}
+
+type replyMessageCtx struct {
+	message string
+	logger  *zap.Logger
+}
+
+func (replyMessage) Info() sdk.Info {
+	return sdk.Info{
+		Name:       "replymessage",
+		SDKVersion: sdk.Version,
+		Schema: sdk.Schema{
+			"message": {Type: sdk.TypeString, Required: true, Description: "Reply-Message of the Access-Accepts"},
+		},
+	}
+}
+
+func (replyMessage) Init(logger *zap.Logger, config sdk.Config) (sdk.Context, error) {
+	// the configuration is validated against the schema before Init
+	return &replyMessageCtx{message: config["message"].(string), logger: logger}, nil
+}
+
+func (replyMessage) Handle(
+	m sdk.Context, c *sdk.RequestContext, r *radius.Request, next sdk.Middleware,
+) (*sdk.Response, error) {
+	mCtx := m.(*replyMessageCtx)
+	res, err := next(c, r)
+	if err != nil || res == nil || res.Code != radius.CodeAccessAccept {
+		return res, err
+	}
+	if res.Attributes == nil {
+		res.Attributes = radius.Attributes{}
+	}
+	res.Attributes.Add(rfc2865.ReplyMessage_Type, radius.Attribute(mCtx.message))
+	return res, nil
+}
+
+func (replyMessage) Close(m sdk.Context) error {
+	m.(*replyMessageCtx).logger.Debug("replymessage module closed")
+	return nil
+}
+
+func main() {}
@@ -0,0 +1,177 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package sdk is the stable interface of out-of-tree RADIUS modules. An external module is a Go plugin
+// (go build -buildmode=plugin) exporting a Module variable of type sdk.Module, see modules/sdk/example. The server
+// loads the plugins listed in the server configuration's externalModules & listeners enable them by name like the
+// built-in modules.
+//
+// Plugins are rejected if built against a different SDK major Version. Within a major version the SDK only grows:
+// types & fields are added, never changed or removed
+package sdk
+
+import (
+	"fmt"
+	"sort"
+
+	"fbc/cwf/radius/modules"
+	"fbc/lib/go/radius"
+
+	"go.uber.org/zap"
+)
+
+// Version the major version of the module SDK
+const Version = 1
+
+// Symbol the name of the plugin's exported sdk.Module variable
+const Symbol = "Module"
+
+// Stable aliases of the server's module types, external modules only depend on the sdk package
+type (
+	Config         = modules.ModuleConfig
+	Context        = modules.Context
+	RequestContext = modules.RequestContext
+	Response       = modules.Response
+	Middleware     = modules.Middleware
+)
+
+// Info describes an external module
+type Info struct {
+	Name       string
+	SDKVersion int    // the SDK Version the module is built against
+	Schema     Schema // the module's configuration, validated before Init
+}
+
+// Module the interface of external modules
+type Module interface {
+	// Info returns the module's description
+	Info() Info
+	// Init returns the context of a module instance, one per listener enabling the module
+	Init(logger *zap.Logger, config Config) (Context, error)
+	// Handle handles the request, calling next to pass it on to the listener's next module
+	Handle(m Context, c *RequestContext, r *radius.Request, next Middleware) (*Response, error)
+	// Close releases the resources of the module instance when the server stops
+	Close(m Context) error
+}
+
+// Field types of the configuration schema, as decoded from the JSON (or YAML) configuration
+const (
+	TypeString = "string"
+	TypeNumber = "number"
+	TypeBool   = "bool"
+	TypeObject = "object"
+	TypeArray  = "array"
+)
+
+// Field a configuration field of an external module
+type Field struct {
+	Type        string
+	Required    bool
+	Description string
+}
+
+// Schema the configuration fields of an external module by name
+type Schema map[string]Field
+
+// Validate returns an error if a required field is missing, a field has the wrong type or isn't in the schema
+func (s Schema) Validate(config Config) error {
+	names := make([]string, 0, len(s))
+	for name := range s {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		field := s[name]
+		value, ok := config[name]
+		if !ok {
+			if field.Required {
+				return fmt.Errorf("missing required config field '%s'", name)
+			}
+			continue
+		}
+		if !field.matches(value) {
+			return fmt.Errorf("config field '%s' must be of type %s, got %T", name, field.Type, value)
+		}
+	}
+	for name := range config {
+		if _, ok := s[name]; !ok {
+			return fmt.Errorf("unknown config field '%s'", name)
+		}
+	}
+	return nil
+}
+
+func (f Field) matches(value interface{}) bool {
+	switch value.(type) {
+	case string:
+		return f.Type == TypeString
+	case float64, int:
+		return f.Type == TypeNumber
+	case bool:
+		return f.Type == TypeBool
+	case map[string]interface{}:
+		return f.Type == TypeObject
+	case []interface{}:
+		return f.Type == TypeArray
+	default:
+		return false
+	}
+}
+
+// Check returns an error if the module is built against an incompatible SDK version or its schema is invalid
+func Check(m Module) error {
+	info := m.Info()
+	if info.SDKVersion != Version {
+		return fmt.Errorf("module '%s' is built against SDK version %d, expected %d", info.Name, info.SDKVersion, Version)
+	}
+	for name, field := range info.Schema {
+		switch field.Type {
+		case TypeString, TypeNumber, TypeBool, TypeObject, TypeArray:
+		default:
+			return fmt.Errorf("module '%s' config field '%s' has unknown type '%s'", info.Name, name, field.Type)
+		}
+	}
+	return nil
+}
+
+// Adapt returns the server module of the external module, its instances' configurations are validated against the
+// module's schema & their contexts are closed when the server stops
+func Adapt(m Module) modules.Module {
+	return adapter{m}
+}
+
+type adapter struct {
+	module Module
+}
+
+// closableContext the context of an external module instance, closed by the server
+type closableContext struct {
+	module Module
+	ctx    Context
+}
+
+func (c closableContext) Close() error {
+	return c.module.Close(c.ctx)
+}
+
+func (a adapter) Init(logger *zap.Logger, config modules.ModuleConfig) (modules.Context, error) {
+	if err := a.module.Info().Schema.Validate(config); err != nil {
+		return nil, fmt.Errorf("invalid module '%s' config: %s", a.module.Info().Name, err)
+	}
+	ctx, err := a.module.Init(logger, config)
+	if err != nil {
+		return nil, err
+	}
+	return closableContext{module: a.module, ctx: ctx}, nil
+}
+
+func (a adapter) Handle(
+	m modules.Context, c *modules.RequestContext, r *radius.Request, next modules.Middleware,
+) (*modules.Response, error) {
+	return a.module.Handle(m.(closableContext).ctx, c, r, next)
+}
@@ -0,0 +1,94 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package sdk
+
+import (
+	"testing"
+
+	"fbc/cwf/radius/modules"
+	"fbc/lib/go/radius"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// testModule an external module counting its instances' closes
+type testModule struct {
+	version int
+	schema  Schema
+	closed  *[]Context
+}
+
+func (m testModule) Info() Info {
+	return Info{Name: "test", SDKVersion: m.version, Schema: m.schema}
+}
+
+func (m testModule) Init(_ *zap.Logger, config Config) (Context, error) {
+	return config["name"], nil
+}
+
+func (m testModule) Handle(ctx Context, c *RequestContext, r *radius.Request, next Middleware) (*Response, error) {
+	return &Response{Code: radius.CodeAccessAccept, Raw: []byte(ctx.(string))}, nil
+}
+
+func (m testModule) Close(ctx Context) error {
+	*m.closed = append(*m.closed, ctx)
+	return nil
+}
+
+func TestSchemaValidate(t *testing.T) {
+	// Arrange
+	schema := Schema{
+		"name":    {Type: TypeString, Required: true},
+		"retries": {Type: TypeNumber},
+		"enabled": {Type: TypeBool},
+		"hosts":   {Type: TypeArray},
+		"limits":  {Type: TypeObject},
+	}
+
+	// Act & Assert
+	require.NoError(t, schema.Validate(Config{"name": "a"}))
+	require.NoError(t, schema.Validate(Config{
+		"name":    "a",
+		"retries": float64(3),
+		"enabled": true,
+		"hosts":   []interface{}{"h1"},
+		"limits":  map[string]interface{}{"max": float64(1)},
+	}))
+	require.Error(t, schema.Validate(Config{}), "missing required field")
+	require.Error(t, schema.Validate(Config{"name": "a", "retries": "3"}), "wrong type")
+	require.Error(t, schema.Validate(Config{"name": "a", "retry": float64(3)}), "unknown field")
+}
+
+func TestCheck(t *testing.T) {
+	require.NoError(t, Check(testModule{version: Version, schema: Schema{"name": {Type: TypeString}}}))
+	require.Error(t, Check(testModule{version: Version + 1}))
+	require.Error(t, Check(testModule{version: Version, schema: Schema{"name": {Type: "int"}}}))
+}
+
+func TestAdapt(t *testing.T) {
+	// Arrange
+	var closed []Context
+	module := Adapt(testModule{version: Version, schema: Schema{"name": {Type: TypeString}}, closed: &closed})
+
+	// Act
+	_, err := module.Init(zap.NewNop(), Config{"other": "x"})
+	require.Error(t, err)
+	ctx, err := module.Init(zap.NewNop(), Config{"name": "instance1"})
+	require.NoError(t, err)
+	res, err := module.Handle(ctx, &RequestContext{}, &radius.Request{}, nil)
+	require.NoError(t, err)
+	closable, ok := ctx.(modules.ClosableContext)
+	require.True(t, ok)
+	require.NoError(t, closable.Close())
+
+	// Assert
+	require.Equal(t, []byte("instance1"), res.Raw)
+	require.Equal(t, []Context{"instance1"}, closed)
+}
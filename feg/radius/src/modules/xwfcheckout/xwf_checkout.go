@@ -0,0 +1,211 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package xwfcheckout
+
+import (
+	"encoding/hex"
+	"errors"
+	"fbc/cwf/radius/modules"
+	"fbc/cwf/radius/modules/protos"
+	"fbc/cwf/radius/session"
+	"fmt"
+	"net"
+	"strconv"
+
+	"fbc/lib/go/radius"
+	"fbc/lib/go/radius/expresswifi"
+	"fbc/lib/go/radius/rfc2865"
+
+	"github.com/mitchellh/mapstructure"
+	"go.uber.org/zap"
+)
+
+// Context attribute keys of the Express Wi-Fi express checkout attributes
+const (
+	PartnerIDAttribute  = "xwf_partner_id"
+	CampaignIDAttribute = "xwf_campaign_id"
+)
+
+// Value formats of Access-Accept attributes
+const (
+	FormatString  = "string"
+	FormatInteger = "integer"
+	FormatIP      = "ip"
+	FormatHex     = "hex"
+)
+
+// Attribute a RADIUS attribute to add to Access-Accept responses
+type Attribute struct {
+	// Type the RADIUS attribute type, Vendor-Specific (26) attributes also require VendorID & VendorType
+	Type int
+	// VendorID & VendorType identify a Vendor-Specific attribute
+	VendorID   uint32
+	VendorType int
+	// Value the attribute value, encoded as per Format
+	Value string
+	// Format of the value: string (default), integer, ip or hex
+	Format string
+}
+
+// Config the module configuration
+type Config struct {
+	// Partners Access-Accept attributes to add per partner ID, the "*" partner applies to sessions of partners
+	// without their own entry
+	Partners map[string][]Attribute
+}
+
+type encodedAttribute struct {
+	typ   radius.Type
+	value radius.Attribute
+}
+
+// ModuleCtx ...
+type ModuleCtx struct {
+	partners map[string][]encodedAttribute
+}
+
+// Init module interface implementation
+func Init(logger *zap.Logger, config modules.ModuleConfig) (modules.Context, error) {
+	var mConfig Config
+	err := mapstructure.Decode(config, &mConfig)
+	if err != nil {
+		return nil, err
+	}
+	ctx := ModuleCtx{partners: map[string][]encodedAttribute{}}
+	for partner, attrs := range mConfig.Partners {
+		for _, a := range attrs {
+			encoded, err := encode(a)
+			if err != nil {
+				return nil, fmt.Errorf("invalid Access-Accept attribute of partner '%s': %s", partner, err)
+			}
+			ctx.partners[partner] = append(ctx.partners[partner], encoded)
+		}
+	}
+	return ctx, nil
+}
+
+// Handle module interface implementation
+func Handle(m modules.Context, c *modules.RequestContext, r *radius.Request, next modules.Middleware) (*modules.Response, error) {
+	mCtx := m.(ModuleCtx)
+	attrs := Extract(r.Packet)
+	if len(attrs) > 0 {
+		if err := attach(c, attrs); err != nil {
+			c.Logger.Error("failed to attach express checkout attributes to the session", zap.Error(err))
+		}
+	}
+
+	resp, err := next(c, r)
+	if err != nil || resp == nil || resp.Code != radius.CodeAccessAccept || len(mCtx.partners) == 0 {
+		return resp, err
+	}
+	partner, ok := attrs[PartnerIDAttribute]
+	if !ok {
+		if state, err := c.SessionStorage.Get(); err == nil {
+			partner, ok = state.Attributes[PartnerIDAttribute]
+		}
+	}
+	augment, found := mCtx.partners[partner]
+	if !ok || !found {
+		augment = mCtx.partners["*"]
+	}
+	if len(augment) > 0 && resp.Attributes == nil {
+		resp.Attributes = radius.Attributes{}
+	}
+	for _, a := range augment {
+		resp.Attributes.Add(a.typ, a.value)
+	}
+	return resp, nil
+}
+
+// Extract returns the express checkout context attributes of the packet
+func Extract(p *radius.Packet) map[string]string {
+	attrs := map[string]string{}
+	if partner, err := expresswifi.XWFPartnerID_LookupString(p); err == nil && len(partner) > 0 {
+		attrs[PartnerIDAttribute] = partner
+	}
+	if campaign, err := expresswifi.XWFCampaignID_LookupString(p); err == nil && len(campaign) > 0 {
+		attrs[CampaignIDAttribute] = campaign
+	}
+	return attrs
+}
+
+// attach stores the attributes in the session state, so they are propagated to the session's AAA context &
+// analytics events, including of requests not carrying them (e.g. accounting)
+func attach(c *modules.RequestContext, attrs map[string]string) error {
+	if err := protos.ValidateAttributes(attrs); err != nil {
+		return err
+	}
+	state, err := c.SessionStorage.Get()
+	if err != nil {
+		state = &session.State{}
+	}
+	merged := make(map[string]string, len(state.Attributes)+len(attrs))
+	for k, v := range state.Attributes {
+		merged[k] = v
+	}
+	changed := false
+	for k, v := range attrs {
+		if merged[k] != v {
+			merged[k] = v
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	state.Attributes = merged
+	return c.SessionStorage.Set(*state)
+}
+
+func encode(a Attribute) (encodedAttribute, error) {
+	if a.Type <= 0 || a.Type > 255 {
+		return encodedAttribute{}, fmt.Errorf("invalid RADIUS attribute type %d", a.Type)
+	}
+	var value radius.Attribute
+	var err error
+	switch a.Format {
+	case "", FormatString:
+		value, err = radius.NewString(a.Value)
+	case FormatInteger:
+		var i uint64
+		if i, err = strconv.ParseUint(a.Value, 10, 32); err == nil {
+			value = radius.NewInteger(uint32(i))
+		}
+	case FormatIP:
+		ip := net.ParseIP(a.Value)
+		if ip == nil {
+			return encodedAttribute{}, fmt.Errorf("invalid IP address '%s'", a.Value)
+		}
+		value, err = radius.NewIPAddr(ip)
+	case FormatHex:
+		value, err = hex.DecodeString(a.Value)
+	default:
+		return encodedAttribute{}, fmt.Errorf("unknown format '%s'", a.Format)
+	}
+	if err != nil {
+		return encodedAttribute{}, err
+	}
+	if a.Type != int(rfc2865.VendorSpecific_Type) {
+		return encodedAttribute{typ: radius.Type(a.Type), value: value}, nil
+	}
+	if a.VendorID == 0 || a.VendorType <= 0 || a.VendorType > 255 {
+		return encodedAttribute{}, errors.New("Vendor-Specific attribute requires VendorID & VendorType")
+	}
+	if len(value) > 253-6 {
+		return encodedAttribute{}, fmt.Errorf("Vendor-Specific attribute value is too long: %d", len(value))
+	}
+	vendorValue := make(radius.Attribute, 2+len(value))
+	vendorValue[0], vendorValue[1] = byte(a.VendorType), byte(len(vendorValue))
+	copy(vendorValue[2:], value)
+	vsa, err := radius.NewVendorSpecific(a.VendorID, vendorValue)
+	if err != nil {
+		return encodedAttribute{}, err
+	}
+	return encodedAttribute{typ: rfc2865.VendorSpecific_Type, value: vsa}, nil
+}
@@ -0,0 +1,102 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package xwfcheckout
+
+import (
+	"fbc/cwf/radius/modules"
+	"fbc/cwf/radius/session"
+	"testing"
+
+	"fbc/lib/go/radius"
+	"fbc/lib/go/radius/expresswifi"
+	"fbc/lib/go/radius/rfc2865"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestExpressCheckout(t *testing.T) {
+	// Arrange
+	logger, err := zap.NewDevelopment()
+	require.NoError(t, err, "failed to get logger")
+	ctx, err := Init(logger, modules.ModuleConfig{
+		"Partners": map[string]interface{}{
+			"partner1": []map[string]interface{}{
+				{"Type": 27, "Value": "3600", "Format": "integer"},
+				{"Type": 26, "VendorID": 40981, "VendorType": 3, "Value": "1000000", "Format": "integer"},
+			},
+			"*": []map[string]interface{}{
+				{"Type": 18, "Value": "welcome"},
+			},
+		},
+	})
+	require.NoError(t, err, "failed to init")
+	sessionStorage := session.NewSessionStorage(session.NewMultiSessionMemoryStorage(), "sessionID")
+	reqCtx := &modules.RequestContext{Logger: logger, SessionStorage: sessionStorage}
+
+	packet := radius.New(radius.CodeAccessRequest, []byte{0x01, 0x02, 0x03, 0x4, 0x05, 0x06})
+	require.NoError(t, expresswifi.XWFPartnerID_AddString(packet, "partner1"))
+	require.NoError(t, expresswifi.XWFCampaignID_AddString(packet, "summer"))
+	accept := func(c *modules.RequestContext, r *radius.Request) (*modules.Response, error) {
+		return &modules.Response{Code: radius.CodeAccessAccept}, nil
+	}
+
+	// Act
+	resp, err := Handle(ctx, reqCtx, &radius.Request{Packet: packet}, accept)
+
+	// Assert
+	require.NoError(t, err)
+	state, err := sessionStorage.Get()
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{PartnerIDAttribute: "partner1", CampaignIDAttribute: "summer"}, state.Attributes)
+	require.Equal(t, radius.NewInteger(3600), resp.Attributes.Get(rfc2865.SessionTimeout_Type))
+	require.Equal(t, expresswifi.XWFAllowedQuota(1000000), expresswifi.XWFAllowedQuota_Get(&radius.Packet{
+		Attributes: resp.Attributes,
+	}))
+	_, ok := resp.Attributes.Lookup(rfc2865.ReplyMessage_Type)
+	require.False(t, ok)
+
+	// Act - the partner of a request without express checkout attributes is taken from the session state
+	resp, err = Handle(ctx, reqCtx, &radius.Request{
+		Packet: radius.New(radius.CodeAccessRequest, []byte{0x01, 0x02, 0x03, 0x4, 0x05, 0x06}),
+	}, accept)
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, radius.NewInteger(3600), resp.Attributes.Get(rfc2865.SessionTimeout_Type))
+
+	// Act - partners without their own attributes get the default ones
+	other := radius.New(radius.CodeAccessRequest, []byte{0x01, 0x02, 0x03, 0x4, 0x05, 0x06})
+	require.NoError(t, expresswifi.XWFPartnerID_AddString(other, "partner2"))
+	resp, err = Handle(ctx, reqCtx, &radius.Request{Packet: other}, accept)
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, "welcome", rfc2865.ReplyMessage_GetString(&radius.Packet{Attributes: resp.Attributes}))
+	state, err = sessionStorage.Get()
+	require.NoError(t, err)
+	require.Equal(t, "partner2", state.Attributes[PartnerIDAttribute])
+	require.Equal(t, "summer", state.Attributes[CampaignIDAttribute])
+}
+
+func TestInvalidConfig(t *testing.T) {
+	// Arrange
+	logger, err := zap.NewDevelopment()
+	require.NoError(t, err, "failed to get logger")
+
+	// Act
+	_, err = Init(logger, modules.ModuleConfig{
+		"Partners": map[string]interface{}{
+			"partner1": []map[string]interface{}{{"Type": 26, "Value": "x"}},
+		},
+	})
+
+	// Assert
+	require.Error(t, err)
+}
@@ -0,0 +1,52 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package counters
+
+import (
+	"context"
+	"fbc/lib/go/rolling"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+// AuthOutcomes counts Access-Request outcomes, Access-Accept or Access-Reject, over the rolling.DefaultWindows
+var AuthOutcomes = rolling.NewDefault()
+
+var (
+	authSuccessRate = stats.Float64(
+		"auth_success_rate",
+		"Access-Request success rate over a sliding window, 1 if the window has no outcomes",
+		stats.UnitDimensionless,
+	)
+	// authRateCtxs tagged contexts of the windows' success rates, created once as tagging allocates
+	authRateCtxs = make([]context.Context, len(rolling.DefaultWindows))
+)
+
+func init() {
+	registerViews(&view.View{
+		Name:        "auth_success_rate/value",
+		Measure:     authSuccessRate,
+		Description: "Access-Request success rate over a sliding window, 1 if the window has no outcomes",
+		Aggregation: view.LastValue(),
+		TagKeys:     []tag.Key{WindowTag},
+	})
+	for i, w := range rolling.DefaultWindows {
+		authRateCtxs[i], _ = tag.New(context.Background(), tag.Upsert(WindowTag, rolling.Label(w)))
+	}
+}
+
+// RecordAuth counts an Access-Request's outcome & records the success rates of the windows
+func RecordAuth(success bool) {
+	AuthOutcomes.Add(success)
+	for i, s := range AuthOutcomes.Windows(rolling.DefaultWindows...) {
+		stats.Record(authRateCtxs[i], authSuccessRate.M(s.SuccessRate()))
+	}
+}
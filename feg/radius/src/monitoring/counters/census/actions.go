@@ -104,7 +104,9 @@ func NewHandler(opt ...Option) (http.Handler, func(), error) {
 	if err != nil {
 		return nil, nil, err
 	}
-	view.RegisterExporter(exporter)
-	closer := func() { view.UnregisterExporter(exporter) }
+	// the restored baselines are merged in, so restarts don't reset the exported counters
+	baselineExporter := WithBaseline(exporter)
+	view.RegisterExporter(baselineExporter)
+	closer := func() { view.UnregisterExporter(baselineExporter) }
 	return exporter, closer, nil
 }
@@ -0,0 +1,132 @@
+package census
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+// baseline values of the views restored from the previous server process, by view name & encoded tags. The
+// cumulative (count & sum) values are added to the views' values, the last values are reported until the views
+// record their own, so short restarts don't reset the exported counters
+var baseline struct {
+	sync.RWMutex
+	values map[string]map[string]baselineValue
+}
+
+type baselineValue struct {
+	tags  map[string]string
+	value float64
+}
+
+// SetBaseline sets the baseline value of the view's row of the tags
+func SetBaseline(name string, tags map[string]string, value float64) {
+	baseline.Lock()
+	defer baseline.Unlock()
+	if baseline.values == nil {
+		baseline.values = map[string]map[string]baselineValue{}
+	}
+	rows, ok := baseline.values[name]
+	if !ok {
+		rows = map[string]baselineValue{}
+		baseline.values[name] = rows
+	}
+	rows[encodeTags(tags)] = baselineValue{tags: tags, value: value}
+}
+
+// ClearBaseline removes all the baseline values
+func ClearBaseline() {
+	baseline.Lock()
+	baseline.values = nil
+	baseline.Unlock()
+}
+
+// MergeBaseline returns the view's rows with the view's baseline values merged in, the rows aren't modified.
+// Distributions have no baseline, their rows are returned as is
+func MergeBaseline(v *view.View, rows []*view.Row) []*view.Row {
+	baseline.RLock()
+	values := baseline.values[v.Name]
+	baseline.RUnlock()
+	if len(values) == 0 || v.Aggregation.Type == view.AggTypeDistribution {
+		return rows
+	}
+	result := make([]*view.Row, 0, len(rows)+len(values))
+	merged := map[string]bool{}
+	for _, row := range rows {
+		tags := map[string]string{}
+		for _, t := range row.Tags {
+			tags[t.Key.Name()] = t.Value
+		}
+		key := encodeTags(tags)
+		base, ok := values[key]
+		if !ok {
+			result = append(result, row)
+			continue
+		}
+		merged[key] = true
+		switch data := row.Data.(type) {
+		case *view.CountData:
+			row = &view.Row{Tags: row.Tags, Data: &view.CountData{Value: data.Value + int64(base.value)}}
+		case *view.SumData:
+			row = &view.Row{Tags: row.Tags, Data: &view.SumData{Value: data.Value + base.value}}
+		}
+		result = append(result, row)
+	}
+	for key, base := range values {
+		if merged[key] {
+			continue
+		}
+		row := &view.Row{Tags: viewTags(v, base.tags)}
+		switch v.Aggregation.Type {
+		case view.AggTypeCount:
+			row.Data = &view.CountData{Value: int64(base.value)}
+		case view.AggTypeSum:
+			row.Data = &view.SumData{Value: base.value}
+		case view.AggTypeLastValue:
+			row.Data = &view.LastValueData{Value: base.value}
+		default:
+			continue
+		}
+		result = append(result, row)
+	}
+	return result
+}
+
+// baselineExporter exports the views with their baseline values merged in
+type baselineExporter struct {
+	view.Exporter
+}
+
+// WithBaseline returns the exporter exporting the views with their baseline values merged in
+func WithBaseline(exporter view.Exporter) view.Exporter {
+	return baselineExporter{exporter}
+}
+
+func (e baselineExporter) ExportView(vd *view.Data) {
+	// the view data is shared by all the exporters, so it's copied
+	e.Exporter.ExportView(&view.Data{View: vd.View, Start: vd.Start, End: vd.End, Rows: MergeBaseline(vd.View, vd.Rows)})
+}
+
+// viewTags returns the tags of the view's row, in the view's tag keys order
+func viewTags(v *view.View, tags map[string]string) []tag.Tag {
+	var result []tag.Tag
+	for _, key := range v.TagKeys {
+		if value, ok := tags[key.Name()]; ok {
+			result = append(result, tag.Tag{Key: key, Value: value})
+		}
+	}
+	return result
+}
+
+// encodeTags returns the tags as a string, sorted by key
+func encodeTags(tags map[string]string) string {
+	pairs := make([]string, 0, len(tags))
+	for key, value := range tags {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, "\x00")
+}
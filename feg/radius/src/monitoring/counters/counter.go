@@ -0,0 +1,51 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package counters
+
+import (
+	"context"
+	"fmt"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+// Counter a cumulative counter of recorded amounts, e.g. messages of a batch
+type Counter struct {
+	ctx     context.Context
+	measure *stats.Int64Measure
+}
+
+// NewCounter creates a new counter
+func NewCounter(name string, description string, tagKeys ...tag.Key) Counter {
+	counter := Counter{
+		ctx:     context.Background(),
+		measure: stats.Int64(name, description, stats.UnitDimensionless),
+	}
+	registerViews(&view.View{
+		Name:        fmt.Sprintf("%s/total", name),
+		Measure:     counter.measure,
+		Description: description,
+		Aggregation: view.Sum(),
+		TagKeys:     tagKeys,
+	})
+	return counter
+}
+
+// SetTag sets a sticky tag which will be emitted with every recorded amount
+func (c Counter) SetTag(key tag.Key, value string) Counter {
+	c.ctx, _ = tag.New(c.ctx, tag.Upsert(key, value))
+	return c
+}
+
+// Add adds the amount to the counter
+func (c Counter) Add(amount int64) {
+	stats.Record(c.ctx, c.measure.M(amount))
+}
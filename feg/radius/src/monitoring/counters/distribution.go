@@ -0,0 +1,51 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package counters
+
+import (
+	"context"
+	"fmt"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+// Distribution a histogram of recorded values, e.g. latencies, exported with the buckets of its bounds
+type Distribution struct {
+	ctx     context.Context
+	measure *stats.Float64Measure
+}
+
+// NewDistribution creates a new distribution of the unit's values, bounds are the buckets' ascending upper bounds
+func NewDistribution(name string, description string, unit string, bounds []float64, tagKeys ...tag.Key) Distribution {
+	distribution := Distribution{
+		ctx:     context.Background(),
+		measure: stats.Float64(name, description, unit),
+	}
+	registerViews(&view.View{
+		Name:        fmt.Sprintf("%s/distribution", name),
+		Measure:     distribution.measure,
+		Description: description,
+		Aggregation: view.Distribution(bounds...),
+		TagKeys:     tagKeys,
+	})
+	return distribution
+}
+
+// SetTag sets a sticky tag which will be emitted with every recorded value
+func (d Distribution) SetTag(key tag.Key, value string) Distribution {
+	d.ctx, _ = tag.New(d.ctx, tag.Upsert(key, value))
+	return d
+}
+
+// Record records the value
+func (d Distribution) Record(value float64) {
+	stats.Record(d.ctx, d.measure.M(value))
+}
@@ -0,0 +1,51 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package counters
+
+import (
+	"context"
+	"fmt"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+// Gauge a counter reporting the last recorded value of a measurement, e.g. a value sampled from the OS
+type Gauge struct {
+	ctx     context.Context
+	measure *stats.Int64Measure
+}
+
+// NewGauge creates a new gauge
+func NewGauge(name string, description string, tagKeys ...tag.Key) Gauge {
+	gauge := Gauge{
+		ctx:     context.Background(),
+		measure: stats.Int64(name, description, stats.UnitDimensionless),
+	}
+	registerViews(&view.View{
+		Name:        fmt.Sprintf("%s/value", name),
+		Measure:     gauge.measure,
+		Description: description,
+		Aggregation: view.LastValue(),
+		TagKeys:     tagKeys,
+	})
+	return gauge
+}
+
+// SetTag sets a sticky tag which will be emitted with every recorded value
+func (g Gauge) SetTag(key tag.Key, value string) Gauge {
+	g.ctx, _ = tag.New(g.ctx, tag.Upsert(key, value))
+	return g
+}
+
+// Record records the current value
+func (g Gauge) Record(value int64) {
+	stats.Record(g.ctx, g.measure.M(value))
+}
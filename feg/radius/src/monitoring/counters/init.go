@@ -23,9 +23,11 @@ func Init(config census.Config, logger *zap.Logger) {
 		logger.Error("Failed building census", zap.Error(err))
 		return
 	}
-	http.Handle("/metrics", census.StatsHandler)
+	// a dedicated mux, the default mux has the debug handlers which must not be exposed on the metrics port
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", census.StatsHandler)
 	go func() {
 		defer census.Close()
-		http.ListenAndServe(":9100", nil)
+		http.ListenAndServe(":9100", mux)
 	}()
 }
@@ -23,4 +23,32 @@ var (
 
 	// DedupPacket RADIUS dedup logic counter
 	DedupPacket = NewOperation("radius_dedup", ListenerTag)
+
+	// UDPSocketDrops packets dropped by the kernel on a UDP listener's socket since it was opened, mostly due to a
+	// full receive buffer
+	UDPSocketDrops = NewGauge("udp_socket_drops", "Packets dropped by the kernel on the UDP socket", ListenerTag)
+
+	// UDPSocketRxQueue bytes waiting in a UDP listener's socket receive buffer
+	UDPSocketRxQueue = NewGauge("udp_socket_rx_queue", "Bytes queued in the UDP socket receive buffer", ListenerTag)
+
+	// RadSecConnection failed RadSec connections of NASes, e.g. TLS handshakes of NASes without valid certificates
+	RadSecConnection = NewOperation("radsec_connection", ListenerTag)
+
+	// ScubaBatch scuba batches sent, the failed ones are either spooled or dropped
+	ScubaBatch = NewOperation("scuba_batch")
+
+	// ScubaBatchRetry scuba batch posts failing transiently & retried, by error code
+	ScubaBatchRetry = NewOperation("scuba_batch_retry")
+
+	// ScubaSpoolReplay spooled scuba batches resent, the ones failing permanently are dropped
+	ScubaSpoolReplay = NewOperation("scuba_spool_replay")
+
+	// ScubaSpooledBatches scuba batches waiting in the spool directory to be resent
+	ScubaSpooledBatches = NewGauge("scuba_spooled_batches", "Scuba batches waiting in the spool to be resent")
+
+	// AcctCapturePacket Accounting-Requests written to the capture files, the dropped ones fail by reason
+	AcctCapturePacket = NewOperation("acct_capture_packet")
+
+	// AcctCaptureFiles accounting capture files kept in the capture directory
+	AcctCaptureFiles = NewGauge("acct_capture_files", "Accounting capture files kept in the capture directory")
 )
@@ -100,7 +100,7 @@ func NewOperation(name string, tagKeys ...tag.Key) Operation {
 		},
 	}
 
-	view.Register(views...)
+	registerViews(views...)
 
 	return operation
 }
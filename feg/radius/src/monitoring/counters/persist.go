@@ -0,0 +1,91 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package counters
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"fbc/cwf/radius/monitoring/counters/census"
+)
+
+// SnapshotConfig configuration of the counters' snapshot written on graceful shutdown & restored on the next start,
+// so short restarts don't reset the exported counters
+type SnapshotConfig struct {
+	Path      string `json:"path" required:"true"`
+	MaxAgeSec int    `json:"max_age_sec" default:"300"` // older snapshots are discarded, the restart wasn't short
+}
+
+// Validate validates the snapshot configuration (after defaults are applied)
+func (c *SnapshotConfig) Validate() error {
+	if c.MaxAgeSec <= 0 {
+		return fmt.Errorf("invalid snapshot max age %d, must be positive", c.MaxAgeSec)
+	}
+	return nil
+}
+
+// snapshotFile the snapshot file's format
+type snapshotFile struct {
+	Time   time.Time `json:"time"`
+	Values []Value   `json:"values"`
+}
+
+// WriteSnapshot writes the current values of the counters & gauges to the file, the distributions aren't written.
+// The file is replaced atomically, so a crash while writing doesn't leave a partial snapshot
+func WriteSnapshot(path string) error {
+	data, err := json.Marshal(snapshotFile{Time: time.Now(), Values: snapshot(false)})
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return fmt.Errorf("failed creating counters snapshot: %v", err)
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed writing counters snapshot: %v", err)
+	}
+	return nil
+}
+
+// RestoreSnapshot restores the counters' values of the snapshot file as the baselines of their views & removes the
+// file, so it's restored once. A missing snapshot or one older than the max age isn't restored. Returns the number of
+// restored values
+func RestoreSnapshot(path string, maxAge time.Duration) (int, error) {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed reading counters snapshot: %v", err)
+	}
+	os.Remove(path)
+	var file snapshotFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return 0, fmt.Errorf("failed parsing counters snapshot: %v", err)
+	}
+	if age := time.Since(file.Time); age > maxAge {
+		return 0, fmt.Errorf("counters snapshot is stale, written %v ago", age.Round(time.Second))
+	}
+	for _, v := range file.Values {
+		census.SetBaseline(v.Name, v.Tags, v.Value)
+	}
+	return len(file.Values), nil
+}
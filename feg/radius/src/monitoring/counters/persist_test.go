@@ -0,0 +1,80 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package counters
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"fbc/cwf/radius/monitoring/counters/census"
+
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+)
+
+func TestSnapshotRestore(t *testing.T) {
+	// Arrange
+	defer census.ClearBaseline()
+	dir, err := ioutil.TempDir("", "counters_snapshot")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "counters.json")
+	op := NewOperation("snapshot_test")
+	op.Start().Success()
+	op.Start().Failure("timeout")
+	gauge := NewGauge("snapshot_test_gauge", "Snapshot test gauge")
+	gauge.Record(7)
+
+	// Act: the server shuts down & restarts, its views start from zero
+	require.NoError(t, WriteSnapshot(path))
+	for _, name := range []string{
+		"snapshot_test/start/count", "snapshot_test/success/count", "snapshot_test/failure/count",
+		"snapshot_test_gauge/value",
+	} {
+		v := view.Find(name)
+		view.Unregister(v)
+		registerViews(v)
+	}
+	restored, err := RestoreSnapshot(path, time.Minute)
+	require.NoError(t, err)
+	op.Start().Success()
+
+	// Assert
+	require.True(t, restored > 0)
+	require.Equal(t, 3.0, snapshotValue(t, "snapshot_test/start/count"))
+	require.Equal(t, 2.0, snapshotValue(t, "snapshot_test/success/count"))
+	require.Equal(t, 1.0, snapshotValue(t, "snapshot_test/failure/count"), "restored without live rows")
+	require.Equal(t, 7.0, snapshotValue(t, "snapshot_test_gauge/value"), "restored until recorded")
+	gauge.Record(3)
+	require.Equal(t, 3.0, snapshotValue(t, "snapshot_test_gauge/value"))
+
+	_, err = os.Stat(path)
+	require.True(t, os.IsNotExist(err), "the snapshot is restored once")
+	restored, err = RestoreSnapshot(path, time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, 0, restored)
+
+	require.NoError(t, WriteSnapshot(path))
+	_, err = RestoreSnapshot(path, time.Nanosecond)
+	require.Error(t, err, "stale snapshots aren't restored")
+}
+
+// snapshotValue returns the snapshot's value of the untagged view
+func snapshotValue(t *testing.T, name string) float64 {
+	for _, v := range Snapshot() {
+		if v.Name == name {
+			return v.Value
+		}
+	}
+	require.Fail(t, "no value of "+name)
+	return 0
+}
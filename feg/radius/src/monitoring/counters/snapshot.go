@@ -0,0 +1,89 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package counters
+
+import (
+	"sort"
+	"sync"
+
+	"fbc/cwf/radius/monitoring/counters/census"
+
+	"go.opencensus.io/stats/view"
+)
+
+// Value current value of a counter view for one set of tag values
+type Value struct {
+	Name  string            `json:"name"`
+	Tags  map[string]string `json:"tags"`
+	Value float64           `json:"value"`
+}
+
+// registeredViews names of all views registered by operations
+var registeredViews struct {
+	sync.Mutex
+	names map[string]bool
+}
+
+func registerViews(views ...*view.View) {
+	view.Register(views...)
+	registeredViews.Lock()
+	defer registeredViews.Unlock()
+	if registeredViews.names == nil {
+		registeredViews.names = map[string]bool{}
+	}
+	for _, v := range views {
+		registeredViews.names[v.Name] = true
+	}
+}
+
+// Snapshot returns current values of all operations' counters sorted by name, including the restored baselines
+func Snapshot() []Value {
+	return snapshot(true)
+}
+
+// snapshot returns current values of the counters, the distributions' means are included only if requested
+func snapshot(distributions bool) []Value {
+	registeredViews.Lock()
+	names := make([]string, 0, len(registeredViews.names))
+	for name := range registeredViews.names {
+		names = append(names, name)
+	}
+	registeredViews.Unlock()
+	sort.Strings(names)
+
+	var res []Value
+	for _, name := range names {
+		vw := view.Find(name)
+		if vw == nil || (!distributions && vw.Aggregation.Type == view.AggTypeDistribution) {
+			continue
+		}
+		rows, err := view.RetrieveData(name)
+		if err != nil {
+			continue
+		}
+		for _, row := range census.MergeBaseline(vw, rows) {
+			v := Value{Name: name, Tags: map[string]string{}}
+			for _, t := range row.Tags {
+				v.Tags[t.Key.Name()] = t.Value
+			}
+			switch data := row.Data.(type) {
+			case *view.CountData:
+				v.Value = float64(data.Value)
+			case *view.SumData:
+				v.Value = data.Value
+			case *view.LastValueData:
+				v.Value = data.Value
+			case *view.DistributionData:
+				v.Value = data.Mean
+			}
+			res = append(res, v)
+		}
+	}
+	return res
+}
@@ -26,9 +26,27 @@ var (
 	// ErrorCodeTag code describing the error
 	ErrorCodeTag, _ = tag.NewKey("error_code")
 
+	// ErrorClassTag the class of the error, e.g. transient or permanent
+	ErrorClassTag, _ = tag.NewKey("error_class")
+
 	// SessionIDTag code indicating the session id used for the operation
 	SessionIDTag, _ = tag.NewKey("session_id")
 
 	// StorageTag code describing the type of storage used for the operation
 	StorageTag, _ = tag.NewKey("storage")
+
+	// WindowTag the sliding window of a rate, e.g. 5m
+	WindowTag, _ = tag.NewKey("window")
+
+	// TableTag the scuba table of the logs
+	TableTag, _ = tag.NewKey("table")
+
+	// PriorityTag the scuba priority tier of the logs
+	PriorityTag, _ = tag.NewKey("priority")
+
+	// StatusCodeTag the HTTP status code of a response, or the kind of error of a request without response
+	StatusCodeTag, _ = tag.NewKey("status_code")
+
+	// OutcomeTag the outcome of the logs of a scuba batch, e.g. sent or dropped
+	OutcomeTag, _ = tag.NewKey("outcome")
 )
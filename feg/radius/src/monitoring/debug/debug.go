@@ -0,0 +1,48 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package debug serves pprof profiles, expvar variables & Go runtime metrics of the radius server for in place
+// diagnostics of its CPU & memory issues, see fbc/lib/go/debughttp
+package debug
+
+import (
+	"net/http"
+
+	"fbc/lib/go/debughttp"
+
+	"go.uber.org/zap"
+)
+
+// Config of the debug endpoint
+type Config struct {
+	Address     string `json:"address" default:"127.0.0.1:6060"`
+	AuthToken   string `json:"authToken"`   // Optional, requests must carry the bearer token if set
+	AllowRemote bool   `json:"allowRemote"` // Allows listening on non loopback addresses
+}
+
+// Validate validates the debug endpoint configuration (after defaults are applied)
+func (c *Config) Validate() error {
+	return c.endpoint().Validate()
+}
+
+func (c *Config) endpoint() *debughttp.Config {
+	return &debughttp.Config{Addr: c.Address, Token: c.AuthToken, AllowRemote: c.AllowRemote}
+}
+
+// Start serves the debug endpoint in the background, the returned server is closed to release its port
+func Start(config *Config, logger *zap.Logger) (*http.Server, error) {
+	srv, err := debughttp.Start(config.endpoint(), func(err error) {
+		logger.Error("debug endpoint stopped serving", zap.Error(err))
+	})
+	if err != nil {
+		return nil, err
+	}
+	logger.Info("debug endpoint is listening",
+		zap.String("address", config.Address), zap.Bool("auth", config.AuthToken != ""))
+	return srv, nil
+}
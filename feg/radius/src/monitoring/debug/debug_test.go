@@ -0,0 +1,26 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package debug
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	for _, address := range []string{"127.0.0.1:6060", "localhost:6060", "[::1]:6060"} {
+		require.NoError(t, (&Config{Address: address}).Validate(), address)
+	}
+	for _, address := range []string{":6060", "0.0.0.0:6060", "10.0.0.1:6060"} {
+		require.Error(t, (&Config{Address: address}).Validate(), address)
+		require.NoError(t, (&Config{Address: address, AllowRemote: true}).Validate(), address)
+	}
+	require.Error(t, (&Config{Address: "6060", AllowRemote: true}).Validate())
+}
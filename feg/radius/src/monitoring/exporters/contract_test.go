@@ -0,0 +1,138 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package exporters_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+
+	"fbc/cwf/radius/monitoring/exporters"
+	"fbc/cwf/radius/monitoring/exporters/exporterstest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPSExporterContract(t *testing.T) {
+	exporterstest.RunContract(t, func(t *testing.T) *exporterstest.Sink {
+		var (
+			mu        sync.Mutex
+			fail      bool
+			delivered []string
+		)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			if fail {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			var batch struct {
+				Logs []json.RawMessage `json:"logs"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&batch))
+			for _, log := range batch.Logs {
+				delivered = append(delivered, string(log))
+			}
+		}))
+		config := &exporters.Config{HTTPS: &exporters.HTTPSConfig{URL: server.URL, TimeoutMs: 1000}}
+		exporter, err := exporters.New(exporters.HTTPS, "contract", config)
+		require.NoError(t, err)
+		return &exporterstest.Sink{
+			Exporter: exporter,
+			Delivered: func() []string {
+				mu.Lock()
+				defer mu.Unlock()
+				return append([]string(nil), delivered...)
+			},
+			Fail: func(f bool) {
+				mu.Lock()
+				defer mu.Unlock()
+				fail = f
+			},
+			Cleanup: server.Close,
+		}
+	})
+}
+
+func TestFileExporterContract(t *testing.T) {
+	exporterstest.RunContract(t, func(t *testing.T) *exporterstest.Sink {
+		dir, err := ioutil.TempDir("", "exporters")
+		require.NoError(t, err)
+		config := &exporters.Config{File: &exporters.FileConfig{Dir: dir}}
+		exporter, err := exporters.New(exporters.File, "contract", config)
+		require.NoError(t, err)
+		return &exporterstest.Sink{
+			Exporter: exporter,
+			Delivered: func() []string {
+				logs, err := ioutil.ReadFile(filepath.Join(dir, "contract.log"))
+				require.NoError(t, err)
+				if len(logs) == 0 {
+					return nil
+				}
+				return strings.Split(strings.TrimSuffix(string(logs), "\n"), "\n")
+			},
+			Cleanup: func() { os.RemoveAll(dir) },
+		}
+	})
+}
+
+func TestSyslogExporterContract(t *testing.T) {
+	if runtime.GOOS == "windows" || runtime.GOOS == "plan9" {
+		t.Skip("the syslog exporter is not supported on this platform")
+	}
+	exporterstest.RunContract(t, func(t *testing.T) *exporterstest.Sink {
+		conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+		require.NoError(t, err)
+		var (
+			mu        sync.Mutex
+			delivered []string
+		)
+		go func() {
+			buf := make([]byte, 64*1024)
+			for {
+				n, _, err := conn.ReadFrom(buf)
+				if err != nil {
+					return
+				}
+				// <priority>timestamp hostname contract[pid]: message
+				packet := string(buf[:n])
+				if i := strings.Index(packet, "]: "); i >= 0 {
+					mu.Lock()
+					delivered = append(delivered, packet[i+len("]: "):])
+					mu.Unlock()
+				}
+			}
+		}()
+		config := &exporters.Config{Syslog: &exporters.SyslogConfig{
+			Network:  "udp",
+			Address:  conn.LocalAddr().String(),
+			Facility: "local0",
+		}}
+		exporter, err := exporters.New(exporters.Syslog, "contract", config)
+		require.NoError(t, err)
+		return &exporterstest.Sink{
+			Exporter: exporter,
+			Delivered: func() []string {
+				mu.Lock()
+				defer mu.Unlock()
+				return append([]string(nil), delivered...)
+			},
+			Cleanup: func() { conn.Close() },
+		}
+	})
+}
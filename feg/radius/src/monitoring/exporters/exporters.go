@@ -0,0 +1,103 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package exporters implements the destinations of the structured logs' tables, besides Scuba: a plain HTTP(S) JSON
+// endpoint, syslog & files
+package exporters
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Exporter kinds, selectable per table
+const (
+	Scuba  = "scuba"
+	HTTPS  = "https"
+	Syslog = "syslog"
+	File   = "file"
+)
+
+// Exporter exports the batches of a table's log messages, the messages are the JSON encoded log entries
+type Exporter interface {
+	// Write exports the batch of messages
+	Write(messages []string) error
+	// Flush flushes the messages buffered by the exporter, if any
+	Flush() error
+	// Close flushes the buffered messages & releases the exporter, no batches are written after Close
+	Close() error
+}
+
+// Config the configuration of the exporters other than Scuba, an exporter's section is required by the tables
+// exporting to it
+type Config struct {
+	HTTPS  *HTTPSConfig  `json:"https"`
+	Syslog *SyslogConfig `json:"syslog"`
+	File   *FileConfig   `json:"file"`
+}
+
+// Validate returns an error if the kind is unknown or its configuration section is missing
+func (c *Config) Validate(kind string) error {
+	switch kind {
+	case Scuba:
+		return nil
+	case HTTPS:
+		if c.HTTPS == nil {
+			return fmt.Errorf("the https exporter requires the https section")
+		}
+	case Syslog:
+		if c.Syslog == nil {
+			return fmt.Errorf("the syslog exporter requires the syslog section")
+		}
+	case File:
+		if c.File == nil {
+			return fmt.Errorf("the file exporter requires the file section")
+		}
+	default:
+		return fmt.Errorf("unknown exporter '%s', expected one of %s, %s, %s or %s", kind, Scuba, HTTPS, Syslog, File)
+	}
+	return nil
+}
+
+// New returns the table's exporter of the given kind, Scuba exporters are created by the scuba package
+func New(kind string, table string, c *Config) (Exporter, error) {
+	if err := c.Validate(kind); err != nil {
+		return nil, err
+	}
+	switch kind {
+	case HTTPS:
+		return newHTTPSExporter(table, c.HTTPS), nil
+	case Syslog:
+		return newSyslogExporter(table, c.Syslog)
+	case File:
+		return newFileExporter(table, c.File)
+	default:
+		return nil, fmt.Errorf("%s exporters are not created by the exporters package", kind)
+	}
+}
+
+// rawMessage returns the message as raw JSON, messages which aren't valid JSON (e.g. of a console encoder) are
+// encoded as JSON strings
+func rawMessage(msg string) json.RawMessage {
+	if json.Valid([]byte(msg)) {
+		return json.RawMessage(msg)
+	}
+	encoded, _ := json.Marshal(msg) // strings can't fail to encode
+	return encoded
+}
+
+// messageLevel returns the level of the JSON encoded log entry, empty if it has none
+func messageLevel(msg string) string {
+	var entry struct {
+		Level string `json:"level"`
+	}
+	if err := json.Unmarshal([]byte(msg), &entry); err != nil {
+		return ""
+	}
+	return entry.Level
+}
@@ -0,0 +1,103 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package exporters
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPSExporter(t *testing.T) {
+	// Arrange
+	var batches []httpsBatch
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		var batch httpsBatch
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&batch))
+		batches = append(batches, batch)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+	config := &Config{HTTPS: &HTTPSConfig{
+		URL:       server.URL,
+		Headers:   map[string]string{"Authorization": "Bearer token"},
+		TimeoutMs: 1000,
+	}}
+	exporter, err := New(HTTPS, "auth", config)
+	require.NoError(t, err)
+
+	// Act
+	err = exporter.Write([]string{`{"level":"info","msg":"log"}` + "\n", "not json"})
+	status = http.StatusBadGateway
+	failed := exporter.Write([]string{`{"level":"error"}`})
+
+	// Assert
+	require.NoError(t, err)
+	require.Error(t, failed)
+	require.Len(t, batches, 2)
+	require.Equal(t, "auth", batches[0].Table)
+	require.Len(t, batches[0].Logs, 2)
+	require.JSONEq(t, `{"level":"info","msg":"log"}`, string(batches[0].Logs[0]))
+	require.Equal(t, `"not json"`, string(batches[0].Logs[1]))
+	require.NoError(t, exporter.Flush())
+	require.NoError(t, exporter.Close())
+}
+
+func TestFileExporter(t *testing.T) {
+	// Arrange
+	dir, err := ioutil.TempDir("", "exporters")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	config := &Config{File: &FileConfig{Dir: filepath.Join(dir, "logs")}}
+	exporter, err := New(File, "acct", config)
+	require.NoError(t, err)
+
+	// Act
+	require.NoError(t, exporter.Write([]string{`{"msg":"first"}` + "\n", `{"msg":"second"}`}))
+	require.NoError(t, exporter.Flush())
+	require.NoError(t, exporter.Write([]string{`{"msg":"third"}`}))
+	require.NoError(t, exporter.Close())
+
+	// Assert
+	logs, err := ioutil.ReadFile(filepath.Join(dir, "logs", "acct.log"))
+	require.NoError(t, err)
+	require.Equal(t, "{\"msg\":\"first\"}\n{\"msg\":\"second\"}\n{\"msg\":\"third\"}\n", string(logs))
+	require.Error(t, exporter.Write([]string{`{"msg":"closed"}`}))
+}
+
+func TestConfigValidate(t *testing.T) {
+	config := &Config{}
+	require.NoError(t, config.Validate(Scuba))
+	require.Error(t, config.Validate(HTTPS))
+	require.Error(t, config.Validate(Syslog))
+	require.Error(t, config.Validate(File))
+	require.Error(t, config.Validate("kafka"))
+	_, err := New(Scuba, "auth", config)
+	require.Error(t, err, "scuba exporters are created by the scuba package")
+
+	require.Error(t, (&HTTPSConfig{URL: "ftp://example.com", TimeoutMs: 1}).Validate())
+	require.NoError(t, (&HTTPSConfig{URL: "https://example.com/logs", TimeoutMs: 1}).Validate())
+	require.Error(t, (&SyslogConfig{Facility: "local9"}).Validate())
+	require.Error(t, (&SyslogConfig{Network: "udp", Facility: "local0"}).Validate())
+	require.NoError(t, (&SyslogConfig{Network: "udp", Address: "127.0.0.1:514", Facility: "local0"}).Validate())
+}
+
+func TestMessageLevel(t *testing.T) {
+	require.Equal(t, "warn", messageLevel(`{"level":"warn","msg":"log"}`))
+	require.Equal(t, "", messageLevel("not json"))
+}
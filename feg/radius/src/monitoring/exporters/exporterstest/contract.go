@@ -0,0 +1,154 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package exporterstest implements an in-memory fake exporter & the contract tests of the exporters' delivery
+// semantics, which every exporter implementation must pass
+package exporterstest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"fbc/cwf/radius/monitoring/exporters"
+
+	"github.com/stretchr/testify/require"
+)
+
+// deliveryTimeout the time the contract tests wait for the messages to reach the sinks' destinations
+const deliveryTimeout = 5 * time.Second
+
+// Sink an exporter under the contract tests & the destination of its messages
+type Sink struct {
+	// Exporter the exporter under test
+	Exporter exporters.Exporter
+	// Delivered returns the messages received by the destination so far, in their order of receipt, without
+	// trailing new lines
+	Delivered func() []string
+	// Fail makes the destination fail (true) or accept (false) the following writes, nil if it can't fail
+	Fail func(fail bool)
+	// Dropped returns the number of messages dropped by the exporter, nil if it reports drops as Write errors
+	Dropped func() int
+	// Cleanup releases the destination once the test is done, if set
+	Cleanup func()
+}
+
+// RunContract runs the contract tests on the sinks of newSink, each test runs on a new sink, cleaned up once done:
+//   - Ordering: the messages are delivered in their write order, batches & messages are never duplicated
+//   - Batching: a batch is delivered whole once written & flushed, empty batches are no-ops
+//   - FlushOnClose: Close delivers the messages written & not flushed yet
+//   - DropAccounting: messages written while the destination fails are delivered or accounted as dropped, either
+//     by the failed Write or by the exporter's Dropped count
+func RunContract(t *testing.T, newSink func(t *testing.T) *Sink) {
+	t.Run("Ordering", func(t *testing.T) {
+		sink := newSink(t)
+		defer sink.cleanup()
+		var written []string
+		for i := 0; i < 5; i++ {
+			batch := messages(fmt.Sprintf("ordering-%d", i), i+1)
+			require.NoError(t, sink.Exporter.Write(batch))
+			written = append(written, batch...)
+		}
+		require.NoError(t, sink.Exporter.Flush())
+		requireDelivered(t, sink, written)
+		require.NoError(t, sink.Exporter.Close())
+		requireDelivered(t, sink, written)
+	})
+	t.Run("Batching", func(t *testing.T) {
+		sink := newSink(t)
+		defer sink.cleanup()
+		require.NoError(t, sink.Exporter.Write(nil))
+		batch := messages("batching", 20)
+		batch[0] += "\n"
+		require.NoError(t, sink.Exporter.Write(batch))
+		require.NoError(t, sink.Exporter.Flush())
+		requireDelivered(t, sink, messages("batching", 20))
+		require.NoError(t, sink.Exporter.Close())
+	})
+	t.Run("FlushOnClose", func(t *testing.T) {
+		sink := newSink(t)
+		defer sink.cleanup()
+		flushed := messages("flushed", 2)
+		require.NoError(t, sink.Exporter.Write(flushed))
+		require.NoError(t, sink.Exporter.Flush())
+		closed := messages("closed", 3)
+		require.NoError(t, sink.Exporter.Write(closed))
+		require.NoError(t, sink.Exporter.Close())
+		requireDelivered(t, sink, append(flushed, closed...))
+	})
+	t.Run("DropAccounting", func(t *testing.T) {
+		sink := newSink(t)
+		defer sink.cleanup()
+		if sink.Fail == nil {
+			t.Skip("the sink's destination can't fail")
+		}
+		sink.Fail(true)
+		failed := messages("failed", 4)
+		before, dropped := sink.dropped(), 0
+		if err := sink.Exporter.Write(failed); err != nil {
+			dropped = len(failed)
+		}
+		sink.Fail(false)
+		sent := messages("sent", 2)
+		require.NoError(t, sink.Exporter.Write(sent))
+		require.NoError(t, sink.Exporter.Close())
+
+		delivered := waitDelivered(t, sink, len(sent))
+		require.True(t, len(delivered) >= len(sent), "the sent messages weren't delivered")
+		require.Equal(t, sent, delivered[len(delivered)-len(sent):], "the destination recovered")
+		dropped += sink.dropped() - before
+		require.Equal(
+			t, len(failed), len(delivered)-len(sent)+dropped, "every failed message is delivered or dropped")
+	})
+}
+
+// cleanup runs the sink's Cleanup, if set
+func (s *Sink) cleanup() {
+	if s.Cleanup != nil {
+		s.Cleanup()
+	}
+}
+
+// dropped returns the exporter's count of dropped messages, 0 if it reports drops as Write errors
+func (s *Sink) dropped() int {
+	if s.Dropped == nil {
+		return 0
+	}
+	return s.Dropped()
+}
+
+// messages returns n distinct JSON log messages
+func messages(prefix string, n int) []string {
+	res := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		res = append(res, fmt.Sprintf(`{"level":"info","msg":"%s-%d"}`, prefix, i))
+	}
+	return res
+}
+
+// requireDelivered waits for the destination to receive the expected messages & requires it received exactly them
+func requireDelivered(t *testing.T, sink *Sink, expected []string) {
+	require.Equal(t, expected, waitDelivered(t, sink, len(expected)))
+}
+
+// waitDelivered waits up to the delivery timeout for the destination to receive n messages, it returns the received
+// messages without their trailing new lines
+func waitDelivered(t *testing.T, sink *Sink, n int) []string {
+	deadline := time.Now().Add(deliveryTimeout)
+	for {
+		delivered := sink.Delivered()
+		if len(delivered) >= n || time.Now().After(deadline) {
+			for i := range delivered {
+				delivered[i] = strings.TrimRight(delivered[i], "\n")
+			}
+			return delivered
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,139 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package exporterstest
+
+import (
+	"errors"
+	"strings"
+	"sync"
+)
+
+// ErrClosed the error of writes to a closed FakeExporter
+var ErrClosed = errors.New("the exporter is closed")
+
+// FakeExporter an in-memory exporter, it buffers the written batches & delivers them on Flush or Close. While its
+// error is set, writes fail & their messages are counted as dropped
+type FakeExporter struct {
+	mu        sync.Mutex
+	err       error
+	batches   [][]string // the written batches, in write order
+	buffered  []string
+	delivered []string
+	dropped   int
+	flushes   int
+	closed    bool
+}
+
+// NewFakeExporter returns a new, empty FakeExporter
+func NewFakeExporter() *FakeExporter {
+	return &FakeExporter{}
+}
+
+// Write buffers the batch, it fails with the exporter's error, if set, or once the exporter is closed
+func (f *FakeExporter) Write(messages []string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed {
+		f.dropped += len(messages)
+		return ErrClosed
+	}
+	if f.err != nil {
+		f.dropped += len(messages)
+		return f.err
+	}
+	batch := make([]string, 0, len(messages))
+	for _, msg := range messages {
+		batch = append(batch, strings.TrimRight(msg, "\n"))
+	}
+	f.batches = append(f.batches, batch)
+	f.buffered = append(f.buffered, batch...)
+	return nil
+}
+
+// Flush delivers the buffered messages
+func (f *FakeExporter) Flush() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.flushUnsafe()
+	return nil
+}
+
+// Close delivers the buffered messages & closes the exporter, closing it again is a no-op
+func (f *FakeExporter) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.closed {
+		f.flushUnsafe()
+		f.closed = true
+	}
+	return nil
+}
+
+// SetError makes the following writes fail with err, nil makes them succeed again
+func (f *FakeExporter) SetError(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.err = err
+}
+
+// Batches returns the written batches, in write order
+func (f *FakeExporter) Batches() [][]string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([][]string(nil), f.batches...)
+}
+
+// Delivered returns the flushed messages, in write order
+func (f *FakeExporter) Delivered() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.delivered...)
+}
+
+// Dropped returns the number of messages of the failed writes
+func (f *FakeExporter) Dropped() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.dropped
+}
+
+// Flushes returns the number of flushes, including the flush of Close
+func (f *FakeExporter) Flushes() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.flushes
+}
+
+// Closed returns true once the exporter is closed
+func (f *FakeExporter) Closed() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.closed
+}
+
+// Sink returns the exporter as a Sink of the contract tests
+func (f *FakeExporter) Sink() *Sink {
+	return &Sink{
+		Exporter:  f,
+		Delivered: f.Delivered,
+		Fail: func(fail bool) {
+			if fail {
+				f.SetError(errors.New("fake failure"))
+			} else {
+				f.SetError(nil)
+			}
+		},
+	}
+}
+
+func (f *FakeExporter) flushUnsafe() {
+	f.delivered = append(f.delivered, f.buffered...)
+	f.buffered = nil
+	f.flushes++
+}
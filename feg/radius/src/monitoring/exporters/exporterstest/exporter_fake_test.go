@@ -0,0 +1,48 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package exporterstest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeExporterContract(t *testing.T) {
+	RunContract(t, func(t *testing.T) *Sink {
+		return NewFakeExporter().Sink()
+	})
+}
+
+func TestFakeExporter(t *testing.T) {
+	// Arrange
+	fake := NewFakeExporter()
+
+	// Act
+	require.NoError(t, fake.Write([]string{"first\n", "second"}))
+	delivered := fake.Delivered()
+	fake.SetError(errors.New("unavailable"))
+	failed := fake.Write([]string{"failed"})
+	fake.SetError(nil)
+	require.NoError(t, fake.Write([]string{"third"}))
+	require.NoError(t, fake.Close())
+
+	// Assert
+	require.Empty(t, delivered, "messages are delivered once flushed")
+	require.Error(t, failed)
+	require.Equal(t, [][]string{{"first", "second"}, {"third"}}, fake.Batches())
+	require.Equal(t, []string{"first", "second", "third"}, fake.Delivered())
+	require.Equal(t, 1, fake.Flushes())
+	require.True(t, fake.Closed())
+	require.Equal(t, ErrClosed, fake.Write([]string{"closed"}))
+	require.Equal(t, 2, fake.Dropped())
+	require.NoError(t, fake.Close())
+	require.Equal(t, 1, fake.Flushes())
+}
@@ -0,0 +1,63 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package exporters
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FileConfig the configuration of the exporter appending the tables' logs to files, one JSON log per line
+type FileConfig struct {
+	// Dir the directory of the tables' log files, named <table>.log
+	Dir string `json:"dir" required:"true"`
+}
+
+type fileExporter struct {
+	file *os.File
+}
+
+func newFileExporter(table string, config *FileConfig) (Exporter, error) {
+	if err := os.MkdirAll(config.Dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create log directory %s: %s", config.Dir, err)
+	}
+	path := filepath.Join(config.Dir, table+".log")
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log file %s: %s", path, err)
+	}
+	return &fileExporter{file: file}, nil
+}
+
+// Write appends the batch's logs to the table's file, in a single write
+func (e *fileExporter) Write(messages []string) error {
+	var b strings.Builder
+	for _, msg := range messages {
+		b.WriteString(strings.TrimRight(msg, "\n"))
+		b.WriteByte('\n')
+	}
+	_, err := e.file.WriteString(b.String())
+	return err
+}
+
+// Flush commits the written logs to stable storage
+func (e *fileExporter) Flush() error {
+	return e.file.Sync()
+}
+
+// Close commits the written logs to stable storage & closes the table's file
+func (e *fileExporter) Close() error {
+	syncErr := e.file.Sync()
+	if err := e.file.Close(); err != nil {
+		return err
+	}
+	return syncErr
+}
@@ -0,0 +1,103 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package exporters
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// HTTPSConfig the configuration of the exporter posting the batches as JSON to an HTTP(S) endpoint
+type HTTPSConfig struct {
+	URL string `json:"url" required:"true"`
+	// Headers added to the requests, e.g. Authorization
+	Headers   map[string]string `json:"headers"`
+	TimeoutMs int               `json:"timeout_ms" default:"5000"`
+}
+
+// Validate validates the HTTPS exporter configuration (after defaults are applied)
+func (c *HTTPSConfig) Validate() error {
+	u, err := url.ParseRequestURI(c.URL)
+	if err != nil {
+		return fmt.Errorf("url is invalid: %s", err)
+	}
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return fmt.Errorf("url must be an http(s) URL, got %s", c.URL)
+	}
+	if c.TimeoutMs <= 0 {
+		return fmt.Errorf("timeout_ms must be positive, got %d", c.TimeoutMs)
+	}
+	return nil
+}
+
+// httpsBatch the body of the HTTPS exporter's requests
+type httpsBatch struct {
+	Table string            `json:"table"`
+	Logs  []json.RawMessage `json:"logs"`
+}
+
+type httpsExporter struct {
+	table  string
+	config *HTTPSConfig
+	client *http.Client
+}
+
+func newHTTPSExporter(table string, config *HTTPSConfig) *httpsExporter {
+	return &httpsExporter{
+		table:  table,
+		config: config,
+		client: &http.Client{Timeout: time.Duration(config.TimeoutMs) * time.Millisecond},
+	}
+}
+
+// Write posts the batch as a JSON object of the table name & its logs, any 2xx response is a success
+func (e *httpsExporter) Write(messages []string) error {
+	batch := httpsBatch{Table: e.table, Logs: make([]json.RawMessage, 0, len(messages))}
+	for _, msg := range messages {
+		batch.Logs = append(batch.Logs, rawMessage(strings.TrimSpace(msg)))
+	}
+	body, err := json.Marshal(batch)
+	if err != nil {
+		return fmt.Errorf("failed to serialize %d log(s): %s", len(messages), err)
+	}
+	req, err := http.NewRequest(http.MethodPost, e.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range e.config.Headers {
+		req.Header.Set(name, value)
+	}
+	res, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		resBody, _ := ioutil.ReadAll(res.Body)
+		return fmt.Errorf("got status code %d (%s): %s", res.StatusCode, res.Status, string(resBody))
+	}
+	return nil
+}
+
+// Flush is a no-op, the batches are posted as they are written
+func (e *httpsExporter) Flush() error {
+	return nil
+}
+
+// Close is a no-op, the batches are posted as they are written
+func (e *httpsExporter) Close() error {
+	return nil
+}
@@ -0,0 +1,48 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package exporters
+
+import (
+	"fmt"
+)
+
+// syslogFacilities the syslog facility codes by name (RFC 5424)
+var syslogFacilities = map[string]int{
+	"kern":   0,
+	"user":   1,
+	"daemon": 3,
+	"auth":   4,
+	"local0": 16,
+	"local1": 17,
+	"local2": 18,
+	"local3": 19,
+	"local4": 20,
+	"local5": 21,
+	"local6": 22,
+	"local7": 23,
+}
+
+// SyslogConfig the configuration of the exporter sending the tables' logs to syslog, tagged with the table name
+type SyslogConfig struct {
+	// Network & Address of the syslog server, e.g. udp & 127.0.0.1:514, the local syslog daemon if not set
+	Network  string `json:"network"`
+	Address  string `json:"address"`
+	Facility string `json:"facility" default:"local0"`
+}
+
+// Validate validates the syslog exporter configuration (after defaults are applied)
+func (c *SyslogConfig) Validate() error {
+	if _, ok := syslogFacilities[c.Facility]; !ok {
+		return fmt.Errorf("facility '%s' is unknown", c.Facility)
+	}
+	if (len(c.Network) == 0) != (len(c.Address) == 0) {
+		return fmt.Errorf("network & address must be set together, got network '%s' & address '%s'", c.Network, c.Address)
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+//go:build windows || plan9
+// +build windows plan9
+
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package exporters
+
+import (
+	"errors"
+)
+
+// newSyslogExporter is not supported, log/syslog isn't available on this platform
+func newSyslogExporter(table string, config *SyslogConfig) (Exporter, error) {
+	return nil, errors.New("the syslog exporter is not supported on this platform")
+}
@@ -0,0 +1,65 @@
+//go:build !windows && !plan9
+// +build !windows,!plan9
+
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package exporters
+
+import (
+	"fmt"
+	"log/syslog"
+	"strings"
+)
+
+type syslogExporter struct {
+	writer *syslog.Writer
+}
+
+func newSyslogExporter(table string, config *SyslogConfig) (Exporter, error) {
+	facility := syslog.Priority(syslogFacilities[config.Facility] << 3)
+	writer, err := syslog.Dial(config.Network, config.Address, facility|syslog.LOG_INFO, table)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to syslog: %s", err)
+	}
+	return &syslogExporter{writer: writer}, nil
+}
+
+// Write sends each log of the batch with the severity of its level, the first failure aborts the batch
+func (e *syslogExporter) Write(messages []string) error {
+	for _, msg := range messages {
+		msg = strings.TrimRight(msg, "\n")
+		var err error
+		switch messageLevel(msg) {
+		case "debug":
+			err = e.writer.Debug(msg)
+		case "warn":
+			err = e.writer.Warning(msg)
+		case "error":
+			err = e.writer.Err(msg)
+		case "dpanic", "panic", "fatal":
+			err = e.writer.Crit(msg)
+		default:
+			err = e.writer.Info(msg)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Flush is a no-op, the logs are sent as they are written
+func (e *syslogExporter) Flush() error {
+	return nil
+}
+
+// Close closes the connection to syslog
+func (e *syslogExporter) Close() error {
+	return e.writer.Close()
+}
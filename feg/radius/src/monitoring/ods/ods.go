@@ -21,8 +21,9 @@ import (
 	"strings"
 	"time"
 
-	"github.com/pkg/errors"
+	"fbc/cwf/radius/monitoring/counters/census"
 
+	"github.com/pkg/errors"
 	"go.opencensus.io/plugin/ochttp"
 	"go.opencensus.io/stats/view"
 	"go.uber.org/zap"
@@ -49,6 +50,14 @@ type Config struct {
 	ReportingPeriod time.Duration `json:"reporting_period" default:"60s"`
 }
 
+// Validate validates the ODS configuration (after defaults are applied)
+func (c *Config) Validate() error {
+	if c.ReportingPeriod <= 0 {
+		return fmt.Errorf("reporting_period must be positive, got %s", c.ReportingPeriod)
+	}
+	return nil
+}
+
 // Datapoint is used to Marshal JSON encoding for ODS data submission
 // see https://phabricator.intern.facebook.com/diffusion/E/browse/tfb/trunk/www/flib/platform/graph/resources/ods/metrics/GraphOdsMetricsPost.php
 // for types accepted
@@ -185,8 +194,8 @@ func Init(odsConfig *Config, logger *zap.Logger) {
 	logger.Info("initializing ODS counters", zap.String("config", string(config)))
 
 	log.SetFlags(0)
-	view.RegisterExporter(&odsMetricsExporter{
+	view.RegisterExporter(census.WithBaseline(&odsMetricsExporter{
 		config: *odsConfig,
-	})
+	}))
 	view.SetReportingPeriod(odsConfig.ReportingPeriod)
 }
@@ -0,0 +1,156 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package scuba
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// probeResult the result of a single endpoint probe
+type probeResult struct {
+	healthy bool
+	latency time.Duration
+}
+
+// endpointSelector periodically probes the configured Graph endpoints & selects the fastest healthy one. The
+// selected endpoint is only replaced by a faster one if it's faster by more than the switch threshold, so similar
+// endpoints don't cause flapping
+type endpointSelector struct {
+	urls            []string
+	switchThreshold float64 // fraction of the current endpoint latency
+	client          *http.Client
+	logger          *zap.Logger
+
+	mu      sync.RWMutex // guards urls & current
+	current int
+}
+
+func newEndpointSelector(config *Config, logger *zap.Logger) *endpointSelector {
+	timeout := time.Duration(config.ProbeTimeoutMs) * time.Millisecond
+	if timeout <= 0 {
+		timeout = time.Second
+	}
+	return &endpointSelector{
+		urls:            graphURLs(config),
+		switchThreshold: float64(config.SwitchThresholdPct) / 100,
+		client:          &http.Client{Timeout: timeout},
+		logger:          logger,
+	}
+}
+
+// graphURLs returns the configured Graph endpoints, GraphURL first
+func graphURLs(config *Config) []string {
+	urls := []string{config.GraphURL}
+	for _, u := range config.GraphURLs {
+		if u != config.GraphURL {
+			urls = append(urls, u)
+		}
+	}
+	return urls
+}
+
+// setURLs replaces the endpoints with the configuration's, the selected endpoint is kept if it's still configured
+func (s *endpointSelector) setURLs(config *Config) {
+	urls := graphURLs(config)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	selected := s.urls[s.current]
+	s.urls, s.current = urls, 0
+	for i, u := range urls {
+		if u == selected {
+			s.current = i
+		}
+	}
+}
+
+// URL returns the selected endpoint
+func (s *endpointSelector) URL() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.urls[s.current]
+}
+
+// run probes the endpoints every interval while there are several, it never returns
+func (s *endpointSelector) run(interval time.Duration) {
+	for {
+		s.mu.RLock()
+		several := len(s.urls) > 1
+		s.mu.RUnlock()
+		if several {
+			s.update(s.probeAll())
+		}
+		time.Sleep(interval)
+	}
+}
+
+func (s *endpointSelector) probeAll() []probeResult {
+	s.mu.RLock()
+	urls := s.urls
+	s.mu.RUnlock()
+	results := make([]probeResult, len(urls))
+	var wg sync.WaitGroup
+	for i, u := range urls {
+		wg.Add(1)
+		go func(i int, u string) {
+			defer wg.Done()
+			results[i] = s.probe(u)
+		}(i, u)
+	}
+	wg.Wait()
+	return results
+}
+
+// probe measures the endpoint's round trip, any response but a server error means the endpoint is healthy
+func (s *endpointSelector) probe(u string) probeResult {
+	start := time.Now()
+	res, err := s.client.Head(u)
+	if err != nil {
+		return probeResult{}
+	}
+	res.Body.Close()
+	return probeResult{healthy: res.StatusCode < http.StatusInternalServerError, latency: time.Since(start)}
+}
+
+// update selects the endpoint as per the probe results: an unhealthy selected endpoint is replaced by the fastest
+// healthy one, a healthy one only if the fastest is faster by more than the switch threshold. Results racing a reload
+// of a different number of endpoints are ignored
+func (s *endpointSelector) update(results []probeResult) {
+	fastest := -1
+	for i, r := range results {
+		if r.healthy && (fastest < 0 || r.latency < results[fastest].latency) {
+			fastest = i
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(results) != len(s.urls) {
+		return
+	}
+	current := results[s.current]
+	if fastest < 0 || fastest == s.current {
+		return
+	}
+	if current.healthy &&
+		float64(results[fastest].latency) >= float64(current.latency)*(1-s.switchThreshold) {
+		return
+	}
+	s.logger.Info(
+		"switching scuba Graph endpoint",
+		zap.String("from", s.urls[s.current]),
+		zap.Bool("from_healthy", current.healthy),
+		zap.Duration("from_latency", current.latency),
+		zap.String("to", s.urls[fastest]),
+		zap.Duration("to_latency", results[fastest].latency),
+	)
+	s.current = fastest
+}
@@ -0,0 +1,89 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package scuba
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestEndpointSelection(t *testing.T) {
+	// Arrange
+	logger, _ := zap.NewDevelopment()
+	s := newEndpointSelector(&Config{
+		GraphURL:           "http://a/scribe_logs",
+		GraphURLs:          []string{"http://b/scribe_logs", "http://c/scribe_logs"},
+		SwitchThresholdPct: 20,
+	}, logger)
+	require.Equal(t, "http://a/scribe_logs", s.URL())
+
+	// Act - slightly faster endpoints don't replace a healthy one
+	s.update([]probeResult{
+		{healthy: true, latency: 100 * time.Millisecond},
+		{healthy: true, latency: 90 * time.Millisecond},
+		{healthy: false},
+	})
+
+	// Assert
+	require.Equal(t, "http://a/scribe_logs", s.URL())
+
+	// Act - much faster endpoints do
+	s.update([]probeResult{
+		{healthy: true, latency: 100 * time.Millisecond},
+		{healthy: true, latency: 50 * time.Millisecond},
+		{healthy: true, latency: 70 * time.Millisecond},
+	})
+
+	// Assert
+	require.Equal(t, "http://b/scribe_logs", s.URL())
+
+	// Act - unhealthy endpoints are replaced by the fastest healthy one
+	s.update([]probeResult{
+		{healthy: true, latency: 100 * time.Millisecond},
+		{healthy: false},
+		{healthy: true, latency: 70 * time.Millisecond},
+	})
+
+	// Assert
+	require.Equal(t, "http://c/scribe_logs", s.URL())
+
+	// Act - the endpoint is kept if none is healthy
+	s.update([]probeResult{{}, {}, {}})
+
+	// Assert
+	require.Equal(t, "http://c/scribe_logs", s.URL())
+}
+
+func TestEndpointProbe(t *testing.T) {
+	// Arrange
+	logger, _ := zap.NewDevelopment()
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer healthy.Close()
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer failing.Close()
+	s := newEndpointSelector(&Config{GraphURL: failing.URL, GraphURLs: []string{healthy.URL}}, logger)
+
+	// Act
+	results := s.probeAll()
+	s.update(results)
+
+	// Assert
+	require.False(t, results[0].healthy)
+	require.True(t, results[1].healthy)
+	require.Equal(t, healthy.URL, s.URL())
+}
@@ -0,0 +1,59 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package scuba
+
+import (
+	"fmt"
+
+	"fbc/cwf/radius/monitoring/exporters"
+)
+
+// scubaExporter exports the table's batches to Scuba as Scribe entries of the table's category
+type scubaExporter struct {
+	sender   *sender
+	table    string
+	category string
+}
+
+// Write sends the batch, the sender retries, spools & reports the failed batches so Write doesn't fail
+func (e *scubaExporter) Write(messages []string) error {
+	e.sender.send(e.table, e.entries(messages))
+	return nil
+}
+
+// entries returns the Scribe entries of the table's messages
+func (e *scubaExporter) entries(messages []string) []ScribeEntry {
+	entries := make([]ScribeEntry, 0, len(messages))
+	for _, msg := range messages {
+		entries = append(entries, ScribeEntry{
+			Category: e.category,
+			Message:  fmt.Sprintf("perfpipe_%s %s", e.table, msg),
+		})
+	}
+	return entries
+}
+
+// Flush is a no-op, the batches are sent as they are written
+func (e *scubaExporter) Flush() error {
+	return nil
+}
+
+// Close is a no-op, the sender is shared by all the tables
+func (e *scubaExporter) Close() error {
+	return nil
+}
+
+// newExporter returns the exporter of the table, per its effective configuration
+func newExporter(config *Config, sender *sender, table string) (exporters.Exporter, error) {
+	tableCfg := config.tableConfig(table)
+	if tableCfg.Exporter == exporters.Scuba {
+		return &scubaExporter{sender: sender, table: table, category: tableCfg.Category}, nil
+	}
+	return exporters.New(tableCfg.Exporter, table, &config.Exporters)
+}
@@ -0,0 +1,227 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package scuba
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Priority the priority tier of a scuba message, lower tiers are sampled & dropped first
+type Priority int
+
+// Priority tiers, in drop order
+const (
+	PriorityDebug Priority = iota
+	PriorityNormal
+	PriorityCritical
+	numPriorities
+)
+
+// PriorityKey the log field overriding the level based priority of a message
+const PriorityKey = "scuba_priority"
+
+var priorityNames = [numPriorities]string{"debug", "normal", "critical"}
+
+func (p Priority) String() string {
+	if p < 0 || p >= numPriorities {
+		return fmt.Sprintf("Priority(%d)", int(p))
+	}
+	return priorityNames[p]
+}
+
+// ParsePriority returns the priority of the given name
+func ParsePriority(name string) (Priority, error) {
+	for p, n := range priorityNames {
+		if n == name {
+			return Priority(p), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown scuba priority '%s'", name)
+}
+
+// PriorityField a log field setting the message's priority, e.g. PriorityField(PriorityCritical) for billing
+// impacting events which must not be dropped before other messages
+func PriorityField(p Priority) zap.Field {
+	return zap.String(PriorityKey, p.String())
+}
+
+// messagePriority returns the priority of the JSON encoded log message: as set by its PriorityField, otherwise
+// debug for debug logs, critical for error (and above) logs & normal for the rest
+func messagePriority(msg []byte) Priority {
+	var fields struct {
+		Level    string `json:"level"`
+		Priority string `json:"scuba_priority"`
+	}
+	if err := json.Unmarshal(msg, &fields); err != nil {
+		return PriorityNormal
+	}
+	if p, err := ParsePriority(fields.Priority); err == nil {
+		return p
+	}
+	switch fields.Level {
+	case "debug":
+		return PriorityDebug
+	case "error", "dpanic", "panic", "fatal":
+		return PriorityCritical
+	default:
+		return PriorityNormal
+	}
+}
+
+// droppedMessages the number of messages dropped due to a full queue, per priority
+var droppedMessages [numPriorities]uint64
+
+// DroppedMessages returns the number of messages dropped due to full queues, per priority
+func DroppedMessages() map[Priority]uint64 {
+	result := make(map[Priority]uint64, numPriorities)
+	for p := range droppedMessages {
+		result[Priority(p)] = atomic.LoadUint64(&droppedMessages[p])
+	}
+	return result
+}
+
+// priorityQueue a bounded message queue which, when full, makes room for a message by dropping the oldest message
+// of the lowest priority lower than its own, so higher priority messages are never dropped before lower ones. A full
+// queue without lower priority messages drops the new message, or the oldest message of its priority if dropOldest
+type priorityQueue struct {
+	mu         sync.Mutex
+	tiers      [numPriorities][]string
+	size       int
+	capacity   int
+	dropOldest bool
+	dropped    func(p Priority) // called with the priority of each dropped message, if set
+	ready      chan struct{}    // signaled when a message is pushed
+	room       chan struct{}    // closed (& cleared) when a message is popped, if awaited
+}
+
+func newPriorityQueue(capacity int) *priorityQueue {
+	return &priorityQueue{capacity: capacity, ready: make(chan struct{}, 1)}
+}
+
+// push queues the message, it returns false if the message was dropped
+func (q *priorityQueue) push(msg string, p Priority) bool {
+	q.mu.Lock()
+	if q.size >= q.capacity && !q.evictBelow(p) {
+		if !q.dropOldest || len(q.tiers[p]) == 0 {
+			q.drop(p)
+			q.mu.Unlock()
+			return false
+		}
+		q.tiers[p] = q.tiers[p][1:]
+		q.size--
+		q.drop(p)
+	}
+	q.tiers[p] = append(q.tiers[p], msg)
+	q.size++
+	q.mu.Unlock()
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+	return true
+}
+
+// evictBelow drops the oldest message of the lowest priority lower than p, it returns false if there's none
+func (q *priorityQueue) evictBelow(p Priority) bool {
+	for lower := PriorityDebug; lower < p; lower++ {
+		if len(q.tiers[lower]) > 0 {
+			q.tiers[lower] = q.tiers[lower][1:]
+			q.size--
+			q.drop(lower)
+			return true
+		}
+	}
+	return false
+}
+
+// drop counts a dropped message of priority p, it must be called with the lock held
+func (q *priorityQueue) drop(p Priority) {
+	atomic.AddUint64(&droppedMessages[p], 1)
+	if q.dropped != nil {
+		q.dropped(p)
+	}
+}
+
+// awaitRoom waits up to timeout until the queue has room for a message of priority p, without dropping a message of
+// its priority. It returns false if the queue is still full
+func (q *priorityQueue) awaitRoom(p Priority, timeout time.Duration) bool {
+	var deadline *time.Timer
+	q.mu.Lock()
+	for q.size >= q.capacity && !q.hasBelow(p) {
+		if deadline == nil {
+			deadline = time.NewTimer(timeout)
+			defer deadline.Stop()
+		}
+		if q.room == nil {
+			q.room = make(chan struct{})
+		}
+		room := q.room
+		q.mu.Unlock()
+		select {
+		case <-room:
+		case <-deadline.C:
+			return false
+		}
+		q.mu.Lock()
+	}
+	q.mu.Unlock()
+	return true
+}
+
+// hasBelow returns true if the queue has messages of lower priorities than p, it must be called with the lock held
+func (q *priorityQueue) hasBelow(p Priority) bool {
+	for lower := PriorityDebug; lower < p; lower++ {
+		if len(q.tiers[lower]) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// tryPop returns the oldest message of the highest priority, if any
+func (q *priorityQueue) tryPop() (string, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for p := numPriorities - 1; p >= PriorityDebug; p-- {
+		if len(q.tiers[p]) > 0 {
+			msg := q.tiers[p][0]
+			q.tiers[p] = q.tiers[p][1:]
+			q.size--
+			if q.room != nil {
+				close(q.room)
+				q.room = nil
+			}
+			return msg, true
+		}
+	}
+	return "", false
+}
+
+// len returns the number of queued messages
+func (q *priorityQueue) len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.size
+}
+
+// pop returns the oldest message of the highest priority, waiting for one if the queue is empty
+func (q *priorityQueue) pop() string {
+	for {
+		if msg, ok := q.tryPop(); ok {
+			return msg
+		}
+		<-q.ready
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package scuba
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPriorityQueueDropOrder(t *testing.T) {
+	// Arrange
+	q := newPriorityQueue(3)
+	require.True(t, q.push("debug1", PriorityDebug))
+	require.True(t, q.push("normal1", PriorityNormal))
+	require.True(t, q.push("debug2", PriorityDebug))
+
+	// Act
+	criticalQueued := q.push("critical1", PriorityCritical)
+	debugQueued := q.push("debug3", PriorityDebug)
+	normalQueued := q.push("normal2", PriorityNormal)
+
+	// Assert - the full queue dropped the debug messages to make room for the higher priority ones
+	require.True(t, criticalQueued)
+	require.False(t, debugQueued)
+	require.True(t, normalQueued)
+	require.Equal(t, "critical1", q.pop())
+	require.Equal(t, "normal1", q.pop())
+	require.Equal(t, "normal2", q.pop())
+	_, ok := q.tryPop()
+	require.False(t, ok)
+}
+
+func TestPriorityQueueDropOldest(t *testing.T) {
+	// Arrange
+	q := newPriorityQueue(2)
+	q.dropOldest = true
+	var dropped []Priority
+	q.dropped = func(p Priority) { dropped = append(dropped, p) }
+	require.True(t, q.push("normal1", PriorityNormal))
+	require.True(t, q.push("critical1", PriorityCritical))
+
+	// Act
+	normalQueued := q.push("normal2", PriorityNormal)
+	debugQueued := q.push("debug1", PriorityDebug)
+
+	// Assert - the oldest normal message made room for the new one, the debug one had nothing to drop
+	require.True(t, normalQueued)
+	require.False(t, debugQueued)
+	require.Equal(t, []Priority{PriorityNormal, PriorityDebug}, dropped)
+	require.Equal(t, "critical1", q.pop())
+	require.Equal(t, "normal2", q.pop())
+}
+
+func TestPriorityQueueAwaitRoom(t *testing.T) {
+	// Arrange
+	q := newPriorityQueue(1)
+	require.True(t, q.push("normal1", PriorityNormal))
+
+	// Act & Assert - a full queue times out, unless a lower priority message can be dropped or a message is popped
+	require.False(t, q.awaitRoom(PriorityNormal, 10*time.Millisecond))
+	require.True(t, q.awaitRoom(PriorityCritical, time.Millisecond))
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		q.pop()
+	}()
+	require.True(t, q.awaitRoom(PriorityNormal, 5*time.Second))
+}
+
+func TestMessagePriority(t *testing.T) {
+	require.Equal(t, PriorityDebug, messagePriority([]byte(`{"level":"debug","msg":"x"}`)))
+	require.Equal(t, PriorityNormal, messagePriority([]byte(`{"level":"info","msg":"x"}`)))
+	require.Equal(t, PriorityCritical, messagePriority([]byte(`{"level":"error","msg":"x"}`)))
+	require.Equal(t, PriorityCritical, messagePriority([]byte(`{"level":"info","scuba_priority":"critical"}`)))
+	require.Equal(t, PriorityNormal, messagePriority([]byte(`not json`)))
+}
@@ -0,0 +1,81 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package scuba
+
+import (
+	"errors"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"go.uber.org/zap"
+)
+
+// live the initialized sink, whose configuration is swapped by Reload
+var live liveSink
+
+type liveSink struct {
+	mu       sync.Mutex
+	sender   *sender
+	endpoint *endpointSelector
+	syncers  []*scubaWriteSyncer
+}
+
+// add adds the table's syncer created by the sink of the sender & endpoint selector
+func (l *liveSink) add(sender *sender, endpoint *endpointSelector, syncer *scubaWriteSyncer) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sender, l.endpoint = sender, endpoint
+	l.syncers = append(l.syncers, syncer)
+}
+
+// reload swaps the configuration of the sender, the endpoint selector & the tables' syncers
+func (l *liveSink) reload(config *Config) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.sender == nil {
+		return errors.New("no scuba logger was created")
+	}
+	l.sender.config.Store(config)
+	l.endpoint.setURLs(config)
+	for _, syncer := range l.syncers {
+		syncer.reload(config)
+	}
+	return nil
+}
+
+// Reload swaps the configuration of the initialized sink without a restart or losing the queued messages. The access
+// token, Graph endpoints & the tables' batch sizes, flush intervals & sampling apply from the next batch on, the other
+// fields (e.g. the queues, retries, spool & exporters) only apply after a restart
+func Reload(config *Config) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	return live.reload(config)
+}
+
+// ReloadOnSIGHUP reloads the configuration returned by load on every SIGHUP, e.g. once the access token is rotated
+func ReloadOnSIGHUP(load func() (*Config, error), logger *zap.Logger) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	go func() {
+		for range signals {
+			config, err := load()
+			if err == nil {
+				err = Reload(config)
+			}
+			if err != nil {
+				logger.Error("failed reloading scuba configuration, the current configuration is kept", zap.Error(err))
+				continue
+			}
+			logger.Info("reloaded scuba configuration")
+		}
+	}()
+}
@@ -9,15 +9,18 @@ LICENSE file in the root directory of this source tree.
 package scuba
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
-	"io/ioutil"
-	"net/http"
+	"math/rand"
 	"net/url"
-	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"fbc/cwf/radius/monitoring/counters"
+	"fbc/cwf/radius/monitoring/exporters"
+	"fbc/lib/go/retry"
+
 	"go.uber.org/zap"
 )
 
@@ -26,38 +29,361 @@ import (
 // for more details
 const ANY_SCUBA_CATEGORY string = "xwf_json_to_any_scuba"
 
+// Full queue policies, applied once no lower priority message can be dropped to make room for a new message
+const (
+	QueueDropNewest = "drop_newest" // the new message is dropped
+	QueueDropOldest = "drop_oldest" // the oldest message of the new message's priority is dropped
+	QueueBlock      = "block"       // the logging goroutine waits up to the timeout for room, then drops the new message
+)
+
+var (
+	queueDepthGauge = counters.NewGauge(
+		"scuba_queue_depth", "Messages queued for a scuba table", counters.TableTag)
+	droppedMessagesGauge = counters.NewGauge(
+		"scuba_dropped_messages", "Messages of a scuba table dropped due to its full queue, per priority",
+		counters.TableTag, counters.PriorityTag)
+	truncatedMessagesGauge = counters.NewGauge(
+		"scuba_truncated_messages", "Messages of a scuba table truncated to fit in a batch", counters.TableTag)
+)
+
 // Config scuba logger config for the service
 type Config struct {
-	MessageQueueSize int    `json:"message_queue_size" default:"2000"`
-	FlushIntervalSec int    `json:"flush_interval_sec" default:"2"`
-	BatchSize        int    `json:"batch_size" default:"15"`
-	GraphURL         string `json:"graph_url" default:"https://graph.facebook.com/scribe_logs"`
-	AccessToken      string
+	MessageQueueSize int `json:"message_queue_size" default:"2000"`
+	// QueueFullPolicy what a table's full queue drops: drop_newest, drop_oldest or block (up to QueueFullTimeoutMs)
+	QueueFullPolicy    string `json:"queue_full_policy" default:"drop_newest"`
+	QueueFullTimeoutMs int    `json:"queue_full_timeout_ms" default:"100"`
+	FlushIntervalSec   int    `json:"flush_interval_sec" default:"2"`
+	BatchSize          int    `json:"batch_size" default:"15"`
+	// MaxBatchBytes the maximum size of a batch's messages, e.g. to fit in the Graph API request size limit. Larger
+	// batches are split & messages larger than it are truncated, flagged with the TruncatedKey field
+	MaxBatchBytes int    `json:"max_batch_bytes" default:"1000000"`
+	GraphURL      string `json:"graph_url" default:"https://graph.facebook.com/scribe_logs"`
+	// GraphURLs additional (e.g. regional) Graph endpoints, the fastest healthy one of these & GraphURL is used
+	GraphURLs          []string `json:"graph_urls"`
+	ProbeIntervalSec   int      `json:"probe_interval_sec" default:"30"`
+	ProbeTimeoutMs     int      `json:"probe_timeout_ms" default:"1000"`
+	SwitchThresholdPct int      `json:"switch_threshold_pct" default:"20"`
+	// DrainTimeoutSec the maximum time Sync & Close wait for the queued messages to be sent
+	DrainTimeoutSec int `json:"drain_timeout_sec" default:"5"`
+	// PrioritySampling per priority (debug, normal, critical) fraction of messages sent to scuba, in (0, 1]
+	PrioritySampling map[string]float64 `json:"priority_sampling"`
+	// Tables per table overrides of the sink configuration, keyed by the NewLogger table name
+	Tables map[string]TableConfig `json:"tables"`
+	// Retry retries of the batches failing with transient errors (network errors, 429 & 5xx responses), batches aren't
+	// retried if not set. Its retryableCodes don't apply
+	Retry *retry.Config `json:"retry"`
+	// SpoolDir the directory batches are spooled to when failing after the retries, to resend them every
+	// SpoolRetryIntervalSec, including after restarts. Failed batches are dropped if not set
+	SpoolDir              string `json:"spool_dir"`
+	SpoolMaxBatches       int    `json:"spool_max_batches" default:"1000"`
+	SpoolRetryIntervalSec int    `json:"spool_retry_interval_sec" default:"30"`
+	// Exporter the exporter of the tables' logs (scuba, https, syslog or file), unless overridden per table
+	Exporter string `json:"exporter" default:"scuba"`
+	// Exporters the configuration of the exporters other than scuba, required by the tables exporting to them
+	Exporters   exporters.Config `json:"exporters"`
+	AccessToken string
+}
+
+// TableConfig per table scuba sink configuration, unset (zero) fields fall back to the global configuration
+type TableConfig struct {
+	FlushIntervalSec int    `json:"flush_interval_sec"`
+	BatchSize        int    `json:"batch_size"`
+	MaxBatchBytes    int    `json:"max_batch_bytes"`
+	Category         string `json:"category"`
+	// SampleRatio the fraction of the table's messages sent to scuba, in (0, 1]
+	SampleRatio float64 `json:"sample_ratio"`
+	// Exporter the exporter of the table's logs, the Category only applies to the scuba exporter
+	Exporter string `json:"exporter"`
+}
+
+// prioritySampling returns the sampling ratio of each priority
+func (c *Config) prioritySampling() [numPriorities]float64 {
+	var result [numPriorities]float64
+	for p := range result {
+		result[p] = 1
+		if ratio, ok := c.PrioritySampling[Priority(p).String()]; ok && ratio > 0 {
+			result[p] = ratio
+		}
+	}
+	return result
+}
+
+// tableConfig returns the effective configuration of the table
+func (c *Config) tableConfig(table string) TableConfig {
+	result := TableConfig{
+		FlushIntervalSec: c.FlushIntervalSec,
+		BatchSize:        c.BatchSize,
+		MaxBatchBytes:    c.MaxBatchBytes,
+		Category:         ANY_SCUBA_CATEGORY,
+		SampleRatio:      1,
+		Exporter:         c.Exporter,
+	}
+	if len(result.Exporter) == 0 {
+		result.Exporter = exporters.Scuba
+	}
+	override, ok := c.Tables[table]
+	if !ok {
+		return result
+	}
+	if override.FlushIntervalSec > 0 {
+		result.FlushIntervalSec = override.FlushIntervalSec
+	}
+	if override.BatchSize > 0 {
+		result.BatchSize = override.BatchSize
+	}
+	if override.MaxBatchBytes > 0 {
+		result.MaxBatchBytes = override.MaxBatchBytes
+	}
+	if len(override.Category) > 0 {
+		result.Category = override.Category
+	}
+	if override.SampleRatio > 0 {
+		result.SampleRatio = override.SampleRatio
+	}
+	if len(override.Exporter) > 0 {
+		result.Exporter = override.Exporter
+	}
+	return result
+}
+
+// Validate validates the scuba configuration (after defaults are applied)
+func (c *Config) Validate() error {
+	if c.MessageQueueSize <= 0 {
+		return fmt.Errorf("message_queue_size must be positive, got %d", c.MessageQueueSize)
+	}
+	switch c.QueueFullPolicy {
+	case "", QueueDropNewest, QueueDropOldest:
+	case QueueBlock:
+		if c.QueueFullTimeoutMs <= 0 {
+			return fmt.Errorf("queue_full_timeout_ms must be positive, got %d", c.QueueFullTimeoutMs)
+		}
+	default:
+		return fmt.Errorf("queue_full_policy must be %s, %s or %s, got '%s'",
+			QueueDropNewest, QueueDropOldest, QueueBlock, c.QueueFullPolicy)
+	}
+	if c.FlushIntervalSec <= 0 {
+		return fmt.Errorf("flush_interval_sec must be positive, got %d", c.FlushIntervalSec)
+	}
+	if c.BatchSize <= 0 {
+		return fmt.Errorf("batch_size must be positive, got %d", c.BatchSize)
+	}
+	if c.MaxBatchBytes < 0 {
+		return fmt.Errorf("max_batch_bytes must not be negative, got %d", c.MaxBatchBytes)
+	}
+	if _, err := url.ParseRequestURI(c.GraphURL); err != nil {
+		return fmt.Errorf("graph_url is invalid: %s", err)
+	}
+	for _, u := range c.GraphURLs {
+		if _, err := url.ParseRequestURI(u); err != nil {
+			return fmt.Errorf("graph_urls entry '%s' is invalid: %s", u, err)
+		}
+	}
+	if len(c.GraphURLs) > 0 && c.ProbeIntervalSec <= 0 {
+		return fmt.Errorf("probe_interval_sec must be positive, got %d", c.ProbeIntervalSec)
+	}
+	if c.SwitchThresholdPct < 0 || c.SwitchThresholdPct >= 100 {
+		return fmt.Errorf("switch_threshold_pct must be in [0, 100), got %d", c.SwitchThresholdPct)
+	}
+	if c.DrainTimeoutSec <= 0 {
+		return fmt.Errorf("drain_timeout_sec must be positive, got %d", c.DrainTimeoutSec)
+	}
+	if c.Retry != nil {
+		if _, err := retry.New(*c.Retry); err != nil {
+			return fmt.Errorf("retry is invalid: %s", err)
+		}
+	}
+	if len(c.SpoolDir) > 0 {
+		if c.SpoolMaxBatches <= 0 {
+			return fmt.Errorf("spool_max_batches must be positive, got %d", c.SpoolMaxBatches)
+		}
+		if c.SpoolRetryIntervalSec <= 0 {
+			return fmt.Errorf("spool_retry_interval_sec must be positive, got %d", c.SpoolRetryIntervalSec)
+		}
+	}
+	if err := c.Exporters.Validate(c.Exporter); err != nil {
+		return fmt.Errorf("exporter is invalid: %s", err)
+	}
+	for name, ratio := range c.PrioritySampling {
+		if _, err := ParsePriority(name); err != nil {
+			return fmt.Errorf("priority_sampling is invalid: %s", err)
+		}
+		if ratio <= 0 || ratio > 1 {
+			return fmt.Errorf("priority_sampling.%s must be in (0, 1], got %f", name, ratio)
+		}
+	}
+	for table, tc := range c.Tables {
+		if tc.FlushIntervalSec < 0 {
+			return fmt.Errorf("tables.%s.flush_interval_sec must not be negative, got %d", table, tc.FlushIntervalSec)
+		}
+		if tc.BatchSize < 0 {
+			return fmt.Errorf("tables.%s.batch_size must not be negative, got %d", table, tc.BatchSize)
+		}
+		if tc.MaxBatchBytes < 0 {
+			return fmt.Errorf("tables.%s.max_batch_bytes must not be negative, got %d", table, tc.MaxBatchBytes)
+		}
+		if tc.SampleRatio < 0 || tc.SampleRatio > 1 {
+			return fmt.Errorf("tables.%s.sample_ratio must be in (0, 1], got %f", table, tc.SampleRatio)
+		}
+		if len(tc.Exporter) > 0 {
+			if err := c.Exporters.Validate(tc.Exporter); err != nil {
+				return fmt.Errorf("tables.%s.exporter is invalid: %s", table, err)
+			}
+		}
+	}
+	return nil
+}
+
+// tableSettings the table's effective batching & sampling settings, swapped on configuration reloads
+type tableSettings struct {
+	tableCfg TableConfig
+	sampling [numPriorities]float64
 }
 
 type scubaWriteSyncer struct {
-	disabled bool
 	config   *Config
+	exporter exporters.Exporter
 	url      url.URL
 	table    string
-	msgQ     chan string
+	settings atomic.Value // *tableSettings
+	msgQ     *priorityQueue
+	timeout  time.Duration // Sync & Close wait up to timeout for the queued messages to be sent
+	// blockTimeout the time Write waits for room in the full queue, 0 - a full queue drops messages right away
+	blockTimeout time.Duration
+	depth        counters.Gauge
+	dropped      [numPriorities]uint64 // messages dropped due to the full queue, per priority
+	droppedGauge [numPriorities]counters.Gauge
+	truncated    uint64 // messages truncated to fit in a batch
+	truncGauge   counters.Gauge
+
+	mu       sync.Mutex    // guards closed & inflight, queue pops & pushes are done under mu
+	closed   bool          // no more messages are queued, serve exits once the queue is drained
+	inflight int           // messages popped from the queue & not sent yet
+	drained  chan struct{} // closed (& replaced) when the queue is empty & no messages are in flight
+	closing  chan struct{} // closed by Close
+	done     chan struct{} // closed when serve exits, after closing the exporter
+	closeErr error         // the error closing the exporter, set before done is closed
+}
+
+func newScubaWriteSyncer(config *Config, sender *sender, u url.URL) (*scubaWriteSyncer, error) {
+	exporter, err := newExporter(config, sender, u.Hostname())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the exporter of table %s: %s", u.Hostname(), err)
+	}
+	s := &scubaWriteSyncer{
+		config:     config,
+		exporter:   exporter,
+		url:        u,
+		table:      u.Hostname(),
+		msgQ:       newPriorityQueue(config.MessageQueueSize),
+		timeout:    time.Second * time.Duration(config.DrainTimeoutSec),
+		depth:      queueDepthGauge.SetTag(counters.TableTag, u.Hostname()),
+		truncGauge: truncatedMessagesGauge.SetTag(counters.TableTag, u.Hostname()),
+		drained:    make(chan struct{}),
+		closing:    make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+	s.reload(config)
+	for p := range s.droppedGauge {
+		s.droppedGauge[p] = droppedMessagesGauge.SetTag(counters.TableTag, s.table).
+			SetTag(counters.PriorityTag, Priority(p).String())
+	}
+	switch config.QueueFullPolicy {
+	case QueueDropOldest:
+		s.msgQ.dropOldest = true
+	case QueueBlock:
+		s.blockTimeout = time.Millisecond * time.Duration(config.QueueFullTimeoutMs)
+	}
+	s.msgQ.dropped = s.countDropped
+	return s, nil
+}
+
+// reload applies the table's batching & sampling settings of the configuration, from the next batch on
+func (s *scubaWriteSyncer) reload(config *Config) {
+	s.settings.Store(&tableSettings{tableCfg: config.tableConfig(s.table), sampling: config.prioritySampling()})
+}
+
+// countDropped counts & reports a message dropped due to the full queue
+func (s *scubaWriteSyncer) countDropped(p Priority) {
+	s.droppedGauge[p].Record(int64(atomic.AddUint64(&s.dropped[p], 1)))
+	s.depth.Record(int64(s.msgQ.capacity))
 }
 
 func (s *scubaWriteSyncer) Write(p []byte) (int, error) {
-	if s.disabled {
+	settings := s.settings.Load().(*tableSettings)
+	if settings.tableCfg.SampleRatio < 1 && rand.Float64() >= settings.tableCfg.SampleRatio {
+		return len(p), nil
+	}
+	priority := messagePriority(p)
+	if ratio := settings.sampling[priority]; ratio < 1 && rand.Float64() >= ratio {
+		return len(p), nil
+	}
+	if s.blockTimeout > 0 {
+		// waits outside of mu, so the queue keeps being served
+		s.msgQ.awaitRoom(priority, s.blockTimeout)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
 		return 0, errors.New("Logger is already closed, cannot write")
 	}
-	s.msgQ <- string(p)
+	// a full queue drops lower priority messages first, then applies the queue full policy
+	s.msgQ.push(string(p), priority)
+	s.depth.Record(int64(s.msgQ.len()))
 	return len(p), nil
 }
 
+// Sync waits up to the drain timeout for the queued messages to be sent, then flushes the exporter
 func (s *scubaWriteSyncer) Sync() error {
-	return nil
+	if err := s.flush(s.timeout); err != nil {
+		return err
+	}
+	return s.exporter.Flush()
 }
 
+// Close stops queueing messages & waits up to the drain timeout for the queued messages to be sent & serve to exit,
+// closing the exporter. Messages not sent within the timeout are still sent in the background
 func (s *scubaWriteSyncer) Close() error {
-	s.disabled = true
-	return nil
+	s.mu.Lock()
+	if !s.closed {
+		s.closed = true
+		close(s.closing)
+	}
+	s.mu.Unlock()
+	deadline := time.NewTimer(s.timeout)
+	defer deadline.Stop()
+	select {
+	case <-s.done:
+		return s.closeErr
+	case <-deadline.C:
+		return fmt.Errorf("timed out closing scuba table %s, %d log(s) are not sent yet", s.table, s.pending())
+	}
+}
+
+// flush waits up to timeout until the queue is empty & no messages are in flight
+func (s *scubaWriteSyncer) flush(timeout time.Duration) error {
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	for {
+		s.mu.Lock()
+		if s.inflight == 0 && s.msgQ.len() == 0 {
+			s.mu.Unlock()
+			return nil
+		}
+		drained := s.drained
+		s.mu.Unlock()
+		select {
+		case <-drained:
+		case <-deadline.C:
+			return fmt.Errorf("timed out flushing scuba table %s, %d log(s) are not sent yet", s.table, s.pending())
+		}
+	}
+}
+
+// pending returns the number of queued & in flight messages
+func (s *scubaWriteSyncer) pending() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.inflight + s.msgQ.len()
 }
 
 // ScribeEntry ...
@@ -66,110 +392,128 @@ type ScribeEntry struct {
 	Message  string `json:"message"`
 }
 
-func (s *scubaWriteSyncer) makeScribeEntry(msg string) ScribeEntry {
-	return ScribeEntry{
-		Category: ANY_SCUBA_CATEGORY,
-		Message:  fmt.Sprintf("perfpipe_%s %s", s.table, msg),
+// serve exports the queued messages in batches, it closes the exporter & exits once the syncer is closed & its queue
+// is drained
+func (s *scubaWriteSyncer) serve() {
+	defer close(s.done)
+	for {
+		messages := s.nextBatch()
+		if len(messages) == 0 {
+			s.closeErr = s.exporter.Close()
+			return
+		}
+		// a failed batch doesn't fail the rest of its split batches
+		for _, batch := range s.split(messages) {
+			if err := s.exporter.Write(batch); err != nil {
+				fmt.Printf("ERROR exporting %d log(s) of table %s: %s\n", len(batch), s.table, err.Error())
+			}
+		}
+		s.sent(len(messages))
+	}
+}
+
+// split splits the batch into batches of up to the table's MaxBatchBytes of messages, truncating the messages
+// larger than it
+func (s *scubaWriteSyncer) split(messages []string) [][]string {
+	max := s.settings.Load().(*tableSettings).tableCfg.MaxBatchBytes
+	if max <= 0 {
+		return [][]string{messages}
 	}
+	var batches [][]string
+	start, size := 0, 0
+	for i, msg := range messages {
+		if len(msg) > max {
+			msg = truncate(msg, max)
+			messages[i] = msg
+			s.truncGauge.Record(int64(atomic.AddUint64(&s.truncated, 1)))
+		}
+		if i > start && size+len(msg) > max {
+			batches = append(batches, messages[start:i])
+			start, size = i, 0
+		}
+		size += len(msg)
+	}
+	return append(batches, messages[start:])
 }
 
-func (s *scubaWriteSyncer) serve() {
+// nextBatch waits for queued messages & returns up to a batch of them, it returns nil once the syncer is closed &
+// its queue is drained
+func (s *scubaWriteSyncer) nextBatch() []string {
 	for {
-		// Grab messages from the queue
-		var messages []ScribeEntry
-		firstMsg := <-s.msgQ
-		messages = append(messages, s.makeScribeEntry(firstMsg))
-		flush := time.NewTimer(time.Second * time.Duration(s.config.FlushIntervalSec))
-
+		var messages []string
+		tableCfg := s.settings.Load().(*tableSettings).tableCfg
+		flush := time.NewTimer(time.Second * time.Duration(tableCfg.FlushIntervalSec))
+		s.mu.Lock()
 	Remaining:
-		for i := 0; i < s.config.BatchSize-1; i++ {
+		for len(messages) < tableCfg.BatchSize {
 			select {
-			case msg := <-s.msgQ:
-				messages = append(
-					messages,
-					s.makeScribeEntry(msg),
-				)
 			case <-flush.C:
 				break Remaining
 			default:
-				break Remaining
 			}
-		}
-
-		flush.Stop()
-
-		// Break or go back to wait
-		if len(messages) == 0 {
-			if s.disabled {
-				break // exit the go routine
+			msg, ok := s.msgQ.tryPop()
+			if !ok {
+				break
 			}
-			continue
+			messages = append(messages, msg)
 		}
-
-		// Build the message
-		msgs, err := json.Marshal(messages)
-		if err != nil {
-			fmt.Printf("ERROR serializing %d log(s): %s\n", len(messages), err.Error())
-			continue
+		s.inflight += len(messages)
+		closed := s.closed
+		depth := s.msgQ.len()
+		s.mu.Unlock()
+		flush.Stop()
+		s.depth.Record(int64(depth))
+		if len(messages) > 0 || closed {
+			return messages
 		}
-
-		form := url.Values{
-			"access_token": []string{s.config.AccessToken},
-			"logs":         []string{string(msgs)},
-		}
-
-		// Do Post
-		res, err := http.Post(
-			s.config.GraphURL,
-			"application/x-www-form-urlencoded",
-			strings.NewReader(form.Encode()),
-		)
-		if err != nil {
-			fmt.Printf("ERROR sending %d log(s) to Scuba: %s\n", len(messages), err.Error())
-			continue
+		select {
+		case <-s.msgQ.ready:
+		case <-s.closing:
 		}
+	}
+}
 
-		if res.StatusCode != 200 {
-			bodyBytes, err := ioutil.ReadAll(res.Body)
-			if err != nil {
-				fmt.Printf(
-					"ERROR sending %d log(s) to Scuba: Got status code %d (%s)\n",
-					len(messages),
-					res.StatusCode,
-					res.Status,
-				)
-			} else {
-				fmt.Printf(
-					"ERROR sending %d logs to Scuba: Got status code %d (%s): %s\n",
-					len(messages),
-					res.StatusCode,
-					res.Status,
-					string(bodyBytes),
-				)
-			}
-		}
+// sent marks the n in flight messages as sent, successfully or not, & signals the flushes once drained
+func (s *scubaWriteSyncer) sent(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.inflight -= n
+	if s.inflight == 0 && s.msgQ.len() == 0 {
+		close(s.drained)
+		s.drained = make(chan struct{})
 	}
 }
 
-// Initialize ...
-func Initialize(config *Config, logger *zap.Logger) {
-	zap.RegisterSink(
+// Initialize registers the scuba sink, it fails if the spool directory can't be opened. Creating a table's sink fails
+// if its exporter can't be created. The sink's configuration is swapped by Reload
+func Initialize(config *Config, logger *zap.Logger) error {
+	endpoint := newEndpointSelector(config, logger)
+	sender, err := newSender(config, endpoint)
+	if err != nil {
+		return err
+	}
+	if config.ProbeIntervalSec > 0 {
+		// the Graph endpoints may be added by reloads, a single endpoint isn't probed
+		go endpoint.run(time.Second * time.Duration(config.ProbeIntervalSec))
+	}
+	if sender.spool != nil {
+		go sender.replay(time.Second * time.Duration(config.SpoolRetryIntervalSec))
+	}
+	return zap.RegisterSink(
 		"scuba",
 		func(url *url.URL) (zap.Sink, error) {
-			result := &scubaWriteSyncer{
-				disabled: false,
-				config:   config,
-				url:      *url,
-				table:    url.Hostname(),
-				msgQ:     make(chan string, config.MessageQueueSize),
+			result, err := newScubaWriteSyncer(sender.getConfig(), sender, *url)
+			if err != nil {
+				return nil, err
 			}
+			live.add(sender, endpoint, result)
 			go result.serve()
 			return result, nil
 		},
 	)
 }
 
-// NewLogger creates a new Scuba logger
+// NewLogger creates a new Scuba logger, the table's Config.Tables overrides apply to it
 func NewLogger(table string, options ...zap.Option) (*zap.Logger, error) {
 	// Create configuration
 	c := zap.NewProductionConfig()
@@ -9,12 +9,26 @@ LICENSE file in the root directory of this source tree.
 package scuba
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"fbc/cwf/radius/monitoring/exporters"
+	"fbc/cwf/radius/monitoring/exporters/exporterstest"
+	"fbc/lib/go/retry"
+
 	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
 	"go.uber.org/zap"
 )
 
@@ -68,3 +82,556 @@ func TestSendOdsCounters(t *testing.T) {
 		require.Fail(t, "timed out waiting for metrics to propagate")
 	}
 }
+
+func TestTableConfig(t *testing.T) {
+	// Arrange
+	config := &Config{
+		FlushIntervalSec: 2,
+		BatchSize:        15,
+		Tables: map[string]TableConfig{
+			"auth":   {BatchSize: 100, SampleRatio: 0.1},
+			"errors": {FlushIntervalSec: 10, Category: "xwf_errors", Exporter: exporters.File},
+		},
+	}
+
+	// Act
+	auth := config.tableConfig("auth")
+	errs := config.tableConfig("errors")
+	other := config.tableConfig("other")
+
+	// Assert
+	require.Equal(t, TableConfig{
+		FlushIntervalSec: 2, BatchSize: 100, Category: ANY_SCUBA_CATEGORY, SampleRatio: 0.1, Exporter: exporters.Scuba,
+	}, auth)
+	require.Equal(t, TableConfig{
+		FlushIntervalSec: 10, BatchSize: 15, Category: "xwf_errors", SampleRatio: 1, Exporter: exporters.File,
+	}, errs)
+	require.Equal(t, TableConfig{
+		FlushIntervalSec: 2, BatchSize: 15, Category: ANY_SCUBA_CATEGORY, SampleRatio: 1, Exporter: exporters.Scuba,
+	}, other)
+
+	// Act
+	config.Tables["auth"] = TableConfig{SampleRatio: 1.5}
+	config.MessageQueueSize, config.GraphURL = 1, "http://127.0.0.1/scuba"
+
+	// Assert
+	require.Error(t, config.Validate())
+}
+
+func TestCloseDrainsQueue(t *testing.T) {
+	// Arrange
+	var logs int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		atomic.AddInt32(&logs, int32(strings.Count(r.PostForm.Get("logs"), "perfpipe_drain")))
+		time.Sleep(10 * time.Millisecond)
+	}))
+	defer server.Close()
+	config := &Config{
+		MessageQueueSize: 100,
+		FlushIntervalSec: 1,
+		BatchSize:        3,
+		GraphURL:         server.URL,
+		DrainTimeoutSec:  5,
+	}
+	sender, err := newSender(config, newEndpointSelector(config, zap.NewNop()))
+	require.NoError(t, err)
+	syncer, err := newScubaWriteSyncer(config, sender, url.URL{Scheme: "scuba", Host: "drain"})
+	require.NoError(t, err)
+	go syncer.serve()
+
+	// Act
+	for i := 0; i < 10; i++ {
+		_, err := syncer.Write([]byte(`{"level":"info","msg":"log"}`))
+		require.NoError(t, err)
+	}
+	require.NoError(t, syncer.Sync())
+	synced := atomic.LoadInt32(&logs)
+	_, err = syncer.Write([]byte(`{"level":"info","msg":"last log"}`))
+	require.NoError(t, err)
+	require.NoError(t, syncer.Close())
+
+	// Assert
+	require.Equal(t, int32(10), synced)
+	require.Equal(t, int32(11), atomic.LoadInt32(&logs))
+	select {
+	case <-syncer.done:
+	default:
+		require.Fail(t, "serve didn't exit once the queue was drained")
+	}
+	_, err = syncer.Write([]byte(`{"level":"info","msg":"closed"}`))
+	require.Error(t, err)
+	require.NoError(t, syncer.Close())
+}
+
+func TestSyncTimeout(t *testing.T) {
+	// Arrange
+	config := &Config{MessageQueueSize: 10, FlushIntervalSec: 1, BatchSize: 1, GraphURL: "http://127.0.0.1/scuba"}
+	sender, err := newSender(config, newEndpointSelector(config, zap.NewNop()))
+	require.NoError(t, err)
+	syncer, err := newScubaWriteSyncer(config, sender, url.URL{Scheme: "scuba", Host: "t"})
+	require.NoError(t, err)
+	syncer.timeout = 10 * time.Millisecond
+
+	// Act: nothing serves the queue
+	_, err = syncer.Write([]byte(`{"level":"info","msg":"log"}`))
+	require.NoError(t, err)
+
+	// Assert
+	require.Error(t, syncer.Sync())
+	require.Error(t, syncer.Close())
+}
+
+func TestRetryTransientFailures(t *testing.T) {
+	// Arrange
+	var posts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&posts, 1) {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 3:
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+	config := &Config{GraphURL: server.URL, Retry: &retry.Config{MaxAttempts: 3, InitialBackoffMs: 1}}
+	sender, err := newSender(config, newEndpointSelector(config, zap.NewNop()))
+	require.NoError(t, err)
+	batch := []ScribeEntry{{Category: ANY_SCUBA_CATEGORY, Message: "log"}}
+
+	// Act
+	sender.send("t", batch)
+	retried := atomic.LoadInt32(&posts)
+	sender.send("t", batch)
+
+	// Assert
+	require.Equal(t, int32(3), retried)
+	require.Equal(t, int32(4), atomic.LoadInt32(&posts), "4xx responses are not retried")
+}
+
+func TestSenderMetrics(t *testing.T) {
+	// Arrange
+	var posts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&posts, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+	config := &Config{GraphURL: server.URL, Retry: &retry.Config{MaxAttempts: 2, InitialBackoffMs: 1}}
+	sender, err := newSender(config, newEndpointSelector(config, zap.NewNop()))
+	require.NoError(t, err)
+	batch := []ScribeEntry{
+		{Category: ANY_SCUBA_CATEGORY, Message: "first"},
+		{Category: ANY_SCUBA_CATEGORY, Message: "second"},
+	}
+
+	// Act
+	sender.send("metrics", batch)
+
+	// Assert
+	require.Equal(t, int64(2), viewValue(t, "scuba_messages/total", "metrics", messagesSent))
+	require.Equal(t, int64(2), viewValue(t, "scuba_messages/total", "metrics", messagesRetried))
+	require.Equal(t, int64(0), viewValue(t, "scuba_messages/total", "metrics", messagesDropped))
+	require.Equal(t, int64(1), viewValue(t, "scuba_batch_size/distribution", "metrics"))
+	require.True(t, viewValue(t, "scuba_post_responses/total", "503") >= 1)
+	require.True(t, viewValue(t, "scuba_post_latency/distribution") >= 2)
+}
+
+// viewValue returns the sum, or the count of a distribution, of the view's rows tagged with all the tag values
+func viewValue(t *testing.T, name string, tagValues ...string) int64 {
+	rows, err := view.RetrieveData(name)
+	require.NoError(t, err)
+	var res int64
+Rows:
+	for _, row := range rows {
+		for _, value := range tagValues {
+			tagged := false
+			for _, tag := range row.Tags {
+				tagged = tagged || tag.Value == value
+			}
+			if !tagged {
+				continue Rows
+			}
+		}
+		switch data := row.Data.(type) {
+		case *view.SumData:
+			res += int64(data.Value)
+		case *view.DistributionData:
+			res += data.Count
+		}
+	}
+	return res
+}
+
+func TestSpoolFailedBatches(t *testing.T) {
+	// Arrange
+	var available int32
+	var logs []string
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&available) == 0 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		require.NoError(t, r.ParseForm())
+		mu.Lock()
+		logs = append(logs, r.PostForm.Get("logs"))
+		mu.Unlock()
+	}))
+	defer server.Close()
+	dir, err := ioutil.TempDir("", "scuba_spool")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	config := &Config{GraphURL: server.URL, SpoolDir: dir, SpoolMaxBatches: 2}
+	sender, err := newSender(config, newEndpointSelector(config, zap.NewNop()))
+	require.NoError(t, err)
+
+	// Act: the Graph API is down
+	for _, msg := range []string{"first", "second", "third"} {
+		sender.send("t", []ScribeEntry{{Category: ANY_SCUBA_CATEGORY, Message: msg}})
+	}
+	sender.replaySpool()
+	spooled := sender.spool.len()
+
+	// Act: the process restarts & the Graph API recovers
+	restarted, err := newSender(config, newEndpointSelector(config, zap.NewNop()))
+	require.NoError(t, err)
+	reopened := restarted.spool.len()
+	atomic.StoreInt32(&available, 1)
+	restarted.replaySpool()
+
+	// Assert
+	require.Equal(t, 2, spooled, "the third batch is dropped by the full spool")
+	require.Equal(t, 2, reopened)
+	require.Equal(t, 0, restarted.spool.len())
+	require.Len(t, logs, 2)
+	require.Contains(t, logs[0], "first")
+	require.Contains(t, logs[1], "second")
+	files, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	require.Empty(t, files)
+}
+
+func TestTableExporter(t *testing.T) {
+	// Arrange
+	dir, err := ioutil.TempDir("", "scuba_exporter")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	config := &Config{
+		MessageQueueSize: 10,
+		FlushIntervalSec: 1,
+		BatchSize:        2,
+		GraphURL:         "http://127.0.0.1/scuba",
+		DrainTimeoutSec:  5,
+		Exporter:         exporters.Scuba,
+		Exporters:        exporters.Config{File: &exporters.FileConfig{Dir: dir}},
+		Tables:           map[string]TableConfig{"audit": {Exporter: exporters.File}},
+	}
+	require.NoError(t, config.Validate())
+	sender, err := newSender(config, newEndpointSelector(config, zap.NewNop()))
+	require.NoError(t, err)
+	syncer, err := newScubaWriteSyncer(config, sender, url.URL{Scheme: "scuba", Host: "audit"})
+	require.NoError(t, err)
+	go syncer.serve()
+
+	// Act
+	for _, msg := range []string{"first", "second", "third"} {
+		_, err := syncer.Write([]byte(`{"level":"info","msg":"` + msg + `"}` + "\n"))
+		require.NoError(t, err)
+	}
+	require.NoError(t, syncer.Close())
+
+	// Assert
+	logs, err := ioutil.ReadFile(dir + "/audit.log")
+	require.NoError(t, err)
+	require.Equal(t, 3, strings.Count(string(logs), "\n"))
+	require.Contains(t, string(logs), `"msg":"third"`)
+	require.NotContains(t, string(logs), "perfpipe_")
+
+	// the tables' exporters must be configured
+	config.Tables["other"] = TableConfig{Exporter: exporters.HTTPS}
+	require.Error(t, config.Validate())
+	config.Tables["other"] = TableConfig{Exporter: "kafka"}
+	require.Error(t, config.Validate())
+	delete(config.Tables, "other")
+
+	// the queue full policy must be known & block must time out
+	config.QueueFullPolicy = QueueDropOldest
+	require.NoError(t, config.Validate())
+	config.QueueFullPolicy = QueueBlock
+	require.Error(t, config.Validate())
+	config.QueueFullTimeoutMs = 100
+	require.NoError(t, config.Validate())
+	config.QueueFullPolicy = "drop_all"
+	require.Error(t, config.Validate())
+}
+
+func TestScubaExporterContract(t *testing.T) {
+	var sinks int32
+	exporterstest.RunContract(t, func(t *testing.T) *exporterstest.Sink {
+		table := fmt.Sprintf("contract%d", atomic.AddInt32(&sinks, 1))
+		var (
+			mu        sync.Mutex
+			fail      bool
+			delivered []string
+		)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			if fail {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			require.NoError(t, r.ParseForm())
+			var entries []ScribeEntry
+			require.NoError(t, json.Unmarshal([]byte(r.PostForm.Get("logs")), &entries))
+			for _, entry := range entries {
+				delivered = append(delivered, strings.TrimPrefix(entry.Message, "perfpipe_"+table+" "))
+			}
+		}))
+		config := &Config{GraphURL: server.URL}
+		sender, err := newSender(config, newEndpointSelector(config, zap.NewNop()))
+		require.NoError(t, err)
+		exporter, err := newExporter(config, sender, table)
+		require.NoError(t, err)
+		return &exporterstest.Sink{
+			Exporter: exporter,
+			Delivered: func() []string {
+				mu.Lock()
+				defer mu.Unlock()
+				return append([]string(nil), delivered...)
+			},
+			Fail: func(f bool) {
+				mu.Lock()
+				defer mu.Unlock()
+				fail = f
+			},
+			Dropped: func() int {
+				return int(viewValue(t, "scuba_messages/total", table, messagesDropped))
+			},
+			Cleanup: server.Close,
+		}
+	})
+}
+
+func TestWriteSyncerDelivery(t *testing.T) {
+	// Arrange
+	config := &Config{
+		MessageQueueSize: 10,
+		FlushIntervalSec: 1,
+		BatchSize:        2,
+		GraphURL:         "http://127.0.0.1/scuba",
+		DrainTimeoutSec:  5,
+	}
+	sender, err := newSender(config, newEndpointSelector(config, zap.NewNop()))
+	require.NoError(t, err)
+	syncer, err := newScubaWriteSyncer(config, sender, url.URL{Scheme: "scuba", Host: "fake"})
+	require.NoError(t, err)
+	exporter := exporterstest.NewFakeExporter()
+	syncer.exporter = exporter
+	go syncer.serve()
+	write := func(msgs ...string) {
+		for _, msg := range msgs {
+			_, err := syncer.Write([]byte(`{"level":"info","msg":"` + msg + `"}` + "\n"))
+			require.NoError(t, err)
+		}
+	}
+
+	// Act
+	write("first", "second", "third")
+	require.NoError(t, syncer.Sync())
+	synced := exporter.Delivered()
+	exporter.SetError(errors.New("unavailable"))
+	write("failed")
+	require.NoError(t, syncer.Sync())
+	exporter.SetError(nil)
+	write("last")
+	require.NoError(t, syncer.Close())
+
+	// Assert
+	require.Len(t, synced, 3, "Sync flushes the exporter")
+	var written []string
+	for _, batch := range exporter.Batches() {
+		require.True(t, len(batch) <= config.BatchSize)
+		written = append(written, batch...)
+	}
+	require.Equal(t, exporter.Delivered(), written, "the batches are delivered in order")
+	require.Equal(t, `{"level":"info","msg":"last"}`, written[len(written)-1])
+	require.Len(t, written, 4)
+	require.Equal(t, 1, exporter.Dropped(), "a failed batch doesn't stop the syncer")
+	require.True(t, exporter.Closed(), "Close closes the exporter once the queue is drained")
+}
+
+func TestReload(t *testing.T) {
+	// Arrange
+	var mu sync.Mutex
+	posts := map[string]int{} // logs by server & access token
+	newServer := func(name string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, r.ParseForm())
+			mu.Lock()
+			defer mu.Unlock()
+			key := name + "/" + r.PostForm.Get("access_token")
+			posts[key] += strings.Count(r.PostForm.Get("logs"), "perfpipe_reload")
+		}))
+	}
+	old, rotated := newServer("old"), newServer("new")
+	defer old.Close()
+	defer rotated.Close()
+	config := &Config{
+		MessageQueueSize: 100,
+		FlushIntervalSec: 1,
+		BatchSize:        1,
+		GraphURL:         old.URL,
+		DrainTimeoutSec:  5,
+		Exporter:         exporters.Scuba,
+		AccessToken:      "token1",
+	}
+	endpoint := newEndpointSelector(config, zap.NewNop())
+	sender, err := newSender(config, endpoint)
+	require.NoError(t, err)
+	syncer, err := newScubaWriteSyncer(config, sender, url.URL{Scheme: "scuba", Host: "reload"})
+	require.NoError(t, err)
+	sink := &liveSink{}
+	sink.add(sender, endpoint, syncer)
+	go syncer.serve()
+	_, err = syncer.Write([]byte(`{"level":"info","msg":"log"}`))
+	require.NoError(t, err)
+	require.NoError(t, syncer.Sync())
+
+	// Act
+	reloaded := *config
+	reloaded.GraphURL = rotated.URL
+	reloaded.BatchSize = 3
+	reloaded.AccessToken = "token2"
+	require.NoError(t, sink.reload(&reloaded))
+	for i := 0; i < 3; i++ {
+		_, err = syncer.Write([]byte(`{"level":"info","msg":"log"}`))
+		require.NoError(t, err)
+	}
+	require.NoError(t, syncer.Close())
+
+	// Assert
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, map[string]int{"old/token1": 1, "new/token2": 3}, posts)
+	require.Equal(t, 3, syncer.settings.Load().(*tableSettings).tableCfg.BatchSize)
+}
+
+func TestSplitOversizedBatches(t *testing.T) {
+	// Arrange
+	var mu sync.Mutex
+	var batches [][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		var entries []ScribeEntry
+		require.NoError(t, json.Unmarshal([]byte(r.PostForm.Get("logs")), &entries))
+		var batch []string
+		for _, e := range entries {
+			batch = append(batch, strings.TrimPrefix(e.Message, "perfpipe_split "))
+		}
+		mu.Lock()
+		batches = append(batches, batch)
+		mu.Unlock()
+	}))
+	defer server.Close()
+	config := &Config{
+		MessageQueueSize: 100,
+		FlushIntervalSec: 1,
+		BatchSize:        10,
+		MaxBatchBytes:    200,
+		GraphURL:         server.URL,
+		DrainTimeoutSec:  5,
+	}
+	sender, err := newSender(config, newEndpointSelector(config, zap.NewNop()))
+	require.NoError(t, err)
+	syncer, err := newScubaWriteSyncer(config, sender, url.URL{Scheme: "scuba", Host: "split"})
+	require.NoError(t, err)
+	msg := `{"level":"info","msg":"` + strings.Repeat("a", 60) + `"}`
+	oversized := `{"level":"info","msg":"log","stack":"` + strings.Repeat("s", 1000) + `"}`
+
+	// Act
+	for _, m := range []string{msg, msg, msg, oversized} {
+		_, err := syncer.Write([]byte(m))
+		require.NoError(t, err)
+	}
+	go syncer.serve()
+	require.NoError(t, syncer.Close())
+
+	// Assert
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, batches, 3)
+	require.Equal(t, []string{msg, msg}, batches[0])
+	require.Equal(t, []string{msg}, batches[1])
+	require.Len(t, batches[2], 1)
+	var truncated map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(batches[2][0]), &truncated))
+	require.Equal(t, "info", truncated["level"])
+	require.Equal(t, "log", truncated["msg"])
+	require.Equal(t, float64(len(oversized)), truncated[TruncatedKey])
+	stack := truncated["stack"].(string)
+	require.Equal(t, strings.Repeat("s", len(stack)), stack)
+	require.True(t, len(batches[2][0]) <= 200)
+}
+
+func TestTruncate(t *testing.T) {
+	require.Equal(t, `{"msg":"not js","scuba_truncated_bytes":40}`, truncate("not json "+strings.Repeat("x", 31), 48))
+	require.Equal(t, `{"scuba_truncated_bytes":40}`, truncate("not json "+strings.Repeat("x", 31), 30))
+	require.Equal(t, "ab", truncateString("abé", 3), "runes aren't split")
+}
+
+func TestShutdownSpoolsQueue(t *testing.T) {
+	// Arrange: the Graph API is down & nothing serves the queue
+	dir, err := ioutil.TempDir("", "scuba_spool")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	config := &Config{
+		MessageQueueSize: 10,
+		FlushIntervalSec: 1,
+		BatchSize:        2,
+		GraphURL:         "http://127.0.0.1/scuba",
+		SpoolDir:         dir,
+		SpoolMaxBatches:  10,
+	}
+	sender, err := newSender(config, newEndpointSelector(config, zap.NewNop()))
+	require.NoError(t, err)
+	syncer, err := newScubaWriteSyncer(config, sender, url.URL{Scheme: "scuba", Host: "t"})
+	require.NoError(t, err)
+	syncer.timeout = 10 * time.Millisecond
+	for i := 0; i < 3; i++ {
+		_, err := syncer.Write([]byte(`{"level":"info","msg":"log"}`))
+		require.NoError(t, err)
+	}
+
+	// Act
+	err = syncer.shutdown()
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, 0, syncer.pending())
+	require.Equal(t, 2, sender.spool.len(), "the queued messages are spooled in batches")
+	_, logs, err := sender.spool.oldest()
+	require.NoError(t, err)
+	var entries []ScribeEntry
+	require.NoError(t, json.Unmarshal(logs, &entries))
+	require.Len(t, entries, 2)
+	require.Equal(t, `perfpipe_t {"level":"info","msg":"log"}`, entries[0].Message)
+	_, err = syncer.Write([]byte(`{"level":"info","msg":"closed"}`))
+	require.Error(t, err)
+
+	// Act: without a spool the queued messages are dropped
+	config.SpoolDir = ""
+	sender, err = newSender(config, newEndpointSelector(config, zap.NewNop()))
+	require.NoError(t, err)
+	syncer, err = newScubaWriteSyncer(config, sender, url.URL{Scheme: "scuba", Host: "t"})
+	require.NoError(t, err)
+	syncer.timeout = 10 * time.Millisecond
+	_, err = syncer.Write([]byte(`{"level":"info","msg":"log"}`))
+	require.NoError(t, err)
+	require.Error(t, syncer.shutdown())
+}
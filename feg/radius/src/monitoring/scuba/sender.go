@@ -0,0 +1,234 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package scuba
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"fbc/cwf/radius/monitoring/counters"
+	"fbc/lib/go/retry"
+
+	"go.opencensus.io/stats"
+)
+
+// Failure codes of the batch counters
+const (
+	batchDropped = "dropped"
+	batchSpooled = "spooled"
+)
+
+// Outcomes of the messages counter
+const (
+	messagesSent    = "sent"
+	messagesRetried = "retried"
+	messagesSpooled = "spooled"
+	messagesDropped = "dropped"
+)
+
+var (
+	batchSizeDistribution = counters.NewDistribution(
+		"scuba_batch_size", "Messages per batch sent to a scuba table", stats.UnitDimensionless,
+		[]float64{1, 5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000}, counters.TableTag)
+	postLatencyDistribution = counters.NewDistribution(
+		"scuba_post_latency", "Latency of the batches' posts to the Graph API", stats.UnitMilliseconds,
+		[]float64{5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000, 30000})
+	postResponsesCounter = counters.NewCounter(
+		"scuba_post_responses", "Responses of the batches' posts to the Graph API per HTTP status code, "+
+			"network - failed without response", counters.StatusCodeTag)
+	messagesCounter = counters.NewCounter(
+		"scuba_messages", "Messages of a scuba table's batches per outcome: sent, retried, spooled or dropped",
+		counters.TableTag, counters.OutcomeTag)
+)
+
+// sender posts the batches of all the tables to the selected Graph endpoint, retrying & spooling the failed ones
+type sender struct {
+	config   atomic.Value // *Config, swapped by Reload
+	endpoint *endpointSelector
+	retrier  *retry.Retrier
+	spool    *spool // nil if spooling is disabled
+}
+
+func newSender(config *Config, endpoint *endpointSelector) (*sender, error) {
+	result := &sender{
+		endpoint: endpoint,
+		retrier:  &retry.Retrier{MaxAttempts: 1},
+	}
+	result.config.Store(config)
+	if config.Retry != nil {
+		retrier, err := retry.New(*config.Retry)
+		if err != nil {
+			return nil, err
+		}
+		// the errors are classified by post, not by gRPC code
+		retrier.Retryable = retry.Any
+		result.retrier = retrier
+	}
+	result.retrier.OnRetry = func(_ int, err error) {
+		counters.ScubaBatchRetry.Start().Failure(errorCode(err))
+	}
+	if len(config.SpoolDir) > 0 {
+		spool, err := openSpool(config.SpoolDir, config.SpoolMaxBatches)
+		if err != nil {
+			return nil, err
+		}
+		result.spool = spool
+	}
+	return result, nil
+}
+
+// getConfig returns the current configuration, its retries & spool are the initial configuration's
+func (s *sender) getConfig() *Config {
+	return s.config.Load().(*Config)
+}
+
+// send posts the table's batch of messages, retrying transient failures. Batches still failing are spooled, if
+// enabled & not full, or dropped
+func (s *sender) send(table string, messages []ScribeEntry) {
+	op := counters.ScubaBatch.Start()
+	batchSizeDistribution.SetTag(counters.TableTag, table).Record(float64(len(messages)))
+	outcome := func(o string) {
+		messagesCounter.SetTag(counters.TableTag, table).SetTag(counters.OutcomeTag, o).Add(int64(len(messages)))
+	}
+	logs, err := json.Marshal(messages)
+	if err != nil {
+		fmt.Printf("ERROR serializing %d log(s): %s\n", len(messages), err.Error())
+		op.Failure(batchDropped)
+		outcome(messagesDropped)
+		return
+	}
+
+	var last error
+	attempts := 0
+	err = s.retrier.Do(context.Background(), func(context.Context) error {
+		if attempts++; attempts > 1 {
+			outcome(messagesRetried)
+		}
+		last = s.post(logs)
+		return last
+	})
+	if err == nil {
+		op.Success()
+		outcome(messagesSent)
+		return
+	}
+	fmt.Printf("ERROR sending %d log(s) to Scuba: %s\n", len(messages), err.Error())
+	if retry.IsPermanent(last) || s.spool == nil {
+		op.Failure(batchDropped)
+		outcome(messagesDropped)
+		return
+	}
+	spooled, err := s.spool.push(table, logs)
+	if err != nil {
+		fmt.Printf("ERROR spooling %d log(s): %s\n", len(messages), err.Error())
+	}
+	if !spooled {
+		fmt.Printf("ERROR dropping %d log(s), the Scuba spool is full\n", len(messages))
+		op.Failure(batchDropped)
+		outcome(messagesDropped)
+		return
+	}
+	op.Failure(batchSpooled)
+	outcome(messagesSpooled)
+}
+
+// replay resends the spooled batches every interval, starting with the ones spooled by previous runs
+func (s *sender) replay(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		s.replaySpool()
+		<-ticker.C
+	}
+}
+
+// replaySpool resends the spooled batches, oldest first, until the spool is empty or a batch fails transiently.
+// Batches failing permanently are dropped
+func (s *sender) replaySpool() {
+	for {
+		name, logs, err := s.spool.oldest()
+		if len(name) == 0 {
+			return
+		}
+		op := counters.ScubaSpoolReplay.Start()
+		if err == nil {
+			err = s.post(logs)
+			if err != nil && !retry.IsPermanent(err) {
+				fmt.Printf("ERROR resending spooled Scuba batch %s: %s\n", name, err.Error())
+				op.Failure(errorCode(err))
+				return
+			}
+		}
+		if err != nil {
+			fmt.Printf("ERROR dropping spooled Scuba batch %s: %s\n", name, retry.Unwrap(err).Error())
+			op.Failure(batchDropped)
+		} else {
+			op.Success()
+		}
+		s.spool.remove(name)
+	}
+}
+
+// statusError a non 200 response of the Graph API
+type statusError struct {
+	code   int
+	status string
+	body   string
+}
+
+func (e statusError) Error() string {
+	return fmt.Sprintf("got status code %d (%s): %s", e.code, e.status, e.body)
+}
+
+// post posts the logs to the selected Graph endpoint with the current access token. Network errors, 429 & 5xx responses are transient, other
+// failures are marked as permanent
+func (s *sender) post(logs []byte) error {
+	form := url.Values{
+		"access_token": []string{s.getConfig().AccessToken},
+		"logs":         []string{string(logs)},
+	}
+	start := time.Now()
+	res, err := http.Post(
+		s.endpoint.URL(),
+		"application/x-www-form-urlencoded",
+		strings.NewReader(form.Encode()),
+	)
+	postLatencyDistribution.Record(float64(time.Since(start)) / float64(time.Millisecond))
+	if err != nil {
+		postResponsesCounter.SetTag(counters.StatusCodeTag, "network").Add(1)
+		return err
+	}
+	postResponsesCounter.SetTag(counters.StatusCodeTag, strconv.Itoa(res.StatusCode)).Add(1)
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusOK {
+		return nil
+	}
+	body, _ := ioutil.ReadAll(res.Body)
+	err = statusError{code: res.StatusCode, status: res.Status, body: string(body)}
+	if res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= http.StatusInternalServerError {
+		return err
+	}
+	return retry.Permanent(err)
+}
+
+// errorCode returns the counter error code of the failed post
+func errorCode(err error) string {
+	if status, ok := retry.Unwrap(err).(statusError); ok {
+		return fmt.Sprintf("status_%d", status.code)
+	}
+	return "network"
+}
@@ -0,0 +1,124 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package scuba
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"fbc/cwf/radius/monitoring/counters"
+)
+
+// Shutdown closes the tables' syncers on a graceful shutdown: the queued messages are sent for up to the drain
+// timeout, the ones still queued are spooled, if enabled, to be resent by the next run, instead of being lost
+func Shutdown() error {
+	return live.shutdown()
+}
+
+// shutdown closes the syncers concurrently, so the drain timeouts don't add up
+func (l *liveSink) shutdown() error {
+	l.mu.Lock()
+	syncers := append([]*scubaWriteSyncer{}, l.syncers...)
+	l.mu.Unlock()
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []string
+	)
+	for _, syncer := range syncers {
+		wg.Add(1)
+		go func(s *scubaWriteSyncer) {
+			defer wg.Done()
+			if err := s.shutdown(); err != nil {
+				mu.Lock()
+				errs = append(errs, err.Error())
+				mu.Unlock()
+			}
+		}(syncer)
+	}
+	wg.Wait()
+	if len(errs) > 0 {
+		return fmt.Errorf("failed shutting down scuba tables: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// shutdown closes the syncer & spools the messages still queued after the drain timeout, it fails if they're dropped
+func (s *scubaWriteSyncer) shutdown() error {
+	s.mu.Lock()
+	if !s.closed {
+		s.closed = true
+		close(s.closing)
+	}
+	s.mu.Unlock()
+	deadline := time.NewTimer(s.timeout)
+	defer deadline.Stop()
+	select {
+	case <-s.done:
+		return s.closeErr
+	case <-deadline.C:
+	}
+	// serve exits once it finds the queue empty, the in flight messages are still being sent
+	var messages []string
+	s.mu.Lock()
+	for {
+		msg, ok := s.msgQ.tryPop()
+		if !ok {
+			break
+		}
+		messages = append(messages, msg)
+	}
+	s.mu.Unlock()
+	s.depth.Record(0)
+	if len(messages) == 0 {
+		return nil
+	}
+	return s.spoolRemaining(messages)
+}
+
+// spoolRemaining spools the messages in batches of the table's batch size, the messages of the tables exported to
+// other exporters than scuba can't be spooled & are dropped
+func (s *scubaWriteSyncer) spoolRemaining(messages []string) error {
+	exporter, ok := s.exporter.(*scubaExporter)
+	if !ok || exporter.sender.spool == nil {
+		return fmt.Errorf("dropped %d log(s) of table %s, the spool is disabled", len(messages), s.table)
+	}
+	size := s.settings.Load().(*tableSettings).tableCfg.BatchSize
+	dropped := 0
+	for start := 0; start < len(messages); start += size {
+		end := start + size
+		if end > len(messages) {
+			end = len(messages)
+		}
+		for _, batch := range s.split(messages[start:end]) {
+			op := counters.ScubaBatch.Start()
+			logs, err := json.Marshal(exporter.entries(batch))
+			if err == nil {
+				var spooled bool
+				if spooled, err = exporter.sender.spool.push(s.table, logs); err == nil && !spooled {
+					err = fmt.Errorf("the spool is full")
+				}
+			}
+			if err != nil {
+				fmt.Printf("ERROR spooling %d log(s) of table %s: %s\n", len(batch), s.table, err.Error())
+				dropped += len(batch)
+				op.Failure(batchDropped)
+				continue
+			}
+			op.Failure(batchSpooled)
+		}
+	}
+	if dropped > 0 {
+		return fmt.Errorf("dropped %d log(s) of table %s", dropped, s.table)
+	}
+	return nil
+}
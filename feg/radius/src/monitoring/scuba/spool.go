@@ -0,0 +1,122 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package scuba
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"fbc/cwf/radius/monitoring/counters"
+)
+
+const (
+	spoolFileExt = ".json"
+	spoolTempExt = ".tmp"
+)
+
+// spool persists failed batches as files of a directory to resend them later, including after restarts. The file
+// names start with the spooling time so they sort oldest first
+type spool struct {
+	dir        string
+	maxBatches int
+
+	mu    sync.Mutex
+	files []string // the spooled batches' file names, oldest first
+	seq   int
+}
+
+// openSpool returns the spool of the directory, creating it if needed, with the batches spooled by previous runs
+func openSpool(dir string, maxBatches int) (*spool, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create scuba spool directory %s: %s", dir, err)
+	}
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scuba spool directory %s: %s", dir, err)
+	}
+	result := &spool{dir: dir, maxBatches: maxBatches}
+	// ReadDir sorts the entries by name, i.e. oldest first
+	for _, entry := range entries {
+		switch {
+		case entry.IsDir():
+		case strings.HasSuffix(entry.Name(), spoolFileExt):
+			result.files = append(result.files, entry.Name())
+		case strings.HasSuffix(entry.Name(), spoolTempExt):
+			// a batch partially written before a crash
+			os.Remove(filepath.Join(dir, entry.Name()))
+		}
+	}
+	counters.ScubaSpooledBatches.Record(int64(len(result.files)))
+	return result, nil
+}
+
+// push spools the table's batch, it returns false if the spool is full
+func (s *spool) push(table string, logs []byte) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.files) >= s.maxBatches {
+		return false, nil
+	}
+	s.seq++
+	name := fmt.Sprintf("%020d-%06d-%s%s", time.Now().UnixNano(), s.seq%1000000, table, spoolFileExt)
+	// written to a temporary file & renamed, so a crash doesn't leave a truncated batch behind
+	temp := filepath.Join(s.dir, name+spoolTempExt)
+	if err := ioutil.WriteFile(temp, logs, 0644); err != nil {
+		os.Remove(temp)
+		return false, err
+	}
+	if err := os.Rename(temp, filepath.Join(s.dir, name)); err != nil {
+		os.Remove(temp)
+		return false, err
+	}
+	s.files = append(s.files, name)
+	counters.ScubaSpooledBatches.Record(int64(len(s.files)))
+	return true, nil
+}
+
+// oldest returns the file name & logs of the oldest spooled batch, an empty name if the spool is empty
+func (s *spool) oldest() (string, []byte, error) {
+	s.mu.Lock()
+	if len(s.files) == 0 {
+		s.mu.Unlock()
+		return "", nil, nil
+	}
+	name := s.files[0]
+	s.mu.Unlock()
+	logs, err := ioutil.ReadFile(filepath.Join(s.dir, name))
+	return name, logs, err
+}
+
+// remove removes the spooled batch
+func (s *spool) remove(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, file := range s.files {
+		if file == name {
+			s.files = append(s.files[:i], s.files[i+1:]...)
+			break
+		}
+	}
+	if err := os.Remove(filepath.Join(s.dir, name)); err != nil && !os.IsNotExist(err) {
+		fmt.Printf("ERROR removing spooled scuba batch %s: %s\n", name, err.Error())
+	}
+	counters.ScubaSpooledBatches.Record(int64(len(s.files)))
+}
+
+// len returns the number of spooled batches
+func (s *spool) len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.files)
+}
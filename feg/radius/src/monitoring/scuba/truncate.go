@@ -0,0 +1,75 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package scuba
+
+import (
+	"bytes"
+	"encoding/json"
+	"unicode/utf8"
+)
+
+// TruncatedKey the log field flagging a message truncated to fit in a batch, its value is the message's original size
+const TruncatedKey = "scuba_truncated_bytes"
+
+// minTruncatedField the length string fields aren't truncated below
+const minTruncatedField = 64
+
+// truncate returns the JSON encoded message truncated to up to max bytes & flagged with the TruncatedKey field: its
+// longest string fields are halved until it fits. Messages which don't fit, e.g. of many long fields or which aren't
+// JSON objects, are replaced by the flag & the prefix of the message fitting in max bytes
+func truncate(msg string, max int) string {
+	decoder := json.NewDecoder(bytes.NewReader([]byte(msg)))
+	decoder.UseNumber()
+	var fields map[string]interface{}
+	if err := decoder.Decode(&fields); err == nil && fields != nil {
+		fields[TruncatedKey] = len(msg)
+		for {
+			encoded, err := json.Marshal(fields)
+			if err != nil {
+				break
+			}
+			if len(encoded) <= max {
+				return string(encoded)
+			}
+			longest, value := "", ""
+			for k, v := range fields {
+				if s, ok := v.(string); ok && len(s) > len(value) {
+					longest, value = k, s
+				}
+			}
+			if len(value) <= minTruncatedField {
+				break
+			}
+			fields[longest] = truncateString(value, len(value)/2)
+		}
+	}
+
+	// the prefix's escaping may double its size
+	prefix := truncateString(msg, max/2)
+	for prefix != "" {
+		encoded, err := json.Marshal(map[string]interface{}{"msg": prefix, TruncatedKey: len(msg)})
+		if err == nil && len(encoded) <= max {
+			return string(encoded)
+		}
+		prefix = truncateString(prefix, len(prefix)/2)
+	}
+	encoded, _ := json.Marshal(map[string]interface{}{TruncatedKey: len(msg)})
+	return string(encoded)
+}
+
+// truncateString returns the prefix of s of up to n bytes, without splitting a UTF-8 encoded rune
+func truncateString(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
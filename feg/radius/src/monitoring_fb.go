@@ -0,0 +1,71 @@
+//go:build !oss
+// +build !oss
+
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package main
+
+import (
+	"errors"
+
+	"fbc/cwf/radius/config"
+	"fbc/cwf/radius/monitoring/ods"
+	"fbc/cwf/radius/monitoring/scuba"
+
+	"go.uber.org/zap"
+)
+
+// initFacebookSinks starts reporting the counters to ODS & the structured logs to Scuba, if configured. It returns
+// the scuba logger of the server, or the given logger if scuba isn't configured
+func initFacebookSinks(config *config.MonitoringConfig, logger *zap.Logger) (*zap.Logger, error) {
+	if config.Ods != nil {
+		ods.Init(config.Ods, logger)
+	}
+	if config.Scuba == nil {
+		return logger, nil
+	}
+	if err := scuba.Initialize(config.Scuba, logger); err != nil {
+		return nil, err
+	}
+	return scuba.NewLogger("goradius")
+}
+
+// reloadFacebookSinksOnSIGHUP applies the rotated access tokens & scuba tuning of the configuration file on SIGHUP,
+// without restarting
+func reloadFacebookSinksOnSIGHUP(config *config.MonitoringConfig, filename string, logger *zap.Logger) {
+	if config.Scuba == nil {
+		return
+	}
+	scuba.ReloadOnSIGHUP(func() (*scuba.Config, error) {
+		return readScubaConfig(filename)
+	}, logger)
+}
+
+// shutdownFacebookSinks spools the queued scuba logs, so the next start resumes them
+func shutdownFacebookSinks(config *config.MonitoringConfig, logger *zap.Logger) {
+	if config.Scuba == nil {
+		return
+	}
+	logger.Sync()
+	if err := scuba.Shutdown(); err != nil {
+		logger.Error("Failed shutting down scuba", zap.Error(err))
+	}
+}
+
+// readScubaConfig reads the scuba configuration of the configuration file
+func readScubaConfig(filename string) (*scuba.Config, error) {
+	c, err := config.Read(filename)
+	if err != nil {
+		return nil, err
+	}
+	if c.Monitoring == nil || c.Monitoring.Scuba == nil {
+		return nil, errors.New("scuba is not configured anymore")
+	}
+	return c.Monitoring.Scuba, nil
+}
@@ -0,0 +1,38 @@
+//go:build oss
+// +build oss
+
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package main
+
+import (
+	"fbc/cwf/radius/config"
+
+	"go.uber.org/zap"
+)
+
+// initFacebookSinks ignores the ods & scuba sections, the oss build has no ODS & Scuba code: the counters are only
+// exported by the census Prometheus endpoint & the logs are written to stdout by the given logger
+func initFacebookSinks(config *config.MonitoringConfig, logger *zap.Logger) (*zap.Logger, error) {
+	if config.Ods != nil {
+		logger.Warn("The ods section is ignored, ODS is not supported by this build. " +
+			"The counters are exported by the census Prometheus endpoint, if configured")
+	}
+	if config.Scuba != nil {
+		logger.Warn("The scuba section is ignored, Scuba is not supported by this build. The logs are written to stdout")
+	}
+	return logger, nil
+}
+
+// reloadFacebookSinksOnSIGHUP is a no-op, there's no scuba configuration to reload
+func reloadFacebookSinksOnSIGHUP(config *config.MonitoringConfig, filename string, logger *zap.Logger) {
+}
+
+// shutdownFacebookSinks is a no-op, there are no queued scuba logs to spool
+func shutdownFacebookSinks(config *config.MonitoringConfig, logger *zap.Logger) {}
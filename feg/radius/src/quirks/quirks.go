@@ -0,0 +1,199 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+// Package quirks adjusts the handling of requests of NASes with known interop
+// quirks (e.g. APs of vendors deviating from RFC 2866). Quirks are configured
+// per NAS, adjust how the server matches the NAS's requests to sessions & are
+// passed on to the AAA as a context attribute
+package quirks
+
+import (
+	"fbc/cwf/radius/monitoring/counters"
+	"fbc/cwf/radius/session"
+	"fbc/lib/go/radius"
+	"fbc/lib/go/radius/rfc2865"
+	"fbc/lib/go/radius/rfc2866"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Quirks a bitmask of a NAS's known interop quirks
+type Quirks uint32
+
+const (
+	// StopBeforeFinalInterim the NAS may send a session's final
+	// Interim-Update after its Stop
+	StopBeforeFinalInterim Quirks = 1 << iota
+	// UppercaseDashedMAC the NAS sends MAC addresses in upper case & dash
+	// separated, e.g. AA-BB-CC-DD-EE-FF
+	UppercaseDashedMAC
+	// NoAcctSessionIDOnInterim the NAS omits Acct-Session-Id from
+	// Interim-Updates
+	NoAcctSessionIDOnInterim
+	// KilobyteOctets the NAS reports Acct-Input/Output-Octets in kilobytes,
+	// the AAA normalizes its counters to octets
+	KilobyteOctets
+)
+
+// Attribute the AAA context attribute of the NAS's quirks bitmask in decimal
+const Attribute = "nas_quirks"
+
+// names configuration names of the quirks
+var names = map[string]Quirks{
+	"stop_before_final_interim":     StopBeforeFinalInterim,
+	"uppercase_dashed_mac":          UppercaseDashedMAC,
+	"no_acct_session_id_on_interim": NoAcctSessionIDOnInterim,
+	"kilobyte_octets":               KilobyteOctets,
+}
+
+var (
+	// NormalizedMAC counts session IDs composed of normalized MAC addresses
+	NormalizedMAC = counters.NewOperation("quirk_normalized_mac")
+	// RestoredAcctSessionID counts Interim-Updates completed with their
+	// session's Acct-Session-Id, failures are sessions without a known one
+	RestoredAcctSessionID = counters.NewOperation("quirk_restored_acct_session_id")
+)
+
+// Parse returns the quirks of the given names
+func Parse(quirkNames []string) (Quirks, error) {
+	var q Quirks
+	for _, name := range quirkNames {
+		quirk, ok := names[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			return 0, fmt.Errorf("unknown NAS quirk '%s'", name)
+		}
+		q |= quirk
+	}
+	return q, nil
+}
+
+// Has returns true if all of the quirk's bits are set
+func (q Quirks) Has(quirk Quirks) bool {
+	return q&quirk == quirk
+}
+
+// String returns the comma separated names of the quirks
+func (q Quirks) String() string {
+	quirkNames := make([]string, 0, len(names))
+	for name, quirk := range names {
+		if q.Has(quirk) {
+			quirkNames = append(quirkNames, name)
+		}
+	}
+	sort.Strings(quirkNames)
+	return strings.Join(quirkNames, ",")
+}
+
+// AttributeValue returns the quirks as the value of the AAA context Attribute
+func (q Quirks) AttributeValue() string {
+	return strconv.FormatUint(uint64(q), 10)
+}
+
+// StationID returns the Called/Calling-Station-Id to match sessions by, a
+// leading MAC address sent by a NAS with the UppercaseDashedMAC quirk is
+// normalized to lower case & colon separated notation
+func (q Quirks) StationID(id string) string {
+	if !q.Has(UppercaseDashedMAC) {
+		return id
+	}
+	normalized := NormalizeStationID(id)
+	if normalized != id {
+		NormalizedMAC.Success()
+	}
+	return normalized
+}
+
+// NormalizeStationID returns the station ID with its leading MAC address in
+// lower case & colon separated notation, the rest of the ID (e.g. the
+// ":SSID" suffix of a Called-Station-Id) is kept as is
+func NormalizeStationID(id string) string {
+	const macLen = len("aa-bb-cc-dd-ee-ff")
+	if len(id) < macLen || (len(id) > macLen && id[macLen] != ':') {
+		return id
+	}
+	hw, err := net.ParseMAC(id[:macLen])
+	if err != nil {
+		return id
+	}
+	return hw.String() + id[macLen:]
+}
+
+// RestoreAcctSessionID completes an Interim-Update of a NAS with the
+// NoAcctSessionIDOnInterim quirk with the Acct-Session-Id of its session
+func (q Quirks) RestoreAcctSessionID(r *radius.Request, storage session.Storage) {
+	if !q.Has(NoAcctSessionIDOnInterim) || r.Code != radius.CodeAccountingRequest {
+		return
+	}
+	if acctType, err := rfc2866.AcctStatusType_Lookup(r.Packet); err != nil ||
+		acctType != rfc2866.AcctStatusType_Value_InterimUpdate {
+		return
+	}
+	if _, ok := r.Lookup(rfc2866.AcctSessionID_Type); ok {
+		return
+	}
+	state, err := storage.Get()
+	if err != nil || state.AcctSessionID == "" {
+		RestoredAcctSessionID.Failure("session_not_found")
+		return
+	}
+	if err = rfc2866.AcctSessionID_SetString(r.Packet, state.AcctSessionID); err != nil {
+		RestoredAcctSessionID.Failure("set_failed")
+		return
+	}
+	RestoredAcctSessionID.Success()
+}
+
+// Config the interop quirks of a NAS, identified by its NAS-IP-Address,
+// NAS-Identifier or source IP
+type Config struct {
+	NAS    string   `json:"nas" required:"true"`
+	Quirks []string `json:"quirks"` // e.g. stop_before_final_interim
+}
+
+// Table the quirks of NASes by NAS-IP-Address, NAS-Identifier or source IP
+type Table map[string]Quirks
+
+// NewTable returns the table of the configured NASes' quirks
+func NewTable(cfgs []Config) (Table, error) {
+	t := make(Table, len(cfgs))
+	for _, cfg := range cfgs {
+		q, err := Parse(cfg.Quirks)
+		if err != nil {
+			return nil, fmt.Errorf("NAS %s: %v", cfg.NAS, err)
+		}
+		t[cfg.NAS] |= q
+	}
+	return t, nil
+}
+
+// Of returns the quirks of the request's NAS, identified by its
+// NAS-IP-Address, NAS-Identifier or the request's source IP, in this order
+func (t Table) Of(r *radius.Request) Quirks {
+	if len(t) == 0 {
+		return 0
+	}
+	if ip := rfc2865.NASIPAddress_Get(r.Packet); ip != nil {
+		if q, ok := t[ip.String()]; ok {
+			return q
+		}
+	}
+	if id := rfc2865.NASIdentifier_GetString(r.Packet); id != "" {
+		if q, ok := t[id]; ok {
+			return q
+		}
+	}
+	if r.RemoteAddr != nil {
+		if host, _, err := net.SplitHostPort(r.RemoteAddr.String()); err == nil {
+			return t[host]
+		}
+	}
+	return 0
+}
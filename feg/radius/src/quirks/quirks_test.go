@@ -0,0 +1,137 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package quirks
+
+import (
+	"fbc/cwf/radius/session"
+	"fbc/lib/go/radius"
+	"fbc/lib/go/radius/rfc2865"
+	"fbc/lib/go/radius/rfc2866"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newInterimUpdate(acctSessionID string) *radius.Request {
+	packet := radius.New(radius.CodeAccountingRequest, []byte("123456"))
+	rfc2866.AcctStatusType_Set(packet, rfc2866.AcctStatusType_Value_InterimUpdate)
+	if acctSessionID != "" {
+		rfc2866.AcctSessionID_SetString(packet, acctSessionID)
+	}
+	return &radius.Request{Packet: packet}
+}
+
+func TestParse(t *testing.T) {
+	q, err := Parse([]string{"stop_before_final_interim", " Uppercase_Dashed_MAC "})
+	require.NoError(t, err)
+	require.True(t, q.Has(StopBeforeFinalInterim))
+	require.True(t, q.Has(UppercaseDashedMAC))
+	require.False(t, q.Has(NoAcctSessionIDOnInterim))
+	require.Equal(t, "stop_before_final_interim,uppercase_dashed_mac", q.String())
+	require.Equal(t, "3", q.AttributeValue())
+
+	q, err = Parse(nil)
+	require.NoError(t, err)
+	require.Equal(t, Quirks(0), q)
+	require.Equal(t, "", q.String())
+
+	_, err = Parse([]string{"sends_stop_twice"})
+	require.Error(t, err)
+}
+
+func TestStopBeforeFinalInterim(t *testing.T) {
+	// handled by the AAA, the server only passes the quirk on
+	q, err := Parse([]string{"stop_before_final_interim"})
+	require.NoError(t, err)
+	require.Equal(t, "1", q.AttributeValue())
+	require.Equal(t, "AA-BB-CC-DD-EE-FF", q.StationID("AA-BB-CC-DD-EE-FF"))
+}
+
+func TestKilobyteOctets(t *testing.T) {
+	// handled by the AAA, its bit must match the AAA's quirk
+	q, err := Parse([]string{"kilobyte_octets"})
+	require.NoError(t, err)
+	require.Equal(t, "8", q.AttributeValue())
+}
+
+func TestUppercaseDashedMAC(t *testing.T) {
+	// Without the quirk station IDs are matched as sent
+	var none Quirks
+	require.Equal(t, "AA-BB-CC-DD-EE-FF:venue", none.StationID("AA-BB-CC-DD-EE-FF:venue"))
+
+	q := UppercaseDashedMAC
+	require.Equal(t, "aa:bb:cc:dd:ee:ff", q.StationID("AA-BB-CC-DD-EE-FF"))
+	require.Equal(t, "aa:bb:cc:dd:ee:ff:venue", q.StationID("AA-BB-CC-DD-EE-FF:venue"))
+	require.Equal(t, "aa:bb:cc:dd:ee:ff:venue", q.StationID("aa:bb:cc:dd:ee:ff:venue"))
+	require.Equal(t, "", q.StationID(""))
+	require.Equal(t, "venue-ssid", q.StationID("venue-ssid"))
+	require.Equal(t, "AA-BB-CC-DD-EE-FF-00", q.StationID("AA-BB-CC-DD-EE-FF-00"))
+	require.Equal(t, "ZZ-BB-CC-DD-EE-FF", q.StationID("ZZ-BB-CC-DD-EE-FF"))
+}
+
+func TestNoAcctSessionIDOnInterim(t *testing.T) {
+	storage := session.NewSessionStorage(session.NewMultiSessionMemoryStorage(), "sessionID")
+	require.NoError(t, storage.Set(session.State{AcctSessionID: "acct-1"}))
+
+	// Without the quirk the Interim-Update is kept as sent
+	r := newInterimUpdate("")
+	Quirks(0).RestoreAcctSessionID(r, storage)
+	require.Equal(t, "", rfc2866.AcctSessionID_GetString(r.Packet))
+
+	q := NoAcctSessionIDOnInterim
+	q.RestoreAcctSessionID(r, storage)
+	require.Equal(t, "acct-1", rfc2866.AcctSessionID_GetString(r.Packet))
+
+	// Acct-Session-Ids sent by the NAS are kept
+	r = newInterimUpdate("acct-2")
+	q.RestoreAcctSessionID(r, storage)
+	require.Equal(t, "acct-2", rfc2866.AcctSessionID_GetString(r.Packet))
+
+	// Only Interim-Updates are completed
+	r = newInterimUpdate("")
+	rfc2866.AcctStatusType_Set(r.Packet, rfc2866.AcctStatusType_Value_Stop)
+	q.RestoreAcctSessionID(r, storage)
+	require.Equal(t, "", rfc2866.AcctSessionID_GetString(r.Packet))
+
+	// Unknown sessions have no Acct-Session-Id to restore
+	r = newInterimUpdate("")
+	q.RestoreAcctSessionID(r, session.NewSessionStorage(session.NewMultiSessionMemoryStorage(), "unknown"))
+	require.Equal(t, "", rfc2866.AcctSessionID_GetString(r.Packet))
+}
+
+func TestTable(t *testing.T) {
+	table, err := NewTable([]Config{
+		{NAS: "10.0.0.1", Quirks: []string{"uppercase_dashed_mac"}},
+		{NAS: "ap-vendor-x", Quirks: []string{"no_acct_session_id_on_interim"}},
+		{NAS: "10.0.0.1", Quirks: []string{"stop_before_final_interim"}},
+	})
+	require.NoError(t, err)
+
+	// by NAS-IP-Address
+	r := newInterimUpdate("")
+	rfc2865.NASIPAddress_Set(r.Packet, net.IPv4(10, 0, 0, 1))
+	require.Equal(t, UppercaseDashedMAC|StopBeforeFinalInterim, table.Of(r))
+
+	// by NAS-Identifier
+	r = newInterimUpdate("")
+	rfc2865.NASIdentifier_SetString(r.Packet, "ap-vendor-x")
+	require.Equal(t, NoAcctSessionIDOnInterim, table.Of(r))
+
+	// by source IP
+	r = newInterimUpdate("")
+	r.RemoteAddr = &net.UDPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 1813}
+	require.Equal(t, UppercaseDashedMAC|StopBeforeFinalInterim, table.Of(r))
+	r.RemoteAddr = &net.UDPAddr{IP: net.IPv4(10, 0, 0, 2), Port: 1813}
+	require.Equal(t, Quirks(0), table.Of(r))
+	require.Equal(t, Quirks(0), Table(nil).Of(r))
+
+	_, err = NewTable([]Config{{NAS: "10.0.0.1", Quirks: []string{"unknown"}}})
+	require.Error(t, err)
+}
@@ -33,7 +33,18 @@ function lint {
 }
 
 function build {
-    ${GO} build .
+    # identify the build, see the buildinfo package
+    BUILDINFO=fbc/cwf/radius/buildinfo
+    VERSION=$(git describe --tags --always 2>/dev/null || echo dev)
+    COMMIT=$(git rev-parse HEAD 2>/dev/null)
+    BUILD_TIME=$(date -u +%Y-%m-%dT%H:%M:%SZ)
+    LDFLAGS="-X ${BUILDINFO}.Version=${VERSION} -X ${BUILDINFO}.Commit=${COMMIT}"
+    ${GO} build -tags "${BUILD_TAGS}" -ldflags "${LDFLAGS} -X ${BUILDINFO}.BuildTime=${BUILD_TIME}" .
+}
+
+# the oss build has no ODS & Scuba (Facebook Graph API) code, the counters are only exported to Prometheus
+function build_oss {
+    BUILD_TAGS=oss build
 }
 
 function gen {
@@ -71,6 +82,9 @@ function e2e {
 }
 
 case $1 in
+build-oss*)
+	build_oss
+	;;
 build*)
 	build
 	;;
@@ -96,6 +110,6 @@ gen*)
     gen
     ;;
 *)
-	echo "usage: ./run.sh {build | clean | start | test | e2e | lint | gen}"
+	echo "usage: ./run.sh {build | build-oss | clean | start | test | e2e | lint | gen}"
 	;;
 esac
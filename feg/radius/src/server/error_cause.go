@@ -0,0 +1,94 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package server
+
+import (
+	"fmt"
+
+	"fbc/cwf/radius/modules"
+	"fbc/lib/go/radius"
+	"fbc/lib/go/radius/rfc3576"
+	"fbc/lib/go/retry"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorResponsesConfig configures RADIUS error responses of requests failed by AAA GRPC errors
+type ErrorResponsesConfig struct {
+	// Enabled - failed Access-Requests are rejected & failed CoA/Disconnect-Requests are NAKed with the Error-Cause
+	// of the GRPC error, instead of being dropped
+	Enabled bool `json:"enabled"`
+	// Causes overrides the default Error-Cause of GRPC status codes by code name, e.g. {"FailedPrecondition": 503} or
+	// {"FAILED_PRECONDITION": 503}, 0 removes the code's default Error-Cause
+	Causes map[string]uint32 `json:"causes"`
+}
+
+// defaultErrorCauses - Error-Cause (RFC 5176) of GRPC status codes of errors which are not resolved by retransmitting
+// the request. Requests failed with other (transient) errors are still dropped, so NASes retransmit them
+var defaultErrorCauses = map[codes.Code]rfc3576.ErrorCause{
+	codes.InvalidArgument:    rfc3576.ErrorCause_Value_InvalidRequest,
+	codes.FailedPrecondition: rfc3576.ErrorCause_Value_SessionContextNotFound,
+	codes.NotFound:           rfc3576.ErrorCause_Value_SessionContextNotFound,
+	codes.PermissionDenied:   rfc3576.ErrorCause_Value_AdministrativelyProhibited,
+	codes.Unimplemented:      rfc3576.ErrorCause_Value_UnsupportedService,
+}
+
+// errorResponseCodes - error response codes of the requests which have one, Accounting-Requests have none
+var errorResponseCodes = map[radius.Code]radius.Code{
+	radius.CodeAccessRequest:     radius.CodeAccessReject,
+	radius.CodeCoARequest:        radius.CodeCoANAK,
+	radius.CodeDisconnectRequest: radius.CodeDisconnectNAK,
+}
+
+// errorCauses maps GRPC status codes to Error-Causes
+type errorCauses map[codes.Code]rfc3576.ErrorCause
+
+// newErrorCauses returns the Error-Causes of the configuration, nil if error responses are disabled
+func newErrorCauses(cfg *ErrorResponsesConfig) (errorCauses, error) {
+	if cfg == nil || !cfg.Enabled {
+		return nil, nil
+	}
+	causes := errorCauses{}
+	for code, cause := range defaultErrorCauses {
+		causes[code] = cause
+	}
+	for name, cause := range cfg.Causes {
+		code, err := retry.ParseCode(name)
+		if err != nil || code == codes.OK {
+			return nil, fmt.Errorf("invalid GRPC status code '%s' of Error-Cause %d", name, cause)
+		}
+		if cause == 0 {
+			delete(causes, code)
+			continue
+		}
+		causes[code] = rfc3576.ErrorCause(cause)
+	}
+	return causes, nil
+}
+
+// errorResponse returns the error response of the request failed with the given error, nil if the request has no
+// error response or the error has no Error-Cause
+func (c errorCauses) errorResponse(r *radius.Request, err error) *modules.Response {
+	code, ok := errorResponseCodes[r.Code]
+	if !ok || len(c) == 0 {
+		return nil
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return nil
+	}
+	cause, ok := c[st.Code()]
+	if !ok {
+		return nil
+	}
+	attrs := radius.Attributes{}
+	attrs.Add(rfc3576.ErrorCause_Type, radius.NewInteger(uint32(cause)))
+	return &modules.Response{Code: code, Attributes: attrs}
+}
@@ -0,0 +1,100 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package server
+
+import (
+	"errors"
+	"testing"
+
+	"fbc/lib/go/radius"
+	"fbc/lib/go/radius/rfc3576"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestNewErrorCauses(t *testing.T) {
+	// Act
+	causes, err := newErrorCauses(&ErrorResponsesConfig{Enabled: false})
+
+	// Assert
+	require.NoError(t, err)
+	require.Nil(t, causes)
+
+	// Act
+	causes, err = newErrorCauses(&ErrorResponsesConfig{
+		Enabled: true,
+		Causes:  map[string]uint32{"Unavailable": 506, "NotFound": 0},
+	})
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, rfc3576.ErrorCause_Value_ResourcesUnavailable, causes[codes.Unavailable])
+	require.Equal(t, rfc3576.ErrorCause_Value_InvalidRequest, causes[codes.InvalidArgument])
+	_, ok := causes[codes.NotFound]
+	require.False(t, ok)
+
+	// Act
+	causes, err = newErrorCauses(&ErrorResponsesConfig{
+		Enabled: true,
+		Causes:  map[string]uint32{"RESOURCE_EXHAUSTED": 506},
+	})
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, rfc3576.ErrorCause_Value_ResourcesUnavailable, causes[codes.ResourceExhausted])
+
+	// Act
+	_, err = newErrorCauses(&ErrorResponsesConfig{Enabled: true, Causes: map[string]uint32{"NotACode": 404}})
+
+	// Assert
+	require.Error(t, err)
+}
+
+func TestErrorResponse(t *testing.T) {
+	// Arrange
+	causes, err := newErrorCauses(&ErrorResponsesConfig{Enabled: true})
+	require.NoError(t, err)
+	failedPrecondition := status.Error(codes.FailedPrecondition, "Session was not authenticated")
+	request := func(code radius.Code) *radius.Request {
+		return &radius.Request{Packet: radius.New(code, []byte("secret"))}
+	}
+
+	// Act
+	res := causes.errorResponse(request(radius.CodeCoARequest), failedPrecondition)
+
+	// Assert
+	require.NotNil(t, res)
+	require.Equal(t, radius.CodeCoANAK, res.Code)
+	require.Equal(t, []radius.Attribute{radius.NewInteger(503)}, res.Attributes[rfc3576.ErrorCause_Type])
+
+	// Act
+	res = causes.errorResponse(request(radius.CodeDisconnectRequest), status.Error(codes.InvalidArgument, "Nil"))
+
+	// Assert
+	require.NotNil(t, res)
+	require.Equal(t, radius.CodeDisconnectNAK, res.Code)
+	require.Equal(t, []radius.Attribute{radius.NewInteger(404)}, res.Attributes[rfc3576.ErrorCause_Type])
+
+	// Act
+	res = causes.errorResponse(request(radius.CodeAccessRequest), failedPrecondition)
+
+	// Assert
+	require.NotNil(t, res)
+	require.Equal(t, radius.CodeAccessReject, res.Code)
+
+	// Accounting-Requests have no error response, transient & non GRPC errors are dropped
+	require.Nil(t, causes.errorResponse(request(radius.CodeAccountingRequest), failedPrecondition))
+	require.Nil(t, causes.errorResponse(request(radius.CodeCoARequest), status.Error(codes.Unavailable, "Down")))
+	require.Nil(t, causes.errorResponse(request(radius.CodeCoARequest), errors.New("timeout")))
+
+	// disabled error responses
+	require.Nil(t, errorCauses(nil).errorResponse(request(radius.CodeCoARequest), failedPrecondition))
+}
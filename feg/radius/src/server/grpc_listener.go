@@ -11,6 +11,7 @@ package server
 import (
 	"context"
 	"errors"
+	"fbc/cwf/radius/certmanager"
 	"fbc/cwf/radius/config"
 	"fbc/cwf/radius/modules"
 	"fbc/cwf/radius/modules/protos"
@@ -19,14 +20,19 @@ import (
 	"fmt"
 	"math/rand"
 	"net"
+	"strconv"
 
 	"fbc/lib/go/radius"
 	"fbc/lib/go/radius/rfc2865"
 	"fbc/lib/go/radius/rfc2866"
+	"fbc/lib/go/radius/rfc2868"
+	"fbc/lib/go/radius/rfc3576"
+	"fbc/lib/go/retry"
 
 	"github.com/mitchellh/mapstructure"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 // GRPCListener listens to gRpc
@@ -35,12 +41,25 @@ type GRPCListener struct {
 	GrpcServer *grpc.Server
 	Server     *Server
 	Port       int
+	unixSocket string
+	allowedUID []int
 	ready      chan bool
+	certs      *certmanager.CertManager
+	errorCause bool
 }
 
 // GRPCListenerExtraConfig extra config for GRPC listener
 type GRPCListenerExtraConfig struct {
-	Port int `json:"port"`
+	Port int                 `json:"port"`
+	TLS  *certmanager.Config `json:"tls"` // Optional, serve over (m)TLS with hitless certificate rotation
+	// ErrorCause adds the Error-Cause attribute of the termination reason to Disconnect-Requests. RFC 5176 only
+	// allows Error-Cause in NAKs, so it's off by default & should only be enabled for NASes known to accept it
+	ErrorCause bool `json:"error_cause"`
+	// UnixSocket serves on the unix domain socket instead of the port if set, connecting peers are authenticated by
+	// their SO_PEERCRED credentials
+	UnixSocket string `json:"unixSocket"`
+	// AllowedUIDs UIDs allowed to connect to the unix domain socket, besides root & this process' user
+	AllowedUIDs []int `json:"allowedUIDs"`
 }
 
 // NewGRPCListener ...
@@ -69,21 +88,50 @@ func (l *GRPCListener) Init(
 
 	l.Server = server
 	l.Port = cfg.Port
+	l.errorCause = cfg.ErrorCause
+	l.unixSocket = cfg.UnixSocket
+	l.allowedUID = cfg.AllowedUIDs
+	if len(l.unixSocket) > 0 && cfg.TLS != nil {
+		return errors.New("grpc listener: TLS is not supported over unix domain sockets")
+	}
+	if cfg.TLS != nil {
+		l.certs, err = certmanager.New(*cfg.TLS, server.logger)
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
 // ListenAndServe override
 func (l *GRPCListener) ListenAndServe() error {
 	// Start listenning
-	listenAddress := fmt.Sprintf(":%d", l.Port)
-	lis, err := net.Listen("tcp", listenAddress)
-	if err != nil {
-		l.ready <- false
-		return errors.New("grpc listener: failed to open tcp connection" + listenAddress)
+	var (
+		lis net.Listener
+		err error
+	)
+	if len(l.unixSocket) > 0 {
+		lis, err = listenUnix(l.unixSocket, l.allowedUID, l.Server.logger)
+		if err != nil {
+			l.ready <- false
+			return fmt.Errorf("grpc listener: failed to listen on unix socket %s: %v", l.unixSocket, err)
+		}
+	} else {
+		listenAddress := fmt.Sprintf(":%d", l.Port)
+		lis, err = net.Listen("tcp", listenAddress)
+		if err != nil {
+			l.ready <- false
+			return errors.New("grpc listener: failed to open tcp connection" + listenAddress)
+		}
 	}
 
 	// Start serving
-	l.GrpcServer = grpc.NewServer()
+	var opts []grpc.ServerOption
+	if l.certs != nil {
+		l.certs.Start()
+		opts = append(opts, grpc.Creds(credentials.NewTLS(l.certs.ServerTLSConfig())))
+	}
+	l.GrpcServer = grpc.NewServer(opts...)
 	protos.RegisterAuthorizationServer(l.GrpcServer, &authorizationServer{Listener: l})
 	go func() {
 		l.GrpcServer.Serve(lis)
@@ -103,6 +151,9 @@ func (l *GRPCListener) GetHandleRequest() modules.Middleware {
 
 // Shutdown override
 func (l *GRPCListener) Shutdown(ctx context.Context) error {
+	if l.certs != nil {
+		l.certs.Stop()
+	}
 	return nil
 }
 
@@ -129,6 +180,42 @@ func (s *authorizationServer) Change(ctx context.Context, request *protos.Change
 		},
 	}
 
+	// Bandwidth changes are sent as CoA-Request with WISPr bandwidth attributes
+	if request.GetMaxBandwidthUp() > 0 || request.GetMaxBandwidthDown() > 0 {
+		attrs, err := bandwidthAttributes(request.GetMaxBandwidthUp(), request.GetMaxBandwidthDown())
+		if err != nil {
+			return nil, err
+		}
+		req.Code = radius.CodeCoARequest
+		req.Attributes = attrs
+	}
+
+	// Quarantine moves the session to the quarantine profile's VLAN, filter & remediation page, otherwise policy
+	// changes move the session to the policy's VLAN & filter via CoA-Request
+	var (
+		attrs radius.Attributes
+		err   error
+	)
+	if quarantine := request.GetQuarantine(); quarantine != nil {
+		attrs, err = quarantineAttributes(quarantine)
+	} else if request.GetVlanId() > 0 || len(request.GetFilterId()) > 0 {
+		attrs, err = policyAttributes(request.GetVlanId(), request.GetFilterId())
+	}
+	if err != nil {
+		return nil, err
+	}
+	if attrs != nil {
+		if req.Attributes == nil {
+			req.Attributes = radius.Attributes{}
+		}
+		for typ, values := range attrs {
+			for _, value := range values {
+				req.Attributes.Add(typ, value)
+			}
+		}
+		req.Code = radius.CodeCoARequest
+	}
+
 	// Handle RADIUS request
 	return s.handleCoaRequest(request.Ctx, &req)
 }
@@ -141,6 +228,7 @@ func (s *authorizationServer) Disconnect(ctx context.Context, request *protos.Di
 			Secret: []byte(s.Listener.Server.config.Secret),
 		},
 	}
+	req.Attributes = disconnectAttributes(request.GetReason(), request.GetReplyMessage(), s.Listener.errorCause)
 
 	// Handle RADIUS request
 	return s.handleCoaRequest(request.Ctx, &req)
@@ -166,6 +254,7 @@ func (s *authorizationServer) handleCoaRequest(ctx *protos.Context, request *rad
 			srv.multiSessionStorage,
 			ctx.SessionId,
 		),
+		NASAddress: nasHost(ctx),
 	}
 
 	// Load state, read CoA identifier and persist the state again
@@ -175,7 +264,9 @@ func (s *authorizationServer) handleCoaRequest(ctx *protos.Context, request *rad
 	}
 
 	// Add Acct-Session-Id attribute
-	request.Attributes = radius.Attributes{}
+	if request.Attributes == nil {
+		request.Attributes = radius.Attributes{}
+	}
 	request.Set(rfc2866.AcctSessionID_Type, radius.Attribute(state.AcctSessionID))
 	request.Set(rfc2865.CallingStationID_Type, radius.Attribute(ctx.MacAddr))
 
@@ -197,6 +288,7 @@ func (s *authorizationServer) handleCoaRequest(ctx *protos.Context, request *rad
 		return nil, err
 	}
 	counter.Success()
+	logErrorCause(requestContext.Logger, res)
 
 	// Persist state
 	err = srv.multiSessionStorage.Set(ctx.SessionId, *state)
@@ -211,6 +303,165 @@ func (s *authorizationServer) handleCoaRequest(ctx *protos.Context, request *rad
 	}, nil
 }
 
+// nasHost returns the host of the session's NAS address known to the AAA, empty if unknown
+func nasHost(ctx *protos.Context) string {
+	addr, ok := ctx.GetAttribute(protos.NASAddressAttribute)
+	if !ok {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return ""
+	}
+	return host
+}
+
+const (
+	// wisprVendorID the IANA enterprise number of the Wi-Fi Alliance (WISPr)
+	wisprVendorID = 14122
+	// WISPr-Bandwidth-Max-Up & WISPr-Bandwidth-Max-Down vendor attribute types (bits per second)
+	wisprBandwidthMaxUpType   = 7
+	wisprBandwidthMaxDownType = 8
+	// WISPr-Redirection-URL vendor attribute type
+	wisprRedirectionURLType = 4
+	// tunnelTypeVLAN Tunnel-Type of VLAN assignments (RFC 3580)
+	tunnelTypeVLAN rfc2868.TunnelType = 13
+	// maxAttributeLen the maximum length of a RADIUS attribute value
+	maxAttributeLen = 253
+)
+
+// bandwidthAttributes returns WISPr-Bandwidth-Max-Up/Down vendor attributes for the non zero bandwidths
+func bandwidthAttributes(up, down uint32) (radius.Attributes, error) {
+	attrs := radius.Attributes{}
+	for _, bw := range []struct {
+		typ   byte
+		value uint32
+	}{{wisprBandwidthMaxUpType, up}, {wisprBandwidthMaxDownType, down}} {
+		if bw.value == 0 {
+			continue
+		}
+		value := append([]byte{bw.typ, 6}, radius.NewInteger(bw.value)...)
+		vsa, err := radius.NewVendorSpecific(wisprVendorID, radius.Attribute(value))
+		if err != nil {
+			return nil, fmt.Errorf("failed encoding WISPr bandwidth attribute: %s", err.Error())
+		}
+		attrs.Add(rfc2865.VendorSpecific_Type, vsa)
+	}
+	return attrs, nil
+}
+
+// policyAttributes returns VLAN assignment (Tunnel-Type, Tunnel-Medium-Type & Tunnel-Private-Group-Id) & Filter-Id
+// attributes of the set VLAN & filter
+func policyAttributes(vlan uint32, filter string) (radius.Attributes, error) {
+	p := &radius.Packet{Attributes: radius.Attributes{}}
+	if vlan > 0 {
+		// The tag (0) of tagged integer attributes is their value's first octet, rfc2868 helpers prepend it
+		p.Attributes.Add(rfc2868.TunnelType_Type, radius.NewInteger(uint32(tunnelTypeVLAN)))
+		p.Attributes.Add(
+			rfc2868.TunnelMediumType_Type, radius.NewInteger(uint32(rfc2868.TunnelMediumType_Value_IEEE802)))
+		if err := rfc2868.TunnelPrivateGroupID_AddString(p, 0, strconv.FormatUint(uint64(vlan), 10)); err != nil {
+			return nil, fmt.Errorf("failed encoding VLAN attributes: %s", err.Error())
+		}
+	}
+	if len(filter) > 0 {
+		if err := rfc2865.FilterID_AddString(p, filter); err != nil {
+			return nil, fmt.Errorf("failed encoding Filter-Id: %s", err.Error())
+		}
+	}
+	return p.Attributes, nil
+}
+
+// quarantineAttributes returns VLAN assignment (Tunnel-Type, Tunnel-Medium-Type & Tunnel-Private-Group-Id),
+// Filter-Id & WISPr-Redirection-URL attributes of the quarantine profile's set fields
+func quarantineAttributes(profile *protos.QuarantineProfile) (radius.Attributes, error) {
+	attrs, err := policyAttributes(profile.GetVlanId(), profile.GetFilterId())
+	if err != nil {
+		return nil, fmt.Errorf("invalid quarantine profile: %s", err.Error())
+	}
+	if url := profile.GetRedirectUrl(); len(url) > 0 {
+		if len(url)+8 > maxAttributeLen {
+			return nil, fmt.Errorf("quarantine redirection URL is too long: %d bytes", len(url))
+		}
+		value := append([]byte{wisprRedirectionURLType, byte(len(url) + 2)}, url...)
+		vsa, err := radius.NewVendorSpecific(wisprVendorID, radius.Attribute(value))
+		if err != nil {
+			return nil, fmt.Errorf("failed encoding WISPr redirection URL attribute: %s", err.Error())
+		}
+		attrs.Add(rfc2865.VendorSpecific_Type, vsa)
+	}
+	if len(attrs) == 0 {
+		return nil, errors.New("empty quarantine profile")
+	}
+	return attrs, nil
+}
+
+// replyMessages - default Reply-Message of each termination reason
+var replyMessages = map[protos.TerminateReason]string{
+	protos.TerminateReason_QUOTA_EXHAUSTED:    "Your data quota is exhausted",
+	protos.TerminateReason_ADMIN_ACTION:       "Your session was terminated by the operator",
+	protos.TerminateReason_POLICY:             "Service is not available at this time",
+	protos.TerminateReason_SUBSCRIPTION_ENDED: "Your subscription does not allow this service",
+	protos.TerminateReason_SESSION_IDLE:       "Your session expired due to inactivity",
+	protos.TerminateReason_GUEST_TIME_LIMIT:   "Your guest session time limit has been reached",
+	protos.TerminateReason_SESSION_LIFETIME:   "Your session time limit has been reached, please reconnect",
+}
+
+// terminateErrorCauses - Error-Cause (RFC 5176) of each termination reason
+var terminateErrorCauses = map[protos.TerminateReason]rfc3576.ErrorCause{
+	protos.TerminateReason_QUOTA_EXHAUSTED:    rfc3576.ErrorCause_Value_ResourcesUnavailable,
+	protos.TerminateReason_ADMIN_ACTION:       rfc3576.ErrorCause_Value_AdministrativelyProhibited,
+	protos.TerminateReason_POLICY:             rfc3576.ErrorCause_Value_AdministrativelyProhibited,
+	protos.TerminateReason_SUBSCRIPTION_ENDED: rfc3576.ErrorCause_Value_UnsupportedService,
+	protos.TerminateReason_SESSION_IDLE:       rfc3576.ErrorCause_Value_RequestInitiated,
+	protos.TerminateReason_GUEST_TIME_LIMIT:   rfc3576.ErrorCause_Value_RequestInitiated,
+	protos.TerminateReason_SESSION_LIFETIME:   rfc3576.ErrorCause_Value_RequestInitiated,
+}
+
+// disconnectAttributes returns Reply-Message (& optionally Error-Cause) attributes of the termination reason, the
+// given message overrides the reason's default message. Messages longer than an attribute are split into several
+// Reply-Message attributes, which NASes concatenate
+func disconnectAttributes(reason protos.TerminateReason, msg string, errorCause bool) radius.Attributes {
+	attrs := radius.Attributes{}
+	if len(msg) == 0 {
+		msg = replyMessages[reason]
+	}
+	for len(msg) > 0 {
+		n := len(msg)
+		if n > maxAttributeLen {
+			n = maxAttributeLen
+		}
+		attrs.Add(rfc2865.ReplyMessage_Type, radius.Attribute(msg[:n]))
+		msg = msg[n:]
+	}
+	if cause, ok := terminateErrorCauses[reason]; ok && errorCause {
+		attrs.Add(rfc3576.ErrorCause_Type, radius.NewInteger(uint32(cause)))
+	}
+	return attrs
+}
+
+// logErrorCause logs the Error-Cause of a NAK & its class, e.g. a Disconnect-NAK of an unknown session is permanent
+// while a NAS lacking resources may accept the request later
+func logErrorCause(logger *zap.Logger, res *modules.Response) {
+	if convertCoaCode(res.Code) != protos.CoaResponse_NAK {
+		return
+	}
+	attr, ok := res.Attributes.Lookup(rfc3576.ErrorCause_Type)
+	if !ok {
+		return
+	}
+	cause, err := radius.Integer(attr)
+	if err != nil {
+		logger.Warn("NAS responded with an invalid Error-Cause", zap.Int("code", int(res.Code)), zap.Error(err))
+		return
+	}
+	logger.Warn(
+		"NAS responded with an Error-Cause",
+		zap.Int("code", int(res.Code)),
+		zap.String("error_cause", rfc3576.ErrorCause(cause).String()),
+		zap.Stringer("error_class", retry.ErrorCauseClass(cause)),
+	)
+}
+
 func convertCoaCode(code radius.Code) protos.CoaResponseCoaResponseTypeEnum {
 	if code == radius.CodeCoAACK || code == radius.CodeDisconnectACK {
 		return protos.CoaResponse_ACK
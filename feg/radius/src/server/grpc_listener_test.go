@@ -0,0 +1,162 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package server
+
+import (
+	"strings"
+	"testing"
+
+	"fbc/cwf/radius/modules"
+	"fbc/cwf/radius/modules/protos"
+	"fbc/lib/go/radius"
+	"fbc/lib/go/radius/rfc2865"
+	"fbc/lib/go/radius/rfc2868"
+	"fbc/lib/go/radius/rfc3576"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestBandwidthAttributes(t *testing.T) {
+	// Act
+	attrs, err := bandwidthAttributes(1000000, 0x01020304)
+
+	// Assert
+	require.NoError(t, err)
+	vsas := attrs[rfc2865.VendorSpecific_Type]
+	require.Len(t, vsas, 2)
+	require.Equal(t, radius.Attribute{0, 0, 0x37, 0x2a, 7, 6, 0, 0x0f, 0x42, 0x40}, vsas[0])
+	require.Equal(t, radius.Attribute{0, 0, 0x37, 0x2a, 8, 6, 1, 2, 3, 4}, vsas[1])
+
+	// Act
+	attrs, err = bandwidthAttributes(0, 5)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, attrs[rfc2865.VendorSpecific_Type], 1)
+	require.Equal(t, byte(wisprBandwidthMaxDownType), attrs[rfc2865.VendorSpecific_Type][0][4])
+}
+
+func TestPolicyAttributes(t *testing.T) {
+	// Act
+	attrs, err := policyAttributes(100, "gold")
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, []radius.Attribute{{0, 0, 0, 13}}, attrs[rfc2868.TunnelType_Type])
+	require.Equal(t, []radius.Attribute{{0, 0, 0, 6}}, attrs[rfc2868.TunnelMediumType_Type])
+	require.Equal(t, []radius.Attribute{radius.Attribute("\x00100")}, attrs[rfc2868.TunnelPrivateGroupID_Type])
+	require.Equal(t, []radius.Attribute{radius.Attribute("gold")}, attrs[rfc2865.FilterID_Type])
+
+	// Act
+	attrs, err = policyAttributes(0, "gold")
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, attrs, 1)
+
+	// Act
+	attrs, err = policyAttributes(0, "")
+
+	// Assert
+	require.NoError(t, err)
+	require.Empty(t, attrs)
+}
+
+func TestQuarantineAttributes(t *testing.T) {
+	// Act
+	attrs, err := quarantineAttributes(&protos.QuarantineProfile{
+		VlanId: 999, FilterId: "quarantine", RedirectUrl: "http://portal"})
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, []radius.Attribute{{0, 0, 0, 13}}, attrs[rfc2868.TunnelType_Type])
+	require.Equal(t, []radius.Attribute{{0, 0, 0, 6}}, attrs[rfc2868.TunnelMediumType_Type])
+	require.Equal(t, []radius.Attribute{radius.Attribute("\x00999")}, attrs[rfc2868.TunnelPrivateGroupID_Type])
+	require.Equal(t, []radius.Attribute{radius.Attribute("quarantine")}, attrs[rfc2865.FilterID_Type])
+	vsas := attrs[rfc2865.VendorSpecific_Type]
+	require.Len(t, vsas, 1)
+	require.Equal(t, append(radius.Attribute{0, 0, 0x37, 0x2a, 4, 15}, "http://portal"...), vsas[0])
+
+	// Act
+	attrs, err = quarantineAttributes(&protos.QuarantineProfile{FilterId: "quarantine"})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, attrs, 1)
+
+	// Act
+	_, err = quarantineAttributes(&protos.QuarantineProfile{})
+
+	// Assert
+	require.Error(t, err)
+
+	// Act
+	_, err = quarantineAttributes(&protos.QuarantineProfile{RedirectUrl: strings.Repeat("u", 250)})
+
+	// Assert
+	require.Error(t, err)
+}
+
+func TestDisconnectAttributes(t *testing.T) {
+	// Act
+	attrs := disconnectAttributes(protos.TerminateReason_QUOTA_EXHAUSTED, "", false)
+
+	// Assert
+	require.Equal(t, []radius.Attribute{radius.Attribute(replyMessages[protos.TerminateReason_QUOTA_EXHAUSTED])},
+		attrs[rfc2865.ReplyMessage_Type])
+	require.Empty(t, attrs[rfc3576.ErrorCause_Type])
+
+	// Act
+	attrs = disconnectAttributes(protos.TerminateReason_ADMIN_ACTION, "Maintenance", true)
+
+	// Assert
+	require.Equal(t, []radius.Attribute{radius.Attribute("Maintenance")}, attrs[rfc2865.ReplyMessage_Type])
+	require.Equal(t, []radius.Attribute{radius.NewInteger(501)}, attrs[rfc3576.ErrorCause_Type])
+
+	// Act
+	attrs = disconnectAttributes(protos.TerminateReason_UNSPECIFIED_REASON, strings.Repeat("a", 300), true)
+
+	// Assert
+	require.Len(t, attrs[rfc2865.ReplyMessage_Type], 2)
+	require.Len(t, attrs[rfc2865.ReplyMessage_Type][0], maxAttributeLen)
+	require.Len(t, attrs[rfc2865.ReplyMessage_Type][1], 300-maxAttributeLen)
+	require.Empty(t, attrs[rfc3576.ErrorCause_Type])
+
+	// Act
+	attrs = disconnectAttributes(protos.TerminateReason_UNSPECIFIED_REASON, "", true)
+
+	// Assert
+	require.Empty(t, attrs)
+}
+
+func TestLogErrorCause(t *testing.T) {
+	// Arrange
+	core, logs := observer.New(zap.WarnLevel)
+	logger := zap.New(core)
+	nak := func(cause uint32) *modules.Response {
+		attrs := radius.Attributes{}
+		attrs.Add(rfc3576.ErrorCause_Type, radius.NewInteger(cause))
+		return &modules.Response{Code: radius.CodeDisconnectNAK, Attributes: attrs}
+	}
+
+	// Act
+	logErrorCause(logger, nak(503))
+	logErrorCause(logger, nak(506))
+	logErrorCause(logger, &modules.Response{Code: radius.CodeDisconnectNAK})
+	logErrorCause(logger, &modules.Response{Code: radius.CodeCoAACK, Attributes: nak(201).Attributes})
+
+	// Assert
+	require.Equal(t, 2, logs.Len())
+	fields := logs.AllUntimed()[0].ContextMap()
+	require.Equal(t, "Session-Context-Not-Found", fields["error_cause"])
+	require.Equal(t, "permanent", fields["error_class"])
+	require.Equal(t, "transient", logs.AllUntimed()[1].ContextMap()["error_class"])
+}
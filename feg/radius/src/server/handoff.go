@@ -0,0 +1,408 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fbc/cwf/radius/config"
+	"fbc/cwf/radius/modules"
+	"fbc/cwf/radius/session"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Zero downtime upgrades hand the UDP listeners' sockets & the in-flight state of a running server process off to
+// a new one, over the handoff unix socket served by the running process:
+//  1. the new process connects to the handoff socket, the processes must run as the same user
+//  2. the old process sends a marker byte carrying the UDP listeners' socket fds (SCM_RIGHTS), followed by a
+//     handoffHeader naming the listeners of the fds. From here on the sockets are shared by both processes
+//  3. the old process stops reading from the sockets, waits for its in-flight requests & shuts its listeners down
+//  4. the old process sends a handoffState of its sessions & stateful modules
+//  5. the new process replies a handoffAck, after which the old process terminates & the new process serves the
+//     handed off sockets with the imported state
+// Requests arriving during the handoff are queued in the sockets' receive buffers, so EAP conversations are not
+// dropped by upgrades
+
+// handoffMarker the byte carrying the handed off sockets' fds
+const handoffMarker = 'H'
+
+// maxHandoffSockets maximal number of sockets handed off
+const maxHandoffSockets = 64
+
+type (
+	handoffHeader struct {
+		Listeners []string `json:"listeners"` // names of the listeners of the handed off sockets, in fds order
+	}
+
+	moduleState struct {
+		Name  string          `json:"name"`
+		State json.RawMessage `json:"state"`
+	}
+
+	handoffState struct {
+		Sessions map[string]session.State `json:"sessions"`
+		Modules  map[string][]moduleState `json:"modules"` // states of stateful modules by listener names
+	}
+
+	handoffAck struct {
+		Error string `json:"error,omitempty"`
+	}
+
+	// Handoff the sockets & state handed off by the previous server process
+	Handoff struct {
+		conns map[string]net.PacketConn
+		state handoffState
+	}
+)
+
+// TakeOver takes the UDP listeners' sockets & the in-flight state over from the server process serving the
+// handoff socket. It returns nil if handoffs are disabled or no server process serves the handoff socket
+func TakeOver(cfg *config.HandoffConfig, logger *zap.Logger) (*Handoff, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+	conn, err := net.DialUnix("unix", nil, &net.UnixAddr{Name: cfg.SocketPath, Net: "unix"})
+	if err != nil {
+		logger.Info(
+			"no server process to take over, starting fresh",
+			zap.String("socket_path", cfg.SocketPath),
+			zap.Error(err),
+		)
+		return nil, nil
+	}
+	defer conn.Close()
+	// the handed off sockets & state are only taken from a server process of this process' user
+	if err = checkHandoffPeer(conn); err != nil {
+		return nil, err
+	}
+	// the old process may need the whole timeout for draining its in-flight requests
+	conn.SetDeadline(time.Now().Add(2 * handoffTimeout(cfg)))
+
+	handoff, err := receiveHandoff(conn)
+	if err != nil {
+		return nil, err
+	}
+	logger.Info(
+		"took over the previous server process",
+		zap.Int("num_sockets", len(handoff.conns)),
+		zap.Int("num_sessions", len(handoff.state.Sessions)),
+	)
+	return handoff, nil
+}
+
+// Resume makes the server serve the sockets handed off by the previous server process & imports its state.
+// Handed off sockets not matching any of the server's UDP listeners are closed
+func (s Server) Resume(handoff *Handoff) error {
+	if handoff == nil {
+		return nil
+	}
+	for name, conn := range handoff.conns {
+		listener, ok := s.listeners[name].(*UDPListener)
+		if !ok || !listener.takeOver(conn) {
+			s.logger.Warn("handed off socket matches no UDP listener, closing it", zap.String("listener", name))
+			conn.Close()
+		}
+	}
+	for sessionID, state := range handoff.state.Sessions {
+		if err := s.multiSessionStorage.Set(sessionID, state); err != nil {
+			return err
+		}
+	}
+	for name, states := range handoff.state.Modules {
+		listener, ok := s.listeners[name]
+		if !ok {
+			continue
+		}
+		for _, module := range listener.GetModules() {
+			stateful, ok := module.Context.(modules.StatefulContext)
+			if !ok {
+				continue
+			}
+			for _, state := range states {
+				if state.Name != module.Name {
+					continue
+				}
+				if err := stateful.ImportState(state.State); err != nil {
+					return fmt.Errorf("failed importing state of module %s of listener %s: %v", module.Name, name, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// OnHandoff adds a hook called once the server hands off to the next server process, after its listeners are
+// shut down, e.g. for releasing ports other than the listeners'. Hooks are called in the order they were added
+func (s *Server) OnHandoff(hook func()) {
+	s.onHandoff = append(s.onHandoff, hook)
+}
+
+// serveHandoffs serves the handoff socket, the server hands off to the first server process connecting to it &
+// terminates
+func (s Server) serveHandoffs(cfg *config.HandoffConfig) error {
+	listener, err := listenHandoffs(cfg.SocketPath)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		defer listener.Close()
+		for {
+			conn, err := listener.AcceptUnix()
+			if err != nil {
+				s.logger.Error("failed accepting handoff connection", zap.Error(err))
+				return
+			}
+			if err = checkHandoffPeer(conn); err != nil {
+				s.logger.Warn("handoff connection rejected", zap.Error(err))
+				conn.Close()
+				continue
+			}
+			handedOff, err := s.handOff(conn, handoffTimeout(cfg))
+			conn.Close()
+			if err != nil {
+				s.logger.Error("handoff failed", zap.Bool("sockets_handed_off", handedOff), zap.Error(err))
+			}
+			if !handedOff {
+				continue
+			}
+			s.logger.Info("handed off to the next server process, terminating server")
+			s.terminate <- true
+			return
+		}
+	}()
+	return nil
+}
+
+// listenHandoffs listens on the handoff socket, only this process' user may connect to it
+func listenHandoffs(socketPath string) (*net.UnixListener, error) {
+	// a stale socket file of the previous server process blocks listening
+	os.Remove(socketPath)
+	listener, err := net.ListenUnix("unix", &net.UnixAddr{Name: socketPath, Net: "unix"})
+	if err != nil {
+		return nil, err
+	}
+	if err = os.Chmod(socketPath, 0600); err != nil {
+		listener.Close()
+		return nil, err
+	}
+	// the socket file is replaced by the next server process, which must not lose it when this process exits
+	listener.SetUnlinkOnClose(false)
+	return listener, nil
+}
+
+// checkHandoffPeer checks that the peer of the handoff connection runs as this process' user, the UDP listeners'
+// sockets & the sessions are never handed off to or taken over from another user's process
+func checkHandoffPeer(conn *net.UnixConn) error {
+	uid, err := peerUID(conn)
+	if err != nil {
+		return fmt.Errorf("failed reading handoff peer credentials: %v", err)
+	}
+	if uid != uint32(os.Getuid()) {
+		return fmt.Errorf("handoff peer of uid %d is not of this process' uid %d", uid, os.Getuid())
+	}
+	return nil
+}
+
+// handOff hands the server off to the server process of conn. It returns whether the sockets were handed off, in
+// which case the server's listeners are shut down even if handing off its state failed
+func (s Server) handOff(conn *net.UnixConn, timeout time.Duration) (bool, error) {
+	conn.SetDeadline(time.Now().Add(2 * timeout))
+	names, files, err := s.handoffSockets()
+	defer func() {
+		for _, f := range files {
+			f.Close()
+		}
+	}()
+	if err != nil {
+		return false, err
+	}
+	if err = sendSockets(conn, names, files); err != nil {
+		return false, err
+	}
+
+	// Requests arriving from here on are read by the next server process
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	for _, name := range names {
+		if err := s.listeners[name].(*UDPListener).Server.Drain(ctx); err != nil {
+			s.logger.Warn("in-flight requests not drained", zap.String("listener", name), zap.Error(err))
+		}
+	}
+	for name, listener := range s.listeners {
+		if err := listener.Shutdown(ctx); err != nil {
+			s.logger.Error("Error shutting down listener", zap.String("listener", name), zap.Error(err))
+		}
+	}
+	s.closeAcctCapture()
+	for _, hook := range s.onHandoff {
+		hook()
+	}
+
+	if err = json.NewEncoder(conn).Encode(s.exportState()); err != nil {
+		return true, err
+	}
+	var ack handoffAck
+	if err = json.NewDecoder(conn).Decode(&ack); err != nil {
+		return true, err
+	}
+	if len(ack.Error) != 0 {
+		return true, errors.New(ack.Error)
+	}
+	return true, nil
+}
+
+// handoffSockets returns the names of the serving UDP listeners, sorted, & duplicates of their sockets' files
+func (s Server) handoffSockets() ([]string, []*os.File, error) {
+	var (
+		names []string
+		files []*os.File
+	)
+	for name, listener := range s.listeners {
+		if udpListener, ok := listener.(*UDPListener); ok && udpListener.packetConn() != nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	if len(names) > maxHandoffSockets {
+		return nil, nil, fmt.Errorf("%d UDP listeners exceed the %d handed off sockets limit", len(names), maxHandoffSockets)
+	}
+	for _, name := range names {
+		conn, ok := s.listeners[name].(*UDPListener).packetConn().(interface{ File() (*os.File, error) })
+		if !ok {
+			return nil, files, fmt.Errorf("socket of listener %s can not be handed off", name)
+		}
+		f, err := conn.File()
+		if err != nil {
+			return nil, files, err
+		}
+		files = append(files, f)
+	}
+	return names, files, nil
+}
+
+// exportState exports the server's sessions & the states of its stateful modules
+func (s Server) exportState() handoffState {
+	state := handoffState{Modules: map[string][]moduleState{}}
+	if snapshotter, ok := s.multiSessionStorage.(session.Snapshotter); ok {
+		state.Sessions = snapshotter.Snapshot()
+	}
+	for name, listener := range s.listeners {
+		for _, module := range listener.GetModules() {
+			stateful, ok := module.Context.(modules.StatefulContext)
+			if !ok {
+				continue
+			}
+			exported, err := stateful.ExportState()
+			if err != nil {
+				s.logger.Error(
+					"failed exporting module state",
+					zap.String("listener", name),
+					zap.String("module_name", module.Name),
+					zap.Error(err),
+				)
+				continue
+			}
+			if len(exported) == 0 {
+				continue
+			}
+			state.Modules[name] = append(state.Modules[name], moduleState{Name: module.Name, State: exported})
+		}
+	}
+	return state
+}
+
+// sendSockets sends the sockets' fds & the header naming their listeners
+func sendSockets(conn *net.UnixConn, names []string, files []*os.File) error {
+	fds := make([]int, len(files))
+	for i, f := range files {
+		fds[i] = int(f.Fd())
+	}
+	if _, _, err := conn.WriteMsgUnix([]byte{handoffMarker}, syscall.UnixRights(fds...), nil); err != nil {
+		return err
+	}
+	return json.NewEncoder(conn).Encode(handoffHeader{Listeners: names})
+}
+
+// receiveHandoff receives the handed off sockets & state & acknowledges them
+func receiveHandoff(conn *net.UnixConn) (*Handoff, error) {
+	buf := make([]byte, 1)
+	oob := make([]byte, syscall.CmsgSpace(maxHandoffSockets*4))
+	n, oobn, _, _, err := conn.ReadMsgUnix(buf, oob)
+	if err != nil {
+		return nil, err
+	}
+	if n != 1 || buf[0] != handoffMarker {
+		return nil, errors.New("invalid handoff message")
+	}
+	msgs, err := syscall.ParseSocketControlMessage(oob[:oobn])
+	if err != nil {
+		return nil, err
+	}
+	var fds []int
+	for _, msg := range msgs {
+		msgFds, err := syscall.ParseUnixRights(&msg)
+		if err != nil {
+			return nil, err
+		}
+		fds = append(fds, msgFds...)
+	}
+
+	handoff := &Handoff{conns: map[string]net.PacketConn{}}
+	closeAll := func() {
+		for _, fd := range fds {
+			syscall.Close(fd)
+		}
+		for _, c := range handoff.conns {
+			c.Close()
+		}
+	}
+	decoder := json.NewDecoder(conn)
+	var header handoffHeader
+	if err = decoder.Decode(&header); err != nil {
+		closeAll()
+		return nil, err
+	}
+	if len(header.Listeners) != len(fds) {
+		closeAll()
+		return nil, fmt.Errorf("received %d sockets of %d listeners", len(fds), len(header.Listeners))
+	}
+	for len(fds) > 0 {
+		f := os.NewFile(uintptr(fds[0]), header.Listeners[0])
+		fds = fds[1:]
+		packetConn, err := net.FilePacketConn(f)
+		// FilePacketConn duplicates the fd
+		f.Close()
+		if err != nil {
+			closeAll()
+			return nil, err
+		}
+		handoff.conns[header.Listeners[0]] = packetConn
+		header.Listeners = header.Listeners[1:]
+	}
+	if err = decoder.Decode(&handoff.state); err != nil {
+		closeAll()
+		return nil, err
+	}
+	// the old process has shut its listeners down already, a failed ack must not drop the handed off sockets
+	json.NewEncoder(conn).Encode(handoffAck{})
+	return handoff, nil
+}
+
+func handoffTimeout(cfg *config.HandoffConfig) time.Duration {
+	return time.Duration(cfg.TimeoutSec) * time.Second
+}
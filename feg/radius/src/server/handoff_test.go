@@ -0,0 +1,148 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package server
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"fbc/cwf/radius/config"
+	"fbc/cwf/radius/session"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestHandoffSocketsAndState(t *testing.T) {
+	// Arrange
+	dir, err := ioutil.TempDir("", "handoff_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	socketPath := filepath.Join(dir, "handoff.sock")
+	handoffListener, err := net.ListenUnix("unix", &net.UnixAddr{Name: socketPath, Net: "unix"})
+	require.NoError(t, err)
+	defer handoffListener.Close()
+
+	udpConn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	state := handoffState{
+		Sessions: map[string]session.State{"session1": {MSISDN: "12345", Attributes: map[string]string{"a": "b"}}},
+		Modules: map[string][]moduleState{
+			"udp1": {{Name: "eap", State: json.RawMessage(`{"eap__calling__called":{"protocol_state":"x"}}`)}},
+		},
+	}
+
+	acked := make(chan error, 1)
+	go func() {
+		conn, err := handoffListener.AcceptUnix()
+		if err != nil {
+			acked <- err
+			return
+		}
+		defer conn.Close()
+		f, err := udpConn.File()
+		if err != nil {
+			acked <- err
+			return
+		}
+		defer f.Close()
+		if err = sendSockets(conn, []string{"udp1"}, []*os.File{f}); err != nil {
+			acked <- err
+			return
+		}
+		udpConn.Close()
+		if err = json.NewEncoder(conn).Encode(state); err != nil {
+			acked <- err
+			return
+		}
+		var ack handoffAck
+		acked <- json.NewDecoder(conn).Decode(&ack)
+	}()
+
+	// Act
+	conn, err := net.DialUnix("unix", nil, &net.UnixAddr{Name: socketPath, Net: "unix"})
+	require.NoError(t, err)
+	defer conn.Close()
+	handoff, err := receiveHandoff(conn)
+
+	// Assert
+	require.NoError(t, err)
+	require.NoError(t, <-acked)
+	require.Equal(t, state, handoff.state)
+	require.Len(t, handoff.conns, 1)
+	handedOff := handoff.conns["udp1"]
+	require.NotNil(t, handedOff)
+	defer handedOff.Close()
+	require.Equal(t, udpConn.LocalAddr().String(), handedOff.LocalAddr().String())
+
+	// the handed off socket keeps receiving the port's packets after the old one is closed
+	client, err := net.DialUDP("udp", nil, handedOff.LocalAddr().(*net.UDPAddr))
+	require.NoError(t, err)
+	defer client.Close()
+	_, err = client.Write([]byte("request"))
+	require.NoError(t, err)
+	handedOff.SetReadDeadline(time.Now().Add(5 * time.Second))
+	buf := make([]byte, 16)
+	n, _, err := handedOff.ReadFrom(buf)
+	require.NoError(t, err)
+	require.Equal(t, "request", string(buf[:n]))
+}
+
+func TestTakeOverWithoutServerProcess(t *testing.T) {
+	// Arrange
+	dir, err := ioutil.TempDir("", "handoff_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// Act
+	handoff, err := TakeOver(&config.HandoffConfig{SocketPath: filepath.Join(dir, "none.sock"), TimeoutSec: 1}, zap.NewNop())
+
+	// Assert
+	require.NoError(t, err)
+	require.Nil(t, handoff)
+}
+
+func TestListenHandoffs(t *testing.T) {
+	// Arrange
+	dir, err := ioutil.TempDir("", "handoff_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	socketPath := filepath.Join(dir, "handoff.sock")
+
+	// Act
+	listener, err := listenHandoffs(socketPath)
+	require.NoError(t, err)
+	defer listener.Close()
+
+	// Assert: only this process' user may connect
+	fi, err := os.Stat(socketPath)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), fi.Mode().Perm())
+
+	// Assert: the peers of the same user are allowed on both ends
+	accepted := make(chan error, 1)
+	go func() {
+		conn, err := listener.AcceptUnix()
+		if err == nil {
+			err = checkHandoffPeer(conn)
+			conn.Close()
+		}
+		accepted <- err
+	}()
+	conn, err := net.DialUnix("unix", nil, &net.UnixAddr{Name: socketPath, Net: "unix"})
+	require.NoError(t, err)
+	defer conn.Close()
+	require.NoError(t, checkHandoffPeer(conn))
+	require.NoError(t, <-accepted)
+}
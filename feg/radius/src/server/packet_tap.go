@@ -0,0 +1,210 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package server
+
+import (
+	"fbc/lib/go/radius"
+	"fbc/lib/go/radius/debug"
+	"fbc/lib/go/radius/rfc2865"
+	"fbc/lib/go/radius/rfc2869"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// microsoftVendorID the vendor of the MS-MPPE keys & MS-CHAP attributes, all redacted
+const microsoftVendorID = 311
+
+var redacted = radius.Attribute("<redacted>")
+
+// PacketFilter selects the tapped packets, empty fields match all packets
+type PacketFilter struct {
+	// NAS matches the NAS-IP-Address, the NAS-Identifier or the source IP of the packet's request
+	NAS string
+	// IMSI matches the packets whose request's User-Name contains it
+	IMSI string
+	// Codes matches the packets of the codes
+	Codes []radius.Code
+}
+
+// TappedPacket a decoded & redacted packet received or sent by a listener
+type TappedPacket struct {
+	Time       time.Time
+	Listener   string
+	Outgoing   bool // a response sent by the listener
+	NAS        string
+	RemoteAddr string
+	Code       radius.Code
+	Identifier byte
+	SessionID  string
+	Dump       string
+	Dropped    uint64 // packets dropped since the previous packet, as the watcher didn't keep up
+}
+
+// packetTap publishes the packets handled by the listeners to their watchers
+type packetTap struct {
+	watchers int32 // number of watchers, packets aren't decoded if zero
+	mu       sync.Mutex
+	watching map[*packetWatcher]struct{}
+}
+
+type packetWatcher struct {
+	filter  PacketFilter
+	packets chan TappedPacket
+	dropped uint64 // guarded by the tap's mutex
+}
+
+func newPacketTap() *packetTap {
+	return &packetTap{watching: map[*packetWatcher]struct{}{}}
+}
+
+// watch returns the channel of the filtered packets, buffering up to buffer packets. Packets are dropped while the
+// buffer is full. The returned function stops the watch & closes the channel
+func (t *packetTap) watch(filter PacketFilter, buffer int) (<-chan TappedPacket, func()) {
+	w := &packetWatcher{filter: filter, packets: make(chan TappedPacket, buffer)}
+	t.mu.Lock()
+	t.watching[w] = struct{}{}
+	atomic.AddInt32(&t.watchers, 1)
+	t.mu.Unlock()
+	var once sync.Once
+	return w.packets, func() {
+		once.Do(func() {
+			t.mu.Lock()
+			delete(t.watching, w)
+			atomic.AddInt32(&t.watchers, -1)
+			close(w.packets)
+			t.mu.Unlock()
+		})
+	}
+}
+
+// publish taps the packet received in request r, or sent in response to it if outgoing
+func (t *packetTap) publish(listener string, sessionID string, r *radius.Request, p *radius.Packet, outgoing bool) {
+	if t == nil || atomic.LoadInt32(&t.watchers) == 0 {
+		return
+	}
+	var (
+		nas      = requestNAS(r)
+		tapped   *TappedPacket
+		userName = rfc2865.UserName_GetString(r.Packet)
+	)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for w := range t.watching {
+		if !w.filter.matches(nas, userName, p.Code) {
+			continue
+		}
+		if tapped == nil {
+			tapped = &TappedPacket{
+				Time:       time.Now(),
+				Listener:   listener,
+				Outgoing:   outgoing,
+				NAS:        strings.Join(nas, ","),
+				Code:       p.Code,
+				Identifier: p.Identifier,
+				SessionID:  sessionID,
+				Dump:       debug.DumpString(&debug.Config{Dictionary: debug.IncludedDictionary}, redact(p)),
+			}
+			if r.RemoteAddr != nil {
+				tapped.RemoteAddr = r.RemoteAddr.String()
+			}
+		}
+		packet := *tapped
+		packet.Dropped = w.dropped
+		select {
+		case w.packets <- packet:
+			w.dropped = 0
+		default:
+			w.dropped++
+		}
+	}
+}
+
+func (f PacketFilter) matches(nas []string, userName string, code radius.Code) bool {
+	if len(f.IMSI) > 0 && !strings.Contains(userName, f.IMSI) {
+		return false
+	}
+	if len(f.NAS) > 0 {
+		found := false
+		for _, n := range nas {
+			found = found || n == f.NAS
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(f.Codes) == 0 {
+		return true
+	}
+	for _, c := range f.Codes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// requestNAS returns the identities of the request's NAS: its NAS-IP-Address, NAS-Identifier & source IP
+func requestNAS(r *radius.Request) []string {
+	var nas []string
+	if ip := rfc2865.NASIPAddress_Get(r.Packet); ip != nil {
+		nas = append(nas, ip.String())
+	}
+	if id := rfc2865.NASIdentifier_GetString(r.Packet); id != "" {
+		nas = append(nas, id)
+	}
+	if r.RemoteAddr != nil {
+		if host, _, err := net.SplitHostPort(r.RemoteAddr.String()); err == nil {
+			nas = append(nas, host)
+		}
+	}
+	return nas
+}
+
+// redact returns a copy of the packet without its secret, passwords, keys & EAP payloads. Only the header (code,
+// identifier, length & type) of the EAP message is kept
+func redact(p *radius.Packet) *radius.Packet {
+	res := &radius.Packet{
+		Code:          p.Code,
+		Identifier:    p.Identifier,
+		Authenticator: p.Authenticator,
+		Attributes:    make(radius.Attributes, len(p.Attributes)),
+	}
+	for typ, attrs := range p.Attributes {
+		switch typ {
+		case rfc2865.UserPassword_Type, rfc2865.CHAPPassword_Type, rfc2869.ARAPPassword_Type,
+			rfc2869.MessageAuthenticator_Type:
+			res.Attributes[typ] = []radius.Attribute{redacted}
+		case rfc2869.EAPMessage_Type:
+			if len(attrs) > 0 && len(attrs[0]) >= 5 {
+				res.Attributes[typ] = []radius.Attribute{append(radius.Attribute{}, attrs[0][:5]...)}
+			} else {
+				res.Attributes[typ] = attrs
+			}
+		case rfc2865.VendorSpecific_Type:
+			vsas := make([]radius.Attribute, len(attrs))
+			for i, vsa := range attrs {
+				switch vendorID, _, err := radius.VendorSpecific(vsa); {
+				case err != nil:
+					vsas[i] = redacted
+				case vendorID == microsoftVendorID:
+					vsas[i] = append(append(radius.Attribute{}, vsa[:4]...), redacted...)
+				default:
+					vsas[i] = vsa
+				}
+			}
+			res.Attributes[typ] = vsas
+		default:
+			res.Attributes[typ] = attrs
+		}
+	}
+	return res
+}
@@ -0,0 +1,121 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package server
+
+import (
+	"net"
+	"testing"
+
+	"fbc/lib/go/radius"
+	"fbc/lib/go/radius/rfc2865"
+	"fbc/lib/go/radius/rfc2869"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPacketTapFilters(t *testing.T) {
+	// Arrange
+	tap := newPacketTap()
+	all, stopAll := tap.watch(PacketFilter{}, 10)
+	byNAS, stopByNAS := tap.watch(PacketFilter{NAS: "nas1"}, 10)
+	byIMSI, stopByIMSI := tap.watch(PacketFilter{IMSI: "001010000000001"}, 10)
+	accepts, stopAccepts := tap.watch(PacketFilter{Codes: []radius.Code{radius.CodeAccessAccept}}, 10)
+	defer stopByNAS()
+	defer stopByIMSI()
+	defer stopAccepts()
+
+	request := newTapRequest("nas1", "1001010000000001@wlan.mnc001.mcc001.3gppnetwork.org")
+	response := radius.New(radius.CodeAccessAccept, []byte("123456"))
+
+	// Act
+	tap.publish("udp", "sid1", request, request.Packet, false)
+	tap.publish("udp", "sid1", request, response, true)
+	tap.publish("udp", "sid2", newTapRequest("nas2", "001010000000002"), request.Packet, false)
+
+	// Assert
+	require.Len(t, all, 3)
+	require.Len(t, byNAS, 2)
+	require.Len(t, byIMSI, 2)
+	require.Len(t, accepts, 1)
+	packet := <-accepts
+	require.True(t, packet.Outgoing)
+	require.Equal(t, "udp", packet.Listener)
+	require.Equal(t, "sid1", packet.SessionID)
+	require.Equal(t, "nas1,10.0.0.1", packet.NAS)
+	require.Equal(t, "10.0.0.1:1812", packet.RemoteAddr)
+	require.Contains(t, packet.Dump, "Access-Accept")
+
+	// a stopped watch is closed and no longer receives packets
+	stopAll()
+	stopAll()
+	tap.publish("udp", "sid1", request, request.Packet, false)
+	for range all {
+	}
+	require.Len(t, byNAS, 3)
+}
+
+func TestPacketTapDropsOnFullBuffer(t *testing.T) {
+	// Arrange
+	tap := newPacketTap()
+	packets, stop := tap.watch(PacketFilter{}, 1)
+	defer stop()
+	request := newTapRequest("nas1", "001010000000001")
+
+	// Act
+	for i := 0; i < 3; i++ {
+		tap.publish("udp", "sid1", request, request.Packet, false)
+	}
+	<-packets
+	tap.publish("udp", "sid1", request, request.Packet, false)
+
+	// Assert
+	packet := <-packets
+	require.Equal(t, uint64(2), packet.Dropped)
+}
+
+func TestRedact(t *testing.T) {
+	// Arrange
+	packet := radius.New(radius.CodeAccessRequest, []byte("123456"))
+	rfc2865.UserName_SetString(packet, "001010000000001")
+	rfc2865.UserPassword_SetString(packet, "password")
+	eap := radius.Attribute{0x02, 0x01, 0x00, 0x0a, 0x12, 0xde, 0xad, 0xbe, 0xef, 0x00}
+	packet.Add(rfc2869.EAPMessage_Type, eap)
+	packet.Add(rfc2869.EAPMessage_Type, radius.Attribute{0xca, 0xfe})
+	mppe, err := radius.NewVendorSpecific(microsoftVendorID, radius.Attribute{16, 6, 0xab, 0xcd, 0xef, 0x01})
+	require.NoError(t, err)
+	packet.Add(rfc2865.VendorSpecific_Type, mppe)
+	other, err := radius.NewVendorSpecific(10415, radius.Attribute{1, 3, 0x01})
+	require.NoError(t, err)
+	packet.Add(rfc2865.VendorSpecific_Type, other)
+
+	// Act
+	res := redact(packet)
+
+	// Assert
+	require.Nil(t, res.Secret)
+	require.Equal(t, "001010000000001", rfc2865.UserName_GetString(res))
+	require.Equal(t, []radius.Attribute{redacted}, res.Attributes[rfc2865.UserPassword_Type])
+	require.Equal(t, []radius.Attribute{eap[:5]}, res.Attributes[rfc2869.EAPMessage_Type])
+	vendorID, value, err := radius.VendorSpecific(res.Attributes[rfc2865.VendorSpecific_Type][0])
+	require.NoError(t, err)
+	require.Equal(t, uint32(microsoftVendorID), vendorID)
+	require.Equal(t, redacted, value)
+	require.Equal(t, other, res.Attributes[rfc2865.VendorSpecific_Type][1])
+	require.Len(t, packet.Attributes[rfc2869.EAPMessage_Type], 2, "the packet must not be modified")
+}
+
+func newTapRequest(nas string, userName string) *radius.Request {
+	packet := radius.New(radius.CodeAccessRequest, []byte("123456"))
+	rfc2865.NASIdentifier_SetString(packet, nas)
+	rfc2865.UserName_SetString(packet, userName)
+	return &radius.Request{
+		RemoteAddr: &net.UDPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 1812},
+		Packet:     packet,
+	}
+}
@@ -0,0 +1,21 @@
+// +build !linux
+
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package server
+
+import (
+	"errors"
+	"net"
+)
+
+// peerUID is not supported, all unix socket connections are rejected
+func peerUID(conn *net.UnixConn) (uint32, error) {
+	return 0, errors.New("SO_PEERCRED is not supported on this platform")
+}
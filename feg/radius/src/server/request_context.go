@@ -0,0 +1,66 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package server
+
+import (
+	"fbc/cwf/radius/modules"
+	"fbc/lib/go/radius"
+	"strconv"
+	"sync"
+)
+
+// requestState per-request scratch space of the packet handler (dedup key
+// buffer & response builder). It is pooled and reused across requests, so it
+// must never be handed over to modules, which may retain what they are given
+// (e.g. the analytics module keeps the RequestContext for async tasks)
+type requestState struct {
+	key      []byte
+	response radius.Packet
+}
+
+var requestStatePool = sync.Pool{
+	New: func() interface{} {
+		return &requestState{key: make([]byte, 0, 64)}
+	},
+}
+
+func acquireRequestState() *requestState {
+	return requestStatePool.Get().(*requestState)
+}
+
+func releaseRequestState(s *requestState) {
+	s.response = radius.Packet{}
+	requestStatePool.Put(s)
+}
+
+// dedupKey returns the key identifying duplicate requests, as depicted in
+// rfc2865 section 3 (Identifier)
+func (s *requestState) dedupKey(r *radius.Request) string {
+	s.key = append(s.key[:0], r.RemoteAddr.String()...)
+	s.key = append(s.key, '_')
+	s.key = strconv.AppendUint(s.key, uint64(r.Identifier), 10)
+	return string(s.key)
+}
+
+// buildResponse builds the response to r from the module chain's response,
+// the attributes are used as is (and not copied). The returned packet is valid
+// until the state is released
+func (s *requestState) buildResponse(r *radius.Request, response *modules.Response) *radius.Packet {
+	s.response = radius.Packet{
+		Code:          response.Code,
+		Identifier:    r.Identifier,
+		Authenticator: r.Authenticator,
+		Secret:        r.Secret,
+		Attributes:    response.Attributes,
+	}
+	if s.response.Attributes == nil {
+		s.response.Attributes = radius.Attributes{}
+	}
+	return &s.response
+}
@@ -10,13 +10,17 @@ package server
 
 import (
 	"context"
+	"fbc/cwf/radius/acctcapture"
 	"fbc/cwf/radius/config"
 	"fbc/cwf/radius/filters"
 	"fbc/cwf/radius/loader"
 	"fbc/cwf/radius/modules"
 	"fbc/cwf/radius/monitoring/counters"
+	"fbc/cwf/radius/quirks"
 	"fbc/cwf/radius/session"
 	"fmt"
+	"net"
+	"sort"
 	"sync/atomic"
 	"time"
 
@@ -65,6 +69,10 @@ type (
 		logger              *zap.Logger
 		multiSessionStorage session.GlobalStorage
 		dedupSet            *cache.Cache
+		quirks              quirks.Table         // interop quirks of known NASes
+		onHandoff           []func()             // called once the server handed off to the next server process
+		tap                 *packetTap           // publishes the handled packets to their watchers
+		acctCapture         *acctcapture.Capture // captures the raw Accounting-Requests, nil if not configured
 	}
 )
 
@@ -82,6 +90,18 @@ func New(config config.ServerConfig, logger *zap.Logger, loader loader.Loader) (
 		logger:              logger,
 		multiSessionStorage: session.NewMultiSessionMemoryStorage(),
 		dedupSet:            cache.New(config.DedupWindow.Duration, time.Minute),
+		tap:                 newPacketTap(),
+	}
+	var err error
+	if server.quirks, err = quirks.NewTable(config.Quirks); err != nil {
+		logger.Error("invalid NAS quirks", zap.Error(err))
+		return nil, err
+	}
+	if config.AcctCapture != nil {
+		if server.acctCapture, err = acctcapture.New(*config.AcctCapture, logger); err != nil {
+			logger.Error("failed to start accounting capture", zap.Error(err))
+			return nil, err
+		}
 	}
 	logger.Info("allocate new server", zap.Int("num_listeners", len(config.Listeners)), zap.Int("num_filters", len(config.Filters)))
 
@@ -125,6 +145,8 @@ func New(config config.ServerConfig, logger *zap.Logger, loader loader.Loader) (
 			listener = NewGRPCListener()
 		case "sse":
 			listener = NewSSEListener()
+		case "tls":
+			listener = NewTLSListener()
 		default:
 			logger.Error(
 				fmt.Sprintf("failed to create listener, listener type '%s'", lconfig.Type),
@@ -137,7 +159,7 @@ func New(config config.ServerConfig, logger *zap.Logger, loader loader.Loader) (
 		listener.SetConfig(lconfig)
 
 		// Load modules
-		for _, modDesc := range lconfig.Modules {
+		for _, modDesc := range lconfig.EnabledModules() {
 			counters.ModuleInit.
 				SetTag(counters.ListenerTag, lconfig.Name).
 				SetTag(counters.ModuleTag, modDesc.Name).
@@ -203,12 +225,12 @@ func New(config config.ServerConfig, logger *zap.Logger, loader loader.Loader) (
 }
 
 func wrapMiddleware(listenerName string, next modules.Middleware, module Module) modules.Middleware {
+	operation := counters.NewOperation(module.Name).
+		SetTag(counters.ListenerTag, listenerName).
+		SetTag(counters.ModuleTag, module.Name)
 	return func(c *modules.RequestContext, r *radius.Request) (*modules.Response, error) {
 		// Start counter
-		counter := counters.NewOperation(module.Name).
-			SetTag(counters.ListenerTag, listenerName).
-			SetTag(counters.ModuleTag, module.Name).
-			Start()
+		counter := operation.Start()
 
 		// Handle
 		res, err := module.Code.Handle(module.Context, c, r, next)
@@ -254,6 +276,13 @@ func (s Server) Start() {
 		logger.Info("listener is ready")
 	}
 
+	// Serve the next server process taking over on upgrades
+	if s.config.Handoff != nil {
+		if err := s.serveHandoffs(s.config.Handoff); err != nil {
+			s.logger.Error("failed serving handoff socket, zero downtime upgrades are disabled", zap.Error(err))
+		}
+	}
+
 	// Server is ready!
 	s.logger.Info("all listeners ready, server is up and running")
 	s.ready <- true
@@ -294,11 +323,47 @@ func (s Server) Stop() {
 		}
 	}
 
+	// Release the modules' resources
+	for name, listener := range s.listeners {
+		for _, module := range listener.GetModules() {
+			closable, ok := module.Context.(modules.ClosableContext)
+			if !ok {
+				continue
+			}
+			if err := closable.Close(); err != nil {
+				s.logger.Error(
+					"Error closing module",
+					zap.String("listener", name),
+					zap.String("module_name", module.Name),
+					zap.Error(err),
+				)
+			}
+		}
+	}
+
+	// Write the captured Accounting-Requests of the listeners
+	s.closeAcctCapture()
+
 	// Signal termination
 	s.logger.Debug("All listeners are now down, terminating server")
 	s.terminate <- true
 }
 
+// requestTap returns the RequestTap of the listeners' radius servers, nil if no raw requests are captured
+func (s Server) requestTap() func(wire []byte, remoteAddr net.Addr) {
+	if s.acctCapture == nil {
+		return nil
+	}
+	return s.acctCapture.Tap
+}
+
+// closeAcctCapture writes the captured Accounting-Requests & closes the capture, if any
+func (s Server) closeAcctCapture() {
+	if s.acctCapture != nil {
+		s.acctCapture.Close()
+	}
+}
+
 // getSessionStateAPI returns a per-session accessor to session state
 func (s Server) getSessionStateAPI(sessionID string) session.Storage {
 	return session.NewSessionStorage(s.multiSessionStorage, sessionID)
@@ -310,3 +375,40 @@ func (s Server) getSessionState(sessionID string) (*session.State, error) {
 	sessionStg := s.getSessionStateAPI(sessionID)
 	return sessionStg.Get()
 }
+
+// ListenerInfo describes a loaded listener & its module chain
+type ListenerInfo struct {
+	Name              string
+	Type              string
+	Modules           []string
+	DuplicatesDropped uint32
+}
+
+// Pipeline returns names of the loaded filters & the loaded listeners sorted by name
+func (s Server) Pipeline() ([]string, []ListenerInfo) {
+	filterNames := make([]string, 0, len(s.filters))
+	for _, f := range s.filters {
+		filterNames = append(filterNames, f.Name)
+	}
+	listeners := make([]ListenerInfo, 0, len(s.listeners))
+	for name, l := range s.listeners {
+		info := ListenerInfo{
+			Name:              name,
+			Type:              l.GetConfig().Type,
+			DuplicatesDropped: atomic.LoadUint32(l.GetDupDropped()),
+		}
+		for _, m := range l.GetModules() {
+			info.Modules = append(info.Modules, m.Name)
+		}
+		listeners = append(listeners, info)
+	}
+	sort.Slice(listeners, func(i, j int) bool { return listeners[i].Name < listeners[j].Name })
+	return filterNames, listeners
+}
+
+// WatchPackets returns the channel of the decoded & redacted packets received & sent by the UDP listeners, which
+// match the filter. Up to buffer packets are buffered, packets are dropped while the buffer is full. The returned
+// function stops the watch & closes the channel
+func (s Server) WatchPackets(filter PacketFilter, buffer int) (<-chan TappedPacket, func()) {
+	return s.tap.watch(filter, buffer)
+}
@@ -25,6 +25,7 @@ import (
 	"fbc/cwf/radius/loader/loaderstest"
 	"fbc/cwf/radius/modules"
 	"fbc/cwf/radius/modules/modulestest"
+	"fbc/cwf/radius/quirks"
 	"fbc/cwf/radius/session"
 	"fbc/lib/go/radius"
 	"fbc/lib/go/radius/rfc2865"
@@ -664,6 +665,21 @@ func analyticsModuleTestEnvDestroy(testParam *FullRADIUSSessiontWithAnalyticsMod
 	testParam.Server.Stop()
 }
 
+func TestQuirksSessionID(t *testing.T) {
+	server := &Server{quirks: quirks.Table{"10.0.0.1": quirks.UppercaseDashedMAC}}
+	newRequest := func(nas net.IP) *radius.Request {
+		packet := radius.New(radius.CodeAccessRequest, []byte("123456"))
+		rfc2865.CallingStationID_SetString(packet, "AA-BB-CC-DD-EE-FF")
+		rfc2865.CalledStationID_SetString(packet, "01-23-45-AB-CD-EF:venue")
+		return &radius.Request{Packet: packet, RemoteAddr: &net.UDPAddr{IP: nas, Port: 1812}}
+	}
+
+	// MACs of the quirky NAS are matched in the canonical notation
+	require.Equal(t, "01:23:45:ab:cd:ef:venue__aa:bb:cc:dd:ee:ff", server.GetSessionID(newRequest(net.IPv4(10, 0, 0, 1))))
+	// other NASes' MACs are matched as sent
+	require.Equal(t, "01-23-45-AB-CD-EF:venue__AA-BB-CC-DD-EE-FF", server.GetSessionID(newRequest(net.IPv4(10, 0, 0, 2))))
+}
+
 func getSessionIDStrings(server *Server, calling string, called string, acctSessionId string) string {
 	r := radius.Request{
 		Packet: &radius.Packet{
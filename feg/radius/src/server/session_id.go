@@ -11,18 +11,24 @@ package server
 import (
 	"fmt"
 
+	"fbc/cwf/radius/quirks"
 	"fbc/lib/go/radius"
 	"fbc/lib/go/radius/rfc2865"
 )
 
 // GetSessionID Extracts the radius session id from the given radius request
 func (s *Server) GetSessionID(r *radius.Request) string {
+	return s.sessionID(r, s.quirks.Of(r))
+}
+
+// sessionID composes the session id of the request of a NAS with the given quirks
+func (s *Server) sessionID(r *radius.Request, nasQuirks quirks.Quirks) string {
 	calledStationIDAttr, _ := rfc2865.CalledStationID_Lookup(r.Packet)
 	callingStationIDAttr, _ := rfc2865.CallingStationID_Lookup(r.Packet)
 
 	return s.ComposeSessionID(
-		string(calledStationIDAttr),
-		string(callingStationIDAttr),
+		nasQuirks.StationID(string(calledStationIDAttr)),
+		nasQuirks.StationID(string(callingStationIDAttr)),
 	)
 }
 
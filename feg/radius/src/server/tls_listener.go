@@ -0,0 +1,169 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fbc/cwf/radius/certmanager"
+	"fbc/cwf/radius/config"
+	"fbc/cwf/radius/modules"
+	"fbc/cwf/radius/monitoring/counters"
+	"fbc/lib/go/radius"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"go.uber.org/zap"
+)
+
+// TLSListener listens to RadSec (RADIUS over TLS, RFC 6614) connections of NASes
+type TLSListener struct {
+	Listener
+	Server *radius.StreamServer
+	ready  chan bool
+	cfg    TLSListenerExtraConfig
+	logger *zap.Logger
+	certs  *certmanager.CertManager
+	causes errorCauses // Error-Causes of failed requests' error responses, nil if disabled
+}
+
+// TLSListenerExtraConfig extra config for TLS listener
+type TLSListenerExtraConfig struct {
+	Port int `json:"port" default:"2083"`
+	// TLS the server certificate & key, NASes must present client certificates issued by the CA file's CAs
+	// (mutual TLS, as RFC 6614 requires)
+	TLS certmanager.Config `json:"tls"`
+	// InsecureNoClientAuth accepts NASes without client certificates if the TLS CA file isn't set, any client
+	// reaching the port may then send requests protected by the public RadSec secret only. Testing only
+	InsecureNoClientAuth bool `json:"insecureNoClientAuth"`
+	// KeepAliveSec the TCP keepalive period of the NASes' connections, keepalives are disabled if negative
+	KeepAliveSec int `json:"keepAliveSec" default:"30"`
+	// IdleTimeoutSec closes the connections of NASes sending no requests for the timeout, never closed if not set
+	IdleTimeoutSec int `json:"idleTimeoutSec"`
+	// ErrorResponses configures the error responses of requests failed by AAA GRPC errors, see UDPListenerExtraConfig
+	ErrorResponses *ErrorResponsesConfig `json:"errorResponses"`
+}
+
+// NewTLSListener ...
+func NewTLSListener() *TLSListener {
+	return &TLSListener{
+		ready: make(chan bool),
+	}
+}
+
+// Init override
+func (l *TLSListener) Init(
+	server *Server,
+	serverConfig config.ServerConfig,
+	listenerConfig config.ListenerConfig,
+) error {
+	if server == nil {
+		return errors.New("cannot initialize TLS listener with null server")
+	}
+
+	// Parse configuration
+	var cfg TLSListenerExtraConfig
+	err := mapstructure.Decode(listenerConfig.Extra, &cfg)
+	if err != nil {
+		return err
+	}
+	if err = config.ApplyDefaults(&cfg); err != nil {
+		return err
+	}
+	if len(cfg.TLS.CAFile) == 0 && !cfg.InsecureNoClientAuth {
+		return errors.New("tls listener: a client CA file is required to authenticate NASes (RFC 6614), " +
+			"set insecureNoClientAuth to accept NASes without client certificates")
+	}
+	l.cfg = cfg
+	if l.causes, err = newErrorCauses(cfg.ErrorResponses); err != nil {
+		return err
+	}
+	if l.certs, err = certmanager.New(cfg.TLS, server.logger); err != nil {
+		return fmt.Errorf("tls listener: %v", err)
+	}
+	l.logger = server.logger.With(zap.String("listener", listenerConfig.Name))
+	if len(cfg.TLS.CAFile) == 0 {
+		l.logger.Warn("Insecure RadSec listener doesn't authenticate NASes, RFC 6614 requires client certificates")
+	}
+
+	// Create stream server, RadSec packets are protected by TLS instead of the server's shared secret
+	connections := counters.RadSecConnection.SetTag(counters.ListenerTag, listenerConfig.Name)
+	l.Server = &radius.StreamServer{
+		Handler: radius.HandlerFunc(
+			generatePacketHandler(l, server),
+		),
+		SecretSource: radius.StaticSecretSource([]byte(radius.RadSecSecret)),
+		Addr:         fmt.Sprintf(":%d", cfg.Port),
+		TLSConfig:    l.certs.ServerTLSConfig(),
+		KeepAlive:    time.Duration(cfg.KeepAliveSec) * time.Second,
+		IdleTimeout:  time.Duration(cfg.IdleTimeoutSec) * time.Second,
+		RequestTap:   server.requestTap(),
+		ErrorHandler: func(remoteAddr net.Addr, err error) {
+			if ne, ok := err.(net.Error); ok && ne.Timeout() {
+				l.logger.Debug("RadSec connection timed out", zap.Stringer("nas", remoteAddr))
+				return
+			}
+			connections.Start().Failure("connection_failed")
+			l.logger.Warn("RadSec connection failed", zap.Stringer("nas", remoteAddr), zap.Error(err))
+		},
+		Ready: make(chan bool),
+	}
+	return nil
+}
+
+// ListenAndServe override
+func (l *TLSListener) ListenAndServe() error {
+	serverError := make(chan error, 1)
+	go func() {
+		serverError <- l.Server.ListenAndServe()
+	}()
+
+	// Wait to see if initialization was successful
+	select {
+	case ok := <-l.Server.Ready:
+		if !ok {
+			l.ready <- false
+			return <-serverError
+		}
+		l.certs.Start()
+		l.ready <- true
+		return nil
+	case err := <-serverError:
+		l.ready <- false
+		return err // might be nil if no error
+	}
+}
+
+// GetHandleRequest override
+func (l *TLSListener) GetHandleRequest() modules.Middleware {
+	return l.HandleRequest
+}
+
+// Shutdown override
+func (l *TLSListener) Shutdown(ctx context.Context) error {
+	l.certs.Stop()
+	return l.Server.Shutdown(ctx)
+}
+
+// Ready override
+func (l *TLSListener) Ready() chan bool {
+	return l.ready
+}
+
+// SetConfig override
+func (l *TLSListener) SetConfig(c config.ListenerConfig) {
+	l.Config = c
+}
+
+// errorResponseCauses returns the Error-Causes of the listener's error responses
+func (l *TLSListener) errorResponseCauses() errorCauses {
+	return l.causes
+}
@@ -0,0 +1,36 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package server
+
+import (
+	"fbc/cwf/radius/config"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestTLSListenerRequiresClientCA(t *testing.T) {
+	server := &Server{logger: zap.NewNop()}
+	listenerConfig := func(extra map[string]interface{}) config.ListenerConfig {
+		return config.ListenerConfig{Name: "radsec", Type: "tls", Extra: extra}
+	}
+	tls := map[string]interface{}{"certFile": "/nonexistent/radsec.crt", "keyFile": "/nonexistent/radsec.key"}
+
+	err := NewTLSListener().Init(server, config.ServerConfig{}, listenerConfig(map[string]interface{}{"tls": tls}))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "insecureNoClientAuth")
+
+	// the insecure listener only fails on the missing server certificate
+	err = NewTLSListener().Init(server, config.ServerConfig{},
+		listenerConfig(map[string]interface{}{"tls": tls, "insecureNoClientAuth": true}))
+	require.Error(t, err)
+	require.False(t, strings.Contains(err.Error(), "insecureNoClientAuth"), err.Error())
+}
@@ -14,8 +14,11 @@ import (
 	"fbc/cwf/radius/modules"
 	"fbc/cwf/radius/monitoring/counters"
 	"fbc/lib/go/radius"
+	"fbc/lib/go/retry"
 	"fmt"
 	"math/rand"
+	"net"
+	"time"
 
 	"fbc/cwf/radius/session"
 	"sync/atomic"
@@ -30,17 +33,32 @@ type UDPListener struct {
 	Listener
 	Server *radius.PacketServer
 	ready  chan bool
+	cfg    UDPListenerExtraConfig
+	logger *zap.Logger
+	stop   chan struct{} // stops the socket statistics polling
+	causes errorCauses   // Error-Causes of failed requests' error responses, nil if disabled
+	// handedOff the socket handed off by the previous server process, served instead of listening on the port
+	handedOff net.PacketConn
 }
 
 // UDPListenerExtraConfig extra config for UDP listener
 type UDPListenerExtraConfig struct {
 	Port int `json:"port"`
+	// ReadBufferSize the socket receive buffer size (SO_RCVBUF) in bytes, the system default if not set
+	ReadBufferSize int `json:"readBufferSize"`
+	// SocketStatsIntervalSec the interval of polling the kernel's socket drop & receive queue statistics, polling
+	// is disabled if negative
+	SocketStatsIntervalSec int `json:"socketStatsIntervalSec" default:"10"`
+	// ErrorResponses configures the error responses (Access-Reject, CoA-NAK & Disconnect-NAK with Error-Cause) of
+	// requests failed by AAA GRPC errors, such requests are dropped if not set
+	ErrorResponses *ErrorResponsesConfig `json:"errorResponses"`
 }
 
 // NewUDPListener ...
 func NewUDPListener() *UDPListener {
 	return &UDPListener{
 		ready: make(chan bool),
+		stop:  make(chan struct{}),
 	}
 }
 
@@ -56,15 +74,26 @@ func (l *UDPListener) Init(
 	if err != nil {
 		return err
 	}
+	if err = config.ApplyDefaults(&cfg); err != nil {
+		return err
+	}
+	l.cfg = cfg
+	if l.causes, err = newErrorCauses(cfg.ErrorResponses); err != nil {
+		return err
+	}
+	l.logger = server.logger.With(zap.String("listener", listenerConfig.Name))
+	l.warnCappedReadBuffer()
 
 	// Create packet server
 	l.Server = &radius.PacketServer{
 		Handler: radius.HandlerFunc(
 			generatePacketHandler(l, server),
 		),
-		SecretSource: radius.StaticSecretSource([]byte(serverConfig.Secret)),
-		Addr:         fmt.Sprintf(":%d", cfg.Port),
-		Ready:        make(chan bool),
+		SecretSource:   radius.StaticSecretSource([]byte(serverConfig.Secret)),
+		Addr:           fmt.Sprintf(":%d", cfg.Port),
+		ReadBufferSize: cfg.ReadBufferSize,
+		RequestTap:     server.requestTap(),
+		Ready:          make(chan bool),
 	}
 	return nil
 }
@@ -73,6 +102,11 @@ func (l *UDPListener) Init(
 func (l *UDPListener) ListenAndServe() error {
 	serverError := make(chan error, 1)
 	go func() {
+		if l.handedOff != nil {
+			l.Server.Ready <- true
+			serverError <- l.Server.Serve(l.handedOff)
+			return
+		}
 		err := l.Server.ListenAndServe()
 		serverError <- err
 	}()
@@ -80,6 +114,9 @@ func (l *UDPListener) ListenAndServe() error {
 	// Wait to see if initialization was successful
 	select {
 	case _ = <-l.Server.Ready:
+		if l.cfg.SocketStatsIntervalSec > 0 {
+			go l.pollSocketStats(time.Duration(l.cfg.SocketStatsIntervalSec) * time.Second)
+		}
 		l.ready <- true
 		return nil
 	case err := <-serverError:
@@ -95,9 +132,30 @@ func (l *UDPListener) GetHandleRequest() modules.Middleware {
 
 // Shutdown override
 func (l *UDPListener) Shutdown(ctx context.Context) error {
+	close(l.stop)
 	return l.Server.Shutdown(ctx)
 }
 
+// takeOver makes the listener serve on the socket handed off by the previous server process, if the socket is
+// bound to the listener's port
+func (l *UDPListener) takeOver(conn net.PacketConn) bool {
+	addr, ok := conn.LocalAddr().(*net.UDPAddr)
+	if !ok || addr.Port != l.cfg.Port {
+		return false
+	}
+	l.handedOff = conn
+	return true
+}
+
+// packetConn returns the socket the listener serves on, nil if it's not serving
+func (l *UDPListener) packetConn() net.PacketConn {
+	conns := l.Server.PacketConns()
+	if len(conns) == 0 {
+		return nil
+	}
+	return conns[0]
+}
+
 // Ready override
 func (l *UDPListener) Ready() chan bool {
 	return l.ready
@@ -108,16 +166,86 @@ func (l *UDPListener) SetConfig(c config.ListenerConfig) {
 	l.Config = c
 }
 
+// errorResponseCauses returns the Error-Causes of the listener's error responses
+func (l *UDPListener) errorResponseCauses() errorCauses {
+	return l.causes
+}
+
+// warnCappedReadBuffer warns when the configured receive buffer exceeds the kernel's limit, as the kernel silently
+// caps it
+func (l *UDPListener) warnCappedReadBuffer() {
+	if l.cfg.ReadBufferSize <= 0 {
+		return
+	}
+	rmemMax, err := readRmemMax()
+	if err != nil {
+		l.logger.Debug("failed to read the kernel's receive buffer limit", zap.Error(err))
+		return
+	}
+	if l.cfg.ReadBufferSize > rmemMax {
+		l.logger.Warn(
+			"UDP receive buffer size exceeds net.core.rmem_max and will be capped by the kernel",
+			zap.Int("read_buffer_size", l.cfg.ReadBufferSize),
+			zap.Int("rmem_max", rmemMax),
+		)
+	}
+}
+
+// pollSocketStats periodically exports the kernel's statistics of the listener's socket, as packets dropped on a
+// full receive buffer are otherwise invisible
+func (l *UDPListener) pollSocketStats(interval time.Duration) {
+	name := l.GetConfig().Name
+	drops := counters.UDPSocketDrops.SetTag(counters.ListenerTag, name)
+	rxQueue := counters.UDPSocketRxQueue.SetTag(counters.ListenerTag, name)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	var lastDrops uint64
+	for {
+		select {
+		case <-l.stop:
+			return
+		case <-ticker.C:
+		}
+		stats, err := readUDPSocketStats(l.cfg.Port)
+		if err != nil {
+			l.logger.Debug("failed to read UDP socket statistics", zap.Error(err))
+			continue
+		}
+		drops.Record(int64(stats.Drops))
+		rxQueue.Record(int64(stats.RxQueue))
+		if stats.Drops > lastDrops {
+			l.logger.Warn(
+				"kernel dropped packets on the UDP socket",
+				zap.Uint64("dropped", stats.Drops-lastDrops),
+				zap.Uint64("total_dropped", stats.Drops),
+				zap.Uint64("rx_queue_bytes", stats.RxQueue),
+			)
+		}
+		lastDrops = stats.Drops
+	}
+}
+
 // generatePacketHandler A generic handler method to incoming RADIUS packets
 func generatePacketHandler(l ListenerInterface, server *Server) func(radius.ResponseWriter, *radius.Request) {
+	listenerName := l.GetConfig().Name
 	server.logger.Debug(
 		"Registering handler for listener",
-		zap.String("listener", l.GetConfig().Name),
+		zap.String("listener", listenerName),
 	)
+
+	// Counters are created once per listener, as creating (and registering)
+	// them is far more expensive than the request handling itself
+	filterProcess := counters.NewOperation("filter_process")
+	listenerHandle := counters.NewOperation("listener_handle").
+		SetTag(counters.ListenerTag, listenerName)
+
 	return func(w radius.ResponseWriter, r *radius.Request) {
+		state := acquireRequestState()
+		defer releaseRequestState(state)
+
 		// Make sure no duplicate packet
 		dedupOperation := counters.DedupPacket.Start()
-		requestKey := fmt.Sprintf("%s_%d", r.RemoteAddr, r.Identifier)
+		requestKey := state.dedupKey(r)
 
 		if _, found := server.dedupSet.Get(requestKey); found {
 			server.logger.Warn(
@@ -134,7 +262,8 @@ func generatePacketHandler(l ListenerInterface, server *Server) func(radius.Resp
 
 		// Get session ID from the request, if exists, and setup correlation ID
 		var correlationField = zap.Uint32("correlation", rand.Uint32())
-		sessionID := server.GetSessionID(r)
+		nasQuirks := server.quirks.Of(r)
+		sessionID := server.sessionID(r, nasQuirks)
 
 		// Create request context
 		requestContext := modules.RequestContext{
@@ -142,18 +271,21 @@ func generatePacketHandler(l ListenerInterface, server *Server) func(radius.Resp
 			Logger:         server.logger.With(correlationField),
 			SessionID:      sessionID,
 			SessionStorage: session.NewSessionStorage(server.multiSessionStorage, sessionID),
+			Quirks:         nasQuirks,
 		}
+		nasQuirks.RestoreAcctSessionID(r, requestContext.SessionStorage)
+		server.tap.publish(listenerName, sessionID, r, r.Packet, false)
 
 		server.logger.Debug(
 			"Received RADIUS message on listener...",
-			zap.String("listener", l.GetConfig().Name),
+			zap.String("listener", listenerName),
 			correlationField,
 		)
 
 		// Execute filters
-		filterProcessCounter := counters.NewOperation("filter_process").Start()
+		filterProcessCounter := filterProcess.Start()
 		for _, filter := range server.filters {
-			err := filter.Code.Process(&requestContext, l.GetConfig().Name, r)
+			err := filter.Code.Process(&requestContext, listenerName, r)
 			if err != nil {
 				server.logger.Error("Failed to process reqeust by filter", zap.Error(err), correlationField)
 				filterProcessCounter.SetTag(counters.FilterTag, filter.Name).Failure("filter_failed")
@@ -163,13 +295,31 @@ func generatePacketHandler(l ListenerInterface, server *Server) func(radius.Resp
 		filterProcessCounter.Success()
 
 		// Execute modules
-		listenerHandleCounter := counters.NewOperation("listener_handle").
-			SetTag(counters.ListenerTag, l.GetConfig().Name).
-			Start()
+		listenerHandleCounter := listenerHandle.Start()
 		response, err := l.GetHandleRequest()(&requestContext, r)
 		if err != nil {
-			server.logger.Error("Failed to handle reqeust by listener", zap.Error(err), correlationField)
-			listenerHandleCounter.Failure("handle_failed")
+			class := retry.ClassOf(err)
+			server.logger.Error("Failed to handle reqeust by listener",
+				zap.Error(err), zap.Stringer("error_class", class), correlationField)
+			listenerHandleCounter.SetTag(counters.ErrorClassTag, class.String()).Failure("handle_failed")
+			if r.Code == radius.CodeAccessRequest {
+				counters.RecordAuth(false)
+			}
+			var causes errorCauses
+			if withCauses, ok := l.(interface{ errorResponseCauses() errorCauses }); ok {
+				causes = withCauses.errorResponseCauses()
+			}
+			if response = causes.errorResponse(r, err); response == nil {
+				return
+			}
+			server.logger.Debug(
+				"Responding with Error-Cause",
+				zap.Int("code", int(response.Code)),
+				correlationField,
+			)
+			errorResponse := state.buildResponse(r, response)
+			server.tap.publish(listenerName, sessionID, r, errorResponse, true)
+			w.Write(errorResponse)
 			return
 		}
 		listenerHandleCounter.Success()
@@ -182,17 +332,18 @@ func generatePacketHandler(l ListenerInterface, server *Server) func(radius.Resp
 			return
 		}
 
+		// Access-Challenges are intermediate steps of the authentication, only its outcome is counted
+		if response.Code == radius.CodeAccessAccept || response.Code == radius.CodeAccessReject {
+			counters.RecordAuth(response.Code == radius.CodeAccessAccept)
+		}
+
 		// Build response
-		server.logger.Warn(
+		server.logger.Debug(
 			"Request successfully handled",
 			correlationField,
 		)
-		radiusResponse := r.Response(response.Code)
-		for key, values := range response.Attributes {
-			for _, value := range values {
-				radiusResponse.Add(key, value)
-			}
-		}
-		w.Write(radiusResponse)
+		packet := state.buildResponse(r, response)
+		server.tap.publish(listenerName, sessionID, r, packet, true)
+		w.Write(packet)
 	}
 }
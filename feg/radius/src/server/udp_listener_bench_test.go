@@ -0,0 +1,103 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package server
+
+import (
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"fbc/cwf/radius/config"
+	"fbc/cwf/radius/loader/loaderstest"
+	"fbc/cwf/radius/modules"
+	"fbc/lib/go/radius"
+	"fbc/lib/go/radius/rfc2865"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// benchModule a module with no logic of its own, so benchmarks measure the
+// server's per-request overhead only
+type benchModule struct {
+	terminal bool
+}
+
+func (m benchModule) Init(_ *zap.Logger, _ modules.ModuleConfig) (modules.Context, error) {
+	return nil, nil
+}
+
+func (m benchModule) Handle(_ modules.Context, c *modules.RequestContext, r *radius.Request, next modules.Middleware) (*modules.Response, error) {
+	if !m.terminal {
+		return next(c, r)
+	}
+	return &modules.Response{
+		Code:       radius.CodeAccessAccept,
+		Attributes: radius.Attributes{rfc2865.ReplyMessage_Type: []radius.Attribute{radius.Attribute("ok")}},
+	}, nil
+}
+
+type benchResponseWriter struct{}
+
+func (benchResponseWriter) Write(packet *radius.Packet) error {
+	_, err := packet.Encode()
+	return err
+}
+
+func BenchmarkPacketHandler(b *testing.B) {
+	for _, moduleCount := range []int{1, 5, 10} {
+		b.Run(fmt.Sprintf("modules=%d", moduleCount), func(b *testing.B) {
+			benchmarkPacketHandler(b, moduleCount)
+		})
+	}
+}
+
+func benchmarkPacketHandler(b *testing.B, moduleCount int) {
+	// Arrange
+	logger := zap.NewNop()
+	listenerConfig := config.ListenerConfig{
+		Name:  "bench",
+		Type:  "udp",
+		Extra: map[string]interface{}{"Port": 0},
+	}
+	loader := &loaderstest.MockLoader{}
+	for i := 0; i < moduleCount; i++ {
+		name := fmt.Sprintf("module.%d", i)
+		listenerConfig.Modules = append(listenerConfig.Modules, config.ModuleDescriptor{Name: name})
+		loader.On("LoadModule", name).Return(benchModule{terminal: i == moduleCount-1}, nil)
+	}
+	server, err := New(
+		config.ServerConfig{
+			Secret:      "123456",
+			DedupWindow: config.Duration{Duration: time.Nanosecond},
+			Listeners:   []config.ListenerConfig{listenerConfig},
+		},
+		logger,
+		loader,
+	)
+	require.NoError(b, err)
+	handler := generatePacketHandler(server.listeners["bench"], server)
+
+	packet := radius.New(radius.CodeAccessRequest, []byte("123456"))
+	rfc2865.CalledStationID_SetString(packet, "AB-CD-EF-01-23-45:xwf")
+	rfc2865.CallingStationID_SetString(packet, "01-23-45-AB-CD-EF")
+	remoteAddr := &net.UDPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 1812}
+
+	// Act
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		packet.Identifier = byte(i)
+		handler(benchResponseWriter{}, &radius.Request{
+			RemoteAddr: remoteAddr,
+			Packet:     packet,
+		})
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package server
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// procNetUDPFiles the kernel's UDP socket tables
+var procNetUDPFiles = []string{"/proc/net/udp", "/proc/net/udp6"}
+
+// rmemMaxFile the kernel's upper limit of SO_RCVBUF
+const rmemMaxFile = "/proc/sys/net/core/rmem_max"
+
+// udpSocketStats kernel statistics of the UDP sockets bound to a port
+type udpSocketStats struct {
+	Drops   uint64 // packets dropped since the socket was opened
+	RxQueue uint64 // bytes waiting in the receive buffer
+}
+
+// readUDPSocketStats sums the kernel statistics of the UDP sockets bound to the local port
+func readUDPSocketStats(port int) (udpSocketStats, error) {
+	var total udpSocketStats
+	found := false
+	for _, path := range procNetUDPFiles {
+		f, err := os.Open(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return total, err
+		}
+		stats, ok, err := parseProcNetUDP(f, port)
+		f.Close()
+		if err != nil {
+			return total, fmt.Errorf("failed to parse %s: %s", path, err)
+		}
+		if ok {
+			found = true
+			total.Drops += stats.Drops
+			total.RxQueue += stats.RxQueue
+		}
+	}
+	if !found {
+		return total, fmt.Errorf("no UDP socket is bound to port %d", port)
+	}
+	return total, nil
+}
+
+// parseProcNetUDP sums the statistics of the port's sockets in a /proc/net/udp formatted table:
+//
+//	sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode ref pointer drops
+//	 0: 00000000:0714 00000000:0000 07 00000000:00000000 00:00000000 00000000     0        0 1234 2 ffff8880 0
+func parseProcNetUDP(r io.Reader, port int) (udpSocketStats, bool, error) {
+	var total udpSocketStats
+	found := false
+	scanner := bufio.NewScanner(r)
+	scanner.Scan() // header
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 13 {
+			continue
+		}
+		local := strings.Split(fields[1], ":")
+		if len(local) != 2 {
+			continue
+		}
+		localPort, err := strconv.ParseUint(local[1], 16, 16)
+		if err != nil || int(localPort) != port {
+			continue
+		}
+		queues := strings.Split(fields[4], ":")
+		if len(queues) != 2 {
+			return total, false, fmt.Errorf("invalid tx_queue:rx_queue field '%s'", fields[4])
+		}
+		rxQueue, err := strconv.ParseUint(queues[1], 16, 64)
+		if err != nil {
+			return total, false, err
+		}
+		drops, err := strconv.ParseUint(fields[12], 10, 64)
+		if err != nil {
+			return total, false, err
+		}
+		found = true
+		total.RxQueue += rxQueue
+		total.Drops += drops
+	}
+	return total, found, scanner.Err()
+}
+
+// readRmemMax returns the kernel's upper limit of SO_RCVBUF, larger requested sizes are silently capped to it
+func readRmemMax() (int, error) {
+	b, err := ioutil.ReadFile(rmemMaxFile)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(b)))
+}
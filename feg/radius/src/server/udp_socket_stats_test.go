@@ -0,0 +1,39 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package server
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const procNetUDP = `  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode ref pointer drops
+  120: 00000000:0714 00000000:0000 07 00000000:00000200 00:00000000 00000000     0        0 21412 2 ffff8f1c3c4e0000 17
+  121: 0100007F:0715 00000000:0000 07 00000000:00000000 00:00000000 00000000     0        0 21413 2 ffff8f1c3c4e0400 0
+  122: 00000000:0714 00000000:0000 07 00000000:00000100 00:00000000 00000000     0        0 21414 2 ffff8f1c3c4e0800 3
+`
+
+func TestParseProcNetUDP(t *testing.T) {
+	// Act
+	stats, found, err := parseProcNetUDP(strings.NewReader(procNetUDP), 1812)
+
+	// Assert
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, udpSocketStats{Drops: 20, RxQueue: 0x300}, stats)
+
+	// Act
+	_, found, err = parseProcNetUDP(strings.NewReader(procNetUDP), 1814)
+
+	// Assert
+	require.NoError(t, err)
+	require.False(t, found)
+}
@@ -0,0 +1,66 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package server
+
+import (
+	"net"
+	"os"
+
+	"go.uber.org/zap"
+)
+
+// listenUnix listens on the unix domain socket, replacing a stale socket file. Connections are authenticated by
+// their peers' SO_PEERCRED credentials, connections of peers other than root, this process' user & the allowed UIDs
+// are closed
+func listenUnix(socketPath string, allowedUIDs []int, logger *zap.Logger) (net.Listener, error) {
+	if fi, err := os.Stat(socketPath); err == nil && fi.Mode()&os.ModeSocket != 0 {
+		os.Remove(socketPath)
+	}
+	lis, err := net.ListenUnix("unix", &net.UnixAddr{Name: socketPath, Net: "unix"})
+	if err != nil {
+		return nil, err
+	}
+	// connecting requires write permission, peers are authenticated by their credentials instead
+	if err = os.Chmod(socketPath, 0666); err != nil {
+		lis.Close()
+		return nil, err
+	}
+	allowed := map[uint32]bool{0: true, uint32(os.Getuid()): true}
+	for _, uid := range allowedUIDs {
+		allowed[uint32(uid)] = true
+	}
+	return &peerCredListener{UnixListener: lis, allowed: allowed, logger: logger}, nil
+}
+
+// peerCredListener accepts connections of allowed peers only
+type peerCredListener struct {
+	*net.UnixListener
+	allowed map[uint32]bool
+	logger  *zap.Logger
+}
+
+// Accept override, connections of not allowed peers are closed
+func (l *peerCredListener) Accept() (net.Conn, error) {
+	for {
+		conn, err := l.AcceptUnix()
+		if err != nil {
+			return nil, err
+		}
+		uid, err := peerUID(conn)
+		if err == nil && l.allowed[uid] {
+			return conn, nil
+		}
+		if err != nil {
+			l.logger.Error("failed reading unix socket peer credentials", zap.Error(err))
+		} else {
+			l.logger.Warn("unix socket connection rejected", zap.Uint32("uid", uid))
+		}
+		conn.Close()
+	}
+}
@@ -18,6 +18,9 @@ type (
 		Tier              string
 		RadiusSessionFBID uint64 // the FBID of the XWFEntRadiusSession created for this RADIUS session
 		AcctSessionID     string
+		// Attributes context attributes attached to the session by modules, propagated to the AAA context and
+		// analytics events
+		Attributes map[string]string
 	}
 
 	// GlobalStorage an interface for session-level storage, which allows
@@ -28,6 +31,12 @@ type (
 		Reset(sessionID string) error
 	}
 
+	// Snapshotter a global storage which can snapshot all of its session states, e.g. for handing them off to
+	// another server process
+	Snapshotter interface {
+		Snapshot() map[string]State
+	}
+
 	// Storage an interface for session-level storage, which allows access
 	// to one specific session state. This interface is to be used on
 	// session-specific flows, like accounting
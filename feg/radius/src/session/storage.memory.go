@@ -63,6 +63,18 @@ func (m *memoryStorage) Reset(sessionID string) error {
 	return nil
 }
 
+// Snapshot returns all stored session states by session IDs
+func (m *memoryStorage) Snapshot() map[string]State {
+	res := map[string]State{}
+	m.data.Range(func(key, value interface{}) bool {
+		if state, ok := value.(State); ok {
+			res[key.(string)] = state
+		}
+		return true
+	})
+	return res
+}
+
 // NewMultiSessionMemoryStorage Returns a new memory-stored session state storage
 func NewMultiSessionMemoryStorage() GlobalStorage {
 	return &memoryStorage{
@@ -319,6 +319,37 @@ func (MonitoringLevel) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_session_manager_b847eb08e3baf860, []int{4}
 }
 
+type LocalCreateSessionResponse_CreditControlMode int32
+
+const (
+	// the interface is in service
+	LocalCreateSessionResponse_NORMAL LocalCreateSessionResponse_CreditControlMode = 0
+	// the session was created while the interface failed, per the interface's failure handling (e.g. the PCRF is
+	// unreachable & the default rules are installed)
+	LocalCreateSessionResponse_FAILURE LocalCreateSessionResponse_CreditControlMode = 1
+	// the interface is bypassed & the session isn't controlled by it (e.g. the OCS is unreachable & Credit-Control-
+	// Failure-Handling is CONTINUE)
+	LocalCreateSessionResponse_BYPASS LocalCreateSessionResponse_CreditControlMode = 2
+)
+
+var LocalCreateSessionResponse_CreditControlMode_name = map[int32]string{
+	0: "NORMAL",
+	1: "FAILURE",
+	2: "BYPASS",
+}
+var LocalCreateSessionResponse_CreditControlMode_value = map[string]int32{
+	"NORMAL":  0,
+	"FAILURE": 1,
+	"BYPASS":  2,
+}
+
+func (x LocalCreateSessionResponse_CreditControlMode) String() string {
+	return proto.EnumName(LocalCreateSessionResponse_CreditControlMode_name, int32(x))
+}
+func (LocalCreateSessionResponse_CreditControlMode) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_session_manager_b847eb08e3baf860, []int{3, 0}
+}
+
 type ChargingReAuthRequest_Type int32
 
 const (
@@ -745,23 +776,29 @@ func (m *RuleRecordTable) GetEpoch() uint64 {
 }
 
 type LocalCreateSessionRequest struct {
-	Sid                  *SubscriberID          `protobuf:"bytes,1,opt,name=sid,proto3" json:"sid,omitempty"`
-	UeIpv4               string                 `protobuf:"bytes,2,opt,name=ue_ipv4,json=ueIpv4,proto3" json:"ue_ipv4,omitempty"`
-	SpgwIpv4             string                 `protobuf:"bytes,3,opt,name=spgw_ipv4,json=spgwIpv4,proto3" json:"spgw_ipv4,omitempty"`
-	Apn                  string                 `protobuf:"bytes,4,opt,name=apn,proto3" json:"apn,omitempty"`
-	Imei                 string                 `protobuf:"bytes,6,opt,name=imei,proto3" json:"imei,omitempty"`
-	PlmnId               string                 `protobuf:"bytes,7,opt,name=plmn_id,json=plmnId,proto3" json:"plmn_id,omitempty"`
-	ImsiPlmnId           string                 `protobuf:"bytes,8,opt,name=imsi_plmn_id,json=imsiPlmnId,proto3" json:"imsi_plmn_id,omitempty"`
-	UserLocation         []byte                 `protobuf:"bytes,9,opt,name=user_location,json=userLocation,proto3" json:"user_location,omitempty"`
-	QosInfo              *QosInformationRequest `protobuf:"bytes,10,opt,name=qos_info,json=qosInfo,proto3" json:"qos_info,omitempty"`
-	Msisdn               []byte                 `protobuf:"bytes,11,opt,name=msisdn,proto3" json:"msisdn,omitempty"`
-	RatType              RATType                `protobuf:"varint,12,opt,name=rat_type,json=ratType,proto3,enum=magma.lte.RATType" json:"rat_type,omitempty"`
-	HardwareAddr         []byte                 `protobuf:"bytes,13,opt,name=hardware_addr,json=hardwareAddr,proto3" json:"hardware_addr,omitempty"`
-	RadiusSessionId      string                 `protobuf:"bytes,14,opt,name=radius_session_id,json=radiusSessionId,proto3" json:"radius_session_id,omitempty"`
-	BearerId             uint32                 `protobuf:"varint,15,opt,name=bearer_id,json=bearerId,proto3" json:"bearer_id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
-	XXX_unrecognized     []byte                 `json:"-"`
-	XXX_sizecache        int32                  `json:"-"`
+	Sid                     *SubscriberID          `protobuf:"bytes,1,opt,name=sid,proto3" json:"sid,omitempty"`
+	UeIpv4                  string                 `protobuf:"bytes,2,opt,name=ue_ipv4,json=ueIpv4,proto3" json:"ue_ipv4,omitempty"`
+	SpgwIpv4                string                 `protobuf:"bytes,3,opt,name=spgw_ipv4,json=spgwIpv4,proto3" json:"spgw_ipv4,omitempty"`
+	Apn                     string                 `protobuf:"bytes,4,opt,name=apn,proto3" json:"apn,omitempty"`
+	Imei                    string                 `protobuf:"bytes,6,opt,name=imei,proto3" json:"imei,omitempty"`
+	PlmnId                  string                 `protobuf:"bytes,7,opt,name=plmn_id,json=plmnId,proto3" json:"plmn_id,omitempty"`
+	ImsiPlmnId              string                 `protobuf:"bytes,8,opt,name=imsi_plmn_id,json=imsiPlmnId,proto3" json:"imsi_plmn_id,omitempty"`
+	UserLocation            []byte                 `protobuf:"bytes,9,opt,name=user_location,json=userLocation,proto3" json:"user_location,omitempty"`
+	QosInfo                 *QosInformationRequest `protobuf:"bytes,10,opt,name=qos_info,json=qosInfo,proto3" json:"qos_info,omitempty"`
+	Msisdn                  []byte                 `protobuf:"bytes,11,opt,name=msisdn,proto3" json:"msisdn,omitempty"`
+	RatType                 RATType                `protobuf:"varint,12,opt,name=rat_type,json=ratType,proto3,enum=magma.lte.RATType" json:"rat_type,omitempty"`
+	HardwareAddr            []byte                 `protobuf:"bytes,13,opt,name=hardware_addr,json=hardwareAddr,proto3" json:"hardware_addr,omitempty"`
+	RadiusSessionId         string                 `protobuf:"bytes,14,opt,name=radius_session_id,json=radiusSessionId,proto3" json:"radius_session_id,omitempty"`
+	BearerId                uint32                 `protobuf:"varint,15,opt,name=bearer_id,json=bearerId,proto3" json:"bearer_id,omitempty"`
+	StaticRuleIds           []string               `protobuf:"bytes,16,rep,name=static_rule_ids,json=staticRuleIds,proto3" json:"static_rule_ids,omitempty"`
+	RuleBaseNames           []string               `protobuf:"bytes,17,rep,name=rule_base_names,json=ruleBaseNames,proto3" json:"rule_base_names,omitempty"`
+	UeIpv6                  string                 `protobuf:"bytes,18,opt,name=ue_ipv6,json=ueIpv6,proto3" json:"ue_ipv6,omitempty"`
+	UeIpv6Prefix            string                 `protobuf:"bytes,19,opt,name=ue_ipv6_prefix,json=ueIpv6Prefix,proto3" json:"ue_ipv6_prefix,omitempty"`
+	ChargingCharacteristics string                 `protobuf:"bytes,20,opt,name=charging_characteristics,json=chargingCharacteristics,proto3" json:"charging_characteristics,omitempty"`
+	RatingGroups            []uint32               `protobuf:"varint,21,rep,packed,name=rating_groups,json=ratingGroups,proto3" json:"rating_groups,omitempty"`
+	XXX_NoUnkeyedLiteral    struct{}               `json:"-"`
+	XXX_unrecognized        []byte                 `json:"-"`
+	XXX_sizecache           int32                  `json:"-"`
 }
 
 func (m *LocalCreateSessionRequest) Reset()         { *m = LocalCreateSessionRequest{} }
@@ -886,10 +923,55 @@ func (m *LocalCreateSessionRequest) GetBearerId() uint32 {
 	return 0
 }
 
+func (m *LocalCreateSessionRequest) GetStaticRuleIds() []string {
+	if m != nil {
+		return m.StaticRuleIds
+	}
+	return nil
+}
+
+func (m *LocalCreateSessionRequest) GetRuleBaseNames() []string {
+	if m != nil {
+		return m.RuleBaseNames
+	}
+	return nil
+}
+
+func (m *LocalCreateSessionRequest) GetUeIpv6() string {
+	if m != nil {
+		return m.UeIpv6
+	}
+	return ""
+}
+
+func (m *LocalCreateSessionRequest) GetUeIpv6Prefix() string {
+	if m != nil {
+		return m.UeIpv6Prefix
+	}
+	return ""
+}
+
+func (m *LocalCreateSessionRequest) GetChargingCharacteristics() string {
+	if m != nil {
+		return m.ChargingCharacteristics
+	}
+	return ""
+}
+
+func (m *LocalCreateSessionRequest) GetRatingGroups() []uint32 {
+	if m != nil {
+		return m.RatingGroups
+	}
+	return nil
+}
+
 type LocalCreateSessionResponse struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	// gx_mode & gy_mode - failure handling modes of the session's Gx & Gy
+	GxMode               LocalCreateSessionResponse_CreditControlMode `protobuf:"varint,1,opt,name=gx_mode,json=gxMode,proto3,enum=magma.lte.LocalCreateSessionResponse_CreditControlMode" json:"gx_mode,omitempty"`
+	GyMode               LocalCreateSessionResponse_CreditControlMode `protobuf:"varint,2,opt,name=gy_mode,json=gyMode,proto3,enum=magma.lte.LocalCreateSessionResponse_CreditControlMode" json:"gy_mode,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                     `json:"-"`
+	XXX_unrecognized     []byte                                       `json:"-"`
+	XXX_sizecache        int32                                        `json:"-"`
 }
 
 func (m *LocalCreateSessionResponse) Reset()         { *m = LocalCreateSessionResponse{} }
@@ -916,6 +998,20 @@ func (m *LocalCreateSessionResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_LocalCreateSessionResponse proto.InternalMessageInfo
 
+func (m *LocalCreateSessionResponse) GetGxMode() LocalCreateSessionResponse_CreditControlMode {
+	if m != nil {
+		return m.GxMode
+	}
+	return LocalCreateSessionResponse_NORMAL
+}
+
+func (m *LocalCreateSessionResponse) GetGyMode() LocalCreateSessionResponse_CreditControlMode {
+	if m != nil {
+		return m.GyMode
+	}
+	return LocalCreateSessionResponse_NORMAL
+}
+
 type LocalEndSessionResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -2118,23 +2214,29 @@ func (m *QosInformationRequest) GetQosClassId() uint32 {
 }
 
 type CreateSessionRequest struct {
-	Subscriber           *SubscriberID          `protobuf:"bytes,1,opt,name=subscriber,proto3" json:"subscriber,omitempty"`
-	SessionId            string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
-	UeIpv4               string                 `protobuf:"bytes,3,opt,name=ue_ipv4,json=ueIpv4,proto3" json:"ue_ipv4,omitempty"`
-	SpgwIpv4             string                 `protobuf:"bytes,4,opt,name=spgw_ipv4,json=spgwIpv4,proto3" json:"spgw_ipv4,omitempty"`
-	Apn                  string                 `protobuf:"bytes,5,opt,name=apn,proto3" json:"apn,omitempty"`
-	Imei                 string                 `protobuf:"bytes,7,opt,name=imei,proto3" json:"imei,omitempty"`
-	PlmnId               string                 `protobuf:"bytes,8,opt,name=plmn_id,json=plmnId,proto3" json:"plmn_id,omitempty"`
-	ImsiPlmnId           string                 `protobuf:"bytes,9,opt,name=imsi_plmn_id,json=imsiPlmnId,proto3" json:"imsi_plmn_id,omitempty"`
-	UserLocation         []byte                 `protobuf:"bytes,10,opt,name=user_location,json=userLocation,proto3" json:"user_location,omitempty"`
-	QosInfo              *QosInformationRequest `protobuf:"bytes,11,opt,name=qos_info,json=qosInfo,proto3" json:"qos_info,omitempty"`
-	Msisdn               []byte                 `protobuf:"bytes,12,opt,name=msisdn,proto3" json:"msisdn,omitempty"`
-	GcId                 string                 `protobuf:"bytes,13,opt,name=gc_id,json=gcId,proto3" json:"gc_id,omitempty"`
-	RatType              RATType                `protobuf:"varint,14,opt,name=rat_type,json=ratType,proto3,enum=magma.lte.RATType" json:"rat_type,omitempty"`
-	HardwareAddr         []byte                 `protobuf:"bytes,15,opt,name=hardware_addr,json=hardwareAddr,proto3" json:"hardware_addr,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
-	XXX_unrecognized     []byte                 `json:"-"`
-	XXX_sizecache        int32                  `json:"-"`
+	Subscriber              *SubscriberID          `protobuf:"bytes,1,opt,name=subscriber,proto3" json:"subscriber,omitempty"`
+	SessionId               string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	UeIpv4                  string                 `protobuf:"bytes,3,opt,name=ue_ipv4,json=ueIpv4,proto3" json:"ue_ipv4,omitempty"`
+	SpgwIpv4                string                 `protobuf:"bytes,4,opt,name=spgw_ipv4,json=spgwIpv4,proto3" json:"spgw_ipv4,omitempty"`
+	Apn                     string                 `protobuf:"bytes,5,opt,name=apn,proto3" json:"apn,omitempty"`
+	Imei                    string                 `protobuf:"bytes,7,opt,name=imei,proto3" json:"imei,omitempty"`
+	PlmnId                  string                 `protobuf:"bytes,8,opt,name=plmn_id,json=plmnId,proto3" json:"plmn_id,omitempty"`
+	ImsiPlmnId              string                 `protobuf:"bytes,9,opt,name=imsi_plmn_id,json=imsiPlmnId,proto3" json:"imsi_plmn_id,omitempty"`
+	UserLocation            []byte                 `protobuf:"bytes,10,opt,name=user_location,json=userLocation,proto3" json:"user_location,omitempty"`
+	QosInfo                 *QosInformationRequest `protobuf:"bytes,11,opt,name=qos_info,json=qosInfo,proto3" json:"qos_info,omitempty"`
+	Msisdn                  []byte                 `protobuf:"bytes,12,opt,name=msisdn,proto3" json:"msisdn,omitempty"`
+	GcId                    string                 `protobuf:"bytes,13,opt,name=gc_id,json=gcId,proto3" json:"gc_id,omitempty"`
+	RatType                 RATType                `protobuf:"varint,14,opt,name=rat_type,json=ratType,proto3,enum=magma.lte.RATType" json:"rat_type,omitempty"`
+	HardwareAddr            []byte                 `protobuf:"bytes,15,opt,name=hardware_addr,json=hardwareAddr,proto3" json:"hardware_addr,omitempty"`
+	StaticRuleIds           []string               `protobuf:"bytes,16,rep,name=static_rule_ids,json=staticRuleIds,proto3" json:"static_rule_ids,omitempty"`
+	RuleBaseNames           []string               `protobuf:"bytes,17,rep,name=rule_base_names,json=ruleBaseNames,proto3" json:"rule_base_names,omitempty"`
+	UeIpv6                  string                 `protobuf:"bytes,18,opt,name=ue_ipv6,json=ueIpv6,proto3" json:"ue_ipv6,omitempty"`
+	UeIpv6Prefix            string                 `protobuf:"bytes,19,opt,name=ue_ipv6_prefix,json=ueIpv6Prefix,proto3" json:"ue_ipv6_prefix,omitempty"`
+	ChargingCharacteristics string                 `protobuf:"bytes,20,opt,name=charging_characteristics,json=chargingCharacteristics,proto3" json:"charging_characteristics,omitempty"`
+	RatingGroups            []uint32               `protobuf:"varint,21,rep,packed,name=rating_groups,json=ratingGroups,proto3" json:"rating_groups,omitempty"`
+	XXX_NoUnkeyedLiteral    struct{}               `json:"-"`
+	XXX_unrecognized        []byte                 `json:"-"`
+	XXX_sizecache           int32                  `json:"-"`
 }
 
 func (m *CreateSessionRequest) Reset()         { *m = CreateSessionRequest{} }
@@ -2259,15 +2361,59 @@ func (m *CreateSessionRequest) GetHardwareAddr() []byte {
 	return nil
 }
 
+func (m *CreateSessionRequest) GetStaticRuleIds() []string {
+	if m != nil {
+		return m.StaticRuleIds
+	}
+	return nil
+}
+
+func (m *CreateSessionRequest) GetRuleBaseNames() []string {
+	if m != nil {
+		return m.RuleBaseNames
+	}
+	return nil
+}
+
+func (m *CreateSessionRequest) GetUeIpv6() string {
+	if m != nil {
+		return m.UeIpv6
+	}
+	return ""
+}
+
+func (m *CreateSessionRequest) GetUeIpv6Prefix() string {
+	if m != nil {
+		return m.UeIpv6Prefix
+	}
+	return ""
+}
+
+func (m *CreateSessionRequest) GetChargingCharacteristics() string {
+	if m != nil {
+		return m.ChargingCharacteristics
+	}
+	return ""
+}
+
+func (m *CreateSessionRequest) GetRatingGroups() []uint32 {
+	if m != nil {
+		return m.RatingGroups
+	}
+	return nil
+}
+
 type CreateSessionResponse struct {
-	Credits              []*CreditUpdateResponse          `protobuf:"bytes,1,rep,name=credits,proto3" json:"credits,omitempty"`
-	RuleBaseNames        []string                         `protobuf:"bytes,5,rep,name=rule_base_names,json=ruleBaseNames,proto3" json:"rule_base_names,omitempty"`
-	UsageMonitors        []*UsageMonitoringUpdateResponse `protobuf:"bytes,6,rep,name=usage_monitors,json=usageMonitors,proto3" json:"usage_monitors,omitempty"`
-	StaticRules          []*StaticRuleInstall             `protobuf:"bytes,7,rep,name=static_rules,json=staticRules,proto3" json:"static_rules,omitempty"`
-	DynamicRules         []*DynamicRuleInstall            `protobuf:"bytes,8,rep,name=dynamic_rules,json=dynamicRules,proto3" json:"dynamic_rules,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                         `json:"-"`
-	XXX_unrecognized     []byte                           `json:"-"`
-	XXX_sizecache        int32                            `json:"-"`
+	Credits              []*CreditUpdateResponse                      `protobuf:"bytes,1,rep,name=credits,proto3" json:"credits,omitempty"`
+	RuleBaseNames        []string                                     `protobuf:"bytes,5,rep,name=rule_base_names,json=ruleBaseNames,proto3" json:"rule_base_names,omitempty"`
+	UsageMonitors        []*UsageMonitoringUpdateResponse             `protobuf:"bytes,6,rep,name=usage_monitors,json=usageMonitors,proto3" json:"usage_monitors,omitempty"`
+	StaticRules          []*StaticRuleInstall                         `protobuf:"bytes,7,rep,name=static_rules,json=staticRules,proto3" json:"static_rules,omitempty"`
+	DynamicRules         []*DynamicRuleInstall                        `protobuf:"bytes,8,rep,name=dynamic_rules,json=dynamicRules,proto3" json:"dynamic_rules,omitempty"`
+	GxMode               LocalCreateSessionResponse_CreditControlMode `protobuf:"varint,9,opt,name=gx_mode,json=gxMode,proto3,enum=magma.lte.LocalCreateSessionResponse_CreditControlMode" json:"gx_mode,omitempty"`
+	GyMode               LocalCreateSessionResponse_CreditControlMode `protobuf:"varint,10,opt,name=gy_mode,json=gyMode,proto3,enum=magma.lte.LocalCreateSessionResponse_CreditControlMode" json:"gy_mode,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                     `json:"-"`
+	XXX_unrecognized     []byte                                       `json:"-"`
+	XXX_sizecache        int32                                        `json:"-"`
 }
 
 func (m *CreateSessionResponse) Reset()         { *m = CreateSessionResponse{} }
@@ -2329,6 +2475,20 @@ func (m *CreateSessionResponse) GetDynamicRules() []*DynamicRuleInstall {
 	return nil
 }
 
+func (m *CreateSessionResponse) GetGxMode() LocalCreateSessionResponse_CreditControlMode {
+	if m != nil {
+		return m.GxMode
+	}
+	return LocalCreateSessionResponse_NORMAL
+}
+
+func (m *CreateSessionResponse) GetGyMode() LocalCreateSessionResponse_CreditControlMode {
+	if m != nil {
+		return m.GyMode
+	}
+	return LocalCreateSessionResponse_NORMAL
+}
+
 type StaticRuleInstall struct {
 	RuleId               string               `protobuf:"bytes,1,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
 	ActivationTime       *timestamp.Timestamp `protobuf:"bytes,2,opt,name=activation_time,json=activationTime,proto3" json:"activation_time,omitempty"`
@@ -2725,6 +2885,369 @@ func (m *SessionTerminateRequest) GetHardwareAddr() []byte {
 	return nil
 }
 
+// Cumulative usage of a session metered by its access network (e.g. a WLAN NAS's accounting Interim-Updates), for
+// sessions whose traffic isn't metered by pipelined
+type LocalSessionUsage struct {
+	Sid             *SubscriberID `protobuf:"bytes,1,opt,name=sid,proto3" json:"sid,omitempty"`
+	RadiusSessionId string        `protobuf:"bytes,2,opt,name=radius_session_id,json=radiusSessionId,proto3" json:"radius_session_id,omitempty"`
+	// octets & packets sent by the UE (uplink)
+	OctetsIn  uint64 `protobuf:"varint,3,opt,name=octets_in,json=octetsIn,proto3" json:"octets_in,omitempty"`
+	PacketsIn uint64 `protobuf:"varint,4,opt,name=packets_in,json=packetsIn,proto3" json:"packets_in,omitempty"`
+	// octets & packets received by the UE (downlink)
+	OctetsOut            uint64   `protobuf:"varint,5,opt,name=octets_out,json=octetsOut,proto3" json:"octets_out,omitempty"`
+	PacketsOut           uint64   `protobuf:"varint,6,opt,name=packets_out,json=packetsOut,proto3" json:"packets_out,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LocalSessionUsage) Reset()         { *m = LocalSessionUsage{} }
+func (m *LocalSessionUsage) String() string { return proto.CompactTextString(m) }
+func (*LocalSessionUsage) ProtoMessage()    {}
+func (*LocalSessionUsage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_session_manager_b847eb08e3baf860, []int{30}
+}
+func (m *LocalSessionUsage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LocalSessionUsage.Unmarshal(m, b)
+}
+func (m *LocalSessionUsage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LocalSessionUsage.Marshal(b, m, deterministic)
+}
+func (dst *LocalSessionUsage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LocalSessionUsage.Merge(dst, src)
+}
+func (m *LocalSessionUsage) XXX_Size() int {
+	return xxx_messageInfo_LocalSessionUsage.Size(m)
+}
+func (m *LocalSessionUsage) XXX_DiscardUnknown() {
+	xxx_messageInfo_LocalSessionUsage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LocalSessionUsage proto.InternalMessageInfo
+
+func (m *LocalSessionUsage) GetSid() *SubscriberID {
+	if m != nil {
+		return m.Sid
+	}
+	return nil
+}
+
+func (m *LocalSessionUsage) GetRadiusSessionId() string {
+	if m != nil {
+		return m.RadiusSessionId
+	}
+	return ""
+}
+
+func (m *LocalSessionUsage) GetOctetsIn() uint64 {
+	if m != nil {
+		return m.OctetsIn
+	}
+	return 0
+}
+
+func (m *LocalSessionUsage) GetPacketsIn() uint64 {
+	if m != nil {
+		return m.PacketsIn
+	}
+	return 0
+}
+
+func (m *LocalSessionUsage) GetOctetsOut() uint64 {
+	if m != nil {
+		return m.OctetsOut
+	}
+	return 0
+}
+
+func (m *LocalSessionUsage) GetPacketsOut() uint64 {
+	if m != nil {
+		return m.PacketsOut
+	}
+	return 0
+}
+
+// Termination of a session with its final usage metered by its access network (e.g. a WLAN NAS's accounting Stop)
+type LocalEndSessionRequest struct {
+	Sid *SubscriberID `protobuf:"bytes,1,opt,name=sid,proto3" json:"sid,omitempty"`
+	// final cumulative usage, added to the session's usage before the termination reports it
+	FinalUsage *LocalSessionUsage `protobuf:"bytes,2,opt,name=final_usage,json=finalUsage,proto3" json:"final_usage,omitempty"`
+	// session duration metered by the access network, in seconds
+	SessionTime          uint32   `protobuf:"varint,3,opt,name=session_time,json=sessionTime,proto3" json:"session_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LocalEndSessionRequest) Reset()         { *m = LocalEndSessionRequest{} }
+func (m *LocalEndSessionRequest) String() string { return proto.CompactTextString(m) }
+func (*LocalEndSessionRequest) ProtoMessage()    {}
+func (*LocalEndSessionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_session_manager_b847eb08e3baf860, []int{31}
+}
+func (m *LocalEndSessionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LocalEndSessionRequest.Unmarshal(m, b)
+}
+func (m *LocalEndSessionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LocalEndSessionRequest.Marshal(b, m, deterministic)
+}
+func (dst *LocalEndSessionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LocalEndSessionRequest.Merge(dst, src)
+}
+func (m *LocalEndSessionRequest) XXX_Size() int {
+	return xxx_messageInfo_LocalEndSessionRequest.Size(m)
+}
+func (m *LocalEndSessionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LocalEndSessionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LocalEndSessionRequest proto.InternalMessageInfo
+
+func (m *LocalEndSessionRequest) GetSid() *SubscriberID {
+	if m != nil {
+		return m.Sid
+	}
+	return nil
+}
+
+func (m *LocalEndSessionRequest) GetFinalUsage() *LocalSessionUsage {
+	if m != nil {
+		return m.FinalUsage
+	}
+	return nil
+}
+
+func (m *LocalEndSessionRequest) GetSessionTime() uint32 {
+	if m != nil {
+		return m.SessionTime
+	}
+	return 0
+}
+
+// Session manager's decision on a session after its access network usage report, so the access network enforces
+// the session's exhausted quota
+type LocalSessionUsageResponse struct {
+	// the session's quota is exhausted, its final unit action is to be enforced by the access network
+	QuotaExhausted bool                       `protobuf:"varint,1,opt,name=quota_exhausted,json=quotaExhausted,proto3" json:"quota_exhausted,omitempty"`
+	FinalAction    ChargingCredit_FinalAction `protobuf:"varint,2,opt,name=final_action,json=finalAction,enum=magma.lte.ChargingCredit_FinalAction,proto3" json:"final_action,omitempty"`
+	// redirect server of the REDIRECT final action
+	RedirectServer       *RedirectServer `protobuf:"bytes,3,opt,name=redirect_server,json=redirectServer,proto3" json:"redirect_server,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *LocalSessionUsageResponse) Reset()         { *m = LocalSessionUsageResponse{} }
+func (m *LocalSessionUsageResponse) String() string { return proto.CompactTextString(m) }
+func (*LocalSessionUsageResponse) ProtoMessage()    {}
+func (*LocalSessionUsageResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_session_manager_b847eb08e3baf860, []int{32}
+}
+func (m *LocalSessionUsageResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LocalSessionUsageResponse.Unmarshal(m, b)
+}
+func (m *LocalSessionUsageResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LocalSessionUsageResponse.Marshal(b, m, deterministic)
+}
+func (dst *LocalSessionUsageResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LocalSessionUsageResponse.Merge(dst, src)
+}
+func (m *LocalSessionUsageResponse) XXX_Size() int {
+	return xxx_messageInfo_LocalSessionUsageResponse.Size(m)
+}
+func (m *LocalSessionUsageResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LocalSessionUsageResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LocalSessionUsageResponse proto.InternalMessageInfo
+
+func (m *LocalSessionUsageResponse) GetQuotaExhausted() bool {
+	if m != nil {
+		return m.QuotaExhausted
+	}
+	return false
+}
+
+func (m *LocalSessionUsageResponse) GetFinalAction() ChargingCredit_FinalAction {
+	if m != nil {
+		return m.FinalAction
+	}
+	return ChargingCredit_TERMINATE
+}
+
+func (m *LocalSessionUsageResponse) GetRedirectServer() *RedirectServer {
+	if m != nil {
+		return m.RedirectServer
+	}
+	return nil
+}
+
+// Request of the local session manager's active sessions, e.g. for the AAA's session table resynchronization
+type LocalListSessionsRequest struct {
+	// only the sessions of the RAT type are listed, e.g. TGPP_WLAN for the CWF sessions
+	RatType              RATType  `protobuf:"varint,1,opt,name=rat_type,json=ratType,enum=magma.lte.RATType,proto3" json:"rat_type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LocalListSessionsRequest) Reset()         { *m = LocalListSessionsRequest{} }
+func (m *LocalListSessionsRequest) String() string { return proto.CompactTextString(m) }
+func (*LocalListSessionsRequest) ProtoMessage()    {}
+func (*LocalListSessionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_session_manager_b847eb08e3baf860, []int{33}
+}
+func (m *LocalListSessionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LocalListSessionsRequest.Unmarshal(m, b)
+}
+func (m *LocalListSessionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LocalListSessionsRequest.Marshal(b, m, deterministic)
+}
+func (dst *LocalListSessionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LocalListSessionsRequest.Merge(dst, src)
+}
+func (m *LocalListSessionsRequest) XXX_Size() int {
+	return xxx_messageInfo_LocalListSessionsRequest.Size(m)
+}
+func (m *LocalListSessionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LocalListSessionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LocalListSessionsRequest proto.InternalMessageInfo
+
+func (m *LocalListSessionsRequest) GetRatType() RATType {
+	if m != nil {
+		return m.RatType
+	}
+	return RATType_TGPP_LTE
+}
+
+// An active session of the local session manager
+type LocalSessionInfo struct {
+	Sid       *SubscriberID `protobuf:"bytes,1,opt,name=sid,proto3" json:"sid,omitempty"`
+	SessionId string        `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Apn       string        `protobuf:"bytes,3,opt,name=apn,proto3" json:"apn,omitempty"`
+	Msisdn    string        `protobuf:"bytes,4,opt,name=msisdn,proto3" json:"msisdn,omitempty"`
+	UeIpv4    string        `protobuf:"bytes,5,opt,name=ue_ipv4,json=ueIpv4,proto3" json:"ue_ipv4,omitempty"`
+	// the WLAN session's UE MAC address (e.g. 01-23-45-67-89-AB) & RADIUS session ID
+	MacAddr              string   `protobuf:"bytes,6,opt,name=mac_addr,json=macAddr,proto3" json:"mac_addr,omitempty"`
+	RadiusSessionId      string   `protobuf:"bytes,7,opt,name=radius_session_id,json=radiusSessionId,proto3" json:"radius_session_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LocalSessionInfo) Reset()         { *m = LocalSessionInfo{} }
+func (m *LocalSessionInfo) String() string { return proto.CompactTextString(m) }
+func (*LocalSessionInfo) ProtoMessage()    {}
+func (*LocalSessionInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_session_manager_b847eb08e3baf860, []int{34}
+}
+func (m *LocalSessionInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LocalSessionInfo.Unmarshal(m, b)
+}
+func (m *LocalSessionInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LocalSessionInfo.Marshal(b, m, deterministic)
+}
+func (dst *LocalSessionInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LocalSessionInfo.Merge(dst, src)
+}
+func (m *LocalSessionInfo) XXX_Size() int {
+	return xxx_messageInfo_LocalSessionInfo.Size(m)
+}
+func (m *LocalSessionInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_LocalSessionInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LocalSessionInfo proto.InternalMessageInfo
+
+func (m *LocalSessionInfo) GetSid() *SubscriberID {
+	if m != nil {
+		return m.Sid
+	}
+	return nil
+}
+
+func (m *LocalSessionInfo) GetSessionId() string {
+	if m != nil {
+		return m.SessionId
+	}
+	return ""
+}
+
+func (m *LocalSessionInfo) GetApn() string {
+	if m != nil {
+		return m.Apn
+	}
+	return ""
+}
+
+func (m *LocalSessionInfo) GetMsisdn() string {
+	if m != nil {
+		return m.Msisdn
+	}
+	return ""
+}
+
+func (m *LocalSessionInfo) GetUeIpv4() string {
+	if m != nil {
+		return m.UeIpv4
+	}
+	return ""
+}
+
+func (m *LocalSessionInfo) GetMacAddr() string {
+	if m != nil {
+		return m.MacAddr
+	}
+	return ""
+}
+
+func (m *LocalSessionInfo) GetRadiusSessionId() string {
+	if m != nil {
+		return m.RadiusSessionId
+	}
+	return ""
+}
+
+type LocalListSessionsResponse struct {
+	Sessions             []*LocalSessionInfo `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *LocalListSessionsResponse) Reset()         { *m = LocalListSessionsResponse{} }
+func (m *LocalListSessionsResponse) String() string { return proto.CompactTextString(m) }
+func (*LocalListSessionsResponse) ProtoMessage()    {}
+func (*LocalListSessionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_session_manager_b847eb08e3baf860, []int{35}
+}
+func (m *LocalListSessionsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LocalListSessionsResponse.Unmarshal(m, b)
+}
+func (m *LocalListSessionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LocalListSessionsResponse.Marshal(b, m, deterministic)
+}
+func (dst *LocalListSessionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LocalListSessionsResponse.Merge(dst, src)
+}
+func (m *LocalListSessionsResponse) XXX_Size() int {
+	return xxx_messageInfo_LocalListSessionsResponse.Size(m)
+}
+func (m *LocalListSessionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LocalListSessionsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LocalListSessionsResponse proto.InternalMessageInfo
+
+func (m *LocalListSessionsResponse) GetSessions() []*LocalSessionInfo {
+	if m != nil {
+		return m.Sessions
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*RuleRecord)(nil), "magma.lte.RuleRecord")
 	proto.RegisterType((*RuleRecordTable)(nil), "magma.lte.RuleRecordTable")
@@ -2762,6 +3285,7 @@ func init() {
 	proto.RegisterEnum("magma.lte.QCI", QCI_name, QCI_value)
 	proto.RegisterEnum("magma.lte.ReAuthResult", ReAuthResult_name, ReAuthResult_value)
 	proto.RegisterEnum("magma.lte.MonitoringLevel", MonitoringLevel_name, MonitoringLevel_value)
+	proto.RegisterEnum("magma.lte.LocalCreateSessionResponse_CreditControlMode", LocalCreateSessionResponse_CreditControlMode_name, LocalCreateSessionResponse_CreditControlMode_value)
 	proto.RegisterEnum("magma.lte.ChargingReAuthRequest_Type", ChargingReAuthRequest_Type_name, ChargingReAuthRequest_Type_value)
 	proto.RegisterEnum("magma.lte.ChargingReAuthAnswer_Result", ChargingReAuthAnswer_Result_name, ChargingReAuthAnswer_Result_value)
 	proto.RegisterEnum("magma.lte.PolicyReAuthAnswer_FailureCode", PolicyReAuthAnswer_FailureCode_name, PolicyReAuthAnswer_FailureCode_value)
@@ -2771,6 +3295,12 @@ func init() {
 	proto.RegisterEnum("magma.lte.CreditUsage_UpdateType", CreditUsage_UpdateType_name, CreditUsage_UpdateType_value)
 	proto.RegisterEnum("magma.lte.CreditUpdateResponse_ResponseType", CreditUpdateResponse_ResponseType_name, CreditUpdateResponse_ResponseType_value)
 	proto.RegisterEnum("magma.lte.UsageMonitoringCredit_Action", UsageMonitoringCredit_Action_name, UsageMonitoringCredit_Action_value)
+	proto.RegisterType((*LocalSessionUsage)(nil), "magma.lte.LocalSessionUsage")
+	proto.RegisterType((*LocalEndSessionRequest)(nil), "magma.lte.LocalEndSessionRequest")
+	proto.RegisterType((*LocalSessionUsageResponse)(nil), "magma.lte.LocalSessionUsageResponse")
+	proto.RegisterType((*LocalListSessionsRequest)(nil), "magma.lte.LocalListSessionsRequest")
+	proto.RegisterType((*LocalSessionInfo)(nil), "magma.lte.LocalSessionInfo")
+	proto.RegisterType((*LocalListSessionsResponse)(nil), "magma.lte.LocalListSessionsResponse")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -2788,6 +3318,13 @@ type LocalSessionManagerClient interface {
 	ReportRuleStats(ctx context.Context, in *RuleRecordTable, opts ...grpc.CallOption) (*protos.Void, error)
 	CreateSession(ctx context.Context, in *LocalCreateSessionRequest, opts ...grpc.CallOption) (*LocalCreateSessionResponse, error)
 	EndSession(ctx context.Context, in *SubscriberID, opts ...grpc.CallOption) (*LocalEndSessionResponse, error)
+	// Report the cumulative usage of a session metered by its access network, the usage since the previous report is
+	// added to the session's usage. The response carries the final unit action of the session's exhausted quota
+	ReportSessionUsage(ctx context.Context, in *LocalSessionUsage, opts ...grpc.CallOption) (*LocalSessionUsageResponse, error)
+	// End the session like EndSession, with the final usage metered by its access network
+	EndSessionWithUsage(ctx context.Context, in *LocalEndSessionRequest, opts ...grpc.CallOption) (*LocalEndSessionResponse, error)
+	// List the active sessions, terminating sessions are not listed
+	ListSessions(ctx context.Context, in *LocalListSessionsRequest, opts ...grpc.CallOption) (*LocalListSessionsResponse, error)
 }
 
 type localSessionManagerClient struct {
@@ -2825,11 +3362,45 @@ func (c *localSessionManagerClient) EndSession(ctx context.Context, in *Subscrib
 	return out, nil
 }
 
+func (c *localSessionManagerClient) ReportSessionUsage(ctx context.Context, in *LocalSessionUsage, opts ...grpc.CallOption) (*LocalSessionUsageResponse, error) {
+	out := new(LocalSessionUsageResponse)
+	err := c.cc.Invoke(ctx, "/magma.lte.LocalSessionManager/ReportSessionUsage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localSessionManagerClient) EndSessionWithUsage(ctx context.Context, in *LocalEndSessionRequest, opts ...grpc.CallOption) (*LocalEndSessionResponse, error) {
+	out := new(LocalEndSessionResponse)
+	err := c.cc.Invoke(ctx, "/magma.lte.LocalSessionManager/EndSessionWithUsage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localSessionManagerClient) ListSessions(ctx context.Context, in *LocalListSessionsRequest, opts ...grpc.CallOption) (*LocalListSessionsResponse, error) {
+	out := new(LocalListSessionsResponse)
+	err := c.cc.Invoke(ctx, "/magma.lte.LocalSessionManager/ListSessions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // LocalSessionManagerServer is the server API for LocalSessionManager service.
 type LocalSessionManagerServer interface {
 	ReportRuleStats(context.Context, *RuleRecordTable) (*protos.Void, error)
 	CreateSession(context.Context, *LocalCreateSessionRequest) (*LocalCreateSessionResponse, error)
 	EndSession(context.Context, *SubscriberID) (*LocalEndSessionResponse, error)
+	// Report the cumulative usage of a session metered by its access network, the usage since the previous report is
+	// added to the session's usage. The response carries the final unit action of the session's exhausted quota
+	ReportSessionUsage(context.Context, *LocalSessionUsage) (*LocalSessionUsageResponse, error)
+	// End the session like EndSession, with the final usage metered by its access network
+	EndSessionWithUsage(context.Context, *LocalEndSessionRequest) (*LocalEndSessionResponse, error)
+	// List the active sessions, terminating sessions are not listed
+	ListSessions(context.Context, *LocalListSessionsRequest) (*LocalListSessionsResponse, error)
 }
 
 func RegisterLocalSessionManagerServer(s *grpc.Server, srv LocalSessionManagerServer) {
@@ -2890,6 +3461,60 @@ func _LocalSessionManager_EndSession_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LocalSessionManager_ReportSessionUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LocalSessionUsage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalSessionManagerServer).ReportSessionUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.lte.LocalSessionManager/ReportSessionUsage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalSessionManagerServer).ReportSessionUsage(ctx, req.(*LocalSessionUsage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LocalSessionManager_EndSessionWithUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LocalEndSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalSessionManagerServer).EndSessionWithUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.lte.LocalSessionManager/EndSessionWithUsage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalSessionManagerServer).EndSessionWithUsage(ctx, req.(*LocalEndSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LocalSessionManager_ListSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LocalListSessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalSessionManagerServer).ListSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.lte.LocalSessionManager/ListSessions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalSessionManagerServer).ListSessions(ctx, req.(*LocalListSessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _LocalSessionManager_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "magma.lte.LocalSessionManager",
 	HandlerType: (*LocalSessionManagerServer)(nil),
@@ -2906,6 +3531,18 @@ var _LocalSessionManager_serviceDesc = grpc.ServiceDesc{
 			MethodName: "EndSession",
 			Handler:    _LocalSessionManager_EndSession_Handler,
 		},
+		{
+			MethodName: "ReportSessionUsage",
+			Handler:    _LocalSessionManager_ReportSessionUsage_Handler,
+		},
+		{
+			MethodName: "EndSessionWithUsage",
+			Handler:    _LocalSessionManager_EndSessionWithUsage_Handler,
+		},
+		{
+			MethodName: "ListSessions",
+			Handler:    _LocalSessionManager_ListSessions_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "lte/protos/session_manager.proto",
@@ -3151,252 +3788,281 @@ func init() {
 }
 
 var fileDescriptor_session_manager_b847eb08e3baf860 = []byte{
-	// 3952 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x3a, 0xcb, 0x72, 0x23, 0xc9,
-	0x56, 0x96, 0x64, 0x49, 0xf6, 0xd1, 0xc3, 0xe5, 0xb4, 0xdd, 0x96, 0xdd, 0x2f, 0x77, 0xf5, 0xed,
-	0x1e, 0xdf, 0x9e, 0x19, 0x7b, 0xc6, 0xd3, 0x4f, 0x06, 0x6e, 0x53, 0x2e, 0xa5, 0xec, 0xa2, 0xa5,
-	0x2a, 0x39, 0xab, 0xe4, 0xee, 0x9e, 0x08, 0x48, 0xca, 0x52, 0xb5, 0x47, 0x71, 0xf5, 0xea, 0x2a,
-	0xc9, 0xd3, 0xfe, 0x01, 0x02, 0x76, 0x2c, 0x60, 0x47, 0xb0, 0x21, 0x58, 0x11, 0xac, 0x58, 0xf0,
-	0x5a, 0x10, 0xf7, 0x0f, 0x58, 0xb1, 0xe0, 0x0b, 0x88, 0x80, 0x05, 0x0b, 0x82, 0x35, 0x91, 0x8f,
-	0x92, 0xaa, 0x2c, 0xb9, 0x35, 0x3d, 0x70, 0x23, 0x58, 0x55, 0xe6, 0xc9, 0x93, 0x27, 0x33, 0x4f,
-	0x9e, 0x77, 0x16, 0xec, 0x74, 0x86, 0xde, 0xfe, 0xc0, 0xef, 0x0f, 0xfb, 0xc1, 0x7e, 0xe0, 0x05,
-	0x41, 0xbb, 0xdf, 0xa3, 0x5d, 0xb7, 0xe7, 0x9e, 0x7b, 0xfe, 0x1e, 0x07, 0xa3, 0xe5, 0xae, 0x7b,
-	0xde, 0x75, 0xf7, 0x3a, 0x43, 0x6f, 0x7b, 0xab, 0xef, 0x37, 0x9f, 0xfb, 0x21, 0x7a, 0xb3, 0xdf,
-	0xed, 0xf6, 0x7b, 0x02, 0x6b, 0x7b, 0x2b, 0x42, 0x67, 0xd0, 0xef, 0xb4, 0x9b, 0x97, 0xad, 0x33,
-	0x39, 0x74, 0x3b, 0xba, 0xc4, 0xe8, 0x2c, 0x68, 0xfa, 0xed, 0x33, 0xcf, 0x1f, 0x0f, 0xdf, 0x3d,
-	0xef, 0xf7, 0xcf, 0x3b, 0x12, 0xe3, 0x6c, 0xf4, 0x6e, 0x7f, 0xd8, 0xee, 0x7a, 0xc1, 0xd0, 0xed,
-	0x0e, 0x04, 0x82, 0xda, 0x05, 0x20, 0xa3, 0x8e, 0x47, 0xbc, 0x66, 0xdf, 0x6f, 0x21, 0x05, 0x52,
-	0x41, 0xbb, 0x55, 0x4a, 0xec, 0x24, 0x76, 0x97, 0x09, 0x6b, 0xa2, 0x4d, 0xc8, 0xfa, 0xa3, 0x8e,
-	0x47, 0xdb, 0xad, 0x52, 0x92, 0x43, 0x33, 0xac, 0x6b, 0xb4, 0xd0, 0x16, 0x2c, 0x9d, 0x5d, 0x0e,
-	0xbd, 0x80, 0x0e, 0x3f, 0x94, 0x52, 0x3b, 0x89, 0xdd, 0x45, 0x92, 0xe5, 0x7d, 0xe7, 0xc3, 0x64,
-	0xc8, 0xff, 0x50, 0x5a, 0x8c, 0x0c, 0x91, 0x0f, 0xea, 0x1b, 0x58, 0x99, 0x2c, 0xe7, 0xb8, 0x67,
-	0x1d, 0x0f, 0xed, 0x43, 0xd6, 0xe7, 0xdd, 0xa0, 0x94, 0xd8, 0x49, 0xed, 0xe6, 0x0e, 0x36, 0xf6,
-	0xc6, 0x4c, 0xd9, 0x9b, 0x20, 0x93, 0x10, 0x0b, 0xad, 0x43, 0xda, 0x1b, 0xf4, 0x9b, 0xdf, 0xf3,
-	0x0d, 0x2d, 0x12, 0xd1, 0x51, 0xff, 0x2b, 0x05, 0x5b, 0xd5, 0x7e, 0xd3, 0xed, 0xe8, 0xbe, 0xe7,
-	0x0e, 0x3d, 0x5b, 0xb0, 0x9b, 0x78, 0xef, 0x47, 0x5e, 0x30, 0x44, 0x3f, 0x9f, 0x1c, 0x2c, 0x77,
-	0xb0, 0x19, 0x59, 0xc0, 0x1e, 0xf3, 0xcc, 0x28, 0x8f, 0x4f, 0x3c, 0xf2, 0x68, 0x7b, 0x70, 0xf1,
-	0x38, 0x3c, 0xf1, 0xc8, 0x33, 0x06, 0x17, 0x8f, 0xd1, 0x4d, 0x58, 0x0e, 0x06, 0xe7, 0x3f, 0x88,
-	0xa1, 0x14, 0x1f, 0x5a, 0x62, 0x00, 0x3e, 0xa8, 0x40, 0xca, 0x1d, 0xf4, 0xf8, 0x71, 0x97, 0x09,
-	0x6b, 0x22, 0x04, 0x8b, 0xed, 0xae, 0xd7, 0x2e, 0x65, 0x38, 0x88, 0xb7, 0x19, 0xed, 0x41, 0xa7,
-	0xdb, 0x63, 0xdc, 0xcc, 0x0a, 0xda, 0xac, 0x6b, 0xb4, 0xd0, 0x0e, 0xe4, 0xdb, 0xdd, 0xa0, 0x4d,
-	0xc3, 0xd1, 0x25, 0x3e, 0x0a, 0x0c, 0x56, 0x17, 0x18, 0xf7, 0xa1, 0x30, 0x0a, 0x3c, 0x9f, 0x76,
-	0xfa, 0x4d, 0x77, 0xd8, 0xee, 0xf7, 0x4a, 0xcb, 0x3b, 0x89, 0xdd, 0x3c, 0xc9, 0x33, 0x60, 0x55,
-	0xc2, 0xd0, 0xb7, 0xb0, 0xf4, 0xbe, 0x1f, 0xd0, 0x76, 0xef, 0x5d, 0xbf, 0x04, 0xfc, 0xac, 0x3b,
-	0x91, 0xb3, 0x9e, 0xf4, 0x03, 0xa3, 0xf7, 0xae, 0xef, 0x77, 0x39, 0xb2, 0x64, 0x0d, 0xc9, 0xbe,
-	0x17, 0x60, 0x74, 0x03, 0x32, 0xdd, 0xa0, 0x1d, 0xb4, 0x7a, 0xa5, 0x1c, 0x27, 0x2d, 0x7b, 0xe8,
-	0x4b, 0x58, 0xf2, 0xdd, 0x21, 0x1d, 0x5e, 0x0e, 0xbc, 0x52, 0x7e, 0x27, 0xb1, 0x5b, 0x3c, 0x40,
-	0xd1, 0x1b, 0xd2, 0x1c, 0xe7, 0x72, 0xe0, 0x91, 0xac, 0xef, 0x0e, 0x59, 0x83, 0x6d, 0xf4, 0x7b,
-	0xd7, 0x6f, 0xfd, 0xe0, 0xfa, 0x1e, 0x75, 0x5b, 0x2d, 0xbf, 0x54, 0x10, 0x1b, 0x0d, 0x81, 0x5a,
-	0xab, 0xe5, 0xa3, 0x47, 0xb0, 0xea, 0xbb, 0xad, 0xf6, 0x28, 0xa0, 0xa1, 0x5e, 0xb4, 0x5b, 0xa5,
-	0x22, 0x3f, 0xf4, 0x8a, 0x18, 0x90, 0x17, 0x68, 0xb4, 0x18, 0xdf, 0xcf, 0x3c, 0xd7, 0xf7, 0x7c,
-	0x86, 0xb3, 0xb2, 0x93, 0xd8, 0x2d, 0x90, 0x25, 0x01, 0x30, 0x5a, 0xea, 0x2d, 0xd8, 0x9e, 0x75,
-	0xeb, 0xc1, 0xa0, 0xdf, 0x0b, 0x3c, 0x75, 0x0b, 0x36, 0xf9, 0x28, 0xee, 0xb5, 0xae, 0x0e, 0xfd,
-	0x4b, 0x02, 0x36, 0xf4, 0xef, 0x5d, 0xff, 0xbc, 0xdd, 0x3b, 0x27, 0x9e, 0x36, 0x1a, 0x7e, 0x1f,
-	0xca, 0xca, 0x6d, 0x80, 0xc8, 0xa6, 0x84, 0x2e, 0x2c, 0x07, 0xe3, 0xed, 0xdc, 0x83, 0x7c, 0x53,
-	0xce, 0xa3, 0xbf, 0xf4, 0x2e, 0xb9, 0x90, 0x14, 0x48, 0x2e, 0x84, 0xbd, 0xf2, 0x2e, 0x43, 0x35,
-	0x4a, 0x4d, 0xd4, 0xe8, 0x05, 0x2c, 0x72, 0xfe, 0x2d, 0x72, 0xfe, 0x3d, 0x88, 0xf0, 0x6f, 0xe6,
-	0x1e, 0xf6, 0x38, 0x4b, 0xf9, 0x14, 0x75, 0x0f, 0x16, 0x39, 0x5f, 0x11, 0x14, 0x6d, 0xc3, 0x3c,
-	0xaa, 0x62, 0x6a, 0x63, 0x72, 0x6a, 0xe8, 0x58, 0x59, 0x60, 0x30, 0x6c, 0x3a, 0x06, 0x61, 0x30,
-	0xdb, 0x36, 0x2c, 0x53, 0x49, 0xa8, 0x7f, 0x9b, 0x80, 0xf5, 0x38, 0x51, 0xad, 0x17, 0xfc, 0xe0,
-	0xf9, 0xe8, 0x17, 0x90, 0xf1, 0xbd, 0x60, 0xd4, 0x19, 0xf2, 0x33, 0x15, 0x0f, 0x1e, 0x5e, 0xbb,
-	0x0b, 0x31, 0x61, 0x8f, 0x70, 0x6c, 0x22, 0x67, 0xa9, 0x14, 0x32, 0x02, 0x82, 0xd6, 0x41, 0x69,
-	0xd4, 0xcb, 0x9a, 0x83, 0xa9, 0x61, 0x1a, 0x8e, 0xa1, 0x39, 0xb8, 0xac, 0x2c, 0xa0, 0x0d, 0x58,
-	0x95, 0x50, 0xd3, 0x72, 0xa8, 0x89, 0x71, 0x19, 0x97, 0x95, 0x04, 0x03, 0xcb, 0xcd, 0x71, 0x78,
-	0xc5, 0x6a, 0x98, 0x65, 0x25, 0x89, 0x56, 0xa1, 0x60, 0x39, 0xc7, 0x98, 0xd0, 0x8a, 0x66, 0x54,
-	0x1b, 0x04, 0x2b, 0x29, 0xf5, 0xaf, 0x16, 0x61, 0xad, 0xce, 0xcd, 0xdb, 0x27, 0x5d, 0x08, 0x57,
-	0xb4, 0xa0, 0x2d, 0xb5, 0x95, 0xb7, 0xd1, 0x43, 0x58, 0x61, 0x76, 0x2a, 0xa0, 0xc3, 0x3e, 0xf5,
-	0xbd, 0x6e, 0xff, 0xc2, 0x2b, 0xa5, 0x76, 0x52, 0xbb, 0xcb, 0xa4, 0xc0, 0xc1, 0x4e, 0x9f, 0x70,
-	0x20, 0xaa, 0x80, 0x32, 0xc6, 0x6b, 0xf7, 0x82, 0xa1, 0xdb, 0xe9, 0x94, 0x32, 0xdc, 0x0a, 0xdd,
-	0x8a, 0x1a, 0x89, 0xa1, 0x3b, 0x6c, 0x37, 0x99, 0x2d, 0x32, 0x04, 0x0e, 0x29, 0x4a, 0x32, 0xb2,
-	0x8f, 0x4e, 0xa1, 0xd4, 0xba, 0xec, 0xb9, 0xdd, 0x76, 0x93, 0x4e, 0xd1, 0xcb, 0x72, 0x7a, 0xb7,
-	0x23, 0xf4, 0xca, 0x02, 0x35, 0x4a, 0x70, 0xa3, 0x35, 0x81, 0x45, 0xe8, 0xfe, 0x02, 0x8a, 0xde,
-	0x85, 0xd7, 0x1b, 0xd2, 0xa1, 0xdf, 0x3e, 0x3f, 0xf7, 0xfc, 0xa0, 0xb4, 0xb4, 0x93, 0xda, 0x2d,
-	0xc6, 0x4c, 0x18, 0x66, 0x08, 0x8e, 0x18, 0x27, 0x05, 0x2f, 0xd2, 0x0b, 0xd0, 0x11, 0xac, 0xfa,
-	0xde, 0x85, 0xdb, 0x69, 0xb7, 0xb8, 0xce, 0x53, 0x66, 0xfe, 0xb9, 0xe5, 0xc8, 0x1d, 0x6c, 0xef,
-	0x09, 0xdf, 0xb0, 0x17, 0xfa, 0x86, 0x3d, 0x27, 0xf4, 0x0d, 0x44, 0x89, 0x4e, 0x62, 0x60, 0xf4,
-	0x1d, 0x94, 0x46, 0x81, 0x7b, 0xee, 0xd1, 0x6e, 0xbf, 0xd7, 0x1e, 0xf6, 0x7d, 0x26, 0xfd, 0x4d,
-	0xdf, 0x6b, 0xb5, 0x87, 0x41, 0x09, 0xf8, 0x01, 0xa3, 0x96, 0xa6, 0xc1, 0x50, 0x6b, 0x63, 0x4c,
-	0x9d, 0x23, 0x92, 0x1b, 0xa3, 0x59, 0xe0, 0x00, 0x3d, 0x8e, 0x58, 0xad, 0x1c, 0xdf, 0xdb, 0x56,
-	0xcc, 0x6a, 0xd9, 0x51, 0xab, 0x15, 0x9a, 0x2b, 0xd5, 0x82, 0x62, 0x7c, 0x28, 0x6e, 0x28, 0x84,
-	0x98, 0x8c, 0x0d, 0x05, 0xda, 0x81, 0xd4, 0xfb, 0xa6, 0x10, 0x92, 0xe2, 0x41, 0x31, 0x4a, 0x5f,
-	0x37, 0x08, 0x1b, 0x52, 0xff, 0x74, 0x09, 0x50, 0x54, 0xfc, 0xa4, 0xda, 0xcc, 0x91, 0xbe, 0xfd,
-	0xb1, 0x56, 0x09, 0xd2, 0xd1, 0x9b, 0x09, 0xc5, 0x38, 0xaa, 0x46, 0xe8, 0x04, 0xf2, 0xef, 0xdc,
-	0x76, 0xc7, 0x6b, 0x09, 0x49, 0xe1, 0x72, 0x99, 0x3b, 0xd8, 0x8b, 0x4c, 0x9b, 0xde, 0xc4, 0x5e,
-	0x85, 0xcf, 0xe0, 0xc2, 0x81, 0x7b, 0x43, 0xff, 0x92, 0xe4, 0xde, 0x4d, 0x20, 0xdb, 0x6d, 0x50,
-	0xae, 0x22, 0x30, 0x1b, 0xc4, 0xac, 0x93, 0x74, 0xe5, 0xbf, 0xf4, 0x2e, 0xd1, 0x4b, 0x48, 0x5f,
-	0xb8, 0x9d, 0x91, 0x27, 0x37, 0xfa, 0xf3, 0xf9, 0x2b, 0x8e, 0x7c, 0x4f, 0xef, 0xb7, 0x3c, 0x22,
-	0xe6, 0xfd, 0x46, 0xf2, 0x79, 0x42, 0xfd, 0xcf, 0x34, 0xe4, 0x22, 0x43, 0x08, 0x20, 0xd3, 0x30,
-	0x1b, 0xf6, 0xd8, 0x00, 0x98, 0xaf, 0x4c, 0xeb, 0xb5, 0x49, 0x49, 0xa3, 0x8a, 0xa9, 0xa9, 0xd5,
-	0xb0, 0x92, 0x40, 0x37, 0x00, 0x11, 0xcd, 0x31, 0xcc, 0x23, 0x7a, 0x44, 0xac, 0x46, 0x9d, 0x62,
-	0x42, 0x2c, 0xa2, 0x24, 0xd1, 0x2d, 0x28, 0x49, 0x4b, 0x46, 0x8d, 0x32, 0x33, 0x63, 0x15, 0x03,
-	0x13, 0x39, 0x9a, 0x42, 0x9b, 0xb0, 0x76, 0xf4, 0x9a, 0xd6, 0x75, 0x5c, 0xa1, 0x35, 0xad, 0x5a,
-	0x69, 0x98, 0xba, 0xc3, 0xec, 0xdb, 0x22, 0x2a, 0xc1, 0x3a, 0xc1, 0xb6, 0xd5, 0x20, 0x3a, 0xb6,
-	0x69, 0xd5, 0xa8, 0x19, 0x8e, 0xc6, 0x47, 0xd2, 0x68, 0x1b, 0x6e, 0xd4, 0xb4, 0x37, 0xd4, 0x24,
-	0xf4, 0x10, 0x6b, 0x04, 0x13, 0x9b, 0x12, 0xac, 0xe9, 0xc7, 0xb8, 0xac, 0x64, 0xa2, 0x7b, 0x13,
-	0x83, 0xd4, 0x28, 0x2b, 0x59, 0x06, 0xae, 0x19, 0x36, 0xb3, 0xab, 0x11, 0xf0, 0x12, 0xdb, 0x5a,
-	0x08, 0xae, 0x54, 0xad, 0xd7, 0xd4, 0x30, 0x2b, 0x16, 0xa9, 0x89, 0x75, 0x96, 0xd1, 0x5d, 0xb8,
-	0x19, 0xee, 0x80, 0x6a, 0xd5, 0xaa, 0xa5, 0xf3, 0x81, 0xb1, 0x21, 0x03, 0x86, 0xd0, 0x30, 0xed,
-	0x86, 0xae, 0x63, 0xdb, 0xae, 0x34, 0xaa, 0xf4, 0xc4, 0xb2, 0xe9, 0xa9, 0x56, 0x35, 0xca, 0x82,
-	0x42, 0x0e, 0xdd, 0x81, 0x6d, 0xc3, 0xd4, 0x2d, 0x42, 0xb0, 0xee, 0x4c, 0xaf, 0x90, 0x67, 0xdb,
-	0xaa, 0xdb, 0xd4, 0xb1, 0xa8, 0x6e, 0xd3, 0x63, 0xcd, 0x2c, 0x5b, 0xa7, 0x98, 0x28, 0x05, 0xf4,
-	0x33, 0xd8, 0x71, 0xca, 0x15, 0xaa, 0xd5, 0xeb, 0x55, 0x43, 0x2e, 0x3a, 0xc5, 0xb9, 0x22, 0x5a,
-	0x83, 0x15, 0xd3, 0x0a, 0x8f, 0x23, 0xcc, 0xed, 0x0a, 0x63, 0x67, 0xc5, 0xa8, 0x3a, 0x98, 0x50,
-	0x82, 0x6d, 0x87, 0x18, 0x9c, 0x9b, 0xb6, 0xa2, 0x20, 0x05, 0xf2, 0x9a, 0x49, 0x8f, 0x5e, 0xf3,
-	0xed, 0xe3, 0xb2, 0xb2, 0x8a, 0xee, 0xc3, 0xdd, 0xf0, 0xf0, 0x04, 0x97, 0x0d, 0xbe, 0x47, 0x76,
-	0x51, 0x98, 0x50, 0xad, 0x5c, 0x26, 0xd8, 0xb6, 0x15, 0xc4, 0x4e, 0xa0, 0xd7, 0x28, 0x36, 0xcb,
-	0xb4, 0x61, 0x63, 0x12, 0xba, 0x24, 0x5a, 0xc6, 0xa6, 0x81, 0xcb, 0xca, 0x1a, 0xdb, 0xaa, 0x5e,
-	0xa3, 0x3a, 0x23, 0xe0, 0x50, 0xdd, 0x32, 0x1d, 0x62, 0x55, 0xb9, 0xfd, 0x97, 0x9b, 0x3f, 0xac,
-	0x62, 0x65, 0x1d, 0xdd, 0x86, 0x2d, 0xbd, 0x46, 0xb5, 0x86, 0x73, 0x6c, 0x11, 0xe3, 0x3b, 0x71,
-	0x22, 0x82, 0x7f, 0x07, 0xeb, 0xcc, 0xa3, 0x6c, 0xb0, 0x93, 0xe8, 0x35, 0xb1, 0x80, 0xbc, 0x3c,
-	0xe5, 0x06, 0x73, 0x3e, 0x7a, 0x8d, 0x4a, 0x89, 0x92, 0x9b, 0xde, 0x64, 0x77, 0x4f, 0xac, 0x06,
-	0x87, 0x71, 0xd9, 0x13, 0x54, 0x18, 0x37, 0x4b, 0xe8, 0x21, 0xa8, 0x63, 0xb9, 0x94, 0x38, 0x1a,
-	0xbf, 0x9b, 0x18, 0xd7, 0xb7, 0x18, 0xd7, 0x4d, 0x8b, 0x9a, 0x87, 0x46, 0xc5, 0xaa, 0x51, 0xbb,
-	0x51, 0xaf, 0x5b, 0xc4, 0x51, 0xb6, 0xd5, 0x97, 0x00, 0xc2, 0x52, 0x35, 0x7a, 0xed, 0x21, 0x0b,
-	0x6e, 0xdb, 0x01, 0xe5, 0xd6, 0x91, 0x2b, 0xd7, 0x12, 0xc9, 0xb6, 0x83, 0x53, 0xd6, 0x65, 0x01,
-	0xd4, 0x45, 0xbf, 0x33, 0xea, 0x7a, 0x32, 0x32, 0x95, 0x3d, 0xf5, 0x8f, 0x12, 0x90, 0x3f, 0xf2,
-	0xdd, 0xde, 0xd0, 0x6b, 0x31, 0x12, 0x01, 0xfa, 0x1c, 0xd2, 0xc3, 0xfe, 0xd0, 0xed, 0xc8, 0x78,
-	0x34, 0x1a, 0xf0, 0x4e, 0x56, 0x22, 0x02, 0x07, 0x3d, 0x80, 0xe4, 0xf0, 0x03, 0xa7, 0x78, 0x2d,
-	0x66, 0x72, 0xf8, 0x81, 0xa1, 0xf9, 0x22, 0x12, 0xbf, 0x1e, 0xcd, 0xff, 0xa0, 0xfe, 0x47, 0x02,
-	0x8a, 0xc4, 0x6b, 0xb5, 0x7d, 0xaf, 0x39, 0xb4, 0x3d, 0xff, 0xc2, 0xf3, 0x91, 0x0b, 0x1b, 0xbe,
-	0x84, 0xf0, 0x80, 0xcd, 0x0b, 0x02, 0x11, 0xec, 0x89, 0x30, 0xe1, 0xcb, 0x98, 0x41, 0x8b, 0xce,
-	0x1c, 0x77, 0x35, 0x31, 0x8b, 0x07, 0x2d, 0x6b, 0xfe, 0x34, 0x10, 0x3d, 0x85, 0xcd, 0xf1, 0x12,
-	0x01, 0x9f, 0x1b, 0xae, 0x24, 0xbd, 0xf6, 0x78, 0x07, 0x82, 0xb2, 0x9c, 0xab, 0xbe, 0x84, 0xb5,
-	0x19, 0x6b, 0xa0, 0x25, 0x58, 0x34, 0xea, 0xa7, 0x8f, 0x95, 0x05, 0xd9, 0x7a, 0xaa, 0x24, 0x50,
-	0x16, 0x52, 0x0d, 0x52, 0x55, 0x92, 0x28, 0x07, 0x59, 0xdb, 0xa8, 0xd3, 0x06, 0x31, 0x94, 0x94,
-	0xfa, 0xf7, 0x29, 0x28, 0x86, 0xb1, 0x8d, 0xe0, 0x04, 0x7a, 0x2a, 0x43, 0x31, 0x61, 0x05, 0xd5,
-	0x19, 0x41, 0x90, 0x40, 0xdc, 0x63, 0x3c, 0x9b, 0xc4, 0x61, 0x2c, 0xae, 0xe5, 0xb7, 0xde, 0x1e,
-	0x5e, 0x0a, 0x37, 0x9a, 0xe2, 0x81, 0x5f, 0x3e, 0x04, 0x72, 0x37, 0x29, 0xa4, 0xe3, 0x5d, 0xbb,
-	0xe7, 0x76, 0x78, 0xac, 0xc7, 0xa5, 0xa3, 0xc2, 0xba, 0xe8, 0x18, 0xf2, 0x1c, 0x4e, 0xdd, 0x26,
-	0x8f, 0xdf, 0xd3, 0xd7, 0x86, 0x82, 0x72, 0x7d, 0x3e, 0x4d, 0xe3, 0xc8, 0x24, 0xf7, 0x6e, 0xd2,
-	0x41, 0xbf, 0x09, 0x85, 0x73, 0x21, 0x4e, 0x74, 0xc4, 0xe4, 0x89, 0xa7, 0x18, 0xf1, 0xb4, 0x26,
-	0x2a, 0x6e, 0x24, 0x7f, 0x1e, 0x15, 0xbe, 0x43, 0x58, 0xb9, 0x72, 0x17, 0x3c, 0x17, 0x89, 0x3b,
-	0xdd, 0xf8, 0x45, 0x93, 0x62, 0xfc, 0x7a, 0x54, 0x15, 0x96, 0x42, 0xee, 0xa0, 0x65, 0x48, 0x1f,
-	0xbe, 0x75, 0xb0, 0xad, 0x2c, 0x70, 0xd6, 0x63, 0xdd, 0x32, 0xcb, 0xb6, 0x92, 0x50, 0x5f, 0x42,
-	0x2e, 0x72, 0x02, 0x54, 0x80, 0x65, 0x07, 0x93, 0x9a, 0x61, 0x6a, 0x0e, 0x8b, 0x5c, 0xf3, 0xb0,
-	0x14, 0x1a, 0x17, 0x25, 0xc1, 0x14, 0x3d, 0x34, 0x4b, 0x52, 0x35, 0x95, 0xa4, 0xfa, 0x87, 0x29,
-	0xc8, 0x49, 0xe9, 0x65, 0x71, 0x43, 0x2c, 0xe3, 0x4c, 0x5c, 0x9f, 0x71, 0x26, 0x63, 0x19, 0xe7,
-	0x54, 0xb8, 0xbe, 0x38, 0x1d, 0xae, 0x3f, 0x91, 0x12, 0x21, 0x6e, 0xe4, 0xde, 0xb4, 0xf2, 0xb0,
-	0xe5, 0xf7, 0x1a, 0x83, 0x96, 0x3b, 0xf4, 0x22, 0x02, 0xf1, 0x00, 0x8a, 0x91, 0x60, 0x88, 0xd1,
-	0x16, 0xa9, 0x5e, 0x61, 0x02, 0x7d, 0xe5, 0x5d, 0xaa, 0xbf, 0x4a, 0x00, 0x4c, 0xe6, 0x72, 0x3e,
-	0x1c, 0x13, 0x6c, 0x1f, 0x5b, 0x55, 0xe6, 0x33, 0xb3, 0x90, 0x3a, 0x39, 0x66, 0x2c, 0x28, 0x02,
-	0x8c, 0xf9, 0xc3, 0xe2, 0xe3, 0x35, 0x58, 0x39, 0x69, 0x58, 0x8e, 0x46, 0xf1, 0x9b, 0x63, 0xad,
-	0x61, 0x33, 0x60, 0x8a, 0x59, 0x39, 0xee, 0x47, 0x0c, 0xe7, 0x2d, 0x75, 0x8c, 0x1a, 0x33, 0xfa,
-	0x6f, 0xea, 0x06, 0xc1, 0x65, 0x65, 0x91, 0xd9, 0x45, 0x11, 0x50, 0x8b, 0x69, 0xce, 0xdb, 0x3a,
-	0x56, 0xd2, 0xe8, 0x26, 0x6c, 0x4a, 0x53, 0xc9, 0xee, 0xc5, 0xe0, 0x16, 0x56, 0x3f, 0xd6, 0xcc,
-	0x23, 0xac, 0x64, 0x04, 0xdb, 0x99, 0xf5, 0xa5, 0x04, 0x9f, 0x34, 0x38, 0x9d, 0x2c, 0xcb, 0x29,
-	0xea, 0x96, 0x55, 0x8d, 0xac, 0xbb, 0xa4, 0xfe, 0x2a, 0x05, 0xab, 0x11, 0x5e, 0x88, 0xe3, 0xa0,
-	0x2f, 0x20, 0xcd, 0x23, 0x3a, 0x69, 0xc6, 0x6e, 0xcc, 0x66, 0x1c, 0x11, 0x48, 0x57, 0xe2, 0xa8,
-	0xe4, 0xd5, 0x38, 0xea, 0x01, 0x14, 0x7d, 0x11, 0xef, 0xd3, 0xde, 0xa8, 0x7b, 0xe6, 0xf9, 0x52,
-	0xbf, 0x0a, 0x12, 0x6a, 0x72, 0x60, 0x98, 0x5a, 0x2d, 0x4e, 0x52, 0xab, 0x49, 0xda, 0x9a, 0x8e,
-	0xa5, 0xad, 0x91, 0x3c, 0x3e, 0x73, 0x7d, 0x1e, 0x9f, 0x9d, 0x9d, 0xc7, 0x2f, 0x4d, 0xe7, 0xf1,
-	0xcb, 0xb3, 0xf3, 0x78, 0xf8, 0x68, 0x1e, 0x9f, 0x9b, 0x9f, 0xc7, 0xe7, 0x67, 0xe4, 0xf1, 0xd1,
-	0x94, 0xbb, 0xf0, 0x13, 0x52, 0xee, 0xe2, 0x74, 0xca, 0xad, 0xfe, 0x37, 0xcb, 0x0b, 0xc5, 0xb5,
-	0xf0, 0xeb, 0x0b, 0x33, 0x61, 0x54, 0x82, 0x6c, 0x30, 0x6a, 0x36, 0x99, 0x31, 0x96, 0x0e, 0x4d,
-	0x76, 0x43, 0x66, 0x27, 0x27, 0xcc, 0xbe, 0xaa, 0x4d, 0xa9, 0x69, 0x6d, 0xfa, 0x1a, 0x32, 0x22,
-	0x31, 0xe0, 0x97, 0x14, 0x37, 0x2b, 0x71, 0x0b, 0x47, 0x24, 0x22, 0xfa, 0xed, 0x98, 0x02, 0x7e,
-	0x31, 0x2d, 0x47, 0xb1, 0x0d, 0xef, 0x85, 0x8d, 0x48, 0x92, 0xbc, 0x0d, 0xf9, 0x28, 0x94, 0x87,
-	0xa5, 0x3c, 0x17, 0x55, 0x16, 0xd4, 0xbf, 0x48, 0x00, 0x8a, 0x26, 0x24, 0x52, 0x7a, 0xa7, 0xd5,
-	0x37, 0x31, 0x43, 0x7d, 0xd1, 0x57, 0x90, 0xee, 0x78, 0x17, 0x5e, 0x47, 0xfa, 0x8b, 0xed, 0xc8,
-	0xe6, 0x26, 0x99, 0x4c, 0x95, 0x61, 0x10, 0x81, 0xf8, 0x13, 0x2b, 0x63, 0x7f, 0x92, 0x84, 0x8d,
-	0x99, 0x69, 0x13, 0x7a, 0x09, 0x19, 0xe9, 0x32, 0x84, 0x43, 0xfe, 0x6c, 0x5e, 0xa2, 0xb5, 0x27,
-	0x9d, 0x86, 0x9c, 0x36, 0xe3, 0xa4, 0xc9, 0x8f, 0x9e, 0x34, 0xf5, 0x63, 0x4f, 0x3a, 0xe5, 0x88,
-	0xd2, 0x9f, 0xe0, 0x88, 0xd4, 0xfb, 0x90, 0x91, 0xbe, 0x21, 0x0f, 0x4b, 0x2c, 0x44, 0x34, 0xcc,
-	0x06, 0x16, 0x5e, 0xa4, 0x6c, 0xd8, 0x3c, 0x42, 0x4c, 0xa8, 0xff, 0x9e, 0x80, 0x5b, 0x57, 0x0e,
-	0x19, 0x4a, 0x83, 0x28, 0x0e, 0x3c, 0x81, 0xcc, 0x88, 0x03, 0xa4, 0x15, 0xba, 0x7d, 0x0d, 0x77,
-	0xe4, 0x2c, 0x89, 0xfc, 0x6b, 0xb3, 0x46, 0x11, 0xab, 0x93, 0x8e, 0x59, 0x9d, 0x29, 0x1d, 0xcd,
-	0xcc, 0xd0, 0xd1, 0xbf, 0x4e, 0xc2, 0xed, 0x6b, 0x4e, 0x2b, 0x95, 0xf5, 0xf9, 0x58, 0xbb, 0x12,
-	0x53, 0xf5, 0xbd, 0xd9, 0x59, 0x77, 0xa8, 0x64, 0x73, 0x4e, 0x3c, 0x5d, 0xb3, 0x8a, 0xd8, 0x85,
-	0xc5, 0xb8, 0x5d, 0x98, 0xae, 0x4a, 0xa4, 0xff, 0xf7, 0x55, 0x89, 0xcc, 0xa7, 0x57, 0x25, 0xd4,
-	0x3f, 0x4e, 0xc2, 0xc6, 0xcc, 0xaa, 0x26, 0xba, 0x03, 0x39, 0x77, 0xd0, 0xa3, 0x6e, 0xf7, 0xcc,
-	0xa7, 0x2d, 0x11, 0x68, 0x17, 0xc8, 0xb2, 0x3b, 0xe8, 0x69, 0xdd, 0x33, 0xbf, 0xdc, 0x89, 0x8d,
-	0x8f, 0x3a, 0xb2, 0x88, 0x17, 0x8e, 0x37, 0x58, 0xd4, 0x5d, 0x1c, 0xf8, 0xed, 0xbe, 0xcf, 0xa2,
-	0xbd, 0x89, 0x56, 0x14, 0x48, 0x21, 0x84, 0x72, 0x45, 0x40, 0xdf, 0xc0, 0xc6, 0xc0, 0xf7, 0xbc,
-	0xee, 0x80, 0x9f, 0xa3, 0xe9, 0x0e, 0xdc, 0xb3, 0x76, 0xa7, 0x3d, 0x0c, 0xc3, 0x8c, 0xf5, 0xc9,
-	0xa0, 0x3e, 0x1e, 0x43, 0x2f, 0xa0, 0x14, 0x99, 0x74, 0x31, 0xea, 0xf4, 0x3c, 0x3f, 0x9c, 0x97,
-	0xe6, 0xf3, 0x36, 0x27, 0xe3, 0xa7, 0xd1, 0x61, 0xe6, 0x5f, 0xde, 0xf7, 0x03, 0xda, 0xec, 0xb8,
-	0x41, 0xc0, 0xae, 0x31, 0xc3, 0xd1, 0xe1, 0x7d, 0x3f, 0xd0, 0x19, 0xc8, 0x68, 0xa9, 0xff, 0x96,
-	0xe2, 0x66, 0x7e, 0xba, 0x04, 0xfe, 0x0c, 0x60, 0xf2, 0x40, 0x30, 0xaf, 0x12, 0x1e, 0x41, 0x9d,
-	0x27, 0x38, 0x11, 0x89, 0x4f, 0x5d, 0xef, 0x67, 0x17, 0x67, 0xfb, 0xd9, 0xf4, 0xb4, 0x9f, 0xcd,
-	0xce, 0xf6, 0xb3, 0x4b, 0x1f, 0xf5, 0xb3, 0xcb, 0xf3, 0xfd, 0x2c, 0xcc, 0xa9, 0x97, 0xe7, 0x7e,
-	0x7a, 0xbd, 0x3c, 0x1f, 0x0b, 0x3c, 0xd6, 0x20, 0x7d, 0xde, 0x64, 0x9b, 0x2a, 0x88, 0x93, 0x9c,
-	0x37, 0x8d, 0x56, 0xcc, 0xa3, 0x17, 0x7f, 0x82, 0x47, 0x5f, 0x99, 0x61, 0x2d, 0xfe, 0x39, 0x09,
-	0x1b, 0x33, 0xeb, 0xde, 0xe8, 0x05, 0x64, 0xc3, 0xe2, 0x9c, 0x78, 0x53, 0xb9, 0x3b, 0xc7, 0xa7,
-	0x92, 0x10, 0x3f, 0xac, 0x9c, 0xd2, 0x33, 0x37, 0xf0, 0x68, 0xcf, 0xed, 0x7a, 0x42, 0xb9, 0x65,
-	0xe5, 0xf4, 0xd0, 0x0d, 0x3c, 0x93, 0x01, 0x91, 0x05, 0xc5, 0x58, 0x41, 0x30, 0x90, 0x75, 0xd3,
-	0xdd, 0xeb, 0x0d, 0xd2, 0x95, 0x25, 0x0b, 0xd1, 0x72, 0x60, 0x80, 0x5e, 0x42, 0x3e, 0xe0, 0x75,
-	0x56, 0x59, 0x17, 0xcb, 0xfe, 0x88, 0x32, 0x6c, 0x2e, 0x18, 0x83, 0x58, 0x62, 0x53, 0x88, 0xd5,
-	0x60, 0x79, 0xa9, 0x74, 0x6e, 0xe1, 0x35, 0x1f, 0x2d, 0xbc, 0xaa, 0xff, 0x90, 0x80, 0xd5, 0xa9,
-	0x65, 0xa2, 0x8f, 0x60, 0x89, 0xd8, 0x23, 0x98, 0x0e, 0x2b, 0xcc, 0xc7, 0x5e, 0x44, 0xcc, 0x58,
-	0x72, 0xae, 0x19, 0x2b, 0x4e, 0xa6, 0xf0, 0x9c, 0xf1, 0x08, 0x56, 0x5b, 0xde, 0x55, 0x32, 0xa9,
-	0xf9, 0xd6, 0x30, 0x3a, 0x89, 0x5b, 0xc3, 0x7f, 0x4d, 0x00, 0x9a, 0x3e, 0x21, 0x7a, 0x0a, 0x39,
-	0xf1, 0x68, 0xc8, 0xd9, 0x32, 0xa3, 0xe6, 0x20, 0xab, 0x7f, 0xa3, 0x8e, 0x47, 0x60, 0x30, 0x6e,
-	0xff, 0x3f, 0x3b, 0xdc, 0x9f, 0x27, 0x60, 0x5d, 0x08, 0xd0, 0x15, 0xbb, 0xf6, 0x14, 0xb2, 0xc2,
-	0xa7, 0x87, 0xb2, 0x7e, 0x6b, 0x76, 0x1e, 0x22, 0xa5, 0x2f, 0x44, 0x46, 0xe6, 0x94, 0x00, 0x8b,
-	0x4a, 0xec, 0x67, 0xf3, 0x05, 0x58, 0x18, 0x82, 0xb8, 0xfc, 0xaa, 0x7f, 0x97, 0x80, 0x8d, 0x2b,
-	0x1b, 0x94, 0xda, 0xf8, 0x5b, 0xb0, 0xec, 0xcb, 0xf6, 0x8f, 0xd6, 0xc7, 0xc9, 0x0c, 0xf4, 0xfb,
-	0xb0, 0x19, 0xdb, 0x28, 0x9d, 0x10, 0x4b, 0x7d, 0xa2, 0xca, 0x6d, 0x44, 0xb7, 0x1c, 0x42, 0x03,
-	0xf5, 0x15, 0x94, 0xe4, 0x9e, 0x1d, 0xcf, 0xef, 0xb6, 0x7b, 0xd1, 0x80, 0x63, 0xfa, 0x49, 0xf8,
-	0xe3, 0xfe, 0x40, 0xfd, 0xb3, 0x45, 0xd8, 0x9c, 0xa6, 0x26, 0xee, 0xea, 0x53, 0x89, 0x85, 0x6e,
-	0x22, 0x35, 0x71, 0x13, 0xd3, 0x91, 0xd9, 0xe2, 0xac, 0xc8, 0xec, 0x5b, 0x28, 0x08, 0x8b, 0x46,
-	0xf9, 0x91, 0x85, 0x11, 0xbb, 0x3e, 0x47, 0xcd, 0x37, 0x27, 0x9d, 0x00, 0x95, 0xc7, 0x01, 0x73,
-	0x38, 0x3b, 0x33, 0x65, 0x4a, 0x66, 0xc4, 0x96, 0x61, 0x3c, 0x2d, 0xa9, 0x44, 0x1c, 0x63, 0x36,
-	0xe6, 0x18, 0x27, 0x8e, 0x63, 0x29, 0xe6, 0x38, 0x62, 0x0e, 0x73, 0xf9, 0x8a, 0xc3, 0x0c, 0xdd,
-	0x23, 0xcc, 0x76, 0x8f, 0xb9, 0x8f, 0xba, 0xc7, 0xfc, 0x7c, 0xf7, 0x58, 0x98, 0x93, 0x86, 0xfe,
-	0x1f, 0x39, 0xad, 0x47, 0x0f, 0x21, 0x2b, 0x27, 0xb2, 0xb0, 0xdf, 0x39, 0xaa, 0xd7, 0x69, 0x95,
-	0x57, 0x84, 0x0a, 0xb0, 0xcc, 0x7b, 0xaf, 0xab, 0x9a, 0xa9, 0x24, 0x1e, 0xfd, 0xcd, 0x32, 0xe4,
-	0xa3, 0x31, 0x24, 0x5a, 0x81, 0x9c, 0x7d, 0x64, 0x8f, 0xab, 0x17, 0x0b, 0xa8, 0x08, 0x70, 0x62,
-	0xd9, 0x61, 0x9f, 0x57, 0x50, 0x88, 0xe6, 0x84, 0xfd, 0x24, 0xaf, 0xa8, 0x54, 0xc6, 0xfd, 0x14,
-	0x23, 0x50, 0xaf, 0xd6, 0xc6, 0x04, 0x16, 0x11, 0x82, 0x62, 0xd5, 0xb2, 0x6d, 0x6a, 0x55, 0x64,
-	0xb5, 0x5c, 0x49, 0xf3, 0xc7, 0x0a, 0xac, 0x5b, 0xa7, 0x98, 0xbc, 0x8d, 0xc0, 0x33, 0x68, 0x15,
-	0x0a, 0x46, 0x9d, 0xea, 0xda, 0x78, 0x7a, 0x16, 0x3d, 0x04, 0x75, 0xb2, 0x3e, 0xc5, 0x6f, 0x74,
-	0x8c, 0xcb, 0xbc, 0xb6, 0x1c, 0x2d, 0x67, 0x2b, 0x39, 0xb1, 0x2f, 0x23, 0x9c, 0x97, 0x47, 0x25,
-	0x58, 0xe7, 0x25, 0xed, 0xf1, 0xc3, 0x81, 0x1c, 0x29, 0xc8, 0x02, 0x34, 0x3e, 0xc5, 0xa6, 0x43,
-	0x1d, 0x62, 0x1c, 0x1d, 0x61, 0x62, 0x2b, 0x45, 0xfe, 0x54, 0xda, 0x70, 0xd8, 0x76, 0x44, 0x3d,
-	0x5d, 0x59, 0xe1, 0xe5, 0x6e, 0x1c, 0x79, 0x7b, 0x98, 0x8c, 0x29, 0xe2, 0x81, 0x64, 0xf2, 0xdc,
-	0xc0, 0x0b, 0x45, 0x56, 0xc3, 0x51, 0x56, 0xd9, 0xac, 0x06, 0xa6, 0x46, 0x3d, 0xac, 0xe3, 0x87,
-	0xaf, 0x17, 0x58, 0x41, 0x68, 0x0b, 0x36, 0xe2, 0x63, 0x04, 0x57, 0xb1, 0x66, 0x63, 0x65, 0x0d,
-	0xdd, 0x83, 0xdb, 0x65, 0x5c, 0xd1, 0x1a, 0x55, 0x87, 0xe2, 0xba, 0x1d, 0xbe, 0x2c, 0x44, 0x78,
-	0xbf, 0x3e, 0x79, 0x45, 0x90, 0x90, 0x0d, 0xa4, 0xc2, 0x9d, 0xc8, 0x0b, 0xc8, 0x8c, 0xf7, 0x12,
-	0xe5, 0x06, 0x23, 0x3c, 0x1e, 0xa8, 0x59, 0x65, 0xa3, 0x12, 0xbe, 0x6a, 0x10, 0x7c, 0xd2, 0xc0,
-	0xb6, 0xa3, 0x6c, 0xf2, 0x97, 0x90, 0xa3, 0xd7, 0xd4, 0x21, 0x9a, 0x8e, 0xc3, 0x77, 0x04, 0xa5,
-	0x84, 0x36, 0x61, 0xad, 0x81, 0xf9, 0xc9, 0xe8, 0x77, 0x96, 0x89, 0xc3, 0x65, 0xb7, 0xf8, 0xa5,
-	0x4f, 0x98, 0xbd, 0xcd, 0x2e, 0x1d, 0xeb, 0x47, 0x63, 0xc0, 0x4d, 0xb6, 0xa6, 0x7e, 0xac, 0x91,
-	0x23, 0x51, 0x12, 0x23, 0x04, 0x57, 0xc5, 0x92, 0xf8, 0x8d, 0x44, 0xb9, 0xc5, 0x50, 0xb4, 0xba,
-	0x49, 0xb5, 0xda, 0x21, 0x89, 0x6f, 0x2b, 0x7c, 0xe1, 0xb9, 0xcd, 0x5f, 0x78, 0xd8, 0x1d, 0xea,
-	0xf6, 0x51, 0xf4, 0x11, 0x21, 0x5c, 0xe6, 0x0e, 0x63, 0x48, 0xc3, 0xd6, 0x8e, 0x30, 0x25, 0x98,
-	0x3f, 0x23, 0xdc, 0x43, 0xfb, 0xf0, 0xf9, 0x35, 0x5c, 0x9c, 0xb9, 0x86, 0x8a, 0xbe, 0x86, 0x2f,
-	0xc7, 0x6b, 0x1c, 0xbf, 0x3d, 0x24, 0x46, 0x99, 0xda, 0x8d, 0x43, 0x5b, 0x27, 0xc6, 0x21, 0x2e,
-	0xcf, 0x5a, 0xf5, 0x3e, 0xfa, 0x06, 0xf6, 0xaf, 0x4e, 0x69, 0x98, 0x1f, 0x9f, 0xf4, 0x33, 0xc6,
-	0xcb, 0xd8, 0xd3, 0x89, 0x1c, 0x78, 0xc0, 0x78, 0x1f, 0x7d, 0x6a, 0xb2, 0x1d, 0x8d, 0x38, 0xca,
-	0x67, 0x68, 0x1d, 0x94, 0x38, 0xd8, 0xaa, 0x2b, 0xbb, 0x0c, 0x59, 0xe7, 0x4f, 0x56, 0xf5, 0xc8,
-	0x93, 0xd5, 0x23, 0x74, 0x07, 0xb6, 0x1b, 0x98, 0x8b, 0x7a, 0x35, 0x2a, 0x5c, 0x72, 0x8d, 0xcf,
-	0xd1, 0x0e, 0xdc, 0x3a, 0xc6, 0xe6, 0xe1, 0xb5, 0x18, 0x5f, 0x30, 0x0a, 0xf2, 0xb5, 0xc6, 0xc4,
-	0xce, 0x6b, 0x8b, 0xbc, 0xe2, 0xa7, 0x08, 0xf9, 0xfa, 0x25, 0x7a, 0x00, 0xf7, 0xe4, 0x33, 0x53,
-	0x4d, 0x33, 0xb5, 0x23, 0x5c, 0x63, 0xda, 0x13, 0xfe, 0x71, 0x10, 0x72, 0x73, 0x8f, 0x29, 0x76,
-	0xc8, 0xfe, 0x88, 0xe4, 0xee, 0xa3, 0x6f, 0xe1, 0x99, 0xd4, 0x60, 0xab, 0x42, 0x1b, 0x98, 0xd6,
-	0x09, 0xb6, 0xb1, 0xa9, 0x63, 0x6a, 0x98, 0x93, 0xb6, 0x58, 0x8c, 0x2b, 0x37, 0xc1, 0x5a, 0xb8,
-	0xf6, 0x57, 0x4c, 0xba, 0x18, 0x7f, 0xf9, 0x4b, 0x11, 0x2e, 0x2b, 0x5f, 0x3f, 0xfa, 0xc7, 0x04,
-	0xa4, 0x4e, 0x74, 0x03, 0x2d, 0x43, 0xfa, 0x44, 0x37, 0xe8, 0x57, 0xca, 0x42, 0xd8, 0xfc, 0x5a,
-	0x49, 0x84, 0xcd, 0x03, 0x25, 0x19, 0x36, 0xbf, 0x51, 0x52, 0x61, 0xf3, 0xb1, 0xb2, 0x18, 0x36,
-	0x9f, 0x28, 0xe9, 0xb0, 0xf9, 0x54, 0xc9, 0x84, 0xcd, 0x67, 0x4a, 0x36, 0x6c, 0x3e, 0x57, 0x96,
-	0xc2, 0xe6, 0x0b, 0x65, 0x19, 0x01, 0x64, 0x38, 0xee, 0x13, 0x45, 0x1b, 0xb7, 0x9f, 0x2a, 0x87,
-	0xe3, 0xf6, 0x33, 0x45, 0x0f, 0xdb, 0xcf, 0xbe, 0x52, 0x2a, 0xe3, 0xf6, 0x13, 0xe5, 0xd5, 0xb8,
-	0xfd, 0x42, 0xb1, 0x1e, 0x79, 0x90, 0x8f, 0x3e, 0x59, 0xff, 0x9a, 0xfe, 0xf3, 0x78, 0xf4, 0x1c,
-	0x56, 0xae, 0x14, 0x94, 0x18, 0x56, 0x38, 0xb9, 0x8a, 0x4f, 0x71, 0x55, 0xfc, 0xdb, 0x52, 0xd7,
-	0x75, 0x21, 0x92, 0x02, 0x96, 0x38, 0xf8, 0x83, 0x24, 0xac, 0xf1, 0x1f, 0x7a, 0x64, 0x80, 0x51,
-	0x13, 0x3f, 0xd3, 0xa1, 0x43, 0x58, 0x21, 0xde, 0xa0, 0xef, 0x0f, 0x59, 0xe0, 0xca, 0xe2, 0xf7,
-	0x00, 0x6d, 0xcf, 0xfc, 0x8b, 0x8c, 0xff, 0x72, 0xb6, 0xbd, 0x2a, 0xc7, 0xf8, 0x1f, 0x77, 0x7b,
-	0xa7, 0xfd, 0x76, 0x4b, 0x5d, 0x40, 0xbf, 0x07, 0x85, 0x58, 0x32, 0x85, 0x7e, 0x16, 0xa1, 0x70,
-	0xed, 0x9f, 0x65, 0xdb, 0x0f, 0xe6, 0x60, 0xc9, 0xdf, 0x8d, 0x16, 0xd0, 0x2b, 0x80, 0xc9, 0x6f,
-	0x48, 0xe8, 0xba, 0xcc, 0x7b, 0x5b, 0xbd, 0x4a, 0x6f, 0xc6, 0xbf, 0x4b, 0x0b, 0x07, 0xff, 0x94,
-	0x80, 0x0d, 0x09, 0xad, 0xfb, 0xfd, 0x0f, 0x97, 0x62, 0xa8, 0xe5, 0xf9, 0xa8, 0x31, 0x79, 0xf0,
-	0x12, 0x77, 0x89, 0x76, 0xe6, 0xfd, 0x6d, 0xb4, 0x7d, 0x77, 0xce, 0x9f, 0x40, 0xea, 0x02, 0xb2,
-	0x20, 0x1f, 0xfd, 0x49, 0x00, 0xdd, 0xb9, 0xe6, 0xef, 0x81, 0x90, 0xe4, 0xed, 0x8f, 0xfe, 0x5d,
-	0xa0, 0x2e, 0x1c, 0xfc, 0x65, 0x12, 0x4a, 0xba, 0xd7, 0x1b, 0xfa, 0xe3, 0xcb, 0xd4, 0xfb, 0xbd,
-	0xa1, 0xdf, 0xef, 0x74, 0x3c, 0x1f, 0x39, 0x57, 0xef, 0xe2, 0x4a, 0xbc, 0x3c, 0x7d, 0x0d, 0x3b,
-	0xd7, 0x23, 0x8c, 0x6f, 0xc0, 0x81, 0x42, 0x2c, 0x40, 0x8f, 0x51, 0x9d, 0x95, 0x5b, 0xc4, 0xa8,
-	0xce, 0x8c, 0xed, 0xd5, 0x05, 0xf4, 0xbb, 0xa0, 0x8c, 0xe3, 0xdc, 0x90, 0x70, 0xf4, 0x12, 0xaf,
-	0x89, 0x85, 0xb7, 0xef, 0x7f, 0x14, 0x27, 0x24, 0x7f, 0x78, 0xf3, 0xbb, 0x2d, 0x8e, 0xb7, 0xdf,
-	0x19, 0x7a, 0xfb, 0xcd, 0x4e, 0x7f, 0xd4, 0xda, 0x3f, 0xef, 0xcb, 0x3f, 0x3e, 0xcf, 0x32, 0xfc,
-	0xfb, 0xcd, 0xff, 0x04, 0x00, 0x00, 0xff, 0xff, 0x8e, 0xbc, 0x31, 0xfc, 0x69, 0x2a, 0x00, 0x00,
+	// 4403 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe5, 0x5b, 0x4b, 0x93, 0xdb, 0x48,
+	0x72, 0x16, 0x9b, 0xef, 0xe2, 0xa3, 0x21, 0xb4, 0x5a, 0xcd, 0x6e, 0x49, 0x23, 0x09, 0x1a, 0xcd,
+	0xcc, 0x6a, 0x66, 0xd8, 0x33, 0x9a, 0xd1, 0x63, 0xbc, 0xde, 0x1d, 0xa3, 0x49, 0xb0, 0x1b, 0x16,
+	0x1b, 0xa4, 0x0a, 0xa0, 0x5e, 0x0e, 0x2f, 0xcc, 0x26, 0xa1, 0x16, 0x63, 0xf9, 0x12, 0x40, 0x6a,
+	0xba, 0xff, 0x81, 0x7d, 0xf3, 0xc1, 0xbe, 0x39, 0xec, 0x83, 0x63, 0x4f, 0x0e, 0x9f, 0x7c, 0xf0,
+	0xeb, 0xe0, 0xd8, 0xbf, 0xe0, 0x83, 0x1d, 0xe1, 0xab, 0x23, 0x7c, 0xb1, 0x0f, 0x3e, 0x38, 0x7c,
+	0xf0, 0xc9, 0x59, 0x0f, 0x00, 0x85, 0x26, 0xd8, 0x9c, 0xd6, 0xce, 0x46, 0x38, 0xc2, 0x27, 0x16,
+	0xb2, 0xb2, 0x5e, 0x59, 0x59, 0x5f, 0x66, 0x65, 0x16, 0xd1, 0xad, 0xe1, 0xcc, 0xd9, 0x9d, 0xba,
+	0x93, 0xd9, 0xc4, 0xdb, 0xf5, 0x1c, 0xcf, 0x1b, 0x4c, 0xc6, 0xf6, 0xa8, 0x3b, 0xee, 0x1e, 0x3b,
+	0x6e, 0x95, 0x92, 0xe5, 0xfc, 0xa8, 0x7b, 0x3c, 0xea, 0x56, 0x81, 0x6f, 0x67, 0x7b, 0xe2, 0xf6,
+	0x1e, 0xbb, 0x3e, 0x7b, 0x6f, 0x32, 0x1a, 0x4d, 0xc6, 0x8c, 0x6b, 0x67, 0x5b, 0xe8, 0x67, 0x3a,
+	0x19, 0x0e, 0x7a, 0xa7, 0xfd, 0x23, 0x5e, 0x75, 0x43, 0x1c, 0x62, 0x7e, 0xe4, 0xf5, 0xdc, 0xc1,
+	0x91, 0xe3, 0x06, 0xd5, 0x37, 0x8f, 0x27, 0x93, 0xe3, 0x21, 0xe7, 0x38, 0x9a, 0xbf, 0xde, 0x9d,
+	0x0d, 0x46, 0x8e, 0x37, 0xeb, 0x8e, 0xa6, 0x8c, 0x41, 0x19, 0x21, 0x84, 0xe7, 0x43, 0x07, 0x3b,
+	0xbd, 0x89, 0xdb, 0x97, 0x25, 0x94, 0xf4, 0x06, 0xfd, 0x4a, 0xe2, 0x56, 0xe2, 0x93, 0x3c, 0x26,
+	0x45, 0x79, 0x0b, 0x65, 0x5d, 0xa8, 0xb7, 0x81, 0xba, 0x46, 0xa9, 0x19, 0xf2, 0xa9, 0xf7, 0xe5,
+	0x6d, 0x94, 0x3b, 0x3a, 0x9d, 0x39, 0x9e, 0x3d, 0x3b, 0xa9, 0x24, 0xa1, 0x26, 0x85, 0xb3, 0xf4,
+	0xdb, 0x3a, 0x09, 0xab, 0xdc, 0x93, 0x4a, 0x4a, 0xa8, 0xc2, 0x27, 0xca, 0x0b, 0xb4, 0x1e, 0x0e,
+	0x67, 0x75, 0x8f, 0x86, 0x8e, 0xbc, 0x0b, 0x23, 0xd0, 0x4f, 0x0f, 0xc6, 0x4d, 0x7e, 0x52, 0xb8,
+	0xbf, 0x59, 0x0d, 0x84, 0x52, 0x0d, 0x99, 0xb1, 0xcf, 0x25, 0x5f, 0x41, 0x69, 0x67, 0x3a, 0xe9,
+	0xbd, 0xa1, 0x13, 0x4a, 0x61, 0xf6, 0xa1, 0xfc, 0x6b, 0x1a, 0x6d, 0x37, 0x27, 0xbd, 0xee, 0xb0,
+	0xe6, 0x3a, 0xdd, 0x99, 0x63, 0x32, 0x71, 0x63, 0xe7, 0xed, 0x1c, 0xd6, 0x2b, 0xff, 0x28, 0x5c,
+	0x58, 0xe1, 0xfe, 0x96, 0x30, 0x80, 0x19, 0xc8, 0x4c, 0xaf, 0x07, 0x2b, 0x9e, 0xc3, 0x7a, 0xa7,
+	0xef, 0xbe, 0xf6, 0x57, 0x3c, 0x77, 0x74, 0xf8, 0x92, 0xaf, 0xa1, 0xbc, 0x37, 0x3d, 0xfe, 0x8e,
+	0x55, 0x25, 0x69, 0x55, 0x8e, 0x10, 0x68, 0x25, 0x48, 0xae, 0x3b, 0x1d, 0xd3, 0xe5, 0x82, 0xe4,
+	0xa0, 0x28, 0xcb, 0x28, 0x05, 0xb2, 0x1e, 0x54, 0x32, 0x94, 0x44, 0xcb, 0xa4, 0xef, 0xe9, 0x70,
+	0x34, 0x26, 0xd2, 0xcc, 0xb2, 0xbe, 0xc9, 0x27, 0x48, 0xf3, 0x16, 0x2a, 0x0e, 0x46, 0xde, 0xc0,
+	0xf6, 0x6b, 0x73, 0xb4, 0x16, 0x11, 0x5a, 0x9b, 0x71, 0xdc, 0x41, 0xa5, 0xb9, 0xe7, 0xb8, 0xf6,
+	0x10, 0xd6, 0x38, 0x83, 0x95, 0x55, 0xf2, 0xc0, 0x52, 0xc4, 0x45, 0x42, 0x6c, 0x72, 0x9a, 0xfc,
+	0x63, 0x94, 0x7b, 0x3b, 0xf1, 0xec, 0xc1, 0xf8, 0xf5, 0xa4, 0x82, 0xe8, 0x5a, 0x6f, 0x09, 0x6b,
+	0x7d, 0x3a, 0xf1, 0x74, 0xa8, 0x71, 0x47, 0x94, 0x99, 0x8b, 0x06, 0x67, 0xdf, 0x32, 0xb2, 0x7c,
+	0x15, 0x65, 0x60, 0x38, 0xaf, 0x3f, 0xae, 0x14, 0x68, 0xd7, 0xfc, 0x4b, 0xfe, 0x1c, 0xe5, 0xdc,
+	0xee, 0xcc, 0x9e, 0x9d, 0x4e, 0x9d, 0x4a, 0x11, 0x6a, 0xca, 0xf7, 0x65, 0x71, 0x87, 0x54, 0xcb,
+	0x82, 0x1a, 0xd8, 0x9e, 0xee, 0x8c, 0x14, 0xc8, 0x44, 0xdf, 0x74, 0xdd, 0xfe, 0x77, 0x5d, 0xd7,
+	0xb1, 0xbb, 0xfd, 0xbe, 0x5b, 0x29, 0xb1, 0x89, 0xfa, 0x44, 0x15, 0x68, 0xf2, 0x3d, 0x74, 0xd9,
+	0xed, 0xf6, 0x07, 0x73, 0xcf, 0xf6, 0xcf, 0x05, 0x2c, 0xba, 0x4c, 0x17, 0xbd, 0xce, 0x2a, 0xf8,
+	0x06, 0xc2, 0xca, 0x41, 0xee, 0x47, 0x0e, 0x34, 0x74, 0x09, 0xcf, 0x3a, 0xf0, 0x94, 0x70, 0x8e,
+	0x11, 0xa0, 0xf2, 0x23, 0xb4, 0x0e, 0xea, 0x3c, 0x1b, 0xf4, 0x6c, 0xae, 0xa6, 0x5e, 0x45, 0x02,
+	0x2d, 0xca, 0xe3, 0x12, 0x23, 0x63, 0xaa, 0xad, 0x1e, 0xe1, 0xa3, 0x0c, 0x47, 0x5d, 0xcf, 0xb1,
+	0xc7, 0x5d, 0x38, 0x04, 0x95, 0xcb, 0x8c, 0x8f, 0x90, 0xf7, 0x80, 0x6a, 0x10, 0x62, 0xb8, 0xfb,
+	0x0f, 0x2b, 0xb2, 0xb0, 0xfb, 0x0f, 0xe5, 0x0f, 0x51, 0x99, 0x57, 0xd8, 0x53, 0xd7, 0x79, 0x3d,
+	0x38, 0xa9, 0x6c, 0xd0, 0xfa, 0x22, 0xab, 0x6f, 0x53, 0x9a, 0xfc, 0x0d, 0xaa, 0xf4, 0x60, 0xa1,
+	0xc7, 0x83, 0xf1, 0xb1, 0x4d, 0x0a, 0xdd, 0xde, 0xcc, 0x71, 0x07, 0x1e, 0x4c, 0xc4, 0xab, 0x5c,
+	0xa1, 0xfc, 0x5b, 0x7e, 0x7d, 0x2d, 0x5a, 0x4d, 0xe4, 0x06, 0x22, 0x24, 0x0d, 0x8f, 0xdd, 0xc9,
+	0x7c, 0xea, 0x55, 0x36, 0x61, 0x7e, 0x25, 0x5c, 0x64, 0xc4, 0x7d, 0x4a, 0x53, 0xfe, 0x27, 0x81,
+	0x76, 0xe2, 0xb4, 0xdc, 0x9b, 0x4e, 0xc6, 0x9e, 0x23, 0xb7, 0x51, 0xf6, 0xf8, 0xc4, 0x1e, 0x4d,
+	0xfa, 0x0e, 0x55, 0xf5, 0xf2, 0xfd, 0x47, 0xc2, 0x4e, 0x2d, 0x6f, 0x57, 0x05, 0x6a, 0x7f, 0x30,
+	0xab, 0x4d, 0xc6, 0x33, 0x77, 0x32, 0x3c, 0x84, 0xe6, 0x38, 0x73, 0x7c, 0x42, 0x7e, 0x69, 0x8f,
+	0xa7, 0xac, 0xc7, 0xb5, 0x5f, 0xb5, 0xc7, 0x53, 0xf2, 0xab, 0x3c, 0x46, 0x97, 0x17, 0x2a, 0x65,
+	0x84, 0x32, 0x46, 0x0b, 0x1f, 0xaa, 0x4d, 0xe9, 0x92, 0x5c, 0x40, 0xd9, 0x86, 0xaa, 0x37, 0x3b,
+	0x58, 0x93, 0x12, 0xa4, 0x62, 0xef, 0x65, 0x5b, 0x35, 0x4d, 0x69, 0x4d, 0xd9, 0x46, 0x5b, 0x74,
+	0x44, 0x6d, 0xdc, 0x3f, 0x33, 0x9c, 0xf2, 0x4f, 0x09, 0xb4, 0x59, 0xe3, 0x82, 0xc5, 0x8e, 0x3a,
+	0x9f, 0xbd, 0xf1, 0x4f, 0xfe, 0x0d, 0x84, 0x04, 0x15, 0x63, 0xc8, 0x96, 0xf7, 0x02, 0xe5, 0xba,
+	0x8d, 0x8a, 0xc1, 0x86, 0xfd, 0xdc, 0x39, 0xa5, 0x8b, 0x2c, 0xe1, 0x82, 0x4f, 0x7b, 0xe2, 0x9c,
+	0xfa, 0xa0, 0x98, 0x0c, 0x41, 0xf1, 0x1b, 0x94, 0xa2, 0xa7, 0x21, 0x45, 0x25, 0x72, 0x57, 0x90,
+	0x48, 0xec, 0x1c, 0xaa, 0xf4, 0x80, 0xd0, 0x26, 0x4a, 0x15, 0xa5, 0xe8, 0x29, 0x91, 0x51, 0xd9,
+	0xd4, 0x8d, 0xfd, 0xa6, 0x66, 0x9b, 0x1a, 0x7e, 0xa6, 0xd7, 0x34, 0x58, 0x38, 0xd0, 0x34, 0xc3,
+	0xd2, 0x31, 0xa1, 0x99, 0xa6, 0xde, 0x32, 0xa4, 0x84, 0xf2, 0xd7, 0x09, 0x74, 0x25, 0xda, 0xa9,
+	0x3a, 0xf6, 0xbe, 0x73, 0x5c, 0xf9, 0xa7, 0x28, 0xe3, 0x3a, 0xde, 0x7c, 0x38, 0xe3, 0x3b, 0xfd,
+	0xd1, 0xd2, 0x59, 0xb0, 0x06, 0x55, 0x4c, 0xb9, 0x31, 0x6f, 0xa5, 0xd8, 0x28, 0xc3, 0x28, 0x80,
+	0xa7, 0x52, 0xa7, 0x5d, 0x57, 0x2d, 0xcd, 0xd6, 0x0d, 0xdd, 0xd2, 0xa1, 0x50, 0x87, 0xc9, 0x6c,
+	0xa2, 0xcb, 0x9c, 0x6a, 0xb4, 0x2c, 0xdb, 0xd0, 0xb4, 0x3a, 0x90, 0x13, 0x84, 0xcc, 0x27, 0x47,
+	0xe9, 0x8d, 0x56, 0xc7, 0xa8, 0x4b, 0x6b, 0xf2, 0x65, 0x54, 0x6a, 0x59, 0x07, 0x1a, 0xb6, 0xfd,
+	0x9d, 0x4b, 0x2a, 0x7f, 0x91, 0x42, 0x1b, 0x6d, 0x6a, 0xac, 0x2e, 0xb4, 0x21, 0x14, 0x36, 0xbd,
+	0x01, 0xc7, 0x5e, 0x5a, 0xf6, 0x0f, 0x2f, 0xd8, 0x9a, 0x89, 0xed, 0x3a, 0xa3, 0xc9, 0x3b, 0x07,
+	0x76, 0x23, 0x38, 0xbc, 0x9e, 0x35, 0xc1, 0x94, 0x28, 0x37, 0x90, 0x14, 0xf0, 0x0d, 0xc6, 0x00,
+	0x00, 0xc3, 0x21, 0xc0, 0x2f, 0xb1, 0x29, 0xd7, 0x45, 0xc8, 0x0f, 0x81, 0x81, 0xf1, 0xe0, 0x32,
+	0xef, 0x86, 0x7f, 0xcb, 0xcf, 0x50, 0xa5, 0x7f, 0x0a, 0x20, 0xc1, 0x51, 0x25, 0xd2, 0x5f, 0x96,
+	0xf6, 0x77, 0x43, 0xe8, 0xaf, 0xce, 0x58, 0xc5, 0x0e, 0x37, 0xfb, 0x21, 0x4d, 0xe8, 0xf7, 0xa7,
+	0xa8, 0xec, 0xbc, 0x73, 0xc6, 0x80, 0xa5, 0xee, 0xe0, 0x18, 0x9c, 0x00, 0x0f, 0x70, 0x3e, 0x09,
+	0x7b, 0x27, 0x1a, 0x24, 0x8d, 0x30, 0x58, 0xac, 0x1e, 0x97, 0x1c, 0xe1, 0xcb, 0x93, 0xf7, 0x01,
+	0x35, 0x9d, 0x77, 0xdd, 0xe1, 0xa0, 0x4f, 0x11, 0xdc, 0x26, 0xc6, 0x9c, 0xda, 0x81, 0xc2, 0xfd,
+	0x9d, 0x2a, 0xb3, 0xf4, 0x55, 0xdf, 0xd2, 0x57, 0x2d, 0xdf, 0xd2, 0x63, 0x49, 0x6c, 0x44, 0xc8,
+	0xf2, 0x2b, 0x54, 0x99, 0x7b, 0xe0, 0x86, 0xc0, 0xc1, 0x1e, 0x0f, 0x66, 0x13, 0x97, 0xc2, 0x15,
+	0x3d, 0x94, 0x1e, 0xd8, 0x8d, 0xe4, 0x19, 0xbb, 0xd1, 0x21, 0xac, 0x87, 0x01, 0x27, 0x3b, 0xbd,
+	0xf8, 0xea, 0x3c, 0x8e, 0xec, 0xc9, 0x5f, 0x0b, 0x36, 0xa8, 0x40, 0xe7, 0xb6, 0x1d, 0xb1, 0x41,
+	0xa6, 0x68, 0x83, 0x7c, 0xe3, 0xa3, 0xb4, 0x50, 0x39, 0x5a, 0x15, 0x85, 0x7d, 0xa6, 0x26, 0x21,
+	0xec, 0xdf, 0x42, 0xc9, 0xb7, 0xbd, 0x01, 0x87, 0xa4, 0xb2, 0xd8, 0x7f, 0x4d, 0xc7, 0xa4, 0x4a,
+	0xf9, 0xe3, 0x1c, 0x92, 0x45, 0xf5, 0xe3, 0xc7, 0x66, 0x85, 0xf6, 0xed, 0x06, 0xa7, 0x8a, 0x75,
+	0x2d, 0xee, 0x8c, 0xaf, 0xc6, 0xe2, 0x31, 0x92, 0x9f, 0xa2, 0xe2, 0xeb, 0xee, 0x60, 0xe8, 0xf4,
+	0x99, 0xa6, 0x50, 0xbd, 0x2c, 0xdc, 0xaf, 0x0a, 0xcd, 0x16, 0x27, 0x51, 0x6d, 0xd0, 0x16, 0x54,
+	0x39, 0x34, 0xc0, 0xc0, 0x53, 0x5c, 0x78, 0x1d, 0x52, 0x76, 0x06, 0x48, 0x3a, 0xcb, 0x40, 0x30,
+	0x88, 0xa0, 0x13, 0x77, 0xcc, 0xa0, 0x28, 0x7f, 0x8b, 0xd2, 0xb0, 0xa9, 0x73, 0x1f, 0x96, 0x7f,
+	0xb4, 0x7a, 0xc4, 0xb9, 0xeb, 0xd4, 0x08, 0x10, 0xb3, 0x76, 0xbf, 0xb1, 0xf6, 0x38, 0xa1, 0xfc,
+	0x67, 0x1a, 0x15, 0x84, 0x2a, 0x82, 0xb6, 0x1d, 0xa3, 0x63, 0x06, 0x00, 0x60, 0x3c, 0x31, 0x5a,
+	0xcf, 0x0d, 0x1b, 0x77, 0x00, 0xa7, 0x0c, 0xf5, 0x90, 0x00, 0xf2, 0x55, 0x24, 0x83, 0xc9, 0x07,
+	0xe8, 0xb2, 0xf7, 0x71, 0xab, 0xd3, 0xb6, 0x35, 0x8c, 0x5b, 0x18, 0x10, 0xe0, 0x3a, 0xaa, 0x70,
+	0x24, 0xb3, 0xf5, 0x3a, 0x81, 0xb1, 0x86, 0x0e, 0x70, 0xc0, 0x6a, 0x93, 0x60, 0x56, 0x37, 0xf6,
+	0x9f, 0xdb, 0xed, 0x9a, 0xd6, 0xb0, 0x01, 0xe4, 0x1b, 0x1d, 0xa3, 0x66, 0x11, 0x7c, 0x4b, 0xc9,
+	0x15, 0x74, 0x05, 0x6b, 0x66, 0xab, 0x83, 0x6b, 0x9a, 0x69, 0x37, 0xf5, 0x43, 0xdd, 0x52, 0x69,
+	0x4d, 0x5a, 0xde, 0x41, 0x57, 0x0f, 0xd5, 0x17, 0xb6, 0x81, 0xed, 0x3d, 0x4d, 0xc5, 0x1a, 0x36,
+	0x6d, 0xac, 0xa9, 0xb5, 0x03, 0x98, 0x5b, 0x46, 0x9c, 0x1b, 0xab, 0x84, 0x31, 0xa5, 0x2c, 0x21,
+	0x1f, 0xea, 0x26, 0xc1, 0x55, 0x81, 0x9c, 0x23, 0x53, 0xf3, 0xc9, 0x8d, 0x66, 0xeb, 0x39, 0xc0,
+	0x5c, 0x83, 0xd8, 0x1a, 0x3a, 0x4e, 0x5e, 0xbe, 0x89, 0xae, 0xf9, 0x33, 0xb0, 0xd5, 0x66, 0xb3,
+	0x55, 0xa3, 0x15, 0x01, 0x90, 0x21, 0xc2, 0xd0, 0x31, 0xcc, 0x4e, 0x0d, 0x66, 0x68, 0x36, 0x3a,
+	0x4d, 0xfb, 0x69, 0xcb, 0xb4, 0x9f, 0xa9, 0x4d, 0xbd, 0xce, 0x7a, 0x28, 0xc8, 0x1f, 0xa0, 0x1d,
+	0xdd, 0xa8, 0xb5, 0x30, 0xd6, 0x6a, 0xd6, 0xe2, 0x08, 0x45, 0x32, 0xad, 0xb6, 0x69, 0x5b, 0x2d,
+	0xbb, 0x66, 0xda, 0x07, 0xaa, 0x51, 0x6f, 0x3d, 0xd3, 0xb0, 0x54, 0x02, 0x8f, 0xe2, 0x96, 0x55,
+	0x6f, 0xd8, 0x6a, 0xbb, 0xdd, 0xd4, 0xf9, 0xa0, 0x0b, 0x92, 0x2b, 0xcb, 0x1b, 0x68, 0xdd, 0x68,
+	0xf9, 0xcb, 0x61, 0x70, 0xbb, 0x4e, 0xc4, 0xd9, 0xd0, 0x9b, 0x16, 0x50, 0x60, 0xea, 0x16, 0xd6,
+	0xa9, 0x34, 0x4d, 0x49, 0x02, 0x3d, 0x29, 0xaa, 0x86, 0x0d, 0xa2, 0x26, 0xd3, 0x07, 0x51, 0x5d,
+	0x06, 0xb7, 0xe2, 0xa6, 0xbf, 0x78, 0xac, 0xd5, 0x75, 0x3a, 0x47, 0xb2, 0x51, 0xd0, 0x56, 0xad,
+	0xd7, 0xa1, 0xb9, 0x29, 0xc9, 0x64, 0x05, 0xb5, 0x43, 0x5b, 0x33, 0xea, 0x36, 0x6c, 0x3e, 0xf6,
+	0x4d, 0x92, 0x0d, 0xb3, 0xd1, 0xa1, 0x93, 0x0d, 0x32, 0x55, 0xa8, 0xaf, 0x91, 0x0e, 0x2c, 0xbb,
+	0xd6, 0x32, 0x2c, 0xdc, 0x6a, 0x52, 0xfc, 0xe7, 0x93, 0xdf, 0x6b, 0x6a, 0xd2, 0x15, 0x38, 0x5b,
+	0xdb, 0xc0, 0xa5, 0x76, 0xac, 0x83, 0x16, 0xd6, 0x5f, 0xb1, 0x15, 0x61, 0xed, 0xb7, 0x61, 0x44,
+	0xe8, 0x64, 0x93, 0xac, 0x04, 0xaa, 0xe9, 0x00, 0x7c, 0xf3, 0xa4, 0xab, 0xc4, 0xf8, 0x00, 0x91,
+	0x6b, 0x14, 0x9f, 0xf4, 0x16, 0xd9, 0x7b, 0x50, 0x2e, 0x4a, 0xa3, 0xba, 0xc7, 0x7a, 0x21, 0xd2,
+	0xac, 0x80, 0x31, 0x50, 0x02, 0xbd, 0xe4, 0x3c, 0x2a, 0xdd, 0x9b, 0x88, 0xd4, 0xb7, 0x89, 0xd4,
+	0x41, 0x70, 0xc6, 0x9e, 0xde, 0x68, 0x1d, 0xda, 0x66, 0xa7, 0xdd, 0x6e, 0x61, 0x4b, 0xda, 0x51,
+	0xbe, 0x45, 0x88, 0x21, 0x55, 0x07, 0x80, 0x8b, 0x5c, 0x55, 0x06, 0x9e, 0x4d, 0xd1, 0x91, 0x1e,
+	0xae, 0x1c, 0xce, 0x0e, 0xbc, 0x67, 0xe4, 0x93, 0xb8, 0xc3, 0xef, 0x26, 0xc3, 0xf9, 0xc8, 0xe1,
+	0xf7, 0x0c, 0xfe, 0xa5, 0xfc, 0x41, 0x02, 0x15, 0xf7, 0xdd, 0xee, 0x78, 0xe6, 0xf4, 0x49, 0x17,
+	0x9e, 0xfc, 0x29, 0x4a, 0xcf, 0x26, 0x80, 0xef, 0xfc, 0x76, 0x21, 0x5e, 0x5f, 0xc2, 0x91, 0x30,
+	0xe3, 0x91, 0xef, 0xa2, 0x35, 0xb8, 0x30, 0xad, 0x9d, 0xc7, 0x09, 0x0c, 0x84, 0xcd, 0x65, 0xf7,
+	0xaa, 0xe5, 0x6c, 0xee, 0x89, 0xf2, 0x1f, 0x09, 0x54, 0xc6, 0x40, 0x81, 0xab, 0xd1, 0xcc, 0x74,
+	0xdc, 0x77, 0x00, 0x70, 0x5d, 0xb4, 0xe9, 0x72, 0x0a, 0x75, 0xbf, 0x01, 0xda, 0x98, 0xeb, 0xce,
+	0xdc, 0x84, 0xcf, 0x23, 0x80, 0x26, 0xb6, 0x0c, 0x3e, 0x55, 0xd6, 0x8a, 0x3a, 0x2d, 0x1b, 0xee,
+	0x22, 0x51, 0x7e, 0x88, 0xb6, 0x82, 0x21, 0x3c, 0xda, 0xd6, 0x1f, 0x89, 0x5b, 0xed, 0x60, 0x06,
+	0xac, 0x67, 0xde, 0x16, 0x44, 0xbf, 0x11, 0x33, 0x86, 0x9c, 0x43, 0x29, 0xbd, 0xfd, 0xec, 0x6b,
+	0x80, 0x1c, 0x56, 0x7a, 0x08, 0x28, 0x93, 0x45, 0xc9, 0x0e, 0x6e, 0x02, 0xac, 0x80, 0x33, 0x68,
+	0xea, 0x6d, 0xbb, 0x83, 0x75, 0x70, 0x29, 0xfe, 0x36, 0x89, 0xca, 0xbe, 0x6f, 0xc3, 0x24, 0x01,
+	0x73, 0x61, 0xae, 0x18, 0x43, 0x41, 0x25, 0xc6, 0x09, 0x62, 0x8c, 0x55, 0x22, 0xb3, 0xd0, 0x0f,
+	0x23, 0xde, 0x36, 0xdd, 0xf5, 0xc1, 0xec, 0x94, 0x99, 0xd1, 0x24, 0x75, 0xfc, 0x8a, 0x3e, 0x91,
+	0x9a, 0x49, 0xa6, 0x1d, 0xaf, 0x07, 0x63, 0xd8, 0xdc, 0x94, 0xaf, 0x1d, 0x0d, 0xf2, 0x29, 0x1f,
+	0x00, 0xee, 0x93, 0x82, 0x0d, 0x2e, 0x3c, 0xb9, 0x8d, 0xa5, 0x97, 0xba, 0x82, 0x7c, 0x7c, 0xda,
+	0x4c, 0xa5, 0xcc, 0x00, 0xf7, 0xe1, 0x87, 0xfc, 0x9b, 0xa8, 0x74, 0xcc, 0xd4, 0xc9, 0x9e, 0x13,
+	0x7d, 0xa2, 0x17, 0xc6, 0xe8, 0x25, 0x55, 0x54, 0x37, 0x5c, 0x3c, 0x16, 0x95, 0x6f, 0x0f, 0x5c,
+	0xa3, 0xe8, 0x5e, 0xd0, 0x9b, 0x65, 0xd4, 0xe8, 0x46, 0x37, 0x1a, 0xdc, 0x9d, 0xc8, 0xb7, 0xa2,
+	0xa0, 0x9c, 0x2f, 0x1d, 0x39, 0x8f, 0xd2, 0x7b, 0x2f, 0x2d, 0xcd, 0x64, 0x7e, 0xb8, 0xa9, 0xc1,
+	0x61, 0xaf, 0x9b, 0xe0, 0x87, 0x7e, 0x0b, 0x86, 0x42, 0x98, 0x74, 0x09, 0xe5, 0x01, 0x7d, 0x0e,
+	0x75, 0x03, 0x1c, 0x44, 0x60, 0x2d, 0xa2, 0x9c, 0x0f, 0x2e, 0xb0, 0x79, 0x70, 0xd0, 0x7d, 0x58,
+	0xe2, 0x47, 0x13, 0x9c, 0xf7, 0xdf, 0x4f, 0xa2, 0x02, 0xd7, 0x5e, 0xe2, 0x37, 0x44, 0xe2, 0x07,
+	0x89, 0xe5, 0xf1, 0x83, 0xb5, 0x48, 0xfc, 0x60, 0xc1, 0x5d, 0x4f, 0x2d, 0xba, 0xeb, 0x0f, 0xb8,
+	0x46, 0xb0, 0x1d, 0xb9, 0xbd, 0x78, 0x78, 0xc8, 0xf0, 0xd5, 0xce, 0x14, 0xdc, 0x21, 0x47, 0x50,
+	0x88, 0xbb, 0xa8, 0x2c, 0x38, 0x43, 0xa4, 0x6f, 0x76, 0x71, 0x2f, 0x85, 0x54, 0xe8, 0x5d, 0xf9,
+	0x65, 0x02, 0xa1, 0xb0, 0x2d, 0x95, 0xc3, 0x01, 0x2c, 0xf6, 0xa0, 0xd5, 0x24, 0x36, 0x13, 0xd4,
+	0xf6, 0xe9, 0x01, 0x11, 0x41, 0x19, 0xa1, 0x40, 0x3e, 0xc4, 0x3f, 0x06, 0x91, 0x3c, 0xed, 0xb4,
+	0x2c, 0xd5, 0xd6, 0x5e, 0x1c, 0xa8, 0x1d, 0x93, 0x10, 0x93, 0x04, 0xe5, 0xa8, 0x1d, 0xd1, 0xad,
+	0x97, 0xb6, 0xa5, 0x1f, 0x12, 0xd0, 0x7f, 0xd1, 0x06, 0x21, 0xd6, 0xc1, 0x2e, 0x02, 0x2e, 0x32,
+	0x87, 0x9a, 0x35, 0xb3, 0x5e, 0xb6, 0x35, 0xb0, 0x89, 0xd7, 0xd0, 0x16, 0x87, 0x4a, 0xb2, 0x2f,
+	0x3a, 0x45, 0xd8, 0x1a, 0x98, 0x94, 0x7d, 0x0d, 0x8c, 0x22, 0x15, 0x3b, 0x41, 0x5f, 0x80, 0xcb,
+	0xa7, 0x1d, 0xda, 0x4f, 0x96, 0xdc, 0x29, 0xda, 0x2d, 0x00, 0xeb, 0x70, 0xdc, 0x9c, 0xf2, 0xcb,
+	0xa4, 0x7f, 0x05, 0xa3, 0xb2, 0x60, 0xcb, 0x91, 0x3f, 0x43, 0x69, 0xea, 0xd1, 0x71, 0x18, 0xbb,
+	0x1a, 0x2f, 0x38, 0xcc, 0x98, 0xce, 0xf8, 0x51, 0x6b, 0x67, 0xfd, 0x28, 0x90, 0xa6, 0xcb, 0xfc,
+	0x7d, 0x7b, 0x3c, 0x1f, 0x1d, 0x81, 0x56, 0xb2, 0xf3, 0x55, 0xe2, 0x54, 0x83, 0x12, 0xfd, 0xab,
+	0x55, 0x2a, 0xbc, 0x5a, 0x85, 0x41, 0x88, 0x74, 0x24, 0x08, 0x21, 0x44, 0x65, 0x32, 0xcb, 0xa3,
+	0x32, 0xd9, 0xf8, 0xa8, 0x4c, 0x6e, 0x31, 0x2a, 0x93, 0x8f, 0x8f, 0xca, 0xa0, 0x73, 0xa3, 0x32,
+	0x85, 0xd5, 0x51, 0x99, 0x62, 0x4c, 0x54, 0x46, 0x0c, 0xa0, 0x94, 0xde, 0x23, 0x80, 0x52, 0x5e,
+	0x0c, 0xa0, 0x28, 0xff, 0x4d, 0xee, 0x85, 0x6c, 0x5b, 0xe8, 0xf6, 0x05, 0x21, 0x80, 0x0a, 0xca,
+	0x7a, 0xf3, 0x5e, 0x8f, 0x80, 0x31, 0x37, 0x68, 0xfc, 0xd3, 0x17, 0xf6, 0x5a, 0x28, 0xec, 0xb3,
+	0xa7, 0x29, 0xb9, 0x78, 0x9a, 0xbe, 0x44, 0x19, 0x76, 0x31, 0xa0, 0x9b, 0x14, 0x85, 0x95, 0x28,
+	0xc2, 0x61, 0xce, 0x28, 0xff, 0x56, 0xe4, 0x00, 0x7e, 0xb6, 0xa8, 0x47, 0x91, 0x09, 0x57, 0xfd,
+	0x82, 0x70, 0x49, 0xde, 0x41, 0x45, 0x91, 0x4a, 0xdd, 0x52, 0x7a, 0x17, 0x95, 0x2e, 0x29, 0x7f,
+	0x9e, 0x40, 0xb2, 0x78, 0x21, 0xe1, 0xda, 0xbb, 0x78, 0x7c, 0x13, 0x31, 0xc7, 0x57, 0xfe, 0x02,
+	0xa5, 0x87, 0x70, 0xa7, 0x1a, 0x72, 0x7b, 0xb1, 0x23, 0x4c, 0x2e, 0xbc, 0xc9, 0x34, 0x09, 0x07,
+	0x66, 0x8c, 0xef, 0x19, 0xe7, 0xfc, 0xa3, 0x35, 0xb4, 0x19, 0x7b, 0x6d, 0x02, 0xbf, 0x3d, 0xc3,
+	0x4d, 0x06, 0x33, 0xc8, 0x1f, 0xaf, 0xba, 0x68, 0x55, 0xb9, 0xd1, 0xe0, 0xcd, 0x62, 0x56, 0xba,
+	0x76, 0xee, 0x4a, 0x93, 0xdf, 0x77, 0xa5, 0x0b, 0x86, 0x28, 0x7d, 0x01, 0x43, 0xa4, 0xdc, 0x41,
+	0x19, 0x6e, 0x1b, 0xc0, 0x18, 0x10, 0x17, 0x51, 0x37, 0x3a, 0x1a, 0xb3, 0x22, 0x75, 0xdd, 0xa4,
+	0x1e, 0x62, 0x42, 0xf9, 0xf7, 0x04, 0xba, 0x7e, 0x66, 0x91, 0xbe, 0x36, 0xb0, 0xe0, 0xc0, 0x03,
+	0x94, 0x99, 0x53, 0x02, 0x47, 0xa1, 0x1b, 0x4b, 0xa4, 0xc3, 0x5b, 0x71, 0xe6, 0x5f, 0x1b, 0x1a,
+	0x09, 0xa8, 0x93, 0x8e, 0xa0, 0xce, 0xc2, 0x19, 0xcd, 0xc4, 0x9c, 0xd1, 0xbf, 0x5c, 0x43, 0x37,
+	0x96, 0xac, 0x96, 0x1f, 0xd6, 0xc7, 0xc1, 0xe9, 0x4a, 0x2c, 0x44, 0x6b, 0xe3, 0x6f, 0xdd, 0xfe,
+	0x21, 0x5b, 0xb1, 0xe2, 0xc5, 0x98, 0x95, 0x80, 0x0b, 0xa9, 0x28, 0x2e, 0x2c, 0x46, 0x25, 0xd2,
+	0xbf, 0x7a, 0x54, 0x22, 0x73, 0xf1, 0xa8, 0x84, 0xf2, 0x87, 0x70, 0x68, 0x62, 0x63, 0xd4, 0x70,
+	0x3f, 0x29, 0x00, 0x78, 0xdb, 0xdd, 0xd1, 0x91, 0x6b, 0xf7, 0x99, 0xa3, 0x5d, 0xc2, 0x79, 0x20,
+	0xa9, 0x40, 0xa9, 0x0f, 0x23, 0xf5, 0xf3, 0x21, 0x0f, 0xe2, 0xf9, 0xf5, 0x1d, 0xe2, 0x75, 0x97,
+	0xa7, 0xee, 0x00, 0xe4, 0x08, 0xde, 0x5e, 0x78, 0x2a, 0x40, 0x01, 0x7c, 0x2a, 0x3d, 0x08, 0xf2,
+	0x57, 0x68, 0x73, 0xea, 0x3a, 0xce, 0x68, 0x4a, 0xd7, 0xd1, 0xeb, 0x4e, 0xbb, 0x47, 0x83, 0x21,
+	0xd4, 0x72, 0x37, 0xe3, 0x4a, 0x58, 0x59, 0x0b, 0xea, 0x48, 0xc8, 0x57, 0x68, 0xf4, 0x6e, 0x3e,
+	0x1c, 0x3b, 0xae, 0xdf, 0x2e, 0x4d, 0xdb, 0x6d, 0x85, 0xf5, 0xcf, 0xc4, 0x6a, 0x62, 0x5f, 0x48,
+	0xa8, 0xa4, 0x37, 0xec, 0x82, 0x93, 0x0e, 0xdb, 0x95, 0xa1, 0xec, 0x08, 0x68, 0x35, 0x42, 0xd2,
+	0xfb, 0xca, 0x3f, 0xa7, 0x29, 0xcc, 0x2f, 0x26, 0x34, 0x1e, 0xc1, 0xfe, 0x07, 0xa9, 0x8b, 0x55,
+	0x79, 0x0d, 0x81, 0x75, 0x95, 0xe2, 0x08, 0x1a, 0x9f, 0x5c, 0x6e, 0x67, 0x53, 0xf1, 0x76, 0x36,
+	0xbd, 0x68, 0x67, 0xb3, 0xf1, 0x76, 0x36, 0x77, 0xae, 0x9d, 0xcd, 0xaf, 0xb6, 0xb3, 0x68, 0x45,
+	0xf6, 0xa3, 0xf0, 0xfe, 0xd9, 0x8f, 0x62, 0xc4, 0xf1, 0xd8, 0x40, 0xe9, 0xe3, 0x1e, 0x99, 0x54,
+	0x89, 0xad, 0xe4, 0xb8, 0x07, 0xd3, 0x11, 0x2d, 0x7a, 0xf9, 0x3d, 0x2c, 0xfa, 0x7a, 0x4c, 0x4a,
+	0xe4, 0xff, 0x59, 0x26, 0xe3, 0xbf, 0x92, 0x68, 0x33, 0x3e, 0x89, 0xf1, 0x0d, 0xca, 0xfa, 0xb1,
+	0x48, 0x96, 0x10, 0xbc, 0xb9, 0xc2, 0x85, 0xc0, 0x3e, 0x7f, 0x9c, 0x6c, 0xd2, 0x71, 0xb2, 0x69,
+	0x81, 0x08, 0xc4, 0xf8, 0xa7, 0xc7, 0xc3, 0xc4, 0x9f, 0x2c, 0xc7, 0xdf, 0x33, 0x43, 0x96, 0xc4,
+	0xe8, 0xa7, 0x07, 0x56, 0xbd, 0x28, 0x6c, 0x9e, 0xc7, 0xa3, 0xc4, 0xe7, 0x47, 0x9d, 0x0b, 0xe1,
+	0xbe, 0x92, 0x7b, 0x5c, 0x29, 0x12, 0x72, 0xa6, 0x91, 0xe1, 0x95, 0x71, 0xe6, 0xa2, 0x18, 0x67,
+	0x16, 0xb3, 0x3f, 0xf9, 0x1f, 0x3c, 0xfb, 0x83, 0x7e, 0x98, 0xec, 0xcf, 0xdf, 0x25, 0xd0, 0xe5,
+	0x05, 0x51, 0x88, 0x59, 0xe6, 0x44, 0x24, 0xcb, 0x5c, 0x43, 0xeb, 0xc4, 0xed, 0x79, 0x27, 0x58,
+	0x96, 0xb5, 0x95, 0x96, 0xa5, 0x1c, 0x36, 0xa1, 0xd7, 0x78, 0x30, 0x50, 0x7d, 0xe7, 0x6c, 0x37,
+	0xc9, 0xd5, 0x06, 0x4a, 0x6c, 0x44, 0x0d, 0xd4, 0xbf, 0x80, 0xef, 0xb9, 0xb8, 0x0b, 0xf2, 0x43,
+	0x54, 0x60, 0x59, 0x79, 0xba, 0x75, 0x31, 0x61, 0x20, 0x1e, 0x90, 0x25, 0xb9, 0x6c, 0x34, 0x0d,
+	0xca, 0xff, 0xc7, 0x16, 0xf7, 0xa7, 0x70, 0xa3, 0x60, 0x4a, 0x7e, 0xc6, 0xd4, 0x3c, 0x04, 0x20,
+	0xa1, 0x74, 0xff, 0x3c, 0x5e, 0x8f, 0xbf, 0x1a, 0xf2, 0x13, 0xe2, 0x33, 0xcb, 0xc6, 0xc2, 0x21,
+	0x63, 0xc1, 0xf1, 0x8f, 0x57, 0x1f, 0x32, 0x86, 0xcd, 0xd1, 0x33, 0xa6, 0xfc, 0x4d, 0x02, 0x7c,
+	0xea, 0xe8, 0x04, 0x39, 0x62, 0xfc, 0x04, 0xe5, 0x5d, 0x5e, 0xfe, 0xde, 0x98, 0x11, 0xb6, 0x90,
+	0x7f, 0x0f, 0x6d, 0x45, 0x26, 0x6a, 0x87, 0x9d, 0x25, 0x2f, 0x08, 0x0b, 0x9b, 0xe2, 0x94, 0x7d,
+	0xaa, 0xa7, 0x3c, 0x41, 0x15, 0x3e, 0x67, 0xcb, 0x71, 0x47, 0x83, 0xb1, 0xe8, 0x03, 0x2e, 0xbe,
+	0xb9, 0x38, 0xdf, 0x44, 0x2b, 0x7f, 0x92, 0x42, 0x5b, 0x8b, 0xbd, 0xb1, 0xbd, 0xba, 0x68, 0x67,
+	0xbe, 0xe5, 0x4e, 0x86, 0x96, 0x7b, 0xd1, 0x59, 0x4e, 0xc5, 0x39, 0xcb, 0x3f, 0x46, 0x25, 0x86,
+	0xba, 0x36, 0x5d, 0x32, 0x03, 0xda, 0xe5, 0x61, 0x83, 0x62, 0x2f, 0xfc, 0xf0, 0xe4, 0x7a, 0x70,
+	0x87, 0xf1, 0x5b, 0x67, 0x16, 0xe0, 0x2e, 0xc6, 0xdd, 0xf7, 0xaf, 0x38, 0xbc, 0x17, 0xc1, 0x57,
+	0xc9, 0x46, 0x7c, 0x95, 0xd0, 0x96, 0xe7, 0x22, 0xb6, 0x3c, 0xe2, 0xc3, 0xe4, 0xcf, 0xf8, 0x30,
+	0xbe, 0xc7, 0x82, 0xe2, 0x3d, 0x96, 0xc2, 0xb9, 0x1e, 0x4b, 0x71, 0xb5, 0xc7, 0x52, 0x5a, 0x11,
+	0x19, 0xf8, 0x81, 0xfc, 0x08, 0xe5, 0xdf, 0x00, 0x61, 0x29, 0x34, 0x73, 0x1d, 0x61, 0xe1, 0xb6,
+	0x0b, 0x3c, 0x80, 0x89, 0x7d, 0x9b, 0xb1, 0xb6, 0xf4, 0x6d, 0xc6, 0x04, 0x2c, 0xff, 0x8c, 0x78,
+	0x5d, 0xfc, 0x7a, 0x9c, 0x63, 0x04, 0x7d, 0x4c, 0x54, 0x6f, 0xda, 0xed, 0xfd, 0x9c, 0xd7, 0xb2,
+	0x1b, 0x72, 0x9e, 0x53, 0x58, 0x35, 0x6f, 0x3b, 0x99, 0xcf, 0xa8, 0xef, 0x08, 0xd5, 0x8c, 0xd2,
+	0x9a, 0xcf, 0xe4, 0x9b, 0x80, 0xaa, 0xbc, 0x35, 0xa9, 0xcf, 0xd0, 0x7a, 0xbf, 0x43, 0x60, 0x50,
+	0x7e, 0x91, 0x40, 0x57, 0x17, 0xde, 0x03, 0x5c, 0xf8, 0xb9, 0xcf, 0x4f, 0x10, 0x8b, 0xc6, 0x32,
+	0x45, 0xe4, 0x00, 0x7c, 0xfd, 0xac, 0x99, 0x13, 0x65, 0x89, 0x11, 0x6d, 0xc0, 0xe4, 0x7a, 0x1b,
+	0x0c, 0x3f, 0x97, 0x92, 0x10, 0x46, 0x2e, 0x70, 0x1a, 0x05, 0xd6, 0x7f, 0x4c, 0xf0, 0x97, 0x49,
+	0x91, 0x4e, 0xfc, 0xe3, 0xff, 0x31, 0x5a, 0x7f, 0x3b, 0x9f, 0xcc, 0xba, 0xb6, 0x73, 0xf2, 0xa6,
+	0x3b, 0xf7, 0xe0, 0x3e, 0xcd, 0xe3, 0x36, 0x65, 0x4a, 0xd6, 0x7c, 0xea, 0x42, 0xc4, 0x79, 0xed,
+	0xbd, 0x23, 0xce, 0x31, 0x31, 0xe3, 0xe4, 0x45, 0x63, 0xc6, 0x3a, 0xaa, 0xd0, 0x35, 0x35, 0xc1,
+	0xe7, 0xe3, 0xeb, 0xf2, 0x7c, 0xe9, 0x8b, 0x5a, 0x9d, 0x58, 0xa9, 0xd5, 0x44, 0x61, 0x25, 0x51,
+	0x3e, 0xd4, 0x1d, 0xbf, 0xc0, 0x0e, 0x5e, 0x18, 0xe1, 0x42, 0x78, 0x60, 0xf7, 0x98, 0x98, 0x18,
+	0x63, 0xf4, 0xb6, 0xbf, 0x8d, 0x72, 0xa3, 0x6e, 0x2f, 0xbc, 0xe8, 0xe7, 0x71, 0x16, 0xbe, 0x97,
+	0x3f, 0x64, 0xca, 0xc6, 0x1e, 0x16, 0xc5, 0xe2, 0x7a, 0x10, 0x95, 0x19, 0xd7, 0x83, 0x47, 0x28,
+	0xc7, 0x7b, 0xf0, 0x4d, 0xd8, 0xb5, 0x25, 0x4a, 0x48, 0xe4, 0x83, 0x03, 0xe6, 0x7b, 0x1f, 0xa1,
+	0x2c, 0x17, 0x29, 0x89, 0xbc, 0x58, 0xfb, 0xed, 0xb6, 0xdd, 0xa4, 0x41, 0x79, 0x12, 0x9b, 0x26,
+	0x5f, 0xcf, 0x9b, 0xaa, 0x21, 0x25, 0xee, 0xfd, 0x55, 0x1e, 0x15, 0xc5, 0x6b, 0xbc, 0xbc, 0x8e,
+	0x0a, 0xe6, 0xbe, 0x19, 0x04, 0x90, 0x2f, 0x91, 0xa0, 0x35, 0xc9, 0x6d, 0xf2, 0x6f, 0x1a, 0xc4,
+	0x86, 0x9e, 0xfd, 0xef, 0x35, 0x1a, 0xd4, 0x6e, 0x04, 0xdf, 0x49, 0xd2, 0x41, 0xbb, 0x79, 0x18,
+	0x74, 0x90, 0x22, 0xc1, 0xe6, 0x66, 0xcb, 0x34, 0xed, 0x56, 0x83, 0x27, 0x2c, 0xa5, 0x34, 0xcd,
+	0x17, 0x6b, 0x35, 0x92, 0xf2, 0x7c, 0x29, 0xd0, 0x33, 0xe4, 0xc5, 0x88, 0xde, 0xb6, 0x6b, 0x6a,
+	0xd0, 0x3c, 0x4b, 0x32, 0x7b, 0xe1, 0xf8, 0xb6, 0xf6, 0xa2, 0xa6, 0x69, 0x75, 0x9a, 0xde, 0x13,
+	0x33, 0x8a, 0x52, 0x81, 0xcd, 0x4b, 0xf7, 0xdb, 0x15, 0x49, 0x0e, 0x99, 0x66, 0x15, 0x83, 0xdc,
+	0x2d, 0xaf, 0x29, 0xf1, 0x1c, 0xa0, 0xf6, 0x4c, 0x33, 0x2c, 0xdb, 0xc2, 0xfa, 0xfe, 0xbe, 0x86,
+	0x4d, 0xa9, 0x4c, 0x5f, 0xab, 0x74, 0x2c, 0x32, 0x1d, 0x96, 0xd2, 0x94, 0xd6, 0x69, 0xc6, 0x51,
+	0x13, 0xd2, 0xbf, 0x61, 0x9d, 0xc4, 0x72, 0xd4, 0x61, 0xc6, 0x97, 0xc6, 0xea, 0xa1, 0xbd, 0x74,
+	0x99, 0xb4, 0xea, 0x68, 0x36, 0xac, 0x83, 0xa7, 0x52, 0xfd, 0x04, 0xb2, 0x26, 0xc9, 0xa0, 0x34,
+	0x9b, 0xd1, 0x3a, 0xac, 0x35, 0x35, 0xd5, 0xd4, 0xa4, 0x0d, 0x00, 0x8d, 0x1b, 0x75, 0xad, 0xa1,
+	0x76, 0x9a, 0x96, 0xad, 0xb5, 0x4d, 0x3f, 0xb9, 0x2b, 0xc8, 0xfe, 0x4a, 0x98, 0xc8, 0xe5, 0x94,
+	0x4d, 0x59, 0x41, 0x1f, 0x08, 0x49, 0xe8, 0x98, 0x94, 0xb5, 0x74, 0x95, 0x74, 0x1c, 0x54, 0x1c,
+	0xb6, 0xea, 0x7a, 0xc3, 0x4f, 0x2c, 0x93, 0x8c, 0x80, 0x66, 0x5a, 0xd2, 0x16, 0x4d, 0x46, 0x43,
+	0xb7, 0x16, 0x56, 0x81, 0x87, 0xa7, 0x72, 0xa5, 0x0a, 0xc9, 0x28, 0xc3, 0x6c, 0xc9, 0xca, 0xec,
+	0x57, 0x2d, 0x43, 0xf3, 0x87, 0xdd, 0xa6, 0x9b, 0x1e, 0x0a, 0x7b, 0x87, 0x6c, 0xba, 0x56, 0xdb,
+	0x0f, 0x08, 0xd7, 0xc8, 0x98, 0x50, 0xc6, 0xfb, 0x2c, 0x2b, 0x81, 0x61, 0x95, 0x6c, 0x48, 0xd8,
+	0x3f, 0xc6, 0x72, 0x9d, 0xb0, 0xa8, 0x6d, 0xc3, 0x56, 0x0f, 0xf7, 0x70, 0x74, 0x5a, 0x7e, 0x92,
+	0xfd, 0x06, 0x4d, 0xb2, 0x93, 0x3d, 0xac, 0x99, 0xfb, 0x62, 0x1e, 0xd7, 0x1f, 0xe6, 0x03, 0x22,
+	0x90, 0x8e, 0xa9, 0xee, 0x93, 0x5c, 0x30, 0xcd, 0xe4, 0xde, 0x96, 0x77, 0xd1, 0xa7, 0x4b, 0xa4,
+	0x18, 0x3b, 0x86, 0x22, 0x7f, 0x89, 0x3e, 0x0f, 0xc6, 0x38, 0x78, 0xb9, 0x87, 0xf5, 0xba, 0x6d,
+	0x76, 0xf6, 0xcc, 0x1a, 0xd6, 0xf7, 0xb4, 0x7a, 0xdc, 0xa8, 0x77, 0xe4, 0xaf, 0xd0, 0xee, 0xd9,
+	0x26, 0xe4, 0x2d, 0xc0, 0x79, 0x8d, 0x3e, 0x24, 0xb2, 0x8c, 0x64, 0xaf, 0x79, 0xc5, 0x5d, 0x22,
+	0x7b, 0x31, 0xdb, 0x6f, 0x5a, 0x2a, 0x2c, 0xe4, 0x63, 0x92, 0xeb, 0x89, 0x92, 0x5b, 0x6d, 0xe9,
+	0x13, 0xc2, 0x5c, 0xa3, 0xaf, 0x06, 0xda, 0xc2, 0xab, 0x81, 0x7b, 0x24, 0x55, 0x0f, 0x1b, 0x45,
+	0xf6, 0xbc, 0x29, 0x2a, 0x17, 0x1f, 0xe3, 0x53, 0xf0, 0x4c, 0xae, 0x1f, 0x68, 0xc6, 0xde, 0x52,
+	0x8e, 0xcf, 0x48, 0x0f, 0x3c, 0x61, 0x6e, 0x68, 0xd6, 0xf3, 0x16, 0x7e, 0x42, 0x57, 0xe1, 0xcb,
+	0xf5, 0x73, 0x70, 0x00, 0x6f, 0xf3, 0x4c, 0xff, 0xa1, 0x6a, 0x80, 0xc4, 0x0f, 0xc9, 0xe9, 0xf1,
+	0x1f, 0x7d, 0xf9, 0xd2, 0xac, 0x92, 0x83, 0xed, 0x8b, 0x5f, 0xd0, 0xdc, 0x5d, 0x70, 0x0c, 0x1f,
+	0xf1, 0x13, 0x0c, 0x67, 0x08, 0xa6, 0xda, 0x86, 0xd1, 0x35, 0x83, 0x3c, 0x0b, 0x31, 0xc2, 0x32,
+	0x1b, 0x8c, 0x1e, 0x6e, 0x38, 0x76, 0xfe, 0xd8, 0x5f, 0x10, 0xed, 0x22, 0xf2, 0xa5, 0xc9, 0x7a,
+	0xad, 0x2e, 0x7d, 0x79, 0xef, 0xef, 0x13, 0x28, 0xf9, 0xb4, 0xa6, 0x93, 0xbc, 0x24, 0xfc, 0xd8,
+	0x5f, 0x00, 0x4c, 0xf1, 0xe2, 0x97, 0x80, 0x50, 0xbc, 0x78, 0x1f, 0xc0, 0x89, 0x17, 0xbf, 0x02,
+	0x5c, 0xe2, 0xc5, 0xaf, 0x01, 0x91, 0x78, 0xf1, 0x01, 0x00, 0x11, 0x2f, 0x3e, 0x04, 0xec, 0xe1,
+	0xc5, 0x47, 0x80, 0x39, 0xbc, 0xf8, 0x58, 0xca, 0xf9, 0xc5, 0x6f, 0xa4, 0x3c, 0x49, 0x38, 0x50,
+	0xde, 0x07, 0x92, 0x1a, 0x94, 0x1f, 0x4a, 0x7b, 0x41, 0xf9, 0x91, 0x54, 0xf3, 0xcb, 0x8f, 0xbe,
+	0x90, 0x1a, 0x41, 0xf9, 0x81, 0xf4, 0x24, 0x28, 0x7f, 0x23, 0xb5, 0xee, 0x39, 0x24, 0x91, 0x11,
+	0xbe, 0x1a, 0xfa, 0x35, 0x3d, 0xb5, 0xbb, 0xf7, 0x18, 0xad, 0x9f, 0x89, 0xe9, 0x13, 0x2e, 0xbf,
+	0x71, 0x13, 0xf0, 0xaf, 0xc9, 0x9e, 0x17, 0xb6, 0x6b, 0x35, 0xa6, 0x92, 0x8c, 0x96, 0xb8, 0xff,
+	0x67, 0x29, 0xb4, 0x21, 0xda, 0x96, 0x43, 0xf6, 0x3a, 0x9d, 0xb8, 0x08, 0xd8, 0x99, 0x4e, 0xdc,
+	0x19, 0xb9, 0xa8, 0x92, 0xfb, 0xba, 0x27, 0xef, 0xc4, 0x3e, 0xcb, 0xa6, 0x6f, 0xb8, 0x77, 0x2e,
+	0xf3, 0x3a, 0xfa, 0x84, 0xbd, 0xfa, 0x6c, 0x32, 0xe8, 0x2b, 0x97, 0xe4, 0x9f, 0xa1, 0x52, 0x24,
+	0x3a, 0x20, 0x7f, 0xb8, 0x22, 0x78, 0x40, 0xbd, 0x87, 0x9d, 0xbb, 0xdf, 0x2b, 0xc4, 0x00, 0xfd,
+	0x3f, 0x41, 0x28, 0xf4, 0xfc, 0xe4, 0x65, 0x3e, 0xc2, 0x8e, 0x72, 0xb6, 0xbf, 0x98, 0xe7, 0xa3,
+	0x97, 0xe4, 0x57, 0x60, 0xa6, 0xe8, 0x82, 0x23, 0x5e, 0xf3, 0xb9, 0x7e, 0xe0, 0xce, 0x87, 0xe7,
+	0x7a, 0x89, 0x61, 0xdf, 0x3f, 0x43, 0x1b, 0xe1, 0x98, 0xcf, 0x07, 0xb3, 0x37, 0xdc, 0x75, 0x3c,
+	0x6f, 0x62, 0x4c, 0x16, 0xdf, 0x6f, 0xee, 0xbf, 0x83, 0x8a, 0xa2, 0x4b, 0x21, 0xdf, 0x39, 0xdb,
+	0x2a, 0xc6, 0x49, 0x5b, 0x9c, 0x7c, 0x9c, 0x57, 0xa2, 0x5c, 0xba, 0xff, 0x0f, 0x70, 0xeb, 0xe6,
+	0xe4, 0xb6, 0x3b, 0x39, 0x39, 0x65, 0x55, 0x7d, 0xd0, 0x91, 0x4e, 0xf8, 0x18, 0x83, 0x29, 0xb9,
+	0x7c, 0x6b, 0xd5, 0x4b, 0xd8, 0x9d, 0x9b, 0x2b, 0x5e, 0xa9, 0xc2, 0x6a, 0x5a, 0xa8, 0x28, 0x3e,
+	0x60, 0x93, 0x3f, 0x58, 0xf2, 0xb2, 0xcd, 0xef, 0xf2, 0xc6, 0xb9, 0x2f, 0xdf, 0x60, 0x05, 0xbf,
+	0x58, 0x43, 0x95, 0x1a, 0xf8, 0x3d, 0x6e, 0xb0, 0x41, 0x3c, 0x30, 0x35, 0x84, 0x45, 0x58, 0x67,
+	0x95, 0xf4, 0x4c, 0xe0, 0x60, 0x51, 0x3f, 0x6f, 0x2d, 0x67, 0x08, 0x76, 0x04, 0x7a, 0x8d, 0x44,
+	0x2a, 0x22, 0xbd, 0xc6, 0x05, 0x59, 0x22, 0xbd, 0xc6, 0x06, 0x39, 0xa0, 0xd7, 0xdf, 0x45, 0x52,
+	0x70, 0xe1, 0xf7, 0x3b, 0x16, 0x35, 0x64, 0x49, 0x50, 0x60, 0xe7, 0xce, 0xb9, 0x3c, 0x7e, 0xf7,
+	0x7b, 0xd7, 0x5e, 0x6d, 0x53, 0xbe, 0x5d, 0xf2, 0x97, 0x92, 0xde, 0x70, 0x32, 0xef, 0xef, 0x1e,
+	0x4f, 0xf8, 0x7f, 0x4b, 0x8e, 0x32, 0xf4, 0xf7, 0xab, 0xff, 0x05, 0x05, 0x96, 0x9b, 0xda, 0xd3,
+	0x32, 0x00, 0x00,
 }
@@ -1009,6 +1009,105 @@ func (m *GetAllSubscriberDataResponse) GetSubscribers() []*SubscriberData {
 	return nil
 }
 
+// --------------------------------------------------------------------------
+// MAC allow-list of the devices authenticated by MAC Authentication Bypass
+// (MAB), e.g. IoT devices which don't support EAP
+// --------------------------------------------------------------------------
+type MACLookup struct {
+	// Device's MAC address, lower case & colon separated (e.g. 0a:1b:2c:3d:4e:5f)
+	MacAddr              string   `protobuf:"bytes,1,opt,name=mac_addr,json=macAddr,proto3" json:"mac_addr,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MACLookup) Reset()         { *m = MACLookup{} }
+func (m *MACLookup) String() string { return proto.CompactTextString(m) }
+func (*MACLookup) ProtoMessage()    {}
+func (*MACLookup) Descriptor() ([]byte, []int) {
+	return fileDescriptor_subscriberdb_ab4369fc4534f12d, []int{12}
+}
+func (m *MACLookup) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MACLookup.Unmarshal(m, b)
+}
+func (m *MACLookup) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MACLookup.Marshal(b, m, deterministic)
+}
+func (dst *MACLookup) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MACLookup.Merge(dst, src)
+}
+func (m *MACLookup) XXX_Size() int {
+	return xxx_messageInfo_MACLookup.Size(m)
+}
+func (m *MACLookup) XXX_DiscardUnknown() {
+	xxx_messageInfo_MACLookup.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MACLookup proto.InternalMessageInfo
+
+func (m *MACLookup) GetMacAddr() string {
+	if m != nil {
+		return m.MacAddr
+	}
+	return ""
+}
+
+type MACAllowListEntry struct {
+	MacAddr string `protobuf:"bytes,1,opt,name=mac_addr,json=macAddr,proto3" json:"mac_addr,omitempty"`
+	// Subscriber the device's sessions are created for & accounted to
+	Sid *SubscriberID `protobuf:"bytes,2,opt,name=sid,proto3" json:"sid,omitempty"`
+	// APN of the device's sessions, empty - the APN requested by the NAS
+	Apn                  string   `protobuf:"bytes,3,opt,name=apn,proto3" json:"apn,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MACAllowListEntry) Reset()         { *m = MACAllowListEntry{} }
+func (m *MACAllowListEntry) String() string { return proto.CompactTextString(m) }
+func (*MACAllowListEntry) ProtoMessage()    {}
+func (*MACAllowListEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_subscriberdb_ab4369fc4534f12d, []int{13}
+}
+func (m *MACAllowListEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MACAllowListEntry.Unmarshal(m, b)
+}
+func (m *MACAllowListEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MACAllowListEntry.Marshal(b, m, deterministic)
+}
+func (dst *MACAllowListEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MACAllowListEntry.Merge(dst, src)
+}
+func (m *MACAllowListEntry) XXX_Size() int {
+	return xxx_messageInfo_MACAllowListEntry.Size(m)
+}
+func (m *MACAllowListEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_MACAllowListEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MACAllowListEntry proto.InternalMessageInfo
+
+func (m *MACAllowListEntry) GetMacAddr() string {
+	if m != nil {
+		return m.MacAddr
+	}
+	return ""
+}
+
+func (m *MACAllowListEntry) GetSid() *SubscriberID {
+	if m != nil {
+		return m.Sid
+	}
+	return nil
+}
+
+func (m *MACAllowListEntry) GetApn() string {
+	if m != nil {
+		return m.Apn
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*SubscriberID)(nil), "magma.lte.SubscriberID")
 	proto.RegisterType((*SubscriberIDSet)(nil), "magma.lte.SubscriberIDSet")
@@ -1023,6 +1122,8 @@ func init() {
 	proto.RegisterType((*SubscriberUpdate)(nil), "magma.lte.SubscriberUpdate")
 	proto.RegisterType((*SubscriberLookup)(nil), "magma.lte.SubscriberLookup")
 	proto.RegisterType((*GetAllSubscriberDataResponse)(nil), "magma.lte.GetAllSubscriberDataResponse")
+	proto.RegisterType((*MACLookup)(nil), "magma.lte.MACLookup")
+	proto.RegisterType((*MACAllowListEntry)(nil), "magma.lte.MACAllowListEntry")
 	proto.RegisterEnum("magma.lte.AccessNetworkIdentifier", AccessNetworkIdentifier_name, AccessNetworkIdentifier_value)
 	proto.RegisterEnum("magma.lte.SubscriberID_IDType", SubscriberID_IDType_name, SubscriberID_IDType_value)
 	proto.RegisterEnum("magma.lte.GSMSubscription_GSMSubscriptionState", GSMSubscription_GSMSubscriptionState_name, GSMSubscription_GSMSubscriptionState_value)
@@ -1065,6 +1166,10 @@ type SubscriberDBClient interface {
 	// List the subscribers in the store.
 	//
 	ListSubscribers(ctx context.Context, in *protos.Void, opts ...grpc.CallOption) (*SubscriberIDSet, error)
+	// Returns the MAC allow-list entry of a device.
+	// Throws NOT_FOUND if the device's MAC address isn't allowed.
+	//
+	GetMACAllowListEntry(ctx context.Context, in *MACLookup, opts ...grpc.CallOption) (*MACAllowListEntry, error)
 }
 
 type subscriberDBClient struct {
@@ -1120,6 +1225,15 @@ func (c *subscriberDBClient) ListSubscribers(ctx context.Context, in *protos.Voi
 	return out, nil
 }
 
+func (c *subscriberDBClient) GetMACAllowListEntry(ctx context.Context, in *MACLookup, opts ...grpc.CallOption) (*MACAllowListEntry, error) {
+	out := new(MACAllowListEntry)
+	err := c.cc.Invoke(ctx, "/magma.lte.SubscriberDB/GetMACAllowListEntry", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SubscriberDBServer is the server API for SubscriberDB service.
 type SubscriberDBServer interface {
 	// Adds a new subscriber to the store.
@@ -1141,6 +1255,10 @@ type SubscriberDBServer interface {
 	// List the subscribers in the store.
 	//
 	ListSubscribers(context.Context, *protos.Void) (*SubscriberIDSet, error)
+	// Returns the MAC allow-list entry of a device.
+	// Throws NOT_FOUND if the device's MAC address isn't allowed.
+	//
+	GetMACAllowListEntry(context.Context, *MACLookup) (*MACAllowListEntry, error)
 }
 
 func RegisterSubscriberDBServer(s *grpc.Server, srv SubscriberDBServer) {
@@ -1237,6 +1355,24 @@ func _SubscriberDB_ListSubscribers_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SubscriberDB_GetMACAllowListEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MACLookup)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SubscriberDBServer).GetMACAllowListEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.lte.SubscriberDB/GetMACAllowListEntry",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SubscriberDBServer).GetMACAllowListEntry(ctx, req.(*MACLookup))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _SubscriberDB_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "magma.lte.SubscriberDB",
 	HandlerType: (*SubscriberDBServer)(nil),
@@ -1261,6 +1397,10 @@ var _SubscriberDB_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ListSubscribers",
 			Handler:    _SubscriberDB_ListSubscribers_Handler,
 		},
+		{
+			MethodName: "GetMACAllowListEntry",
+			Handler:    _SubscriberDB_GetMACAllowListEntry_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "lte/protos/subscriberdb.proto",
@@ -1530,99 +1670,102 @@ func init() {
 }
 
 var fileDescriptor_subscriberdb_ab4369fc4534f12d = []byte{
-	// 1493 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x57, 0xdd, 0x72, 0xdb, 0x36,
-	0x16, 0xd6, 0x9f, 0x7f, 0x74, 0x64, 0xcb, 0x34, 0xc6, 0xb1, 0x65, 0x39, 0xde, 0x78, 0x99, 0xd9,
-	0x5d, 0x27, 0xd9, 0xc8, 0x19, 0x79, 0x93, 0xcd, 0x6e, 0x66, 0xda, 0x52, 0x96, 0xe2, 0x70, 0x2a,
-	0xd1, 0x2a, 0x24, 0x3b, 0x6d, 0x7a, 0xc1, 0x81, 0x44, 0x58, 0xe1, 0x98, 0x7f, 0x26, 0xa1, 0xc4,
-	0x7e, 0x90, 0xbe, 0x40, 0x2f, 0xfb, 0x02, 0xbd, 0xeb, 0x23, 0xf4, 0xb2, 0x37, 0xbd, 0xef, 0x73,
-	0x74, 0x00, 0x92, 0x12, 0x2d, 0x4b, 0x6a, 0xdc, 0x5e, 0x89, 0x38, 0xe7, 0x3b, 0x1f, 0x0e, 0x3e,
-	0x9c, 0x03, 0x40, 0xb0, 0x6b, 0x31, 0x7a, 0xe0, 0xf9, 0x2e, 0x73, 0x83, 0x83, 0x60, 0xd8, 0x0b,
-	0xfa, 0xbe, 0xd9, 0xa3, 0xbe, 0xd1, 0xab, 0x08, 0x1b, 0xca, 0xdb, 0x64, 0x60, 0x93, 0x8a, 0xc5,
-	0x68, 0x79, 0xdb, 0xf5, 0xfb, 0x2f, 0xfd, 0x18, 0xdb, 0x77, 0x6d, 0xdb, 0x75, 0x42, 0x54, 0x79,
-	0x6f, 0xe0, 0xba, 0x03, 0x2b, 0xe2, 0xe9, 0x0d, 0xcf, 0x0f, 0xce, 0x4d, 0x6a, 0x19, 0xba, 0x4d,
-	0x82, 0x8b, 0x10, 0x21, 0x9f, 0xc3, 0x4a, 0x67, 0xc4, 0xae, 0xd6, 0x51, 0x11, 0x32, 0xa6, 0x51,
-	0x4a, 0xef, 0xa5, 0xf7, 0xf3, 0x38, 0x63, 0x1a, 0xa8, 0x0a, 0x39, 0x76, 0xed, 0xd1, 0x52, 0x66,
-	0x2f, 0xbd, 0x5f, 0xac, 0xfe, 0xad, 0x32, 0x9a, 0xb6, 0x92, 0x0c, 0xab, 0xa8, 0xf5, 0xee, 0xb5,
-	0x47, 0xb1, 0xc0, 0xca, 0x08, 0x16, 0xc3, 0x31, 0x5a, 0x86, 0x9c, 0xda, 0xea, 0xa8, 0x52, 0x4a,
-	0xfe, 0x0c, 0xd6, 0x92, 0x01, 0x1d, 0xca, 0xd0, 0x13, 0xc8, 0x05, 0xa6, 0x11, 0x94, 0xd2, 0x7b,
-	0xd9, 0xfd, 0x42, 0x75, 0x6b, 0x06, 0x35, 0x16, 0x20, 0xf9, 0xc7, 0x0c, 0xac, 0x1d, 0x77, 0x5a,
-	0x91, 0xc7, 0x63, 0xa6, 0xeb, 0xa0, 0x06, 0x2c, 0x04, 0x8c, 0x30, 0x2a, 0xd2, 0x2d, 0x56, 0x0f,
-	0x12, 0x0c, 0x13, 0xd0, 0xc9, 0x71, 0x87, 0x87, 0xe1, 0x30, 0x1a, 0x1d, 0x41, 0x9e, 0x0c, 0xd9,
-	0x7b, 0x9d, 0x58, 0x03, 0x37, 0x5a, 0xe7, 0x3f, 0xe7, 0x53, 0x29, 0x43, 0xf6, 0x5e, 0xb1, 0x06,
-	0x2e, 0x5e, 0x26, 0xd1, 0x17, 0xda, 0x06, 0xf1, 0xad, 0x5f, 0xd0, 0xeb, 0x52, 0x76, 0x2f, 0xbd,
-	0xbf, 0x82, 0x97, 0xf8, 0xf8, 0x4b, 0x7a, 0x8d, 0x1e, 0x40, 0x41, 0xb8, 0xd8, 0xd0, 0xb3, 0x68,
-	0x50, 0xca, 0xed, 0x65, 0xf7, 0x57, 0x30, 0x70, 0x53, 0x57, 0x58, 0xe4, 0x67, 0xb0, 0x31, 0x2d,
-	0x3f, 0xb4, 0x02, 0xcb, 0xaa, 0xa6, 0x1c, 0x75, 0xd5, 0xb3, 0x86, 0x94, 0x42, 0x00, 0x8b, 0xd1,
-	0x77, 0x5a, 0x7e, 0x0c, 0x85, 0x44, 0x1a, 0x68, 0x07, 0xb6, 0xda, 0xb8, 0x71, 0x74, 0xd2, 0x6a,
-	0x9f, 0x76, 0x1b, 0x75, 0x5d, 0x39, 0xed, 0xbe, 0xd1, 0xbb, 0xa7, 0xed, 0x66, 0xa3, 0x23, 0xa5,
-	0xe4, 0xef, 0x33, 0xb0, 0xd6, 0xec, 0x36, 0x3e, 0x55, 0xb9, 0x09, 0xe8, 0xe4, 0xf8, 0x2e, 0xca,
-	0x4d, 0xa1, 0xba, 0x9b, 0x72, 0xb1, 0xcb, 0xf5, 0xfa, 0xa5, 0xdc, 0xd8, 0x75, 0xe2, 0xf5, 0xb9,
-	0x66, 0xd3, 0x32, 0x9b, 0xa3, 0xd9, 0x0e, 0x14, 0x12, 0x09, 0x70, 0x60, 0x4b, 0x6d, 0x36, 0x34,
-	0xe5, 0xb8, 0x21, 0xa5, 0xe4, 0x1f, 0xd2, 0xc9, 0xfa, 0x0c, 0xa9, 0x1e, 0xc1, 0xba, 0xc5, 0xa8,
-	0x2e, 0x32, 0x70, 0xe8, 0x15, 0xd3, 0x03, 0x7a, 0x29, 0x04, 0xcb, 0xe1, 0xa2, 0xc5, 0x28, 0x67,
-	0xd2, 0xe8, 0x15, 0xeb, 0xd0, 0x4b, 0x74, 0x00, 0x1b, 0x6c, 0xe0, 0x79, 0x3a, 0x21, 0x44, 0x0f,
-	0xa8, 0xff, 0x81, 0xfa, 0xba, 0x43, 0xec, 0xb0, 0x6b, 0xf2, 0x78, 0x9d, 0xfb, 0x14, 0x42, 0x3a,
-	0xc2, 0xa3, 0x11, 0x9b, 0xa2, 0x57, 0x50, 0x9e, 0x0c, 0xf0, 0xe9, 0xc0, 0x0c, 0x18, 0xf5, 0xa9,
-	0x21, 0x64, 0x58, 0xc6, 0x5b, 0x37, 0xc2, 0xf0, 0xc8, 0x2d, 0x7f, 0x97, 0x03, 0x49, 0x69, 0x6b,
-	0x47, 0xae, 0x73, 0x6e, 0x0e, 0x86, 0x3e, 0x11, 0x5b, 0xba, 0x0b, 0xd0, 0x77, 0x1d, 0xc6, 0xf3,
-	0x8c, 0x1a, 0x78, 0x15, 0xe7, 0x23, 0x8b, 0x6a, 0xa0, 0x27, 0xb0, 0xce, 0xe7, 0x31, 0xfb, 0x54,
-	0x0f, 0xa8, 0x45, 0xfb, 0x3c, 0x26, 0x4a, 0x4f, 0x8a, 0x1c, 0x9d, 0xd8, 0x8e, 0x8e, 0xa1, 0x70,
-	0xe9, 0x06, 0xba, 0xe7, 0xbb, 0xe7, 0xa6, 0x45, 0x45, 0x3a, 0x85, 0x1b, 0x3b, 0x3b, 0x39, 0x7b,
-	0xe5, 0x2b, 0xb7, 0xd3, 0x0e, 0xd1, 0x18, 0x2e, 0xdd, 0x20, 0xfa, 0x46, 0xff, 0x85, 0x1c, 0xb1,
-	0x7b, 0xbe, 0xd8, 0xbc, 0x42, 0xf5, 0x61, 0x92, 0x61, 0x30, 0xf0, 0xe9, 0x80, 0x30, 0x6a, 0xb4,
-	0xc8, 0x95, 0x69, 0x0f, 0xed, 0x9a, 0xc9, 0x7c, 0x5e, 0x5a, 0x22, 0x00, 0x3d, 0x87, 0xac, 0x67,
-	0x38, 0xa5, 0x05, 0x51, 0x53, 0x0f, 0xe7, 0xcd, 0xdc, 0xae, 0x6b, 0xe2, 0xe8, 0xe1, 0xf8, 0xf2,
-	0x4f, 0x69, 0x80, 0x71, 0x2a, 0xbc, 0x7e, 0xfa, 0x16, 0x09, 0x82, 0x58, 0x91, 0x05, 0xbc, 0x24,
-	0xc6, 0xaa, 0x81, 0xfe, 0x01, 0x45, 0xcf, 0x37, 0x5d, 0xdf, 0x64, 0xd7, 0xba, 0x45, 0x3f, 0x50,
-	0x4b, 0x88, 0xb1, 0x8a, 0x57, 0x63, 0x6b, 0x93, 0x1b, 0xd1, 0x21, 0xdc, 0xf3, 0x7c, 0x4a, 0x6d,
-	0x51, 0x61, 0x7a, 0x9f, 0x78, 0xa4, 0x67, 0x5a, 0x26, 0xbb, 0x8e, 0xb6, 0x68, 0x63, 0xec, 0x3c,
-	0x1a, 0xf9, 0xd0, 0xff, 0xa0, 0x94, 0x08, 0xfa, 0x30, 0xb4, 0x1c, 0xea, 0xc7, 0x71, 0xb9, 0x70,
-	0x6b, 0xc7, 0xfe, 0xb3, 0xa4, 0x5b, 0x7e, 0x05, 0x4b, 0xd1, 0x82, 0xc4, 0xd9, 0xd9, 0x3e, 0xfb,
-	0x8f, 0x94, 0x8a, 0xbe, 0x5e, 0x48, 0x69, 0x5e, 0xcf, 0xdc, 0x76, 0xf6, 0x42, 0xca, 0x20, 0x09,
-	0x56, 0xf8, 0xb7, 0x7e, 0x82, 0x75, 0xe1, 0xcd, 0xca, 0x0e, 0x94, 0x66, 0xc9, 0x8a, 0xf6, 0x41,
-	0xb2, 0xc9, 0x95, 0xde, 0x23, 0x8e, 0xf1, 0xd1, 0x34, 0xd8, 0x7b, 0x7d, 0x68, 0x45, 0x45, 0x52,
-	0xb4, 0xc9, 0x55, 0x2d, 0x36, 0x9f, 0x5a, 0xb7, 0x91, 0x46, 0xac, 0xcd, 0x0d, 0x64, 0xdd, 0x92,
-	0x7f, 0xce, 0x01, 0xd2, 0x5c, 0xe7, 0xf0, 0xb8, 0xdd, 0x3e, 0x0d, 0xa8, 0x1f, 0xab, 0xbe, 0x09,
-	0x8b, 0x76, 0x60, 0x06, 0x86, 0x13, 0x5d, 0x23, 0xd1, 0x08, 0xbd, 0x03, 0xe4, 0xb8, 0x8e, 0x7e,
-	0xc8, 0xeb, 0xde, 0xf4, 0x74, 0xd2, 0xef, 0xd3, 0x20, 0x88, 0x8e, 0x8d, 0xa7, 0x89, 0x2d, 0xbe,
-	0x4d, 0x19, 0x9b, 0xd4, 0xb6, 0x22, 0x82, 0xf0, 0x9a, 0xe3, 0x3a, 0x9c, 0x47, 0xf5, 0x42, 0x03,
-	0x32, 0x60, 0xf3, 0x36, 0xb7, 0x4e, 0x3c, 0x47, 0x6c, 0x54, 0xb1, 0xfa, 0xec, 0x4e, 0xfc, 0x4a,
-	0x5b, 0xc3, 0x68, 0x62, 0x0a, 0xc5, 0x73, 0xfe, 0x7c, 0x39, 0xff, 0x1f, 0x80, 0x78, 0x8e, 0xde,
-	0x17, 0x95, 0x2b, 0xaa, 0xba, 0x50, 0xdd, 0x99, 0x53, 0xd5, 0x38, 0x4f, 0x3c, 0x27, 0xb4, 0xa0,
-	0xd7, 0xb0, 0x1a, 0x2d, 0xc7, 0xa1, 0xa2, 0xb7, 0x17, 0xc5, 0x8a, 0xe4, 0x64, 0xb8, 0xf0, 0x6b,
-	0x94, 0x7d, 0x74, 0xfd, 0x0b, 0xd5, 0xa0, 0x0e, 0x33, 0xcf, 0x4d, 0xea, 0xe3, 0x02, 0x89, 0x1d,
-	0xaa, 0x21, 0x9f, 0xc1, 0xda, 0xc4, 0x32, 0xd1, 0xdf, 0x61, 0x57, 0x3b, 0xd1, 0x74, 0x6e, 0xd3,
-	0x3b, 0xa7, 0xb5, 0xce, 0x11, 0x56, 0xdb, 0x5d, 0xf5, 0x44, 0xd3, 0x95, 0x66, 0xf3, 0xe4, 0x6d,
-	0xa3, 0x2e, 0xa5, 0xd0, 0x1e, 0xdc, 0x9f, 0x0e, 0xa9, 0x29, 0x18, 0x37, 0xea, 0x52, 0x5a, 0x56,
-	0x47, 0x45, 0x90, 0x90, 0x0f, 0x95, 0x60, 0x63, 0x14, 0xa7, 0xb4, 0xb5, 0x8e, 0xde, 0xd0, 0x94,
-	0x5a, 0x93, 0x9f, 0xc9, 0xdb, 0x70, 0xef, 0xa6, 0xa7, 0xae, 0x76, 0x84, 0x2b, 0x2d, 0xff, 0x9a,
-	0x81, 0xe2, 0xf8, 0x14, 0xae, 0x13, 0x46, 0xd0, 0x23, 0xc8, 0x06, 0x51, 0xf7, 0xce, 0x79, 0x23,
-	0x70, 0x0c, 0xfa, 0x37, 0x64, 0x07, 0x81, 0x2d, 0x0a, 0xaa, 0x50, 0x2d, 0xcf, 0xbe, 0xc1, 0x31,
-	0x87, 0x71, 0xb4, 0xc5, 0xe2, 0xb3, 0xad, 0x3c, 0xfb, 0xd6, 0xc2, 0x1c, 0x86, 0x9e, 0x03, 0x38,
-	0xa1, 0xbc, 0x7c, 0x07, 0xc2, 0xfd, 0xdf, 0x8c, 0x82, 0xc4, 0xf3, 0xab, 0x12, 0xab, 0x5f, 0xc7,
-	0x79, 0x27, 0xde, 0x08, 0xf4, 0x2c, 0xbe, 0x67, 0x17, 0x6e, 0x4d, 0x33, 0x71, 0xdb, 0xc4, 0x57,
-	0xea, 0x03, 0x28, 0x04, 0xc3, 0xde, 0xe8, 0xe8, 0x5d, 0x14, 0x1d, 0x04, 0xc1, 0xb0, 0x17, 0x77,
-	0xd7, 0x4b, 0x58, 0x8e, 0x2b, 0xbd, 0xb4, 0x24, 0x58, 0x77, 0xe7, 0xd6, 0x36, 0x5e, 0x8a, 0x0a,
-	0x59, 0xbe, 0x04, 0x69, 0x3c, 0xe9, 0xa9, 0x67, 0xf0, 0xe9, 0x9e, 0x42, 0xce, 0x20, 0x8c, 0x44,
-	0xfa, 0x6e, 0x4f, 0xcd, 0x8f, 0xef, 0x03, 0x16, 0x30, 0x54, 0x81, 0x1c, 0x7f, 0x3b, 0x8e, 0x34,
-	0x0e, 0x9f, 0x97, 0x95, 0xf8, 0x79, 0x59, 0x79, 0xcd, 0x9f, 0x97, 0x2d, 0x12, 0x5c, 0x60, 0x81,
-	0x93, 0x59, 0x72, 0xca, 0xa6, 0xeb, 0x5e, 0x0c, 0xbd, 0x09, 0x29, 0xd3, 0x9f, 0x2a, 0x65, 0x54,
-	0x08, 0x99, 0x3f, 0x2e, 0x04, 0xf9, 0x5b, 0xb8, 0x7f, 0x4c, 0x99, 0x62, 0x59, 0x13, 0x6b, 0xa0,
-	0x81, 0xe7, 0x3a, 0x01, 0xbf, 0x7c, 0x0b, 0xe3, 0x17, 0x75, 0xfc, 0xfe, 0x9c, 0xb3, 0xf6, 0x24,
-	0xfa, 0xf1, 0x6b, 0xd8, 0x9a, 0xd1, 0x6e, 0xfc, 0x9c, 0x7e, 0x83, 0xdb, 0xbc, 0x6b, 0xf2, 0xb0,
-	0xf0, 0x56, 0x6d, 0x29, 0x5f, 0x4b, 0x69, 0x6e, 0x7c, 0xdb, 0x54, 0x34, 0x29, 0xc3, 0x5f, 0x1c,
-	0x8d, 0xee, 0x9b, 0x06, 0xd6, 0x1a, 0x5d, 0x29, 0x5b, 0xfd, 0x2d, 0x93, 0x7c, 0x79, 0xd7, 0x6b,
-	0xe8, 0x73, 0x58, 0x55, 0x0c, 0x63, 0x6c, 0x42, 0xb3, 0x33, 0x2a, 0xaf, 0xdf, 0xd0, 0xeb, 0xcc,
-	0x35, 0x0d, 0x39, 0x85, 0xbe, 0x00, 0xa9, 0x4e, 0x2d, 0xca, 0x68, 0x82, 0x63, 0x96, 0x50, 0xd3,
-	0x19, 0xea, 0x20, 0x85, 0x75, 0x91, 0x60, 0xd8, 0x99, 0xca, 0x10, 0xc2, 0xa6, 0xb3, 0xa8, 0xb0,
-	0x7e, 0x4c, 0xd9, 0x44, 0x1f, 0xcf, 0x4c, 0x64, 0xf6, 0x2a, 0xe5, 0x14, 0xaa, 0xc1, 0x5a, 0xd3,
-	0x0c, 0x12, 0x5c, 0x01, 0xba, 0x3d, 0x65, 0xb9, 0x3c, 0x83, 0xbb, 0x43, 0x99, 0x9c, 0xaa, 0xfe,
-	0x92, 0x85, 0xcd, 0xa4, 0xd0, 0x47, 0xae, 0xc3, 0x7c, 0xd7, 0xb2, 0xa8, 0xff, 0xd7, 0x25, 0xaf,
-	0x4f, 0x91, 0x7c, 0xba, 0x60, 0x61, 0xf1, 0x4f, 0x67, 0xa9, 0x4d, 0x91, 0xfd, 0xae, 0x99, 0xb4,
-	0xa6, 0x89, 0x3e, 0x37, 0x95, 0xb9, 0xc2, 0x1f, 0xdf, 0x16, 0x7e, 0x46, 0x8f, 0xce, 0x57, 0x1f,
-	0x7d, 0x03, 0x1b, 0xd3, 0x7a, 0x71, 0x26, 0xdb, 0xbf, 0x92, 0xe7, 0xf6, 0x9c, 0x26, 0x96, 0x53,
-	0xb5, 0x9d, 0x77, 0xdb, 0x02, 0x7b, 0xc0, 0xff, 0x29, 0xf7, 0x2d, 0x77, 0x68, 0x1c, 0x0c, 0xdc,
-	0xe8, 0x6f, 0x70, 0x6f, 0x51, 0xfc, 0x1e, 0xfe, 0x1e, 0x00, 0x00, 0xff, 0xff, 0xb9, 0x73, 0x87,
-	0x63, 0x47, 0x0f, 0x00, 0x00,
+	// 1541 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa5, 0x58, 0x5f, 0x73, 0xdb, 0x44,
+	0x10, 0x8f, 0x63, 0xe7, 0x8f, 0xd7, 0x89, 0xe3, 0xdc, 0xa4, 0x89, 0xe3, 0x34, 0xd0, 0xaa, 0x43,
+	0x29, 0x85, 0x3a, 0x1d, 0x87, 0x3f, 0x85, 0xce, 0x00, 0x72, 0xec, 0xa6, 0x1a, 0x6c, 0xc7, 0x9c,
+	0x9d, 0x14, 0xca, 0x83, 0x46, 0xb6, 0x2e, 0x46, 0x13, 0x59, 0x52, 0x24, 0xb9, 0x6d, 0x3e, 0x08,
+	0x5f, 0x80, 0x47, 0x9e, 0x99, 0xe1, 0x8d, 0x8f, 0xc0, 0x23, 0x2f, 0x7c, 0x19, 0xf6, 0x4e, 0x92,
+	0xad, 0xf8, 0x5f, 0x1b, 0x78, 0xca, 0xdd, 0xee, 0x6f, 0xf7, 0xf6, 0x76, 0x7f, 0xda, 0x3d, 0x07,
+	0xf6, 0x4d, 0x9f, 0x1d, 0x38, 0xae, 0xed, 0xdb, 0xde, 0x81, 0x37, 0xe8, 0x78, 0x5d, 0xd7, 0xe8,
+	0x30, 0x57, 0xef, 0x14, 0x85, 0x8c, 0xa4, 0xfb, 0x5a, 0xaf, 0xaf, 0x15, 0x11, 0x54, 0xd8, 0xb5,
+	0xdd, 0xee, 0x13, 0x37, 0xc2, 0x76, 0xed, 0x7e, 0xdf, 0xb6, 0x02, 0x54, 0xe1, 0x4e, 0xcf, 0xb6,
+	0x7b, 0x66, 0xe8, 0xa7, 0x33, 0x38, 0x3f, 0x38, 0x37, 0x98, 0xa9, 0xab, 0x7d, 0xcd, 0xbb, 0x08,
+	0x10, 0xd2, 0x39, 0xac, 0xb5, 0x86, 0xde, 0x95, 0x0a, 0xc9, 0xc2, 0xa2, 0xa1, 0xe7, 0x13, 0x77,
+	0x12, 0x0f, 0xd2, 0x14, 0x57, 0xa4, 0x04, 0x29, 0xff, 0xca, 0x61, 0xf9, 0x45, 0x94, 0x64, 0x4b,
+	0xef, 0x15, 0x87, 0xc7, 0x16, 0xe3, 0x66, 0x45, 0xa5, 0xd2, 0x46, 0x14, 0x15, 0x58, 0x89, 0xc0,
+	0x72, 0xb0, 0x27, 0xab, 0x90, 0x52, 0xea, 0x2d, 0x25, 0xb7, 0x20, 0x7d, 0x0d, 0x1b, 0x71, 0x83,
+	0x16, 0xf3, 0xc9, 0xc7, 0x90, 0xf2, 0x0c, 0xdd, 0xc3, 0xc3, 0x92, 0x0f, 0x32, 0xa5, 0x9d, 0x19,
+	0xae, 0xa9, 0x00, 0x49, 0x7f, 0x2c, 0xc2, 0xc6, 0x71, 0xab, 0x1e, 0x6a, 0x1c, 0xdf, 0xb0, 0x2d,
+	0x52, 0x85, 0x25, 0xcf, 0xd7, 0x7c, 0x26, 0xc2, 0xcd, 0x96, 0x0e, 0x62, 0x1e, 0xc6, 0xa0, 0xe3,
+	0xfb, 0x16, 0x37, 0xa3, 0x81, 0x35, 0x39, 0x82, 0xb4, 0x36, 0xf0, 0x7f, 0x56, 0x35, 0xb3, 0x67,
+	0x87, 0xf7, 0xbc, 0x3f, 0xdf, 0x95, 0x8c, 0x70, 0x19, 0xd1, 0x74, 0x55, 0x0b, 0x57, 0x64, 0x17,
+	0xc4, 0x5a, 0xbd, 0x60, 0x57, 0xf9, 0x24, 0xfa, 0x58, 0xa3, 0x2b, 0x7c, 0xff, 0x1d, 0xbb, 0x22,
+	0xef, 0x43, 0x46, 0xa8, 0xfc, 0x81, 0x63, 0x32, 0x2f, 0x9f, 0xc2, 0xeb, 0xae, 0x51, 0xe0, 0xa2,
+	0xb6, 0x90, 0x48, 0x8f, 0x61, 0x6b, 0x5a, 0x7c, 0x64, 0x0d, 0x56, 0x95, 0x86, 0x7c, 0xd4, 0x56,
+	0xce, 0xaa, 0xb9, 0x05, 0x02, 0xb0, 0x1c, 0xae, 0x13, 0xd2, 0x43, 0xc8, 0xc4, 0xc2, 0x20, 0x7b,
+	0xb0, 0xd3, 0xa4, 0xd5, 0xa3, 0x93, 0x7a, 0xf3, 0xb4, 0x5d, 0xad, 0xa8, 0xf2, 0x69, 0xfb, 0xb9,
+	0xda, 0x3e, 0x6d, 0xd6, 0xaa, 0x2d, 0xcc, 0xfc, 0xaf, 0x98, 0xb9, 0x5a, 0xbb, 0xfa, 0xae, 0x99,
+	0x1b, 0x83, 0x8e, 0xef, 0x6f, 0x92, 0xb9, 0x29, 0xae, 0x6e, 0x96, 0xb9, 0x48, 0x65, 0x3b, 0x5d,
+	0x4c, 0xdb, 0x50, 0x75, 0xe2, 0x74, 0x79, 0xce, 0xa6, 0x45, 0x36, 0x27, 0x67, 0x7b, 0x90, 0x89,
+	0x05, 0xc0, 0x81, 0x75, 0xa5, 0x56, 0x6d, 0xc8, 0xc7, 0x08, 0x94, 0x7e, 0x4b, 0xc4, 0xf9, 0x19,
+	0xb8, 0xfa, 0x08, 0x36, 0xf1, 0x16, 0xaa, 0x88, 0xc0, 0x62, 0x6f, 0x7c, 0xd5, 0x63, 0x97, 0x22,
+	0x61, 0x29, 0x9a, 0x45, 0x05, 0xf7, 0xd4, 0x40, 0x71, 0x8b, 0x5d, 0x92, 0x03, 0xd8, 0xf2, 0x7b,
+	0x8e, 0xa3, 0x6a, 0x9a, 0x86, 0x28, 0xf7, 0x15, 0x73, 0x55, 0x4b, 0xeb, 0x07, 0x5f, 0x4d, 0x9a,
+	0x6e, 0x72, 0x9d, 0xac, 0x69, 0x2d, 0xa1, 0x69, 0xa0, 0x82, 0x3c, 0x85, 0xc2, 0xb8, 0x81, 0xcb,
+	0x7a, 0x86, 0xe7, 0x33, 0x97, 0xe9, 0x22, 0x0d, 0xab, 0x74, 0xe7, 0x9a, 0x19, 0x1d, 0xaa, 0xa5,
+	0x5f, 0x52, 0x90, 0x93, 0x9b, 0x8d, 0x23, 0xdb, 0x3a, 0x37, 0x7a, 0x03, 0x57, 0x13, 0x25, 0xdd,
+	0x07, 0xe8, 0xda, 0x96, 0xcf, 0xe3, 0x0c, 0x3f, 0xe0, 0x75, 0x9a, 0x0e, 0x25, 0x8a, 0x8e, 0x1f,
+	0xdb, 0x26, 0x3f, 0xc7, 0xe8, 0x32, 0x3c, 0xcf, 0x64, 0x5d, 0x6e, 0x13, 0x86, 0x97, 0x0b, 0x15,
+	0xad, 0x48, 0x4e, 0x8e, 0x21, 0x73, 0x69, 0x7b, 0x2a, 0x76, 0x88, 0x73, 0xc3, 0x64, 0x22, 0x9c,
+	0xcc, 0xb5, 0xca, 0x8e, 0x9f, 0x5e, 0xfc, 0xde, 0x6e, 0x35, 0x03, 0x34, 0x05, 0x34, 0x0d, 0xd7,
+	0xe4, 0x0b, 0x48, 0x69, 0xfd, 0x8e, 0x2b, 0x8a, 0x97, 0x29, 0xdd, 0x8b, 0x7b, 0xe8, 0xf5, 0xf0,
+	0xc2, 0x98, 0x66, 0xbd, 0xae, 0xbd, 0x31, 0xfa, 0x83, 0x7e, 0xd9, 0xf0, 0x5d, 0x4e, 0x2d, 0x61,
+	0x40, 0x3e, 0x83, 0xa4, 0xa3, 0x5b, 0xf9, 0x25, 0xc1, 0xa9, 0x7b, 0xf3, 0x4e, 0x6e, 0x56, 0x1a,
+	0xa2, 0xf5, 0x70, 0x7c, 0xe1, 0xcf, 0x04, 0xc0, 0x28, 0x14, 0xce, 0x9f, 0xae, 0xa9, 0x79, 0x5e,
+	0x94, 0x91, 0x25, 0xba, 0x22, 0xf6, 0x98, 0x8f, 0x0f, 0x20, 0xeb, 0xb8, 0x86, 0xed, 0x1a, 0xfe,
+	0x95, 0x6a, 0xb2, 0x57, 0xcc, 0x14, 0xc9, 0x58, 0xa7, 0xeb, 0x91, 0xb4, 0xc6, 0x85, 0xe4, 0x10,
+	0x6e, 0x39, 0x2e, 0x63, 0x7d, 0xc1, 0x30, 0xb5, 0xab, 0x39, 0x5a, 0xc7, 0x30, 0x51, 0x1b, 0x96,
+	0x68, 0x6b, 0xa4, 0x3c, 0x1a, 0xea, 0xc8, 0x97, 0x90, 0x8f, 0x19, 0xbd, 0x1a, 0x98, 0x16, 0x73,
+	0x23, 0xbb, 0x54, 0x50, 0xda, 0x91, 0xfe, 0x2c, 0xae, 0x96, 0x9e, 0xc2, 0x4a, 0x78, 0x21, 0xd1,
+	0x3b, 0x9b, 0x67, 0x9f, 0x22, 0x8b, 0x83, 0xd5, 0xe7, 0xb9, 0x04, 0xe7, 0x33, 0x97, 0xe1, 0x7a,
+	0x91, 0xe4, 0x60, 0x8d, 0xaf, 0xd5, 0x13, 0xaa, 0x0a, 0x6d, 0x52, 0xb2, 0x20, 0x3f, 0x2b, 0xad,
+	0xe4, 0x01, 0xe4, 0xfa, 0xda, 0x1b, 0xb5, 0xa3, 0x59, 0xfa, 0x6b, 0x43, 0x47, 0x46, 0x0f, 0xcc,
+	0x90, 0x24, 0x59, 0x94, 0x97, 0x23, 0xf1, 0xa9, 0x39, 0x89, 0xd4, 0xa3, 0xdc, 0x5c, 0x43, 0x56,
+	0x4c, 0xe9, 0xaf, 0x14, 0x90, 0x86, 0x6d, 0x1d, 0x1e, 0x37, 0x9b, 0xa7, 0xc8, 0xa1, 0x28, 0xeb,
+	0xdb, 0xb0, 0xdc, 0xf7, 0x0c, 0x0f, 0xcb, 0x17, 0x8c, 0x91, 0x70, 0x47, 0x5e, 0x02, 0xb1, 0x30,
+	0x1f, 0x87, 0x9c, 0xf7, 0x06, 0x52, 0xbf, 0xdb, 0x65, 0x9e, 0x17, 0xb6, 0x8d, 0x47, 0xb1, 0x12,
+	0x4f, 0xba, 0x8c, 0x44, 0x4a, 0x53, 0x16, 0x46, 0x74, 0x03, 0x1d, 0x71, 0x3f, 0x8a, 0x13, 0x08,
+	0x88, 0x0e, 0xdb, 0x93, 0xbe, 0x55, 0xcd, 0xb1, 0x44, 0xa1, 0xb2, 0xa5, 0xc7, 0x37, 0xf2, 0x8f,
+	0x24, 0xa3, 0x64, 0xec, 0x08, 0xd9, 0xb1, 0xfe, 0x3b, 0x9d, 0xbf, 0x02, 0xc0, 0x58, 0xd4, 0xae,
+	0x60, 0xae, 0x60, 0x75, 0xa6, 0xb4, 0x37, 0x87, 0xd5, 0x34, 0x8d, 0xf0, 0x40, 0x42, 0x9e, 0xc1,
+	0x7a, 0x78, 0x1d, 0x8b, 0x89, 0x6f, 0x7b, 0x59, 0xdc, 0x48, 0x8a, 0x9b, 0x0b, 0x7d, 0x83, 0xf9,
+	0xaf, 0x6d, 0xf7, 0x42, 0xd1, 0x99, 0xe5, 0x1b, 0x38, 0xe8, 0x5d, 0x9a, 0xd1, 0x22, 0x85, 0xa2,
+	0x4b, 0x67, 0xb0, 0x31, 0x76, 0x4d, 0x72, 0x17, 0xf6, 0x1b, 0x27, 0x0d, 0x95, 0xcb, 0xd4, 0xd6,
+	0x69, 0xb9, 0x75, 0x44, 0x95, 0x66, 0x5b, 0x41, 0x89, 0x5c, 0xab, 0x9d, 0xbc, 0xa8, 0x56, 0x90,
+	0x7b, 0x77, 0xe0, 0xf6, 0x74, 0x48, 0x59, 0xa6, 0x14, 0x11, 0x09, 0x49, 0x19, 0x92, 0x20, 0x96,
+	0x3e, 0x92, 0x87, 0xad, 0xa1, 0x1d, 0xee, 0x5b, 0x2a, 0x76, 0xda, 0x72, 0x8d, 0xf7, 0xe4, 0x5d,
+	0xb8, 0x75, 0x5d, 0x53, 0x51, 0x5a, 0x42, 0x95, 0x90, 0xfe, 0x59, 0x84, 0xec, 0xa8, 0x0b, 0x57,
+	0x34, 0x5f, 0xc3, 0x26, 0x9c, 0xf4, 0xc2, 0xaf, 0x77, 0xce, 0x1b, 0x81, 0x63, 0xc8, 0x27, 0x90,
+	0xec, 0x79, 0x7d, 0x41, 0xa8, 0x4c, 0xa9, 0x30, 0x7b, 0x82, 0x53, 0x0e, 0xe3, 0x68, 0xd4, 0x85,
+	0xbd, 0xad, 0x30, 0x7b, 0x6a, 0x51, 0x0e, 0xc3, 0x7e, 0x04, 0x56, 0x90, 0x5e, 0x5e, 0x81, 0xa0,
+	0xfe, 0xdb, 0xa1, 0x91, 0x78, 0x7e, 0x15, 0xa3, 0xec, 0x57, 0x68, 0xda, 0x8a, 0x0a, 0x41, 0x1e,
+	0x47, 0x73, 0x76, 0x69, 0xe2, 0x98, 0xb1, 0x69, 0x13, 0x8d, 0x54, 0x7c, 0x2c, 0xe0, 0x6b, 0x6f,
+	0xd8, 0x7a, 0x97, 0xc5, 0x17, 0x04, 0x28, 0x8a, 0xbe, 0xae, 0x27, 0xb0, 0x1a, 0x31, 0x3d, 0xbf,
+	0x22, 0xbc, 0xee, 0xcf, 0xe5, 0x36, 0x5d, 0x09, 0x89, 0x2c, 0x5d, 0x42, 0x6e, 0x74, 0xe8, 0xa9,
+	0xa3, 0xf3, 0xe3, 0x1e, 0x41, 0x0a, 0xff, 0x6a, 0x61, 0x7e, 0x77, 0xa7, 0xc6, 0xc7, 0xeb, 0x40,
+	0x05, 0x8c, 0x14, 0x21, 0xc5, 0xdf, 0x8e, 0xc3, 0x1c, 0x07, 0xcf, 0xcb, 0x62, 0xf4, 0xbc, 0x2c,
+	0x3e, 0xe3, 0xcf, 0xcb, 0x3a, 0x22, 0xa8, 0xc0, 0x49, 0x7e, 0xfc, 0xc8, 0x9a, 0x6d, 0x5f, 0x0c,
+	0x9c, 0xb1, 0x54, 0x26, 0xde, 0x35, 0x95, 0x21, 0x11, 0x16, 0xdf, 0x4e, 0x04, 0xe9, 0x27, 0xb8,
+	0x7d, 0xcc, 0x7c, 0xd9, 0x34, 0xc7, 0xee, 0xc0, 0x3c, 0xc7, 0xb6, 0x3c, 0x3e, 0x7c, 0x33, 0xa3,
+	0x17, 0x75, 0xf4, 0xfe, 0x9c, 0x73, 0xf7, 0x38, 0x5a, 0xba, 0x0f, 0xe9, 0xba, 0x7c, 0x14, 0xde,
+	0x05, 0x07, 0x4c, 0x5f, 0xeb, 0xaa, 0x9a, 0xae, 0xbb, 0x61, 0xb3, 0x5b, 0xc1, 0xbd, 0x8c, 0x5b,
+	0xe9, 0x02, 0x36, 0x11, 0x87, 0x41, 0xd8, 0xaf, 0x6b, 0x38, 0xb8, 0xab, 0x96, 0xef, 0x5e, 0xcd,
+	0xc1, 0xdf, 0xe0, 0x7e, 0xd8, 0xf9, 0x93, 0x51, 0x67, 0x4b, 0x53, 0xbe, 0x7c, 0xf8, 0x0c, 0x76,
+	0x66, 0xf4, 0x00, 0x3e, 0x3c, 0x9e, 0xd3, 0x26, 0xff, 0x94, 0xd3, 0xb0, 0xf4, 0x42, 0xa9, 0xcb,
+	0x3f, 0xe0, 0x1c, 0x41, 0xe1, 0x8b, 0x9a, 0xdc, 0xc0, 0x29, 0x82, 0xcf, 0xa0, 0x6a, 0xfb, 0x79,
+	0x95, 0x36, 0xaa, 0xed, 0x5c, 0xb2, 0xf4, 0x7b, 0x32, 0xfe, 0x73, 0xa0, 0x52, 0x26, 0xdf, 0xc0,
+	0x3a, 0x46, 0x37, 0x12, 0x91, 0xd9, 0x69, 0x2a, 0x6c, 0x5e, 0x2b, 0xe2, 0x99, 0x8d, 0x95, 0x58,
+	0x20, 0xdf, 0x42, 0xae, 0x82, 0xef, 0x0a, 0x9f, 0xc5, 0x7c, 0xcc, 0xba, 0xdd, 0x74, 0x0f, 0x15,
+	0xc8, 0x05, 0x64, 0x8d, 0x79, 0xd8, 0x9b, 0xea, 0x21, 0x80, 0x4d, 0xf7, 0xa2, 0xc0, 0x26, 0x72,
+	0x62, 0xac, 0xb9, 0xcc, 0x0c, 0x64, 0xf6, 0x2d, 0xd1, 0x55, 0x19, 0xdf, 0xd3, 0x58, 0xd1, 0x91,
+	0xdc, 0x23, 0x93, 0x47, 0x16, 0x0a, 0x33, 0x7c, 0xe3, 0x2f, 0x1f, 0xf4, 0x51, 0xc3, 0x27, 0x3f,
+	0xf3, 0x27, 0x09, 0xb2, 0x15, 0xb3, 0x1a, 0xd2, 0xac, 0x70, 0xfb, 0xba, 0xf4, 0xba, 0x8d, 0xb4,
+	0x50, 0xfa, 0x3b, 0x09, 0xdb, 0xf1, 0xb2, 0xe1, 0xe4, 0xf0, 0x5d, 0xdb, 0x34, 0x31, 0x53, 0xff,
+	0xbb, 0x80, 0x95, 0x29, 0x05, 0x9c, 0x9e, 0xfe, 0x30, 0xd8, 0xa9, 0x5e, 0xca, 0x53, 0x8a, 0x78,
+	0xd3, 0x48, 0xea, 0xd3, 0x4a, 0x38, 0x37, 0x94, 0xb9, 0x65, 0x3c, 0x9e, 0x2c, 0xe3, 0x8c, 0x36,
+	0xf4, 0x96, 0x5a, 0xfe, 0x28, 0x6a, 0x39, 0xd1, 0x6e, 0x66, 0x7a, 0xfb, 0x30, 0x3e, 0x9a, 0xe6,
+	0xf4, 0x29, 0x69, 0xa1, 0xbc, 0xf7, 0x72, 0x57, 0x60, 0x0f, 0xf8, 0x3f, 0x03, 0xba, 0xa6, 0x3d,
+	0xd0, 0x0f, 0x7a, 0x76, 0xf8, 0x4b, 0xbf, 0xb3, 0x2c, 0xfe, 0x1e, 0xfe, 0x0b, 0x86, 0xcd, 0xc8,
+	0xc8, 0x2a, 0x10, 0x00, 0x00,
 }
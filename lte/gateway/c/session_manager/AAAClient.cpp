@@ -53,6 +53,9 @@ bool AsyncAAAClient::terminate_session(
       MLOG(MERROR) << "Could not add terminate session. Radius ID:"
                    << radius_session_id << ", IMSI: " << imsi
                    << ", Error: " << status.error_message();
+    } else if (!resp.detail().empty()) {
+      MLOG(MINFO) << "Terminate session. Radius ID:" << radius_session_id
+                  << ", IMSI: " << imsi << ": " << resp.detail();
     }
   });
   return true;
@@ -121,6 +121,32 @@ void ChargingCreditPool::get_updates(
   }
 }
 
+bool ChargingCreditPool::get_access_action(
+  LocalSessionUsageResponse *response_out)
+{
+  bool exhausted = false;
+  for (auto &credit_pair : credit_map_) {
+    auto &credit = *(credit_pair.second);
+    if (!credit.is_service_deactivated()) {
+      continue;
+    }
+    response_out->set_quota_exhausted(true);
+    if (credit.get_action_for_deactivating_service() != REDIRECT) {
+      // restricted access is unsupported, the service is terminated instead
+      response_out->set_final_action(ChargingCredit_FinalAction_TERMINATE);
+      response_out->clear_redirect_server();
+      return true;
+    }
+    if (!exhausted) {
+      response_out->set_final_action(ChargingCredit_FinalAction_REDIRECT);
+      response_out->mutable_redirect_server()->CopyFrom(
+        credit.get_redirect_server());
+      exhausted = true;
+    }
+  }
+  return exhausted;
+}
+
 bool ChargingCreditPool::get_termination_updates(
   SessionTerminateRequest *termination_out)
 {
@@ -97,6 +97,15 @@ class ChargingCreditPool :
 
   ChargingReAuthAnswer::Result reauth_all();
 
+  /**
+   * get_access_action sets the final unit action the access network is to
+   * enforce if the service of any of the credits is deactivated. Termination
+   * takes precedence over the other final unit actions
+   * @param response_out (out) - the access network usage report's response
+   * @return true if the quota of any of the credits is exhausted
+   */
+  bool get_access_action(LocalSessionUsageResponse *response_out);
+
  private:
   std::unordered_map<uint32_t, std::unique_ptr<SessionCredit>> credit_map_;
   std::string imsi_;
@@ -0,0 +1,54 @@
+/**
+ * Copyright (c) 2016-present, Facebook, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree. An additional grant
+ * of patent rights can be found in the PATENTS file in the same directory.
+ */
+#include <algorithm>
+
+#include "IdempotencyKeys.h"
+
+namespace magma {
+
+const std::string IdempotencyKeys::METADATA_KEY = "x-idempotency-key";
+
+IdempotencyKeys::IdempotencyKeys(size_t max_keys): max_keys_(max_keys) {}
+
+std::string IdempotencyKeys::get_key(const grpc::ServerContext *context)
+{
+  if (context == nullptr) {
+    return "";
+  }
+  const auto &metadata = context->client_metadata();
+  auto it = metadata.find(METADATA_KEY);
+  if (it == metadata.end()) {
+    return "";
+  }
+  return std::string(it->second.data(), it->second.size());
+}
+
+bool IdempotencyKeys::add(const std::string &key)
+{
+  std::lock_guard<std::mutex> lock(mutex_);
+  if (!keys_.insert(key).second) {
+    return false;
+  }
+  order_.push_back(key);
+  while (order_.size() > max_keys_) {
+    keys_.erase(order_.front());
+    order_.pop_front();
+  }
+  return true;
+}
+
+void IdempotencyKeys::remove(const std::string &key)
+{
+  std::lock_guard<std::mutex> lock(mutex_);
+  if (keys_.erase(key) > 0) {
+    order_.erase(std::find(order_.begin(), order_.end(), key));
+  }
+}
+
+} // namespace magma
@@ -0,0 +1,59 @@
+/**
+ * Copyright (c) 2016-present, Facebook, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree. An additional grant
+ * of patent rights can be found in the PATENTS file in the same directory.
+ */
+
+#pragma once
+
+#include <deque>
+#include <mutex>
+#include <string>
+#include <unordered_set>
+
+#include <grpc++/grpc++.h>
+
+namespace magma {
+
+/**
+ * IdempotencyKeys tracks the idempotency keys of the calls replayed by the
+ * AAA's accounting queue, so a call replayed while or after it's handled,
+ * e.g. after the AAA's previous attempt timed out, isn't applied twice. At
+ * most max_keys keys are tracked, the oldest keys are evicted first
+ */
+class IdempotencyKeys {
+ public:
+  /**
+   * Client metadata key of the calls' idempotency keys
+   */
+  static const std::string METADATA_KEY;
+
+  explicit IdempotencyKeys(size_t max_keys);
+
+  /**
+   * Returns the idempotency key of the call, empty if it has none
+   */
+  static std::string get_key(const grpc::ServerContext *context);
+
+  /**
+   * Tracks the key of a received call, returns false if the key is already
+   * tracked, i.e. the call is a replay of a handled or in flight call
+   */
+  bool add(const std::string &key);
+
+  /**
+   * Untracks the key of a failed call, so its replay is handled
+   */
+  void remove(const std::string &key);
+
+ private:
+  std::mutex mutex_;
+  size_t max_keys_;
+  std::unordered_set<std::string> keys_;
+  std::deque<std::string> order_; // keys in the order they're added
+};
+
+} // namespace magma
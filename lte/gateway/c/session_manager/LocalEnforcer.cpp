@@ -144,6 +144,43 @@ void LocalEnforcer::aggregate_records(const RuleRecordTable &records)
   finish_report();
 }
 
+bool LocalEnforcer::aggregate_access_usage(const LocalSessionUsage &usage)
+{
+  auto it = session_map_.find(usage.sid().id());
+  if (it == session_map_.end()) {
+    MLOG(MERROR) << "Could not find session for IMSI " << usage.sid().id()
+                 << " during access usage aggregation";
+    return false;
+  }
+  auto radius_session_id = it->second->get_radius_session_id();
+  if (
+    !usage.radius_session_id().empty() && !radius_session_id.empty() &&
+    usage.radius_session_id() != radius_session_id) {
+    MLOG(MERROR) << "Access usage of IMSI " << usage.sid().id()
+                 << " is reported for RADIUS session "
+                 << usage.radius_session_id() << ", not for the current "
+                 << radius_session_id;
+    return false;
+  }
+  it->second->add_access_usage(usage.octets_in(), usage.octets_out());
+  return true;
+}
+
+void LocalEnforcer::get_access_action(
+  const std::string &imsi,
+  LocalSessionUsageResponse *response_out)
+{
+  auto it = session_map_.find(imsi);
+  if (it == session_map_.end()) {
+    return;
+  }
+  if (it->second->get_charging_pool().get_access_action(response_out)) {
+    MLOG(MDEBUG) << "Quota of IMSI " << imsi << " is exhausted, final action "
+                 << response_out->final_action()
+                 << " is enforced by the access network";
+  }
+}
+
 void LocalEnforcer::execute_actions(
   const std::vector<std::unique_ptr<ServiceAction>> &actions)
 {
@@ -903,6 +940,26 @@ void LocalEnforcer::check_usage_for_reporting()
     });
 }
 
+void LocalEnforcer::list_sessions(
+  RATType rat_type,
+  LocalListSessionsResponse *response_out)
+{
+  for (auto &session_pair : session_map_) {
+    auto &session = session_pair.second;
+    if (session->get_rat_type() != rat_type || !session->is_active()) {
+      continue;
+    }
+    auto info = response_out->add_sessions();
+    info->mutable_sid()->set_id(session_pair.first);
+    info->set_session_id(session->get_session_id());
+    info->set_apn(session->get_apn());
+    info->set_msisdn(session->get_msisdn());
+    info->set_ue_ipv4(session->get_subscriber_ip_addr());
+    info->set_mac_addr(session->get_mac_addr());
+    info->set_radius_session_id(session->get_radius_session_id());
+  }
+}
+
 bool LocalEnforcer::is_imsi_duplicate(const std::string &imsi)
 {
   auto it = session_map_.find(imsi);
@@ -912,6 +969,51 @@ bool LocalEnforcer::is_imsi_duplicate(const std::string &imsi)
   return true;
 }
 
+std::vector<std::string> LocalEnforcer::activate_requested_rules(
+  const std::string &imsi,
+  const std::string &ip_addr,
+  const RepeatedPtrField<std::string> &rule_ids)
+{
+  std::vector<std::string> static_rules;
+  for (const auto &id : rule_ids) {
+    PolicyRule rule;
+    if (!rule_store_->get_rule(id, &rule)) {
+      LOG(ERROR) << "Not activating requested rule " << id
+                 << " because it could not be found";
+      continue;
+    }
+    static_rules.push_back(id);
+  }
+  // activate_flows_for_rules() activates a "drop all packet" rule when no
+  // rule is provided, so it's only called for sessions with requested rules
+  if (static_rules.empty()) {
+    return static_rules;
+  }
+  std::vector<PolicyRule> no_dynamic_rules;
+  if (!pipelined_client_->activate_flows_for_rules(
+        imsi, ip_addr, static_rules, no_dynamic_rules)) {
+    MLOG(MERROR) << "Failed to activate requested rules for IMSI " << imsi;
+    return std::vector<std::string>();
+  }
+  MLOG(MDEBUG) << "Activated " << static_rules.size()
+               << " requested rules for IMSI " << imsi;
+  return static_rules;
+}
+
+void LocalEnforcer::deactivate_requested_rules(
+  const std::string &imsi,
+  const std::vector<std::string> &rule_ids)
+{
+  if (rule_ids.empty()) {
+    return;
+  }
+  std::vector<PolicyRule> no_dynamic_rules;
+  if (!pipelined_client_->deactivate_flows_for_rules(
+        imsi, rule_ids, no_dynamic_rules)) {
+    MLOG(MERROR) << "Failed to deactivate requested rules for IMSI " << imsi;
+  }
+}
+
 bool LocalEnforcer::is_session_duplicate(
   const std::string &imsi, const magma::SessionState::Config &config)
 {
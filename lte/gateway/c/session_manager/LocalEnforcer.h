@@ -67,6 +67,27 @@ class LocalEnforcer {
    */
   void aggregate_records(const RuleRecordTable &records);
 
+  /**
+   * Add the usage metered by a session's access network, for sessions whose
+   * traffic isn't metered by pipelined. The usage is cumulative.
+   *
+   * @param usage - the session's cumulative usage reported by the AAA
+   * @return false if the subscriber has no session or the reported RADIUS
+   *         session isn't the subscriber's current session
+   */
+  bool aggregate_access_usage(const LocalSessionUsage &usage);
+
+  /**
+   * Set the final unit action the access network is to enforce on the
+   * subscriber's session, if the session's quota is exhausted
+   *
+   * @param imsi - the subscriber of the session
+   * @param response_out (out) - the access network usage report's response
+   */
+  void get_access_action(
+    const std::string &imsi,
+    LocalSessionUsageResponse *response_out);
+
   /**
    * reset_updates resets all of the charging keys being updated in
    * failed_request. This should only be called if the *entire* request fails
@@ -135,6 +156,34 @@ class LocalEnforcer {
     PolicyReAuthRequest request,
     PolicyReAuthAnswer &answer_out);
 
+  /**
+   * Activate the static rules requested at session creation without waiting
+   * for the session's create response. Rules missing in the rule store are
+   * skipped
+   * @return ids of the activated rules
+   */
+  std::vector<std::string> activate_requested_rules(
+    const std::string &imsi,
+    const std::string &ip_addr,
+    const google::protobuf::RepeatedPtrField<std::string> &rule_ids);
+
+  /**
+   * Deactivate the requested rules of a session which failed to be created
+   */
+  void deactivate_requested_rules(
+    const std::string &imsi,
+    const std::vector<std::string> &rule_ids);
+
+  /**
+   * List the active sessions of the RAT type, terminating sessions aren't
+   * listed
+   * @param rat_type - RAT type of the listed sessions
+   * @param response_out (out) - the listed sessions
+   */
+  void list_sessions(
+    RATType rat_type,
+    LocalListSessionsResponse *response_out);
+
   bool is_imsi_duplicate(const std::string &imsi);
 
   bool is_session_duplicate(
@@ -28,10 +28,29 @@ LocalSessionManagerHandlerImpl::LocalSessionManagerHandlerImpl(
   reporter_(reporter),
   current_epoch_(0),
   reported_epoch_(0),
-  retry_timeout_(1)
+  retry_timeout_(1),
+  replayed_keys_(max_replayed_keys_)
 {
 }
 
+/**
+ * Wraps the response callback of a replayed call, so the call's idempotency
+ * key is untracked if the call fails and its next replay is handled
+ */
+template<typename ResponseType>
+static std::function<void(Status, ResponseType)> untrack_failed(
+  IdempotencyKeys &keys,
+  const std::string &key,
+  std::function<void(Status, ResponseType)> response_callback)
+{
+  return [&keys, key, response_callback](Status status, ResponseType response) {
+    if (!status.ok()) {
+      keys.remove(key);
+    }
+    response_callback(status, response);
+  };
+}
+
 void LocalSessionManagerHandlerImpl::ReportRuleStats(
   ServerContext *context,
   const RuleRecordTable *request,
@@ -53,6 +72,26 @@ void LocalSessionManagerHandlerImpl::ReportRuleStats(
   response_callback(Status::OK, Void());
 }
 
+void LocalSessionManagerHandlerImpl::ReportSessionUsage(
+  ServerContext *context,
+  const LocalSessionUsage *request,
+  std::function<void(Status, LocalSessionUsageResponse)> response_callback)
+{
+  auto &request_cpy = *request;
+  enforcer_->get_event_base().runInEventBaseThread(
+    [this, request_cpy, response_callback]() {
+      if (!enforcer_->aggregate_access_usage(request_cpy)) {
+        Status status(grpc::FAILED_PRECONDITION, "Session not found");
+        response_callback(status, LocalSessionUsageResponse());
+        return;
+      }
+      check_usage_for_reporting();
+      LocalSessionUsageResponse response;
+      enforcer_->get_access_action(request_cpy.sid().id(), &response);
+      response_callback(Status::OK, response);
+    });
+}
+
 void LocalSessionManagerHandlerImpl::check_usage_for_reporting()
 {
   auto request = enforcer_->collect_updates();
@@ -149,11 +188,20 @@ static CreateSessionRequest copy_wifi_session_info2create_req(
   create_request.mutable_subscriber()->CopyFrom(request->sid());
   create_request.set_session_id(sid);
   create_request.set_ue_ipv4(request->ue_ipv4());
+  create_request.set_ue_ipv6(request->ue_ipv6());
+  create_request.set_ue_ipv6_prefix(request->ue_ipv6_prefix());
   create_request.set_apn(request->apn());
   create_request.set_imei(request->imei());
   create_request.set_msisdn(request->msisdn());
   create_request.set_hardware_addr(request->hardware_addr());
 
+  create_request.mutable_static_rule_ids()->CopyFrom(
+    request->static_rule_ids());
+  create_request.mutable_rule_base_names()->CopyFrom(
+    request->rule_base_names());
+  create_request.set_charging_characteristics(
+    request->charging_characteristics());
+  create_request.mutable_rating_groups()->CopyFrom(request->rating_groups());
   return create_request;
 }
 
@@ -166,6 +214,8 @@ static CreateSessionRequest copy_session_info2create_req(
   create_request.mutable_subscriber()->CopyFrom(request->sid());
   create_request.set_session_id(sid);
   create_request.set_ue_ipv4(request->ue_ipv4());
+  create_request.set_ue_ipv6(request->ue_ipv6());
+  create_request.set_ue_ipv6_prefix(request->ue_ipv6_prefix());
   create_request.set_spgw_ipv4(request->spgw_ipv4());
   create_request.set_apn(request->apn());
   create_request.set_msisdn(request->msisdn());
@@ -178,6 +228,13 @@ static CreateSessionRequest copy_session_info2create_req(
     create_request.mutable_qos_info()->CopyFrom(request->qos_info());
   }
 
+  create_request.mutable_static_rule_ids()->CopyFrom(
+    request->static_rule_ids());
+  create_request.mutable_rule_base_names()->CopyFrom(
+    request->rule_base_names());
+  create_request.set_charging_characteristics(
+    request->charging_characteristics());
+  create_request.mutable_rating_groups()->CopyFrom(request->rating_groups());
   return create_request;
 }
 
@@ -187,6 +244,18 @@ void LocalSessionManagerHandlerImpl::CreateSession(
   std::function<void(Status, LocalCreateSessionResponse)> response_callback)
 {
   auto imsi = request->sid().id();
+  auto key = IdempotencyKeys::get_key(context);
+  if (!key.empty()) {
+    if (!replayed_keys_.add(key)) {
+      MLOG(MINFO) << "Replayed session creation " << key << " of subscriber "
+                  << imsi << " is already handled";
+      Status status(grpc::ALREADY_EXISTS, "Session creation already handled");
+      response_callback(status, LocalCreateSessionResponse());
+      return;
+    }
+    response_callback =
+      untrack_failed(replayed_keys_, key, std::move(response_callback));
+  }
   auto sid = id_gen_.gen_session_id(imsi);
   auto mac_addr = convert_mac_addr_to_str(request->hardware_addr());
   SessionState::Config cfg = {.ue_ipv4 = request->ue_ipv4(),
@@ -213,20 +282,52 @@ void LocalSessionManagerHandlerImpl::CreateSession(
     if (enforcer_->is_session_duplicate(imsi, cfg)) {
       MLOG(MINFO) << "Found completely duplicated session with IMSI " << imsi
                   << ", not creating session";
+      Status status(grpc::ALREADY_EXISTS, "Duplicated session");
+      response_callback(status, LocalCreateSessionResponse());
       return;
     }
     MLOG(MINFO) << "Found session with the same IMSI " << imsi
                 << ", terminating the old session";
+    // without the call's context, so its idempotency key isn't the old
+    // session's termination's
     EndSession(
-      context,
+      nullptr,
       &request->sid(),
       [&](grpc::Status status, LocalEndSessionResponse response) {
         return;
       });
   }
+  // Requested static rules are activated without waiting for the session's
+  // Gx round-trip, they are deactivated if the session is not created
+  auto requested_rules = enforcer_->activate_requested_rules(
+    imsi, request->ue_ipv4(), request->static_rule_ids());
   send_create_session(
     copy_session_info2create_req(request, sid),
-    imsi, sid, cfg, response_callback);
+    imsi, sid, cfg, requested_rules, response_callback);
+}
+
+/**
+ * Add the activated requested rules missing in the create response, so they
+ * are tracked by the session even if the controller doesn't return them
+ */
+static CreateSessionResponse add_requested_rules(
+  const CreateSessionResponse &response,
+  const std::vector<std::string> &requested_rules)
+{
+  CreateSessionResponse res = response;
+  for (const auto &id : requested_rules) {
+    bool installed = false;
+    for (const auto &static_rule : response.static_rules()) {
+      if (static_rule.rule_id() == id) {
+        installed = true;
+        break;
+      }
+    }
+    if (!installed) {
+      res.add_static_rules()->set_rule_id(id);
+    }
+  }
+  return res;
 }
 
 void LocalSessionManagerHandlerImpl::send_create_session(
@@ -234,14 +335,17 @@ void LocalSessionManagerHandlerImpl::send_create_session(
   const std::string &imsi,
   const std::string &sid,
   const SessionState::Config &cfg,
+  const std::vector<std::string> &requested_rules,
   std::function<void(grpc::Status, LocalCreateSessionResponse)> response_callback)
 {
   reporter_->report_create_session(
     request,
-    [this, imsi, sid, cfg, response_callback](
+    [this, imsi, sid, cfg, requested_rules, response_callback](
       Status status, CreateSessionResponse response) {
+      LocalCreateSessionResponse local_response;
       if (status.ok()) {
-        bool success = enforcer_->init_session_credit(imsi, sid, cfg, response);
+        bool success = enforcer_->init_session_credit(
+          imsi, sid, cfg, add_requested_rules(response, requested_rules));
         if (!success) {
           MLOG(MERROR) << "Failed to init session in Usage Monitor "
                        << "for IMSI " << imsi;
@@ -251,12 +355,26 @@ void LocalSessionManagerHandlerImpl::send_create_session(
         } else {
           MLOG(MINFO) << "Successfully initialized new session "
                       << "in sessiond for subscriber " << imsi;
+          // the session's creator handles the sessions created in Gx/Gy
+          // failure modes
+          local_response.set_gx_mode(response.gx_mode());
+          local_response.set_gy_mode(response.gy_mode());
+          if (response.gx_mode() != LocalCreateSessionResponse::NORMAL ||
+              response.gy_mode() != LocalCreateSessionResponse::NORMAL) {
+            MLOG(MWARNING) << "Session of subscriber " << imsi
+                           << " was created in Gx mode "
+                           << response.gx_mode() << ", Gy mode "
+                           << response.gy_mode();
+          }
         }
       } else {
         MLOG(MERROR) << "Failed to initialize session in OCS for IMSI "
                      << imsi << ": " << status.error_message();
       }
-      response_callback(status, LocalCreateSessionResponse());
+      if (!status.ok()) {
+        enforcer_->deactivate_requested_rules(imsi, requested_rules);
+      }
+      response_callback(status, local_response);
     });
 }
 
@@ -315,24 +433,92 @@ void LocalSessionManagerHandlerImpl::EndSession(
   const SubscriberID *request,
   std::function<void(Status, LocalEndSessionResponse)> response_callback)
 {
+  auto key = IdempotencyKeys::get_key(context);
+  if (!key.empty()) {
+    if (!replayed_keys_.add(key)) {
+      MLOG(MINFO) << "Replayed session termination " << key
+                  << " of subscriber " << request->id()
+                  << " is already handled";
+      response_callback(grpc::Status::OK, LocalEndSessionResponse());
+      return;
+    }
+    response_callback =
+      untrack_failed(replayed_keys_, key, std::move(response_callback));
+  }
   auto &request_cpy = *request;
   enforcer_->get_event_base().runInEventBaseThread(
     [this, request_cpy, response_callback]() {
-      try {
-        auto reporter = reporter_;
-        enforcer_->terminate_subscriber(
-          request_cpy.id(), [reporter](SessionTerminateRequest term_req) {
-            // report to cloud
-            report_termination(*reporter, term_req);
-          });
-        response_callback(grpc::Status::OK, LocalEndSessionResponse());
-      } catch (const SessionNotFound &ex) {
-        MLOG(MERROR) << "Failed to find session to terminate for subscriber "
-                     << request_cpy.id();
-        Status status(grpc::FAILED_PRECONDITION, "Session not found");
-        response_callback(status, LocalEndSessionResponse());
+      end_session(request_cpy.id(), response_callback);
+    });
+}
+
+void LocalSessionManagerHandlerImpl::EndSessionWithUsage(
+  ServerContext *context,
+  const LocalEndSessionRequest *request,
+  std::function<void(Status, LocalEndSessionResponse)> response_callback)
+{
+  auto key = IdempotencyKeys::get_key(context);
+  if (!key.empty()) {
+    if (!replayed_keys_.add(key)) {
+      MLOG(MINFO) << "Replayed session termination " << key
+                  << " of subscriber " << request->sid().id()
+                  << " is already handled";
+      response_callback(grpc::Status::OK, LocalEndSessionResponse());
+      return;
+    }
+    response_callback =
+      untrack_failed(replayed_keys_, key, std::move(response_callback));
+  }
+  auto &request_cpy = *request;
+  enforcer_->get_event_base().runInEventBaseThread(
+    [this, request_cpy, response_callback]() {
+      if (request_cpy.has_final_usage()) {
+        // the final usage is included in the termination's usage report
+        enforcer_->aggregate_access_usage(request_cpy.final_usage());
       }
+      MLOG(MINFO) << "Ending session of subscriber " << request_cpy.sid().id()
+                  << " after " << request_cpy.session_time() << " seconds";
+      end_session(request_cpy.sid().id(), response_callback);
+    });
+}
+
+void LocalSessionManagerHandlerImpl::ListSessions(
+  ServerContext *context,
+  const LocalListSessionsRequest *request,
+  std::function<void(Status, LocalListSessionsResponse)> response_callback)
+{
+  auto rat_type = request->rat_type();
+  enforcer_->get_event_base().runInEventBaseThread(
+    [this, rat_type, response_callback]() {
+      LocalListSessionsResponse response;
+      enforcer_->list_sessions(rat_type, &response);
+      MLOG(MDEBUG) << "Listed " << response.sessions_size()
+                   << " active sessions of RAT type " << rat_type;
+      response_callback(grpc::Status::OK, response);
     });
 }
 
+/**
+ * Terminate the subscriber's session, must be called on the event base thread
+ */
+void LocalSessionManagerHandlerImpl::end_session(
+  const std::string &imsi,
+  std::function<void(Status, LocalEndSessionResponse)> response_callback)
+{
+  try {
+    auto reporter = reporter_;
+    enforcer_->terminate_subscriber(
+      imsi, [reporter](SessionTerminateRequest term_req) {
+        // report to cloud
+        report_termination(*reporter, term_req);
+      });
+    response_callback(grpc::Status::OK, LocalEndSessionResponse());
+  } catch (const SessionNotFound &ex) {
+    MLOG(MERROR) << "Failed to find session to terminate for subscriber "
+                 << imsi;
+    Status status(grpc::FAILED_PRECONDITION, "Session not found");
+    response_callback(status, LocalEndSessionResponse());
+  }
+}
+
 } // namespace magma
@@ -15,6 +15,7 @@
 
 #include "LocalEnforcer.h"
 #include "CloudReporter.h"
+#include "IdempotencyKeys.h"
 #include "SessionID.h"
 
 using grpc::Server;
@@ -53,6 +54,36 @@ class LocalSessionManagerHandler {
     ServerContext *context,
     const SubscriberID *request,
     std::function<void(Status, LocalEndSessionResponse)> response_callback) = 0;
+
+  /**
+   * Add the usage metered by a session's access network and report it to the
+   * cloud if needed. The response carries the final unit action the access
+   * network is to enforce if the session's quota is exhausted
+   */
+  virtual void ReportSessionUsage(
+    ServerContext *context,
+    const LocalSessionUsage *request,
+    std::function<void(Status, LocalSessionUsageResponse)>
+      response_callback) = 0;
+
+  /**
+   * Terminate a session like EndSession, adding its final usage metered by
+   * its access network before the termination reports the usage to the cloud
+   */
+  virtual void EndSessionWithUsage(
+    ServerContext *context,
+    const LocalEndSessionRequest *request,
+    std::function<void(Status, LocalEndSessionResponse)> response_callback) = 0;
+
+  /**
+   * List the active sessions of the requested RAT type, e.g. for the AAA to
+   * resynchronize its sessions with the session manager's after a restart
+   */
+  virtual void ListSessions(
+    ServerContext *context,
+    const LocalListSessionsRequest *request,
+    std::function<void(Status, LocalListSessionsResponse)>
+      response_callback) = 0;
 };
 
 /**
@@ -92,6 +123,34 @@ class LocalSessionManagerHandlerImpl : public LocalSessionManagerHandler {
     const SubscriberID *request,
     std::function<void(Status, LocalEndSessionResponse)> response_callback);
 
+  /**
+   * Add the usage metered by a session's access network and report it to the
+   * cloud if needed. The response carries the final unit action the access
+   * network is to enforce if the session's quota is exhausted
+   */
+  void ReportSessionUsage(
+    ServerContext *context,
+    const LocalSessionUsage *request,
+    std::function<void(Status, LocalSessionUsageResponse)> response_callback);
+
+  /**
+   * Terminate a session like EndSession, adding its final usage metered by
+   * its access network before the termination reports the usage to the cloud
+   */
+  void EndSessionWithUsage(
+    ServerContext *context,
+    const LocalEndSessionRequest *request,
+    std::function<void(Status, LocalEndSessionResponse)> response_callback);
+
+  /**
+   * List the active sessions of the requested RAT type, e.g. for the AAA to
+   * resynchronize its sessions with the session manager's after a restart
+   */
+  void ListSessions(
+    ServerContext *context,
+    const LocalListSessionsRequest *request,
+    std::function<void(Status, LocalListSessionsResponse)> response_callback);
+
  private:
   LocalEnforcer *enforcer_;
   SessionCloudReporter *reporter_;
@@ -99,10 +158,17 @@ class LocalSessionManagerHandlerImpl : public LocalSessionManagerHandler {
   uint64_t current_epoch_;
   uint64_t reported_epoch_;
   std::chrono::seconds retry_timeout_;
+  // keys of the replayed calls, a replay of a handled call isn't applied again
+  IdempotencyKeys replayed_keys_;
   static const std::string hex_digit_;
+  // number of tracked replayed calls, the size of the AAA's accounting queue
+  static const size_t max_replayed_keys_ = 10000;
 
  private:
   void check_usage_for_reporting();
+  void end_session(
+    const std::string &imsi,
+    std::function<void(Status, LocalEndSessionResponse)> response_callback);
   bool is_pipelined_restarted();
   bool restart_pipelined(const std::uint64_t &epoch);
 
@@ -113,6 +179,7 @@ class LocalSessionManagerHandlerImpl : public LocalSessionManagerHandler {
     const std::string &imsi,
     const std::string &sid,
     const SessionState::Config &cfg,
+    const std::vector<std::string> &requested_rules,
     std::function<void(grpc::Status, LocalCreateSessionResponse)> response_callback);
 
   void handle_setup_callback(
@@ -262,6 +262,12 @@ bool SessionCredit::is_reporting()
   return reporting_;
 }
 
+bool SessionCredit::is_service_deactivated()
+{
+  return service_state_ == SERVICE_NEEDS_DEACTIVATION ||
+    service_state_ == SERVICE_DISABLED;
+}
+
 uint64_t SessionCredit::get_credit(Bucket bucket) const
 {
   return buckets_[bucket];
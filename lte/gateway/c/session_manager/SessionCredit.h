@@ -133,6 +133,18 @@ class SessionCredit {
    */
   bool is_reporting();
 
+  /**
+   * Returns true if the credit's service is, or is about to be, deactivated
+   * because its quota is exhausted
+   */
+  bool is_service_deactivated();
+
+  /**
+   * get_action_for_deactivating_service returns the final unit action of a
+   * final credit (REDIRECT or RESTRICT_ACCESS), TERMINATE_SERVICE otherwise
+   */
+  ServiceActionType get_action_for_deactivating_service();
+
   /**
    * Helper function to get the credit in a particular bucket
    */
@@ -202,8 +214,6 @@ class SessionCredit {
   void set_expiry_time(uint32_t validity_time);
 
   bool is_reauth_required();
-
-  ServiceActionType get_action_for_deactivating_service();
 };
 
 } // namespace magma
@@ -59,6 +59,9 @@ void LocalSessionManagerAsyncService::init_call_data()
   new ReportRuleStatsCallData(cq_.get(), *this, *handler_);
   new CreateSessionCallData(cq_.get(), *this, *handler_);
   new EndSessionCallData(cq_.get(), *this, *handler_);
+  new ReportSessionUsageCallData(cq_.get(), *this, *handler_);
+  new EndSessionWithUsageCallData(cq_.get(), *this, *handler_);
+  new ListSessionsCallData(cq_.get(), *this, *handler_);
 }
 
 SessionProxyResponderAsyncService::SessionProxyResponderAsyncService(
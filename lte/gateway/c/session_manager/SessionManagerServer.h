@@ -267,6 +267,108 @@ class EndSessionCallData :
   LocalSessionManagerHandler &handler_;
 };
 
+/**
+ * Class to handle ReportSessionUsage requests
+ */
+class ReportSessionUsageCallData :
+  public AsyncGRPCRequest<
+    LocalSessionManager::AsyncService,
+    LocalSessionUsage,
+    LocalSessionUsageResponse> {
+ public:
+  ReportSessionUsageCallData(
+    ServerCompletionQueue *cq,
+    LocalSessionManager::AsyncService &service,
+    LocalSessionManagerHandler &handler):
+    AsyncGRPCRequest(cq, service),
+    handler_(handler)
+  {
+    service_.RequestReportSessionUsage(
+      &ctx_, &request_, &responder_, cq_, cq_, (void *) this);
+  }
+
+ protected:
+  void clone() override
+  {
+    new ReportSessionUsageCallData(cq_, service_, handler_);
+  }
+
+  void process() override
+  {
+    handler_.ReportSessionUsage(&ctx_, &request_, get_finish_callback());
+  }
+
+ private:
+  LocalSessionManagerHandler &handler_;
+};
+
+/**
+ * Class to handle EndSessionWithUsage requests
+ */
+class EndSessionWithUsageCallData :
+  public AsyncGRPCRequest<
+    LocalSessionManager::AsyncService,
+    LocalEndSessionRequest,
+    LocalEndSessionResponse> {
+ public:
+  EndSessionWithUsageCallData(
+    ServerCompletionQueue *cq,
+    LocalSessionManager::AsyncService &service,
+    LocalSessionManagerHandler &handler):
+    AsyncGRPCRequest(cq, service),
+    handler_(handler)
+  {
+    service_.RequestEndSessionWithUsage(
+      &ctx_, &request_, &responder_, cq_, cq_, (void *) this);
+  }
+
+ protected:
+  void clone() override
+  {
+    new EndSessionWithUsageCallData(cq_, service_, handler_);
+  }
+
+  void process() override
+  {
+    handler_.EndSessionWithUsage(&ctx_, &request_, get_finish_callback());
+  }
+
+ private:
+  LocalSessionManagerHandler &handler_;
+};
+
+/**
+ * Class to handle ListSessions requests
+ */
+class ListSessionsCallData :
+  public AsyncGRPCRequest<
+    LocalSessionManager::AsyncService,
+    LocalListSessionsRequest,
+    LocalListSessionsResponse> {
+ public:
+  ListSessionsCallData(
+    ServerCompletionQueue *cq,
+    LocalSessionManager::AsyncService &service,
+    LocalSessionManagerHandler &handler):
+    AsyncGRPCRequest(cq, service),
+    handler_(handler)
+  {
+    service_.RequestListSessions(
+      &ctx_, &request_, &responder_, cq_, cq_, (void *) this);
+  }
+
+ protected:
+  void clone() override { new ListSessionsCallData(cq_, service_, handler_); }
+
+  void process() override
+  {
+    handler_.ListSessions(&ctx_, &request_, get_finish_callback());
+  }
+
+ private:
+  LocalSessionManagerHandler &handler_;
+};
+
 /**
  * Class to handle ChargingReauth requests
  */
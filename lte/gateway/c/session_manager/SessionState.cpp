@@ -28,7 +28,9 @@ SessionState::SessionState(
   curr_state_(SESSION_ACTIVE),
   session_rules_(rule_store),
   charging_pool_(imsi),
-  monitor_pool_(imsi)
+  monitor_pool_(imsi),
+  access_usage_tx_(0),
+  access_usage_rx_(0)
 {
 }
 
@@ -71,6 +73,26 @@ void SessionState::add_used_credit(
   }
 }
 
+static uint64_t counter_delta(uint64_t prev, uint64_t curr)
+{
+  return curr < prev ? curr : curr - prev;
+}
+
+void SessionState::add_access_usage(uint64_t total_tx, uint64_t total_rx)
+{
+  uint64_t used_tx = counter_delta(access_usage_tx_, total_tx);
+  uint64_t used_rx = counter_delta(access_usage_rx_, total_rx);
+  access_usage_tx_ = total_tx;
+  access_usage_rx_ = total_rx;
+  auto session_level_key_p = monitor_pool_.get_session_level_key();
+  if (session_level_key_p == nullptr) {
+    MLOG(MDEBUG) << "Access network usage of IMSI " << imsi_
+                 << " not tracked, the session has no session level key";
+    return;
+  }
+  monitor_pool_.add_used_credit(*session_level_key_p, used_tx, used_rx);
+}
+
 void SessionState::get_updates_from_charging_pool(
   UpdateSessionRequest *update_request_out,
   std::vector<std::unique_ptr<ServiceAction>> *actions_out)
@@ -259,6 +281,21 @@ bool SessionState::is_radius_cwf_session()
   return (config_.rat_type == RATType::TGPP_WLAN);
 }
 
+std::string SessionState::get_msisdn()
+{
+  return config_.msisdn;
+}
+
+RATType SessionState::get_rat_type()
+{
+  return config_.rat_type;
+}
+
+bool SessionState::is_active()
+{
+  return curr_state_ == SESSION_ACTIVE;
+}
+
 std::string SessionState::get_radius_session_id()
 {
   return config_.radius_session_id;
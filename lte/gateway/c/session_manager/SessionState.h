@@ -81,6 +81,16 @@ class SessionState {
     uint64_t used_tx,
     uint64_t used_rx);
 
+  /**
+   * add_access_usage adds the usage metered by the session's access network
+   * (e.g. a WLAN NAS) since its previous report to the session level
+   * monitoring key. The access network's usage isn't broken down by rule.
+   * A total going backwards is treated as an access network counter reset
+   * @param total_tx - cumulative bytes sent by the UE
+   * @param total_rx - cumulative bytes received by the UE
+   */
+  void add_access_usage(uint64_t total_tx, uint64_t total_rx);
+
   /**
    * get_updates collects updates and adds them to a UpdateSessionRequest
    * for reporting.
@@ -151,6 +161,16 @@ class SessionState {
 
   bool is_radius_cwf_session();
 
+  std::string get_msisdn();
+
+  RATType get_rat_type();
+
+  /**
+   * is_active returns whether the session is active, i.e. its termination
+   * hasn't been started
+   */
+  bool is_active();
+
   bool is_same_config(const Config &new_config);
 
   void get_session_info(SessionState::SessionInfo &info);
@@ -195,6 +215,9 @@ class SessionState {
   SessionState::State curr_state_;
   SessionState::Config config_;
   std::function<void(SessionTerminateRequest)> on_termination_callback_;
+  // last cumulative usage reported by the access network
+  uint64_t access_usage_tx_;
+  uint64_t access_usage_rx_;
 
  private:
   void get_updates_from_charging_pool(
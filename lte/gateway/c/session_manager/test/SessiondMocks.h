@@ -162,6 +162,27 @@ class MockSessionHandler final : public LocalSessionManagerHandler {
       grpc::ServerContext *,
       const SubscriberID *,
       std::function<void(Status, LocalEndSessionResponse)>));
+
+  MOCK_METHOD3(
+    ReportSessionUsage,
+    void(
+      grpc::ServerContext *,
+      const LocalSessionUsage *,
+      std::function<void(Status, LocalSessionUsageResponse)>));
+
+  MOCK_METHOD3(
+    EndSessionWithUsage,
+    void(
+      grpc::ServerContext *,
+      const LocalEndSessionRequest *,
+      std::function<void(Status, LocalEndSessionResponse)>));
+
+  MOCK_METHOD3(
+    ListSessions,
+    void(
+      grpc::ServerContext *,
+      const LocalListSessionsRequest *,
+      std::function<void(Status, LocalListSessionsResponse)>));
 };
 
 class MockSessionCloudReporter : public SessionCloudReporter {
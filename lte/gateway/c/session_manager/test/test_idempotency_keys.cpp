@@ -0,0 +1,61 @@
+/**
+ * Copyright (c) 2016-present, Facebook, Inc.
+ * All rights reserved.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree. An additional grant
+ * of patent rights can be found in the PATENTS file in the same directory.
+ */
+#include <gtest/gtest.h>
+#include "IdempotencyKeys.h"
+
+using ::testing::Test;
+
+namespace magma {
+
+TEST(test_replayed_key, test_idempotency_keys)
+{
+  IdempotencyKeys keys(10);
+  EXPECT_TRUE(keys.add("start/sid1"));
+  // a replay of the handled or in flight call isn't handled again
+  EXPECT_FALSE(keys.add("start/sid1"));
+  EXPECT_TRUE(keys.add("stop/sid1"));
+}
+
+TEST(test_failed_key, test_idempotency_keys)
+{
+  IdempotencyKeys keys(10);
+  EXPECT_TRUE(keys.add("start/sid1"));
+  // the replay of the failed call is handled
+  keys.remove("start/sid1");
+  EXPECT_TRUE(keys.add("start/sid1"));
+  keys.remove("unknown");
+}
+
+TEST(test_evicted_keys, test_idempotency_keys)
+{
+  IdempotencyKeys keys(2);
+  EXPECT_TRUE(keys.add("start/sid1"));
+  EXPECT_TRUE(keys.add("start/sid2"));
+  keys.remove("start/sid2");
+  EXPECT_TRUE(keys.add("start/sid2"));
+  EXPECT_TRUE(keys.add("start/sid3"));
+  // the oldest key is evicted, the re-added key is kept
+  EXPECT_FALSE(keys.add("start/sid2"));
+  EXPECT_TRUE(keys.add("start/sid1"));
+}
+
+TEST(test_calls_without_key, test_idempotency_keys)
+{
+  grpc::ServerContext context;
+  EXPECT_EQ("", IdempotencyKeys::get_key(&context));
+  EXPECT_EQ("", IdempotencyKeys::get_key(nullptr));
+}
+
+int main(int argc, char **argv)
+{
+  ::testing::InitGoogleTest(&argc, argv);
+  return RUN_ALL_TESTS();
+}
+
+} // namespace magma
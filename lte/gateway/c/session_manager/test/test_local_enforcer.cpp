@@ -213,6 +213,37 @@ TEST_F(LocalEnforcerTest, test_init_session_credit)
     local_enforcer->get_charging_credit("IMSI1", 1, ALLOWED_TOTAL), 1024);
 }
 
+TEST_F(LocalEnforcerTest, test_activate_requested_rules)
+{
+  insert_static_rule(1, "", "rule1");
+  google::protobuf::RepeatedPtrField<std::string> rule_ids;
+  rule_ids.Add()->assign("rule1");
+  rule_ids.Add()->assign("unknown_rule");
+
+  EXPECT_CALL(
+    *pipelined_client,
+    activate_flows_for_rules(
+      "IMSI1", "127.0.0.1", CheckCount(1), CheckCount(0)))
+    .Times(1)
+    .WillOnce(testing::Return(true));
+  auto activated =
+    local_enforcer->activate_requested_rules("IMSI1", "127.0.0.1", rule_ids);
+  EXPECT_EQ(activated, std::vector<std::string>{"rule1"});
+
+  EXPECT_CALL(
+    *pipelined_client,
+    deactivate_flows_for_rules("IMSI1", CheckCount(1), CheckCount(0)))
+    .Times(1)
+    .WillOnce(testing::Return(true));
+  local_enforcer->deactivate_requested_rules("IMSI1", activated);
+
+  // no requested rules, pipelined must not be called
+  google::protobuf::RepeatedPtrField<std::string> no_rules;
+  EXPECT_TRUE(
+    local_enforcer->activate_requested_rules("IMSI1", "127.0.0.1", no_rules)
+      .empty());
+}
+
 TEST_F(LocalEnforcerTest, test_single_record)
 {
   // insert initial session credit
@@ -508,6 +539,38 @@ TEST_F(LocalEnforcerTest, test_cwf_final_unit_handling)
   auto usage_updates = local_enforcer->collect_updates();
 }
 
+TEST_F(LocalEnforcerTest, test_list_sessions)
+{
+  CreateSessionResponse response;
+  SessionState::Config test_cwf_cfg;
+  test_cwf_cfg.rat_type = RATType::TGPP_WLAN;
+  test_cwf_cfg.apn = "apn1";
+  test_cwf_cfg.mac_addr = "00:00:00:00:00:00";
+  test_cwf_cfg.radius_session_id = "1234567";
+
+  local_enforcer->init_session_credit("IMSI1", "1234", test_cwf_cfg, response);
+  local_enforcer->init_session_credit("IMSI2", "4321", test_cwf_cfg, response);
+  local_enforcer->init_session_credit("IMSI3", "5678", test_cfg, response);
+
+  LocalListSessionsResponse lte_sessions;
+  local_enforcer->list_sessions(RATType::TGPP_LTE, &lte_sessions);
+  EXPECT_EQ(lte_sessions.sessions_size(), 1);
+  EXPECT_EQ(lte_sessions.sessions(0).sid().id(), "IMSI3");
+
+  // terminating sessions aren't listed
+  local_enforcer->terminate_subscriber(
+    "IMSI2", [](SessionTerminateRequest req) {});
+  LocalListSessionsResponse cwf_sessions;
+  local_enforcer->list_sessions(RATType::TGPP_WLAN, &cwf_sessions);
+  EXPECT_EQ(cwf_sessions.sessions_size(), 1);
+  auto &session = cwf_sessions.sessions(0);
+  EXPECT_EQ(session.sid().id(), "IMSI1");
+  EXPECT_EQ(session.session_id(), "1234");
+  EXPECT_EQ(session.apn(), "apn1");
+  EXPECT_EQ(session.mac_addr(), "00:00:00:00:00:00");
+  EXPECT_EQ(session.radius_session_id(), "1234567");
+}
+
 TEST_F(LocalEnforcerTest, test_all)
 {
   // insert key rule mapping
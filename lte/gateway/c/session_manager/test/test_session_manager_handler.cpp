@@ -90,10 +90,89 @@ TEST_F(SessionManagerHandlerTest, test_create_session_cfg)
 
     // Ensure session is not reported as its a duplicate
     EXPECT_CALL(*reporter, report_create_session(_, _)).Times(0);
+    grpc::StatusCode code = grpc::OK;
+    session_manager->CreateSession(&create_context, &request, [&code](
+            grpc::Status status, LocalCreateSessionResponse response_out) {
+        code = status.error_code();
+    });
+    // The duplicate is answered, so its caller doesn't wait for its deadline
+    EXPECT_EQ(code, grpc::ALREADY_EXISTS);
+}
+
+MATCHER_P2(CheckCreateSessionIpv6, ue_ipv6, ue_ipv6_prefix, "")
+{
+  return arg.ue_ipv6() == ue_ipv6 && arg.ue_ipv6_prefix() == ue_ipv6_prefix;
+}
+
+TEST_F(SessionManagerHandlerTest, test_create_session_ipv6)
+{
+    LocalCreateSessionRequest request;
+    grpc::ServerContext create_context;
+    request.mutable_sid()->set_id("IMSI2");
+    request.set_rat_type(RATType::TGPP_WLAN);
+    request.set_ue_ipv6("2001:db8::2");
+    request.set_ue_ipv6_prefix("2001:db8:1::/56");
+
+    // The UE's IPv6 address & delegated prefix are reported to the cloud
+    EXPECT_CALL(
+      *reporter,
+      report_create_session(
+        CheckCreateSessionIpv6("2001:db8::2", "2001:db8:1::/56"), _))
+      .Times(1);
+    session_manager->CreateSession(&create_context, &request, [this](
+            grpc::Status status, LocalCreateSessionResponse response_out) {});
+}
+
+MATCHER_P2(CheckCreateSessionCharging, charging_characteristics, rating_group, "")
+{
+  return arg.charging_characteristics() == charging_characteristics &&
+         arg.rating_groups_size() == 1 &&
+         arg.rating_groups(0) == rating_group;
+}
+
+TEST_F(SessionManagerHandlerTest, test_create_session_charging_hints)
+{
+    LocalCreateSessionRequest request;
+    grpc::ServerContext create_context;
+    request.mutable_sid()->set_id("IMSI3");
+    request.set_rat_type(RATType::TGPP_WLAN);
+    request.set_charging_characteristics("0800");
+    request.add_rating_groups(42);
+
+    // The charging characteristics & rating groups are reported to the cloud
+    EXPECT_CALL(
+      *reporter,
+      report_create_session(CheckCreateSessionCharging("0800", 42), _))
+      .Times(1);
     session_manager->CreateSession(&create_context, &request, [this](
             grpc::Status status, LocalCreateSessionResponse response_out) {});
 }
 
+TEST_F(SessionManagerHandlerTest, test_create_session_failure_modes)
+{
+    LocalCreateSessionRequest request;
+    grpc::ServerContext create_context;
+    request.mutable_sid()->set_id("IMSI4");
+    request.set_rat_type(RATType::TGPP_WLAN);
+
+    // The session was created in the controller's Gx failure & Gy bypass
+    // modes, which are returned to the session's creator
+    CreateSessionResponse create_response;
+    create_response.set_gx_mode(LocalCreateSessionResponse::FAILURE);
+    create_response.set_gy_mode(LocalCreateSessionResponse::BYPASS);
+    EXPECT_CALL(*reporter, report_create_session(_, _))
+      .WillOnce(testing::InvokeArgument<1>(grpc::Status::OK, create_response));
+
+    LocalCreateSessionResponse local_response;
+    session_manager->CreateSession(&create_context, &request, [&local_response](
+            grpc::Status status, LocalCreateSessionResponse response_out) {
+        EXPECT_TRUE(status.ok());
+        local_response = response_out;
+    });
+    EXPECT_EQ(LocalCreateSessionResponse::FAILURE, local_response.gx_mode());
+    EXPECT_EQ(LocalCreateSessionResponse::BYPASS, local_response.gy_mode());
+}
+
 int main(int argc, char **argv)
 {
     ::testing::InitGoogleTest(&argc, argv);
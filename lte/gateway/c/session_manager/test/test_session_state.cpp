@@ -222,6 +222,36 @@ TEST_F(SessionStateTest, test_session_level_key)
   EXPECT_EQ(single_update.bytes_tx(), 5000);
 }
 
+TEST_F(SessionStateTest, test_access_usage)
+{
+  // usage isn't tracked without a session level key
+  session_state->add_access_usage(1000, 2000);
+
+  receive_credit_from_pcrf("m1", 8000, MonitoringLevel::SESSION_LEVEL);
+  session_state->add_access_usage(3000, 5000);
+  EXPECT_EQ(session_state->get_monitor_pool().get_credit("m1", USED_TX), 2000);
+  EXPECT_EQ(session_state->get_monitor_pool().get_credit("m1", USED_RX), 3000);
+
+  // the access network's counters were reset
+  session_state->add_access_usage(500, 6000);
+  EXPECT_EQ(session_state->get_monitor_pool().get_credit("m1", USED_TX), 2500);
+  EXPECT_EQ(session_state->get_monitor_pool().get_credit("m1", USED_RX), 4000);
+}
+
+TEST_F(SessionStateTest, test_access_action)
+{
+  receive_credit_from_ocs(1, 1024);
+  LocalSessionUsageResponse response;
+  EXPECT_FALSE(session_state->get_charging_pool().get_access_action(&response));
+  EXPECT_FALSE(response.quota_exhausted());
+
+  // the quota & its extra margin are used up
+  session_state->get_charging_pool().add_used_credit(1, 2048, 1024);
+  EXPECT_TRUE(session_state->get_charging_pool().get_access_action(&response));
+  EXPECT_TRUE(response.quota_exhausted());
+  EXPECT_EQ(response.final_action(), ChargingCredit_FinalAction_TERMINATE);
+}
+
 TEST_F(SessionStateTest, test_reauth_key)
 {
   insert_rule(1, "", "rule1", true);
@@ -28,15 +28,27 @@ type TableID int32
 const (
 	TableID_IMSI_TO_HWID     TableID = 0
 	TableID_HWID_TO_HOSTNAME TableID = 1
+	// UE addresses & access point of the subscriber's Wi-Fi session, JSON encoded by the AAA server
+	TableID_IMSI_TO_UE TableID = 2
+	// IMSI of the Wi-Fi session's UE IP address (IPv4, IPv6 or IPv6 prefix)
+	TableID_IP_TO_IMSI TableID = 3
+	// IMSI of the Wi-Fi session's UE MAC address
+	TableID_MAC_TO_IMSI TableID = 4
 )
 
 var TableID_name = map[int32]string{
 	0: "IMSI_TO_HWID",
 	1: "HWID_TO_HOSTNAME",
+	2: "IMSI_TO_UE",
+	3: "IP_TO_IMSI",
+	4: "MAC_TO_IMSI",
 }
 var TableID_value = map[string]int32{
 	"IMSI_TO_HWID":     0,
 	"HWID_TO_HOSTNAME": 1,
+	"IMSI_TO_UE":       2,
+	"IP_TO_IMSI":       3,
+	"MAC_TO_IMSI":      4,
 }
 
 func (x TableID) String() string {
@@ -392,27 +404,28 @@ func init() {
 }
 
 var fileDescriptor_directoryd_f529f21e91423ef9 = []byte{
-	// 345 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x92, 0x4f, 0x4f, 0xc2, 0x30,
-	0x18, 0xc6, 0xd9, 0x8c, 0xa8, 0x2f, 0x66, 0x99, 0x0d, 0x26, 0x38, 0x82, 0x92, 0x9d, 0x08, 0x9a,
-	0x2d, 0xc2, 0xc5, 0xab, 0x66, 0x46, 0x97, 0x88, 0x98, 0x0d, 0x35, 0xf1, 0x42, 0x46, 0xdb, 0x90,
-	0x25, 0x1b, 0xc5, 0x52, 0x4c, 0xbc, 0xf9, 0x15, 0xfc, 0xc6, 0x86, 0x6e, 0x0e, 0x1a, 0x09, 0x5c,
-	0x3c, 0x6d, 0x7b, 0xf6, 0xf4, 0xd7, 0xf7, 0xcf, 0x03, 0x0d, 0xc6, 0xf1, 0x15, 0x77, 0xa7, 0x9c,
-	0x09, 0x36, 0x73, 0x49, 0xcc, 0x29, 0x16, 0x8c, 0x7f, 0x12, 0x47, 0x2a, 0xa8, 0x92, 0x46, 0xe3,
-	0x34, 0x72, 0xa4, 0xc9, 0x3a, 0x51, 0xbc, 0x98, 0xa5, 0x29, 0x9b, 0x64, 0x3e, 0xfb, 0x09, 0xd0,
-	0x1d, 0x15, 0x0f, 0x0c, 0x47, 0x22, 0x66, 0x93, 0x80, 0xbe, 0xcf, 0xe9, 0x4c, 0x20, 0x03, 0xf4,
-	0x98, 0xd4, 0xb4, 0xa6, 0xd6, 0x3a, 0x08, 0xf4, 0x98, 0xa0, 0x36, 0xec, 0x8a, 0x68, 0x94, 0xd0,
-	0x9a, 0xde, 0xd4, 0x5a, 0x46, 0xa7, 0xea, 0xac, 0xd0, 0x9d, 0xc1, 0xe2, 0x8f, 0xef, 0x05, 0x99,
-	0xc5, 0x0e, 0xe1, 0xd8, 0xa3, 0x09, 0x15, 0xf4, 0x3f, 0xa1, 0x17, 0x60, 0x2c, 0x71, 0x98, 0x71,
-	0x82, 0x2c, 0xd8, 0x4f, 0x72, 0x25, 0x67, 0x16, 0xdf, 0xf6, 0xb7, 0x06, 0xa7, 0xcf, 0x53, 0x12,
-	0x09, 0xea, 0xfd, 0xce, 0x65, 0x5b, 0x31, 0x5d, 0x28, 0x73, 0x09, 0x96, 0xd5, 0x54, 0x3a, 0x75,
-	0xa5, 0x1a, 0xf5, 0xee, 0x20, 0xb7, 0x2e, 0x3b, 0xd8, 0xd9, 0xda, 0x41, 0xfb, 0x12, 0xf6, 0x72,
-	0x05, 0x99, 0x70, 0xe8, 0xf7, 0x42, 0x7f, 0x38, 0xe8, 0x0f, 0xef, 0x5f, 0x7d, 0xcf, 0x2c, 0xa1,
-	0x2a, 0x98, 0x8b, 0x37, 0xa9, 0xf4, 0xc3, 0xc1, 0xe3, 0x75, 0xef, 0xd6, 0xd4, 0x3a, 0x5f, 0x3a,
-	0x98, 0x45, 0x03, 0x21, 0xe5, 0x1f, 0x31, 0xa6, 0xa8, 0x07, 0x95, 0x95, 0x85, 0xa1, 0x33, 0xe5,
-	0xce, 0xbf, 0xab, 0xb4, 0x36, 0x35, 0x62, 0x97, 0x50, 0x00, 0x46, 0x36, 0xa9, 0x82, 0x78, 0xae,
-	0x1c, 0xd8, 0x3c, 0x46, 0xeb, 0x48, 0x31, 0xbf, 0xb0, 0x78, 0xc1, 0xf4, 0xc1, 0x50, 0x13, 0x80,
-	0x6c, 0xc5, 0xb6, 0x36, 0x1e, 0x6b, 0x51, 0x37, 0x8d, 0xb7, 0xba, 0x54, 0xdd, 0x2c, 0xc1, 0x38,
-	0x61, 0x73, 0xe2, 0x8e, 0x59, 0x1e, 0xe5, 0x51, 0x59, 0x3e, 0xbb, 0x3f, 0x01, 0x00, 0x00, 0xff,
-	0xff, 0x94, 0x33, 0x66, 0x34, 0x0d, 0x03, 0x00, 0x00,
+	// 362 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xad, 0x52, 0x4d, 0x4f, 0xc2, 0x40,
+	0x14, 0xa4, 0xa0, 0xa8, 0xaf, 0xa6, 0xd6, 0x0d, 0x26, 0x58, 0x82, 0x9a, 0x9e, 0x0c, 0x9a, 0x36,
+	0x81, 0x8b, 0x57, 0xb4, 0x44, 0x9b, 0x58, 0x21, 0x2d, 0x68, 0xe2, 0x85, 0x94, 0x76, 0x43, 0x9a,
+	0xb4, 0x2c, 0x2e, 0x8b, 0x89, 0x37, 0xff, 0x82, 0xff, 0x58, 0xba, 0x2d, 0x85, 0x46, 0x02, 0x17,
+	0x4f, 0xfb, 0xde, 0xec, 0xec, 0xec, 0xbc, 0x0f, 0xa8, 0x13, 0xea, 0xdd, 0x51, 0x7d, 0x4a, 0x09,
+	0x23, 0x33, 0xdd, 0x0f, 0x28, 0xf6, 0x18, 0xa1, 0x5f, 0xbe, 0xc6, 0x11, 0x24, 0x46, 0xee, 0x38,
+	0x72, 0x35, 0x4e, 0x52, 0xce, 0x73, 0x5c, 0x8f, 0x44, 0x11, 0x99, 0x24, 0x3c, 0xb5, 0x07, 0xe8,
+	0x11, 0xb3, 0x67, 0xe2, 0xb9, 0x2c, 0x20, 0x13, 0x1b, 0x7f, 0xcc, 0xf1, 0x8c, 0x21, 0x09, 0x8a,
+	0x81, 0x5f, 0x15, 0xae, 0x84, 0xeb, 0x23, 0x7b, 0x11, 0xa1, 0x06, 0xec, 0x33, 0x77, 0x14, 0xe2,
+	0x6a, 0x71, 0x01, 0x49, 0xcd, 0x8a, 0xb6, 0xa6, 0xae, 0xf5, 0xe3, 0x1b, 0xd3, 0xb0, 0x13, 0x8a,
+	0xea, 0xc0, 0x99, 0x81, 0x43, 0xcc, 0xf0, 0x7f, 0x8a, 0xde, 0x82, 0xb4, 0x92, 0xf3, 0x08, 0xf5,
+	0x91, 0x02, 0x87, 0x61, 0x8a, 0xa4, 0x9a, 0x59, 0xae, 0xfe, 0x08, 0x70, 0x31, 0x98, 0xfa, 0x2e,
+	0xc3, 0xc6, 0xb2, 0x2f, 0xbb, 0xcc, 0xb4, 0xa0, 0x4c, 0xb9, 0x30, 0x77, 0x23, 0x36, 0x6b, 0x39,
+	0x37, 0xf9, 0xbf, 0xed, 0x94, 0xba, 0xaa, 0xa0, 0xb4, 0xb3, 0x82, 0xc6, 0x08, 0x0e, 0x52, 0x04,
+	0xc9, 0x70, 0x6c, 0x5a, 0x8e, 0x39, 0xec, 0x77, 0x87, 0x4f, 0x6f, 0xa6, 0x21, 0x17, 0x50, 0x05,
+	0xe4, 0x38, 0xe2, 0x48, 0xd7, 0xe9, 0xbf, 0xb4, 0xad, 0x8e, 0x2c, 0x2c, 0x3c, 0xc2, 0x92, 0x37,
+	0xe8, 0xc8, 0x45, 0x9e, 0xf7, 0xe2, 0x2c, 0x46, 0xe5, 0x12, 0x3a, 0x01, 0xd1, 0x6a, 0x3f, 0x64,
+	0xc0, 0x5e, 0xf3, 0xbb, 0x08, 0x72, 0x56, 0xb1, 0x83, 0xe9, 0x67, 0xe0, 0x61, 0x64, 0x81, 0xb8,
+	0x36, 0x61, 0x74, 0x99, 0x33, 0xf9, 0x77, 0xf6, 0xca, 0xb6, 0xca, 0xd5, 0x02, 0xb2, 0x41, 0x4a,
+	0x5a, 0x9b, 0x29, 0xde, 0xe4, 0x1e, 0x6c, 0xef, 0xbb, 0x72, 0x9a, 0x23, 0xbf, 0x92, 0x20, 0xd6,
+	0x34, 0x41, 0xca, 0xaf, 0x0c, 0x52, 0x73, 0xb4, 0x8d, 0xfb, 0xb4, 0x51, 0xea, 0xbe, 0xfe, 0x5e,
+	0xe3, 0xa8, 0x9e, 0xac, 0xbc, 0x17, 0x92, 0xb9, 0xaf, 0x8f, 0x49, 0xba, 0xfb, 0xa3, 0x32, 0x3f,
+	0x5b, 0xbf, 0x67, 0x96, 0x44, 0x8a, 0x3e, 0x03, 0x00, 0x00,
 }
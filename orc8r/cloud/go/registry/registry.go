@@ -27,6 +27,12 @@ type ServiceLocation struct {
 	Host         string
 	Port         int
 	ProxyAliases map[string]int
+	// UnixSocket is the unix domain socket path of the service, the service is
+	// served on the socket instead of the port if set
+	UnixSocket string
+	// AllowedUIDs are UIDs, besides root and the service's user, allowed to
+	// connect to the service's unix domain socket
+	AllowedUIDs []int
 }
 
 const (
@@ -56,6 +62,9 @@ func (sl ServiceLocation) String() string {
 		}
 		alsoKnown = " (also known as:" + aliases[:len(aliases)-1] + ")"
 	}
+	if len(sl.UnixSocket) > 0 {
+		return fmt.Sprintf("%s @ %s%s", sl.Name, UnixAddress(sl.UnixSocket), alsoKnown)
+	}
 	return fmt.Sprintf("%s @ %s:%d%s", sl.Name, sl.Host, sl.Port, alsoKnown)
 }
 
@@ -92,6 +101,9 @@ func GetServiceAddress(service string) (string, error) {
 	if !ok {
 		return "", fmt.Errorf("Service %s not registered", service)
 	}
+	if len(location.UnixSocket) > 0 {
+		return UnixAddress(location.UnixSocket), nil
+	}
 	if location.Port == 0 {
 		return "", fmt.Errorf("Service %s is not available", service)
 	}
@@ -0,0 +1,91 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package registry
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/golang/glog"
+)
+
+// UnixScheme is the address prefix of services served on unix domain sockets,
+// e.g. unix:///var/run/magma/aaa.sock
+const UnixScheme = "unix://"
+
+// UnixAddress returns the gRPC address of the unix domain socket
+func UnixAddress(socketPath string) string {
+	return UnixScheme + socketPath
+}
+
+// GetServiceUnixSocket returns the unix domain socket path of the service and
+// the UIDs allowed to connect to it. The path is empty if the service is served
+// over TCP.
+func GetServiceUnixSocket(service string) (string, []int, error) {
+	registry.RLock()
+	defer registry.RUnlock()
+	location, ok := registry.serviceLocations[strings.ToUpper(service)]
+	if !ok {
+		return "", nil, fmt.Errorf("Service %s not registered", service)
+	}
+	return location.UnixSocket, location.AllowedUIDs, nil
+}
+
+// ListenUnix listens on the unix domain socket, replacing a stale socket file.
+// Connections are authenticated by their peers' SO_PEERCRED credentials,
+// connections of peers other than root, the listening process' user and the
+// allowed UIDs are closed.
+func ListenUnix(socketPath string, allowedUIDs []int) (net.Listener, error) {
+	if fi, err := os.Stat(socketPath); err == nil && fi.Mode()&os.ModeSocket != 0 {
+		os.Remove(socketPath)
+	}
+	lis, err := net.ListenUnix("unix", &net.UnixAddr{Name: socketPath, Net: "unix"})
+	if err != nil {
+		return nil, err
+	}
+	// connecting requires write permission, peers are authenticated by their
+	// credentials instead
+	if err = os.Chmod(socketPath, 0666); err != nil {
+		lis.Close()
+		return nil, err
+	}
+	allowed := map[uint32]bool{0: true, uint32(os.Getuid()): true}
+	for _, uid := range allowedUIDs {
+		allowed[uint32(uid)] = true
+	}
+	return &peerCredListener{UnixListener: lis, allowed: allowed}, nil
+}
+
+// peerCredListener accepts connections of allowed peers only
+type peerCredListener struct {
+	*net.UnixListener
+	allowed map[uint32]bool
+}
+
+// Accept implements net.Listener, connections of not allowed peers are closed
+func (l *peerCredListener) Accept() (net.Conn, error) {
+	for {
+		conn, err := l.AcceptUnix()
+		if err != nil {
+			return nil, err
+		}
+		uid, err := peerUID(conn)
+		if err == nil && l.allowed[uid] {
+			return conn, nil
+		}
+		if err != nil {
+			glog.Errorf("Unix socket %s peer credentials error: %v", l.Addr(), err)
+		} else {
+			glog.Warningf("Unix socket %s connection of UID %d is rejected", l.Addr(), uid)
+		}
+		conn.Close()
+	}
+}
@@ -0,0 +1,78 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package registry_test
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"magma/orc8r/cloud/go/registry"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnixServiceLocation(t *testing.T) {
+	registry.AddService(registry.ServiceLocation{
+		Name:        "UNIX_TEST",
+		UnixSocket:  "/var/run/test.sock",
+		AllowedUIDs: []int{1000},
+	})
+	addr, err := registry.GetServiceAddress("UNIX_TEST")
+	assert.NoError(t, err)
+	assert.Equal(t, "unix:///var/run/test.sock", addr)
+
+	path, uids, err := registry.GetServiceUnixSocket("unix_test")
+	assert.NoError(t, err)
+	assert.Equal(t, "/var/run/test.sock", path)
+	assert.Equal(t, []int{1000}, uids)
+
+	registry.AddService(registry.ServiceLocation{Name: "TCP_TEST", Host: "localhost", Port: 9999})
+	path, _, err = registry.GetServiceUnixSocket("TCP_TEST")
+	assert.NoError(t, err)
+	assert.Empty(t, path)
+}
+
+func TestListenUnix(t *testing.T) {
+	dir, err := ioutil.TempDir("", "registry_test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "service.sock")
+
+	// a stale socket file of a previous run is replaced
+	stale, err := net.Listen("unix", path)
+	assert.NoError(t, err)
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	stale.Close()
+
+	lis, err := registry.ListenUnix(path, nil)
+	assert.NoError(t, err)
+	defer lis.Close()
+
+	accepted := make(chan error, 1)
+	go func() {
+		conn, err := lis.Accept()
+		if err == nil {
+			_, err = conn.Write([]byte("ok"))
+			conn.Close()
+		}
+		accepted <- err
+	}()
+	// the test's own UID is allowed
+	conn, err := net.Dial("unix", path)
+	assert.NoError(t, err)
+	defer conn.Close()
+	assert.NoError(t, <-accepted)
+	buf := make([]byte, 2)
+	_, err = conn.Read(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", string(buf))
+}
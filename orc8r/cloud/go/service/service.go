@@ -16,7 +16,6 @@ import (
 	"fmt"
 	"net"
 	"os"
-	"strings"
 	"time"
 
 	"magma/orc8r/cloud/go/plugin"
@@ -24,6 +23,7 @@ import (
 	"magma/orc8r/cloud/go/registry"
 	"magma/orc8r/cloud/go/service/config"
 	"magma/orc8r/cloud/go/service/middleware/unary"
+	"magma/orc8r/cloud/go/util"
 
 	"github.com/golang/glog"
 	"google.golang.org/grpc"
@@ -118,7 +118,7 @@ func NewServiceWithOptions(moduleName string, serviceName string, serverOptions
 	}
 
 	// Check if service was started with print-grpc-payload flag or MAGMA_PRINT_GRPC_PAYLOAD env is set
-	if ev := strings.ToLower(os.Getenv(PrintGrpcPayloadEnv)); printGrpcPayload || isTruthy(ev) {
+	if printGrpcPayload || util.IsTruthy(os.Getenv(PrintGrpcPayloadEnv)) {
 		ls := logCodec{encoding.GetCodec(grpc_proto.Name)}
 		if ls.protoCodec != nil {
 			glog.Errorf("Adding Debug Codec for service %s", serviceName)
@@ -152,6 +152,17 @@ func NewServiceWithOptions(moduleName string, serviceName string, serverOptions
 // Run the service. This function blocks until its interrupted
 // by a signal or until the gRPC server is stopped.
 func (service *Service) Run() error {
+	socketPath, allowedUIDs, err := registry.GetServiceUnixSocket(service.Type)
+	if err == nil && len(socketPath) > 0 {
+		lis, err := registry.ListenUnix(socketPath, allowedUIDs)
+		if err != nil {
+			return fmt.Errorf("Failed to listen on unix socket %s: %s", socketPath, err)
+		}
+		service.State = protos.ServiceInfo_ALIVE
+		service.Health = protos.ServiceInfo_APP_HEALTHY
+		return service.GrpcServer.Serve(lis)
+	}
+
 	port, err := registry.GetServicePort(service.Type)
 	if err != nil {
 		return fmt.Errorf("Failed to get service port: %s", err)
@@ -179,13 +190,3 @@ func (service *Service) RunTest(lis net.Listener) error {
 func GetDefaultKeepaliveParameters() keepalive.ServerParameters {
 	return defaultKeepaliveParams
 }
-
-func isTruthy(value string) bool {
-	if len(value) == 0 {
-		return false
-	}
-	if value == "0" || value == "false" || value == "no" {
-		return false
-	}
-	return true
-}
@@ -57,6 +57,27 @@ func getProxyAliases(rawMap map[interface{}]interface{}) map[string]int {
 	return proxyAliases
 }
 
+// getAllowedUIDs returns the UIDs allowed to connect to the service's unix domain socket
+func getAllowedUIDs(rawMap map[interface{}]interface{}) ([]int, error) {
+	val, ok := rawMap["allowed_uids"]
+	if !ok {
+		return nil, nil
+	}
+	list, ok := val.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("not a list: %v", val)
+	}
+	uids := make([]int, 0, len(list))
+	for _, v := range list {
+		uid, ok := v.(int)
+		if !ok || uid < 0 {
+			return nil, fmt.Errorf("invalid UID: %v", v)
+		}
+		uids = append(uids, uid)
+	}
+	return uids, nil
+}
+
 func getRawMap(serviceRegistry *config.ConfigMap) (map[interface{}]interface{}, error) {
 	services, ok := serviceRegistry.RawMap["services"]
 	if !ok {
@@ -69,8 +90,8 @@ func getRawMap(serviceRegistry *config.ConfigMap) (map[interface{}]interface{},
 	return rawMap, nil
 }
 
-func convertToServiceLocations(rawMap rawMapType, len int) ([]registry.ServiceLocation, error) {
-	serviceLocations := make([]registry.ServiceLocation, len)
+func convertToServiceLocations(rawMap rawMapType, numServices int) ([]registry.ServiceLocation, error) {
+	serviceLocations := make([]registry.ServiceLocation, numServices)
 	for k, v := range rawMap {
 		name, ok := k.(string)
 		if !ok {
@@ -89,12 +110,25 @@ func convertToServiceLocations(rawMap rawMapType, len int) ([]registry.ServiceLo
 				return nil, err
 			}
 		}
+		// services served on unix domain sockets need no port
+		unixSocket, _ := configMap.GetStringParam("unix_socket")
 		port, err := configMap.GetIntParam("port")
-		if err != nil {
+		if err != nil && len(unixSocket) == 0 {
 			return nil, err
 		}
+		allowedUIDs, err := getAllowedUIDs(rawMap)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid allowed_uids of service %s: %v", name, err)
+		}
 		proxyAliases := getProxyAliases(rawMap)
-		serviceLocations = append(serviceLocations, registry.ServiceLocation{Name: strings.ToUpper(name), Host: host, Port: port, ProxyAliases: proxyAliases})
+		serviceLocations = append(serviceLocations, registry.ServiceLocation{
+			Name:         strings.ToUpper(name),
+			Host:         host,
+			Port:         port,
+			ProxyAliases: proxyAliases,
+			UnixSocket:   unixSocket,
+			AllowedUIDs:  allowedUIDs,
+		})
 	}
 	return serviceLocations, nil
 }
@@ -40,6 +40,21 @@ func GetHostNameByIMSI(hwId string) (string, error) {
 	return getLocation(protos.TableID_HWID_TO_HOSTNAME, hwId)
 }
 
+// GetUEByIMSI returns the JSON encoded UE addresses & access point of the subscriber's Wi-Fi session
+func GetUEByIMSI(imsi string) (string, error) {
+	return getLocation(protos.TableID_IMSI_TO_UE, imsi)
+}
+
+// GetIMSIByIP returns the IMSI of the Wi-Fi session's UE IP address (IPv4, IPv6 or IPv6 prefix)
+func GetIMSIByIP(ip string) (string, error) {
+	return getLocation(protos.TableID_IP_TO_IMSI, ip)
+}
+
+// GetIMSIByMAC returns the IMSI of the Wi-Fi session's UE MAC address
+func GetIMSIByMAC(mac string) (string, error) {
+	return getLocation(protos.TableID_MAC_TO_IMSI, mac)
+}
+
 func getLocation(tableId protos.TableID, recordId string) (string, error) {
 	client, err := GetDirectorydClient()
 	if err != nil {
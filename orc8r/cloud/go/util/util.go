@@ -124,3 +124,13 @@ func FormatPkixSubject(s *pkix.Name) string {
 	})
 	return string(res)
 }
+
+// IsTruthy returns false for empty, "0", "off", "false..." & "n..." (no) values, case insensitive, and true for any
+// other value
+func IsTruthy(value string) bool {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if len(value) == 0 || value == "0" || value == "off" {
+		return false
+	}
+	return !strings.HasPrefix(value, "false") && !strings.HasPrefix(value, "n")
+}
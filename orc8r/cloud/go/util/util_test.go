@@ -0,0 +1,48 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package util_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"magma/orc8r/cloud/go/util"
+)
+
+func TestIsTruthy(t *testing.T) {
+	for _, tc := range []struct {
+		value    string
+		expected bool
+	}{
+		{"", false},
+		{"  ", false},
+		{"0", false},
+		{"false", false},
+		{"FALSE", false},
+		{"False ", false},
+		{"falsey", false},
+		{"n", false},
+		{"no", false},
+		{"No", false},
+		{"none", false},
+		{"off", false},
+		{"OFF", false},
+		{"1", true},
+		{"true", true},
+		{"TRUE", true},
+		{" yes", true},
+		{"y", true},
+		{"on", true},
+		{"enabled", true},
+		{"2", true},
+	} {
+		assert.Equal(t, tc.expected, util.IsTruthy(tc.value), "value: '%s'", tc.value)
+	}
+}